@@ -274,7 +274,13 @@ class SourceManagementAdmin {
 
             const response = await this.makeApiCall('/crawl/submit', 'POST', requestData);
 
-            if (response.success) {
+            if (response.success && response.data.status === 'queued') {
+                this.showAlert(
+                    `Re-extraction is taking a while and continues in the background (job ${response.data.job_id}). ` +
+                    `Check the pending events tab shortly.`,
+                    'info'
+                );
+            } else if (response.success) {
                 this.showAlert(
                     `Successfully re-extracted ${response.data.events_count} events! ` +
                     `Check the pending events tab for review.`,
@@ -295,6 +301,33 @@ class SourceManagementAdmin {
 
 
 
+    // Admin routes need an admin API key, kept in local storage once entered
+    getApiKey() {
+        let apiKey = localStorage.getItem('adminApiKey');
+        if (!apiKey) {
+            apiKey = (window.prompt('Enter your admin API key') || '').trim();
+            if (apiKey) {
+                localStorage.setItem('adminApiKey', apiKey);
+            }
+        }
+        return apiKey;
+    }
+
+    // fetch with the admin API key; a refused key is forgotten so the next call asks again
+    async apiFetch(url, options = {}) {
+        const apiKey = this.getApiKey();
+        const headers = { ...(options.headers || {}) };
+        if (apiKey) {
+            headers['X-Api-Key'] = apiKey;
+        }
+
+        const response = await fetch(url, { ...options, headers });
+        if (response.status === 401 || response.status === 403) {
+            localStorage.removeItem('adminApiKey');
+        }
+        return response;
+    }
+
     async makeApiCall(endpoint, method = 'GET', body = null) {
         const url = `${this.apiBaseUrl}${endpoint}`;
         const isLocal = window.location.hostname === 'localhost' ||
@@ -315,7 +348,7 @@ class SourceManagementAdmin {
         }
 
         try {
-            const response = await fetch(url, options);
+            const response = await this.apiFetch(url, options);
             const data = await response.json();
 
             if (!response.ok) {
@@ -655,8 +688,8 @@ class SourceManagementAdmin {
                             <div style="font-size: 2rem; font-weight: bold; color: #10b981;">${analytics.success_rate || '0%'}</div>
                         </div>
                         <div class="source-card">
-                            <h4>Avg Analysis Time</h4>
-                            <div style="font-size: 2rem; font-weight: bold; color: var(--primary-color);">${analytics.avg_analysis_time || 'N/A'}</div>
+                            <h4>Approval Rate</h4>
+                            <div style="font-size: 2rem; font-weight: bold; color: var(--primary-color);">${analytics.approvals && (analytics.approvals.approved + analytics.approvals.rejected) > 0 ? Math.round(analytics.approvals.approval_rate * 100) + '%' : 'N/A'}</div>
                         </div>
                     </div>
                     
@@ -707,7 +740,7 @@ class SourceManagementAdmin {
 
     async loadSchemas() {
         try {
-            const response = await fetch(`${this.apiBaseUrl}/schemas`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/schemas`, {
                 method: 'GET',
                 headers: {
                     'Content-Type': 'application/json',
@@ -818,7 +851,7 @@ class SourceManagementAdmin {
         submitBtn.textContent = 'Extracting...';
 
         try {
-            const response = await fetch(`${this.apiBaseUrl}/crawl/submit`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/crawl/submit`, {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',
@@ -828,7 +861,15 @@ class SourceManagementAdmin {
 
             const result = await response.json();
 
-            if (response.ok && result.success) {
+            if (response.status === 202 && result.success) {
+                this.showAlert(
+                    `Extraction is taking a while and continues in the background (job ${result.data.job_id}). ` +
+                    `The events will appear in pending events when it finishes.`,
+                    'info'
+                );
+                form.reset();
+                this.handleSchemaChange(''); // Reset schema preview
+            } else if (response.ok && result.success) {
                 this.showAlert(
                     `Successfully extracted ${result.data.events_count} events! ` +
                     `Processing time: ${result.data.processing_time}. ` +
@@ -853,7 +894,7 @@ class SourceManagementAdmin {
 
     async loadPendingEvents() {
         try {
-            const response = await fetch(`${this.apiBaseUrl}/events/pending?limit=25`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/events/pending?limit=25`, {
                 method: 'GET',
                 headers: {
                     'Content-Type': 'application/json',
@@ -963,7 +1004,7 @@ class SourceManagementAdmin {
 
     async viewEventDetails(eventId) {
         try {
-            const response = await fetch(`${this.apiBaseUrl}/events/${eventId}`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/events/${eventId}`, {
                 method: 'GET',
                 headers: {
                     'Content-Type': 'application/json',
@@ -1063,7 +1104,7 @@ class SourceManagementAdmin {
         }
 
         try {
-            const response = await fetch(`${this.apiBaseUrl}/events/${eventId}/approve`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/events/${eventId}/approve`, {
                 method: 'PUT',
                 headers: {
                     'Content-Type': 'application/json',
@@ -1093,7 +1134,7 @@ class SourceManagementAdmin {
         if (!reason) return;
 
         try {
-            const response = await fetch(`${this.apiBaseUrl}/events/${eventId}/reject`, {
+            const response = await this.apiFetch(`${this.apiBaseUrl}/events/${eventId}/reject`, {
                 method: 'PUT',
                 headers: {
                     'Content-Type': 'application/json',
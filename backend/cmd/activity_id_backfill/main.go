@@ -0,0 +1,101 @@
+// Command activity_id_backfill moves activities published under legacy IDs (random UUIDs,
+// timestamped fallback IDs and short hashes) to the deterministic IDs of the activityid package.
+// Each moved activity leaves a redirect under its old ID, so clients holding the old ID are
+// sent to the new one by GET /api/activities/{id}.
+//
+// Usage:
+//
+//	FAMILY_ACTIVITIES_TABLE=seattle-family-activities \
+//	ADMIN_EVENTS_TABLE=seattle-admin-events go run ./cmd/activity_id_backfill -apply
+//
+// Without -apply the planned moves are only printed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "move the activities; without it the plan is only printed")
+	flag.Parse()
+
+	familyActivitiesTable := os.Getenv("FAMILY_ACTIVITIES_TABLE")
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	if familyActivitiesTable == "" || adminEventsTable == "" {
+		log.Fatal("❌ Required environment variables not set: FAMILY_ACTIVITIES_TABLE, ADMIN_EVENTS_TABLE")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		familyActivitiesTable,
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		adminEventsTable,
+	)
+
+	// Convert the way the admin API does, since the title it publishes is part of the ID
+	conversionService := services.NewSchemaConversionService()
+	conversionService.SetValidationRules(services.NewValidationRuleCache(dynamoService, 5*time.Minute))
+	conversionService.SetTags(services.NewTagCache(dynamoService, 5*time.Minute))
+	if policy := os.Getenv("TITLE_EMOJI_POLICY"); policy != "" {
+		conversionService.TitleNormalizer().SetEmojiPolicy(policy)
+	}
+	venues, err := dynamoService.GetVenues(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load the venue registry: %v", err)
+	}
+	venueRegistry := services.NewVenueRegistry(venues)
+	conversionService.TitleNormalizer().SetVenueNames(venueRegistry.Names())
+
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to get approved events: %v", err)
+	}
+
+	plan := services.PlanActivityIDBackfill(approvedEvents, conversionService)
+	output, _ := json.MarshalIndent(plan, "", "  ")
+	log.Printf("Activity ID backfill plan for %d approved events:\n%s", len(approvedEvents), output)
+
+	if !*apply {
+		log.Printf("Dry run: %d activities would move, %d keep their legacy IDs. Run with -apply to move them.", len(plan.Migrations), len(plan.Skipped))
+		return
+	}
+
+	moved, failed := 0, 0
+	for _, migration := range plan.Migrations {
+		err := services.ApplyActivityIDMigration(ctx, dynamoService, venueRegistry, migration, time.Now())
+		switch {
+		case errors.Is(err, services.ErrAdminEventChanged):
+			log.Printf("Warning: Event %s was reviewed during the backfill, leaving activity %s in place", migration.EventID, migration.FromID)
+			failed++
+		case err != nil:
+			log.Printf("Error moving activity %s to %s for event %s: %v", migration.FromID, migration.ToID, migration.EventID, err)
+			failed++
+		default:
+			log.Printf("Moved activity %s to %s for event %s", migration.FromID, migration.ToID, migration.EventID)
+			moved++
+		}
+	}
+
+	log.Printf("Activity ID backfill complete: %d moved, %d failed, %d skipped", moved, failed, len(plan.Skipped))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -3,12 +3,15 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
-	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -20,8 +23,13 @@ import (
 	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/google/uuid"
 
+	"seattle-family-activities-scraper/internal/adminauth"
+	"seattle-family-activities-scraper/internal/apiheaders"
+	"seattle-family-activities-scraper/internal/export"
 	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/queryparams"
 	"seattle-family-activities-scraper/internal/services"
+	"seattle-family-activities-scraper/internal/urlutil"
 )
 
 // AdminAPIResponse represents the Lambda response
@@ -37,6 +45,7 @@ type ResponseBody struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Meta    map[string]interface{} `json:"meta,omitempty"` // next_token of paged lists
 }
 
 // SourceSubmissionRequest represents the request for submitting a new source
@@ -50,21 +59,83 @@ type SourceSubmissionRequest struct {
 	SubmittedBy     string   `json:"submitted_by"`
 }
 
+// SourceRejectionRequest represents the optional request body for rejecting a source
+type SourceRejectionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SourcePauseRequest represents the optional body for pausing a source
+type SourcePauseRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ProviderCancelRequest represents the optional body for cancelling a provider event
+type ProviderCancelRequest struct {
+	Reason string `json:"reason"`
+}
+
 // SourceActivationRequest represents the request for activating a source
 type SourceActivationRequest struct {
 	AdminNotes     string                 `json:"admin_notes"`
 	OverrideConfig map[string]interface{} `json:"override_config,omitempty"`
+	RunLimits      *models.SourceRunLimits `json:"run_limits,omitempty"` // defaults to models.DefaultSourceRunLimits
+	AutoHealSelectors bool                `json:"auto_heal_selectors"`   // hot-swap re-analyzed selectors after a canary scrape
+	ActivatedBy       string              `json:"activated_by,omitempty"`
 }
 
 var (
 	dynamoService         *services.DynamoDBService
 	firecrawlService      *services.FireCrawlClient
 	conversionService     *services.SchemaConversionService
+	firecrawlStats        *services.FireCrawlStatsCollector
+	metricsNamespace      string
+	dynamoDBMetricsNamespace string
+	progressReporter      *services.ProgressReporter
+	stripDeadRegistrationLinks bool
+	publicSiteURL         string // the public site, linked from the RSS and Atom feeds
+	preflightChecker      *services.PreflightChecker
+	reportStore           *services.S3Store
+	catalogSnapshots      *services.CatalogSnapshotReader
+	activityPublisher     *services.ActivityPublisher // republishes the S3 activity feed after visibility changes
+	feedInvalidator       *services.CloudFrontInvalidator
+	previewTokens         *services.PreviewTokenSigner
+	validationRules       *services.ValidationRuleCache
+	domainPolicy          *services.DomainPolicyCache
+	suggestIndex          *services.SuggestIndex
+	venueRegistry         *services.VenueRegistry
+	neighborhoods         *services.NeighborhoodCache
+	activityTags          *services.TagCache
+	geocoder              *services.GeocodingService
+	concurrencySettings   *services.ConcurrencySettingsCache
+	concurrencyLimiter    *services.LeaseConcurrencyLimiter
+	sqsClient             *services.SQSClient
+	taskQueueURLs         map[string]string
+	cloudWatchClient      *services.CloudWatchClient
+	monitoredFunctionNames []string
+	reviewLatencySLO      = services.DefaultReviewLatencySLO
 	lambdaClient          *lambdaclient.Client
 	sourceAnalyzerFunctionName string
+	adminAPIFunctionName  string // this function, which finishes deferred crawl submissions
+	manualTriggerHourlyLimit = models.DefaultManualTriggerHourlyLimit
+	claimMailer           *services.SESMailer
+	publicQueryCache      *services.PublicQueryCache
+	twoPersonRule         bool // high-impact actions need a second admin's confirmation
+	productionTenant      *dataTenant
+	sandboxTenant         *dataTenant // nil unless the sandbox tables are configured
+	apiHeaders            *apiheaders.Config // CORS origins and security headers of every response
+)
+
+const (
+	// Public query results are cached this long, matching the cache_duration suggested to clients
+	publicQueryCacheTTL = 5 * time.Minute
+	// How often the catalog generation is re-read, i.e. how long a published change can take to show
+	publicCacheGenerationRefresh = 10 * time.Second
+	defaultPublicCacheEntries    = 256
 )
 
-func init() {
+// setup creates the services from the environment. It runs from main rather than init, so tests
+// can build the package and set up the services themselves.
+func setup() {
 	// Load AWS configuration
 	cfg, err := config.LoadDefaultConfig(context.TODO())
 	if err != nil {
@@ -94,14 +165,111 @@ func init() {
 	)
 
 	// Initialize Firecrawl service
+	firecrawlStats = services.NewFireCrawlStatsCollector()
+	metricsNamespace = os.Getenv("FIRECRAWL_METRICS_NAMESPACE")
+	dynamoDBMetricsNamespace = os.Getenv("DYNAMODB_METRICS_NAMESPACE")
 	firecrawlService, err = services.NewFireCrawlClient()
 	if err != nil {
 		log.Printf("Warning: Failed to initialize Firecrawl service: %v", err)
 		// Don't fail startup, just log the warning
+	} else {
+		firecrawlService.SetStatsCollector(firecrawlStats)
 	}
 
-	// Initialize schema conversion service
-	conversionService = services.NewSchemaConversionService()
+	// FireCrawl calls share per-provider concurrency caps with the scrape executors
+	concurrencySettings = services.NewConcurrencySettingsCache(dynamoService, time.Minute)
+	concurrencyLimiter = services.NewLeaseConcurrencyLimiter(dynamoService, concurrencySettings, time.Minute)
+	if firecrawlService != nil {
+		firecrawlService.SetConcurrencyLimiter(concurrencyLimiter)
+	}
+
+	// Everything that reads or writes the tables belongs to the production tenant, which sandbox
+	// requests swap out for the sandbox tenant
+	productionTenant = newDataTenant(cfg, dynamoService)
+	if bucket := os.Getenv("ANALYSIS_REPORTS_BUCKET"); bucket != "" {
+		productionTenant.reportStore = services.NewS3Store(cfg, bucket)
+	}
+	productionTenant.activate()
+	if firecrawlService != nil {
+		firecrawlService.SetValidationRules(validationRules)
+	}
+
+	// Admin API keys flagged as sandbox keys use a copy of the tables, when one is configured
+	sandboxTenant = newSandboxTenant(cfg, dynamoClient)
+
+	// Initialize source URL pre-flight checks
+	preflightChecker = services.NewPreflightChecker()
+
+	// Browsers may call the API from CORS_ALLOWED_ORIGINS, or from anywhere when it is unset
+	apiHeaders = apiheaders.FromEnv()
+
+	// The visibility refresh republishes the S3 activity feed when activities surface or are
+	// withdrawn (disabled without a bucket)
+	if bucket := os.Getenv("PUBLISH_BUCKET"); bucket != "" {
+		activityPublisher = services.NewActivityPublisher(productionTenant.dynamo, productionTenant.conversion, services.NewS3Store(cfg, bucket))
+	}
+	if distributionID := os.Getenv("CDN_DISTRIBUTION_ID"); distributionID != "" {
+		feedInvalidator = services.NewCloudFrontInvalidator(cfg, distributionID)
+	}
+
+	// Initialize catalog snapshots, served with ?snapshot= (disabled without a bucket)
+	if bucket := os.Getenv("CATALOG_SNAPSHOTS_BUCKET"); bucket != "" {
+		catalogSnapshots = services.NewCatalogSnapshotReader(services.NewS3Store(cfg, bucket))
+	}
+
+	// Initialize preview tokens, served with ?preview_token= (disabled without a secret)
+	if secret := os.Getenv("PREVIEW_TOKEN_SECRET"); secret != "" {
+		previewTokens = services.NewPreviewTokenSigner(secret)
+	}
+
+	// Initialize scrape task queue monitoring, keyed by queue priority
+	sqsClient = services.NewSQSClient(cfg)
+	taskQueueURLs = map[string]string{}
+	if queueURL := os.Getenv("SCRAPE_TASK_QUEUE_URL"); queueURL != "" {
+		taskQueueURLs[models.QueuePriorityNormal] = queueURL
+	}
+	if queueURL := os.Getenv("SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL"); queueURL != "" {
+		taskQueueURLs[models.QueuePriorityHigh] = queueURL
+	}
+
+	// Lambda error rates on the system load overview are read from CloudWatch
+	cloudWatchClient = services.NewCloudWatchClient(cfg)
+	for _, name := range strings.Split(os.Getenv("MONITORED_FUNCTION_NAMES"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			monitoredFunctionNames = append(monitoredFunctionNames, name)
+		}
+	}
+
+	// The review queue on the system load overview is measured against the review latency SLO
+	if hours, err := strconv.Atoi(os.Getenv("REVIEW_LATENCY_SLO_HOURS")); err == nil && hours > 0 {
+		reviewLatencySLO = time.Duration(hours) * time.Hour
+	}
+
+	// Venue claims email verification codes and provider tokens; claims are disabled without a sender
+	if from := os.Getenv("CLAIM_EMAIL_FROM"); from != "" {
+		claimMailer = services.NewSESMailer(cfg, from)
+	}
+
+	// Public catalog queries are cached in the Lambda instance, and in ElastiCache when an endpoint is configured
+	cacheEntries := defaultPublicCacheEntries
+	if entries, err := strconv.Atoi(os.Getenv("PUBLIC_CACHE_ENTRIES")); err == nil && entries > 0 {
+		cacheEntries = entries
+	}
+	var queryCache services.CacheService = services.NewLRUCache(cacheEntries)
+	if addr := os.Getenv("PUBLIC_CACHE_REDIS_ADDR"); addr != "" {
+		redisCache := services.NewRedisCache(addr, os.Getenv("PUBLIC_CACHE_REDIS_TLS") == "true", 200*time.Millisecond)
+		queryCache = services.NewTieredCache(publicCacheGenerationRefresh, queryCache, redisCache)
+	}
+	publicQueryCache = services.NewPublicQueryCache(queryCache, dynamoService, publicQueryCacheTTL, publicCacheGenerationRefresh)
+
+	// Optionally hide links the link checker has marked broken from the public events API
+	stripDeadRegistrationLinks = os.Getenv("STRIP_DEAD_REGISTRATION_LINKS") == "true"
+
+	// The public site the RSS and Atom feeds link to, optional
+	publicSiteURL = os.Getenv("PUBLIC_SITE_URL")
+
+	// Optionally hold high-impact actions until a second admin confirms them
+	twoPersonRule = os.Getenv("REQUIRE_TWO_PERSON_APPROVAL") == "true"
 
 	// Initialize Lambda client for triggering source analyzer
 	lambdaClient = lambdaclient.NewFromConfig(cfg)
@@ -109,31 +277,143 @@ func init() {
 	if sourceAnalyzerFunctionName == "" {
 		log.Fatal("SOURCE_ANALYZER_FUNCTION_NAME environment variable not set")
 	}
+	// Set by the Lambda runtime
+	adminAPIFunctionName = os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+
+	if limit, err := strconv.Atoi(os.Getenv("MANUAL_TRIGGER_HOURLY_LIMIT")); err == nil && limit > 0 {
+		manualTriggerHourlyLimit = limit
+	}
 }
 
-func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
-	// Set CORS headers
-	headers := map[string]string{
-		"Access-Control-Allow-Origin":  "*",
-		"Access-Control-Allow-Headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token",
-		"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
-		"Content-Type":                 "application/json",
+// dataTenant holds the services bound to one set of tables. Handlers use the package globals, so
+// a tenant is put in use by assigning them; Lambda serves one request per instance at a time, so
+// a sandbox request can swap them for its duration.
+
+type dataTenant struct {
+	sandbox         bool
+	dynamo          *services.DynamoDBService
+	conversion      *services.SchemaConversionService
+	progress        *services.ProgressReporter
+	validationRules *services.ValidationRuleCache
+	domainPolicy    *services.DomainPolicyCache
+	suggestIndex    *services.SuggestIndex
+	venueRegistry   *services.VenueRegistry
+	neighborhoods   *services.NeighborhoodCache
+	activityTags    *services.TagCache
+	geocoder        *services.GeocodingService
+	reportStore     *services.S3Store // analysis report export is disabled without a bucket
+}
+
+// newDataTenant initializes the services that read and write a tenant's tables
+func newDataTenant(cfg aws.Config, dynamo *services.DynamoDBService) *dataTenant {
+	t := &dataTenant{dynamo: dynamo}
+
+	// Validation rules are editable per schema type; edits reach other instances when their cache expires
+	t.validationRules = services.NewValidationRuleCache(dynamo, 5*time.Minute)
+
+	// Domain allow/deny lists are enforced on source and crawl submissions
+	t.domainPolicy = services.NewDomainPolicyCache(dynamo, 5*time.Minute)
+
+	// Search box completions are indexed on approval and cached briefly per instance
+	t.suggestIndex = services.NewSuggestIndex(dynamo, time.Minute)
+
+	// Initialize schema conversion service
+	t.conversion = services.NewSchemaConversionService()
+	t.conversion.SetValidationRules(t.validationRules)
+
+	// Venue names are stripped from the end of converted titles
+	if policy := os.Getenv("TITLE_EMOJI_POLICY"); policy != "" {
+		t.conversion.TitleNormalizer().SetEmojiPolicy(policy)
+	}
+	// and approved activities are linked to the registry venue they are held at
+	venues, err := dynamo.GetVenues(context.Background())
+	if err != nil {
+		log.Printf("Warning: Failed to load the venue registry: %v", err)
 	}
+	t.venueRegistry = services.NewVenueRegistry(venues)
+	t.conversion.TitleNormalizer().SetVenueNames(t.venueRegistry.Names())
 
-	// Handle preflight OPTIONS request
-	if request.HTTPMethod == "OPTIONS" {
-		return AdminAPIResponse{
-			StatusCode: 200,
-			Headers:    headers,
-			Body:       "",
-		}, nil
+	// Converted locations are clustered into the neighborhoods of the admin-maintained lookup
+	t.neighborhoods = services.NewNeighborhoodCache(dynamo, t.venueRegistry, 5*time.Minute)
+	t.conversion.SetNeighborhoods(t.neighborhoods)
+
+	// Converted activities are tagged with the built-in and admin-managed tags their text suggests
+	t.activityTags = services.NewTagCache(dynamo, 5*time.Minute)
+	t.conversion.SetTags(t.activityTags)
+
+	// Locations without coordinates are geocoded during conversion and publishing (disabled without a provider)
+	t.geocoder, err = services.NewGeocodingServiceFromEnv(cfg, dynamo)
+	if err != nil {
+		log.Printf("Warning: Geocoding disabled: %v", err)
+	} else if t.geocoder != nil {
+		t.conversion.SetGeocoder(t.geocoder)
+	}
+
+	// Initialize job progress reporting; live push requires the WebSocket management endpoint
+	var progressPoster services.ConnectionPoster
+	if endpoint := os.Getenv("PROGRESS_WEBSOCKET_ENDPOINT"); endpoint != "" {
+		progressPoster = services.NewWebSocketPoster(cfg, endpoint)
+	}
+	t.progress = services.NewProgressReporter(dynamo, progressPoster)
+	return t
+}
+
+// newSandboxTenant initializes the tenant of sandbox API keys, or returns nil unless all four
+// SANDBOX_*_TABLE variables name sandbox tables
+func newSandboxTenant(cfg aws.Config, dynamoClient *dynamodb.Client) *dataTenant {
+	tables := []string{
+		os.Getenv("SANDBOX_FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SANDBOX_SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SANDBOX_SCRAPING_OPERATIONS_TABLE"),
+		os.Getenv("SANDBOX_ADMIN_EVENTS_TABLE"),
+	}
+	for _, table := range tables {
+		if table == "" {
+			return nil
+		}
+		if !strings.HasSuffix(table, services.SandboxTableSuffix) {
+			log.Printf("Warning: Sandbox disabled: table %s does not end with %s", table, services.SandboxTableSuffix)
+			return nil
+		}
 	}
 
+	t := newDataTenant(cfg, services.NewDynamoDBService(dynamoClient, tables[0], tables[1], tables[2], tables[3]))
+	t.sandbox = true
+	return t
+}
+
+// activate puts the tenant's services in use
+func (t *dataTenant) activate() {
+	dynamoService = t.dynamo
+	conversionService = t.conversion
+	progressReporter = t.progress
+	validationRules = t.validationRules
+	domainPolicy = t.domainPolicy
+	suggestIndex = t.suggestIndex
+	venueRegistry = t.venueRegistry
+	neighborhoods = t.neighborhoods
+	activityTags = t.activityTags
+	geocoder = t.geocoder
+	reportStore = t.reportStore
+}
+
+// isSandboxRequest reports whether a request was made with a sandbox API key
+func isSandboxRequest(ctx context.Context) bool {
+	apiKey := requestAdminKey(ctx)
+	return apiKey != nil && apiKey.Sandbox
+}
+
+// handleRequest routes an API request. Handlers set only the headers particular to their
+// response; withResponseHeaders adds the rest.
+func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
+	headers := map[string]string{}
+
 	// Route requests based on path and method
 	path := request.Path
 	method := request.HTTPMethod
 
 	log.Printf("Admin API request: %s %s", method, path)
+	defer flushDynamoDBTelemetry()
 
 	var responseBody ResponseBody
 	var statusCode int
@@ -148,10 +428,34 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 	case method == "GET" && path == "/api/sources/active":
 		responseBody, statusCode = handleGetActiveSources(ctx, request.QueryStringParameters)
 
+	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/analysis/report"):
+		sourceID := extractSourceIDFromPath(path, "/analysis/report")
+		responseBody, statusCode = handleGetAnalysisReport(ctx, sourceID)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/analysis/compare"):
+		sourceID := extractSourceIDFromPath(path, "/analysis/compare")
+		responseBody, statusCode = handleCompareAnalyses(ctx, sourceID, request.QueryStringParameters)
+
 	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/analysis"):
 		sourceID := extractSourceIDFromPath(path, "/analysis")
 		responseBody, statusCode = handleGetAnalysis(ctx, sourceID)
 
+	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/report-card"):
+		sourceID := extractSourceIDFromPath(path, "/report-card")
+		responseBody, statusCode = handleGetSourceReportCard(ctx, sourceID, request.QueryStringParameters)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/onboarding"):
+		sourceID := extractSourceIDFromPath(path, "/onboarding")
+		responseBody, statusCode = handleGetSourceOnboarding(ctx, sourceID)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/sources/") && strings.Contains(path, "/onboarding/"):
+		parts := strings.SplitN(strings.TrimPrefix(path, "/api/sources/"), "/onboarding/", 2)
+		responseBody, statusCode = handleUpdateSourceOnboardingStep(ctx, parts[0], parts[1], request.Body)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/cost-forecast"):
+		sourceID := extractSourceIDFromPath(path, "/cost-forecast")
+		responseBody, statusCode = handleGetCostForecast(ctx, sourceID, request.QueryStringParameters)
+
 	case method == "GET" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/details"):
 		sourceID := extractSourceIDFromPath(path, "/details")
 		responseBody, statusCode = handleGetSourceDetails(ctx, sourceID, request.QueryStringParameters)
@@ -160,10 +464,22 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 		sourceID := extractSourceIDFromPath(path, "/trigger")
 		responseBody, statusCode = handleTriggerManualScrape(ctx, sourceID, request.Body)
 
+	case method == "POST" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/preview-diff"):
+		sourceID := extractSourceIDFromPath(path, "/preview-diff")
+		responseBody, statusCode = handlePreviewSourceDiff(ctx, sourceID, request.Body)
+
 	case method == "PUT" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/activate"):
 		sourceID := extractSourceIDFromPath(path, "/activate")
 		responseBody, statusCode = handleActivateSource(ctx, sourceID, request.Body)
 
+	case method == "PUT" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/pause"):
+		sourceID := extractSourceIDFromPath(path, "/pause")
+		responseBody, statusCode = handlePauseSource(ctx, sourceID, request.Body)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/resume"):
+		sourceID := extractSourceIDFromPath(path, "/resume")
+		responseBody, statusCode = handleResumeSource(ctx, sourceID)
+
 	case method == "PUT" && strings.HasPrefix(path, "/api/sources/") && strings.HasSuffix(path, "/reject"):
 		sourceID := extractSourceIDFromPath(path, "/reject")
 		responseBody, statusCode = handleRejectSource(ctx, sourceID, request.Body)
@@ -172,6 +488,9 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 		sourceID := strings.TrimPrefix(path, "/api/sources/")
 		responseBody, statusCode = handleDeleteSource(ctx, sourceID)
 
+	case method == "GET" && path == "/api/analytics/system":
+		responseBody, statusCode = handleGetSystemAnalytics(ctx)
+
 	case method == "GET" && path == "/api/analytics":
 		responseBody, statusCode = handleGetAnalytics(ctx, request.QueryStringParameters)
 
@@ -183,9 +502,85 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 	case method == "POST" && path == "/api/debug/extract":
 		responseBody, statusCode = handleDebugExtraction(ctx, request.Body)
 
+	case method == "GET" && strings.HasPrefix(path, "/api/jobs/") && strings.HasSuffix(path, "/progress"):
+		jobID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/jobs/"), "/progress")
+		responseBody, statusCode = handleGetJobProgress(ctx, jobID)
+
+	case method == "POST" && path == "/api/progress/tickets":
+		responseBody, statusCode = handleCreateProgressTicket(ctx)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/runs/"):
+		runID := strings.TrimPrefix(path, "/api/runs/")
+		responseBody, statusCode = handleGetScrapingRun(ctx, runID)
+
+	case method == "GET" && path == "/api/tasks/failed":
+		responseBody, statusCode = handleGetFailedTasks(ctx, request.QueryStringParameters)
+
+	case method == "DELETE" && strings.HasPrefix(path, "/api/tasks/") && !strings.Contains(path[11:], "/"):
+		taskID := strings.TrimPrefix(path, "/api/tasks/")
+		responseBody, statusCode = handleCancelScrapingTask(ctx, taskID)
+
 	case method == "GET" && path == "/api/events/pending":
 		responseBody, statusCode = handleGetPendingEvents(ctx, request.QueryStringParameters)
 
+	// Matched before GET /api/events/{id}
+	case method == "GET" && path == "/api/events/next":
+		responseBody, statusCode = handleGetNextEvent(ctx, request.QueryStringParameters)
+
+	// Public month view for main frontend; matched before GET /api/events/{id}
+	case method == "GET" && path == "/api/events/calendar":
+		responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleGetEventsCalendar)
+
+	case method == "GET" && path == "/api/events/changes":
+		responseBody, statusCode = handleGetPendingEventChanges(ctx)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/diagnostics"):
+		eventID := extractEventIDFromPath(path, "/diagnostics")
+		responseBody, statusCode = handleGetEventDiagnostics(ctx, eventID)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/public-preview"):
+		eventID := extractEventIDFromPath(path, "/public-preview")
+		responseBody, statusCode = handleGetEventPublicPreview(ctx, eventID)
+
+	// Public Events API for main frontend; matched before GET /api/events/{id}
+	case method == "GET" && path == "/api/events/approved":
+		if request.QueryStringParameters["preview_token"] != "" {
+			// Previews show unpublished events to one reviewer, so nothing along the way may cache them
+			headers["Cache-Control"] = "private, no-store"
+			responseBody, statusCode = handleGetApprovedEvents(ctx, request.QueryStringParameters)
+		} else {
+			responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleGetApprovedEvents)
+		}
+
+	case method == "GET" && path == "/api/events/approved.ics":
+		// The feed is served as iCalendar rather than JSON; errors are still JSON
+		responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleGetApprovedEventsFeed)
+		if feed, ok := responseBody.Data.(string); ok && statusCode == 200 {
+			headers["Content-Type"] = "text/calendar; charset=utf-8"
+			headers["Content-Disposition"] = `inline; filename="seattle-family-activities.ics"`
+			return AdminAPIResponse{
+				StatusCode: statusCode,
+				Headers:    headers,
+				Body:       feed,
+			}, nil
+		}
+
+	case method == "GET" && (path == "/api/events/approved.rss" || path == "/api/events/approved.atom"):
+		// The feeds are served as XML rather than JSON; errors are still JSON
+		format, contentType := latestFeedRSS, "application/rss+xml; charset=utf-8"
+		if strings.HasSuffix(path, ".atom") {
+			format, contentType = latestFeedAtom, "application/atom+xml; charset=utf-8"
+		}
+		responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleGetLatestApprovedFeed(format))
+		if feed, ok := responseBody.Data.(string); ok && statusCode == 200 {
+			headers["Content-Type"] = contentType
+			return AdminAPIResponse{
+				StatusCode: statusCode,
+				Headers:    headers,
+				Body:       feed,
+			}, nil
+		}
+
 	case method == "GET" && strings.HasPrefix(path, "/api/events/") && !strings.Contains(path[12:], "/"):
 		eventID := strings.TrimPrefix(path, "/api/events/")
 		responseBody, statusCode = handleGetEvent(ctx, eventID)
@@ -198,16 +593,233 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 		eventID := extractEventIDFromPath(path, "/reject")
 		responseBody, statusCode = handleRejectEvent(ctx, eventID, request.Body)
 
+	case method == "PUT" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/visibility"):
+		eventID := extractEventIDFromPath(path, "/visibility")
+		responseBody, statusCode = handleSetEventVisibility(ctx, eventID, request.Body)
+
+	case method == "PUT" && path == "/api/events/bulk-edit":
+		responseBody, statusCode = handleBulkEditEvents(ctx, request.Body)
+
 	case method == "PUT" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/edit"):
 		eventID := extractEventIDFromPath(path, "/edit")
 		responseBody, statusCode = handleEditEvent(ctx, eventID, request.Body)
 
+	case method == "PUT" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/split"):
+		eventID := extractEventIDFromPath(path, "/split")
+		responseBody, statusCode = handleSplitEvent(ctx, eventID)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/approve-change"):
+		eventID := extractEventIDFromPath(path, "/approve-change")
+		responseBody, statusCode = handleApproveEventChange(ctx, eventID, request.Body)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/events/") && strings.HasSuffix(path, "/reject-change"):
+		eventID := extractEventIDFromPath(path, "/reject-change")
+		responseBody, statusCode = handleRejectEventChange(ctx, eventID, request.Body)
+
+	// Crawl submissions: the events extracted together from one URL, reviewed as a batch
+	case method == "GET" && strings.HasPrefix(path, "/api/submissions/") && !strings.Contains(path[17:], "/"):
+		submissionID := strings.TrimPrefix(path, "/api/submissions/")
+		responseBody, statusCode = handleGetSubmission(ctx, submissionID)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/submissions/") && strings.HasSuffix(path, "/approve"):
+		submissionID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/submissions/"), "/approve")
+		responseBody, statusCode = handleReviewSubmission(ctx, submissionID, "approve", request.Body)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/submissions/") && strings.HasSuffix(path, "/reject"):
+		submissionID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/submissions/"), "/reject")
+		responseBody, statusCode = handleReviewSubmission(ctx, submissionID, "reject", request.Body)
+
+	// Provider accounts, managed by admins
+	case method == "POST" && path == "/api/providers":
+		responseBody, statusCode = handleCreateProvider(ctx, request.Body)
+
+	case method == "GET" && path == "/api/providers":
+		responseBody, statusCode = handleListProviders(ctx)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/providers/") && strings.HasSuffix(path, "/revoke"):
+		providerID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/providers/"), "/revoke")
+		responseBody, statusCode = handleRevokeProvider(ctx, providerID)
+
+	// Admin API keys, managed by admins
+	case method == "POST" && path == "/api/admin/api-keys":
+		responseBody, statusCode = handleCreateAdminAPIKey(ctx, request.Body)
+
+	case method == "GET" && path == "/api/admin/api-keys":
+		responseBody, statusCode = handleListAdminAPIKeys(ctx)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/admin/api-keys/") && strings.HasSuffix(path, "/revoke"):
+		keyID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/admin/api-keys/"), "/revoke")
+		responseBody, statusCode = handleRevokeAdminAPIKey(ctx, keyID)
+
+	// Sandbox for training new admins, used with sandbox API keys
+	case method == "POST" && path == "/api/sandbox/reset":
+		responseBody, statusCode = handleResetSandbox(ctx)
+
+	// Provider self-service API, authenticated with a provider token
+	case method == "POST" && path == "/api/provider/events":
+		responseBody, statusCode = handleProviderSubmitEvent(ctx, request.Headers, request.Body)
+
+	case method == "GET" && path == "/api/provider/events":
+		responseBody, statusCode = handleProviderListEvents(ctx, request.Headers)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/provider/events/") && !strings.Contains(path[21:], "/"):
+		eventID := strings.TrimPrefix(path, "/api/provider/events/")
+		responseBody, statusCode = handleProviderUpdateEvent(ctx, request.Headers, eventID, request.Body)
+
+	case method == "DELETE" && strings.HasPrefix(path, "/api/provider/events/") && !strings.Contains(path[21:], "/"):
+		eventID := strings.TrimPrefix(path, "/api/provider/events/")
+		responseBody, statusCode = handleProviderCancelEvent(ctx, request.Headers, eventID, request.Body)
+
+	// Venue claims: claimants request and verify publicly, admins decide
+	case method == "POST" && strings.HasPrefix(path, "/api/venues/") && strings.HasSuffix(path, "/claims"):
+		venueID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/venues/"), "/claims")
+		responseBody, statusCode = handleCreateVenueClaim(ctx, venueID, request.Body)
+
+	case method == "POST" && strings.HasPrefix(path, "/api/venue-claims/") && strings.HasSuffix(path, "/verify"):
+		claimID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/venue-claims/"), "/verify")
+		responseBody, statusCode = handleVerifyVenueClaim(ctx, claimID, request.Body)
+
+	case method == "GET" && path == "/api/venue-claims":
+		responseBody, statusCode = handleListVenueClaims(ctx, request.QueryStringParameters)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/venue-claims/") && !strings.Contains(path[18:], "/"):
+		claimID := strings.TrimPrefix(path, "/api/venue-claims/")
+		responseBody, statusCode = handleGetVenueClaim(ctx, claimID)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/venue-claims/") && strings.HasSuffix(path, "/approve"):
+		claimID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/venue-claims/"), "/approve")
+		responseBody, statusCode = handleApproveVenueClaim(ctx, claimID, request.Body)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/venue-claims/") && strings.HasSuffix(path, "/reject"):
+		claimID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/venue-claims/"), "/reject")
+		responseBody, statusCode = handleRejectVenueClaim(ctx, claimID, request.Body)
+
+	case method == "GET" && path == "/api/links/broken":
+		responseBody, statusCode = handleGetBrokenLinks(ctx)
+
 	case method == "GET" && path == "/api/schemas":
 		responseBody, statusCode = handleGetSchemas(ctx)
 
-	// Public Events API for main frontend
-	case method == "GET" && path == "/api/events/approved":
-		responseBody, statusCode = handleGetApprovedEvents(ctx, request.QueryStringParameters)
+	case method == "GET" && strings.HasPrefix(path, "/api/validation-rules/"):
+		schemaType := strings.TrimPrefix(path, "/api/validation-rules/")
+		responseBody, statusCode = handleGetValidationRules(ctx, schemaType)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/validation-rules/"):
+		schemaType := strings.TrimPrefix(path, "/api/validation-rules/")
+		responseBody, statusCode = handleUpdateValidationRules(ctx, schemaType, request.Body)
+
+	case method == "GET" && path == "/api/settings/concurrency":
+		responseBody, statusCode = handleGetConcurrencySettings(ctx)
+
+	case method == "PUT" && path == "/api/settings/concurrency":
+		responseBody, statusCode = handleUpdateConcurrencySettings(ctx, request.Body)
+
+	case method == "GET" && path == "/api/settings/feature-flags":
+		responseBody, statusCode = handleGetFeatureFlags(ctx)
+
+	case method == "PUT" && path == "/api/settings/feature-flags":
+		responseBody, statusCode = handleUpdateFeatureFlags(ctx, request.Body)
+
+	// Status badges and action buttons in the admin UI are rendered from the state machines
+	case method == "GET" && path == "/api/meta/pipeline":
+		responseBody, statusCode = handleGetPipelineStates()
+
+	case method == "GET" && path == "/api/meta/models":
+		responseBody, statusCode = handleGetModelSchemas(request.QueryStringParameters)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/admin/venues/") && strings.HasSuffix(path, "/arrival"):
+		venueID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/admin/venues/"), "/arrival")
+		responseBody, statusCode = handleUpdateVenueArrival(ctx, venueID, request.Body)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/admin/venues/"):
+		venueID := strings.TrimPrefix(path, "/api/admin/venues/")
+		responseBody, statusCode = handleGetAdminVenue(ctx, venueID, request.QueryStringParameters)
+
+	case method == "GET" && path == "/api/admin/me/stats":
+		responseBody, statusCode = handleGetAdminStats(ctx, request.QueryStringParameters)
+
+	case method == "POST" && path == "/api/admin/preview-tokens":
+		responseBody, statusCode = handleCreatePreviewToken(ctx, request.Body)
+
+	case method == "GET" && path == "/api/admin/presets":
+		responseBody, statusCode = handleListReviewPresets(ctx, request.QueryStringParameters)
+
+	case method == "POST" && path == "/api/admin/presets":
+		responseBody, statusCode = handleCreateReviewPreset(ctx, request.Body)
+
+	case method == "DELETE" && strings.HasPrefix(path, "/api/admin/presets/"):
+		presetID := strings.TrimPrefix(path, "/api/admin/presets/")
+		responseBody, statusCode = handleDeleteReviewPreset(ctx, presetID, request.QueryStringParameters)
+
+	// Admin notification inbox
+	case method == "GET" && path == "/api/notifications":
+		responseBody, statusCode = handleListNotifications(ctx, request.QueryStringParameters)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/notifications/") && strings.HasSuffix(path, "/read"):
+		notificationID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/notifications/"), "/read")
+		responseBody, statusCode = handleMarkNotificationRead(ctx, notificationID)
+
+	// Activity tags: listed and browsed publicly, managed by editors
+	case method == "GET" && path == "/api/tags":
+		responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleListTags)
+
+	case method == "POST" && path == "/api/tags":
+		responseBody, statusCode = handleCreateTag(ctx, request.Body)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/tags/") && !strings.Contains(path[10:], "/"):
+		slug := strings.TrimPrefix(path, "/api/tags/")
+		responseBody, statusCode = handleGetTag(ctx, slug, request.QueryStringParameters)
+
+	case method == "PUT" && strings.HasPrefix(path, "/api/tags/") && !strings.Contains(path[10:], "/"):
+		slug := strings.TrimPrefix(path, "/api/tags/")
+		responseBody, statusCode = handleUpdateTag(ctx, slug, request.Body)
+
+	case method == "DELETE" && strings.HasPrefix(path, "/api/tags/") && !strings.Contains(path[10:], "/"):
+		slug := strings.TrimPrefix(path, "/api/tags/")
+		responseBody, statusCode = handleDeleteTag(ctx, slug)
+
+	case method == "GET" && path == "/api/domain-policy":
+		responseBody, statusCode = handleGetDomainPolicy(ctx)
+
+	case method == "PUT" && path == "/api/domain-policy":
+		responseBody, statusCode = handleUpdateDomainPolicy(ctx, request.Body)
+
+	case method == "GET" && path == "/api/admin/neighborhoods":
+		responseBody, statusCode = handleGetNeighborhoods(ctx)
+
+	case method == "PUT" && path == "/api/admin/neighborhoods":
+		responseBody, statusCode = handleUpdateNeighborhoods(ctx, request.Body)
+
+	case method == "POST" && path == "/api/activities/bulk-delete":
+		responseBody, statusCode = handleBulkDeleteActivities(ctx, request.Body)
+
+	case method == "GET" && path == "/api/approvals":
+		responseBody, statusCode = handleListApprovals(ctx, request.QueryStringParameters)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/approvals/") && !strings.Contains(path[15:], "/"):
+		proposalID := strings.TrimPrefix(path, "/api/approvals/")
+		responseBody, statusCode = handleGetApproval(ctx, proposalID)
+
+	case method == "POST" && strings.HasPrefix(path, "/api/approvals/") && strings.HasSuffix(path, "/confirm"):
+		proposalID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/approvals/"), "/confirm")
+		responseBody, statusCode = handleConfirmApproval(ctx, proposalID)
+
+	case method == "GET" && path == "/api/changes":
+		responseBody, statusCode = handleGetCatalogChanges(ctx, request.QueryStringParameters)
+
+	case method == "GET" && path == "/api/plans/weekend":
+		responseBody, statusCode = cachedPublicQuery(ctx, path, request.QueryStringParameters, handleGetWeekendPlan)
+
+	case method == "GET" && path == "/api/search/suggest":
+		responseBody, statusCode = handleSearchSuggest(ctx, request.QueryStringParameters)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/venues/") && strings.HasSuffix(path, "/events"):
+		venueID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/venues/"), "/events")
+		responseBody, statusCode = handleGetVenueEvents(ctx, venueID, request.QueryStringParameters)
+
+	case method == "GET" && strings.HasPrefix(path, "/api/activities/") && !strings.Contains(path[16:], "/"):
+		activityID := strings.TrimPrefix(path, "/api/activities/")
+		responseBody, statusCode = handleGetActivity(ctx, activityID)
 
 	// Source Management API for admin interface
 	case method == "GET" && path == "/api/sources/active":
@@ -249,6 +861,161 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 	}, nil
 }
 
+// withResponseHeaders wraps the API so that CORS preflight requests are answered before they reach
+// authentication, since browsers send them without credentials, and every response carries the
+// CORS, security and content type headers of apiHeaders
+func withResponseHeaders(next func(context.Context, events.APIGatewayProxyRequest) (AdminAPIResponse, error)) func(context.Context, events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
+		if request.HTTPMethod == "OPTIONS" {
+			statusCode, headers := apiHeaders.Preflight(request.Headers)
+			return AdminAPIResponse{
+				StatusCode: statusCode,
+				Headers:    headers,
+			}, nil
+		}
+
+		response, err := next(ctx, request)
+		response.Headers = apiHeaders.Apply(request.Headers, response.Headers)
+		return response, err
+	}
+}
+
+// withAdminAuth wraps the router so that every route except the public ones needs an admin API
+// key whose role allows the route. Requests made with sandbox keys are served from the sandbox tables.
+func withAdminAuth(next func(context.Context, events.APIGatewayProxyRequest) (AdminAPIResponse, error)) func(context.Context, events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (AdminAPIResponse, error) {
+		if isPublicRoute(request.HTTPMethod, request.Path) {
+			return next(ctx, request)
+		}
+
+		apiKey, response, statusCode := authenticateAdmin(ctx, request.Headers)
+		if apiKey != nil {
+			if required := requiredAdminRole(request.HTTPMethod, request.Path); !models.AdminRoleAllows(apiKey.EffectiveRole(), required) {
+				response = ResponseBody{
+					Success: false,
+					Error:   fmt.Sprintf("This action requires the %s role", required),
+				}
+				statusCode = 403
+			}
+		}
+		if apiKey != nil && apiKey.Sandbox && statusCode == 0 {
+			switch {
+			case sandboxTenant == nil:
+				response = ResponseBody{Success: false, Error: "The sandbox is not configured"}
+				statusCode = 503
+			case strings.HasPrefix(request.Path, "/api/admin/api-keys"):
+				response = ResponseBody{Success: false, Error: "Sandbox keys cannot manage API keys"}
+				statusCode = 403
+			}
+		}
+		if statusCode != 0 {
+			log.Printf("Admin API request refused with %d: %s %s", statusCode, request.HTTPMethod, request.Path)
+			bodyJSON, _ := json.Marshal(response) // a ResponseBody of strings always encodes
+			return AdminAPIResponse{
+				StatusCode: statusCode,
+				Body:       string(bodyJSON),
+			}, nil
+		}
+		ctx = context.WithValue(ctx, adminAPIKeyContextKey{}, apiKey)
+		if apiKey.Sandbox {
+			// Sandbox keys are authenticated against production, where keys are managed, and then
+			// read and write only the sandbox tables
+			sandboxTenant.activate()
+			defer productionTenant.activate()
+		}
+		return next(ctx, request)
+	}
+}
+
+type adminAPIKeyContextKey struct{}
+
+// requestAdminKey returns the admin API key a request was authenticated with, or nil for public routes
+func requestAdminKey(ctx context.Context) *models.AdminAPIKey {
+	apiKey, _ := ctx.Value(adminAPIKeyContextKey{}).(*models.AdminAPIKey)
+	return apiKey
+}
+
+// isPublicRoute reports whether a route can be called without an admin API key: the catalog the
+// main frontend reads, the steps venue claimants take, and the provider API, which checks
+// provider tokens itself. Unknown routes are not public, so they are only reported as not found
+// to admins.
+func isPublicRoute(method, path string) bool {
+	switch {
+	case method == "GET" && (path == "/api/events/approved" || path == "/api/events/approved.ics" || path == "/api/events/approved.rss" || path == "/api/events/approved.atom" || path == "/api/events/calendar" || path == "/api/changes" ||
+		path == "/api/plans/weekend" || path == "/api/search/suggest"):
+		return true
+	case method == "GET" && strings.HasPrefix(path, "/api/venues/") && strings.HasSuffix(path, "/events"):
+		return true
+	case method == "GET" && strings.HasPrefix(path, "/api/activities/") && !strings.Contains(path[16:], "/"):
+		return true
+	case method == "GET" && (path == "/api/tags" || strings.HasPrefix(path, "/api/tags/")):
+		return true
+	case method == "POST" && strings.HasPrefix(path, "/api/venues/") && strings.HasSuffix(path, "/claims"):
+		return true
+	case method == "POST" && strings.HasPrefix(path, "/api/venue-claims/") && strings.HasSuffix(path, "/verify"):
+		return true
+	case strings.HasPrefix(path, "/api/provider/"):
+		return true
+	}
+	return false
+}
+
+// requiredAdminRole returns the role a key needs for an admin route. Reads need a viewer and
+// changes an editor, except for managing API keys, providers and settings, which is left to admins.
+// Marking a notification read changes only the inbox of the request's own key, so viewers may do it,
+// as may they get a ticket to follow job progress.
+func requiredAdminRole(method, path string) string {
+	switch {
+	case method == "PUT" && strings.HasPrefix(path, "/api/notifications/"):
+		return models.AdminRoleViewer
+	case method == "POST" && path == "/api/progress/tickets":
+		return models.AdminRoleViewer
+	case strings.HasPrefix(path, "/api/admin/api-keys"):
+		return models.AdminRoleAdmin
+	case method != "GET" && strings.HasPrefix(path, "/api/providers"):
+		return models.AdminRoleAdmin
+	case method == "PUT" && (path == "/api/settings/concurrency" || path == "/api/settings/feature-flags" || path == "/api/domain-policy" ||
+		path == "/api/admin/neighborhoods" || strings.HasPrefix(path, "/api/validation-rules/")):
+		return models.AdminRoleAdmin
+	case method == "POST" && path == "/api/metrics/reset":
+		return models.AdminRoleAdmin
+	case method == "DELETE" && strings.HasPrefix(path, "/api/sources/"):
+		return models.AdminRoleAdmin
+	case method == "POST" && path == "/api/activities/bulk-delete":
+		return models.AdminRoleAdmin
+	case method == "GET":
+		return models.AdminRoleViewer
+	}
+	return models.AdminRoleEditor
+}
+
+// authenticateAdmin resolves the admin API key sent in the X-Api-Key header, or as a bearer token
+// in the Authorization header. It returns a nil key with the response to send when the key is
+// missing or not accepted.
+func authenticateAdmin(ctx context.Context, headers map[string]string) (*models.AdminAPIKey, ResponseBody, int) {
+	apiKey, err := adminauth.Authenticate(ctx, dynamoService, adminauth.RequestKey(headers))
+	switch {
+	case errors.Is(err, adminauth.ErrUnauthorized):
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "A valid admin API key is required",
+		}, 401
+	case errors.Is(err, adminauth.ErrRevoked):
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Admin API key has been revoked",
+		}, 403
+	case err != nil:
+		log.Printf("Error verifying admin API key: %v", err)
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Failed to verify admin API key",
+		}, 500
+	}
+
+	return apiKey, ResponseBody{}, 0
+}
+
 // extractSourceIDFromPath extracts source ID from path like /api/sources/{id}/analysis
 func extractSourceIDFromPath(path, suffix string) string {
 	// Remove /api/sources/ prefix and suffix
@@ -292,7 +1059,7 @@ func handleSourceSubmission(ctx context.Context, body string) (ResponseBody, int
 		SubmittedBy:     req.SubmittedBy,
 		SubmittedAt:     time.Now(),
 		Status:          models.SourceStatusPendingAnalysis,
-		StatusKey:       models.GenerateSourceStatusKey(models.SourceStatusPendingAnalysis),
+		StatusKey:       models.GenerateSourceStatusKey(models.SourceStatusPendingAnalysis, sourceID),
 		PriorityKey:     models.GenerateSourcePriorityKey(req.Priority, sourceID),
 	}
 
@@ -304,6 +1071,38 @@ func handleSourceSubmission(ctx context.Context, body string) (ResponseBody, int
 		}, 400
 	}
 
+	// Sources on the domain deny list are recorded as rejected so the denial can be reviewed
+	if decision := domainPolicy.Check(submission.BaseURL); !decision.Allowed {
+		submission.Status = models.SourceStatusRejected
+		submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusRejected, submission.SourceID)
+		submission.DenialReason = decision.Reason
+
+		if err := dynamoService.CreateSourceSubmission(ctx, submission); err != nil {
+			log.Printf("Error creating source submission: %v", err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to store source submission",
+			}, 500
+		}
+
+		log.Printf("Source %s at %s denied by domain policy: %s", sourceID, decision.Domain, decision.Reason)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source domain is not allowed: " + decision.Reason,
+			Data: map[string]interface{}{
+				"source_id":       sourceID,
+				"domain_decision": decision,
+			},
+		}, 403
+	}
+
+	// Check DNS, TLS and HTTP reachability up front so unreachable sources fail fast
+	submission.Preflight = preflightChecker.Check(ctx, submission.BaseURL)
+	if !submission.Preflight.Passed {
+		submission.Status = models.SourceStatusPreflightFailed
+		submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusPreflightFailed, submission.SourceID)
+	}
+
 	// Store submission in DynamoDB
 	if err := dynamoService.CreateSourceSubmission(ctx, submission); err != nil {
 		log.Printf("Error creating source submission: %v", err)
@@ -313,6 +1112,18 @@ func handleSourceSubmission(ctx context.Context, body string) (ResponseBody, int
 		}, 500
 	}
 
+	if !submission.Preflight.Passed {
+		log.Printf("Pre-flight check failed for source %s at %s stage: %s", sourceID, submission.Preflight.FailureStage, submission.Preflight.Diagnosis)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source URL failed pre-flight checks: " + submission.Preflight.Diagnosis,
+			Data: map[string]interface{}{
+				"source_id": sourceID,
+				"preflight": submission.Preflight,
+			},
+		}, 422
+	}
+
 	// Automatically trigger source analyzer Lambda
 	if err := triggerSourceAnalyzer(ctx, sourceID); err != nil {
 		log.Printf("Error triggering source analyzer: %v", err)
@@ -323,63 +1134,89 @@ func handleSourceSubmission(ctx context.Context, body string) (ResponseBody, int
 	return ResponseBody{
 		Success: true,
 		Message: "Source submitted successfully and analysis started",
-		Data: map[string]string{
+		Data: map[string]interface{}{
 			"source_id": sourceID,
+			"preflight": submission.Preflight,
 		},
 	}, 201
 }
 
-// handleGetPendingSources handles GET /api/sources/pending
+// handleGetPendingSources handles GET /api/sources/pending.
+// Sources are filtered and sorted by a saved review preset and/or review filter query parameters.
 func handleGetPendingSources(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
-	limit := int32(50)
-	if limitStr, ok := queryParams["limit"]; ok {
-		// Parse limit (simplified, should add proper validation)
-		if parsedLimit := parseLimit(limitStr); parsedLimit > 0 {
-			limit = parsedLimit
-		}
+	filters, statusCode, err := reviewFiltersFromQuery(ctx, models.PresetTargetSources, queryParams)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, statusCode
 	}
 
-	// Get sources with pending_analysis status
-	pendingSources, err := dynamoService.QuerySourcesByStatus(ctx, models.SourceStatusPendingAnalysis, limit/2)
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
 	if err != nil {
-		log.Printf("Error querying pending sources: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to retrieve pending sources",
-		}, 500
+			Error:   err.Error(),
+		}, 400
 	}
 
-	// Get sources with analysis_complete status
-	analysisCompleteSources, err := dynamoService.QuerySourcesByStatus(ctx, models.SourceStatusAnalysisComplete, limit/2)
+	// Sources awaiting analysis or review, and those that failed pre-flight checks so the admin can
+	// see the diagnosis, paged together in priority order
+	statuses := []string{models.SourceStatusPendingAnalysis, models.SourceStatusAnalysisComplete, models.SourceStatusPreflightFailed}
+	allSources, nextToken, err := dynamoService.QuerySourcesByStatusPage(ctx, statuses, int32(limit), queryParams["next_token"])
+	if errors.Is(err, services.ErrInvalidPageToken) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid next_token",
+		}, 400
+	}
 	if err != nil {
-		log.Printf("Error querying analysis complete sources: %v", err)
+		log.Printf("Error querying pending sources: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to retrieve analysis complete sources",
+			Error:   "Failed to retrieve pending sources",
 		}, 500
 	}
 
-	// Combine results
-	allSources := append(pendingSources, analysisCompleteSources...)
+	// Filters and sorting apply within the page
+	allSources = services.FilterSourceSubmissions(allSources, filters)
+	services.SortSourceSubmissions(allSources, filters)
 
 	return ResponseBody{
 		Success: true,
 		Message: "Pending sources retrieved successfully",
 		Data:    allSources,
+		Meta:    pageMeta(nextToken),
 	}, 200
 }
 
+// Page sizes of the admin lists, set with ?limit=
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// pageMeta returns the response meta of a paged list, holding the token of the next page, or nil
+// after the last page
+func pageMeta(nextToken string) map[string]interface{} {
+	if nextToken == "" {
+		return nil
+	}
+	return map[string]interface{}{"next_token": nextToken}
+}
+
 // handleGetActiveSources handles GET /api/sources/active
 func handleGetActiveSources(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
-	limit := int32(50)
-	if limitStr, ok := queryParams["limit"]; ok {
-		if parsedLimit := parseLimit(limitStr); parsedLimit > 0 {
-			limit = parsedLimit
-		}
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
 
 	// Get active sources
-	activeSources, err := dynamoService.QuerySourcesByStatus(ctx, models.SourceStatusActive, limit)
+	activeSources, err := dynamoService.QuerySourcesByStatus(ctx, models.SourceStatusActive, int32(limit))
 	if err != nil {
 		log.Printf("Error querying active sources: %v", err)
 		return ResponseBody{
@@ -526,1926 +1363,7935 @@ func handleGetAnalysis(ctx context.Context, sourceID string) (ResponseBody, int)
 	}, 200
 }
 
-// handleActivateSource handles PUT /api/sources/{id}/activate
-func handleActivateSource(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
-	var req SourceActivationRequest
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
-		return ResponseBody{
-			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
-		}, 400
-	}
-
-	// Get source analysis to ensure it's complete
-	analysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
+// handleCompareAnalyses handles GET /api/sources/{id}/analysis/compare?from=v1&to=v3.
+// to defaults to the latest analysis and from to the version before it.
+func handleCompareAnalyses(ctx context.Context, sourceID string, queryParams map[string]string) (ResponseBody, int) {
+	latest, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
 	if err != nil {
+		log.Printf("Error getting source analysis: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source analysis not found",
+			Error:   "Analysis not found",
 		}, 404
 	}
 
-	if analysis.Status != "analysis_complete" {
-		return ResponseBody{
-			Success: false,
-			Error:   "Source analysis must be complete before activation",
-		}, 400
+	toVersion := latest.Version
+	if value := queryParams["to"]; value != "" {
+		if toVersion, err = models.ParseAnalysisVersion(value); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
 	}
-
-	// Create DynamoSourceConfig from analysis recommendations
-	config, err := createSourceConfigFromAnalysis(ctx, sourceID, analysis, req.AdminNotes)
-	if err != nil {
-		log.Printf("Error creating source config from analysis: %v", err)
+	fromVersion := toVersion - 1
+	if value := queryParams["from"]; value != "" {
+		if fromVersion, err = models.ParseAnalysisVersion(value); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+	}
+	if fromVersion < 1 {
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to create source configuration",
-		}, 500
+			Error:   "No earlier analysis version to compare against",
+		}, 400
 	}
-
-	// Store source configuration
-	if err := dynamoService.CreateSourceConfig(ctx, config); err != nil {
-		log.Printf("Error creating source config: %v", err)
+	if fromVersion == toVersion {
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to activate source",
-		}, 500
+			Error:   "from and to must be different analysis versions",
+		}, 400
 	}
 
-	// Create initial scraping task
-	if err := createInitialScrapingTask(ctx, sourceID, analysis); err != nil {
-		log.Printf("Error creating initial scraping task: %v", err)
-		// Don't fail activation, just log the error
+	analyses := make(map[int]*models.SourceAnalysis, 2)
+	for _, version := range []int{fromVersion, toVersion} {
+		analysis, err := dynamoService.GetSourceAnalysisVersion(ctx, sourceID, version)
+		if err != nil {
+			log.Printf("Error getting source analysis version %d: %v", version, err)
+			return ResponseBody{
+				Success: false,
+				Error:   fmt.Sprintf("Analysis version v%d not found", version),
+			}, 404
+		}
+		analyses[version] = analysis
 	}
 
 	return ResponseBody{
 		Success: true,
-		Message: "Source activated successfully",
-		Data: map[string]string{
-			"source_id": sourceID,
-			"status":    "active",
-		},
+		Message: "Analyses compared successfully",
+		Data:    services.CompareSourceAnalyses(analyses[fromVersion], analyses[toVersion]),
 	}, 200
 }
 
-// handleRejectSource handles PUT /api/sources/{id}/reject
-func handleRejectSource(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
-	// Update source submission status to rejected
+// reportLinkExpiry is how long presigned report links stay valid. Links signed with the
+// Lambda role's temporary credentials stop working when those credentials expire.
+const reportLinkExpiry = 12 * time.Hour
+
+// handleGetAnalysisReport handles GET /api/sources/{id}/analysis/report
+func handleGetAnalysisReport(ctx context.Context, sourceID string) (ResponseBody, int) {
+	if reportStore == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Report storage is not configured",
+		}, 503
+	}
+
 	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
 	if err != nil {
+		log.Printf("Error getting source submission: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source submission not found",
+			Error:   "Source not found",
 		}, 404
 	}
 
-	submission.Status = models.SourceStatusRejected
-	submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusRejected)
+	analysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source analysis: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Analysis not found",
+		}, 404
+	}
 
-	if err := dynamoService.UpdateSourceSubmission(ctx, submission); err != nil {
-		log.Printf("Error updating source submission: %v", err)
+	report, err := services.RenderAnalysisReport(submission, analysis)
+	if err != nil {
+		log.Printf("Error rendering analysis report: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to reject source",
+			Error:   "Failed to render analysis report",
+		}, 500
+	}
+
+	key := fmt.Sprintf("analysis-reports/%s/%s.html", sourceID, time.Now().UTC().Format("20060102T150405Z"))
+	if err := reportStore.Put(ctx, key, report, "text/html; charset=utf-8"); err != nil {
+		log.Printf("Error uploading analysis report: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to store analysis report",
+		}, 500
+	}
+
+	reportURL, err := reportStore.PresignGet(ctx, key, reportLinkExpiry)
+	if err != nil {
+		log.Printf("Error presigning analysis report: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create report link",
 		}, 500
 	}
 
 	return ResponseBody{
 		Success: true,
-		Message: "Source rejected successfully",
-		Data: map[string]string{
-			"source_id": sourceID,
-			"status":    "rejected",
+		Message: "Analysis report generated successfully",
+		Data: map[string]interface{}{
+			"source_id":  sourceID,
+			"report_url": reportURL,
+			"report_key": key,
+			"format":     "html",
+			"expires_at": time.Now().Add(reportLinkExpiry).Format(time.RFC3339),
 		},
 	}, 200
 }
 
-// handleDeleteSource handles DELETE /api/sources/{id}
-func handleDeleteSource(ctx context.Context, sourceID string) (ResponseBody, int) {
-	// Validate source ID
-	if sourceID == "" {
+// handleGetSourceReportCard handles GET /api/sources/{id}/report-card?week=2024-01-15.
+// Without a week the source's latest report card is returned; a week is any date in it.
+func handleGetSourceReportCard(ctx context.Context, sourceID string, queryParams map[string]string) (ResponseBody, int) {
+	if reportStore == nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Source ID is required",
-		}, 400
+			Error:   "Report storage is not configured",
+		}, 503
 	}
 
-	log.Printf("Delete request for source: %s", sourceID)
-
-	// Verify source exists before attempting deletion
-	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
-	if err != nil {
-		log.Printf("Error getting source submission for deletion: %v", err)
-		
-		// Log failed deletion attempt
-		if logErr := logSourceDeletionEvent(ctx, sourceID, "Unknown Source", "", nil, false, err.Error()); logErr != nil {
-			log.Printf("Error logging failed deletion attempt: %v", logErr)
+	key := models.CreateLatestReportCardKey(sourceID)
+	if week := queryParams["week"]; week != "" {
+		date, err := time.Parse("2006-01-02", week)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid week, expected a date like 2024-01-15",
+			}, 400
 		}
-		
+		key = models.CreateReportCardKey(sourceID, models.ReportCardWeekStart(date))
+	}
+
+	body, err := reportStore.Get(ctx, key)
+	if errors.Is(err, services.ErrObjectNotFound) {
 		return ResponseBody{
 			Success: false,
-			Error:   "Source not found",
+			Error:   "Report card not found",
 		}, 404
 	}
-
-	// Call DynamoDB service deletion method
-	deletionResult, err := dynamoService.DeleteSourceCompletely(ctx, sourceID)
 	if err != nil {
-		log.Printf("Error deleting source %s: %v", sourceID, err)
-		
-		// Log failed deletion attempt
-		if logErr := logSourceDeletionEvent(ctx, sourceID, sourceSubmission.SourceName, sourceSubmission.BaseURL, nil, false, err.Error()); logErr != nil {
-			log.Printf("Error logging failed deletion attempt: %v", logErr)
-		}
-		
+		log.Printf("Error getting report card %s: %v", key, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to delete source: " + err.Error(),
+			Error:   "Failed to load report card",
 		}, 500
 	}
 
-	// Log successful deletion
-	if logErr := logSourceDeletionEvent(ctx, sourceID, sourceSubmission.SourceName, sourceSubmission.BaseURL, deletionResult, true, ""); logErr != nil {
-		log.Printf("Error logging successful deletion: %v", logErr)
-		// Don't fail the request if logging fails
+	var card models.SourceReportCard
+	if err := json.Unmarshal(body, &card); err != nil {
+		log.Printf("Error unmarshaling report card %s: %v", key, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to load report card",
+		}, 500
 	}
 
-	// Format response with deletion results
-	responseData := map[string]interface{}{
-		"source_id": sourceID,
-		"source_name": sourceSubmission.SourceName,
-		"deleted_records": map[string]interface{}{
-			"submission": deletionResult.SubmissionDeleted,
-			"analysis":   deletionResult.AnalysisDeleted,
-			"config":     deletionResult.ConfigDeleted,
-			"activities_count": deletionResult.ActivitiesDeleted,
-		},
-		"total_records_deleted": deletionResult.TotalRecords,
+	return ResponseBody{
+		Success: true,
+		Data:    card,
+	}, 200
+}
+
+// handleGetSourceOnboarding handles GET /api/sources/{id}/onboarding - the source's onboarding
+// checklist, with the steps that follow from its analysis and configuration brought up to date
+func handleGetSourceOnboarding(ctx context.Context, sourceID string) (ResponseBody, int) {
+	source, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
 	}
 
-	log.Printf("Successfully deleted source %s - %d total records removed", sourceID, deletionResult.TotalRecords)
+	// Sources that are not analyzed or activated yet have neither record
+	analysis, _ := dynamoService.GetSourceAnalysis(ctx, sourceID)
+	config, _ := dynamoService.GetSourceConfig(ctx, sourceID)
+	if source.SyncOnboarding(analysis, config) {
+		if err := dynamoService.UpdateSourceSubmission(ctx, source); err != nil {
+			log.Printf("Warning: Failed to save onboarding progress of source %s: %v", sourceID, err)
+		}
+	}
 
 	return ResponseBody{
 		Success: true,
-		Message: fmt.Sprintf("Source '%s' deleted successfully", sourceSubmission.SourceName),
-		Data:    responseData,
+		Data:    source.OnboardingChecklist(),
 	}, 200
 }
 
-// logSourceDeletionEvent logs a source deletion event to the admin events table
-func logSourceDeletionEvent(ctx context.Context, sourceID, sourceName, sourceURL string, deletionResult *models.DeletionResult, success bool, errorMessage string) error {
-	eventID := uuid.New().String()
-	
-	// Create deletion event
-	deletionEvent := &models.SourceDeletionEvent{
-		EventType:  models.AdminEventTypeDeletion,
-		EventID:    eventID,
-		AdminUser:  "admin", // TODO: Get actual admin user from context/auth
-		SourceID:   sourceID,
-		SourceName: sourceName,
-		SourceURL:  sourceURL,
-		Success:    success,
-		ErrorMessage: errorMessage,
+// handleUpdateSourceOnboardingStep handles PUT /api/sources/{id}/onboarding/{step}, checking off or
+// reopening a manual onboarding step
+func handleUpdateSourceOnboardingStep(ctx context.Context, sourceID, step string, body string) (ResponseBody, int) {
+	definition, ok := models.GetOnboardingStepDefinition(step)
+	if !ok {
+		return ResponseBody{
+			Success: false,
+			Error:   "Unknown onboarding step: " + step,
+		}, 404
 	}
-	
-	// Set deletion data if available
-	if deletionResult != nil {
-		deletionEvent.DeletionData = *deletionResult
-	} else {
-		// Create empty deletion result for failed attempts
-		deletionEvent.DeletionData = models.DeletionResult{
-			SourceID:          sourceID,
-			SubmissionDeleted: false,
-			AnalysisDeleted:   false,
-			ConfigDeleted:     false,
-			ActivitiesDeleted: 0,
-			TotalRecords:      0,
-		}
+	if !definition.Manual {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Onboarding step %s is recorded automatically", step),
+		}, 400
 	}
-	
-	// Store the deletion event
-	return dynamoService.CreateSourceDeletionEvent(ctx, deletionEvent)
-}
 
-// handleGetAnalytics handles GET /api/analytics
-func handleGetAnalytics(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
-	// For MVP, return basic analytics data
-	// In production, this would query source metrics from DynamoDB
-	analytics := map[string]interface{}{
-		"total_sources_submitted": 12,
-		"sources_pending_analysis": 3,
-		"sources_active":          6,
-		"sources_rejected":        3,
-		"avg_analysis_time":       "3.2 minutes",
-		"success_rate":            "75%",
+	var req models.OnboardingStepUpdate
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	source, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+
+	if source.SetOnboardingStep(step, req.Status, req.Detail, req.UpdatedBy, time.Now()) {
+		if err := dynamoService.UpdateSourceSubmission(ctx, source); err != nil {
+			log.Printf("Error saving onboarding step %s of source %s: %v", step, sourceID, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to update onboarding step",
+			}, 500
+		}
 	}
 
 	return ResponseBody{
 		Success: true,
-		Message: "Analytics retrieved successfully",
-		Data:    analytics,
+		Message: fmt.Sprintf("Onboarding step %s marked %s", step, req.Status),
+		Data:    source.OnboardingChecklist(),
 	}, 200
 }
 
-// Helper functions
+// recordCanaryScrape records a preview scrape of a source as its onboarding canary scrape. The
+// scrape passes when it found activities.
+func recordCanaryScrape(ctx context.Context, source *models.SourceSubmission, targetURLs []string, scraped int, scrapeErrors []string) {
+	status := models.OnboardingStatusComplete
+	if scraped == 0 {
+		status = models.OnboardingStatusFailed
+	}
+	detail := fmt.Sprintf("Preview scrape found %d activities on %d target URLs", scraped, len(targetURLs))
+	if len(scrapeErrors) > 0 {
+		detail += fmt.Sprintf(", %d failed", len(scrapeErrors))
+	}
 
-func generateSourceID(sourceName string) string {
-	// Create a URL-safe ID from source name + UUID
-	baseID := strings.ToLower(sourceName)
-	baseID = strings.ReplaceAll(baseID, " ", "-")
-	baseID = strings.ReplaceAll(baseID, "&", "and")
-	
-	// Remove special characters
-	var cleanID strings.Builder
-	for _, r := range baseID {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			cleanID.WriteRune(r)
+	if source.SetOnboardingStep(models.OnboardingStepCanaryScrapePassed, status, detail, models.OnboardingRecordedBySystem, time.Now()) {
+		if err := dynamoService.UpdateSourceSubmission(ctx, source); err != nil {
+			log.Printf("Warning: Failed to record canary scrape of source %s: %v", source.SourceID, err)
 		}
 	}
-	
-	// Add UUID suffix to ensure uniqueness
-	shortUUID := uuid.New().String()[:8]
-	return cleanID.String() + "-" + shortUUID
 }
 
-func triggerSourceAnalyzer(ctx context.Context, sourceID string) error {
-	payload := map[string]interface{}{
-		"source_id":    sourceID,
-		"trigger_type": "automatic",
-	}
+// handleActivateSource handles PUT /api/sources/{id}/activate. Under the two-person rule, a source
+// auto-approval is on for is only activated once a second admin confirms.
+func handleActivateSource(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
+	return activateSource(ctx, sourceID, body, twoPersonRule)
+}
 
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return err
+// activateSource activates a source, or proposes its activation when it needs confirmation and
+// auto-approval is on for it
+func activateSource(ctx context.Context, sourceID string, body string, needsConfirmation bool) (ResponseBody, int) {
+	var req SourceActivationRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
 	}
 
-	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
-		FunctionName:   aws.String(sourceAnalyzerFunctionName),
-		InvocationType: "Event", // Async invocation
-		Payload:        payloadBytes,
-	})
-
-	return err
-}
+	runLimits := models.DefaultSourceRunLimits
+	if req.RunLimits != nil {
+		if err := req.RunLimits.Validate(); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid run limits: " + err.Error(),
+			}, 400
+		}
+		runLimits = *req.RunLimits
+	}
 
-func createSourceConfigFromAnalysis(ctx context.Context, sourceID string, analysis *models.SourceAnalysis, adminNotes string) (*models.DynamoSourceConfig, error) {
-	// Get the original source submission to populate fields
-	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	// Get source analysis to ensure it's complete
+	analysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get source submission: %w", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source analysis not found",
+		}, 404
 	}
 
-	now := time.Now()
-	
-	return &models.DynamoSourceConfig{
-		PK:         models.CreateSourcePK(sourceID),
-		SK:         models.CreateSourceConfigSK(),
+	if analysis.Status != "analysis_complete" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source analysis must be complete before activation",
+		}, 400
+	}
+
+	// Events of a source auto-approval is on for are published without review
+	if needsConfirmation {
+		flags, err := dynamoService.GetFeatureFlagSettings(ctx)
+		if err != nil {
+			log.Printf("Error getting feature flags to activate source %s: %v", sourceID, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to activate source",
+			}, 500
+		}
+		if flags != nil && flags.IsEnabled(models.FlagAutoApproval, sourceID) {
+			summary := fmt.Sprintf("Activate source %s, whose events are auto-approved", sourceID)
+			return proposeAction(ctx, models.ApprovalActionActivateSource, sourceID, summary, body)
+		}
+	}
+
+	// Create DynamoSourceConfig from analysis recommendations
+	config, err := createSourceConfigFromAnalysis(ctx, sourceID, analysis, req.AdminNotes)
+	if err != nil {
+		log.Printf("Error creating source config from analysis: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create source configuration",
+		}, 500
+	}
+	config.RunLimits = runLimits
+	config.AutoHealSelectors = req.AutoHealSelectors
+	if req.ActivatedBy != "" {
+		config.ActivatedBy = req.ActivatedBy
+	}
+
+	// Store source configuration
+	if err := dynamoService.CreateSourceConfig(ctx, config); err != nil {
+		log.Printf("Error creating source config: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to activate source",
+		}, 500
+	}
+
+	recordAdminAction(ctx, req.ActivatedBy, models.AdminAuditSourceActivated, sourceID, config.ActivatedAt, 0)
+
+	// Create initial scraping task
+	if err := createInitialScrapingTask(ctx, sourceID, analysis); err != nil {
+		log.Printf("Error creating initial scraping task: %v", err)
+		// Don't fail activation, just log the error
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Source activated successfully",
+		Data: map[string]interface{}{
+			"source_id":     sourceID,
+			"status":        "active",
+			"cost_forecast": services.ForecastScrapingCost(analysis, config.ScrapingConfig.Frequency),
+		},
+	}, 200
+}
+
+// handleGetCostForecast handles GET /api/sources/{id}/cost-forecast
+func handleGetCostForecast(ctx context.Context, sourceID string, queryParams map[string]string) (ResponseBody, int) {
+	analysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source analysis: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Analysis not found",
+		}, 404
+	}
+
+	// ?frequency=daily|weekly|... lets admins compare schedules before activating
+	forecast := services.ForecastScrapingCost(analysis, queryParams["frequency"])
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Estimated monthly cost: $%.2f", forecast.TotalCostPerMonth),
+		Data:    forecast,
+	}, 200
+}
+
+// handlePauseSource handles PUT /api/sources/{id}/pause. The source stops being scheduled and
+// its tasks that have not started yet are cancelled; a task already running is left to finish.
+func handlePauseSource(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
+	// The pause reason is optional
+	var req SourcePauseRequest
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid request body: " + err.Error(),
+			}, 400
+		}
+	}
+
+	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+	if submission.Status != models.SourceStatusActive {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Only active sources can be paused (status: %s)", submission.Status),
+		}, 409
+	}
+
+	if err := dynamoService.SetSourceStatus(ctx, sourceID, models.SourceStatusActive, models.SourceStatusPaused, req.Reason); err != nil {
+		if errors.Is(err, services.ErrSourceStatusChanged) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Source status changed while pausing, reload and try again",
+			}, 409
+		}
+		log.Printf("Error pausing source %s: %v", sourceID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to pause source",
+		}, 500
+	}
+
+	// The source is already paused, so a failure here leaves tasks the executor will skip
+	cancelledTasks, err := dynamoService.CancelPendingTasksForSource(ctx, sourceID)
+	if err != nil {
+		log.Printf("Warning: Paused source %s but failed to cancel all pending tasks: %v", sourceID, err)
+	}
+	log.Printf("Paused source %s (%s), cancelled %d pending tasks", sourceID, submission.SourceName, len(cancelledTasks))
+
+	return ResponseBody{
+		Success: true,
+		Message: "Source paused successfully",
+		Data: map[string]interface{}{
+			"source_id":       sourceID,
+			"status":          models.SourceStatusPaused,
+			"reason":          req.Reason,
+			"cancelled_tasks": cancelledTasks,
+		},
+	}, 200
+}
+
+// handleResumeSource handles PUT /api/sources/{id}/resume. The source is scheduled again from the
+// next orchestrator run; tasks cancelled by the pause are not restored.
+func handleResumeSource(ctx context.Context, sourceID string) (ResponseBody, int) {
+	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+	if submission.Status != models.SourceStatusPaused {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Only paused sources can be resumed (status: %s)", submission.Status),
+		}, 409
+	}
+
+	if err := dynamoService.SetSourceStatus(ctx, sourceID, models.SourceStatusPaused, models.SourceStatusActive, ""); err != nil {
+		if errors.Is(err, services.ErrSourceStatusChanged) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Source status changed while resuming, reload and try again",
+			}, 409
+		}
+		log.Printf("Error resuming source %s: %v", sourceID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to resume source",
+		}, 500
+	}
+	log.Printf("Resumed source %s (%s)", sourceID, submission.SourceName)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Source resumed successfully",
+		Data: map[string]string{
+			"source_id": sourceID,
+			"status":    models.SourceStatusActive,
+		},
+	}, 200
+}
+
+// handleRejectSource handles PUT /api/sources/{id}/reject
+func handleRejectSource(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
+	// Update source submission status to rejected
+	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source submission not found",
+		}, 404
+	}
+
+	// The rejection reason is optional
+	var req SourceRejectionRequest
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid request body: " + err.Error(),
+			}, 400
+		}
+	}
+
+	submission.Status = models.SourceStatusRejected
+	submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusRejected, submission.SourceID)
+	submission.DenialReason = req.Reason
+
+	if err := dynamoService.UpdateSourceSubmission(ctx, submission); err != nil {
+		log.Printf("Error updating source submission: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to reject source",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Source rejected successfully",
+		Data: map[string]string{
+			"source_id": sourceID,
+			"status":    "rejected",
+		},
+	}, 200
+}
+
+// handleDeleteSource handles DELETE /api/sources/{id}
+func handleDeleteSource(ctx context.Context, sourceID string) (ResponseBody, int) {
+	// Validate source ID
+	if sourceID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source ID is required",
+		}, 400
+	}
+
+	log.Printf("Delete request for source: %s", sourceID)
+
+	// Verify source exists before attempting deletion
+	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source submission for deletion: %v", err)
+		
+		// Log failed deletion attempt
+		if logErr := logSourceDeletionEvent(ctx, sourceID, "Unknown Source", "", nil, false, err.Error()); logErr != nil {
+			log.Printf("Error logging failed deletion attempt: %v", logErr)
+		}
+		
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+
+	// Call DynamoDB service deletion method
+	deletionResult, err := dynamoService.DeleteSourceCompletely(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error deleting source %s: %v", sourceID, err)
+		
+		// Log failed deletion attempt
+		if logErr := logSourceDeletionEvent(ctx, sourceID, sourceSubmission.SourceName, sourceSubmission.BaseURL, nil, false, err.Error()); logErr != nil {
+			log.Printf("Error logging failed deletion attempt: %v", logErr)
+		}
+		
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to delete source: " + err.Error(),
+		}, 500
+	}
+
+	// Log successful deletion
+	if logErr := logSourceDeletionEvent(ctx, sourceID, sourceSubmission.SourceName, sourceSubmission.BaseURL, deletionResult, true, ""); logErr != nil {
+		log.Printf("Error logging successful deletion: %v", logErr)
+		// Don't fail the request if logging fails
+	}
+
+	// Format response with deletion results
+	responseData := map[string]interface{}{
+		"source_id": sourceID,
+		"source_name": sourceSubmission.SourceName,
+		"deleted_records": map[string]interface{}{
+			"submission": deletionResult.SubmissionDeleted,
+			"analysis":   deletionResult.AnalysisDeleted,
+			"analysis_versions_count": deletionResult.AnalysisVersionsDeleted,
+			"config":     deletionResult.ConfigDeleted,
+			"activities_count": deletionResult.ActivitiesDeleted,
+		},
+		"total_records_deleted": deletionResult.TotalRecords,
+	}
+
+	log.Printf("Successfully deleted source %s - %d total records removed", sourceID, deletionResult.TotalRecords)
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Source '%s' deleted successfully", sourceSubmission.SourceName),
+		Data:    responseData,
+	}, 200
+}
+
+// logSourceDeletionEvent logs a source deletion event to the admin events table
+func logSourceDeletionEvent(ctx context.Context, sourceID, sourceName, sourceURL string, deletionResult *models.DeletionResult, success bool, errorMessage string) error {
+	eventID := uuid.New().String()
+	
+	// Create deletion event
+	deletionEvent := &models.SourceDeletionEvent{
+		EventType:  models.AdminEventTypeDeletion,
+		EventID:    eventID,
+		AdminUser:  "admin", // TODO: Get actual admin user from context/auth
 		SourceID:   sourceID,
-		SourceName: submission.SourceName,
-		SourceType: submission.SourceType,
-		BaseURL:    submission.BaseURL,
-		TargetURLs: analysis.RecommendedConfig.TargetURLs,
-		ContentSelectors: analysis.RecommendedConfig.BestSelectors,
-		ScrapingConfig: models.DynamoScrapingConfig{
-			Frequency:         analysis.RecommendedConfig.ScrapingFrequency,
-			Priority:          "medium",
-			RateLimit:         analysis.RecommendedConfig.RateLimit,
-			UserAgent:         "SeattleFamilyActivities/1.0",
-			RespectRobotsTxt:  true,
-			Timeout:           30,
-			MaxRetries:        3,
-			BackoffMultiplier: 2.0,
+		SourceName: sourceName,
+		SourceURL:  sourceURL,
+		Success:    success,
+		ErrorMessage: errorMessage,
+	}
+	
+	// Set deletion data if available
+	if deletionResult != nil {
+		deletionEvent.DeletionData = *deletionResult
+	} else {
+		// Create empty deletion result for failed attempts
+		deletionEvent.DeletionData = models.DeletionResult{
+			SourceID:          sourceID,
+			SubmissionDeleted: false,
+			AnalysisDeleted:   false,
+			ConfigDeleted:     false,
+			ActivitiesDeleted: 0,
+			TotalRecords:      0,
+		}
+	}
+	
+	// Store the deletion event
+	return dynamoService.CreateSourceDeletionEvent(ctx, deletionEvent)
+}
+
+// analyticsResponse is the source analytics along with the live queue depth and the stored
+// public conversion counts
+type analyticsResponse struct {
+	*models.SourceAnalytics
+	QueueDepth       map[string]*services.QueueDepth   `json:"queue_depth,omitempty"`
+	PublicConversion []services.PublicConversionStats `json:"public_conversion"`
+}
+
+// handleGetAnalytics handles GET /api/analytics?range=7d
+// It summarizes source counts, source approval rates, scrape success rates and per-source activity
+// yield over the range, which is written in hours or days (e.g. 24h, 30d) and defaults to 7d.
+func handleGetAnalytics(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	rangeParam := queryParams["range"]
+	if rangeParam == "" {
+		rangeParam = models.DefaultAnalyticsRange
+	}
+	window, err := models.ParseAnalyticsRange(rangeParam)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
+	}
+	now := time.Now().UTC()
+	from := now.Add(-window)
+
+	sources, err := dynamoService.GetAllSourceSubmissions(ctx)
+	if err != nil {
+		log.Printf("Error getting source submissions: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve analytics",
+		}, 500
+	}
+	runs, err := dynamoService.GetFanOutRunsStartedBetween(ctx, from, now)
+	if err != nil {
+		log.Printf("Error getting fan-out runs since %s: %v", from.Format(time.RFC3339), err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve analytics",
+		}, 500
+	}
+
+	conversionCounts, err := dynamoService.GetPublicConversionCounts(ctx)
+	if err != nil {
+		log.Printf("Error getting public conversion counts: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve analytics",
+		}, 500
+	}
+
+	analytics := services.BuildSourceAnalytics(sources, runs, from, now, now)
+	analytics.Range = strings.ToLower(strings.TrimSpace(rangeParam))
+
+	response := analyticsResponse{
+		SourceAnalytics:  analytics,
+		PublicConversion: services.BuildPublicConversionStats(conversionCounts),
+	}
+	if len(taskQueueURLs) > 0 {
+		response.QueueDepth = getTaskQueueDepths(ctx)
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Analytics retrieved successfully",
+		Data:    response,
+	}, 200
+}
+
+// systemErrorRateWindow is how far back Lambda error rates are summed on the system load overview
+const systemErrorRateWindow = time.Hour
+
+// handleGetSystemAnalytics handles GET /api/analytics/system
+// It reports queue depths, task load, recent Lambda error rates, today's usage and how long events
+// have waited for review in one overview.
+// A part that cannot be read is listed under errors instead of failing the whole request.
+func handleGetSystemAnalytics(ctx context.Context) (ResponseBody, int) {
+	now := time.Now().UTC()
+	overview := map[string]interface{}{
+		"generated_at": now,
+	}
+	var failures []string
+
+	depths := getTaskQueueDepths(ctx)
+	overview["queue_depth"] = depths
+	if len(depths) < len(taskQueueURLs) {
+		failures = append(failures, "queue_depth: some queues could not be read")
+	}
+
+	if load, err := dynamoService.GetScrapingTaskLoad(ctx, now); err != nil {
+		log.Printf("Error getting scraping task load: %v", err)
+		failures = append(failures, "task_load: "+err.Error())
+	} else {
+		overview["task_load"] = load
+	}
+
+	if rates, err := cloudWatchClient.GetLambdaErrorRates(ctx, monitoredFunctionNames, systemErrorRateWindow); err != nil {
+		log.Printf("Error getting Lambda error rates: %v", err)
+		failures = append(failures, "lambda_errors: "+err.Error())
+	} else {
+		overview["lambda_errors"] = map[string]interface{}{
+			"window":    systemErrorRateWindow.String(),
+			"functions": rates,
+		}
+	}
+
+	today := now.Truncate(24 * time.Hour)
+	if runs, err := dynamoService.GetFanOutRunsStartedBetween(ctx, today, today.AddDate(0, 0, 1)); err != nil {
+		log.Printf("Error getting today's fan-out runs: %v", err)
+		failures = append(failures, "usage_today: "+err.Error())
+	} else {
+		overview["usage_today"] = services.SummarizeDailyUsage(today.Format("2006-01-02"), runs)
+	}
+
+	if pending, err := dynamoService.GetPendingAdminEventsMatching(ctx, "", ""); err != nil {
+		log.Printf("Error getting pending admin events: %v", err)
+		failures = append(failures, "review_queue: "+err.Error())
+	} else {
+		overview["review_queue"] = services.MeasureReviewQueue(pending, reviewLatencySLO, now)
+	}
+
+	if flags, err := dynamoService.GetFeatureFlagSettings(ctx); err != nil {
+		log.Printf("Error getting feature flags: %v", err)
+		failures = append(failures, "feature_flags: "+err.Error())
+	} else {
+		overview["feature_flags"] = flags.States()
+	}
+
+	// Only covers the calls this Lambda instance made, including the ones above
+	overview["dynamodb"] = services.GetDynamoDBTelemetry().Summary(now)
+
+	if len(failures) > 0 {
+		overview["errors"] = failures
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "System analytics retrieved successfully",
+		Data:    overview,
+	}, 200
+}
+
+// getTaskQueueDepths returns the approximate backlog of each scrape task queue by priority.
+// Queues whose depth cannot be read are left out.
+func getTaskQueueDepths(ctx context.Context) map[string]*services.QueueDepth {
+	depths := make(map[string]*services.QueueDepth)
+	for priority, queueURL := range taskQueueURLs {
+		depth, err := sqsClient.GetQueueDepth(ctx, queueURL)
+		if err != nil {
+			log.Printf("Warning: Failed to get %s-priority queue depth: %v", priority, err)
+			continue
+		}
+		depths[priority] = depth
+	}
+	return depths
+}
+
+// Helper functions
+
+func generateSourceID(sourceName string) string {
+	// Create a URL-safe ID from source name + UUID
+	baseID := strings.ToLower(sourceName)
+	baseID = strings.ReplaceAll(baseID, " ", "-")
+	baseID = strings.ReplaceAll(baseID, "&", "and")
+	
+	// Remove special characters
+	var cleanID strings.Builder
+	for _, r := range baseID {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			cleanID.WriteRune(r)
+		}
+	}
+	
+	// Add UUID suffix to ensure uniqueness
+	shortUUID := uuid.New().String()[:8]
+	return cleanID.String() + "-" + shortUUID
+}
+
+func triggerSourceAnalyzer(ctx context.Context, sourceID string) error {
+	if isSandboxRequest(ctx) {
+		return completeSandboxAnalysis(ctx, sourceID)
+	}
+
+	payload := map[string]interface{}{
+		"source_id":    sourceID,
+		"trigger_type": "automatic",
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
+		FunctionName:   aws.String(sourceAnalyzerFunctionName),
+		InvocationType: "Event", // Async invocation
+		Payload:        payloadBytes,
+	})
+
+	return err
+}
+
+// completeSandboxAnalysis stands in for the source analyzer in the sandbox, which would crawl the
+// source and write to the production tables: the source gets a canned analysis right away
+func completeSandboxAnalysis(ctx context.Context, sourceID string) error {
+	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get source submission: %w", err)
+	}
+	if err := dynamoService.CreateSourceAnalysis(ctx, services.SandboxAnalysis(submission)); err != nil {
+		return err
+	}
+	submission.Status = models.SourceStatusAnalysisComplete
+	return dynamoService.UpdateSourceSubmission(ctx, submission)
+}
+
+func createSourceConfigFromAnalysis(ctx context.Context, sourceID string, analysis *models.SourceAnalysis, adminNotes string) (*models.DynamoSourceConfig, error) {
+	// Get the original source submission to populate fields
+	submission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source submission: %w", err)
+	}
+
+	now := time.Now()
+	
+	return &models.DynamoSourceConfig{
+		PK:         models.CreateSourcePK(sourceID),
+		SK:         models.CreateSourceConfigSK(),
+		SourceID:   sourceID,
+		SourceName: submission.SourceName,
+		SourceType: submission.SourceType,
+		BaseURL:    submission.BaseURL,
+		TargetURLs: analysis.RecommendedConfig.TargetURLs,
+		ContentSelectors: analysis.RecommendedConfig.BestSelectors,
+		ScrapingConfig: models.DynamoScrapingConfig{
+			Frequency:         analysis.RecommendedConfig.ScrapingFrequency,
+			Priority:          "medium",
+			RateLimit:         analysis.RecommendedConfig.RateLimit,
+			UserAgent:         "SeattleFamilyActivities/1.0",
+			RespectRobotsTxt:  true,
+			Timeout:           30,
+			MaxRetries:        3,
+			BackoffMultiplier: 2.0,
+		},
+		DataQuality: models.DataQuality{
+			ReliabilityScore: analysis.OverallQualityScore,
+			ExpectedItemsRange: models.ItemRange{
+				Min: 5,
+				Max: 50,
+			},
+		},
+		AdaptiveFrequency: models.AdaptiveFrequency{
+			BaseFrequency:    analysis.RecommendedConfig.ScrapingFrequency,
+			CurrentFrequency: analysis.RecommendedConfig.ScrapingFrequency,
+		},
+		Status:       models.SourceStatusActive,
+		ActivatedBy:  "admin",
+		ActivatedAt:  now,
+		LastModified: now,
+		StatusKey:    models.GenerateSourceStatusKey(models.SourceStatusActive, sourceID),
+	}, nil
+}
+
+func createInitialScrapingTask(ctx context.Context, sourceID string, analysis *models.SourceAnalysis) error {
+	taskID := uuid.New().String()
+	now := time.Now()
+
+	task := &models.ScrapingTask{
+		PK:            models.CreateTaskPK(taskID),
+		SK:            models.CreateTaskSK("high", sourceID, taskID),
+		TaskID:        taskID,
+		SourceID:      sourceID,
+		TaskType:      models.TaskTypeFullScrape,
+		Priority:      models.TaskPriorityHigh,
+		ScheduledTime: now.Add(5 * time.Minute), // Schedule 5 minutes from now
+		TargetURLs:    analysis.RecommendedConfig.TargetURLs,
+		ExtractionRules: analysis.RecommendedConfig.BestSelectors,
+		RateLimits:      analysis.RecommendedConfig.RateLimit,
+		Timeout:         300, // 5 minutes
+		MaxRetries:      3,
+		Status:          models.TaskStatusScheduled,
+		RetryCount:      0,
+		EstimatedDuration: 120, // 2 minutes
+		Dependencies:      []string{},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		TTL:               models.CalculateTaskTTL(now, 90), // 90 days retention
+		NextRunShard:      models.GenerateNextRunShardKey(taskID),
+		NextRunKey:        models.GenerateNextRunKey(now.Add(5 * time.Minute)),
+		PrioritySourceKey: models.GenerateTaskPrioritySourceKey("high", sourceID),
+	}
+
+	return dynamoService.CreateScrapingTask(ctx, task)
+}
+
+// handleGetSourceDetails handles GET /api/sources/{id}/details
+func handleGetSourceDetails(ctx context.Context, sourceID string, queryParams map[string]string) (ResponseBody, int) {
+	// Validate source ID
+	if sourceID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source ID is required",
+		}, 400
+	}
+
+	log.Printf("Getting details for source: %s", sourceID)
+
+	// Collect all data for this source
+	sourceDetails := make(map[string]interface{})
+
+	// 1. Get source submission info
+	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source submission: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+	
+	sourceDetails["source_info"] = map[string]interface{}{
+		"source_id":         sourceSubmission.SourceID,
+		"source_name":       sourceSubmission.SourceName,
+		"base_url":          sourceSubmission.BaseURL,
+		"source_type":       sourceSubmission.SourceType,
+		"priority":          sourceSubmission.Priority,
+		"expected_content":  sourceSubmission.ExpectedContent,
+		"hint_urls":         sourceSubmission.HintURLs,
+		"submitted_by":      sourceSubmission.SubmittedBy,
+		"submitted_at":      sourceSubmission.SubmittedAt,
+		"status":            sourceSubmission.Status,
+		"updated_at":        sourceSubmission.UpdatedAt,
+	}
+
+	// 2. Get source analysis (if available)
+	sourceAnalysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
+	if err != nil {
+		log.Printf("No analysis found for source %s: %v", sourceID, err)
+		sourceDetails["analysis"] = nil
+	} else {
+		sourceDetails["analysis"] = map[string]interface{}{
+			"quality_score":         sourceAnalysis.OverallQualityScore,
+			"content_richness":      0.0, // placeholder
+			"extraction_confidence": 0.0, // placeholder
+			"recommended_selectors": sourceAnalysis.RecommendedConfig.BestSelectors,
+			"target_urls":          sourceAnalysis.RecommendedConfig.TargetURLs,
+			"analysis_notes":       "Analysis completed", // placeholder
+			"analyzed_at":          sourceAnalysis.AnalysisCompletedAt,
+		}
+	}
+
+	// 3. Get source configuration (if active)
+	sourceConfig, err := dynamoService.GetSourceConfig(ctx, sourceID)
+	if err != nil {
+		log.Printf("No config found for source %s: %v", sourceID, err)
+		sourceDetails["config"] = nil
+	} else {
+		sourceDetails["config"] = map[string]interface{}{
+			"scraping_frequency":       "daily",
+			"success_rate":             0.0,
+			"total_scrapes":            0,
+			"successful_scrapes":       0,
+			"total_activities_found":   0,
+			"avg_activities_per_scrape": 0.0,
+			"last_scraped":             nil,
+			"content_selectors":        sourceConfig.ContentSelectors,
+			"selector_drift":           sourceConfig.SelectorDrift,
+			"auto_heal_selectors":      sourceConfig.AutoHealSelectors,
+			"is_active":                true,
+		}
+	}
+
+	// 4. Get task history
+	taskLimit, err := queryparams.Int(queryParams, "task_limit", 20, 1, maxPageLimit)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
+	}
+
+	taskHistory, err := dynamoService.GetRecentTasksForSource(ctx, sourceID, taskLimit)
+	if err != nil {
+		log.Printf("Error getting task history for %s: %v", sourceID, err)
+		sourceDetails["task_history"] = []interface{}{}
+	} else {
+		tasks := make([]map[string]interface{}, len(taskHistory))
+		for i, task := range taskHistory {
+			tasks[i] = map[string]interface{}{
+				"task_id":          task.TaskID,
+				"task_type":        task.TaskType,
+				"priority":         task.Priority,
+				"status":           task.Status,
+				"scheduled_time":   task.ScheduledTime,
+				"created_at":       task.CreatedAt,
+				"updated_at":       task.UpdatedAt,
+				"retry_count":      task.RetryCount,
+				"error_message":    task.ErrorMessage,
+				"error_code":       task.ErrorCode,
+				"retryable":        task.Retryable,
+				"estimated_duration": task.EstimatedDuration,
+			}
+		}
+		sourceDetails["task_history"] = tasks
+	}
+
+	// 5. Get performance metrics summary
+	if sourceConfig != nil {
+		sourceDetails["performance"] = map[string]interface{}{
+			"reliability_score":    calculateReliabilityScore(taskHistory),
+			"avg_task_duration":    calculateAvgTaskDuration(taskHistory),
+			"recent_failure_rate":  calculateRecentFailureRate(taskHistory),
+			"last_successful_scrape": getLastSuccessfulScrape(taskHistory),
+			"next_estimated_run":   calculateNextEstimatedRun(sourceConfig, taskHistory),
+		}
+	}
+
+	// 6. Get recent activities extracted (last 50)
+	// This would require a new method to get activities by source
+	// For now, we'll add placeholder
+	sourceDetails["recent_activities"] = map[string]interface{}{
+		"count": 0,
+		"activities": []interface{}{},
+		"note": "Activity extraction details coming soon",
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Source details retrieved successfully",
+		Data:    sourceDetails,
+	}, 200
+}
+
+// Helper functions for source details
+func calculateReliabilityScore(tasks []models.ScrapingTask) float64 {
+	if len(tasks) == 0 {
+		return 0.0
+	}
+	
+	successful := 0
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusCompleted {
+			successful++
+		}
+	}
+	
+	return float64(successful) / float64(len(tasks)) * 100
+}
+
+func calculateAvgTaskDuration(tasks []models.ScrapingTask) int64 {
+	if len(tasks) == 0 {
+		return 0
+	}
+	
+	totalDuration := int64(0)
+	completedTasks := 0
+	
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusCompleted && task.EstimatedDuration > 0 {
+			totalDuration += task.EstimatedDuration
+			completedTasks++
+		}
+	}
+	
+	if completedTasks == 0 {
+		return 0
+	}
+	
+	return totalDuration / int64(completedTasks)
+}
+
+func calculateRecentFailureRate(tasks []models.ScrapingTask) float64 {
+	// Look at last 10 tasks
+	recentTasks := tasks
+	if len(tasks) > 10 {
+		recentTasks = tasks[:10]
+	}
+	
+	if len(recentTasks) == 0 {
+		return 0.0
+	}
+	
+	failed := 0
+	for _, task := range recentTasks {
+		if task.Status == models.TaskStatusFailed {
+			failed++
+		}
+	}
+	
+	return float64(failed) / float64(len(recentTasks)) * 100
+}
+
+func getLastSuccessfulScrape(tasks []models.ScrapingTask) *time.Time {
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusCompleted {
+			return &task.UpdatedAt
+		}
+	}
+	return nil
+}
+
+func calculateNextEstimatedRun(config *models.DynamoSourceConfig, tasks []models.ScrapingTask) *time.Time {
+	// Simple calculation: next run in 24 hours
+	next := time.Now().Add(24 * time.Hour)
+	return &next
+}
+
+// handleTriggerManualScrape handles POST /api/sources/{id}/trigger  
+func handleTriggerManualScrape(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
+	// Validate source ID
+	if sourceID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source ID is required",
+		}, 400
+	}
+
+	// The orchestrator only knows the production tables
+	if isSandboxRequest(ctx) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Scrapes cannot be triggered in the sandbox; use POST /api/crawl/submit to practice extraction",
+		}, 403
+	}
+
+	log.Printf("Manual scrape triggered for source: %s", sourceID)
+
+	// Parse optional request body for task configuration
+	var req struct {
+		TaskType string `json:"task_type,omitempty"` // full_scrape (default), incremental, validation
+		Priority string `json:"priority,omitempty"` // high (default), medium, low  
+		Notes    string `json:"notes,omitempty"`    // admin notes
+	}
+	
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			log.Printf("Invalid request body for manual trigger: %v", err)
+			// Continue with defaults if body is invalid
+		}
+	}
+
+	// Set defaults
+	if req.TaskType == "" {
+		req.TaskType = models.TaskTypeFullScrape
+	}
+	if req.Priority == "" {
+		req.Priority = models.TaskPriorityHigh
+	}
+
+	// Verify source exists and is active
+	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source submission: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+
+	if sourceSubmission.Status != models.SourceStatusActive {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Source is not active (status: %s)", sourceSubmission.Status),
+		}, 400
+	}
+
+	// Get source configuration to build proper task
+	sourceConfig, err := dynamoService.GetSourceConfig(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source config: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source configuration not found - source may not be properly activated",
+		}, 400
+	}
+
+	now := time.Now()
+	triggerState, response, statusCode := checkManualTriggerGuardrails(ctx, sourceID, req.TaskType, now)
+	if statusCode != 0 {
+		return response, statusCode
+	}
+
+	// Create immediate scraping task
+	taskID := uuid.New().String()
+	
+	task := &models.ScrapingTask{
+		PK:            models.CreateTaskPK(taskID),
+		SK:            models.CreateTaskSK(req.Priority, sourceID, taskID),
+		TaskID:        taskID,
+		SourceID:      sourceID,
+		TaskType:      req.TaskType,
+		Priority:      req.Priority,
+		TriggerType:   models.TriggerTypeManual,
+		ScheduledTime: now.Add(1 * time.Minute), // Run in 1 minute
+		TargetURLs:    []string{sourceConfig.BaseURL},
+		ExtractionRules: sourceConfig.ContentSelectors,
+		RateLimits:      sourceConfig.ScrapingConfig.RateLimit,
+		Timeout:         300, // 5 minutes
+		MaxRetries:      2,   // Lower retries for manual tasks
+		Status:          models.TaskStatusScheduled,
+		RetryCount:      0,
+		EstimatedDuration: 120, // 2 minutes
+		Dependencies:      []string{},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		TTL:               models.CalculateTaskTTL(now, 30), // 30 days retention for manual tasks
+		NextRunShard:      models.GenerateNextRunShardKey(taskID),
+		NextRunKey:        models.GenerateNextRunKey(now.Add(1 * time.Minute)),
+		PrioritySourceKey: models.GenerateTaskPrioritySourceKey(req.Priority, sourceID),
+	}
+
+	// Store the task in DynamoDB, counting the trigger against the limits in the same transaction.
+	// Two triggers that both passed the checks race here; only the first is recorded.
+	if err := dynamoService.CreateManualScrapingTask(ctx, triggerState, triggerState.Next(sourceID, now), task, manualTriggerHourlyLimit); err != nil {
+		switch {
+		case errors.Is(err, services.ErrManualTriggerLimitReached):
+			log.Printf("Manual trigger of source %s refused: %d manual triggers this hour", sourceID, manualTriggerHourlyLimit)
+			return manualTriggerLimitedResponse(fmt.Sprintf("The limit of %d manual triggers per hour is reached", manualTriggerHourlyLimit), now.Truncate(time.Hour).Add(time.Hour).Sub(now))
+		case errors.Is(err, services.ErrManualTriggerConflict):
+			return manualTriggerLimitedResponse("Source was just triggered", models.ManualTriggerBaseCooldown)
+		}
+		log.Printf("Error creating manual scraping task: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create scraping task",
+		}, 500
+	}
+
+	// Trigger the orchestrator to process the new task immediately
+	// We can invoke the orchestrator Lambda directly for immediate processing
+	if err := triggerOrchestratorForSource(ctx, sourceID, req.TaskType, taskID); err != nil {
+		log.Printf("Error triggering orchestrator: %v", err)
+		// Don't fail the request - task is created, orchestrator will pick it up on next run
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Manual scrape triggered successfully",
+		Data: map[string]interface{}{
+			"task_id":        taskID,
+			"source_id":      sourceID,
+			"task_type":      req.TaskType,
+			"priority":       req.Priority,
+			"scheduled_for":  task.ScheduledTime,
+			"estimated_completion": now.Add(time.Duration(task.EstimatedDuration) * time.Second),
+		},
+	}, 201
+}
+
+// checkManualTriggerGuardrails decides whether a source may be triggered manually at now, and
+// returns the source's manual trigger state to record the trigger against. It returns a zero
+// status code when the trigger may go ahead. A pending manual task of the same type is answered
+// with 409 and its ID; a source still cooling down from its last trigger with 429. The hourly
+// limit shared by all sources is checked when the task is created.
+func checkManualTriggerGuardrails(ctx context.Context, sourceID, taskType string, now time.Time) (*models.ManualTriggerState, ResponseBody, int) {
+	// The state is read before the pending tasks: a trigger recorded after this read fails the
+	// task's transaction, and one recorded before it left a task the pending check finds
+	state, err := dynamoService.GetManualTriggerState(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting manual trigger state for source %s: %v", sourceID, err)
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Failed to check manual trigger limits",
+		}, 500
+	}
+
+	pending, err := dynamoService.GetPendingTasksForSource(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting pending tasks for source %s: %v", sourceID, err)
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Failed to check pending tasks",
+		}, 500
+	}
+	for _, task := range pending {
+		if task.TriggerType == models.TriggerTypeManual && task.TaskType == taskType {
+			return nil, ResponseBody{
+				Success: false,
+				Error:   "Task already queued",
+				Data: map[string]interface{}{
+					"task_id":        task.TaskID,
+					"status":         task.Status,
+					"scheduled_time": task.ScheduledTime,
+				},
+			}, 409
+		}
+	}
+
+	if wait := state.RetryAfter(now); wait > 0 {
+		response, statusCode := manualTriggerLimitedResponse(fmt.Sprintf("Source was triggered %d times recently; wait before triggering it again", state.Triggers), wait)
+		return nil, response, statusCode
+	}
+	return state, ResponseBody{}, 0
+}
+
+// manualTriggerLimitedResponse refuses a manual trigger with 429 and how long to wait
+func manualTriggerLimitedResponse(message string, wait time.Duration) (ResponseBody, int) {
+	return ResponseBody{
+		Success: false,
+		Error:   message,
+		Data: map[string]interface{}{
+			"retry_after_seconds": int(math.Ceil(wait.Seconds())),
+		},
+	}, 429
+}
+
+// triggerOrchestratorForSource invokes the orchestrator Lambda for immediate processing.
+// The run is linked to the scraping task so cancelling the task stops the run.
+func triggerOrchestratorForSource(ctx context.Context, sourceID, taskType, taskID string) error {
+	// Get orchestrator function name from environment
+	orchestratorFunctionName := os.Getenv("ORCHESTRATOR_FUNCTION_NAME")
+	if orchestratorFunctionName == "" {
+		return fmt.Errorf("ORCHESTRATOR_FUNCTION_NAME not configured")
+	}
+
+	// Create event payload for orchestrator
+	event := map[string]interface{}{
+		"trigger_type": "manual",
+		"source_id":    sourceID,
+		"task_type":    taskType,
+		"task_id":      taskID,
+	}
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal orchestrator event: %w", err)
+	}
+
+	// Invoke orchestrator Lambda asynchronously
+	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
+		FunctionName:   aws.String(orchestratorFunctionName),
+		InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
+		Payload:        eventBytes,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to invoke orchestrator: %w", err)
+	}
+
+	log.Printf("Successfully triggered orchestrator for source %s", sourceID)
+	return nil
+}
+
+// handlePreviewSourceDiff handles POST /api/sources/{id}/preview-diff
+func handlePreviewSourceDiff(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
+	if sourceID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Source ID is required",
+		}, 400
+	}
+
+	if firecrawlService == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Firecrawl service not available",
+		}, 500
+	}
+
+	// Optional body lets admins preview specific URLs instead of the configured targets
+	var req struct {
+		URLs []string `json:"urls,omitempty"`
+	}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid request body: " + err.Error(),
+			}, 400
+		}
+	}
+
+	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	if err != nil {
+		log.Printf("Error getting source submission: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Source not found",
+		}, 404
+	}
+
+	// Use the same target URLs as the orchestrator
+	targetURLs := req.URLs
+	if len(targetURLs) == 0 {
+		targetURLs = sourceSubmission.HintURLs
+	}
+	if len(targetURLs) == 0 {
+		targetURLs = []string{sourceSubmission.BaseURL}
+	}
+
+	// Dry-run scrape - nothing is stored
+	var scraped []models.Activity
+	var scrapeErrors []string
+	for _, targetURL := range targetURLs {
+		extractResponse, err := firecrawlService.ExtractActivities(targetURL)
+		if err != nil {
+			log.Printf("Error during preview scrape of %s: %v", targetURL, err)
+			scrapeErrors = append(scrapeErrors, fmt.Sprintf("%s: %v", targetURL, err))
+			continue
+		}
+		scraped = append(scraped, extractResponse.Data.Activities...)
+	}
+	flushFireCrawlStats()
+	recordCanaryScrape(ctx, sourceSubmission, targetURLs, len(scraped), scrapeErrors)
+
+	if len(scrapeErrors) == len(targetURLs) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Preview scrape failed for all target URLs",
+			Data:    map[string]interface{}{"errors": scrapeErrors},
+		}, 502
+	}
+
+	published, err := getPublishedActivitiesForSource(ctx, sourceSubmission.BaseURL)
+	if err != nil {
+		log.Printf("Error loading published activities for source %s: %v", sourceID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to load published activities",
+		}, 500
+	}
+
+	diff := services.DiffActivities(published, scraped)
+
+	data := map[string]interface{}{
+		"source_id":   sourceID,
+		"source_name": sourceSubmission.SourceName,
+		"target_urls": targetURLs,
+		"summary": map[string]interface{}{
+			"published": len(published),
+			"scraped":   len(scraped),
+			"new":       len(diff.New),
+			"removed":   len(diff.Removed),
+			"changed":   len(diff.Changed),
+			"unchanged": diff.Unchanged,
+		},
+		"diff": diff,
+	}
+	if len(scrapeErrors) > 0 {
+		data["errors"] = scrapeErrors
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Preview found %d new, %d removed and %d changed activities", len(diff.New), len(diff.Removed), len(diff.Changed)),
+		Data:    data,
+	}, 200
+}
+
+// getPublishedActivitiesForSource returns approved activities whose source URL is on the same domain as baseURL
+func getPublishedActivitiesForSource(ctx context.Context, baseURL string) ([]models.Activity, error) {
+	approvedEvents, err := dynamoService.GetApprovedAdminEvents(ctx, 500)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceHost := urlutil.Domain(baseURL)
+	var activities []models.Activity
+	for _, event := range approvedEvents {
+		if sourceHost == "" || urlutil.Domain(event.SourceURL) != sourceHost {
+			continue
+		}
+
+		conversionResult, err := conversionService.ConvertToActivity(&event)
+		if err != nil || conversionResult.Activity == nil {
+			log.Printf("Skipping approved event %s in preview diff: could not convert to activity", event.EventID)
+			continue
+		}
+		activities = append(activities, *conversionResult.Activity)
+	}
+
+	return activities, nil
+}
+
+// Admin Crawling Handler Functions
+
+// extractionErrorResponse describes a failed FireCrawl extraction. FireCrawl API errors get the
+// error code and a status saying whose problem it is: 429 with retry_after_seconds when rate
+// limited, 422 for sites FireCrawl will not scrape, and 503 when the account is out of credits or
+// its key was rejected. Other failures are a 500.
+func extractionErrorResponse(err error) (ResponseBody, int) {
+	response := ResponseBody{
+		Success: false,
+		Error:   "Failed to extract data from URL: " + err.Error(),
+	}
+	var apiErr *services.FireCrawlAPIError
+	if !errors.As(err, &apiErr) {
+		return response, 500
+	}
+
+	data := map[string]interface{}{"error_code": apiErr.Code}
+	if apiErr.RetryAfter > 0 {
+		data["retry_after_seconds"] = int(apiErr.RetryAfter / time.Second)
+	}
+	response.Data = data
+
+	switch apiErr.Code {
+	case models.ErrorCodeRateLimited:
+		return response, 429
+	case models.ErrorCodeUnsupportedURL:
+		return response, 422
+	case models.ErrorCodeQuotaExhausted, models.ErrorCodeInvalidAPIKey:
+		return response, 503
+	}
+	return response, 500
+}
+
+// Time allocated to the steps of a crawl submission. A submission is answered within the API
+// Gateway timeout; one whose extraction will not finish in time is deferred to a background
+// invocation, which has the rest of the Lambda timeout.
+const (
+	crawlRequestBudget           = services.APIGatewayTimeout - time.Second // leaves time to send the response
+	crawlLookupTimeout           = 3 * time.Second                          // duplicate checks
+	crawlMinExtractionAllocation = 10 * time.Second                         // least time worth waiting for an extraction
+	crawlConversionAllocation    = 3 * time.Second
+	crawlStorageAllocation       = 4 * time.Second
+	crawlFinishAllocation        = crawlConversionAllocation + crawlStorageAllocation
+	crawlCleanupTimeout          = 10 * time.Second
+)
+
+// deferredCrawlEvent is the payload this function invokes itself with to finish a crawl
+// submission in the background
+type deferredCrawlEvent struct {
+	DeferredCrawlSubmission *models.CrawlSubmissionRequest `json:"deferred_crawl_submission"`
+	Sandbox                 bool                           `json:"sandbox,omitempty"` // submitted with a sandbox key
+}
+
+// handleCrawlSubmission handles POST /api/crawl/submit
+func handleCrawlSubmission(ctx context.Context, body string) (ResponseBody, int) {
+	if firecrawlService == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Firecrawl service not available",
+		}, 500
+	}
+
+	var req models.CrawlSubmissionRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	// Validate the request
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	if decision := domainPolicy.Check(req.URL); !decision.Allowed {
+		log.Printf("Crawl of %s by %s denied by domain policy: %s", req.URL, req.ExtractedByUser, decision.Reason)
+		return ResponseBody{
+			Success: false,
+			Error:   "URL domain is not allowed: " + decision.Reason,
+			Data: map[string]interface{}{
+				"domain_decision": decision,
+			},
+		}, 403
+	}
+
+	budget := services.NewDeadlineBudget(ctx, crawlRequestBudget)
+	lookupCtx, cancel := budget.Context(ctx, crawlLookupTimeout)
+	defer cancel()
+
+	// Check for duplicate URLs in pending/approved admin events
+	existingEvent, err := dynamoService.GetAdminEventByURL(lookupCtx, req.URL)
+	if err == nil && existingEvent != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("URL already exists with status: %s. Event ID: %s", existingEvent.Status, existingEvent.EventID),
+		}, 409 // Conflict
+	}
+
+	// Check if URL is already configured as a source
+	existingSource, err := dynamoService.GetSourceByURL(lookupCtx, req.URL)
+	if err == nil && existingSource != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("URL already exists as source: %s (ID: %s)", existingSource.SourceName, existingSource.SourceID),
+		}, 409 // Conflict
+	}
+
+	// Clients may supply a job ID up front so they can subscribe to progress before submitting
+	if req.JobID == "" {
+		req.JobID = uuid.New().String()
+	}
+
+	return runCrawlSubmission(ctx, req, budget, true)
+}
+
+// handleDeferredCrawlSubmission finishes a crawl submission that did not fit in its request's
+// deadline budget, with the rest of this invocation's time. The client follows it through the
+// job's progress.
+func handleDeferredCrawlSubmission(ctx context.Context, req models.CrawlSubmissionRequest) {
+	if firecrawlService == nil {
+		log.Printf("Error finishing deferred crawl submission of %s: Firecrawl service not available", req.URL)
+		progressReporter.Report(ctx, req.JobID, models.ProgressStageFailed, "Firecrawl service not available", nil)
+		return
+	}
+
+	log.Printf("Finishing deferred crawl submission of %s for job %s", req.URL, req.JobID)
+	response, statusCode := runCrawlSubmission(ctx, req, services.NewDeadlineBudget(ctx, 0), false)
+	if !response.Success {
+		log.Printf("Deferred crawl submission of %s for job %s failed with %d: %s", req.URL, req.JobID, statusCode, response.Error)
+	}
+}
+
+// runCrawlSubmission extracts the events of a validated crawl submission and stores them for
+// review, giving each step its share of budget. When canDefer is set, a submission whose
+// extraction will not finish in time is handed to a background invocation and answered with
+// 202. Events are only stored when every one of them can be; a failed write removes the ones
+// already written.
+func runCrawlSubmission(ctx context.Context, req models.CrawlSubmissionRequest, budget *services.DeadlineBudget, canDefer bool) (ResponseBody, int) {
+	jobID := req.JobID
+	if !budget.Fits(crawlMinExtractionAllocation, crawlFinishAllocation) {
+		if canDefer {
+			return deferCrawlSubmission(ctx, req)
+		}
+		progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Not enough time left to extract events", nil)
+		return ResponseBody{
+			Success: false,
+			Error:   "Not enough time left to extract events",
+		}, 503
+	}
+
+	// Create firecrawl extract request
+	extractRequest := services.AdminExtractRequest{
+		URL:          req.URL,
+		SchemaType:   req.SchemaType,
+		CustomSchema: req.CustomSchema,
+	}
+
+	// Perform extraction, keeping enough of the budget to convert and store the events
+	extractResponse, finished, err := extractWithinBudget(ctx, extractRequest, jobID, budget.Remaining()-crawlFinishAllocation)
+	flushFireCrawlStats()
+	if !finished {
+		if canDefer {
+			log.Printf("Extraction of %s for job %s is taking longer than the request allows, deferring it", req.URL, jobID)
+			return deferCrawlSubmission(ctx, req)
+		}
+		log.Printf("Error extracting with Firecrawl: %s did not finish in time", req.URL)
+		progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Extraction did not finish in time", nil)
+		return ResponseBody{
+			Success: false,
+			Error:   "Extraction did not finish in time",
+		}, 504
+	}
+	if err != nil {
+		log.Printf("Error extracting with Firecrawl: %v", err)
+		progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Extraction failed: "+err.Error(), nil)
+		return extractionErrorResponse(err)
+	}
+
+	if !extractResponse.Success {
+		progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Extraction was not successful", nil)
+		return ResponseBody{
+			Success: false,
+			Error:   "Extraction was not successful",
+		}, 500
+	}
+
+	// Each extracted event becomes its own admin event, grouped under the submission
+	submission := &models.CrawlSubmission{
+		SubmissionID:    uuid.New().String(),
+		URL:             req.URL,
+		SchemaType:      req.SchemaType,
+		ExtractedByUser: req.ExtractedByUser,
+		AdminNotes:      req.AdminNotes,
+		JobID:           jobID,
+		CreditsUsed:     extractResponse.CreditsUsed,
+	}
+	eventsData := models.SplitExtractedEvents(req.SchemaType, extractResponse.RawData)
+
+	progressReporter.Report(ctx, jobID, models.ProgressStageConverting, "Converting extracted data to activities", nil)
+	conversionCtx, cancelConversion := budget.Context(ctx, crawlConversionAllocation)
+	defer cancelConversion()
+	adminEvents := make([]*models.AdminEvent, 0, len(eventsData))
+	conversionDiagnostics := make([]*services.ConversionDiagnostics, 0, len(eventsData))
+	for _, eventData := range eventsData {
+		adminEvent := &models.AdminEvent{
+			EventID:          uuid.New().String(),
+			SourceURL:        req.URL,
+			SchemaType:       req.SchemaType,
+			SchemaUsed:       extractResponse.SchemaUsed,
+			RawExtractedData: eventData,
+			Status:           models.AdminEventStatusPending,
+			ExtractedByUser:  req.ExtractedByUser,
+			SubmissionID:     submission.SubmissionID,
+			AdminNotes:       req.AdminNotes,
+		}
+
+		// Generate conversion preview
+		conversionResult, diagnostics, err := conversionService.ConvertToActivityWithDiagnostics(adminEvent)
+		if err != nil {
+			log.Printf("Error generating conversion preview: %v", err)
+			// Continue without preview - admin can still review raw data
+		} else {
+			// Store conversion preview and issues
+			if conversionResult.Activity != nil {
+				activityJSON, _ := json.Marshal(conversionResult.Activity)
+				var activityMap map[string]interface{}
+				json.Unmarshal(activityJSON, &activityMap)
+				adminEvent.ConvertedData = activityMap
+			}
+			adminEvent.ConversionIssues = append(conversionResult.Issues, scheduleConflictWarnings(conversionCtx, adminEvent, conversionResult.Activity)...)
+			adminEvent.ConfidenceScore = conversionResult.ConfidenceScore
+			adminEvent.CompletenessScore = services.CompletenessScore(conversionResult.Activity)
+		}
+
+		adminEvents = append(adminEvents, adminEvent)
+		conversionDiagnostics = append(conversionDiagnostics, diagnostics)
+		submission.EventIDs = append(submission.EventIDs, adminEvent.EventID)
+	}
+
+	// Store in DynamoDB
+	progressReporter.Report(ctx, jobID, models.ProgressStageValidating, "Validating and storing extracted events", nil)
+	storageCtx, cancelStorage := budget.Context(ctx, crawlStorageAllocation)
+	defer cancelStorage()
+	for i, adminEvent := range adminEvents {
+		if err := dynamoService.CreateAdminEvent(storageCtx, adminEvent); err != nil {
+			log.Printf("Error storing admin event: %v", err)
+			removeStoredAdminEvents(ctx, adminEvents[:i])
+			progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Failed to store extracted events", nil)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to store extracted events",
+			}, 500
+		}
+	}
+	if err := dynamoService.CreateCrawlSubmission(storageCtx, submission); err != nil {
+		// The events are stored and can still be reviewed one by one
+		log.Printf("Warning: Failed to store crawl submission %s: %v", submission.SubmissionID, err)
+	}
+
+	// Diagnostics and the source record are best effort, with what is left of the budget
+	followUpCtx, cancelFollowUp := budget.Context(ctx, 0)
+	defer cancelFollowUp()
+
+	// Persist diagnostics so they can be reviewed later via /api/events/{id}/diagnostics
+	for i, adminEvent := range adminEvents {
+		if extractResponse.Diagnostics != nil {
+			persistDiagnostics(followUpCtx, adminEvent.EventID, models.DiagnosticsStageExtraction, req.URL, extractResponse.Diagnostics.Success, extractResponse.Diagnostics)
+		}
+		if conversionDiagnostics[i] != nil {
+			persistDiagnostics(followUpCtx, adminEvent.EventID, models.DiagnosticsStageConversion, req.URL, conversionDiagnostics[i].Success, conversionDiagnostics[i])
+		}
+	}
+
+	// Create or update source record if extraction was successful
+	err = createOrUpdateSourceRecord(followUpCtx, req, extractResponse.EventsCount)
+	if err != nil {
+		log.Printf("Warning: Failed to create/update source record: %v", err)
+		// Don't fail the entire request for source management issues
+	}
+
+	progressReporter.Report(ctx, jobID, models.ProgressStageCompleted, fmt.Sprintf("Extracted %d events", extractResponse.EventsCount), map[string]interface{}{
+		"submission_id": submission.SubmissionID,
+		"event_id":      submission.EventIDs[0],
+		"events_count":  extractResponse.EventsCount,
+	})
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Successfully extracted %d events from URL", extractResponse.EventsCount),
+		Data: map[string]interface{}{
+			"job_id":          jobID,
+			"submission_id":   submission.SubmissionID,
+			"event_id":        submission.EventIDs[0],
+			"event_ids":       submission.EventIDs,
+			"events_count":    extractResponse.EventsCount,
+			"credits_used":    extractResponse.CreditsUsed,
+			"processing_time": extractResponse.Metadata.ProcessingTime.String(),
+		},
+	}, 201
+}
+
+// deferCrawlSubmission hands a crawl submission to an asynchronous invocation of this function
+// and answers 202 with the job ID to follow it by
+func deferCrawlSubmission(ctx context.Context, req models.CrawlSubmissionRequest) (ResponseBody, int) {
+	payload, err := json.Marshal(deferredCrawlEvent{DeferredCrawlSubmission: &req, Sandbox: isSandboxRequest(ctx)})
+	if err == nil {
+		// The invocation is queued by Lambda, so it must not be cut short by the spent budget
+		invokeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), crawlCleanupTimeout)
+		defer cancel()
+		_, err = lambdaClient.Invoke(invokeCtx, &lambdaclient.InvokeInput{
+			FunctionName:   aws.String(adminAPIFunctionName),
+			InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
+			Payload:        payload,
+		})
+	}
+	if err != nil {
+		log.Printf("Error deferring crawl submission of %s for job %s: %v", req.URL, req.JobID, err)
+		progressReporter.Report(ctx, req.JobID, models.ProgressStageFailed, "Failed to continue the extraction in the background", nil)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to continue the extraction in the background",
+		}, 500
+	}
+
+	progressReporter.Report(ctx, req.JobID, models.ProgressStageQueued, "Extraction continues in the background", nil)
+	return ResponseBody{
+		Success: true,
+		Message: "Extraction continues in the background; follow it with the job ID",
+		Data: map[string]interface{}{
+			"job_id":       req.JobID,
+			"status":       models.ProgressStageQueued,
+			"progress_url": "/api/jobs/" + req.JobID + "/progress",
+		},
+	}, 202
+}
+
+// extractWithinBudget runs an extraction and waits for it at most wait. The FireCrawl call cannot
+// be cancelled, so an extraction that takes longer is abandoned: it runs on, but its result and
+// progress are discarded. finished is false when the extraction was abandoned.
+func extractWithinBudget(ctx context.Context, request services.AdminExtractRequest, jobID string, wait time.Duration) (response *services.AdminExtractResponse, finished bool, err error) {
+	var abandoned atomic.Bool
+	report := progressCallback(ctx, jobID, request.URL)
+	request.OnProgress = func(stage string) {
+		if !abandoned.Load() {
+			report(stage)
+		}
+	}
+
+	type extractionResult struct {
+		response *services.AdminExtractResponse
+		err      error
+	}
+	done := make(chan extractionResult, 1)
+	go func() {
+		response, err := firecrawlService.ExtractWithSchema(request)
+		done <- extractionResult{response, err}
+	}()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result.response, true, result.err
+	case <-timer.C:
+		abandoned.Store(true)
+		return nil, false, nil
+	}
+}
+
+// removeStoredAdminEvents deletes the events of a submission that could not be stored in full.
+// It gets its own time, as the request's budget may be what made the submission fail.
+func removeStoredAdminEvents(ctx context.Context, adminEvents []*models.AdminEvent) {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), crawlCleanupTimeout)
+	defer cancel()
+	for _, adminEvent := range adminEvents {
+		if err := dynamoService.DeleteAdminEvent(cleanupCtx, adminEvent.EventID, adminEvent.ExtractedAt); err != nil {
+			log.Printf("Error removing admin event %s of a failed submission: %v", adminEvent.EventID, err)
+		}
+	}
+}
+
+// handleDebugExtraction handles POST /api/debug/extract
+func handleDebugExtraction(ctx context.Context, body string) (ResponseBody, int) {
+	if firecrawlService == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Firecrawl service not available",
+		}, 500
+	}
+
+	var req models.DebugExtractionRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	// Validate the request
+	if req.URL == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "URL is required",
+		}, 400
+	}
+
+	if req.SchemaType == "" {
+		req.SchemaType = "events" // Default schema type
+	}
+
+	jobID := req.JobID
+	if jobID == "" {
+		jobID = uuid.New().String()
+	}
+
+	// Create firecrawl extract request
+	extractRequest := services.AdminExtractRequest{
+		URL:          req.URL,
+		SchemaType:   req.SchemaType,
+		CustomSchema: req.CustomSchema,
+		OnProgress:   progressCallback(ctx, jobID, req.URL),
+	}
+
+	// Perform extraction with detailed diagnostics
+	extractResponse, err := firecrawlService.ExtractWithSchema(extractRequest)
+	flushFireCrawlStats()
+	if err != nil {
+		log.Printf("Error extracting with Firecrawl: %v", err)
+		progressReporter.Report(ctx, jobID, models.ProgressStageFailed, "Extraction failed: "+err.Error(), nil)
+		return extractionErrorResponse(err)
+	}
+
+	// Create a temporary admin event for conversion testing
+	tempEventID := "debug-" + uuid.New().String()
+	tempAdminEvent := &models.AdminEvent{
+		EventID:          tempEventID,
+		SourceURL:        req.URL,
+		SchemaType:       req.SchemaType,
+		SchemaUsed:       extractResponse.SchemaUsed,
+		RawExtractedData: extractResponse.RawData,
+		Status:           models.AdminEventStatusPending,
+		ExtractedByUser:  "debug-user",
+		SubmissionID:     tempEventID,
+		AdminNotes:       "Debug extraction - not stored",
+		ExtractedAt:      time.Now(),
+	}
+
+	// Perform conversion with detailed diagnostics
+	progressReporter.Report(ctx, jobID, models.ProgressStageConverting, "Converting extracted data to activities", nil)
+	conversionResult, conversionDiagnostics, conversionErr := conversionService.ConvertToActivityWithDiagnostics(tempAdminEvent)
+
+	// Persist diagnostics so they can be retrieved after this response
+	extractionDiagnostics := extractResponse.Diagnostics
+	if extractionDiagnostics != nil {
+		persistDiagnostics(ctx, tempEventID, models.DiagnosticsStageExtraction, req.URL, extractionDiagnostics.Success, extractionDiagnostics)
+	}
+	if conversionDiagnostics != nil {
+		persistDiagnostics(ctx, tempEventID, models.DiagnosticsStageConversion, req.URL, conversionDiagnostics.Success, conversionDiagnostics)
+	}
+
+	// Build comprehensive debug response
+	debugResponse := map[string]interface{}{
+		"diagnostics_id": tempEventID,
+		"extraction": map[string]interface{}{
+			"url":             req.URL,
+			"schema_type":     req.SchemaType,
+			"success":         extractResponse.Success,
+			"events_count":    extractResponse.EventsCount,
+			"credits_used":    extractResponse.CreditsUsed,
+			"processing_time": extractResponse.Metadata.ProcessingTime.String(),
+			"schema_used":     extractResponse.SchemaUsed,
+		},
+		"raw_data": map[string]interface{}{
+			"structured_data": extractResponse.RawData,
+		},
+		"conversion": map[string]interface{}{
+			"success": conversionErr == nil,
+		},
+	}
+
+	// Add extraction diagnostics if available
+	if extractionDiagnostics != nil {
+		debugResponse["raw_data"].(map[string]interface{})["markdown_length"] = extractionDiagnostics.RawMarkdownLength
+		debugResponse["raw_data"].(map[string]interface{})["markdown_sample"] = extractionDiagnostics.RawMarkdownSample
+		debugResponse["extraction_diagnostics"] = extractionDiagnostics
+		
+		// Add validation issues from extraction
+		if len(extractionDiagnostics.ValidationIssues) > 0 {
+			debugResponse["extraction_validation"] = map[string]interface{}{
+				"issues": extractionDiagnostics.ValidationIssues,
+			}
+		}
+	}
+
+	// Add conversion details if successful
+	if conversionErr == nil && conversionResult != nil {
+		debugResponse["conversion"].(map[string]interface{})["activity"] = conversionResult.Activity
+		debugResponse["conversion"].(map[string]interface{})["issues"] = conversionResult.Issues
+		debugResponse["conversion"].(map[string]interface{})["field_mappings"] = conversionResult.FieldMappings
+		debugResponse["conversion"].(map[string]interface{})["confidence_score"] = conversionResult.ConfidenceScore
+		
+		// Add detailed mappings and validation results if available
+		if conversionResult.DetailedMappings != nil {
+			debugResponse["conversion"].(map[string]interface{})["detailed_mappings"] = conversionResult.DetailedMappings
+		}
+		if conversionResult.ValidationResults != nil {
+			debugResponse["conversion"].(map[string]interface{})["validation_results"] = conversionResult.ValidationResults
+		}
+	} else if conversionErr != nil {
+		debugResponse["conversion"].(map[string]interface{})["error"] = conversionErr.Error()
+	}
+
+	// Add conversion diagnostics if available
+	if conversionDiagnostics != nil {
+		debugResponse["conversion_diagnostics"] = conversionDiagnostics
+	}
+
+	// Add suggestions for improvement
+	progressReporter.Report(ctx, jobID, models.ProgressStageValidating, "Validating results and building suggestions", nil)
+	suggestions := generateExtractionSuggestions(extractResponse, conversionResult, conversionErr)
+	if len(suggestions) > 0 {
+		debugResponse["suggestions"] = suggestions
+	}
+
+	progressReporter.Report(ctx, jobID, models.ProgressStageCompleted, "Debug extraction completed", map[string]interface{}{
+		"diagnostics_id": tempEventID,
+		"events_count":   extractResponse.EventsCount,
+	})
+	debugResponse["job_id"] = jobID
+
+	return ResponseBody{
+		Success: true,
+		Message: "Debug extraction completed",
+		Data:    debugResponse,
+	}, 200
+}
+
+// handleCancelScrapingTask handles DELETE /api/tasks/{id}. A task that has not started is cancelled
+// outright. Cancelling a running task is cooperative: executors check the task before each URL, so
+// URLs already extracted keep their results and the rest of the run is skipped.
+func handleCancelScrapingTask(ctx context.Context, taskID string) (ResponseBody, int) {
+	if taskID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Task ID is required",
+		}, 400
+	}
+
+	task, cancelled, err := dynamoService.TransitionScrapingTask(ctx, taskID, models.TaskStatusCancelled)
+	if err != nil {
+		if task == nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Scraping task not found",
+			}, 404
+		}
+		log.Printf("Error cancelling scraping task %s: %v", taskID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to cancel scraping task",
+		}, 500
+	}
+	if !cancelled {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Scraping task cannot be cancelled (status: %s)", task.Status),
+		}, 409
+	}
+	log.Printf("Cancelled scraping task %s for source %s", taskID, task.SourceID)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Scraping task cancelled",
+		Data: map[string]string{
+			"task_id":   taskID,
+			"source_id": task.SourceID,
+			"status":    string(models.TaskStatusCancelled),
+		},
+	}, 200
+}
+
+// handleGetFailedTasks handles GET /api/tasks/failed: the scrape task messages that failed every
+// delivery and were dead-lettered, most recent first, with the error of each failed delivery.
+// ?source_id= lists only one source's messages.
+func handleGetFailedTasks(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
+	}
+
+	messages, err := dynamoService.ListFailedTaskMessages(ctx, queryParams["source_id"], limit)
+	if err != nil {
+		log.Printf("Error listing failed task messages: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve failed tasks",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Failed tasks retrieved successfully",
+		Data: map[string]interface{}{
+			"failed_tasks": messages,
+			"count":        len(messages),
+		},
+	}, 200
+}
+
+// handleGetScrapingRun handles GET /api/runs/{id}
+func handleGetScrapingRun(ctx context.Context, runID string) (ResponseBody, int) {
+	if runID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Run ID is required",
+		}, 400
+	}
+
+	run, err := dynamoService.GetFanOutRun(ctx, runID)
+	if err != nil {
+		log.Printf("Error getting scraping run %s: %v", runID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Scraping run not found",
+		}, 404
+	}
+
+	tasks, err := dynamoService.GetFanOutTaskResults(ctx, runID)
+	if err != nil {
+		log.Printf("Error getting task results for run %s: %v", runID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve task results",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Run %s: %d of %d tasks finished", runID, run.FinishedTasks(), run.TotalTasks),
+		Data: map[string]interface{}{
+			"run":   run,
+			"tasks": tasks,
+		},
+	}, 200
+}
+
+// handleGetJobProgress handles GET /api/jobs/{id}/progress (polling fallback for the progress WebSocket)
+func handleGetJobProgress(ctx context.Context, jobID string) (ResponseBody, int) {
+	if jobID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Job ID is required",
+		}, 400
+	}
+
+	updates, err := dynamoService.GetProgressUpdates(ctx, jobID)
+	if err != nil {
+		log.Printf("Error retrieving progress for job %s: %v", jobID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve job progress",
+		}, 500
+	}
+
+	if len(updates) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "No progress found for job",
+		}, 404
+	}
+
+	current := updates[len(updates)-1]
+	return ResponseBody{
+		Success: true,
+		Message: "Job progress retrieved successfully",
+		Data: map[string]interface{}{
+			"job_id":  jobID,
+			"stage":   current.Stage,
+			"done":    current.Stage == models.ProgressStageCompleted || current.Stage == models.ProgressStageFailed,
+			"updates": updates,
+		},
+	}, 200
+}
+
+// handleCreateProgressTicket handles POST /api/progress/tickets. Browsers cannot send headers when
+// opening a WebSocket, so the admin UI connects to the progress socket with a short-lived,
+// single-use ticket in the query string rather than its API key, which would end up in access logs.
+func handleCreateProgressTicket(ctx context.Context) (ResponseBody, int) {
+	apiKey := requestAdminKey(ctx)
+	if apiKey == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "An admin API key is required",
+		}, 403
+	}
+
+	ticket, value, err := models.NewProgressTicket(apiKey.KeyID, time.Now())
+	if err != nil {
+		log.Printf("Error generating progress ticket: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create progress ticket",
+		}, 500
+	}
+	// The progress socket redeems tickets from production, where sandbox keys are managed too
+	if err := productionTenant.dynamo.CreateProgressTicket(ctx, ticket); err != nil {
+		log.Printf("Error storing progress ticket: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create progress ticket",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Progress ticket created successfully",
+		Data: map[string]interface{}{
+			"ticket":     value,
+			"expires_at": ticket.ExpiresAt,
+		},
+	}, 201
+}
+
+// progressCallback returns an extraction progress callback that reports stages for the job
+func progressCallback(ctx context.Context, jobID, sourceURL string) func(stage string) {
+	return func(stage string) {
+		message := "Processing " + sourceURL
+		switch stage {
+		case models.ProgressStageFetching:
+			message = "Fetching " + sourceURL
+		case models.ProgressStageExtracting:
+			message = "Extracting structured data from page content"
+		}
+		progressReporter.Report(ctx, jobID, stage, message, nil)
+	}
+}
+
+// generateExtractionSuggestions provides actionable suggestions based on extraction and conversion results
+func generateExtractionSuggestions(extractResponse *services.AdminExtractResponse, conversionResult *models.ConversionResult, conversionErr error) []string {
+	var suggestions []string
+
+	// Extraction-level suggestions
+	if !extractResponse.Success {
+		suggestions = append(suggestions, "Extraction failed - check if the URL is accessible and contains structured content")
+	}
+
+	if extractResponse.EventsCount == 0 {
+		suggestions = append(suggestions, "No events found - try a different schema type or check if the page contains event information")
+	}
+
+	// Conversion-level suggestions
+	if conversionErr != nil {
+		suggestions = append(suggestions, fmt.Sprintf("Conversion failed: %s", conversionErr.Error()))
+	}
+
+	if conversionResult != nil {
+		if conversionResult.ConfidenceScore < 50 {
+			suggestions = append(suggestions, "Low confidence score - extracted data may be incomplete or malformed")
+		}
+
+		if len(conversionResult.Issues) > 3 {
+			suggestions = append(suggestions, "Multiple conversion issues detected - consider using a different schema type or improving source data quality")
+		}
+
+		// Check for specific field mapping issues
+		if conversionResult.FieldMappings != nil {
+			missingFields := []string{}
+			for field, source := range conversionResult.FieldMappings {
+				if source == "not_found" || source == "generated" {
+					missingFields = append(missingFields, field)
+				}
+			}
+			if len(missingFields) > 0 {
+				suggestions = append(suggestions, fmt.Sprintf("Missing or generated fields: %s - check if source contains this information", strings.Join(missingFields, ", ")))
+			}
+		}
+	}
+
+	// Schema-specific suggestions
+	if extractResponse.SchemaUsed != nil && extractResponse.EventsCount > 0 {
+		suggestions = append(suggestions, "Successfully extracted events - consider this schema configuration for production")
+	}
+
+	return suggestions
+}
+
+// truncateString truncates a string to maxLength characters
+func truncateString(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	return s[:maxLength] + "..."
+}
+
+// generateConversionDetails creates detailed conversion information for an admin event
+func generateConversionDetails(ctx context.Context, event *models.AdminEvent) map[string]interface{} {
+	details := map[string]interface{}{
+		"has_conversion_preview": event.ConvertedData != nil,
+		"conversion_issues_count": len(event.ConversionIssues),
+		"conversion_status": "unknown",
+	}
+
+	// Attempt to regenerate conversion to get latest diagnostics
+	if conversionService != nil {
+		conversionResult, err := conversionService.ConvertToActivity(event)
+		if err != nil {
+			details["conversion_status"] = "failed"
+			details["conversion_error"] = err.Error()
+		} else if conversionResult != nil {
+			details["conversion_status"] = "success"
+			details["confidence_score"] = conversionResult.ConfidenceScore
+			details["field_mappings"] = conversionResult.FieldMappings
+			details["issues_count"] = len(conversionResult.Issues)
+			
+			// Add detailed mappings if available
+			if conversionResult.DetailedMappings != nil {
+				details["detailed_mappings"] = conversionResult.DetailedMappings
+			}
+			
+			// Add validation results if available
+			if conversionResult.ValidationResults != nil {
+				details["validation_results"] = conversionResult.ValidationResults
+			}
+
+			// Categorize issues by severity
+			issuesByType := make(map[string][]string)
+			for _, issue := range conversionResult.Issues {
+				// Simple categorization based on keywords
+				if strings.Contains(strings.ToLower(issue), "missing") {
+					issuesByType["missing_data"] = append(issuesByType["missing_data"], issue)
+				} else if strings.Contains(strings.ToLower(issue), "invalid") || strings.Contains(strings.ToLower(issue), "format") {
+					issuesByType["format_issues"] = append(issuesByType["format_issues"], issue)
+				} else {
+					issuesByType["other"] = append(issuesByType["other"], issue)
+				}
+			}
+			details["issues_by_type"] = issuesByType
+		}
+	}
+
+	return details
+}
+
+// generateRawDataSample creates a sample of the raw extracted data for debugging
+func generateRawDataSample(rawData map[string]interface{}) map[string]interface{} {
+	sample := map[string]interface{}{
+		"structure": analyzeDataStructure(rawData),
+		"sample_fields": make(map[string]interface{}),
+		"total_fields": len(rawData),
+	}
+
+	// Add samples of each top-level field
+	fieldCount := 0
+	for key, value := range rawData {
+		if fieldCount >= 5 { // Limit to first 5 fields
+			break
+		}
+
+		switch v := value.(type) {
+		case string:
+			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
+				"type": "string",
+				"length": len(v),
+				"sample": truncateString(v, 100),
+			}
+		case []interface{}:
+			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
+				"type": "array",
+				"length": len(v),
+				"sample": truncateArray(v, 2),
+			}
+		case map[string]interface{}:
+			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
+				"type": "object",
+				"fields": len(v),
+				"sample": truncateObject(v, 3),
+			}
+		default:
+			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
+				"type": fmt.Sprintf("%T", v),
+				"value": v,
+			}
+		}
+		fieldCount++
+	}
+
+	return sample
+}
+
+// assessDataQuality provides a quality assessment of the extracted data
+func assessDataQuality(event *models.AdminEvent) map[string]interface{} {
+	assessment := map[string]interface{}{
+		"overall_score": 0.0,
+		"factors": make(map[string]interface{}),
+		"recommendations": []string{},
+	}
+
+	score := 100.0
+	factors := make(map[string]interface{})
+
+	// Check if we have extracted data
+	if event.RawExtractedData == nil || len(event.RawExtractedData) == 0 {
+		score -= 50
+		factors["data_availability"] = map[string]interface{}{
+			"score": 0,
+			"message": "No extracted data available",
+		}
+		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Re-run extraction with different schema or check source URL")
+	} else {
+		factors["data_availability"] = map[string]interface{}{
+			"score": 100,
+			"message": "Data successfully extracted",
+		}
+	}
+
+	// Check conversion success
+	if event.ConvertedData != nil {
+		factors["conversion_success"] = map[string]interface{}{
+			"score": 100,
+			"message": "Successfully converted to Activity model",
+		}
+	} else {
+		score -= 30
+		factors["conversion_success"] = map[string]interface{}{
+			"score": 0,
+			"message": "Failed to convert to Activity model",
+		}
+		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Check conversion issues and consider different schema type")
+	}
+
+	// Check conversion issues
+	issueCount := len(event.ConversionIssues)
+	if issueCount == 0 {
+		factors["conversion_issues"] = map[string]interface{}{
+			"score": 100,
+			"message": "No conversion issues",
+		}
+	} else if issueCount <= 2 {
+		score -= 10
+		factors["conversion_issues"] = map[string]interface{}{
+			"score": 80,
+			"message": fmt.Sprintf("%d minor conversion issues", issueCount),
+		}
+	} else {
+		score -= 20
+		factors["conversion_issues"] = map[string]interface{}{
+			"score": 60,
+			"message": fmt.Sprintf("%d conversion issues detected", issueCount),
+		}
+		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Review conversion issues and improve source data quality")
+	}
+
+	// Check events count
+	eventsCount := event.GetExtractedEventsCount()
+	if eventsCount == 0 {
+		score -= 40
+		factors["events_count"] = map[string]interface{}{
+			"score": 0,
+			"message": "No events found in extracted data",
+		}
+		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Try different schema type or check if URL contains event information")
+	} else if eventsCount >= 1 && eventsCount <= 50 {
+		factors["events_count"] = map[string]interface{}{
+			"score": 100,
+			"message": fmt.Sprintf("%d events found", eventsCount),
+		}
+	} else {
+		score -= 10
+		factors["events_count"] = map[string]interface{}{
+			"score": 90,
+			"message": fmt.Sprintf("%d events found (unusually high)", eventsCount),
+		}
+		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Verify extraction accuracy - high event count may indicate over-extraction")
+	}
+
+	assessment["overall_score"] = math.Max(0, score)
+	assessment["factors"] = factors
+
+	return assessment
+}
+
+// Helper functions for data sampling
+func truncateArray(arr []interface{}, maxItems int) []interface{} {
+	if len(arr) <= maxItems {
+		return arr
+	}
+	return arr[:maxItems]
+}
+
+func truncateObject(obj map[string]interface{}, maxFields int) map[string]interface{} {
+	if len(obj) <= maxFields {
+		return obj
+	}
+	
+	result := make(map[string]interface{})
+	count := 0
+	for k, v := range obj {
+		if count >= maxFields {
+			break
+		}
+		result[k] = v
+		count++
+	}
+	return result
+}
+
+func analyzeDataStructure(data map[string]interface{}) map[string]interface{} {
+	structure := map[string]interface{}{
+		"total_fields": len(data),
+		"field_types": make(map[string]int),
+		"array_fields": []string{},
+		"object_fields": []string{},
+		"string_fields": []string{},
+	}
+
+	fieldTypes := make(map[string]int)
+	var arrayFields, objectFields, stringFields []string
+
+	for key, value := range data {
+		switch value.(type) {
+		case string:
+			fieldTypes["string"]++
+			stringFields = append(stringFields, key)
+		case []interface{}:
+			fieldTypes["array"]++
+			arrayFields = append(arrayFields, key)
+		case map[string]interface{}:
+			fieldTypes["object"]++
+			objectFields = append(objectFields, key)
+		case int, int64, float64:
+			fieldTypes["number"]++
+		case bool:
+			fieldTypes["boolean"]++
+		default:
+			fieldTypes["other"]++
+		}
+	}
+
+	structure["field_types"] = fieldTypes
+	structure["array_fields"] = arrayFields
+	structure["object_fields"] = objectFields
+	structure["string_fields"] = stringFields
+
+	return structure
+}
+
+// pendingEventDiagnostics returns an event's cached conversion details and quality assessment,
+// rebuilding and storing them when the event's data has changed since they were computed
+func pendingEventDiagnostics(ctx context.Context, event *models.AdminEvent) *models.ConversionDiagnostics {
+	if cached := event.CachedDiagnostics(); cached != nil {
+		return cached
+	}
+
+	event.Diagnostics = &models.ConversionDiagnostics{
+		InputHash:         event.DiagnosticsInputHash(),
+		ConversionDetails: generateConversionDetails(ctx, event),
+		QualityAssessment: assessDataQuality(event),
+		ComputedAt:        time.Now(),
+	}
+	if err := dynamoService.UpdateAdminEventDiagnostics(ctx, event); err != nil {
+		log.Printf("Warning: Failed to cache diagnostics for event %s: %v", event.EventID, err)
+	}
+
+	return event.Diagnostics
+}
+
+// handleGetPendingEvents handles GET /api/events/pending.
+// Events are filtered and sorted by a saved review preset and/or review filter query parameters.
+// Conversion details, raw data samples and quality assessments are expensive, so they are only
+// included with ?include=details and conversion results are cached on each event.
+func handleGetPendingEvents(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
+	}
+
+	filters, statusCode, err := reviewFiltersFromQuery(ctx, models.PresetTargetEvents, queryParams)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, statusCode
+	}
+
+	// Filters and sorting apply to the whole review queue (pending + edited), as for the next
+	// event, so each page holds the next matches in order
+	queue, err := dynamoService.GetReviewQueue(ctx)
+	if err != nil {
+		log.Printf("Error getting pending events: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve pending events",
+		}, 500
+	}
+	pendingEvents, nextToken, err := services.PageAdminEvents(queue, filters, limit, queryParams["next_token"])
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid next_token",
+		}, 400
+	}
+
+	includeDetails := false
+	for _, include := range strings.Split(queryParams["include"], ",") {
+		includeDetails = includeDetails || strings.TrimSpace(include) == "details"
+	}
+
+	// Enhance each event, with detailed conversion and diagnostic information when requested
+	enhancedEvents := []map[string]interface{}{}
+	for _, event := range pendingEvents {
+		enhanced := map[string]interface{}{
+			"event_id":             event.EventID,
+			"source_url":           event.SourceURL,
+			"schema_type":          event.SchemaType,
+			"status":               event.Status,
+			"extracted_at":         event.ExtractedAt,
+			"queued_at":            event.QueueEntryTime(),
+			"extracted_by_user":    event.ExtractedByUser,
+			"submission_id":        event.SubmissionID,
+			"events_count":         event.GetExtractedEventsCount(),
+			"conversion_issues":    event.ConversionIssues,
+			"confidence_score":     event.ConfidenceScore,
+			"completeness_score":   event.CompletenessScore,
+			"can_approve":          event.CanBeApproved(),
+			"admin_notes":          event.AdminNotes,
+		}
+
+		// Add conversion preview if available
+		if event.ConvertedData != nil {
+			enhanced["conversion_preview"] = event.ConvertedData
+		}
+
+		if includeDetails {
+			// Conversion details and quality assessment are cached on the event
+			diagnostics := pendingEventDiagnostics(ctx, &event)
+			enhanced["conversion_details"] = diagnostics.ConversionDetails
+			enhanced["quality_assessment"] = diagnostics.QualityAssessment
+
+			// Add raw data sample for debugging
+			rawDataSample := generateRawDataSample(event.RawExtractedData)
+			enhanced["raw_data_sample"] = rawDataSample
+		}
+
+		enhancedEvents = append(enhancedEvents, enhanced)
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Pending events retrieved successfully",
+		Data:    enhancedEvents,
+		Meta:    pageMeta(nextToken),
+	}, 200
+}
+
+// handleGetEvent handles GET /api/events/{id}
+func handleGetEvent(ctx context.Context, eventID string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	// Get the admin event by ID
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting admin event: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Event details retrieved successfully",
+		Data:    eventDetails(adminEvent),
+	}, 200
+}
+
+// eventDetails describes an admin event for review, with a freshly generated conversion preview
+func eventDetails(adminEvent *models.AdminEvent) map[string]interface{} {
+	conversionPreview, err := conversionService.PreviewConversion(adminEvent)
+	if err != nil {
+		log.Printf("Error generating conversion preview: %v", err)
+		conversionPreview = map[string]interface{}{
+			"error": "Could not generate conversion preview",
+		}
+	}
+
+	return map[string]interface{}{
+		"event_id":             adminEvent.EventID,
+		"source_url":           adminEvent.SourceURL,
+		"schema_type":          adminEvent.SchemaType,
+		"schema_used":          adminEvent.SchemaUsed,
+		"raw_extracted_data":   adminEvent.RawExtractedData,
+		"conversion_preview":   conversionPreview,
+		"status":               adminEvent.Status,
+		"extracted_at":         adminEvent.ExtractedAt,
+		"extracted_by_user":    adminEvent.ExtractedByUser,
+		"admin_notes":          adminEvent.AdminNotes,
+		"conversion_issues":    adminEvent.ConversionIssues,
+		"can_approve":          adminEvent.CanBeApproved(),
+		"events_count":         adminEvent.GetExtractedEventsCount(),
+	}
+}
+
+// handleGetNextEvent handles GET /api/events/next?after={id} - the pending event to review after
+// the one with ID after, or the first one without it, in the order of GET /api/events/pending
+// with the same preset and filters. The event comes with its conversion preview, so a reviewer
+// can approve and advance without reloading the queue.
+func handleGetNextEvent(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	filters, statusCode, err := reviewFiltersFromQuery(ctx, models.PresetTargetEvents, queryParams)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, statusCode
+	}
+
+	// The event advanced from has usually just been reviewed, so it is read by ID for its place
+	// in the order rather than found in the queue
+	var after *models.AdminEvent
+	if afterID := queryParams["after"]; afterID != "" {
+		after, err = dynamoService.GetAdminEventByID(ctx, afterID)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   fmt.Sprintf("Event %s not found", afterID),
+			}, 404
+		}
+	}
+
+	queue, err := dynamoService.GetReviewQueue(ctx)
+	if err != nil {
+		log.Printf("Error getting the review queue: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve pending events",
+		}, 500
+	}
+
+	next, remaining := services.NextAdminEvent(queue, filters, after)
+	if next == nil {
+		return ResponseBody{
+			Success: true,
+			Message: "No more pending events to review",
+			Data: map[string]interface{}{
+				"event":     nil,
+				"remaining": 0,
+			},
+		}, 200
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Next pending event retrieved successfully",
+		Data: map[string]interface{}{
+			"event":     eventDetails(next),
+			"remaining": remaining,
+		},
+	}, 200
+}
+
+// handleGetBrokenLinks handles GET /api/links/broken
+func handleGetBrokenLinks(ctx context.Context) (ResponseBody, int) {
+	records, err := dynamoService.GetBrokenLinks(ctx)
+	if err != nil {
+		log.Printf("Error retrieving broken links: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve broken links",
+		}, 500
+	}
+
+	// Longest-broken links first so they get fixed first
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].FirstFailedAt == nil || records[j].FirstFailedAt == nil {
+			return records[i].FirstFailedAt != nil
+		}
+		return records[i].FirstFailedAt.Before(*records[j].FirstFailedAt)
+	})
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Found %d broken links", len(records)),
+		Data: map[string]interface{}{
+			"links": records,
+			"count": len(records),
+		},
+	}, 200
+}
+
+// handleGetEventDiagnostics handles GET /api/events/{id}/diagnostics
+func handleGetEventDiagnostics(ctx context.Context, eventID string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	records, err := dynamoService.GetDiagnosticsForEvent(ctx, eventID)
+	if err != nil {
+		log.Printf("Error retrieving diagnostics for event %s: %v", eventID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve diagnostics",
+		}, 500
+	}
+
+	if len(records) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "No diagnostics found for event",
+		}, 404
+	}
+
+	// Records are sorted oldest first, so later entries win
+	latest := make(map[string]models.DiagnosticsRecord)
+	for _, record := range records {
+		latest[record.Stage] = record
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Found %d diagnostics records", len(records)),
+		Data: map[string]interface{}{
+			"event_id": eventID,
+			"latest":   latest,
+			"history":  records,
+		},
+	}, 200
+}
+
+// addPreviewActivities puts the events of a preview token in front of the activities, marked as
+// previews, and returns the IDs of the events it added. Events that are rejected, withdrawn or
+// already listed, or that would not publish, are left out.
+func addPreviewActivities(ctx context.Context, activities []*models.PublicActivity, preview *models.PreviewToken) ([]*models.PublicActivity, []string) {
+	listed := make(map[string]bool, len(activities))
+	for _, activity := range activities {
+		listed[activity.ID] = true
+	}
+
+	previews := []*models.PublicActivity{}
+	previewed := []string{}
+	for _, eventID := range preview.EventIDs {
+		adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+		if err != nil {
+			log.Printf("Warning: Skipping preview event %s: %v", eventID, err)
+			continue
+		}
+		if adminEvent.Status == models.AdminEventStatusRejected || adminEvent.Status == models.AdminEventStatusCancelled {
+			continue
+		}
+
+		activity, err := convertAdminEventToActivity(adminEvent)
+		if err != nil {
+			log.Printf("Warning: Skipping preview event %s that does not convert: %v", eventID, err)
+			continue
+		}
+		if len(activity.ValidatePublic()) > 0 || listed[activity.ID] {
+			continue
+		}
+		listed[activity.ID] = true
+		activity.Preview = true
+		previews = append(previews, activity)
+		previewed = append(previewed, eventID)
+	}
+
+	return append(previews, activities...), previewed
+}
+
+// handleCreatePreviewToken handles POST /api/admin/preview-tokens - a signed token that shows
+// unpublished events in GET /api/events/approved to whoever holds it
+func handleCreatePreviewToken(ctx context.Context, body string) (ResponseBody, int) {
+	if previewTokens == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Preview tokens are not configured",
+		}, 503
+	}
+
+	var req models.PreviewTokenRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	token := req.Token(time.Now())
+	unavailable := []string{}
+	for _, eventID := range token.EventIDs {
+		adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+		if err != nil || adminEvent.Status == models.AdminEventStatusRejected || adminEvent.Status == models.AdminEventStatusCancelled {
+			unavailable = append(unavailable, eventID)
+		}
+	}
+	if len(unavailable) > 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "Some events cannot be previewed - they are missing, rejected or withdrawn",
+			Data: map[string]interface{}{
+				"event_ids": unavailable,
+			},
+		}, 400
+	}
+
+	log.Printf("Preview token for %d events (%s) created by %s", len(token.EventIDs), token.Label, token.CreatedBy)
+	return ResponseBody{
+		Success: true,
+		Message: "Preview token created",
+		Data: map[string]interface{}{
+			"preview_token": previewTokens.Sign(token),
+			"event_ids":     token.EventIDs,
+			"label":         token.Label,
+			"expires_at":    time.Unix(token.ExpiresAt, 0).UTC().Format(time.RFC3339),
+		},
+	}, 201
+}
+
+// handleGetEventPublicPreview handles GET /api/events/{id}/public-preview - the payload
+// GET /api/events/approved would serve for the event once it is approved
+func handleGetEventPublicPreview(ctx context.Context, eventID string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+
+	activity, err := convertAdminEventToActivity(adminEvent)
+	if err != nil {
+		log.Printf("Error previewing public payload for event %s: %v", eventID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Event does not convert to a public activity - see the conversion diagnostics",
+			Data: map[string]interface{}{
+				"event_id":         eventID,
+				"conversion_error": err.Error(),
+			},
+		}, 400
+	}
+
+	// Approval refuses, and the public API skips, activities with payload issues
+	issues := activity.ValidatePublic()
+
+	return ResponseBody{
+		Success: true,
+		Message: "Public preview generated",
+		Data: map[string]interface{}{
+			"event_id":       eventID,
+			"status":         adminEvent.Status,
+			"publishable":    len(issues) == 0,
+			"payload_issues": issues,
+			"activity":       activity,
+		},
+	}, 200
+}
+
+// persistDiagnostics stores extraction or conversion diagnostics for an event.
+// Failures are logged rather than returned so diagnostics never block the main flow.
+func persistDiagnostics(ctx context.Context, eventID, stage, sourceURL string, success bool, diagnostics interface{}) {
+	diagnosticsJSON, err := json.Marshal(diagnostics)
+	if err != nil {
+		log.Printf("Warning: Failed to marshal %s diagnostics for event %s: %v", stage, eventID, err)
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(diagnosticsJSON, &payload); err != nil {
+		log.Printf("Warning: Failed to convert %s diagnostics for event %s: %v", stage, eventID, err)
+		return
+	}
+
+	record := &models.DiagnosticsRecord{
+		EventID:   eventID,
+		Stage:     stage,
+		SourceURL: sourceURL,
+		Success:   success,
+		Payload:   payload,
+	}
+
+	if err := dynamoService.CreateDiagnosticsRecord(ctx, record); err != nil {
+		log.Printf("Warning: Failed to persist %s diagnostics for event %s: %v", stage, eventID, err)
+	}
+}
+
+// recordPublicConversion stores an event's public conversion outcome under its source domain and
+// schema type. Failures are logged rather than returned so the counts never block approval.
+func recordPublicConversion(ctx context.Context, adminEvent *models.AdminEvent, outcome string) {
+	if err := dynamoService.RecordPublicConversion(ctx, adminEvent.EventID, urlutil.Domain(adminEvent.SourceURL), adminEvent.SchemaType, outcome); err != nil {
+		log.Printf("Warning: Failed to record public conversion outcome for event %s: %v", adminEvent.EventID, err)
+	}
+}
+
+// handleApproveEvent handles PUT /api/events/{id}/approve
+func handleApproveEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	// Get the admin event
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+
+	// Check if event can be approved
+	if !adminEvent.IsPending() {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Event cannot be approved - current status: %s", adminEvent.Status),
+		}, 400
+	}
+
+	// Convert to Activity model with detailed diagnostics
+	conversionResult, conversionDiagnostics, err := conversionService.ConvertToActivityWithDiagnostics(adminEvent)
+	if conversionDiagnostics != nil {
+		persistDiagnostics(ctx, eventID, models.DiagnosticsStageConversion, adminEvent.SourceURL, conversionDiagnostics.Success, conversionDiagnostics)
+	}
+	if err != nil {
+		recordPublicConversion(ctx, adminEvent, services.PublicConversionFailed)
+		errorDetails := map[string]interface{}{
+			"conversion_error": err.Error(),
+			"event_id": eventID,
+			"source_url": adminEvent.SourceURL,
+			"schema_type": adminEvent.SchemaType,
+		}
+		
+		if conversionDiagnostics != nil {
+			errorDetails["diagnostics"] = map[string]interface{}{
+				"processing_time": conversionDiagnostics.ProcessingTime.String(),
+				"conversion_issues": conversionDiagnostics.ConversionIssues,
+				"field_mappings": conversionDiagnostics.FieldMappings,
+				"confidence_score": conversionDiagnostics.ConfidenceScore,
+			}
+		}
+		
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to convert event to activity - see details for more information",
+			Data:    errorDetails,
+		}, 500
+	}
+
+	if conversionResult.Activity == nil {
+		recordPublicConversion(ctx, adminEvent, services.PublicConversionFailed)
+		errorDetails := map[string]interface{}{
+			"conversion_issues": conversionResult.Issues,
+			"field_mappings": conversionResult.FieldMappings,
+			"confidence_score": conversionResult.ConfidenceScore,
+			"event_id": eventID,
+			"source_url": adminEvent.SourceURL,
+			"suggestions": []string{
+				"Check if the extracted data contains valid event information",
+				"Try using a different schema type for extraction",
+				"Review the conversion issues for specific problems",
+			},
+		}
+		
+		if conversionResult.DetailedMappings != nil {
+			errorDetails["detailed_mappings"] = conversionResult.DetailedMappings
+		}
+		
+		if conversionResult.ValidationResults != nil {
+			errorDetails["validation_results"] = conversionResult.ValidationResults
+		}
+		
+		return ResponseBody{
+			Success: false,
+			Error:   "Could not generate valid activity from event data - see details for diagnostic information",
+			Data:    errorDetails,
+		}, 400
+	}
+
+	// Refuse to publish an activity the frontend would render incorrectly
+	if issues := conversionResult.Activity.ValidatePublic(); len(issues) > 0 {
+		recordPublicConversion(ctx, adminEvent, services.PublicConversionUnpublishable)
+		return ResponseBody{
+			Success: false,
+			Error:   "Converted activity would not render correctly on the public site - fix the event data before approving",
+			Data: map[string]interface{}{
+				"event_id":       eventID,
+				"source_url":     adminEvent.SourceURL,
+				"payload_issues": issues,
+			},
+		}, 400
+	}
+
+	// Store the converted activity in the main activities table, unless its visibility window is closed
+	if err := applyEventVisibility(ctx, adminEvent, conversionResult.Activity, time.Now()); err != nil {
+		log.Printf("Error storing approved activity: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to publish approved event",
+		}, 500
+	}
+	recordPublicConversion(ctx, adminEvent, services.PublicConversionConverted)
+
+	// Update admin event status
+	now := time.Now()
+	adminEvent.Status = models.AdminEventStatusApproved
+	adminEvent.ConfidenceScore = conversionResult.ConfidenceScore
+	adminEvent.CompletenessScore = services.CompletenessScore(conversionResult.Activity)
+	adminEvent.ReviewedAt = &now
+	adminEvent.ReviewedBy = req.ReviewedBy
+	adminEvent.AdminNotes = req.AdminNotes
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error updating admin event status: %v", err)
+		// Event was published but status update failed - log but don't fail
+	}
+	recordAdminAction(ctx, req.ReviewedBy, models.AdminAuditEventApproved, eventID, now, now.Sub(adminEvent.ExtractedAt))
+
+	successData := map[string]interface{}{
+		"event_id":    eventID,
+		"activity_id": conversionResult.Activity.ID,
+		"status":      "approved",
+		"conversion_summary": map[string]interface{}{
+			"confidence_score": conversionResult.ConfidenceScore,
+			"completeness": conversionResult.Activity.Completeness,
+			"issues_count": len(conversionResult.Issues),
+			"field_mappings_count": len(conversionResult.FieldMappings),
+		},
+	}
+	
+	// Add detailed conversion information if available
+	if conversionDiagnostics != nil {
+		successData["conversion_details"] = map[string]interface{}{
+			"processing_time": conversionDiagnostics.ProcessingTime.String(),
+			"success": conversionDiagnostics.Success,
+			"field_mappings": conversionDiagnostics.FieldMappings,
+		}
+	}
+	
+	// Include any conversion issues as warnings
+	if len(conversionResult.Issues) > 0 {
+		successData["warnings"] = conversionResult.Issues
+	}
+	if adminEvent.Hidden {
+		successData["hidden"] = true
+		successData["publish_at"] = adminEvent.PublishAt
+		successData["unpublish_at"] = adminEvent.UnpublishAt
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Event approved and published successfully",
+		Data:    successData,
+	}, 200
+}
+
+// publishEventActivity stores an event's converted activity in the main activities table, makes it
+// available to the search box, places it on the calendar and the map and indexes its tags. An event published before keeps its
+// activity ID, so the calendar days of the previous version are replaced.
+func publishEventActivity(ctx context.Context, adminEvent *models.AdminEvent, activity *models.Activity) error {
+	if adminEvent.ActivityID != "" {
+		activity.ID = adminEvent.ActivityID
+	}
+
+	// Geocode a location without coordinates so the activity can be found by nearby searches. The
+	// result is kept on the event for its later conversions.
+	if geocoder != nil {
+		geocoded, err := services.GeocodeLocation(ctx, geocoder, &activity.Location)
+		if err != nil {
+			log.Printf("Warning: Failed to geocode the location of event %s: %v", adminEvent.EventID, err)
+		} else if geocoded != nil {
+			neighborhoods.AssignNeighborhood(&activity.Location)
+			adminEvent.Geocoded = geocoded
+		}
+	}
+
+	if err := dynamoService.BatchPutActivities(ctx, []*models.Activity{activity}); err != nil {
+		return err
+	}
+	adminEvent.ActivityID = activity.ID
+
+	// Make the activity's title, venue and category available to the search box
+	if err := suggestIndex.IndexActivity(ctx, activity); err != nil {
+		log.Printf("Warning: Failed to index search suggestions for event %s: %v", adminEvent.EventID, err)
+	}
+
+	// Place the activity on the days it occurs for the month calendar
+	calendarEntries := services.CalendarEntriesForActivity(activity, venueRegistry.MatchID(activity.Location))
+	if err := dynamoService.ReplaceCalendarEntries(ctx, activity.ID, calendarEntries); err != nil {
+		log.Printf("Warning: Failed to store calendar entries for event %s: %v", adminEvent.EventID, err)
+	}
+
+	// Index the activity under its tags for tag pages and usage counts
+	if err := dynamoService.ReplaceTagEntries(ctx, activity.ID, services.TagEntriesForActivity(activity)); err != nil {
+		log.Printf("Warning: Failed to store tag entries for event %s: %v", adminEvent.EventID, err)
+	}
+
+	// Place the activity on the map for nearby searches
+	if err := dynamoService.ReplaceGeoEntry(ctx, activity.ID, services.GeoEntryForActivity(activity, adminEvent.EventID)); err != nil {
+		log.Printf("Warning: Failed to store geo entry for event %s: %v", adminEvent.EventID, err)
+	}
+
+	return nil
+}
+
+// applyEventVisibility publishes an approved event's activity while its visibility window is open.
+// Outside the window the event is hidden and an activity published before is withdrawn; the
+// visibility refresh publishes it when the window opens.
+func applyEventVisibility(ctx context.Context, adminEvent *models.AdminEvent, activity *models.Activity, now time.Time) error {
+	adminEvent.Hidden = !adminEvent.InVisibilityWindow(now)
+	if !adminEvent.Hidden {
+		return publishEventActivity(ctx, adminEvent, activity)
+	}
+	if adminEvent.ActivityID != "" {
+		return dynamoService.DeleteActivity(ctx, adminEvent.ActivityID)
+	}
+	return nil
+}
+
+// refreshEventVisibility publishes or withdraws an approved event's activity to match its
+// visibility window at now
+func refreshEventVisibility(ctx context.Context, adminEvent *models.AdminEvent, now time.Time) error {
+	var activity *models.Activity
+	if adminEvent.InVisibilityWindow(now) {
+		conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+		if err != nil || conversionResult.Activity == nil {
+			return fmt.Errorf("failed to convert event %s: %v", adminEvent.EventID, err)
+		}
+		activity = conversionResult.Activity
+	}
+	return applyEventVisibility(ctx, adminEvent, activity, now)
+}
+
+// visibilityRefreshEvent is the payload the visibility schedule invokes this function with
+type visibilityRefreshEvent struct {
+	RefreshVisibility bool `json:"refresh_visibility"`
+}
+
+// handleVisibilityRefresh publishes approved activities whose visibility window has opened and
+// withdraws those whose window has closed, then republishes the S3 activity feed if any changed
+func handleVisibilityRefresh(ctx context.Context) error {
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load approved events: %w", err)
+	}
+
+	now := time.Now()
+	changed := 0
+	for _, adminEvent := range services.VisibilityChanges(approvedEvents, now) {
+		adminEvent := adminEvent
+		if err := refreshEventVisibility(ctx, &adminEvent, now); err != nil {
+			log.Printf("Error refreshing the visibility of event %s: %v", adminEvent.EventID, err)
+			continue
+		}
+		err := dynamoService.UpdateAdminEventFromStatus(ctx, &adminEvent, models.AdminEventStatusApproved)
+		if errors.Is(err, services.ErrAdminEventChanged) {
+			// Reviewed since it was loaded; the next refresh sees its new state
+			continue
+		}
+		if err != nil {
+			log.Printf("Error saving the visibility of event %s: %v", adminEvent.EventID, err)
+			continue
+		}
+		changed++
+	}
+	log.Printf("Visibility refresh: %d approved events, %d changed", len(approvedEvents), changed)
+
+	if changed > 0 && activityPublisher != nil {
+		published, err := activityPublisher.Publish(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to republish activities: %w", err)
+		}
+		log.Printf("Republished %d activities to %s", published, services.PublishedActivitiesKey)
+		if feedInvalidator != nil {
+			paths := []string{services.PublishedActivitiesInvalidationPath}
+			if _, err := feedInvalidator.Invalidate(ctx, paths, fmt.Sprintf("activities-visibility-%d", now.Unix())); err != nil {
+				log.Printf("Warning: Failed to invalidate the published activity feed: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// handleSetEventVisibility handles PUT /api/events/{id}/visibility: it sets or clears the window
+// in which the event's activity is public. An approved event is published or withdrawn at once
+// to match; other events take the window with them when they are approved.
+func handleSetEventVisibility(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	var req models.AdminEventVisibilityRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+	if adminEvent.IsRejected() || adminEvent.Status == models.AdminEventStatusCancelled {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Event cannot be scheduled - current status: %s", adminEvent.Status),
+		}, 409
+	}
+
+	now := time.Now()
+	fromStatus := adminEvent.Status
+	adminEvent.PublishAt = req.PublishAt
+	adminEvent.UnpublishAt = req.UnpublishAt
+	if adminEvent.IsApproved() {
+		if err := refreshEventVisibility(ctx, adminEvent, now); err != nil {
+			log.Printf("Error applying the visibility window of event %s: %v", eventID, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to apply the visibility window",
+			}, 500
+		}
+	}
+
+	err = dynamoService.UpdateAdminEventFromStatus(ctx, adminEvent, fromStatus)
+	if errors.Is(err, services.ErrAdminEventChanged) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event was reviewed while its visibility was being set - try again",
+		}, 409
+	}
+	if err != nil {
+		log.Printf("Error saving the visibility window of event %s: %v", eventID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save the visibility window",
+		}, 500
+	}
+	recordAdminAction(ctx, req.UpdatedBy, models.AdminAuditVisibilitySet, eventID, now, 0)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Event visibility updated",
+		Data: map[string]interface{}{
+			"event_id":     eventID,
+			"status":       adminEvent.Status,
+			"publish_at":   adminEvent.PublishAt,
+			"unpublish_at": adminEvent.UnpublishAt,
+			"visible":      adminEvent.IsPubliclyVisible(now),
+		},
+	}, 200
+}
+
+// handleRejectEvent handles PUT /api/events/{id}/reject
+func handleRejectEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	// Get the admin event
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+
+	// Update admin event status
+	now := time.Now()
+	adminEvent.Status = models.AdminEventStatusRejected
+	adminEvent.ReviewedAt = &now
+	adminEvent.ReviewedBy = req.ReviewedBy
+	adminEvent.AdminNotes = req.AdminNotes
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error updating admin event status: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to reject event",
+		}, 500
+	}
+	recordAdminAction(ctx, req.ReviewedBy, models.AdminAuditEventRejected, eventID, now, now.Sub(adminEvent.ExtractedAt))
+
+	// Generate diagnostic information for the rejection
+	rejectionData := map[string]interface{}{
+		"event_id": eventID,
+		"status":   "rejected",
+		"rejection_details": map[string]interface{}{
+			"rejected_by": req.ReviewedBy,
+			"rejected_at": now,
+			"admin_notes": req.AdminNotes,
+			"source_url": adminEvent.SourceURL,
+			"schema_type": adminEvent.SchemaType,
+		},
+	}
+	
+	// Add conversion analysis to help understand why it was rejected
+	if conversionService != nil {
+		conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+		if err != nil {
+			rejectionData["conversion_analysis"] = map[string]interface{}{
+				"conversion_failed": true,
+				"error": err.Error(),
+			}
+		} else if conversionResult != nil {
+			rejectionData["conversion_analysis"] = map[string]interface{}{
+				"conversion_succeeded": true,
+				"confidence_score": conversionResult.ConfidenceScore,
+				"issues_count": len(conversionResult.Issues),
+				"issues": conversionResult.Issues,
+				"field_mappings": conversionResult.FieldMappings,
+			}
+		}
+	}
+	
+	// Add data quality assessment
+	qualityAssessment := assessDataQuality(adminEvent)
+	rejectionData["quality_assessment"] = qualityAssessment
+
+	return ResponseBody{
+		Success: true,
+		Message: "Event rejected successfully",
+		Data:    rejectionData,
+	}, 200
+}
+
+// handleEditEvent handles PUT /api/events/{id}/edit
+func handleEditEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	// Get the admin event
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+
+	// Update raw extracted data with edited data
+	if req.EditedData != nil {
+		adminEvent.RawExtractedData = req.EditedData
+	}
+
+	// Update status to edited; a reviewed event being edited goes back into the review queue
+	now := time.Now()
+	if !adminEvent.IsPending() {
+		adminEvent.QueuedAt = &now
+	}
+	adminEvent.Status = models.AdminEventStatusEdited
+	adminEvent.ReviewedAt = &now
+	adminEvent.ReviewedBy = req.ReviewedBy
+	adminEvent.AdminNotes = req.AdminNotes
+
+	// Regenerate conversion preview with edited data
+	refreshConversionPreview(ctx, adminEvent)
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error updating admin event: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save edited event",
+		}, 500
+	}
+	recordAdminAction(ctx, req.ReviewedBy, models.AdminAuditEventEdited, eventID, now, 0)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Event edited successfully",
+		Data: map[string]interface{}{
+			"event_id": eventID,
+			"status":   "edited",
+		},
+	}, 200
+}
+
+// handleSplitEvent handles PUT /api/events/{id}/split. Events extracted before crawls were split
+// hold every event found on the page, but only the first is converted. The event keeps the first of
+// them and each of the others becomes a new event with the same status, linked to its submission.
+func handleSplitEvent(ctx context.Context, eventID string) (ResponseBody, int) {
+	if eventID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event ID is required",
+		}, 400
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+	if !adminEvent.IsPending() {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Event cannot be split - current status: %s", adminEvent.Status),
+		}, 400
+	}
+
+	eventsData := models.SplitExtractedEvents(adminEvent.SchemaType, adminEvent.RawExtractedData)
+	if len(eventsData) < 2 {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event holds a single extracted event",
+		}, 400
+	}
+
+	// Store the new events first, so a failure leaves the original event whole
+	fromStatus := adminEvent.Status
+	var splitEvents []*models.AdminEvent
+	var splitIDs []string
+	for _, eventData := range eventsData[1:] {
+		splitEvent := *adminEvent
+		splitEvent.EventID = uuid.New().String()
+		splitEvent.RawExtractedData = eventData
+		splitEvent.ConvertedData = nil
+		splitEvent.ConversionIssues = nil
+		splitEvent.BrokenLinks = nil
+		splitEvent.ActivityID = ""
+		splitEvent.Diagnostics = nil
+		refreshConversionPreview(ctx, &splitEvent)
+
+		if err := dynamoService.CreateAdminEvent(ctx, &splitEvent); err != nil {
+			log.Printf("Error storing event split from %s: %v", eventID, err)
+			deleteSplitEvents(ctx, splitEvents)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to store split events",
+			}, 500
+		}
+		splitEvents = append(splitEvents, &splitEvent)
+		splitIDs = append(splitIDs, splitEvent.EventID)
+	}
+
+	adminEvent.RawExtractedData = eventsData[0]
+	refreshConversionPreview(ctx, adminEvent)
+	err = dynamoService.UpdateAdminEventFromStatus(ctx, adminEvent, fromStatus)
+	if err != nil {
+		deleteSplitEvents(ctx, splitEvents)
+		if errors.Is(err, services.ErrAdminEventChanged) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Event was reviewed while it was being split",
+			}, 409
+		}
+		log.Printf("Error saving split event %s: %v", eventID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save split event",
+		}, 500
+	}
+
+	// Record the new events on the submission, creating it for events extracted before submissions
+	submission, err := dynamoService.GetCrawlSubmission(ctx, adminEvent.SubmissionID)
+	if err != nil {
+		log.Printf("Warning: Failed to get submission of split event %s: %v", eventID, err)
+	} else if submission == nil {
+		submission = &models.CrawlSubmission{
+			SubmissionID:    adminEvent.SubmissionID,
+			URL:             adminEvent.SourceURL,
+			SchemaType:      adminEvent.SchemaType,
+			ExtractedByUser: adminEvent.ExtractedByUser,
+			EventIDs:        append([]string{eventID}, splitIDs...),
+		}
+		if err := dynamoService.CreateCrawlSubmission(ctx, submission); err != nil {
+			log.Printf("Warning: Failed to store submission of split event %s: %v", eventID, err)
+		}
+	} else {
+		submission.InsertEventIDs(eventID, splitIDs)
+		if err := dynamoService.UpdateCrawlSubmission(ctx, submission); err != nil {
+			log.Printf("Warning: Failed to update submission of split event %s: %v", eventID, err)
+		}
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Event split into %d events", len(eventsData)),
+		Data: map[string]interface{}{
+			"event_id":      eventID,
+			"submission_id": adminEvent.SubmissionID,
+			"event_ids":     append([]string{eventID}, splitIDs...),
+		},
+	}, 200
+}
+
+// deleteSplitEvents removes the events stored by a split that could not be completed
+func deleteSplitEvents(ctx context.Context, splitEvents []*models.AdminEvent) {
+	for _, splitEvent := range splitEvents {
+		if err := dynamoService.DeleteAdminEvent(ctx, splitEvent.EventID, splitEvent.ExtractedAt); err != nil {
+			log.Printf("Warning: Failed to delete event %s of an incomplete split: %v", splitEvent.EventID, err)
+		}
+	}
+}
+
+// maxBulkEditEvents bounds how many events a single bulk edit may change
+const maxBulkEditEvents = 100
+
+// handleBulkEditEvents handles PUT /api/events/bulk-edit. The patch is applied to every pending event
+// matching the filter, and each event's conversion preview is regenerated from the patched data.
+func handleBulkEditEvents(ctx context.Context, body string) (ResponseBody, int) {
+	var req models.AdminEventBulkEditRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	adminEvents, err := dynamoService.GetPendingAdminEventsMatching(ctx, req.Filter.SubmissionID, req.Filter.SourceURL)
+	if err != nil {
+		log.Printf("Error getting pending events for bulk edit: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve pending events",
+		}, 500
+	}
+	if len(adminEvents) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "No pending events match the filter",
+		}, 404
+	}
+	if len(adminEvents) > maxBulkEditEvents {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("%d pending events match the filter, at most %d can be edited at once - narrow the filter", len(adminEvents), maxBulkEditEvents),
+		}, 400
+	}
+
+	now := time.Now()
+	updated := []map[string]interface{}{}
+	failed := []map[string]interface{}{}
+	for i := range adminEvents {
+		adminEvent := &adminEvents[i]
+		fromStatus := adminEvent.Status
+
+		req.Patch.ApplyToRawData(adminEvent.RawExtractedData)
+		adminEvent.Status = models.AdminEventStatusEdited
+		adminEvent.ReviewedAt = &now
+		adminEvent.ReviewedBy = req.EditedBy
+		if req.AdminNotes != "" {
+			adminEvent.AdminNotes = req.AdminNotes
+		}
+		refreshConversionPreview(ctx, adminEvent)
+
+		err := dynamoService.UpdateAdminEventFromStatus(ctx, adminEvent, fromStatus)
+		if errors.Is(err, services.ErrAdminEventChanged) {
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    "Event was reviewed during the bulk edit",
+			})
+			continue
+		}
+		if err != nil {
+			log.Printf("Error saving bulk edit of event %s: %v", adminEvent.EventID, err)
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    "Failed to save edited event",
+			})
+			continue
+		}
+
+		recordAdminAction(ctx, req.EditedBy, models.AdminAuditEventEdited, adminEvent.EventID, now, 0)
+		updated = append(updated, map[string]interface{}{
+			"event_id":          adminEvent.EventID,
+			"conversion_issues": adminEvent.ConversionIssues,
+			"confidence_score":  adminEvent.ConfidenceScore,
+		})
+	}
+
+	log.Printf("Bulk edit by %s updated %d of %d pending events", req.EditedBy, len(updated), len(adminEvents))
+
+	data := map[string]interface{}{
+		"matched": len(adminEvents),
+		"updated": updated,
+		"failed":  failed,
+	}
+	if len(updated) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "None of the matching events could be updated",
+			Data:    data,
+		}, 409
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Updated %d of %d matching events", len(updated), len(adminEvents)),
+		Data:    data,
+	}, 200
+}
+
+// handleGetSubmission handles GET /api/submissions/{id} - a crawl submission and every event it
+// extracted, in extraction order
+func handleGetSubmission(ctx context.Context, submissionID string) (ResponseBody, int) {
+	if submissionID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Submission ID is required",
+		}, 400
+	}
+
+	submission, err := dynamoService.GetCrawlSubmission(ctx, submissionID)
+	if err != nil {
+		log.Printf("Error getting crawl submission %s: %v", submissionID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve submission",
+		}, 500
+	}
+
+	adminEvents, err := dynamoService.GetAdminEventsBySubmission(ctx, submissionID)
+	if err != nil {
+		log.Printf("Error getting events of submission %s: %v", submissionID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve submission events",
+		}, 500
+	}
+
+	// Events extracted before submissions were recorded each have a submission ID of their own
+	if submission == nil && len(adminEvents) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "Submission not found",
+		}, 404
+	}
+
+	if submission != nil {
+		order := make(map[string]int, len(submission.EventIDs))
+		for i, eventID := range submission.EventIDs {
+			order[eventID] = i
+		}
+		sort.SliceStable(adminEvents, func(i, j int) bool {
+			return order[adminEvents[i].EventID] < order[adminEvents[j].EventID]
+		})
+	}
+
+	statusCounts := make(map[models.AdminEventStatus]int)
+	events := []map[string]interface{}{}
+	for _, event := range adminEvents {
+		statusCounts[event.Status]++
+		eventView := map[string]interface{}{
+			"event_id":           event.EventID,
+			"status":             event.Status,
+			"extracted_at":       event.ExtractedAt,
+			"conversion_issues":  event.ConversionIssues,
+			"confidence_score":   event.ConfidenceScore,
+			"completeness_score": event.CompletenessScore,
+			"can_approve":        event.CanBeApproved(),
+			"admin_notes":        event.AdminNotes,
+			"reviewed_by":        event.ReviewedBy,
+			"reviewed_at":        event.ReviewedAt,
+			"activity_id":        event.ActivityID,
+		}
+		if event.ConvertedData != nil {
+			eventView["conversion_preview"] = event.ConvertedData
+		}
+		events = append(events, eventView)
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Submission retrieved successfully",
+		Data: map[string]interface{}{
+			"submission_id": submissionID,
+			"submission":    submission,
+			"events_count":  len(events),
+			"status_counts": statusCounts,
+			"events":        events,
+		},
+	}, 200
+}
+
+// handleReviewSubmission handles PUT /api/submissions/{id}/approve and /reject, approving or
+// rejecting every event of the submission still awaiting review. Each event is reviewed as by
+// PUT /api/events/{id}/approve or /reject; events that cannot be are reported and left pending.
+func handleReviewSubmission(ctx context.Context, submissionID, action string, body string) (ResponseBody, int) {
+	if submissionID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Submission ID is required",
+		}, 400
+	}
+
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	adminEvents, err := dynamoService.GetPendingAdminEventsMatching(ctx, submissionID, "")
+	if err != nil {
+		log.Printf("Error getting pending events of submission %s: %v", submissionID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve submission events",
+		}, 500
+	}
+	if len(adminEvents) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "Submission has no events awaiting review",
+		}, 404
+	}
+
+	reviewEvent, reviewed := handleApproveEvent, "approved"
+	if action == "reject" {
+		reviewEvent, reviewed = handleRejectEvent, "rejected"
+	}
+
+	succeeded := []map[string]interface{}{}
+	failed := []map[string]interface{}{}
+	for _, adminEvent := range adminEvents {
+		result, _ := reviewEvent(ctx, adminEvent.EventID, body)
+		if !result.Success {
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    result.Error,
+				"details":  result.Data,
+			})
+			continue
+		}
+
+		eventResult := map[string]interface{}{"event_id": adminEvent.EventID}
+		if data, ok := result.Data.(map[string]interface{}); ok && data["activity_id"] != nil {
+			eventResult["activity_id"] = data["activity_id"]
+		}
+		succeeded = append(succeeded, eventResult)
+	}
+
+	log.Printf("Submission %s review by %s: %s %d of %d pending events", submissionID, req.ReviewedBy, reviewed, len(succeeded), len(adminEvents))
+
+	data := map[string]interface{}{
+		"submission_id": submissionID,
+		"matched":       len(adminEvents),
+		reviewed:        succeeded,
+		"failed":        failed,
+	}
+	if len(succeeded) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("None of the submission's pending events could be %s", reviewed),
+			Data:    data,
+		}, 400
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("%d of %d pending events in submission %s", len(succeeded), len(adminEvents), reviewed),
+		Data:    data,
+	}, 200
+}
+
+// refreshConversionPreview regenerates an event's conversion preview from its raw data and returns
+// the conversion result. Events whose data cannot be converted keep their previous preview.
+func refreshConversionPreview(ctx context.Context, adminEvent *models.AdminEvent) *models.ConversionResult {
+	conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+	if err != nil {
+		log.Printf("Error regenerating conversion preview: %v", err)
+		return nil
+	}
+
+	if conversionResult.Activity != nil {
+		activityJSON, _ := json.Marshal(conversionResult.Activity)
+		var activityMap map[string]interface{}
+		json.Unmarshal(activityJSON, &activityMap)
+		adminEvent.ConvertedData = activityMap
+	}
+	adminEvent.ConversionIssues = append(conversionResult.Issues, scheduleConflictWarnings(ctx, adminEvent, conversionResult.Activity)...)
+	adminEvent.ConfidenceScore = conversionResult.ConfidenceScore
+	adminEvent.CompletenessScore = services.CompletenessScore(conversionResult.Activity)
+	return conversionResult
+}
+
+// scheduleConflictWarnings returns a conversion issue for each published program that overlaps the
+// converted activity in the same room of its venue under a different title
+func scheduleConflictWarnings(ctx context.Context, adminEvent *models.AdminEvent, activity *models.Activity) []string {
+	if activity == nil {
+		return nil
+	}
+	venueID := venueRegistry.MatchID(activity.Location)
+	if venueID == "" {
+		return nil
+	}
+	entries := services.CalendarEntriesForActivity(activity, venueID)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	published, err := dynamoService.GetVenueCalendarEntries(ctx, venueID, entries[0].Date, entries[len(entries)-1].Date)
+	if err != nil {
+		log.Printf("Warning: Failed to check schedule conflicts for event %s: %v", adminEvent.EventID, err)
+		return nil
+	}
+	for _, entry := range published {
+		// The event's own published sessions are replaced when it is approved again
+		if adminEvent.ActivityID != "" && entry.ActivityID == adminEvent.ActivityID {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return services.ScheduleConflictWarnings(activity.ID, services.FindScheduleConflicts(entries))
+}
+
+// handleGetPendingEventChanges handles GET /api/events/changes - provider updates and
+// cancellations of published events awaiting review
+func handleGetPendingEventChanges(ctx context.Context) (ResponseBody, int) {
+	events, err := dynamoService.GetAdminEventsWithPendingChanges(ctx)
+	if err != nil {
+		log.Printf("Error getting pending event changes: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve pending event changes",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d pending event changes", len(events)),
+		Data: map[string]interface{}{
+			"events": events,
+			"count":  len(events),
+		},
+	}, 200
+}
+
+// handleApproveEventChange handles PUT /api/events/{id}/approve-change. An approved update
+// republishes the event with the provider's data; an approved cancellation unpublishes it.
+func handleApproveEventChange(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+	if !adminEvent.HasPendingChange() {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event has no pending change",
+		}, 409
+	}
+
+	change := adminEvent.PendingChange
+	switch change.Action {
+	case models.ProviderChangeUpdate:
+		// Convert a copy so a change that cannot be published leaves the event as it was
+		updated := *adminEvent
+		updated.RawExtractedData = models.ProviderEventRawData(change.EventData)
+		conversionResult := refreshConversionPreview(ctx, &updated)
+		if conversionResult == nil || conversionResult.Activity == nil {
+			data := map[string]interface{}{"event_id": eventID}
+			if conversionResult != nil {
+				data["conversion_issues"] = conversionResult.Issues
+			}
+			return ResponseBody{
+				Success: false,
+				Error:   "Could not generate a valid activity from the updated event - reject the change instead",
+				Data:    data,
+			}, 400
+		}
+		if issues := conversionResult.Activity.ValidatePublic(); len(issues) > 0 {
+			return ResponseBody{
+				Success: false,
+				Error:   "Updated activity would not render correctly on the public site - reject the change instead",
+				Data: map[string]interface{}{
+					"event_id":       eventID,
+					"payload_issues": issues,
+				},
+			}, 400
+		}
+
+		if err := applyEventVisibility(ctx, &updated, conversionResult.Activity, time.Now()); err != nil {
+			log.Printf("Error storing updated activity for event %s: %v", eventID, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to publish updated event",
+			}, 500
+		}
+		adminEvent = &updated
+
+	case models.ProviderChangeCancel:
+		adminEvent.Status = models.AdminEventStatusCancelled
+		if adminEvent.ActivityID != "" {
+			if err := dynamoService.DeleteActivity(ctx, adminEvent.ActivityID); err != nil {
+				log.Printf("Warning: Failed to remove the activity of cancelled event %s: %v", eventID, err)
+			}
+		}
+
+	default:
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Unknown change action: %s", change.Action),
+		}, 400
+	}
+
+	now := time.Now()
+	adminEvent.PendingChange = nil
+	adminEvent.ReviewedAt = &now
+	adminEvent.ReviewedBy = req.ReviewedBy
+	adminEvent.AdminNotes = req.AdminNotes
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error updating admin event %s after approving its %s: %v", eventID, change.Action, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save the approved change",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Event %s approved", change.Action),
+		Data: map[string]interface{}{
+			"event_id":    eventID,
+			"activity_id": adminEvent.ActivityID,
+			"action":      change.Action,
+			"status":      adminEvent.Status,
+		},
+	}, 200
+}
+
+// handleRejectEventChange handles PUT /api/events/{id}/reject-change. The published event is left as it was.
+func handleRejectEventChange(ctx context.Context, eventID string, body string) (ResponseBody, int) {
+	var req models.AdminEventReview
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event not found",
+		}, 404
+	}
+	if !adminEvent.HasPendingChange() {
+		return ResponseBody{
+			Success: false,
+			Error:   "Event has no pending change",
+		}, 409
+	}
+
+	action := adminEvent.PendingChange.Action
+	now := time.Now()
+	adminEvent.PendingChange = nil
+	adminEvent.ReviewedAt = &now
+	adminEvent.ReviewedBy = req.ReviewedBy
+	adminEvent.AdminNotes = req.AdminNotes
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error rejecting the %s of event %s: %v", action, eventID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to reject change",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Event %s rejected", action),
+		Data: map[string]interface{}{
+			"event_id": eventID,
+			"action":   action,
+			"status":   adminEvent.Status,
+		},
+	}, 200
+}
+
+// handleCreateProvider handles POST /api/providers. The provider's token is only returned here.
+func handleCreateProvider(ctx context.Context, body string) (ResponseBody, int) {
+	var req models.ProviderAccountRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	providerID := generateSourceID(req.ProviderName)
+	token, tokenHash, err := models.GenerateProviderToken(providerID)
+	if err != nil {
+		log.Printf("Error generating provider token: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to generate provider token",
+		}, 500
+	}
+
+	account := &models.ProviderAccount{
+		ProviderID:   providerID,
+		ProviderName: strings.TrimSpace(req.ProviderName),
+		Website:      req.Website,
+		ContactEmail: req.ContactEmail,
+		Status:       models.ProviderStatusActive,
+		TokenHash:    tokenHash,
+		TokenPrefix:  models.ProviderTokenDisplayPrefix(token),
+		CreatedBy:    req.CreatedBy,
+	}
+	if err := dynamoService.CreateProviderAccount(ctx, account); err != nil {
+		log.Printf("Error creating provider account: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create provider",
+		}, 500
+	}
+
+	log.Printf("Provider %s (%s) created by %s", providerID, account.ProviderName, req.CreatedBy)
+	return ResponseBody{
+		Success: true,
+		Message: "Provider created - the token is only shown once",
+		Data: map[string]interface{}{
+			"provider": account,
+			"token":    token,
+		},
+	}, 201
+}
+
+// handleListProviders handles GET /api/providers
+func handleListProviders(ctx context.Context) (ResponseBody, int) {
+	providers, err := dynamoService.ListProviderAccounts(ctx)
+	if err != nil {
+		log.Printf("Error listing provider accounts: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve providers",
+		}, 500
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return strings.ToLower(providers[i].ProviderName) < strings.ToLower(providers[j].ProviderName)
+	})
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d providers", len(providers)),
+		Data: map[string]interface{}{
+			"providers": providers,
+			"count":     len(providers),
+		},
+	}, 200
+}
+
+// handleRevokeProvider handles PUT /api/providers/{id}/revoke. Events the provider already
+// submitted stay as they are.
+func handleRevokeProvider(ctx context.Context, providerID string) (ResponseBody, int) {
+	found, err := dynamoService.RevokeProviderAccount(ctx, providerID)
+	if err != nil {
+		log.Printf("Error revoking provider %s: %v", providerID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to revoke provider",
+		}, 500
+	}
+	if !found {
+		return ResponseBody{
+			Success: false,
+			Error:   "Provider not found",
+		}, 404
+	}
+
+	log.Printf("Provider %s revoked", providerID)
+	return ResponseBody{
+		Success: true,
+		Message: "Provider token revoked",
+		Data: map[string]interface{}{
+			"provider_id": providerID,
+			"status":      models.ProviderStatusRevoked,
+		},
+	}, 200
+}
+
+// handleCreateAdminAPIKey handles POST /api/admin/api-keys. The key is only returned here.
+func handleCreateAdminAPIKey(ctx context.Context, body string) (ResponseBody, int) {
+	var req models.AdminAPIKeyRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	keyID, key, keyHash, err := models.GenerateAdminAPIKey()
+	if err != nil {
+		log.Printf("Error generating admin API key: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to generate admin API key",
+		}, 500
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.AdminRoleViewer
+	}
+	apiKey := &models.AdminAPIKey{
+		KeyID:     keyID,
+		Name:      strings.TrimSpace(req.Name),
+		Status:    models.AdminAPIKeyStatusActive,
+		Role:      role,
+		Sandbox:   req.Sandbox,
+		KeyHash:   keyHash,
+		KeyPrefix: models.AdminAPIKeyDisplayPrefix(key),
+		CreatedBy: req.CreatedBy,
+	}
+	if err := dynamoService.CreateAdminAPIKey(ctx, apiKey); err != nil {
+		log.Printf("Error creating admin API key: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create admin API key",
+		}, 500
+	}
+
+	log.Printf("Admin API key %s (%s, %s, sandbox %t) created by %s", keyID, apiKey.Name, role, apiKey.Sandbox, req.CreatedBy)
+	return ResponseBody{
+		Success: true,
+		Message: "Admin API key created - the key is only shown once",
+		Data: map[string]interface{}{
+			"api_key": apiKey,
+			"key":     key,
+		},
+	}, 201
+}
+
+// handleListAdminAPIKeys handles GET /api/admin/api-keys
+func handleListAdminAPIKeys(ctx context.Context) (ResponseBody, int) {
+	keys, err := dynamoService.ListAdminAPIKeys(ctx)
+	if err != nil {
+		log.Printf("Error listing admin API keys: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve admin API keys",
+		}, 500
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d admin API keys", len(keys)),
+		Data: map[string]interface{}{
+			"api_keys": keys,
+			"count":    len(keys),
+		},
+	}, 200
+}
+
+// handleRevokeAdminAPIKey handles PUT /api/admin/api-keys/{id}/revoke. The key is refused from
+// the next request on.
+func handleRevokeAdminAPIKey(ctx context.Context, keyID string) (ResponseBody, int) {
+	found, err := dynamoService.RevokeAdminAPIKey(ctx, keyID)
+	if err != nil {
+		log.Printf("Error revoking admin API key %s: %v", keyID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to revoke admin API key",
+		}, 500
+	}
+	if !found {
+		return ResponseBody{
+			Success: false,
+			Error:   "Admin API key not found",
+		}, 404
+	}
+
+	log.Printf("Admin API key %s revoked", keyID)
+	return ResponseBody{
+		Success: true,
+		Message: "Admin API key revoked",
+		Data: map[string]interface{}{
+			"key_id": keyID,
+			"status": models.AdminAPIKeyStatusRevoked,
+		},
+	}, 200
+}
+
+// handleResetSandbox handles POST /api/sandbox/reset: the sandbox tables are emptied and seeded
+// with demo sources and events. Only sandbox keys may reset, so production can never be cleared.
+func handleResetSandbox(ctx context.Context) (ResponseBody, int) {
+	if !isSandboxRequest(ctx) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Only sandbox API keys can reset the sandbox",
+		}, 403
+	}
+
+	seed, deleted, err := services.ResetSandbox(ctx, dynamoService, conversionService, time.Now())
+	if err != nil {
+		log.Printf("Error resetting the sandbox: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to reset the sandbox",
+		}, 500
+	}
+
+	log.Printf("Sandbox reset by %s: %d items deleted, %d sources and %d events seeded", requestAdminKey(ctx).Name, deleted, len(seed.Submissions), len(seed.AdminEvents))
+	return ResponseBody{
+		Success: true,
+		Message: "Sandbox reset with demo data",
+		Data: map[string]interface{}{
+			"items_deleted":  deleted,
+			"seeded_sources": len(seed.Submissions),
+			"seeded_events":  len(seed.AdminEvents),
+		},
+	}, 200
+}
+
+// authenticateProvider resolves the provider from the bearer token in the Authorization header.
+// It returns a nil provider with the response to send when the token is missing or not accepted.
+func authenticateProvider(ctx context.Context, headers map[string]string) (*models.ProviderAccount, ResponseBody, int) {
+	unauthorized := ResponseBody{
+		Success: false,
+		Error:   "A valid provider token is required",
+	}
+	providerID, secret, err := models.ParseProviderToken(apiheaders.BearerToken(headers))
+	if err != nil {
+		return nil, unauthorized, 401
+	}
+
+	provider, err := dynamoService.GetProviderAccount(ctx, providerID)
+	if err != nil {
+		log.Printf("Error getting provider account %s: %v", providerID, err)
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Failed to verify provider token",
+		}, 500
+	}
+	if provider == nil || !provider.CheckTokenSecret(secret) {
+		return nil, unauthorized, 401
+	}
+	if !provider.IsActive() {
+		return nil, ResponseBody{
+			Success: false,
+			Error:   "Provider token has been revoked",
+		}, 403
+	}
+
+	return provider, ResponseBody{}, 0
+}
+
+// getProviderEvent retrieves an event the provider submitted. Events of other providers are
+// reported as not found.
+func getProviderEvent(ctx context.Context, provider *models.ProviderAccount, eventID string) (*models.AdminEvent, ResponseBody, int) {
+	notFound := ResponseBody{
+		Success: false,
+		Error:   "Event not found",
+	}
+	if eventID == "" {
+		return nil, notFound, 404
+	}
+
+	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	if err != nil || adminEvent.ProviderID != provider.ProviderID {
+		return nil, notFound, 404
+	}
+	return adminEvent, ResponseBody{}, 0
+}
+
+// providerEventView is what providers see of their submissions; review internals such as
+// conversion diagnostics are left out
+func providerEventView(adminEvent *models.AdminEvent) map[string]interface{} {
+	view := map[string]interface{}{
+		"event_id":     adminEvent.EventID,
+		"status":       adminEvent.Status,
+		"published":    adminEvent.IsApproved(),
+		"submitted_at": adminEvent.CreatedAt,
+		"updated_at":   adminEvent.UpdatedAt,
+	}
+	if events, ok := adminEvent.RawExtractedData["events"].([]interface{}); ok && len(events) > 0 {
+		view["event"] = events[0]
+	}
+	if adminEvent.ReviewedAt != nil {
+		view["reviewed_at"] = adminEvent.ReviewedAt
+		view["review_notes"] = adminEvent.AdminNotes
+	}
+	if adminEvent.PendingChange != nil {
+		view["pending_change"] = adminEvent.PendingChange
+	}
+	return view
+}
+
+// scopeSubmissionToVenue pins submissions of a provider issued by a venue claim to the claimed
+// venue, so they are published at it. It returns a non-zero status when the venue can't be loaded.
+func scopeSubmissionToVenue(ctx context.Context, provider *models.ProviderAccount, req *models.ProviderEventSubmission) (ResponseBody, int) {
+	if provider.VenueID == "" {
+		return ResponseBody{}, 0
+	}
+
+	venue, err := dynamoService.GetVenue(ctx, provider.VenueID)
+	if err != nil || venue == nil {
+		log.Printf("Error getting venue %s of provider %s: %v", provider.VenueID, provider.ProviderID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to load the provider's venue",
+		}, 500
+	}
+	req.ApplyVenue(venue)
+	return ResponseBody{}, 0
+}
+
+// handleProviderSubmitEvent handles POST /api/provider/events. Submissions join the pending
+// review queue like crawled events.
+func handleProviderSubmitEvent(ctx context.Context, headers map[string]string, body string) (ResponseBody, int) {
+	provider, response, statusCode := authenticateProvider(ctx, headers)
+	if provider == nil {
+		return response, statusCode
+	}
+
+	var req models.ProviderEventSubmission
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if response, statusCode := scopeSubmissionToVenue(ctx, provider, &req); statusCode != 0 {
+		return response, statusCode
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	schema, err := models.GetSchemaByType("events")
+	if err != nil {
+		log.Printf("Error getting events schema: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to submit event",
+		}, 500
+	}
+
+	adminEvent := &models.AdminEvent{
+		EventID:          uuid.New().String(),
+		SourceURL:        provider.Website,
+		SchemaType:       "events",
+		SchemaUsed:       schema.Schema,
+		RawExtractedData: models.ProviderEventRawData(req.EventData()),
+		Status:           models.AdminEventStatusPending,
+		ExtractedByUser:  models.ProviderUser(provider.ProviderID),
+		SubmissionID:     uuid.New().String(),
+		ProviderID:       provider.ProviderID,
+	}
+	refreshConversionPreview(ctx, adminEvent)
+
+	if err := dynamoService.CreateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error storing event submitted by provider %s: %v", provider.ProviderID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to submit event",
+		}, 500
+	}
+
+	log.Printf("Provider %s submitted event %s", provider.ProviderID, adminEvent.EventID)
+	return ResponseBody{
+		Success: true,
+		Message: "Event submitted for review",
+		Data:    providerEventView(adminEvent),
+	}, 201
+}
+
+// handleProviderListEvents handles GET /api/provider/events
+func handleProviderListEvents(ctx context.Context, headers map[string]string) (ResponseBody, int) {
+	provider, response, statusCode := authenticateProvider(ctx, headers)
+	if provider == nil {
+		return response, statusCode
+	}
+
+	events, err := dynamoService.GetAdminEventsByProvider(ctx, provider.ProviderID)
+	if err != nil {
+		log.Printf("Error getting events of provider %s: %v", provider.ProviderID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve events",
+		}, 500
+	}
+
+	views := make([]map[string]interface{}, 0, len(events))
+	for i := range events {
+		views = append(views, providerEventView(&events[i]))
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d events", len(views)),
+		Data: map[string]interface{}{
+			"events": views,
+			"count":  len(views),
+		},
+	}, 200
+}
+
+// handleProviderUpdateEvent handles PUT /api/provider/events/{id}. A submission still awaiting
+// review is replaced; an update to a published event waits for admin review.
+func handleProviderUpdateEvent(ctx context.Context, headers map[string]string, eventID string, body string) (ResponseBody, int) {
+	provider, response, statusCode := authenticateProvider(ctx, headers)
+	if provider == nil {
+		return response, statusCode
+	}
+
+	var req models.ProviderEventSubmission
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if response, statusCode := scopeSubmissionToVenue(ctx, provider, &req); statusCode != 0 {
+		return response, statusCode
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	adminEvent, response, statusCode := getProviderEvent(ctx, provider, eventID)
+	if adminEvent == nil {
+		return response, statusCode
+	}
+
+	var message string
+	switch {
+	case adminEvent.IsPending():
+		adminEvent.RawExtractedData = models.ProviderEventRawData(req.EventData())
+		refreshConversionPreview(ctx, adminEvent)
+		message = "Submission updated"
+	case adminEvent.IsApproved():
+		adminEvent.PendingChange = &models.ProviderEventChange{
+			Action:      models.ProviderChangeUpdate,
+			EventData:   req.EventData(),
+			RequestedAt: time.Now(),
+		}
+		message = "Update submitted for review - the published event is unchanged until it is approved"
+	default:
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Event can no longer be changed (status: %s)", adminEvent.Status),
+		}, 409
+	}
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error updating event %s for provider %s: %v", eventID, provider.ProviderID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update event",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: message,
+		Data:    providerEventView(adminEvent),
+	}, 200
+}
+
+// handleProviderCancelEvent handles DELETE /api/provider/events/{id}. A submission still awaiting
+// review is withdrawn; cancelling a published event waits for admin review.
+func handleProviderCancelEvent(ctx context.Context, headers map[string]string, eventID string, body string) (ResponseBody, int) {
+	provider, response, statusCode := authenticateProvider(ctx, headers)
+	if provider == nil {
+		return response, statusCode
+	}
+
+	// The cancellation reason is optional
+	var req ProviderCancelRequest
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid request body: " + err.Error(),
+			}, 400
+		}
+	}
+
+	adminEvent, response, statusCode := getProviderEvent(ctx, provider, eventID)
+	if adminEvent == nil {
+		return response, statusCode
+	}
+
+	var message string
+	switch {
+	case adminEvent.IsPending():
+		adminEvent.Status = models.AdminEventStatusCancelled
+		message = "Submission withdrawn"
+	case adminEvent.IsApproved():
+		adminEvent.PendingChange = &models.ProviderEventChange{
+			Action:      models.ProviderChangeCancel,
+			Reason:      req.Reason,
+			RequestedAt: time.Now(),
+		}
+		message = "Cancellation submitted for review - the event stays published until it is approved"
+	default:
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Event can no longer be cancelled (status: %s)", adminEvent.Status),
+		}, 409
+	}
+
+	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+		log.Printf("Error cancelling event %s for provider %s: %v", eventID, provider.ProviderID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to cancel event",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: message,
+		Data:    providerEventView(adminEvent),
+	}, 200
+}
+
+// venueClaimsDisabled is the response to claim requests when no claim email sender is configured
+var venueClaimsDisabled = ResponseBody{
+	Success: false,
+	Error:   "Venue claims are not enabled",
+}
+
+// venueClaimView is what claimants see of their claim
+func venueClaimView(claim *models.VenueClaim) map[string]interface{} {
+	return map[string]interface{}{
+		"claim_id":        claim.ClaimID,
+		"venue_id":        claim.VenueID,
+		"venue_name":      claim.VenueName,
+		"claimant_email":  claim.ClaimantEmail,
+		"status":          claim.Status,
+		"code_expires_at": claim.CodeExpiresAt,
+	}
+}
+
+// venueClaimSaveFailed is the response when storing a claim step fails
+func venueClaimSaveFailed(claimID string, err error) (ResponseBody, int) {
+	if errors.Is(err, services.ErrVenueClaimChanged) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Claim was changed by another request - reload and try again",
+		}, 409
+	}
+	log.Printf("Error saving venue claim %s: %v", claimID, err)
+	return ResponseBody{
+		Success: false,
+		Error:   "Failed to save claim",
+	}, 500
+}
+
+// venueClaimCodeEmail is the email carrying a claim's verification code
+func venueClaimCodeEmail(claim *models.VenueClaim, code string) (subject, body string) {
+	subject = fmt.Sprintf("Your verification code for %s", claim.VenueName)
+	body = fmt.Sprintf("Hi %s,\n\n"+
+		"Someone asked to manage the listings of %s on Seattle Family Activities with this email address.\n\n"+
+		"Your verification code is %s. It expires in %d hours.\n\n"+
+		"Claim ID: %s\n\n"+
+		"If you did not request this, you can ignore this email.\n",
+		claim.ClaimantName, claim.VenueName, code, int(models.VenueClaimCodeTTL.Hours()), claim.ClaimID)
+	return subject, body
+}
+
+// venueClaimApprovedEmail is the email delivering the provider token of an approved claim
+func venueClaimApprovedEmail(claim *models.VenueClaim, token string) (subject, body string) {
+	subject = fmt.Sprintf("Your claim of %s was approved", claim.VenueName)
+	body = fmt.Sprintf("Hi %s,\n\n"+
+		"Your claim of %s was approved. Use this provider token to submit and manage the venue's events "+
+		"through the provider API:\n\n%s\n\n"+
+		"Keep it secret - anyone with the token can publish events for the venue. It is only sent once.\n",
+		claim.ClaimantName, claim.VenueName, token)
+	return subject, body
+}
+
+// handleCreateVenueClaim handles POST /api/venues/{id}/claims - Public endpoint. The claimant's
+// email must be on the venue website's domain; a verification code is emailed to it.
+func handleCreateVenueClaim(ctx context.Context, venueID string, body string) (ResponseBody, int) {
+	if claimMailer == nil {
+		return venueClaimsDisabled, 503
+	}
+
+	var req models.VenueClaimRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	venue, err := dynamoService.GetVenue(ctx, venueID)
+	if err != nil {
+		log.Printf("Error getting venue %s: %v", venueID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve venue",
+		}, 500
+	}
+	if venue == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Venue not found",
+		}, 404
+	}
+
+	website := venue.ClaimWebsite()
+	if website == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Venue has no website to verify a claim against",
+		}, 409
+	}
+	if !models.EmailMatchesWebsite(req.ClaimantEmail, website) {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Validation error: claimant_email must be an address on the venue's domain (%s)", urlutil.RegistrableDomain(website)),
+		}, 400
+	}
+
+	existing, err := dynamoService.ListVenueClaims(ctx, venueID, "")
+	if err != nil {
+		log.Printf("Error getting claims of venue %s: %v", venueID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create claim",
+		}, 500
+	}
+	for _, other := range existing {
+		switch {
+		case other.Status == models.VenueClaimStatusApproved:
+			provider, err := dynamoService.GetProviderAccount(ctx, other.ProviderID)
+			if err == nil && provider != nil && provider.IsActive() {
+				return ResponseBody{
+					Success: false,
+					Error:   "Venue has already been claimed",
+				}, 409
+			}
+		case other.ClaimantEmail == req.ClaimantEmail && (other.Status == models.VenueClaimStatusPendingVerification || other.IsDecidable()):
+			return ResponseBody{
+				Success: false,
+				Error:   fmt.Sprintf("A claim by this email is already open (claim %s)", other.ClaimID),
+			}, 409
+		}
+	}
+
+	code, err := models.GenerateVenueClaimCode()
+	if err != nil {
+		log.Printf("Error generating venue claim code: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to create claim",
+		}, 500
+	}
+
+	now := time.Now()
+	venueName := venue.VenueName
+	if venueName == "" {
+		venueName = venue.Name
+	}
+	claim := &models.VenueClaim{
+		ClaimID:       uuid.New().String(),
+		VenueID:       venueID,
+		VenueName:     venueName,
+		VenueWebsite:  website,
+		ClaimantName:  strings.TrimSpace(req.ClaimantName),
+		ClaimantEmail: req.ClaimantEmail,
+		ClaimantRole:  strings.TrimSpace(req.ClaimantRole),
+		Message:       strings.TrimSpace(req.Message),
+		Status:        models.VenueClaimStatusPendingVerification,
+		CodeExpiresAt: now.Add(models.VenueClaimCodeTTL),
+	}
+	claim.CodeHash = models.HashVenueClaimCode(claim.ClaimID, code)
+
+	// The code is sent before the claim is stored so a claim never waits on a code that wasn't delivered
+	subject, message := venueClaimCodeEmail(claim, code)
+	if err := claimMailer.Send(ctx, claim.ClaimantEmail, subject, message); err != nil {
+		log.Printf("Error sending verification code for claim of venue %s: %v", venueID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to send the verification email",
+		}, 502
+	}
+
+	audit := models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionRequested, claim.ClaimantEmail, "verification code sent", now)
+	if err := dynamoService.SaveVenueClaim(ctx, claim, "", audit); err != nil {
+		return venueClaimSaveFailed(claim.ClaimID, err)
+	}
+
+	log.Printf("Venue claim %s requested for venue %s by %s", claim.ClaimID, venueID, claim.ClaimantEmail)
+	return ResponseBody{
+		Success: true,
+		Message: "Claim created - enter the code sent to " + claim.ClaimantEmail,
+		Data:    venueClaimView(claim),
+	}, 201
+}
+
+// handleVerifyVenueClaim handles POST /api/venue-claims/{id}/verify - Public endpoint. A verified
+// claim waits for an admin decision; the code stops working after it expires or after too many
+// wrong attempts.
+func handleVerifyVenueClaim(ctx context.Context, claimID string, body string) (ResponseBody, int) {
+	if claimMailer == nil {
+		return venueClaimsDisabled, 503
+	}
+
+	var req models.VenueClaimVerification
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if req.Code == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: code is required",
+		}, 400
+	}
+
+	claim, err := dynamoService.GetVenueClaim(ctx, claimID)
+	if err != nil {
+		log.Printf("Error getting venue claim %s: %v", claimID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve claim",
+		}, 500
+	}
+	if claim == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Claim not found",
+		}, 404
+	}
+	if claim.Status != models.VenueClaimStatusPendingVerification {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Claim is not waiting for verification (status: %s)", claim.Status),
+		}, 409
+	}
+
+	now := time.Now()
+	var audit *models.VenueClaimAuditEntry
+	switch {
+	case claim.CodeExpired(now):
+		claim.Status = models.VenueClaimStatusExpired
+		audit = models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionExpired, claim.ClaimantEmail, "verification code expired", now)
+	case !claim.CheckCode(req.Code):
+		claim.CodeAttempts++
+		audit = models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionVerificationFailed, claim.ClaimantEmail,
+			fmt.Sprintf("incorrect code (attempt %d of %d)", claim.CodeAttempts, models.VenueClaimMaxCodeAttempts), now)
+		if claim.CodeExpired(now) {
+			claim.Status = models.VenueClaimStatusExpired
+			audit.Status = claim.Status
+		}
+	default:
+		claim.Status = models.VenueClaimStatusVerified
+		claim.VerifiedAt = &now
+		audit = models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionVerified, claim.ClaimantEmail, "email address verified", now)
+	}
+
+	if err := dynamoService.SaveVenueClaim(ctx, claim, models.VenueClaimStatusPendingVerification, audit); err != nil {
+		return venueClaimSaveFailed(claimID, err)
+	}
+
+	switch {
+	case claim.Status == models.VenueClaimStatusExpired:
+		return ResponseBody{
+			Success: false,
+			Error:   "Verification code is no longer valid - submit a new claim",
+			Data:    venueClaimView(claim),
+		}, 410
+	case claim.Status == models.VenueClaimStatusPendingVerification:
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Incorrect verification code - %d attempts left", models.VenueClaimMaxCodeAttempts-claim.CodeAttempts),
+		}, 400
+	}
+
+	log.Printf("Venue claim %s verified", claimID)
+	return ResponseBody{
+		Success: true,
+		Message: "Email verified - the claim is waiting for review",
+		Data:    venueClaimView(claim),
+	}, 200
+}
+
+// handleListVenueClaims handles GET /api/venue-claims, optionally filtered by status and venue_id
+func handleListVenueClaims(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	claims, err := dynamoService.ListVenueClaims(ctx, queryParams["venue_id"], queryParams["status"])
+	if err != nil {
+		log.Printf("Error listing venue claims: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve claims",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d claims", len(claims)),
+		Data: map[string]interface{}{
+			"claims": claims,
+			"count":  len(claims),
+		},
+	}, 200
+}
+
+// handleGetVenueClaim handles GET /api/venue-claims/{id}, including the claim's audit trail
+func handleGetVenueClaim(ctx context.Context, claimID string) (ResponseBody, int) {
+	claim, err := dynamoService.GetVenueClaim(ctx, claimID)
+	if err != nil {
+		log.Printf("Error getting venue claim %s: %v", claimID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve claim",
+		}, 500
+	}
+	if claim == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Claim not found",
+		}, 404
+	}
+
+	audit, err := dynamoService.GetVenueClaimAudit(ctx, claimID)
+	if err != nil {
+		log.Printf("Error getting audit trail of venue claim %s: %v", claimID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve claim",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Claim retrieved",
+		Data: map[string]interface{}{
+			"claim": claim,
+			"audit": audit,
+		},
+	}, 200
+}
+
+// getDecidableVenueClaim reads an admin decision and the claim it applies to
+func getDecidableVenueClaim(ctx context.Context, claimID string, body string) (*models.VenueClaim, *models.VenueClaimDecision, ResponseBody, int) {
+	var decision models.VenueClaimDecision
+	if err := json.Unmarshal([]byte(body), &decision); err != nil {
+		return nil, nil, ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := decision.Validate(); err != nil {
+		return nil, nil, ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	claim, err := dynamoService.GetVenueClaim(ctx, claimID)
+	if err != nil {
+		log.Printf("Error getting venue claim %s: %v", claimID, err)
+		return nil, nil, ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve claim",
+		}, 500
+	}
+	if claim == nil {
+		return nil, nil, ResponseBody{
+			Success: false,
+			Error:   "Claim not found",
+		}, 404
+	}
+	return claim, &decision, ResponseBody{}, 0
+}
+
+// handleApproveVenueClaim handles PUT /api/venue-claims/{id}/approve. A provider account scoped to
+// the venue is issued and its token is emailed to the claimant; nothing is stored if the email fails.
+func handleApproveVenueClaim(ctx context.Context, claimID string, body string) (ResponseBody, int) {
+	if claimMailer == nil {
+		return venueClaimsDisabled, 503
+	}
+
+	claim, decision, response, statusCode := getDecidableVenueClaim(ctx, claimID, body)
+	if claim == nil {
+		return response, statusCode
+	}
+	if !claim.IsDecidable() {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Only verified claims can be approved (status: %s)", claim.Status),
+		}, 409
+	}
+
+	providerID := generateSourceID(claim.VenueName)
+	token, tokenHash, err := models.GenerateProviderToken(providerID)
+	if err != nil {
+		log.Printf("Error generating provider token: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to generate provider token",
+		}, 500
+	}
+	account := &models.ProviderAccount{
+		ProviderID:   providerID,
+		ProviderName: claim.VenueName,
+		Website:      claim.VenueWebsite,
+		ContactEmail: claim.ClaimantEmail,
+		Status:       models.ProviderStatusActive,
+		VenueID:      claim.VenueID,
+		TokenHash:    tokenHash,
+		TokenPrefix:  models.ProviderTokenDisplayPrefix(token),
+		CreatedBy:    decision.DecidedBy,
+	}
+
+	subject, message := venueClaimApprovedEmail(claim, token)
+	if err := claimMailer.Send(ctx, claim.ClaimantEmail, subject, message); err != nil {
+		log.Printf("Error sending provider token for venue claim %s: %v", claimID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to email the provider token - the claim was not approved",
+		}, 502
+	}
+
+	now := time.Now()
+	claim.Status = models.VenueClaimStatusApproved
+	claim.DecidedBy = decision.DecidedBy
+	claim.DecidedAt = &now
+	claim.DecisionNotes = decision.Notes
+	claim.ProviderID = providerID
+	audit := models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionApproved, decision.DecidedBy,
+		fmt.Sprintf("provider %s issued (token %s)", providerID, account.TokenPrefix), now)
+	if err := dynamoService.ApproveVenueClaim(ctx, claim, account, audit); err != nil {
+		return venueClaimSaveFailed(claimID, err)
+	}
+
+	log.Printf("Venue claim %s approved by %s, provider %s issued for venue %s", claimID, decision.DecidedBy, providerID, claim.VenueID)
+	return ResponseBody{
+		Success: true,
+		Message: "Claim approved - the provider token was emailed to " + claim.ClaimantEmail,
+		Data: map[string]interface{}{
+			"claim":    claim,
+			"provider": account,
+		},
+	}, 200
+}
+
+// handleRejectVenueClaim handles PUT /api/venue-claims/{id}/reject. Claims can be rejected before
+// or after the claimant verified their email.
+func handleRejectVenueClaim(ctx context.Context, claimID string, body string) (ResponseBody, int) {
+	claim, decision, response, statusCode := getDecidableVenueClaim(ctx, claimID, body)
+	if claim == nil {
+		return response, statusCode
+	}
+	fromStatus := claim.Status
+	if fromStatus != models.VenueClaimStatusPendingVerification && !claim.IsDecidable() {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Claim has already been decided (status: %s)", claim.Status),
+		}, 409
+	}
+
+	now := time.Now()
+	claim.Status = models.VenueClaimStatusRejected
+	claim.DecidedBy = decision.DecidedBy
+	claim.DecidedAt = &now
+	claim.DecisionNotes = decision.Notes
+	audit := models.NewVenueClaimAuditEntry(claim, models.VenueClaimActionRejected, decision.DecidedBy, decision.Notes, now)
+	if err := dynamoService.SaveVenueClaim(ctx, claim, fromStatus, audit); err != nil {
+		return venueClaimSaveFailed(claimID, err)
+	}
+
+	log.Printf("Venue claim %s rejected by %s", claimID, decision.DecidedBy)
+	return ResponseBody{
+		Success: true,
+		Message: "Claim rejected",
+		Data: map[string]interface{}{
+			"claim": claim,
+		},
+	}, 200
+}
+
+// handleGetSchemas handles GET /api/schemas
+func handleGetSchemas(ctx context.Context) (ResponseBody, int) {
+	schemas := models.GetPredefinedSchemas()
+
+	// Format schemas for frontend consumption
+	formattedSchemas := make(map[string]interface{})
+	for key, schema := range schemas {
+		formattedSchemas[key] = map[string]interface{}{
+			"name":        schema.Name,
+			"description": schema.Description,
+			"examples":    schema.Examples,
+			"schema":      schema.Schema,
+		}
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Available extraction schemas",
+		Data:    formattedSchemas,
+	}, 200
+}
+
+// handleGetValidationRules handles GET /api/validation-rules/{schema_type}
+func handleGetValidationRules(ctx context.Context, schemaType string) (ResponseBody, int) {
+	ruleSet, err := dynamoService.GetValidationRuleSet(ctx, schemaType)
+	if err != nil {
+		log.Printf("Error getting validation rules: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to get validation rules",
+		}, 500
+	}
+
+	isDefault := ruleSet == nil
+	if isDefault {
+		ruleSet = services.DefaultValidationRuleSet(schemaType)
+		if err := ruleSet.Validate(); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Validation rules retrieved successfully",
+		Data: map[string]interface{}{
+			"rule_set":   ruleSet,
+			"is_default": isDefault,
+		},
+	}, 200
+}
+
+// handleUpdateValidationRules handles PUT /api/validation-rules/{schema_type}
+func handleUpdateValidationRules(ctx context.Context, schemaType string, body string) (ResponseBody, int) {
+	var ruleSet models.ValidationRuleSet
+	if err := json.Unmarshal([]byte(body), &ruleSet); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	ruleSet.SchemaType = schemaType
+	if err := ruleSet.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid validation rules: " + err.Error(),
+		}, 400
+	}
+
+	existing, err := dynamoService.GetValidationRuleSet(ctx, schemaType)
+	if err != nil {
+		log.Printf("Error getting validation rules: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update validation rules",
+		}, 500
+	}
+	ruleSet.Version = 1
+	if existing != nil {
+		ruleSet.Version = existing.Version + 1
+	}
+
+	if err := dynamoService.PutValidationRuleSet(ctx, &ruleSet); err != nil {
+		log.Printf("Error storing validation rules: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update validation rules",
+		}, 500
+	}
+	validationRules.Invalidate(schemaType)
+
+	log.Printf("Validation rules for %s updated to version %d by %s", schemaType, ruleSet.Version, ruleSet.UpdatedBy)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Validation rules updated successfully",
+		Data:    ruleSet,
+	}, 200
+}
+
+// handleGetConcurrencySettings handles GET /api/settings/concurrency
+func handleGetConcurrencySettings(ctx context.Context) (ResponseBody, int) {
+	settings, err := dynamoService.GetConcurrencySettings(ctx)
+	if err != nil {
+		log.Printf("Error getting concurrency settings: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to get concurrency settings",
+		}, 500
+	}
+
+	isDefault := settings == nil
+	if isDefault {
+		settings = models.NewDefaultConcurrencySettings()
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Concurrency settings retrieved successfully",
+		Data: map[string]interface{}{
+			"settings":       settings,
+			"is_default":     isDefault,
+			"throttle_stats": concurrencyLimiter.Stats().Snapshot(),
+		},
+	}, 200
+}
+
+// handleUpdateConcurrencySettings handles PUT /api/settings/concurrency
+func handleUpdateConcurrencySettings(ctx context.Context, body string) (ResponseBody, int) {
+	var settings models.ConcurrencySettings
+	if err := json.Unmarshal([]byte(body), &settings); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	if settings.LeaseSeconds == 0 {
+		settings.LeaseSeconds = models.DefaultConcurrencyLeaseSeconds
+	}
+	if err := settings.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid concurrency settings: " + err.Error(),
+		}, 400
+	}
+
+	existing, err := dynamoService.GetConcurrencySettings(ctx)
+	if err != nil {
+		log.Printf("Error getting concurrency settings: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update concurrency settings",
+		}, 500
+	}
+	settings.Version = 1
+	if existing != nil {
+		settings.Version = existing.Version + 1
+	}
+
+	if err := dynamoService.PutConcurrencySettings(ctx, &settings); err != nil {
+		log.Printf("Error storing concurrency settings: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update concurrency settings",
+		}, 500
+	}
+	concurrencySettings.Invalidate()
+
+	log.Printf("Concurrency settings updated to version %d by %s: %v", settings.Version, settings.UpdatedBy, settings.Limits)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Concurrency settings updated successfully",
+		Data:    settings,
+	}, 200
+}
+
+// pipelineTransition is a status transition with the role an admin API key needs to make it, or
+// none when the pipeline or a provider makes it
+type pipelineTransition struct {
+	models.StatusTransition
+	Role string `json:"role,omitempty"`
+}
+
+// pipelineStateMachine is a state machine as GET /api/meta/pipeline describes it
+type pipelineStateMachine struct {
+	Entity      string                    `json:"entity"`
+	Statuses    []models.StatusDefinition `json:"statuses"`
+	Transitions []pipelineTransition      `json:"transitions"`
+}
+
+// handleGetPipelineStates handles GET /api/meta/pipeline: the statuses of sources, scraping tasks
+// and admin events, and the transitions allowed between them
+func handleGetPipelineStates() (ResponseBody, int) {
+	machines := make([]pipelineStateMachine, 0, len(models.PipelineStateMachines()))
+	for _, machine := range models.PipelineStateMachines() {
+		described := pipelineStateMachine{Entity: machine.Entity, Statuses: machine.Statuses}
+		for _, transition := range machine.Transitions {
+			role := ""
+			if transition.Method != "" && !isPublicRoute(transition.Method, transition.Path) {
+				role = requiredAdminRole(transition.Method, transition.Path)
+			}
+			described.Transitions = append(described.Transitions, pipelineTransition{StatusTransition: transition, Role: role})
+		}
+		machines = append(machines, described)
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Pipeline state machines retrieved successfully",
+		Data: map[string]interface{}{
+			"state_machines": machines,
 		},
-		DataQuality: models.DataQuality{
-			ReliabilityScore: analysis.OverallQualityScore,
-			ExpectedItemsRange: models.ItemRange{
-				Min: 5,
-				Max: 50,
-			},
+	}, 200
+}
+
+// handleGetModelSchemas handles GET /api/meta/models: JSON Schemas generated from the Activity,
+// Venue, Program and AdminEvent structs, so clients can check their types against the API.
+// ?model= returns only one model's schema.
+func handleGetModelSchemas(queryParams map[string]string) (ResponseBody, int) {
+	schemas := models.ModelSchemas()
+	if name := queryParams["model"]; name != "" {
+		schema, ok := schemas[name]
+		if !ok {
+			return ResponseBody{
+				Success: false,
+				Error:   fmt.Sprintf("Unknown model: %s", name),
+			}, 404
+		}
+		schemas = map[string]*models.JSONSchema{name: schema}
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Model schemas retrieved successfully",
+		Data: map[string]interface{}{
+			"schemas": schemas,
 		},
-		AdaptiveFrequency: models.AdaptiveFrequency{
-			BaseFrequency:    analysis.RecommendedConfig.ScrapingFrequency,
-			CurrentFrequency: analysis.RecommendedConfig.ScrapingFrequency,
+	}, 200
+}
+
+// handleGetFeatureFlags handles GET /api/settings/feature-flags
+func handleGetFeatureFlags(ctx context.Context) (ResponseBody, int) {
+	settings, err := dynamoService.GetFeatureFlagSettings(ctx)
+	if err != nil {
+		log.Printf("Error getting feature flags: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to get feature flags",
+		}, 500
+	}
+
+	isDefault := settings == nil
+	if isDefault {
+		settings = models.NewDefaultFeatureFlagSettings()
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Feature flags retrieved successfully",
+		Data: map[string]interface{}{
+			"settings":   settings,
+			"is_default": isDefault,
+			"states":     settings.States(),
 		},
-		Status:       models.SourceStatusActive,
-		ActivatedBy:  "admin",
-		ActivatedAt:  now,
-		LastModified: now,
-		StatusKey:    models.GenerateSourceStatusKey(models.SourceStatusActive),
-	}, nil
+	}, 200
 }
 
-func createInitialScrapingTask(ctx context.Context, sourceID string, analysis *models.SourceAnalysis) error {
-	taskID := uuid.New().String()
+// handleUpdateFeatureFlags handles PUT /api/settings/feature-flags
+// The body replaces every flag; flags left out are turned off.
+func handleUpdateFeatureFlags(ctx context.Context, body string) (ResponseBody, int) {
+	var settings models.FeatureFlagSettings
+	if err := json.Unmarshal([]byte(body), &settings); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+
+	if settings.Flags == nil {
+		settings.Flags = map[string]models.FeatureFlag{}
+	}
+	if err := settings.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid feature flags: " + err.Error(),
+		}, 400
+	}
+
+	existing, err := dynamoService.GetFeatureFlagSettings(ctx)
+	if err != nil {
+		log.Printf("Error getting feature flags: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update feature flags",
+		}, 500
+	}
+	settings.Version = 1
+	if existing != nil {
+		settings.Version = existing.Version + 1
+	}
+
+	if err := dynamoService.PutFeatureFlagSettings(ctx, &settings); err != nil {
+		log.Printf("Error storing feature flags: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update feature flags",
+		}, 500
+	}
+
+	log.Printf("Feature flags updated to version %d by %s: %+v", settings.Version, settings.UpdatedBy, settings.Flags)
+
+	return ResponseBody{
+		Success: true,
+		Message: "Feature flags updated successfully",
+		Data:    settings,
+	}, 200
+}
+
+// reviewFiltersFromQuery builds the review filters for a pending queue: the saved preset named
+// by preset (owned by admin), overridden by any filter query parameters. It returns the
+// status code to respond with when the filters cannot be built.
+func reviewFiltersFromQuery(ctx context.Context, target string, queryParams map[string]string) (models.ReviewFilters, int, error) {
+	var filters models.ReviewFilters
+	if presetID := queryParams["preset"]; presetID != "" {
+		preset, err := dynamoService.GetReviewPreset(ctx, queryParams["admin"], presetID)
+		if err != nil {
+			log.Printf("Error getting review preset: %v", err)
+			return filters, 500, fmt.Errorf("failed to load review preset")
+		}
+		if preset == nil {
+			return filters, 404, fmt.Errorf("review preset %s not found", presetID)
+		}
+		if preset.Target != target {
+			return filters, 400, fmt.Errorf("review preset %s applies to %s, not %s", presetID, preset.Target, target)
+		}
+		filters = preset.Filters
+	}
+
+	overrides := models.ReviewFilters{
+		SchemaType:   queryParams["schema_type"],
+		SourceDomain: queryParams["source_domain"],
+		ExtractedBy:  queryParams["extracted_by"],
+		SourceType:   queryParams["source_type"],
+		Priority:     queryParams["priority"],
+		Sort:         queryParams["sort"],
+		Order:        queryParams["order"],
+	}
+	for param, bound := range map[string]**float64{
+		"min_confidence": &overrides.MinConfidence,
+		"max_confidence": &overrides.MaxConfidence,
+	} {
+		if value := queryParams[param]; value != "" {
+			parsed, err := queryparams.ParseFloat(param, value, 0, 100)
+			if err != nil {
+				return filters, 400, err
+			}
+			*bound = &parsed
+		}
+	}
+
+	filters = filters.Merge(overrides)
+	if err := filters.Validate(target); err != nil {
+		return filters, 400, fmt.Errorf("invalid review filters: %w", err)
+	}
+	return filters, 200, nil
+}
+
+// recordAdminAction logs an admin's review action to the audit log behind GET /api/admin/me/stats.
+// Actions taken without naming the admin are not attributed to anyone.
+func recordAdminAction(ctx context.Context, admin, action, targetID string, at time.Time, latency time.Duration) {
+	if admin == "" {
+		return
+	}
+	entry := models.NewAdminAuditEntry(admin, action, targetID, at)
+	if latency > 0 {
+		entry.LatencySeconds = latency.Seconds()
+	}
+	if err := dynamoService.CreateAdminAuditEntry(ctx, entry); err != nil {
+		log.Printf("Warning: Failed to log %s of %s by %s: %v", action, targetID, admin, err)
+	}
+}
+
+// proposeAction holds a high-impact action until a second admin confirms it with
+// POST /api/approvals/{id}/confirm. The request is kept as sent and carried out on confirmation.
+func proposeAction(ctx context.Context, action, targetID, summary, request string) (ResponseBody, int) {
+	proposer := requestAdminKey(ctx)
+	if proposer == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "This action must be proposed with an admin API key",
+		}, 403
+	}
+
 	now := time.Now()
+	proposal := models.NewApprovalProposal(uuid.New().String(), action, targetID, summary, request, proposer, now)
+	audit := models.NewApprovalAuditEntry(proposal, models.ApprovalAuditProposed, proposer.Name, summary, now)
+	if err := dynamoService.SaveApprovalProposal(ctx, proposal, "", audit); err != nil {
+		log.Printf("Error saving %s proposal by %s: %v", action, proposer.Name, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save the proposal",
+		}, 500
+	}
+	recordAdminAction(ctx, proposer.Name, models.AdminAuditApprovalProposed, proposal.ProposalID, now, 0)
 
-	task := &models.ScrapingTask{
-		PK:            models.CreateTaskPK(taskID),
-		SK:            models.CreateTaskSK("high", sourceID, taskID),
-		TaskID:        taskID,
-		SourceID:      sourceID,
-		TaskType:      models.TaskTypeFullScrape,
-		Priority:      models.TaskPriorityHigh,
-		ScheduledTime: now.Add(5 * time.Minute), // Schedule 5 minutes from now
-		TargetURLs:    analysis.RecommendedConfig.TargetURLs,
-		ExtractionRules: analysis.RecommendedConfig.BestSelectors,
-		RateLimits:      analysis.RecommendedConfig.RateLimit,
-		Timeout:         300, // 5 minutes
-		MaxRetries:      3,
-		Status:          models.TaskStatusScheduled,
-		RetryCount:      0,
-		EstimatedDuration: 120, // 2 minutes
-		Dependencies:      []string{},
-		CreatedAt:         now,
-		UpdatedAt:         now,
-		TTL:               models.CalculateTaskTTL(now, 90), // 90 days retention
-		NextRunKey:        models.GenerateNextRunKey(now.Add(5 * time.Minute)),
-		PrioritySourceKey: models.GenerateTaskPrioritySourceKey("high", sourceID),
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Proposed - a second admin must confirm it by %s", proposal.ExpiresAt.UTC().Format(time.RFC3339)),
+		Data:    proposal,
+	}, 202
+}
+
+// handleBulkDeleteActivities handles POST /api/activities/bulk-delete. The activities of the
+// listed approved events are removed from the catalog and the events marked rejected with the
+// reason. Under the two-person rule, they are only removed once a second admin confirms.
+func handleBulkDeleteActivities(ctx context.Context, body string) (ResponseBody, int) {
+	return bulkDeleteActivities(ctx, body, twoPersonRule)
+}
+
+// bulkDeleteActivities removes the activities of approved events, or proposes their removal when
+// it needs confirmation
+func bulkDeleteActivities(ctx context.Context, body string, needsConfirmation bool) (ResponseBody, int) {
+	var req models.ActivityBulkDeleteRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if err := req.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Validation error: " + err.Error(),
+		}, 400
+	}
+
+	adminEvents, err := dynamoService.GetAdminEventsByIDs(ctx, req.EventIDs, 10)
+	if err != nil {
+		log.Printf("Error getting events for bulk delete: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve events",
+		}, 500
+	}
+	published := []models.AdminEvent{}
+	failed := []map[string]interface{}{}
+	found := make(map[string]bool, len(adminEvents))
+	for _, adminEvent := range adminEvents {
+		found[adminEvent.EventID] = true
+		if adminEvent.Status != models.AdminEventStatusApproved {
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    fmt.Sprintf("Event is %s, not approved", adminEvent.Status),
+			})
+			continue
+		}
+		published = append(published, adminEvent)
+	}
+	for _, eventID := range req.EventIDs {
+		if !found[eventID] {
+			failed = append(failed, map[string]interface{}{
+				"event_id": eventID,
+				"error":    "Event not found",
+			})
+		}
+	}
+	if len(published) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "None of the events are published",
+			Data:    map[string]interface{}{"failed": failed},
+		}, 404
+	}
+
+	if needsConfirmation {
+		summary := fmt.Sprintf("Delete %d published activities: %s", len(published), req.Reason)
+		return proposeAction(ctx, models.ApprovalActionBulkDeleteActivities, "", summary, body)
+	}
+
+	now := time.Now()
+	deleted := []map[string]interface{}{}
+	for i := range published {
+		adminEvent := &published[i]
+		adminEvent.Status = models.AdminEventStatusRejected
+		adminEvent.ReviewedAt = &now
+		adminEvent.ReviewedBy = req.DeletedBy
+		adminEvent.AdminNotes = req.Reason
+
+		// The public catalog is read from approved events, so the event is rejected first
+		err := dynamoService.UpdateAdminEventFromStatus(ctx, adminEvent, models.AdminEventStatusApproved)
+		if errors.Is(err, services.ErrAdminEventChanged) {
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    "Event changed during the bulk delete",
+			})
+			continue
+		}
+		if err != nil {
+			log.Printf("Error rejecting event %s in bulk delete: %v", adminEvent.EventID, err)
+			failed = append(failed, map[string]interface{}{
+				"event_id": adminEvent.EventID,
+				"error":    "Failed to remove event",
+			})
+			continue
+		}
+		if adminEvent.ActivityID != "" {
+			if err := dynamoService.DeleteActivity(ctx, adminEvent.ActivityID); err != nil {
+				log.Printf("Warning: Failed to remove the activity of deleted event %s: %v", adminEvent.EventID, err)
+			}
+		}
+
+		recordAdminAction(ctx, req.DeletedBy, models.AdminAuditActivityDeleted, adminEvent.EventID, now, 0)
+		deleted = append(deleted, map[string]interface{}{
+			"event_id":    adminEvent.EventID,
+			"activity_id": adminEvent.ActivityID,
+		})
+	}
+
+	log.Printf("Bulk delete by %s removed %d of %d activities: %s", req.DeletedBy, len(deleted), len(req.EventIDs), req.Reason)
+
+	data := map[string]interface{}{
+		"deleted": deleted,
+		"failed":  failed,
+	}
+	if len(deleted) == 0 {
+		return ResponseBody{
+			Success: false,
+			Error:   "None of the activities could be deleted",
+			Data:    data,
+		}, 409
+	}
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Deleted %d activities", len(deleted)),
+		Data:    data,
+	}, 200
+}
+
+// expireApprovalIfDue marks a pending proposal expired once its 48 hours have passed, reporting
+// whether it is expired
+func expireApprovalIfDue(ctx context.Context, proposal *models.ApprovalProposal, now time.Time) bool {
+	if !proposal.IsExpired(now) {
+		return proposal.Status == models.ApprovalStatusExpired
+	}
+	proposal.Status = models.ApprovalStatusExpired
+	audit := models.NewApprovalAuditEntry(proposal, models.ApprovalAuditExpired, "system", "Not confirmed in time", now)
+	if err := dynamoService.SaveApprovalProposal(ctx, proposal, models.ApprovalStatusPending, audit); err != nil && !errors.Is(err, services.ErrApprovalProposalChanged) {
+		log.Printf("Warning: Failed to expire proposal %s: %v", proposal.ProposalID, err)
+	}
+	return true
+}
+
+// handleListApprovals handles GET /api/approvals?status= - proposals awaiting a second admin,
+// newest first. status=all lists every proposal.
+func handleListApprovals(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	status := queryParams["status"]
+	switch status {
+	case "":
+		status = models.ApprovalStatusPending
+	case "all":
+		status = ""
+	case models.ApprovalStatusPending, models.ApprovalStatusConfirmed, models.ApprovalStatusExecuted,
+		models.ApprovalStatusFailed, models.ApprovalStatusExpired:
+	default:
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid status: must be one of pending, confirmed, executed, failed, expired, all",
+		}, 400
+	}
+
+	proposals, err := dynamoService.ListApprovalProposals(ctx, status)
+	if err != nil {
+		log.Printf("Error listing approval proposals: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to list proposals",
+		}, 500
+	}
+
+	now := time.Now()
+	listed := []models.ApprovalProposal{}
+	for i := range proposals {
+		if expireApprovalIfDue(ctx, &proposals[i], now) && status == models.ApprovalStatusPending {
+			continue
+		}
+		listed = append(listed, proposals[i])
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d proposals", len(listed)),
+		Data:    listed,
+	}, 200
+}
+
+// handleGetApproval handles GET /api/approvals/{id} - a proposal and its audit trail
+func handleGetApproval(ctx context.Context, proposalID string) (ResponseBody, int) {
+	proposal, err := dynamoService.GetApprovalProposal(ctx, proposalID)
+	if err != nil {
+		log.Printf("Error getting proposal %s: %v", proposalID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve proposal",
+		}, 500
+	}
+	if proposal == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Proposal not found",
+		}, 404
+	}
+	expireApprovalIfDue(ctx, proposal, time.Now())
+
+	audit, err := dynamoService.GetApprovalAudit(ctx, proposalID)
+	if err != nil {
+		log.Printf("Error getting audit trail of proposal %s: %v", proposalID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve proposal",
+		}, 500
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: "Proposal retrieved successfully",
+		Data: map[string]interface{}{
+			"proposal": proposal,
+			"audit":    audit,
+		},
+	}, 200
+}
+
+// approvalRequiredRole returns the role needed to carry out a proposed action, the role its
+// endpoint needs
+func approvalRequiredRole(action string) string {
+	switch action {
+	case models.ApprovalActionBulkDeleteActivities:
+		return requiredAdminRole("POST", "/api/activities/bulk-delete")
+	default:
+		return requiredAdminRole("PUT", "/api/sources/{id}/activate")
+	}
+}
+
+// handleConfirmApproval handles POST /api/approvals/{id}/confirm. A pending proposal is confirmed
+// by an admin other than the proposer, whose key must allow the action, and the action is then
+// carried out. The response is the action's, with the proposal.
+func handleConfirmApproval(ctx context.Context, proposalID string) (ResponseBody, int) {
+	confirmer := requestAdminKey(ctx)
+	if confirmer == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Proposals must be confirmed with an admin API key",
+		}, 403
+	}
+
+	proposal, err := dynamoService.GetApprovalProposal(ctx, proposalID)
+	if err != nil {
+		log.Printf("Error getting proposal %s: %v", proposalID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve proposal",
+		}, 500
+	}
+	if proposal == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Proposal not found",
+		}, 404
+	}
+
+	now := time.Now()
+	if expireApprovalIfDue(ctx, proposal, now) {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Proposal expired at %s - propose the action again", proposal.ExpiresAt.UTC().Format(time.RFC3339)),
+		}, 409
+	}
+	if proposal.Status != models.ApprovalStatusPending {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Proposal is already %s", proposal.Status),
+		}, 409
+	}
+	if err := proposal.CanBeConfirmedBy(confirmer); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 403
+	}
+	if required := approvalRequiredRole(proposal.Action); !models.AdminRoleAllows(confirmer.EffectiveRole(), required) {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Confirming this proposal requires the %s role", required),
+		}, 403
+	}
+
+	// Confirming claims the proposal, so two admins confirming at once carry it out only once
+	proposal.Status = models.ApprovalStatusConfirmed
+	proposal.ConfirmedBy = confirmer.Name
+	proposal.ConfirmedByKeyID = confirmer.KeyID
+	proposal.ConfirmedAt = &now
+	audit := models.NewApprovalAuditEntry(proposal, models.ApprovalAuditConfirmed, confirmer.Name, "", now)
+	err = dynamoService.SaveApprovalProposal(ctx, proposal, models.ApprovalStatusPending, audit)
+	if errors.Is(err, services.ErrApprovalProposalChanged) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Proposal was confirmed or expired in the meantime",
+		}, 409
 	}
+	if err != nil {
+		log.Printf("Error confirming proposal %s: %v", proposalID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to confirm proposal",
+		}, 500
+	}
+	recordAdminAction(ctx, confirmer.Name, models.AdminAuditApprovalConfirmed, proposalID, now, 0)
 
-	return dynamoService.CreateScrapingTask(ctx, task)
+	var responseBody ResponseBody
+	var statusCode int
+	switch proposal.Action {
+	case models.ApprovalActionActivateSource:
+		responseBody, statusCode = activateSource(ctx, proposal.TargetID, proposal.Request, false)
+	case models.ApprovalActionBulkDeleteActivities:
+		responseBody, statusCode = bulkDeleteActivities(ctx, proposal.Request, false)
+	default:
+		responseBody, statusCode = ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Unknown proposed action: %s", proposal.Action),
+		}, 400
+	}
+
+	outcome, details := models.ApprovalAuditExecuted, responseBody.Message
+	proposal.Status = models.ApprovalStatusExecuted
+	if statusCode >= 300 {
+		outcome, details = models.ApprovalAuditFailed, responseBody.Error
+		proposal.Status = models.ApprovalStatusFailed
+		proposal.Error = responseBody.Error
+	}
+	audit = models.NewApprovalAuditEntry(proposal, outcome, confirmer.Name, details, time.Now())
+	if err := dynamoService.SaveApprovalProposal(ctx, proposal, models.ApprovalStatusConfirmed, audit); err != nil {
+		log.Printf("Warning: Failed to record the outcome of proposal %s: %v", proposalID, err)
+	}
+
+	responseBody.Data = map[string]interface{}{
+		"proposal": proposal,
+		"result":   responseBody.Data,
+	}
+	return responseBody, statusCode
 }
 
-// handleGetSourceDetails handles GET /api/sources/{id}/details
-func handleGetSourceDetails(ctx context.Context, sourceID string, queryParams map[string]string) (ResponseBody, int) {
-	// Validate source ID
-	if sourceID == "" {
+// handleGetAdminStats handles GET /api/admin/me/stats?admin=&week= - an admin's review throughput
+// in a week, the current one unless week names a date in another
+func handleGetAdminStats(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	admin := queryParams["admin"]
+	if admin == "" {
 		return ResponseBody{
 			Success: false,
-			Error:   "Source ID is required",
+			Error:   "Query parameter admin is required",
 		}, 400
 	}
 
-	log.Printf("Getting details for source: %s", sourceID)
-
-	// Collect all data for this source
-	sourceDetails := make(map[string]interface{})
+	weekStart := models.ReportCardWeekStart(time.Now())
+	if week := queryParams["week"]; week != "" {
+		date, err := time.Parse("2006-01-02", week)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid week, expected a date like 2024-01-15",
+			}, 400
+		}
+		weekStart = models.ReportCardWeekStart(date)
+	}
 
-	// 1. Get source submission info
-	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+	entries, err := dynamoService.GetAdminAuditEntries(ctx, admin, weekStart, weekStart.AddDate(0, 0, 7))
 	if err != nil {
-		log.Printf("Error getting source submission: %v", err)
+		log.Printf("Error getting audit entries of %s: %v", admin, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source not found",
-		}, 404
+			Error:   "Failed to retrieve admin activity",
+		}, 500
 	}
-	
-	sourceDetails["source_info"] = map[string]interface{}{
-		"source_id":         sourceSubmission.SourceID,
-		"source_name":       sourceSubmission.SourceName,
-		"base_url":          sourceSubmission.BaseURL,
-		"source_type":       sourceSubmission.SourceType,
-		"priority":          sourceSubmission.Priority,
-		"expected_content":  sourceSubmission.ExpectedContent,
-		"hint_urls":         sourceSubmission.HintURLs,
-		"submitted_by":      sourceSubmission.SubmittedBy,
-		"submitted_at":      sourceSubmission.SubmittedAt,
-		"status":            sourceSubmission.Status,
-		"updated_at":        sourceSubmission.UpdatedAt,
+
+	return ResponseBody{
+		Success: true,
+		Data:    services.BuildAdminStats(admin, weekStart, entries),
+	}, 200
+}
+
+// handleListReviewPresets handles GET /api/admin/presets?admin={admin}
+func handleListReviewPresets(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	admin := queryParams["admin"]
+	if admin == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Query parameter admin is required",
+		}, 400
 	}
 
-	// 2. Get source analysis (if available)
-	sourceAnalysis, err := dynamoService.GetSourceAnalysis(ctx, sourceID)
+	presets, err := dynamoService.ListReviewPresets(ctx, admin)
 	if err != nil {
-		log.Printf("No analysis found for source %s: %v", sourceID, err)
-		sourceDetails["analysis"] = nil
-	} else {
-		sourceDetails["analysis"] = map[string]interface{}{
-			"quality_score":         sourceAnalysis.OverallQualityScore,
-			"content_richness":      0.0, // placeholder
-			"extraction_confidence": 0.0, // placeholder
-			"recommended_selectors": sourceAnalysis.RecommendedConfig.BestSelectors,
-			"target_urls":          sourceAnalysis.RecommendedConfig.TargetURLs,
-			"analysis_notes":       "Analysis completed", // placeholder
-			"analyzed_at":          sourceAnalysis.AnalysisCompletedAt,
-		}
+		log.Printf("Error listing review presets: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to list review presets",
+		}, 500
 	}
 
-	// 3. Get source configuration (if active)
-	sourceConfig, err := dynamoService.GetSourceConfig(ctx, sourceID)
-	if err != nil {
-		log.Printf("No config found for source %s: %v", sourceID, err)
-		sourceDetails["config"] = nil
-	} else {
-		sourceDetails["config"] = map[string]interface{}{
-			"scraping_frequency":       "daily",
-			"success_rate":             0.0,
-			"total_scrapes":            0,
-			"successful_scrapes":       0,
-			"total_activities_found":   0,
-			"avg_activities_per_scrape": 0.0,
-			"last_scraped":             nil,
-			"content_selectors":        sourceConfig.ContentSelectors,
-			"is_active":                true,
-		}
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Found %d review presets", len(presets)),
+		Data:    presets,
+	}, 200
+}
+
+// handleCreateReviewPreset handles POST /api/admin/presets
+func handleCreateReviewPreset(ctx context.Context, body string) (ResponseBody, int) {
+	var preset models.ReviewPreset
+	if err := json.Unmarshal([]byte(body), &preset); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
 	}
 
-	// 4. Get task history
-	taskLimit := 20
-	if limitStr, ok := queryParams["task_limit"]; ok {
-		if parsed := parseLimit(limitStr); parsed > 0 {
-			taskLimit = int(parsed)
-		}
+	if err := preset.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid review preset: " + err.Error(),
+		}, 400
 	}
 
-	taskHistory, err := dynamoService.GetRecentTasksForSource(ctx, sourceID, taskLimit)
-	if err != nil {
-		log.Printf("Error getting task history for %s: %v", sourceID, err)
-		sourceDetails["task_history"] = []interface{}{}
-	} else {
-		tasks := make([]map[string]interface{}, len(taskHistory))
-		for i, task := range taskHistory {
-			tasks[i] = map[string]interface{}{
-				"task_id":          task.TaskID,
-				"task_type":        task.TaskType,
-				"priority":         task.Priority,
-				"status":           task.Status,
-				"scheduled_time":   task.ScheduledTime,
-				"created_at":       task.CreatedAt,
-				"updated_at":       task.UpdatedAt,
-				"retry_count":      task.RetryCount,
-				"error_message":    "", // ErrorMessage field doesn't exist
-				"estimated_duration": task.EstimatedDuration,
-			}
-		}
-		sourceDetails["task_history"] = tasks
+	preset.PresetID = uuid.New().String()
+	preset.CreatedAt = time.Time{}
+	if err := dynamoService.PutReviewPreset(ctx, &preset); err != nil {
+		log.Printf("Error storing review preset: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to save review preset",
+		}, 500
 	}
 
-	// 5. Get performance metrics summary
-	if sourceConfig != nil {
-		sourceDetails["performance"] = map[string]interface{}{
-			"reliability_score":    calculateReliabilityScore(taskHistory),
-			"avg_task_duration":    calculateAvgTaskDuration(taskHistory),
-			"recent_failure_rate":  calculateRecentFailureRate(taskHistory),
-			"last_successful_scrape": getLastSuccessfulScrape(taskHistory),
-			"next_estimated_run":   calculateNextEstimatedRun(sourceConfig, taskHistory),
-		}
+	return ResponseBody{
+		Success: true,
+		Message: "Review preset saved successfully",
+		Data:    preset,
+	}, 201
+}
+
+// handleDeleteReviewPreset handles DELETE /api/admin/presets/{id}?admin={admin}
+func handleDeleteReviewPreset(ctx context.Context, presetID string, queryParams map[string]string) (ResponseBody, int) {
+	admin := queryParams["admin"]
+	if presetID == "" || admin == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Preset ID and query parameter admin are required",
+		}, 400
 	}
 
-	// 6. Get recent activities extracted (last 50)
-	// This would require a new method to get activities by source
-	// For now, we'll add placeholder
-	sourceDetails["recent_activities"] = map[string]interface{}{
-		"count": 0,
-		"activities": []interface{}{},
-		"note": "Activity extraction details coming soon",
+	if err := dynamoService.DeleteReviewPreset(ctx, admin, presetID); err != nil {
+		log.Printf("Error deleting review preset: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to delete review preset",
+		}, 500
 	}
 
 	return ResponseBody{
 		Success: true,
-		Message: "Source details retrieved successfully",
-		Data:    sourceDetails,
+		Message: "Review preset deleted successfully",
 	}, 200
 }
 
-// Helper functions for source details
-func calculateReliabilityScore(tasks []models.ScrapingTask) float64 {
-	if len(tasks) == 0 {
-		return 0.0
+// handleListNotifications handles GET /api/notifications - The inbox of notable events of the
+// admin whose API key made the request,
+// newest first, with whether the admin has read each one
+func handleListNotifications(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	admin := requestAdminKey(ctx)
+	if admin == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Notifications must be read with an admin API key",
+		}, 403
 	}
-	
-	successful := 0
-	for _, task := range tasks {
-		if task.Status == models.TaskStatusCompleted {
-			successful++
-		}
+	kind := queryParams["kind"]
+	if kind != "" && !models.IsValidNotificationKind(kind) {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Invalid kind %q, expected analysis_complete, scrape_failure, suggestion or sla_breach", kind),
+		}, 400
+	}
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
-	
-	return float64(successful) / float64(len(tasks)) * 100
-}
 
-func calculateAvgTaskDuration(tasks []models.ScrapingTask) int64 {
-	if len(tasks) == 0 {
-		return 0
+	notifications, err := dynamoService.ListAdminNotifications(ctx, kind)
+	if err != nil {
+		log.Printf("Error listing admin notifications: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve notifications",
+		}, 500
 	}
-	
-	totalDuration := int64(0)
-	completedTasks := 0
-	
-	for _, task := range tasks {
-		if task.Status == models.TaskStatusCompleted && task.EstimatedDuration > 0 {
-			totalDuration += task.EstimatedDuration
-			completedTasks++
+	receipts, err := dynamoService.ListNotificationReceipts(ctx, admin.KeyID)
+	if err != nil {
+		log.Printf("Error listing notification receipts of %s: %v", admin.Name, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve notifications",
+		}, 500
+	}
+	unread := models.ApplyNotificationReceipts(notifications, receipts)
+
+	if queryParams["unread"] == "true" {
+		unreadNotifications := []models.AdminNotification{}
+		for _, notification := range notifications {
+			if !notification.Read {
+				unreadNotifications = append(unreadNotifications, notification)
+			}
 		}
+		notifications = unreadNotifications
 	}
-	
-	if completedTasks == 0 {
-		return 0
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
 	}
-	
-	return totalDuration / int64(completedTasks)
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Found %d notifications, %d unread", len(notifications), unread),
+		Data: map[string]interface{}{
+			"notifications": notifications,
+			"count":         len(notifications),
+			"unread_count":  unread,
+		},
+	}, 200
 }
 
-func calculateRecentFailureRate(tasks []models.ScrapingTask) float64 {
-	// Look at last 10 tasks
-	recentTasks := tasks
-	if len(tasks) > 10 {
-		recentTasks = tasks[:10]
+// handleMarkNotificationRead handles PUT /api/notifications/{id}/read - Marks a notification read
+// in the inbox of the admin whose API key made the request. Marking it again keeps the time it
+// was first read.
+func handleMarkNotificationRead(ctx context.Context, notificationID string) (ResponseBody, int) {
+	admin := requestAdminKey(ctx)
+	if admin == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Notifications must be marked read with an admin API key",
+		}, 403
 	}
-	
-	if len(recentTasks) == 0 {
-		return 0.0
+	if notificationID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Notification ID is required",
+		}, 400
 	}
-	
-	failed := 0
-	for _, task := range recentTasks {
-		if task.Status == models.TaskStatusFailed {
-			failed++
-		}
+
+	notification, err := dynamoService.GetAdminNotification(ctx, notificationID)
+	if err != nil {
+		log.Printf("Error getting admin notification %s: %v", notificationID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve notification",
+		}, 500
+	}
+	if notification == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Notification not found",
+		}, 404
 	}
-	
-	return float64(failed) / float64(len(recentTasks)) * 100
-}
 
-func getLastSuccessfulScrape(tasks []models.ScrapingTask) *time.Time {
-	for _, task := range tasks {
-		if task.Status == models.TaskStatusCompleted {
-			return &task.UpdatedAt
-		}
+	receipt := models.NewNotificationReceipt(admin, notification, time.Now())
+	created, err := dynamoService.CreateNotificationReceipt(ctx, receipt)
+	if err != nil {
+		log.Printf("Error marking notification %s read for %s: %v", notificationID, admin.Name, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to mark notification read",
+		}, 500
+	}
+
+	message := "Notification marked as read"
+	notification.Read = true
+	if created {
+		notification.ReadAt = &receipt.ReadAt
+	} else {
+		message = "Notification was already read"
 	}
-	return nil
-}
 
-func calculateNextEstimatedRun(config *models.DynamoSourceConfig, tasks []models.ScrapingTask) *time.Time {
-	// Simple calculation: next run in 24 hours
-	next := time.Now().Add(24 * time.Hour)
-	return &next
+	return ResponseBody{
+		Success: true,
+		Message: message,
+		Data:    notification,
+	}, 200
 }
 
-// handleTriggerManualScrape handles POST /api/sources/{id}/trigger  
-func handleTriggerManualScrape(ctx context.Context, sourceID string, body string) (ResponseBody, int) {
-	// Validate source ID
-	if sourceID == "" {
+// handleListTags handles GET /api/tags - Public endpoint listing the built-in and stored tags, each
+// with the number of published activities that have it
+func handleListTags(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	stored, err := dynamoService.ListTags(ctx)
+	if err != nil {
+		log.Printf("Error listing tags: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source ID is required",
-		}, 400
+			Error:   "Failed to list tags",
+		}, 500
 	}
 
-	log.Printf("Manual scrape triggered for source: %s", sourceID)
-
-	// Parse optional request body for task configuration
-	var req struct {
-		TaskType string `json:"task_type,omitempty"` // full_scrape (default), incremental, validation
-		Priority string `json:"priority,omitempty"` // high (default), medium, low  
-		Notes    string `json:"notes,omitempty"`    // admin notes
-	}
-	
-	if body != "" {
-		if err := json.Unmarshal([]byte(body), &req); err != nil {
-			log.Printf("Invalid request body for manual trigger: %v", err)
-			// Continue with defaults if body is invalid
+	tags := services.MergeTags(models.DefaultTags(), stored)
+	for i := range tags {
+		tags[i].UsageCount, err = dynamoService.CountTaggedActivities(ctx, tags[i].Slug)
+		if err != nil {
+			log.Printf("Error counting activities tagged %s: %v", tags[i].Slug, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to count tag usage",
+			}, 500
 		}
 	}
 
-	// Set defaults
-	if req.TaskType == "" {
-		req.TaskType = models.TaskTypeFullScrape
-	}
-	if req.Priority == "" {
-		req.Priority = models.TaskPriorityHigh
-	}
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Found %d tags", len(tags)),
+		Data:    tags,
+	}, 200
+}
 
-	// Verify source exists and is active
-	sourceSubmission, err := dynamoService.GetSourceSubmission(ctx, sourceID)
+// handleGetTag handles GET /api/tags/{slug}?from=YYYY-MM-DD&limit=N - Public endpoint for tag pages.
+// It lists the published activities with the tag starting on or after from (default: today), soonest
+// first. Freeform tags no tag is stored for are listed too.
+func handleGetTag(ctx context.Context, slug string, queryParams map[string]string) (ResponseBody, int) {
+	slug, err := models.NormalizeTag(slug)
 	if err != nil {
-		log.Printf("Error getting source submission: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source not found",
-		}, 404
+			Error:   err.Error(),
+		}, 400
 	}
 
-	if sourceSubmission.Status != models.SourceStatusActive {
+	from := queryParams["from"]
+	if from == "" {
+		from = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", from); err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   fmt.Sprintf("Source is not active (status: %s)", sourceSubmission.Status),
+			Error:   "Invalid from: must be YYYY-MM-DD",
 		}, 400
 	}
-
-	// Get source configuration to build proper task
-	sourceConfig, err := dynamoService.GetSourceConfig(ctx, sourceID)
+	limit, err := queryparams.Int(queryParams, "limit", defaultPageLimit, 1, maxPageLimit)
 	if err != nil {
-		log.Printf("Error getting source config: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Source configuration not found - source may not be properly activated",
+			Error:   err.Error(),
 		}, 400
 	}
 
-	// Create immediate scraping task
-	taskID := uuid.New().String()
-	now := time.Now()
-	
-	task := &models.ScrapingTask{
-		PK:            models.CreateTaskPK(taskID),
-		SK:            models.CreateTaskSK(req.Priority, sourceID, taskID),
-		TaskID:        taskID,
-		SourceID:      sourceID,
-		TaskType:      req.TaskType,
-		Priority:      req.Priority,
-		ScheduledTime: now.Add(1 * time.Minute), // Run in 1 minute
-		TargetURLs:    []string{sourceConfig.BaseURL},
-		ExtractionRules: sourceConfig.ContentSelectors,
-		RateLimits:      sourceConfig.ScrapingConfig.RateLimit,
-		Timeout:         300, // 5 minutes
-		MaxRetries:      2,   // Lower retries for manual tasks
-		Status:          models.TaskStatusScheduled,
-		RetryCount:      0,
-		EstimatedDuration: 120, // 2 minutes
-		Dependencies:      []string{},
-		CreatedAt:         now,
-		UpdatedAt:         now,
-		TTL:               models.CalculateTaskTTL(now, 30), // 30 days retention for manual tasks
-		NextRunKey:        models.GenerateNextRunKey(now.Add(1 * time.Minute)),
-		PrioritySourceKey: models.GenerateTaskPrioritySourceKey(req.Priority, sourceID),
-		// Note: ErrorMessage field doesn't exist in ScrapingTask
+	tag, status, err := lookupTag(ctx, slug)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, status
+	}
+	if tag == nil {
+		tag = &models.Tag{Slug: slug, Label: slug}
 	}
 
-	// Store the task in DynamoDB
-	if err := dynamoService.CreateScrapingTask(ctx, task); err != nil {
-		log.Printf("Error creating manual scraping task: %v", err)
+	entries, err := dynamoService.GetTagEntries(ctx, slug, from, limit)
+	if err != nil {
+		log.Printf("Error getting activities tagged %s: %v", slug, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to create scraping task",
+			Error:   "Failed to retrieve tagged activities",
 		}, 500
 	}
 
-	// Trigger the orchestrator to process the new task immediately
-	// We can invoke the orchestrator Lambda directly for immediate processing
-	if err := triggerOrchestratorForSource(ctx, sourceID, req.TaskType); err != nil {
-		log.Printf("Error triggering orchestrator: %v", err)
-		// Don't fail the request - task is created, orchestrator will pick it up on next run
-	}
-
 	return ResponseBody{
 		Success: true,
-		Message: "Manual scrape triggered successfully",
+		Message: fmt.Sprintf("Retrieved %d activities tagged %s", len(entries), slug),
 		Data: map[string]interface{}{
-			"task_id":        taskID,
-			"source_id":      sourceID,
-			"task_type":      req.TaskType,
-			"priority":       req.Priority,
-			"scheduled_for":  task.ScheduledTime,
-			"estimated_completion": now.Add(time.Duration(task.EstimatedDuration) * time.Second),
+			"tag":        tag,
+			"from":       from,
+			"activities": entries,
 		},
-	}, 201
+	}, 200
 }
 
-// triggerOrchestratorForSource invokes the orchestrator Lambda for immediate processing
-func triggerOrchestratorForSource(ctx context.Context, sourceID, taskType string) error {
-	// Get orchestrator function name from environment
-	orchestratorFunctionName := os.Getenv("ORCHESTRATOR_FUNCTION_NAME")
-	if orchestratorFunctionName == "" {
-		return fmt.Errorf("ORCHESTRATOR_FUNCTION_NAME not configured")
+// lookupTag returns the stored tag with a slug, the built-in tag when none is stored, or nil when
+// the slug is not a tag. The status goes with the returned error.
+func lookupTag(ctx context.Context, slug string) (*models.Tag, int, error) {
+	stored, err := dynamoService.GetTag(ctx, slug)
+	if err != nil {
+		log.Printf("Error getting tag %s: %v", slug, err)
+		return nil, 500, fmt.Errorf("failed to retrieve tag")
 	}
 
-	// Create event payload for orchestrator
-	event := map[string]interface{}{
-		"trigger_type": "manual",
-		"source_id":    sourceID,
-		"task_type":    taskType,
+	var tags []models.Tag
+	if stored != nil {
+		tags = []models.Tag{*stored}
+	}
+	for _, tag := range services.MergeTags(models.DefaultTags(), tags) {
+		if tag.Slug == slug {
+			return &tag, 0, nil
+		}
 	}
+	return nil, 0, nil
+}
 
-	eventBytes, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal orchestrator event: %w", err)
+// handleCreateTag handles POST /api/tags
+func handleCreateTag(ctx context.Context, body string) (ResponseBody, int) {
+	var tag models.Tag
+	if err := json.Unmarshal([]byte(body), &tag); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
 	}
 
-	// Invoke orchestrator Lambda asynchronously
-	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
-		FunctionName:   aws.String(orchestratorFunctionName),
-		InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
-		Payload:        eventBytes,
-	})
+	if err := tag.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid tag: " + err.Error(),
+		}, 400
+	}
 
+	existing, status, err := lookupTag(ctx, tag.Slug)
 	if err != nil {
-		return fmt.Errorf("failed to invoke orchestrator: %w", err)
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, status
 	}
-
-	log.Printf("Successfully triggered orchestrator for source %s", sourceID)
-	return nil
-}
-
-func parseLimit(limitStr string) int32 {
-	// Simple parsing, should add proper validation
-	switch limitStr {
-	case "10":
-		return 10
-	case "25":
-		return 25
-	case "50":
-		return 50
-	case "100":
-		return 100
-	default:
-		return 0
+	if existing != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("Tag %s already exists, use PUT /api/tags/%s to change it", tag.Slug, tag.Slug),
+		}, 409
 	}
-}
-
-// Admin Crawling Handler Functions
 
-// handleCrawlSubmission handles POST /api/crawl/submit
-func handleCrawlSubmission(ctx context.Context, body string) (ResponseBody, int) {
-	if firecrawlService == nil {
+	tag.CreatedAt = time.Time{}
+	if err := dynamoService.PutTag(ctx, &tag); err != nil {
+		log.Printf("Error storing tag: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Firecrawl service not available",
+			Error:   "Failed to save tag",
 		}, 500
 	}
+	activityTags.Invalidate()
 
-	var req models.CrawlSubmissionRequest
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
+	return ResponseBody{
+		Success: true,
+		Message: "Tag created successfully",
+		Data:    tag,
+	}, 201
+}
+
+// handleUpdateTag handles PUT /api/tags/{slug}. Changing a built-in tag stores the changed copy.
+// Changed keywords apply to events converted from then on.
+func handleUpdateTag(ctx context.Context, slug string, body string) (ResponseBody, int) {
+	var tag models.Tag
+	if err := json.Unmarshal([]byte(body), &tag); err != nil {
 		return ResponseBody{
 			Success: false,
 			Error:   "Invalid request body: " + err.Error(),
 		}, 400
 	}
 
-	// Validate the request
-	if err := req.Validate(); err != nil {
+	tag.Slug = slug
+	if err := tag.Validate(); err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Validation error: " + err.Error(),
+			Error:   "Invalid tag: " + err.Error(),
 		}, 400
 	}
 
-	// Check for duplicate URLs in pending/approved admin events
-	existingEvent, err := dynamoService.GetAdminEventByURL(ctx, req.URL)
-	if err == nil && existingEvent != nil {
+	existing, status, err := lookupTag(ctx, tag.Slug)
+	if err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   fmt.Sprintf("URL already exists with status: %s. Event ID: %s", existingEvent.Status, existingEvent.EventID),
-		}, 409 // Conflict
+			Error:   err.Error(),
+		}, status
+	}
+	if existing == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Tag not found",
+		}, 404
 	}
 
-	// Check if URL is already configured as a source
-	existingSource, err := dynamoService.GetSourceByURL(ctx, req.URL)
-	if err == nil && existingSource != nil {
+	tag.CreatedAt = existing.CreatedAt
+	if err := dynamoService.PutTag(ctx, &tag); err != nil {
+		log.Printf("Error storing tag: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   fmt.Sprintf("URL already exists as source: %s (ID: %s)", existingSource.SourceName, existingSource.SourceID),
-		}, 409 // Conflict
+			Error:   "Failed to save tag",
+		}, 500
 	}
+	activityTags.Invalidate()
+	tag.BuiltIn = existing.BuiltIn
 
-	// Create firecrawl extract request
-	extractRequest := services.AdminExtractRequest{
-		URL:          req.URL,
-		SchemaType:   req.SchemaType,
-		CustomSchema: req.CustomSchema,
+	return ResponseBody{
+		Success: true,
+		Message: "Tag updated successfully",
+		Data:    tag,
+	}, 200
+}
+
+// handleDeleteTag handles DELETE /api/tags/{slug}. A tag published activities still have cannot be
+// deleted; deleting a built-in tag reverts it to its defaults.
+func handleDeleteTag(ctx context.Context, slug string) (ResponseBody, int) {
+	slug, err := models.NormalizeTag(slug)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
 
-	// Perform extraction
-	extractResponse, err := firecrawlService.ExtractWithSchema(extractRequest)
+	stored, err := dynamoService.GetTag(ctx, slug)
 	if err != nil {
-		log.Printf("Error extracting with Firecrawl: %v", err)
+		log.Printf("Error getting tag %s: %v", slug, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to extract data from URL: " + err.Error(),
+			Error:   "Failed to retrieve tag",
 		}, 500
 	}
+	if stored == nil {
+		if models.IsBuiltInTag(slug) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Built-in tags cannot be deleted",
+			}, 400
+		}
+		return ResponseBody{
+			Success: false,
+			Error:   "Tag not found",
+		}, 404
+	}
 
-	if !extractResponse.Success {
+	if !models.IsBuiltInTag(slug) {
+		count, err := dynamoService.CountTaggedActivities(ctx, slug)
+		if err != nil {
+			log.Printf("Error counting activities tagged %s: %v", slug, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to count tag usage",
+			}, 500
+		}
+		if count > 0 {
+			return ResponseBody{
+				Success: false,
+				Error:   fmt.Sprintf("Tag %s is used by %d published activities, remove it from them first", slug, count),
+				Data: map[string]interface{}{
+					"usage_count": count,
+				},
+			}, 409
+		}
+	}
+
+	if err := dynamoService.DeleteTag(ctx, slug); err != nil {
+		log.Printf("Error deleting tag %s: %v", slug, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Extraction was not successful",
+			Error:   "Failed to delete tag",
 		}, 500
 	}
+	activityTags.Invalidate()
 
-	// Generate unique event ID for this extraction
-	eventID := uuid.New().String()
+	return ResponseBody{
+		Success: true,
+		Message: "Tag deleted successfully",
+	}, 200
+}
 
-	// Create admin event record
-	adminEvent := &models.AdminEvent{
-		EventID:            eventID,
-		SourceURL:          req.URL,
-		SchemaType:         req.SchemaType,
-		SchemaUsed:         extractResponse.SchemaUsed,
-		RawExtractedData:   extractResponse.RawData,
-		Status:             models.AdminEventStatusPending,
-		ExtractedByUser:    req.ExtractedByUser,
-		SubmissionID:       uuid.New().String(),
-		AdminNotes:         req.AdminNotes,
-	}
-
-	// Generate conversion preview
-	conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+// handleGetDomainPolicy handles GET /api/domain-policy
+func handleGetDomainPolicy(ctx context.Context) (ResponseBody, int) {
+	policy, err := dynamoService.GetDomainPolicy(ctx)
 	if err != nil {
-		log.Printf("Error generating conversion preview: %v", err)
-		// Continue without preview - admin can still review raw data
-	} else {
-		// Store conversion preview and issues
-		if conversionResult.Activity != nil {
-			activityJSON, _ := json.Marshal(conversionResult.Activity)
-			var activityMap map[string]interface{}
-			json.Unmarshal(activityJSON, &activityMap)
-			adminEvent.ConvertedData = activityMap
+		log.Printf("Error getting domain policy: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to get domain policy",
+		}, 500
+	}
+
+	if policy == nil {
+		policy = &models.DomainPolicy{
+			AllowList: []models.DomainRule{},
+			DenyList:  []models.DomainRule{},
 		}
-		adminEvent.ConversionIssues = conversionResult.Issues
 	}
 
-	// Store in DynamoDB
-	if err := dynamoService.CreateAdminEvent(ctx, adminEvent); err != nil {
-		log.Printf("Error storing admin event: %v", err)
+	return ResponseBody{
+		Success: true,
+		Message: "Domain policy retrieved successfully",
+		Data:    policy,
+	}, 200
+}
+
+// handleUpdateDomainPolicy handles PUT /api/domain-policy
+func handleUpdateDomainPolicy(ctx context.Context, body string) (ResponseBody, int) {
+	var policy models.DomainPolicy
+	if err := json.Unmarshal([]byte(body), &policy); err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to store extracted events",
-		}, 500
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
 	}
 
-	// Create or update source record if extraction was successful
-	err = createOrUpdateSourceRecord(ctx, req, extractResponse.EventsCount)
+	// Accept URLs or mixed-case hosts and store them the way submissions are matched
+	for _, rules := range [][]models.DomainRule{policy.AllowList, policy.DenyList} {
+		for i := range rules {
+			if domain := urlutil.Domain(rules[i].Domain); domain != "" {
+				rules[i].Domain = domain
+			}
+		}
+	}
+	if err := policy.Validate(); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid domain policy: " + err.Error(),
+		}, 400
+	}
+
+	existing, err := dynamoService.GetDomainPolicy(ctx)
 	if err != nil {
-		log.Printf("Warning: Failed to create/update source record: %v", err)
-		// Don't fail the entire request for source management issues
+		log.Printf("Error getting domain policy: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update domain policy",
+		}, 500
+	}
+	policy.Version = 1
+	if existing != nil {
+		policy.Version = existing.Version + 1
+	}
+
+	if err := dynamoService.PutDomainPolicy(ctx, &policy); err != nil {
+		log.Printf("Error storing domain policy: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update domain policy",
+		}, 500
 	}
+	domainPolicy.Invalidate()
+
+	log.Printf("Domain policy updated to version %d by %s: %d allowed, %d denied", policy.Version, policy.UpdatedBy, len(policy.AllowList), len(policy.DenyList))
 
 	return ResponseBody{
 		Success: true,
-		Message: fmt.Sprintf("Successfully extracted %d events from URL", extractResponse.EventsCount),
-		Data: map[string]interface{}{
-			"event_id":      eventID,
-			"events_count":  extractResponse.EventsCount,
-			"credits_used":  extractResponse.CreditsUsed,
-			"processing_time": extractResponse.Metadata.ProcessingTime.String(),
-		},
-	}, 201
+		Message: "Domain policy updated successfully",
+		Data:    policy,
+	}, 200
 }
 
-// handleDebugExtraction handles POST /api/debug/extract
-func handleDebugExtraction(ctx context.Context, body string) (ResponseBody, int) {
-	if firecrawlService == nil {
+// handleGetNeighborhoods handles GET /api/admin/neighborhoods
+func handleGetNeighborhoods(ctx context.Context) (ResponseBody, int) {
+	lookup, err := dynamoService.GetNeighborhoodLookup(ctx)
+	if err != nil {
+		log.Printf("Error getting neighborhood lookup: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Firecrawl service not available",
+			Error:   "Failed to get neighborhoods",
 		}, 500
 	}
 
-	var req models.DebugExtractionRequest
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
+	if lookup == nil {
+		lookup = &models.NeighborhoodLookup{Neighborhoods: []models.Neighborhood{}}
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d neighborhoods", len(lookup.Neighborhoods)),
+		Data:    lookup,
+	}, 200
+}
+
+// handleUpdateNeighborhoods handles PUT /api/admin/neighborhoods, replacing the whole lookup
+func handleUpdateNeighborhoods(ctx context.Context, body string) (ResponseBody, int) {
+	var lookup models.NeighborhoodLookup
+	if err := json.Unmarshal([]byte(body), &lookup); err != nil {
 		return ResponseBody{
 			Success: false,
 			Error:   "Invalid request body: " + err.Error(),
 		}, 400
 	}
-
-	// Validate the request
-	if req.URL == "" {
+	if err := lookup.Validate(); err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "URL is required",
+			Error:   "Invalid neighborhoods: " + err.Error(),
 		}, 400
 	}
 
-	if req.SchemaType == "" {
-		req.SchemaType = "events" // Default schema type
-	}
-
-	// Create firecrawl extract request
-	extractRequest := services.AdminExtractRequest{
-		URL:          req.URL,
-		SchemaType:   req.SchemaType,
-		CustomSchema: req.CustomSchema,
-	}
-
-	// Perform extraction with detailed diagnostics
-	extractResponse, err := firecrawlService.ExtractWithSchema(extractRequest)
+	existing, err := dynamoService.GetNeighborhoodLookup(ctx)
 	if err != nil {
-		log.Printf("Error extracting with Firecrawl: %v", err)
+		log.Printf("Error getting neighborhood lookup: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to extract data from URL: " + err.Error(),
+			Error:   "Failed to update neighborhoods",
 		}, 500
 	}
+	lookup.Version = 1
+	if existing != nil {
+		lookup.Version = existing.Version + 1
+	}
 
-	// Create a temporary admin event for conversion testing
-	tempEventID := "debug-" + uuid.New().String()
-	tempAdminEvent := &models.AdminEvent{
-		EventID:          tempEventID,
-		SourceURL:        req.URL,
-		SchemaType:       req.SchemaType,
-		SchemaUsed:       extractResponse.SchemaUsed,
-		RawExtractedData: extractResponse.RawData,
-		Status:           models.AdminEventStatusPending,
-		ExtractedByUser:  "debug-user",
-		SubmissionID:     tempEventID,
-		AdminNotes:       "Debug extraction - not stored",
-		ExtractedAt:      time.Now(),
+	if err := dynamoService.PutNeighborhoodLookup(ctx, &lookup); err != nil {
+		log.Printf("Error storing neighborhood lookup: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to update neighborhoods",
+		}, 500
 	}
+	neighborhoods.Invalidate()
 
-	// Perform conversion with detailed diagnostics
-	conversionResult, conversionErr := conversionService.ConvertToActivity(tempAdminEvent)
+	log.Printf("Neighborhood lookup updated to version %d by %s: %d neighborhoods", lookup.Version, lookup.UpdatedBy, len(lookup.Neighborhoods))
 
-	// Get detailed diagnostics from the services
-	extractionDiagnostics := firecrawlService.GetLastExtractionDiagnostics()
-	conversionDiagnostics := conversionService.GetLastConversionDiagnostics()
+	return ResponseBody{
+		Success: true,
+		Message: "Neighborhoods updated successfully",
+		Data:    lookup,
+	}, 200
+}
 
-	// Build comprehensive debug response
-	debugResponse := map[string]interface{}{
-		"extraction": map[string]interface{}{
-			"url":             req.URL,
-			"schema_type":     req.SchemaType,
-			"success":         extractResponse.Success,
-			"events_count":    extractResponse.EventsCount,
-			"credits_used":    extractResponse.CreditsUsed,
-			"processing_time": extractResponse.Metadata.ProcessingTime.String(),
-			"schema_used":     extractResponse.SchemaUsed,
-		},
-		"raw_data": map[string]interface{}{
-			"structured_data": extractResponse.RawData,
-		},
-		"conversion": map[string]interface{}{
-			"success": conversionErr == nil,
-		},
+// cachedPublicQuery serves a public catalog query from the query cache, running the handler and
+// caching its response on a miss. Only successful responses are cached. The cache is skipped when
+// it cannot be used, so a cache problem never fails the query.
+func cachedPublicQuery(ctx context.Context, path string, queryParams map[string]string, handler func(context.Context, map[string]string) (ResponseBody, int)) (ResponseBody, int) {
+	key, err := publicQueryCache.Key(ctx, path, queryParams)
+	if err != nil {
+		log.Printf("Warning: Serving %s uncached: %v", path, err)
+		return handler(ctx, queryParams)
 	}
 
-	// Add extraction diagnostics if available
-	if extractionDiagnostics != nil {
-		debugResponse["raw_data"].(map[string]interface{})["markdown_length"] = extractionDiagnostics.RawMarkdownLength
-		debugResponse["raw_data"].(map[string]interface{})["markdown_sample"] = extractionDiagnostics.RawMarkdownSample
-		debugResponse["extraction_diagnostics"] = extractionDiagnostics
-		
-		// Add validation issues from extraction
-		if len(extractionDiagnostics.ValidationIssues) > 0 {
-			debugResponse["extraction_validation"] = map[string]interface{}{
-				"issues": extractionDiagnostics.ValidationIssues,
-			}
+	if cached, found, err := publicQueryCache.Get(ctx, key); err != nil {
+		log.Printf("Warning: Failed to read cached %s: %v", key, err)
+	} else if found {
+		var responseBody ResponseBody
+		if err := json.Unmarshal(cached, &responseBody); err == nil {
+			return responseBody, 200
 		}
+		log.Printf("Warning: Ignoring unreadable cached %s", key)
 	}
 
-	// Add conversion details if successful
-	if conversionErr == nil && conversionResult != nil {
-		debugResponse["conversion"].(map[string]interface{})["activity"] = conversionResult.Activity
-		debugResponse["conversion"].(map[string]interface{})["issues"] = conversionResult.Issues
-		debugResponse["conversion"].(map[string]interface{})["field_mappings"] = conversionResult.FieldMappings
-		debugResponse["conversion"].(map[string]interface{})["confidence_score"] = conversionResult.ConfidenceScore
-		
-		// Add detailed mappings and validation results if available
-		if conversionResult.DetailedMappings != nil {
-			debugResponse["conversion"].(map[string]interface{})["detailed_mappings"] = conversionResult.DetailedMappings
-		}
-		if conversionResult.ValidationResults != nil {
-			debugResponse["conversion"].(map[string]interface{})["validation_results"] = conversionResult.ValidationResults
-		}
-	} else if conversionErr != nil {
-		debugResponse["conversion"].(map[string]interface{})["error"] = conversionErr.Error()
+	responseBody, statusCode := handler(ctx, queryParams)
+	if statusCode != 200 {
+		return responseBody, statusCode
 	}
+	if encoded, err := json.Marshal(responseBody); err != nil {
+		log.Printf("Warning: Failed to encode %s for the cache: %v", key, err)
+	} else if err := publicQueryCache.Set(ctx, key, encoded); err != nil {
+		log.Printf("Warning: Failed to cache %s: %v", key, err)
+	}
+	return responseBody, statusCode
+}
 
-	// Add conversion diagnostics if available
-	if conversionDiagnostics != nil {
-		debugResponse["conversion_diagnostics"] = conversionDiagnostics
+// nearbyApprovedEvents returns up to count approved events whose activities are held within
+// radiusKm of a point, nearest first. Only the geohash cells around the point are queried.
+func nearbyApprovedEvents(ctx context.Context, center models.Coordinates, radiusKm float64, count int) ([]models.AdminEvent, error) {
+	entries, err := dynamoService.GetGeoEntries(ctx, services.GeohashCellsWithin(center, radiusKm))
+	if err != nil {
+		return nil, err
+	}
+	entries = services.NearestGeoEntries(entries, center, radiusKm)
+	if len(entries) > count {
+		entries = entries[:count]
 	}
 
-	// Add suggestions for improvement
-	suggestions := generateExtractionSuggestions(extractResponse, conversionResult, conversionErr)
-	if len(suggestions) > 0 {
-		debugResponse["suggestions"] = suggestions
+	eventIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.EventID != "" {
+			eventIDs = append(eventIDs, entry.EventID)
+		}
+	}
+	events, err := dynamoService.GetAdminEventsByIDs(ctx, eventIDs, 10)
+	if err != nil {
+		return nil, err
 	}
 
-	return ResponseBody{
-		Success: true,
-		Message: "Debug extraction completed",
-		Data:    debugResponse,
-	}, 200
+	// An entry can outlive its event's approval until the event is published again
+	approved := make([]models.AdminEvent, 0, len(events))
+	for _, event := range events {
+		if event.Status == models.AdminEventStatusApproved {
+			approved = append(approved, event)
+		}
+	}
+	return approved, nil
 }
 
-// generateExtractionSuggestions provides actionable suggestions based on extraction and conversion results
-func generateExtractionSuggestions(extractResponse *services.AdminExtractResponse, conversionResult *models.ConversionResult, conversionErr error) []string {
-	var suggestions []string
+// maxApprovedOffset bounds how far ?offset= skips into the approved events
+const maxApprovedOffset = 10000
 
-	// Extraction-level suggestions
-	if !extractResponse.Success {
-		suggestions = append(suggestions, "Extraction failed - check if the URL is accessible and contains structured content")
+// handleGetApprovedEvents handles GET /api/events/approved - Public endpoint for main frontend
+func handleGetApprovedEvents(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	// Parse query parameters
+	parsedLimit, err := queryparams.Int(queryParams, "limit", 100, 1, 500)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
-
-	if extractResponse.EventsCount == 0 {
-		suggestions = append(suggestions, "No events found - try a different schema type or check if the page contains event information")
+	parsedOffset, err := queryparams.Int(queryParams, "offset", 0, 0, maxApprovedOffset)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
-
-	// Conversion-level suggestions
-	if conversionErr != nil {
-		suggestions = append(suggestions, fmt.Sprintf("Conversion failed: %s", conversionErr.Error()))
+	limit, offset := int32(parsedLimit), int32(parsedOffset)
+
+	// lat/lng/radius keep the activities held within radius km of a point. The live catalog is
+	// searched through the geohash index, nearest first, so it is paged with offset rather than
+	// next_token.
+	var near *models.Coordinates
+	radiusKm := models.DefaultGeoRadiusKm
+	if queryParams["lat"] != "" || queryParams["lng"] != "" {
+		if queryParams["lat"] == "" || queryParams["lng"] == "" {
+			return ResponseBody{
+				Success: false,
+				Error:   "lat and lng must be given together",
+			}, 400
+		}
+		lat, err := queryparams.ParseFloat("lat", queryParams["lat"], -90, 90)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+		lng, err := queryparams.ParseFloat("lng", queryParams["lng"], -180, 180)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+		radiusKm, err = queryparams.Float(queryParams, "radius", models.DefaultGeoRadiusKm, 0.1, models.MaxGeoRadiusKm)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+		if queryParams["next_token"] != "" {
+			return ResponseBody{
+				Success: false,
+				Error:   "next_token cannot be used with lat and lng, use offset instead",
+			}, 400
+		}
+		near = &models.Coordinates{Lat: lat, Lng: lng}
+	}
+
+	// expand=occurrences returns one item per day in the date range (default: today only), so multi-day
+	// and recurring activities appear on every day they run instead of just their first
+	expandOccurrences := queryParams["expand"] == "occurrences"
+	var occurrenceFrom, occurrenceTo time.Time
+	var calendarEntries []models.CalendarEntry
+	var approvedEvents []models.AdminEvent
+	var nextToken string
+
+	// snapshot=YYYY-MM-DD serves the catalog as frozen that night instead of the live catalog.
+	// Snapshots are read whole, so they are paged with offset rather than next_token.
+	snapshot := queryParams["snapshot"]
+	if snapshot != "" {
+		if snapshot, err = models.ParseCatalogSnapshotVersion(snapshot); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
+		if catalogSnapshots == nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Catalog snapshots are not configured",
+			}, 503
+		}
+		if queryParams["next_token"] != "" {
+			return ResponseBody{
+				Success: false,
+				Error:   "next_token cannot be used with snapshot, use offset instead",
+			}, 400
+		}
 	}
 
-	if conversionResult != nil {
-		if conversionResult.ConfidenceScore < 50 {
-			suggestions = append(suggestions, "Low confidence score - extracted data may be incomplete or malformed")
+	// preview_token adds the unpublished events a reviewer was given a token for, as they will look
+	// once published. They are added to the first page only, and never to a snapshot.
+	var preview *models.PreviewToken
+	if value := queryParams["preview_token"]; value != "" {
+		if previewTokens == nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Preview tokens are not configured",
+			}, 503
 		}
-
-		if len(conversionResult.Issues) > 3 {
-			suggestions = append(suggestions, "Multiple conversion issues detected - consider using a different schema type or improving source data quality")
+		if snapshot != "" {
+			return ResponseBody{
+				Success: false,
+				Error:   "preview_token cannot be used with snapshot",
+			}, 400
 		}
-
-		// Check for specific field mapping issues
-		if conversionResult.FieldMappings != nil {
-			missingFields := []string{}
-			for field, source := range conversionResult.FieldMappings {
-				if source == "not_found" || source == "generated" {
-					missingFields = append(missingFields, field)
-				}
-			}
-			if len(missingFields) > 0 {
-				suggestions = append(suggestions, fmt.Sprintf("Missing or generated fields: %s - check if source contains this information", strings.Join(missingFields, ", ")))
-			}
+		preview, err = previewTokens.Verify(value, time.Now())
+		if errors.Is(err, services.ErrPreviewTokenExpired) {
+			return ResponseBody{
+				Success: false,
+				Error:   "preview_token has expired",
+			}, 403
+		}
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid preview_token",
+			}, 403
 		}
 	}
 
-	// Schema-specific suggestions
-	if extractResponse.SchemaUsed != nil && extractResponse.EventsCount > 0 {
-		suggestions = append(suggestions, "Successfully extracted events - consider this schema configuration for production")
+	if expandOccurrences {
+		occurrenceFrom, occurrenceTo, err = parseOccurrenceRange(queryParams["date_from"], queryParams["date_to"])
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
+		}
 	}
 
-	return suggestions
-}
-
-// truncateString truncates a string to maxLength characters
-func truncateString(s string, maxLength int) string {
-	if len(s) <= maxLength {
-		return s
+	switch {
+	case snapshot != "":
+		approvedEvents, err = catalogSnapshots.Events(ctx, snapshot)
+	case expandOccurrences:
+		// The range's activities are paged once they are known from the calendar, so every approved
+		// event is read rather than only the newest
+		calendarEntries, err = dynamoService.GetCalendarEntriesForRange(ctx, occurrenceFrom, occurrenceTo, 0)
+		if err != nil {
+			log.Printf("Error getting calendar entries from %s to %s: %v", occurrenceFrom.Format("2006-01-02"), occurrenceTo.Format("2006-01-02"), err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to retrieve approved events",
+			}, 500
+		}
+		approvedEvents, err = dynamoService.GetAllApprovedAdminEvents(ctx)
+	case near != nil:
+		// offset skips into the nearest events, so limit+offset of them are read
+		approvedEvents, err = nearbyApprovedEvents(ctx, *near, radiusKm, int(limit+offset))
+	default:
+		// offset skips into the page, so the next page starts after limit+offset events
+		approvedEvents, nextToken, err = dynamoService.GetApprovedAdminEventsPage(ctx, limit+offset, queryParams["next_token"])
+	}
+	if errors.Is(err, services.ErrCatalogSnapshotNotFound) {
+		return ResponseBody{
+			Success: false,
+			Error:   fmt.Sprintf("No catalog snapshot for %s", snapshot),
+		}, 404
+	}
+	if errors.Is(err, services.ErrInvalidPageToken) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid next_token",
+		}, 400
+	}
+	if err != nil {
+		log.Printf("Error getting approved events: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve approved events",
+		}, 500
+	}
+	// Snapshots were filtered when they were taken
+	if snapshot == "" {
+		approvedEvents = services.VisibleAdminEvents(approvedEvents, time.Now())
 	}
-	return s[:maxLength] + "..."
-}
 
-// generateConversionDetails creates detailed conversion information for an admin event
-func generateConversionDetails(ctx context.Context, event *models.AdminEvent) map[string]interface{} {
-	details := map[string]interface{}{
-		"has_conversion_preview": event.ConvertedData != nil,
-		"conversion_issues_count": len(event.ConversionIssues),
-		"conversion_status": "unknown",
+	if !expandOccurrences {
+		// Apply offset if specified
+		if int(offset) < len(approvedEvents) {
+			approvedEvents = approvedEvents[offset:]
+		} else {
+			approvedEvents = []models.AdminEvent{}
+		}
+
+		// Apply limit
+		if int(limit) < len(approvedEvents) {
+			approvedEvents = approvedEvents[:limit]
+		}
 	}
 
-	// Attempt to regenerate conversion to get latest diagnostics
-	if conversionService != nil {
-		conversionResult, err := conversionService.ConvertToActivity(event)
+	// Convert AdminEvents to the public activity payload, flagging any the frontend could not render
+	activities := []*models.PublicActivity{}
+	unpublishable := []map[string]interface{}{}
+	for _, event := range approvedEvents {
+		activity, err := convertAdminEventToActivity(&event)
 		if err != nil {
-			details["conversion_status"] = "failed"
-			details["conversion_error"] = err.Error()
-		} else if conversionResult != nil {
-			details["conversion_status"] = "success"
-			details["confidence_score"] = conversionResult.ConfidenceScore
-			details["field_mappings"] = conversionResult.FieldMappings
-			details["issues_count"] = len(conversionResult.Issues)
-			
-			// Add detailed mappings if available
-			if conversionResult.DetailedMappings != nil {
-				details["detailed_mappings"] = conversionResult.DetailedMappings
-			}
-			
-			// Add validation results if available
-			if conversionResult.ValidationResults != nil {
-				details["validation_results"] = conversionResult.ValidationResults
-			}
-
-			// Categorize issues by severity
-			issuesByType := make(map[string][]string)
-			for _, issue := range conversionResult.Issues {
-				// Simple categorization based on keywords
-				if strings.Contains(strings.ToLower(issue), "missing") {
-					issuesByType["missing_data"] = append(issuesByType["missing_data"], issue)
-				} else if strings.Contains(strings.ToLower(issue), "invalid") || strings.Contains(strings.ToLower(issue), "format") {
-					issuesByType["format_issues"] = append(issuesByType["format_issues"], issue)
-				} else {
-					issuesByType["other"] = append(issuesByType["other"], issue)
-				}
-			}
-			details["issues_by_type"] = issuesByType
+			log.Printf("Error converting admin event to activity: %v", err)
+			continue // Skip this event rather than fail entire request
+		}
+		if issues := activity.ValidatePublic(); len(issues) > 0 {
+			log.Printf("Warning: Skipping approved event %s with an unpublishable activity: %v", event.EventID, issues)
+			unpublishable = append(unpublishable, map[string]interface{}{
+				"event_id": event.EventID,
+				"issues":   issues,
+			})
+			continue
 		}
+		activities = append(activities, activity)
 	}
 
-	return details
-}
+	if expandOccurrences {
+		// The calendar is live, so a snapshot's occurrences come from its activities' schedules alone
+		if snapshot == "" {
+			activities = services.OnCalendar(activities, calendarEntries)
+		}
+		if int(offset) < len(activities) {
+			activities = activities[offset:]
+		} else {
+			activities = []*models.PublicActivity{}
+		}
+		if int(limit) < len(activities) {
+			activities = activities[:limit]
+		}
+	}
 
-// generateRawDataSample creates a sample of the raw extracted data for debugging
-func generateRawDataSample(rawData map[string]interface{}) map[string]interface{} {
-	sample := map[string]interface{}{
-		"structure": analyzeDataStructure(rawData),
-		"sample_fields": make(map[string]interface{}),
-		"total_fields": len(rawData),
+	var previewed []string
+	if preview != nil && offset == 0 && queryParams["next_token"] == "" {
+		activities, previewed = addPreviewActivities(ctx, activities, preview)
 	}
 
-	// Add samples of each top-level field
-	fieldCount := 0
-	for key, value := range rawData {
-		if fieldCount >= 5 { // Limit to first 5 fields
-			break
+	// Create response metadata
+	meta := map[string]interface{}{
+		"total":         len(activities),
+		"limit":         limit,
+		"offset":        offset,
+		"last_updated":  time.Now().Format(time.RFC3339),
+		"cache_duration": 300, // 5 minutes cache suggestion
+	}
+	if len(unpublishable) > 0 {
+		meta["unpublishable"] = unpublishable
+	}
+	if snapshot != "" {
+		meta["snapshot"] = snapshot
+	}
+	if preview != nil {
+		meta["preview"] = map[string]interface{}{
+			"label":      preview.Label,
+			"event_ids":  previewed,
+			"expires_at": time.Unix(preview.ExpiresAt, 0).UTC().Format(time.RFC3339),
 		}
+	}
 
-		switch v := value.(type) {
-		case string:
-			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
-				"type": "string",
-				"length": len(v),
-				"sample": truncateString(v, 100),
-			}
-		case []interface{}:
-			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
-				"type": "array",
-				"length": len(v),
-				"sample": truncateArray(v, 2),
-			}
-		case map[string]interface{}:
-			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
-				"type": "object",
-				"fields": len(v),
-				"sample": truncateObject(v, 3),
-			}
-		default:
-			sample["sample_fields"].(map[string]interface{})[key] = map[string]interface{}{
-				"type": fmt.Sprintf("%T", v),
-				"value": v,
-			}
-		}
-		fieldCount++
+	// Apply additional filters if provided
+	if category, ok := queryParams["category"]; ok && category != "" {
+		activities = filterActivitiesByCategory(activities, category)
+		meta["filtered_by_category"] = category
 	}
 
-	return sample
-}
+	if neighborhood, ok := queryParams["neighborhood"]; ok && neighborhood != "" {
+		activities = filterActivitiesByNeighborhood(activities, neighborhood)
+		meta["filtered_by_neighborhood"] = neighborhood
+	}
 
-// assessDataQuality provides a quality assessment of the extracted data
-func assessDataQuality(event *models.AdminEvent) map[string]interface{} {
-	assessment := map[string]interface{}{
-		"overall_score": 0.0,
-		"factors": make(map[string]interface{}),
-		"recommendations": []string{},
+	if transitFriendly, ok := queryParams["transit_friendly"]; ok && transitFriendly != "" {
+		switch transitFriendly {
+		case "true":
+			activities = filterActivitiesByTransitFriendly(activities)
+			meta["filtered_transit_friendly"] = true
+		case "false":
+		default:
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid transit_friendly: must be true or false",
+			}, 400
+		}
 	}
 
-	score := 100.0
-	factors := make(map[string]interface{})
+	if updatedSince, ok := queryParams["updated_since"]; ok && updatedSince != "" {
+		activities = filterActivitiesByUpdatedSince(activities, updatedSince)
+		meta["filtered_updated_since"] = updatedSince
+	}
 
-	// Check if we have extracted data
-	if event.RawExtractedData == nil || len(event.RawExtractedData) == 0 {
-		score -= 50
-		factors["data_availability"] = map[string]interface{}{
-			"score": 0,
-			"message": "No extracted data available",
-		}
-		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Re-run extraction with different schema or check source URL")
-	} else {
-		factors["data_availability"] = map[string]interface{}{
-			"score": 100,
-			"message": "Data successfully extracted",
+	if minCompleteness, ok := queryParams["min_completeness"]; ok && minCompleteness != "" {
+		floor, err := queryparams.ParseFloat("min_completeness", minCompleteness, 0, 1)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
 		}
+		activities = filterActivitiesByCompleteness(activities, floor)
+		meta["filtered_min_completeness"] = floor
 	}
 
-	// Check conversion success
-	if event.ConvertedData != nil {
-		factors["conversion_success"] = map[string]interface{}{
-			"score": 100,
-			"message": "Successfully converted to Activity model",
+	if near != nil {
+		activities = services.WithinRadius(activities, *near, radiusKm)
+		meta["filtered_near"] = map[string]interface{}{
+			"lat":       near.Lat,
+			"lng":       near.Lng,
+			"radius_km": radiusKm,
 		}
-	} else {
-		score -= 30
-		factors["conversion_success"] = map[string]interface{}{
-			"score": 0,
-			"message": "Failed to convert to Activity model",
+	}
+
+	// tag=stem,outdoor keeps activities with every one of the tags
+	if tagFilter, ok := queryParams["tag"]; ok && tagFilter != "" {
+		tags, err := models.NormalizeTags(strings.Split(tagFilter, ","))
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
 		}
-		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Check conversion issues and consider different schema type")
+		activities = filterActivitiesByTags(activities, tags)
+		meta["filtered_by_tags"] = tags
 	}
 
-	// Check conversion issues
-	issueCount := len(event.ConversionIssues)
-	if issueCount == 0 {
-		factors["conversion_issues"] = map[string]interface{}{
-			"score": 100,
-			"message": "No conversion issues",
+	if participationType, ok := queryParams["participation_type"]; ok && participationType != "" {
+		if !models.IsParticipationType(participationType) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid participation_type: must be one of drop-in, registration, ticketed, sold-out",
+			}, 400
 		}
-	} else if issueCount <= 2 {
-		score -= 10
-		factors["conversion_issues"] = map[string]interface{}{
-			"score": 80,
-			"message": fmt.Sprintf("%d minor conversion issues", issueCount),
+		activities = filterActivitiesByParticipation(activities, participationType)
+		meta["filtered_participation_type"] = participationType
+	}
+
+	// A child's age can be given directly in months or as a birthdate, which is converted to months today
+	childAgeStr := queryParams["child_age_months"]
+	if birthdate, ok := queryParams["child_birthdate"]; ok && birthdate != "" && childAgeStr == "" {
+		born, err := time.Parse("2006-01-02", birthdate)
+		if err != nil || born.After(time.Now()) {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid child_birthdate: must be a YYYY-MM-DD date that is not in the future",
+			}, 400
 		}
-	} else {
-		score -= 20
-		factors["conversion_issues"] = map[string]interface{}{
-			"score": 60,
-			"message": fmt.Sprintf("%d conversion issues detected", issueCount),
+		childAgeStr = strconv.Itoa(models.AgeInMonths(born, time.Now()))
+	}
+	if childAgeStr != "" {
+		childAgeMonths, err := queryparams.ParseInt("child_age_months", childAgeStr, 0, maxChildAgeMonths)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, 400
 		}
-		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Review conversion issues and improve source data quality")
+		activities = filterActivitiesByChildAge(activities, childAgeMonths)
+		meta["filtered_child_age_months"] = childAgeMonths
 	}
 
-	// Check events count
-	eventsCount := event.GetExtractedEventsCount()
-	if eventsCount == 0 {
-		score -= 40
-		factors["events_count"] = map[string]interface{}{
-			"score": 0,
-			"message": "No events found in extracted data",
-		}
-		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Try different schema type or check if URL contains event information")
-	} else if eventsCount >= 1 && eventsCount <= 50 {
-		factors["events_count"] = map[string]interface{}{
-			"score": 100,
-			"message": fmt.Sprintf("%d events found", eventsCount),
-		}
+	if expandOccurrences {
+		activities = services.ExpandOccurrences(activities, occurrenceFrom, occurrenceTo)
+		meta["expanded_occurrences"] = true
+		meta["filtered_from_date"] = occurrenceFrom.Format("2006-01-02")
+		meta["filtered_to_date"] = occurrenceTo.Format("2006-01-02")
 	} else {
-		score -= 10
-		factors["events_count"] = map[string]interface{}{
-			"score": 90,
-			"message": fmt.Sprintf("%d events found (unusually high)", eventsCount),
+		if dateFrom, ok := queryParams["date_from"]; ok && dateFrom != "" {
+			activities = filterActivitiesByDate(activities, dateFrom)
+			meta["filtered_from_date"] = dateFrom
+		}
+		if dateTo, ok := queryParams["date_to"]; ok && dateTo != "" {
+			activities = filterActivitiesByDateTo(activities, dateTo)
+			meta["filtered_to_date"] = dateTo
 		}
-		assessment["recommendations"] = append(assessment["recommendations"].([]string), "Verify extraction accuracy - high event count may indicate over-extraction")
 	}
 
-	assessment["overall_score"] = math.Max(0, score)
-	assessment["factors"] = factors
+	// Update final count after filtering
+	meta["total"] = len(activities)
 
-	return assessment
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d approved events", len(activities)),
+		Data: map[string]interface{}{
+			"activities": activities,
+			"facets":     services.ComputeActivityFacets(activities),
+			"meta":       meta,
+		},
+		Meta: pageMeta(nextToken),
+	}, 200
 }
 
-// Helper functions for data sampling
-func truncateArray(arr []interface{}, maxItems int) []interface{} {
-	if len(arr) <= maxItems {
-		return arr
-	}
-	return arr[:maxItems]
-}
+// Calendar feed settings
+const (
+	calendarFeedName            = "Seattle Family Activities"
+	calendarFeedDomain          = "seattle-family-activities"
+	calendarFeedRefreshInterval = 6 * time.Hour
+	// Activities that ended longer ago than this are dropped from the feed
+	calendarFeedHistoryDays = 30
+)
 
-func truncateObject(obj map[string]interface{}, maxFields int) map[string]interface{} {
-	if len(obj) <= maxFields {
-		return obj
-	}
-	
-	result := make(map[string]interface{})
-	count := 0
-	for k, v := range obj {
-		if count >= maxFields {
-			break
-		}
-		result[k] = v
-		count++
+// handleGetApprovedEventsFeed handles GET /api/events/approved.ics - Public iCalendar feed of the
+// approved activities that families subscribe to from Google or Apple Calendar. The feed is
+// returned as the response's data for the router to serve as text/calendar. category,
+// neighborhood, tag and child_age_months filter it as on GET /api/events/approved.
+func handleGetApprovedEventsFeed(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	activities, _, statusCode, err := approvedFeedActivities(ctx, queryParams, time.Now().AddDate(0, 0, -calendarFeedHistoryDays))
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, statusCode
 	}
-	return result
-}
 
-func analyzeDataStructure(data map[string]interface{}) map[string]interface{} {
-	structure := map[string]interface{}{
-		"total_fields": len(data),
-		"field_types": make(map[string]int),
-		"array_fields": []string{},
-		"object_fields": []string{},
-		"string_fields": []string{},
+	feedActivities := make([]*models.Activity, len(activities))
+	for i := range activities {
+		feedActivities[i] = &activities[i].Activity
 	}
+	feed := export.ICS(export.Feed{
+		Name:            calendarFeedName,
+		Domain:          calendarFeedDomain,
+		RefreshInterval: calendarFeedRefreshInterval,
+	}, feedActivities, time.Now())
 
-	fieldTypes := make(map[string]int)
-	var arrayFields, objectFields, stringFields []string
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Exported %d approved events", len(feedActivities)),
+		Data:    feed,
+	}, 200
+}
 
-	for key, value := range data {
-		switch value.(type) {
-		case string:
-			fieldTypes["string"]++
-			stringFields = append(stringFields, key)
-		case []interface{}:
-			fieldTypes["array"]++
-			arrayFields = append(arrayFields, key)
-		case map[string]interface{}:
-			fieldTypes["object"]++
-			objectFields = append(objectFields, key)
-		case int, int64, float64:
-			fieldTypes["number"]++
-		case bool:
-			fieldTypes["boolean"]++
-		default:
-			fieldTypes["other"]++
+// approvedFeedActivities returns the published activities for a feed that have not ended before
+// endedBefore, filtered by category, neighborhood, tag and child_age_months as on
+// GET /api/events/approved, with when each was approved keyed by event ID. Invalid filters return
+// 400 and a failed read 500.
+func approvedFeedActivities(ctx context.Context, queryParams map[string]string, endedBefore time.Time) ([]*models.PublicActivity, map[string]time.Time, int, error) {
+	var tags []string
+	if tagFilter := queryParams["tag"]; tagFilter != "" {
+		var err error
+		if tags, err = models.NormalizeTags(strings.Split(tagFilter, ",")); err != nil {
+			return nil, nil, 400, err
+		}
+	}
+	childAgeMonths := -1
+	if childAgeStr := queryParams["child_age_months"]; childAgeStr != "" {
+		var err error
+		if childAgeMonths, err = queryparams.ParseInt("child_age_months", childAgeStr, 0, maxChildAgeMonths); err != nil {
+			return nil, nil, 400, err
 		}
 	}
 
-	structure["field_types"] = fieldTypes
-	structure["array_fields"] = arrayFields
-	structure["object_fields"] = objectFields
-	structure["string_fields"] = stringFields
-
-	return structure
-}
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		log.Printf("Error getting approved events for a feed: %v", err)
+		return nil, nil, 500, fmt.Errorf("failed to retrieve approved events")
+	}
+	approvedEvents = services.VisibleAdminEvents(approvedEvents, time.Now())
 
-// handleGetPendingEvents handles GET /api/events/pending
-func handleGetPendingEvents(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
-	limit := int32(50)
-	if limitStr, ok := queryParams["limit"]; ok {
-		if parsedLimit := parseLimit(limitStr); parsedLimit > 0 {
-			limit = parsedLimit
+	activities := []*models.PublicActivity{}
+	approvedAt := make(map[string]time.Time, len(approvedEvents))
+	for _, event := range approvedEvents {
+		activity, err := convertAdminEventToActivity(&event)
+		if err != nil {
+			log.Printf("Error converting admin event %s for a feed: %v", event.EventID, err)
+			continue
 		}
+		if issues := activity.ValidatePublic(); len(issues) > 0 {
+			continue // Unpublishable activities are reported by GET /api/events/approved
+		}
+		activities = append(activities, activity)
+		approvedAt[event.EventID] = event.ApprovalTime()
 	}
 
-	// Get all pending events (pending + edited)
-	pendingEvents, err := dynamoService.GetAllPendingAdminEvents(ctx, limit)
-	if err != nil {
-		log.Printf("Error getting pending events: %v", err)
-		return ResponseBody{
-			Success: false,
-			Error:   "Failed to retrieve pending events",
-		}, 500
+	activities = filterActivitiesByDate(activities, endedBefore.Format("2006-01-02"))
+	if category := queryParams["category"]; category != "" {
+		activities = filterActivitiesByCategory(activities, category)
+	}
+	if neighborhood := queryParams["neighborhood"]; neighborhood != "" {
+		activities = filterActivitiesByNeighborhood(activities, neighborhood)
+	}
+	if len(tags) > 0 {
+		activities = filterActivitiesByTags(activities, tags)
+	}
+	if childAgeMonths >= 0 {
+		activities = filterActivitiesByChildAge(activities, childAgeMonths)
 	}
+	return activities, approvedAt, 200, nil
+}
 
-	// Enhance each event with detailed conversion and diagnostic information
-	var enhancedEvents []map[string]interface{}
-	for _, event := range pendingEvents {
-		enhanced := map[string]interface{}{
-			"event_id":             event.EventID,
-			"source_url":           event.SourceURL,
-			"schema_type":          event.SchemaType,
-			"status":               event.Status,
-			"extracted_at":         event.ExtractedAt,
-			"extracted_by_user":    event.ExtractedByUser,
-			"events_count":         event.GetExtractedEventsCount(),
-			"conversion_issues":    event.ConversionIssues,
-			"can_approve":          event.CanBeApproved(),
-			"admin_notes":          event.AdminNotes,
+// Latest approvals feeds, GET /api/events/approved.rss and GET /api/events/approved.atom
+const (
+	latestFeedMaxItems = 200
+	latestFeedRSS      = "rss"
+	latestFeedAtom     = "atom"
+)
+
+// handleGetLatestApprovedFeed handles GET /api/events/approved.rss and GET /api/events/approved.atom -
+// Public RSS and Atom feeds of the most recently approved activities that have not ended, newest
+// first. limit sets how many are listed (default 50), and category, neighborhood, tag and
+// child_age_months filter them as on GET /api/events/approved. The feed is returned as the
+// response's data for the router to serve as XML.
+func handleGetLatestApprovedFeed(format string) func(context.Context, map[string]string) (ResponseBody, int) {
+	return func(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+		limit := services.DefaultFeedItems
+		if limitStr := queryParams["limit"]; limitStr != "" {
+			var err error
+			if limit, err = queryparams.ParseInt("limit", limitStr, 1, latestFeedMaxItems); err != nil {
+				return ResponseBody{
+					Success: false,
+					Error:   err.Error(),
+				}, 400
+			}
 		}
 
-		// Add conversion preview if available
-		if event.ConvertedData != nil {
-			enhanced["conversion_preview"] = event.ConvertedData
+		now := time.Now()
+		activities, approvedAt, statusCode, err := approvedFeedActivities(ctx, queryParams, now)
+		if err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   err.Error(),
+			}, statusCode
+		}
+
+		items := make([]export.FeedItem, 0, len(activities))
+		for _, activity := range activities {
+			items = append(items, export.FeedItem{
+				Activity:   &activity.Activity,
+				ApprovedAt: approvedAt[activity.AdminMetadata.EventID],
+			})
+		}
+		items = export.Latest(items, limit)
+
+		channel := services.DefaultFeedChannel
+		channel.Link = publicSiteURL
+		var feed []byte
+		if format == latestFeedAtom {
+			feed, err = export.Atom(channel, items, now)
+		} else {
+			feed, err = export.RSS(channel, items, now)
+		}
+		if err != nil {
+			log.Printf("Error rendering the %s feed: %v", format, err)
+			return ResponseBody{
+				Success: false,
+				Error:   "Failed to render feed",
+			}, 500
 		}
 
-		// Generate detailed conversion information
-		conversionDetails := generateConversionDetails(ctx, &event)
-		enhanced["conversion_details"] = conversionDetails
+		return ResponseBody{
+			Success: true,
+			Message: fmt.Sprintf("Exported the %d latest approved events", len(items)),
+			Data:    string(feed),
+		}, 200
+	}
+}
 
-		// Add raw data sample for debugging
-		rawDataSample := generateRawDataSample(event.RawExtractedData)
-		enhanced["raw_data_sample"] = rawDataSample
+// handleGetEventsCalendar handles GET /api/events/calendar - Public endpoint for the month view.
+// Approved activities are bucketed by the days they occur on in the requested month (default: current month).
+func handleGetEventsCalendar(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	month := queryParams["month"]
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid month: must be YYYY-MM",
+		}, 400
+	}
 
-		// Add data quality assessment
-		qualityAssessment := assessDataQuality(&event)
-		enhanced["quality_assessment"] = qualityAssessment
+	first, _ := time.Parse("2006-01", month)
+	entries, err := dynamoService.GetCalendarEntriesForRange(ctx, first, first.AddDate(0, 1, -1), 0)
+	if err != nil {
+		log.Printf("Error getting calendar for %s: %v", month, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve calendar",
+		}, 500
+	}
 
-		enhancedEvents = append(enhancedEvents, enhanced)
+	calendar, err := services.BuildCalendarMonth(month, entries)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
 
 	return ResponseBody{
 		Success: true,
-		Message: "Pending events retrieved successfully",
-		Data:    enhancedEvents,
+		Message: fmt.Sprintf("Retrieved %d activities for %s", calendar.Total, month),
+		Data:    calendar,
 	}, 200
 }
 
-// handleGetEvent handles GET /api/events/{id}
-func handleGetEvent(ctx context.Context, eventID string) (ResponseBody, int) {
-	if eventID == "" {
+// handleGetWeekendPlan handles GET /api/plans/weekend?ages=4,7&region=north-seattle&budget=free -
+// Public endpoint for the frontend's planner. Suggests activities for this weekend's (or, on a
+// weekday, the next weekend's) mornings and afternoons from the published activities.
+func handleGetWeekendPlan(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	planRequest, err := services.ParseWeekendPlanRequest(queryParams)
+	if err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Event ID is required",
+			Error:   err.Error(),
 		}, 400
 	}
 
-	// Get the admin event by ID
-	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
 	if err != nil {
-		log.Printf("Error getting admin event: %v", err)
+		log.Printf("Error getting approved events for weekend plan: %v", err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Event not found",
-		}, 404
+			Error:   "Failed to retrieve activities",
+		}, 500
 	}
+	approvedEvents = services.VisibleAdminEvents(approvedEvents, time.Now())
 
-	// Generate fresh conversion preview
-	conversionPreview, err := conversionService.PreviewConversion(adminEvent)
-	if err != nil {
-		log.Printf("Error generating conversion preview: %v", err)
-		conversionPreview = map[string]interface{}{
-			"error": "Could not generate conversion preview",
+	activities := []*models.PublicActivity{}
+	for _, event := range approvedEvents {
+		activity, err := convertAdminEventToActivity(&event)
+		if err != nil {
+			log.Printf("Error converting admin event %s for weekend plan: %v", event.EventID, err)
+			continue
 		}
+		if issues := activity.ValidatePublic(); len(issues) > 0 {
+			continue // Unpublishable activities are reported by GET /api/events/approved
+		}
+		activities = append(activities, activity)
 	}
 
-	eventDetails := map[string]interface{}{
-		"event_id":             adminEvent.EventID,
-		"source_url":           adminEvent.SourceURL,
-		"schema_type":          adminEvent.SchemaType,
-		"schema_used":          adminEvent.SchemaUsed,
-		"raw_extracted_data":   adminEvent.RawExtractedData,
-		"conversion_preview":   conversionPreview,
-		"status":               adminEvent.Status,
-		"extracted_at":         adminEvent.ExtractedAt,
-		"extracted_by_user":    adminEvent.ExtractedByUser,
-		"admin_notes":          adminEvent.AdminNotes,
-		"conversion_issues":    adminEvent.ConversionIssues,
-		"can_approve":          adminEvent.CanBeApproved(),
-		"events_count":         adminEvent.GetExtractedEventsCount(),
+	// Dated activities are planned on the days the calendar has them
+	saturday := services.WeekendOf(time.Now())
+	entries, err := dynamoService.GetCalendarEntriesForRange(ctx, saturday, saturday.AddDate(0, 0, 1), 0)
+	if err != nil {
+		log.Printf("Error getting calendar entries for weekend plan: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve activities",
+		}, 500
 	}
 
+	plan := services.PlanWeekend(services.OnCalendar(activities, entries), saturday, planRequest)
+
 	return ResponseBody{
 		Success: true,
-		Message: "Event details retrieved successfully",
-		Data:    eventDetails,
+		Message: fmt.Sprintf("Planned the weekend of %s from %d matching activities", plan.Saturday, plan.Candidates),
+		Data:    plan,
 	}, 200
 }
 
-// handleApproveEvent handles PUT /api/events/{id}/approve
-func handleApproveEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
-	if eventID == "" {
+// Page sizes of the catalog change feed
+const (
+	defaultCatalogChangesLimit = 100
+	maxCatalogChangesLimit     = 500
+)
+
+// handleGetCatalogChanges handles GET /api/changes?since=cursor - Public endpoint for partners
+// syncing the catalog incrementally. Changes are returned in order after the cursor; next_cursor
+// continues after the last one returned.
+func handleGetCatalogChanges(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	since, err := models.ParseCatalogChangeCursor(queryParams["since"])
+	if err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Event ID is required",
+			Error:   err.Error(),
 		}, 400
 	}
 
-	var req models.AdminEventReview
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
+	limit, err := queryparams.Int(queryParams, "limit", defaultCatalogChangesLimit, 1, maxCatalogChangesLimit)
+	if err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
+			Error:   err.Error(),
 		}, 400
 	}
 
-	// Get the admin event
-	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	changes, more, err := dynamoService.GetCatalogChanges(ctx, since, int32(limit))
 	if err != nil {
+		log.Printf("Error getting catalog changes since %d: %v", since, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Event not found",
-		}, 404
+			Error:   "Failed to retrieve changes",
+		}, 500
 	}
 
-	// Check if event can be approved
-	if !adminEvent.IsPending() {
+	next := since
+	if len(changes) > 0 {
+		next = changes[len(changes)-1].Sequence
+	}
+
+	return ResponseBody{
+		Success: true,
+		Message: fmt.Sprintf("Retrieved %d changes", len(changes)),
+		Data: map[string]interface{}{
+			"changes":     changes,
+			"count":       len(changes),
+			"next_cursor": models.CatalogChangeCursor(next),
+			"has_more":    more,
+		},
+	}, 200
+}
+
+// maxVenueScheduleDays bounds the date range of a venue schedule request
+const maxVenueScheduleDays = 92
+
+// handleGetVenueEvents handles GET /api/venues/{id}/events - Public endpoint for venue profile pages.
+// Upcoming activity days at the venue are grouped by week; the range defaults to the next 8 weeks.
+func handleGetVenueEvents(ctx context.Context, venueID string, queryParams map[string]string) (ResponseBody, int) {
+	if venueID == "" {
 		return ResponseBody{
 			Success: false,
-			Error:   fmt.Sprintf("Event cannot be approved - current status: %s", adminEvent.Status),
+			Error:   "Venue ID is required",
 		}, 400
 	}
 
-	// Convert to Activity model with detailed diagnostics
-	conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+	from, to, err := parseVenueScheduleRange(queryParams)
 	if err != nil {
-		// Get detailed conversion diagnostics for better error reporting
-		conversionDiagnostics := conversionService.GetLastConversionDiagnostics()
-		
-		errorDetails := map[string]interface{}{
-			"conversion_error": err.Error(),
-			"event_id": eventID,
-			"source_url": adminEvent.SourceURL,
-			"schema_type": adminEvent.SchemaType,
-		}
-		
-		if conversionDiagnostics != nil {
-			errorDetails["diagnostics"] = map[string]interface{}{
-				"processing_time": conversionDiagnostics.ProcessingTime.String(),
-				"conversion_issues": conversionDiagnostics.ConversionIssues,
-				"field_mappings": conversionDiagnostics.FieldMappings,
-				"confidence_score": conversionDiagnostics.ConfidenceScore,
-			}
-		}
-		
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to convert event to activity - see details for more information",
-			Data:    errorDetails,
-		}, 500
+			Error:   err.Error(),
+		}, 400
 	}
 
-	if conversionResult.Activity == nil {
-		errorDetails := map[string]interface{}{
-			"conversion_issues": conversionResult.Issues,
-			"field_mappings": conversionResult.FieldMappings,
-			"confidence_score": conversionResult.ConfidenceScore,
-			"event_id": eventID,
-			"source_url": adminEvent.SourceURL,
-			"suggestions": []string{
-				"Check if the extracted data contains valid event information",
-				"Try using a different schema type for extraction",
-				"Review the conversion issues for specific problems",
-			},
-		}
-		
-		if conversionResult.DetailedMappings != nil {
-			errorDetails["detailed_mappings"] = conversionResult.DetailedMappings
-		}
-		
-		if conversionResult.ValidationResults != nil {
-			errorDetails["validation_results"] = conversionResult.ValidationResults
-		}
-		
+	venue, err := dynamoService.GetVenue(ctx, venueID)
+	if err != nil {
+		log.Printf("Error getting venue %s: %v", venueID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Could not generate valid activity from event data - see details for diagnostic information",
-			Data:    errorDetails,
-		}, 400
+			Error:   "Failed to retrieve venue",
+		}, 500
 	}
-
-	// Store the converted activity in the main activities table
-	activities := []*models.Activity{conversionResult.Activity}
-	if err := dynamoService.BatchPutActivities(ctx, activities); err != nil {
-		log.Printf("Error storing approved activity: %v", err)
+	if venue == nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to publish approved event",
-		}, 500
+			Error:   "Venue not found",
+		}, 404
 	}
 
-	// Update admin event status
-	now := time.Now()
-	adminEvent.Status = models.AdminEventStatusApproved
-	adminEvent.ReviewedAt = &now
-	adminEvent.ReviewedBy = req.ReviewedBy
-	adminEvent.AdminNotes = req.AdminNotes
-
-	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
-		log.Printf("Error updating admin event status: %v", err)
-		// Event was published but status update failed - log but don't fail
+	entries, err := dynamoService.GetVenueCalendarEntries(ctx, venueID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		log.Printf("Error getting events for venue %s: %v", venueID, err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to retrieve venue events",
+		}, 500
 	}
 
-	// Get final conversion diagnostics for success response
-	conversionDiagnostics := conversionService.GetLastConversionDiagnostics()
-	
-	successData := map[string]interface{}{
-		"event_id":    eventID,
-		"activity_id": conversionResult.Activity.ID,
-		"status":      "approved",
-		"conversion_summary": map[string]interface{}{
-			"confidence_score": conversionResult.ConfidenceScore,
-			"issues_count": len(conversionResult.Issues),
-			"field_mappings_count": len(conversionResult.FieldMappings),
-		},
-	}
-	
-	// Add detailed conversion information if available
-	if conversionDiagnostics != nil {
-		successData["conversion_details"] = map[string]interface{}{
-			"processing_time": conversionDiagnostics.ProcessingTime.String(),
-			"success": conversionDiagnostics.Success,
-			"field_mappings": conversionDiagnostics.FieldMappings,
-		}
-	}
-	
-	// Include any conversion issues as warnings
-	if len(conversionResult.Issues) > 0 {
-		successData["warnings"] = conversionResult.Issues
-	}
+	schedule := services.BuildVenueSchedule(venue, from, to, entries)
 
 	return ResponseBody{
 		Success: true,
-		Message: "Event approved and published successfully",
-		Data:    successData,
+		Message: fmt.Sprintf("Retrieved %d upcoming activities at %s", schedule.Total, schedule.VenueName),
+		Data:    schedule,
 	}, 200
 }
 
-// handleRejectEvent handles PUT /api/events/{id}/reject
-func handleRejectEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
-	if eventID == "" {
-		return ResponseBody{
-			Success: false,
-			Error:   "Event ID is required",
-		}, 400
-	}
-
-	var req models.AdminEventReview
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
+// handleGetActivity handles GET /api/activities/{id} - Public endpoint for links and saved activities.
+// An ID retired by the activity ID backfill is followed to the activity's current ID, which the
+// response reports next to the ID that was asked for.
+func handleGetActivity(ctx context.Context, activityID string) (ResponseBody, int) {
+	if activityID == "" {
 		return ResponseBody{
 			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
+			Error:   "Activity ID is required",
 		}, 400
 	}
 
-	// Get the admin event
-	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	redirectedFrom := ""
+	redirect, err := dynamoService.GetActivityRedirect(ctx, activityID)
 	if err != nil {
+		log.Printf("Error getting redirect for activity %s: %v", activityID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Event not found",
-		}, 404
+			Error:   "Failed to retrieve activity",
+		}, 500
+	}
+	if redirect != nil {
+		redirectedFrom = activityID
+		activityID = redirect.ActivityID
 	}
 
-	// Update admin event status
-	now := time.Now()
-	adminEvent.Status = models.AdminEventStatusRejected
-	adminEvent.ReviewedAt = &now
-	adminEvent.ReviewedBy = req.ReviewedBy
-	adminEvent.AdminNotes = req.AdminNotes
-
-	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
-		log.Printf("Error updating admin event status: %v", err)
+	adminEvents, err := dynamoService.GetAdminEventsByActivityID(ctx, activityID)
+	if err != nil {
+		log.Printf("Error getting events for activity %s: %v", activityID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to reject event",
+			Error:   "Failed to retrieve activity",
 		}, 500
 	}
 
-	// Generate diagnostic information for the rejection
-	rejectionData := map[string]interface{}{
-		"event_id": eventID,
-		"status":   "rejected",
-		"rejection_details": map[string]interface{}{
-			"rejected_by": req.ReviewedBy,
-			"rejected_at": now,
-			"admin_notes": req.AdminNotes,
-			"source_url": adminEvent.SourceURL,
-			"schema_type": adminEvent.SchemaType,
-		},
-	}
-	
-	// Add conversion analysis to help understand why it was rejected
-	if conversionService != nil {
-		conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+	now := time.Now()
+	for i := range adminEvents {
+		if !adminEvents[i].IsPubliclyVisible(now) {
+			continue
+		}
+		activity, err := convertAdminEventToActivity(&adminEvents[i])
 		if err != nil {
-			rejectionData["conversion_analysis"] = map[string]interface{}{
-				"conversion_failed": true,
-				"error": err.Error(),
-			}
-		} else if conversionResult != nil {
-			rejectionData["conversion_analysis"] = map[string]interface{}{
-				"conversion_succeeded": true,
-				"confidence_score": conversionResult.ConfidenceScore,
-				"issues_count": len(conversionResult.Issues),
-				"issues": conversionResult.Issues,
-				"field_mappings": conversionResult.FieldMappings,
-			}
+			log.Printf("Error converting admin event %s to activity: %v", adminEvents[i].EventID, err)
+			continue
+		}
+
+		data := map[string]interface{}{
+			"activity": activity,
+		}
+		if redirectedFrom != "" {
+			data["redirected_from"] = redirectedFrom
 		}
+		return ResponseBody{
+			Success: true,
+			Message: "Activity retrieved successfully",
+			Data:    data,
+		}, 200
 	}
-	
-	// Add data quality assessment
-	qualityAssessment := assessDataQuality(adminEvent)
-	rejectionData["quality_assessment"] = qualityAssessment
 
 	return ResponseBody{
-		Success: true,
-		Message: "Event rejected successfully",
-		Data:    rejectionData,
-	}, 200
+		Success: false,
+		Error:   "Activity not found",
+	}, 404
 }
 
-// handleEditEvent handles PUT /api/events/{id}/edit
-func handleEditEvent(ctx context.Context, eventID string, body string) (ResponseBody, int) {
-	if eventID == "" {
+// parseVenueScheduleRange reads the from and to dates (YYYY-MM-DD, inclusive) of a venue schedule
+// request. The range starts today and spans 8 weeks unless given.
+func parseVenueScheduleRange(queryParams map[string]string) (time.Time, time.Time, error) {
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	from, to := today, today.AddDate(0, 0, 8*7-1)
+	if fromStr := queryParams["from"]; fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return from, to, fmt.Errorf("Invalid from: must be YYYY-MM-DD")
+		}
+		from, to = parsed, parsed.AddDate(0, 0, 8*7-1)
+	}
+	if toStr := queryParams["to"]; toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return from, to, fmt.Errorf("Invalid to: must be YYYY-MM-DD")
+		}
+		to = parsed
+	}
+	if to.Before(from) || to.Sub(from) >= maxVenueScheduleDays*24*time.Hour {
+		return from, to, fmt.Errorf("Invalid range: to must be on or after from and at most %d days later", maxVenueScheduleDays-1)
+	}
+	return from, to, nil
+}
+
+// handleGetAdminVenue handles GET /api/admin/venues/{id}?from=&to= - the admin detail view of a venue.
+// It lists the program sessions in the range that overlap in the same room under different titles,
+// which usually point at an extraction error in one of the events.
+func handleGetAdminVenue(ctx context.Context, venueID string, queryParams map[string]string) (ResponseBody, int) {
+	if venueID == "" {
 		return ResponseBody{
 			Success: false,
-			Error:   "Event ID is required",
+			Error:   "Venue ID is required",
 		}, 400
 	}
 
-	var req models.AdminEventReview
-	if err := json.Unmarshal([]byte(body), &req); err != nil {
+	from, to, err := parseVenueScheduleRange(queryParams)
+	if err != nil {
 		return ResponseBody{
 			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
+			Error:   err.Error(),
 		}, 400
 	}
 
-	// Get the admin event
-	adminEvent, err := dynamoService.GetAdminEventByID(ctx, eventID)
+	venue, err := dynamoService.GetVenue(ctx, venueID)
 	if err != nil {
+		log.Printf("Error getting venue %s: %v", venueID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Event not found",
-		}, 404
+			Error:   "Failed to retrieve venue",
+		}, 500
 	}
-
-	// Update raw extracted data with edited data
-	if req.EditedData != nil {
-		adminEvent.RawExtractedData = req.EditedData
+	if venue == nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Venue not found",
+		}, 404
 	}
 
-	// Update status to edited
-	now := time.Now()
-	adminEvent.Status = models.AdminEventStatusEdited
-	adminEvent.ReviewedAt = &now
-	adminEvent.ReviewedBy = req.ReviewedBy
-	adminEvent.AdminNotes = req.AdminNotes
-
-	// Regenerate conversion preview with edited data
-	conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+	entries, err := dynamoService.GetVenueCalendarEntries(ctx, venueID, from.Format("2006-01-02"), to.Format("2006-01-02"))
 	if err != nil {
-		log.Printf("Error regenerating conversion preview: %v", err)
-	} else {
-		if conversionResult.Activity != nil {
-			activityJSON, _ := json.Marshal(conversionResult.Activity)
-			var activityMap map[string]interface{}
-			json.Unmarshal(activityJSON, &activityMap)
-			adminEvent.ConvertedData = activityMap
-		}
-		adminEvent.ConversionIssues = conversionResult.Issues
-	}
-
-	if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
-		log.Printf("Error updating admin event: %v", err)
+		log.Printf("Error getting events for venue %s: %v", venueID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to save edited event",
+			Error:   "Failed to retrieve venue events",
 		}, 500
 	}
 
+	conflicts := services.FindScheduleConflicts(entries)
+
 	return ResponseBody{
 		Success: true,
-		Message: "Event edited successfully",
+		Message: fmt.Sprintf("Found %d schedule conflicts at %s", len(conflicts), venue.VenueName),
 		Data: map[string]interface{}{
-			"event_id": eventID,
-			"status":   "edited",
+			"venue":          venue,
+			"from":           from.Format("2006-01-02"),
+			"to":             to.Format("2006-01-02"),
+			"sessions":       len(entries),
+			"conflicts":      conflicts,
+			"conflict_count": len(conflicts),
 		},
 	}, 200
 }
 
-// handleGetSchemas handles GET /api/schemas
-func handleGetSchemas(ctx context.Context) (ResponseBody, int) {
-	schemas := models.GetPredefinedSchemas()
-
-	// Format schemas for frontend consumption
-	formattedSchemas := make(map[string]interface{})
-	for key, schema := range schemas {
-		formattedSchemas[key] = map[string]interface{}{
-			"name":        schema.Name,
-			"description": schema.Description,
-			"examples":    schema.Examples,
-			"schema":      schema.Schema,
-		}
+// handleUpdateVenueArrival handles PUT /api/admin/venues/{id}/arrival - sets the parking and arrival
+// notes of a registry venue. Activities held at the venue take the parts of them they lack.
+func handleUpdateVenueArrival(ctx context.Context, venueID string, body string) (ResponseBody, int) {
+	if venueID == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Venue ID is required",
+		}, 400
 	}
 
-	return ResponseBody{
-		Success: true,
-		Message: "Available extraction schemas",
-		Data:    formattedSchemas,
-	}, 200
-}
-
-// handleGetApprovedEvents handles GET /api/events/approved - Public endpoint for main frontend
-func handleGetApprovedEvents(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
-	// Parse query parameters
-	limit := int32(100) // Default limit
-	if limitStr, ok := queryParams["limit"]; ok {
-		if parsedLimit := parseLimit(limitStr); parsedLimit > 0 && parsedLimit <= 500 {
-			limit = parsedLimit
-		}
+	var req struct {
+		ArrivalInfo *models.ArrivalInfo `json:"arrival_info"`
+		UpdatedBy   string              `json:"updated_by"`
 	}
-
-	offset := int32(0)
-	if offsetStr, ok := queryParams["offset"]; ok {
-		if parsedOffset := parseLimit(offsetStr); parsedOffset > 0 {
-			offset = parsedOffset
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		}, 400
+	}
+	if req.ArrivalInfo != nil {
+		if err := req.ArrivalInfo.Validate(); err != nil {
+			return ResponseBody{
+				Success: false,
+				Error:   "Invalid arrival_info: " + err.Error(),
+			}, 400
 		}
 	}
 
-	// Get all approved admin events
-	approvedEvents, err := dynamoService.GetApprovedAdminEvents(ctx, limit+offset) // Get extra for offset
+	err := dynamoService.SaveVenueArrivalInfo(ctx, venueID, req.ArrivalInfo)
+	if errors.Is(err, services.ErrVenueNotFound) {
+		return ResponseBody{
+			Success: false,
+			Error:   "Venue not found",
+		}, 404
+	}
 	if err != nil {
-		log.Printf("Error getting approved events: %v", err)
+		log.Printf("Error saving arrival info for venue %s: %v", venueID, err)
 		return ResponseBody{
 			Success: false,
-			Error:   "Failed to retrieve approved events",
+			Error:   "Failed to update venue arrival info",
 		}, 500
 	}
 
-	// Apply offset if specified
-	if offset > 0 && int(offset) < len(approvedEvents) {
-		approvedEvents = approvedEvents[offset:]
-	}
-
-	// Apply limit
-	if int(limit) < len(approvedEvents) {
-		approvedEvents = approvedEvents[:limit]
-	}
-
-	// Convert AdminEvents to Activity format for frontend compatibility
-	var activities []map[string]interface{}
-	for _, event := range approvedEvents {
-		activity, err := convertAdminEventToActivity(&event)
-		if err != nil {
-			log.Printf("Error converting admin event to activity: %v", err)
-			continue // Skip this event rather than fail entire request
-		}
-		activities = append(activities, activity)
-	}
+	log.Printf("Arrival info of venue %s updated by %s", venueID, req.UpdatedBy)
 
-	// Create response metadata
-	meta := map[string]interface{}{
-		"total":         len(activities),
-		"limit":         limit,
-		"offset":        offset,
-		"last_updated":  time.Now().Format(time.RFC3339),
-		"cache_duration": 300, // 5 minutes cache suggestion
-	}
+	return ResponseBody{
+		Success: true,
+		Message: "Venue arrival info updated successfully",
+		Data: map[string]interface{}{
+			"venue_id":     venueID,
+			"arrival_info": req.ArrivalInfo,
+		},
+	}, 200
+}
 
-	// Apply additional filters if provided
-	if category, ok := queryParams["category"]; ok && category != "" {
-		activities = filterActivitiesByCategory(activities, category)
-		meta["filtered_by_category"] = category
+// handleSearchSuggest handles GET /api/search/suggest - Public endpoint for the search box
+func handleSearchSuggest(ctx context.Context, queryParams map[string]string) (ResponseBody, int) {
+	query := strings.TrimSpace(queryParams["q"])
+	if query == "" {
+		return ResponseBody{
+			Success: false,
+			Error:   "Query parameter q is required",
+		}, 400
 	}
 
-	if dateFrom, ok := queryParams["date_from"]; ok && dateFrom != "" {
-		activities = filterActivitiesByDate(activities, dateFrom)
-		meta["filtered_from_date"] = dateFrom
+	limit, err := queryparams.Int(queryParams, "limit", 10, 1, 25)
+	if err != nil {
+		return ResponseBody{
+			Success: false,
+			Error:   err.Error(),
+		}, 400
 	}
 
-	if updatedSince, ok := queryParams["updated_since"]; ok && updatedSince != "" {
-		activities = filterActivitiesByUpdatedSince(activities, updatedSince)
-		meta["filtered_updated_since"] = updatedSince
+	suggestions, err := suggestIndex.Suggest(ctx, query, limit)
+	if err != nil {
+		log.Printf("Error getting search suggestions: %v", err)
+		return ResponseBody{
+			Success: false,
+			Error:   "Failed to get search suggestions",
+		}, 500
 	}
 
-	// Update final count after filtering
-	meta["total"] = len(activities)
-
 	return ResponseBody{
 		Success: true,
-		Message: fmt.Sprintf("Retrieved %d approved events", len(activities)),
+		Message: fmt.Sprintf("Found %d suggestions", len(suggestions)),
 		Data: map[string]interface{}{
-			"activities": activities,
-			"meta":       meta,
+			"query":       query,
+			"suggestions": suggestions,
 		},
 	}, 200
 }
 
 // Helper functions for approved events endpoint
 
-// convertAdminEventToActivity converts an AdminEvent to Activity format for frontend
-func convertAdminEventToActivity(event *models.AdminEvent) (map[string]interface{}, error) {
-	// Use the conversion service if available, otherwise create basic mapping
-	if conversionService != nil {
-		conversionResult, err := conversionService.ConvertToActivity(event)
-		if err != nil {
-			return nil, fmt.Errorf("conversion service failed: %w", err)
-		}
-		if conversionResult.Activity != nil {
-			// Convert Activity struct to map for JSON response
-			activityJSON, _ := json.Marshal(conversionResult.Activity)
-			var activityMap map[string]interface{}
-			json.Unmarshal(activityJSON, &activityMap)
-
-			// Add admin metadata
-			activityMap["admin_metadata"] = map[string]interface{}{
-				"extracted_at":     event.ExtractedAt,
-				"extracted_by":     event.ExtractedByUser,
-				"event_id":         event.EventID,
-				"source_url":       event.SourceURL,
-				"schema_type":      event.SchemaType,
-			}
+// convertAdminEventToActivity converts an AdminEvent to the canonical public activity payload
+func convertAdminEventToActivity(event *models.AdminEvent) (*models.PublicActivity, error) {
+	conversionResult, err := conversionService.ConvertToActivity(event)
+	if err != nil {
+		return nil, fmt.Errorf("conversion service failed: %w", err)
+	}
+	if conversionResult.Activity == nil {
+		return nil, fmt.Errorf("event %s did not convert to an activity", event.EventID)
+	}
+	if stripDeadRegistrationLinks {
+		stripBrokenLinks(conversionResult.Activity, event.BrokenLinks)
+	}
+	// Activities held at a registry venue take the venue's arrival notes where they have none of their own
+	conversionResult.Activity.ArrivalInfo = services.MergeArrivalInfo(conversionResult.Activity.ArrivalInfo, venueRegistry.ArrivalInfo(conversionResult.Activity.Location))
+
+	return &models.PublicActivity{
+		Activity: *conversionResult.Activity,
+		AdminMetadata: &models.PublicActivityAdminMetadata{
+			ExtractedAt: event.ExtractedAt,
+			ExtractedBy: event.ExtractedByUser,
+			EventID:     event.EventID,
+			SourceURL:   event.SourceURL,
+			SchemaType:  event.SchemaType,
+			BrokenLinks: event.BrokenLinks,
+		},
+	}, nil
+}
 
-			return activityMap, nil
+// stripBrokenLinks clears activity URLs that the link checker has marked broken
+func stripBrokenLinks(activity *models.Activity, brokenLinks []string) {
+	for _, link := range brokenLinks {
+		if activity.Registration.URL == link {
+			activity.Registration.URL = ""
+		}
+		if activity.DetailURL == link {
+			activity.DetailURL = ""
 		}
 	}
+}
 
-	// Fallback: create basic activity from raw data
-	activity := map[string]interface{}{
-		"id":          event.EventID,
-		"source":      map[string]interface{}{
-			"url":       event.SourceURL,
-			"scraped_at": event.ExtractedAt,
-		},
-		"updated_at":  event.UpdatedAt,
-		"created_at":  event.ExtractedAt,
+// filterActivitiesByCategory filters activities by category type
+func filterActivitiesByCategory(activities []*models.PublicActivity, category string) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if activity.Category == category {
+			filtered = append(filtered, activity)
+		}
 	}
+	return filtered
+}
 
-	// Try to extract basic fields from raw data
-	if rawData := event.RawExtractedData; rawData != nil {
-		if events, ok := rawData["events"].([]interface{}); ok && len(events) > 0 {
-			if firstEvent, ok := events[0].(map[string]interface{}); ok {
-				activity["title"] = firstEvent["title"]
-				activity["description"] = firstEvent["description"]
-				activity["location"] = firstEvent["location"]
-				activity["schedule"] = firstEvent["date"]
-				activity["pricing"] = firstEvent["price"]
-			}
+// filterActivitiesByNeighborhood filters activities by neighborhood, given by name or slug
+func filterActivitiesByNeighborhood(activities []*models.PublicActivity, neighborhood string) []*models.PublicActivity {
+	slug := models.NeighborhoodSlug(neighborhood)
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if activity.Location.Neighborhood != "" && models.NeighborhoodSlug(activity.Location.Neighborhood) == slug {
+			filtered = append(filtered, activity)
 		}
 	}
-
-	return activity, nil
+	return filtered
 }
 
-// filterActivitiesByCategory filters activities by category type
-func filterActivitiesByCategory(activities []map[string]interface{}, category string) []map[string]interface{} {
-	var filtered []map[string]interface{}
+// filterActivitiesByTransitFriendly keeps activities held at registry venues scored as transit
+// friendly. Activities at venues that have not been scored are left out.
+func filterActivitiesByTransitFriendly(activities []*models.PublicActivity) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
 	for _, activity := range activities {
-		// Check if activity matches category
-		if activityCategory, ok := activity["category"].(string); ok && activityCategory == category {
+		if venueRegistry.TransitFriendly(activity.Location) {
 			filtered = append(filtered, activity)
 		}
 	}
 	return filtered
 }
 
-// filterActivitiesByDate filters activities from a specific date
-func filterActivitiesByDate(activities []map[string]interface{}, dateFrom string) []map[string]interface{} {
-	var filtered []map[string]interface{}
+// filterActivitiesByDate filters activities still running on or after a specific date.
+// Multi-day activities that started earlier are kept while their end date has not passed.
+func filterActivitiesByDate(activities []*models.PublicActivity, dateFrom string) []*models.PublicActivity {
 	fromDate, err := time.Parse("2006-01-02", dateFrom)
 	if err != nil {
 		return activities // Return unfiltered if date parsing fails
 	}
 
+	filtered := []*models.PublicActivity{}
 	for _, activity := range activities {
-		// Check activity date
-		if schedule, ok := activity["schedule"].(map[string]interface{}); ok {
-			if startDate, ok := schedule["start_date"].(string); ok {
-				if activityDate, err := time.Parse("2006-01-02", startDate); err == nil {
-					if activityDate.After(fromDate) || activityDate.Equal(fromDate) {
-						filtered = append(filtered, activity)
-					}
-				}
+		lastDate := activity.Schedule.EndDate
+		if lastDate == "" {
+			lastDate = activity.Schedule.StartDate
+		}
+		if activityDate, err := time.Parse("2006-01-02", lastDate); err == nil {
+			if activityDate.After(fromDate) || activityDate.Equal(fromDate) {
+				filtered = append(filtered, activity)
 			}
 		}
 	}
 	return filtered
 }
 
+// filterActivitiesByDateTo filters activities starting on or before a specific date
+func filterActivitiesByDateTo(activities []*models.PublicActivity, dateTo string) []*models.PublicActivity {
+	toDate, err := time.Parse("2006-01-02", dateTo)
+	if err != nil {
+		return activities // Return unfiltered if date parsing fails
+	}
+
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if activityDate, err := time.Parse("2006-01-02", activity.Schedule.StartDate); err == nil && !activityDate.After(toDate) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// maxOccurrenceRangeDays bounds how many days an expand=occurrences query may cover
+const maxOccurrenceRangeDays = 31
+
+// parseOccurrenceRange parses the date range of an expand=occurrences query. from defaults to today
+// and to defaults to from, so a single date_from asks "what's on that day".
+func parseOccurrenceRange(dateFrom, dateTo string) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var err error
+	if dateFrom != "" {
+		if from, err = time.Parse("2006-01-02", dateFrom); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Invalid date_from: must be YYYY-MM-DD")
+		}
+	}
+	to := from
+	if dateTo != "" {
+		if to, err = time.Parse("2006-01-02", dateTo); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Invalid date_to: must be YYYY-MM-DD")
+		}
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid date range: date_to is before date_from")
+	}
+	if to.Sub(from).Hours()/24 >= maxOccurrenceRangeDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid date range: at most %d days can be expanded", maxOccurrenceRangeDays)
+	}
+	return from, to, nil
+}
+
 // filterActivitiesByUpdatedSince filters activities updated since a timestamp
-func filterActivitiesByUpdatedSince(activities []map[string]interface{}, updatedSince string) []map[string]interface{} {
-	var filtered []map[string]interface{}
+func filterActivitiesByUpdatedSince(activities []*models.PublicActivity, updatedSince string) []*models.PublicActivity {
 	sinceTime, err := time.Parse(time.RFC3339, updatedSince)
 	if err != nil {
 		return activities // Return unfiltered if timestamp parsing fails
 	}
 
+	filtered := []*models.PublicActivity{}
 	for _, activity := range activities {
-		if updatedAt, ok := activity["updated_at"].(time.Time); ok {
-			if updatedAt.After(sinceTime) {
-				filtered = append(filtered, activity)
-			}
+		if activity.UpdatedAt.After(sinceTime) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// filterActivitiesByTags keeps activities with every one of the tags
+func filterActivitiesByTags(activities []*models.PublicActivity, tags []string) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if models.HasAllTags(activity.Tags, tags) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// filterActivitiesByParticipation filters activities by how families take part (drop-in, registration, ...)
+func filterActivitiesByParticipation(activities []*models.PublicActivity, participationType string) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if activity.ParticipationType == participationType {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// maxChildAgeMonths is the oldest age accepted by the child age filter (18 years)
+const maxChildAgeMonths = 216
+
+// filterActivitiesByChildAge keeps activities with an age group that includes a child of the given age in months.
+// Open-ended "and up" age groups match any child past their minimum age.
+func filterActivitiesByChildAge(activities []*models.PublicActivity, childAgeMonths int) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if activity.SuitableForAgeMonths(childAgeMonths) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// filterActivitiesByCompleteness drops activities whose completeness score is below the floor.
+// Activities that were never scored are dropped by any positive floor.
+func filterActivitiesByCompleteness(activities []*models.PublicActivity, floor float64) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		score := 0.0
+		if activity.Completeness != nil {
+			score = activity.Completeness.Score
+		}
+		if score >= floor {
+			filtered = append(filtered, activity)
 		}
 	}
 	return filtered
@@ -2466,7 +9312,7 @@ func createOrUpdateSourceRecord(ctx context.Context, req models.CrawlSubmissionR
 		// If source was inactive, activate it since extraction was successful
 		if existingSource.Status != "active" {
 			existingSource.Status = "active"
-			existingSource.StatusKey = "STATUS#active"
+			existingSource.StatusKey = models.GenerateSourceStatusKey("active", existingSource.SourceID)
 			log.Printf("Activated source %s due to successful extraction", existingSource.SourceID)
 		}
 
@@ -2491,7 +9337,7 @@ func createOrUpdateSourceRecord(ctx context.Context, req models.CrawlSubmissionR
 		SubmittedAt:  time.Now(),
 		UpdatedAt:    time.Now(),
 		Status:       "active", // Auto-approve since extraction was successful
-		StatusKey:    "STATUS#active",
+		StatusKey:    models.GenerateSourceStatusKey("active", sourceID),
 		PriorityKey:  fmt.Sprintf("PRIORITY#medium#%s", sourceID),
 	}
 
@@ -2501,18 +9347,13 @@ func createOrUpdateSourceRecord(ctx context.Context, req models.CrawlSubmissionR
 
 // generateSourceIDFromURL creates a source ID from a URL
 func generateSourceIDFromURL(urlStr string) string {
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
+	// Use domain name as base for ID
+	domain := urlutil.Domain(urlStr)
+	if domain == "" {
 		// Fallback to simple slug generation
 		return strings.ReplaceAll(strings.ToLower(urlStr), "/", "-")
 	}
 
-	// Use domain name as base for ID
-	domain := parsedURL.Host
-	if strings.HasPrefix(domain, "www.") {
-		domain = domain[4:]
-	}
-
 	// Remove common TLD for cleaner ID
 	if strings.HasSuffix(domain, ".com") {
 		domain = domain[:len(domain)-4]
@@ -2529,16 +9370,11 @@ func generateSourceIDFromURL(urlStr string) string {
 
 // extractSourceNameFromURL creates a human-readable source name from URL
 func extractSourceNameFromURL(urlStr string) string {
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
+	domain := urlutil.Domain(urlStr)
+	if domain == "" {
 		return urlStr
 	}
 
-	domain := parsedURL.Host
-	if strings.HasPrefix(domain, "www.") {
-		domain = domain[4:]
-	}
-
 	// Convert domain to title case
 	parts := strings.Split(domain, ".")
 	if len(parts) > 0 {
@@ -2565,6 +9401,12 @@ func extractSourceNameFromURL(urlStr string) string {
 func handleGetMetricsDashboard(ctx context.Context) (ResponseBody, int) {
 	metrics := services.GetExtractionMetrics()
 	dashboardData := metrics.GetDashboardMetrics()
+	dashboardData["firecrawl"] = firecrawlStats.Snapshot()
+	conversionCounts, err := dynamoService.GetPublicConversionCounts(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get public conversion counts: %v", err)
+	}
+	dashboardData["public_conversion"] = services.BuildPublicConversionStats(conversionCounts)
 
 	return ResponseBody{
 		Success: true,
@@ -2589,9 +9431,11 @@ func handleGetMetricsAlerts(ctx context.Context) (ResponseBody, int) {
 }
 
 // handleResetMetrics handles POST /api/metrics/reset
+// The public conversion counts are stored per event in DynamoDB and are not reset.
 func handleResetMetrics(ctx context.Context) (ResponseBody, int) {
 	metrics := services.GetExtractionMetrics()
 	metrics.ResetMetrics()
+	firecrawlStats.Reset()
 
 	return ResponseBody{
 		Success: true,
@@ -2599,6 +9443,54 @@ func handleResetMetrics(ctx context.Context) (ResponseBody, int) {
 	}, 200
 }
 
+// flushFireCrawlStats publishes FireCrawl usage and throttle events to CloudWatch when FIRECRAWL_METRICS_NAMESPACE is set
+func flushFireCrawlStats() {
+	if metricsNamespace == "" {
+		return
+	}
+	firecrawlStats.FlushToCloudWatch(metricsNamespace)
+	concurrencyLimiter.Stats().FlushToCloudWatch(metricsNamespace)
+}
+
+// flushDynamoDBTelemetry publishes DynamoDB capacity, latency and throttling metrics to CloudWatch when DYNAMODB_METRICS_NAMESPACE is set
+func flushDynamoDBTelemetry() {
+	if dynamoDBMetricsNamespace == "" {
+		return
+	}
+	services.GetDynamoDBTelemetry().FlushToCloudWatch(dynamoDBMetricsNamespace)
+}
+
+// handleInvocation serves API Gateway requests, the crawl submissions this function defers to
+// itself, and the scheduled visibility refresh. API Gateway payloads never have a
+// deferred_crawl_submission or refresh_visibility field, so this work can only be started by
+// callers allowed to invoke the function directly.
+func handleInvocation(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+	var deferred deferredCrawlEvent
+	if err := json.Unmarshal(payload, &deferred); err == nil && deferred.DeferredCrawlSubmission != nil {
+		if deferred.Sandbox {
+			if sandboxTenant == nil {
+				return nil, fmt.Errorf("sandbox crawl submission for job %s, but the sandbox is not configured", deferred.DeferredCrawlSubmission.JobID)
+			}
+			sandboxTenant.activate()
+			defer productionTenant.activate()
+		}
+		handleDeferredCrawlSubmission(ctx, *deferred.DeferredCrawlSubmission)
+		return nil, nil
+	}
+
+	var refresh visibilityRefreshEvent
+	if err := json.Unmarshal(payload, &refresh); err == nil && refresh.RefreshVisibility {
+		return nil, handleVisibilityRefresh(ctx)
+	}
+
+	var request events.APIGatewayProxyRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+	return withResponseHeaders(withAdminAuth(handleRequest))(ctx, request)
+}
+
 func main() {
-	lambda.Start(handleRequest)
+	setup()
+	lambda.Start(handleInvocation)
 }
\ No newline at end of file
@@ -0,0 +1,627 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"seattle-family-activities-scraper/internal/apiheaders"
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// fakeAWS stands in for DynamoDB and S3. Every DynamoDB request gets an empty result unless
+// respond answers it; S3 objects are read from objects, by bucket and key.
+type fakeAWS struct {
+	server *httptest.Server
+	cfg    aws.Config // addresses the fake
+
+	mu       sync.Mutex
+	requests []fakeRequest
+	respond  func(request fakeRequest) (string, bool)
+	objects  map[string][]byte
+}
+
+// fakeRequest is a DynamoDB request the fake received
+type fakeRequest struct {
+	Operation string // Query, GetItem, ...
+	Body      map[string]interface{}
+}
+
+// Table returns the table the request was made to
+func (r fakeRequest) Table() string {
+	table, _ := r.Body["TableName"].(string)
+	return table
+}
+
+// newTestServices points the admin API's services at a fake AWS, as setup does for the real one
+func newTestServices(t *testing.T) *fakeAWS {
+	t.Helper()
+	fake := &fakeAWS{objects: make(map[string][]byte)}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.server.Close)
+
+	fake.cfg = aws.Config{
+		Region:       "us-west-2",
+		BaseEndpoint: aws.String(fake.server.URL),
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+	}
+	dynamo := services.NewDynamoDBService(dynamodb.NewFromConfig(fake.cfg), "activities", "sources", "operations", "admin-events")
+
+	productionTenant = newDataTenant(fake.cfg, dynamo)
+	productionTenant.activate()
+	firecrawlStats = services.NewFireCrawlStatsCollector()
+	apiHeaders = apiheaders.New(nil)
+	publicQueryCache = services.NewPublicQueryCache(services.NewLRUCache(16), dynamo, publicQueryCacheTTL, publicCacheGenerationRefresh)
+	catalogSnapshots, previewTokens = nil, nil
+	return fake
+}
+
+func (f *fakeAWS) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := fakeRequest{Body: map[string]interface{}{}}
+	target := r.Header.Get("X-Amz-Target")
+	if target == "" {
+		f.mu.Lock()
+		object, ok := f.objects[strings.TrimPrefix(r.URL.Path, "/")]
+		f.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(object)
+		return
+	}
+	request.Operation = target[strings.LastIndex(target, ".")+1:]
+	json.Unmarshal(body, &request.Body)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, request)
+	respond := f.respond
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	if respond != nil {
+		if response, ok := respond(request); ok {
+			io.WriteString(w, response)
+			return
+		}
+	}
+	io.WriteString(w, "{}")
+}
+
+// count returns how many requests of an operation were made to a table
+func (f *fakeAWS) count(operation, table string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, request := range f.requests {
+		if request.Operation == operation && request.Table() == table {
+			count++
+		}
+	}
+	return count
+}
+
+// keyValues returns the string values of a request's expression attribute values
+func (r fakeRequest) keyValues() []string {
+	values, _ := r.Body["ExpressionAttributeValues"].(map[string]interface{})
+	var keys []string
+	for _, value := range values {
+		if attribute, ok := value.(map[string]interface{}); ok {
+			if s, ok := attribute["S"].(string); ok {
+				keys = append(keys, s)
+			}
+		}
+	}
+	return keys
+}
+
+// approvedDemoEvents returns the sandbox's demo events that convert to activities, approved
+func approvedDemoEvents() []*models.AdminEvent {
+	events := services.NewSandboxSeed(time.Now()).AdminEvents[:3]
+	for i, event := range events {
+		event.Status = models.AdminEventStatusApproved
+		event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
+		event.PK = models.CreateAdminEventPK(event.EventID)
+		event.SK = fmt.Sprintf("SUBMISSION#2026-10-1%d", 6-i) // newest first
+	}
+	return events
+}
+
+// pendingDemoEvent returns the sandbox's toddler story time, awaiting review
+func pendingDemoEvent() *models.AdminEvent {
+	event := services.NewSandboxSeed(time.Now()).AdminEvents[0]
+	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
+	event.PK = models.CreateAdminEventPK(event.EventID)
+	event.SK = "SUBMISSION#2026-10-16"
+	return event
+}
+
+// respondWithEvents answers queries of the events by ID, and of their shards of the status index
+func respondWithEvents(t *testing.T, events []*models.AdminEvent) func(request fakeRequest) (string, bool) {
+	t.Helper()
+	byStatus := make(map[string][]interface{})
+	byID := make(map[string][]interface{})
+	for _, event := range events {
+		item, err := attributevalue.MarshalMap(event)
+		if err != nil {
+			t.Fatalf("Expected event %s to marshal, got %v", event.EventID, err)
+		}
+		encoded := attributeValueJSON(&types.AttributeValueMemberM{Value: item})["M"]
+		byStatus[event.StatusKey] = append(byStatus[event.StatusKey], encoded)
+		byID[event.PK] = append(byID[event.PK], encoded)
+	}
+
+	return func(request fakeRequest) (string, bool) {
+		if request.Operation != "Query" {
+			return "", false
+		}
+		items := byID
+		if request.Body["IndexName"] == "status-date-index" {
+			items = byStatus
+		} else if request.Body["IndexName"] != nil {
+			return "", false
+		}
+		for _, key := range request.keyValues() {
+			if shardItems, ok := items[key]; ok {
+				response, _ := json.Marshal(map[string]interface{}{"Items": shardItems, "Count": len(shardItems)})
+				return string(response), true
+			}
+		}
+		return "", false
+	}
+}
+
+// attributeValueJSON encodes an attribute value as DynamoDB sends it over the wire
+func attributeValueJSON(value types.AttributeValue) map[string]interface{} {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]interface{}{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]interface{}{"N": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]interface{}{"BOOL": v.Value}
+	case *types.AttributeValueMemberSS:
+		return map[string]interface{}{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]interface{}{"NS": v.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, 0, len(v.Value))
+		for _, element := range v.Value {
+			list = append(list, attributeValueJSON(element))
+		}
+		return map[string]interface{}{"L": list}
+	case *types.AttributeValueMemberM:
+		fields := make(map[string]interface{}, len(v.Value))
+		for name, field := range v.Value {
+			fields[name] = attributeValueJSON(field)
+		}
+		return map[string]interface{}{"M": fields}
+	}
+	return map[string]interface{}{"NULL": true}
+}
+
+// get sends a GET request through the router, as a public route is called
+func get(t *testing.T, path string, queryParams map[string]string) AdminAPIResponse {
+	t.Helper()
+	return send(t, context.Background(), "GET", path, queryParams)
+}
+
+// send sends a request through the router. Admin routes take the request's API key from ctx.
+func send(t *testing.T, ctx context.Context, method, path string, queryParams map[string]string) AdminAPIResponse {
+	t.Helper()
+	response, err := handleRequest(ctx, events.APIGatewayProxyRequest{
+		HTTPMethod:            method,
+		Path:                  path,
+		QueryStringParameters: queryParams,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error from %s %s, got %v", method, path, err)
+	}
+	return response
+}
+
+// decodeBody decodes a JSON response body
+func decodeBody(t *testing.T, response AdminAPIResponse) ResponseBody {
+	t.Helper()
+	var body ResponseBody
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+		t.Fatalf("Expected a JSON body, got %q: %v", response.Body, err)
+	}
+	return body
+}
+
+func TestRoutesApprovedEvents(t *testing.T) {
+	newTestServices(t)
+
+	t.Run("ApprovedEvents", func(t *testing.T) {
+		response := get(t, "/api/events/approved", nil)
+		body := decodeBody(t, response)
+		if response.StatusCode != 200 || !strings.HasPrefix(body.Message, "Retrieved 0 approved events") {
+			t.Errorf("Expected handleGetApprovedEvents to answer, got %d %+v", response.StatusCode, body)
+		}
+	})
+
+	t.Run("CalendarFeed", func(t *testing.T) {
+		response := get(t, "/api/events/approved.ics", nil)
+		if response.StatusCode != 200 || response.Headers["Content-Type"] != "text/calendar; charset=utf-8" {
+			t.Errorf("Expected the iCalendar feed, got %d %v", response.StatusCode, response.Headers)
+		}
+		if !strings.HasPrefix(response.Body, "BEGIN:VCALENDAR") {
+			t.Errorf("Expected an iCalendar body, got %q", response.Body)
+		}
+	})
+
+	t.Run("LatestFeeds", func(t *testing.T) {
+		feeds := []struct {
+			path        string
+			contentType string
+			root        string
+		}{
+			{"/api/events/approved.rss", "application/rss+xml; charset=utf-8", "<rss"},
+			{"/api/events/approved.atom", "application/atom+xml; charset=utf-8", "<feed"},
+		}
+		for _, feed := range feeds {
+			response := get(t, feed.path, nil)
+			if response.StatusCode != 200 || response.Headers["Content-Type"] != feed.contentType {
+				t.Errorf("Expected %s to be served as %s, got %d %v", feed.path, feed.contentType, response.StatusCode, response.Headers)
+			}
+			if !strings.Contains(response.Body, feed.root) {
+				t.Errorf("Expected %s to contain %s, got %q", feed.path, feed.root, response.Body)
+			}
+		}
+	})
+
+	t.Run("EventByID", func(t *testing.T) {
+		response := get(t, "/api/events/evt-123", nil)
+		if body := decodeBody(t, response); response.StatusCode != 404 || body.Error != "Event not found" {
+			t.Errorf("Expected handleGetEvent to answer, got %d %+v", response.StatusCode, body)
+		}
+	})
+}
+
+func TestRoutesApprovedEventsThroughCache(t *testing.T) {
+	fake := newTestServices(t)
+
+	first := get(t, "/api/events/approved", map[string]string{"category": "arts-creativity"})
+	queries := fake.count("Query", "admin-events")
+	if first.StatusCode != 200 || queries == 0 {
+		t.Fatalf("Expected the first request to query the approved events, got %d with %d queries", first.StatusCode, queries)
+	}
+
+	second := get(t, "/api/events/approved", map[string]string{"category": "arts-creativity"})
+	if body := decodeBody(t, second); second.StatusCode != 200 || body.Message != decodeBody(t, first).Message {
+		t.Errorf("Expected the cached response, got %d %+v", second.StatusCode, body)
+	}
+	if fake.count("Query", "admin-events") != queries {
+		t.Errorf("Expected the second request to be served from the cache, got %d queries", fake.count("Query", "admin-events"))
+	}
+
+	if get(t, "/api/events/approved", map[string]string{"category": "outdoor-nature"}); fake.count("Query", "admin-events") == queries {
+		t.Error("Expected another query to miss the cache")
+	}
+}
+
+func TestRoutesApprovedEventsWithFacets(t *testing.T) {
+	fake := newTestServices(t)
+	fake.respond = respondWithEvents(t, approvedDemoEvents())
+
+	response := get(t, "/api/events/approved", nil)
+	var body struct {
+		Data struct {
+			Activities []models.PublicActivity `json:"activities"`
+			Facets     models.ActivityFacets   `json:"facets"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil || response.StatusCode != 200 {
+		t.Fatalf("Expected approved events, got %d %q", response.StatusCode, response.Body)
+	}
+	if len(body.Data.Activities) != 3 {
+		t.Fatalf("Expected the 3 approved events, got %d", len(body.Data.Activities))
+	}
+
+	categories := 0
+	for _, facet := range body.Data.Facets.Category {
+		categories += facet.Count
+	}
+	if categories != 3 {
+		t.Errorf("Expected every activity counted in a category, got %+v", body.Data.Facets.Category)
+	}
+	for _, facet := range body.Data.Facets.Cost {
+		if facet.Value == "free" && facet.Count != 2 {
+			t.Errorf("Expected the 2 free activities, got %+v", facet)
+		}
+	}
+}
+
+func TestRoutesApprovedEventsSnapshot(t *testing.T) {
+	fake := newTestServices(t)
+
+	if response := get(t, "/api/events/approved", map[string]string{"snapshot": "2026-10-15"}); response.StatusCode != 503 {
+		t.Errorf("Expected 503 without a snapshots bucket, got %d %q", response.StatusCode, response.Body)
+	}
+
+	taken := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	events := make([]models.AdminEvent, 0, 3)
+	for _, event := range approvedDemoEvents() {
+		events = append(events, *event)
+	}
+	manifest, body, err := services.BuildCatalogSnapshot(events, taken)
+	if err != nil {
+		t.Fatalf("Expected a snapshot, got %v", err)
+	}
+	manifestBody, _ := json.Marshal(manifest)
+	fake.objects["snapshots/"+manifest.EventsKey] = body
+	fake.objects["snapshots/"+models.CatalogSnapshotManifestKey(manifest.Version)] = manifestBody
+	catalogSnapshots = services.NewCatalogSnapshotReader(services.NewS3Store(fake.cfg, "snapshots"))
+
+	response := get(t, "/api/events/approved", map[string]string{"snapshot": manifest.Version})
+	var snapshot struct {
+		Data struct {
+			Activities []models.PublicActivity `json:"activities"`
+			Meta       map[string]interface{}  `json:"meta"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &snapshot); err != nil || response.StatusCode != 200 {
+		t.Fatalf("Expected the snapshot, got %d %q", response.StatusCode, response.Body)
+	}
+	if len(snapshot.Data.Activities) != 3 || snapshot.Data.Meta["snapshot"] != manifest.Version {
+		t.Errorf("Expected the 3 events of snapshot %s, got %d with meta %v", manifest.Version, len(snapshot.Data.Activities), snapshot.Data.Meta)
+	}
+	if fake.count("Query", "admin-events") != 0 {
+		t.Error("Expected a snapshot to be served without reading the live catalog")
+	}
+
+	if response := get(t, "/api/events/approved", map[string]string{"snapshot": "2026-10-14"}); response.StatusCode != 404 {
+		t.Errorf("Expected 404 for a day without a snapshot, got %d %q", response.StatusCode, response.Body)
+	}
+}
+
+func TestRoutesApprovedEventsPreview(t *testing.T) {
+	fake := newTestServices(t)
+	pending := pendingDemoEvent()
+	fake.respond = respondWithEvents(t, []*models.AdminEvent{pending})
+
+	if response := get(t, "/api/events/approved", map[string]string{"preview_token": "token"}); response.StatusCode != 503 {
+		t.Errorf("Expected 503 without a preview token secret, got %d %q", response.StatusCode, response.Body)
+	}
+
+	previewTokens = services.NewPreviewTokenSigner("secret")
+	token := previewTokens.Sign(models.PreviewToken{
+		EventIDs:  []string{pending.EventID},
+		Label:     "Story times",
+		CreatedBy: "editor@example.com",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+
+	response := get(t, "/api/events/approved", map[string]string{"preview_token": token})
+	var preview struct {
+		Data struct {
+			Activities []models.PublicActivity `json:"activities"`
+			Meta       map[string]interface{}  `json:"meta"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &preview); err != nil || response.StatusCode != 200 {
+		t.Fatalf("Expected the preview, got %d %q", response.StatusCode, response.Body)
+	}
+	if len(preview.Data.Activities) != 1 || !preview.Data.Activities[0].Preview || preview.Data.Meta["preview"] == nil {
+		t.Errorf("Expected the pending event as a preview, got %+v with meta %v", preview.Data.Activities, preview.Data.Meta)
+	}
+	if response.Headers["Cache-Control"] != "private, no-store" {
+		t.Errorf("Expected a preview not to be cached, got %v", response.Headers)
+	}
+
+	// A preview is never served from, or stored in, the public query cache
+	if response := get(t, "/api/events/approved", nil); strings.Contains(response.Body, pending.EventID) {
+		t.Errorf("Expected the public catalog without the preview, got %q", response.Body)
+	}
+
+	if response := get(t, "/api/events/approved", map[string]string{"preview_token": token + "x"}); response.StatusCode != 403 {
+		t.Errorf("Expected 403 for a tampered token, got %d %q", response.StatusCode, response.Body)
+	}
+}
+
+func TestRoutesApprovedEventsNearPoint(t *testing.T) {
+	fake := newTestServices(t)
+
+	events := approvedDemoEvents()
+	located := map[string]models.Coordinates{
+		"demo-event-toddler-story-time": {Lat: 47.6687, Lng: -122.3843}, // Ballard
+		"demo-event-tide-pool-walk":     {Lat: 47.5613, Lng: -122.4071}, // West Seattle
+	}
+	var entries []*models.GeoEntry
+	for _, event := range events {
+		coordinates, ok := located[event.EventID]
+		if !ok {
+			continue
+		}
+		data := event.RawExtractedData["events"].([]interface{})[0].(map[string]interface{})
+		data["latitude"], data["longitude"] = coordinates.Lat, coordinates.Lng
+		activity := &models.Activity{ID: "act-" + event.EventID, Title: data["title"].(string)}
+		activity.Location.Coordinates = coordinates
+		entries = append(entries, services.GeoEntryForActivity(activity, event.EventID))
+	}
+
+	respondWithEvent := respondWithEvents(t, events)
+	fake.respond = func(request fakeRequest) (string, bool) {
+		if request.Operation != "Query" || request.Body["IndexName"] != "geohash-index" {
+			return respondWithEvent(request)
+		}
+		// Cells longer than the partition's are read with a prefix of the sort key
+		cellKey, prefix := "", ""
+		for _, value := range request.keyValues() {
+			if strings.HasPrefix(value, "GEO#") {
+				cellKey = value
+			} else {
+				prefix = value
+			}
+		}
+		var cellEntries []interface{}
+		for _, entry := range entries {
+			if entry.GeoCellKey == cellKey && strings.HasPrefix(entry.GeoSortKey, prefix) {
+				item, _ := attributevalue.MarshalMap(entry)
+				cellEntries = append(cellEntries, attributeValueJSON(&types.AttributeValueMemberM{Value: item})["M"])
+			}
+		}
+		response, _ := json.Marshal(map[string]interface{}{"Items": cellEntries, "Count": len(cellEntries)})
+		return string(response), true
+	}
+
+	response := get(t, "/api/events/approved", map[string]string{"lat": "47.6690", "lng": "-122.3850", "radius": "2"})
+	var near struct {
+		Data struct {
+			Activities []models.PublicActivity `json:"activities"`
+			Meta       map[string]interface{}  `json:"meta"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &near); err != nil || response.StatusCode != 200 {
+		t.Fatalf("Expected the activities near the point, got %d %q", response.StatusCode, response.Body)
+	}
+	if len(near.Data.Activities) != 1 || near.Data.Activities[0].DistanceKm == nil || near.Data.Meta["filtered_near"] == nil {
+		t.Fatalf("Expected only the Ballard activity with its distance, got %+v with meta %v", near.Data.Activities, near.Data.Meta)
+	}
+	if title := near.Data.Activities[0].Title; !strings.Contains(title, "Story Time") {
+		t.Errorf("Expected the story time, got %q", title)
+	}
+	if fake.count("Query", "activities") == 0 {
+		t.Error("Expected the geohash index to be queried")
+	}
+
+	if response := get(t, "/api/events/approved", map[string]string{"lat": "47.6690"}); response.StatusCode != 400 {
+		t.Errorf("Expected 400 for lat without lng, got %d %q", response.StatusCode, response.Body)
+	}
+}
+
+func TestRoutesNotificationsOfRequestKey(t *testing.T) {
+	fake := newTestServices(t)
+	viewer := &models.AdminAPIKey{KeyID: "key-viewer", Name: "viewer@example.com", Role: models.AdminRoleViewer}
+	ctx := context.WithValue(context.Background(), adminAPIKeyContextKey{}, viewer)
+
+	if response := get(t, "/api/notifications", nil); response.StatusCode != 403 {
+		t.Errorf("Expected 403 without an API key, got %d %q", response.StatusCode, response.Body)
+	}
+
+	// The admin parameter the inbox used to be chosen by is ignored
+	response := send(t, ctx, "GET", "/api/notifications", map[string]string{"admin": "key-admin"})
+	if response.StatusCode != 200 {
+		t.Fatalf("Expected the viewer's inbox, got %d %q", response.StatusCode, response.Body)
+	}
+	var inboxes []string
+	fake.mu.Lock()
+	for _, request := range fake.requests {
+		for _, value := range request.keyValues() {
+			if strings.HasPrefix(value, "ADMIN#") {
+				inboxes = append(inboxes, value)
+			}
+		}
+	}
+	fake.mu.Unlock()
+	if len(inboxes) != 1 || inboxes[0] != models.CreateNotificationReceiptPK(viewer.KeyID) {
+		t.Errorf("Expected only the viewer's read state to be read, got %v", inboxes)
+	}
+
+	if response := send(t, ctx, "PUT", "/api/notifications/20261016T180000.000Z-0a1b2c3d/read", nil); response.StatusCode != 404 {
+		t.Errorf("Expected 404 for an unknown notification, got %d %q", response.StatusCode, response.Body)
+	}
+}
+
+func TestRoutesPendingEventsPagesThePresetOverTheWholeQueue(t *testing.T) {
+	fake := newTestServices(t)
+	viewer := &models.AdminAPIKey{KeyID: "key-viewer", Name: "viewer@example.com", Role: models.AdminRoleViewer}
+	ctx := context.WithValue(context.Background(), adminAPIKeyContextKey{}, viewer)
+
+	// The low-confidence events are the oldest in the queue, beyond a first page of the newest
+	var queue []*models.AdminEvent
+	for i, confidence := range []float64{90, 85, 80, 30, 20} {
+		eventID := fmt.Sprintf("evt-%d", i)
+		queue = append(queue, &models.AdminEvent{
+			PK:              models.CreateAdminEventPK(eventID),
+			SK:              fmt.Sprintf("SUBMISSION#2026-10-1%d", 6-i),
+			EventID:         eventID,
+			Status:          models.AdminEventStatusPending,
+			StatusKey:       models.GenerateAdminEventStatusKey(models.AdminEventStatusPending, eventID),
+			ConfidenceScore: confidence,
+			ExtractedAt:     time.Date(2026, 10, 16-i, 12, 0, 0, 0, time.UTC),
+		})
+	}
+	maxConfidence := 50.0
+	preset, err := attributevalue.MarshalMap(models.ReviewPreset{
+		PresetID: "low-confidence-first",
+		Admin:    "viewer@example.com",
+		Target:   models.PresetTargetEvents,
+		Filters:  models.ReviewFilters{MaxConfidence: &maxConfidence, Sort: models.ReviewSortConfidence, Order: models.ReviewOrderAsc},
+	})
+	if err != nil {
+		t.Fatalf("Expected the preset to marshal, got %v", err)
+	}
+	presetItem, _ := json.Marshal(map[string]interface{}{"Item": attributeValueJSON(&types.AttributeValueMemberM{Value: preset})["M"]})
+	events := respondWithEvents(t, queue)
+	fake.respond = func(request fakeRequest) (string, bool) {
+		if request.Operation == "GetItem" && request.Table() == "sources" {
+			return string(presetItem), true
+		}
+		return events(request)
+	}
+
+	params := map[string]string{"preset": "low-confidence-first", "admin": "viewer@example.com", "limit": "1"}
+	var got []string
+	for page := 0; page < 5; page++ {
+		body := decodeBody(t, send(t, ctx, "GET", "/api/events/pending", params))
+		if !body.Success {
+			t.Fatalf("Expected the pending events, got %+v", body)
+		}
+		for _, event := range body.Data.([]interface{}) {
+			got = append(got, event.(map[string]interface{})["event_id"].(string))
+		}
+		if body.Meta == nil {
+			break
+		}
+		params["next_token"] = body.Meta["next_token"].(string)
+	}
+
+	if strings.Join(got, ",") != "evt-4,evt-3" {
+		t.Errorf("Expected the preset's matches lowest confidence first across pages, got %v", got)
+	}
+}
+
+func TestRoutesProgressTicket(t *testing.T) {
+	fake := newTestServices(t)
+	viewer := &models.AdminAPIKey{KeyID: "key-viewer", Name: "viewer@example.com", Role: models.AdminRoleViewer}
+	ctx := context.WithValue(context.Background(), adminAPIKeyContextKey{}, viewer)
+
+	response := send(t, ctx, "POST", "/api/progress/tickets", nil)
+	if response.StatusCode != 201 {
+		t.Fatalf("Expected a ticket, got %d %q", response.StatusCode, response.Body)
+	}
+	data := decodeBody(t, response).Data.(map[string]interface{})
+	ticket, _ := data["ticket"].(string)
+	if ticket == "" || data["expires_at"] == nil {
+		t.Fatalf("Expected a ticket with its expiry, got %v", data)
+	}
+
+	if fake.count("PutItem", "operations") != 1 {
+		t.Fatalf("Expected the ticket to be stored in the scraping operations table")
+	}
+	fake.mu.Lock()
+	stored, _ := json.Marshal(fake.requests[len(fake.requests)-1].Body)
+	fake.mu.Unlock()
+	if strings.Contains(string(stored), ticket) || !strings.Contains(string(stored), models.CreateProgressTicketPK(ticket)) {
+		t.Errorf("Expected only the ticket's hash to be stored, got %s", stored)
+	}
+}
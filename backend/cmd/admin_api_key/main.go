@@ -0,0 +1,79 @@
+// Command admin_api_key issues an admin API key straight into DynamoDB. Keys are normally issued
+// with POST /api/admin/api-keys, which itself needs a key, so this is how the first one is made.
+//
+// Usage:
+//
+//	SOURCE_MANAGEMENT_TABLE=seattle-source-management \
+//	go run ./cmd/admin_api_key -name "Review laptop" -created-by alice
+//
+// Keys are admin keys unless -role says otherwise, so the first key can issue the others.
+// The key is printed once; only the hash of its secret is stored.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+func main() {
+	name := flag.String("name", "", "who or what will hold the key")
+	role := flag.String("role", models.AdminRoleAdmin, "viewer, editor or admin")
+	createdBy := flag.String("created-by", "", "the admin issuing the key")
+	sandbox := flag.Bool("sandbox", false, "issue a training key that only reaches the sandbox tables")
+	flag.Parse()
+
+	req := models.AdminAPIKeyRequest{Name: *name, Role: *role, Sandbox: *sandbox, CreatedBy: *createdBy}
+	if err := req.Validate(); err != nil {
+		log.Fatalf("❌ Invalid key request: %v", err)
+	}
+
+	sourceManagementTable := os.Getenv("SOURCE_MANAGEMENT_TABLE")
+	if sourceManagementTable == "" {
+		log.Fatal("❌ Required environment variable not set: SOURCE_MANAGEMENT_TABLE")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		sourceManagementTable,
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	keyID, key, keyHash, err := models.GenerateAdminAPIKey()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	apiKey := &models.AdminAPIKey{
+		KeyID:     keyID,
+		Name:      strings.TrimSpace(req.Name),
+		Status:    models.AdminAPIKeyStatusActive,
+		Role:      req.Role,
+		Sandbox:   req.Sandbox,
+		KeyHash:   keyHash,
+		KeyPrefix: models.AdminAPIKeyDisplayPrefix(key),
+		CreatedBy: req.CreatedBy,
+	}
+	if err := dynamoService.CreateAdminAPIKey(ctx, apiKey); err != nil {
+		log.Fatalf("❌ Failed to store the admin API key: %v", err)
+	}
+
+	log.Printf("✅ Admin API key %s (%s) issued to %s. It is only shown once:", keyID, apiKey.Role, apiKey.Name)
+	fmt.Println(key)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+var (
+	dynamoService     *services.DynamoDBService
+	conversionService *services.SchemaConversionService
+	invalidator       *services.CloudFrontInvalidator // nil when the public API is not behind CloudFront
+	feedPublisher     *services.FeedPublisher         // nil when the feeds are not published to S3
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	familyActivitiesTable := os.Getenv("FAMILY_ACTIVITIES_TABLE")
+	if familyActivitiesTable == "" {
+		log.Fatal("Required environment variable not set: FAMILY_ACTIVITIES_TABLE")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		familyActivitiesTable,
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+	conversionService = services.NewSchemaConversionService()
+
+	// Changed catalog responses are invalidated at the CDN when it is configured
+	if distributionID := os.Getenv("CDN_DISTRIBUTION_ID"); distributionID != "" {
+		invalidator = services.NewCloudFrontInvalidator(cfg, distributionID)
+	}
+
+	// The RSS and Atom feeds of newly approved activities are refreshed when the catalog changes
+	if bucket := os.Getenv("PUBLISH_BUCKET"); bucket != "" && os.Getenv("ADMIN_EVENTS_TABLE") != "" {
+		channel := services.DefaultFeedChannel
+		channel.Link = os.Getenv("PUBLIC_SITE_URL")
+		feedPublisher = services.NewFeedPublisher(dynamoService, conversionService, services.NewS3Store(cfg, bucket), channel)
+	}
+}
+
+// handleRequest materializes admin event stream records into the catalog change log.
+// Each recorded change moves the log head, which invalidates the cached public catalog queries,
+// the published feeds are refreshed, and the changed responses are then invalidated at the CDN. Records are processed in order;
+// when one fails, it and the records after it are reported as failures so the stream retries
+// them in order.
+func handleRequest(ctx context.Context, event events.DynamoDBEvent) (events.DynamoDBEventResponse, error) {
+	recorded := 0
+	var changes []models.CatalogChange
+	for i, record := range event.Records {
+		detected, count, err := processRecord(ctx, record)
+		if err != nil {
+			log.Printf("Error recording catalog changes for stream record %s: %v", record.EventID, err)
+			// The records before this one are not retried, so their changes are published now
+			feedsPublished := publishFeeds(ctx, changes)
+			if err := invalidateCatalogChanges(ctx, event.Records[:i], changes, feedsPublished); err != nil {
+				log.Printf("Error invalidating catalog changes at the CDN: %v", err)
+			}
+			return batchFailure(event.Records[i]), nil
+		}
+		changes = append(changes, detected...)
+		recorded += count
+	}
+
+	// Changes are detected again when the batch is retried, even those already recorded, so a
+	// failed invalidation is retried with the batch
+	feedsPublished := publishFeeds(ctx, changes)
+	if err := invalidateCatalogChanges(ctx, event.Records, changes, feedsPublished); err != nil {
+		log.Printf("Error invalidating catalog changes at the CDN: %v", err)
+		return batchFailure(event.Records[0]), nil
+	}
+
+	log.Printf("Recorded %d catalog changes from %d stream records", recorded, len(event.Records))
+	return events.DynamoDBEventResponse{}, nil
+}
+
+// batchFailure reports a record, and the records after it, as failed
+func batchFailure(record events.DynamoDBEventRecord) events.DynamoDBEventResponse {
+	return events.DynamoDBEventResponse{
+		BatchItemFailures: []events.DynamoDBBatchItemFailure{
+			{ItemIdentifier: record.Change.SequenceNumber},
+		},
+	}
+}
+
+// publishFeeds refreshes the published RSS and Atom feeds when some stream records changed the
+// catalog, and reports whether they were. A failure is logged rather than retried, as the next
+// change publishes the feeds again.
+func publishFeeds(ctx context.Context, changes []models.CatalogChange) bool {
+	if feedPublisher == nil || len(changes) == 0 {
+		return false
+	}
+	listed, err := feedPublisher.Publish(ctx, time.Now())
+	if err != nil {
+		log.Printf("Error publishing the activity feeds: %v", err)
+		return false
+	}
+	log.Printf("Published %d activities to %s and %s", listed, services.PublishedRSSFeedKey, services.PublishedAtomFeedKey)
+	return true
+}
+
+// invalidateCatalogChanges invalidates the public API at the CDN when some stream records changed
+// the catalog, and the published feeds when they were refreshed. The records' sequence numbers
+// make the caller reference, so retrying the same records does not start a second invalidation.
+func invalidateCatalogChanges(ctx context.Context, records []events.DynamoDBEventRecord, changes []models.CatalogChange, feedsPublished bool) error {
+	if invalidator == nil || len(changes) == 0 {
+		return nil
+	}
+	paths := []string{services.CatalogAPIInvalidationPath}
+	if feedsPublished {
+		paths = append(paths, services.PublishedFeedsInvalidationPath)
+	}
+
+	reference := fmt.Sprintf("catalog-changes-%s-%s", records[0].Change.SequenceNumber, records[len(records)-1].Change.SequenceNumber)
+	invalidationID, err := invalidator.Invalidate(ctx, paths, reference)
+	if err != nil {
+		return err
+	}
+	log.Printf("CDN invalidation %s started for %d catalog changes", invalidationID, len(changes))
+	return nil
+}
+
+// processRecord records the catalog changes of one admin event write. It returns the changes
+// the write made, whether or not an earlier attempt already recorded them, and how many it recorded.
+func processRecord(ctx context.Context, record events.DynamoDBEventRecord) ([]models.CatalogChange, int, error) {
+	oldEvent, err := services.AdminEventFromStreamImage(record.Change.OldImage)
+	if err != nil {
+		return nil, 0, fmt.Errorf("old image: %w", err)
+	}
+	newEvent, err := services.AdminEventFromStreamImage(record.Change.NewImage)
+	if err != nil {
+		return nil, 0, fmt.Errorf("new image: %w", err)
+	}
+
+	changedAt := record.Change.ApproximateCreationDateTime.Time
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+
+	recorded := 0
+	changes := services.DetectCatalogChanges(oldEvent, newEvent, conversionService, changedAt)
+	for _, change := range changes {
+		ok, err := dynamoService.RecordCatalogChange(ctx, &change, record.Change.SequenceNumber)
+		if err != nil {
+			return nil, recorded, err
+		}
+		if ok {
+			log.Printf("Catalog change %d: activity %s %s (version %d)", change.Sequence, change.ActivityID, change.Type, change.Version)
+			recorded++
+		}
+	}
+	return changes, recorded, nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
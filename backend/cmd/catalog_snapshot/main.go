@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+var snapshotter *services.CatalogSnapshotter
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	bucket := os.Getenv("CATALOG_SNAPSHOTS_BUCKET")
+	if adminEventsTable == "" || bucket == "" {
+		log.Fatal("Required environment variables not set: ADMIN_EVENTS_TABLE, CATALOG_SNAPSHOTS_BUCKET")
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		adminEventsTable,
+	)
+
+	snapshotter = services.NewCatalogSnapshotter(dynamoService, services.NewS3Store(cfg, bucket))
+}
+
+// handleRequest freezes the approved catalog into the nightly snapshot
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (*models.CatalogSnapshotManifest, error) {
+	manifest, created, err := snapshotter.Snapshot(ctx, time.Now())
+	if err != nil {
+		log.Printf("Error taking catalog snapshot: %v", err)
+		return nil, err
+	}
+
+	if !created {
+		log.Printf("Catalog snapshot %s already exists with %d events, leaving it unchanged", manifest.Version, manifest.EventCount)
+		return manifest, nil
+	}
+	log.Printf("Catalog snapshot %s taken: %d approved events under %s", manifest.Version, manifest.EventCount, manifest.EventsKey)
+	return manifest, nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
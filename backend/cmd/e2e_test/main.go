@@ -0,0 +1,414 @@
+// Command e2e_test is a one-command smoke test of the whole pipeline against a deployed test
+// environment. It hosts a synthetic events page, submits it as a source, waits for analysis,
+// activates the source and triggers a scrape, extracts and converts the page, approves the
+// pending event, and checks that the activity is served by the public events API.
+//
+// Usage:
+//
+//	ADMIN_API_URL=https://abc123.execute-api.us-west-2.amazonaws.com/prod \
+//	E2E_FIXTURE_BUCKET=my-test-bucket go run ./cmd/e2e_test
+//
+// FireCrawl has to reach the page, so it is uploaded to S3 behind a presigned link, or served
+// from -fixture-url when the page is already hosted somewhere public.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// pollInterval is how long to wait between checks of asynchronous pipeline stages
+const pollInterval = 10 * time.Second
+
+// fixtureTemplate is the synthetic events page. The marker makes the event title unique to the
+// run so it can be found in the public API.
+const fixtureTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>E2E Test Library - Upcoming Events</title>
+</head>
+<body>
+  <h1>Upcoming Family Events</h1>
+  <div class="event">
+    <h2 class="event-title">E2E Toddler Story Time %[1]s</h2>
+    <p class="event-date">%[2]s</p>
+    <p class="event-time">10:30 AM - 11:15 AM</p>
+    <p class="event-location">E2E Test Library, 1000 4th Ave, Seattle, WA 98104</p>
+    <p class="event-ages">Ages 2-5 with a caregiver</p>
+    <p class="event-price">Free</p>
+    <p class="event-description">Songs, rhymes and picture books for toddlers and their grown-ups.
+      This event is generated by the pipeline smoke test.</p>
+    <a class="event-register" href="https://example.com/register/%[1]s">Register</a>
+  </div>
+</body>
+</html>
+`
+
+// apiResponse mirrors the admin API response envelope
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+// apiClient calls the admin API
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// call sends a request and decodes the response data into out when it is not nil.
+// Unsuccessful responses are returned as errors.
+func (c *apiClient) call(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var response apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("%s %s returned status %d with an unreadable body: %w", method, path, resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 || !response.Success {
+		return fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, response.Error)
+	}
+
+	if out != nil && len(response.Data) > 0 {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+		}
+	}
+	return nil
+}
+
+// e2eRun holds the settings and the state passed between pipeline steps
+type e2eRun struct {
+	api             *apiClient
+	marker          string
+	eventDate       time.Time
+	fixtureURL      string
+	fixtureBucket   string
+	analysisTimeout time.Duration
+	publishTimeout  time.Duration
+	skipSourceFlow  bool
+	cleanup         bool
+
+	pageURL    string
+	sourceID   string
+	eventID    string
+	activityID string
+}
+
+// step is one stage of the pipeline
+type step struct {
+	name string
+	run  func(ctx context.Context, r *e2eRun) error
+}
+
+func main() {
+	apiURL := flag.String("api", os.Getenv("ADMIN_API_URL"), "admin API base URL, e.g. https://abc123.execute-api.us-west-2.amazonaws.com/prod")
+	fixtureURL := flag.String("fixture-url", "", "publicly reachable copy of the fixture page; skips the S3 upload")
+	fixtureBucket := flag.String("fixture-bucket", os.Getenv("E2E_FIXTURE_BUCKET"), "S3 bucket to host the fixture page in")
+	analysisTimeout := flag.Duration("analysis-timeout", 5*time.Minute, "how long to wait for source analysis")
+	publishTimeout := flag.Duration("publish-timeout", 2*time.Minute, "how long to wait for the activity to reach the public API")
+	skipSourceFlow := flag.Bool("skip-source-flow", false, "skip source submission, analysis, activation and the scrape trigger")
+	cleanup := flag.Bool("cleanup", true, "delete the synthetic source when the test finishes")
+	flag.Parse()
+
+	if *apiURL == "" {
+		log.Fatal("❌ Admin API URL is required: set ADMIN_API_URL or -api")
+	}
+	if *fixtureURL == "" && *fixtureBucket == "" {
+		log.Fatal("❌ A fixture location is required: set E2E_FIXTURE_BUCKET, -fixture-bucket or -fixture-url")
+	}
+
+	now := time.Now()
+	r := &e2eRun{
+		api: &apiClient{
+			baseURL:    strings.TrimRight(*apiURL, "/"),
+			httpClient: &http.Client{Timeout: 2 * time.Minute}, // extraction runs inside the request
+		},
+		marker:          now.UTC().Format("20060102-150405"),
+		eventDate:       now.AddDate(0, 0, 14),
+		fixtureURL:      *fixtureURL,
+		fixtureBucket:   *fixtureBucket,
+		analysisTimeout: *analysisTimeout,
+		publishTimeout:  *publishTimeout,
+		skipSourceFlow:  *skipSourceFlow,
+		cleanup:         *cleanup,
+	}
+
+	steps := []step{{"Host fixture page", hostFixture}}
+	if !r.skipSourceFlow {
+		steps = append(steps,
+			step{"Submit source", submitSource},
+			step{"Wait for source analysis", waitForAnalysis},
+			step{"Activate source", activateSource},
+			step{"Trigger scrape", triggerScrape},
+		)
+	}
+	steps = append(steps,
+		step{"Extract and convert page", extractPage},
+		step{"Check conversion", checkConversion},
+		step{"Approve event", approveEvent},
+		step{"Find activity in public API", findPublishedActivity},
+	)
+
+	ctx := context.Background()
+	log.Printf("Running pipeline smoke test %s against %s", r.marker, r.api.baseURL)
+
+	failed := false
+	for i, s := range steps {
+		log.Printf("\n--- Step %d/%d: %s ---", i+1, len(steps), s.name)
+		start := time.Now()
+		if err := s.run(ctx, r); err != nil {
+			log.Printf("❌ %s failed after %s: %v", s.name, time.Since(start).Round(time.Second), err)
+			failed = true
+			break
+		}
+		log.Printf("✅ %s (%s)", s.name, time.Since(start).Round(time.Second))
+	}
+
+	if r.cleanup && r.sourceID != "" {
+		if err := r.api.call(ctx, http.MethodDelete, "/api/sources/"+url.PathEscape(r.sourceID), nil, nil); err != nil {
+			log.Printf("⚠️  Failed to delete synthetic source %s: %v", r.sourceID, err)
+		} else {
+			log.Printf("Deleted synthetic source %s", r.sourceID)
+		}
+	}
+
+	if failed {
+		log.Println("\n❌ Pipeline smoke test failed")
+		os.Exit(1)
+	}
+	log.Printf("\n🎉 Pipeline smoke test passed: activity %s is live", r.activityID)
+}
+
+// hostFixture makes the synthetic page reachable by FireCrawl and records its URL
+func hostFixture(ctx context.Context, r *e2eRun) error {
+	if r.fixtureURL != "" {
+		// A unique query keeps the URL from clashing with earlier runs' admin events
+		pageURL, err := url.Parse(r.fixtureURL)
+		if err != nil {
+			return fmt.Errorf("invalid fixture URL: %w", err)
+		}
+		query := pageURL.Query()
+		query.Set("e2e", r.marker)
+		pageURL.RawQuery = query.Encode()
+		r.pageURL = pageURL.String()
+		log.Printf("Using hosted fixture %s", r.pageURL)
+		return nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	store := services.NewS3Store(cfg, r.fixtureBucket)
+
+	key := fmt.Sprintf("e2e/%s.html", r.marker)
+	page := fmt.Sprintf(fixtureTemplate, r.marker, r.eventDate.Format("Monday, January 2, 2006"))
+	if err := store.Put(ctx, key, []byte(page), "text/html; charset=utf-8"); err != nil {
+		return err
+	}
+	r.pageURL, err = store.PresignGet(ctx, key, 2*time.Hour)
+	if err != nil {
+		return err
+	}
+	log.Printf("Uploaded fixture to s3://%s/%s", r.fixtureBucket, key)
+	return nil
+}
+
+// submitSource submits the fixture page as a new source
+func submitSource(ctx context.Context, r *e2eRun) error {
+	var data struct {
+		SourceID string `json:"source_id"`
+	}
+	err := r.api.call(ctx, http.MethodPost, "/api/sources/submit", map[string]interface{}{
+		"source_name":      "E2E Test Library " + r.marker,
+		"base_url":         r.pageURL,
+		"source_type":      "library",
+		"priority":         "low",
+		"expected_content": []string{"events"},
+		"hint_urls":        []string{r.pageURL},
+		"submitted_by":     "e2e-test",
+	}, &data)
+	if err != nil {
+		return err
+	}
+	if data.SourceID == "" {
+		return fmt.Errorf("no source_id in the submission response")
+	}
+	r.sourceID = data.SourceID
+	log.Printf("Submitted source %s", r.sourceID)
+	return nil
+}
+
+// waitForAnalysis polls the source's analysis until it is complete
+func waitForAnalysis(ctx context.Context, r *e2eRun) error {
+	deadline := time.Now().Add(r.analysisTimeout)
+	for {
+		var analysis struct {
+			Status              string  `json:"status"`
+			OverallQualityScore float64 `json:"overall_quality_score"`
+		}
+		err := r.api.call(ctx, http.MethodGet, "/api/sources/"+url.PathEscape(r.sourceID)+"/analysis", nil, &analysis)
+		switch {
+		case err == nil && analysis.Status == "analysis_complete":
+			log.Printf("Analysis complete with quality score %.2f", analysis.OverallQualityScore)
+			return nil
+		case err == nil && analysis.Status == "failed":
+			return fmt.Errorf("source analysis failed")
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("analysis not ready after %s: %w", r.analysisTimeout, err)
+			}
+			return fmt.Errorf("analysis still %q after %s", analysis.Status, r.analysisTimeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// activateSource activates the analyzed source
+func activateSource(ctx context.Context, r *e2eRun) error {
+	return r.api.call(ctx, http.MethodPut, "/api/sources/"+url.PathEscape(r.sourceID)+"/activate", map[string]interface{}{
+		"admin_notes": "Activated by the pipeline smoke test",
+	}, nil)
+}
+
+// triggerScrape queues a manual scrape of the activated source
+func triggerScrape(ctx context.Context, r *e2eRun) error {
+	return r.api.call(ctx, http.MethodPost, "/api/sources/"+url.PathEscape(r.sourceID)+"/trigger", map[string]interface{}{
+		"task_type": "full_scrape",
+	}, nil)
+}
+
+// extractPage crawls the fixture page, creating a pending admin event with its conversion
+func extractPage(ctx context.Context, r *e2eRun) error {
+	var data struct {
+		EventID     string `json:"event_id"`
+		EventsCount int    `json:"events_count"`
+	}
+	err := r.api.call(ctx, http.MethodPost, "/api/crawl/submit", map[string]interface{}{
+		"url":               r.pageURL,
+		"schema_type":       "events",
+		"extracted_by_user": "e2e-test",
+		"admin_notes":       "Pipeline smoke test " + r.marker,
+	}, &data)
+	if err != nil {
+		return err
+	}
+	if data.EventsCount == 0 {
+		return fmt.Errorf("no events extracted from the fixture page")
+	}
+	r.eventID = data.EventID
+	log.Printf("Extracted %d events into pending event %s", data.EventsCount, r.eventID)
+	return nil
+}
+
+// checkConversion verifies the pending event converted to an activity
+func checkConversion(ctx context.Context, r *e2eRun) error {
+	var event struct {
+		ConversionPreview map[string]interface{} `json:"conversion_preview"`
+		ConversionIssues  []string               `json:"conversion_issues"`
+		CanApprove        bool                   `json:"can_approve"`
+	}
+	if err := r.api.call(ctx, http.MethodGet, "/api/events/"+url.PathEscape(r.eventID), nil, &event); err != nil {
+		return err
+	}
+	if len(event.ConversionPreview) == 0 {
+		return fmt.Errorf("event has no conversion preview")
+	}
+	if previewError, ok := event.ConversionPreview["error"]; ok {
+		return fmt.Errorf("conversion preview failed: %v", previewError)
+	}
+	if !event.CanApprove {
+		return fmt.Errorf("event cannot be approved, conversion issues: %v", event.ConversionIssues)
+	}
+	return nil
+}
+
+// approveEvent approves the pending event, publishing its activity
+func approveEvent(ctx context.Context, r *e2eRun) error {
+	var data struct {
+		ActivityID string `json:"activity_id"`
+	}
+	err := r.api.call(ctx, http.MethodPut, "/api/events/"+url.PathEscape(r.eventID)+"/approve", map[string]interface{}{
+		"action":      "approve",
+		"admin_notes": "Approved by the pipeline smoke test",
+		"reviewed_by": "e2e-test",
+	}, &data)
+	if err != nil {
+		return err
+	}
+	if data.ActivityID == "" {
+		return fmt.Errorf("no activity_id in the approval response")
+	}
+	r.activityID = data.ActivityID
+	log.Printf("Approved event as activity %s", r.activityID)
+	return nil
+}
+
+// findPublishedActivity polls the public events API until the approved activity appears
+func findPublishedActivity(ctx context.Context, r *e2eRun) error {
+	deadline := time.Now().Add(r.publishTimeout)
+	for {
+		var data struct {
+			Activities []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"activities"`
+		}
+		err := r.api.call(ctx, http.MethodGet, "/api/events/approved?limit=500", nil, &data)
+		if err == nil {
+			for _, activity := range data.Activities {
+				if activity.ID == r.activityID {
+					log.Printf("Found %q in the public API", activity.Title)
+					return nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("public API not reachable after %s: %w", r.publishTimeout, err)
+			}
+			return fmt.Errorf("activity %s not in the public API after %s", r.activityID, r.publishTimeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,155 @@
+// Command geocode_backfill places the activities published before nearby search existed on the
+// map. Locations without coordinates are geocoded and the result is kept on the event, then each
+// published activity gets the geo entry the lat/lng/radius filter of GET /api/events/approved reads.
+//
+// Usage:
+//
+//	FAMILY_ACTIVITIES_TABLE=seattle-family-activities \
+//	ADMIN_EVENTS_TABLE=seattle-admin-events \
+//	SCRAPING_OPERATIONS_TABLE=seattle-scraping-operations \
+//	GEOCODING_PLACE_INDEX=seattle-family-activities-places go run ./cmd/geocode_backfill -apply
+//
+// The provider is configured as for the admin API (GEOCODING_PROVIDER, GEOCODING_PLACE_INDEX and
+// GEOCODING_API_KEY), and answers are cached in the scraping operations table when it is set.
+// Without a provider only activities that already have coordinates are placed. Without -apply the
+// events are only counted.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "geocode and store the geo entries; without it the events are only counted")
+	flag.Parse()
+
+	familyActivitiesTable := os.Getenv("FAMILY_ACTIVITIES_TABLE")
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	if familyActivitiesTable == "" || adminEventsTable == "" {
+		log.Fatal("❌ Required environment variables not set: FAMILY_ACTIVITIES_TABLE, ADMIN_EVENTS_TABLE")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		familyActivitiesTable,
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		adminEventsTable,
+	)
+
+	// Geocoding answers are cached in the scraping operations table when it is set
+	var geocodeCache *services.DynamoDBService
+	if os.Getenv("SCRAPING_OPERATIONS_TABLE") != "" {
+		geocodeCache = dynamoService
+	}
+	geocoder, err := services.NewGeocodingServiceFromEnv(cfg, geocodeCache)
+	if err != nil {
+		log.Fatalf("❌ Failed to configure geocoding: %v", err)
+	}
+	if geocoder == nil {
+		log.Printf("Warning: No geocoding provider is configured, locations without coordinates stay off the map")
+	}
+
+	// Convert the way the admin API does, so venue coordinates are used before geocoding
+	conversionService := services.NewSchemaConversionService()
+	conversionService.SetValidationRules(services.NewValidationRuleCache(dynamoService, 5*time.Minute))
+	venues, err := dynamoService.GetVenues(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load the venue registry: %v", err)
+	}
+	conversionService.SetNeighborhoods(services.NewNeighborhoodCache(dynamoService, services.NewVenueRegistry(venues), 5*time.Minute))
+
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to get approved events: %v", err)
+	}
+
+	placed, geocoded, unplaced, failed, skipped := 0, 0, 0, 0, 0
+	for i := range approvedEvents {
+		event := &approvedEvents[i]
+		if event.ActivityID == "" {
+			skipped++
+			continue
+		}
+		result, err := conversionService.ConvertToActivity(event)
+		if err != nil || result.Activity == nil {
+			log.Printf("Warning: Event %s could not be converted, skipping: %v", event.EventID, err)
+			skipped++
+			continue
+		}
+		activity := result.Activity
+		activity.ID = event.ActivityID
+
+		coordinates := activity.Location.Coordinates
+		if !*apply {
+			if coordinates.Lat != 0 || coordinates.Lng != 0 {
+				placed++
+			} else {
+				unplaced++
+			}
+			continue
+		}
+
+		if geocoder != nil {
+			location, err := services.GeocodeLocation(ctx, geocoder, &activity.Location)
+			if err != nil {
+				log.Printf("Error geocoding the location of event %s: %v", event.EventID, err)
+				failed++
+				continue
+			}
+			if location != nil {
+				event.Geocoded = location
+				err := dynamoService.UpdateAdminEventFromStatus(ctx, event, models.AdminEventStatusApproved)
+				if errors.Is(err, services.ErrAdminEventChanged) {
+					log.Printf("Warning: Event %s was reviewed during the backfill, skipping", event.EventID)
+					failed++
+					continue
+				}
+				if err != nil {
+					log.Printf("Error saving the geocoded location of event %s: %v", event.EventID, err)
+					failed++
+					continue
+				}
+				geocoded++
+			}
+		}
+
+		entry := services.GeoEntryForActivity(activity, event.EventID)
+		if entry == nil {
+			unplaced++
+			continue
+		}
+		if err := dynamoService.ReplaceGeoEntry(ctx, activity.ID, entry); err != nil {
+			log.Printf("Error storing the geo entry of activity %s: %v", activity.ID, err)
+			failed++
+			continue
+		}
+		placed++
+	}
+
+	if !*apply {
+		log.Printf("Dry run: %d published activities have coordinates, %d would need geocoding, %d events skipped. Run with -apply to place them.", placed, unplaced, skipped)
+		return
+	}
+	log.Printf("Geocode backfill complete: %d placed (%d geocoded), %d without coordinates, %d failed, %d skipped", placed, geocoded, unplaced, failed, skipped)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// LinkCheckSummary is returned by each scheduled run
+type LinkCheckSummary struct {
+	EventsScanned int `json:"events_scanned"`
+	LinksChecked  int `json:"links_checked"`
+	LinksSkipped  int `json:"links_skipped"` // over the per-run limit, or left when the run ran out of time
+	Inconclusive  int `json:"inconclusive"`  // no response, so the link's status was left as it was
+	Healthy       int `json:"healthy"`
+	Failing       int `json:"failing"`
+	Broken        int `json:"broken"`
+	EventsUpdated int `json:"events_updated"`
+}
+
+// linkTarget is a single URL referenced by an approved event
+type linkTarget struct {
+	url    string
+	field  string
+	event  *models.AdminEvent
+	title  string
+	record *models.LinkHealthRecord // the link's health so far
+}
+
+var (
+	dynamoService     *services.DynamoDBService
+	conversionService *services.SchemaConversionService
+	linkChecker       *services.LinkChecker
+	maxLinksPerRun    = 300
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	if scrapingOperationsTable == "" || adminEventsTable == "" {
+		log.Fatal("Required environment variables not set: SCRAPING_OPERATIONS_TABLE, ADMIN_EVENTS_TABLE")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		scrapingOperationsTable,
+		adminEventsTable,
+	)
+
+	conversionService = services.NewSchemaConversionService()
+
+	// Be polite to venue sites: one request per host every couple of seconds by default
+	hostInterval := 2 * time.Second
+	if ms, err := strconv.Atoi(os.Getenv("LINK_CHECK_HOST_INTERVAL_MS")); err == nil && ms >= 0 {
+		hostInterval = time.Duration(ms) * time.Millisecond
+	}
+	linkChecker = services.NewLinkChecker(hostInterval)
+
+	if max, err := strconv.Atoi(os.Getenv("LINK_CHECK_MAX_URLS")); err == nil && max > 0 {
+		maxLinksPerRun = max
+	}
+}
+
+// handleRequest checks the registration and detail URLs of approved events. Each run checks the
+// links that were checked longest ago, up to the per-run limit, so runs rotate through the catalog.
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (LinkCheckSummary, error) {
+	summary := LinkCheckSummary{}
+
+	approvedEvents, err := dynamoService.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		log.Printf("Error getting approved events: %v", err)
+		return summary, err
+	}
+	summary.EventsScanned = len(approvedEvents)
+
+	targets := collectLinkTargets(approvedEvents)
+	if err := loadLinkHealth(ctx, targets); err != nil {
+		log.Printf("Error getting link health: %v", err)
+		return summary, err
+	}
+	// Links never checked have a zero check time, so they go first
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].record.LastCheckedAt.Before(targets[j].record.LastCheckedAt)
+	})
+	checking := targets
+	if len(checking) > maxLinksPerRun {
+		summary.LinksSkipped = len(checking) - maxLinksPerRun
+		checking = checking[:maxLinksPerRun]
+	}
+
+	// Results are cached per URL so links shared by several events are only requested once
+	results := make(map[string]services.LinkCheckResult)
+	checkedEvents := make(map[string]*models.AdminEvent)
+
+	for i, target := range checking {
+		if ctx.Err() != nil {
+			// Out of time: the links left are the oldest checked in the next run
+			summary.LinksSkipped += len(checking) - i
+			break
+		}
+
+		result, exists := results[target.url]
+		if !exists {
+			result = linkChecker.Check(ctx, target.url)
+			results[target.url] = result
+			summary.LinksChecked++
+		}
+		if result.Inconclusive {
+			summary.Inconclusive++
+			continue
+		}
+
+		record := *target.record
+		record.Field = target.field
+		record.EventTitle = target.title
+		record.SourceURL = target.event.SourceURL
+		record.RecordCheck(result.Healthy, result.StatusCode, result.Error, time.Now())
+
+		if err := dynamoService.PutLinkHealthRecord(ctx, &record); err != nil {
+			log.Printf("Warning: Failed to store link health for %s: %v", target.url, err)
+			continue
+		}
+		*target.record = record
+
+		checkedEvents[target.event.EventID] = target.event
+		switch record.Status {
+		case models.LinkStatusHealthy:
+			summary.Healthy++
+		case models.LinkStatusFailing:
+			summary.Failing++
+		case models.LinkStatusBroken:
+			summary.Broken++
+		}
+	}
+
+	// An event's broken list covers all of its links, including those not checked this run
+	brokenByEvent := make(map[string][]string)
+	for _, target := range targets {
+		if target.record.IsBroken() {
+			brokenByEvent[target.event.EventID] = append(brokenByEvent[target.event.EventID], target.url)
+		}
+	}
+
+	// Only events whose links were checked this run can have their broken list changed
+	for eventID, adminEvent := range checkedEvents {
+		broken := brokenByEvent[eventID]
+		sort.Strings(broken)
+		if sameLinks(adminEvent.BrokenLinks, broken) {
+			continue
+		}
+
+		adminEvent.BrokenLinks = broken
+		if err := dynamoService.UpdateAdminEvent(ctx, adminEvent); err != nil {
+			log.Printf("Error updating broken links for event %s: %v", eventID, err)
+			continue
+		}
+		summary.EventsUpdated++
+	}
+
+	log.Printf("Link check complete: %d events, %d links checked (%d skipped, %d inconclusive), %d healthy, %d failing, %d broken, %d events updated",
+		summary.EventsScanned, summary.LinksChecked, summary.LinksSkipped, summary.Inconclusive, summary.Healthy, summary.Failing, summary.Broken, summary.EventsUpdated)
+
+	return summary, nil
+}
+
+// collectLinkTargets converts approved events to activities and gathers their registration and detail URLs
+func collectLinkTargets(approvedEvents []models.AdminEvent) []linkTarget {
+	var targets []linkTarget
+	for i := range approvedEvents {
+		adminEvent := &approvedEvents[i]
+
+		conversionResult, err := conversionService.ConvertToActivity(adminEvent)
+		if err != nil || conversionResult.Activity == nil {
+			log.Printf("Warning: Skipping event %s, conversion failed: %v", adminEvent.EventID, err)
+			continue
+		}
+		activity := conversionResult.Activity
+
+		if activity.Registration.URL != "" {
+			targets = append(targets, linkTarget{url: activity.Registration.URL, field: models.LinkFieldRegistration, event: adminEvent, title: activity.Title})
+		}
+		if activity.DetailURL != "" && activity.DetailURL != activity.Registration.URL {
+			targets = append(targets, linkTarget{url: activity.DetailURL, field: models.LinkFieldDetail, event: adminEvent, title: activity.Title})
+		}
+	}
+	return targets
+}
+
+// loadLinkHealth sets each target's record to its stored link health, or to a new record when
+// the link has not been checked yet
+func loadLinkHealth(ctx context.Context, targets []linkTarget) error {
+	links := make([]models.LinkHealthRecord, len(targets))
+	for i, target := range targets {
+		links[i] = models.LinkHealthRecord{URL: target.url, EventID: target.event.EventID}
+	}
+
+	records, err := dynamoService.GetLinkHealthRecords(ctx, links)
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		targets[i].record = &records[i]
+	}
+	return nil
+}
+
+// sameLinks reports whether two sorted URL lists are equal
+func sameLinks(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
@@ -0,0 +1,78 @@
+// Command next_run_backfill adds the scraping tasks written before the next-run index was sharded
+// to next-run-shard-index, which the task scheduler reads due tasks from. Until it runs, those
+// tasks are never picked up again.
+//
+// Usage:
+//
+//	SCRAPING_OPERATIONS_TABLE=seattle-scraping-operations go run ./cmd/next_run_backfill -apply
+//
+// Run it once next-run-shard-index is active. Without -apply the tasks are only counted.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "shard the tasks; without it the tasks are only counted")
+	flag.Parse()
+
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	if scrapingOperationsTable == "" {
+		log.Fatal("❌ Required environment variable not set: SCRAPING_OPERATIONS_TABLE")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		scrapingOperationsTable,
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	tasks, err := dynamoService.GetUnshardedScrapingTasks(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to get unsharded scraping tasks: %v", err)
+	}
+
+	if !*apply {
+		log.Printf("Dry run: %d scraping tasks would be added to next-run-shard-index. Run with -apply to shard them.", len(tasks))
+		return
+	}
+
+	sharded, skipped, failed := 0, 0, 0
+	for i := range tasks {
+		task := &tasks[i]
+		applied, err := dynamoService.SetScrapingTaskNextRunShard(ctx, task)
+		switch {
+		case err != nil:
+			log.Printf("Error sharding scraping task %s: %v", task.TaskID, err)
+			failed++
+		case !applied:
+			log.Printf("Scraping task %s left the next-run index or was sharded during the backfill, skipping", task.TaskID)
+			skipped++
+		default:
+			log.Printf("Added scraping task %s (%s) to %s", task.TaskID, task.Status, task.NextRunShard)
+			sharded++
+		}
+	}
+
+	log.Printf("Next-run backfill complete: %d sharded, %d skipped, %d failed", sharded, skipped, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
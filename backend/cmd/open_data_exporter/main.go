@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/services"
+)
+
+var (
+	exporter    *services.OpenDataExporter
+	invalidator *services.CloudFrontInvalidator // nil when the published bucket is not behind CloudFront
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	bucket := os.Getenv("PUBLISH_BUCKET")
+	if adminEventsTable == "" || bucket == "" {
+		log.Fatal("Required environment variables not set: ADMIN_EVENTS_TABLE, PUBLISH_BUCKET")
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		adminEventsTable,
+	)
+
+	license := services.DefaultOpenDataLicense
+	if name := os.Getenv("OPEN_DATA_LICENSE"); name != "" {
+		license.Name = name
+		license.URL = os.Getenv("OPEN_DATA_LICENSE_URL")
+	}
+	if attribution := os.Getenv("OPEN_DATA_ATTRIBUTION"); attribution != "" {
+		license.Attribution = attribution
+	}
+
+	exporter = services.NewOpenDataExporter(dynamoService, services.NewSchemaConversionService(), services.NewS3Store(cfg, bucket), license)
+
+	if distributionID := os.Getenv("CDN_DISTRIBUTION_ID"); distributionID != "" {
+		invalidator = services.NewCloudFrontInvalidator(cfg, distributionID)
+	}
+}
+
+// handleRequest publishes the nightly open data dump of the activity catalog
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (*services.OpenDataMetadata, error) {
+	metadata, err := exporter.Export(ctx, time.Now())
+	if err != nil {
+		log.Printf("Error exporting open data catalog: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Open data export complete: %d activities from %d sources under %s (schema %s, %s)",
+		metadata.TotalActivities, len(metadata.Sources), services.OpenDataPrefix, metadata.SchemaVersion, metadata.License.Name)
+
+	// Dated exports are new keys; only the latest/ copies are replaced
+	if invalidator != nil {
+		paths := []string{services.OpenDataInvalidationPath}
+		if _, err := invalidator.Invalidate(ctx, paths, "open-data-"+metadata.GeneratedAt.Format(time.RFC3339Nano)); err != nil {
+			log.Printf("Warning: Failed to invalidate the latest open data export: %v", err)
+		}
+	}
+	return metadata, nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
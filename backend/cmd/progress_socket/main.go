@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/adminauth"
+	"seattle-family-activities-scraper/internal/apiheaders"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// SubscribeMessage is sent by the admin UI to follow a job after connecting
+type SubscribeMessage struct {
+	Action string `json:"action"` // "subscribe"
+	JobID  string `json:"job_id"`
+}
+
+var (
+	dynamoService    *services.DynamoDBService
+	progressReporter *services.ProgressReporter
+	apiHeaders       = apiheaders.FromEnv()
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	sourceManagementTable := os.Getenv("SOURCE_MANAGEMENT_TABLE")
+	if scrapingOperationsTable == "" || sourceManagementTable == "" {
+		log.Fatal("Required environment variables not set: SCRAPING_OPERATIONS_TABLE, SOURCE_MANAGEMENT_TABLE")
+	}
+
+	// Progress and connection tickets are kept in the scraping operations table; admin API keys
+	// are checked against the source management table
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		sourceManagementTable,
+		scrapingOperationsTable,
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	var poster services.ConnectionPoster
+	if endpoint := os.Getenv("PROGRESS_WEBSOCKET_ENDPOINT"); endpoint != "" {
+		poster = services.NewWebSocketPoster(cfg, endpoint)
+	}
+	progressReporter = services.NewProgressReporter(dynamoService, poster)
+}
+
+// handleRequest handles WebSocket $connect, $disconnect and $default routes for job progress
+func handleRequest(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	connectionID := request.RequestContext.ConnectionID
+	routeKey := request.RequestContext.RouteKey
+
+	log.Printf("Progress socket %s for connection %s", routeKey, connectionID)
+
+	switch routeKey {
+	case "$connect":
+		// Only admins may follow jobs. Refusing $connect rejects the connection, so later
+		// subscribe messages always come from an authenticated connection.
+		if status := authenticateConnection(ctx, request); status != 0 {
+			return events.APIGatewayProxyResponse{StatusCode: status}, nil
+		}
+
+		// The job can be supplied up front as ?job_id=... or later with a subscribe message
+		if jobID := request.QueryStringParameters["job_id"]; jobID != "" {
+			if err := dynamoService.CreateProgressConnection(ctx, connectionID, jobID); err != nil {
+				log.Printf("Error subscribing connection %s to job %s: %v", connectionID, jobID, err)
+				return events.APIGatewayProxyResponse{StatusCode: 500}, nil
+			}
+		}
+
+	case "$disconnect":
+		if err := dynamoService.DeleteProgressConnection(ctx, connectionID); err != nil {
+			log.Printf("Error removing connection %s: %v", connectionID, err)
+		}
+
+	default:
+		var message SubscribeMessage
+		if err := json.Unmarshal([]byte(request.Body), &message); err != nil || message.Action != "subscribe" || message.JobID == "" {
+			return events.APIGatewayProxyResponse{
+				StatusCode: 400,
+				Headers:    map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+				Body:       "expected {\"action\":\"subscribe\",\"job_id\":\"...\"}",
+			}, nil
+		}
+
+		if err := dynamoService.CreateProgressConnection(ctx, connectionID, message.JobID); err != nil {
+			log.Printf("Error subscribing connection %s to job %s: %v", connectionID, message.JobID, err)
+			return events.APIGatewayProxyResponse{StatusCode: 500}, nil
+		}
+
+		// Catch the client up on anything that happened before it subscribed
+		if err := progressReporter.Replay(ctx, message.JobID, connectionID); err != nil {
+			log.Printf("Warning: Failed to replay progress for job %s: %v", message.JobID, err)
+		}
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+}
+
+// authenticateConnection checks that a connection request is made for an admin. Browsers cannot
+// set headers on WebSocket connections, so the admin UI sends a single-use ticket from
+// POST /api/progress/tickets as ?ticket=...; other clients can send an admin API key in the
+// X-Api-Key header or as a bearer token. API keys are not accepted in the query string, which
+// access logs record. It returns 0 when the connection is allowed, or the status to refuse it with.
+func authenticateConnection(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) int {
+	if ticket := request.QueryStringParameters["ticket"]; ticket != "" {
+		redeemed, err := dynamoService.RedeemProgressTicket(ctx, ticket, time.Now())
+		if err != nil {
+			log.Printf("Error redeeming progress ticket: %v", err)
+			return 500
+		}
+		if redeemed == nil {
+			return 401
+		}
+		return 0
+	}
+
+	_, err := adminauth.Authenticate(ctx, dynamoService, adminauth.RequestKey(request.Headers))
+	switch {
+	case errors.Is(err, adminauth.ErrUnauthorized):
+		return 401
+	case errors.Is(err, adminauth.ErrRevoked):
+		return 403
+	case err != nil:
+		log.Printf("Error verifying admin API key: %v", err)
+		return 500
+	}
+	return 0
+}
+
+// withResponseHeaders adds the CORS, security and content type headers shared by API responses
+func withResponseHeaders(next func(context.Context, events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error)) func(context.Context, events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return func(ctx context.Context, request events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+		response, err := next(ctx, request)
+		response.Headers = apiHeaders.Apply(request.Headers, response.Headers)
+		return response, err
+	}
+}
+
+func main() {
+	lambda.Start(withResponseHeaders(handleRequest))
+}
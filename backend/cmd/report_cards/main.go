@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// ReportCardSummary is returned by each scheduled run
+type ReportCardSummary struct {
+	WeekStart string         `json:"week_start"`
+	Sources   int            `json:"sources"`
+	Stored    int            `json:"stored"`
+	Grades    map[string]int `json:"grades"`
+	Errors    []string       `json:"errors,omitempty"`
+}
+
+var (
+	dynamoService *services.DynamoDBService
+	reportStore   *services.S3Store
+	notifier      *services.SNSNotifier
+	alertTopicARN string
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	sourceManagementTable := os.Getenv("SOURCE_MANAGEMENT_TABLE")
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	bucket := os.Getenv("ANALYSIS_REPORTS_BUCKET")
+	if sourceManagementTable == "" || scrapingOperationsTable == "" || adminEventsTable == "" || bucket == "" {
+		log.Fatal("Required environment variables not set: SOURCE_MANAGEMENT_TABLE, SCRAPING_OPERATIONS_TABLE, ADMIN_EVENTS_TABLE, ANALYSIS_REPORTS_BUCKET")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		sourceManagementTable,
+		scrapingOperationsTable,
+		adminEventsTable,
+	)
+	reportStore = services.NewS3Store(cfg, bucket)
+
+	alertTopicARN = os.Getenv("ALERT_TOPIC_ARN")
+	if alertTopicARN != "" {
+		notifier = services.NewSNSNotifier(cfg)
+	}
+}
+
+// handleRequest grades every active source for the previous week, stores each report card in
+// the reports bucket and sends admins a digest of the grades
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (ReportCardSummary, error) {
+	now := time.Now().UTC()
+	weekStart := models.ReportCardWeekStart(now).AddDate(0, 0, -7)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	summary := ReportCardSummary{
+		WeekStart: weekStart.Format("2006-01-02"),
+		Grades:    make(map[string]int),
+	}
+
+	sources, err := dynamoService.QuerySourcesByStatus(ctx, models.SourceStatusActive, 500)
+	if err != nil {
+		log.Printf("Error getting active sources: %v", err)
+		return summary, err
+	}
+	summary.Sources = len(sources)
+
+	runs, err := dynamoService.GetFanOutRunsStartedBetween(ctx, weekStart, weekEnd)
+	if err != nil {
+		log.Printf("Error getting fan-out runs: %v", err)
+		return summary, err
+	}
+
+	adminEvents, err := dynamoService.GetAdminEventsExtractedOrReviewedBetween(ctx, weekStart, weekEnd)
+	if err != nil {
+		log.Printf("Error getting admin events: %v", err)
+		return summary, err
+	}
+
+	var cards []*models.SourceReportCard
+	for i := range sources {
+		card := services.BuildSourceReportCard(&sources[i], weekStart, runs, adminEvents, now)
+		cards = append(cards, card)
+		summary.Grades[card.Grade]++
+
+		if err := storeReportCard(ctx, card); err != nil {
+			log.Printf("Warning: Failed to store report card for source %s: %v", card.SourceID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+			continue
+		}
+		summary.Stored++
+	}
+
+	if notifier != nil && len(cards) > 0 {
+		subject, message := services.FormatReportCardDigest(cards, weekStart)
+		if err := notifier.Publish(ctx, alertTopicARN, subject, message); err != nil {
+			log.Printf("Warning: Failed to send report card digest: %v", err)
+			summary.Errors = append(summary.Errors, err.Error())
+		}
+	}
+
+	log.Printf("Report cards for the week of %s: %d sources, %d stored, grades %v",
+		summary.WeekStart, summary.Sources, summary.Stored, summary.Grades)
+	return summary, nil
+}
+
+// storeReportCard stores the card under its week and as the source's latest card
+func storeReportCard(ctx context.Context, card *models.SourceReportCard) error {
+	body, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report card: %w", err)
+	}
+
+	for _, key := range []string{
+		models.CreateReportCardKey(card.SourceID, card.WeekStart),
+		models.CreateLatestReportCardKey(card.SourceID),
+	} {
+		if err := reportStore.Put(ctx, key, body, "application/json"); err != nil {
+			return fmt.Errorf("failed to store report card %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
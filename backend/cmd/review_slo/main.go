@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// ReviewSLOSummary is returned by each scheduled run
+type ReviewSLOSummary struct {
+	Latency *models.ReviewQueueLatency `json:"latency"`
+	Alerted bool                       `json:"alerted"`
+}
+
+var (
+	dynamoService    *services.DynamoDBService
+	notifier         *services.SNSNotifier
+	alertTopicARN    string
+	reviewLatencySLO = services.DefaultReviewLatencySLO
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	if adminEventsTable == "" {
+		log.Fatal("Required environment variable not set: ADMIN_EVENTS_TABLE")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		adminEventsTable,
+	)
+
+	if hours, err := strconv.Atoi(os.Getenv("REVIEW_LATENCY_SLO_HOURS")); err == nil && hours > 0 {
+		reviewLatencySLO = time.Duration(hours) * time.Hour
+	}
+
+	alertTopicARN = os.Getenv("ALERT_TOPIC_ARN")
+	if alertTopicARN != "" {
+		notifier = services.NewSNSNotifier(cfg)
+	}
+}
+
+// handleRequest measures how long pending events have waited for review and alerts admins when
+// the p90 time-in-queue is over the review latency SLO
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (ReviewSLOSummary, error) {
+	summary := ReviewSLOSummary{}
+
+	pending, err := dynamoService.GetPendingAdminEventsMatching(ctx, "", "")
+	if err != nil {
+		log.Printf("Error getting pending admin events: %v", err)
+		return summary, err
+	}
+
+	latency := services.MeasureReviewQueue(pending, reviewLatencySLO, time.Now())
+	summary.Latency = latency
+	log.Printf("Review queue: %d pending, p50 %.1fh, p90 %.1fh, max %.1fh (SLO %.0fh)",
+		latency.Pending, latency.P50Hours, latency.P90Hours, latency.MaxHours, latency.SLOHours)
+
+	if latency.SLOBreached {
+		subject, message := services.FormatReviewSLOAlert(latency)
+		notification := models.NewAdminNotification(models.NotificationKindSLABreach, subject, message, time.Now())
+		if err := dynamoService.CreateAdminNotification(ctx, notification); err != nil {
+			log.Printf("Warning: Failed to record review SLO breach in the admin inbox: %v", err)
+		}
+
+		if notifier != nil {
+			if err := notifier.Publish(ctx, alertTopicARN, subject, message); err != nil {
+				log.Printf("Warning: Failed to send review SLO alert: %v", err)
+			} else {
+				summary.Alerted = true
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
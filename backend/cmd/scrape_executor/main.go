@@ -0,0 +1,982 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	lambdaclient "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"seattle-family-activities-scraper/internal/activityid"
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+var (
+	dynamoService            *services.DynamoDBService
+	firecrawlClient          *services.FireCrawlClient
+	firecrawlStats           *services.FireCrawlStatsCollector
+	metricsNamespace         string
+	dynamoDBMetricsNamespace string
+	activityPublisher        *services.ActivityPublisher
+	feedInvalidator          *services.CloudFrontInvalidator
+	notifier                 *services.SNSNotifier
+	alertTopicARN            string
+
+	sqsClient            *services.SQSClient
+	taskQueueURL         string
+	highPriorityQueueURL string
+	deadLetterQueueARN   string
+	domainPolicy         *services.DomainPolicyCache
+	concurrencyLimiter   *services.LeaseConcurrencyLimiter
+	geocoder             *services.GeocodingService
+
+	lambdaClient               *lambdaclient.Client
+	sourceAnalyzerFunctionName string
+)
+
+// Normal-priority tasks yield to waiting manual triggers by going back on the queue with a delay.
+// The deferral cap keeps a steady stream of manual triggers from starving scheduled work.
+const (
+	maxTaskDeferrals  = 5
+	taskDeferralDelay = 2 * time.Minute
+)
+
+// deadlineMargin is the time a task needs before the Lambda deadline: a wait for a FireCrawl
+// slot, the FireCrawl call itself, and recording the result. Tasks are not started with less left.
+const deadlineMargin = 150 * time.Second
+
+// heartbeatInterval is how often an executor reports a scraping task alive while it extracts one of
+// the task's URLs. The watchdog fails tasks whose heartbeats stop for much longer than this.
+const heartbeatInterval = time.Minute
+
+// A URL whose extraction fails with a retryable error goes back on the queue with a growing delay,
+// up to maxExtractionRetries times, before the failure is recorded on the run. A longer wait asked
+// for by FireCrawl is honored up to the longest delay SQS allows.
+const (
+	maxExtractionRetries   = 2
+	extractionRetryBackoff = time.Minute
+	maxQueueDelay          = 15 * time.Minute
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	// Create FireCrawl client
+	firecrawlClient, err = services.NewFireCrawlClient()
+	if err != nil {
+		log.Fatalf("Failed to create FireCrawl client: %v", err)
+	}
+
+	// Track FireCrawl usage; metrics are flushed to CloudWatch when a namespace is configured
+	firecrawlStats = services.NewFireCrawlStatsCollector()
+	firecrawlClient.SetStatsCollector(firecrawlStats)
+	firecrawlClient.SetValidationRules(services.NewValidationRuleCache(dynamoService, 5*time.Minute))
+	metricsNamespace = os.Getenv("FIRECRAWL_METRICS_NAMESPACE")
+	dynamoDBMetricsNamespace = os.Getenv("DYNAMODB_METRICS_NAMESPACE")
+
+	// FireCrawl calls share per-provider concurrency caps with every other executor
+	concurrencySettings := services.NewConcurrencySettingsCache(dynamoService, time.Minute)
+	concurrencyLimiter = services.NewLeaseConcurrencyLimiter(dynamoService, concurrencySettings, time.Minute)
+	firecrawlClient.SetConcurrencyLimiter(concurrencyLimiter)
+
+	// Domains denied after a source was activated are skipped at crawl time
+	domainPolicy = services.NewDomainPolicyCache(dynamoService, 5*time.Minute)
+
+	// Extracted and published locations without coordinates are geocoded when a provider is configured
+	geocoder, err = services.NewGeocodingServiceFromEnv(cfg, dynamoService)
+	if err != nil {
+		log.Printf("Warning: Geocoding disabled: %v", err)
+	}
+
+	// The executor that finishes a run publishes the activity feed and notifies admins when configured
+	if bucket := os.Getenv("PUBLISH_BUCKET"); bucket != "" {
+		conversionService := services.NewSchemaConversionService()
+		if geocoder != nil {
+			conversionService.SetGeocoder(geocoder)
+		}
+		activityPublisher = services.NewActivityPublisher(dynamoService, conversionService, services.NewS3Store(cfg, bucket))
+	}
+	if distributionID := os.Getenv("CDN_DISTRIBUTION_ID"); distributionID != "" {
+		feedInvalidator = services.NewCloudFrontInvalidator(cfg, distributionID)
+	}
+	alertTopicARN = os.Getenv("ALERT_TOPIC_ARN")
+	if alertTopicARN != "" {
+		notifier = services.NewSNSNotifier(cfg)
+	}
+
+	// Preemption is only possible when both task queues are configured
+	taskQueueURL = os.Getenv("SCRAPE_TASK_QUEUE_URL")
+	highPriorityQueueURL = os.Getenv("SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL")
+	sqsClient = services.NewSQSClient(cfg)
+
+	// Messages that failed every delivery arrive from the dead-letter queue to be recorded
+	deadLetterQueueARN = os.Getenv("SCRAPE_TASK_DLQ_ARN")
+
+	// Sources whose selectors drift are re-analyzed when the source analyzer is configured
+	sourceAnalyzerFunctionName = os.Getenv("SOURCE_ANALYZER_FUNCTION_NAME")
+	if sourceAnalyzerFunctionName != "" {
+		lambdaClient = lambdaclient.NewFromConfig(cfg)
+	}
+}
+
+// handleRequest processes scrape task messages queued by the orchestrator, and the messages moved
+// to the dead-letter queue after failing every delivery.
+// Extraction failures are recorded on the run; only messages that could not be recorded are retried.
+// The error of each failed delivery is kept until the message succeeds or is dead-lettered.
+func handleRequest(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+	var response events.SQSEventResponse
+
+	for _, record := range event.Records {
+		var err error
+		if deadLetterQueueARN != "" && record.EventSourceARN == deadLetterQueueARN {
+			err = recordDeadLetter(ctx, record)
+		} else if err = processMessage(ctx, record); err != nil {
+			recordMessageFailure(ctx, record, err)
+		}
+		if err != nil {
+			log.Printf("ERROR: Failed to process message %s: %v", record.MessageId, err)
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: record.MessageId,
+			})
+		}
+	}
+
+	if metricsNamespace != "" {
+		firecrawlStats.FlushToCloudWatch(metricsNamespace)
+		concurrencyLimiter.Stats().FlushToCloudWatch(metricsNamespace)
+	}
+	if dynamoDBMetricsNamespace != "" {
+		services.GetDynamoDBTelemetry().FlushToCloudWatch(dynamoDBMetricsNamespace)
+	}
+
+	return response, nil
+}
+
+// processMessage extracts activities for one queued URL and records the result on the run.
+// The result is checkpointed before it is counted, so a retry after a timeout resumes from
+// the checkpoint instead of extracting the URL again.
+func processMessage(ctx context.Context, record events.SQSMessage) error {
+	var task models.ScrapeTaskMessage
+	if err := json.Unmarshal([]byte(record.Body), &task); err != nil {
+		// A malformed message will never succeed, so drop it instead of retrying
+		log.Printf("ERROR: Discarding malformed task message %s: %v", record.MessageId, err)
+		return nil
+	}
+
+	result, err := dynamoService.GetFanOutTaskResult(ctx, task.RunID, task.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to check task checkpoint: %w", err)
+	}
+	if result != nil {
+		log.Printf("Run %s: resuming task %s from checkpoint", task.RunID, task.TaskID)
+	} else {
+		if nearDeadline(ctx) {
+			// Hand the task to a fresh invocation rather than be killed mid-extraction
+			if err := requeueTask(ctx, task, 0); err != nil {
+				return fmt.Errorf("failed to requeue task near deadline: %w", err)
+			}
+			log.Printf("Run %s: requeued task %s, too little time left before the Lambda deadline", task.RunID, task.TaskID)
+			return nil
+		}
+
+		if shouldYieldToHighPriority(ctx, task) {
+			task.Deferrals++
+			if err := requeueTask(ctx, task, taskDeferralDelay); err != nil {
+				return fmt.Errorf("failed to defer task: %w", err)
+			}
+			log.Printf("Run %s: deferred task %s behind high-priority work (deferral %d/%d)", task.RunID, task.TaskID, task.Deferrals, maxTaskDeferrals)
+			return nil
+		}
+
+		stopHeartbeat := startTaskHeartbeat(ctx, task)
+		result = extractTask(ctx, task)
+		stopHeartbeat()
+
+		if shouldRetryExtraction(task, result) {
+			task.Attempts++
+			delay := extractionRetryDelay(task.Attempts, result)
+			if err := requeueTask(ctx, task, delay); err != nil {
+				return fmt.Errorf("failed to requeue task for retry: %w", err)
+			}
+			log.Printf("Run %s: retrying task %s in %s after a %s error (retry %d/%d)", task.RunID, task.TaskID, delay, result.ErrorCode, task.Attempts, maxExtractionRetries)
+			return nil
+		}
+
+		checkpointed, err := dynamoService.CheckpointFanOutTaskResult(ctx, result)
+		if err != nil {
+			return fmt.Errorf("failed to checkpoint task result: %w", err)
+		}
+		if checkpointed {
+			sendTaskHeartbeat(ctx, task, describeCheckpoint(result))
+		} else {
+			// Another delivery of the same task got there first; count its result, not ours
+			result, err = dynamoService.GetFanOutTaskResult(ctx, task.RunID, task.TaskID)
+			if err != nil {
+				return fmt.Errorf("failed to get task checkpoint: %w", err)
+			}
+		}
+	}
+
+	var run *models.FanOutRun
+	if !result.Counted {
+		run, err = dynamoService.RecordFanOutTaskResult(ctx, result)
+		if err != nil {
+			return fmt.Errorf("failed to record task result: %w", err)
+		}
+	}
+	if run == nil {
+		log.Printf("Task %s for run %s was already recorded, skipping duplicate delivery", task.TaskID, task.RunID)
+
+		// A redelivery after a failed finalization is the only chance left to finish the run
+		run, err = dynamoService.GetFanOutRun(ctx, task.RunID)
+		if err != nil {
+			return fmt.Errorf("failed to get run: %w", err)
+		}
+		if run.Status == models.RunStatusRunning && run.IsFinished() {
+			return finalizeRun(ctx, run)
+		}
+		return nil
+	}
+
+	log.Printf("Run %s progress: %d/%d tasks finished (%d failed)", run.RunID, run.FinishedTasks(), run.TotalTasks, run.FailedTasks)
+	if run.IsFinished() {
+		return finalizeRun(ctx, run)
+	}
+	return nil
+}
+
+// recordMessageFailure keeps the error of a failed delivery for when the message is dead-lettered.
+// A failure to record it is only logged; the delivery is retried either way.
+func recordMessageFailure(ctx context.Context, record events.SQSMessage, processErr error) {
+	receiveCount, _ := strconv.Atoi(record.Attributes["ApproximateReceiveCount"])
+	failure := models.MessageFailure{
+		ReceiveCount: receiveCount,
+		Error:        processErr.Error(),
+		FailedAt:     time.Now(),
+	}
+	if err := dynamoService.RecordMessageFailure(ctx, record.MessageId, failure); err != nil {
+		log.Printf("Warning: Failed to record the failure of message %s: %v", record.MessageId, err)
+	}
+}
+
+// recordDeadLetter stores a message that failed every delivery with the errors recorded for it,
+// and alerts admins. A scrape task's URL is counted as failed on its run so the run can still
+// finish. The message stays in the dead-letter queue until it is stored.
+func recordDeadLetter(ctx context.Context, record events.SQSMessage) error {
+	failures, err := dynamoService.GetMessageFailures(ctx, record.MessageId)
+	if err != nil {
+		return fmt.Errorf("failed to get message failures: %w", err)
+	}
+
+	now := time.Now()
+	queuedAt := now
+	if sent, err := strconv.ParseInt(record.Attributes["SentTimestamp"], 10, 64); err == nil {
+		queuedAt = time.UnixMilli(sent)
+	}
+	message := models.NewFailedTaskMessage(record.MessageId, record.Body, failures, queuedAt, now)
+
+	if message.Task != nil {
+		if err := failDeadLetteredTask(ctx, message); err != nil {
+			log.Printf("Warning: Failed to record dead-lettered task %s on run %s: %v", message.Task.TaskID, message.Task.RunID, err)
+		} else {
+			message.RunRecorded = true
+		}
+	}
+
+	if err := dynamoService.CreateFailedTaskMessage(ctx, message); err != nil {
+		return err
+	}
+	log.Printf("Recorded dead-lettered message %s after %d failed deliveries: %s", record.MessageId, len(message.Failures), message.LastError())
+
+	subject, body := services.FormatDeadLetterAlert(message)
+	notification := models.NewAdminNotification(models.NotificationKindScrapeFailure, subject, body, now)
+	notification.SourceID = message.SourceID
+	if message.Task != nil {
+		notification.RunID = message.Task.RunID
+	}
+	recordAdminNotification(ctx, notification)
+	if notifier != nil {
+		if err := notifier.Publish(ctx, alertTopicARN, subject, body); err != nil {
+			log.Printf("Warning: Failed to send dead-letter alert for message %s: %v", record.MessageId, err)
+		}
+	}
+	return nil
+}
+
+// recordAdminNotification keeps a notable event in the admin inbox. A failure is only logged; the
+// inbox backs up the push notifications and never fails the work that raised the event.
+func recordAdminNotification(ctx context.Context, notification *models.AdminNotification) {
+	if err := dynamoService.CreateAdminNotification(ctx, notification); err != nil {
+		log.Printf("Warning: Failed to record %q in the admin inbox: %v", notification.Subject, err)
+	}
+}
+
+// failDeadLetteredTask records a dead-lettered task's URL as failed on its run, unless a delivery
+// already recorded a result, and finishes the run when it was the last URL
+func failDeadLetteredTask(ctx context.Context, message *models.FailedTaskMessage) error {
+	task := message.Task
+	result := &models.FanOutTaskResult{
+		RunID:        task.RunID,
+		TaskID:       task.TaskID,
+		SourceID:     task.SourceID,
+		SourceName:   task.SourceName,
+		URL:          task.URL,
+		ErrorMessage: message.LastError(),
+		ErrorCode:    models.ErrorCodeDeadLettered,
+	}
+	if _, err := dynamoService.CheckpointFanOutTaskResult(ctx, result); err != nil {
+		return fmt.Errorf("failed to checkpoint task result: %w", err)
+	}
+	result, err := dynamoService.GetFanOutTaskResult(ctx, task.RunID, task.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task checkpoint: %w", err)
+	}
+	if result == nil {
+		return fmt.Errorf("task checkpoint not found")
+	}
+
+	var run *models.FanOutRun
+	if !result.Counted {
+		run, err = dynamoService.RecordFanOutTaskResult(ctx, result)
+		if err != nil {
+			return fmt.Errorf("failed to record task result: %w", err)
+		}
+	}
+	if run == nil {
+		run, err = dynamoService.GetFanOutRun(ctx, task.RunID)
+		if err != nil {
+			return fmt.Errorf("failed to get run: %w", err)
+		}
+	}
+	if run.Status == models.RunStatusRunning && run.IsFinished() {
+		return finalizeRun(ctx, run)
+	}
+	return nil
+}
+
+// extractTask extracts activities from the task's URL unless its domain is denied, its source
+// was paused after the task was queued, or its source has used up a run limit. A source that hits a limit stops gracefully: the task
+// succeeds with the activities kept so far and records which limit was hit.
+// The page is scraped before it is compared with its last extraction, so skipping an unchanged
+// page saves the extraction and event creation but not the FireCrawl credits of the scrape.
+func extractTask(ctx context.Context, task models.ScrapeTaskMessage) *models.FanOutTaskResult {
+	if scrapingTaskCancelled(ctx, task) {
+		// The URLs already extracted keep their results; the rest of the run is skipped
+		log.Printf("Run %s: skipping %s, scraping task %s was cancelled", task.RunID, task.URL, task.ScrapingTaskID)
+		return &models.FanOutTaskResult{
+			RunID:        task.RunID,
+			TaskID:       task.TaskID,
+			SourceID:     task.SourceID,
+			SourceName:   task.SourceName,
+			URL:          task.URL,
+			ErrorMessage: "scraping task cancelled",
+			Cancelled:    true,
+		}
+	}
+
+	log.Printf("Run %s: extracting activities from %s (%s)", task.RunID, task.URL, task.SourceName)
+	start := time.Now()
+
+	var activities []models.Activity
+	var limitHit, outcome string
+	var credits int
+	var err error
+	if decision := domainPolicy.Check(task.URL); !decision.Allowed {
+		err = fmt.Errorf("domain %s %w: %s", decision.Domain, services.ErrDomainDenied, decision.Reason)
+	} else if sourcePaused(ctx, task) {
+		err = fmt.Errorf("source %s %w", task.SourceName, services.ErrSourcePaused)
+	} else if limitHit = reserveSourceRunPage(ctx, task); limitHit == "" {
+		var page *services.ScrapedPage
+		page, err = firecrawlClient.ScrapePage(task.URL)
+		if err != nil {
+			err = fmt.Errorf("FireCrawl extraction failed: %w", err)
+		} else if credits = page.CreditsUsed; pageUnchanged(ctx, task, page) {
+			outcome = models.TaskOutcomeSkippedUnchanged
+		} else {
+			activities, err = extractActivitiesFromPage(ctx, task, page)
+		}
+		if err == nil {
+			activities, limitHit = applySourceRunUsage(ctx, task, activities, credits)
+			if outcome == "" && limitHit == "" {
+				recordPageContent(ctx, task, page, len(activities))
+			}
+		}
+	}
+
+	result := &models.FanOutTaskResult{
+		RunID:           task.RunID,
+		TaskID:          task.TaskID,
+		SourceID:        task.SourceID,
+		SourceName:      task.SourceName,
+		URL:             task.URL,
+		Success:         err == nil,
+		ActivitiesFound: len(activities),
+		DurationMs:      time.Since(start).Milliseconds(),
+		LimitHit:        limitHit,
+		CreditsUsed:     credits,
+		Outcome:         outcome,
+	}
+	if limitHit != "" {
+		log.Printf("Run %s: source %s reached its %s run limit at %s", task.RunID, task.SourceName, limitHit, task.URL)
+	}
+	if err != nil {
+		failure := services.ClassifyError(err)
+		result.ErrorMessage = failure.Message
+		result.ErrorCode = failure.Code
+		result.Retryable = failure.Retryable
+		result.RetryAfterSeconds = failure.RetryAfterSeconds
+		log.Printf("ERROR: Failed to extract from %s (%s), %s: %v", task.SourceName, task.URL, failure.Code, err)
+	} else if outcome == models.TaskOutcomeSkippedUnchanged {
+		log.Printf("Skipped extracting %s, its content has not changed since it was last extracted", task.URL)
+	} else {
+		// Activities go through the admin approval process; they are not stored directly here
+		log.Printf("Extracted %d activities from %s", len(activities), task.URL)
+	}
+	return result
+}
+
+// pageUnchanged reports whether the task's page has the same content as when activities were last
+// extracted from it, counting the skipped check when it has. The check fails open, extracting the
+// page, when the recorded content hash cannot be read. It needs the scraped page, whose credits
+// are already spent.
+func pageUnchanged(ctx context.Context, task models.ScrapeTaskMessage, page *services.ScrapedPage) bool {
+	if task.Force {
+		return false
+	}
+	state, err := dynamoService.GetPageContentState(ctx, task.SourceID, task.URL)
+	if err != nil {
+		log.Printf("Warning: Failed to check whether %s changed, extracting it: %v", task.URL, err)
+		return false
+	}
+	now := time.Now()
+	if !state.Unchanged(page.ContentHash, now) {
+		return false
+	}
+	if err := dynamoService.RecordUnchangedPageCheck(ctx, task.SourceID, task.URL, now); err != nil {
+		log.Printf("Warning: Failed to record unchanged check of %s: %v", task.URL, err)
+	}
+	return true
+}
+
+// recordPageContent records the content hash of a page activities were just extracted from, so
+// later runs skip the page while it stays unchanged
+func recordPageContent(ctx context.Context, task models.ScrapeTaskMessage, page *services.ScrapedPage, activitiesFound int) {
+	now := time.Now()
+	state := &models.PageContentState{
+		SourceID:        task.SourceID,
+		URL:             task.URL,
+		ContentHash:     page.ContentHash,
+		ActivitiesFound: activitiesFound,
+		ExtractedAt:     now,
+		CheckedAt:       now,
+	}
+	if err := dynamoService.PutPageContentState(ctx, state); err != nil {
+		log.Printf("Warning: Failed to record content hash of %s: %v", task.URL, err)
+	}
+}
+
+// shouldRetryExtraction reports whether a failed extraction should go back on the queue instead of
+// being recorded as failed. Only retryable errors are retried, and only while attempts remain and
+// there is a queue to put the task back on.
+func shouldRetryExtraction(task models.ScrapeTaskMessage, result *models.FanOutTaskResult) bool {
+	if result.Success || result.Cancelled || !result.Retryable {
+		return false
+	}
+	return task.Attempts < maxExtractionRetries && (taskQueueURL != "" || highPriorityQueueURL != "")
+}
+
+// extractionRetryDelay returns how long a failed extraction waits before its nth retry: the
+// retry backoff, or the wait FireCrawl asked for when that is longer
+func extractionRetryDelay(attempt int, result *models.FanOutTaskResult) time.Duration {
+	delay := extractionRetryBackoff << (attempt - 1)
+	if retryAfter := time.Duration(result.RetryAfterSeconds) * time.Second; retryAfter > delay {
+		delay = retryAfter
+	}
+	if delay > maxQueueDelay {
+		delay = maxQueueDelay
+	}
+	return delay
+}
+
+// startTaskHeartbeat reports the scraping task that requested the task's run alive now and every
+// heartbeatInterval until the returned function is called
+func startTaskHeartbeat(ctx context.Context, task models.ScrapeTaskMessage) func() {
+	if task.ScrapingTaskID == "" {
+		return func() {}
+	}
+	sendTaskHeartbeat(ctx, task, "")
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sendTaskHeartbeat(ctx, task, "")
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// sendTaskHeartbeat records a heartbeat, and the checkpoint when set, on the task's scraping task.
+// A missed heartbeat is only logged; the watchdog waits for many intervals before giving up on a task.
+func sendTaskHeartbeat(ctx context.Context, task models.ScrapeTaskMessage, checkpoint string) {
+	if task.ScrapingTaskID == "" {
+		return
+	}
+	if err := dynamoService.RecordScrapingTaskHeartbeat(ctx, task.ScrapingTaskID, task.RunID, checkpoint); err != nil {
+		log.Printf("Warning: Failed to record heartbeat for scraping task %s: %v", task.ScrapingTaskID, err)
+	}
+}
+
+// describeCheckpoint summarizes a checkpointed task result for the scraping task's last checkpoint
+func describeCheckpoint(result *models.FanOutTaskResult) string {
+	outcome := fmt.Sprintf("succeeded with %d activities", result.ActivitiesFound)
+	switch {
+	case result.Cancelled:
+		outcome = "was cancelled"
+	case result.SkippedUnchanged():
+		outcome = "was skipped, unchanged since its last extraction"
+	case !result.Success:
+		outcome = "failed: " + result.ErrorMessage
+	}
+	return fmt.Sprintf("%s (%s) %s at %s", result.TaskID, result.URL, outcome, time.Now().UTC().Format(time.RFC3339))
+}
+
+// completeScrapingTask records the outcome of a finished run on the scraping task that requested it.
+// A failed run records the classified failure on the task, which is retried later when the failure
+// is retryable and the task has retries left. A cancelled task keeps its cancelled status.
+func completeScrapingTask(ctx context.Context, run *models.FanOutRun, results []models.FanOutTaskResult) {
+	if run.ScrapingTaskID == "" || run.Status == models.RunStatusCancelled {
+		return
+	}
+	if run.Status != models.RunStatusFailed {
+		if _, _, err := dynamoService.TransitionScrapingTask(ctx, run.ScrapingTaskID, models.TaskStatusCompleted); err != nil {
+			log.Printf("Warning: Failed to mark scraping task %s completed: %v", run.ScrapingTaskID, err)
+		}
+		return
+	}
+
+	scrapingTask, err := dynamoService.GetScrapingTask(ctx, run.ScrapingTaskID)
+	if err != nil {
+		log.Printf("Warning: Failed to get scraping task %s: %v", run.ScrapingTaskID, err)
+		return
+	}
+	status, applied, err := dynamoService.FailScrapingTask(ctx, scrapingTask, services.RunFailure(results))
+	switch {
+	case err != nil:
+		log.Printf("Warning: Failed to mark scraping task %s failed: %v", run.ScrapingTaskID, err)
+	case applied:
+		log.Printf("Scraping task %s is %s after run %s failed with %s errors", run.ScrapingTaskID, status, run.RunID, scrapingTask.ErrorCode)
+	}
+}
+
+// scrapingTaskCancelled reports whether an admin cancelled the scraping task that requested the
+// task's run. It is checked before each URL, so a cancelled run stops at the next URL boundary.
+// The check fails open when the scraping task cannot be read.
+func scrapingTaskCancelled(ctx context.Context, task models.ScrapeTaskMessage) bool {
+	if task.ScrapingTaskID == "" {
+		return false
+	}
+	scrapingTask, err := dynamoService.GetScrapingTask(ctx, task.ScrapingTaskID)
+	if err != nil {
+		log.Printf("Warning: Failed to check whether scraping task %s was cancelled, continuing: %v", task.ScrapingTaskID, err)
+		return false
+	}
+	return scrapingTask.Status == models.TaskStatusCancelled
+}
+
+// sourcePaused reports whether an admin paused the task's source. Queued messages cannot be
+// recalled, so they are dropped here instead. The check fails open when the source cannot be read.
+func sourcePaused(ctx context.Context, task models.ScrapeTaskMessage) bool {
+	submission, err := dynamoService.GetSourceSubmission(ctx, task.SourceID)
+	if err != nil {
+		log.Printf("Warning: Failed to check whether %s is paused, continuing: %v", task.SourceName, err)
+		return false
+	}
+	return submission.Status == models.SourceStatusPaused
+}
+
+// reserveSourceRunPage counts the task's page against its source's run limits and returns
+// the limit that refuses it, or "" when the page may be fetched. Limits fail open when the
+// usage counter cannot be reached.
+func reserveSourceRunPage(ctx context.Context, task models.ScrapeTaskMessage) string {
+	if !task.Limits.Enabled() {
+		return ""
+	}
+	usage, reserved, err := dynamoService.ReserveSourceRunPage(ctx, task.RunID, task.SourceID, task.Limits)
+	if err != nil {
+		log.Printf("Warning: Failed to check run limits for %s, continuing without them: %v", task.SourceName, err)
+		return ""
+	}
+	if reserved {
+		return ""
+	}
+	return task.Limits.ReachedLimit(usage)
+}
+
+// applySourceRunUsage adds a page's activities and credits to its source's usage and drops
+// the activities beyond the source's activity limit. It returns the activities to keep and
+// the limit that truncated them, if any.
+func applySourceRunUsage(ctx context.Context, task models.ScrapeTaskMessage, activities []models.Activity, credits int) ([]models.Activity, string) {
+	if !task.Limits.Enabled() {
+		return activities, ""
+	}
+	usage, err := dynamoService.AddSourceRunUsage(ctx, task.RunID, task.SourceID, len(activities), credits)
+	if err != nil {
+		log.Printf("Warning: Failed to record run usage for %s: %v", task.SourceName, err)
+		return activities, ""
+	}
+	if allowed := task.Limits.AllowedActivities(len(activities), usage.Activities); allowed < len(activities) {
+		return activities[:allowed], models.RunLimitActivities
+	}
+	return activities, ""
+}
+
+// nearDeadline reports whether too little time is left in this invocation to start a task
+func nearDeadline(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < deadlineMargin
+}
+
+// requeueTask puts a task back on the queue matching its priority
+func requeueTask(ctx context.Context, task models.ScrapeTaskMessage, delay time.Duration) error {
+	queueURL := taskQueueURL
+	if task.QueuePriority == models.QueuePriorityHigh {
+		queueURL = highPriorityQueueURL
+	}
+	if queueURL == "" {
+		return fmt.Errorf("no queue configured for %s priority tasks", task.QueuePriority)
+	}
+	return sqsClient.SendMessage(ctx, queueURL, task, delay)
+}
+
+// shouldYieldToHighPriority reports whether a normal-priority task should step aside
+// because manual trigger tasks are waiting on the high-priority queue
+func shouldYieldToHighPriority(ctx context.Context, task models.ScrapeTaskMessage) bool {
+	if task.QueuePriority == models.QueuePriorityHigh || task.Deferrals >= maxTaskDeferrals {
+		return false
+	}
+	if taskQueueURL == "" || highPriorityQueueURL == "" {
+		return false
+	}
+
+	depth, err := sqsClient.GetQueueDepth(ctx, highPriorityQueueURL)
+	if err != nil {
+		// Run the task rather than risk dropping it over a monitoring call
+		log.Printf("Warning: Failed to check high-priority queue depth: %v", err)
+		return false
+	}
+	return depth.Visible > 0
+}
+
+// finalizeRun closes out a run after its last task reported: it records the final status and
+// aggregate stats, checks for selector drift, then publishes the approved activity feed and sends
+// a completion notification.
+// Publishing and notification failures are logged but do not fail the message, since the run is already final.
+func finalizeRun(ctx context.Context, run *models.FanOutRun) error {
+	results, err := dynamoService.GetFanOutTaskResults(ctx, run.RunID)
+	if err != nil {
+		return fmt.Errorf("failed to load task results: %w", err)
+	}
+
+	now := time.Now()
+	run.Status = services.FinalRunStatus(run)
+	run.CompletedAt = &now
+	run.Stats = services.SummarizeFanOutRun(run, results, now)
+
+	finalized, err := dynamoService.FinalizeFanOutRun(ctx, run)
+	if err != nil {
+		return err
+	}
+	if !finalized {
+		log.Printf("Run %s was already finalized by another executor", run.RunID)
+		return nil
+	}
+
+	log.Printf("Run %s finished with status %s: %d activities from %d/%d tasks in %d ms",
+		run.RunID, run.Status, run.TotalActivities, run.CompletedTasks, run.TotalTasks, run.Stats.DurationMs)
+
+	completeScrapingTask(ctx, run, results)
+
+	checkSelectorDrift(ctx, run)
+
+	if activityPublisher != nil {
+		published, err := activityPublisher.Publish(ctx)
+		if err != nil {
+			log.Printf("ERROR: Failed to publish activities for run %s: %v", run.RunID, err)
+			run.Errors = append(run.Errors, fmt.Sprintf("publish: %v", err))
+		} else {
+			run.PublishedActivities = published
+			log.Printf("Run %s: published %d activities to %s", run.RunID, published, services.PublishedActivitiesKey)
+			if err := dynamoService.SetFanOutRunPublished(ctx, run.RunID, published); err != nil {
+				log.Printf("Warning: Failed to record published activities for run %s: %v", run.RunID, err)
+			}
+			if feedInvalidator != nil {
+				paths := []string{services.PublishedActivitiesInvalidationPath}
+				if _, err := feedInvalidator.Invalidate(ctx, paths, "activities-feed-"+run.RunID); err != nil {
+					log.Printf("Warning: Failed to invalidate the published activity feed for run %s: %v", run.RunID, err)
+				}
+			}
+		}
+	}
+
+	// Runs with failed URLs are kept in the admin inbox; every run's notification is pushed
+	subject, message := services.FormatRunNotification(run)
+	if run.Status == models.RunStatusFailed || run.Status == models.RunStatusPartial {
+		notification := models.NewAdminNotification(models.NotificationKindScrapeFailure, subject, message, time.Now())
+		notification.SourceID, notification.RunID = run.SourceID, run.RunID
+		recordAdminNotification(ctx, notification)
+	}
+	if notifier != nil {
+		if err := notifier.Publish(ctx, alertTopicARN, subject, message); err != nil {
+			log.Printf("Warning: Failed to send completion notification for run %s: %v", run.RunID, err)
+		}
+	}
+
+	// Out of credits or a rejected key fails every scrape, so it gets its own alert
+	if subject, message, ok := services.FormatFireCrawlAccountAlert(run); ok {
+		notification := models.NewAdminNotification(models.NotificationKindScrapeFailure, subject, message, time.Now())
+		notification.RunID = run.RunID
+		recordAdminNotification(ctx, notification)
+		if notifier != nil {
+			if err := notifier.Publish(ctx, alertTopicARN, subject, message); err != nil {
+				log.Printf("Warning: Failed to send FireCrawl account alert for run %s: %v", run.RunID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSelectorDrift detects sources whose stored selectors stopped yielding items and moves
+// their open drifts along. The drift status each source reached is added to the run stats for
+// the completion notification. Drift handling never fails the run.
+func checkSelectorDrift(ctx context.Context, run *models.FanOutRun) {
+	for sourceID, stats := range run.Stats.Sources {
+		config, err := dynamoService.GetSourceConfig(ctx, sourceID)
+		if err != nil {
+			// Sources without a production config have no stored selectors to drift
+			continue
+		}
+
+		status, err := advanceSelectorDrift(ctx, run.RunID, config, stats)
+		if err != nil {
+			log.Printf("Warning: Failed to check selector drift for %s: %v", stats.SourceName, err)
+			continue
+		}
+		if status != "" {
+			stats.SelectorDrift = status
+			run.Stats.Sources[sourceID] = stats
+		}
+	}
+}
+
+// advanceSelectorDrift takes one step for a source's selector drift: a source that yielded no
+// items opens a drift and is re-analyzed, a newer analysis becomes a proposal, and a proposal is
+// hot-swapped in after a canary scrape when the source allows auto-healing. It returns the drift
+// status to report, or "" when nothing changed.
+func advanceSelectorDrift(ctx context.Context, runID string, config *models.DynamoSourceConfig, stats models.FanOutSourceStats) (string, error) {
+	drift := config.SelectorDrift
+	if !drift.Open() {
+		if config.ContentSelectors.IsEmpty() || !services.SelectorDriftSuspected(stats) {
+			return "", nil
+		}
+
+		drift = &models.SelectorDrift{
+			Status:     models.SelectorDriftDetected,
+			DetectedAt: time.Now(),
+			RunID:      runID,
+		}
+		if err := dynamoService.SetSourceSelectorDrift(ctx, config.SourceID, drift); err != nil {
+			return "", err
+		}
+		log.Printf("Run %s: selector drift detected for %s, %d pages yielded no items", runID, stats.SourceName, stats.Tasks)
+
+		if err := triggerReanalysis(ctx, config.SourceID); err != nil {
+			log.Printf("Warning: Failed to trigger re-analysis of %s: %v", stats.SourceName, err)
+		}
+		return drift.Status, nil
+	}
+
+	status := ""
+	if drift.Status == models.SelectorDriftDetected {
+		analysis, err := dynamoService.GetSourceAnalysis(ctx, config.SourceID)
+		if err != nil || !services.ProposeSelectorFix(drift, analysis) {
+			// Still waiting for the re-analysis
+			return "", nil
+		}
+		if err := dynamoService.SetSourceSelectorDrift(ctx, config.SourceID, drift); err != nil {
+			return "", err
+		}
+		log.Printf("Source %s: analysis v%d proposed new selectors", stats.SourceName, drift.ProposedFromVersion)
+		status = drift.Status
+
+		notification := models.NewAdminNotification(models.NotificationKindSuggestion,
+			fmt.Sprintf("New selectors proposed for %s", stats.SourceName),
+			fmt.Sprintf("%s stopped yielding items in run %s. Analysis v%d proposed new selectors to replace the stored ones.", stats.SourceName, drift.RunID, drift.ProposedFromVersion),
+			time.Now())
+		notification.SourceID, notification.RunID = config.SourceID, runID
+		recordAdminNotification(ctx, notification)
+	}
+
+	if !config.AutoHealSelectors {
+		return status, nil
+	}
+	return healSelectorDrift(ctx, config, drift)
+}
+
+// healSelectorDrift hot-swaps a drift's proposed selectors once a canary scrape of the source's
+// first target URL finds activities again. A failed canary keeps the proposal for the next run.
+func healSelectorDrift(ctx context.Context, config *models.DynamoSourceConfig, drift *models.SelectorDrift) (string, error) {
+	if len(config.TargetURLs) == 0 {
+		return "", nil
+	}
+
+	now := time.Now()
+	drift.CanaryURL = config.TargetURLs[0]
+	drift.CanaryAt = &now
+	drift.CanaryItems = 0
+	response, err := firecrawlClient.ExtractActivities(drift.CanaryURL)
+	if err != nil {
+		log.Printf("Warning: Canary scrape of %s failed: %v", drift.CanaryURL, err)
+	} else if response != nil {
+		drift.CanaryItems = len(response.Data.Activities)
+	}
+
+	if drift.CanaryItems == 0 {
+		if err := dynamoService.SetSourceSelectorDrift(ctx, config.SourceID, drift); err != nil {
+			return "", err
+		}
+		log.Printf("Source %s: canary scrape of %s found no items, keeping current selectors", config.SourceName, drift.CanaryURL)
+		return models.SelectorDriftCanaryFailed, nil
+	}
+
+	drift.Status = models.SelectorDriftApplied
+	drift.AppliedAt = &now
+	if err := dynamoService.ApplySourceSelectors(ctx, config.SourceID, *drift.ProposedSelectors, drift); err != nil {
+		return "", err
+	}
+	log.Printf("Source %s: applied selectors from analysis v%d after canary found %d items", config.SourceName, drift.ProposedFromVersion, drift.CanaryItems)
+	return drift.Status, nil
+}
+
+// triggerReanalysis asynchronously invokes the source analyzer for a source
+func triggerReanalysis(ctx context.Context, sourceID string) error {
+	if lambdaClient == nil {
+		return fmt.Errorf("source analyzer is not configured")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"source_id":    sourceID,
+		"trigger_type": "selector_drift",
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
+		FunctionName:   aws.String(sourceAnalyzerFunctionName),
+		InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
+		Payload:        payload,
+	})
+	return err
+}
+
+// extractActivitiesFromPage returns the activities extracted from the task's scraped page
+func extractActivitiesFromPage(ctx context.Context, task models.ScrapeTaskMessage, page *services.ScrapedPage) ([]models.Activity, error) {
+	// Parse structured data from the page's markdown
+	response, err := firecrawlClient.ExtractActivitiesFromPage(page)
+	if err != nil {
+		return nil, fmt.Errorf("FireCrawl extraction failed: %w", err)
+	}
+
+	if response == nil || len(response.Data.Activities) == 0 {
+		log.Printf("No activities extracted from %s", task.URL)
+		return []models.Activity{}, nil
+	}
+
+	// Add source metadata to each activity
+	now := time.Now()
+	for i := range response.Data.Activities {
+		response.Data.Activities[i].Source = models.Source{
+			URL:         task.URL,
+			Domain:      urlutil.Domain(task.URL),
+			ScrapedAt:   now,
+			LastChecked: now,
+			Reliability: "medium",
+		}
+		response.Data.Activities[i].UpdatedAt = now
+		if response.Data.Activities[i].CreatedAt.IsZero() {
+			response.Data.Activities[i].CreatedAt = now
+		}
+
+		// Associate with source via Provider field
+		response.Data.Activities[i].Provider = models.Provider{
+			Name:    task.SourceName,
+			Type:    "community-calendar",
+			Website: task.BaseURL,
+		}
+
+		// Generate ID if not provided
+		if response.Data.Activities[i].ID == "" {
+			response.Data.Activities[i].ID = activityid.New(
+				response.Data.Activities[i].Source.URL,
+				response.Data.Activities[i].Title,
+				response.Data.Activities[i].Schedule.StartDate,
+				response.Data.Activities[i].Location.Name,
+			)
+		}
+
+		// Sources expected to list camps or classes get their camp or class details
+		services.ApplyCategoryDetails(&response.Data.Activities[i], task.Category)
+
+		// Listings without separate arrival notes often give them in the description
+		if response.Data.Activities[i].ArrivalInfo == nil {
+			response.Data.Activities[i].ArrivalInfo = services.ParseArrivalInfo(response.Data.Activities[i].Description)
+		}
+
+		// Most listings give an address but no coordinates
+		if geocoder != nil {
+			if _, err := services.GeocodeLocation(ctx, geocoder, &response.Data.Activities[i].Location); err != nil {
+				log.Printf("Warning: Failed to geocode %q from %s: %v", response.Data.Activities[i].Title, task.URL, err)
+			}
+		}
+	}
+
+	return response.Data.Activities, nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
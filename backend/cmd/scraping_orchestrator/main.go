@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"net/url"
 	"os"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 
+	"seattle-family-activities-scraper/internal/apiheaders"
 	"seattle-family-activities-scraper/internal/models"
 	"seattle-family-activities-scraper/internal/services"
 )
@@ -30,7 +30,10 @@ type Source struct {
 
 // ScrapingOrchestratorEvent represents the input event for orchestrator
 type ScrapingOrchestratorEvent struct {
-	SourceID string `json:"source_id,omitempty"` // optional: scrape specific source
+	SourceID    string `json:"source_id,omitempty"`    // optional: scrape specific source
+	TriggerType string `json:"trigger_type,omitempty"` // scheduled (default), manual, automatic
+	TaskID      string `json:"task_id,omitempty"`      // optional: admin scraping task the run is for, so it can be cancelled
+	Force       bool   `json:"force,omitempty"`        // optional: extract every page, even those unchanged since their last extraction
 }
 
 // ScrapingOrchestratorResponse represents the Lambda response
@@ -42,18 +45,22 @@ type ScrapingOrchestratorResponse struct {
 
 // ResponseBody structure
 type ResponseBody struct {
-	Success         bool     `json:"success"`
-	Message         string   `json:"message"`
-	TotalSources    int      `json:"total_sources"`
-	ProcessedSources int     `json:"processed_sources"`
-	TotalActivities int      `json:"total_activities"`
-	ProcessingTime  int64    `json:"processing_time_ms"`
-	Errors          []string `json:"errors,omitempty"`
+	Success          bool     `json:"success"`
+	Message          string   `json:"message"`
+	RunID            string   `json:"run_id,omitempty"`
+	TotalSources     int      `json:"total_sources"`
+	ProcessedSources int      `json:"processed_sources"`
+	QueuedTasks      int      `json:"queued_tasks"`
+	ProcessingTime   int64    `json:"processing_time_ms"`
+	Errors           []string `json:"errors,omitempty"`
 }
 
 var (
-	dynamoService   *services.DynamoDBService
-	firecrawlClient *services.FireCrawlClient
+	dynamoService        *services.DynamoDBService
+	sqsClient            *services.SQSClient
+	taskQueueURL         string
+	highPriorityQueueURL string
+	apiHeaders           = apiheaders.FromEnv()
 )
 
 // Note: All sources are now managed dynamically through the admin interface
@@ -66,7 +73,7 @@ func init() {
 		log.Fatalf("Failed to load AWS config: %v", err)
 	}
 
-	// Create DynamoDB client and service (for storing results)
+	// Create DynamoDB client and service (for run tracking)
 	dynamoClient := dynamodb.NewFromConfig(cfg)
 	dynamoService = services.NewDynamoDBService(
 		dynamoClient,
@@ -76,19 +83,40 @@ func init() {
 		os.Getenv("ADMIN_EVENTS_TABLE"),
 	)
 
-	// Create FireCrawl client
-	firecrawlClient, err = services.NewFireCrawlClient()
-	if err != nil {
-		log.Fatalf("Failed to create FireCrawl client: %v", err)
+	// Each source URL is extracted by the scrape executor, fed from the task queue
+	taskQueueURL = os.Getenv("SCRAPE_TASK_QUEUE_URL")
+	if taskQueueURL == "" {
+		log.Fatal("Required environment variable not set: SCRAPE_TASK_QUEUE_URL")
 	}
+	// Manual triggers jump ahead of scheduled work when a high-priority queue is configured
+	highPriorityQueueURL = os.Getenv("SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL")
+	sqsClient = services.NewSQSClient(cfg)
 }
 
+// handleRequest fans a scraping run out to the task queue, one message per source URL.
+// Results are aggregated on the run record by the executors as tasks finish.
 func handleRequest(ctx context.Context, event ScrapingOrchestratorEvent) (ScrapingOrchestratorResponse, error) {
 	start := time.Now()
 
 	log.Printf("Starting scraping orchestrator")
 
-	var allActivities []models.Activity
+	// A task cancelled before the orchestrator got to it, or already started by another
+	// invocation, must not start a run
+	if event.TaskID != "" {
+		task, started, err := dynamoService.TransitionScrapingTask(ctx, event.TaskID, models.TaskStatusInProgress)
+		switch {
+		case err != nil:
+			log.Printf("Warning: Failed to mark scraping task %s in progress: %v", event.TaskID, err)
+		case !started:
+			log.Printf("Scraping task %s is %s, not queuing a run", event.TaskID, task.Status)
+			body, _ := json.Marshal(ResponseBody{Success: true, Message: fmt.Sprintf("Scraping task %s is %s", event.TaskID, task.Status)})
+			return ScrapingOrchestratorResponse{
+				StatusCode: 200,
+				Body:       string(body),
+			}, nil
+		}
+	}
+
 	var errors []string
 	processedSources := 0
 
@@ -97,26 +125,21 @@ func handleRequest(ctx context.Context, event ScrapingOrchestratorEvent) (Scrapi
 	if err != nil {
 		errorMsg := fmt.Sprintf("Failed to get active sources: %v", err)
 		log.Printf("ERROR: %s", errorMsg)
-		return ScrapingOrchestratorResponse{
-			StatusCode: 500,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: fmt.Sprintf(`{"success": false, "message": "%s"}`, errorMsg),
-		}, nil
+		return errorResponse(errorMsg), nil
 	}
 
 	log.Printf("Processing %d sources", len(sources))
 
-	// Process each source directly with FireCrawl
+	runID := models.GenerateScrapingRunID(start)
+	queuePriority, queueURL := taskQueueFor(event.TriggerType)
+	var tasks []interface{}
+
 	for _, source := range sources {
 		if !source.Enabled {
 			log.Printf("Skipping disabled source: %s", source.Name)
 			continue
 		}
 
-		log.Printf("Processing source: %s", source.Name)
-
 		// Save source to DynamoDB if not already exists
 		err := ensureSourceInDatabase(source)
 		if err != nil {
@@ -124,78 +147,196 @@ func handleRequest(ctx context.Context, event ScrapingOrchestratorEvent) (Scrapi
 			// Continue processing even if database save fails
 		}
 
-		// Process each target URL for the source
-		for _, url := range source.TargetURLs {
-			log.Printf("Extracting activities from: %s", url)
-
-			activities, err := extractActivitiesFromURL(url, source)
-			if err != nil {
-				errorMsg := fmt.Sprintf("Failed to extract from %s (%s): %v", source.Name, url, err)
-				log.Printf("ERROR: %s", errorMsg)
-				errors = append(errors, errorMsg)
-				continue
-			}
+		targetURLs := source.TargetURLs
+		if len(targetURLs) == 0 {
+			errors = append(errors, fmt.Sprintf("Source %s has no target URLs", source.Name))
+			continue
+		}
 
-			log.Printf("Extracted %d activities from %s", len(activities), url)
-			allActivities = append(allActivities, activities...)
+		limits := sourceRunLimits(ctx, source.ID)
+		for i, targetURL := range targetURLs {
+			tasks = append(tasks, models.ScrapeTaskMessage{
+				RunID:      runID,
+				TaskID:     fmt.Sprintf("%s-%d", source.ID, i),
+				SourceID:   source.ID,
+				SourceName: source.Name,
+				BaseURL:    source.BaseURL,
+				Category:   source.Category,
+				URL:        targetURL,
+				Priority:   source.Priority,
+
+				QueuePriority: queuePriority,
+				Limits:        limits,
+				Force:         event.Force,
+
+				ScrapingTaskID: event.TaskID,
+			})
 		}
 
 		processedSources++
 	}
 
-	log.Printf("Total activities extracted: %d", len(allActivities))
+	triggerType := event.TriggerType
+	if triggerType == "" {
+		triggerType = "scheduled"
+	}
+
+	// The run record must exist before any executor reports a result
+	run := &models.FanOutRun{
+		RunID:        runID,
+		TriggerType:  triggerType,
+		SourceID:     event.SourceID,
+		TotalSources: processedSources,
+		TotalTasks:   len(tasks),
+		StartedAt:    start,
+		Priority:     queuePriority,
+
+		ScrapingTaskID: event.TaskID,
+	}
+	if len(tasks) == 0 {
+		// No executor will ever report on an empty run, so it is finished as soon as it is created
+		run.Status = models.RunStatusCompleted
+		run.CompletedAt = &start
+	}
+	if err := dynamoService.CreateFanOutRun(ctx, run); err != nil {
+		errorMsg := fmt.Sprintf("Failed to create run record: %v", err)
+		log.Printf("ERROR: %s", errorMsg)
+		return errorResponse(errorMsg), nil
+	}
+
+	// Link the scraping task to its run; executors keep its heartbeat going from here.
+	// An empty run has no executors, so its task is done now rather than left for the watchdog.
+	if event.TaskID != "" && len(tasks) == 0 {
+		if _, _, err := dynamoService.TransitionScrapingTask(ctx, event.TaskID, models.TaskStatusCompleted); err != nil {
+			log.Printf("Warning: Failed to mark scraping task %s completed: %v", event.TaskID, err)
+		}
+	} else if event.TaskID != "" {
+		if err := dynamoService.RecordScrapingTaskHeartbeat(ctx, event.TaskID, runID, ""); err != nil {
+			log.Printf("Warning: Failed to record heartbeat for scraping task %s: %v", event.TaskID, err)
+		}
+	}
 
-	// Note: Activities are now stored directly via admin API flow
-	// The orchestrator extracts activities and they go through the admin approval process
-	// No direct storage needed here - activities will be approved and served via database API
-	if len(allActivities) > 0 {
-		log.Printf("Extracted %d activities - these will be available via admin interface for review", len(allActivities))
+	if sent, err := sqsClient.SendMessages(ctx, queueURL, tasks); err != nil {
+		errorMsg := fmt.Sprintf("Failed to enqueue scrape tasks for run %s: queued %d of %d: %v", runID, sent, len(tasks), err)
+		log.Printf("ERROR: %s", errorMsg)
+		shrinkRun(ctx, run, sent, err)
+		return errorResponse(errorMsg), nil
 	}
 
+	log.Printf("Run %s: queued %d %s-priority tasks for %d sources", runID, len(tasks), queuePriority, processedSources)
+
 	processingTime := time.Since(start).Milliseconds()
 
 	// Create response
 	success := len(errors) == 0
-	message := "Scraping completed successfully"
+	message := fmt.Sprintf("Queued %d scrape tasks", len(tasks))
 	if !success {
-		message = fmt.Sprintf("Scraping completed with %d errors", len(errors))
+		message = fmt.Sprintf("Queued %d scrape tasks with %d errors", len(tasks), len(errors))
 	}
 
 	responseBody := ResponseBody{
-		Success:         success,
-		Message:         message,
-		TotalSources:    len(sources),
+		Success:          success,
+		Message:          message,
+		RunID:            runID,
+		TotalSources:     len(sources),
 		ProcessedSources: processedSources,
-		TotalActivities: len(allActivities),
-		ProcessingTime:  processingTime,
-		Errors:          errors,
+		QueuedTasks:      len(tasks),
+		ProcessingTime:   processingTime,
+		Errors:           errors,
 	}
 
 	bodyJSON, err := json.Marshal(responseBody)
 	if err != nil {
 		return ScrapingOrchestratorResponse{
 			StatusCode: 500,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: `{"success": false, "message": "Failed to marshal response"}`,
+			Body:       `{"success": false, "message": "Failed to marshal response"}`,
 		}, err
 	}
 
-	statusCode := 200
+	statusCode := 202 // Accepted: results are reported on the run record
 	if !success {
 		statusCode = 207 // Multi-status (partial success)
 	}
 
 	return ScrapingOrchestratorResponse{
 		StatusCode: statusCode,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(bodyJSON),
+		Body:       string(bodyJSON),
 	}, nil
 }
 
+// taskQueueFor returns the queue priority and queue URL for a run's trigger type.
+// Manual triggers go to the high-priority queue so they are not stuck behind scheduled work.
+func taskQueueFor(triggerType string) (string, string) {
+	if triggerType == "manual" && highPriorityQueueURL != "" {
+		return models.QueuePriorityHigh, highPriorityQueueURL
+	}
+	return models.QueuePriorityNormal, taskQueueURL
+}
+
+// shrinkRun takes the tasks that could not be queued off the run, so it finishes once the queued
+// tasks have reported instead of staying running. Executors finalize a run when its last task
+// reports; when no task was queued, or every queued task reported before the total came down,
+// none will, so the run is finalized here.
+func shrinkRun(ctx context.Context, run *models.FanOutRun, sent int, enqueueErr error) {
+	reason := fmt.Sprintf("enqueue: %d of %d tasks were not queued: %v", run.TotalTasks-sent, run.TotalTasks, enqueueErr)
+	shrunk, err := dynamoService.ShrinkFanOutRun(ctx, run.RunID, sent, reason)
+	if err != nil {
+		log.Printf("ERROR: Failed to take unqueued tasks off run %s: %v", run.RunID, err)
+		return
+	}
+	if !shrunk.IsFinished() {
+		return
+	}
+
+	results, err := dynamoService.GetFanOutTaskResults(ctx, run.RunID)
+	if err != nil {
+		log.Printf("ERROR: Failed to load task results of run %s: %v", run.RunID, err)
+		return
+	}
+	now := time.Now()
+	shrunk.Status = services.FinalRunStatus(shrunk)
+	shrunk.CompletedAt = &now
+	shrunk.Stats = services.SummarizeFanOutRun(shrunk, results, now)
+	finalized, err := dynamoService.FinalizeFanOutRun(ctx, shrunk)
+	if err != nil {
+		log.Printf("ERROR: Failed to finalize run %s: %v", run.RunID, err)
+		return
+	}
+	if !finalized {
+		return // an executor finalized it after the total came down
+	}
+	log.Printf("Run %s finished with status %s: %d of %d tasks were queued", run.RunID, shrunk.Status, sent, run.TotalTasks)
+
+	if shrunk.ScrapingTaskID == "" || shrunk.Status == models.RunStatusCancelled {
+		return
+	}
+	if shrunk.Status != models.RunStatusFailed {
+		if _, _, err := dynamoService.TransitionScrapingTask(ctx, shrunk.ScrapingTaskID, models.TaskStatusCompleted); err != nil {
+			log.Printf("Warning: Failed to mark scraping task %s completed: %v", shrunk.ScrapingTaskID, err)
+		}
+		return
+	}
+	scrapingTask, err := dynamoService.GetScrapingTask(ctx, shrunk.ScrapingTaskID)
+	if err != nil {
+		log.Printf("Warning: Failed to get scraping task %s: %v", shrunk.ScrapingTaskID, err)
+		return
+	}
+	failure := services.ClassifyError(enqueueErr)
+	failure.Message = reason
+	if _, _, err := dynamoService.FailScrapingTask(ctx, scrapingTask, failure); err != nil {
+		log.Printf("Warning: Failed to mark scraping task %s failed: %v", shrunk.ScrapingTaskID, err)
+	}
+}
+
+// errorResponse builds a failed orchestrator response
+func errorResponse(message string) ScrapingOrchestratorResponse {
+	body, _ := json.Marshal(ResponseBody{Success: false, Message: message})
+	return ScrapingOrchestratorResponse{
+		StatusCode: 500,
+		Body:       string(body),
+	}
+}
+
 // getActiveSources retrieves active sources from DynamoDB, optionally filtered by source ID
 func getActiveSources(ctx context.Context, sourceID string) ([]Source, error) {
 	if sourceID != "" {
@@ -235,6 +376,20 @@ func getActiveSources(ctx context.Context, sourceID string) ([]Source, error) {
 	return sources, nil
 }
 
+// sourceRunLimits returns the run limits configured for a source, or nil when it has none.
+// Sources without a production config, such as ones activated before configs existed, run unlimited.
+func sourceRunLimits(ctx context.Context, sourceID string) *models.SourceRunLimits {
+	config, err := dynamoService.GetSourceConfig(ctx, sourceID)
+	if err != nil {
+		log.Printf("Warning: No run limits loaded for source %s: %v", sourceID, err)
+		return nil
+	}
+	if !config.RunLimits.Enabled() {
+		return nil
+	}
+	return &config.RunLimits
+}
+
 // convertSourceSubmissionToSource converts a DynamoDB SourceSubmission to the Source format used by orchestrator
 func convertSourceSubmissionToSource(submission *models.SourceSubmission) Source {
 	return Source{
@@ -255,6 +410,8 @@ func determineCategory(expectedContent []string) string {
 	}
 	// Use first expected content type as category
 	switch expectedContent[0] {
+	case "camps":
+		return "camps"
 	case "classes":
 		return "classes"
 	case "activities":
@@ -266,64 +423,6 @@ func determineCategory(expectedContent []string) string {
 	}
 }
 
-func extractActivitiesFromURL(url string, source Source) ([]models.Activity, error) {
-	// Use FireCrawl Extract API to get structured data
-	response, err := firecrawlClient.ExtractActivities(url)
-	if err != nil {
-		return nil, fmt.Errorf("FireCrawl extraction failed: %w", err)
-	}
-
-	if response == nil || len(response.Data.Activities) == 0 {
-		log.Printf("No activities extracted from %s", url)
-		return []models.Activity{}, nil
-	}
-
-	// Add source metadata to each activity
-	now := time.Now()
-	for i := range response.Data.Activities {
-		response.Data.Activities[i].Source = models.Source{
-			URL:         url,
-			Domain:      extractDomain(url),
-			ScrapedAt:   now,
-			LastChecked: now,
-			Reliability: "medium",
-		}
-		response.Data.Activities[i].UpdatedAt = now
-		if response.Data.Activities[i].CreatedAt.IsZero() {
-			response.Data.Activities[i].CreatedAt = now
-		}
-
-		// Associate with source via Provider field
-		response.Data.Activities[i].Provider = models.Provider{
-			Name:    source.Name,
-			Type:    "community-calendar",
-			Website: source.BaseURL,
-		}
-
-		// Generate ID if not provided
-		if response.Data.Activities[i].ID == "" {
-			response.Data.Activities[i].ID = models.GenerateActivityID(
-				response.Data.Activities[i].Title,
-				response.Data.Activities[i].Schedule.StartDate,
-				response.Data.Activities[i].Location.Name,
-			)
-		}
-	}
-
-	return response.Data.Activities, nil
-}
-
-// Note: S3 storage function removed - activities now flow through admin API for approval
-
-func extractDomain(urlStr string) string {
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
-		log.Printf("Warning: Failed to parse URL %s: %v", urlStr, err)
-		return urlStr
-	}
-	return parsedURL.Host
-}
-
 // ensureSourceInDatabase saves the source to DynamoDB if it doesn't already exist
 func ensureSourceInDatabase(source Source) error {
 	ctx := context.Background()
@@ -351,7 +450,7 @@ func ensureSourceInDatabase(source Source) error {
 		SubmittedAt:  time.Now(),
 		UpdatedAt:    time.Now(),
 		Status:       "active", // Auto-approve system sources
-		StatusKey:    "STATUS#active",
+		StatusKey:    models.GenerateSourceStatusKey("active", source.ID),
 		PriorityKey:  fmt.Sprintf("PRIORITY#%s#%s", source.Priority, source.ID),
 	}
 
@@ -359,6 +458,15 @@ func ensureSourceInDatabase(source Source) error {
 	return dynamoService.CreateSourceSubmission(ctx, &sourceRecord)
 }
 
+// withResponseHeaders adds the security and content type headers shared by API responses
+func withResponseHeaders(next func(context.Context, ScrapingOrchestratorEvent) (ScrapingOrchestratorResponse, error)) func(context.Context, ScrapingOrchestratorEvent) (ScrapingOrchestratorResponse, error) {
+	return func(ctx context.Context, event ScrapingOrchestratorEvent) (ScrapingOrchestratorResponse, error) {
+		response, err := next(ctx, event)
+		response.Headers = apiHeaders.Apply(nil, response.Headers)
+		return response, err
+	}
+}
+
 func main() {
-	lambda.Start(handleRequest)
+	lambda.Start(withResponseHeaders(handleRequest))
 }
\ No newline at end of file
@@ -0,0 +1,91 @@
+// Command seed fills the four tables with generated fixture data for testing list endpoints and
+// analytics outside production: sources in every status, their scraping tasks and executions,
+// extracted events at each confidence level, and the published activities of approved events.
+// The data is deterministic, so a seed and date always generate the same items and writing them
+// again overwrites them.
+//
+// Usage:
+//
+//	FAMILY_ACTIVITIES_TABLE=seattle-family-activities-staging \
+//	SOURCE_MANAGEMENT_TABLE=seattle-source-management-staging \
+//	SCRAPING_OPERATIONS_TABLE=seattle-scraping-operations-staging \
+//	ADMIN_EVENTS_TABLE=seattle-admin-events-staging go run ./cmd/seed -sources 40 -events 15 -apply
+//
+// Without -apply the fixtures are only counted. Every generated ID starts with "seed-".
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+func main() {
+	sources := flag.Int("sources", 20, "sources to generate, spread over every source status")
+	events := flag.Int("events", 10, "extracted events of each source that was scraped")
+	seed := flag.Int64("seed", 1, "seed of the generated data")
+	date := flag.String("date", time.Now().UTC().Format("2006-01-02"), "date (YYYY-MM-DD) the data is generated around")
+	apply := flag.Bool("apply", false, "write the fixtures; without it they are only counted")
+	flag.Parse()
+
+	if *sources < 1 || *events < 0 {
+		log.Fatal("❌ -sources must be at least 1 and -events at least 0")
+	}
+	day, err := time.Parse("2006-01-02", *date)
+	if err != nil {
+		log.Fatalf("❌ Invalid -date %q: must be YYYY-MM-DD", *date)
+	}
+	now := day.Add(12 * time.Hour)
+
+	familyActivitiesTable := os.Getenv("FAMILY_ACTIVITIES_TABLE")
+	sourceManagementTable := os.Getenv("SOURCE_MANAGEMENT_TABLE")
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	adminEventsTable := os.Getenv("ADMIN_EVENTS_TABLE")
+	if familyActivitiesTable == "" || sourceManagementTable == "" || scrapingOperationsTable == "" || adminEventsTable == "" {
+		log.Fatal("❌ Required environment variables not set: FAMILY_ACTIVITIES_TABLE, SOURCE_MANAGEMENT_TABLE, SCRAPING_OPERATIONS_TABLE, ADMIN_EVENTS_TABLE")
+	}
+
+	options := services.FixtureOptions{Sources: *sources, EventsPerSource: *events, Seed: *seed}
+	fixtures := services.GenerateFixtures(options, services.NewSchemaConversionService(), now)
+
+	approved := 0
+	for _, adminEvent := range fixtures.AdminEvents {
+		if adminEvent.Status == models.AdminEventStatusApproved {
+			approved++
+		}
+	}
+	log.Printf("Fixtures around %s (seed %d): %d sources (%d analyzed, %d activated), %d tasks, %d executions, %d events (%d approved), %d activities",
+		*date, *seed, len(fixtures.Submissions), len(fixtures.Analyses), len(fixtures.Configs),
+		len(fixtures.Tasks), len(fixtures.Executions), len(fixtures.AdminEvents), approved, len(fixtures.Activities))
+
+	if !*apply {
+		log.Printf("Dry run: nothing was written. Run with -apply to write the fixtures.")
+		return
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		familyActivitiesTable,
+		sourceManagementTable,
+		scrapingOperationsTable,
+		adminEventsTable,
+	)
+
+	if err := services.WriteFixtures(ctx, dynamoService, fixtures); err != nil {
+		log.Fatalf("❌ Failed to write fixtures: %v", err)
+	}
+	log.Printf("✅ Fixtures written")
+}
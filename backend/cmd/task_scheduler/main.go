@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	lambdaclient "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/google/uuid"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// SchedulerSummary is returned by each scheduled run
+type SchedulerSummary struct {
+	DueTasks    int      `json:"due_tasks"`
+	Queued      int      `json:"queued"`
+	Rescheduled int      `json:"rescheduled"` // next runs created for recurring tasks
+	Restarted   int      `json:"restarted"`   // queued tasks whose start was lost, started again
+	Abandoned   int      `json:"abandoned"`   // queued tasks cancelled after every restart was lost
+	Cancelled   int      `json:"cancelled"`   // due tasks of sources that are no longer active
+	Errors      []string `json:"errors,omitempty"`
+}
+
+var (
+	dynamoService            *services.DynamoDBService
+	lambdaClient             *lambdaclient.Client
+	orchestratorFunctionName string
+)
+
+const (
+	// schedulerBatchSize caps the due tasks started by one run; the rest wait for the next run
+	schedulerBatchSize = 100
+
+	// queuedTaskGracePeriod is how long a queued task may wait for the orchestrator before its
+	// start is considered lost. Async invocations can sit in the Lambda event queue for a while.
+	// Each restart doubles the wait before the next one.
+	queuedTaskGracePeriod = 15 * time.Minute
+
+	// maxQueuedTaskRestarts caps how often a queued task is started again before it is cancelled
+	maxQueuedTaskRestarts = 3
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	sourceManagementTable := os.Getenv("SOURCE_MANAGEMENT_TABLE")
+	orchestratorFunctionName = os.Getenv("ORCHESTRATOR_FUNCTION_NAME")
+	if scrapingOperationsTable == "" || sourceManagementTable == "" || orchestratorFunctionName == "" {
+		log.Fatal("Required environment variables not set: SCRAPING_OPERATIONS_TABLE, SOURCE_MANAGEMENT_TABLE, ORCHESTRATOR_FUNCTION_NAME")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		sourceManagementTable,
+		scrapingOperationsTable,
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+	lambdaClient = lambdaclient.NewFromConfig(cfg)
+}
+
+// handleRequest queues the scheduled scraping tasks whose run time has passed, starts them through
+// the orchestrator, which sends their URLs to the task executor queue, and schedules the next run
+// of recurring tasks from their source's scraping frequency. Queued tasks whose start was lost are
+// started again. Retrying tasks are left to the watchdog.
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (SchedulerSummary, error) {
+	summary := SchedulerSummary{}
+	now := time.Now()
+
+	dueTasks, err := dynamoService.QueryNextScrapingTasks(ctx, now, models.TaskStatusScheduled, schedulerBatchSize)
+	if err != nil {
+		log.Printf("Error querying due scraping tasks: %v", err)
+		return summary, err
+	}
+	for i := range dueTasks {
+		summary.DueTasks++
+		scheduleTask(ctx, &dueTasks[i], now, &summary)
+	}
+
+	queuedTasks, err := dynamoService.QueryNextScrapingTasks(ctx, now, models.TaskStatusQueued, schedulerBatchSize)
+	if err != nil {
+		log.Printf("Warning: Failed to query queued scraping tasks: %v", err)
+		summary.Errors = append(summary.Errors, err.Error())
+	}
+	for i := range queuedTasks {
+		restartTask(ctx, &queuedTasks[i], now, &summary)
+	}
+
+	log.Printf("Scheduler finished: %d due tasks, %d queued, %d rescheduled, %d restarted, %d abandoned, %d cancelled",
+		summary.DueTasks, summary.Queued, summary.Rescheduled, summary.Restarted, summary.Abandoned, summary.Cancelled)
+	return summary, nil
+}
+
+// restartTask starts a queued task again once it has waited past the grace period without the
+// orchestrator starting it. Each restart is recorded first and pushes the task's next check out
+// by a doubling backoff, so a start that keeps failing is not retried on every run; a task whose
+// restarts have all been lost is cancelled.
+func restartTask(ctx context.Context, task *models.ScrapingTask, now time.Time, summary *SchedulerSummary) {
+	if task.RestartCount == 0 && task.UpdatedAt.After(now.Add(-queuedTaskGracePeriod)) {
+		return
+	}
+
+	if task.RestartCount >= maxQueuedTaskRestarts {
+		if _, applied, err := dynamoService.TransitionScrapingTask(ctx, task.TaskID, models.TaskStatusCancelled); err != nil {
+			log.Printf("Warning: Failed to cancel scraping task %s: %v", task.TaskID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+		} else if applied {
+			log.Printf("Scraping task %s did not start after %d restarts, cancelled it", task.TaskID, task.RestartCount)
+			summary.Abandoned++
+		}
+		return
+	}
+
+	nextCheck := now.Add(queuedTaskGracePeriod << (task.RestartCount + 1))
+	applied, err := dynamoService.RecordScrapingTaskRestart(ctx, task, nextCheck)
+	if err != nil {
+		log.Printf("Warning: Failed to record restart of scraping task %s: %v", task.TaskID, err)
+		summary.Errors = append(summary.Errors, err.Error())
+		return
+	}
+	if !applied {
+		return
+	}
+
+	if err := startTask(ctx, task); err != nil {
+		log.Printf("Warning: Failed to restart queued scraping task %s: %v", task.TaskID, err)
+		summary.Errors = append(summary.Errors, err.Error())
+		return
+	}
+	log.Printf("Scraping task %s was queued without starting, started it again (restart %d of %d)", task.TaskID, task.RestartCount, maxQueuedTaskRestarts)
+	summary.Restarted++
+}
+
+// scheduleTask queues a due task, schedules its next run when it recurs and starts it. A task whose
+// source is no longer active is cancelled instead. Tasks left scheduled or queued by an error stay
+// due, so the next scheduler run tries again.
+func scheduleTask(ctx context.Context, task *models.ScrapingTask, now time.Time, summary *SchedulerSummary) {
+	submission, err := dynamoService.GetSourceSubmission(ctx, task.SourceID)
+	if err != nil {
+		log.Printf("Warning: Failed to get source %s of scraping task %s: %v", task.SourceID, task.TaskID, err)
+		summary.Errors = append(summary.Errors, err.Error())
+		return
+	}
+	if submission.Status != models.SourceStatusActive {
+		if _, applied, err := dynamoService.TransitionScrapingTask(ctx, task.TaskID, models.TaskStatusCancelled); err != nil {
+			log.Printf("Warning: Failed to cancel scraping task %s: %v", task.TaskID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+		} else if applied {
+			log.Printf("Source %s is %s, cancelled scraping task %s", task.SourceID, submission.Status, task.TaskID)
+			summary.Cancelled++
+		}
+		return
+	}
+
+	current, applied, err := dynamoService.TransitionScrapingTask(ctx, task.TaskID, models.TaskStatusQueued)
+	if err != nil {
+		log.Printf("Warning: Failed to queue scraping task %s: %v", task.TaskID, err)
+		summary.Errors = append(summary.Errors, err.Error())
+		return
+	}
+	if !applied {
+		log.Printf("Scraping task %s is %s, not queuing it", task.TaskID, current.Status)
+		return
+	}
+	summary.Queued++
+
+	if task.Recurring() {
+		next, err := scheduleNextRun(ctx, task, now)
+		if err != nil {
+			log.Printf("Warning: Failed to schedule the next run of scraping task %s: %v", task.TaskID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+		} else if next != nil {
+			log.Printf("Scheduled the next run of source %s for %s as task %s", task.SourceID, next.ScheduledTime.UTC().Format(time.RFC3339), next.TaskID)
+			summary.Rescheduled++
+		}
+	}
+
+	if err := startTask(ctx, task); err != nil {
+		log.Printf("Warning: Failed to start scraping task %s: %v", task.TaskID, err)
+		summary.Errors = append(summary.Errors, err.Error())
+		return
+	}
+	log.Printf("Scraping task %s for source %s queued (scheduled for %s)", task.TaskID, task.SourceID, task.ScheduledTime.UTC().Format(time.RFC3339))
+}
+
+// scheduleNextRun creates the next run of a recurring task from its source's production config. It
+// returns nil when the source's scraping frequency does not recur.
+func scheduleNextRun(ctx context.Context, task *models.ScrapingTask, now time.Time) (*models.ScrapingTask, error) {
+	sourceConfig, err := dynamoService.GetSourceConfig(ctx, task.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config of source %s: %w", task.SourceID, err)
+	}
+	scheduledTime, recurs := models.NextScheduledRun(sourceConfig.ScrapingConfig.Frequency, task.ScheduledTime, now)
+	if !recurs {
+		log.Printf("Source %s has scraping frequency %q, which does not recur", task.SourceID, sourceConfig.ScrapingConfig.Frequency)
+		return nil, nil
+	}
+
+	priority := sourceConfig.ScrapingConfig.Priority
+	if priority == "" {
+		priority = task.Priority
+	}
+	targetURLs := sourceConfig.TargetURLs
+	if len(targetURLs) == 0 {
+		targetURLs = task.TargetURLs
+	}
+	timeout := sourceConfig.ScrapingConfig.Timeout
+	if timeout <= 0 {
+		timeout = task.Timeout
+	}
+	maxRetries := sourceConfig.ScrapingConfig.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = task.MaxRetries
+	}
+
+	taskID := uuid.New().String()
+	next := &models.ScrapingTask{
+		PK:                models.CreateTaskPK(taskID),
+		SK:                models.CreateTaskSK(priority, task.SourceID, taskID),
+		TaskID:            taskID,
+		SourceID:          task.SourceID,
+		TaskType:          task.TaskType,
+		Priority:          priority,
+		TriggerType:       models.TriggerTypeScheduled,
+		ScheduledTime:     scheduledTime,
+		TargetURLs:        targetURLs,
+		ExtractionRules:   sourceConfig.ContentSelectors,
+		RateLimits:        sourceConfig.ScrapingConfig.RateLimit,
+		Timeout:           timeout,
+		MaxRetries:        maxRetries,
+		Status:            models.TaskStatusScheduled,
+		EstimatedDuration: task.EstimatedDuration,
+		Dependencies:      []string{},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		TTL:               models.CalculateTaskTTL(scheduledTime, 90), // 90 days retention after it runs
+		NextRunShard:      models.GenerateNextRunShardKey(taskID),
+		NextRunKey:        models.GenerateNextRunKey(scheduledTime),
+		PrioritySourceKey: models.GenerateTaskPrioritySourceKey(priority, task.SourceID),
+	}
+	if err := dynamoService.CreateScrapingTask(ctx, next); err != nil {
+		return nil, fmt.Errorf("failed to create next scraping task: %w", err)
+	}
+	return next, nil
+}
+
+// startTask starts a run for a queued task through the orchestrator. The orchestrator only starts
+// tasks that are still waiting, so starting a task twice queues a single run.
+func startTask(ctx context.Context, task *models.ScrapingTask) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"trigger_type": models.TriggerTypeScheduled,
+		"source_id":    task.SourceID,
+		"task_id":      task.TaskID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal orchestrator event: %w", err)
+	}
+
+	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
+		FunctionName:   aws.String(orchestratorFunctionName),
+		InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
+		Payload:        payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invoke orchestrator: %w", err)
+	}
+	return nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	lambdaclient "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/services"
+)
+
+// WatchdogSummary is returned by each scheduled run
+type WatchdogSummary struct {
+	StaleTasks int      `json:"stale_tasks"`
+	Requeued   int      `json:"requeued"`
+	Failed     int      `json:"failed"`
+	Recovered  int      `json:"recovered"` // sent a heartbeat while being failed, so left alone
+	Retried    int      `json:"retried"`   // retrying tasks whose backoff passed, started again
+	Errors     []string `json:"errors,omitempty"`
+}
+
+var (
+	dynamoService            *services.DynamoDBService
+	notifier                 *services.SNSNotifier
+	alertTopicARN            string
+	lambdaClient             *lambdaclient.Client
+	orchestratorFunctionName string
+
+	// staleTaskTimeout must be longer than the task queue visibility timeout, so a message whose
+	// executor crashed is redelivered and gets a chance to resume the run before the task is failed
+	staleTaskTimeout = 45 * time.Minute
+)
+
+func init() {
+	// Load AWS configuration
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	scrapingOperationsTable := os.Getenv("SCRAPING_OPERATIONS_TABLE")
+	if scrapingOperationsTable == "" {
+		log.Fatal("Required environment variable not set: SCRAPING_OPERATIONS_TABLE")
+	}
+
+	dynamoService = services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		os.Getenv("FAMILY_ACTIVITIES_TABLE"),
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		scrapingOperationsTable,
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	if minutes, err := strconv.Atoi(os.Getenv("STALE_TASK_TIMEOUT_MINUTES")); err == nil && minutes > 0 {
+		staleTaskTimeout = time.Duration(minutes) * time.Minute
+	}
+
+	// Requeued tasks are started again through the orchestrator when it is configured
+	orchestratorFunctionName = os.Getenv("ORCHESTRATOR_FUNCTION_NAME")
+	if orchestratorFunctionName != "" {
+		lambdaClient = lambdaclient.NewFromConfig(cfg)
+	}
+
+	alertTopicARN = os.Getenv("ALERT_TOPIC_ARN")
+	if alertTopicARN != "" {
+		notifier = services.NewSNSNotifier(cfg)
+	}
+}
+
+// handleRequest fails the in-progress scraping tasks whose heartbeats went stale and alerts admins
+// with each task's last checkpoint, then starts the retrying tasks whose backoff has passed
+func handleRequest(ctx context.Context, event events.CloudWatchEvent) (WatchdogSummary, error) {
+	summary := WatchdogSummary{}
+	now := time.Now()
+
+	staleTasks, err := dynamoService.GetStaleScrapingTasks(ctx, now, staleTaskTimeout)
+	if err != nil {
+		log.Printf("Error getting stale scraping tasks: %v", err)
+		return summary, err
+	}
+	summary.StaleTasks = len(staleTasks)
+
+	for i := range staleTasks {
+		task := &staleTasks[i]
+		failure := services.TaskFailure{
+			Code:      models.ErrorCodeHeartbeatLost,
+			Message:   fmt.Sprintf("no heartbeat since %s", task.LastSeen().UTC().Format(time.RFC3339)),
+			Retryable: true,
+		}
+
+		status, applied, err := dynamoService.FailScrapingTask(ctx, task, failure)
+		if err != nil {
+			log.Printf("Error failing stale scraping task %s: %v", task.TaskID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+			continue
+		}
+		if !applied {
+			log.Printf("Scraping task %s changed while being failed, leaving it alone", task.TaskID)
+			summary.Recovered++
+			continue
+		}
+
+		if status == models.TaskStatusRetrying {
+			log.Printf("Scraping task %s stalled on run %s, retrying (retry %d of %d)", task.TaskID, task.RunID, task.RetryCount, task.MaxRetries)
+			summary.Requeued++
+		} else {
+			log.Printf("Scraping task %s stalled on run %s and has no retries left, failed", task.TaskID, task.RunID)
+			summary.Failed++
+		}
+
+		subject, message := services.FormatStaleTaskAlert(task, now)
+		notification := models.NewAdminNotification(models.NotificationKindScrapeFailure, subject, message, now)
+		notification.SourceID, notification.RunID = task.SourceID, task.RunID
+		if err := dynamoService.CreateAdminNotification(ctx, notification); err != nil {
+			log.Printf("Warning: Failed to record stale task %s in the admin inbox: %v", task.TaskID, err)
+		}
+		if notifier != nil {
+			if err := notifier.Publish(ctx, alertTopicARN, subject, message); err != nil {
+				log.Printf("Warning: Failed to send stale task alert for %s: %v", task.TaskID, err)
+			}
+		}
+	}
+
+	dueTasks, err := dynamoService.GetDueRetryingScrapingTasks(ctx, now)
+	if err != nil {
+		log.Printf("Error getting retrying scraping tasks: %v", err)
+		summary.Errors = append(summary.Errors, err.Error())
+	}
+	for i := range dueTasks {
+		task := &dueTasks[i]
+		if err := requeueTask(ctx, task); err != nil {
+			// The task stays retrying and due, so the next watchdog run tries again
+			log.Printf("Warning: Failed to requeue scraping task %s: %v", task.TaskID, err)
+			summary.Errors = append(summary.Errors, err.Error())
+			continue
+		}
+		log.Printf("Scraping task %s requeued after a %s error (retry %d of %d)", task.TaskID, task.ErrorCode, task.RetryCount, task.MaxRetries)
+		summary.Retried++
+	}
+
+	log.Printf("Watchdog finished: %d stale tasks, %d requeued, %d failed, %d recovered, %d retried",
+		summary.StaleTasks, summary.Requeued, summary.Failed, summary.Recovered, summary.Retried)
+	return summary, nil
+}
+
+// requeueTask starts a new run for a retrying task through the orchestrator
+func requeueTask(ctx context.Context, task *models.ScrapingTask) error {
+	if lambdaClient == nil {
+		return fmt.Errorf("ORCHESTRATOR_FUNCTION_NAME not configured")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"trigger_type": "manual",
+		"source_id":    task.SourceID,
+		"task_id":      task.TaskID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal orchestrator event: %w", err)
+	}
+
+	_, err = lambdaClient.Invoke(ctx, &lambdaclient.InvokeInput{
+		FunctionName:   aws.String(orchestratorFunctionName),
+		InvocationType: lambdatypes.InvocationTypeEvent, // Async invocation
+		Payload:        payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invoke orchestrator: %w", err)
+	}
+	return nil
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
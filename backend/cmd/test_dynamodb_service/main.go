@@ -190,7 +190,7 @@ func main() {
 	}
 
 	// Query next scraping tasks
-	nextTasks, err := dbService.QueryNextScrapingTasks(ctx, time.Now().Add(2*time.Hour), 10)
+	nextTasks, err := dbService.QueryNextScrapingTasks(ctx, time.Now().Add(2*time.Hour), models.TaskStatusScheduled, 10)
 	if err != nil {
 		log.Printf("Failed to query next scraping tasks: %v", err)
 	} else {
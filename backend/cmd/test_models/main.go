@@ -146,7 +146,7 @@ func testSourceManagementModels() {
 	}
 	
 	// Generate GSI keys
-	submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusPendingAnalysis)
+	submission.StatusKey = models.GenerateSourceStatusKey(models.SourceStatusPendingAnalysis, submission.SourceID)
 	submission.PriorityKey = models.GenerateSourcePriorityKey(models.SourcePriorityHigh, "seattle-childrens-theatre")
 	
 	// Test validation
@@ -252,6 +252,7 @@ func testScrapingOperationsModels() {
 	}
 	
 	// Generate GSI keys
+	task.NextRunShard = models.GenerateNextRunShardKey(task.TaskID)
 	task.NextRunKey = models.GenerateNextRunKey(task.ScheduledTime)
 	task.PrioritySourceKey = models.GenerateTaskPrioritySourceKey(models.TaskPriorityHigh, "seattle-childrens-theatre")
 	
@@ -0,0 +1,119 @@
+// Command venue_transit scores how well each registry venue is served by public transit, from the
+// stops of a GTFS static feed near the venue. Venues are scored once and rescored only when their
+// coordinates change, so the feed is only needed when venues are added or moved. The admin API
+// reads the stored scores for its transit_friendly filter.
+//
+// Usage:
+//
+//	FAMILY_ACTIVITIES_TABLE=seattle-family-activities go run ./cmd/venue_transit -apply
+//
+// The feed defaults to King County Metro's; -gtfs (or GTFS_FEED) takes another URL, or the path of
+// a downloaded feed or its stops.txt. Without -apply the scores are only printed.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/services"
+)
+
+const defaultGTFSFeed = "https://metro.kingcounty.gov/GTFS/google_transit.zip"
+
+func main() {
+	defaultFeed := os.Getenv("GTFS_FEED")
+	if defaultFeed == "" {
+		defaultFeed = defaultGTFSFeed
+	}
+	feed := flag.String("gtfs", defaultFeed, "URL or path of the GTFS feed, or of its stops.txt")
+	apply := flag.Bool("apply", false, "store the scores; without them they are only printed")
+	force := flag.Bool("force", false, "rescore venues that already have a score at their coordinates")
+	flag.Parse()
+
+	familyActivitiesTable := os.Getenv("FAMILY_ACTIVITIES_TABLE")
+	if familyActivitiesTable == "" {
+		log.Fatal("❌ Required environment variable not set: FAMILY_ACTIVITIES_TABLE")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load AWS config: %v", err)
+	}
+
+	dynamoService := services.NewDynamoDBService(
+		dynamodb.NewFromConfig(cfg),
+		familyActivitiesTable,
+		os.Getenv("SOURCE_MANAGEMENT_TABLE"),
+		os.Getenv("SCRAPING_OPERATIONS_TABLE"),
+		os.Getenv("ADMIN_EVENTS_TABLE"),
+	)
+
+	venues, err := dynamoService.GetVenues(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to load the venue registry: %v", err)
+	}
+
+	pending := 0
+	for i := range venues {
+		hasCoordinates := venues[i].Coordinates.Lat != 0 || venues[i].Coordinates.Lng != 0
+		if hasCoordinates && (*force || services.NeedsTransitScore(&venues[i])) {
+			pending++
+		}
+	}
+	if pending == 0 {
+		log.Printf("None of the %d venues need scoring", len(venues))
+		return
+	}
+
+	stops, err := services.LoadGTFSStops(ctx, *feed)
+	if err != nil {
+		log.Fatalf("❌ Failed to load GTFS stops: %v", err)
+	}
+	log.Printf("Loaded %d stops from %s", len(stops), *feed)
+	index := services.NewTransitStopIndex(stops)
+
+	scored, failed, skipped := 0, 0, 0
+	now := time.Now()
+	for i := range venues {
+		venue := &venues[i]
+		if venue.Coordinates.Lat == 0 && venue.Coordinates.Lng == 0 {
+			log.Printf("Warning: Venue %s (%s) has no coordinates, skipping", venue.EntityID, venue.VenueName)
+			skipped++
+			continue
+		}
+		if !*force && !services.NeedsTransitScore(venue) {
+			continue
+		}
+
+		transit := index.ScoreVenueTransit(venue.Coordinates, *feed, now)
+		log.Printf("Venue %s (%s): score %d, %d stops within 400 m, %d within 800 m, nearest %q at %d m",
+			venue.EntityID, venue.VenueName, transit.Score, transit.StopsWithin400m, transit.StopsWithin800m,
+			transit.NearestStopName, transit.NearestStopMeters)
+		if !*apply {
+			scored++
+			continue
+		}
+		if err := dynamoService.SaveVenueTransit(ctx, venue.EntityID, transit); err != nil {
+			log.Printf("Error saving transit score for venue %s: %v", venue.EntityID, err)
+			failed++
+			continue
+		}
+		scored++
+	}
+
+	if !*apply {
+		log.Printf("Dry run: %d venues would be scored, %d have no coordinates. Run with -apply to store the scores.", scored, skipped)
+		return
+	}
+	log.Printf("Venue transit scoring complete: %d scored, %d failed, %d without coordinates", scored, failed, skipped)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
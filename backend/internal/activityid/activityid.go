@@ -0,0 +1,66 @@
+// Package activityid generates the IDs activities are published under.
+//
+// Every activity ID in the system should come from New, so the same event extracted twice, by
+// the scrape pipeline or an admin crawl, gets the same ID. IDs generated before this scheme
+// (random UUIDs, timestamped fallback IDs and 8-character hashes) are legacy and are moved to
+// the current scheme by the activity ID backfill, which leaves a redirect behind.
+package activityid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// Prefix starts every activity ID
+const Prefix = "act_"
+
+// current matches IDs from New, including the numbered repeats an Assigner hands out
+var current = regexp.MustCompile(`^act_[0-9a-f]{16}(-[0-9]+)?$`)
+
+// New returns the ID of an activity from the domain of the page it was found on, its title, its
+// start date and its location name. Case and surrounding whitespace are ignored, and the title's
+// inner whitespace is collapsed, so cosmetic differences between extractions keep the same ID.
+func New(sourceURL, title, startDate, location string) string {
+	input := strings.Join([]string{
+		urlutil.Domain(sourceURL),
+		normalize(title),
+		normalize(startDate),
+		normalize(location),
+	}, "|")
+	hash := sha256.Sum256([]byte(input))
+	return Prefix + hex.EncodeToString(hash[:])[:16]
+}
+
+// IsLegacy reports whether an ID was generated before the current scheme
+func IsLegacy(id string) bool {
+	return !current.MatchString(id)
+}
+
+// Assigner hands out the IDs of the activities extracted from one page. A page can list the same
+// title at the same time and place more than once, e.g. fallback extractions without dates, so
+// repeats are numbered in page order and each activity still gets its own ID.
+type Assigner struct {
+	seen map[string]int
+}
+
+// Next returns the ID of the next activity on the page
+func (a *Assigner) Next(sourceURL, title, startDate, location string) string {
+	if a.seen == nil {
+		a.seen = make(map[string]int)
+	}
+	id := New(sourceURL, title, startDate, location)
+	a.seen[id]++
+	if n := a.seen[id]; n > 1 {
+		return fmt.Sprintf("%s-%d", id, n)
+	}
+	return id
+}
+
+func normalize(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
@@ -0,0 +1,59 @@
+package activityid
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	id := New("https://www.parks.seattle.gov/events", "Toddler Story Time", "2026-10-05", "Ballard Library")
+	if IsLegacy(id) {
+		t.Fatalf("Expected %s to follow the current scheme", id)
+	}
+
+	same := New("http://parks.seattle.gov/other-page", "  toddler   story time ", "2026-10-05", "BALLARD LIBRARY")
+	if same != id {
+		t.Errorf("Expected cosmetic differences to keep the ID, got %s and %s", id, same)
+	}
+
+	for _, other := range []string{
+		New("https://parks.seattle.gov", "Toddler Story Time", "2026-10-06", "Ballard Library"),
+		New("https://parks.seattle.gov", "Toddler Story Time", "2026-10-05", "Fremont Library"),
+		New("https://spl.org", "Toddler Story Time", "2026-10-05", "Ballard Library"),
+	} {
+		if other == id {
+			t.Errorf("Expected a different activity to get a different ID than %s", id)
+		}
+	}
+}
+
+func TestIsLegacy(t *testing.T) {
+	tests := []struct {
+		id     string
+		legacy bool
+	}{
+		{"act_0123456789abcdef", false},
+		{"act_0123456789abcdef-2", false},
+		{"act_0123abcd", true},
+		{"6f1c2b7e-1a8d-4c5e-9f0a-3b2c1d4e5f60", true},
+		{"heuristic-1760000000", true},
+		{"parentmap-event-1-1760000000", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if got := IsLegacy(tt.id); got != tt.legacy {
+			t.Errorf("IsLegacy(%q) = %v, expected %v", tt.id, got, tt.legacy)
+		}
+	}
+}
+
+func TestAssigner(t *testing.T) {
+	var assigner Assigner
+	first := assigner.Next("https://example.com", "Event", "", "")
+	second := assigner.Next("https://example.com", "Event", "", "")
+	other := assigner.Next("https://example.com", "Other event", "", "")
+
+	if first != New("https://example.com", "Event", "", "") || second != first+"-2" {
+		t.Errorf("Expected repeats to be numbered, got %s and %s", first, second)
+	}
+	if other != New("https://example.com", "Other event", "", "") {
+		t.Errorf("Expected a distinct activity to keep its plain ID, got %s", other)
+	}
+}
@@ -0,0 +1,53 @@
+// Package adminauth verifies the admin API keys sent to the admin API and the progress socket
+package adminauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/apiheaders"
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ErrUnauthorized is returned for a missing, malformed or unknown key, or a wrong secret
+var ErrUnauthorized = errors.New("a valid admin API key is required")
+
+// ErrRevoked is returned for a key that has been revoked
+var ErrRevoked = errors.New("admin API key has been revoked")
+
+// KeyStore looks up admin API keys by ID, returning nil without an error for an unknown ID
+type KeyStore interface {
+	GetAdminAPIKey(ctx context.Context, keyID string) (*models.AdminAPIKey, error)
+}
+
+// RequestKey returns the admin API key sent in the X-Api-Key header, or as a bearer token in the
+// Authorization header
+func RequestKey(headers map[string]string) string {
+	if key := strings.TrimSpace(apiheaders.Header(headers, "X-Api-Key")); key != "" {
+		return key
+	}
+	return apiheaders.BearerToken(headers)
+}
+
+// Authenticate resolves an admin API key and checks its secret. It returns ErrUnauthorized or
+// ErrRevoked when the key is not accepted, and any other error when the key could not be looked up.
+func Authenticate(ctx context.Context, store KeyStore, key string) (*models.AdminAPIKey, error) {
+	keyID, secret, err := models.ParseAdminAPIKey(key)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	apiKey, err := store.GetAdminAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin API key %s: %w", keyID, err)
+	}
+	if apiKey == nil || !apiKey.CheckSecret(secret) {
+		return nil, ErrUnauthorized
+	}
+	if !apiKey.IsActive() {
+		return nil, ErrRevoked
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,74 @@
+package adminauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// keyStore serves admin API keys from memory
+type keyStore map[string]*models.AdminAPIKey
+
+func (s keyStore) GetAdminAPIKey(ctx context.Context, keyID string) (*models.AdminAPIKey, error) {
+	if keyID == "broken" {
+		return nil, errors.New("table unavailable")
+	}
+	return s[keyID], nil
+}
+
+func TestRequestKey(t *testing.T) {
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"APIKeyHeader", map[string]string{"x-api-key": " adm.k.s "}, "adm.k.s"},
+		{"BearerToken", map[string]string{"authorization": "Bearer adm.k.s"}, "adm.k.s"},
+		{"APIKeyHeaderFirst", map[string]string{"X-Api-Key": "adm.a.s", "Authorization": "Bearer adm.b.s"}, "adm.a.s"},
+		{"None", map[string]string{"Accept": "application/json"}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RequestKey(tc.headers); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	keyID, key, hash, err := models.GenerateAdminAPIKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	revokedID, revokedKey, revokedHash, _ := models.GenerateAdminAPIKey()
+	store := keyStore{
+		keyID:     {KeyID: keyID, KeyHash: hash, Status: models.AdminAPIKeyStatusActive},
+		revokedID: {KeyID: revokedID, KeyHash: revokedHash, Status: models.AdminAPIKeyStatusRevoked},
+	}
+	ctx := context.Background()
+
+	apiKey, err := Authenticate(ctx, store, key)
+	if err != nil || apiKey == nil || apiKey.KeyID != keyID {
+		t.Fatalf("Expected key %s to be accepted, got %+v, %v", keyID, apiKey, err)
+	}
+
+	for name, candidate := range map[string]string{
+		"Missing":     "",
+		"Malformed":   "not-a-key",
+		"Unknown":     "adm.ffffffff.secret",
+		"WrongSecret": "adm." + keyID + ".wrong",
+	} {
+		if _, err := Authenticate(ctx, store, candidate); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+	if _, err := Authenticate(ctx, store, revokedKey); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Expected ErrRevoked for a revoked key, got %v", err)
+	}
+	if _, err := Authenticate(ctx, store, "adm.broken.secret"); err == nil || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected a lookup error, got %v", err)
+	}
+}
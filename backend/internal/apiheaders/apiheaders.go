@@ -0,0 +1,161 @@
+// Package apiheaders sets the headers every API-facing Lambda response carries: CORS for the
+// allowed origins, security headers, and a JSON content type unless the handler chose another.
+//
+// Handlers set only the headers particular to a response, such as the content type of a feed or
+// Cache-Control, and Apply fills in the rest. Preflight answers CORS preflight requests.
+package apiheaders
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnyOrigin allows requests from every origin
+const AnyOrigin = "*"
+
+// DefaultAllowedMethods and DefaultAllowedHeaders are what browsers may send cross-origin. The
+// headers cover API keys and AWS signatures, as well as the cache controls the admin UI sends.
+var (
+	DefaultAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	DefaultAllowedHeaders = []string{"Content-Type", "X-Amz-Date", "Authorization", "X-Api-Key", "X-Amz-Security-Token", "Cache-Control", "Accept"}
+)
+
+// DefaultPreflightMaxAge is how long browsers may reuse a preflight response
+const DefaultPreflightMaxAge = 10 * time.Minute
+
+// SecurityHeaders are sent with every response. API responses are data rather than pages, so
+// browsers may not frame them, run anything they contain, or guess another content type.
+var SecurityHeaders = map[string]string{
+	"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+	"X-Content-Type-Options":    "nosniff",
+	"X-Frame-Options":           "DENY",
+	"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+	"Referrer-Policy":           "no-referrer",
+}
+
+// Config decides the headers of API responses
+type Config struct {
+	AllowedOrigins  []string // exact origins like https://example.com, or AnyOrigin
+	AllowedMethods  []string
+	AllowedHeaders  []string
+	PreflightMaxAge time.Duration
+}
+
+// FromEnv reads the allowed origins from CORS_ALLOWED_ORIGINS, a comma-separated list. Every
+// origin is allowed when it is unset, as before origins could be configured.
+func FromEnv() *Config {
+	return New(strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ","))
+}
+
+// New returns a config allowing origins, with the default methods, headers and preflight max age.
+// Blank origins and trailing slashes are dropped; no origins at all allows every origin.
+func New(origins []string) *Config {
+	config := &Config{
+		AllowedMethods:  DefaultAllowedMethods,
+		AllowedHeaders:  DefaultAllowedHeaders,
+		PreflightMaxAge: DefaultPreflightMaxAge,
+	}
+	for _, origin := range origins {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			config.AllowedOrigins = append(config.AllowedOrigins, origin)
+		}
+	}
+	if len(config.AllowedOrigins) == 0 {
+		config.AllowedOrigins = []string{AnyOrigin}
+	}
+	return config
+}
+
+// allowsAnyOrigin reports whether every origin is allowed
+func (c *Config) allowsAnyOrigin() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == AnyOrigin {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedOrigin returns the Access-Control-Allow-Origin value for a request from origin, or ""
+// when the origin is not allowed
+func (c *Config) AllowedOrigin(origin string) string {
+	if c.allowsAnyOrigin() {
+		return AnyOrigin
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if origin != "" && strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// Apply adds the CORS, security and content type headers to a response's headers, keeping those
+// the handler set. requestHeaders are the request's headers, read for its Origin. It returns
+// headers, or a new map when headers is nil.
+func (c *Config) Apply(requestHeaders, headers map[string]string) map[string]string {
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	for name, value := range SecurityHeaders {
+		setDefault(headers, name, value)
+	}
+	setDefault(headers, "Content-Type", "application/json")
+
+	if origin := c.AllowedOrigin(Header(requestHeaders, "Origin")); origin != "" {
+		headers["Access-Control-Allow-Origin"] = origin
+	}
+	if !c.allowsAnyOrigin() {
+		// Responses differ by origin, so caches such as the CDN must keep one per origin
+		headers["Vary"] = "Origin"
+	}
+	return headers
+}
+
+// Preflight returns the status and headers of the response to a CORS preflight (OPTIONS) request.
+// A preflight from an origin that is not allowed is refused with 403.
+func (c *Config) Preflight(requestHeaders map[string]string) (int, map[string]string) {
+	headers := c.Apply(requestHeaders, nil)
+	if _, allowed := headers["Access-Control-Allow-Origin"]; !allowed {
+		return http.StatusForbidden, headers
+	}
+
+	headers["Access-Control-Allow-Methods"] = strings.Join(c.AllowedMethods, ",")
+	headers["Access-Control-Allow-Headers"] = strings.Join(c.AllowedHeaders, ",")
+	if c.PreflightMaxAge > 0 {
+		headers["Access-Control-Max-Age"] = strconv.Itoa(int(c.PreflightMaxAge / time.Second))
+	}
+	return http.StatusNoContent, headers
+}
+
+// Header returns the value of the named request header. API Gateway passes header names through in
+// the case the client sent them, so the name is matched case-insensitively.
+func Header(headers map[string]string, name string) string {
+	if value, ok := headers[name]; ok {
+		return value
+	}
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// BearerToken returns the token of a bearer Authorization header, or "" when there is none
+func BearerToken(headers map[string]string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(Header(headers, "Authorization")), "Bearer "))
+}
+
+// setDefault sets a header unless the handler set it already, in any case
+func setDefault(headers map[string]string, name, value string) {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return
+		}
+	}
+	headers[name] = value
+}
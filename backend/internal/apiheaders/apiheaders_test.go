@@ -0,0 +1,62 @@
+package apiheaders
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	t.Run("AnyOriginByDefault", func(t *testing.T) {
+		headers := New(nil).Apply(map[string]string{"origin": "https://example.com"}, nil)
+		if headers["Access-Control-Allow-Origin"] != "*" || headers["Vary"] != "" {
+			t.Errorf("Expected any origin without Vary, got %v", headers)
+		}
+		if headers["Content-Type"] != "application/json" || headers["X-Content-Type-Options"] != "nosniff" || headers["Strict-Transport-Security"] == "" {
+			t.Errorf("Expected JSON content type and security headers, got %v", headers)
+		}
+	})
+
+	t.Run("AllowlistEchoesAllowedOrigin", func(t *testing.T) {
+		config := New([]string{" https://seattlefamilyactivities.com/ ", "https://admin.seattlefamilyactivities.com", ""})
+
+		headers := config.Apply(map[string]string{"Origin": "https://admin.seattlefamilyactivities.com"}, nil)
+		if headers["Access-Control-Allow-Origin"] != "https://admin.seattlefamilyactivities.com" || headers["Vary"] != "Origin" {
+			t.Errorf("Expected the allowed origin to be echoed, got %v", headers)
+		}
+
+		headers = config.Apply(map[string]string{"Origin": "https://evil.example.com"}, nil)
+		if _, ok := headers["Access-Control-Allow-Origin"]; ok || headers["Vary"] != "Origin" {
+			t.Errorf("Expected no CORS header for another origin, got %v", headers)
+		}
+	})
+
+	t.Run("KeepsHandlerHeaders", func(t *testing.T) {
+		headers := New(nil).Apply(nil, map[string]string{
+			"content-type":  "text/calendar; charset=utf-8",
+			"Cache-Control": "private, no-store",
+		})
+		if headers["content-type"] != "text/calendar; charset=utf-8" || headers["Content-Type"] != "" || headers["Cache-Control"] != "private, no-store" {
+			t.Errorf("Expected the handler's headers to be kept, got %v", headers)
+		}
+	})
+}
+
+func TestPreflight(t *testing.T) {
+	config := New([]string{"https://seattlefamilyactivities.com"})
+
+	statusCode, headers := config.Preflight(map[string]string{"Origin": "https://seattlefamilyactivities.com"})
+	if statusCode != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", statusCode)
+	}
+	if headers["Access-Control-Allow-Methods"] != "GET,POST,PUT,DELETE,OPTIONS" || headers["Access-Control-Max-Age"] != "600" {
+		t.Errorf("Expected allowed methods and max age, got %v", headers)
+	}
+	if headers["Access-Control-Allow-Headers"] == "" || headers["Access-Control-Allow-Origin"] != "https://seattlefamilyactivities.com" {
+		t.Errorf("Expected allowed headers and origin, got %v", headers)
+	}
+
+	statusCode, headers = config.Preflight(map[string]string{"Origin": "https://evil.example.com"})
+	if statusCode != http.StatusForbidden || headers["Access-Control-Allow-Methods"] != "" {
+		t.Errorf("Expected another origin to be refused, got %d %v", statusCode, headers)
+	}
+}
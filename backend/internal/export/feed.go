@@ -0,0 +1,243 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Channel describes an RSS or Atom feed of newly approved activities
+type Channel struct {
+	Title       string        // e.g. "Seattle Family Activities"
+	Description string        // what the feed lists, for RSS readers
+	Link        string        // the site the activities are published on, optional
+	Domain      string        // item IDs are {activity_id}@{domain}, as in the iCalendar feed
+	TTL         time.Duration // how long readers may cache the feed, optional
+}
+
+// FeedItem is an activity in a feed, with when it was approved
+type FeedItem struct {
+	Activity   *models.Activity
+	ApprovedAt time.Time
+}
+
+// Latest returns the most recently approved items, newest first. A limit of 0 keeps them all.
+func Latest(items []FeedItem, limit int) []FeedItem {
+	latest := append([]FeedItem(nil), items...)
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[i].ApprovedAt.After(latest[j].ApprovedAt)
+	})
+	if limit > 0 && len(latest) > limit {
+		latest = latest[:limit]
+	}
+	return latest
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	TTL           int       `xml:"ttl,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	Description string   `xml:"description"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders items as an RSS 2.0 feed. Each item's description says when and where the activity
+// is held, followed by the activity's own description.
+func RSS(channel Channel, items []FeedItem, now time.Time) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.Link,
+			Description:   channel.Description,
+			Language:      "en-us",
+			LastBuildDate: now.UTC().Format(time.RFC1123Z),
+			TTL:           int(channel.TTL.Minutes()),
+		},
+	}
+	for _, item := range items {
+		activity := item.Activity
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       activity.Title,
+			Link:        eventURL(activity),
+			Description: itemSummary(activity),
+			GUID:        rssGUID{Value: activity.ID + "@" + channel.Domain},
+			PubDate:     item.ApprovedAt.UTC().Format(time.RFC1123Z),
+			Categories:  itemCategories(activity),
+		})
+	}
+	return marshalFeed(doc)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Atom renders items as an RFC 4287 Atom feed, with the same entries as RSS. An entry is updated
+// when its activity last changed, if that was after its approval.
+func Atom(channel Channel, items []FeedItem, now time.Time) ([]byte, error) {
+	feed := atomFeed{
+		Title:   channel.Title,
+		ID:      "urn:" + channel.Domain + ":approved-activities",
+		Updated: now.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: channel.Title},
+	}
+	if channel.Link != "" {
+		feed.Links = []atomLink{{Href: channel.Link, Rel: "alternate"}}
+	}
+	for _, item := range items {
+		activity := item.Activity
+		updated := item.ApprovedAt
+		if activity.UpdatedAt.After(updated) {
+			updated = activity.UpdatedAt
+		}
+		entry := atomEntry{
+			Title:     activity.Title,
+			ID:        "urn:" + channel.Domain + ":activity:" + activity.ID,
+			Published: item.ApprovedAt.UTC().Format(time.RFC3339),
+			Updated:   updated.UTC().Format(time.RFC3339),
+			Summary:   itemSummary(activity),
+		}
+		if url := eventURL(activity); url != "" {
+			entry.Links = []atomLink{{Href: url, Rel: "alternate"}}
+		}
+		for _, category := range itemCategories(activity) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return marshalFeed(feed)
+}
+
+func marshalFeed(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render feed: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// itemSummary says when and where an activity is held, then describes it
+func itemSummary(activity *models.Activity) string {
+	parts := []string{}
+	if when := describeWhen(activity.Schedule); when != "" {
+		parts = append(parts, "When: "+when)
+	}
+	if where := eventLocation(activity.Location); where != "" {
+		parts = append(parts, "Where: "+where)
+	}
+	if description := eventDescription(activity); description != "" {
+		parts = append(parts, description)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// itemCategories are the activity's category and tags
+func itemCategories(activity *models.Activity) []string {
+	var categories []string
+	for _, category := range append([]string{activity.Category}, activity.Tags...) {
+		if category != "" {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// describeWhen describes a schedule's dates, days and times, e.g.
+// "Mar 2 - Mar 31, 2026, Wednesdays and Saturdays, 4:00 PM - 5:00 PM", or "" without a start date
+func describeWhen(schedule models.Schedule) string {
+	start, err := time.Parse("2006-01-02", schedule.StartDate)
+	if err != nil {
+		return ""
+	}
+	parts := []string{start.Format("Mon, Jan 2, 2006")}
+	if end, err := time.Parse("2006-01-02", schedule.EndDate); err == nil && end.After(start) {
+		if end.Year() == start.Year() {
+			parts[0] = start.Format("Jan 2") + " - " + end.Format("Jan 2, 2006")
+		} else {
+			parts[0] = start.Format("Jan 2, 2006") + " - " + end.Format("Jan 2, 2006")
+		}
+	}
+
+	var days []string
+	for _, day := range schedule.DaysOfWeek {
+		if day = strings.TrimSpace(day); day != "" {
+			days = append(days, strings.ToUpper(day[:1])+strings.ToLower(day[1:])+"s")
+		}
+	}
+	switch len(days) {
+	case 0:
+	case 1:
+		parts = append(parts, days[0])
+	default:
+		parts = append(parts, strings.Join(days[:len(days)-1], ", ")+" and "+days[len(days)-1])
+	}
+
+	startTime, endTime := schedule.StartTime, schedule.EndTime
+	if startTime == "" && len(schedule.Times) > 0 {
+		startTime, endTime = schedule.Times[0].StartTime, schedule.Times[0].EndTime
+	}
+	if startClock, err := time.Parse("15:04", startTime); err == nil && !schedule.IsAllDay {
+		times := startClock.Format("3:04 PM")
+		if endClock, err := time.Parse("15:04", endTime); err == nil {
+			times += " - " + endClock.Format("3:04 PM")
+		}
+		parts = append(parts, times)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+var testChannel = Channel{
+	Title:       "Seattle Family Activities",
+	Description: "Newly approved family activities in Seattle",
+	Link:        "https://seattlefamilyactivities.example",
+	Domain:      "seattle-family-activities",
+	TTL:         15 * time.Minute,
+}
+
+func testFeedItems() []FeedItem {
+	return []FeedItem{
+		{
+			Activity: &models.Activity{
+				ID:        "story-time",
+				Title:     "Story Time & Songs",
+				Category:  models.CategoryFreeCommunity,
+				Tags:      []string{models.TagDropIn},
+				Schedule:  models.Schedule{StartDate: "2026-03-14", StartTime: "10:30", EndTime: "11:15"},
+				Location:  models.Location{Name: "Ballard Library", Address: "5614 22nd Ave NW", City: "Seattle"},
+				DetailURL: "https://www.spl.org/story-time",
+			},
+			ApprovedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Activity:   &models.Activity{ID: "art-camp", Title: "Art Camp", Schedule: models.Schedule{StartDate: "2026-06-22", EndDate: "2026-06-26"}},
+			ApprovedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestLatest(t *testing.T) {
+	items := testFeedItems()
+	latest := Latest(items, 1)
+	if len(latest) != 1 || latest[0].Activity.ID != "art-camp" {
+		t.Errorf("Expected the most recently approved item, got %+v", latest)
+	}
+	if items[0].Activity.ID != "story-time" {
+		t.Errorf("Expected the items not to be reordered in place")
+	}
+	if len(Latest(items, 0)) != 2 {
+		t.Errorf("Expected no limit to keep every item")
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(testChannel, testFeedItems(), testNow)
+	if err != nil {
+		t.Fatalf("Expected a feed, got %v", err)
+	}
+
+	var doc rssDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+	if doc.Version != "2.0" || doc.Channel.Title != testChannel.Title || doc.Channel.TTL != 15 {
+		t.Errorf("Unexpected channel %+v", doc.Channel)
+	}
+	if len(doc.Channel.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(doc.Channel.Items))
+	}
+
+	item := doc.Channel.Items[0]
+	if item.Title != "Story Time & Songs" || item.Link != "https://www.spl.org/story-time" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	if item.GUID.Value != "story-time@seattle-family-activities" || item.GUID.IsPermaLink {
+		t.Errorf("Expected the activity's UID as a GUID, got %+v", item.GUID)
+	}
+	if item.PubDate != "Sun, 01 Mar 2026 09:00:00 +0000" {
+		t.Errorf("Expected the approval time as the publication date, got %q", item.PubDate)
+	}
+	for _, want := range []string{"When: Sat, Mar 14, 2026, 10:30 AM - 11:15 AM", "Where: Ballard Library, 5614 22nd Ave NW, Seattle"} {
+		if !strings.Contains(item.Description, want) {
+			t.Errorf("Expected %q in %q", want, item.Description)
+		}
+	}
+	if strings.Join(item.Categories, ",") != "free-community,drop-in" {
+		t.Errorf("Expected the category and tags, got %v", item.Categories)
+	}
+	if doc.Channel.Items[1].Link != "" {
+		t.Errorf("Expected no link for an activity without one, got %q", doc.Channel.Items[1].Link)
+	}
+}
+
+func TestAtom(t *testing.T) {
+	items := testFeedItems()
+	items[1].Activity.UpdatedAt = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	body, err := Atom(testChannel, items, testNow)
+	if err != nil {
+		t.Fatalf("Expected a feed, got %v", err)
+	}
+
+	var feed atomFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+	if !strings.Contains(string(body), `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+		t.Errorf("Expected an Atom feed, got %s", body)
+	}
+	if feed.Updated != "2026-03-02T18:30:00Z" || feed.Author.Name != testChannel.Title {
+		t.Errorf("Unexpected feed %+v", feed)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(feed.Entries))
+	}
+
+	entry := feed.Entries[1]
+	if entry.ID != "urn:seattle-family-activities:activity:art-camp" {
+		t.Errorf("Unexpected entry ID %q", entry.ID)
+	}
+	if entry.Published != "2026-03-02T09:00:00Z" || entry.Updated != "2026-03-02T12:00:00Z" {
+		t.Errorf("Expected the entry to be updated after its approval, got %+v", entry)
+	}
+	if !strings.Contains(entry.Summary, "When: Jun 22 - Jun 26, 2026") {
+		t.Errorf("Expected the dates in %q", entry.Summary)
+	}
+}
+
+func TestDescribeWhen(t *testing.T) {
+	tests := []struct {
+		schedule models.Schedule
+		want     string
+	}{
+		{models.Schedule{StartDate: "2026-03-14", StartTime: "10:30"}, "Sat, Mar 14, 2026, 10:30 AM"},
+		{models.Schedule{StartDate: "2026-03-14", StartTime: "10:30", IsAllDay: true}, "Sat, Mar 14, 2026"},
+		{models.Schedule{StartDate: "2026-12-28", EndDate: "2027-01-02"}, "Dec 28, 2026 - Jan 2, 2027"},
+		{
+			models.Schedule{StartDate: "2026-03-02", EndDate: "2026-03-31", DaysOfWeek: []string{"monday", "wednesday", "saturday"}, StartTime: "16:00", EndTime: "17:00"},
+			"Mar 2 - Mar 31, 2026, Mondays, Wednesdays and Saturdays, 4:00 PM - 5:00 PM",
+		},
+		{models.Schedule{StartDate: "2026-03-14", Times: []models.TimeSlot{{StartTime: "09:30", EndTime: "10:00"}}}, "Sat, Mar 14, 2026, 9:30 AM - 10:00 AM"},
+		{models.Schedule{}, ""},
+	}
+	for _, tt := range tests {
+		if got := describeWhen(tt.schedule); got != tt.want {
+			t.Errorf("describeWhen(%+v) = %q, want %q", tt.schedule, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+// Package export renders the published catalog in formats other applications read, such as the
+// iCalendar feed families subscribe to from Google or Apple Calendar and the RSS and Atom feeds of
+// newly approved activities.
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // schedules are in IANA zones, which a Lambda runtime may not ship
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// DefaultTimezone is the zone of schedules that do not name one
+const DefaultTimezone = "America/Los_Angeles"
+
+// maxRecurrenceDays bounds how long a recurring activity repeats in the feed, as on the calendar,
+// so a season-long or open-ended program does not fill a subscriber's calendar for a year
+const maxRecurrenceDays = 120
+
+// laTimezone defines DefaultTimezone for clients that do not know it, as RFC 5545 requires of
+// every TZID a feed uses
+const laTimezone = `BEGIN:VTIMEZONE
+TZID:America/Los_Angeles
+BEGIN:DAYLIGHT
+TZOFFSETFROM:-0800
+TZOFFSETTO:-0700
+TZNAME:PDT
+DTSTART:19700308T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:-0700
+TZOFFSETTO:-0800
+TZNAME:PST
+DTSTART:19701101T020000
+RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// Feed describes an iCalendar feed
+type Feed struct {
+	Name            string        // calendar name subscribers see, e.g. "Seattle Family Activities"
+	Domain          string        // event UIDs are {activity_id}@{domain}
+	RefreshInterval time.Duration // how often subscribers are asked to fetch the feed again
+}
+
+// ICS renders activities as an RFC 5545 iCalendar feed, one event per activity. Timed activities
+// start and end at their schedule's local time; activities without a start time are all-day.
+// Recurring and multi-day activities repeat on their days of the week, or daily, until their end
+// date or for their number of sessions, and for at most 120 days. Activities without a parseable
+// start date are left out.
+func ICS(feed Feed, activities []*models.Activity, now time.Time) string {
+	var events icsWriter
+	usesDefaultZone := false
+	for _, activity := range activities {
+		usesDefaultZone = writeEvent(&events, activity, feed.Domain, now) || usesDefaultZone
+	}
+
+	var w icsWriter
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//"+feed.Domain+"//Activity Feed//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if feed.Name != "" {
+		w.text("X-WR-CALNAME", feed.Name)
+	}
+	w.line("X-WR-TIMEZONE", DefaultTimezone)
+	if feed.RefreshInterval > 0 {
+		interval := icsDuration(feed.RefreshInterval)
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", interval)
+		w.line("X-PUBLISHED-TTL", interval)
+	}
+	if usesDefaultZone {
+		for _, line := range strings.Split(laTimezone, "\n") {
+			w.b.WriteString(line + "\r\n")
+		}
+	}
+	w.b.WriteString(events.b.String())
+	w.line("END", "VCALENDAR")
+	return w.b.String()
+}
+
+// writeEvent writes an activity's VEVENT, unless its schedule has no start date, and reports
+// whether its times are in DefaultTimezone
+func writeEvent(w *icsWriter, activity *models.Activity, domain string, now time.Time) (usesDefaultZone bool) {
+	schedule := activity.Schedule
+	start, err := time.Parse("2006-01-02", schedule.StartDate)
+	if err != nil {
+		return false
+	}
+	end, hasEnd := start, false
+	if endDate, err := time.Parse("2006-01-02", schedule.EndDate); err == nil && endDate.After(start) {
+		end, hasEnd = endDate, true
+	}
+
+	// The first occurrence is the first listed day of the week, as a recurrence only repeats on those
+	byDay := icsWeekdays(schedule.DaysOfWeek)
+	if len(byDay) > 0 {
+		for i := 0; i < 7 && !containsWeekday(byDay, start.Weekday()); i++ {
+			start = start.AddDate(0, 0, 1)
+		}
+		if hasEnd && start.After(end) {
+			return false
+		}
+	}
+
+	startTime, endTime := schedule.StartTime, schedule.EndTime
+	if startTime == "" && len(schedule.Times) > 0 {
+		startTime, endTime = schedule.Times[0].StartTime, schedule.Times[0].EndTime
+	}
+	startClock, err := time.Parse("15:04", startTime)
+	allDay := schedule.IsAllDay || err != nil
+
+	w.line("BEGIN", "VEVENT")
+	w.text("UID", activity.ID+"@"+domain)
+	w.line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+	if !activity.UpdatedAt.IsZero() {
+		w.line("LAST-MODIFIED", activity.UpdatedAt.UTC().Format("20060102T150405Z"))
+	}
+
+	rule, last := recurrenceRule(schedule, byDay, start, end, hasEnd)
+	if allDay {
+		w.line("DTSTART;VALUE=DATE", start.Format("20060102"))
+		// A daily multi-day activity is one event spanning its days; the end date is exclusive
+		days := 1
+		if rule == "FREQ=DAILY" {
+			days, rule = int(last.Sub(start).Hours()/24)+1, ""
+		}
+		w.line("DTEND;VALUE=DATE", start.AddDate(0, 0, days).Format("20060102"))
+		if rule != "" {
+			rule += ";UNTIL=" + last.Format("20060102")
+		}
+	} else {
+		var zone *time.Location
+		zone, usesDefaultZone = scheduleZone(schedule.Timezone)
+		startsAt := time.Date(start.Year(), start.Month(), start.Day(), startClock.Hour(), startClock.Minute(), 0, 0, zone)
+		w.line(dateTimeProperty("DTSTART", startsAt, usesDefaultZone))
+		if endClock, err := time.Parse("15:04", endTime); err == nil {
+			endsAt := time.Date(start.Year(), start.Month(), start.Day(), endClock.Hour(), endClock.Minute(), 0, 0, zone)
+			if !endsAt.After(startsAt) {
+				endsAt = endsAt.AddDate(0, 0, 1) // ends after midnight
+			}
+			w.line(dateTimeProperty("DTEND", endsAt, usesDefaultZone))
+		}
+		if rule != "" {
+			until := time.Date(last.Year(), last.Month(), last.Day(), 23, 59, 59, 0, zone)
+			rule += ";UNTIL=" + until.UTC().Format("20060102T150405Z")
+		}
+	}
+	if rule != "" {
+		w.line("RRULE", rule)
+	}
+
+	w.text("SUMMARY", activity.Title)
+	if description := eventDescription(activity); description != "" {
+		w.text("DESCRIPTION", description)
+	}
+	if location := eventLocation(activity.Location); location != "" {
+		w.text("LOCATION", location)
+	}
+	if coordinates := activity.Location.Coordinates; coordinates.Lat != 0 || coordinates.Lng != 0 {
+		w.line("GEO", fmt.Sprintf("%.6f;%.6f", coordinates.Lat, coordinates.Lng))
+	}
+	if url := eventURL(activity); url != "" {
+		w.line("URL", url)
+	}
+	if categories := eventCategories(activity); len(categories) > 0 {
+		w.line("CATEGORIES", strings.Join(categories, ","))
+	}
+	// Subscribed activities are suggestions, so they do not mark the family busy
+	w.line("TRANSP", "TRANSPARENT")
+	w.line("END", "VEVENT")
+	return usesDefaultZone
+}
+
+// recurrenceRule returns the FREQ and BYDAY parts of a schedule's RRULE and the date of its last
+// occurrence, or "" when the activity happens once. The caller adds the UNTIL part.
+func recurrenceRule(schedule models.Schedule, byDay []string, start, end time.Time, hasEnd bool) (string, time.Time) {
+	// Ongoing activities run every day, as on the calendar, while a recurring one needs to say when
+	repeats := hasEnd || schedule.Sessions > 1 || schedule.Type == models.ScheduleTypeOngoing ||
+		(schedule.Type == models.ScheduleTypeRecurring && (len(byDay) > 0 || (schedule.Frequency != "" && schedule.Frequency != "seasonal")))
+	if !repeats {
+		return "", start
+	}
+
+	var rule string
+	var step func(time.Time) time.Time
+	switch {
+	case len(byDay) > 0:
+		rule = "FREQ=WEEKLY;BYDAY=" + strings.Join(byDay, ",")
+		step = func(day time.Time) time.Time {
+			for next := day.AddDate(0, 0, 1); ; next = next.AddDate(0, 0, 1) {
+				if containsWeekday(byDay, next.Weekday()) {
+					return next
+				}
+			}
+		}
+	case schedule.Frequency == "weekly":
+		rule, step = "FREQ=WEEKLY", func(day time.Time) time.Time { return day.AddDate(0, 0, 7) }
+	case schedule.Frequency == "monthly":
+		rule, step = "FREQ=MONTHLY", func(day time.Time) time.Time { return day.AddDate(0, 1, 0) }
+	default:
+		rule, step = "FREQ=DAILY", func(day time.Time) time.Time { return day.AddDate(0, 0, 1) }
+	}
+
+	// A number of sessions without an end date ends after the last session
+	if !hasEnd && schedule.Sessions > 1 {
+		end, hasEnd = start, true
+		for i := 1; i < schedule.Sessions; i++ {
+			end = step(end)
+		}
+	}
+	if limit := start.AddDate(0, 0, maxRecurrenceDays-1); !hasEnd || end.After(limit) {
+		end = limit
+	}
+	return rule, end
+}
+
+// scheduleZone returns the location of a schedule's zone, and whether it is DefaultTimezone.
+// Unknown zones are taken to be DefaultTimezone.
+func scheduleZone(name string) (*time.Location, bool) {
+	if name != "" && name != DefaultTimezone {
+		if zone, err := time.LoadLocation(name); err == nil {
+			return zone, false
+		}
+	}
+	zone, _ := time.LoadLocation(DefaultTimezone)
+	return zone, true
+}
+
+// dateTimeProperty formats a date-time property in DefaultTimezone, defined by the feed's
+// VTIMEZONE, or in UTC for other zones
+func dateTimeProperty(name string, t time.Time, inDefaultZone bool) (string, string) {
+	if inDefaultZone {
+		return name + ";TZID=" + DefaultTimezone, t.Format("20060102T150405")
+	}
+	return name, t.UTC().Format("20060102T150405Z")
+}
+
+var icsWeekdayCodes = map[string]string{
+	"monday": "MO", "tuesday": "TU", "wednesday": "WE", "thursday": "TH",
+	"friday": "FR", "saturday": "SA", "sunday": "SU",
+}
+
+// icsWeekdays returns the BYDAY codes of a schedule's days of the week, in week order
+func icsWeekdays(days []string) []string {
+	listed := make(map[string]bool)
+	for _, day := range days {
+		if code, ok := icsWeekdayCodes[strings.ToLower(strings.TrimSpace(day))]; ok {
+			listed[code] = true
+		}
+	}
+	var codes []string
+	for _, code := range []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"} {
+		if listed[code] {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func containsWeekday(codes []string, weekday time.Weekday) bool {
+	code := strings.ToUpper(weekday.String()[:2])
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// eventDescription is the activity's description followed by its registration details
+func eventDescription(activity *models.Activity) string {
+	parts := []string{}
+	if description := strings.TrimSpace(activity.Description); description != "" {
+		parts = append(parts, description)
+	}
+	if url := activity.Registration.URL; url != "" && url != eventURL(activity) {
+		parts = append(parts, "Registration: "+url)
+	}
+	if activity.Pricing.Description != "" {
+		parts = append(parts, "Price: "+activity.Pricing.Description)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// eventLocation is the venue name and address, with the city when the address does not mention it
+func eventLocation(location models.Location) string {
+	parts := []string{}
+	for _, part := range []string{location.Name, location.Address} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	city := strings.TrimSpace(location.City)
+	if city != "" && !strings.Contains(strings.ToLower(location.Address), strings.ToLower(city)) {
+		parts = append(parts, city)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// eventURL links to the activity's details, or its registration page when there is no detail page
+func eventURL(activity *models.Activity) string {
+	if activity.DetailURL != "" {
+		return activity.DetailURL
+	}
+	return activity.Registration.URL
+}
+
+// eventCategories are the activity's category and tags, escaped as CATEGORIES values
+func eventCategories(activity *models.Activity) []string {
+	var categories []string
+	for _, category := range append([]string{activity.Category}, activity.Tags...) {
+		if category != "" {
+			categories = append(categories, escapeText(category))
+		}
+	}
+	return categories
+}
+
+// icsDuration formats a duration as an RFC 5545 duration in whole minutes, e.g. PT6H
+func icsDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	value := "PT"
+	if minutes >= 60 {
+		value += fmt.Sprintf("%dH", minutes/60)
+	}
+	if minutes%60 != 0 || minutes < 60 {
+		value += fmt.Sprintf("%dM", minutes%60)
+	}
+	return value
+}
+
+// icsWriter writes content lines with CRLF endings, folded at 75 octets
+type icsWriter struct {
+	b strings.Builder
+}
+
+// maxLineOctets is the longest content line RFC 5545 allows, without its CRLF
+const maxLineOctets = 75
+
+// line writes a property whose value needs no escaping
+func (w *icsWriter) line(name, value string) {
+	content := name + ":" + value
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			// A folded line continues after a space, which counts towards its length
+			w.b.WriteString("\r\n ")
+			width = 1
+		}
+		w.b.WriteRune(r)
+		width += size
+	}
+	w.b.WriteString("\r\n")
+}
+
+// text writes a property with a TEXT value, escaping it
+func (w *icsWriter) text(name, value string) {
+	w.line(name, escapeText(value))
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value's backslashes, separators and line breaks
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
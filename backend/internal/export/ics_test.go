@@ -0,0 +1,217 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+var testFeed = Feed{Name: "Seattle Family Activities", Domain: "seattle-family-activities", RefreshInterval: 6 * time.Hour}
+
+var testNow = time.Date(2026, 3, 2, 18, 30, 0, 0, time.UTC)
+
+// eventLines renders one activity and returns the unfolded lines of its VEVENT, or nil when it
+// was left out
+func eventLines(t *testing.T, activity models.Activity) []string {
+	t.Helper()
+	feed := ICS(testFeed, []*models.Activity{&activity}, testNow)
+	lines := strings.Split(strings.ReplaceAll(feed, "\r\n ", ""), "\r\n")
+	for i, line := range lines {
+		if line == "BEGIN:VEVENT" {
+			for j := i; j < len(lines); j++ {
+				if lines[j] == "END:VEVENT" {
+					return lines[i : j+1]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestICSFeed(t *testing.T) {
+	activity := &models.Activity{
+		ID:          "story-time",
+		Title:       "Story Time; songs, rhymes",
+		Description: "Stories for little ones.\nBring a blanket.",
+		Category:    models.CategoryFreeCommunity,
+		Tags:        []string{models.TagDropIn},
+		Schedule: models.Schedule{
+			Type:      models.ScheduleTypeOneTime,
+			StartDate: "2026-03-14",
+			StartTime: "10:30",
+			EndTime:   "11:15",
+			Timezone:  "America/Los_Angeles",
+		},
+		Location: models.Location{
+			Name:        "Ballard Library",
+			Address:     "5614 22nd Ave NW",
+			City:        "Seattle",
+			Coordinates: models.Coordinates{Lat: 47.6697, Lng: -122.3846},
+		},
+		DetailURL: "https://www.spl.org/story-time",
+	}
+	undated := &models.Activity{ID: "undated", Title: "Sometime"}
+
+	feed := ICS(testFeed, []*models.Activity{activity, undated}, testNow)
+
+	if !strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(feed, "END:VCALENDAR\r\n") {
+		t.Errorf("Expected a CRLF-delimited VCALENDAR, got %q", feed)
+	}
+	if strings.Count(feed, "BEGIN:VEVENT") != 1 {
+		t.Errorf("Expected the undated activity to be left out, got %d events", strings.Count(feed, "BEGIN:VEVENT"))
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(feed, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected lines to be folded at %d octets, got %d: %q", maxLineOctets, len(line), line)
+		}
+	}
+
+	lines := eventLines(t, *activity)
+	for _, want := range []string{
+		"UID:story-time@seattle-family-activities",
+		"DTSTAMP:20260302T183000Z",
+		"DTSTART;TZID=America/Los_Angeles:20260314T103000",
+		"DTEND;TZID=America/Los_Angeles:20260314T111500",
+		`SUMMARY:Story Time\; songs\, rhymes`,
+		`DESCRIPTION:Stories for little ones.\nBring a blanket.`,
+		`LOCATION:Ballard Library\, 5614 22nd Ave NW\, Seattle`,
+		"GEO:47.669700;-122.384600",
+		"URL:https://www.spl.org/story-time",
+		"CATEGORIES:free-community,drop-in",
+	} {
+		if !hasLine(lines, want) {
+			t.Errorf("Expected %q in %q", want, lines)
+		}
+	}
+	for _, want := range []string{"BEGIN:VTIMEZONE", "TZID:America/Los_Angeles", "X-WR-CALNAME:Seattle Family Activities", "REFRESH-INTERVAL;VALUE=DURATION:PT6H"} {
+		if !strings.Contains(feed, want+"\r\n") {
+			t.Errorf("Expected %q in the feed", want)
+		}
+	}
+}
+
+func TestICSSchedules(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule models.Schedule
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "AllDay",
+			schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2026-03-14", IsAllDay: true},
+			want:     []string{"DTSTART;VALUE=DATE:20260314", "DTEND;VALUE=DATE:20260315"},
+			notWant:  []string{"RRULE"},
+		},
+		{
+			name:     "AllDayMultiDaySpans",
+			schedule: models.Schedule{Type: models.ScheduleTypeMultiDay, StartDate: "2026-06-22", EndDate: "2026-06-26"},
+			want:     []string{"DTSTART;VALUE=DATE:20260622", "DTEND;VALUE=DATE:20260627"},
+			notWant:  []string{"RRULE"},
+		},
+		{
+			name:     "TimedMultiDayRepeatsDaily",
+			schedule: models.Schedule{Type: models.ScheduleTypeMultiDay, StartDate: "2026-06-22", EndDate: "2026-06-26", StartTime: "09:00", EndTime: "15:00"},
+			// 23:59:59 PDT on the last day
+			want: []string{"DTSTART;TZID=America/Los_Angeles:20260622T090000", "RRULE:FREQ=DAILY;UNTIL=20260627T065959Z"},
+		},
+		{
+			name: "WeeklyStartsOnFirstListedDay",
+			schedule: models.Schedule{Type: models.ScheduleTypeRecurring, StartDate: "2026-03-02", EndDate: "2026-03-31",
+				DaysOfWeek: []string{"Saturday", "wednesday"}, StartTime: "16:00", EndTime: "17:00"},
+			want: []string{"DTSTART;TZID=America/Los_Angeles:20260304T160000", "RRULE:FREQ=WEEKLY;BYDAY=WE,SA;UNTIL=20260401T065959Z"},
+		},
+		{
+			name: "SessionsWithoutEndDate",
+			schedule: models.Schedule{Type: models.ScheduleTypeRecurring, StartDate: "2026-03-03", DaysOfWeek: []string{"tuesday"},
+				Sessions: 4, StartTime: "10:00"},
+			// The fourth Tuesday, after the switch to daylight time
+			want:    []string{"RRULE:FREQ=WEEKLY;BYDAY=TU;UNTIL=20260325T065959Z"},
+			notWant: []string{"DTEND"},
+		},
+		{
+			name:     "OngoingIsLimited",
+			schedule: models.Schedule{Type: models.ScheduleTypeOngoing, StartDate: "2026-03-01", StartTime: "10:00", EndTime: "17:00"},
+			want:     []string{"RRULE:FREQ=DAILY;UNTIL=20260629T065959Z"},
+		},
+		{
+			name:     "RecurringWithoutPatternHappensOnce",
+			schedule: models.Schedule{Type: models.ScheduleTypeRecurring, StartDate: "2026-03-14", StartTime: "10:00"},
+			notWant:  []string{"RRULE"},
+		},
+		{
+			name:     "OtherZoneInUTC",
+			schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2026-03-14", StartTime: "10:00", EndTime: "23:30", Timezone: "America/New_York"},
+			want:     []string{"DTSTART:20260314T140000Z", "DTEND:20260315T033000Z"},
+		},
+		{
+			name:     "EndsAfterMidnight",
+			schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2026-03-14", StartTime: "22:00", EndTime: "01:00"},
+			want:     []string{"DTEND;TZID=America/Los_Angeles:20260315T010000"},
+		},
+		{
+			name:     "TimeSlot",
+			schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2026-03-14", Times: []models.TimeSlot{{StartTime: "09:30", EndTime: "10:00"}}},
+			want:     []string{"DTSTART;TZID=America/Los_Angeles:20260314T093000", "DTEND;TZID=America/Los_Angeles:20260314T100000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := eventLines(t, models.Activity{ID: "a", Title: "Activity", Schedule: tt.schedule})
+			if lines == nil {
+				t.Fatalf("Expected an event")
+			}
+			for _, want := range tt.want {
+				if !hasLine(lines, want) {
+					t.Errorf("Expected %q in %q", want, lines)
+				}
+			}
+			for _, prefix := range tt.notWant {
+				for _, line := range lines {
+					if strings.HasPrefix(line, prefix) {
+						t.Errorf("Expected no %s, got %q", prefix, line)
+					}
+				}
+			}
+		})
+	}
+
+	// A date range without any of the listed days has no occurrences
+	lines := eventLines(t, models.Activity{ID: "a", Title: "Activity", Schedule: models.Schedule{
+		Type: models.ScheduleTypeRecurring, StartDate: "2026-03-02", EndDate: "2026-03-03", DaysOfWeek: []string{"saturday"},
+	}})
+	if lines != nil {
+		t.Errorf("Expected no event, got %q", lines)
+	}
+}
+
+func TestICSLineFolding(t *testing.T) {
+	title := strings.Repeat("Ünïcode ", 30)
+	lines := eventLines(t, models.Activity{ID: "a", Title: title, Schedule: models.Schedule{StartDate: "2026-03-14"}})
+	if !hasLine(lines, "SUMMARY:"+title) {
+		t.Errorf("Expected the folded title to unfold intact, got %q", lines)
+	}
+
+	feed := ICS(testFeed, []*models.Activity{{ID: "a", Title: title, Schedule: models.Schedule{StartDate: "2026-03-14"}}}, testNow)
+	for _, line := range strings.Split(feed, "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected at most %d octets, got %d", maxLineOctets, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("Expected folds between characters, got %q", line)
+		}
+	}
+}
@@ -38,13 +38,19 @@ type Activity struct {
 	FamilyType string     `json:"familyType"` // drop-off|parent-child|family-friendly|adult-only
 
 	// Location
-	Location Location `json:"location"`
+	Location    Location     `json:"location"`
+	ArrivalInfo *ArrivalInfo `json:"arrivalInfo,omitempty"` // parking and arrival notes from the listing, an admin edit or the venue
 
 	// Pricing
 	Pricing Pricing `json:"pricing"`
 
 	// Registration
-	Registration Registration `json:"registration"`
+	Registration      Registration `json:"registration"`
+	ParticipationType string       `json:"participationType,omitempty"` // drop-in|registration|ticketed|sold-out
+
+	// Category-specific details, set when extracted with the camps or classes schema
+	Camp  *CampDetails  `json:"camp,omitempty"`
+	Class *ClassDetails `json:"class,omitempty"`
 
 	// Content & Links
 	Images    []Image  `json:"images,omitempty"`
@@ -57,6 +63,13 @@ type Activity struct {
 	// Source Tracking
 	Source Source `json:"source"`
 
+	// Quality
+	Completeness *Completeness `json:"completeness,omitempty"` // scored when the activity is converted
+
+	// Visibility window, from the approved event; the activity is only public between the two
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+
 	// System Fields
 	Featured  bool      `json:"featured"`
 	CreatedAt time.Time `json:"createdAt"`
@@ -64,6 +77,20 @@ type Activity struct {
 	Status    string    `json:"status"` // active|inactive|expired|cancelled
 }
 
+// Completeness scores how much practical detail an activity gives families
+type Completeness struct {
+	Score  float64  `json:"score"`            // 0.0 - 1.0
+	Badges []string `json:"badges,omitempty"` // public-facing badges for the details present
+}
+
+// Completeness badge constants
+const (
+	BadgeHasImage         = "has-image"
+	BadgeHasMap           = "has-map"        // location has coordinates
+	BadgeSpecificTimes    = "specific-times" // start time rather than just a date
+	BadgeRegistrationLink = "registration-link"
+)
+
 // Schedule defines when an activity occurs
 type Schedule struct {
 	Type       string     `json:"type"`                 // one-time|recurring|multi-day|ongoing
@@ -91,7 +118,7 @@ type TimeSlot struct {
 type AgeGroup struct {
 	Category    string `json:"category"`    // infant|toddler|preschool|elementary|tween|teen|adult|all-ages
 	MinAge      int    `json:"minAge"`      // minimum age
-	MaxAge      int    `json:"maxAge"`      // maximum age
+	MaxAge      int    `json:"maxAge"`      // maximum age, 0 for open-ended "and up" ranges
 	Unit        string `json:"unit"`        // months|years
 	Description string `json:"description"` // human-readable description
 }
@@ -106,6 +133,7 @@ type Location struct {
 	Neighborhood  string      `json:"neighborhood,omitempty"`  // Capitol Hill, Ballard, etc.
 	Region        string      `json:"region"`                  // Seattle Metro, Eastside, etc.
 	Coordinates   Coordinates `json:"coordinates,omitempty"`   // lat/lng
+	Geohash       string      `json:"geohash,omitempty"`       // 7-character geohash of the coordinates
 	VenueType     string      `json:"venueType"`               // indoor|outdoor|mixed
 	Accessibility string      `json:"accessibility,omitempty"` // ADA accessible details
 	Parking       string      `json:"parking,omitempty"`       // parking availability info
@@ -135,6 +163,28 @@ type Discount struct {
 	Description string `json:"description"` // description of the discount
 }
 
+// CampDetails holds what families compare camps on beyond the generic fields
+type CampDetails struct {
+	Sessions     []CampSession `json:"sessions,omitempty"`     // the weeks or sessions offered, in page order
+	BeforeCare   bool          `json:"beforeCare"`             // early drop-off offered
+	AfterCare    bool          `json:"afterCare"`              // late pick-up offered
+	CareDetails  string        `json:"careDetails,omitempty"`  // extended care hours and cost as written
+	PricePerWeek float64       `json:"pricePerWeek,omitempty"` // numeric weekly price
+}
+
+// CampSession is one week or session of a camp
+type CampSession struct {
+	Label     string `json:"label"`               // e.g. "Week 1: Ocean Explorers"
+	StartDate string `json:"startDate,omitempty"` // ISO date
+	EndDate   string `json:"endDate,omitempty"`   // ISO date
+}
+
+// ClassDetails holds class-specific information
+type ClassDetails struct {
+	SkillLevel string `json:"skillLevel,omitempty"` // beginner|intermediate|advanced|all-levels
+	Instructor string `json:"instructor,omitempty"`
+}
+
 // Registration contains signup and contact information
 type Registration struct {
 	Required     bool   `json:"required"`             // whether registration is required
@@ -224,6 +274,14 @@ const (
 	PricingTypeVariable = "variable"
 )
 
+// Skill level constants
+const (
+	SkillLevelBeginner     = "beginner"
+	SkillLevelIntermediate = "intermediate"
+	SkillLevelAdvanced     = "advanced"
+	SkillLevelAllLevels    = "all-levels"
+)
+
 // Venue type constants
 const (
 	VenueTypeIndoor  = "indoor"
@@ -247,6 +305,14 @@ const (
 	RegistrationStatusSoldOut  = "sold-out"
 )
 
+// Participation type constants
+const (
+	ParticipationDropIn       = "drop-in"
+	ParticipationRegistration = "registration"
+	ParticipationTicketed     = "ticketed"
+	ParticipationSoldOut      = "sold-out"
+)
+
 // Activity status constants
 const (
 	ActivityStatusActive    = "active"
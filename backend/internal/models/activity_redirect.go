@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SortKeyRedirect is the sort key of the redirect left under a retired activity ID
+const SortKeyRedirect = "REDIRECT"
+
+// ActivityRedirect points an activity ID that is no longer used at the ID the activity is
+// published under now, so clients holding the old ID can still find it. Redirects live in the
+// family activities table under the old ID's partition and have no entity type, so activity
+// scans skip them.
+type ActivityRedirect struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // EVENT#{old_activity_id}
+	SK string `json:"-" dynamodbav:"SK"` // REDIRECT
+
+	RedirectedFrom string    `json:"redirected_from" dynamodbav:"redirected_from"`
+	ActivityID     string    `json:"activity_id" dynamodbav:"activity_id"`
+	CreatedAt      time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// NewActivityRedirect creates the redirect from an old activity ID to its current one
+func NewActivityRedirect(fromID, toID string, at time.Time) *ActivityRedirect {
+	return &ActivityRedirect{
+		PK:             CreateEventPK(fromID),
+		SK:             SortKeyRedirect,
+		RedirectedFrom: fromID,
+		ActivityID:     toID,
+		CreatedAt:      at,
+	}
+}
@@ -9,7 +9,7 @@ import (
 func TestActivityModel(t *testing.T) {
 	// Create a sample activity
 	activity := Activity{
-		ID:          GenerateActivityID("Test Music Class", "2024-09-01", "Seattle Music Academy"),
+		ID:          "act_5f0c1e2a9b3d4c6e",
 		Title:       "Test Music Class",
 		Description: "A test music class for toddlers",
 		Type:        TypeClass,
@@ -150,28 +150,6 @@ func TestValidationFunctions(t *testing.T) {
 	}
 }
 
-func TestIDGeneration(t *testing.T) {
-	// Test activity ID generation
-	id1 := GenerateActivityID("Music Class", "2024-09-01", "Seattle Music Academy")
-	id2 := GenerateActivityID("Music Class", "2024-09-01", "Seattle Music Academy")
-	id3 := GenerateActivityID("Different Class", "2024-09-01", "Seattle Music Academy")
-
-	// Same inputs should generate same ID
-	if id1 != id2 {
-		t.Errorf("Same inputs should generate same ID: %s != %s", id1, id2)
-	}
-
-	// Different inputs should generate different IDs
-	if id1 == id3 {
-		t.Errorf("Different inputs should generate different IDs: %s == %s", id1, id3)
-	}
-
-	// IDs should have correct prefix
-	if len(id1) != 12 || id1[:4] != "act_" {
-		t.Errorf("Activity ID should be 12 characters starting with 'act_', got: %s", id1)
-	}
-}
-
 func TestActivitiesOutput(t *testing.T) {
 	// Create sample activities
 	activities := []Activity{
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Admin API key status constants
+const (
+	AdminAPIKeyStatusActive  = "active"
+	AdminAPIKeyStatusRevoked = "revoked"
+)
+
+// Admin roles, from least to most privileged. Each role can do everything the roles before it can.
+const (
+	AdminRoleViewer = "viewer" // reads the review queues, sources and analytics
+	AdminRoleEditor = "editor" // also reviews events, manages sources and runs crawls
+	AdminRoleAdmin  = "admin"  // also manages API keys, providers and settings
+)
+
+// adminRoleRanks orders the admin roles by privilege
+var adminRoleRanks = map[string]int{
+	AdminRoleViewer: 1,
+	AdminRoleEditor: 2,
+	AdminRoleAdmin:  3,
+}
+
+// adminAPIKeyPrefix starts every admin API key, so admin keys and provider tokens sent in the
+// same header can be told apart
+const adminAPIKeyPrefix = "adm"
+
+// AdminAPIKey lets its holder call the admin-only routes of the admin API. Keys are issued by
+// admins, or with cmd/admin_api_key for the first one; only the hash of a key's secret is stored.
+type AdminAPIKey struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // ADMIN_API_KEY#{key_id}
+	SK string `json:"SK" dynamodbav:"SK"` // KEY
+
+	KeyID  string `json:"key_id" dynamodbav:"key_id"`
+	Name   string `json:"name" dynamodbav:"name"`     // who or what holds the key
+	Status string `json:"status" dynamodbav:"status"` // active, revoked
+	Role   string `json:"role" dynamodbav:"role"`     // viewer, editor, admin; see EffectiveRole
+
+	// Sandbox keys train new admins: their requests read and write the sandbox tables instead of
+	// production, through the same handlers
+	Sandbox bool `json:"sandbox,omitempty" dynamodbav:"sandbox,omitempty"`
+
+	// Secret
+	KeyHash   string `json:"-" dynamodbav:"key_hash"`            // sha256 of the key secret
+	KeyPrefix string `json:"key_prefix" dynamodbav:"key_prefix"` // first characters of the key, to tell keys apart
+
+	// Metadata
+	CreatedBy string     `json:"created_by" dynamodbav:"created_by"`
+	CreatedAt time.Time  `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" dynamodbav:"updated_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" dynamodbav:"revoked_at,omitempty"`
+}
+
+// AdminAPIKeyRequest is an admin's request to issue an admin API key
+type AdminAPIKeyRequest struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"` // defaults to viewer
+	Sandbox   bool   `json:"sandbox"`
+	CreatedBy string `json:"created_by"`
+}
+
+// Validate checks an admin's API key request
+func (r *AdminAPIKeyRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.Role != "" && !IsValidAdminRole(r.Role) {
+		return fmt.Errorf("invalid role %q, expected viewer, editor or admin", r.Role)
+	}
+	if r.CreatedBy == "" {
+		return fmt.Errorf("created_by is required")
+	}
+	return nil
+}
+
+// IsActive returns true if the key is accepted
+func (k *AdminAPIKey) IsActive() bool {
+	return k.Status == AdminAPIKeyStatusActive
+}
+
+// EffectiveRole returns the key's role. Keys issued before roles existed had full access and
+// keep it.
+func (k *AdminAPIKey) EffectiveRole() string {
+	if k.Role == "" {
+		return AdminRoleAdmin
+	}
+	return k.Role
+}
+
+// IsValidAdminRole reports whether role is one of the admin roles
+func IsValidAdminRole(role string) bool {
+	_, ok := adminRoleRanks[role]
+	return ok
+}
+
+// AdminRoleAllows reports whether a holder of role may do what the required role may. Unknown
+// roles allow nothing.
+func AdminRoleAllows(role, required string) bool {
+	rank, ok := adminRoleRanks[role]
+	return ok && rank >= adminRoleRanks[required]
+}
+
+// CheckSecret reports whether a key secret matches the key
+func (k *AdminAPIKey) CheckSecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashProviderTokenSecret(secret)), []byte(k.KeyHash)) == 1
+}
+
+// GenerateAdminAPIKey creates a new admin API key with a random key ID. The key is shown once;
+// only the returned hash of its secret is stored.
+func GenerateAdminAPIKey() (keyID, key, secretHash string, err error) {
+	random := make([]byte, 36)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate admin API key: %w", err)
+	}
+	keyID = hex.EncodeToString(random[:4])
+	secret := hex.EncodeToString(random[4:])
+	return keyID, strings.Join([]string{adminAPIKeyPrefix, keyID, secret}, "."), HashProviderTokenSecret(secret), nil
+}
+
+// ParseAdminAPIKey splits an admin API key into its key ID and secret
+func ParseAdminAPIKey(key string) (keyID, secret string, err error) {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 || parts[0] != adminAPIKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("malformed admin API key")
+	}
+	return parts[1], parts[2], nil
+}
+
+// AdminAPIKeyDisplayPrefix returns the part of a key that is safe to show after it was issued
+func AdminAPIKeyDisplayPrefix(key string) string {
+	keyID, secret, err := ParseAdminAPIKey(key)
+	if err != nil || len(secret) < 6 {
+		return ""
+	}
+	return strings.Join([]string{adminAPIKeyPrefix, keyID, secret[:6]}, ".")
+}
+
+// Helper functions to create primary keys for admin API keys
+func CreateAdminAPIKeyPK(keyID string) string {
+	return fmt.Sprintf("ADMIN_API_KEY#%s", keyID)
+}
+
+func CreateAdminAPIKeySK() string {
+	return "KEY"
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdminAPIKey(t *testing.T) {
+	keyID, key, secretHash, err := GenerateAdminAPIKey()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	parsedID, secret, err := ParseAdminAPIKey(key)
+	if err != nil {
+		t.Fatalf("Expected the generated key to parse, got %v", err)
+	}
+	if parsedID != keyID {
+		t.Errorf("Expected key ID %s, got %s", keyID, parsedID)
+	}
+
+	apiKey := &AdminAPIKey{KeyHash: secretHash, Status: AdminAPIKeyStatusActive}
+	if !apiKey.CheckSecret(secret) {
+		t.Error("Expected the key secret to match its hash")
+	}
+	if apiKey.CheckSecret(secret + "0") {
+		t.Error("Expected a different secret to be refused")
+	}
+	if strings.Contains(secretHash, secret) {
+		t.Error("Expected only a hash of the secret to be stored")
+	}
+
+	prefix := AdminAPIKeyDisplayPrefix(key)
+	if !strings.HasPrefix(key, prefix) || len(prefix) >= len(key) {
+		t.Errorf("Expected a short prefix of the key, got %q", prefix)
+	}
+
+	// Provider tokens travel in the same header and must not parse as admin keys
+	providerToken, _, err := GenerateProviderToken("parks-1a2b3c4d")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, malformed := range []string{"", "adm.key", "abc.key.secret", "adm..secret", "adm.key.secret.extra", providerToken} {
+		if _, _, err := ParseAdminAPIKey(malformed); err == nil {
+			t.Errorf("Expected %q to be refused", malformed)
+		}
+	}
+
+	_, otherKey, _, err := GenerateAdminAPIKey()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if otherKey == key {
+		t.Error("Expected every generated key to be different")
+	}
+}
+
+func TestAdminAPIKeyRequestValidate(t *testing.T) {
+	for _, role := range []string{"", AdminRoleViewer, AdminRoleEditor, AdminRoleAdmin} {
+		valid := AdminAPIKeyRequest{Name: "Review laptop", Role: role, CreatedBy: "alice"}
+		if err := valid.Validate(); err != nil {
+			t.Errorf("Expected a valid request with role %q, got %v", role, err)
+		}
+	}
+	for _, req := range []AdminAPIKeyRequest{
+		{Name: " ", CreatedBy: "alice"},
+		{Name: "Review laptop"},
+		{Name: "Review laptop", Role: "owner", CreatedBy: "alice"},
+	} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Expected %+v to be refused", req)
+		}
+	}
+}
+
+func TestAdminRoles(t *testing.T) {
+	tests := []struct {
+		role     string
+		required string
+		allowed  bool
+	}{
+		{AdminRoleViewer, AdminRoleViewer, true},
+		{AdminRoleViewer, AdminRoleEditor, false},
+		{AdminRoleViewer, AdminRoleAdmin, false},
+		{AdminRoleEditor, AdminRoleViewer, true},
+		{AdminRoleEditor, AdminRoleEditor, true},
+		{AdminRoleEditor, AdminRoleAdmin, false},
+		{AdminRoleAdmin, AdminRoleViewer, true},
+		{AdminRoleAdmin, AdminRoleAdmin, true},
+		{"owner", AdminRoleViewer, false},
+		{"", AdminRoleViewer, false},
+	}
+	for _, tt := range tests {
+		if got := AdminRoleAllows(tt.role, tt.required); got != tt.allowed {
+			t.Errorf("AdminRoleAllows(%q, %q) = %v, expected %v", tt.role, tt.required, got, tt.allowed)
+		}
+	}
+
+	// Keys issued before roles existed keep full access
+	legacy := &AdminAPIKey{}
+	if legacy.EffectiveRole() != AdminRoleAdmin {
+		t.Errorf("Expected a key without a role to be an admin key, got %s", legacy.EffectiveRole())
+	}
+	viewer := &AdminAPIKey{Role: AdminRoleViewer}
+	if viewer.EffectiveRole() != AdminRoleViewer {
+		t.Errorf("Expected a viewer key, got %s", viewer.EffectiveRole())
+	}
+}
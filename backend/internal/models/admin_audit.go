@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Admin audit actions
+const (
+	AdminAuditEventApproved   = "event_approved"
+	AdminAuditEventRejected   = "event_rejected"
+	AdminAuditEventEdited     = "event_edited"
+	AdminAuditSourceActivated = "source_activated"
+	AdminAuditActivityDeleted = "activity_deleted"
+	AdminAuditVisibilitySet   = "visibility_set"
+
+	// Two-person rule steps; the confirmed action is logged as well
+	AdminAuditApprovalProposed  = "approval_proposed"
+	AdminAuditApprovalConfirmed = "approval_confirmed"
+)
+
+// adminAuditTimeFormat keeps audit sort keys in time order; RFC3339Nano drops trailing zeros
+const adminAuditTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// AdminAuditEntry records one review action taken by an admin. Entries are stored in the admin
+// events table under the admin, so an admin's actions in a period can be queried directly.
+type AdminAuditEntry struct {
+	// DynamoDB Keys
+	PK string `json:"PK" dynamodbav:"PK"` // ADMIN#{admin}
+	SK string `json:"SK" dynamodbav:"SK"` // AUDIT#{timestamp}#{target_id}
+
+	Admin     string    `json:"admin" dynamodbav:"admin"`
+	Action    string    `json:"action" dynamodbav:"action"`
+	TargetID  string    `json:"target_id" dynamodbav:"target_id"` // event or source ID
+	Timestamp time.Time `json:"timestamp" dynamodbav:"timestamp"`
+
+	// How long the event waited between extraction and the decision, for approvals and rejections
+	LatencySeconds float64 `json:"latency_seconds,omitempty" dynamodbav:"latency_seconds,omitempty"`
+}
+
+// AdminStats summarizes an admin's review throughput over a week
+type AdminStats struct {
+	Admin     string    `json:"admin"`
+	WeekStart time.Time `json:"week_start"` // Monday 00:00 UTC
+	WeekEnd   time.Time `json:"week_end"`   // exclusive
+
+	EventsApproved   int `json:"events_approved"`
+	EventsRejected   int `json:"events_rejected"`
+	EventsEdited     int `json:"events_edited"`
+	EventsReviewed   int `json:"events_reviewed"` // approved or rejected
+	SourcesActivated int `json:"sources_activated"`
+
+	// Mean time events waited between extraction and the admin's decision
+	AverageReviewLatencyHours float64 `json:"average_review_latency_hours"`
+
+	LastActionAt *time.Time `json:"last_action_at,omitempty"`
+}
+
+// NewAdminAuditEntry creates the audit entry of an admin action
+func NewAdminAuditEntry(admin, action, targetID string, at time.Time) *AdminAuditEntry {
+	return &AdminAuditEntry{
+		PK:        CreateAdminAuditPK(admin),
+		SK:        CreateAdminAuditSK(at, targetID),
+		Admin:     admin,
+		Action:    action,
+		TargetID:  targetID,
+		Timestamp: at,
+	}
+}
+
+// CreateAdminAuditPK creates the partition key of an admin's audit entries
+func CreateAdminAuditPK(admin string) string {
+	return fmt.Sprintf("ADMIN#%s", admin)
+}
+
+// CreateAdminAuditSK creates the sort key of an audit entry
+func CreateAdminAuditSK(at time.Time, targetID string) string {
+	return fmt.Sprintf("AUDIT#%s#%s", at.UTC().Format(adminAuditTimeFormat), targetID)
+}
+
+// CreateAdminAuditSKBound creates the sort key bounding the audit entries of a period
+func CreateAdminAuditSKBound(at time.Time) string {
+	return "AUDIT#" + at.UTC().Format(adminAuditTimeFormat)
+}
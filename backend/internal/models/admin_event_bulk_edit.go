@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AdminEventBulkEditRequest applies the same field changes to every pending event matching a
+// filter, e.g. to fill in the venue name all events extracted from one listing page are missing
+type AdminEventBulkEditRequest struct {
+	Filter     AdminEventBulkEditFilter `json:"filter"`
+	Patch      AdminEventFieldPatch     `json:"patch"`
+	EditedBy   string                   `json:"edited_by"`
+	AdminNotes string                   `json:"admin_notes"`
+}
+
+// AdminEventBulkEditFilter selects the pending events of a bulk edit. Events must match every
+// field that is set.
+type AdminEventBulkEditFilter struct {
+	SubmissionID string `json:"submission_id,omitempty"`
+	SourceURL    string `json:"source_url,omitempty"`
+}
+
+// AdminEventFieldPatch lists the fields a bulk edit sets; empty fields are left unchanged
+type AdminEventFieldPatch struct {
+	LocationName string   `json:"location_name,omitempty"` // sets location.name
+	Category     string   `json:"category,omitempty"`
+	Tags         []string `json:"tags,omitempty"` // replaces the event's tags, which are no longer suggested
+}
+
+// Validate checks that a bulk edit selects events, changes at least one field and names the admin
+func (r *AdminEventBulkEditRequest) Validate() error {
+	r.Filter.SubmissionID = strings.TrimSpace(r.Filter.SubmissionID)
+	r.Filter.SourceURL = strings.TrimSpace(r.Filter.SourceURL)
+	if r.Filter.SubmissionID == "" && r.Filter.SourceURL == "" {
+		return fmt.Errorf("filter must include submission_id or source_url")
+	}
+
+	r.Patch.LocationName = strings.TrimSpace(r.Patch.LocationName)
+	r.Patch.Category = strings.TrimSpace(r.Patch.Category)
+	if r.Patch.LocationName == "" && r.Patch.Category == "" && len(r.Patch.Tags) == 0 {
+		return fmt.Errorf("patch must set location_name, category or tags")
+	}
+	if r.Patch.Category != "" && !ValidateCategory(r.Patch.Category) {
+		return fmt.Errorf("invalid category: %s", r.Patch.Category)
+	}
+	if len(r.Patch.Tags) > 0 {
+		tags, err := NormalizeTags(r.Patch.Tags)
+		if err != nil {
+			return err
+		}
+		r.Patch.Tags = tags
+	}
+
+	if r.EditedBy == "" {
+		return fmt.Errorf("edited_by is required")
+	}
+	return nil
+}
+
+// ApplyToRawData sets the patched fields on every event in an admin event's raw extracted data.
+// Events are the objects in the data's top-level arrays, such as "events"; data without any is
+// patched as a single event. It returns the number of events patched.
+func (p *AdminEventFieldPatch) ApplyToRawData(rawData map[string]interface{}) int {
+	patched := 0
+	for _, value := range rawData {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			if eventData, ok := item.(map[string]interface{}); ok {
+				p.apply(eventData)
+				patched++
+			}
+		}
+	}
+
+	if patched == 0 {
+		p.apply(rawData)
+		patched = 1
+	}
+	return patched
+}
+
+// apply sets the fields on one event under the names conversion reads first
+func (p *AdminEventFieldPatch) apply(eventData map[string]interface{}) {
+	if p.LocationName != "" {
+		eventData["location"] = p.LocationName
+	}
+	if p.Category != "" {
+		eventData["category"] = p.Category
+	}
+	if len(p.Tags) > 0 {
+		tags := make([]interface{}, len(p.Tags))
+		for i, tag := range p.Tags {
+			tags[i] = tag
+		}
+		eventData["tags"] = tags
+	}
+}
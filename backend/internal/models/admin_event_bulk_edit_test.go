@@ -0,0 +1,94 @@
+package models
+
+import "testing"
+
+func TestAdminEventBulkEditRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     AdminEventBulkEditRequest
+		wantErr bool
+	}{
+		{"Valid", AdminEventBulkEditRequest{
+			Filter:   AdminEventBulkEditFilter{SourceURL: "https://example.org/classes"},
+			Patch:    AdminEventFieldPatch{LocationName: "Ballard Library"},
+			EditedBy: "admin",
+		}, false},
+		{"NoFilter", AdminEventBulkEditRequest{
+			Patch:    AdminEventFieldPatch{LocationName: "Ballard Library"},
+			EditedBy: "admin",
+		}, true},
+		{"EmptyPatch", AdminEventBulkEditRequest{
+			Filter:   AdminEventBulkEditFilter{SubmissionID: "sub-1"},
+			Patch:    AdminEventFieldPatch{LocationName: "  "},
+			EditedBy: "admin",
+		}, true},
+		{"InvalidCategory", AdminEventBulkEditRequest{
+			Filter:   AdminEventBulkEditFilter{SubmissionID: "sub-1"},
+			Patch:    AdminEventFieldPatch{Category: "sports"},
+			EditedBy: "admin",
+		}, true},
+		{"Tags", AdminEventBulkEditRequest{
+			Filter:   AdminEventBulkEditFilter{SubmissionID: "sub-1"},
+			Patch:    AdminEventFieldPatch{Tags: []string{"Spanish Immersion", "outdoor"}},
+			EditedBy: "admin",
+		}, false},
+		{"InvalidTag", AdminEventBulkEditRequest{
+			Filter:   AdminEventBulkEditFilter{SubmissionID: "sub-1"},
+			Patch:    AdminEventFieldPatch{Tags: []string{"art & craft"}},
+			EditedBy: "admin",
+		}, true},
+		{"NoEditor", AdminEventBulkEditRequest{
+			Filter: AdminEventBulkEditFilter{SubmissionID: "sub-1"},
+			Patch:  AdminEventFieldPatch{Category: CategoryActiveSports},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAdminEventFieldPatchApplyToRawData(t *testing.T) {
+	patch := AdminEventFieldPatch{LocationName: "Ballard Library", Category: CategoryEducationalSTEM}
+
+	rawData := map[string]interface{}{
+		"events": []interface{}{
+			map[string]interface{}{"title": "Lego Lab", "location": ""},
+			map[string]interface{}{"title": "Coding Club", "category": "tech"},
+			"not an event",
+		},
+		"page_title": "Library classes",
+	}
+	if patched := patch.ApplyToRawData(rawData); patched != 2 {
+		t.Errorf("Expected 2 events patched, got %d", patched)
+	}
+	for _, item := range rawData["events"].([]interface{})[:2] {
+		eventData := item.(map[string]interface{})
+		if eventData["location"] != "Ballard Library" || eventData["category"] != CategoryEducationalSTEM {
+			t.Errorf("Expected patched fields, got %v", eventData)
+		}
+	}
+	if _, ok := rawData["location"]; ok {
+		t.Errorf("Expected the top level of data with events to be left alone")
+	}
+
+	// Data without an events array is a single event
+	single := map[string]interface{}{"title": "Lego Lab"}
+	if patched := (&AdminEventFieldPatch{LocationName: "Ballard Library"}).ApplyToRawData(single); patched != 1 || single["location"] != "Ballard Library" {
+		t.Errorf("Expected the single event to be patched, got %d %v", patched, single)
+	}
+	if _, ok := single["category"]; ok {
+		t.Errorf("Expected unset patch fields to be left alone")
+	}
+
+	tagged := map[string]interface{}{"title": "Lego Lab"}
+	(&AdminEventFieldPatch{Tags: []string{"outdoor", "stem"}}).ApplyToRawData(tagged)
+	if tags, ok := tagged["tags"].([]interface{}); !ok || len(tags) != 2 || tags[0] != "outdoor" {
+		t.Errorf("Expected the tags to be set as a list, got %v", tagged["tags"])
+	}
+}
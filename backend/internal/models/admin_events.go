@@ -1,9 +1,13 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
+
+	"seattle-family-activities-scraper/internal/urlutil"
 )
 
 // AdminEvent represents an event extracted via admin crawling that's pending approval
@@ -15,19 +19,23 @@ type AdminEvent struct {
 	// Core Fields
 	EventID            string                 `json:"event_id"`
 	SourceURL          string                 `json:"source_url"`
-	SchemaType         string                 `json:"schema_type"`         // "events"|"activities"|"venues"|"custom"
+	SchemaType         string                 `json:"schema_type"`         // "events"|"activities"|"camps"|"classes"|"venues"|"custom"
 	SchemaUsed         map[string]interface{} `json:"schema_used"`         // Actual schema sent to Firecrawl
 	RawExtractedData   map[string]interface{} `json:"raw_extracted_data"`  // Original Firecrawl response
 	ConvertedData      map[string]interface{} `json:"converted_data"`      // Preview of Activity conversion
 	ConversionIssues   []string               `json:"conversion_issues"`   // Validation warnings
+	BrokenLinks        []string               `json:"broken_links,omitempty"` // URLs that failed link health checks
+	ConfidenceScore    float64                `json:"confidence_score"`       // confidence of the conversion preview, 0 - 100
+	CompletenessScore  float64                `json:"completeness_score"`     // completeness of the converted activity, 0.0 - 1.0
 
 	// Status and Review
 	Status     AdminEventStatus `json:"status"`      // pending, approved, rejected, edited
-	StatusKey  string           `json:"status_key"`  // GSI key for status queries
+	StatusKey  string           `json:"status_key"`  // GSI key for status queries, STATUS#{status}#{shard}
 	AdminNotes string           `json:"admin_notes"` // Admin comments/notes
 
 	// Timestamps
 	ExtractedAt time.Time  `json:"extracted_at"`
+	QueuedAt    *time.Time `json:"queued_at,omitempty"` // last entered the review queue; unset on events stored before it was tracked
 	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
 	ReviewedBy  string     `json:"reviewed_by,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
@@ -36,16 +44,46 @@ type AdminEvent struct {
 	// Metadata
 	ExtractedByUser string `json:"extracted_by_user"` // Who submitted the crawl request
 	SubmissionID    string `json:"submission_id"`     // Unique submission identifier
+	ProviderID      string `json:"provider_id,omitempty"` // Set when a provider submitted the event directly
+	ActivityID      string `json:"activity_id,omitempty"` // Published activity, kept across re-approvals
+
+	// Where the event's location was geocoded to when it was published without coordinates, used
+	// by later conversions while the location is described the same way
+	Geocoded *GeocodedLocation `json:"geocoded,omitempty"`
+
+	// Provider update or cancellation of the published event awaiting admin review
+	PendingChange *ProviderEventChange `json:"pending_change,omitempty"`
+
+	// Visibility window: an approved event only surfaces publicly from PublishAt until UnpublishAt,
+	// so seasonal content can be loaded and approved early. Hidden is set while an approved event is
+	// outside its window and its activity is withdrawn; the visibility refresh flips it.
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	Hidden      bool       `json:"hidden,omitempty"`
+
+	// Cached review diagnostics, recomputed when the data they were built from changes
+	Diagnostics *ConversionDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// ConversionDiagnostics holds the conversion details and quality assessment shown to reviewers.
+// Building them re-runs conversion, so they are stored on the event and reused while
+// InputHash still matches the event's data.
+type ConversionDiagnostics struct {
+	InputHash         string                 `json:"input_hash"`
+	ConversionDetails map[string]interface{} `json:"conversion_details"`
+	QualityAssessment map[string]interface{} `json:"quality_assessment"`
+	ComputedAt        time.Time              `json:"computed_at"`
 }
 
 // AdminEventStatus represents the status of an admin event
 type AdminEventStatus string
 
 const (
-	AdminEventStatusPending  AdminEventStatus = "pending"
-	AdminEventStatusApproved AdminEventStatus = "approved"
-	AdminEventStatusRejected AdminEventStatus = "rejected"
-	AdminEventStatusEdited   AdminEventStatus = "edited"
+	AdminEventStatusPending   AdminEventStatus = "pending"
+	AdminEventStatusApproved  AdminEventStatus = "approved"
+	AdminEventStatusRejected  AdminEventStatus = "rejected"
+	AdminEventStatusEdited    AdminEventStatus = "edited"
+	AdminEventStatusCancelled AdminEventStatus = "cancelled" // withdrawn by the provider that submitted it
 )
 
 // AdminEventType represents the type of admin event
@@ -67,17 +105,19 @@ type ExtractionSchema struct {
 // CrawlSubmissionRequest represents a request to crawl a website
 type CrawlSubmissionRequest struct {
 	URL              string                 `json:"url"`
-	SchemaType       string                 `json:"schema_type"`         // "events"|"activities"|"venues"|"custom"
+	SchemaType       string                 `json:"schema_type"`         // "events"|"activities"|"camps"|"classes"|"venues"|"custom"
 	CustomSchema     map[string]interface{} `json:"custom_schema,omitempty"` // Only used if schema_type = "custom"
 	ExtractedByUser  string                 `json:"extracted_by_user"`
 	AdminNotes       string                 `json:"admin_notes,omitempty"`
+	JobID            string                 `json:"job_id,omitempty"`        // Optional client-generated ID for following progress
 }
 
 // DebugExtractionRequest represents a request for debug extraction
 type DebugExtractionRequest struct {
 	URL          string                 `json:"url"`
-	SchemaType   string                 `json:"schema_type"`         // "events"|"activities"|"venues"|"custom"
+	SchemaType   string                 `json:"schema_type"`         // "events"|"activities"|"camps"|"classes"|"venues"|"custom"
 	CustomSchema map[string]interface{} `json:"custom_schema,omitempty"` // Only used if schema_type = "custom"
+	JobID        string                 `json:"job_id,omitempty"`        // Optional client-generated ID for following progress
 }
 
 // AdminEventReview represents a review action on an admin event
@@ -88,6 +128,25 @@ type AdminEventReview struct {
 	ReviewedBy string                 `json:"reviewed_by"`
 }
 
+// AdminEventVisibilityRequest sets or clears an event's visibility window. A missing time
+// clears that side of the window.
+type AdminEventVisibilityRequest struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	UpdatedBy   string     `json:"updated_by"`
+}
+
+// Validate checks that the window closes after it opens
+func (r *AdminEventVisibilityRequest) Validate() error {
+	if r.UpdatedBy == "" {
+		return fmt.Errorf("updated_by is required")
+	}
+	if r.PublishAt != nil && r.UnpublishAt != nil && !r.UnpublishAt.After(*r.PublishAt) {
+		return fmt.Errorf("unpublish_at must be after publish_at")
+	}
+	return nil
+}
+
 // ConversionResult represents the result of converting raw data to Activity model
 type ConversionResult struct {
 	Activity         *Activity `json:"activity"`
@@ -136,9 +195,9 @@ func CreateAdminEventSK(timestamp time.Time) string {
 	return fmt.Sprintf("SUBMISSION#%s", timestamp.Format("2006-01-02T15:04:05Z"))
 }
 
-// GenerateAdminEventStatusKey creates a GSI key for querying by status
-func GenerateAdminEventStatusKey(status AdminEventStatus) string {
-	return fmt.Sprintf("STATUS#%s", string(status))
+// GenerateAdminEventStatusKey creates a GSI key for querying by status, sharded by event
+func GenerateAdminEventStatusKey(status AdminEventStatus, eventID string) string {
+	return ShardGSIKey(fmt.Sprintf("STATUS#%s", string(status)), eventID)
 }
 
 // CreateSourceDeletionEventPK creates the primary key for a source deletion event
@@ -184,7 +243,7 @@ func (ae *AdminEvent) Validate() error {
 
 	// Validate status
 	switch ae.Status {
-	case AdminEventStatusPending, AdminEventStatusApproved, AdminEventStatusRejected, AdminEventStatusEdited:
+	case AdminEventStatusPending, AdminEventStatusApproved, AdminEventStatusRejected, AdminEventStatusEdited, AdminEventStatusCancelled:
 		// Valid statuses
 	default:
 		return fmt.Errorf("invalid status: %s", ae.Status)
@@ -192,7 +251,7 @@ func (ae *AdminEvent) Validate() error {
 
 	// Validate schema type
 	switch ae.SchemaType {
-	case "events", "activities", "venues", "custom":
+	case "events", "activities", "camps", "classes", "venues", "custom":
 		// Valid schema types
 	default:
 		return fmt.Errorf("invalid schema_type: %s", ae.SchemaType)
@@ -214,13 +273,13 @@ func (csr *CrawlSubmissionRequest) Validate() error {
 	}
 
 	// Validate URL format
-	if !strings.HasPrefix(csr.URL, "http://") && !strings.HasPrefix(csr.URL, "https://") {
-		return fmt.Errorf("url must start with http:// or https://")
+	if err := urlutil.Validate(csr.URL); err != nil {
+		return fmt.Errorf("invalid url: %w", err)
 	}
 
 	// Validate schema type
 	switch csr.SchemaType {
-	case "events", "activities", "venues", "custom":
+	case "events", "activities", "camps", "classes", "venues", "custom":
 		// Valid schema types
 	default:
 		return fmt.Errorf("invalid schema_type: %s", csr.SchemaType)
@@ -277,11 +336,81 @@ func (ae *AdminEvent) IsPending() bool {
 	return ae.Status == AdminEventStatusPending || ae.Status == AdminEventStatusEdited
 }
 
+// QueueEntryTime returns when the event last entered the review queue, falling back to its
+// extraction for events stored before queue entry was tracked
+func (ae *AdminEvent) QueueEntryTime() time.Time {
+	if ae.QueuedAt != nil {
+		return *ae.QueuedAt
+	}
+	return ae.ExtractedAt
+}
+
+// ApprovalTime returns when an approved event was approved, falling back to its extraction for
+// events approved without a review time. An event approved ahead of its publish time counts as
+// approved when it is published, so feeds of new activities list it when it surfaces.
+func (ae *AdminEvent) ApprovalTime() time.Time {
+	approvedAt := ae.ExtractedAt
+	if ae.ReviewedAt != nil {
+		approvedAt = *ae.ReviewedAt
+	}
+	if ae.PublishAt != nil && ae.PublishAt.After(approvedAt) {
+		return *ae.PublishAt
+	}
+	return approvedAt
+}
+
+// InVisibilityWindow reports whether the event's visibility window is open at now. An event
+// without a window is always in it.
+func (ae *AdminEvent) InVisibilityWindow(now time.Time) bool {
+	if ae.PublishAt != nil && now.Before(*ae.PublishAt) {
+		return false
+	}
+	return ae.UnpublishAt == nil || now.Before(*ae.UnpublishAt)
+}
+
+// IsPubliclyVisible returns true if the event's activity may be shown publicly at now: it is
+// approved, not withdrawn, and within its visibility window
+func (ae *AdminEvent) IsPubliclyVisible(now time.Time) bool {
+	return ae.IsApproved() && !ae.Hidden && ae.InVisibilityWindow(now)
+}
+
+// HasPendingChange returns true if a provider change to the published event awaits review
+func (ae *AdminEvent) HasPendingChange() bool {
+	return ae.PendingChange != nil
+}
+
 // CanBeApproved returns true if the event can be approved
 func (ae *AdminEvent) CanBeApproved() bool {
 	return ae.IsPending() && len(ae.ConversionIssues) == 0
 }
 
+// DiagnosticsInputHash fingerprints the data the review diagnostics are built from
+func (ae *AdminEvent) DiagnosticsInputHash() string {
+	// encoding/json sorts map keys, so equal data always hashes the same
+	data, err := json.Marshal(struct {
+		SchemaType       string                 `json:"schema_type"`
+		RawExtractedData map[string]interface{} `json:"raw_extracted_data"`
+		ConvertedData    map[string]interface{} `json:"converted_data"`
+		ConversionIssues []string               `json:"conversion_issues"`
+	}{ae.SchemaType, ae.RawExtractedData, ae.ConvertedData, ae.ConversionIssues})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CachedDiagnostics returns the stored diagnostics if they are still current, or nil
+func (ae *AdminEvent) CachedDiagnostics() *ConversionDiagnostics {
+	if ae.Diagnostics == nil || ae.Diagnostics.InputHash == "" {
+		return nil
+	}
+	if ae.Diagnostics.InputHash != ae.DiagnosticsInputHash() {
+		return nil
+	}
+	return ae.Diagnostics
+}
+
 // GetExtractedEventsCount returns the number of events extracted
 func (ae *AdminEvent) GetExtractedEventsCount() int {
 	// Try to count events in various possible structures
@@ -291,6 +420,12 @@ func (ae *AdminEvent) GetExtractedEventsCount() int {
 	if activities, ok := ae.RawExtractedData["activities"].([]interface{}); ok {
 		return len(activities)
 	}
+	if camps, ok := ae.RawExtractedData["camps"].([]interface{}); ok {
+		return len(camps)
+	}
+	if classes, ok := ae.RawExtractedData["classes"].([]interface{}); ok {
+		return len(classes)
+	}
 	if venues, ok := ae.RawExtractedData["venues"].([]interface{}); ok {
 		return len(venues)
 	}
@@ -351,6 +486,18 @@ func GetPredefinedSchemas() map[string]ExtractionSchema {
 									"type":        "string",
 									"description": "URL for registration or more information",
 								},
+								"registration_required": map[string]interface{}{
+									"type":        "boolean",
+									"description": "Whether registration is required",
+								},
+								"participation": map[string]interface{}{
+									"type":        "string",
+									"description": "How to attend as written on the page, e.g. 'drop-in', 'registration required', 'tickets required', 'sold out'",
+								},
+								"arrival_info": map[string]interface{}{
+									"type":        "string",
+									"description": "Parking and arrival notes as written on the page, e.g. 'street parking only', 'enter via the north gate'",
+								},
 								"age_groups": map[string]interface{}{
 									"type": "array",
 									"items": map[string]interface{}{
@@ -422,6 +569,14 @@ func GetPredefinedSchemas() map[string]ExtractionSchema {
 									"type":        "boolean",
 									"description": "Whether registration is required",
 								},
+								"participation": map[string]interface{}{
+									"type":        "string",
+									"description": "How to attend as written on the page, e.g. 'drop-in', 'registration required', 'tickets required', 'sold out'",
+								},
+								"arrival_info": map[string]interface{}{
+									"type":        "string",
+									"description": "Parking and arrival notes as written on the page, e.g. 'street parking only', 'enter via the north gate'",
+								},
 							},
 							"required": []string{"name", "age_groups"},
 						},
@@ -436,6 +591,197 @@ func GetPredefinedSchemas() map[string]ExtractionSchema {
 				"Camp programs",
 			},
 		},
+		"camps": {
+			Name:        "Camps",
+			Description: "Extract camps with session weeks, before/after care, and weekly pricing",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"camps": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"name": map[string]interface{}{
+									"type":        "string",
+									"description": "The name of the camp",
+								},
+								"description": map[string]interface{}{
+									"type":        "string",
+									"description": "What campers do at the camp",
+								},
+								"sessions": map[string]interface{}{
+									"type": "array",
+									"items": map[string]interface{}{
+										"type": "object",
+										"properties": map[string]interface{}{
+											"label": map[string]interface{}{
+												"type":        "string",
+												"description": "Session or week name, e.g. 'Week 1: Ocean Explorers'",
+											},
+											"start_date": map[string]interface{}{
+												"type":        "string",
+												"description": "First day of the session in YYYY-MM-DD format",
+											},
+											"end_date": map[string]interface{}{
+												"type":        "string",
+												"description": "Last day of the session in YYYY-MM-DD format",
+											},
+										},
+									},
+									"description": "Every week or session the camp runs, in page order",
+								},
+								"time": map[string]interface{}{
+									"type":        "string",
+									"description": "Daily start time in HH:MM format",
+								},
+								"location": map[string]interface{}{
+									"type":        "string",
+									"description": "Camp location or venue name",
+								},
+								"address": map[string]interface{}{
+									"type":        "string",
+									"description": "Full address of the camp location",
+								},
+								"price_per_week": map[string]interface{}{
+									"type":        "string",
+									"description": "Price of one week of camp, e.g. '$350'",
+								},
+								"price": map[string]interface{}{
+									"type":        "string",
+									"description": "Price as written when it is not given per week",
+								},
+								"before_care": map[string]interface{}{
+									"type":        "boolean",
+									"description": "Whether early drop-off (before care) is offered",
+								},
+								"after_care": map[string]interface{}{
+									"type":        "boolean",
+									"description": "Whether late pick-up (after care) is offered",
+								},
+								"care_details": map[string]interface{}{
+									"type":        "string",
+									"description": "Extended care hours and cost as written, e.g. 'Before care 7:30-9 AM, $50/week'",
+								},
+								"age_groups": map[string]interface{}{
+									"type": "array",
+									"items": map[string]interface{}{
+										"type": "string",
+									},
+									"description": "Ages or grades the camp is for",
+								},
+								"registration_url": map[string]interface{}{
+									"type":        "string",
+									"description": "URL for registration",
+								},
+								"participation": map[string]interface{}{
+									"type":        "string",
+									"description": "How to attend as written on the page, e.g. 'registration required', 'waitlist', 'sold out'",
+								},
+								"arrival_info": map[string]interface{}{
+									"type":        "string",
+									"description": "Parking and arrival notes as written on the page, e.g. 'street parking only', 'enter via the north gate'",
+								},
+							},
+							"required": []string{"name", "sessions"},
+						},
+					},
+				},
+				"required": []string{"camps"},
+			},
+			Examples: []string{
+				"Summer camp listings",
+				"School break camps",
+				"Day camp catalogs",
+			},
+		},
+		"classes": {
+			Name:        "Classes",
+			Description: "Extract classes with skill level, instructor, and schedule",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"classes": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"name": map[string]interface{}{
+									"type":        "string",
+									"description": "The name of the class",
+								},
+								"description": map[string]interface{}{
+									"type":        "string",
+									"description": "What the class covers",
+								},
+								"skill_level": map[string]interface{}{
+									"type":        "string",
+									"description": "Skill level: 'beginner', 'intermediate', 'advanced' or 'all levels'",
+								},
+								"instructor": map[string]interface{}{
+									"type":        "string",
+									"description": "Name of the instructor",
+								},
+								"start_date": map[string]interface{}{
+									"type":        "string",
+									"description": "First class in YYYY-MM-DD format",
+								},
+								"time": map[string]interface{}{
+									"type":        "string",
+									"description": "Class start time in HH:MM format",
+								},
+								"schedule": map[string]interface{}{
+									"type":        "string",
+									"description": "When the class meets (e.g., 'Saturdays 10 AM for 8 weeks')",
+								},
+								"duration": map[string]interface{}{
+									"type":        "string",
+									"description": "Length of one class (e.g., '45 minutes')",
+								},
+								"location": map[string]interface{}{
+									"type":        "string",
+									"description": "Where the class meets",
+								},
+								"address": map[string]interface{}{
+									"type":        "string",
+									"description": "Full address of the class location",
+								},
+								"cost": map[string]interface{}{
+									"type":        "string",
+									"description": "Cost of the class or series, or 'Free'",
+								},
+								"age_groups": map[string]interface{}{
+									"type": "array",
+									"items": map[string]interface{}{
+										"type": "string",
+									},
+									"description": "Ages the class is for",
+								},
+								"registration_url": map[string]interface{}{
+									"type":        "string",
+									"description": "URL for registration",
+								},
+								"participation": map[string]interface{}{
+									"type":        "string",
+									"description": "How to attend as written on the page, e.g. 'drop-in', 'registration required', 'sold out'",
+								},
+								"arrival_info": map[string]interface{}{
+									"type":        "string",
+									"description": "Parking and arrival notes as written on the page, e.g. 'street parking only', 'enter via the north gate'",
+								},
+							},
+							"required": []string{"name"},
+						},
+					},
+				},
+				"required": []string{"classes"},
+			},
+			Examples: []string{
+				"Class catalogs",
+				"Lesson schedules",
+				"Community center course listings",
+			},
+		},
 		"venues": {
 			Name:        "Venues",
 			Description: "Extract venue information with name, address, and facilities",
@@ -485,6 +831,10 @@ func GetPredefinedSchemas() map[string]ExtractionSchema {
 									"type":        "string",
 									"description": "Admission fee or 'Free'",
 								},
+								"arrival_info": map[string]interface{}{
+									"type":        "string",
+									"description": "Parking and arrival notes as written on the page, e.g. 'street parking only', 'enter via the north gate'",
+								},
 							},
 							"required": []string{"name", "address"},
 						},
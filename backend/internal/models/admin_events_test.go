@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdminEventCachedDiagnostics(t *testing.T) {
+	event := &AdminEvent{
+		SchemaType:       "events",
+		RawExtractedData: map[string]interface{}{"events": []interface{}{map[string]interface{}{"title": "Story Time"}}},
+		ConvertedData:    map[string]interface{}{"title": "Story Time"},
+	}
+	if event.CachedDiagnostics() != nil {
+		t.Fatal("Expected no cached diagnostics before they are computed")
+	}
+
+	event.Diagnostics = &ConversionDiagnostics{InputHash: event.DiagnosticsInputHash()}
+	if event.CachedDiagnostics() == nil {
+		t.Fatal("Expected diagnostics to be current for unchanged data")
+	}
+
+	event.ConvertedData["title"] = "Toddler Story Time"
+	if event.CachedDiagnostics() != nil {
+		t.Error("Expected an edit to invalidate the cached diagnostics")
+	}
+
+	event.Diagnostics.InputHash = event.DiagnosticsInputHash()
+	event.RawExtractedData["events"] = []interface{}{}
+	if event.CachedDiagnostics() != nil {
+		t.Error("Expected new raw data to invalidate the cached diagnostics")
+	}
+}
+
+func TestAdminEventVisibilityWindow(t *testing.T) {
+	reviewedAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	publishAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	unpublishAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	event := &AdminEvent{Status: AdminEventStatusApproved, ReviewedAt: &reviewedAt, PublishAt: &publishAt, UnpublishAt: &unpublishAt}
+
+	if event.InVisibilityWindow(reviewedAt) || event.IsPubliclyVisible(reviewedAt) {
+		t.Error("Expected the event to stay hidden before its publish time")
+	}
+	if !event.IsPubliclyVisible(publishAt) {
+		t.Error("Expected the event to be visible from its publish time")
+	}
+	if event.InVisibilityWindow(unpublishAt) {
+		t.Error("Expected the window to close at its unpublish time")
+	}
+	if !event.ApprovalTime().Equal(publishAt) {
+		t.Errorf("Expected an event approved early to count as approved when published, got %v", event.ApprovalTime())
+	}
+
+	event.Hidden = true
+	if event.IsPubliclyVisible(publishAt) {
+		t.Error("Expected a withdrawn event to stay hidden until the refresh publishes it")
+	}
+
+	open := &AdminEvent{Status: AdminEventStatusApproved, ReviewedAt: &reviewedAt}
+	if !open.IsPubliclyVisible(reviewedAt) || !open.ApprovalTime().Equal(reviewedAt) {
+		t.Error("Expected an event without a window to be visible once approved")
+	}
+}
+
+func TestAdminEventVisibilityRequestValidate(t *testing.T) {
+	publishAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := AdminEventVisibilityRequest{PublishAt: &publishAt, UpdatedBy: "admin"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected an open-ended window to be valid, got %v", err)
+	}
+
+	backwards := AdminEventVisibilityRequest{PublishAt: &publishAt, UnpublishAt: &publishAt, UpdatedBy: "admin"}
+	if err := backwards.Validate(); err == nil {
+		t.Error("Expected a window that closes when it opens to be rejected")
+	}
+	if err := (&AdminEventVisibilityRequest{}).Validate(); err == nil {
+		t.Error("Expected updated_by to be required")
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// NotificationRetention is how long notifications and the receipts of admins who read them are kept
+const NotificationRetention = 30 * 24 * time.Hour
+
+// Notification kinds
+const (
+	NotificationKindAnalysisComplete = "analysis_complete" // a source's analysis finished and is ready for review
+	NotificationKindScrapeFailure    = "scrape_failure"    // a run failed, a task stalled or was dead-lettered, or FireCrawl refused the account
+	NotificationKindSuggestion       = "suggestion"        // re-analysis proposed new selectors for a source
+	NotificationKindSLABreach        = "sla_breach"        // pending events waited longer than the review latency SLO
+)
+
+// notificationKinds lists the valid notification kinds
+var notificationKinds = map[string]bool{
+	NotificationKindAnalysisComplete: true,
+	NotificationKindScrapeFailure:    true,
+	NotificationKindSuggestion:       true,
+	NotificationKindSLABreach:        true,
+}
+
+// IsValidNotificationKind reports whether kind is one of the notification kinds
+func IsValidNotificationKind(kind string) bool {
+	return notificationKinds[kind]
+}
+
+// AdminNotification is a notable event kept in the admin inbox, so it is not lost when the push
+// notification sent for it is missed. Notifications are shared by all admins; each admin's read
+// state is kept in the NotificationReceipts of their API key.
+type AdminNotification struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // NOTIFICATIONS
+	SK string `json:"-" dynamodbav:"SK"` // NOTIFICATION#{notification_id}
+
+	NotificationID string    `json:"notification_id" dynamodbav:"notification_id"` // starts with the creation time, so IDs sort oldest first
+	Kind           string    `json:"kind" dynamodbav:"kind"`
+	Subject        string    `json:"subject" dynamodbav:"subject"`
+	Message        string    `json:"message" dynamodbav:"message"`
+	SourceID       string    `json:"source_id,omitempty" dynamodbav:"source_id,omitempty"`
+	RunID          string    `json:"run_id,omitempty" dynamodbav:"run_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at" dynamodbav:"created_at"`
+	TTL            int64     `json:"-" dynamodbav:"TTL"`
+
+	// Read state of the admin the notification is listed for; not stored with the notification
+	Read   bool       `json:"read" dynamodbav:"-"`
+	ReadAt *time.Time `json:"read_at,omitempty" dynamodbav:"-"`
+}
+
+// NewAdminNotification creates a notification of kind raised at now
+func NewAdminNotification(kind, subject, message string, now time.Time) *AdminNotification {
+	suffix := make([]byte, 4)
+	rand.Read(suffix) // only tells apart notifications created in the same millisecond
+
+	notificationID := now.UTC().Format("20060102T150405.000Z") + "-" + hex.EncodeToString(suffix)
+	return &AdminNotification{
+		PK:             CreateNotificationsPK(),
+		SK:             CreateNotificationSK(notificationID),
+		NotificationID: notificationID,
+		Kind:           kind,
+		Subject:        subject,
+		Message:        message,
+		CreatedAt:      now,
+		TTL:            now.Add(NotificationRetention).Unix(),
+	}
+}
+
+// NotificationReceipt records that an admin read a notification. Read state belongs to the admin's
+// API key, so it cannot be read or changed with another key. It expires with the notification.
+type NotificationReceipt struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // ADMIN#{key_id}
+	SK string `json:"-" dynamodbav:"SK"` // NOTIFICATION_READ#{notification_id}
+
+	KeyID          string    `json:"key_id" dynamodbav:"key_id"`
+	Admin          string    `json:"admin" dynamodbav:"admin"` // name of the key
+	NotificationID string    `json:"notification_id" dynamodbav:"notification_id"`
+	ReadAt         time.Time `json:"read_at" dynamodbav:"read_at"`
+	TTL            int64     `json:"-" dynamodbav:"TTL"`
+}
+
+// NewNotificationReceipt records that the admin holding key read notification at now
+func NewNotificationReceipt(key *AdminAPIKey, notification *AdminNotification, now time.Time) *NotificationReceipt {
+	return &NotificationReceipt{
+		PK:             CreateNotificationReceiptPK(key.KeyID),
+		SK:             CreateNotificationReceiptSK(notification.NotificationID),
+		KeyID:          key.KeyID,
+		Admin:          key.Name,
+		NotificationID: notification.NotificationID,
+		ReadAt:         now,
+		TTL:            notification.TTL,
+	}
+}
+
+// ApplyNotificationReceipts marks the notifications an admin has receipts for as read and returns
+// how many are still unread
+func ApplyNotificationReceipts(notifications []AdminNotification, receipts []NotificationReceipt) int {
+	readAt := make(map[string]time.Time, len(receipts))
+	for _, receipt := range receipts {
+		readAt[receipt.NotificationID] = receipt.ReadAt
+	}
+
+	unread := 0
+	for i := range notifications {
+		notification := &notifications[i]
+		if at, ok := readAt[notification.NotificationID]; ok {
+			notification.Read = true
+			notification.ReadAt = &at
+		} else {
+			unread++
+		}
+	}
+	return unread
+}
+
+// Helper functions to create the keys of notifications and their receipts
+func CreateNotificationsPK() string {
+	return "NOTIFICATIONS"
+}
+
+func CreateNotificationSK(notificationID string) string {
+	return "NOTIFICATION#" + notificationID
+}
+
+func CreateNotificationReceiptPK(keyID string) string {
+	return "ADMIN#" + keyID
+}
+
+func CreateNotificationReceiptSK(notificationID string) string {
+	return "NOTIFICATION_READ#" + notificationID
+}
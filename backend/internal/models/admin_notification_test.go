@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAdminNotification(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+
+	notification := NewAdminNotification(NotificationKindScrapeFailure, "Run failed", "Seattle Parks failed", now)
+
+	if !strings.HasPrefix(notification.NotificationID, "20261016T180000.000Z-") {
+		t.Errorf("Expected the ID to start with the creation time, got %q", notification.NotificationID)
+	}
+	if notification.PK != "NOTIFICATIONS" || notification.SK != "NOTIFICATION#"+notification.NotificationID {
+		t.Errorf("Unexpected keys %s / %s", notification.PK, notification.SK)
+	}
+	if notification.TTL != now.Add(NotificationRetention).Unix() {
+		t.Errorf("Expected the notification to expire after the retention, got %d", notification.TTL)
+	}
+
+	later := NewAdminNotification(NotificationKindSLABreach, "SLO breached", "", now.Add(time.Millisecond))
+	if later.NotificationID <= notification.NotificationID {
+		t.Errorf("Expected later notifications to sort after earlier ones, got %q and %q", notification.NotificationID, later.NotificationID)
+	}
+	if again := NewAdminNotification(NotificationKindScrapeFailure, "Run failed", "", now); again.NotificationID == notification.NotificationID {
+		t.Errorf("Expected notifications created at the same time to get different IDs, got %q twice", again.NotificationID)
+	}
+
+	key := &AdminAPIKey{KeyID: "key-1", Name: "editor@example.com"}
+	receipt := NewNotificationReceipt(key, notification, now.Add(time.Hour))
+	if receipt.PK != "ADMIN#key-1" || receipt.SK != "NOTIFICATION_READ#"+notification.NotificationID {
+		t.Errorf("Unexpected receipt keys %s / %s", receipt.PK, receipt.SK)
+	}
+	if receipt.KeyID != "key-1" || receipt.Admin != "editor@example.com" {
+		t.Errorf("Expected the receipt to name the key, got %+v", receipt)
+	}
+	if receipt.TTL != notification.TTL {
+		t.Errorf("Expected the receipt to expire with the notification, got %d", receipt.TTL)
+	}
+}
+
+func TestApplyNotificationReceipts(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	notifications := []AdminNotification{
+		*NewAdminNotification(NotificationKindSuggestion, "New selectors", "", now),
+		*NewAdminNotification(NotificationKindAnalysisComplete, "Analysis complete", "", now),
+		*NewAdminNotification(NotificationKindSLABreach, "SLO breached", "", now),
+	}
+	receipts := []NotificationReceipt{
+		*NewNotificationReceipt(&AdminAPIKey{KeyID: "key-1", Name: "editor@example.com"}, &notifications[1], now.Add(time.Hour)),
+	}
+
+	unread := ApplyNotificationReceipts(notifications, receipts)
+
+	if unread != 2 {
+		t.Errorf("Expected 2 unread notifications, got %d", unread)
+	}
+	if !notifications[1].Read || notifications[1].ReadAt == nil || !notifications[1].ReadAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected the notification with a receipt to be read, got %+v", notifications[1])
+	}
+	if notifications[0].Read || notifications[0].ReadAt != nil || notifications[2].Read {
+		t.Errorf("Expected the other notifications to be unread, got %+v and %+v", notifications[0], notifications[2])
+	}
+}
+
+func TestIsValidNotificationKind(t *testing.T) {
+	for _, kind := range []string{"analysis_complete", "scrape_failure", "suggestion", "sla_breach"} {
+		if !IsValidNotificationKind(kind) {
+			t.Errorf("Expected %q to be valid", kind)
+		}
+	}
+	if IsValidNotificationKind("digest") {
+		t.Error("Expected an unknown kind to be invalid")
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// openEndedMaxAgeYears is the upper bound conversion uses for "and up" style ranges, e.g. adults 18-99
+const openEndedMaxAgeYears = 99
+
+// MonthsRange returns the age group's range in months. max is inclusive of the whole last
+// year, so a 3-5 year range ends at 71 months. openEnded is true for "and up" ranges, which
+// have no maximum age or the conventional 99 years.
+func (g AgeGroup) MonthsRange() (min int, max int, openEnded bool) {
+	if strings.HasPrefix(strings.ToLower(g.Unit), "month") {
+		if g.MaxAge <= 0 {
+			return g.MinAge, 0, true
+		}
+		return g.MinAge, g.MaxAge, false
+	}
+
+	// Ages are in years unless stated otherwise
+	if g.MaxAge <= 0 && g.MinAge > 0 || g.MaxAge >= openEndedMaxAgeYears {
+		return g.MinAge * 12, 0, true
+	}
+	return g.MinAge * 12, g.MaxAge*12 + 11, false
+}
+
+// IncludesAgeMonths reports whether a child of the given age in months falls within the age group
+func (g AgeGroup) IncludesAgeMonths(months int) bool {
+	min, max, openEnded := g.MonthsRange()
+	return months >= min && (openEnded || months <= max)
+}
+
+// SuitableForAgeMonths reports whether any of the activity's age groups includes a child of the
+// given age in months. Activities without age groups are treated as open to all ages.
+func (a *Activity) SuitableForAgeMonths(months int) bool {
+	if len(a.AgeGroups) == 0 {
+		return true
+	}
+	for _, group := range a.AgeGroups {
+		if group.IncludesAgeMonths(months) {
+			return true
+		}
+	}
+	return false
+}
+
+// AgeInMonths returns how many whole months old a child born on birthdate is on the given day
+func AgeInMonths(birthdate, on time.Time) int {
+	months := (on.Year()-birthdate.Year())*12 + int(on.Month()) - int(birthdate.Month())
+	if on.Day() < birthdate.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
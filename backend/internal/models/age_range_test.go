@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivitySuitableForAgeMonths(t *testing.T) {
+	tests := []struct {
+		name      string
+		ageGroups []AgeGroup
+		months    int
+		want      bool
+	}{
+		{"infant range in months", []AgeGroup{{MinAge: 0, MaxAge: 12, Unit: "months"}}, 10, true},
+		{"older than infant range", []AgeGroup{{MinAge: 0, MaxAge: 12, Unit: "months"}}, 13, false},
+		{"within last year of range", []AgeGroup{{MinAge: 3, MaxAge: 5, Unit: "years"}}, 71, true},
+		{"past last year of range", []AgeGroup{{MinAge: 3, MaxAge: 5, Unit: "years"}}, 72, false},
+		{"younger than range", []AgeGroup{{MinAge: 3, MaxAge: 5, Unit: "years"}}, 30, false},
+		{"and up without max", []AgeGroup{{MinAge: 2, Unit: "years"}}, 200, true},
+		{"and up too young", []AgeGroup{{MinAge: 2, Unit: "years"}}, 20, false},
+		{"all ages", []AgeGroup{{Category: AgeGroupAllAges, MinAge: 0, MaxAge: 99, Unit: "years"}}, 30, true},
+		{"any group matches", []AgeGroup{{MinAge: 1, MaxAge: 2, Unit: "years"}, {MinAge: 6, MaxAge: 10, Unit: "years"}}, 80, true},
+		{"no age groups", nil, 30, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := Activity{AgeGroups: tt.ageGroups}
+			if got := activity.SuitableForAgeMonths(tt.months); got != tt.want {
+				t.Errorf("SuitableForAgeMonths(%d) = %v, want %v", tt.months, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgeInMonths(t *testing.T) {
+	birthdate := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		on   time.Time
+		want int
+	}{
+		{time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2025, 10, 14, 0, 0, 0, 0, time.UTC), 29},
+		{time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC), 30},
+		{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range tests {
+		if got := AgeInMonths(birthdate, tt.on); got != tt.want {
+			t.Errorf("AgeInMonths on %s = %d, want %d", tt.on.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
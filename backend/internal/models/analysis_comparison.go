@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SourceAnalysisComparison describes how a source's analysis changed between two versions
+type SourceAnalysisComparison struct {
+	SourceID        string    `json:"source_id"`
+	FromVersion     int       `json:"from_version"`
+	ToVersion       int       `json:"to_version"`
+	FromCompletedAt time.Time `json:"from_completed_at"`
+	ToCompletedAt   time.Time `json:"to_completed_at"`
+
+	QualityScore           NumberDelta `json:"quality_score"`            // overall quality score
+	ExtractionQualityScore NumberDelta `json:"extraction_quality_score"` // quality of the test extraction
+	ItemsFound             NumberDelta `json:"items_found"`              // items found by the test extraction
+
+	SelectorChanges []SelectorChange `json:"selector_changes"` // recommended selectors that changed
+	PagesAdded      []string         `json:"pages_added"`      // content pages only found by the newer analysis
+	PagesRemoved    []string         `json:"pages_removed"`    // content pages the newer analysis no longer found
+
+	FrequencyChange FrequencyChange `json:"frequency_change"`
+}
+
+// NumberDelta holds a value from both analyses and the change between them
+type NumberDelta struct {
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+	Change float64 `json:"change"`
+}
+
+// SelectorChange records a recommended selector that differs between analyses
+type SelectorChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// FrequencyChange records the recommended scraping frequency of both analyses
+type FrequencyChange struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Changed bool   `json:"changed"`
+}
+
+// NewNumberDelta creates a delta between two values
+func NewNumberDelta(from, to float64) NumberDelta {
+	return NumberDelta{From: from, To: to, Change: to - from}
+}
+
+// ParseAnalysisVersion parses an analysis version written as "v3" or "3"
+func ParseAnalysisVersion(value string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "v"))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid analysis version %q", value)
+	}
+	return version, nil
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Analytics time ranges, written like "24h", "7d" or "30d"
+const (
+	DefaultAnalyticsRange = "7d"
+	MaxAnalyticsRange     = 90 * 24 * time.Hour
+)
+
+// SourceAnalytics summarizes the sources and how well they were scraped over a time range.
+// Source counts cover every source; approvals cover the sources submitted in the range and
+// scraping covers the fan-out runs started in it.
+type SourceAnalytics struct {
+	Range string    `json:"range"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+
+	TotalSourcesSubmitted  int            `json:"total_sources_submitted"`
+	SourcesPendingAnalysis int            `json:"sources_pending_analysis"` // submitted or analyzed, awaiting an admin decision
+	SourcesActive          int            `json:"sources_active"`
+	SourcesRejected        int            `json:"sources_rejected"`
+	SourcesByStatus        map[string]int `json:"sources_by_status"`
+
+	Approvals SourceApprovalStats `json:"approvals"`
+	Scraping  ScrapeStats         `json:"scraping"`
+
+	SuccessRate     string `json:"success_rate"`     // scrape success rate as a percentage, "n/a" without scrapes
+	TotalActivities int    `json:"total_activities"` // found by the range's scrapes
+
+	SourceYield []SourceYield `json:"source_yield"` // most activities first
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// SourceApprovalStats counts the admin decisions on the sources submitted in the range
+type SourceApprovalStats struct {
+	Submitted    int     `json:"submitted"`
+	Approved     int     `json:"approved"` // activated, including sources since paused or deactivated
+	Rejected     int     `json:"rejected"`
+	ApprovalRate float64 `json:"approval_rate"` // of the approved and rejected sources, 0.0 - 1.0
+}
+
+// ScrapeStats totals the tasks of the finished fan-out runs started in the range
+type ScrapeStats struct {
+	Runs            int     `json:"runs"`
+	RunsInProgress  int     `json:"runs_in_progress"` // not counted until they finish
+	Tasks           int     `json:"tasks"`
+	FailedTasks     int     `json:"failed_tasks"`
+	SuccessRate     float64 `json:"success_rate"` // 0.0 - 1.0
+	ActivitiesFound int     `json:"activities_found"`
+	CreditsUsed     int     `json:"credits_used"`
+}
+
+// SourceYield measures how many activities a source's scrapes found in the range
+type SourceYield struct {
+	SourceID         string  `json:"source_id"`
+	SourceName       string  `json:"source_name"`
+	Runs             int     `json:"runs"`
+	Tasks            int     `json:"tasks"`
+	FailedTasks      int     `json:"failed_tasks"`
+	SuccessRate      float64 `json:"success_rate"` // 0.0 - 1.0
+	ActivitiesFound  int     `json:"activities_found"`
+	ActivitiesPerRun float64 `json:"activities_per_run"`
+}
+
+// ParseAnalyticsRange parses an analytics time range of whole hours or days, e.g. "24h" or "30d"
+func ParseAnalyticsRange(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	unit := time.Hour
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case !strings.HasSuffix(value, "h"):
+		return 0, fmt.Errorf("invalid range %q, expected hours or days like 24h or 7d", value)
+	}
+
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("invalid range %q, expected hours or days like 24h or 7d", value)
+	}
+	if count > int(MaxAnalyticsRange/unit) {
+		return 0, fmt.Errorf("range can be at most %d days", int(MaxAnalyticsRange.Hours()/24))
+	}
+	return time.Duration(count) * unit, nil
+}
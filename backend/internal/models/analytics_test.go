@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAnalyticsRange(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"24h", 24 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{" 30D ", 30 * 24 * time.Hour, false},
+		{"90d", MaxAnalyticsRange, false},
+		{"91d", 0, true},
+		{"2161h", 0, true},
+		{"99999999999999d", 0, true},
+		{"0d", 0, true},
+		{"-1d", 0, true},
+		{"1w", 0, true},
+		{"d", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseAnalyticsRange(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+}
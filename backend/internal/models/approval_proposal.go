@@ -0,0 +1,178 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Actions that need a second admin's confirmation under the two-person rule
+const (
+	ApprovalActionActivateSource       = "activate_source"        // activating a source auto-approval is on for
+	ApprovalActionBulkDeleteActivities = "bulk_delete_activities" // removing published activities in bulk
+)
+
+// Approval proposal status constants
+const (
+	ApprovalStatusPending   = "pending"   // waiting for a second admin
+	ApprovalStatusConfirmed = "confirmed" // confirmed, the action is being carried out
+	ApprovalStatusExecuted  = "executed"
+	ApprovalStatusFailed    = "failed" // confirmed, but the action failed
+	ApprovalStatusExpired   = "expired"
+)
+
+// Approval proposal audit action constants
+const (
+	ApprovalAuditProposed  = "proposed"
+	ApprovalAuditConfirmed = "confirmed"
+	ApprovalAuditExecuted  = "executed"
+	ApprovalAuditFailed    = "failed"
+	ApprovalAuditExpired   = "expired"
+)
+
+// ApprovalProposalTTL is how long a proposal waits for confirmation
+const ApprovalProposalTTL = 48 * time.Hour
+
+// ApprovalProposal is a high-impact action one admin proposed and a different admin must confirm
+// before it is carried out. The action's request is kept as sent, and is carried out on
+// confirmation as if the proposer had sent it then. Every step is recorded as an
+// ApprovalAuditEntry.
+type ApprovalProposal struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // APPROVALS
+	SK string `json:"-" dynamodbav:"SK"` // PROPOSAL#{proposal_id}
+
+	ProposalID string `json:"proposal_id" dynamodbav:"proposal_id"`
+	Action     string `json:"action" dynamodbav:"action"`
+	TargetID   string `json:"target_id,omitempty" dynamodbav:"target_id,omitempty"` // e.g. the source to activate
+	Summary    string `json:"summary" dynamodbav:"summary"`                         // what the action will do, for the confirming admin
+	Request    string `json:"request" dynamodbav:"request"`                         // the action's request body
+	Status     string `json:"status" dynamodbav:"status"`
+
+	// Admins are identified by their API keys, so the rule holds whatever names requests carry
+	ProposedBy      string    `json:"proposed_by" dynamodbav:"proposed_by"` // name of the proposer's API key
+	ProposedByKeyID string    `json:"proposed_by_key_id" dynamodbav:"proposed_by_key_id"`
+	ProposedAt      time.Time `json:"proposed_at" dynamodbav:"proposed_at"`
+	ExpiresAt       time.Time `json:"expires_at" dynamodbav:"expires_at"`
+
+	ConfirmedBy      string     `json:"confirmed_by,omitempty" dynamodbav:"confirmed_by,omitempty"`
+	ConfirmedByKeyID string     `json:"confirmed_by_key_id,omitempty" dynamodbav:"confirmed_by_key_id,omitempty"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty" dynamodbav:"confirmed_at,omitempty"`
+	Error            string     `json:"error,omitempty" dynamodbav:"error,omitempty"` // why a confirmed action failed
+
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+}
+
+// ApprovalAuditEntry records one step of an approval proposal: who did what, and when
+type ApprovalAuditEntry struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // APPROVALS
+	SK string `json:"-" dynamodbav:"SK"` // AUDIT#{proposal_id}#{timestamp}
+
+	ProposalID string    `json:"proposal_id" dynamodbav:"proposal_id"`
+	Action     string    `json:"action" dynamodbav:"action"`
+	Actor      string    `json:"actor" dynamodbav:"actor"`   // name of the admin's API key, or "system" for expiry
+	Status     string    `json:"status" dynamodbav:"status"` // proposal status after the step
+	Details    string    `json:"details,omitempty" dynamodbav:"details,omitempty"`
+	Timestamp  time.Time `json:"timestamp" dynamodbav:"timestamp"`
+}
+
+// NewApprovalProposal creates a pending proposal of an action by the holder of an admin API key
+func NewApprovalProposal(proposalID, action, targetID, summary, request string, proposer *AdminAPIKey, now time.Time) *ApprovalProposal {
+	return &ApprovalProposal{
+		PK:              CreateApprovalPK(),
+		SK:              CreateApprovalProposalSK(proposalID),
+		ProposalID:      proposalID,
+		Action:          action,
+		TargetID:        targetID,
+		Summary:         summary,
+		Request:         request,
+		Status:          ApprovalStatusPending,
+		ProposedBy:      proposer.Name,
+		ProposedByKeyID: proposer.KeyID,
+		ProposedAt:      now,
+		ExpiresAt:       now.Add(ApprovalProposalTTL),
+		UpdatedAt:       now,
+	}
+}
+
+// IsExpired reports whether a pending proposal was not confirmed in time
+func (p *ApprovalProposal) IsExpired(now time.Time) bool {
+	return p.Status == ApprovalStatusPending && !now.Before(p.ExpiresAt)
+}
+
+// CanBeConfirmedBy checks that an admin other than the proposer is confirming. The same person
+// holding a second key is caught by the key's name.
+func (p *ApprovalProposal) CanBeConfirmedBy(confirmer *AdminAPIKey) error {
+	if confirmer.KeyID == p.ProposedByKeyID || strings.EqualFold(strings.TrimSpace(confirmer.Name), strings.TrimSpace(p.ProposedBy)) {
+		return fmt.Errorf("a proposal must be confirmed by a different admin than %s, who proposed it", p.ProposedBy)
+	}
+	return nil
+}
+
+// NewApprovalAuditEntry creates the audit entry for a step of a proposal
+func NewApprovalAuditEntry(proposal *ApprovalProposal, action, actor, details string, now time.Time) *ApprovalAuditEntry {
+	return &ApprovalAuditEntry{
+		PK:         CreateApprovalPK(),
+		SK:         CreateApprovalAuditSK(proposal.ProposalID, now),
+		ProposalID: proposal.ProposalID,
+		Action:     action,
+		Actor:      actor,
+		Status:     proposal.Status,
+		Details:    details,
+		Timestamp:  now,
+	}
+}
+
+// ActivityBulkDeleteRequest removes published activities from the catalog by their events
+type ActivityBulkDeleteRequest struct {
+	EventIDs  []string `json:"event_ids"`
+	Reason    string   `json:"reason"`
+	DeletedBy string   `json:"deleted_by,omitempty"`
+}
+
+// MaxBulkDeleteActivities bounds how many activities a single bulk delete may remove
+const MaxBulkDeleteActivities = 100
+
+// Validate checks that a bulk delete names its events and why they are removed, dropping
+// duplicate event IDs
+func (r *ActivityBulkDeleteRequest) Validate() error {
+	seen := make(map[string]bool, len(r.EventIDs))
+	eventIDs := make([]string, 0, len(r.EventIDs))
+	for _, eventID := range r.EventIDs {
+		eventID = strings.TrimSpace(eventID)
+		if eventID == "" {
+			return fmt.Errorf("event_ids must not contain empty IDs")
+		}
+		if !seen[eventID] {
+			seen[eventID] = true
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+	if len(eventIDs) == 0 {
+		return fmt.Errorf("event_ids is required")
+	}
+	if len(eventIDs) > MaxBulkDeleteActivities {
+		return fmt.Errorf("at most %d activities can be deleted at once", MaxBulkDeleteActivities)
+	}
+	r.EventIDs = eventIDs
+
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// Helper functions to create the keys of approval proposals and their audit entries
+func CreateApprovalPK() string {
+	return "APPROVALS"
+}
+
+func CreateApprovalProposalSK(proposalID string) string {
+	return "PROPOSAL#" + proposalID
+}
+
+func CreateApprovalAuditSK(proposalID string, timestamp time.Time) string {
+	return fmt.Sprintf("AUDIT#%s#%s", proposalID, timestamp.UTC().Format(time.RFC3339Nano))
+}
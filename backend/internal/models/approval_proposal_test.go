@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApprovalProposal(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	proposer := &AdminAPIKey{KeyID: "key-1", Name: "Alice"}
+
+	proposal := NewApprovalProposal("proposal-1", ApprovalActionActivateSource, "source-1", "Activate source-1", "{}", proposer, now)
+	if proposal.Status != ApprovalStatusPending {
+		t.Errorf("Expected a pending proposal, got %s", proposal.Status)
+	}
+	if !proposal.ExpiresAt.Equal(now.Add(48 * time.Hour)) {
+		t.Errorf("Expected the proposal to expire after 48 hours, got %v", proposal.ExpiresAt)
+	}
+
+	if proposal.IsExpired(now.Add(47 * time.Hour)) {
+		t.Errorf("Expected the proposal not to have expired after 47 hours")
+	}
+	if !proposal.IsExpired(now.Add(48 * time.Hour)) {
+		t.Errorf("Expected the proposal to have expired after 48 hours")
+	}
+	proposal.Status = ApprovalStatusExecuted
+	if proposal.IsExpired(now.Add(72 * time.Hour)) {
+		t.Errorf("Expected only pending proposals to expire")
+	}
+
+	for _, confirmer := range []*AdminAPIKey{
+		{KeyID: "key-1", Name: "Alice"},
+		{KeyID: "key-2", Name: " alice "},
+		{KeyID: "key-1", Name: "Renamed"},
+	} {
+		if err := proposal.CanBeConfirmedBy(confirmer); err == nil {
+			t.Errorf("Expected %+v not to be able to confirm their own proposal", confirmer)
+		}
+	}
+	if err := proposal.CanBeConfirmedBy(&AdminAPIKey{KeyID: "key-2", Name: "Bob"}); err != nil {
+		t.Errorf("Expected a second admin to be able to confirm, got %v", err)
+	}
+
+	audit := NewApprovalAuditEntry(proposal, ApprovalAuditExecuted, "Bob", "Source activated", now)
+	if audit.Status != ApprovalStatusExecuted || !strings.HasPrefix(audit.SK, "AUDIT#proposal-1#") {
+		t.Errorf("Expected an executed audit entry of the proposal, got %+v", audit)
+	}
+}
+
+func TestActivityBulkDeleteRequest(t *testing.T) {
+	req := ActivityBulkDeleteRequest{EventIDs: []string{"event-1", " event-2 ", "event-1"}, Reason: " Duplicate listings "}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+	if strings.Join(req.EventIDs, ",") != "event-1,event-2" {
+		t.Errorf("Expected each event once, got %v", req.EventIDs)
+	}
+	if req.Reason != "Duplicate listings" {
+		t.Errorf("Expected a trimmed reason, got %q", req.Reason)
+	}
+
+	tooMany := make([]string, MaxBulkDeleteActivities+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("event-%d", i)
+	}
+	for _, invalid := range []ActivityBulkDeleteRequest{
+		{Reason: "Spam"},
+		{EventIDs: []string{"event-1", " "}, Reason: "Spam"},
+		{EventIDs: tooMany, Reason: "Spam"},
+		{EventIDs: []string{"event-1"}, Reason: " "},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
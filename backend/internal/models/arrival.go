@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parking constants
+const (
+	ParkingFree   = "free"   // free lot or garage
+	ParkingPaid   = "paid"   // paid lot, garage or meters
+	ParkingStreet = "street" // street parking only
+	ParkingNone   = "none"   // no parking at the venue
+)
+
+// Bounds of arrival info, which is shown as-is on the activity page
+const (
+	MaxArrivalNotes      = 5
+	MaxArrivalNoteLength = 300
+)
+
+// ArrivalInfo tells families where to park and how to find their way in
+type ArrivalInfo struct {
+	Parking  string   `json:"parking,omitempty" dynamodbav:"parking,omitempty"`   // free|paid|street|none
+	Entrance string   `json:"entrance,omitempty" dynamodbav:"entrance,omitempty"` // e.g. "Enter via the north gate on 5th Ave."
+	Notes    []string `json:"notes,omitempty" dynamodbav:"notes,omitempty"`       // other parking and arrival notes
+}
+
+// IsParking reports whether a value is one of the parking constants
+func IsParking(value string) bool {
+	switch value {
+	case ParkingFree, ParkingPaid, ParkingStreet, ParkingNone:
+		return true
+	}
+	return false
+}
+
+// IsEmpty reports whether the arrival info has nothing to show
+func (a *ArrivalInfo) IsEmpty() bool {
+	return a == nil || (a.Parking == "" && a.Entrance == "" && len(a.Notes) == 0)
+}
+
+// Validate checks arrival info entered by an admin, trimming its text and dropping blank notes
+func (a *ArrivalInfo) Validate() error {
+	if a.Parking != "" && !IsParking(a.Parking) {
+		return fmt.Errorf("parking must be one of free, paid, street, none")
+	}
+	a.Entrance = strings.TrimSpace(a.Entrance)
+	if len(a.Entrance) > MaxArrivalNoteLength {
+		return fmt.Errorf("entrance must be at most %d characters", MaxArrivalNoteLength)
+	}
+
+	notes := a.Notes[:0]
+	for _, note := range a.Notes {
+		note = strings.TrimSpace(note)
+		if note == "" {
+			continue
+		}
+		if len(note) > MaxArrivalNoteLength {
+			return fmt.Errorf("notes must be at most %d characters each", MaxArrivalNoteLength)
+		}
+		notes = append(notes, note)
+	}
+	if len(notes) > MaxArrivalNotes {
+		return fmt.Errorf("at most %d notes can be given", MaxArrivalNotes)
+	}
+	a.Notes = notes
+	return nil
+}
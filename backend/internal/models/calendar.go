@@ -0,0 +1,90 @@
+package models
+
+// CalendarEntry is one day an approved activity occurs on. Entries live in the family
+// activities table under the activity's partition and are queried by month through the
+// month-date-index GSI.
+type CalendarEntry struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // EVENT#{activity_id}
+	SK string `json:"SK" dynamodbav:"SK"` // INSTANCE#{date}T{start_time}
+
+	ActivityID   string `json:"activity_id" dynamodbav:"activity_id"`
+	Date         string `json:"date" dynamodbav:"date"` // YYYY-MM-DD
+	StartTime    string `json:"start_time,omitempty" dynamodbav:"start_time,omitempty"`
+	EndTime      string `json:"end_time,omitempty" dynamodbav:"end_time,omitempty"`
+	IsAllDay     bool   `json:"is_all_day" dynamodbav:"is_all_day"`
+	Title        string `json:"title" dynamodbav:"title"`
+	Type         string `json:"type" dynamodbav:"type"`
+	Category     string `json:"category" dynamodbav:"category"`
+	LocationName string `json:"location_name,omitempty" dynamodbav:"location_name,omitempty"`
+	PricingType  string `json:"pricing_type,omitempty" dynamodbav:"pricing_type,omitempty"`
+	Featured     bool   `json:"featured" dynamodbav:"featured"`
+	VenueID      string `json:"venue_id,omitempty" dynamodbav:"venue_id,omitempty"` // registry venue the activity is held at
+
+	// GSI Keys
+	CalendarMonthKey string `json:"CalendarMonthKey" dynamodbav:"CalendarMonthKey"`     // MONTH#{yyyy-mm}
+	DateTypeKey      string `json:"DateTypeKey" dynamodbav:"DateTypeKey"`               // DATE#{date}#TYPE#{type}#{activity_id}
+	VenueKey         string `json:"VenueKey,omitempty" dynamodbav:"VenueKey,omitempty"` // VENUE#{venue_id}, for the venue-date-index GSI
+}
+
+// CalendarDay is one cell of the month grid
+type CalendarDay struct {
+	Date  string          `json:"date"`
+	Count int             `json:"count"`
+	Items []CalendarEntry `json:"items"`
+}
+
+// CalendarMonth buckets a month's activities by day. Every day of the month is present.
+type CalendarMonth struct {
+	Month string        `json:"month"` // YYYY-MM
+	Total int           `json:"total"` // activity occurrences in the month
+	Days  []CalendarDay `json:"days"`
+}
+
+// CalendarWeek rolls up a venue's activities for one week, starting on Monday
+type CalendarWeek struct {
+	WeekStart string          `json:"week_start"` // YYYY-MM-DD of the Monday
+	Count     int             `json:"count"`
+	ByType    map[string]int  `json:"by_type"`
+	Items     []CalendarEntry `json:"items"`
+}
+
+// VenueSchedule lists a venue's upcoming activities grouped by week
+type VenueSchedule struct {
+	VenueID   string         `json:"venue_id"`
+	VenueName string         `json:"venue_name"`
+	Address   string         `json:"address,omitempty"`
+	Transit   *VenueTransit  `json:"transit,omitempty"`
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Total     int            `json:"total"`
+	Weeks     []CalendarWeek `json:"weeks"`
+}
+
+// ScheduleConflict is a pair of program sessions in the same room of a venue whose times overlap
+// while their titles differ, which usually means one of them was extracted wrongly
+type ScheduleConflict struct {
+	VenueID string                  `json:"venue_id"`
+	Date    string                  `json:"date"` // YYYY-MM-DD
+	Room    string                  `json:"room"` // location name both sessions are held at
+	First   ScheduleConflictSession `json:"first"`
+	Second  ScheduleConflictSession `json:"second"`
+}
+
+// ScheduleConflictSession is one of the overlapping sessions of a schedule conflict
+type ScheduleConflictSession struct {
+	ActivityID string `json:"activity_id"`
+	Title      string `json:"title"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time,omitempty"`
+}
+
+// Helper function to create the month key calendar entries are grouped by
+func GenerateCalendarMonthKey(month string) string {
+	return "MONTH#" + month
+}
+
+// Helper function to create the sort key prefix for an activity's calendar entries
+func CreateCalendarEntrySKPrefix() string {
+	return SortKeyInstance + "#"
+}
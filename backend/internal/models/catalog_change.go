@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Catalog change type constants
+const (
+	CatalogChangeCreated = "created" // the activity joined the public catalog
+	CatalogChangeUpdated = "updated" // the published activity changed
+	CatalogChangeExpired = "expired" // the activity left the public catalog
+)
+
+// catalogChangesPK is the partition holding the change log entries, the log head (the latest
+// sequence) and each activity's latest version
+const catalogChangesPK = "CATALOG_CHANGES"
+
+// CatalogChange is one entry of the catalog change log that partners sync from.
+// Entries are numbered by Sequence in the order they were recorded, with no entry
+// committed before an entry with a lower sequence.
+type CatalogChange struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // CATALOG_CHANGES
+	SK string `json:"-" dynamodbav:"SK"` // SEQ#{sequence}
+
+	Sequence   int64     `json:"sequence" dynamodbav:"sequence"`
+	Type       string    `json:"type" dynamodbav:"type"` // created, updated, expired
+	ActivityID string    `json:"activity_id" dynamodbav:"activity_id"`
+	Version    int64     `json:"version" dynamodbav:"version"`                       // increases by one with every change of the activity
+	Activity   *Activity `json:"activity,omitempty" dynamodbav:"activity,omitempty"` // the published activity; nil when expired
+	EventID    string    `json:"-" dynamodbav:"event_id"`                            // the admin event the activity is published from
+	ChangedAt  time.Time `json:"changed_at" dynamodbav:"changed_at"`
+}
+
+// CatalogChangeCursor returns the cursor a consumer passes to continue after a change
+func CatalogChangeCursor(sequence int64) string {
+	return strconv.FormatInt(sequence, 10)
+}
+
+// ParseCatalogChangeCursor returns the sequence a cursor continues after; an empty cursor
+// starts from the beginning of the log
+func ParseCatalogChangeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	sequence, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return sequence, nil
+}
+
+// Helper functions to create primary keys for the catalog change log
+func CreateCatalogChangePK() string {
+	return catalogChangesPK
+}
+
+// CreateCatalogChangeSK zero-pads the sequence so entries sort in sequence order
+func CreateCatalogChangeSK(sequence int64) string {
+	return fmt.Sprintf("SEQ#%020d", sequence)
+}
+
+func CreateCatalogChangeHeadSK() string {
+	return "HEAD"
+}
+
+func CreateCatalogActivityVersionSK(activityID string) string {
+	return fmt.Sprintf("VERSION#%s", activityID)
+}
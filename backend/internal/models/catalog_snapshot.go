@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CatalogSnapshotPrefix is the key prefix catalog snapshots are stored under
+const CatalogSnapshotPrefix = "catalog-snapshots/"
+
+// CatalogSnapshotManifest describes a frozen copy of the approved catalog. Snapshots are taken
+// nightly and never rewritten, so a version always serves the same events. The manifest is written
+// after the events, so a snapshot with a manifest is complete.
+type CatalogSnapshotManifest struct {
+	Version      string    `json:"version"` // YYYY-MM-DD, the UTC day the snapshot was taken
+	CreatedAt    time.Time `json:"created_at"`
+	EventCount   int       `json:"event_count"`
+	EventsKey    string    `json:"events_key"`
+	EventsSHA256 string    `json:"events_sha256"` // hex SHA-256 of the events object
+}
+
+// ParseCatalogSnapshotVersion parses a snapshot version, the date it was taken like 2025-03-01
+func ParseCatalogSnapshotVersion(value string) (string, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot %q, expected a date like 2025-03-01", value)
+	}
+	return date.Format("2006-01-02"), nil
+}
+
+// CatalogSnapshotVersion returns the version of the snapshot taken at t
+func CatalogSnapshotVersion(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// CatalogSnapshotManifestKey returns the object key of a snapshot's manifest
+func CatalogSnapshotManifestKey(version string) string {
+	return CatalogSnapshotPrefix + version + "/manifest.json"
+}
+
+// CatalogSnapshotEventsKey returns the object key of a snapshot's approved events
+func CatalogSnapshotEventsKey(version string) string {
+	return CatalogSnapshotPrefix + version + "/events.json"
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// External API provider constants for distributed concurrency limits
+const (
+	ProviderFirecrawl = "firecrawl"
+	ProviderOpenAI    = "openai"
+)
+
+// DefaultConcurrencyLimits are the per-provider caps used until settings are stored
+var DefaultConcurrencyLimits = map[string]int{
+	ProviderFirecrawl: 5,
+	ProviderOpenAI:    10,
+}
+
+// DefaultConcurrencyLeaseSeconds bounds how long a crashed caller can hold a slot
+const DefaultConcurrencyLeaseSeconds = 120
+
+// ConcurrencyLease is one held slot of a provider's distributed semaphore.
+// Leases expire so slots held by Lambdas that died mid-call are reclaimed.
+type ConcurrencyLease struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // CONCURRENCY#{provider}
+	SK string `json:"SK" dynamodbav:"SK"` // SLOT#{slot}
+
+	Provider   string    `json:"provider" dynamodbav:"provider"`
+	Slot       int       `json:"slot" dynamodbav:"slot"`
+	HolderID   string    `json:"holder_id" dynamodbav:"holder_id"`
+	AcquiredAt time.Time `json:"acquired_at" dynamodbav:"acquired_at"`
+	ExpiresAt  int64     `json:"expires_at" dynamodbav:"expires_at"` // unix seconds; the slot is free after this
+
+	// TTL for auto-expiration
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// ConcurrencySettings holds the per-provider concurrency caps shared by all Lambdas
+type ConcurrencySettings struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // SETTINGS
+	SK string `json:"SK" dynamodbav:"SK"` // CONCURRENCY
+
+	Limits       map[string]int `json:"limits" dynamodbav:"limits"` // provider -> max concurrent calls; 0 disables the limit
+	LeaseSeconds int            `json:"lease_seconds" dynamodbav:"lease_seconds"`
+
+	// Metadata
+	Version   int       `json:"version" dynamodbav:"version"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+// NewDefaultConcurrencySettings returns the settings used when none are stored
+func NewDefaultConcurrencySettings() *ConcurrencySettings {
+	limits := make(map[string]int, len(DefaultConcurrencyLimits))
+	for provider, limit := range DefaultConcurrencyLimits {
+		limits[provider] = limit
+	}
+	return &ConcurrencySettings{
+		Limits:       limits,
+		LeaseSeconds: DefaultConcurrencyLeaseSeconds,
+	}
+}
+
+// Validate checks that limits are for known providers and within sane bounds
+func (cs *ConcurrencySettings) Validate() error {
+	for provider, limit := range cs.Limits {
+		if _, known := DefaultConcurrencyLimits[provider]; !known {
+			return fmt.Errorf("unknown provider %q", provider)
+		}
+		if limit < 0 || limit > 100 {
+			return fmt.Errorf("limit for %s must be between 0 and 100", provider)
+		}
+	}
+	if cs.LeaseSeconds < 10 || cs.LeaseSeconds > 900 {
+		return fmt.Errorf("lease_seconds must be between 10 and 900")
+	}
+	return nil
+}
+
+// Helper functions to create primary keys for concurrency leases and settings
+func CreateConcurrencyPK(provider string) string {
+	return "CONCURRENCY#" + provider
+}
+
+func CreateConcurrencySlotSK(slot int) string {
+	return fmt.Sprintf("SLOT#%d", slot)
+}
+
+func CreateSettingsPK() string {
+	return "SETTINGS"
+}
+
+func CreateConcurrencySettingsSK() string {
+	return "CONCURRENCY"
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// CrawlSubmission records one crawl of a URL and the admin events it produced, one per extracted
+// event, so the events found on a page can be reviewed together. Submissions are stored in the
+// admin events table.
+type CrawlSubmission struct {
+	// DynamoDB Keys
+	PK string `json:"PK" dynamodbav:"PK"` // SUBMISSION#{submission_id}
+	SK string `json:"SK" dynamodbav:"SK"` // METADATA
+
+	SubmissionID    string    `json:"submission_id" dynamodbav:"submission_id"`
+	URL             string    `json:"url" dynamodbav:"url"`
+	SchemaType      string    `json:"schema_type" dynamodbav:"schema_type"`
+	ExtractedByUser string    `json:"extracted_by_user" dynamodbav:"extracted_by_user"`
+	AdminNotes      string    `json:"admin_notes,omitempty" dynamodbav:"admin_notes,omitempty"`
+	JobID           string    `json:"job_id,omitempty" dynamodbav:"job_id,omitempty"`
+	EventIDs        []string  `json:"event_ids" dynamodbav:"event_ids"` // admin events, in extraction order
+	CreditsUsed     int       `json:"credits_used" dynamodbav:"credits_used"`
+	CreatedAt       time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// CreateCrawlSubmissionPK creates the primary key for a crawl submission
+func CreateCrawlSubmissionPK(submissionID string) string {
+	return fmt.Sprintf("SUBMISSION#%s", submissionID)
+}
+
+// InsertEventIDs adds events to the submission right after another of its events, keeping events
+// split from one extraction together. Events are appended if the other event is not listed.
+func (s *CrawlSubmission) InsertEventIDs(afterID string, eventIDs []string) {
+	for i, id := range s.EventIDs {
+		if id == afterID {
+			inserted := append([]string{}, s.EventIDs[:i+1]...)
+			inserted = append(inserted, eventIDs...)
+			s.EventIDs = append(inserted, s.EventIDs[i+1:]...)
+			return
+		}
+	}
+	s.EventIDs = append(s.EventIDs, eventIDs...)
+}
+
+// schemaEventArrays names the array holding the extracted events of each predefined schema
+var schemaEventArrays = map[string]string{
+	"events":     "events",
+	"activities": "activities",
+	"camps":      "camps",
+	"classes":    "classes",
+	"venues":     "venues",
+}
+
+// SplitExtractedEvents splits the raw data of a crawl into the raw data of each extracted event, in
+// the same format, so every event becomes its own admin event. Fields outside the schema's event
+// array are kept on every event. Data of custom schemas, or without events to split, is returned
+// whole.
+func SplitExtractedEvents(schemaType string, rawData map[string]interface{}) []map[string]interface{} {
+	arrayKey, ok := schemaEventArrays[schemaType]
+	if !ok {
+		return []map[string]interface{}{rawData}
+	}
+	items, ok := rawData[arrayKey].([]interface{})
+	if !ok || len(items) < 2 {
+		return []map[string]interface{}{rawData}
+	}
+
+	var split []map[string]interface{}
+	for _, item := range items {
+		if _, ok := item.(map[string]interface{}); !ok {
+			continue
+		}
+		eventData := make(map[string]interface{}, len(rawData))
+		for key, value := range rawData {
+			eventData[key] = value
+		}
+		eventData[arrayKey] = []interface{}{item}
+		split = append(split, eventData)
+	}
+	if len(split) == 0 {
+		return []map[string]interface{}{rawData}
+	}
+	return split
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitExtractedEvents(t *testing.T) {
+	rawData := map[string]interface{}{
+		"page_title": "Spring Classes",
+		"events": []interface{}{
+			map[string]interface{}{"title": "Toddler Art"},
+			"not an event",
+			map[string]interface{}{"title": "Kids Yoga"},
+		},
+	}
+
+	split := SplitExtractedEvents("events", rawData)
+	if len(split) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(split))
+	}
+	for i, title := range []string{"Toddler Art", "Kids Yoga"} {
+		events, ok := split[i]["events"].([]interface{})
+		if !ok || len(events) != 1 {
+			t.Fatalf("Expected event %d to hold one event, got %v", i, split[i]["events"])
+		}
+		if got := events[0].(map[string]interface{})["title"]; got != title {
+			t.Errorf("Expected event %d to be %q, got %v", i, title, got)
+		}
+		if split[i]["page_title"] != "Spring Classes" {
+			t.Errorf("Expected event %d to keep the page fields, got %v", i, split[i])
+		}
+	}
+	if len(rawData["events"].([]interface{})) != 3 {
+		t.Error("Expected the raw data to be left unchanged")
+	}
+}
+
+func TestSplitExtractedEventsKeepsWholeData(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		rawData    map[string]interface{}
+	}{
+		{"SingleEvent", "events", map[string]interface{}{
+			"events": []interface{}{map[string]interface{}{"title": "Story Time"}},
+		}},
+		{"CustomSchema", "custom", map[string]interface{}{
+			"sessions": []interface{}{map[string]interface{}{"day": "Mon"}, map[string]interface{}{"day": "Wed"}},
+		}},
+		{"OtherArray", "activities", map[string]interface{}{
+			"events": []interface{}{map[string]interface{}{"title": "A"}, map[string]interface{}{"title": "B"}},
+		}},
+		{"NoObjects", "venues", map[string]interface{}{
+			"venues": []interface{}{"Ballard Library", "Green Lake"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split := SplitExtractedEvents(tt.schemaType, tt.rawData)
+			if len(split) != 1 {
+				t.Fatalf("Expected the data to be kept whole, got %d parts", len(split))
+			}
+		})
+	}
+}
+
+func TestCrawlSubmissionInsertEventIDs(t *testing.T) {
+	submission := &CrawlSubmission{EventIDs: []string{"a", "b", "c"}}
+	submission.InsertEventIDs("b", []string{"b2", "b3"})
+	if got := strings.Join(submission.EventIDs, ","); got != "a,b,b2,b3,c" {
+		t.Errorf("Expected the events after b, got %s", got)
+	}
+
+	submission.InsertEventIDs("missing", []string{"d"})
+	if got := strings.Join(submission.EventIDs, ","); got != "a,b,b2,b3,c,d" {
+		t.Errorf("Expected the event to be appended, got %s", got)
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DeadLetterRetention is how long failed task messages and their delivery failures are kept,
+// matching the dead-letter queue's retention
+const DeadLetterRetention = 14 * 24 * time.Hour
+
+// MessageFailure is one delivery of a scrape task message the executor failed on
+type MessageFailure struct {
+	ReceiveCount int       `json:"receive_count" dynamodbav:"receive_count"` // the delivery's ApproximateReceiveCount
+	Error        string    `json:"error" dynamodbav:"error"`                 // the executor's error, with the errors it wraps
+	FailedAt     time.Time `json:"failed_at" dynamodbav:"failed_at"`
+}
+
+// FailedTaskMessage is a scrape task message that failed every delivery and was moved to the
+// dead-letter queue. It keeps the message as queued with the errors of its failed deliveries.
+type FailedTaskMessage struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // FAILED_TASKS
+	SK string `json:"-" dynamodbav:"SK"` // MESSAGE#{dead_lettered_at}#{message_id}
+
+	MessageID string             `json:"message_id" dynamodbav:"message_id"`
+	Body      string             `json:"body" dynamodbav:"body"`                     // the message as queued
+	Task      *ScrapeTaskMessage `json:"task,omitempty" dynamodbav:"task,omitempty"` // nil when the body is not a task message
+	SourceID  string             `json:"source_id,omitempty" dynamodbav:"source_id,omitempty"`
+
+	// Failures are oldest first. Deliveries that timed out or crashed the executor left no error,
+	// so a message can have fewer failures than deliveries, or none.
+	Failures []MessageFailure `json:"failures" dynamodbav:"failures"`
+
+	// RunRecorded is set once the task's URL was counted as failed on its run, letting the run finish
+	RunRecorded bool `json:"run_recorded" dynamodbav:"run_recorded"`
+
+	QueuedAt       time.Time `json:"queued_at" dynamodbav:"queued_at"` // when the message was first sent
+	DeadLetteredAt time.Time `json:"dead_lettered_at" dynamodbav:"dead_lettered_at"`
+	TTL            int64     `json:"-" dynamodbav:"TTL"`
+}
+
+// NewFailedTaskMessage records a dead-lettered message with the failures recorded for it
+func NewFailedTaskMessage(messageID, body string, failures []MessageFailure, queuedAt, now time.Time) *FailedTaskMessage {
+	message := &FailedTaskMessage{
+		PK:             CreateFailedTasksPK(),
+		SK:             CreateFailedTaskSK(now, messageID),
+		MessageID:      messageID,
+		Body:           body,
+		Failures:       failures,
+		QueuedAt:       queuedAt,
+		DeadLetteredAt: now,
+		TTL:            now.Add(DeadLetterRetention).Unix(),
+	}
+	if message.Failures == nil {
+		message.Failures = []MessageFailure{}
+	}
+
+	var task ScrapeTaskMessage
+	if err := json.Unmarshal([]byte(body), &task); err == nil && task.RunID != "" && task.TaskID != "" {
+		message.Task = &task
+		message.SourceID = task.SourceID
+	}
+	return message
+}
+
+// LastError describes why the message was dead-lettered: its last recorded error, or that no
+// delivery recorded one
+func (m *FailedTaskMessage) LastError() string {
+	if len(m.Failures) == 0 {
+		return "every delivery timed out or stopped without recording an error"
+	}
+	return m.Failures[len(m.Failures)-1].Error
+}
+
+// Helper functions to create the keys of failed task messages and their delivery failures
+func CreateFailedTasksPK() string {
+	return "FAILED_TASKS"
+}
+
+func CreateFailedTaskSK(deadLetteredAt time.Time, messageID string) string {
+	return fmt.Sprintf("MESSAGE#%s#%s", deadLetteredAt.UTC().Format(time.RFC3339Nano), messageID)
+}
+
+func CreateMessageFailuresPK(messageID string) string {
+	return "MESSAGE#" + messageID
+}
+
+func CreateMessageFailuresSK() string {
+	return "FAILURES"
+}
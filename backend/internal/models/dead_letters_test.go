@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFailedTaskMessage(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	queuedAt := now.Add(-2 * time.Hour)
+	body := `{"run_id":"run-1","task_id":"src-a-0","source_id":"src-a","source_name":"Seattle Parks","url":"https://parks.example.com/events"}`
+	failures := []MessageFailure{
+		{ReceiveCount: 1, Error: "failed to checkpoint task result: throttled", FailedAt: now.Add(-time.Hour)},
+		{ReceiveCount: 3, Error: "failed to record task result: throttled", FailedAt: now},
+	}
+
+	message := NewFailedTaskMessage("msg-1", body, failures, queuedAt, now)
+
+	if message.Task == nil || message.Task.RunID != "run-1" || message.Task.URL != "https://parks.example.com/events" {
+		t.Fatalf("Expected the task to be parsed from the body, got %+v", message.Task)
+	}
+	if message.SourceID != "src-a" {
+		t.Errorf("Expected source src-a, got %q", message.SourceID)
+	}
+	if message.PK != "FAILED_TASKS" || !strings.HasPrefix(message.SK, "MESSAGE#2026-10-16T18:00:00Z#") || !strings.HasSuffix(message.SK, "#msg-1") {
+		t.Errorf("Unexpected keys %s / %s", message.PK, message.SK)
+	}
+	if message.TTL != now.Add(DeadLetterRetention).Unix() {
+		t.Errorf("Expected the message to expire with the dead-letter queue's retention, got %d", message.TTL)
+	}
+	if message.LastError() != "failed to record task result: throttled" {
+		t.Errorf("Expected the last failure's error, got %q", message.LastError())
+	}
+}
+
+func TestNewFailedTaskMessageWithoutTask(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+
+	for _, body := range []string{`not json`, `{"source_id":"src-a"}`} {
+		message := NewFailedTaskMessage("msg-1", body, nil, now, now)
+		if message.Task != nil || message.SourceID != "" {
+			t.Errorf("Expected no task for body %q, got %+v", body, message.Task)
+		}
+		if message.Failures == nil || len(message.Failures) != 0 {
+			t.Errorf("Expected an empty list of failures, got %v", message.Failures)
+		}
+		if !strings.Contains(message.LastError(), "without recording an error") {
+			t.Errorf("Expected the last error to say none was recorded, got %q", message.LastError())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Domain list constants
+const (
+	DomainListAllow = "allow"
+	DomainListDeny  = "deny"
+)
+
+// DomainRule is one entry on the domain allow or deny list.
+// A rule matches its domain and every subdomain of it.
+type DomainRule struct {
+	Domain string `json:"domain" dynamodbav:"domain"`                     // e.g. stubhub.com
+	Reason string `json:"reason,omitempty" dynamodbav:"reason,omitempty"` // shown to submitters when a deny rule matches
+}
+
+// DomainPolicy holds the domain allow and deny lists enforced on sources and crawls
+type DomainPolicy struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // DOMAIN_POLICY
+	SK string `json:"SK" dynamodbav:"SK"` // POLICY
+
+	AllowList []DomainRule `json:"allow_list" dynamodbav:"allow_list"`
+	DenyList  []DomainRule `json:"deny_list" dynamodbav:"deny_list"`
+
+	// AllowListOnly denies every domain that is not on the allow list
+	AllowListOnly bool `json:"allow_list_only" dynamodbav:"allow_list_only"`
+
+	// Metadata
+	Version   int       `json:"version" dynamodbav:"version"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+// DomainDecision is the outcome of checking a URL against the domain policy
+type DomainDecision struct {
+	Allowed     bool   `json:"allowed"`
+	Domain      string `json:"domain"`
+	List        string `json:"list,omitempty"`         // allow, deny; empty when no rule matched
+	MatchedRule string `json:"matched_rule,omitempty"` // domain of the matching rule
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Validate checks that every rule names a lowercase host and that no domain is listed twice
+func (p *DomainPolicy) Validate() error {
+	seen := make(map[string]string)
+	for list, rules := range map[string][]DomainRule{
+		DomainListAllow: p.AllowList,
+		DomainListDeny:  p.DenyList,
+	} {
+		for _, rule := range rules {
+			if rule.Domain == "" {
+				return fmt.Errorf("%s list contains a rule without a domain", list)
+			}
+			if rule.Domain != strings.ToLower(rule.Domain) || strings.ContainsAny(rule.Domain, "/: ") {
+				return fmt.Errorf("%s list domain %q must be a lowercase host name", list, rule.Domain)
+			}
+			if other, exists := seen[rule.Domain]; exists && other == list {
+				return fmt.Errorf("domain %s is listed twice on the %s list", rule.Domain, list)
+			} else if exists {
+				return fmt.Errorf("domain %s is listed on both the %s and %s lists", rule.Domain, other, list)
+			}
+			seen[rule.Domain] = list
+		}
+	}
+	if p.AllowListOnly && len(p.AllowList) == 0 {
+		return fmt.Errorf("allow_list_only requires at least one allowed domain")
+	}
+	return nil
+}
+
+// Helper functions to create primary keys for the domain policy
+func CreateDomainPolicyPK() string {
+	return "DOMAIN_POLICY"
+}
+
+func CreateDomainPolicySK() string {
+	return "POLICY"
+}
@@ -0,0 +1,23 @@
+package models
+
+// Cost bucket constants
+const (
+	CostBucketFree    = "free"     // free and donation activities
+	CostBucketUnder10 = "under-10" // under $10
+	CostBucket10To25  = "10-25"    // $10 to $25
+	CostBucket25Plus  = "25-plus"  // over $25
+)
+
+// ActivityFacets counts the activities of a list response by cost and category, so the frontend
+// can show filter chips with counts. Every bucket and category is listed, including empty ones.
+type ActivityFacets struct {
+	Cost     []FacetCount `json:"cost"`
+	Category []FacetCount `json:"category"`
+}
+
+// FacetCount is the number of activities with one facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
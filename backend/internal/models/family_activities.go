@@ -79,6 +79,8 @@ type Venue struct {
 	OperatingHours  map[string]string `json:"operating_hours" dynamodbav:"operating_hours"` // monday: "10:00-22:00"
 	ContactInfo     ContactInfo       `json:"contact_info" dynamodbav:"contact_info"`
 	Website         string            `json:"website" dynamodbav:"website"`
+	Transit         *VenueTransit     `json:"transit,omitempty" dynamodbav:"transit,omitempty"` // set by the venue transit scorer
+	ArrivalInfo     *ArrivalInfo      `json:"arrival_info,omitempty" dynamodbav:"arrival_info,omitempty"` // entered by admins; activities without their own take it
 }
 
 // Event represents a time-bound happening
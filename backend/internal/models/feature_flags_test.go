@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFeatureFlagSettingsValidate(t *testing.T) {
+	valid := FeatureFlagSettings{Flags: map[string]FeatureFlag{
+		FlagAutoApproval: {Enabled: true, Percentage: 25, Sources: []string{"parks-1a2b3c4d"}},
+		FlagDedupMerge:   {},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid flags, got %v", err)
+	}
+
+	for _, flags := range []map[string]FeatureFlag{
+		{"new_ranking": {Enabled: true}},
+		{FlagAutoApproval: {Percentage: 101}},
+		{FlagAutoApproval: {Percentage: -1}},
+		{FlagDedupMerge: {Sources: []string{""}}},
+	} {
+		settings := FeatureFlagSettings{Flags: flags}
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected %+v to be refused", flags)
+		}
+	}
+}
+
+func TestFeatureFlagSettingsIsEnabled(t *testing.T) {
+	settings := &FeatureFlagSettings{Flags: map[string]FeatureFlag{
+		FlagExtractorBackendV2: {Enabled: true, Percentage: 100},
+		FlagAutoApproval:       {Enabled: true, Sources: []string{"parks-1a2b3c4d"}},
+		FlagDedupMerge:         {Enabled: false, Percentage: 100, Sources: []string{"parks-1a2b3c4d"}},
+	}}
+
+	tests := []struct {
+		flag     string
+		sourceID string
+		enabled  bool
+	}{
+		{FlagExtractorBackendV2, "library-9f8e7d6c", true},
+		{FlagExtractorBackendV2, "", true},
+		{FlagAutoApproval, "parks-1a2b3c4d", true},
+		{FlagAutoApproval, "library-9f8e7d6c", false},
+		{FlagAutoApproval, "", false},
+		{FlagDedupMerge, "parks-1a2b3c4d", false},
+		{"new_ranking", "parks-1a2b3c4d", false},
+	}
+	for _, tt := range tests {
+		if got := settings.IsEnabled(tt.flag, tt.sourceID); got != tt.enabled {
+			t.Errorf("IsEnabled(%q, %q) = %v, expected %v", tt.flag, tt.sourceID, got, tt.enabled)
+		}
+	}
+
+	var missing *FeatureFlagSettings
+	if missing.IsEnabled(FlagExtractorBackendV2, "parks-1a2b3c4d") {
+		t.Error("Expected every flag to be off without settings")
+	}
+}
+
+func TestFeatureFlagPercentageRollout(t *testing.T) {
+	sourceIDs := make([]string, 1000)
+	for i := range sourceIDs {
+		sourceIDs[i] = fmt.Sprintf("source-%04d", i)
+	}
+	rolledOut := func(percentage int) map[string]bool {
+		settings := &FeatureFlagSettings{Flags: map[string]FeatureFlag{
+			FlagAutoApproval: {Enabled: true, Percentage: percentage},
+		}}
+		enabled := make(map[string]bool)
+		for _, sourceID := range sourceIDs {
+			if settings.IsEnabled(FlagAutoApproval, sourceID) {
+				enabled[sourceID] = true
+			}
+		}
+		return enabled
+	}
+
+	if n := len(rolledOut(0)); n != 0 {
+		t.Errorf("Expected no sources at 0%%, got %d", n)
+	}
+	if n := len(rolledOut(100)); n != len(sourceIDs) {
+		t.Errorf("Expected every source at 100%%, got %d", n)
+	}
+
+	quarter := rolledOut(25)
+	if len(quarter) < 200 || len(quarter) > 300 {
+		t.Errorf("Expected about a quarter of the sources at 25%%, got %d", len(quarter))
+	}
+	// Growing a rollout keeps the sources it already covered
+	half := rolledOut(50)
+	for sourceID := range quarter {
+		if !half[sourceID] {
+			t.Errorf("Expected %s to stay enabled when the rollout grows", sourceID)
+		}
+	}
+}
+
+func TestFeatureFlagSettingsStates(t *testing.T) {
+	settings := &FeatureFlagSettings{Flags: map[string]FeatureFlag{
+		FlagDedupMerge: {Enabled: true, Percentage: 10},
+	}}
+
+	states := settings.States()
+	if len(states) != len(KnownFeatureFlags) {
+		t.Fatalf("Expected a state for every known flag, got %d", len(states))
+	}
+	for i, state := range states {
+		if i > 0 && states[i-1].Name >= state.Name {
+			t.Errorf("Expected states sorted by name, got %s before %s", states[i-1].Name, state.Name)
+		}
+		if state.Description == "" {
+			t.Errorf("Expected a description for %s", state.Name)
+		}
+		enabled := state.Name == FlagDedupMerge
+		if state.Enabled != enabled {
+			t.Errorf("Expected %s enabled=%v, got %v", state.Name, enabled, state.Enabled)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Sort key of an activity's geo entry
+const SortKeyGeo = "GEO"
+
+// Geo search limits
+const (
+	DefaultGeoRadiusKm = 5.0
+	MaxGeoRadiusKm     = 50.0
+)
+
+// GeocodedLocation records the coordinates a location description was geocoded to
+type GeocodedLocation struct {
+	Text        string      `json:"text"` // the description that was geocoded
+	Coordinates Coordinates `json:"coordinates"`
+	Region      string      `json:"region,omitempty"` // e.g. North Seattle, when the match's locality is in one
+}
+
+// Geocoding providers
+const (
+	GeocodingProviderAmazonLocation = "amazon-location"
+	GeocodingProviderGoogle         = "google"
+	GeocodingProviderMapbox         = "mapbox"
+	GeocodingProviderNominatim      = "nominatim"
+)
+
+// How long geocoding results are cached. Places rarely move, while a description that could not
+// be placed is retried sooner in case the provider's data improves.
+const (
+	GeocodeCacheTTL         = 90 * 24 * time.Hour
+	GeocodeCacheNotFoundTTL = 7 * 24 * time.Hour
+)
+
+// GeocodeCacheEntry caches a provider's answer for a location description, so the same address is
+// only looked up once across conversions, scrape tasks and Lambda instances
+type GeocodeCacheEntry struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // GEOCODE#{provider}#{text_hash}
+	SK string `json:"SK" dynamodbav:"SK"` // METADATA
+
+	Provider string  `json:"provider" dynamodbav:"provider"`
+	Text     string  `json:"text" dynamodbav:"text"`
+	Found    bool    `json:"found" dynamodbav:"found"`
+	Lat      float64 `json:"lat,omitempty" dynamodbav:"lat,omitempty"`
+	Lng      float64 `json:"lng,omitempty" dynamodbav:"lng,omitempty"`
+	Locality string  `json:"locality,omitempty" dynamodbav:"locality,omitempty"` // neighborhood or city of the match
+
+	CachedAt time.Time `json:"cached_at" dynamodbav:"cached_at"`
+
+	// TTL for auto-expiration
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// CreateGeocodeCachePK creates the partition key of a provider's answer for a location
+// description. Descriptions differing only in case and spacing share an entry.
+func CreateGeocodeCachePK(provider, text string) string {
+	hash := sha256.Sum256([]byte(strings.Join(strings.Fields(strings.ToLower(text)), " ")))
+	return "GEOCODE#" + provider + "#" + hex.EncodeToString(hash[:])[:16]
+}
+
+// GeoEntry places a published activity on the map. Entries live in the family activities table
+// under the activity's partition and are queried by geohash cell through the geohash-index GSI,
+// so nearby activities are found without reading every published event.
+type GeoEntry struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // EVENT#{activity_id}
+	SK string `json:"SK" dynamodbav:"SK"` // GEO
+
+	ActivityID string  `json:"activity_id" dynamodbav:"activity_id"`
+	EventID    string  `json:"event_id" dynamodbav:"event_id"` // admin event the activity was approved from
+	Title      string  `json:"title" dynamodbav:"title"`
+	Lat        float64 `json:"lat" dynamodbav:"lat"`
+	Lng        float64 `json:"lng" dynamodbav:"lng"`
+
+	// GSI Keys
+	GeoCellKey string `json:"GeoCellKey" dynamodbav:"GeoCellKey"` // GEO#{4-character geohash}
+	GeoSortKey string `json:"GeoSortKey" dynamodbav:"GeoSortKey"` // {7-character geohash}#{activity_id}
+}
+
+// Coordinates returns where the entry's activity is held
+func (e *GeoEntry) Coordinates() Coordinates {
+	return Coordinates{Lat: e.Lat, Lng: e.Lng}
+}
+
+// Helper function to create the geohash-index partition of a 4-character geohash cell
+func GenerateGeoCellKey(cell string) string {
+	return "GEO#" + cell
+}
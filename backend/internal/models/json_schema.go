@@ -0,0 +1,202 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema version model schemas are written in
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema needed to describe the models as encoding/json writes them
+type JSONSchema struct {
+	Schema          string      `json:"$schema,omitempty"`
+	Ref             string      `json:"$ref,omitempty"`
+	Title           string      `json:"title,omitempty"`
+	Type            interface{} `json:"type,omitempty"` // a type name, or a list of them when the value may be null
+	Format          string      `json:"format,omitempty"`
+	ContentEncoding string      `json:"contentEncoding,omitempty"`
+	Enum            []string    `json:"enum,omitempty"`
+
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // false for structs, the value schema for maps
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+
+	Defs map[string]*JSONSchema `json:"$defs,omitempty"` // named structs the schema refers to
+}
+
+// ModelSchemas returns the JSON Schemas of the models shared with the frontend, by model name
+func ModelSchemas() map[string]*JSONSchema {
+	return map[string]*JSONSchema{
+		"Activity":   GenerateJSONSchema(Activity{}),
+		"Venue":      GenerateJSONSchema(Venue{}),
+		"Program":    GenerateJSONSchema(Program{}),
+		"AdminEvent": GenerateJSONSchema(AdminEvent{}),
+	}
+}
+
+// GenerateJSONSchema describes the JSON encoding of a struct value. Named structs it contains are
+// described once under $defs. Fields without omitempty are required, and may be null when they
+// are pointers, slices or maps.
+func GenerateJSONSchema(value interface{}) *JSONSchema {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	generator := &schemaGenerator{root: t, defs: make(map[string]*JSONSchema)}
+	schema := generator.structSchema(t)
+	schema.Schema = JSONSchemaDialect
+	schema.Title = t.Name()
+	if len(generator.defs) > 0 {
+		schema.Defs = generator.defs
+	}
+	return schema
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	adminStatusType = reflect.TypeOf(AdminEventStatus(""))
+)
+
+type schemaGenerator struct {
+	root reflect.Type
+	defs map[string]*JSONSchema
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	switch t {
+	case timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &JSONSchema{}
+	case adminStatusType:
+		var statuses []string
+		for _, status := range AdminEventStateMachine.Statuses {
+			statuses = append(statuses, status.Status)
+		}
+		return &JSONSchema{Type: "string", Enum: statuses}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Ptr:
+		return g.typeSchema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if t == g.root {
+			return &JSONSchema{Ref: "#"}
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = &JSONSchema{} // placeholder, for structs that contain themselves
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/$defs/" + t.Name()}
+	}
+	// Interfaces and anything else encoding/json accepts are left open
+	return &JSONSchema{}
+}
+
+// structSchema describes a struct's fields the way encoding/json writes them: embedded structs
+// are flattened, and a field shadows fields of the same name in the structs it embeds
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: false,
+	}
+	g.addFields(schema, t)
+	return schema
+}
+
+func (g *schemaGenerator) addFields(schema *JSONSchema, t reflect.Type) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+
+		property := g.typeSchema(fieldType)
+		omitEmpty := hasTagOption(options, "omitempty")
+		if hasTagOption(options, "string") && property.Type != nil && property.Type != "object" && property.Type != "array" {
+			property = &JSONSchema{Type: "string"}
+		}
+
+		// encoding/json never omits structs, and writes null for nil pointers, slices and maps
+		kind := fieldType.Kind()
+		if !omitEmpty || kind == reflect.Struct {
+			schema.Required = append(schema.Required, name)
+		}
+		if !omitEmpty && (kind == reflect.Ptr || kind == reflect.Slice || kind == reflect.Map) {
+			property = nullableSchema(property)
+		}
+		schema.Properties[name] = property
+	}
+
+	for _, embeddedType := range embedded {
+		g.addFields(schema, embeddedType)
+	}
+}
+
+func hasTagOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// nullableSchema allows null in addition to the values a schema describes
+func nullableSchema(schema *JSONSchema) *JSONSchema {
+	if typeName, ok := schema.Type.(string); ok {
+		schema.Type = []string{typeName, "null"}
+		return schema
+	}
+	return &JSONSchema{AnyOf: []*JSONSchema{schema, {Type: "null"}}}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateJSONSchemaActivity(t *testing.T) {
+	schema := GenerateJSONSchema(Activity{})
+
+	if schema.Schema != JSONSchemaDialect || schema.Title != "Activity" || schema.Type != "object" {
+		t.Fatalf("Unexpected root schema %s %s %v", schema.Schema, schema.Title, schema.Type)
+	}
+	required := make(map[string]bool)
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for name, want := range map[string]bool{"id": true, "title": true, "location": true, "createdAt": true, "detailUrl": false, "camp": false} {
+		if required[name] != want {
+			t.Errorf("Expected %s required %v, got %v", name, want, required[name])
+		}
+	}
+
+	if ref := schema.Properties["location"].Ref; ref != "#/$defs/Location" || schema.Defs["Location"] == nil {
+		t.Errorf("Expected location to refer to the Location definition, got %q", ref)
+	}
+	if coordinates := schema.Defs["Location"].Properties["coordinates"]; coordinates.Ref != "#/$defs/Coordinates" {
+		t.Errorf("Expected coordinates to refer to the Coordinates definition, got %+v", coordinates)
+	}
+	if created := schema.Properties["createdAt"]; created.Type != "string" || created.Format != "date-time" {
+		t.Errorf("Expected a date-time string, got %+v", created)
+	}
+	if tags := schema.Properties["tags"]; !reflect.DeepEqual(tags.Type, []string{"array", "null"}) || tags.Items.Type != "string" {
+		t.Errorf("Expected a nullable array of strings, got %+v", tags)
+	}
+	if camp := schema.Properties["camp"]; camp.Ref != "#/$defs/CampDetails" {
+		t.Errorf("Expected an omitted pointer to refer to its definition without null, got %+v", camp)
+	}
+}
+
+func TestGenerateJSONSchemaFlattensEmbeddedStructs(t *testing.T) {
+	schema := GenerateJSONSchema(Venue{})
+
+	for _, name := range []string{"entity_id", "name", "venue_name", "coordinates"} {
+		if schema.Properties[name] == nil {
+			t.Errorf("Expected property %s", name)
+		}
+	}
+	if schema.Properties["FamilyActivity"] != nil {
+		t.Errorf("Expected the embedded struct to be flattened")
+	}
+	if hours := schema.Properties["operating_hours"]; hours.AdditionalProperties.(*JSONSchema).Type != "string" {
+		t.Errorf("Expected a map of strings, got %+v", hours)
+	}
+}
+
+func TestGenerateJSONSchemaAdminEvent(t *testing.T) {
+	schema := GenerateJSONSchema(AdminEvent{})
+
+	status := schema.Properties["status"]
+	if status.Type != "string" || len(status.Enum) != len(AdminEventStateMachine.Statuses) {
+		t.Errorf("Expected the admin event statuses as an enum, got %+v", status)
+	}
+	if reviewed := schema.Properties["reviewed_at"]; reviewed.Format != "date-time" {
+		t.Errorf("Expected a date-time, got %+v", reviewed)
+	}
+	if raw := schema.Properties["raw_extracted_data"]; !reflect.DeepEqual(raw.Type, []string{"object", "null"}) {
+		t.Errorf("Expected a nullable object, got %+v", raw)
+	}
+}
+
+func TestModelSchemasMarshal(t *testing.T) {
+	for name, schema := range ModelSchemas() {
+		encoded, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("Expected the %s schema to marshal, got %v", name, err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Expected the %s schema to unmarshal, got %v", name, err)
+		}
+		if decoded["additionalProperties"] != false || decoded["title"] != name {
+			t.Errorf("Expected a closed %s schema, got %v", name, decoded)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Link health status constants
+const (
+	LinkStatusHealthy = "healthy"
+	LinkStatusFailing = "failing" // failed at least once but not yet considered broken
+	LinkStatusBroken  = "broken"
+)
+
+// Link field constants identify which activity URL was checked
+const (
+	LinkFieldRegistration = "registration_url"
+	LinkFieldDetail       = "detail_url"
+)
+
+// LinkBrokenThreshold is the number of consecutive failed checks before a link is marked broken
+const LinkBrokenThreshold = 2
+
+// LinkHealthRecord tracks the health of a single URL referenced by a published event
+type LinkHealthRecord struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // LINK#{url_hash}
+	SK string `json:"SK" dynamodbav:"SK"` // EVENT#{event_id}
+
+	// Link identification
+	URL        string `json:"url" dynamodbav:"url"`
+	Field      string `json:"field" dynamodbav:"field"` // registration_url, detail_url
+	EventID    string `json:"event_id" dynamodbav:"event_id"`
+	EventTitle string `json:"event_title" dynamodbav:"event_title"`
+	SourceURL  string `json:"source_url" dynamodbav:"source_url"`
+
+	// Check results
+	Status              string     `json:"status" dynamodbav:"status"` // healthy, failing, broken
+	StatusCode          int        `json:"status_code" dynamodbav:"status_code"`
+	ErrorMessage        string     `json:"error_message,omitempty" dynamodbav:"error_message,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures" dynamodbav:"consecutive_failures"`
+	FirstFailedAt       *time.Time `json:"first_failed_at,omitempty" dynamodbav:"first_failed_at,omitempty"`
+	LastCheckedAt       time.Time  `json:"last_checked_at" dynamodbav:"last_checked_at"`
+
+	// TTL for auto-expiration of links that are no longer checked
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// CreateLinkHealthPK creates the partition key for a URL
+func CreateLinkHealthPK(url string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(url)))
+	return "LINK#" + hex.EncodeToString(hash[:])[:16]
+}
+
+// CreateLinkHealthSK creates the sort key for the event referencing a URL
+func CreateLinkHealthSK(eventID string) string {
+	return "EVENT#" + eventID
+}
+
+// RecordCheck applies the result of a single check to the record
+func (r *LinkHealthRecord) RecordCheck(healthy bool, statusCode int, errorMessage string, checkedAt time.Time) {
+	r.StatusCode = statusCode
+	r.ErrorMessage = errorMessage
+	r.LastCheckedAt = checkedAt
+
+	if healthy {
+		r.Status = LinkStatusHealthy
+		r.ConsecutiveFailures = 0
+		r.FirstFailedAt = nil
+		return
+	}
+
+	r.ConsecutiveFailures++
+	if r.FirstFailedAt == nil {
+		r.FirstFailedAt = &checkedAt
+	}
+	if r.ConsecutiveFailures >= LinkBrokenThreshold {
+		r.Status = LinkStatusBroken
+	} else {
+		r.Status = LinkStatusFailing
+	}
+}
+
+// IsBroken returns true if the link has failed enough consecutive checks to be considered dead
+func (r *LinkHealthRecord) IsBroken() bool {
+	return r.Status == LinkStatusBroken
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// Manual trigger guardrails. Each manual trigger of a source doubles the wait before its next
+// one, and all sources share an hourly limit.
+const (
+	ManualTriggerBaseCooldown       = 5 * time.Minute // wait after the first trigger of a streak
+	ManualTriggerMaxCooldown        = 4 * time.Hour   // the longest a source waits between triggers
+	ManualTriggerStreakReset        = 24 * time.Hour  // a streak ends this long after its last trigger
+	DefaultManualTriggerHourlyLimit = 30              // manual triggers per hour across all sources
+)
+
+// ManualTriggerState tracks a source's streak of manual triggers, stored in the scraping operations
+// table so it expires with the TTL
+type ManualTriggerState struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // MANUAL_TRIGGER#{source_id}
+	SK string `json:"SK" dynamodbav:"SK"` // STATE
+
+	Triggers        int       `json:"triggers" dynamodbav:"triggers"` // triggers in the current streak
+	LastTriggeredAt time.Time `json:"last_triggered_at" dynamodbav:"last_triggered_at"`
+	NextAllowedAt   time.Time `json:"next_allowed_at" dynamodbav:"next_allowed_at"`
+
+	// TTL for auto-expiration once the streak has ended
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// ManualTriggerCooldown returns how long a source waits after the nth trigger of a streak:
+// the base cooldown, doubled for each earlier trigger, up to the maximum
+func ManualTriggerCooldown(n int) time.Duration {
+	cooldown := ManualTriggerBaseCooldown
+	for i := 1; i < n && cooldown < ManualTriggerMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > ManualTriggerMaxCooldown {
+		return ManualTriggerMaxCooldown
+	}
+	return cooldown
+}
+
+// RetryAfter returns how long until the source may be triggered again, or 0 if it may be now.
+// A nil state has no streak.
+func (s *ManualTriggerState) RetryAfter(now time.Time) time.Duration {
+	if s == nil || !now.Before(s.NextAllowedAt) {
+		return 0
+	}
+	return s.NextAllowedAt.Sub(now)
+}
+
+// Next returns the state after a trigger of sourceID at now. A streak whose last trigger is
+// older than ManualTriggerStreakReset starts over.
+func (s *ManualTriggerState) Next(sourceID string, now time.Time) *ManualTriggerState {
+	triggers := 1
+	if s != nil && now.Sub(s.LastTriggeredAt) < ManualTriggerStreakReset {
+		triggers = s.Triggers + 1
+	}
+	return &ManualTriggerState{
+		PK:              CreateManualTriggerPK(sourceID),
+		SK:              CreateManualTriggerSK(),
+		Triggers:        triggers,
+		LastTriggeredAt: now,
+		NextAllowedAt:   now.Add(ManualTriggerCooldown(triggers)),
+		TTL:             now.Add(ManualTriggerStreakReset).Unix(),
+	}
+}
+
+// Helper functions to create primary keys for manual trigger guardrails
+func CreateManualTriggerPK(sourceID string) string {
+	return "MANUAL_TRIGGER#" + sourceID
+}
+
+func CreateManualTriggerSK() string {
+	return "STATE"
+}
+
+// CreateManualTriggerWindowPK returns the key of the item counting the manual triggers of all
+// sources in the hour t falls in
+func CreateManualTriggerWindowPK(t time.Time) string {
+	return "MANUAL_TRIGGER_WINDOW#" + t.UTC().Format("2006-01-02T15")
+}
+
+func CreateManualTriggerWindowSK() string {
+	return "COUNT"
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualTriggerCooldown(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected time.Duration
+	}{
+		{1, 5 * time.Minute},
+		{2, 10 * time.Minute},
+		{3, 20 * time.Minute},
+		{6, 160 * time.Minute},
+		{7, 4 * time.Hour},
+		{50, 4 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := ManualTriggerCooldown(tt.n); got != tt.expected {
+			t.Errorf("ManualTriggerCooldown(%d) = %v, expected %v", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestManualTriggerStateNext(t *testing.T) {
+	now := time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)
+
+	var state *ManualTriggerState
+	if state.RetryAfter(now) != 0 {
+		t.Error("Expected a source without a streak to be triggerable")
+	}
+
+	state = state.Next("parks-1a2b3c4d", now)
+	if state.Triggers != 1 || state.PK != "MANUAL_TRIGGER#parks-1a2b3c4d" || state.SK != "STATE" {
+		t.Errorf("Unexpected first trigger state %+v", state)
+	}
+	if got := state.RetryAfter(now.Add(2 * time.Minute)); got != 3*time.Minute {
+		t.Errorf("Expected 3 minutes left of the first cooldown, got %v", got)
+	}
+	if got := state.RetryAfter(now.Add(5 * time.Minute)); got != 0 {
+		t.Errorf("Expected the source to be triggerable after its cooldown, got %v", got)
+	}
+
+	second := state.Next("parks-1a2b3c4d", now.Add(5*time.Minute))
+	if second.Triggers != 2 || !second.NextAllowedAt.Equal(now.Add(15*time.Minute)) {
+		t.Errorf("Expected the second trigger to double the cooldown, got %+v", second)
+	}
+
+	later := now.Add(5*time.Minute + ManualTriggerStreakReset)
+	if restarted := second.Next("parks-1a2b3c4d", later); restarted.Triggers != 1 {
+		t.Errorf("Expected the streak to start over after a quiet day, got %d triggers", restarted.Triggers)
+	}
+	if second.TTL != now.Add(5*time.Minute+ManualTriggerStreakReset).Unix() {
+		t.Errorf("Expected the state to expire when its streak ends, got TTL %d", second.TTL)
+	}
+}
+
+func TestCreateManualTriggerWindowPK(t *testing.T) {
+	at := time.Date(2026, 10, 16, 17, 59, 0, 0, time.FixedZone("PDT", -7*3600))
+	if got := CreateManualTriggerWindowPK(at); got != "MANUAL_TRIGGER_WINDOW#2026-10-17T00" {
+		t.Errorf("Expected the UTC hour of the trigger, got %s", got)
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bounds of the neighborhood lookup, which is stored as a single item
+const (
+	MaxNeighborhoods        = 250
+	MaxNeighborhoodVertices = 500
+)
+
+// Neighborhood is a named area activities are clustered into
+type Neighborhood struct {
+	Name    string        `json:"name" dynamodbav:"name"`                         // e.g. Columbia City
+	Slug    string        `json:"slug" dynamodbav:"slug"`                         // e.g. columbia-city, derived from the name when not given
+	Region  string        `json:"region,omitempty" dynamodbav:"region,omitempty"` // e.g. south-seattle
+	Polygon []Coordinates `json:"polygon" dynamodbav:"polygon"`                   // boundary vertices in order; the last connects back to the first
+}
+
+// NeighborhoodLookup holds the neighborhood polygons activity locations are matched against
+type NeighborhoodLookup struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // SETTINGS
+	SK string `json:"SK" dynamodbav:"SK"` // NEIGHBORHOODS
+
+	Neighborhoods []Neighborhood `json:"neighborhoods" dynamodbav:"neighborhoods"`
+
+	// Metadata
+	Version   int       `json:"version" dynamodbav:"version"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+// NeighborhoodSlug returns the slug of a neighborhood name, e.g. "columbia-city" for "Columbia City".
+// Public queries match neighborhoods by slug, so either form of a name can be given.
+func NeighborhoodSlug(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "-")
+}
+
+// Validate checks that every neighborhood has a name, a unique slug and a polygon of valid
+// coordinates, filling in slugs derived from names
+func (l *NeighborhoodLookup) Validate() error {
+	if len(l.Neighborhoods) > MaxNeighborhoods {
+		return fmt.Errorf("at most %d neighborhoods can be listed", MaxNeighborhoods)
+	}
+
+	seen := make(map[string]string)
+	for i := range l.Neighborhoods {
+		neighborhood := &l.Neighborhoods[i]
+		neighborhood.Name = strings.TrimSpace(neighborhood.Name)
+		if neighborhood.Name == "" {
+			return fmt.Errorf("neighborhood %d has no name", i+1)
+		}
+		if neighborhood.Slug == "" {
+			neighborhood.Slug = NeighborhoodSlug(neighborhood.Name)
+		}
+		if neighborhood.Slug != NeighborhoodSlug(neighborhood.Slug) {
+			return fmt.Errorf("neighborhood %s slug %q must be lowercase words separated by hyphens", neighborhood.Name, neighborhood.Slug)
+		}
+		if other, exists := seen[neighborhood.Slug]; exists {
+			return fmt.Errorf("neighborhoods %s and %s have the same slug %s", other, neighborhood.Name, neighborhood.Slug)
+		}
+		seen[neighborhood.Slug] = neighborhood.Name
+
+		if len(neighborhood.Polygon) < 3 {
+			return fmt.Errorf("neighborhood %s needs a polygon of at least 3 vertices", neighborhood.Name)
+		}
+		if len(neighborhood.Polygon) > MaxNeighborhoodVertices {
+			return fmt.Errorf("neighborhood %s polygon has more than %d vertices", neighborhood.Name, MaxNeighborhoodVertices)
+		}
+		for _, vertex := range neighborhood.Polygon {
+			if vertex.Lat < -90 || vertex.Lat > 90 || vertex.Lng < -180 || vertex.Lng > 180 {
+				return fmt.Errorf("neighborhood %s has an invalid vertex %v,%v", neighborhood.Name, vertex.Lat, vertex.Lng)
+			}
+		}
+	}
+	return nil
+}
+
+// Helper function to create the sort key of the neighborhood lookup, stored under CreateSettingsPK
+func CreateNeighborhoodLookupSK() string {
+	return "NEIGHBORHOODS"
+}
@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestNeighborhoodSlug(t *testing.T) {
+	tests := map[string]string{
+		"Columbia City":      "columbia-city",
+		"  Phinney  Ridge ":  "phinney-ridge",
+		"U-District":         "u-district",
+		"Queen Anne (Upper)": "queen-anne-upper",
+	}
+	for name, want := range tests {
+		if got := NeighborhoodSlug(name); got != want {
+			t.Errorf("NeighborhoodSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNeighborhoodLookupValidate(t *testing.T) {
+	square := []Coordinates{{Lat: 47.6, Lng: -122.4}, {Lat: 47.6, Lng: -122.3}, {Lat: 47.5, Lng: -122.3}}
+
+	lookup := NeighborhoodLookup{Neighborhoods: []Neighborhood{{Name: " Columbia City ", Polygon: square}}}
+	if err := lookup.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lookup.Neighborhoods[0].Name != "Columbia City" || lookup.Neighborhoods[0].Slug != "columbia-city" {
+		t.Errorf("Expected the name trimmed and the slug derived, got %+v", lookup.Neighborhoods[0])
+	}
+
+	invalid := map[string][]Neighborhood{
+		"no name":        {{Polygon: square}},
+		"duplicate slug": {{Name: "Ballard", Polygon: square}, {Name: "ballard", Polygon: square}},
+		"bad slug":       {{Name: "Ballard", Slug: "Ballard Area", Polygon: square}},
+		"short polygon":  {{Name: "Ballard", Polygon: square[:2]}},
+		"bad vertex":     {{Name: "Ballard", Polygon: []Coordinates{{Lat: 95}, {Lat: 47.6}, {Lat: 47.5}}}},
+	}
+	for name, neighborhoods := range invalid {
+		lookup := NeighborhoodLookup{Neighborhoods: neighborhoods}
+		if err := lookup.Validate(); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
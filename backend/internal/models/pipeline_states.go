@@ -0,0 +1,157 @@
+package models
+
+import "fmt"
+
+// StatusDefinition describes one status an entity can be in
+type StatusDefinition struct {
+	Status      string `json:"status"`
+	Label       string `json:"label"` // e.g. for status badges
+	Description string `json:"description"`
+	Initial     bool   `json:"initial,omitempty"`  // entities are created in it
+	Terminal    bool   `json:"terminal,omitempty"` // no transition leaves it
+}
+
+// StatusTransition is a change of status an entity may make. Transitions with a route are made
+// through the API; the others are made by the pipeline itself.
+type StatusTransition struct {
+	Action string   `json:"action"`
+	From   []string `json:"from"`
+	To     string   `json:"to"`
+	Method string   `json:"method,omitempty"`
+	Path   string   `json:"path,omitempty"` // {id} stands for the entity's ID
+}
+
+// StateMachine lists the statuses of an entity and the transitions allowed between them
+type StateMachine struct {
+	Entity      string             `json:"entity"`
+	Statuses    []StatusDefinition `json:"statuses"`
+	Transitions []StatusTransition `json:"transitions"`
+}
+
+// CanTransition reports whether any transition moves an entity from one status to another
+func (m *StateMachine) CanTransition(from, to string) bool {
+	for _, transition := range m.Transitions {
+		if transition.To == to && containsString(transition.From, from) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionsFrom returns the transitions that leave a status, in the order they are defined
+func (m *StateMachine) TransitionsFrom(from string) []StatusTransition {
+	var transitions []StatusTransition
+	for _, transition := range m.Transitions {
+		if containsString(transition.From, from) {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions
+}
+
+// Validate checks that transitions only use defined statuses, and that terminal statuses are never
+// left
+func (m *StateMachine) Validate() error {
+	defined := make(map[string]StatusDefinition, len(m.Statuses))
+	for _, status := range m.Statuses {
+		defined[status.Status] = status
+	}
+	for _, transition := range m.Transitions {
+		if _, ok := defined[transition.To]; !ok {
+			return fmt.Errorf("%s transition %s leads to undefined status %q", m.Entity, transition.Action, transition.To)
+		}
+		for _, from := range transition.From {
+			status, ok := defined[from]
+			if !ok {
+				return fmt.Errorf("%s transition %s leaves undefined status %q", m.Entity, transition.Action, from)
+			}
+			if status.Terminal {
+				return fmt.Errorf("%s transition %s leaves terminal status %q", m.Entity, transition.Action, from)
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceStateMachine is the lifecycle of a submitted source
+var SourceStateMachine = &StateMachine{
+	Entity: "source",
+	Statuses: []StatusDefinition{
+		{Status: SourceStatusPendingAnalysis, Label: "Analyzing", Description: "Submitted; the source analyzer is looking for its events", Initial: true},
+		{Status: SourceStatusPreflightFailed, Label: "Unreachable", Description: "Its URL failed the pre-flight checks at submission", Initial: true},
+		{Status: SourceStatusAnalysisComplete, Label: "Ready", Description: "Analyzed and waiting to be activated"},
+		{Status: SourceStatusActive, Label: "Active", Description: "Scraped on its schedule"},
+		{Status: SourceStatusPaused, Label: "Paused", Description: "Stopped by an admin; no new scraping tasks are created"},
+		{Status: SourceStatusInactive, Label: "Inactive", Description: "No longer scraped"},
+		{Status: SourceStatusRejected, Label: "Rejected", Description: "Rejected by an admin, or by the domain policy at submission", Initial: true, Terminal: true},
+	},
+	Transitions: []StatusTransition{
+		{Action: "analyze", From: []string{SourceStatusPendingAnalysis}, To: SourceStatusAnalysisComplete},
+		{Action: "activate", From: []string{SourceStatusAnalysisComplete}, To: SourceStatusActive, Method: "PUT", Path: "/api/sources/{id}/activate"},
+		{Action: "pause", From: []string{SourceStatusActive}, To: SourceStatusPaused, Method: "PUT", Path: "/api/sources/{id}/pause"},
+		{Action: "resume", From: []string{SourceStatusPaused}, To: SourceStatusActive, Method: "PUT", Path: "/api/sources/{id}/resume"},
+		{Action: "reject", From: []string{SourceStatusPendingAnalysis, SourceStatusPreflightFailed, SourceStatusAnalysisComplete, SourceStatusActive, SourceStatusPaused, SourceStatusInactive},
+			To: SourceStatusRejected, Method: "PUT", Path: "/api/sources/{id}/reject"},
+	},
+}
+
+// ScrapingTaskStateMachine is the lifecycle of a scraping task. Cancelling a running task is
+// cooperative: executors stop before their next URL.
+var ScrapingTaskStateMachine = &StateMachine{
+	Entity: "scraping_task",
+	Statuses: []StatusDefinition{
+		{Status: string(TaskStatusScheduled), Label: "Scheduled", Description: "Waiting for its run time", Initial: true},
+		{Status: string(TaskStatusQueued), Label: "Queued", Description: "Waiting for an executor", Initial: true},
+		{Status: string(TaskStatusInProgress), Label: "Running", Description: "Being scraped"},
+		{Status: string(TaskStatusFailed), Label: "Failed", Description: "Its run failed"},
+		{Status: string(TaskStatusRetrying), Label: "Retrying", Description: "Waiting to run again after a failure"},
+		{Status: string(TaskStatusCompleted), Label: "Completed", Description: "Its run finished", Terminal: true},
+		{Status: string(TaskStatusCancelled), Label: "Cancelled", Description: "Cancelled by an admin", Terminal: true},
+	},
+	Transitions: []StatusTransition{
+		{Action: "queue", From: []string{string(TaskStatusScheduled)}, To: string(TaskStatusQueued)},
+		{Action: "start", From: []string{string(TaskStatusScheduled), string(TaskStatusQueued), string(TaskStatusRetrying)}, To: string(TaskStatusInProgress)},
+		{Action: "complete", From: []string{string(TaskStatusInProgress)}, To: string(TaskStatusCompleted)},
+		{Action: "fail", From: []string{string(TaskStatusInProgress)}, To: string(TaskStatusFailed)},
+		{Action: "retry", From: []string{string(TaskStatusInProgress), string(TaskStatusFailed)}, To: string(TaskStatusRetrying)},
+		{Action: "cancel", From: []string{string(TaskStatusScheduled), string(TaskStatusQueued), string(TaskStatusInProgress), string(TaskStatusFailed), string(TaskStatusRetrying)},
+			To: string(TaskStatusCancelled), Method: "DELETE", Path: "/api/tasks/{id}"},
+	},
+}
+
+// AdminEventStateMachine is the review of an extracted or provider-submitted event. Editing puts
+// a reviewed event back into the review queue.
+var AdminEventStateMachine = &StateMachine{
+	Entity: "admin_event",
+	Statuses: []StatusDefinition{
+		{Status: string(AdminEventStatusPending), Label: "Pending", Description: "Waiting for review", Initial: true},
+		{Status: string(AdminEventStatusEdited), Label: "Edited", Description: "Edited by a reviewer and waiting for review"},
+		{Status: string(AdminEventStatusApproved), Label: "Approved", Description: "Published to the catalog"},
+		{Status: string(AdminEventStatusRejected), Label: "Rejected", Description: "Rejected by a reviewer, or removed from the catalog"},
+		{Status: string(AdminEventStatusCancelled), Label: "Cancelled", Description: "Withdrawn by the provider that submitted it"},
+	},
+	Transitions: []StatusTransition{
+		{Action: "approve", From: []string{string(AdminEventStatusPending), string(AdminEventStatusEdited)}, To: string(AdminEventStatusApproved), Method: "PUT", Path: "/api/events/{id}/approve"},
+		{Action: "reject", From: []string{string(AdminEventStatusPending), string(AdminEventStatusEdited), string(AdminEventStatusApproved)}, To: string(AdminEventStatusRejected), Method: "PUT", Path: "/api/events/{id}/reject"},
+		{Action: "edit", From: []string{string(AdminEventStatusPending), string(AdminEventStatusEdited), string(AdminEventStatusApproved), string(AdminEventStatusRejected)},
+			To: string(AdminEventStatusEdited), Method: "PUT", Path: "/api/events/{id}/edit"},
+		{Action: "bulk_delete", From: []string{string(AdminEventStatusApproved)}, To: string(AdminEventStatusRejected), Method: "POST", Path: "/api/activities/bulk-delete"},
+		{Action: "withdraw", From: []string{string(AdminEventStatusPending), string(AdminEventStatusEdited)}, To: string(AdminEventStatusCancelled), Method: "DELETE", Path: "/api/provider/events/{id}"},
+		// A provider's cancellation of a published event waits for an admin
+		{Action: "approve_cancellation", From: []string{string(AdminEventStatusApproved)}, To: string(AdminEventStatusCancelled), Method: "PUT", Path: "/api/events/{id}/approve-change"},
+	},
+}
+
+// PipelineStateMachines returns the state machines of the entities moving through the pipeline
+func PipelineStateMachines() []*StateMachine {
+	return []*StateMachine{SourceStateMachine, ScrapingTaskStateMachine, AdminEventStateMachine}
+}
@@ -0,0 +1,68 @@
+package models
+
+import "testing"
+
+func TestPipelineStateMachinesAreValid(t *testing.T) {
+	for _, machine := range PipelineStateMachines() {
+		if err := machine.Validate(); err != nil {
+			t.Errorf("Expected the %s state machine to be valid, got %v", machine.Entity, err)
+		}
+		initial := false
+		for _, status := range machine.Statuses {
+			initial = initial || status.Initial
+		}
+		if !initial {
+			t.Errorf("Expected the %s state machine to have an initial status", machine.Entity)
+		}
+	}
+
+	broken := &StateMachine{
+		Entity:      "broken",
+		Statuses:    []StatusDefinition{{Status: "done", Terminal: true}},
+		Transitions: []StatusTransition{{Action: "reopen", From: []string{"done"}, To: "done"}},
+	}
+	if err := broken.Validate(); err == nil {
+		t.Errorf("Expected a transition leaving a terminal status to be refused")
+	}
+}
+
+func TestScrapingTaskCanTransitionTo(t *testing.T) {
+	allowed := map[ScrapingTaskStatus][]ScrapingTaskStatus{
+		TaskStatusScheduled:  {TaskStatusQueued, TaskStatusInProgress, TaskStatusCancelled},
+		TaskStatusQueued:     {TaskStatusInProgress, TaskStatusCancelled},
+		TaskStatusInProgress: {TaskStatusCompleted, TaskStatusFailed, TaskStatusRetrying, TaskStatusCancelled},
+		TaskStatusFailed:     {TaskStatusRetrying, TaskStatusCancelled},
+		TaskStatusRetrying:   {TaskStatusInProgress, TaskStatusCancelled},
+		TaskStatusCompleted:  nil,
+		TaskStatusCancelled:  nil,
+	}
+
+	for from, targets := range allowed {
+		for to := range allowed {
+			want := false
+			for _, target := range targets {
+				want = want || target == to
+			}
+			task := &ScrapingTask{Status: from}
+			if got := task.CanTransitionTo(to); got != want {
+				t.Errorf("Expected %s -> %s allowed %v, got %v", from, to, want, got)
+			}
+		}
+	}
+}
+
+func TestStateMachineTransitionsFrom(t *testing.T) {
+	var actions []string
+	for _, transition := range AdminEventStateMachine.TransitionsFrom(string(AdminEventStatusApproved)) {
+		actions = append(actions, transition.Action)
+	}
+	want := []string{"reject", "edit", "bulk_delete", "approve_cancellation"}
+	if len(actions) != len(want) {
+		t.Fatalf("Expected actions %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("Expected actions %v, got %v", want, actions)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Preview token limits
+const (
+	DefaultPreviewTokenHours = 48
+	MaxPreviewTokenHours     = 14 * 24
+	MaxPreviewTokenEvents    = 50
+)
+
+// PreviewToken lets whoever holds it see specific unpublished events in the public API, as they
+// will look once published. Tokens are signed rather than stored, so they cannot be revoked and
+// only last until ExpiresAt.
+type PreviewToken struct {
+	EventIDs  []string `json:"ids"`
+	Label     string   `json:"label,omitempty"` // e.g. the curated collection under review
+	CreatedBy string   `json:"by"`
+	ExpiresAt int64    `json:"exp"` // Unix seconds
+}
+
+// PreviewTokenRequest is an editor's request to share unpublished events for review
+type PreviewTokenRequest struct {
+	EventIDs       []string `json:"event_ids"`
+	Label          string   `json:"label"`
+	ExpiresInHours int      `json:"expires_in_hours"` // defaults to DefaultPreviewTokenHours
+	CreatedBy      string   `json:"created_by"`
+}
+
+// Validate checks a preview token request
+func (r *PreviewTokenRequest) Validate() error {
+	if len(r.EventIDs) == 0 {
+		return fmt.Errorf("event_ids is required")
+	}
+	if len(r.EventIDs) > MaxPreviewTokenEvents {
+		return fmt.Errorf("a preview token can cover at most %d events", MaxPreviewTokenEvents)
+	}
+	for _, eventID := range r.EventIDs {
+		if strings.TrimSpace(eventID) == "" {
+			return fmt.Errorf("event_ids cannot contain empty IDs")
+		}
+	}
+	if r.ExpiresInHours < 0 || r.ExpiresInHours > MaxPreviewTokenHours {
+		return fmt.Errorf("expires_in_hours must be between 1 and %d", MaxPreviewTokenHours)
+	}
+	if r.CreatedBy == "" {
+		return fmt.Errorf("created_by is required")
+	}
+	return nil
+}
+
+// Token returns the preview token the request asks for, issued at now. Repeated event IDs are
+// listed once.
+func (r *PreviewTokenRequest) Token(now time.Time) PreviewToken {
+	hours := r.ExpiresInHours
+	if hours == 0 {
+		hours = DefaultPreviewTokenHours
+	}
+
+	seen := make(map[string]bool, len(r.EventIDs))
+	eventIDs := []string{}
+	for _, eventID := range r.EventIDs {
+		eventID = strings.TrimSpace(eventID)
+		if !seen[eventID] {
+			seen[eventID] = true
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+
+	return PreviewToken{
+		EventIDs:  eventIDs,
+		Label:     strings.TrimSpace(r.Label),
+		CreatedBy: r.CreatedBy,
+		ExpiresAt: now.Add(time.Duration(hours) * time.Hour).Unix(),
+	}
+}
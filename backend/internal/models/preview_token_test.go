@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreviewTokenRequest(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	req := PreviewTokenRequest{
+		EventIDs:  []string{"event-1", " event-2 ", "event-1"},
+		Label:     " Winter break camps ",
+		CreatedBy: "editor@example.com",
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+
+	token := req.Token(now)
+	if strings.Join(token.EventIDs, ",") != "event-1,event-2" {
+		t.Errorf("Expected each event once, got %v", token.EventIDs)
+	}
+	if token.Label != "Winter break camps" {
+		t.Errorf("Expected a trimmed label, got %q", token.Label)
+	}
+	if want := now.Add(DefaultPreviewTokenHours * time.Hour).Unix(); token.ExpiresAt != want {
+		t.Errorf("Expected the default expiry %d, got %d", want, token.ExpiresAt)
+	}
+
+	req.ExpiresInHours = 6
+	if want := now.Add(6 * time.Hour).Unix(); req.Token(now).ExpiresAt != want {
+		t.Errorf("Expected a 6 hour expiry %d, got %d", want, req.Token(now).ExpiresAt)
+	}
+
+	tooMany := make([]string, MaxPreviewTokenEvents+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("event-%d", i)
+	}
+	for _, invalid := range []PreviewTokenRequest{
+		{CreatedBy: "editor@example.com"},
+		{EventIDs: []string{"event-1", " "}, CreatedBy: "editor@example.com"},
+		{EventIDs: tooMany, CreatedBy: "editor@example.com"},
+		{EventIDs: []string{"event-1"}, ExpiresInHours: MaxPreviewTokenHours + 1, CreatedBy: "editor@example.com"},
+		{EventIDs: []string{"event-1"}, ExpiresInHours: -1, CreatedBy: "editor@example.com"},
+		{EventIDs: []string{"event-1"}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be refused", invalid)
+		}
+	}
+}
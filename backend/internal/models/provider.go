@@ -0,0 +1,241 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// Provider account status constants
+const (
+	ProviderStatusActive  = "active"
+	ProviderStatusRevoked = "revoked"
+)
+
+// Provider change action constants: what a provider asked to do to a published event
+const (
+	ProviderChangeUpdate = "update"
+	ProviderChangeCancel = "cancel"
+)
+
+// providerTokenPrefix starts every provider token so leaked tokens are easy to recognize
+const providerTokenPrefix = "prv"
+
+// ProviderAccount is a venue or organizer allowed to submit and manage its own events.
+// Providers authenticate with a bearer token issued by an admin; only its hash is stored.
+type ProviderAccount struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PROVIDER_ACCOUNT#{provider_id}
+	SK string `json:"SK" dynamodbav:"SK"` // ACCOUNT
+
+	ProviderID   string `json:"provider_id" dynamodbav:"provider_id"`
+	ProviderName string `json:"provider_name" dynamodbav:"provider_name"`
+	Website      string `json:"website" dynamodbav:"website"` // source URL of the provider's submissions
+	ContactEmail string `json:"contact_email,omitempty" dynamodbav:"contact_email,omitempty"`
+	Status       string `json:"status" dynamodbav:"status"`                         // active, revoked
+	VenueID      string `json:"venue_id,omitempty" dynamodbav:"venue_id,omitempty"` // set for accounts issued by a venue claim
+
+	// Token
+	TokenHash   string `json:"-" dynamodbav:"token_hash"`              // sha256 of the token secret
+	TokenPrefix string `json:"token_prefix" dynamodbav:"token_prefix"` // first characters of the token, to tell tokens apart
+
+	// Metadata
+	CreatedBy string     `json:"created_by" dynamodbav:"created_by"`
+	CreatedAt time.Time  `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" dynamodbav:"updated_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" dynamodbav:"revoked_at,omitempty"`
+}
+
+// ProviderAccountRequest is an admin's request to issue a provider token
+type ProviderAccountRequest struct {
+	ProviderName string `json:"provider_name"`
+	Website      string `json:"website"`
+	ContactEmail string `json:"contact_email"`
+	CreatedBy    string `json:"created_by"`
+}
+
+// ProviderEventSubmission is an event a provider submits or updates. Fields follow the
+// "events" extraction schema so submissions convert and review like crawled events.
+type ProviderEventSubmission struct {
+	Title                string   `json:"title"`
+	Description          string   `json:"description"`
+	Date                 string   `json:"date"` // YYYY-MM-DD
+	Time                 string   `json:"time,omitempty"`
+	Duration             string   `json:"duration,omitempty"`
+	Location             string   `json:"location"`
+	Address              string   `json:"address,omitempty"`
+	Price                string   `json:"price,omitempty"`
+	RegistrationURL      string   `json:"registration_url,omitempty"`
+	RegistrationRequired *bool    `json:"registration_required,omitempty"`
+	Participation        string   `json:"participation,omitempty"`
+	AgeGroups            []string `json:"age_groups,omitempty"`
+}
+
+// ProviderEventChange is a provider's update or cancellation of a published event.
+// The published version stays live until an admin approves the change.
+type ProviderEventChange struct {
+	Action      string                 `json:"action"`               // update, cancel
+	EventData   map[string]interface{} `json:"event_data,omitempty"` // the updated event, for updates
+	Reason      string                 `json:"reason,omitempty"`
+	RequestedAt time.Time              `json:"requested_at"`
+}
+
+// Validate checks an admin's provider token request
+func (r *ProviderAccountRequest) Validate() error {
+	if strings.TrimSpace(r.ProviderName) == "" {
+		return fmt.Errorf("provider_name is required")
+	}
+	if r.Website == "" {
+		return fmt.Errorf("website is required")
+	}
+	if err := urlutil.Validate(r.Website); err != nil {
+		return fmt.Errorf("invalid website: %w", err)
+	}
+	if r.ContactEmail != "" {
+		if _, err := mail.ParseAddress(r.ContactEmail); err != nil {
+			return fmt.Errorf("invalid contact_email: %w", err)
+		}
+	}
+	if r.CreatedBy == "" {
+		return fmt.Errorf("created_by is required")
+	}
+	return nil
+}
+
+// Validate checks that a submission has what reviewers and the public site need
+func (s *ProviderEventSubmission) Validate() error {
+	if strings.TrimSpace(s.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	if s.Date == "" {
+		return fmt.Errorf("date is required")
+	}
+	if _, err := time.Parse("2006-01-02", s.Date); err != nil {
+		return fmt.Errorf("invalid date %q: must be YYYY-MM-DD", s.Date)
+	}
+	if strings.TrimSpace(s.Location) == "" {
+		return fmt.Errorf("location is required")
+	}
+	if s.RegistrationURL != "" {
+		if err := urlutil.Validate(s.RegistrationURL); err != nil {
+			return fmt.Errorf("invalid registration_url: %w", err)
+		}
+	}
+	return nil
+}
+
+// EventData returns the submission as one event of the "events" extraction schema
+func (s *ProviderEventSubmission) EventData() map[string]interface{} {
+	data := map[string]interface{}{
+		"title":    strings.TrimSpace(s.Title),
+		"date":     s.Date,
+		"location": strings.TrimSpace(s.Location),
+	}
+	for key, value := range map[string]string{
+		"description":      s.Description,
+		"time":             s.Time,
+		"duration":         s.Duration,
+		"address":          s.Address,
+		"price":            s.Price,
+		"registration_url": s.RegistrationURL,
+		"participation":    s.Participation,
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			data[key] = value
+		}
+	}
+	if s.RegistrationRequired != nil {
+		data["registration_required"] = *s.RegistrationRequired
+	}
+	if len(s.AgeGroups) > 0 {
+		// Conversion reads age groups the way they come out of a JSON extraction response
+		ageGroups := make([]interface{}, len(s.AgeGroups))
+		for i, group := range s.AgeGroups {
+			ageGroups[i] = group
+		}
+		data["age_groups"] = ageGroups
+	}
+	return data
+}
+
+// ProviderEventRawData wraps one event in the raw extraction format stored on admin events
+func ProviderEventRawData(eventData map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"events": []interface{}{eventData}}
+}
+
+// ApplyVenue pins a submission to the venue the provider claimed
+func (s *ProviderEventSubmission) ApplyVenue(venue *Venue) {
+	s.Location = venue.VenueName
+	if s.Location == "" {
+		s.Location = venue.Name
+	}
+	if venue.Address != "" {
+		s.Address = venue.Address
+	}
+}
+
+// IsActive returns true if the provider may use its token
+func (p *ProviderAccount) IsActive() bool {
+	return p.Status == ProviderStatusActive
+}
+
+// CheckTokenSecret reports whether a token secret matches the provider's token
+func (p *ProviderAccount) CheckTokenSecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashProviderTokenSecret(secret)), []byte(p.TokenHash)) == 1
+}
+
+// GenerateProviderToken creates a new token for a provider. The token is shown to the admin once;
+// only the returned hash of its secret is stored.
+func GenerateProviderToken(providerID string) (token, secretHash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate provider token: %w", err)
+	}
+	encoded := hex.EncodeToString(secret)
+	return strings.Join([]string{providerTokenPrefix, providerID, encoded}, "."), HashProviderTokenSecret(encoded), nil
+}
+
+// ParseProviderToken splits a provider token into the provider it was issued to and its secret
+func ParseProviderToken(token string) (providerID, secret string, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != providerTokenPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("malformed provider token")
+	}
+	return parts[1], parts[2], nil
+}
+
+// HashProviderTokenSecret returns the stored form of a token secret
+func HashProviderTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ProviderTokenDisplayPrefix returns the part of a token that is safe to show after it was issued
+func ProviderTokenDisplayPrefix(token string) string {
+	providerID, secret, err := ParseProviderToken(token)
+	if err != nil || len(secret) < 6 {
+		return ""
+	}
+	return strings.Join([]string{providerTokenPrefix, providerID, secret[:6]}, ".")
+}
+
+// Helper functions to create primary keys for provider accounts
+func CreateProviderAccountPK(providerID string) string {
+	return fmt.Sprintf("PROVIDER_ACCOUNT#%s", providerID)
+}
+
+func CreateProviderAccountSK() string {
+	return "ACCOUNT"
+}
+
+// ProviderUser is the submitter recorded on admin events a provider submits
+func ProviderUser(providerID string) string {
+	return "provider:" + providerID
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProviderToken(t *testing.T) {
+	token, secretHash, err := GenerateProviderToken("parks-1a2b3c4d")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	providerID, secret, err := ParseProviderToken(token)
+	if err != nil {
+		t.Fatalf("Expected the generated token to parse, got %v", err)
+	}
+	if providerID != "parks-1a2b3c4d" {
+		t.Errorf("Expected provider parks-1a2b3c4d, got %s", providerID)
+	}
+
+	account := &ProviderAccount{TokenHash: secretHash}
+	if !account.CheckTokenSecret(secret) {
+		t.Error("Expected the token secret to match its hash")
+	}
+	if account.CheckTokenSecret(secret + "0") {
+		t.Error("Expected a different secret to be refused")
+	}
+	if strings.Contains(secretHash, secret) {
+		t.Error("Expected only a hash of the secret to be stored")
+	}
+
+	prefix := ProviderTokenDisplayPrefix(token)
+	if !strings.HasPrefix(token, prefix) || len(prefix) >= len(token) {
+		t.Errorf("Expected a short prefix of the token, got %q", prefix)
+	}
+
+	for _, malformed := range []string{"", "prv.parks", "abc.parks.secret", "prv..secret", "prv.parks.secret.extra"} {
+		if _, _, err := ParseProviderToken(malformed); err == nil {
+			t.Errorf("Expected %q to be refused", malformed)
+		}
+	}
+}
+
+func TestProviderEventSubmission(t *testing.T) {
+	required := true
+	submission := ProviderEventSubmission{
+		Title:                " Toddler Story Time ",
+		Date:                 "2026-11-07",
+		Time:                 "10:30",
+		Location:             "Ballard Library",
+		RegistrationURL:      "https://example.org/story-time",
+		RegistrationRequired: &required,
+		AgeGroups:            []string{"toddlers"},
+	}
+	if err := submission.Validate(); err != nil {
+		t.Fatalf("Expected a valid submission, got %v", err)
+	}
+
+	data := submission.EventData()
+	if data["title"] != "Toddler Story Time" || data["registration_required"] != true {
+		t.Errorf("Unexpected event data %v", data)
+	}
+	if _, ok := data["description"]; ok {
+		t.Error("Expected empty fields to be left out")
+	}
+	if ageGroups, ok := data["age_groups"].([]interface{}); !ok || len(ageGroups) != 1 {
+		t.Errorf("Expected age groups in extraction response form, got %#v", data["age_groups"])
+	}
+
+	invalid := []ProviderEventSubmission{
+		{Date: "2026-11-07", Location: "Ballard Library"},
+		{Title: "Story Time", Date: "11/07/2026", Location: "Ballard Library"},
+		{Title: "Story Time", Date: "2026-11-07"},
+		{Title: "Story Time", Date: "2026-11-07", Location: "Ballard Library", RegistrationURL: "not a url"},
+	}
+	for _, submission := range invalid {
+		if err := submission.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", submission)
+		}
+	}
+}
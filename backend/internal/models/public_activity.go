@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// PublicActivity is the canonical activity payload served to the frontend by the approved
+// events endpoint. It is the published Activity plus the admin metadata for its event.
+type PublicActivity struct {
+	Activity
+	AdminMetadata *PublicActivityAdminMetadata `json:"admin_metadata,omitempty"`
+	Occurrence    *ActivityOccurrence          `json:"occurrence,omitempty"`  // set when a date range query is expanded into daily occurrences
+	Preview       bool                         `json:"preview,omitempty"`     // an unpublished event shown with a preview token
+	DistanceKm    *float64                     `json:"distance_km,omitempty"` // set when the activities near a point are queried
+}
+
+// ActivityOccurrence identifies one day of an activity in an expanded date range response
+type ActivityOccurrence struct {
+	ID   string `json:"id"`             // {activity_id}#{date}, unique within the response
+	Date string `json:"date"`           // YYYY-MM-DD
+	Day  int    `json:"day,omitempty"`  // day number within a multi-day activity, e.g. day 2 of 3
+	Days int    `json:"days,omitempty"` // total days of a multi-day activity
+}
+
+// PublicActivityAdminMetadata links a public activity back to the admin event it was approved from
+type PublicActivityAdminMetadata struct {
+	ExtractedAt time.Time `json:"extracted_at"`
+	ExtractedBy string    `json:"extracted_by"`
+	EventID     string    `json:"event_id"`
+	SourceURL   string    `json:"source_url"`
+	SchemaType  string    `json:"schema_type"`
+	BrokenLinks []string  `json:"broken_links"`
+}
+
+// PublicActivityIssue describes a field that would render incorrectly on the frontend
+type PublicActivityIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (i PublicActivityIssue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+var (
+	validActivityTypes = map[string]bool{
+		TypeClass: true, TypeCamp: true, TypeEvent: true, TypePerformance: true, TypeFreeActivity: true,
+	}
+	validActivityCategories = map[string]bool{
+		CategoryArtsCreativity: true, CategoryActiveSports: true, CategoryEducationalSTEM: true,
+		CategoryEntertainmentEvents: true, CategoryCampsPrograms: true, CategoryFreeCommunity: true,
+	}
+	validParticipationTypes = map[string]bool{
+		ParticipationDropIn: true, ParticipationRegistration: true, ParticipationTicketed: true, ParticipationSoldOut: true,
+	}
+)
+
+// IsParticipationType reports whether value is one of the participation type constants
+func IsParticipationType(value string) bool {
+	return validParticipationTypes[value]
+}
+
+// ValidatePublic checks the activity against what the frontend needs to render it and
+// returns every problem found. An activity with no issues is safe to publish.
+func (a *Activity) ValidatePublic() []PublicActivityIssue {
+	var issues []PublicActivityIssue
+	add := func(field, format string, args ...interface{}) {
+		issues = append(issues, PublicActivityIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if a.ID == "" {
+		add("id", "is required")
+	}
+	if a.Title == "" {
+		add("title", "is required")
+	}
+	if !validActivityTypes[a.Type] {
+		add("type", "%q is not a known activity type", a.Type)
+	}
+	if a.Category != "" && !validActivityCategories[a.Category] {
+		add("category", "%q is not a known category", a.Category)
+	}
+	if a.ParticipationType != "" && !validParticipationTypes[a.ParticipationType] {
+		add("participationType", "%q is not a known participation type", a.ParticipationType)
+	}
+
+	// Ongoing activities and recurring ones listed by weekday render without a start date
+	schedule := a.Schedule
+	undated := schedule.Type == ScheduleTypeOngoing || (schedule.Type == ScheduleTypeRecurring && len(schedule.DaysOfWeek) > 0)
+	startDate, startErr := time.Parse("2006-01-02", schedule.StartDate)
+	switch {
+	case schedule.StartDate == "" && !undated:
+		add("schedule.startDate", "is required")
+	case schedule.StartDate != "" && startErr != nil:
+		add("schedule.startDate", "%q is not a YYYY-MM-DD date", schedule.StartDate)
+	}
+	if schedule.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", schedule.EndDate)
+		switch {
+		case err != nil:
+			add("schedule.endDate", "%q is not a YYYY-MM-DD date", schedule.EndDate)
+		case startErr == nil && endDate.Before(startDate):
+			add("schedule.endDate", "is before the start date")
+		}
+	}
+	checkTime := func(field, value string) {
+		if value == "" {
+			return
+		}
+		if _, err := time.Parse("15:04", value); err != nil {
+			add(field, "%q is not an HH:MM time", value)
+		}
+	}
+	checkTime("schedule.startTime", schedule.StartTime)
+	checkTime("schedule.endTime", schedule.EndTime)
+	for i, slot := range schedule.Times {
+		checkTime(fmt.Sprintf("schedule.times[%d].startTime", i), slot.StartTime)
+		checkTime(fmt.Sprintf("schedule.times[%d].endTime", i), slot.EndTime)
+	}
+
+	if a.Location.Name == "" && a.Location.Address == "" {
+		add("location", "needs a name or address")
+	}
+
+	checkURL := func(field, value string) {
+		if value == "" {
+			return
+		}
+		if parsed, err := url.Parse(value); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			add(field, "%q is not an absolute http(s) URL", value)
+		}
+	}
+	checkURL("detailUrl", a.DetailURL)
+	checkURL("registration.url", a.Registration.URL)
+	for i, image := range a.Images {
+		checkURL(fmt.Sprintf("images[%d].url", i), image.URL)
+	}
+
+	return issues
+}
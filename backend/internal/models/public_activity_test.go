@@ -0,0 +1,47 @@
+package models
+
+import "testing"
+
+func TestActivityValidatePublic(t *testing.T) {
+	valid := Activity{
+		ID:       "act-1",
+		Title:    "Toddler Story Time",
+		Type:     TypeEvent,
+		Category: CategoryEducationalSTEM,
+		Schedule: Schedule{Type: ScheduleTypeOneTime, StartDate: "2025-07-12", StartTime: "10:30"},
+		Location: Location{Name: "Green Lake Library"},
+	}
+	if issues := valid.ValidatePublic(); len(issues) != 0 {
+		t.Fatalf("Expected a valid activity, got %v", issues)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(a *Activity)
+		field  string
+	}{
+		{"missing title", func(a *Activity) { a.Title = "" }, "title"},
+		{"unknown type", func(a *Activity) { a.Type = "venue" }, "type"},
+		{"unparsed start date", func(a *Activity) { a.Schedule.StartDate = "Saturday, July 12" }, "schedule.startDate"},
+		{"end before start", func(a *Activity) { a.Schedule.EndDate = "2025-07-01" }, "schedule.endDate"},
+		{"bad start time", func(a *Activity) { a.Schedule.StartTime = "10:30am" }, "schedule.startTime"},
+		{"no location", func(a *Activity) { a.Location = Location{} }, "location"},
+		{"relative detail link", func(a *Activity) { a.DetailURL = "/events/42" }, "detailUrl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := valid
+			tt.modify(&activity)
+			issues := activity.ValidatePublic()
+			if len(issues) != 1 || issues[0].Field != tt.field {
+				t.Errorf("Expected one %s issue, got %v", tt.field, issues)
+			}
+		})
+	}
+
+	recurring := valid
+	recurring.Schedule = Schedule{Type: ScheduleTypeRecurring, DaysOfWeek: []string{"saturday"}}
+	if issues := recurring.ValidatePublic(); len(issues) != 0 {
+		t.Errorf("Expected a weekly activity without a start date to be valid, got %v", issues)
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Publish manifest status constants
+const (
+	PublishManifestStatusComplete = "complete"
+	PublishManifestStatusFailed   = "failed" // the run stopped early; Objects lists what it wrote before failing
+)
+
+// PublishManifest records the objects one publisher run wrote, so every published file can be
+// traced to its run and the exact object version restored. It is written under the publisher's own
+// key prefix after the run's objects, including when the run fails.
+type PublishManifest struct {
+	RunID      string            `json:"run_id"`
+	Publisher  string            `json:"publisher"` // activities, feeds, open_data, catalog_snapshot
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Objects    []PublishedObject `json:"objects"`
+}
+
+// PublishedObject is one object written by a publisher run
+type PublishedObject struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`               // hex SHA-256 of the body
+	ETag        string `json:"etag"`                 // as returned by S3, without quotes
+	VersionID   string `json:"version_id,omitempty"` // set when the bucket is versioned
+	Parts       int    `json:"parts,omitempty"`      // parts of a multipart upload
+	Attempts    int    `json:"attempts"`             // requests sent, counting retries
+}
+
+// PublishManifestKey returns the object key of a run's manifest under a publisher's key prefix
+func PublishManifestKey(prefix, runID string) string {
+	return prefix + "manifests/" + runID + ".json"
+}
+
+// Size returns the total bytes written by the run
+func (m *PublishManifest) Size() int64 {
+	var size int64
+	for _, object := range m.Objects {
+		size += object.Size
+	}
+	return size
+}
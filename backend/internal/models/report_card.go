@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Report card grade constants
+const (
+	ReportCardGradeA    = "A"
+	ReportCardGradeB    = "B"
+	ReportCardGradeC    = "C"
+	ReportCardGradeD    = "D"
+	ReportCardGradeF    = "F"
+	ReportCardGradeNone = "n/a" // no runs, extractions or reviews in the week
+)
+
+// SourceReportCard grades the data quality of a source over one week, combining its scraping
+// run metrics, the conversion issues of the events extracted from it, and how often admins
+// rejected its events. Report cards are stored as JSON in the reports bucket.
+type SourceReportCard struct {
+	SourceID   string    `json:"source_id"`
+	SourceName string    `json:"source_name"`
+	BaseURL    string    `json:"base_url"`
+	WeekStart  time.Time `json:"week_start"` // Monday 00:00 UTC
+	WeekEnd    time.Time `json:"week_end"`   // exclusive
+
+	Grade string  `json:"grade"` // A - F, or n/a without data
+	Score float64 `json:"score"` // 0.0 - 1.0
+
+	Metrics    SourceMetrics        `json:"metrics"`
+	Conversion ReportCardConversion `json:"conversion"`
+	Review     ReportCardReview     `json:"review"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// ReportCardConversion summarizes the conversion issues of the events extracted in the week
+type ReportCardConversion struct {
+	EventsExtracted  int               `json:"events_extracted"`
+	EventsWithIssues int               `json:"events_with_issues"`
+	IssueRate        float64           `json:"issue_rate"` // 0.0 - 1.0
+	TopIssues        []ReportCardIssue `json:"top_issues"`
+}
+
+// ReportCardIssue counts the events that had a conversion issue
+type ReportCardIssue struct {
+	Issue string `json:"issue"`
+	Count int    `json:"count"`
+}
+
+// ReportCardReview summarizes the admin decisions made in the week
+type ReportCardReview struct {
+	Reviewed      int     `json:"reviewed"`
+	Approved      int     `json:"approved"`
+	Rejected      int     `json:"rejected"`
+	RejectionRate float64 `json:"rejection_rate"` // 0.0 - 1.0
+}
+
+// ReportCardWeekStart returns the start of the report card week containing t, Monday 00:00 UTC
+func ReportCardWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// ReportCardGrade returns the letter grade for a report card score
+func ReportCardGrade(score float64) string {
+	switch {
+	case score >= 0.9:
+		return ReportCardGradeA
+	case score >= 0.8:
+		return ReportCardGradeB
+	case score >= 0.7:
+		return ReportCardGradeC
+	case score >= 0.6:
+		return ReportCardGradeD
+	default:
+		return ReportCardGradeF
+	}
+}
+
+// Helper functions to create the storage keys of report cards
+func CreateReportCardKey(sourceID string, weekStart time.Time) string {
+	return fmt.Sprintf("report-cards/%s/%s.json", sourceID, weekStart.UTC().Format("2006-01-02"))
+}
+
+// CreateLatestReportCardKey returns the key of a copy of the source's most recent report card
+func CreateLatestReportCardKey(sourceID string) string {
+	return fmt.Sprintf("report-cards/%s/latest.json", sourceID)
+}
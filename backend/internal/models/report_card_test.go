@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportCardWeekStart(t *testing.T) {
+	monday := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	pacific := time.FixedZone("PDT", -7*3600)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected time.Time
+	}{
+		{"Monday", monday, monday},
+		{"Wednesday", time.Date(2026, 10, 7, 15, 30, 0, 0, time.UTC), monday},
+		{"Sunday", time.Date(2026, 10, 11, 23, 59, 0, 0, time.UTC), monday},
+		{"SundayEveningPacific", time.Date(2026, 10, 11, 20, 0, 0, 0, pacific), monday.AddDate(0, 0, 7)}, // Monday in UTC
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReportCardWeekStart(tt.t); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCreateReportCardKey(t *testing.T) {
+	key := CreateReportCardKey("src-a", time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC))
+	if key != "report-cards/src-a/2026-10-05.json" {
+		t.Errorf("Unexpected report card key %s", key)
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Review preset target constants: the pending queue a preset applies to
+const (
+	PresetTargetEvents  = "events"
+	PresetTargetSources = "sources"
+)
+
+// Review sort constants
+const (
+	ReviewSortExtractedAt  = "extracted_at" // events, newest first by default
+	ReviewSortConfidence   = "confidence"   // events
+	ReviewSortCompleteness = "completeness" // events
+	ReviewSortSubmittedAt  = "submitted_at" // sources, newest first by default
+	ReviewSortPriority     = "priority"     // sources, high first by default
+	ReviewSortName         = "name"         // sources
+)
+
+// Review sort order constants
+const (
+	ReviewOrderAsc  = "asc"
+	ReviewOrderDesc = "desc"
+)
+
+// reviewSorts lists the sorts each pending queue supports
+var reviewSorts = map[string][]string{
+	PresetTargetEvents:  {ReviewSortExtractedAt, ReviewSortConfidence, ReviewSortCompleteness},
+	PresetTargetSources: {ReviewSortSubmittedAt, ReviewSortPriority, ReviewSortName},
+}
+
+// ReviewFilters narrows and orders a pending review queue. Empty fields do not filter.
+type ReviewFilters struct {
+	SchemaType    string   `json:"schema_type,omitempty" dynamodbav:"schema_type,omitempty"`       // events
+	SourceDomain  string   `json:"source_domain,omitempty" dynamodbav:"source_domain,omitempty"`   // events: domain of the source URL
+	ExtractedBy   string   `json:"extracted_by,omitempty" dynamodbav:"extracted_by,omitempty"`     // events: who submitted the crawl
+	MinConfidence *float64 `json:"min_confidence,omitempty" dynamodbav:"min_confidence,omitempty"` // events, 0 - 100
+	MaxConfidence *float64 `json:"max_confidence,omitempty" dynamodbav:"max_confidence,omitempty"` // events, 0 - 100
+	SourceType    string   `json:"source_type,omitempty" dynamodbav:"source_type,omitempty"`       // sources
+	Priority      string   `json:"priority,omitempty" dynamodbav:"priority,omitempty"`             // sources: high, medium, low
+	Sort          string   `json:"sort,omitempty" dynamodbav:"sort,omitempty"`
+	Order         string   `json:"order,omitempty" dynamodbav:"order,omitempty"` // asc, desc
+}
+
+// Validate checks that the sort suits the target queue and the confidence range is sensible
+func (f *ReviewFilters) Validate(target string) error {
+	sorts, ok := reviewSorts[target]
+	if !ok {
+		return fmt.Errorf("invalid target: %s", target)
+	}
+
+	if f.Sort != "" {
+		valid := false
+		for _, sort := range sorts {
+			valid = valid || sort == f.Sort
+		}
+		if !valid {
+			return fmt.Errorf("invalid sort %q for %s: must be one of %v", f.Sort, target, sorts)
+		}
+	}
+	if f.Order != "" && f.Order != ReviewOrderAsc && f.Order != ReviewOrderDesc {
+		return fmt.Errorf("invalid order %q: must be asc or desc", f.Order)
+	}
+
+	for _, bound := range []*float64{f.MinConfidence, f.MaxConfidence} {
+		if bound != nil && (*bound < 0 || *bound > 100) {
+			return fmt.Errorf("confidence bounds must be between 0 and 100")
+		}
+	}
+	if f.MinConfidence != nil && f.MaxConfidence != nil && *f.MinConfidence > *f.MaxConfidence {
+		return fmt.Errorf("min_confidence must not exceed max_confidence")
+	}
+
+	return nil
+}
+
+// Merge returns the filters with every field set in overrides replacing the preset's value
+func (f ReviewFilters) Merge(overrides ReviewFilters) ReviewFilters {
+	merged := f
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&merged.SchemaType, overrides.SchemaType},
+		{&merged.SourceDomain, overrides.SourceDomain},
+		{&merged.ExtractedBy, overrides.ExtractedBy},
+		{&merged.SourceType, overrides.SourceType},
+		{&merged.Priority, overrides.Priority},
+		{&merged.Sort, overrides.Sort},
+		{&merged.Order, overrides.Order},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+	if overrides.MinConfidence != nil {
+		merged.MinConfidence = overrides.MinConfidence
+	}
+	if overrides.MaxConfidence != nil {
+		merged.MaxConfidence = overrides.MaxConfidence
+	}
+	return merged
+}
+
+// ReviewPreset is a named set of review filters saved by one admin
+type ReviewPreset struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // ADMIN#{admin}
+	SK string `json:"-" dynamodbav:"SK"` // PRESET#{preset_id}
+
+	PresetID string        `json:"preset_id" dynamodbav:"preset_id"`
+	Admin    string        `json:"admin" dynamodbav:"admin"`
+	Name     string        `json:"name" dynamodbav:"name"`
+	Target   string        `json:"target" dynamodbav:"target"` // events, sources
+	Filters  ReviewFilters `json:"filters" dynamodbav:"filters"`
+
+	// Metadata
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+}
+
+// Validate checks that the preset has an owner, a name and filters valid for its target
+func (p *ReviewPreset) Validate() error {
+	if p.Admin == "" {
+		return fmt.Errorf("admin is required")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return p.Filters.Validate(p.Target)
+}
+
+// Helper functions to create primary keys for review presets
+func CreateReviewPresetPK(admin string) string {
+	return "ADMIN#" + admin
+}
+
+func CreateReviewPresetSK(presetID string) string {
+	return "PRESET#" + presetID
+}
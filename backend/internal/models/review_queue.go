@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ReviewQueueLatency is how long the events pending review have been waiting, measured against
+// the review latency SLO
+type ReviewQueueLatency struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Pending     int       `json:"pending"`
+
+	// Time-in-queue percentiles of the pending events, in hours
+	P50Hours float64 `json:"p50_hours"`
+	P90Hours float64 `json:"p90_hours"`
+	P99Hours float64 `json:"p99_hours"`
+	MaxHours float64 `json:"max_hours"`
+
+	SLOHours    float64 `json:"slo_hours"`
+	SLOBreached bool    `json:"slo_breached"` // p90 over the SLO
+
+	OldestEventID  string     `json:"oldest_event_id,omitempty"`
+	OldestQueuedAt *time.Time `json:"oldest_queued_at,omitempty"`
+
+	Distribution []ReviewQueueBucket `json:"distribution"`
+}
+
+// ReviewQueueBucket counts the pending events that have waited between two ages
+type ReviewQueueBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import "fmt"
+
+// Source run limit names reported when a source stops early
+const (
+	RunLimitPages      = "max_pages"
+	RunLimitActivities = "max_activities"
+	RunLimitCredits    = "max_credits"
+)
+
+// DefaultSourceRunLimits are applied to newly activated sources unless the admin sets their own
+var DefaultSourceRunLimits = SourceRunLimits{
+	MaxPages:      25,
+	MaxActivities: 500,
+	MaxCredits:    250,
+}
+
+// SourceRunLimits caps what one source may consume within a single scraping run.
+// A zero value disables that limit.
+type SourceRunLimits struct {
+	MaxPages      int `json:"max_pages,omitempty" dynamodbav:"max_pages,omitempty"`           // pages fetched
+	MaxActivities int `json:"max_activities,omitempty" dynamodbav:"max_activities,omitempty"` // activities kept for review
+	MaxCredits    int `json:"max_credits,omitempty" dynamodbav:"max_credits,omitempty"`       // FireCrawl credits consumed
+}
+
+// Validate checks that no limit is negative
+func (l *SourceRunLimits) Validate() error {
+	for name, value := range map[string]int{
+		RunLimitPages:      l.MaxPages,
+		RunLimitActivities: l.MaxActivities,
+		RunLimitCredits:    l.MaxCredits,
+	} {
+		if value < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether any limit is set
+func (l *SourceRunLimits) Enabled() bool {
+	return l != nil && (l.MaxPages > 0 || l.MaxActivities > 0 || l.MaxCredits > 0)
+}
+
+// SourceRunUsage counts what one source has consumed so far in a fan-out run.
+// Executors update it atomically so limits hold across concurrent tasks.
+type SourceRunUsage struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // RUN#{run_id}
+	SK string `json:"SK" dynamodbav:"SK"` // USAGE#{source_id}
+
+	RunID      string `json:"run_id" dynamodbav:"run_id"`
+	SourceID   string `json:"source_id" dynamodbav:"source_id"`
+	Pages      int    `json:"pages" dynamodbav:"pages"`
+	Activities int    `json:"activities" dynamodbav:"activities"`
+	Credits    int    `json:"credits" dynamodbav:"credits"`
+
+	// TTL for auto-expiration
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// ReachedLimit returns the name of the first limit the usage has used up, or "" while
+// the source may still fetch another page
+func (l *SourceRunLimits) ReachedLimit(usage *SourceRunUsage) string {
+	if l == nil || usage == nil {
+		return ""
+	}
+	switch {
+	case l.MaxPages > 0 && usage.Pages >= l.MaxPages:
+		return RunLimitPages
+	case l.MaxActivities > 0 && usage.Activities >= l.MaxActivities:
+		return RunLimitActivities
+	case l.MaxCredits > 0 && usage.Credits >= l.MaxCredits:
+		return RunLimitCredits
+	}
+	return ""
+}
+
+// AllowedActivities returns how many of the activities a page just added may be kept,
+// given the source's activity total after they were counted
+func (l *SourceRunLimits) AllowedActivities(added int, totalAfter int) int {
+	if l == nil || l.MaxActivities <= 0 || totalAfter <= l.MaxActivities {
+		return added
+	}
+	allowed := added - (totalAfter - l.MaxActivities)
+	if allowed < 0 {
+		return 0
+	}
+	return allowed
+}
+
+// Helper function to create the sort key for a source's usage within a run
+func CreateSourceRunUsageSK(sourceID string) string {
+	return "USAGE#" + sourceID
+}
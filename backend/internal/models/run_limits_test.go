@@ -0,0 +1,59 @@
+package models
+
+import "testing"
+
+func TestSourceRunLimitsReachedLimit(t *testing.T) {
+	limits := &SourceRunLimits{MaxPages: 10, MaxActivities: 100, MaxCredits: 50}
+
+	tests := []struct {
+		name     string
+		usage    SourceRunUsage
+		expected string
+	}{
+		{"under every limit", SourceRunUsage{Pages: 9, Activities: 99, Credits: 49}, ""},
+		{"pages used up", SourceRunUsage{Pages: 10}, RunLimitPages},
+		{"activities used up", SourceRunUsage{Pages: 3, Activities: 120}, RunLimitActivities},
+		{"credits used up", SourceRunUsage{Pages: 3, Credits: 50}, RunLimitCredits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limits.ReachedLimit(&tt.usage); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	unlimited := &SourceRunLimits{}
+	if unlimited.Enabled() {
+		t.Error("Expected zero limits to be disabled")
+	}
+	if got := unlimited.ReachedLimit(&SourceRunUsage{Pages: 5000, Activities: 5000}); got != "" {
+		t.Errorf("Expected zero limits to never be reached, got %q", got)
+	}
+}
+
+func TestSourceRunLimitsAllowedActivities(t *testing.T) {
+	limits := &SourceRunLimits{MaxActivities: 100}
+
+	if got := limits.AllowedActivities(40, 90); got != 40 {
+		t.Errorf("Expected all 40 activities under the limit, got %d", got)
+	}
+	if got := limits.AllowedActivities(40, 130); got != 10 {
+		t.Errorf("Expected 10 activities to fill the limit, got %d", got)
+	}
+	if got := limits.AllowedActivities(40, 180); got != 0 {
+		t.Errorf("Expected no activities once the limit was already used, got %d", got)
+	}
+	if got := (&SourceRunLimits{}).AllowedActivities(5000, 5000); got != 5000 {
+		t.Errorf("Expected no cap without an activity limit, got %d", got)
+	}
+}
+
+func TestSourceRunLimitsValidate(t *testing.T) {
+	if err := (&SourceRunLimits{MaxPages: 5}).Validate(); err != nil {
+		t.Errorf("Expected valid limits, got %v", err)
+	}
+	if err := (&SourceRunLimits{MaxCredits: -1}).Validate(); err == nil {
+		t.Error("Expected a negative limit to be rejected")
+	}
+}
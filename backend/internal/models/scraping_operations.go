@@ -1,7 +1,11 @@
 package models
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -27,6 +31,26 @@ const (
 	TaskStatusRetrying    ScrapingTaskStatus = "retrying"
 )
 
+// Scraping error code constants, set by services.ClassifyError
+const (
+	ErrorCodeTimeout         = "timeout"
+	ErrorCodeRateLimited     = "rate_limited"
+	ErrorCodeNetwork         = "network"
+	ErrorCodeUpstream        = "upstream_error"   // the site or FireCrawl returned a 5xx
+	ErrorCodeQuotaExhausted  = "quota_exhausted"  // FireCrawl credits ran out
+	ErrorCodeAuth            = "auth_failed"      // 401 or 403
+	ErrorCodeInvalidAPIKey   = "invalid_api_key"  // FireCrawl rejected our API key
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeBlocked         = "blocked_by_policy" // domain denied by the domain policy
+	ErrorCodeSourcePaused    = "source_paused"
+	ErrorCodeInvalidResponse = "invalid_response" // the extraction response could not be parsed
+	ErrorCodeInvalidRequest  = "invalid_request"  // bad URL, schema or configuration
+	ErrorCodeUnsupportedURL  = "unsupported_url"  // FireCrawl refuses to scrape the site
+	ErrorCodeHeartbeatLost   = "heartbeat_lost"   // the task's executors stopped reporting
+	ErrorCodeDeadLettered    = "dead_lettered"    // every delivery of the task's message failed
+	ErrorCodeUnknown         = "unknown"
+)
+
 // Scraping task priority constants
 const (
 	TaskPriorityHigh   = "high"
@@ -34,6 +58,38 @@ const (
 	TaskPriorityLow    = "low"
 )
 
+// Diagnostics stage constants
+const (
+	DiagnosticsStageExtraction = "extraction"
+	DiagnosticsStageConversion = "conversion"
+)
+
+// Job progress stage constants
+const (
+	ProgressStageQueued     = "queued" // deferred to finish in the background
+	ProgressStageFetching   = "fetching"
+	ProgressStageExtracting = "extracting"
+	ProgressStageConverting = "converting"
+	ProgressStageValidating = "validating"
+	ProgressStageCompleted  = "completed"
+	ProgressStageFailed     = "failed"
+)
+
+// Fan-out run status constants
+const (
+	RunStatusRunning   = "running"
+	RunStatusCompleted = "completed"
+	RunStatusPartial   = "partial" // some tasks failed
+	RunStatusFailed    = "failed"  // every task failed
+	RunStatusCancelled = "cancelled" // an admin cancelled the scraping task that started the run
+)
+
+// Scrape task queue priority constants
+const (
+	QueuePriorityHigh   = "high"   // manual triggers, processed ahead of scheduled work
+	QueuePriorityNormal = "normal" // scheduled runs and backfill
+)
+
 // ScrapingTask represents a scheduled scraping task
 type ScrapingTask struct {
 	// Primary Keys
@@ -47,6 +103,7 @@ type ScrapingTask struct {
 	// Task configuration
 	TaskType     string    `json:"task_type" dynamodbav:"task_type"`           // full_scrape, incremental, validation, discovery
 	Priority     string    `json:"priority" dynamodbav:"priority"`             // high, medium, low
+	TriggerType  string    `json:"trigger_type,omitempty" dynamodbav:"trigger_type,omitempty"` // manual for tasks triggered from the admin API
 	ScheduledTime time.Time `json:"scheduled_time" dynamodbav:"scheduled_time"`
 	TargetURLs   []string  `json:"target_urls" dynamodbav:"target_urls"`
 	
@@ -61,6 +118,17 @@ type ScrapingTask struct {
 	RetryCount       int       `json:"retry_count" dynamodbav:"retry_count"`
 	LastRetryAt      time.Time `json:"last_retry_at" dynamodbav:"last_retry_at"`
 	EstimatedDuration int64    `json:"estimated_duration" dynamodbav:"estimated_duration"` // seconds
+
+	// Liveness, updated by executors while the task's run is in progress
+	RunID          string     `json:"run_id,omitempty" dynamodbav:"run_id,omitempty"`                 // fan-out run executing the task
+	HeartbeatAt    *time.Time `json:"heartbeat_at,omitempty" dynamodbav:"heartbeat_at,omitempty"`
+	LastCheckpoint string     `json:"last_checkpoint,omitempty" dynamodbav:"last_checkpoint,omitempty"` // last URL result recorded for the run
+	RestartCount   int        `json:"restart_count,omitempty" dynamodbav:"restart_count,omitempty"`     // times the scheduler started the queued task again
+
+	// Error details of the last failure, from services.ClassifyError
+	ErrorMessage string `json:"error_message,omitempty" dynamodbav:"error_message,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty" dynamodbav:"error_code,omitempty"`
+	Retryable    bool   `json:"retryable,omitempty" dynamodbav:"retryable,omitempty"` // whether running the task again may succeed
 	
 	// Dependencies and prerequisites
 	Dependencies []string `json:"dependencies" dynamodbav:"dependencies"` // other task IDs that must complete first
@@ -71,6 +139,7 @@ type ScrapingTask struct {
 	TTL           int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
 	
 	// GSI Keys
+	NextRunShard      string `json:"NextRunShard,omitempty" dynamodbav:"NextRunShard,omitempty"`           // NEXT_RUN#{shard}
 	NextRunKey        string `json:"NextRunKey,omitempty" dynamodbav:"NextRunKey,omitempty"`               // NEXT_RUN#{timestamp}
 	PrioritySourceKey string `json:"PrioritySourceKey,omitempty" dynamodbav:"PrioritySourceKey,omitempty"` // PRIORITY#{priority}#{source_id}
 }
@@ -91,6 +160,11 @@ type ScrapingExecution struct {
 	CompletedAt  time.Time `json:"completed_at" dynamodbav:"completed_at"`
 	Duration     int64     `json:"duration" dynamodbav:"duration"` // milliseconds
 	Status       string    `json:"status" dynamodbav:"status"`     // running, completed, failed
+
+	// Error details when the execution failed, from services.ClassifyError
+	ErrorMessage string `json:"error_message,omitempty" dynamodbav:"error_message,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty" dynamodbav:"error_code,omitempty"`
+	Retryable    bool   `json:"retryable,omitempty" dynamodbav:"retryable,omitempty"`
 	
 	// Results summary
 	ItemsExtracted  int      `json:"items_extracted" dynamodbav:"items_extracted"`
@@ -179,6 +253,36 @@ type SourceMetrics struct {
 	TTL       int64     `json:"TTL" dynamodbav:"TTL"`
 }
 
+// PageContentMaxAge is how long a page may keep being skipped as unchanged before it is extracted
+// again anyway, so changes to how pages are parsed reach every page eventually
+const PageContentMaxAge = 7 * 24 * time.Hour
+
+// PageContentState is kept with a source's metrics for each of its target URLs. It records the
+// content hash of the page when activities were last extracted from it, so executors can skip
+// extracting a page whose content has not changed since.
+type PageContentState struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // SOURCE#{source_id}
+	SK string `json:"SK" dynamodbav:"SK"` // PAGE#{url_hash}
+
+	SourceID        string    `json:"source_id" dynamodbav:"source_id"`
+	URL             string    `json:"url" dynamodbav:"url"`
+	ContentHash     string    `json:"content_hash" dynamodbav:"content_hash"`         // SHA-256 of the raw markdown
+	ActivitiesFound int       `json:"activities_found" dynamodbav:"activities_found"` // found by the last extraction
+	ExtractedAt     time.Time `json:"extracted_at" dynamodbav:"extracted_at"`
+	CheckedAt       time.Time `json:"checked_at" dynamodbav:"checked_at"`             // last scrape, whether extracted or skipped
+	UnchangedChecks int       `json:"unchanged_checks" dynamodbav:"unchanged_checks"` // scrapes skipped since the last extraction
+
+	// TTL for auto-expiration of pages that are no longer scraped
+	TTL int64 `json:"TTL" dynamodbav:"TTL"`
+}
+
+// Unchanged reports whether a page scraped at now with contentHash may skip extraction: its
+// content is the same as when it was last extracted, and that extraction is recent enough to reuse
+func (p *PageContentState) Unchanged(contentHash string, now time.Time) bool {
+	return p != nil && p.ContentHash != "" && p.ContentHash == contentHash && now.Sub(p.ExtractedAt) < PageContentMaxAge
+}
+
 // DynamoScrapingRun represents the results of an individual scraping run in DynamoDB
 type DynamoScrapingRun struct {
 	// Primary Keys  
@@ -268,6 +372,262 @@ type QueuedTask struct {
 	ReadyToExecute      bool     `json:"ready_to_execute" dynamodbav:"ready_to_execute"`
 }
 
+// DiagnosticsRecord stores extraction or conversion diagnostics so they can be reviewed after the request completes
+type DiagnosticsRecord struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // DIAGNOSTICS#{event_id}
+	SK string `json:"SK" dynamodbav:"SK"` // STAGE#{stage}#{timestamp}
+
+	// Diagnostics identification
+	EventID   string `json:"event_id" dynamodbav:"event_id"`
+	Stage     string `json:"stage" dynamodbav:"stage"` // extraction, conversion
+	SourceURL string `json:"source_url" dynamodbav:"source_url"`
+	Success   bool   `json:"success" dynamodbav:"success"`
+
+	// Diagnostics payload (JSON representation of the service diagnostics)
+	Payload   map[string]interface{} `json:"payload" dynamodbav:"payload"`
+	Truncated bool                   `json:"truncated" dynamodbav:"truncated"` // payload was trimmed to fit the item size limit
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	TTL       int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
+}
+
+// ProgressUpdate records a single stage transition for a long-running admin job
+type ProgressUpdate struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PROGRESS#{job_id}
+	SK string `json:"SK" dynamodbav:"SK"` // UPDATE#{timestamp}
+
+	// Progress details
+	JobID   string                 `json:"job_id" dynamodbav:"job_id"`
+	Stage   string                 `json:"stage" dynamodbav:"stage"` // fetching, extracting, converting, validating, completed, failed
+	Message string                 `json:"message" dynamodbav:"message"`
+	Details map[string]interface{} `json:"details,omitempty" dynamodbav:"details,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	TTL       int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
+}
+
+// ProgressConnection links a WebSocket connection to the job it is following
+type ProgressConnection struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PROGRESS#{job_id} or WSCONN#{connection_id}
+	SK string `json:"SK" dynamodbav:"SK"` // CONNECTION#{connection_id} or JOB
+
+	ConnectionID string    `json:"connection_id" dynamodbav:"connection_id"`
+	JobID        string    `json:"job_id" dynamodbav:"job_id"`
+	ConnectedAt  time.Time `json:"connected_at" dynamodbav:"connected_at"`
+	TTL          int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
+}
+
+// ProgressTicketLifetime is how long a progress ticket can be redeemed after it was issued
+const ProgressTicketLifetime = time.Minute
+
+// ProgressTicket lets one connection to the progress socket be opened on behalf of an admin API
+// key. Only the hash of the ticket is stored, and redeeming it deletes it.
+type ProgressTicket struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PROGRESS_TICKET#{ticket_hash}
+	SK string `json:"SK" dynamodbav:"SK"` // TICKET
+
+	KeyID     string    `json:"key_id" dynamodbav:"key_id"` // admin API key the ticket was issued to
+	ExpiresAt time.Time `json:"expires_at" dynamodbav:"expires_at"`
+	TTL       int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp; expiry is checked on redemption too
+}
+
+// NewProgressTicket issues a progress ticket to an admin API key. The ticket is returned once;
+// only its hash is kept in the stored item.
+func NewProgressTicket(keyID string, now time.Time) (*ProgressTicket, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate progress ticket: %w", err)
+	}
+	value := hex.EncodeToString(random)
+	expiresAt := now.Add(ProgressTicketLifetime)
+	return &ProgressTicket{
+		PK:        CreateProgressTicketPK(value),
+		SK:        CreateProgressTicketSK(),
+		KeyID:     keyID,
+		ExpiresAt: expiresAt,
+		TTL:       expiresAt.Unix(),
+	}, value, nil
+}
+
+// PublicConversionCount counts the approved events from one source domain and schema type by
+// the outcome of their latest conversion for the frontend
+type PublicConversionCount struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PUBLIC_CONVERSION
+	SK string `json:"SK" dynamodbav:"SK"` // SOURCE#{source}#SCHEMA#{schema_type}
+
+	Source        string `json:"source" dynamodbav:"source"`
+	SchemaType    string `json:"schema_type" dynamodbav:"schema_type"`
+	Converted     int    `json:"converted" dynamodbav:"converted"`
+	Failed        int    `json:"failed" dynamodbav:"failed"`
+	Unpublishable int    `json:"unpublishable" dynamodbav:"unpublishable"`
+}
+
+// PublicConversionOutcome is the latest conversion outcome of one event, kept so the event is
+// counted once however often it is converted
+type PublicConversionOutcome struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // PUBLIC_CONVERSION#{event_id}
+	SK string `json:"SK" dynamodbav:"SK"` // OUTCOME
+
+	Source     string `json:"source" dynamodbav:"source"`
+	SchemaType string `json:"schema_type" dynamodbav:"schema_type"`
+	Outcome    string `json:"outcome" dynamodbav:"outcome"` // converted, failed or unpublishable
+}
+
+// ScrapeTaskMessage is the queue message for a single source URL in a fan-out run
+type ScrapeTaskMessage struct {
+	RunID      string `json:"run_id"`
+	TaskID     string `json:"task_id"`
+	SourceID   string `json:"source_id"`
+	SourceName string `json:"source_name"`
+	BaseURL    string `json:"base_url"`
+	Category   string `json:"category"`
+	URL        string `json:"url"`
+	Priority   string `json:"priority"` // source priority
+
+	QueuePriority string `json:"queue_priority,omitempty"` // high or normal
+	Deferrals     int    `json:"deferrals,omitempty"`      // times the task yielded to high-priority work
+	Attempts      int    `json:"attempts,omitempty"`       // extractions already retried after a retryable error
+	Force         bool   `json:"force,omitempty"`          // extract the page even when it has not changed since it was last extracted
+
+	Limits *SourceRunLimits `json:"limits,omitempty"` // per-run caps shared by all of the source's tasks
+
+	ScrapingTaskID string `json:"scraping_task_id,omitempty"` // admin task that requested the run; checked for cancellation before each URL
+}
+
+// FanOutRun tracks a scraping run whose URLs are processed in parallel by queue executors
+type FanOutRun struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // RUN#{run_id}
+	SK string `json:"SK" dynamodbav:"SK"` // SUMMARY
+
+	RunID       string `json:"run_id" dynamodbav:"run_id"`
+	TriggerType string `json:"trigger_type" dynamodbav:"trigger_type"` // scheduled, manual
+	Priority    string `json:"priority,omitempty" dynamodbav:"priority,omitempty"` // queue priority: high, normal
+	SourceID    string `json:"source_id,omitempty" dynamodbav:"source_id,omitempty"` // set when a single source was requested
+	Status      string `json:"status" dynamodbav:"status"` // running, completed, partial, failed, cancelled
+
+	ScrapingTaskID string `json:"scraping_task_id,omitempty" dynamodbav:"scraping_task_id,omitempty"` // admin task that requested the run, for manual triggers
+
+	// Counters are updated atomically by executors as tasks finish
+	TotalSources    int      `json:"total_sources" dynamodbav:"total_sources"`
+	TotalTasks      int      `json:"total_tasks" dynamodbav:"total_tasks"`
+	CompletedTasks  int      `json:"completed_tasks" dynamodbav:"completed_tasks"`
+	FailedTasks     int      `json:"failed_tasks" dynamodbav:"failed_tasks"`
+	CancelledTasks  int      `json:"cancelled_tasks" dynamodbav:"cancelled_tasks"` // skipped because the run was cancelled
+	UnchangedTasks  int      `json:"unchanged_tasks" dynamodbav:"unchanged_tasks"` // completed tasks whose page had not changed, included in CompletedTasks
+	TotalActivities int      `json:"total_activities" dynamodbav:"total_activities"`
+	CreditsUsed     int      `json:"credits_used" dynamodbav:"credits_used"` // FireCrawl credits consumed by the run's tasks
+	Errors          []string `json:"errors,omitempty" dynamodbav:"errors,omitempty"`
+
+	// Set once when the last task finishes
+	Stats               *FanOutRunStats `json:"stats,omitempty" dynamodbav:"stats,omitempty"`
+	PublishedActivities int             `json:"published_activities,omitempty" dynamodbav:"published_activities,omitempty"`
+
+	// Timestamps
+	StartedAt   time.Time  `json:"started_at" dynamodbav:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" dynamodbav:"completed_at,omitempty"`
+	TTL         int64      `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
+}
+
+// FinishedTasks returns the number of tasks that have reported a result
+func (r *FanOutRun) FinishedTasks() int {
+	return r.CompletedTasks + r.FailedTasks + r.CancelledTasks
+}
+
+// IsFinished reports whether every queued task has reported a result
+func (r *FanOutRun) IsFinished() bool {
+	return r.FinishedTasks() >= r.TotalTasks
+}
+
+// FanOutRunStats aggregates task results once a fan-out run has finished
+type FanOutRunStats struct {
+	DurationMs        int64                        `json:"duration_ms" dynamodbav:"duration_ms"`
+	SuccessRate       float64                      `json:"success_rate" dynamodbav:"success_rate"` // 0.0 - 1.0
+	AvgTaskDurationMs int64                        `json:"avg_task_duration_ms" dynamodbav:"avg_task_duration_ms"`
+	MaxTaskDurationMs int64                        `json:"max_task_duration_ms" dynamodbav:"max_task_duration_ms"`
+	SuccessfulSources int                          `json:"successful_sources" dynamodbav:"successful_sources"`
+	FailedSources     int                          `json:"failed_sources" dynamodbav:"failed_sources"` // every task for the source failed
+	Sources           map[string]FanOutSourceStats `json:"sources" dynamodbav:"sources"`               // keyed by source ID
+	ErrorCodes        map[string]int               `json:"error_codes,omitempty" dynamodbav:"error_codes,omitempty"` // failed tasks by error code
+}
+
+// FanOutSourceStats summarizes the tasks of a single source within a fan-out run
+type FanOutSourceStats struct {
+	SourceName      string `json:"source_name" dynamodbav:"source_name"`
+	Tasks           int    `json:"tasks" dynamodbav:"tasks"`
+	FailedTasks     int    `json:"failed_tasks" dynamodbav:"failed_tasks"`
+	UnchangedTasks  int    `json:"unchanged_tasks,omitempty" dynamodbav:"unchanged_tasks,omitempty"` // skipped because their page had not changed
+	ActivitiesFound int    `json:"activities_found" dynamodbav:"activities_found"`
+	LimitHit        string `json:"limit_hit,omitempty" dynamodbav:"limit_hit,omitempty"` // run limit the source stopped at
+	SelectorDrift   string `json:"selector_drift,omitempty" dynamodbav:"selector_drift,omitempty"` // drift status reached after the run
+}
+
+// TaskOutcomeSkippedUnchanged is the outcome of a task whose page had not changed since it was last
+// extracted, so extraction was skipped
+const TaskOutcomeSkippedUnchanged = "skipped_unchanged"
+
+// FanOutTaskResult records the outcome of one queued task in a fan-out run
+type FanOutTaskResult struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // RUN#{run_id}
+	SK string `json:"SK" dynamodbav:"SK"` // TASK#{task_id}
+
+	RunID           string `json:"run_id" dynamodbav:"run_id"`
+	TaskID          string `json:"task_id" dynamodbav:"task_id"`
+	SourceID        string `json:"source_id" dynamodbav:"source_id"`
+	SourceName      string `json:"source_name" dynamodbav:"source_name"`
+	URL             string `json:"url" dynamodbav:"url"`
+	Success         bool   `json:"success" dynamodbav:"success"`
+	ActivitiesFound int    `json:"activities_found" dynamodbav:"activities_found"`
+	DurationMs      int64  `json:"duration_ms" dynamodbav:"duration_ms"`
+	ErrorMessage    string `json:"error_message,omitempty" dynamodbav:"error_message,omitempty"`
+	ErrorCode       string `json:"error_code,omitempty" dynamodbav:"error_code,omitempty"`
+	Retryable       bool   `json:"retryable,omitempty" dynamodbav:"retryable,omitempty"`
+	RetryAfterSeconds int  `json:"retry_after_seconds,omitempty" dynamodbav:"retry_after_seconds,omitempty"` // wait FireCrawl asked for before retrying
+	LimitHit        string `json:"limit_hit,omitempty" dynamodbav:"limit_hit,omitempty"` // source run limit that stopped or truncated the task
+	CreditsUsed     int    `json:"credits_used,omitempty" dynamodbav:"credits_used,omitempty"` // FireCrawl credits consumed by the task
+	Cancelled       bool   `json:"cancelled,omitempty" dynamodbav:"cancelled,omitempty"` // skipped because the run's scraping task was cancelled
+	Outcome         string `json:"outcome,omitempty" dynamodbav:"outcome,omitempty"` // set when a successful task skipped extraction
+
+	// Counted is false while the result is only a checkpoint that has not been added to the run counters
+	Counted bool `json:"counted" dynamodbav:"counted"`
+
+	// Timestamps
+	CompletedAt time.Time `json:"completed_at" dynamodbav:"completed_at"`
+	TTL         int64     `json:"TTL" dynamodbav:"TTL"` // auto-expire timestamp
+}
+
+// SkippedUnchanged reports whether the task skipped extraction because its page had not changed
+func (r *FanOutTaskResult) SkippedUnchanged() bool {
+	return r.Outcome == TaskOutcomeSkippedUnchanged
+}
+
+// ScrapingTaskLoad counts the scraping tasks that are running or waiting to run
+type ScrapingTaskLoad struct {
+	InProgress        int `json:"in_progress"`
+	Queued            int `json:"queued"`
+	Retrying          int `json:"retrying"`
+	ScheduledNextHour int `json:"scheduled_next_hour"` // scheduled to start within the next hour
+	Overdue           int `json:"overdue"`             // scheduled tasks whose start time has passed
+}
+
+// DailyScrapingUsage totals what the fan-out runs started on one day have consumed
+type DailyScrapingUsage struct {
+	Date       string `json:"date"` // YYYY-MM-DD, UTC
+	Runs       int    `json:"runs"`
+	Pages      int    `json:"pages"`
+	Activities int    `json:"activities"`
+	Credits    int    `json:"credits"`
+}
+
 // Helper functions to create primary keys for scraping operations
 func CreateSchedulePK(date string) string {
 	return "SCHEDULE#" + date
@@ -297,11 +657,82 @@ func CreateMetricsSK(date string) string {
 	return "METRICS#" + date
 }
 
+func CreatePageContentSK(url string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(url)))
+	return "PAGE#" + hex.EncodeToString(hash[:])[:16]
+}
+
+func CreateDiagnosticsPK(eventID string) string {
+	return "DIAGNOSTICS#" + eventID
+}
+
+func CreateDiagnosticsSK(stage string, timestamp time.Time) string {
+	return "STAGE#" + stage + "#" + timestamp.Format("2006-01-02T15:04:05.000Z")
+}
+
+func CreateProgressPK(jobID string) string {
+	return "PROGRESS#" + jobID
+}
+
+func CreateProgressUpdateSK(timestamp time.Time) string {
+	return "UPDATE#" + timestamp.UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func CreateProgressConnectionSK(connectionID string) string {
+	return "CONNECTION#" + connectionID
+}
+
+func CreateConnectionPK(connectionID string) string {
+	return "WSCONN#" + connectionID
+}
+
+func CreateProgressTicketPK(ticket string) string {
+	return "PROGRESS_TICKET#" + HashProviderTokenSecret(ticket)
+}
+
+func CreateProgressTicketSK() string {
+	return "TICKET"
+}
+
+func CreatePublicConversionCountPK() string {
+	return "PUBLIC_CONVERSION"
+}
+
+func CreatePublicConversionCountSK(source, schemaType string) string {
+	return "SOURCE#" + source + "#SCHEMA#" + schemaType
+}
+
+func CreatePublicConversionOutcomePK(eventID string) string {
+	return "PUBLIC_CONVERSION#" + eventID
+}
+
+func CreatePublicConversionOutcomeSK() string {
+	return "OUTCOME"
+}
+
+func CreateFanOutRunPK(runID string) string {
+	return "RUN#" + runID
+}
+
+func CreateFanOutRunSK() string {
+	return "SUMMARY"
+}
+
+func CreateFanOutTaskSK(taskID string) string {
+	return "TASK#" + taskID
+}
+
 // Helper functions to generate GSI keys for scraping operations
 func GenerateNextRunKey(scheduledTime time.Time) string {
 	return "NEXT_RUN#" + scheduledTime.Format("2006-01-02T15:04:05Z")
 }
 
+// GenerateNextRunShardKey creates the partition key of a task in the next-run GSI, which orders
+// the tasks of each shard by NextRunKey
+func GenerateNextRunShardKey(taskID string) string {
+	return ShardGSIKey("NEXT_RUN", taskID)
+}
+
 func GenerateTaskPrioritySourceKey(priority, sourceID string) string {
 	return "PRIORITY#" + priority + "#" + sourceID
 }
@@ -349,22 +780,74 @@ func (se *ScrapingExecution) Validate() error {
 	return nil
 }
 
-// Status transition validation
+// CanTransitionTo reports whether ScrapingTaskStateMachine allows the task's move to a new status
 func (st *ScrapingTask) CanTransitionTo(newStatus ScrapingTaskStatus) bool {
-	switch st.Status {
-	case TaskStatusScheduled:
-		return newStatus == TaskStatusInProgress || newStatus == TaskStatusCancelled
-	case TaskStatusInProgress:
-		return newStatus == TaskStatusCompleted || newStatus == TaskStatusFailed || newStatus == TaskStatusRetrying
-	case TaskStatusFailed:
-		return newStatus == TaskStatusRetrying || newStatus == TaskStatusCancelled
-	case TaskStatusRetrying:
-		return newStatus == TaskStatusInProgress || newStatus == TaskStatusCancelled
-	case TaskStatusCompleted, TaskStatusCancelled:
-		return false // terminal states
+	return ScrapingTaskStateMachine.CanTransition(string(st.Status), string(newStatus))
+}
+
+// LastSeen returns when the task last showed signs of life: its latest heartbeat, or its last update
+// for tasks that have not sent one yet
+func (st *ScrapingTask) LastSeen() time.Time {
+	if st.HeartbeatAt != nil {
+		return *st.HeartbeatAt
+	}
+	return st.UpdatedAt
+}
+
+// HeartbeatStale reports whether an in-progress task has not been seen for longer than timeout,
+// which means the executors working on it crashed or its messages were dead-lettered
+func (st *ScrapingTask) HeartbeatStale(now time.Time, timeout time.Duration) bool {
+	return st.Status == TaskStatusInProgress && st.LastSeen().Before(now.Add(-timeout))
+}
+
+// CanRetry reports whether the task has retries left
+func (st *ScrapingTask) CanRetry() bool {
+	return st.RetryCount < st.MaxRetries
+}
+
+// RetryBackoff returns how long a task waits before its nth retry: 5 minutes, doubling with every
+// retry up to an hour
+func RetryBackoff(retry int) time.Duration {
+	backoff := 5 * time.Minute
+	for i := 1; i < retry && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	if backoff > time.Hour {
+		return time.Hour
+	}
+	return backoff
+}
+
+// Recurring reports whether the task is rescheduled after it runs. Manual tasks run once.
+func (st *ScrapingTask) Recurring() bool {
+	return st.TriggerType != TriggerTypeManual
+}
+
+// NextScheduledRun returns the first run after now of a task last scheduled at last, stepping by the
+// source's scraping frequency so runs missed while the scheduler was down are skipped rather than
+// caught up. It returns false for frequencies that do not recur.
+func NextScheduledRun(frequency string, last, now time.Time) (time.Time, bool) {
+	var step func(time.Time) time.Time
+	switch strings.ToLower(strings.TrimSpace(frequency)) {
+	case "hourly":
+		step = func(t time.Time) time.Time { return t.Add(time.Hour) }
+	case "daily":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "weekly":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case "biweekly", "bi-weekly":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 14) }
+	case "monthly":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
 	default:
-		return false
+		return time.Time{}, false
+	}
+
+	next := step(last)
+	for !next.After(now) {
+		next = step(next)
 	}
+	return next, true
 }
 
 // CalculateTTL calculates TTL timestamp for auto-expiring data
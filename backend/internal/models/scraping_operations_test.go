@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScrapingTaskHeartbeatStale(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	recent := now.Add(-5 * time.Minute)
+	old := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		task     ScrapingTask
+		expected bool
+	}{
+		{"RecentHeartbeat", ScrapingTask{Status: TaskStatusInProgress, HeartbeatAt: &recent, UpdatedAt: old}, false},
+		{"StaleHeartbeat", ScrapingTask{Status: TaskStatusInProgress, HeartbeatAt: &old, UpdatedAt: recent}, true},
+		{"NoHeartbeatFallsBackToUpdate", ScrapingTask{Status: TaskStatusInProgress, UpdatedAt: old}, true},
+		{"NotInProgress", ScrapingTask{Status: TaskStatusCompleted, HeartbeatAt: &old}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.HeartbeatStale(now, 30*time.Minute); got != tt.expected {
+				t.Errorf("Expected stale %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNextScheduledRun(t *testing.T) {
+	last := time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		now       time.Time
+		expected  time.Time
+		recurs    bool
+	}{
+		{"Daily", "daily", last.Add(time.Minute), time.Date(2026, 10, 2, 6, 0, 0, 0, time.UTC), true},
+		{"WeeklyMixedCase", " Weekly ", last.Add(time.Minute), time.Date(2026, 10, 8, 6, 0, 0, 0, time.UTC), true},
+		{"Monthly", "monthly", last.Add(time.Minute), time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC), true},
+		{"SkipsMissedRuns", "daily", time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC), time.Date(2026, 10, 6, 6, 0, 0, 0, time.UTC), true},
+		{"NextRunAtNowIsSkipped", "daily", time.Date(2026, 10, 2, 6, 0, 0, 0, time.UTC), time.Date(2026, 10, 3, 6, 0, 0, 0, time.UTC), true},
+		{"Seasonal", "seasonal", last.Add(time.Minute), time.Time{}, false},
+		{"Empty", "", last.Add(time.Minute), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, recurs := NextScheduledRun(tt.frequency, last, tt.now)
+			if recurs != tt.recurs || !next.Equal(tt.expected) {
+				t.Errorf("Expected %v (recurs %v), got %v (recurs %v)", tt.expected, tt.recurs, next, recurs)
+			}
+		})
+	}
+}
+
+func TestPageContentStateUnchanged(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	state := &PageContentState{ContentHash: "abc", ExtractedAt: now.Add(-24 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		state    *PageContentState
+		hash     string
+		expected bool
+	}{
+		{"SameContent", state, "abc", true},
+		{"ChangedContent", state, "def", false},
+		{"NeverExtracted", nil, "abc", false},
+		{"ExtractionTooOld", &PageContentState{ContentHash: "abc", ExtractedAt: now.Add(-PageContentMaxAge)}, "abc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.Unchanged(tt.hash, now); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if CreatePageContentSK(" https://example.com/events ") != CreatePageContentSK("https://example.com/events") {
+		t.Error("Expected surrounding whitespace not to change the page key")
+	}
+}
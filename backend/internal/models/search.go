@@ -0,0 +1,37 @@
+package models
+
+// Search suggestion kind constants
+const (
+	SuggestionKindTitle    = "title"
+	SuggestionKindVenue    = "venue"
+	SuggestionKindCategory = "category"
+)
+
+// SuggestPartitionLength is how many leading characters of a key choose its index partition.
+// Queries shorter than this return no suggestions.
+const SuggestPartitionLength = 2
+
+// SearchSuggestion is one completion in the search box prefix index. A term is stored once
+// per word it can be found by, e.g. "Seattle Children's Theatre" under "seattle childrens
+// theatre", "childrens theatre" and "theatre".
+type SearchSuggestion struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // SUGGEST#{first characters of key}
+	SK string `json:"-" dynamodbav:"SK"` // {key}#{kind}#{normalized text}
+
+	Kind  string `json:"kind" dynamodbav:"kind"`   // title, venue, category
+	Text  string `json:"text" dynamodbav:"text"`   // display text
+	Count int    `json:"count" dynamodbav:"count"` // approved activities carrying the term
+}
+
+// Helper functions to create primary keys for search suggestions
+func CreateSuggestionPK(key string) string {
+	if runes := []rune(key); len(runes) > SuggestPartitionLength {
+		key = string(runes[:SuggestPartitionLength])
+	}
+	return "SUGGEST#" + key
+}
+
+func CreateSuggestionSK(key, kind, normalizedText string) string {
+	return key + "#" + kind + "#" + normalizedText
+}
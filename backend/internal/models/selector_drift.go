@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Selector drift status constants
+const (
+	SelectorDriftDetected = "detected" // a source stopped yielding items and re-analysis was requested
+	SelectorDriftProposed = "proposed" // re-analysis recommended new selectors
+	SelectorDriftApplied  = "applied"  // the proposed selectors replaced the stored ones
+
+	// Reported in run stats when a canary scrape found no items; the drift stays proposed
+	SelectorDriftCanaryFailed = "canary_failed"
+)
+
+// SelectorDrift tracks a source whose stored content selectors stopped yielding items,
+// usually after a site redesign, from detection until new selectors are applied
+type SelectorDrift struct {
+	Status     string    `json:"status" dynamodbav:"status"`
+	DetectedAt time.Time `json:"detected_at" dynamodbav:"detected_at"`
+	RunID      string    `json:"run_id" dynamodbav:"run_id"` // run in which the source yielded no items
+
+	// Selectors recommended by the first analysis completed after detection
+	ProposedSelectors   *DataSelectors `json:"proposed_selectors,omitempty" dynamodbav:"proposed_selectors,omitempty"`
+	ProposedFromVersion int            `json:"proposed_from_version,omitempty" dynamodbav:"proposed_from_version,omitempty"`
+
+	// Latest canary scrape run before hot-swapping the proposed selectors
+	CanaryURL   string     `json:"canary_url,omitempty" dynamodbav:"canary_url,omitempty"`
+	CanaryItems int        `json:"canary_items,omitempty" dynamodbav:"canary_items,omitempty"`
+	CanaryAt    *time.Time `json:"canary_at,omitempty" dynamodbav:"canary_at,omitempty"`
+
+	AppliedAt *time.Time `json:"applied_at,omitempty" dynamodbav:"applied_at,omitempty"`
+}
+
+// Open reports whether the drift still needs new selectors
+func (d *SelectorDrift) Open() bool {
+	return d != nil && (d.Status == SelectorDriftDetected || d.Status == SelectorDriftProposed)
+}
+
+// IsEmpty reports whether no selector is set
+func (s DataSelectors) IsEmpty() bool {
+	return s == DataSelectors{}
+}
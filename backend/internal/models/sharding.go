@@ -0,0 +1,30 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// GSIKeyShards is how many partitions a sharded GSI key is spread over. Status and next-run
+// keys are shared by every item in the same state, so a single partition would throttle as
+// the number of sources, tasks and events grows.
+const GSIKeyShards = 16
+
+// ShardGSIKey appends an item's shard to a GSI partition key, e.g. STATUS#pending_analysis#07.
+// The shard is derived from the item's ID, so an item stays in the same shard when its
+// status changes.
+func ShardGSIKey(key, itemID string) string {
+	hash := sha256.Sum256([]byte(itemID))
+	return fmt.Sprintf("%s#%02d", key, binary.BigEndian.Uint32(hash[:4])%GSIKeyShards)
+}
+
+// GSIKeyShardValues returns the partition key of every shard of a GSI key, for queries that
+// read all shards and merge the results
+func GSIKeyShardValues(key string) []string {
+	values := make([]string, GSIKeyShards)
+	for shard := range values {
+		values[shard] = fmt.Sprintf("%s#%02d", key, shard)
+	}
+	return values
+}
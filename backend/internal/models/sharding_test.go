@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShardGSIKey(t *testing.T) {
+	key := ShardGSIKey("STATUS#pending_analysis", "seattle-childrens-theatre")
+	if key != ShardGSIKey("STATUS#pending_analysis", "seattle-childrens-theatre") {
+		t.Errorf("Expected an item to stay in the same shard, got %s", key)
+	}
+
+	shards := GSIKeyShardValues("STATUS#pending_analysis")
+	if len(shards) != GSIKeyShards || shards[0] != "STATUS#pending_analysis#00" || shards[15] != "STATUS#pending_analysis#15" {
+		t.Fatalf("Expected shards #00 to #15, got %v", shards)
+	}
+
+	used := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		key := ShardGSIKey("STATUS#pending_analysis", fmt.Sprintf("source-%d", i))
+		found := false
+		for _, shard := range shards {
+			found = found || shard == key
+		}
+		if !found {
+			t.Fatalf("Expected %s to be one of the shards", key)
+		}
+		used[key] = true
+	}
+	if len(used) != GSIKeyShards {
+		t.Errorf("Expected items to spread over every shard, only %d used", len(used))
+	}
+}
+
+func TestStatusKeysAreSharded(t *testing.T) {
+	if key := GenerateSourceStatusKey(SourceStatusActive, "source-1"); !strings.HasPrefix(key, "STATUS#active#") {
+		t.Errorf("Expected a sharded source status key, got %s", key)
+	}
+	if key := GenerateAdminEventStatusKey(AdminEventStatusPending, "evt-1"); !strings.HasPrefix(key, "STATUS#pending#") {
+		t.Errorf("Expected a sharded admin event status key, got %s", key)
+	}
+	if key := GenerateNextRunShardKey("task-1"); !strings.HasPrefix(key, "NEXT_RUN#") || len(key) != len("NEXT_RUN#00") {
+		t.Errorf("Expected a next-run shard key, got %s", key)
+	}
+}
@@ -12,6 +12,9 @@ const (
 	SourceStageConfig     = "CONFIG"
 )
 
+// SourceAnalysisVersionPrefix prefixes the sort keys of every analysis kept for comparison
+const SourceAnalysisVersionPrefix = SourceStageAnalysis + "#v"
+
 // Source status constants
 const (
 	SourceStatusPendingAnalysis = "pending_analysis"
@@ -19,6 +22,16 @@ const (
 	SourceStatusActive          = "active"
 	SourceStatusInactive        = "inactive"
 	SourceStatusRejected        = "rejected"
+	SourceStatusPreflightFailed = "preflight_failed"
+	SourceStatusPaused          = "paused" // temporarily stopped by an admin; no new scraping tasks are created
+)
+
+// Preflight check stage constants identify where a pre-flight check failed
+const (
+	PreflightStageURL  = "url"
+	PreflightStageDNS  = "dns"
+	PreflightStageTLS  = "tls"
+	PreflightStageHTTP = "http"
 )
 
 // Source priority constants
@@ -59,11 +72,45 @@ type SourceSubmission struct {
 	UpdatedAt   time.Time `json:"updated_at" dynamodbav:"updated_at"`
 	Status      string    `json:"status" dynamodbav:"status"` // pending_analysis, analysis_complete, etc.
 
+	// Connectivity checks run at submission time
+	Preflight *PreflightResult `json:"preflight,omitempty" dynamodbav:"preflight,omitempty"`
+
+	// Why the source was rejected, by an admin or by the domain deny list
+	DenialReason string `json:"denial_reason,omitempty" dynamodbav:"denial_reason,omitempty"`
+
+	// Why an admin paused the source; cleared when it is resumed
+	PauseReason string `json:"pause_reason,omitempty" dynamodbav:"pause_reason,omitempty"`
+
+	// Onboarding checklist steps recorded so far; see OnboardingChecklist
+	Onboarding []SourceOnboardingStep `json:"onboarding,omitempty" dynamodbav:"onboarding,omitempty"`
+
 	// GSI Keys
-	StatusKey   string `json:"StatusKey,omitempty" dynamodbav:"StatusKey,omitempty"`     // STATUS#{status}
+	StatusKey   string `json:"StatusKey,omitempty" dynamodbav:"StatusKey,omitempty"`     // STATUS#{status}#{shard}
 	PriorityKey string `json:"PriorityKey,omitempty" dynamodbav:"PriorityKey,omitempty"` // PRIORITY#{priority}#{source_id}
 }
 
+// PreflightResult records DNS, TLS and HTTP reachability of a source's base URL
+type PreflightResult struct {
+	Passed       bool      `json:"passed" dynamodbav:"passed"`
+	CheckedAt    time.Time `json:"checked_at" dynamodbav:"checked_at"`
+	FailureStage string    `json:"failure_stage,omitempty" dynamodbav:"failure_stage,omitempty"` // url, dns, tls, http
+	Diagnosis    string    `json:"diagnosis,omitempty" dynamodbav:"diagnosis,omitempty"`         // human readable explanation of the failure
+	Warnings     []string  `json:"warnings,omitempty" dynamodbav:"warnings,omitempty"`
+
+	// DNS
+	ResolvedAddresses []string `json:"resolved_addresses,omitempty" dynamodbav:"resolved_addresses,omitempty"`
+
+	// TLS (https only)
+	TLSVersion        string     `json:"tls_version,omitempty" dynamodbav:"tls_version,omitempty"`
+	CertificateIssuer string     `json:"certificate_issuer,omitempty" dynamodbav:"certificate_issuer,omitempty"`
+	CertificateExpiry *time.Time `json:"certificate_expiry,omitempty" dynamodbav:"certificate_expiry,omitempty"`
+
+	// HTTP
+	StatusCode    int      `json:"status_code,omitempty" dynamodbav:"status_code,omitempty"`
+	FinalURL      string   `json:"final_url,omitempty" dynamodbav:"final_url,omitempty"`
+	RedirectChain []string `json:"redirect_chain,omitempty" dynamodbav:"redirect_chain,omitempty"`
+}
+
 // SourceAnalysis represents the automated analysis results
 type SourceAnalysis struct {
 	// Primary Keys
@@ -74,6 +121,7 @@ type SourceAnalysis struct {
 	SourceID            string    `json:"source_id" dynamodbav:"source_id"`
 	AnalysisCompletedAt time.Time `json:"analysis_completed_at" dynamodbav:"analysis_completed_at"`
 	AnalysisVersion     string    `json:"analysis_version" dynamodbav:"analysis_version"`
+	Version             int       `json:"version" dynamodbav:"version"` // increments each time the source is re-analyzed
 
 	// Discovery results
 	DiscoveredPatterns DiscoveryPatterns `json:"discovered_patterns" dynamodbav:"discovered_patterns"`
@@ -208,6 +256,14 @@ type DynamoSourceConfig struct {
 	// Scraping configuration
 	ScrapingConfig DynamoScrapingConfig `json:"scraping_config" dynamodbav:"scraping_config"`
 
+	// Per-run caps; a source that hits one stops for the rest of the run
+	RunLimits SourceRunLimits `json:"run_limits" dynamodbav:"run_limits"`
+
+	// Selector drift: the latest drift detected, and whether proposed selectors may be
+	// hot-swapped in after a successful canary scrape
+	SelectorDrift     *SelectorDrift `json:"selector_drift,omitempty" dynamodbav:"selector_drift,omitempty"`
+	AutoHealSelectors bool           `json:"auto_heal_selectors" dynamodbav:"auto_heal_selectors"`
+
 	// Data quality tracking
 	DataQuality DataQuality `json:"data_quality" dynamodbav:"data_quality"`
 
@@ -221,7 +277,7 @@ type DynamoSourceConfig struct {
 	LastModified time.Time `json:"last_modified" dynamodbav:"last_modified"`
 
 	// GSI Keys
-	StatusKey   string `json:"StatusKey,omitempty" dynamodbav:"StatusKey,omitempty"`     // STATUS#{status}
+	StatusKey   string `json:"StatusKey,omitempty" dynamodbav:"StatusKey,omitempty"`     // STATUS#{status}#{shard}
 	PriorityKey string `json:"PriorityKey,omitempty" dynamodbav:"PriorityKey,omitempty"` // PRIORITY#{priority}#{source_id}
 }
 
@@ -281,6 +337,7 @@ type DeletionResult struct {
 	SubmissionDeleted bool   `json:"submission_deleted" dynamodbav:"submission_deleted"`
 	AnalysisDeleted   bool   `json:"analysis_deleted" dynamodbav:"analysis_deleted"`
 	ConfigDeleted     bool   `json:"config_deleted" dynamodbav:"config_deleted"`
+	AnalysisVersionsDeleted int `json:"analysis_versions_deleted" dynamodbav:"analysis_versions_deleted"`
 	ActivitiesDeleted int    `json:"activities_deleted" dynamodbav:"activities_deleted"`
 	TotalRecords      int    `json:"total_records" dynamodbav:"total_records"`
 }
@@ -298,13 +355,19 @@ func CreateSourceAnalysisSK() string {
 	return SourceStageAnalysis
 }
 
+// CreateSourceAnalysisVersionSK creates the sort key for a stored analysis version, e.g. ANALYSIS#v0003.
+// Versions are zero-padded so they sort in order.
+func CreateSourceAnalysisVersionSK(version int) string {
+	return fmt.Sprintf("%s%04d", SourceAnalysisVersionPrefix, version)
+}
+
 func CreateSourceConfigSK() string {
 	return SourceStageConfig
 }
 
 // Helper functions to generate GSI keys for source management
-func GenerateSourceStatusKey(status string) string {
-	return "STATUS#" + status
+func GenerateSourceStatusKey(status, sourceID string) string {
+	return ShardGSIKey("STATUS#"+status, sourceID)
 }
 
 func GenerateSourcePriorityKey(priority, sourceID string) string {
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Source onboarding steps
+const (
+	OnboardingStepRobotsChecked      = "robots_checked"
+	OnboardingStepAnalysisComplete   = "analysis_complete"
+	OnboardingStepPreviewReviewed    = "preview_reviewed"
+	OnboardingStepConversionRulesSet = "conversion_rules_set"
+	OnboardingStepCanaryScrapePassed = "canary_scrape_passed"
+	OnboardingStepActivated          = "activated"
+)
+
+// Onboarding step status constants
+const (
+	OnboardingStatusPending  = "pending"
+	OnboardingStatusComplete = "complete"
+	OnboardingStatusFailed   = "failed"
+)
+
+// OnboardingRecordedBySystem marks steps recorded from what the system observed rather than by an admin
+const OnboardingRecordedBySystem = "system"
+
+// OnboardingStepDefinition describes a step of the onboarding checklist. Manual steps are
+// checked off by an admin; the others are recorded as the system completes them.
+type OnboardingStepDefinition struct {
+	Step   string
+	Title  string
+	Manual bool
+}
+
+// OnboardingSteps is the onboarding checklist of a source, in the order the steps are done
+var OnboardingSteps = []OnboardingStepDefinition{
+	{OnboardingStepRobotsChecked, "Check robots.txt and terms allow scraping", true},
+	{OnboardingStepAnalysisComplete, "Automated source analysis complete", false},
+	{OnboardingStepPreviewReviewed, "Preview scrape reviewed", true},
+	{OnboardingStepConversionRulesSet, "Conversion rules set", true},
+	{OnboardingStepCanaryScrapePassed, "Canary scrape passed", false},
+	{OnboardingStepActivated, "Source activated", false},
+}
+
+// SourceOnboardingStep is the status of one onboarding step, stored on the source submission
+type SourceOnboardingStep struct {
+	Step      string     `json:"step" dynamodbav:"step"`
+	Title     string     `json:"title" dynamodbav:"-"`
+	Manual    bool       `json:"manual" dynamodbav:"-"`
+	Status    string     `json:"status" dynamodbav:"status"` // pending, complete, failed
+	Detail    string     `json:"detail,omitempty" dynamodbav:"detail,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"` // admin, or "system"
+	UpdatedAt *time.Time `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
+}
+
+// SourceOnboardingChecklist is the full onboarding checklist of a source, as shown to admins
+type SourceOnboardingChecklist struct {
+	SourceID  string                 `json:"source_id"`
+	Steps     []SourceOnboardingStep `json:"steps"`
+	Completed int                    `json:"completed"`
+	Total     int                    `json:"total"`
+	NextStep  string                 `json:"next_step,omitempty"` // first step not complete
+	Complete  bool                   `json:"complete"`
+}
+
+// OnboardingStepUpdate is an admin checking off, or reopening, a manual onboarding step
+type OnboardingStepUpdate struct {
+	Status    string `json:"status"` // complete or pending
+	Detail    string `json:"detail,omitempty"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// Validate checks an onboarding step update
+func (u *OnboardingStepUpdate) Validate() error {
+	if u.Status != OnboardingStatusComplete && u.Status != OnboardingStatusPending {
+		return fmt.Errorf("status must be %s or %s", OnboardingStatusComplete, OnboardingStatusPending)
+	}
+	if u.UpdatedBy == "" {
+		return fmt.Errorf("updated_by is required")
+	}
+	return nil
+}
+
+// GetOnboardingStepDefinition returns the definition of an onboarding step
+func GetOnboardingStepDefinition(step string) (OnboardingStepDefinition, bool) {
+	for _, definition := range OnboardingSteps {
+		if definition.Step == step {
+			return definition, true
+		}
+	}
+	return OnboardingStepDefinition{}, false
+}
+
+// OnboardingChecklist returns the source's onboarding checklist, with steps not recorded yet pending
+func (ss *SourceSubmission) OnboardingChecklist() SourceOnboardingChecklist {
+	checklist := SourceOnboardingChecklist{
+		SourceID: ss.SourceID,
+		Total:    len(OnboardingSteps),
+	}
+	for _, definition := range OnboardingSteps {
+		step := SourceOnboardingStep{Step: definition.Step, Status: OnboardingStatusPending}
+		if recorded := ss.onboardingStep(definition.Step); recorded != nil {
+			step = *recorded
+		}
+		step.Title = definition.Title
+		step.Manual = definition.Manual
+
+		if step.Status == OnboardingStatusComplete {
+			checklist.Completed++
+		} else if checklist.NextStep == "" {
+			checklist.NextStep = step.Step
+		}
+		checklist.Steps = append(checklist.Steps, step)
+	}
+	checklist.Complete = checklist.Completed == checklist.Total
+	return checklist
+}
+
+// SetOnboardingStep records the status of an onboarding step. It returns false if the step
+// already had that status and detail, so callers can skip saving the source.
+func (ss *SourceSubmission) SetOnboardingStep(step, status, detail, updatedBy string, at time.Time) bool {
+	recorded := ss.onboardingStep(step)
+	if recorded == nil {
+		ss.Onboarding = append(ss.Onboarding, SourceOnboardingStep{Step: step})
+		recorded = &ss.Onboarding[len(ss.Onboarding)-1]
+	} else if recorded.Status == status && recorded.Detail == detail {
+		return false
+	}
+
+	recorded.Status = status
+	recorded.Detail = detail
+	recorded.UpdatedBy = updatedBy
+	recorded.UpdatedAt = &at
+	return true
+}
+
+// SyncOnboarding records the onboarding steps that follow from the source's analysis and
+// configuration, either of which may be nil. Sources analyzed or activated before the checklist
+// existed catch up this way. It returns whether any step changed.
+func (ss *SourceSubmission) SyncOnboarding(analysis *SourceAnalysis, config *DynamoSourceConfig) bool {
+	changed := false
+	if analysis != nil && analysis.Status == SourceStatusAnalysisComplete && !ss.OnboardingStepComplete(OnboardingStepAnalysisComplete) {
+		detail := fmt.Sprintf("Analysis v%d, quality score %.2f", analysis.Version, analysis.OverallQualityScore)
+		changed = ss.SetOnboardingStep(OnboardingStepAnalysisComplete, OnboardingStatusComplete, detail, OnboardingRecordedBySystem, analysis.AnalysisCompletedAt) || changed
+	}
+	if config != nil && !ss.OnboardingStepComplete(OnboardingStepActivated) {
+		updatedBy := config.ActivatedBy
+		if updatedBy == "" {
+			updatedBy = OnboardingRecordedBySystem
+		}
+		changed = ss.SetOnboardingStep(OnboardingStepActivated, OnboardingStatusComplete, "", updatedBy, config.ActivatedAt) || changed
+	}
+	return changed
+}
+
+// OnboardingStepComplete returns true if the onboarding step has been completed
+func (ss *SourceSubmission) OnboardingStepComplete(step string) bool {
+	recorded := ss.onboardingStep(step)
+	return recorded != nil && recorded.Status == OnboardingStatusComplete
+}
+
+func (ss *SourceSubmission) onboardingStep(step string) *SourceOnboardingStep {
+	for i := range ss.Onboarding {
+		if ss.Onboarding[i].Step == step {
+			return &ss.Onboarding[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnboardingChecklist(t *testing.T) {
+	source := &SourceSubmission{SourceID: "seattle-parks"}
+
+	checklist := source.OnboardingChecklist()
+	if checklist.Total != len(OnboardingSteps) || checklist.Completed != 0 || checklist.Complete {
+		t.Fatalf("Expected a new source to have nothing completed, got %+v", checklist)
+	}
+	if checklist.NextStep != OnboardingStepRobotsChecked {
+		t.Errorf("Expected robots check first, got %s", checklist.NextStep)
+	}
+	for i, step := range checklist.Steps {
+		if step.Step != OnboardingSteps[i].Step || step.Status != OnboardingStatusPending || step.Title == "" {
+			t.Errorf("Expected step %d to be pending %s, got %+v", i, OnboardingSteps[i].Step, step)
+		}
+	}
+
+	now := time.Date(2025, 3, 3, 17, 0, 0, 0, time.UTC)
+	if !source.SetOnboardingStep(OnboardingStepRobotsChecked, OnboardingStatusComplete, "", "admin", now) {
+		t.Fatal("Expected recording a step to change the source")
+	}
+	if source.SetOnboardingStep(OnboardingStepRobotsChecked, OnboardingStatusComplete, "", "other-admin", now.Add(time.Hour)) {
+		t.Error("Expected recording the same status again to leave the source unchanged")
+	}
+	source.SetOnboardingStep(OnboardingStepCanaryScrapePassed, OnboardingStatusFailed, "Preview scrape found 0 activities", OnboardingRecordedBySystem, now)
+
+	checklist = source.OnboardingChecklist()
+	if checklist.Completed != 1 || checklist.NextStep != OnboardingStepAnalysisComplete {
+		t.Errorf("Expected 1 step completed with analysis next, got %d and %s", checklist.Completed, checklist.NextStep)
+	}
+	if robots := checklist.Steps[0]; robots.UpdatedBy != "admin" || robots.UpdatedAt == nil || !robots.Manual {
+		t.Errorf("Expected the robots check by admin, got %+v", robots)
+	}
+	if canary := checklist.Steps[4]; canary.Status != OnboardingStatusFailed {
+		t.Errorf("Expected the canary scrape to have failed, got %+v", canary)
+	}
+}
+
+func TestSyncOnboarding(t *testing.T) {
+	source := &SourceSubmission{SourceID: "seattle-parks"}
+	if source.SyncOnboarding(nil, nil) {
+		t.Error("Expected no change without analysis or config")
+	}
+
+	analysis := &SourceAnalysis{Status: SourceStatusAnalysisComplete, Version: 2, OverallQualityScore: 0.8}
+	config := &DynamoSourceConfig{ActivatedBy: "admin", ActivatedAt: time.Now()}
+	if !source.SyncOnboarding(analysis, config) {
+		t.Fatal("Expected analysis and activation to be recorded")
+	}
+	if !source.OnboardingStepComplete(OnboardingStepAnalysisComplete) || !source.OnboardingStepComplete(OnboardingStepActivated) {
+		t.Errorf("Expected analysis and activation complete, got %+v", source.Onboarding)
+	}
+	if source.SyncOnboarding(analysis, config) {
+		t.Error("Expected a second sync to change nothing")
+	}
+}
+
+func TestOnboardingStepUpdateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  OnboardingStepUpdate
+		wantErr bool
+	}{
+		{"Complete", OnboardingStepUpdate{Status: OnboardingStatusComplete, UpdatedBy: "admin"}, false},
+		{"Reopen", OnboardingStepUpdate{Status: OnboardingStatusPending, UpdatedBy: "admin"}, false},
+		{"Failed", OnboardingStepUpdate{Status: OnboardingStatusFailed, UpdatedBy: "admin"}, true},
+		{"NoAdmin", OnboardingStepUpdate{Status: OnboardingStatusComplete}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.update.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Built-in tag constants, suggested during conversion even before any tag is stored
+const (
+	TagSTEM             = "stem"
+	TagOutdoor          = "outdoor"
+	TagDropIn           = "drop-in"
+	TagSpanishImmersion = "spanish-immersion"
+)
+
+// Tag limits
+const (
+	MaxTagLength       = 40 // characters in a tag slug
+	MaxTagsPerActivity = 12
+)
+
+// Tag is a label activities can be found by, finer grained than their category. Tags are
+// managed by admins, and a tag with keywords is suggested for activities whose title or
+// description mention one of them. Activities may also carry freeform tags no tag is stored for.
+type Tag struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // TAGS
+	SK string `json:"-" dynamodbav:"SK"` // TAG#{slug}
+
+	Slug        string   `json:"slug" dynamodbav:"slug"`
+	Label       string   `json:"label" dynamodbav:"label"`
+	Description string   `json:"description,omitempty" dynamodbav:"description,omitempty"`
+	Keywords    []string `json:"keywords,omitempty" dynamodbav:"keywords,omitempty"` // lowercase phrases that suggest the tag
+
+	// BuiltIn marks the tags of DefaultTags, which revert to their defaults when deleted
+	BuiltIn bool `json:"built_in" dynamodbav:"-"`
+	// UsageCount is the number of published activities with the tag, counted when tags are listed
+	UsageCount int `json:"usage_count" dynamodbav:"-"`
+
+	// Metadata
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+// Validate normalizes the tag's slug and keywords and checks that it has a label
+func (t *Tag) Validate() error {
+	slug, err := NormalizeTag(t.Slug)
+	if err != nil {
+		return err
+	}
+	t.Slug = slug
+
+	t.Label = strings.TrimSpace(t.Label)
+	if t.Label == "" {
+		return fmt.Errorf("label is required")
+	}
+	t.Description = strings.TrimSpace(t.Description)
+
+	keywords := make([]string, 0, len(t.Keywords))
+	for _, keyword := range t.Keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	t.Keywords = keywords
+	return nil
+}
+
+// DefaultTags returns the built-in tags. Stored tags with the same slug replace them.
+func DefaultTags() []Tag {
+	tags := []Tag{
+		{
+			Slug:     TagSTEM,
+			Label:    "STEM",
+			Keywords: []string{"stem", "science", "engineering", "coding", "robotics", "math"},
+		},
+		{
+			Slug:     TagOutdoor,
+			Label:    "Outdoor",
+			Keywords: []string{"outdoor", "outside", "nature walk", "hike", "trail", "beach", "garden"},
+		},
+		{
+			Slug:  TagDropIn,
+			Label: "Drop-in",
+		},
+		{
+			Slug:     TagSpanishImmersion,
+			Label:    "Spanish immersion",
+			Keywords: []string{"spanish immersion", "en español", "in spanish", "bilingual spanish"},
+		},
+	}
+	for i := range tags {
+		tags[i].BuiltIn = true
+	}
+	return tags
+}
+
+// IsBuiltInTag reports whether a slug is one of the built-in tags
+func IsBuiltInTag(slug string) bool {
+	for _, tag := range DefaultTags() {
+		if tag.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTag turns a label into a tag slug: lowercase letters, digits and single dashes, e.g.
+// "Spanish Immersion" becomes "spanish-immersion"
+func NormalizeTag(value string) (string, error) {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		case r == '-' || r == '_' || r == ' ':
+			dash = true
+		default:
+			return "", fmt.Errorf("invalid tag %q: use letters, digits, spaces and dashes", value)
+		}
+	}
+
+	slug := b.String()
+	if slug == "" {
+		return "", fmt.Errorf("tag is required")
+	}
+	if len(slug) > MaxTagLength {
+		return "", fmt.Errorf("invalid tag %q: must be at most %d characters", value, MaxTagLength)
+	}
+	return slug, nil
+}
+
+// NormalizeTags normalizes a list of tags, dropping duplicates and sorting them
+func NormalizeTags(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		tag, err := NormalizeTag(value)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > MaxTagsPerActivity {
+		return nil, fmt.Errorf("an activity can have at most %d tags", MaxTagsPerActivity)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// HasAllTags reports whether an activity's tags include every one of the wanted tags
+func HasAllTags(tags, wanted []string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, tag := range tags {
+			found = found || tag == want
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// TagEntry records that a published activity has a tag. Entries live in the family activities
+// table under the activity's partition and are queried by tag through the tag-date-index GSI.
+type TagEntry struct {
+	// Primary Keys
+	PK string `json:"-" dynamodbav:"PK"` // EVENT#{activity_id}
+	SK string `json:"-" dynamodbav:"SK"` // TAG#{slug}
+
+	ActivityID string `json:"activity_id" dynamodbav:"activity_id"`
+	Tag        string `json:"tag" dynamodbav:"tag"`
+	Title      string `json:"title" dynamodbav:"title"`
+	Type       string `json:"type" dynamodbav:"type"`
+	Category   string `json:"category" dynamodbav:"category"`
+	StartDate  string `json:"start_date,omitempty" dynamodbav:"start_date,omitempty"` // YYYY-MM-DD
+
+	// GSI Keys
+	TagKey     string `json:"-" dynamodbav:"TagKey"`     // TAG#{slug}
+	TagDateKey string `json:"-" dynamodbav:"TagDateKey"` // DATE#{start_date}#{activity_id}
+}
+
+// Helper functions to create the keys of tags and tag entries
+func CreateTagPK() string {
+	return "TAGS"
+}
+
+func CreateTagSK(slug string) string {
+	return "TAG#" + slug
+}
+
+func GenerateTagKey(slug string) string {
+	return "TAG#" + slug
+}
+
+func GenerateTagDateKey(startDate, activityID string) string {
+	return "DATE#" + startDate + "#" + activityID
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"stem", "stem", false},
+		{"  Spanish Immersion ", "spanish-immersion", false},
+		{"drop_in", "drop-in", false},
+		{"--outdoor  play--", "outdoor-play", false},
+		{"ages 3-5", "ages-3-5", false},
+		{"", "", true},
+		{" - ", "", true},
+		{"art & craft", "", true},
+		{strings.Repeat("a", MaxTagLength+1), "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeTag(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeTag(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("NormalizeTag(%q) = %q, expected %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got, err := NormalizeTags([]string{"Outdoor", "stem", "outdoor", "Drop In"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"drop-in", "outdoor", "stem"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("NormalizeTags = %v, expected %v", got, expected)
+	}
+
+	if _, err := NormalizeTags([]string{"stem", "art & craft"}); err == nil {
+		t.Error("Expected an invalid tag to be rejected")
+	}
+
+	tooMany := make([]string, MaxTagsPerActivity+1)
+	for i := range tooMany {
+		tooMany[i] = "tag-" + string(rune('a'+i))
+	}
+	if _, err := NormalizeTags(tooMany); err == nil {
+		t.Error("Expected more than the maximum number of tags to be rejected")
+	}
+}
+
+func TestTagValidate(t *testing.T) {
+	tag := Tag{Slug: "Nature Play", Label: " Nature play ", Keywords: []string{" Forest ", "", "MUD"}}
+	if err := tag.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tag.Slug != "nature-play" || tag.Label != "Nature play" {
+		t.Errorf("Expected a normalized slug and label, got %q and %q", tag.Slug, tag.Label)
+	}
+	if expected := []string{"forest", "mud"}; !reflect.DeepEqual(tag.Keywords, expected) {
+		t.Errorf("Keywords = %v, expected %v", tag.Keywords, expected)
+	}
+
+	missingLabel := Tag{Slug: "stem"}
+	if err := missingLabel.Validate(); err == nil {
+		t.Error("Expected a tag without a label to be rejected")
+	}
+}
+
+func TestHasAllTags(t *testing.T) {
+	tags := []string{"outdoor", "stem"}
+	if !HasAllTags(tags, []string{"stem"}) || !HasAllTags(tags, []string{"outdoor", "stem"}) || !HasAllTags(tags, nil) {
+		t.Error("Expected the activity to have the wanted tags")
+	}
+	if HasAllTags(tags, []string{"stem", "drop-in"}) {
+		t.Error("Expected a missing tag not to match")
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// TransitFriendlyScore is the lowest transit score of a venue families can easily reach without a car
+const TransitFriendlyScore = 50
+
+// VenueTransit is how well a venue is served by public transit, computed once from the stops of a
+// GTFS feed near its coordinates and recomputed only when the venue moves
+type VenueTransit struct {
+	Score             int         `json:"score" dynamodbav:"score"`                         // 0 - 100
+	StopsWithin400m   int         `json:"stops_within_400m" dynamodbav:"stops_within_400m"` // about a 5 minute walk
+	StopsWithin800m   int         `json:"stops_within_800m" dynamodbav:"stops_within_800m"` // about a 10 minute walk
+	NearestStopName   string      `json:"nearest_stop_name,omitempty" dynamodbav:"nearest_stop_name,omitempty"`
+	NearestStopMeters int         `json:"nearest_stop_meters,omitempty" dynamodbav:"nearest_stop_meters,omitempty"` // 0 when no stop is within 800 m
+	Coordinates       Coordinates `json:"coordinates" dynamodbav:"coordinates"`                                     // venue coordinates the score was computed at
+	Feed              string      `json:"feed" dynamodbav:"feed"`                                                   // GTFS feed the stops came from
+	ScoredAt          time.Time   `json:"scored_at" dynamodbav:"scored_at"`
+}
+
+// TransitFriendly reports whether the venue is within easy reach of transit
+func (t *VenueTransit) TransitFriendly() bool {
+	return t != nil && t.Score >= TransitFriendlyScore
+}
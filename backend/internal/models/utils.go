@@ -8,23 +8,6 @@ import (
 	"time"
 )
 
-// GenerateActivityID creates a unique ID for an activity based on its core attributes
-func GenerateActivityID(title, date, location string) string {
-	// Normalize inputs
-	normalizedTitle := strings.ToLower(strings.TrimSpace(title))
-	normalizedDate := strings.ToLower(strings.TrimSpace(date))
-	normalizedLocation := strings.ToLower(strings.TrimSpace(location))
-	
-	// Create hash input
-	input := fmt.Sprintf("%s|%s|%s", normalizedTitle, normalizedDate, normalizedLocation)
-	
-	// Generate SHA256 hash
-	hash := sha256.Sum256([]byte(input))
-	
-	// Return first 8 characters with prefix
-	return "act_" + hex.EncodeToString(hash[:])[:8]
-}
-
 // GenerateScrapingJobID creates a unique ID for a scraping job
 func GenerateScrapingJobID(sourceURL string, timestamp time.Time) string {
 	input := fmt.Sprintf("%s|%d", sourceURL, timestamp.Unix())
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Validation rule check constants
+const (
+	ValidationCheckRequired   = "required"    // field must be non-empty
+	ValidationCheckMinLength  = "min_length"  // non-empty field shorter than Value characters
+	ValidationCheckMaxLength  = "max_length"  // non-empty field longer than Value characters
+	ValidationCheckPattern    = "pattern"     // non-empty field must match the Pattern regex
+	ValidationCheckDateFormat = "date_format" // non-empty field must be a recognized date
+	ValidationCheckTimeFormat = "time_format" // non-empty field must be a recognized time
+)
+
+// Validation rule severity constants
+const (
+	ValidationSeverityError   = "error"   // marks the record invalid
+	ValidationSeverityWarning = "warning" // only lowers the confidence score
+)
+
+// Validation stage constants
+const (
+	ValidationStageParsing    = "parsing"    // events parsed from scraped markdown
+	ValidationStageConversion = "conversion" // activities converted from extracted data
+)
+
+// ValidationRule is a single declarative check applied to one field of an extracted record
+type ValidationRule struct {
+	Field    string  `json:"field" dynamodbav:"field"`                         // e.g. title, location.name, schedule.start_date
+	Check    string  `json:"check" dynamodbav:"check"`                         // required, min_length, max_length, pattern, date_format, time_format
+	Value    int     `json:"value,omitempty" dynamodbav:"value,omitempty"`     // length bound for min_length/max_length
+	Pattern  string  `json:"pattern,omitempty" dynamodbav:"pattern,omitempty"` // regex for pattern checks
+	Severity string  `json:"severity" dynamodbav:"severity"`                   // error, warning
+	Weight   float64 `json:"weight" dynamodbav:"weight"`                       // confidence points deducted on failure
+	Message  string  `json:"message" dynamodbav:"message"`
+}
+
+// ValidationRuleSet holds the validation rules for one schema type in DynamoDB
+type ValidationRuleSet struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // VALIDATION_RULES#{schema_type}
+	SK string `json:"SK" dynamodbav:"SK"` // RULESET
+
+	SchemaType      string           `json:"schema_type" dynamodbav:"schema_type"` // events, activities, venues, custom
+	ParsingRules    []ValidationRule `json:"parsing_rules" dynamodbav:"parsing_rules"`
+	ConversionRules []ValidationRule `json:"conversion_rules" dynamodbav:"conversion_rules"`
+
+	// Metadata
+	Version   int       `json:"version" dynamodbav:"version"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty" dynamodbav:"updated_by,omitempty"`
+}
+
+// Validate checks the schema type and that every rule in the set is well formed
+func (rs *ValidationRuleSet) Validate() error {
+	switch rs.SchemaType {
+	case "events", "activities", "camps", "classes", "venues", "custom":
+		// Valid schema types
+	default:
+		return fmt.Errorf("invalid schema_type: %s", rs.SchemaType)
+	}
+
+	for stage, rules := range map[string][]ValidationRule{
+		ValidationStageParsing:    rs.ParsingRules,
+		ValidationStageConversion: rs.ConversionRules,
+	} {
+		for i, rule := range rules {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("%s rule %d: %w", stage, i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the rule has a field, a known check and severity, and usable parameters
+func (r *ValidationRule) Validate() error {
+	if r.Field == "" {
+		return fmt.Errorf("field is required")
+	}
+
+	switch r.Check {
+	case ValidationCheckRequired, ValidationCheckDateFormat, ValidationCheckTimeFormat:
+	case ValidationCheckMinLength, ValidationCheckMaxLength:
+		if r.Value <= 0 {
+			return fmt.Errorf("%s check on %s needs a positive value", r.Check, r.Field)
+		}
+	case ValidationCheckPattern:
+		if r.Pattern == "" {
+			return fmt.Errorf("pattern check on %s needs a pattern", r.Field)
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern for %s: %w", r.Field, err)
+		}
+	default:
+		return fmt.Errorf("unknown check %q", r.Check)
+	}
+
+	if r.Severity != ValidationSeverityError && r.Severity != ValidationSeverityWarning {
+		return fmt.Errorf("unknown severity %q", r.Severity)
+	}
+	if r.Weight < 0 {
+		return fmt.Errorf("weight must not be negative")
+	}
+
+	return nil
+}
+
+// Helper functions to create primary keys for validation rule sets
+func CreateValidationRulesPK(schemaType string) string {
+	return "VALIDATION_RULES#" + schemaType
+}
+
+func CreateValidationRulesSK() string {
+	return "RULESET"
+}
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// Venue claim status constants
+const (
+	VenueClaimStatusPendingVerification = "pending_verification" // waiting for the emailed code
+	VenueClaimStatusVerified            = "verified"             // email verified, waiting for an admin
+	VenueClaimStatusApproved            = "approved"
+	VenueClaimStatusRejected            = "rejected"
+	VenueClaimStatusExpired             = "expired" // the code expired or too many wrong codes were entered
+)
+
+// Venue claim audit action constants
+const (
+	VenueClaimActionRequested          = "requested"
+	VenueClaimActionVerificationFailed = "verification_failed"
+	VenueClaimActionVerified           = "verified"
+	VenueClaimActionExpired            = "expired"
+	VenueClaimActionApproved           = "approved"
+	VenueClaimActionRejected           = "rejected"
+)
+
+// Venue claim verification limits
+const (
+	VenueClaimCodeTTL         = 24 * time.Hour
+	VenueClaimMaxCodeAttempts = 5
+)
+
+// VenueClaim is a request to manage a registry venue's listings through the provider API.
+// The claimant proves control of the venue's domain with a code sent to an address on it,
+// and an admin decides the claim. Every step is recorded as a VenueClaimAuditEntry.
+type VenueClaim struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // VENUE_CLAIM#{claim_id}
+	SK string `json:"SK" dynamodbav:"SK"` // CLAIM
+
+	ClaimID       string `json:"claim_id" dynamodbav:"claim_id"`
+	VenueID       string `json:"venue_id" dynamodbav:"venue_id"`
+	VenueName     string `json:"venue_name" dynamodbav:"venue_name"`
+	VenueWebsite  string `json:"venue_website" dynamodbav:"venue_website"` // the claimant's email must be on its domain
+	ClaimantName  string `json:"claimant_name" dynamodbav:"claimant_name"`
+	ClaimantEmail string `json:"claimant_email" dynamodbav:"claimant_email"`
+	ClaimantRole  string `json:"claimant_role,omitempty" dynamodbav:"claimant_role,omitempty"`
+	Message       string `json:"message,omitempty" dynamodbav:"message,omitempty"`
+	Status        string `json:"status" dynamodbav:"status"`
+
+	// Email verification
+	CodeHash      string     `json:"-" dynamodbav:"code_hash"`
+	CodeExpiresAt time.Time  `json:"code_expires_at" dynamodbav:"code_expires_at"`
+	CodeAttempts  int        `json:"code_attempts" dynamodbav:"code_attempts"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty" dynamodbav:"verified_at,omitempty"`
+
+	// Decision
+	DecidedBy     string     `json:"decided_by,omitempty" dynamodbav:"decided_by,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty" dynamodbav:"decided_at,omitempty"`
+	DecisionNotes string     `json:"decision_notes,omitempty" dynamodbav:"decision_notes,omitempty"`
+	ProviderID    string     `json:"provider_id,omitempty" dynamodbav:"provider_id,omitempty"` // provider account issued on approval
+
+	// Metadata
+	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" dynamodbav:"updated_at"`
+}
+
+// VenueClaimAuditEntry records one step of a venue claim: who did what, and when
+type VenueClaimAuditEntry struct {
+	// Primary Keys
+	PK string `json:"PK" dynamodbav:"PK"` // VENUE_CLAIM#{claim_id}
+	SK string `json:"SK" dynamodbav:"SK"` // AUDIT#{timestamp}
+
+	ClaimID   string    `json:"claim_id" dynamodbav:"claim_id"`
+	Action    string    `json:"action" dynamodbav:"action"`
+	Actor     string    `json:"actor" dynamodbav:"actor"`   // the claimant's email or the deciding admin
+	Status    string    `json:"status" dynamodbav:"status"` // claim status after the step
+	Details   string    `json:"details,omitempty" dynamodbav:"details,omitempty"`
+	Timestamp time.Time `json:"timestamp" dynamodbav:"timestamp"`
+}
+
+// VenueClaimRequest is a claimant's request to manage a venue
+type VenueClaimRequest struct {
+	ClaimantName  string `json:"claimant_name"`
+	ClaimantEmail string `json:"claimant_email"`
+	ClaimantRole  string `json:"claimant_role"` // e.g. "Events coordinator"
+	Message       string `json:"message"`
+}
+
+// VenueClaimVerification is the code the claimant received by email
+type VenueClaimVerification struct {
+	Code string `json:"code"`
+}
+
+// VenueClaimDecision is an admin's approval or rejection of a verified claim
+type VenueClaimDecision struct {
+	DecidedBy string `json:"decided_by"`
+	Notes     string `json:"notes"`
+}
+
+// Validate checks a claim request and normalizes the claimant's email
+func (r *VenueClaimRequest) Validate() error {
+	if strings.TrimSpace(r.ClaimantName) == "" {
+		return fmt.Errorf("claimant_name is required")
+	}
+	if r.ClaimantEmail == "" {
+		return fmt.Errorf("claimant_email is required")
+	}
+	address, err := mail.ParseAddress(r.ClaimantEmail)
+	if err != nil {
+		return fmt.Errorf("invalid claimant_email: %w", err)
+	}
+	r.ClaimantEmail = strings.ToLower(address.Address)
+	return nil
+}
+
+// Validate checks that a decision names the admin who made it
+func (d *VenueClaimDecision) Validate() error {
+	if d.DecidedBy == "" {
+		return fmt.Errorf("decided_by is required")
+	}
+	return nil
+}
+
+// ClaimWebsite returns the website a claim on the venue is verified against, or "" if it has none
+func (v *Venue) ClaimWebsite() string {
+	if v.Website != "" {
+		return v.Website
+	}
+	return v.ContactInfo.Website
+}
+
+// EmailMatchesWebsite reports whether an email address is on the same registrable domain
+// as a website, e.g. events@ballard.example.org for https://www.example.org
+func EmailMatchesWebsite(email, website string) bool {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return false
+	}
+	emailDomain := urlutil.RegistrableDomain("https://" + email[at+1:])
+	return emailDomain != "" && emailDomain == urlutil.RegistrableDomain(website)
+}
+
+// IsDecidable returns true if the claim is waiting for an admin decision
+func (c *VenueClaim) IsDecidable() bool {
+	return c.Status == VenueClaimStatusVerified
+}
+
+// CodeExpired reports whether the verification code can no longer be used
+func (c *VenueClaim) CodeExpired(now time.Time) bool {
+	return now.After(c.CodeExpiresAt) || c.CodeAttempts >= VenueClaimMaxCodeAttempts
+}
+
+// CheckCode reports whether a code is the one sent for the claim
+func (c *VenueClaim) CheckCode(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashVenueClaimCode(c.ClaimID, strings.TrimSpace(code))), []byte(c.CodeHash)) == 1
+}
+
+// GenerateVenueClaimCode creates a 6 digit verification code
+func GenerateVenueClaimCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// HashVenueClaimCode returns the stored form of a claim's verification code
+func HashVenueClaimCode(claimID, code string) string {
+	sum := sha256.Sum256([]byte(claimID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewVenueClaimAuditEntry creates the audit entry for a step of a claim
+func NewVenueClaimAuditEntry(claim *VenueClaim, action, actor, details string, now time.Time) *VenueClaimAuditEntry {
+	return &VenueClaimAuditEntry{
+		PK:        CreateVenueClaimPK(claim.ClaimID),
+		SK:        CreateVenueClaimAuditSK(now),
+		ClaimID:   claim.ClaimID,
+		Action:    action,
+		Actor:     actor,
+		Status:    claim.Status,
+		Details:   details,
+		Timestamp: now,
+	}
+}
+
+// Helper functions to create primary keys for venue claims
+func CreateVenueClaimPK(claimID string) string {
+	return fmt.Sprintf("VENUE_CLAIM#%s", claimID)
+}
+
+func CreateVenueClaimSK() string {
+	return "CLAIM"
+}
+
+func CreateVenueClaimAuditSK(timestamp time.Time) string {
+	return fmt.Sprintf("AUDIT#%s", timestamp.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmailMatchesWebsite(t *testing.T) {
+	tests := []struct {
+		email   string
+		website string
+		want    bool
+	}{
+		{"events@example.org", "https://www.example.org/visit", true},
+		{"events@ballard.example.org", "https://example.org", true},
+		{"events@example.org", "https://example.com", false},
+		{"events@gmail.com", "https://sites.google.com/view/playgroup", false},
+		{"not-an-email", "https://example.org", false},
+		{"events@example.org", "", false},
+	}
+	for _, tt := range tests {
+		if got := EmailMatchesWebsite(tt.email, tt.website); got != tt.want {
+			t.Errorf("EmailMatchesWebsite(%q, %q) = %v, want %v", tt.email, tt.website, got, tt.want)
+		}
+	}
+}
+
+func TestVenueClaimCode(t *testing.T) {
+	code, err := GenerateVenueClaimCode()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(code) != 6 {
+		t.Errorf("Expected a 6 digit code, got %q", code)
+	}
+
+	now := time.Now()
+	claim := &VenueClaim{
+		ClaimID:       "claim-1",
+		CodeHash:      HashVenueClaimCode("claim-1", code),
+		CodeExpiresAt: now.Add(VenueClaimCodeTTL),
+	}
+	if !claim.CheckCode(" " + code + " ") {
+		t.Error("Expected the sent code to match")
+	}
+	other := &VenueClaim{ClaimID: "claim-2", CodeHash: claim.CodeHash}
+	if other.CheckCode(code) {
+		t.Error("Expected the code to be bound to its claim")
+	}
+
+	if claim.CodeExpired(now) {
+		t.Error("Expected a fresh code to be usable")
+	}
+	if !claim.CodeExpired(now.Add(VenueClaimCodeTTL + time.Minute)) {
+		t.Error("Expected the code to expire")
+	}
+	claim.CodeAttempts = VenueClaimMaxCodeAttempts
+	if !claim.CodeExpired(now) {
+		t.Error("Expected the code to be unusable after too many attempts")
+	}
+}
+
+func TestVenueClaimRequestValidate(t *testing.T) {
+	request := VenueClaimRequest{ClaimantName: "Pat", ClaimantEmail: "Pat Lee <Events@Example.org>"}
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+	if request.ClaimantEmail != "events@example.org" {
+		t.Errorf("Expected a normalized email, got %q", request.ClaimantEmail)
+	}
+
+	for _, invalid := range []VenueClaimRequest{
+		{ClaimantEmail: "events@example.org"},
+		{ClaimantName: "Pat"},
+		{ClaimantName: "Pat", ClaimantEmail: "example.org"},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+// Weekend plan slots
+const (
+	PlanSlotMorning   = "morning"   // starts before noon
+	PlanSlotAfternoon = "afternoon" // starts from noon until 6 PM
+)
+
+// Weekend plan budgets other than a maximum cost
+const (
+	PlanBudgetFree = "free"
+	PlanBudgetAny  = "any"
+)
+
+// WeekendPlan is a suggested itinerary for one weekend: a morning and an afternoon activity on
+// Saturday and Sunday, each with alternates
+type WeekendPlan struct {
+	Saturday   string    `json:"saturday"` // YYYY-MM-DD
+	Sunday     string    `json:"sunday"`   // YYYY-MM-DD
+	Ages       []int     `json:"ages,omitempty"`
+	Region     string    `json:"region,omitempty"`
+	Budget     string    `json:"budget"`
+	Candidates int       `json:"candidates"` // activity occurrences that matched the filters
+	Days       []PlanDay `json:"days"`
+}
+
+// PlanDay is one day of a weekend plan
+type PlanDay struct {
+	Date    string     `json:"date"`    // YYYY-MM-DD
+	Weekday string     `json:"weekday"` // Saturday or Sunday
+	Slots   []PlanSlot `json:"slots"`
+}
+
+// PlanSlot is one part of a day. Activity is nil when nothing matched the slot.
+type PlanSlot struct {
+	Slot       string            `json:"slot"` // morning|afternoon
+	Activity   *PublicActivity   `json:"activity"`
+	Alternates []*PublicActivity `json:"alternates"`
+}
@@ -0,0 +1,53 @@
+// Package queryparams parses numeric query string parameters of API requests.
+//
+// Every parser takes the bounds a value must fall within. Its errors name the parameter and its
+// bounds, so handlers can return them as the message of a 400 response.
+package queryparams
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseInt parses value, the value of the parameter name, as a whole number between min and max
+func ParseInt(name, value string, min, max int) (int, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parsed < min || parsed > max {
+		return 0, fmt.Errorf("invalid %s: must be a whole number between %d and %d", name, min, max)
+	}
+	return parsed, nil
+}
+
+// Int returns the parameter name of params as a whole number between min and max, or def when
+// the parameter is absent or empty
+func Int(params map[string]string, name string, def, min, max int) (int, error) {
+	value := params[name]
+	if strings.TrimSpace(value) == "" {
+		return def, nil
+	}
+	return ParseInt(name, value, min, max)
+}
+
+// ParseFloat parses value, the value of the parameter name, as a number between min and max
+func ParseFloat(name, value string, min, max float64) (float64, error) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || parsed != parsed || parsed < min || parsed > max {
+		return 0, fmt.Errorf("invalid %s: must be a number between %s and %s", name, formatBound(min), formatBound(max))
+	}
+	return parsed, nil
+}
+
+// Float returns the parameter name of params as a number between min and max, or def when the
+// parameter is absent or empty
+func Float(params map[string]string, name string, def, min, max float64) (float64, error) {
+	value := params[name]
+	if strings.TrimSpace(value) == "" {
+		return def, nil
+	}
+	return ParseFloat(name, value, min, max)
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
@@ -0,0 +1,83 @@
+package queryparams
+
+import "testing"
+
+func TestInt(t *testing.T) {
+	params := map[string]string{
+		"limit":    "37",
+		"padded":   " 12 ",
+		"empty":    "",
+		"zero":     "0",
+		"negative": "-5",
+		"large":    "501",
+		"word":     "ten",
+		"decimal":  "2.5",
+	}
+
+	tests := []struct {
+		name     string
+		expected int
+		wantErr  bool
+	}{
+		{"limit", 37, false},
+		{"padded", 12, false},
+		{"missing", 50, false},
+		{"empty", 50, false},
+		{"zero", 0, true},
+		{"negative", 0, true},
+		{"large", 0, true},
+		{"word", 0, true},
+		{"decimal", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Int(params, tt.name, 50, 1, 500)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Int(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Int(%q) = %d, expected %d", tt.name, got, tt.expected)
+		}
+	}
+
+	if _, err := Int(params, "large", 50, 1, 500); err == nil || err.Error() != "invalid large: must be a whole number between 1 and 500" {
+		t.Errorf("Expected an error naming the parameter and its bounds, got %v", err)
+	}
+}
+
+func TestFloat(t *testing.T) {
+	params := map[string]string{
+		"min_completeness": "0.75",
+		"whole":            "1",
+		"above":            "1.5",
+		"nan":              "NaN",
+		"word":             "high",
+	}
+
+	tests := []struct {
+		name     string
+		expected float64
+		wantErr  bool
+	}{
+		{"min_completeness", 0.75, false},
+		{"whole", 1, false},
+		{"missing", 0.5, false},
+		{"above", 0, true},
+		{"nan", 0, true},
+		{"word", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Float(params, tt.name, 0.5, 0, 1)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Float(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Float(%q) = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+
+	if _, err := Float(params, "above", 0, 0, 1); err == nil || err.Error() != "invalid above: must be a number between 0 and 1" {
+		t.Errorf("Expected an error naming the parameter and its bounds, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ActivityDiff describes how a fresh scrape differs from the activities already published for a source
+type ActivityDiff struct {
+	New       []models.Activity `json:"new"`       // scraped but not yet published
+	Removed   []models.Activity `json:"removed"`   // published but no longer found on the source
+	Changed   []ActivityChange  `json:"changed"`   // found in both with different field values
+	Unchanged int               `json:"unchanged"` // found in both with identical compared fields
+}
+
+// ActivityChange pairs a published activity with its freshly scraped counterpart
+type ActivityChange struct {
+	Title     string          `json:"title"`
+	Published models.Activity `json:"published"`
+	Scraped   models.Activity `json:"scraped"`
+	Fields    []FieldChange   `json:"fields"`
+}
+
+// FieldChange describes a single field that differs between published and scraped data
+type FieldChange struct {
+	Field     string `json:"field"`
+	Published string `json:"published"`
+	Scraped   string `json:"scraped"`
+}
+
+// diffMatchThreshold is the minimum CalculateDuplicateSimilarity score for pairing activities whose keys differ
+const diffMatchThreshold = 0.75
+
+// diffFields lists the activity fields compared when looking for changes
+var diffFields = []struct {
+	name  string
+	value func(a models.Activity) string
+}{
+	{"description", func(a models.Activity) string { return a.Description }},
+	{"type", func(a models.Activity) string { return a.Type }},
+	{"category", func(a models.Activity) string { return a.Category }},
+	{"schedule.startDate", func(a models.Activity) string { return a.Schedule.StartDate }},
+	{"schedule.endDate", func(a models.Activity) string { return a.Schedule.EndDate }},
+	{"schedule.startTime", func(a models.Activity) string { return a.Schedule.StartTime }},
+	{"schedule.endTime", func(a models.Activity) string { return a.Schedule.EndTime }},
+	{"location.name", func(a models.Activity) string { return a.Location.Name }},
+	{"location.address", func(a models.Activity) string { return a.Location.Address }},
+	{"pricing.type", func(a models.Activity) string { return a.Pricing.Type }},
+	{"pricing.cost", func(a models.Activity) string { return strconv.FormatFloat(a.Pricing.Cost, 'f', 2, 64) }},
+	{"registration.url", func(a models.Activity) string { return a.Registration.URL }},
+	{"registration.status", func(a models.Activity) string { return a.Registration.Status }},
+	{"detailUrl", func(a models.Activity) string { return a.DetailURL }},
+}
+
+// DiffActivities compares published activities against a fresh scrape.
+// Activities are matched by normalized title and start date first, then by duplicate similarity
+// so that events whose date or venue changed show up as changes rather than as a remove/add pair.
+func DiffActivities(published, scraped []models.Activity) *ActivityDiff {
+	diff := &ActivityDiff{
+		New:     []models.Activity{},
+		Removed: []models.Activity{},
+		Changed: []ActivityChange{},
+	}
+
+	publishedByKey := make(map[string]int)
+	for i, activity := range published {
+		publishedByKey[activityDiffKey(activity)] = i
+	}
+
+	matched := make(map[int]bool)
+	var unmatchedScraped []models.Activity
+
+	// Pass 1: exact key matches
+	for _, activity := range scraped {
+		idx, exists := publishedByKey[activityDiffKey(activity)]
+		if !exists || matched[idx] {
+			unmatchedScraped = append(unmatchedScraped, activity)
+			continue
+		}
+		matched[idx] = true
+		diff.addPair(published[idx], activity)
+	}
+
+	// Pass 2: fuzzy matches for activities whose key fields changed
+	for _, activity := range unmatchedScraped {
+		bestIdx := -1
+		bestScore := 0.0
+		for i, candidate := range published {
+			if matched[i] {
+				continue
+			}
+			if score := models.CalculateDuplicateSimilarity(candidate, activity); score >= diffMatchThreshold && score > bestScore {
+				bestIdx = i
+				bestScore = score
+			}
+		}
+
+		if bestIdx == -1 {
+			diff.New = append(diff.New, activity)
+			continue
+		}
+		matched[bestIdx] = true
+		diff.addPair(published[bestIdx], activity)
+	}
+
+	for i, activity := range published {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, activity)
+		}
+	}
+
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Title < diff.Changed[j].Title
+	})
+
+	return diff
+}
+
+// addPair records a matched pair as changed or unchanged
+func (d *ActivityDiff) addPair(published, scraped models.Activity) {
+	fields := compareActivityFields(published, scraped)
+	if len(fields) == 0 {
+		d.Unchanged++
+		return
+	}
+
+	d.Changed = append(d.Changed, ActivityChange{
+		Title:     scraped.Title,
+		Published: published,
+		Scraped:   scraped,
+		Fields:    fields,
+	})
+}
+
+// compareActivityFields returns the compared fields whose values differ
+func compareActivityFields(published, scraped models.Activity) []FieldChange {
+	var changes []FieldChange
+	for _, field := range diffFields {
+		oldValue := strings.TrimSpace(field.value(published))
+		newValue := strings.TrimSpace(field.value(scraped))
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{
+				Field:     field.name,
+				Published: oldValue,
+				Scraped:   newValue,
+			})
+		}
+	}
+	return changes
+}
+
+// activityDiffKey builds the exact-match key for an activity
+func activityDiffKey(activity models.Activity) string {
+	title := strings.Join(strings.Fields(strings.ToLower(activity.Title)), " ")
+	return title + "|" + activity.Schedule.StartDate
+}
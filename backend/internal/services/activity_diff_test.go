@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func diffTestActivity(title, startDate, venue string) models.Activity {
+	return models.Activity{
+		Title:    title,
+		Schedule: models.Schedule{StartDate: startDate},
+		Location: models.Location{Name: venue},
+	}
+}
+
+func TestDiffActivities(t *testing.T) {
+	published := []models.Activity{
+		diffTestActivity("Story Time", "2025-03-01", "Central Library"),
+		diffTestActivity("Lego Club", "2025-03-02", "Ballard Library"),
+		diffTestActivity("Puppet Show", "2025-03-05", "Seattle Center"),
+	}
+
+	priceChanged := diffTestActivity("Lego Club", "2025-03-02", "Ballard Library")
+	priceChanged.Pricing.Cost = 10
+
+	scraped := []models.Activity{
+		diffTestActivity("  story   TIME ", "2025-03-01", "Central Library"), // same, normalized title
+		priceChanged,
+		diffTestActivity("Puppet Show", "2025-03-06", "Seattle Center"), // date moved
+		diffTestActivity("Science Fair", "2025-03-10", "Pacific Science Center"),
+	}
+
+	diff := DiffActivities(published, scraped)
+
+	if len(diff.New) != 1 || diff.New[0].Title != "Science Fair" {
+		t.Errorf("Expected Science Fair to be new, got %+v", diff.New)
+	}
+
+	if len(diff.Removed) != 0 {
+		t.Errorf("Expected no removed activities, got %+v", diff.Removed)
+	}
+
+	changedFields := make(map[string][]FieldChange)
+	for _, change := range diff.Changed {
+		changedFields[change.Title] = change.Fields
+	}
+
+	if fields := changedFields["Lego Club"]; len(fields) != 1 || fields[0].Field != "pricing.cost" {
+		t.Errorf("Expected Lego Club pricing.cost change, got %+v", fields)
+	}
+
+	if fields := changedFields["Puppet Show"]; len(fields) != 1 || fields[0].Field != "schedule.startDate" {
+		t.Errorf("Expected Puppet Show start date change, got %+v", fields)
+	}
+
+	if diff.Unchanged != 1 {
+		t.Errorf("Expected 1 unchanged activity, got %d", diff.Unchanged)
+	}
+}
+
+func TestDiffActivitiesRemoved(t *testing.T) {
+	published := []models.Activity{
+		diffTestActivity("Story Time", "2025-03-01", "Central Library"),
+	}
+
+	diff := DiffActivities(published, nil)
+
+	if len(diff.Removed) != 1 {
+		t.Errorf("Expected 1 removed activity, got %d", len(diff.Removed))
+	}
+	if len(diff.New) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("Expected no new or changed activities, got %+v", diff)
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"seattle-family-activities-scraper/internal/activityid"
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ActivityIDMigration moves one published event from a legacy activity ID to the current scheme
+type ActivityIDMigration struct {
+	EventID string `json:"event_id"`
+	FromID  string `json:"from_id"`
+	ToID    string `json:"to_id"`
+
+	Event    *models.AdminEvent `json:"-"`
+	Activity *models.Activity   `json:"-"` // published under ToID
+}
+
+// ActivityIDBackfillPlan lists the events to move to the current activity ID scheme
+type ActivityIDBackfillPlan struct {
+	Migrations []ActivityIDMigration `json:"migrations"`
+	Skipped    map[string]string     `json:"skipped,omitempty"` // event ID -> why it keeps its legacy ID
+}
+
+// PlanActivityIDBackfill finds the approved events published under a legacy activity ID and
+// works out the ID each moves to. When two events would share an ID, the one extracted first
+// keeps it and the others are numbered like repeats on a page, so no activity is dropped.
+func PlanActivityIDBackfill(approvedEvents []models.AdminEvent, conversion *SchemaConversionService) *ActivityIDBackfillPlan {
+	plan := &ActivityIDBackfillPlan{
+		Migrations: []ActivityIDMigration{},
+		Skipped:    make(map[string]string),
+	}
+
+	taken := make(map[string]bool)
+	var legacy []*models.AdminEvent
+	for i := range approvedEvents {
+		event := &approvedEvents[i]
+		switch {
+		case event.ActivityID == "":
+			// Never published; it gets a current ID when it is
+		case activityid.IsLegacy(event.ActivityID):
+			legacy = append(legacy, event)
+		default:
+			taken[event.ActivityID] = true
+		}
+	}
+
+	sort.Slice(legacy, func(i, j int) bool {
+		if !legacy[i].ExtractedAt.Equal(legacy[j].ExtractedAt) {
+			return legacy[i].ExtractedAt.Before(legacy[j].ExtractedAt)
+		}
+		return legacy[i].EventID < legacy[j].EventID
+	})
+
+	for _, event := range legacy {
+		// Convert without the published ID so conversion derives the current one
+		unpublished := *event
+		unpublished.ActivityID = ""
+		activity, err := publishableActivity(&unpublished, conversion)
+		if err != nil {
+			plan.Skipped[event.EventID] = err.Error()
+			continue
+		}
+
+		toID := activity.ID
+		for n := 2; taken[toID]; n++ {
+			toID = fmt.Sprintf("%s-%d", activity.ID, n)
+		}
+		taken[toID] = true
+		activity.ID = toID
+
+		plan.Migrations = append(plan.Migrations, ActivityIDMigration{
+			EventID:  event.EventID,
+			FromID:   event.ActivityID,
+			ToID:     toID,
+			Event:    event,
+			Activity: activity,
+		})
+	}
+
+	return plan
+}
+
+// ApplyActivityIDMigration republishes an event's activity under its new ID, leaves a redirect
+// under the old ID and removes the old activity. The event is only moved while it is still
+// approved; if it was reviewed in the meantime the new activity is removed again and
+// ErrAdminEventChanged is returned.
+func ApplyActivityIDMigration(ctx context.Context, dynamo *DynamoDBService, venues *VenueRegistry, migration ActivityIDMigration, now time.Time) error {
+	activity := migration.Activity
+	if err := dynamo.BatchPutActivities(ctx, []*models.Activity{activity}); err != nil {
+		return fmt.Errorf("failed to store activity %s: %w", migration.ToID, err)
+	}
+	entries := CalendarEntriesForActivity(activity, venues.MatchID(activity.Location))
+	if err := dynamo.ReplaceCalendarEntries(ctx, migration.ToID, entries); err != nil {
+		return fmt.Errorf("failed to store calendar entries of activity %s: %w", migration.ToID, err)
+	}
+	if err := dynamo.ReplaceTagEntries(ctx, migration.ToID, TagEntriesForActivity(activity)); err != nil {
+		return fmt.Errorf("failed to store tag entries of activity %s: %w", migration.ToID, err)
+	}
+	if err := dynamo.ReplaceGeoEntry(ctx, migration.ToID, GeoEntryForActivity(activity, migration.EventID)); err != nil {
+		return fmt.Errorf("failed to store geo entry of activity %s: %w", migration.ToID, err)
+	}
+	if err := dynamo.PutActivityRedirect(ctx, models.NewActivityRedirect(migration.FromID, migration.ToID, now)); err != nil {
+		return err
+	}
+
+	event := *migration.Event
+	event.ActivityID = migration.ToID
+	if err := dynamo.UpdateAdminEventFromStatus(ctx, &event, models.AdminEventStatusApproved); err != nil {
+		if errors.Is(err, ErrAdminEventChanged) {
+			if undoErr := dynamo.DeleteFamilyActivity(ctx, models.CreateEventPK(migration.FromID), models.SortKeyRedirect); undoErr != nil {
+				return fmt.Errorf("%w; failed to remove redirect: %v", err, undoErr)
+			}
+			if undoErr := dynamo.DeleteActivity(ctx, migration.ToID); undoErr != nil {
+				return fmt.Errorf("%w; failed to remove activity %s: %v", err, migration.ToID, undoErr)
+			}
+		}
+		return err
+	}
+
+	if err := dynamo.DeleteActivity(ctx, migration.FromID); err != nil {
+		return fmt.Errorf("failed to remove activity %s: %w", migration.FromID, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/activityid"
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestPlanActivityIDBackfill(t *testing.T) {
+	extractedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	newEvent := func(eventID, activityID, title string, extractedAt time.Time) models.AdminEvent {
+		return models.AdminEvent{
+			EventID:    eventID,
+			ActivityID: activityID,
+			SourceURL:  "https://www.seattle.gov/parks/events",
+			SchemaType: "events",
+			Status:     models.AdminEventStatusApproved,
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{"title": title, "location": "Green Lake Park", "date": "2026-11-07", "price": "Free"},
+				},
+			},
+			ExtractedAt: extractedAt,
+		}
+	}
+
+	storyTimeID := activityid.New("https://www.seattle.gov/parks/events", "Story Time", "2026-11-07", "Green Lake Park")
+	unconvertible := newEvent("evt-5", "parentmap-fallback-1700000000-0", "Story Time", extractedAt)
+	unconvertible.RawExtractedData = map[string]interface{}{}
+
+	approvedEvents := []models.AdminEvent{
+		newEvent("evt-2", "parentmap-evt-1700000000", "Story Time", extractedAt.Add(time.Hour)),
+		newEvent("evt-1", "3f2b6c1e-9a41-4d7e-8f0a-2c5d9e7b1a64", "Story Time", extractedAt),
+		newEvent("evt-3", activityid.New("https://www.seattle.gov/parks/events", "Art Class", "2026-11-07", "Green Lake Park"), "Art Class", extractedAt),
+		newEvent("evt-4", "", "Nature Walk", extractedAt),
+		unconvertible,
+	}
+
+	plan := PlanActivityIDBackfill(approvedEvents, NewSchemaConversionService())
+
+	if len(plan.Migrations) != 2 {
+		t.Fatalf("Expected 2 migrations, got %+v", plan.Migrations)
+	}
+	first, second := plan.Migrations[0], plan.Migrations[1]
+	if first.EventID != "evt-1" || first.FromID != "3f2b6c1e-9a41-4d7e-8f0a-2c5d9e7b1a64" || first.ToID != storyTimeID {
+		t.Errorf("Expected the first extracted event to take the activity's ID, got %+v", first)
+	}
+	if second.EventID != "evt-2" || second.ToID != storyTimeID+"-2" {
+		t.Errorf("Expected the duplicate event to be numbered, got %+v", second)
+	}
+	if first.Activity == nil || first.Activity.ID != first.ToID || second.Activity.ID != second.ToID {
+		t.Errorf("Expected the activities under their new IDs, got %+v and %+v", first.Activity, second.Activity)
+	}
+	if approvedEvents[0].ActivityID != "parentmap-evt-1700000000" {
+		t.Errorf("Expected planning to leave the events alone, got %s", approvedEvents[0].ActivityID)
+	}
+	if _, ok := plan.Skipped["evt-5"]; !ok || len(plan.Skipped) != 1 {
+		t.Errorf("Expected only the unconvertible event to be skipped, got %v", plan.Skipped)
+	}
+}
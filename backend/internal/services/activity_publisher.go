@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Object keys the activity feed is published under
+const (
+	// PublishedActivitiesPrefix holds the feed and the manifests of its publish runs
+	PublishedActivitiesPrefix = "activities/"
+
+	// PublishedActivitiesKey is the object key the public site reads the activity feed from
+	PublishedActivitiesKey = PublishedActivitiesPrefix + "latest.json"
+)
+
+// maxPublishedEvents bounds how many approved events are included in the published feed
+const maxPublishedEvents = 1000
+
+// ActivityPublisher writes the approved activity feed to S3 for the public site
+type ActivityPublisher struct {
+	dynamo     *DynamoDBService
+	conversion *SchemaConversionService
+	store      *S3Store
+}
+
+// NewActivityPublisher creates a publisher that reads approved events and writes them to the store
+func NewActivityPublisher(dynamo *DynamoDBService, conversion *SchemaConversionService, store *S3Store) *ActivityPublisher {
+	return &ActivityPublisher{
+		dynamo:     dynamo,
+		conversion: conversion,
+		store:      store,
+	}
+}
+
+// Publish converts the approved events within their visibility window and uploads them as the
+// latest activity feed. It returns the number of activities published.
+func (p *ActivityPublisher) Publish(ctx context.Context) (int, error) {
+	now := time.Now()
+	approvedEvents, err := p.dynamo.GetApprovedAdminEvents(ctx, maxPublishedEvents)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get approved events: %w", err)
+	}
+
+	output := BuildActivitiesOutput(VisibleAdminEvents(approvedEvents, now), p.conversion)
+	body, err := json.Marshal(output)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal activities: %w", err)
+	}
+
+	run := NewPublishRun(p.store, PublisherActivities, PublishedActivitiesPrefix, now)
+	err = run.Put(ctx, PublishedActivitiesKey, body, "application/json")
+	if _, err := run.Finish(ctx, err); err != nil {
+		return 0, fmt.Errorf("failed to publish activities: %w", err)
+	}
+
+	return len(output.Activities), nil
+}
+
+// BuildActivitiesOutput converts approved events into the published feed format.
+// Events that cannot be converted, or whose activity would not render on the frontend, are skipped.
+func BuildActivitiesOutput(approvedEvents []models.AdminEvent, conversion *SchemaConversionService) *models.ActivitiesOutput {
+	activities := []models.Activity{}
+	domains := make(map[string]bool)
+
+	for i := range approvedEvents {
+		activity, err := publishableActivity(&approvedEvents[i], conversion)
+		if err != nil {
+			log.Printf("Warning: Skipping event %s: %v", approvedEvents[i].EventID, err)
+			continue
+		}
+
+		activities = append(activities, *activity)
+		if domain := activity.Source.Domain; domain != "" {
+			domains[domain] = true
+		}
+	}
+
+	sources := make([]string, 0, len(domains))
+	for domain := range domains {
+		sources = append(sources, domain)
+	}
+	sort.Strings(sources)
+
+	return &models.ActivitiesOutput{
+		Metadata:   models.NewActivitiesMetadata(len(activities), sources),
+		Activities: activities,
+	}
+}
+
+// publishableActivity converts an approved event into the activity the public site shows.
+// It returns an error if the event cannot be converted or its activity would not render.
+func publishableActivity(adminEvent *models.AdminEvent, conversion *SchemaConversionService) (*models.Activity, error) {
+	result, err := conversion.ConvertToActivity(adminEvent)
+	if err != nil || result.Activity == nil {
+		return nil, fmt.Errorf("could not be converted: %v", err)
+	}
+	if issues := result.Activity.ValidatePublic(); len(issues) > 0 {
+		return nil, fmt.Errorf("unpublishable activity: %v", issues)
+	}
+	return result.Activity, nil
+}
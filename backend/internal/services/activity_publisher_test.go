@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildActivitiesOutput(t *testing.T) {
+	newEvent := func(id, sourceURL, title string) models.AdminEvent {
+		return models.AdminEvent{
+			EventID:    id,
+			SourceURL:  sourceURL,
+			SchemaType: "events",
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{
+						"title":    title,
+						"location": "Green Lake Park",
+						"date":     "2025-07-12",
+						"price":    "Free",
+					},
+				},
+			},
+			ExtractedAt: time.Now(),
+		}
+	}
+
+	approvedEvents := []models.AdminEvent{
+		newEvent("evt-1", "https://www.seattle.gov/parks/events", "Toddler Story Time"),
+		newEvent("evt-2", "https://www.parentmap.com/calendar", "Summer Splash Day"),
+		newEvent("evt-3", "https://www.seattle.gov/parks/classes", "Kids Art Class"),
+	}
+
+	output := BuildActivitiesOutput(approvedEvents, NewSchemaConversionService())
+
+	if len(output.Activities) != 3 || output.Metadata.TotalActivities != 3 {
+		t.Fatalf("Expected 3 activities, got %d (metadata %d)", len(output.Activities), output.Metadata.TotalActivities)
+	}
+	if len(output.Metadata.Sources) != 2 || output.Metadata.Sources[0] > output.Metadata.Sources[1] {
+		t.Errorf("Expected 2 sorted source domains, got %v", output.Metadata.Sources)
+	}
+}
+
+func TestBuildActivitiesOutputSkipsUnpublishableActivities(t *testing.T) {
+	approvedEvents := []models.AdminEvent{
+		{
+			EventID:    "evt-1",
+			SourceURL:  "https://www.seattle.gov/parks/events",
+			SchemaType: "events",
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{"title": "Toddler Story Time", "location": "Green Lake Park", "date": "2025-07-12"},
+				},
+			},
+		},
+		{
+			EventID:    "evt-2",
+			SourceURL:  "https://www.parentmap.com/calendar",
+			SchemaType: "events",
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{"title": "Summer Splash Day", "location": "Magnuson Park", "date": "sometime this summer"},
+				},
+			},
+		},
+	}
+
+	output := BuildActivitiesOutput(approvedEvents, NewSchemaConversionService())
+
+	if len(output.Activities) != 1 || output.Activities[0].Title != "Toddler Story Time" {
+		t.Fatalf("Expected only the activity with a valid start date, got %+v", output.Activities)
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"math"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// completenessFieldWeight is the share of the completeness score taken by the core fields
+// scored at extraction time; the remainder is split evenly between the badge details
+const completenessFieldWeight = 0.5
+
+// ScoreActivityCompleteness scores a converted activity for publication. It extends the
+// extraction-time field score with the details families rely on: an image, map coordinates,
+// specific times and a registration link. Each detail present earns a public badge.
+func ScoreActivityCompleteness(activity *models.Activity) *models.Completeness {
+	details := []struct {
+		badge   string
+		present bool
+	}{
+		{models.BadgeHasImage, hasImage(activity)},
+		{models.BadgeHasMap, activity.Location.Coordinates.Lat != 0 || activity.Location.Coordinates.Lng != 0},
+		{models.BadgeSpecificTimes, hasSpecificTimes(activity)},
+		{models.BadgeRegistrationLink, activity.Registration.URL != ""},
+	}
+
+	completeness := &models.Completeness{}
+	score := completenessFieldWeight * activityFieldScore(activity)
+	for _, detail := range details {
+		if detail.present {
+			score += (1 - completenessFieldWeight) / float64(len(details))
+			completeness.Badges = append(completeness.Badges, detail.badge)
+		}
+	}
+
+	// Round away float noise so stored scores compare cleanly against filter floors
+	completeness.Score = math.Round(score*1000) / 1000
+	return completeness
+}
+
+// CompletenessScore returns an activity's completeness score, or 0 when it was never scored
+func CompletenessScore(activity *models.Activity) float64 {
+	if activity == nil || activity.Completeness == nil {
+		return 0
+	}
+	return activity.Completeness.Score
+}
+
+// hasImage reports whether the activity has at least one usable image
+func hasImage(activity *models.Activity) bool {
+	for _, image := range activity.Images {
+		if image.URL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSpecificTimes reports whether the activity says when it starts, not just on which day
+func hasSpecificTimes(activity *models.Activity) bool {
+	if activity.Schedule.StartTime != "" {
+		return true
+	}
+	for _, slot := range activity.Schedule.Times {
+		if slot.StartTime != "" {
+			return true
+		}
+	}
+	return false
+}
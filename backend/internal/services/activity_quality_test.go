@@ -0,0 +1,60 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestScoreActivityCompleteness(t *testing.T) {
+	coreFields := models.Activity{
+		Title:       "Toddler Story Time",
+		Description: "Songs and stories for little ones",
+		Location:    models.Location{Name: "Ballard Library"},
+		Schedule:    models.Schedule{StartDate: "2025-07-12"},
+		Pricing:     models.Pricing{Type: "free"},
+	}
+
+	bare := coreFields
+	completeness := ScoreActivityCompleteness(&bare)
+	if completeness.Score != 0.5 || len(completeness.Badges) != 0 {
+		t.Errorf("Expected core fields alone to score 0.5 with no badges, got %+v", completeness)
+	}
+
+	full := coreFields
+	full.Images = []models.Image{{URL: "https://example.com/story.jpg"}}
+	full.Location.Coordinates = models.Coordinates{Lat: 47.669, Lng: -122.384}
+	full.Schedule.Times = []models.TimeSlot{{StartTime: "10:30"}}
+	full.Registration.URL = "https://example.com/register"
+	completeness = ScoreActivityCompleteness(&full)
+	if completeness.Score != 1.0 {
+		t.Errorf("Expected a complete activity to score 1.0, got %.3f", completeness.Score)
+	}
+	expected := []string{models.BadgeHasImage, models.BadgeHasMap, models.BadgeSpecificTimes, models.BadgeRegistrationLink}
+	if !reflect.DeepEqual(completeness.Badges, expected) {
+		t.Errorf("Expected badges %v, got %v", expected, completeness.Badges)
+	}
+
+	partial := models.Activity{
+		Title:        "Splash Pad",
+		Schedule:     models.Schedule{StartTime: "11:00"},
+		Registration: models.Registration{URL: "https://example.com/register"},
+	}
+	completeness = ScoreActivityCompleteness(&partial)
+	if completeness.Score != 0.5 {
+		t.Errorf("Expected 0.25 from fields and 0.25 from two badges, got %.3f", completeness.Score)
+	}
+	if !reflect.DeepEqual(completeness.Badges, []string{models.BadgeSpecificTimes, models.BadgeRegistrationLink}) {
+		t.Errorf("Unexpected badges: %v", completeness.Badges)
+	}
+}
+
+func TestCompletenessScoreUnscored(t *testing.T) {
+	if score := CompletenessScore(nil); score != 0 {
+		t.Errorf("Expected 0 for a missing activity, got %.3f", score)
+	}
+	if score := CompletenessScore(&models.Activity{Title: "Unscored"}); score != 0 {
+		t.Errorf("Expected 0 for an unscored activity, got %.3f", score)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// BuildAdminStats summarizes an admin's audit entries for the week starting at weekStart.
+// Entries outside the week are ignored.
+func BuildAdminStats(admin string, weekStart time.Time, entries []models.AdminAuditEntry) *models.AdminStats {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	stats := &models.AdminStats{
+		Admin:     admin,
+		WeekStart: weekStart,
+		WeekEnd:   weekEnd,
+	}
+
+	var latencySeconds float64
+	var latencies int
+	for _, entry := range entries {
+		if entry.Timestamp.Before(weekStart) || !entry.Timestamp.Before(weekEnd) {
+			continue
+		}
+
+		switch entry.Action {
+		case models.AdminAuditEventApproved:
+			stats.EventsApproved++
+		case models.AdminAuditEventRejected:
+			stats.EventsRejected++
+		case models.AdminAuditEventEdited:
+			stats.EventsEdited++
+		case models.AdminAuditSourceActivated:
+			stats.SourcesActivated++
+		}
+		if entry.LatencySeconds > 0 {
+			latencySeconds += entry.LatencySeconds
+			latencies++
+		}
+
+		if stats.LastActionAt == nil || entry.Timestamp.After(*stats.LastActionAt) {
+			lastActionAt := entry.Timestamp
+			stats.LastActionAt = &lastActionAt
+		}
+	}
+
+	stats.EventsReviewed = stats.EventsApproved + stats.EventsRejected
+	if latencies > 0 {
+		stats.AverageReviewLatencyHours = math.Round(latencySeconds/float64(latencies)/3600*100) / 100
+	}
+	return stats
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildAdminStats(t *testing.T) {
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	entry := func(action string, at time.Time, latency time.Duration) models.AdminAuditEntry {
+		audit := models.NewAdminAuditEntry("reviewer@example.com", action, "target", at)
+		audit.LatencySeconds = latency.Seconds()
+		return *audit
+	}
+
+	entries := []models.AdminAuditEntry{
+		entry(models.AdminAuditEventApproved, weekStart.Add(time.Hour), 2*time.Hour),
+		entry(models.AdminAuditEventApproved, weekStart.Add(26*time.Hour), 5*time.Hour),
+		entry(models.AdminAuditEventRejected, weekStart.Add(50*time.Hour), 5*time.Hour),
+		entry(models.AdminAuditEventEdited, weekStart.Add(49*time.Hour), 0),
+		entry(models.AdminAuditSourceActivated, weekStart.Add(72*time.Hour), 0),
+		entry(models.AdminAuditEventApproved, weekStart.Add(-time.Hour), time.Hour),
+		entry(models.AdminAuditEventRejected, weekStart.AddDate(0, 0, 7), time.Hour),
+	}
+
+	stats := BuildAdminStats("reviewer@example.com", weekStart, entries)
+
+	if stats.EventsApproved != 2 || stats.EventsRejected != 1 || stats.EventsReviewed != 3 {
+		t.Errorf("Expected 2 approved and 1 rejected in the week, got %+v", stats)
+	}
+	if stats.EventsEdited != 1 || stats.SourcesActivated != 1 {
+		t.Errorf("Expected 1 edit and 1 activation, got %+v", stats)
+	}
+	if stats.AverageReviewLatencyHours != 4 {
+		t.Errorf("Expected an average latency of 4 hours, got %.2f", stats.AverageReviewLatencyHours)
+	}
+	if stats.LastActionAt == nil || !stats.LastActionAt.Equal(weekStart.Add(72*time.Hour)) {
+		t.Errorf("Expected the activation to be the last action, got %v", stats.LastActionAt)
+	}
+	if !stats.WeekEnd.Equal(weekStart.AddDate(0, 0, 7)) {
+		t.Errorf("Expected the week to end 7 days later, got %v", stats.WeekEnd)
+	}
+}
+
+func TestBuildAdminStatsNoActions(t *testing.T) {
+	stats := BuildAdminStats("reviewer@example.com", time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), nil)
+	if stats.EventsReviewed != 0 || stats.AverageReviewLatencyHours != 0 || stats.LastActionAt != nil {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+}
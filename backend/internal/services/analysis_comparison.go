@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sort"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// CompareSourceAnalyses computes how a source's analysis changed from one version to another,
+// e.g. after a site redesign
+func CompareSourceAnalyses(from, to *models.SourceAnalysis) *models.SourceAnalysisComparison {
+	comparison := &models.SourceAnalysisComparison{
+		SourceID:        to.SourceID,
+		FromVersion:     from.Version,
+		ToVersion:       to.Version,
+		FromCompletedAt: from.AnalysisCompletedAt,
+		ToCompletedAt:   to.AnalysisCompletedAt,
+
+		QualityScore:           models.NewNumberDelta(from.OverallQualityScore, to.OverallQualityScore),
+		ExtractionQualityScore: models.NewNumberDelta(from.ExtractionTestResults.QualityScore, to.ExtractionTestResults.QualityScore),
+		ItemsFound:             models.NewNumberDelta(float64(from.ExtractionTestResults.ItemsFound), float64(to.ExtractionTestResults.ItemsFound)),
+
+		SelectorChanges: compareSelectors(from.RecommendedConfig.BestSelectors, to.RecommendedConfig.BestSelectors),
+		FrequencyChange: models.FrequencyChange{
+			From:    from.RecommendedConfig.ScrapingFrequency,
+			To:      to.RecommendedConfig.ScrapingFrequency,
+			Changed: from.RecommendedConfig.ScrapingFrequency != to.RecommendedConfig.ScrapingFrequency,
+		},
+	}
+
+	fromPages := contentPageURLs(from.DiscoveredPatterns.ContentPages)
+	toPages := contentPageURLs(to.DiscoveredPatterns.ContentPages)
+	comparison.PagesAdded = missingFrom(toPages, fromPages)
+	comparison.PagesRemoved = missingFrom(fromPages, toPages)
+
+	return comparison
+}
+
+// compareSelectors lists the selectors whose value differs, in a stable field order
+func compareSelectors(from, to models.DataSelectors) []models.SelectorChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"date", from.Date, to.Date},
+		{"time", from.Time, to.Time},
+		{"description", from.Description, to.Description},
+		{"location", from.Location, to.Location},
+		{"venue", from.Venue, to.Venue},
+		{"price", from.Price, to.Price},
+		{"age_range", from.AgeRange, to.AgeRange},
+		{"category", from.Category, to.Category},
+		{"registration_url", from.RegistrationURL, to.RegistrationURL},
+		{"contact_info", from.ContactInfo, to.ContactInfo},
+		{"images", from.Images, to.Images},
+	}
+
+	changes := []models.SelectorChange{}
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, models.SelectorChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
+
+// contentPageURLs returns the set of discovered page URLs
+func contentPageURLs(pages []models.ContentPage) map[string]bool {
+	urls := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		urls[page.URL] = true
+	}
+	return urls
+}
+
+// missingFrom returns the sorted URLs in urls that are not in other
+func missingFrom(urls, other map[string]bool) []string {
+	missing := []string{}
+	for url := range urls {
+		if !other[url] {
+			missing = append(missing, url)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
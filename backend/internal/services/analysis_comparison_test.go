@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestCompareSourceAnalyses(t *testing.T) {
+	from := &models.SourceAnalysis{
+		SourceID:            "sct",
+		Version:             1,
+		OverallQualityScore: 0.8,
+		DiscoveredPatterns: models.DiscoveryPatterns{ContentPages: []models.ContentPage{
+			{URL: "https://sct.org/events"},
+			{URL: "https://sct.org/classes"},
+		}},
+		ExtractionTestResults: models.ExtractionTestResults{ItemsFound: 20, QualityScore: 0.9},
+		RecommendedConfig: models.RecommendedSourceConfig{
+			ScrapingFrequency: "daily",
+			BestSelectors:     models.DataSelectors{Title: "h2.event-title", Date: ".date"},
+		},
+	}
+	to := &models.SourceAnalysis{
+		SourceID:            "sct",
+		Version:             3,
+		OverallQualityScore: 0.5,
+		DiscoveredPatterns: models.DiscoveryPatterns{ContentPages: []models.ContentPage{
+			{URL: "https://sct.org/events"},
+			{URL: "https://sct.org/whats-on"},
+		}},
+		ExtractionTestResults: models.ExtractionTestResults{ItemsFound: 8, QualityScore: 0.6},
+		RecommendedConfig: models.RecommendedSourceConfig{
+			ScrapingFrequency: "weekly",
+			BestSelectors:     models.DataSelectors{Title: ".card h3", Date: ".date"},
+		},
+	}
+
+	comparison := CompareSourceAnalyses(from, to)
+
+	if comparison.FromVersion != 1 || comparison.ToVersion != 3 {
+		t.Errorf("Expected versions 1 and 3, got %d and %d", comparison.FromVersion, comparison.ToVersion)
+	}
+	if comparison.QualityScore.Change > -0.29 || comparison.QualityScore.Change < -0.31 {
+		t.Errorf("Expected quality score to drop by 0.3, got %v", comparison.QualityScore.Change)
+	}
+	if comparison.ItemsFound.Change != -12 {
+		t.Errorf("Expected 12 fewer items found, got %v", comparison.ItemsFound.Change)
+	}
+	if len(comparison.SelectorChanges) != 1 || comparison.SelectorChanges[0].Field != "title" || comparison.SelectorChanges[0].To != ".card h3" {
+		t.Errorf("Expected only the title selector to change, got %+v", comparison.SelectorChanges)
+	}
+	if len(comparison.PagesAdded) != 1 || comparison.PagesAdded[0] != "https://sct.org/whats-on" {
+		t.Errorf("Expected whats-on page to be added, got %v", comparison.PagesAdded)
+	}
+	if len(comparison.PagesRemoved) != 1 || comparison.PagesRemoved[0] != "https://sct.org/classes" {
+		t.Errorf("Expected classes page to be removed, got %v", comparison.PagesRemoved)
+	}
+	if !comparison.FrequencyChange.Changed || comparison.FrequencyChange.To != "weekly" {
+		t.Errorf("Expected frequency to change to weekly, got %+v", comparison.FrequencyChange)
+	}
+}
+
+func TestParseAnalysisVersion(t *testing.T) {
+	for _, value := range []string{"v3", "V3", "3"} {
+		if version, err := models.ParseAnalysisVersion(value); err != nil || version != 3 {
+			t.Errorf("Expected %q to parse as version 3, got %d (%v)", value, version, err)
+		}
+	}
+	for _, value := range []string{"", "v0", "latest"} {
+		if _, err := models.ParseAnalysisVersion(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+	if sk := models.CreateSourceAnalysisVersionSK(3); sk != "ANALYSIS#v0003" {
+		t.Errorf("Expected ANALYSIS#v0003, got %s", sk)
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// AnalysisReportData is the view model rendered into a source analysis report
+type AnalysisReportData struct {
+	Submission  *models.SourceSubmission
+	Analysis    *models.SourceAnalysis
+	GeneratedAt time.Time
+}
+
+// analysisReportTemplate renders a self-contained HTML report. The print stylesheet keeps
+// the layout intact when the report is saved as PDF from a browser.
+var analysisReportTemplate = template.Must(template.New("analysis_report").Funcs(template.FuncMap{
+	"percent": func(v float64) string {
+		return fmt.Sprintf("%.0f%%", v*100)
+	},
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "—"
+		}
+		return t.Format("January 2, 2006")
+	},
+	"yesno": func(b bool) string {
+		if b {
+			return "Yes"
+		}
+		return "No"
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Source Analysis Report – {{.Submission.SourceName}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; max-width: 880px; margin: 2em auto; padding: 0 1em; line-height: 1.45; }
+  h1 { margin-bottom: 0.2em; }
+  h2 { border-bottom: 2px solid #2b6cb0; padding-bottom: 0.2em; margin-top: 1.8em; color: #2b6cb0; }
+  .meta { color: #666; }
+  .score { font-size: 2.4em; font-weight: bold; color: #2b6cb0; }
+  table { border-collapse: collapse; width: 100%; margin: 0.8em 0; font-size: 0.92em; }
+  th, td { border: 1px solid #ddd; padding: 6px 8px; text-align: left; vertical-align: top; }
+  th { background: #f3f6fa; }
+  ul { padding-left: 1.3em; }
+  .issue { color: #b7312c; }
+  @media print { body { margin: 0; max-width: none; } h2 { page-break-after: avoid; } table { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Submission.SourceName}}</h1>
+<p class="meta">{{.Submission.BaseURL}} · {{.Submission.SourceType}} · analyzed {{date .Analysis.AnalysisCompletedAt}} · report generated {{date .GeneratedAt}}</p>
+
+<h2>Summary</h2>
+<p><span class="score">{{percent .Analysis.OverallQualityScore}}</span> overall quality score</p>
+<table>
+  <tr><th>Analysis status</th><td>{{.Analysis.Status}}</td></tr>
+  <tr><th>Priority</th><td>{{.Submission.Priority}}</td></tr>
+  <tr><th>Expected content</th><td>{{range $i, $c := .Submission.ExpectedContent}}{{if $i}}, {{end}}{{$c}}{{end}}</td></tr>
+  <tr><th>Submitted by</th><td>{{.Submission.SubmittedBy}} on {{date .Submission.SubmittedAt}}</td></tr>
+</table>
+
+<h2>Discovery</h2>
+{{with .Analysis.DiscoveredPatterns}}
+<table>
+  <tr><th>Sitemap found</th><td>{{yesno .SitemapFound}}{{if .SitemapURL}} ({{.SitemapURL}}){{end}}</td></tr>
+  <tr><th>RSS feeds</th><td>{{if .RSSFeeds}}{{range $i, $f := .RSSFeeds}}{{if $i}}, {{end}}{{$f}}{{end}}{{else}}None{{end}}</td></tr>
+  <tr><th>Structured data</th><td>{{yesno .StructuredDataFound}}{{if .SchemaTypes}} ({{range $i, $t := .SchemaTypes}}{{if $i}}, {{end}}{{$t}}{{end}}){{end}}</td></tr>
+</table>
+{{if .ContentPages}}
+<table>
+  <tr><th>Content page</th><th>Type</th><th>Confidence</th></tr>
+  {{range .ContentPages}}<tr><td>{{if .Title}}{{.Title}}<br>{{end}}{{.URL}}</td><td>{{.Type}}</td><td>{{percent .Confidence}}</td></tr>
+  {{end}}
+</table>
+{{else}}<p>No content pages were discovered.</p>{{end}}
+{{end}}
+
+<h2>Test Extraction</h2>
+{{with .Analysis.ExtractionTestResults}}
+<p>Tested <strong>{{.TestURL}}</strong>: {{.ItemsFound}} items found in {{.TestDuration}} ms, quality score {{percent .QualityScore}}.</p>
+<table>
+  <tr><th>Field</th><th>Completeness</th></tr>
+  <tr><td>Title</td><td>{{percent .Metrics.TitleCompleteness}}</td></tr>
+  <tr><td>Date</td><td>{{percent .Metrics.DateCompleteness}}</td></tr>
+  <tr><td>Description</td><td>{{percent .Metrics.DescriptionCompleteness}}</td></tr>
+  <tr><td>Location</td><td>{{percent .Metrics.LocationCompleteness}}</td></tr>
+  <tr><td>Price</td><td>{{percent .Metrics.PriceCompleteness}}</td></tr>
+  <tr><th>Overall</th><th>{{percent .Metrics.OverallCompleteness}}</th></tr>
+</table>
+{{if .SampleData}}
+<h3>Sample activities</h3>
+<table>
+  <tr><th>Title</th><th>Date / time</th><th>Location</th><th>Price</th><th>Ages</th></tr>
+  {{range .SampleData}}<tr><td>{{.Title}}</td><td>{{.Date}} {{.Time}}</td><td>{{.Location}}</td><td>{{.Price}}</td><td>{{.AgeRange}}</td></tr>
+  {{end}}
+</table>
+{{end}}
+{{if .Errors}}<h3>Errors</h3><ul>{{range .Errors}}<li class="issue">{{.}}</li>{{end}}</ul>{{end}}
+{{if .Warnings}}<h3>Warnings</h3><ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{end}}
+
+<h2>Recommendations</h2>
+{{with .Analysis.RecommendedConfig}}
+<table>
+  <tr><th>Scraping frequency</th><td>{{.ScrapingFrequency}}</td></tr>
+  <tr><th>Preferred extraction</th><td>{{.PreferredExtraction}}</td></tr>
+  <tr><th>Estimated items per scrape</th><td>{{.EstimatedItemsPerScrape}}</td></tr>
+  <tr><th>Rate limit</th><td>{{.RateLimit.RequestsPerMinute}} requests/minute, {{.RateLimit.ConcurrentRequests}} concurrent</td></tr>
+  <tr><th>Target URLs</th><td>{{range .TargetURLs}}{{.}}<br>{{end}}</td></tr>
+</table>
+{{end}}
+{{if .Analysis.Issues}}<h3>Issues</h3><ul>{{range .Analysis.Issues}}<li class="issue">{{.}}</li>{{end}}</ul>{{end}}
+{{if .Analysis.Recommendations}}<h3>Next steps</h3><ul>{{range .Analysis.Recommendations}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+// RenderAnalysisReport renders a source analysis as a standalone HTML document
+func RenderAnalysisReport(submission *models.SourceSubmission, analysis *models.SourceAnalysis) ([]byte, error) {
+	if submission == nil || analysis == nil {
+		return nil, fmt.Errorf("submission and analysis are required")
+	}
+
+	var buf bytes.Buffer
+	err := analysisReportTemplate.Execute(&buf, AnalysisReportData{
+		Submission:  submission,
+		Analysis:    analysis,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render analysis report: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
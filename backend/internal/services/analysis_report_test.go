@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestRenderAnalysisReport(t *testing.T) {
+	submission := &models.SourceSubmission{
+		SourceID:        "seattle-kids",
+		SourceName:      "Seattle Kids <Events>",
+		BaseURL:         "https://example.com",
+		SourceType:      models.SourceTypeEventOrganizer,
+		ExpectedContent: []string{"events", "classes"},
+	}
+	analysis := &models.SourceAnalysis{
+		SourceID:            "seattle-kids",
+		Status:              models.SourceStatusAnalysisComplete,
+		OverallQualityScore: 0.82,
+		ExtractionTestResults: models.ExtractionTestResults{
+			TestURL:    "https://example.com/events",
+			ItemsFound: 12,
+			SampleData: []models.ExtractedActivity{{Title: "Toddler Story Time", Date: "2025-03-01"}},
+		},
+		RecommendedConfig: models.RecommendedSourceConfig{
+			ScrapingFrequency: "weekly",
+			TargetURLs:        []string{"https://example.com/events"},
+		},
+		Recommendations: []string{"Scrape the events calendar weekly"},
+	}
+
+	report, err := RenderAnalysisReport(submission, analysis)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	html := string(report)
+	for _, want := range []string{"Seattle Kids &lt;Events&gt;", "82%", "Toddler Story Time", "weekly", "Scrape the events calendar weekly"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected report to contain %q", want)
+		}
+	}
+
+	if _, err := RenderAnalysisReport(submission, nil); err == nil {
+		t.Error("Expected error when analysis is missing")
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	t.Run("SignsAndUploadsReport", func(t *testing.T) {
+		var gotPath, gotAuth, gotType, gotBody, gotMD5 string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.EscapedPath()
+			gotAuth = r.Header.Get("Authorization")
+			gotType = r.Header.Get("Content-Type")
+			gotMD5 = r.Header.Get("Content-MD5")
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			sum := md5.Sum(body)
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "reports")
+		store.endpoint = server.URL
+
+		err := store.Put(context.Background(), "reports/seattle kids/report.html", []byte("<html></html>"), "text/html")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotPath != "/reports/seattle%20kids/report.html" {
+			t.Errorf("Unexpected object path: %s", gotPath)
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/s3/") || gotType != "text/html" || gotBody != "<html></html>" || gotMD5 == "" {
+			t.Errorf("Unexpected upload request: auth=%q type=%q body=%q md5=%q", gotAuth, gotType, gotBody, gotMD5)
+		}
+	})
+
+	t.Run("RetriesThrottledUploads", func(t *testing.T) {
+		s3RetryBaseDelay = time.Millisecond
+		defer func() { s3RetryBaseDelay = 500 * time.Millisecond }()
+
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			body, _ := io.ReadAll(r.Body)
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("<Error><Code>SlowDown</Code><Message>Please reduce your request rate.</Message></Error>"))
+				return
+			}
+			sum := md5.Sum(body)
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			w.Header().Set("X-Amz-Version-Id", "v3")
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "published")
+		store.endpoint = server.URL
+
+		object, err := store.PutObject(context.Background(), "activities/latest.json", []byte(`{"activities":[]}`), "application/json")
+		if err != nil {
+			t.Fatalf("Expected the upload to succeed after retries, got %v", err)
+		}
+		if object.Attempts != 3 || object.VersionID != "v3" || object.Size != 17 {
+			t.Errorf("Expected 3 attempts of a 17 byte object at version v3, got %+v", object)
+		}
+	})
+
+	t.Run("DoesNotRetryRejectedUploads", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>"))
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "published")
+		store.endpoint = server.URL
+
+		err := store.Put(context.Background(), "feeds/latest.rss", []byte("<rss/>"), "application/rss+xml")
+		var s3Err *S3Error
+		if !errors.As(err, &s3Err) || s3Err.Code != "AccessDenied" || requests != 1 {
+			t.Errorf("Expected one AccessDenied request, got %d requests and %v", requests, err)
+		}
+	})
+
+	t.Run("RejectsChecksumMismatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"0123456789abcdef0123456789abcdef"`)
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "published")
+		store.endpoint = server.URL
+
+		err := store.Put(context.Background(), "feeds/latest.rss", []byte("<rss/>"), "application/rss+xml")
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("Expected a checksum mismatch, got %v", err)
+		}
+	})
+
+	t.Run("UploadsLargeObjectsInParts", func(t *testing.T) {
+		var parts [][]byte
+		var completed string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			query := r.URL.Query()
+			switch {
+			case r.Method == http.MethodPost && query.Has("uploads"):
+				w.Write([]byte("<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>"))
+			case r.Method == http.MethodPut && query.Get("uploadId") == "upload-1":
+				parts = append(parts, body)
+				sum := md5.Sum(body)
+				w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			case r.Method == http.MethodPost && query.Get("uploadId") == "upload-1":
+				completed = string(body)
+				var sums []byte
+				for _, part := range parts {
+					sum := md5.Sum(part)
+					sums = append(sums, sum[:]...)
+				}
+				sum := md5.Sum(sums)
+				w.Write([]byte(fmt.Sprintf("<CompleteMultipartUploadResult><ETag>&quot;%s-%d&quot;</ETag></CompleteMultipartUploadResult>", hex.EncodeToString(sum[:]), len(parts))))
+			default:
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "published")
+		store.endpoint = server.URL
+		store.multipartThreshold = 10
+		store.partSize = 4
+
+		object, err := store.PutObject(context.Background(), "open-data/latest/activities.csv", []byte("id,title\n1,Story time\n"), "text/csv")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(parts) != 6 || string(bytes.Join(parts, nil)) != "id,title\n1,Story time\n" {
+			t.Errorf("Expected the body in 6 parts, got %q", parts)
+		}
+		if object.Parts != 6 || !strings.HasSuffix(object.ETag, "-6") || object.Attempts != 8 {
+			t.Errorf("Expected a 6 part object from 8 requests, got %+v", object)
+		}
+		if !strings.Contains(completed, "<PartNumber>6</PartNumber>") {
+			t.Errorf("Expected every part to be completed, got %s", completed)
+		}
+	})
+
+	t.Run("AbortsFailedMultipartUploads", func(t *testing.T) {
+		aborted := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				w.Write([]byte("<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>"))
+			case http.MethodDelete:
+				aborted = r.URL.Query().Get("uploadId") == "upload-1"
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("<Error><Code>InvalidPart</Code></Error>"))
+			}
+		}))
+		defer server.Close()
+
+		store := NewS3Store(testAWSConfig(), "published")
+		store.endpoint = server.URL
+		store.multipartThreshold = 10
+		store.partSize = 4
+
+		if _, err := store.PutObject(context.Background(), "open-data/latest/activities.csv", []byte("0123456789abc"), "text/csv"); err == nil {
+			t.Fatal("Expected the upload to fail")
+		}
+		if !aborted {
+			t.Error("Expected the multipart upload to be aborted")
+		}
+	})
+
+	t.Run("PresignsDownloadURL", func(t *testing.T) {
+		store := NewS3Store(testAWSConfig(), "reports")
+
+		signedURL, err := store.PresignGet(context.Background(), "reports/a.html", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		parsed, err := url.Parse(signedURL)
+		if err != nil {
+			t.Fatalf("Invalid presigned URL: %v", err)
+		}
+		if parsed.Host != "reports.s3.us-west-2.amazonaws.com" {
+			t.Errorf("Unexpected host: %s", parsed.Host)
+		}
+		query := parsed.Query()
+		if query.Get("X-Amz-Expires") != "86400" || query.Get("X-Amz-Signature") == "" {
+			t.Errorf("Expected expiring signed URL, got %s", signedURL)
+		}
+	})
+}
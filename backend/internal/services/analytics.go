@@ -0,0 +1,108 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// BuildSourceAnalytics summarizes sources and the fan-out runs started from one time to another.
+// Approvals only count the sources submitted in the range, and scraping only counts runs that
+// have finished, since a run's per-source stats are aggregated when its last task reports.
+func BuildSourceAnalytics(sources []models.SourceSubmission, runs []models.FanOutRun, from, to, now time.Time) *models.SourceAnalytics {
+	analytics := &models.SourceAnalytics{
+		From:            from,
+		To:              to,
+		SourcesByStatus: make(map[string]int),
+		SourceYield:     []models.SourceYield{},
+		GeneratedAt:     now,
+	}
+
+	for _, source := range sources {
+		analytics.TotalSourcesSubmitted++
+		analytics.SourcesByStatus[source.Status]++
+
+		approved := false
+		switch source.Status {
+		case models.SourceStatusPendingAnalysis, models.SourceStatusAnalysisComplete:
+			analytics.SourcesPendingAnalysis++
+		case models.SourceStatusActive:
+			analytics.SourcesActive++
+			approved = true
+		case models.SourceStatusPaused, models.SourceStatusInactive:
+			approved = true
+		case models.SourceStatusRejected:
+			analytics.SourcesRejected++
+		}
+
+		if source.SubmittedAt.Before(from) || !source.SubmittedAt.Before(to) {
+			continue
+		}
+		analytics.Approvals.Submitted++
+		switch {
+		case approved:
+			analytics.Approvals.Approved++
+		case source.Status == models.SourceStatusRejected:
+			analytics.Approvals.Rejected++
+		}
+	}
+	if decided := analytics.Approvals.Approved + analytics.Approvals.Rejected; decided > 0 {
+		analytics.Approvals.ApprovalRate = roundRate(float64(analytics.Approvals.Approved) / float64(decided))
+	}
+
+	yields := make(map[string]*models.SourceYield)
+	for _, run := range runs {
+		if run.Stats == nil {
+			analytics.Scraping.RunsInProgress++
+			continue
+		}
+		analytics.Scraping.Runs++
+		analytics.Scraping.CreditsUsed += run.CreditsUsed
+
+		for sourceID, stats := range run.Stats.Sources {
+			analytics.Scraping.Tasks += stats.Tasks
+			analytics.Scraping.FailedTasks += stats.FailedTasks
+			analytics.Scraping.ActivitiesFound += stats.ActivitiesFound
+
+			yield, ok := yields[sourceID]
+			if !ok {
+				yield = &models.SourceYield{SourceID: sourceID}
+				yields[sourceID] = yield
+			}
+			if stats.SourceName != "" {
+				yield.SourceName = stats.SourceName
+			}
+			yield.Runs++
+			yield.Tasks += stats.Tasks
+			yield.FailedTasks += stats.FailedTasks
+			yield.ActivitiesFound += stats.ActivitiesFound
+		}
+	}
+
+	analytics.TotalActivities = analytics.Scraping.ActivitiesFound
+	analytics.SuccessRate = "n/a"
+	if analytics.Scraping.Tasks > 0 {
+		rate := float64(analytics.Scraping.Tasks-analytics.Scraping.FailedTasks) / float64(analytics.Scraping.Tasks)
+		analytics.Scraping.SuccessRate = roundRate(rate)
+		analytics.SuccessRate = fmt.Sprintf("%.0f%%", rate*100)
+	}
+
+	for _, yield := range yields {
+		if yield.Tasks > 0 {
+			yield.SuccessRate = roundRate(float64(yield.Tasks-yield.FailedTasks) / float64(yield.Tasks))
+		}
+		yield.ActivitiesPerRun = roundRate(float64(yield.ActivitiesFound) / float64(yield.Runs))
+		analytics.SourceYield = append(analytics.SourceYield, *yield)
+	}
+	sort.Slice(analytics.SourceYield, func(i, j int) bool {
+		a, b := analytics.SourceYield[i], analytics.SourceYield[j]
+		if a.ActivitiesFound != b.ActivitiesFound {
+			return a.ActivitiesFound > b.ActivitiesFound
+		}
+		return a.SourceID < b.SourceID
+	})
+
+	return analytics
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildSourceAnalytics(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -7)
+	inRange := now.AddDate(0, 0, -2)
+	before := from.Add(-time.Hour)
+
+	sources := []models.SourceSubmission{
+		{SourceID: "src-a", Status: models.SourceStatusActive, SubmittedAt: inRange},
+		{SourceID: "src-b", Status: models.SourceStatusPaused, SubmittedAt: inRange},
+		{SourceID: "src-c", Status: models.SourceStatusRejected, SubmittedAt: inRange},
+		{SourceID: "src-d", Status: models.SourceStatusPendingAnalysis, SubmittedAt: inRange},
+		{SourceID: "src-e", Status: models.SourceStatusAnalysisComplete, SubmittedAt: before},
+		{SourceID: "src-f", Status: models.SourceStatusRejected, SubmittedAt: before},
+		{SourceID: "src-g", Status: models.SourceStatusActive, SubmittedAt: before},
+	}
+	runs := []models.FanOutRun{
+		{CreditsUsed: 12, Stats: &models.FanOutRunStats{Sources: map[string]models.FanOutSourceStats{
+			"src-a": {SourceName: "Seattle Parks", Tasks: 4, FailedTasks: 1, ActivitiesFound: 20},
+			"src-g": {SourceName: "Library", Tasks: 2, FailedTasks: 2},
+		}}},
+		{CreditsUsed: 8, Stats: &models.FanOutRunStats{Sources: map[string]models.FanOutSourceStats{
+			"src-a": {SourceName: "Seattle Parks", Tasks: 4, ActivitiesFound: 10},
+			"src-g": {SourceName: "Library", Tasks: 2, ActivitiesFound: 6},
+		}}},
+		{}, // still running
+	}
+
+	analytics := BuildSourceAnalytics(sources, runs, from, now, now)
+
+	if analytics.TotalSourcesSubmitted != 7 || analytics.SourcesActive != 2 || analytics.SourcesRejected != 2 || analytics.SourcesPendingAnalysis != 2 {
+		t.Errorf("Unexpected source counts: %+v", analytics)
+	}
+	if analytics.SourcesByStatus[models.SourceStatusPaused] != 1 {
+		t.Errorf("Expected 1 paused source, got %v", analytics.SourcesByStatus)
+	}
+
+	approvals := analytics.Approvals
+	if approvals.Submitted != 4 || approvals.Approved != 2 || approvals.Rejected != 1 {
+		t.Errorf("Expected 2 approved and 1 rejected of 4 submitted in range, got %+v", approvals)
+	}
+	if approvals.ApprovalRate != 0.667 {
+		t.Errorf("Expected approval rate 0.667, got %v", approvals.ApprovalRate)
+	}
+
+	scraping := analytics.Scraping
+	if scraping.Runs != 2 || scraping.RunsInProgress != 1 || scraping.Tasks != 12 || scraping.FailedTasks != 3 {
+		t.Errorf("Unexpected scrape counts: %+v", scraping)
+	}
+	if scraping.SuccessRate != 0.75 || analytics.SuccessRate != "75%" {
+		t.Errorf("Expected 75%% scrape success, got %v and %q", scraping.SuccessRate, analytics.SuccessRate)
+	}
+	if scraping.ActivitiesFound != 36 || analytics.TotalActivities != 36 || scraping.CreditsUsed != 20 {
+		t.Errorf("Expected 36 activities for 20 credits, got %+v", scraping)
+	}
+
+	if len(analytics.SourceYield) != 2 {
+		t.Fatalf("Expected yield for 2 sources, got %+v", analytics.SourceYield)
+	}
+	top := analytics.SourceYield[0]
+	if top.SourceID != "src-a" || top.SourceName != "Seattle Parks" || top.Runs != 2 || top.ActivitiesFound != 30 {
+		t.Errorf("Expected src-a to yield the most activities, got %+v", top)
+	}
+	if top.SuccessRate != 0.875 || top.ActivitiesPerRun != 15 {
+		t.Errorf("Expected src-a to succeed 87.5%% with 15 activities per run, got %+v", top)
+	}
+	if library := analytics.SourceYield[1]; library.SuccessRate != 0.5 || library.ActivitiesPerRun != 3 {
+		t.Errorf("Expected src-g to succeed 50%% with 3 activities per run, got %+v", library)
+	}
+}
+
+func TestBuildSourceAnalyticsEmpty(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	analytics := BuildSourceAnalytics(nil, nil, now.AddDate(0, 0, -7), now, now)
+
+	if analytics.SuccessRate != "n/a" || analytics.Approvals.ApprovalRate != 0 {
+		t.Errorf("Expected no rates without data, got %+v", analytics)
+	}
+	if analytics.SourceYield == nil || analytics.SourcesByStatus == nil {
+		t.Error("Expected empty yield and status counts rather than nil")
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+var (
+	parkingSentencePattern  = regexp.MustCompile(`\bparking\b|\bpark (?:on|in|at|along|behind|across) the\b|\bgarage\b|\bvalet\b|\bmetered\b`)
+	entranceSentencePattern = regexp.MustCompile(`\benter (?:through|via|at|from|by|on|using)\b|\bentrances?\b|\bcheck[\s-]?in (?:at|is)\b|\b(?:front|back|rear|side|main|north|south|east|west) (?:door|gate)s?\b|\bdrop[\s-]?off (?:loop|zone|area|lane)\b|\bmeet (?:at|in) the\b`)
+
+	noParkingPattern     = regexp.MustCompile(`\bno (?:on[\s-]?site |public |visitor |guest )?parking\b|\bparking (?:is )?(?:not available|unavailable)\b`)
+	streetOnlyPattern    = regexp.MustCompile(`\bstreet parking only\b|\bonly street parking\b|\bparking is (?:on[\s-]street|street) only\b`)
+	paidParkingPattern   = regexp.MustCompile(`\bpaid (?:parking|lot|garage)\b|\bpay (?:to park|for parking|parking)\b|\bparking (?:is |costs |fee:? )?\$|\$\d+(?:\.\d{2})? (?:parking|to park)\b|\bparking fees?\b|\bmetered\b`)
+	freeParkingPattern   = regexp.MustCompile(`\bfree (?:on[\s-]?site |visitor |guest )?(?:parking|lot|garage)\b|\bparking is free\b|\bfree to park\b`)
+	streetParkingPattern = regexp.MustCompile(`\bstreet parking\b|\bpark(?:ing)? on the street\b|\bon[\s-]street parking\b`)
+)
+
+// arrivalFields are the event data fields that only hold parking and arrival notes
+var arrivalFields = []string{"arrival_info", "parking", "directions", "getting_there", "getting_here"}
+
+// extractArrivalInfo reads parking and arrival notes from event data. An admin edit can set
+// arrival_info to an object with parking, entrance and notes, which is taken as given. Otherwise
+// the notes come from the arrival fields and the parking and entrance sentences of the description.
+func (scs *SchemaConversionService) extractArrivalInfo(eventData map[string]interface{}) (*models.ArrivalInfo, []string) {
+	if edited, ok := eventData["arrival_info"].(map[string]interface{}); ok {
+		info := &models.ArrivalInfo{
+			Parking:  scs.extractStringWithFallbacks(edited, []string{"parking"}),
+			Entrance: scs.extractStringWithFallbacks(edited, []string{"entrance"}),
+		}
+		if notes, ok := edited["notes"].([]interface{}); ok {
+			for _, note := range notes {
+				if text, ok := note.(string); ok {
+					info.Notes = append(info.Notes, text)
+				}
+			}
+		}
+		if err := info.Validate(); err != nil {
+			return nil, []string{"Invalid arrival_info: " + err.Error()}
+		}
+		if info.IsEmpty() {
+			return nil, nil
+		}
+		return info, nil
+	}
+
+	var given []string
+	for _, field := range arrivalFields {
+		if text := scs.extractStringWithFallbacks(eventData, []string{field}); text != "" {
+			given = append(given, splitSentences(text)...)
+		}
+	}
+	description := scs.extractStringWithFallbacks(eventData, []string{"description"})
+	return arrivalInfoFromSentences(given, splitSentences(description)), nil
+}
+
+// ParseArrivalInfo reads parking and arrival notes from listing text, returning nil when it has none.
+// Only the sentences about parking and entrances are kept.
+func ParseArrivalInfo(text string) *models.ArrivalInfo {
+	return arrivalInfoFromSentences(nil, splitSentences(text))
+}
+
+// arrivalInfoFromSentences builds arrival info from sentences known to be arrival notes and from
+// sentences of other text, which are only kept when they are about parking or entrances. The
+// first sentence about an entrance becomes the entrance; the rest become notes.
+func arrivalInfoFromSentences(given, other []string) *models.ArrivalInfo {
+	info := &models.ArrivalInfo{}
+	var parkingText []string
+	seen := make(map[string]bool)
+	for i, sentence := range append(given, other...) {
+		lower := strings.ToLower(sentence)
+		isParking := parkingSentencePattern.MatchString(lower)
+		isEntrance := entranceSentencePattern.MatchString(lower)
+		if i >= len(given) && !isParking && !isEntrance {
+			continue
+		}
+		if seen[lower] {
+			continue
+		}
+		seen[lower] = true
+
+		if isParking || i < len(given) {
+			parkingText = append(parkingText, lower)
+		}
+		if runes := []rune(sentence); len(runes) > models.MaxArrivalNoteLength {
+			sentence = strings.TrimSpace(string(runes[:models.MaxArrivalNoteLength-3])) + "..."
+		}
+		switch {
+		case isEntrance && !isParking && info.Entrance == "":
+			info.Entrance = sentence
+		case len(info.Notes) < models.MaxArrivalNotes:
+			info.Notes = append(info.Notes, sentence)
+		}
+	}
+	info.Parking = classifyParking(strings.Join(parkingText, " "))
+
+	if info.IsEmpty() {
+		return nil
+	}
+	return info
+}
+
+// classifyParking reads lowercase text about parking for the kind of parking a venue has. No
+// parking wins, then street parking only, paid and free parking. It returns "" when the text gives
+// no signal.
+func classifyParking(text string) string {
+	switch {
+	case noParkingPattern.MatchString(text):
+		return models.ParkingNone
+	case streetOnlyPattern.MatchString(text):
+		return models.ParkingStreet
+	case paidParkingPattern.MatchString(text):
+		return models.ParkingPaid
+	case freeParkingPattern.MatchString(text):
+		return models.ParkingFree
+	case streetParkingPattern.MatchString(text):
+		return models.ParkingStreet
+	default:
+		return ""
+	}
+}
+
+// splitSentences splits text into trimmed sentences at line breaks and sentence-ending punctuation
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		start := 0
+		for i := 0; i < len(line); i++ {
+			if line[i] != '.' && line[i] != '!' && line[i] != '?' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] != ' ' {
+				continue
+			}
+			if sentence := trimSentence(line[start : i+1]); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			start = i + 1
+		}
+		if sentence := trimSentence(line[start:]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// trimSentence trims the whitespace and any list bullet around a sentence
+func trimSentence(sentence string) string {
+	return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(sentence), "-*•"))
+}
+
+// MergeArrivalInfo fills the parts of an activity's arrival info it lacks from its venue's
+func MergeArrivalInfo(info, venue *models.ArrivalInfo) *models.ArrivalInfo {
+	if venue.IsEmpty() {
+		return info
+	}
+	if info.IsEmpty() {
+		merged := *venue
+		merged.Notes = append([]string(nil), venue.Notes...)
+		return &merged
+	}
+
+	merged := *info
+	if merged.Parking == "" {
+		merged.Parking = venue.Parking
+	}
+	if merged.Entrance == "" {
+		merged.Entrance = venue.Entrance
+	}
+	if len(merged.Notes) == 0 {
+		merged.Notes = append([]string(nil), venue.Notes...)
+	}
+	return &merged
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestParseArrivalInfo(t *testing.T) {
+	info := ParseArrivalInfo("Join us for stories and songs! Street parking only. Please enter via the north gate on 5th Ave.\n- Strollers welcome")
+	if info == nil {
+		t.Fatal("Expected arrival info")
+	}
+	if info.Parking != models.ParkingStreet {
+		t.Errorf("Expected street parking, got %q", info.Parking)
+	}
+	if info.Entrance != "Please enter via the north gate on 5th Ave." {
+		t.Errorf("Unexpected entrance %q", info.Entrance)
+	}
+	if len(info.Notes) != 1 || info.Notes[0] != "Street parking only." {
+		t.Errorf("Unexpected notes %v", info.Notes)
+	}
+
+	if info := ParseArrivalInfo("A morning at the park with music and crafts."); info != nil {
+		t.Errorf("Expected no arrival info, got %+v", info)
+	}
+}
+
+func TestClassifyParking(t *testing.T) {
+	tests := map[string]string{
+		"free parking in the lot behind the building":      models.ParkingFree,
+		"parking is $5 in the garage":                      models.ParkingPaid,
+		"metered street parking nearby":                    models.ParkingPaid,
+		"street parking only, the lot is closed":           models.ParkingStreet,
+		"there is no on-site parking; free lot two blocks": models.ParkingNone,
+		"plenty of parking":                                "",
+	}
+	for text, want := range tests {
+		if got := classifyParking(text); got != want {
+			t.Errorf("classifyParking(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestExtractArrivalInfo(t *testing.T) {
+	scs := NewSchemaConversionService()
+
+	// Arrival fields are notes even when they do not mention parking or entrances
+	info, issues := scs.extractArrivalInfo(map[string]interface{}{
+		"parking":     "Free lot on site",
+		"directions":  "Take the stairs down to the lower level.",
+		"description": "Check in at the front desk. Bring water.",
+	})
+	if len(issues) != 0 || info == nil {
+		t.Fatalf("Expected arrival info without issues, got %+v (%v)", info, issues)
+	}
+	if info.Parking != models.ParkingFree || info.Entrance != "Check in at the front desk." || len(info.Notes) != 2 {
+		t.Errorf("Unexpected arrival info %+v", info)
+	}
+
+	// An admin edit is taken as given
+	info, issues = scs.extractArrivalInfo(map[string]interface{}{
+		"arrival_info": map[string]interface{}{"parking": "paid", "notes": []interface{}{" Use the Pine St garage ", ""}},
+		"description":  "Street parking only.",
+	})
+	if len(issues) != 0 || info.Parking != models.ParkingPaid || len(info.Notes) != 1 || info.Notes[0] != "Use the Pine St garage" {
+		t.Errorf("Expected the edited arrival info, got %+v (%v)", info, issues)
+	}
+
+	_, issues = scs.extractArrivalInfo(map[string]interface{}{"arrival_info": map[string]interface{}{"parking": "valet"}})
+	if len(issues) != 1 || !strings.Contains(issues[0], "parking") {
+		t.Errorf("Expected an issue for an unknown parking value, got %v", issues)
+	}
+}
+
+func TestConvertToActivityArrivalInfo(t *testing.T) {
+	scs := NewSchemaConversionService()
+	result, err := scs.ConvertToActivity(&models.AdminEvent{
+		EventID:    "arrival",
+		SourceURL:  "https://example.com/events",
+		SchemaType: "events",
+		RawExtractedData: map[string]interface{}{
+			"events": []interface{}{map[string]interface{}{
+				"title":        "Toddler Time",
+				"description":  "Songs and stories for toddlers.",
+				"date":         "2025-06-14",
+				"location":     "Fremont Library",
+				"address":      "731 N 35th St, Seattle, WA",
+				"arrival_info": "Enter through the side door. No parking on site.",
+			}},
+		},
+	})
+	if err != nil || result.Activity == nil {
+		t.Fatalf("Unexpected conversion error: %v", err)
+	}
+	info := result.Activity.ArrivalInfo
+	if info == nil || info.Parking != models.ParkingNone || info.Entrance != "Enter through the side door." {
+		t.Errorf("Unexpected arrival info %+v", info)
+	}
+}
+
+func TestMergeArrivalInfo(t *testing.T) {
+	venue := &models.ArrivalInfo{Parking: models.ParkingFree, Entrance: "Main entrance faces the park.", Notes: []string{"Bike racks by the door."}}
+
+	merged := MergeArrivalInfo(&models.ArrivalInfo{Parking: models.ParkingPaid}, venue)
+	if merged.Parking != models.ParkingPaid || merged.Entrance != venue.Entrance || len(merged.Notes) != 1 {
+		t.Errorf("Expected the venue to fill the gaps, got %+v", merged)
+	}
+	if merged := MergeArrivalInfo(nil, venue); merged == venue || merged.Parking != models.ParkingFree {
+		t.Errorf("Expected a copy of the venue arrival info, got %+v", merged)
+	}
+	if merged := MergeArrivalInfo(nil, nil); merged != nil {
+		t.Errorf("Expected no arrival info, got %+v", merged)
+	}
+
+	venues := []models.Venue{{VenueName: "Fremont Library", ArrivalInfo: venue}, {VenueName: "Gas Works Park"}}
+	venues[0].EntityID, venues[1].EntityID = "fremont", "gas-works"
+	registry := NewVenueRegistry(venues)
+	if registry.ArrivalInfo(models.Location{Name: "Fremont Library"}) != venue {
+		t.Error("Expected the library's arrival info from the registry")
+	}
+	if registry.ArrivalInfo(models.Location{Name: "Gas Works Park"}) != nil {
+		t.Error("Expected no arrival info for a venue without it")
+	}
+}
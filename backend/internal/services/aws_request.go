@@ -0,0 +1,121 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// maxAWSResponseSize bounds how much of an AWS API response is read
+const maxAWSResponseSize = 1 << 20
+
+// maxAWSErrorBodySize bounds how much of an error response is kept in the error message
+const maxAWSErrorBodySize = 512
+
+// awsRequester signs requests with SigV4 and sends them to AWS APIs that are called over plain
+// HTTP rather than through an SDK client
+type awsRequester struct {
+	service     string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+}
+
+// newAWSRequester creates a requester that signs for the service in the region
+func newAWSRequester(cfg aws.Config, service, region string, timeout time.Duration) *awsRequester {
+	return &awsRequester{
+		service:     service,
+		region:      region,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient:  &http.Client{Timeout: timeout},
+	}
+}
+
+// awsStatusError is returned when an AWS API answers with an error status
+type awsStatusError struct {
+	operation  string
+	StatusCode int
+	Body       string // the start of the response body
+}
+
+func (e *awsStatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.operation, e.StatusCode, e.Body)
+}
+
+// post signs the payload and posts it to the endpoint with the given headers, returning the
+// response body. operation names the call in errors.
+func (r *awsRequester) post(ctx context.Context, operation, endpoint string, headers map[string]string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", operation, err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	credentials, err := r.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	err = r.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), r.service, r.region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign %s request: %w", operation, err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAWSResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", operation, err)
+	}
+	if resp.StatusCode >= 300 {
+		if len(body) > maxAWSErrorBodySize {
+			body = body[:maxAWSErrorBodySize]
+		}
+		return nil, &awsStatusError{operation: operation, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return body, nil
+}
+
+// callJSON sends a single AWS JSON 1.0 protocol request, where the action is named by the target
+// header, and decodes the response into output unless output is nil
+func (r *awsRequester) callJSON(ctx context.Context, endpoint, targetPrefix, action string, input interface{}, output interface{}) error {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", action, err)
+	}
+
+	body, err := r.post(ctx, action, endpoint, map[string]string{
+		"Content-Type": "application/x-amz-json-1.0",
+		"X-Amz-Target": targetPrefix + "." + action,
+	}, payload)
+	if err != nil {
+		return err
+	}
+
+	if output != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, output); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", action, err)
+		}
+	}
+
+	return nil
+}
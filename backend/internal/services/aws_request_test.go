@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAWSRequesterPost(t *testing.T) {
+	t.Run("SignsForServiceAndRegion", func(t *testing.T) {
+		var gotAuth, gotContentType, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			gotContentType = r.Header.Get("Content-Type")
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		requester := newAWSRequester(testAWSConfig(), "cloudfront", "us-east-1", time.Second)
+		body, err := requester.post(context.Background(), "invalidation", server.URL, map[string]string{"Content-Type": "application/xml"}, []byte("<x/>"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if string(body) != "ok" || gotBody != "<x/>" || gotContentType != "application/xml" {
+			t.Errorf("Unexpected exchange: sent %q as %q, got back %q", gotBody, gotContentType, body)
+		}
+		if !strings.Contains(gotAuth, "/us-east-1/cloudfront/") {
+			t.Errorf("Expected SigV4 authorization for cloudfront in us-east-1, got %q", gotAuth)
+		}
+	})
+
+	t.Run("ReturnsStatusError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(strings.Repeat("x", 2*maxAWSErrorBodySize)))
+		}))
+		defer server.Close()
+
+		requester := newAWSRequester(testAWSConfig(), "execute-api", "us-west-2", time.Second)
+		_, err := requester.post(context.Background(), "post to connection", server.URL, nil, []byte("{}"))
+
+		var statusErr *awsStatusError
+		if !errors.As(err, &statusErr) {
+			t.Fatalf("Expected a status error, got %v", err)
+		}
+		if statusErr.StatusCode != http.StatusGone || len(statusErr.Body) != maxAWSErrorBodySize {
+			t.Errorf("Expected status 410 with the body cut to %d bytes, got %d with %d bytes", maxAWSErrorBodySize, statusErr.StatusCode, len(statusErr.Body))
+		}
+		if !strings.HasPrefix(err.Error(), "post to connection returned status 410") {
+			t.Errorf("Unexpected error message %q", err.Error())
+		}
+	})
+}
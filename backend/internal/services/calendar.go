@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxCalendarDays bounds how many days a single activity is expanded into, so a
+// season-long program does not fill the calendar for a year
+const maxCalendarDays = 120
+
+// CalendarEntriesForActivity expands an activity's schedule into one calendar entry per day it
+// occurs on. Multi-day and recurring activities cover every day from the start date to the end
+// date, limited to their days of the week when those are listed. Activities without a parseable
+// start date have no entries. venueID links the entries to a registry venue and may be empty.
+func CalendarEntriesForActivity(activity *models.Activity, venueID string) []models.CalendarEntry {
+	schedule := activity.Schedule
+	start, err := time.Parse("2006-01-02", schedule.StartDate)
+	if err != nil {
+		return nil
+	}
+	end := start
+	if endDate, err := time.Parse("2006-01-02", schedule.EndDate); err == nil && endDate.After(start) {
+		end = endDate
+	}
+	if limit := start.AddDate(0, 0, maxCalendarDays-1); end.After(limit) {
+		end = limit
+	}
+
+	weekdays := scheduleWeekdays(schedule)
+
+	var entries []models.CalendarEntry
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if len(weekdays) > 0 && !weekdays[strings.ToLower(day.Weekday().String())] {
+			continue
+		}
+		date := day.Format("2006-01-02")
+		entry := models.CalendarEntry{
+			PK:               models.CreateEventPK(activity.ID),
+			SK:               models.CreateInstanceSK(date, schedule.StartTime),
+			ActivityID:       activity.ID,
+			Date:             date,
+			StartTime:        schedule.StartTime,
+			EndTime:          schedule.EndTime,
+			IsAllDay:         schedule.IsAllDay,
+			Title:            activity.Title,
+			Type:             activity.Type,
+			Category:         activity.Category,
+			LocationName:     activity.Location.Name,
+			PricingType:      activity.Pricing.Type,
+			Featured:         activity.Featured,
+			CalendarMonthKey: models.GenerateCalendarMonthKey(day.Format("2006-01")),
+			DateTypeKey:      models.GenerateDateTypeKey(date, activity.Type, activity.ID),
+		}
+		if venueID != "" {
+			entry.VenueID = venueID
+			entry.VenueKey = models.GenerateVenueKey(venueID)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ExpandOccurrences returns one item per day each activity occurs on between from and to (inclusive),
+// so multi-day and recurring activities show up on every day of a date range query rather than only
+// their first. Each item carries that day's schedule and an occurrence reference. Ongoing activities
+// without an end date, and undated ongoing or weekday-recurring ones, occur on every day of the range.
+// Items are ordered by date, then start time.
+func ExpandOccurrences(activities []*models.PublicActivity, from, to time.Time) []*models.PublicActivity {
+	expanded := []*models.PublicActivity{}
+	for _, activity := range activities {
+		schedule := activity.Schedule
+		start, err := time.Parse("2006-01-02", schedule.StartDate)
+		end := start
+		dated := err == nil
+		switch {
+		case dated:
+			if endDate, err := time.Parse("2006-01-02", schedule.EndDate); err == nil && endDate.After(start) {
+				end = endDate
+			} else if schedule.Type == models.ScheduleTypeOngoing && schedule.EndDate == "" {
+				end = to
+			}
+		case schedule.Type == models.ScheduleTypeOngoing || (schedule.Type == models.ScheduleTypeRecurring && len(schedule.DaysOfWeek) > 0):
+			start, end = from, to
+		default:
+			continue
+		}
+		totalDays := int(end.Sub(start).Hours()/24) + 1
+
+		weekdays := scheduleWeekdays(schedule)
+		first := start
+		if first.Before(from) {
+			first = from
+		}
+		for day := first; !day.After(end) && !day.After(to); day = day.AddDate(0, 0, 1) {
+			if len(weekdays) > 0 && !weekdays[strings.ToLower(day.Weekday().String())] {
+				continue
+			}
+			date := day.Format("2006-01-02")
+			occurrence := *activity
+			occurrence.Schedule.StartDate = date
+			occurrence.Schedule.EndDate = date
+			occurrence.Occurrence = &models.ActivityOccurrence{ID: activity.ID + "#" + date, Date: date}
+			if dated && totalDays > 1 && schedule.Type != models.ScheduleTypeOngoing {
+				occurrence.Occurrence.Day = int(day.Sub(start).Hours()/24) + 1
+				occurrence.Occurrence.Days = totalDays
+			}
+			expanded = append(expanded, &occurrence)
+		}
+	}
+
+	sort.SliceStable(expanded, func(i, j int) bool {
+		if expanded[i].Occurrence.Date != expanded[j].Occurrence.Date {
+			return expanded[i].Occurrence.Date < expanded[j].Occurrence.Date
+		}
+		return expanded[i].Schedule.StartTime < expanded[j].Schedule.StartTime
+	})
+	return expanded
+}
+
+// scheduleWeekdays returns the lowercased days of the week a schedule is limited to, or an empty set for every day
+func scheduleWeekdays(schedule models.Schedule) map[string]bool {
+	weekdays := make(map[string]bool)
+	for _, day := range schedule.DaysOfWeek {
+		weekdays[strings.ToLower(strings.TrimSpace(day))] = true
+	}
+	return weekdays
+}
+
+// BuildCalendarMonth buckets a month's calendar entries by day. Days are listed in order and
+// include days with no activities; items within a day are ordered by start time, all-day first.
+func BuildCalendarMonth(month string, entries []models.CalendarEntry) (*models.CalendarMonth, error) {
+	first, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q: must be YYYY-MM", month)
+	}
+
+	byDate := make(map[string][]models.CalendarEntry)
+	for _, entry := range entries {
+		byDate[entry.Date] = append(byDate[entry.Date], entry)
+	}
+
+	calendar := &models.CalendarMonth{Month: month, Days: []models.CalendarDay{}}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		items := byDate[date]
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].StartTime != items[j].StartTime {
+				return items[i].StartTime < items[j].StartTime
+			}
+			return items[i].Title < items[j].Title
+		})
+		if items == nil {
+			items = []models.CalendarEntry{}
+		}
+
+		calendar.Days = append(calendar.Days, models.CalendarDay{Date: date, Count: len(items), Items: items})
+		calendar.Total += len(items)
+	}
+	return calendar, nil
+}
+
+// BuildVenueSchedule groups a venue's calendar entries between from and to (inclusive) into weeks
+// starting on Monday. Only weeks with activities are listed.
+func BuildVenueSchedule(venue *models.Venue, from, to time.Time, entries []models.CalendarEntry) *models.VenueSchedule {
+	schedule := &models.VenueSchedule{
+		VenueID:   venue.EntityID,
+		VenueName: venue.VenueName,
+		Address:   venue.Address,
+		Transit:   venue.Transit,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Weeks:     []models.CalendarWeek{},
+	}
+	if schedule.VenueName == "" {
+		schedule.VenueName = venue.Name
+	}
+
+	sorted := append([]models.CalendarEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Date != sorted[j].Date {
+			return sorted[i].Date < sorted[j].Date
+		}
+		if sorted[i].StartTime != sorted[j].StartTime {
+			return sorted[i].StartTime < sorted[j].StartTime
+		}
+		return sorted[i].Title < sorted[j].Title
+	})
+
+	for _, entry := range sorted {
+		date, err := time.Parse("2006-01-02", entry.Date)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+		// Weeks start on Monday; Go's weekdays start on Sunday
+		weekStart := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7)).Format("2006-01-02")
+
+		if len(schedule.Weeks) == 0 || schedule.Weeks[len(schedule.Weeks)-1].WeekStart != weekStart {
+			schedule.Weeks = append(schedule.Weeks, models.CalendarWeek{WeekStart: weekStart, ByType: make(map[string]int)})
+		}
+		week := &schedule.Weeks[len(schedule.Weeks)-1]
+		week.Items = append(week.Items, entry)
+		week.Count++
+		week.ByType[entry.Type]++
+		schedule.Total++
+	}
+	return schedule
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestCalendarEntriesForActivity(t *testing.T) {
+	activity := &models.Activity{
+		ID:    "act-1",
+		Title: "Saturday Swim",
+		Type:  models.TypeClass,
+		Schedule: models.Schedule{
+			Type:       models.ScheduleTypeRecurring,
+			StartDate:  "2025-03-01",
+			EndDate:    "2025-03-31",
+			StartTime:  "09:00",
+			DaysOfWeek: []string{"Saturday"},
+		},
+	}
+
+	entries := CalendarEntriesForActivity(activity, "")
+	if len(entries) != 5 {
+		t.Fatalf("Expected the 5 Saturdays in March 2025, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.Date != "2025-03-01" || first.SK != "INSTANCE#2025-03-01T09:00" || first.PK != "EVENT#act-1" {
+		t.Errorf("Unexpected first entry keys: %+v", first)
+	}
+	if first.CalendarMonthKey != "MONTH#2025-03" || first.DateTypeKey != "DATE#2025-03-01#TYPE#class#act-1" {
+		t.Errorf("Unexpected first entry GSI keys: %+v", first)
+	}
+
+	oneDay := &models.Activity{ID: "act-2", Schedule: models.Schedule{StartDate: "2025-03-31", EndDate: "2025-03-01"}}
+	if entries := CalendarEntriesForActivity(oneDay, ""); len(entries) != 1 {
+		t.Errorf("Expected an end date before the start to be ignored, got %d entries", len(entries))
+	}
+
+	season := &models.Activity{ID: "act-3", Schedule: models.Schedule{StartDate: "2025-01-01", EndDate: "2025-12-31"}}
+	if entries := CalendarEntriesForActivity(season, ""); len(entries) != maxCalendarDays {
+		t.Errorf("Expected a long program to be capped at %d days, got %d", maxCalendarDays, len(entries))
+	}
+
+	undated := &models.Activity{ID: "act-4", Schedule: models.Schedule{Type: models.ScheduleTypeOngoing}}
+	if entries := CalendarEntriesForActivity(undated, ""); len(entries) != 0 {
+		t.Errorf("Expected no entries without a start date, got %d", len(entries))
+	}
+}
+
+func TestBuildCalendarMonth(t *testing.T) {
+	entries := []models.CalendarEntry{
+		{ActivityID: "b", Date: "2024-02-10", StartTime: "14:00", Title: "Puppet Show"},
+		{ActivityID: "a", Date: "2024-02-10", StartTime: "09:00", Title: "Story Time"},
+		{ActivityID: "c", Date: "2024-02-29", IsAllDay: true, Title: "Leap Day Fair"},
+	}
+
+	calendar, err := BuildCalendarMonth("2024-02", entries)
+	if err != nil {
+		t.Fatalf("Failed to build calendar: %v", err)
+	}
+	if len(calendar.Days) != 29 || calendar.Total != 3 {
+		t.Fatalf("Expected 29 days and 3 activities in February 2024, got %d days and %d", len(calendar.Days), calendar.Total)
+	}
+
+	day := calendar.Days[9]
+	if day.Date != "2024-02-10" || day.Count != 2 || day.Items[0].ActivityID != "a" {
+		t.Errorf("Expected two activities on Feb 10 ordered by start time, got %+v", day)
+	}
+	if calendar.Days[0].Items == nil || calendar.Days[0].Count != 0 {
+		t.Errorf("Expected empty days to have an empty item list, got %+v", calendar.Days[0])
+	}
+
+	if _, err := BuildCalendarMonth("March 2025", nil); err == nil {
+		t.Error("Expected an invalid month to be rejected")
+	}
+}
+
+func TestBuildVenueSchedule(t *testing.T) {
+	venue := &models.Venue{FamilyActivity: models.FamilyActivity{EntityID: "magnuson-park", Name: "Magnuson Park"}}
+	activity := &models.Activity{
+		ID:       "act-1",
+		Title:    "Nature Walk",
+		Type:     models.TypeEvent,
+		Schedule: models.Schedule{StartDate: "2025-03-01", EndDate: "2025-03-12", DaysOfWeek: []string{"saturday", "wednesday"}},
+	}
+	entries := CalendarEntriesForActivity(activity, venue.EntityID)
+	entries = append(entries, models.CalendarEntry{ActivityID: "act-2", Date: "2025-03-05", Type: models.TypeClass, Title: "Kite Class"})
+	if entries[0].VenueKey != "VENUE#magnuson-park" {
+		t.Fatalf("Expected entries to carry the venue key, got %+v", entries[0])
+	}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	schedule := BuildVenueSchedule(venue, from, to, entries)
+
+	if schedule.VenueName != "Magnuson Park" || schedule.Total != 4 {
+		t.Fatalf("Expected 4 activities at Magnuson Park up to Mar 10, got %d at %q", schedule.Total, schedule.VenueName)
+	}
+	if len(schedule.Weeks) != 2 || schedule.Weeks[0].WeekStart != "2025-02-24" || schedule.Weeks[1].WeekStart != "2025-03-03" {
+		t.Fatalf("Expected weeks starting Feb 24 and Mar 3, got %+v", schedule.Weeks)
+	}
+	second := schedule.Weeks[1]
+	if second.Count != 3 || second.ByType[models.TypeEvent] != 2 || second.ByType[models.TypeClass] != 1 {
+		t.Errorf("Unexpected rollup for the week of Mar 3: %+v", second)
+	}
+	if second.Items[0].Date != "2025-03-05" || second.Items[0].Title != "Kite Class" {
+		t.Errorf("Expected items ordered by date and title, got %+v", second.Items[0])
+	}
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	festival := &models.PublicActivity{Activity: models.Activity{
+		ID:       "fest",
+		Title:    "Folk Festival",
+		Schedule: models.Schedule{Type: models.ScheduleTypeMultiDay, StartDate: "2025-05-23", EndDate: "2025-05-26", StartTime: "11:00"},
+	}}
+	storyTime := &models.PublicActivity{Activity: models.Activity{
+		ID:       "story",
+		Title:    "Story Time",
+		Schedule: models.Schedule{Type: models.ScheduleTypeRecurring, DaysOfWeek: []string{"saturday"}, StartTime: "10:00"},
+	}}
+	oneOff := &models.PublicActivity{Activity: models.Activity{
+		ID:       "concert",
+		Title:    "Concert",
+		Schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2025-05-22"},
+	}}
+
+	saturday := time.Date(2025, 5, 24, 0, 0, 0, 0, time.UTC)
+	expanded := ExpandOccurrences([]*models.PublicActivity{festival, storyTime, oneOff}, saturday, saturday)
+	if len(expanded) != 2 {
+		t.Fatalf("Expected the festival's second day and story time on Saturday, got %d items", len(expanded))
+	}
+	if expanded[0].ID != "story" || expanded[1].ID != "fest" {
+		t.Errorf("Expected items ordered by start time, got %s then %s", expanded[0].ID, expanded[1].ID)
+	}
+	day := expanded[1]
+	if day.Schedule.StartDate != "2025-05-24" || day.Schedule.EndDate != "2025-05-24" || day.Schedule.StartTime != "11:00" {
+		t.Errorf("Expected the festival's Saturday schedule, got %+v", day.Schedule)
+	}
+	if day.Occurrence == nil || day.Occurrence.ID != "fest#2025-05-24" || day.Occurrence.Day != 2 || day.Occurrence.Days != 4 {
+		t.Errorf("Expected day 2 of 4, got %+v", day.Occurrence)
+	}
+	if festival.Schedule.StartDate != "2025-05-23" || festival.Occurrence != nil {
+		t.Errorf("Expected the original activity to be left unchanged, got %+v", festival.Schedule)
+	}
+
+	weekend := ExpandOccurrences([]*models.PublicActivity{festival}, saturday, saturday.AddDate(0, 0, 6))
+	if len(weekend) != 3 || weekend[2].Occurrence.Date != "2025-05-26" {
+		t.Errorf("Expected the festival's remaining 3 days, got %d items", len(weekend))
+	}
+}
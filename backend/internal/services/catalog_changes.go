@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// DetectCatalogChanges compares an admin event before and after a write and returns the catalog
+// changes it caused. An event is in the catalog while it is approved, not withdrawn outside its
+// visibility window, and converts into a publishable activity, so edits that don't change the published activity cause no change.
+// Either image may be nil for an insert or a removal.
+func DetectCatalogChanges(oldEvent, newEvent *models.AdminEvent, conversion *SchemaConversionService, changedAt time.Time) []models.CatalogChange {
+	oldActivity := catalogActivity(oldEvent, conversion)
+	newActivity := catalogActivity(newEvent, conversion)
+
+	created := func() models.CatalogChange {
+		newActivity.CreatedAt = newEvent.CreatedAt
+		newActivity.UpdatedAt = changedAt
+		return models.CatalogChange{Type: models.CatalogChangeCreated, ActivityID: newActivity.ID, Activity: newActivity, EventID: newEvent.EventID, ChangedAt: changedAt}
+	}
+	expired := func() models.CatalogChange {
+		return models.CatalogChange{Type: models.CatalogChangeExpired, ActivityID: oldActivity.ID, EventID: oldEvent.EventID, ChangedAt: changedAt}
+	}
+
+	switch {
+	case oldActivity == nil && newActivity == nil:
+		return nil
+	case oldActivity == nil:
+		return []models.CatalogChange{created()}
+	case newActivity == nil:
+		return []models.CatalogChange{expired()}
+	case oldActivity.ID != newActivity.ID:
+		// Events approved before activity IDs were kept are republished under a new ID
+		return []models.CatalogChange{expired(), created()}
+	}
+
+	oldJSON, _ := json.Marshal(oldActivity)
+	newJSON, _ := json.Marshal(newActivity)
+	if string(oldJSON) == string(newJSON) {
+		return nil
+	}
+	change := created()
+	change.Type = models.CatalogChangeUpdated
+	return []models.CatalogChange{change}
+}
+
+// catalogActivity returns the activity an admin event publishes, or nil if it is not in the catalog.
+// Conversion derives the ID of an unpublished event from its title, date and venue, which edits
+// change, so the event ID stands in until it is published, and the timestamps are cleared to
+// make activities of two versions of an event comparable.
+func catalogActivity(adminEvent *models.AdminEvent, conversion *SchemaConversionService) *models.Activity {
+	if adminEvent == nil || !adminEvent.IsApproved() || adminEvent.Hidden {
+		return nil
+	}
+	activity, err := publishableActivity(adminEvent, conversion)
+	if err != nil {
+		return nil
+	}
+
+	activity.ID = adminEvent.ActivityID
+	if activity.ID == "" {
+		activity.ID = adminEvent.EventID
+	}
+	activity.CreatedAt = time.Time{}
+	activity.UpdatedAt = time.Time{}
+	return activity
+}
+
+// AdminEventFromStreamImage decodes an admin event from a DynamoDB stream image.
+// It returns nil without an error for an empty image.
+func AdminEventFromStreamImage(image map[string]events.DynamoDBAttributeValue) (*models.AdminEvent, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+
+	var adminEvent models.AdminEvent
+	if err := attributevalue.UnmarshalMap(StreamImageToItem(image), &adminEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admin event: %w", err)
+	}
+	return &adminEvent, nil
+}
+
+// StreamImageToItem converts a DynamoDB stream image into the attribute values the SDK reads
+func StreamImageToItem(image map[string]events.DynamoDBAttributeValue) map[string]types.AttributeValue {
+	item := make(map[string]types.AttributeValue, len(image))
+	for name, value := range image {
+		item[name] = streamAttributeValue(value)
+	}
+	return item
+}
+
+func streamAttributeValue(value events.DynamoDBAttributeValue) types.AttributeValue {
+	switch value.DataType() {
+	case events.DataTypeString:
+		return &types.AttributeValueMemberS{Value: value.String()}
+	case events.DataTypeNumber:
+		return &types.AttributeValueMemberN{Value: value.Number()}
+	case events.DataTypeBoolean:
+		return &types.AttributeValueMemberBOOL{Value: value.Boolean()}
+	case events.DataTypeBinary:
+		return &types.AttributeValueMemberB{Value: value.Binary()}
+	case events.DataTypeStringSet:
+		return &types.AttributeValueMemberSS{Value: value.StringSet()}
+	case events.DataTypeNumberSet:
+		return &types.AttributeValueMemberNS{Value: value.NumberSet()}
+	case events.DataTypeBinarySet:
+		return &types.AttributeValueMemberBS{Value: value.BinarySet()}
+	case events.DataTypeList:
+		list := value.List()
+		values := make([]types.AttributeValue, len(list))
+		for i, element := range list {
+			values[i] = streamAttributeValue(element)
+		}
+		return &types.AttributeValueMemberL{Value: values}
+	case events.DataTypeMap:
+		return &types.AttributeValueMemberM{Value: StreamImageToItem(value.Map())}
+	default:
+		return &types.AttributeValueMemberNULL{Value: true}
+	}
+}
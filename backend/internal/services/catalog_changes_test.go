@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestDetectCatalogChanges(t *testing.T) {
+	conversion := NewSchemaConversionService()
+	changedAt := time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)
+	newEvent := func(status models.AdminEventStatus, title string) *models.AdminEvent {
+		return &models.AdminEvent{
+			EventID:    "evt-1",
+			ActivityID: "act-1",
+			SourceURL:  "https://www.seattle.gov/parks/events",
+			SchemaType: "events",
+			Status:     status,
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{"title": title, "location": "Green Lake Park", "date": "2026-11-07"},
+				},
+			},
+			ExtractedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	t.Run("Approved", func(t *testing.T) {
+		changes := DetectCatalogChanges(newEvent(models.AdminEventStatusPending, "Story Time"), newEvent(models.AdminEventStatusApproved, "Story Time"), conversion, changedAt)
+		if len(changes) != 1 || changes[0].Type != models.CatalogChangeCreated {
+			t.Fatalf("Expected one created change, got %+v", changes)
+		}
+		if changes[0].ActivityID != "act-1" || changes[0].Activity == nil || changes[0].Activity.ID != "act-1" {
+			t.Errorf("Expected the activity under its published ID, got %+v", changes[0])
+		}
+	})
+
+	t.Run("UnchangedActivity", func(t *testing.T) {
+		approved := newEvent(models.AdminEventStatusApproved, "Story Time")
+		edited := newEvent(models.AdminEventStatusApproved, "Story Time")
+		edited.AdminNotes = "checked the link"
+		if changes := DetectCatalogChanges(approved, edited, conversion, changedAt); len(changes) != 0 {
+			t.Errorf("Expected no change for an edit that leaves the activity alone, got %+v", changes)
+		}
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		changes := DetectCatalogChanges(newEvent(models.AdminEventStatusApproved, "Story Time"), newEvent(models.AdminEventStatusApproved, "Toddler Story Time"), conversion, changedAt)
+		if len(changes) != 1 || changes[0].Type != models.CatalogChangeUpdated || changes[0].Activity.Title != "Toddler Story Time" {
+			t.Fatalf("Expected one updated change with the new activity, got %+v", changes)
+		}
+	})
+
+	t.Run("CancelledOrRemoved", func(t *testing.T) {
+		approved := newEvent(models.AdminEventStatusApproved, "Story Time")
+		for _, after := range []*models.AdminEvent{newEvent(models.AdminEventStatusCancelled, "Story Time"), nil} {
+			changes := DetectCatalogChanges(approved, after, conversion, changedAt)
+			if len(changes) != 1 || changes[0].Type != models.CatalogChangeExpired || changes[0].Activity != nil {
+				t.Errorf("Expected one expired change without an activity, got %+v", changes)
+			}
+		}
+	})
+
+	t.Run("NotInCatalog", func(t *testing.T) {
+		if changes := DetectCatalogChanges(nil, newEvent(models.AdminEventStatusPending, "Story Time"), conversion, changedAt); len(changes) != 0 {
+			t.Errorf("Expected no change for a pending event, got %+v", changes)
+		}
+	})
+}
+
+func TestStreamImageToItem(t *testing.T) {
+	image := map[string]events.DynamoDBAttributeValue{
+		"EventID": events.NewStringAttribute("evt-1"),
+		"RawExtractedData": events.NewMapAttribute(map[string]events.DynamoDBAttributeValue{
+			"events": events.NewListAttribute([]events.DynamoDBAttributeValue{
+				events.NewMapAttribute(map[string]events.DynamoDBAttributeValue{
+					"registration_required": events.NewBooleanAttribute(true),
+					"capacity":              events.NewNumberAttribute("12"),
+					"notes":                 events.NewNullAttribute(),
+				}),
+			}),
+		}),
+	}
+
+	item := StreamImageToItem(image)
+	if id, ok := item["EventID"].(*types.AttributeValueMemberS); !ok || id.Value != "evt-1" {
+		t.Errorf("Expected EventID string attribute, got %#v", item["EventID"])
+	}
+	raw, ok := item["RawExtractedData"].(*types.AttributeValueMemberM)
+	if !ok {
+		t.Fatalf("Expected RawExtractedData map attribute, got %#v", item["RawExtractedData"])
+	}
+	list, ok := raw.Value["events"].(*types.AttributeValueMemberL)
+	if !ok || len(list.Value) != 1 {
+		t.Fatalf("Expected a one element list, got %#v", raw.Value["events"])
+	}
+	eventData := list.Value[0].(*types.AttributeValueMemberM).Value
+	if required, ok := eventData["registration_required"].(*types.AttributeValueMemberBOOL); !ok || !required.Value {
+		t.Errorf("Expected boolean attribute, got %#v", eventData["registration_required"])
+	}
+	if capacity, ok := eventData["capacity"].(*types.AttributeValueMemberN); !ok || capacity.Value != "12" {
+		t.Errorf("Expected number attribute, got %#v", eventData["capacity"])
+	}
+	if _, ok := eventData["notes"].(*types.AttributeValueMemberNULL); !ok {
+		t.Errorf("Expected null attribute, got %#v", eventData["notes"])
+	}
+
+	if adminEvent, err := AdminEventFromStreamImage(nil); adminEvent != nil || err != nil {
+		t.Errorf("Expected nil for an empty image, got %+v, %v", adminEvent, err)
+	}
+}
+
+func TestStreamSequenceAfter(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"400000000000000000001", "400000000000000000000", true},
+		{"1000", "999", true},
+		{"999", "1000", false},
+		{"1000", "1000", false},
+	}
+	for _, tt := range tests {
+		if got := streamSequenceAfter(tt.a, tt.b); got != tt.want {
+			t.Errorf("streamSequenceAfter(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
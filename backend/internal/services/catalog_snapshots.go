@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ErrCatalogSnapshotNotFound is returned for a snapshot version that was never taken
+var ErrCatalogSnapshotNotFound = errors.New("catalog snapshot not found")
+
+// catalogSnapshotsCached is how many snapshots a reader keeps in memory. Snapshots never change,
+// so cached copies never go stale.
+const catalogSnapshotsCached = 3
+
+// BuildCatalogSnapshot freezes approved events into a snapshot taken at now, returning its manifest
+// and the events object. Cached review diagnostics are left out; they are rebuilt from the event.
+func BuildCatalogSnapshot(events []models.AdminEvent, now time.Time) (*models.CatalogSnapshotManifest, []byte, error) {
+	frozen := make([]models.AdminEvent, len(events))
+	for i, event := range events {
+		event.Diagnostics = nil
+		frozen[i] = event
+	}
+
+	body, err := json.Marshal(frozen)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal snapshot events: %w", err)
+	}
+	hash := sha256.Sum256(body)
+
+	version := models.CatalogSnapshotVersion(now)
+	return &models.CatalogSnapshotManifest{
+		Version:      version,
+		CreatedAt:    now.UTC(),
+		EventCount:   len(frozen),
+		EventsKey:    models.CatalogSnapshotEventsKey(version),
+		EventsSHA256: hex.EncodeToString(hash[:]),
+	}, body, nil
+}
+
+// ReadCatalogSnapshot decodes the events object of a snapshot, checking it against the manifest
+func ReadCatalogSnapshot(manifest *models.CatalogSnapshotManifest, body []byte) ([]models.AdminEvent, error) {
+	hash := sha256.Sum256(body)
+	if hex.EncodeToString(hash[:]) != manifest.EventsSHA256 {
+		return nil, fmt.Errorf("snapshot %s events do not match the manifest checksum", manifest.Version)
+	}
+
+	var events []models.AdminEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot %s events: %w", manifest.Version, err)
+	}
+	if len(events) != manifest.EventCount {
+		return nil, fmt.Errorf("snapshot %s has %d events, manifest lists %d", manifest.Version, len(events), manifest.EventCount)
+	}
+	return events, nil
+}
+
+// CatalogSnapshotter takes the nightly catalog snapshots
+type CatalogSnapshotter struct {
+	dynamo *DynamoDBService
+	store  *S3Store
+}
+
+// NewCatalogSnapshotter creates a snapshotter that stores snapshots under models.CatalogSnapshotPrefix
+func NewCatalogSnapshotter(dynamo *DynamoDBService, store *S3Store) *CatalogSnapshotter {
+	return &CatalogSnapshotter{
+		dynamo: dynamo,
+		store:  store,
+	}
+}
+
+// Snapshot freezes the approved catalog as the snapshot for now's day. Snapshots are immutable, so
+// when the day already has one it is returned as is and created is false.
+func (c *CatalogSnapshotter) Snapshot(ctx context.Context, now time.Time) (manifest *models.CatalogSnapshotManifest, created bool, err error) {
+	version := models.CatalogSnapshotVersion(now)
+	existing, err := getCatalogSnapshotManifest(ctx, c.store, version)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, ErrCatalogSnapshotNotFound) {
+		return nil, false, err
+	}
+
+	events, err := c.dynamo.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get approved events: %w", err)
+	}
+	manifest, body, err := BuildCatalogSnapshot(VisibleAdminEvents(events, now), now)
+	if err != nil {
+		return nil, false, err
+	}
+	manifestBody, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+
+	// The manifest is written last, so a failed run leaves no manifest and the next run retries
+	run := NewPublishRun(c.store, PublisherCatalogSnapshot, models.CatalogSnapshotPrefix, now)
+	err = run.Put(ctx, manifest.EventsKey, body, "application/json")
+	if err != nil {
+		err = fmt.Errorf("failed to store snapshot events: %w", err)
+	} else if err = run.Put(ctx, models.CatalogSnapshotManifestKey(version), manifestBody, "application/json"); err != nil {
+		err = fmt.Errorf("failed to store snapshot manifest: %w", err)
+	}
+	if _, err := run.Finish(ctx, err); err != nil {
+		return nil, false, err
+	}
+	return manifest, true, nil
+}
+
+// CatalogSnapshotReader serves the approved events of catalog snapshots, keeping the most recently
+// read snapshots in memory
+type CatalogSnapshotReader struct {
+	store *S3Store
+
+	mu     sync.Mutex
+	cached map[string][]models.AdminEvent
+	order  []string // cached versions, least recently read first
+}
+
+// NewCatalogSnapshotReader creates a reader for the snapshots in a store
+func NewCatalogSnapshotReader(store *S3Store) *CatalogSnapshotReader {
+	return &CatalogSnapshotReader{
+		store:  store,
+		cached: make(map[string][]models.AdminEvent),
+	}
+}
+
+// Events returns the approved events frozen in a snapshot, newest first. The slice is shared
+// between callers and must not be modified. It returns ErrCatalogSnapshotNotFound when the version
+// has no snapshot.
+func (r *CatalogSnapshotReader) Events(ctx context.Context, version string) ([]models.AdminEvent, error) {
+	r.mu.Lock()
+	if events, ok := r.cached[version]; ok {
+		r.touch(version)
+		r.mu.Unlock()
+		return events, nil
+	}
+	r.mu.Unlock()
+
+	manifest, err := getCatalogSnapshotManifest(ctx, r.store, version)
+	if err != nil {
+		return nil, err
+	}
+	body, err := r.store.Get(ctx, manifest.EventsKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot %s events: %w", version, err)
+	}
+	events, err := ReadCatalogSnapshot(manifest, body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cached[version]; !ok {
+		r.cached[version] = events
+		r.order = append(r.order, version)
+		if len(r.order) > catalogSnapshotsCached {
+			delete(r.cached, r.order[0])
+			r.order = r.order[1:]
+		}
+	}
+	r.touch(version)
+	return events, nil
+}
+
+// touch marks a cached version as the most recently read. The caller holds mu.
+func (r *CatalogSnapshotReader) touch(version string) {
+	for i, cached := range r.order {
+		if cached == version {
+			r.order = append(append(r.order[:i:i], r.order[i+1:]...), version)
+			return
+		}
+	}
+}
+
+// getCatalogSnapshotManifest reads the manifest of a snapshot version
+func getCatalogSnapshotManifest(ctx context.Context, store *S3Store, version string) (*models.CatalogSnapshotManifest, error) {
+	body, err := store.Get(ctx, models.CatalogSnapshotManifestKey(version))
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, ErrCatalogSnapshotNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot %s manifest: %w", version, err)
+	}
+
+	var manifest models.CatalogSnapshotManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot %s manifest: %w", version, err)
+	}
+	return &manifest, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestCatalogSnapshotRoundTrip(t *testing.T) {
+	// Taken late in the evening Pacific time, which is already the next day in UTC
+	now := time.Date(2025, 2, 28, 20, 30, 0, 0, time.FixedZone("PST", -8*60*60))
+	events := []models.AdminEvent{
+		{EventID: "evt-2", Status: models.AdminEventStatusApproved, Diagnostics: &models.ConversionDiagnostics{InputHash: "abc"}},
+		{EventID: "evt-1", Status: models.AdminEventStatusApproved},
+	}
+
+	manifest, body, err := BuildCatalogSnapshot(events, now)
+	if err != nil {
+		t.Fatalf("Failed to build snapshot: %v", err)
+	}
+	if manifest.Version != "2025-03-01" || manifest.EventsKey != "catalog-snapshots/2025-03-01/events.json" || manifest.EventCount != 2 {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+	if events[0].Diagnostics == nil {
+		t.Error("Expected building a snapshot to leave the given events unchanged")
+	}
+
+	frozen, err := ReadCatalogSnapshot(manifest, body)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	if len(frozen) != 2 || frozen[0].EventID != "evt-2" || frozen[1].EventID != "evt-1" {
+		t.Errorf("Expected the events in their order, got %+v", frozen)
+	}
+	if frozen[0].Diagnostics != nil {
+		t.Error("Expected cached diagnostics to be left out of the snapshot")
+	}
+
+	tampered := []byte(strings.Replace(string(body), "evt-1", "evt-9", 1))
+	if _, err := ReadCatalogSnapshot(manifest, tampered); err == nil {
+		t.Error("Expected events that do not match the manifest checksum to be rejected")
+	}
+}
+
+func TestParseCatalogSnapshotVersion(t *testing.T) {
+	if version, err := models.ParseCatalogSnapshotVersion(" 2025-03-01 "); err != nil || version != "2025-03-01" {
+		t.Errorf("Expected 2025-03-01, got %q (%v)", version, err)
+	}
+	for _, bad := range []string{"2025-3-1", "latest", "2025-02-30", "../2025-03-01"} {
+		if _, err := models.ParseCatalogSnapshotVersion(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
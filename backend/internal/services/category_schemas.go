@@ -0,0 +1,398 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Camps and classes have their own extraction schemas. Conversion first maps their fields onto the
+// generic ones (a camp's first session becomes its start date, its weekly price its price), then
+// fills the camp or class details. Activities scraped from sources expected to list camps or
+// classes get the same details detected from their text.
+
+var (
+	beforeCarePattern   = regexp.MustCompile(`(?i)\b(before[- ]?care|before[- ]camp care|early drop[- ]?off|morning care)\b`)
+	afterCarePattern    = regexp.MustCompile(`(?i)\b(after[- ]?care|after[- ]camp care|late pick[- ]?up|extended day)\b`)
+	pricePerWeekPattern = regexp.MustCompile(`(?i)\$\s?(\d[\d,]*(?:\.\d{1,2})?)\s*(?:/\s*(?:week|wk)\b|per\s+week\b|a\s+week\b|weekly\b)`)
+	campSessionPattern  = regexp.MustCompile(`(?i)^[\W_]*((?:week|session)\s*\d+)\b`)
+	allLevelsPattern    = regexp.MustCompile(`(?i)\ball(?:\s+skill)?\s+levels\b`)
+	skillLevelPattern   = regexp.MustCompile(`(?i)\b(beginners?|introductory|intro|novice|intermediate|advanced)\b`)
+	instructorPattern   = regexp.MustCompile(`(?:[Ii]nstructors?|[Tt]eachers?|[Tt]aught by|[Ll]ed by|[Cc]oach)\s*:?\s+([A-Z][\w'.-]*(?:\s+[A-Z][\w'.-]*){0,3})`)
+)
+
+// ApplyCategoryDetails fills the camp or class details of an activity scraped from a source whose
+// expected content is camps or classes, from its title, description and pricing text. Details
+// already set are kept.
+func ApplyCategoryDetails(activity *models.Activity, category string) {
+	text := strings.Join([]string{activity.Title, activity.Description, activity.Pricing.Description}, "\n")
+	switch category {
+	case "camps":
+		if activity.Camp == nil {
+			activity.Camp = detectCampDetails(text)
+		}
+		if activity.Camp != nil {
+			applyWeeklyPrice(activity)
+		}
+	case "classes":
+		if activity.Class == nil {
+			activity.Class = detectClassDetails(text)
+		}
+	}
+}
+
+// prepareCategoryFields returns the event data with the generic fields a camp or class schema
+// leaves out filled from its specialized ones. Other data is returned unchanged.
+func (scs *SchemaConversionService) prepareCategoryFields(eventData map[string]interface{}, schemaType string) map[string]interface{} {
+	if schemaType != "camps" {
+		return eventData
+	}
+
+	prepared := make(map[string]interface{}, len(eventData)+2)
+	for key, value := range eventData {
+		prepared[key] = value
+	}
+	if scs.extractStringWithFallbacks(eventData, []string{"date", "start_date", "event_date", "schedule_date"}) == "" {
+		if sessions := scs.extractCampSessions(eventData); len(sessions) > 0 && sessions[0].StartDate != "" {
+			prepared["start_date"] = sessions[0].StartDate
+		}
+	}
+	if scs.extractStringWithFallbacks(eventData, []string{"price", "cost", "fee", "admission_fee", "pricing"}) == "" {
+		if weekly := scs.extractStringWithFallbacks(eventData, []string{"price_per_week", "weekly_price"}); weekly != "" {
+			prepared["price"] = weekly
+		}
+	}
+	return prepared
+}
+
+// applyCategoryDetails sets the camp or class details of a converted activity from its event data,
+// falling back to details detected in its text
+func (scs *SchemaConversionService) applyCategoryDetails(activity *models.Activity, eventData map[string]interface{}, schemaType string) {
+	switch schemaType {
+	case "camps":
+		camp := &models.CampDetails{
+			Sessions:    scs.extractCampSessions(eventData),
+			BeforeCare:  careOffered(eventData["before_care"]),
+			AfterCare:   careOffered(eventData["after_care"]),
+			CareDetails: scs.extractStringWithFallbacks(eventData, []string{"care_details", "extended_care"}),
+		}
+		if weekly := scs.extractStringWithFallbacks(eventData, []string{"price_per_week", "weekly_price"}); weekly != "" {
+			if cost, err := scs.extractCostFromString(weekly); err == nil {
+				camp.PricePerWeek = cost
+			}
+		}
+
+		detected := detectCampDetails(strings.Join([]string{activity.Description, camp.CareDetails}, "\n"))
+		if detected != nil {
+			camp.BeforeCare = camp.BeforeCare || detected.BeforeCare
+			camp.AfterCare = camp.AfterCare || detected.AfterCare
+			if camp.PricePerWeek == 0 {
+				camp.PricePerWeek = detected.PricePerWeek
+			}
+			if len(camp.Sessions) == 0 {
+				camp.Sessions = detected.Sessions
+			}
+		}
+		activity.Camp = camp
+
+		if len(camp.Sessions) > 0 {
+			activity.Schedule.Sessions = len(camp.Sessions)
+			if last := camp.Sessions[len(camp.Sessions)-1]; last.EndDate != "" && last.EndDate != activity.Schedule.StartDate {
+				activity.Schedule.EndDate = last.EndDate
+				activity.Schedule.Type = models.ScheduleTypeMultiDay
+			}
+		}
+		applyWeeklyPrice(activity)
+		if category, ok := eventData["category"].(string); !ok || !models.ValidateCategory(category) {
+			activity.Category = models.CategoryCampsPrograms
+		}
+
+	case "classes":
+		class := &models.ClassDetails{
+			SkillLevel: normalizeSkillLevel(scs.extractStringWithFallbacks(eventData, []string{"skill_level", "level"})),
+			Instructor: scs.extractStringWithFallbacks(eventData, []string{"instructor", "teacher"}),
+		}
+		if detected := detectClassDetails(activity.Description); detected != nil {
+			if class.SkillLevel == "" {
+				class.SkillLevel = detected.SkillLevel
+			}
+			if class.Instructor == "" {
+				class.Instructor = detected.Instructor
+			}
+		}
+		if class.SkillLevel != "" || class.Instructor != "" {
+			activity.Class = class
+		}
+	}
+}
+
+// extractCampSessions reads a camp's sessions, given as objects or as text, in page order
+func (scs *SchemaConversionService) extractCampSessions(eventData map[string]interface{}) []models.CampSession {
+	var raw []interface{}
+	for _, field := range []string{"sessions", "session_weeks", "weeks"} {
+		if values, ok := eventData[field].([]interface{}); ok {
+			raw = values
+			break
+		}
+	}
+
+	var sessions []models.CampSession
+	for _, value := range raw {
+		switch session := value.(type) {
+		case string:
+			if label := strings.TrimSpace(session); label != "" {
+				sessions = append(sessions, models.CampSession{Label: label})
+			}
+		case map[string]interface{}:
+			label := scs.extractStringWithFallbacks(session, []string{"label", "name", "title"})
+			startDate := scs.extractStringWithFallbacks(session, []string{"start_date", "start", "date"})
+			endDate := scs.extractStringWithFallbacks(session, []string{"end_date", "end"})
+			parsed := models.CampSession{Label: label}
+			if formatted, err := scs.parseAndFormatDate(startDate); err == nil {
+				parsed.StartDate = formatted
+			}
+			if formatted, err := scs.parseAndFormatDate(endDate); err == nil {
+				parsed.EndDate = formatted
+			}
+			if parsed.Label == "" {
+				parsed.Label = strings.TrimSpace(strings.Trim(startDate+" - "+endDate, " -"))
+			}
+			if parsed.Label != "" || parsed.StartDate != "" {
+				sessions = append(sessions, parsed)
+			}
+		}
+	}
+	return sessions
+}
+
+// applyWeeklyPrice prices an activity per week when its camp details carry a weekly price
+func applyWeeklyPrice(activity *models.Activity) {
+	if activity.Camp == nil || activity.Camp.PricePerWeek <= 0 {
+		return
+	}
+	if activity.Pricing.Cost == 0 || activity.Pricing.Cost == activity.Camp.PricePerWeek {
+		activity.Pricing.Cost = activity.Camp.PricePerWeek
+		activity.Pricing.Type = models.PricingTypePaid
+		activity.Pricing.Unit = "per-week"
+		if activity.Pricing.Currency == "" {
+			activity.Pricing.Currency = "USD"
+		}
+	}
+}
+
+// careOffered reads a before or after care field, given as a flag or as text
+func careOffered(value interface{}) bool {
+	switch care := value.(type) {
+	case bool:
+		return care
+	case string:
+		care = strings.ToLower(strings.TrimSpace(care))
+		switch care {
+		case "", "no", "false", "none", "n/a", "not offered", "not available":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// detectCampDetails finds extended care, weekly pricing and numbered weeks or sessions in camp
+// text. It returns nil when none are mentioned.
+func detectCampDetails(text string) *models.CampDetails {
+	camp := &models.CampDetails{}
+	var careLines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		isCare := false
+		if beforeCarePattern.MatchString(line) {
+			camp.BeforeCare = true
+			isCare = true
+		}
+		if afterCarePattern.MatchString(line) {
+			camp.AfterCare = true
+			isCare = true
+		}
+		if isCare {
+			careLines = append(careLines, line)
+			continue // a care line's price is the care's, not the camp's
+		}
+
+		if camp.PricePerWeek == 0 {
+			if match := pricePerWeekPattern.FindStringSubmatch(line); match != nil {
+				if cost, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64); err == nil {
+					camp.PricePerWeek = cost
+				}
+			}
+		}
+		if campSessionPattern.MatchString(line) {
+			camp.Sessions = append(camp.Sessions, models.CampSession{Label: line})
+		}
+	}
+	camp.CareDetails = strings.Join(careLines, "; ")
+
+	if !camp.BeforeCare && !camp.AfterCare && camp.PricePerWeek == 0 && len(camp.Sessions) == 0 {
+		return nil
+	}
+	return camp
+}
+
+// detectClassDetails finds the skill level and instructor in class text. It returns nil when
+// neither is mentioned.
+func detectClassDetails(text string) *models.ClassDetails {
+	class := &models.ClassDetails{}
+	if allLevelsPattern.MatchString(text) {
+		class.SkillLevel = models.SkillLevelAllLevels
+	} else if match := skillLevelPattern.FindString(text); match != "" {
+		class.SkillLevel = normalizeSkillLevel(match)
+	}
+	if match := instructorPattern.FindStringSubmatch(text); match != nil {
+		class.Instructor = strings.TrimRight(match[1], ".")
+	}
+
+	if class.SkillLevel == "" && class.Instructor == "" {
+		return nil
+	}
+	return class
+}
+
+// normalizeSkillLevel maps a skill level as written to a skill level constant. A range such as
+// "beginner to intermediate" maps to its lowest level. Unknown levels map to an empty string.
+func normalizeSkillLevel(level string) string {
+	level = strings.ToLower(level)
+	switch {
+	case level == "":
+		return ""
+	case strings.Contains(level, "all"), strings.Contains(level, "any"), strings.Contains(level, "mixed"):
+		return models.SkillLevelAllLevels
+	case strings.Contains(level, "beginn"), strings.Contains(level, "intro"), strings.Contains(level, "novice"):
+		return models.SkillLevelBeginner
+	case strings.Contains(level, "intermediate"):
+		return models.SkillLevelIntermediate
+	case strings.Contains(level, "advanced"), strings.Contains(level, "expert"):
+		return models.SkillLevelAdvanced
+	}
+	return ""
+}
+
+// extractCampsFromMarkdown extracts camp-like objects from markdown, one per header
+func (fc *FireCrawlClient) extractCampsFromMarkdown(markdown string) []map[string]interface{} {
+	var camps []map[string]interface{}
+	var current map[string]interface{}
+	var sessions []interface{}
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current["sessions"] = sessions
+		camps = append(camps, current)
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+			flush()
+			current = map[string]interface{}{
+				"name": strings.TrimPrefix(strings.TrimPrefix(line, "## "), "# "),
+			}
+			sessions = []interface{}{}
+			continue
+		}
+		if current == nil || line == "" {
+			continue
+		}
+
+		switch {
+		case beforeCarePattern.MatchString(line) || afterCarePattern.MatchString(line):
+			if beforeCarePattern.MatchString(line) {
+				current["before_care"] = true
+			}
+			if afterCarePattern.MatchString(line) {
+				current["after_care"] = true
+			}
+			if details, ok := current["care_details"].(string); ok {
+				current["care_details"] = details + "; " + line
+			} else {
+				current["care_details"] = line
+			}
+		case pricePerWeekPattern.MatchString(line):
+			current["price_per_week"] = pricePerWeekPattern.FindString(line)
+		case campSessionPattern.MatchString(line):
+			sessions = append(sessions, line)
+		case fc.containsAgePattern(line):
+			current["age_groups"] = []string{line}
+		case fc.containsPricePattern(line):
+			current["price"] = line
+		case fc.containsLocationPattern(line):
+			current["location"] = line
+		default:
+			if _, ok := current["description"]; !ok && !strings.HasPrefix(line, "#") {
+				current["description"] = line
+			}
+		}
+	}
+	flush()
+
+	return camps
+}
+
+// extractClassesFromMarkdown extracts class-like objects from markdown, one per header
+func (fc *FireCrawlClient) extractClassesFromMarkdown(markdown string) []map[string]interface{} {
+	var classes []map[string]interface{}
+	var current map[string]interface{}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+			if current != nil {
+				classes = append(classes, current)
+			}
+			current = map[string]interface{}{
+				"name": strings.TrimPrefix(strings.TrimPrefix(line, "## "), "# "),
+			}
+			continue
+		}
+		if current == nil || line == "" {
+			continue
+		}
+
+		if match := instructorPattern.FindStringSubmatch(line); match != nil {
+			current["instructor"] = strings.TrimRight(match[1], ".")
+		}
+		switch {
+		case allLevelsPattern.MatchString(line):
+			current["skill_level"] = "all levels"
+		case skillLevelPattern.MatchString(line):
+			if _, ok := current["skill_level"]; !ok {
+				current["skill_level"] = skillLevelPattern.FindString(line)
+			}
+		}
+		switch {
+		case fc.containsAgePattern(line):
+			current["age_groups"] = []string{line}
+		case fc.containsDurationPattern(line):
+			current["duration"] = line
+		case fc.containsPricePattern(line):
+			current["cost"] = line
+		case fc.containsDatePattern(line):
+			current["schedule"] = line
+		case fc.containsLocationPattern(line):
+			current["location"] = line
+		default:
+			if _, ok := current["description"]; !ok && !strings.HasPrefix(line, "#") {
+				current["description"] = line
+			}
+		}
+	}
+	if current != nil {
+		classes = append(classes, current)
+	}
+
+	return classes
+}
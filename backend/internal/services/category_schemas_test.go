@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestConvertCampSchema(t *testing.T) {
+	scs := NewSchemaConversionService()
+	adminEvent := &models.AdminEvent{
+		EventID:    "test-camp",
+		SourceURL:  "https://test.example.com/camps",
+		SchemaType: "camps",
+		RawExtractedData: map[string]interface{}{
+			"camps": []interface{}{
+				map[string]interface{}{
+					"name":        "Nature Explorers Day Camp",
+					"description": "Hikes, crafts and games in the park",
+					"sessions": []interface{}{
+						map[string]interface{}{"label": "Week 1", "start_date": "2025-06-23", "end_date": "2025-06-27"},
+						map[string]interface{}{"label": "Week 2", "start_date": "2025-06-30", "end_date": "2025-07-03"},
+					},
+					"location":       "Discovery Park",
+					"price_per_week": "$350",
+					"before_care":    "yes",
+					"after_care":     false,
+					"care_details":   "Before care from 7:30 AM, $40/week",
+				},
+			},
+		},
+		ExtractedAt: time.Now(),
+	}
+
+	result, err := scs.ConvertToActivity(adminEvent)
+	if err != nil || result.Activity == nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+	activity := result.Activity
+
+	if activity.Type != models.TypeCamp || activity.Category != models.CategoryCampsPrograms {
+		t.Errorf("Expected a camp in camps-programs, got %s in %s", activity.Type, activity.Category)
+	}
+	if activity.Schedule.StartDate != "2025-06-23" || activity.Schedule.EndDate != "2025-07-03" || activity.Schedule.Type != models.ScheduleTypeMultiDay {
+		t.Errorf("Expected the schedule to span the sessions, got %+v", activity.Schedule)
+	}
+	if activity.Pricing.Cost != 350 || activity.Pricing.Unit != "per-week" {
+		t.Errorf("Expected $350 per week, got %+v", activity.Pricing)
+	}
+	if activity.Camp == nil {
+		t.Fatal("Expected camp details")
+	}
+	if len(activity.Camp.Sessions) != 2 || activity.Camp.Sessions[1].Label != "Week 2" || activity.Camp.Sessions[1].StartDate != "2025-06-30" {
+		t.Errorf("Unexpected sessions: %+v", activity.Camp.Sessions)
+	}
+	if !activity.Camp.BeforeCare || activity.Camp.AfterCare || activity.Camp.PricePerWeek != 350 {
+		t.Errorf("Unexpected camp details: %+v", activity.Camp)
+	}
+	if activity.Class != nil {
+		t.Errorf("Expected no class details on a camp, got %+v", activity.Class)
+	}
+}
+
+func TestConvertClassSchema(t *testing.T) {
+	scs := NewSchemaConversionService()
+	adminEvent := &models.AdminEvent{
+		EventID:    "test-class",
+		SourceURL:  "https://test.example.com/classes",
+		SchemaType: "classes",
+		RawExtractedData: map[string]interface{}{
+			"classes": []interface{}{
+				map[string]interface{}{
+					"name":        "Youth Pottery",
+					"description": "Learn to throw on the wheel",
+					"skill_level": "Beginner to Intermediate",
+					"instructor":  "Maria Lopez",
+					"start_date":  "2025-09-08",
+					"schedule":    "Mondays",
+					"location":    "Fremont Arts Studio",
+					"cost":        "$180",
+				},
+			},
+		},
+		ExtractedAt: time.Now(),
+	}
+
+	result, err := scs.ConvertToActivity(adminEvent)
+	if err != nil || result.Activity == nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+	activity := result.Activity
+
+	if activity.Type != models.TypeClass {
+		t.Errorf("Expected a class, got %s", activity.Type)
+	}
+	if activity.Class == nil || activity.Class.SkillLevel != models.SkillLevelBeginner || activity.Class.Instructor != "Maria Lopez" {
+		t.Errorf("Unexpected class details: %+v", activity.Class)
+	}
+	if activity.Camp != nil {
+		t.Errorf("Expected no camp details on a class, got %+v", activity.Camp)
+	}
+}
+
+func TestApplyCategoryDetails(t *testing.T) {
+	camp := models.Activity{
+		Title:       "Summer Robotics Camp",
+		Description: "Week 1: June 16-20\nWeek 2: June 23-27\nTuition $425/week\nAfter care until 6 PM, $75 per week",
+	}
+	ApplyCategoryDetails(&camp, "camps")
+	if camp.Camp == nil {
+		t.Fatal("Expected camp details")
+	}
+	if len(camp.Camp.Sessions) != 2 || camp.Camp.Sessions[0].Label != "Week 1: June 16-20" {
+		t.Errorf("Unexpected sessions: %+v", camp.Camp.Sessions)
+	}
+	if camp.Camp.BeforeCare || !camp.Camp.AfterCare || camp.Camp.CareDetails != "After care until 6 PM, $75 per week" {
+		t.Errorf("Unexpected extended care: %+v", camp.Camp)
+	}
+	// The weekly price is the camp's, not the after care's
+	if camp.Camp.PricePerWeek != 425 || camp.Pricing.Cost != 425 || camp.Pricing.Unit != "per-week" {
+		t.Errorf("Expected $425 per week, got %v and %+v", camp.Camp.PricePerWeek, camp.Pricing)
+	}
+
+	class := models.Activity{
+		Title:       "Intro to Ballet",
+		Description: "All levels welcome. Taught by Anna Petrova.",
+	}
+	ApplyCategoryDetails(&class, "classes")
+	if class.Class == nil || class.Class.SkillLevel != models.SkillLevelAllLevels || class.Class.Instructor != "Anna Petrova" {
+		t.Errorf("Unexpected class details: %+v", class.Class)
+	}
+
+	event := models.Activity{Title: "Week 1 of the festival", Description: "Tickets $20 per week"}
+	ApplyCategoryDetails(&event, "events")
+	if event.Camp != nil || event.Class != nil {
+		t.Errorf("Expected no details for other categories, got %+v and %+v", event.Camp, event.Class)
+	}
+}
+
+func TestNormalizeSkillLevel(t *testing.T) {
+	tests := map[string]string{
+		"Beginner":                 models.SkillLevelBeginner,
+		"intro":                    models.SkillLevelBeginner,
+		"Intermediate to Advanced": models.SkillLevelIntermediate,
+		"ADVANCED":                 models.SkillLevelAdvanced,
+		"All Levels":               models.SkillLevelAllLevels,
+		"":                         "",
+		"level 3":                  "",
+	}
+	for level, want := range tests {
+		if got := normalizeSkillLevel(level); got != want {
+			t.Errorf("normalizeSkillLevel(%q) = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestExtractCampsAndClassesFromMarkdown(t *testing.T) {
+	fc := &FireCrawlClient{}
+
+	camps := fc.extractCampsFromMarkdown("# Summer Camps\n## Art Camp\nPainting and sculpture\nWeek 1: July 7-11\nWeek 2: July 14-18\n$300/week\nBefore care 8-9 AM\n## Soccer Camp\nAges 6-10\n")
+	if len(camps) != 3 {
+		t.Fatalf("Expected 3 camps, got %d", len(camps))
+	}
+	art := camps[1]
+	if art["name"] != "Art Camp" || art["description"] != "Painting and sculpture" || art["price_per_week"] != "$300/week" || art["before_care"] != true {
+		t.Errorf("Unexpected camp: %+v", art)
+	}
+	if sessions, ok := art["sessions"].([]interface{}); !ok || len(sessions) != 2 {
+		t.Errorf("Expected 2 sessions, got %+v", art["sessions"])
+	}
+
+	classes := fc.extractClassesFromMarkdown("## Watercolor Basics\nBeginner friendly, instructor: Sam Lee\n")
+	if len(classes) != 1 || classes[0]["skill_level"] != "Beginner" || classes[0]["instructor"] != "Sam Lee" {
+		t.Errorf("Unexpected classes: %+v", classes)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// cloudFrontSigningRegion is the region CloudFront requests are signed for; the API is global
+const cloudFrontSigningRegion = "us-east-1"
+
+// CDN paths to invalidate when what they serve is republished. Every public API response lists or
+// embeds catalog activities, so a catalog change invalidates all of them with one wildcard;
+// CloudFront only allows 15 wildcard paths in progress at a time.
+const (
+	CatalogAPIInvalidationPath          = "/api/*"
+	PublishedActivitiesInvalidationPath = "/" + PublishedActivitiesKey
+	OpenDataInvalidationPath            = "/" + OpenDataPrefix + "latest/*"
+)
+
+// CloudFrontInvalidator removes stale objects from a CloudFront distribution's edge caches
+// using the CloudFront REST API
+type CloudFrontInvalidator struct {
+	distributionID string
+	endpoint       string
+	requester      *awsRequester
+}
+
+// NewCloudFrontInvalidator creates an invalidator for a distribution
+func NewCloudFrontInvalidator(cfg aws.Config, distributionID string) *CloudFrontInvalidator {
+	return &CloudFrontInvalidator{
+		distributionID: distributionID,
+		endpoint:       "https://cloudfront.amazonaws.com",
+		requester:      newAWSRequester(cfg, "cloudfront", cloudFrontSigningRegion, 10*time.Second),
+	}
+}
+
+// cloudFrontInvalidationBatch is the request body of CreateInvalidation
+type cloudFrontInvalidationBatch struct {
+	XMLName         xml.Name `xml:"http://cloudfront.amazonaws.com/doc/2020-05-31/ InvalidationBatch"`
+	CallerReference string   `xml:"CallerReference"`
+	Quantity        int      `xml:"Paths>Quantity"`
+	Items           []string `xml:"Paths>Items>Path"`
+}
+
+// cloudFrontInvalidation is the part of the CreateInvalidation response that is used
+type cloudFrontInvalidation struct {
+	ID     string `xml:"Id"`
+	Status string `xml:"Status"`
+}
+
+// Invalidate asks CloudFront to drop the cached copies of paths and returns the invalidation's ID.
+// Requests with the same callerReference and paths are only carried out once, so a retried
+// request does not start a second invalidation.
+func (c *CloudFrontInvalidator) Invalidate(ctx context.Context, paths []string, callerReference string) (string, error) {
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	payload, err := xml.Marshal(cloudFrontInvalidationBatch{
+		CallerReference: callerReference,
+		Quantity:        len(paths),
+		Items:           paths,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal invalidation: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/2020-05-31/distribution/%s/invalidation", c.endpoint, url.PathEscape(c.distributionID))
+	body, err := c.requester.post(ctx, "invalidation", endpoint, map[string]string{
+		"Content-Type": "application/xml",
+	}, payload)
+	if err != nil {
+		return "", err
+	}
+
+	var invalidation cloudFrontInvalidation
+	if err := xml.Unmarshal(body, &invalidation); err != nil {
+		return "", fmt.Errorf("failed to unmarshal invalidation response: %w", err)
+	}
+	return invalidation.ID, nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCloudFrontInvalidatorInvalidate(t *testing.T) {
+	t.Run("SignsAndInvalidates", func(t *testing.T) {
+		var gotPath, gotAuth string
+		var gotBatch cloudFrontInvalidationBatch
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotAuth = r.Header.Get("Authorization")
+			body, _ := io.ReadAll(r.Body)
+			if err := xml.Unmarshal(body, &gotBatch); err != nil {
+				t.Errorf("Invalid invalidation body %s: %v", body, err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`<Invalidation xmlns="http://cloudfront.amazonaws.com/doc/2020-05-31/"><Id>I2J0I21PCUYOIK</Id><Status>InProgress</Status></Invalidation>`))
+		}))
+		defer server.Close()
+
+		invalidator := NewCloudFrontInvalidator(testAWSConfig(), "E2QWRUHAPOMQZL")
+		invalidator.endpoint = server.URL
+
+		paths := []string{"/api/events/approved*", "/api/activities/story-time"}
+		id, err := invalidator.Invalidate(context.Background(), paths, "catalog-changes-1-2")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if id != "I2J0I21PCUYOIK" {
+			t.Errorf("Expected invalidation I2J0I21PCUYOIK, got %q", id)
+		}
+		if gotPath != "/2020-05-31/distribution/E2QWRUHAPOMQZL/invalidation" {
+			t.Errorf("Unexpected invalidation path %q", gotPath)
+		}
+		if gotBatch.CallerReference != "catalog-changes-1-2" || gotBatch.Quantity != 2 || strings.Join(gotBatch.Items, ",") != strings.Join(paths, ",") {
+			t.Errorf("Unexpected invalidation batch %+v", gotBatch)
+		}
+		if !strings.Contains(gotAuth, "/us-east-1/cloudfront/") {
+			t.Errorf("Expected SigV4 authorization for cloudfront in us-east-1, got %q", gotAuth)
+		}
+	})
+
+	t.Run("SkipsEmptyPaths", func(t *testing.T) {
+		invalidator := NewCloudFrontInvalidator(testAWSConfig(), "E2QWRUHAPOMQZL")
+		invalidator.endpoint = "http://127.0.0.1:0" // never called
+
+		id, err := invalidator.Invalidate(context.Background(), nil, "catalog-changes-1-2")
+		if err != nil || id != "" {
+			t.Errorf("Expected nothing to be invalidated, got %q, %v", id, err)
+		}
+	})
+
+	t.Run("ReportsErrorStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`<ErrorResponse><Error><Code>TooManyInvalidationsInProgress</Code></Error></ErrorResponse>`))
+		}))
+		defer server.Close()
+
+		invalidator := NewCloudFrontInvalidator(testAWSConfig(), "E2QWRUHAPOMQZL")
+		invalidator.endpoint = server.URL
+
+		_, err := invalidator.Invalidate(context.Background(), []string{"/api/changes*"}, "catalog-changes-1-2")
+		if err == nil || !strings.Contains(err.Error(), "TooManyInvalidationsInProgress") {
+			t.Errorf("Expected throttling error, got %v", err)
+		}
+	})
+}
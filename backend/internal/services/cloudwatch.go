@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// CloudWatchClient reads metrics from CloudWatch using the CloudWatch JSON protocol
+type CloudWatchClient struct {
+	endpoint  string
+	requester *awsRequester
+}
+
+// NewCloudWatchClient creates a CloudWatch client from the AWS configuration
+func NewCloudWatchClient(cfg aws.Config) *CloudWatchClient {
+	return &CloudWatchClient{
+		endpoint:  fmt.Sprintf("https://monitoring.%s.amazonaws.com/", cfg.Region),
+		requester: newAWSRequester(cfg, "monitoring", cfg.Region, 10*time.Second),
+	}
+}
+
+// LambdaErrorRate is how often a Lambda function failed over a window
+type LambdaErrorRate struct {
+	FunctionName string  `json:"function_name"`
+	Invocations  int     `json:"invocations"`
+	Errors       int     `json:"errors"`
+	Throttles    int     `json:"throttles"`
+	ErrorRate    float64 `json:"error_rate"` // errors per invocation, 0 when the function was not invoked
+}
+
+// metricDataResult is a single series in a GetMetricData response
+type metricDataResult struct {
+	ID     string    `json:"Id"`
+	Values []float64 `json:"Values"`
+}
+
+// GetLambdaErrorRates sums the invocations, errors and throttles of each function over the
+// window ending now and returns them in the order the functions were given
+func (c *CloudWatchClient) GetLambdaErrorRates(ctx context.Context, functionNames []string, window time.Duration) ([]LambdaErrorRate, error) {
+	if len(functionNames) == 0 {
+		return []LambdaErrorRate{}, nil
+	}
+
+	metrics := []string{"Invocations", "Errors", "Throttles"}
+	period := int(window / time.Second)
+	// CloudWatch periods of a minute or more must be a multiple of 60 seconds
+	if period < 60 {
+		period = 60
+	}
+	period -= period % 60
+
+	var queries []map[string]interface{}
+	for i, functionName := range functionNames {
+		for j, metric := range metrics {
+			queries = append(queries, map[string]interface{}{
+				"Id": fmt.Sprintf("m%d_%d", i, j),
+				"MetricStat": map[string]interface{}{
+					"Metric": map[string]interface{}{
+						"Namespace":  "AWS/Lambda",
+						"MetricName": metric,
+						"Dimensions": []map[string]string{{"Name": "FunctionName", "Value": functionName}},
+					},
+					"Period": period,
+					"Stat":   "Sum",
+				},
+				"ReturnData": true,
+			})
+		}
+	}
+
+	end := time.Now()
+	input := map[string]interface{}{
+		"MetricDataQueries": queries,
+		"StartTime":         end.Add(-window).Unix(),
+		"EndTime":           end.Unix(),
+	}
+
+	totals := make(map[string]float64)
+	for {
+		var response struct {
+			MetricDataResults []metricDataResult `json:"MetricDataResults"`
+			NextToken         string             `json:"NextToken"`
+		}
+		if err := c.call(ctx, "GetMetricData", input, &response); err != nil {
+			return nil, err
+		}
+		for _, result := range response.MetricDataResults {
+			for _, value := range result.Values {
+				totals[result.ID] += value
+			}
+		}
+		if response.NextToken == "" {
+			break
+		}
+		input["NextToken"] = response.NextToken
+	}
+
+	rates := make([]LambdaErrorRate, 0, len(functionNames))
+	for i, functionName := range functionNames {
+		rate := LambdaErrorRate{
+			FunctionName: functionName,
+			Invocations:  int(totals[fmt.Sprintf("m%d_0", i)]),
+			Errors:       int(totals[fmt.Sprintf("m%d_1", i)]),
+			Throttles:    int(totals[fmt.Sprintf("m%d_2", i)]),
+		}
+		if rate.Invocations > 0 {
+			rate.ErrorRate = math.Round(float64(rate.Errors)/float64(rate.Invocations)*10000) / 10000
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// call signs and sends a single CloudWatch JSON protocol request
+func (c *CloudWatchClient) call(ctx context.Context, action string, input interface{}, output interface{}) error {
+	return c.requester.callJSON(ctx, c.endpoint, "GraniteServiceVersion20100801", action, input, output)
+}
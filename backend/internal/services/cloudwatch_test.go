@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloudWatchClientGetLambdaErrorRates(t *testing.T) {
+	t.Run("SumsSeriesPerFunction", func(t *testing.T) {
+		var gotTarget, gotAuth string
+		var input struct {
+			MetricDataQueries []struct {
+				Id         string
+				MetricStat struct {
+					Metric struct {
+						MetricName string
+						Dimensions []struct{ Name, Value string }
+					}
+					Period int
+				}
+			}
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotTarget = r.Header.Get("X-Amz-Target")
+			gotAuth = r.Header.Get("Authorization")
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				t.Errorf("Invalid request body: %v", err)
+			}
+			w.Write([]byte(`{"MetricDataResults":[
+				{"Id":"m0_0","Values":[40,60]},
+				{"Id":"m0_1","Values":[3,2]},
+				{"Id":"m0_2","Values":[]},
+				{"Id":"m1_0","Values":[]},
+				{"Id":"m1_1","Values":[]},
+				{"Id":"m1_2","Values":[]}
+			]}`))
+		}))
+		defer server.Close()
+
+		client := NewCloudWatchClient(testAWSConfig())
+		client.endpoint = server.URL
+
+		rates, err := client.GetLambdaErrorRates(context.Background(), []string{"scrape-executor", "orchestrator"}, time.Hour)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if gotTarget != "GraniteServiceVersion20100801.GetMetricData" {
+			t.Errorf("Unexpected request target %q", gotTarget)
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/monitoring/") {
+			t.Errorf("Expected SigV4 authorization for monitoring, got %q", gotAuth)
+		}
+		if len(input.MetricDataQueries) != 6 {
+			t.Fatalf("Expected 3 queries per function, got %d", len(input.MetricDataQueries))
+		}
+		first := input.MetricDataQueries[1].MetricStat
+		if first.Metric.MetricName != "Errors" || first.Metric.Dimensions[0].Value != "scrape-executor" || first.Period != 3600 {
+			t.Errorf("Unexpected query %+v", first)
+		}
+
+		if len(rates) != 2 {
+			t.Fatalf("Expected 2 rates, got %d", len(rates))
+		}
+		if rates[0].Invocations != 100 || rates[0].Errors != 5 || rates[0].ErrorRate != 0.05 {
+			t.Errorf("Unexpected executor rate %+v", rates[0])
+		}
+		if rates[1].FunctionName != "orchestrator" || rates[1].Invocations != 0 || rates[1].ErrorRate != 0 {
+			t.Errorf("Expected an idle orchestrator with no error rate, got %+v", rates[1])
+		}
+	})
+
+	t.Run("ReportsErrors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"AccessDeniedException"}`))
+		}))
+		defer server.Close()
+
+		client := NewCloudWatchClient(testAWSConfig())
+		client.endpoint = server.URL
+
+		_, err := client.GetLambdaErrorRates(context.Background(), []string{"scrape-executor"}, time.Hour)
+		if err == nil || !strings.Contains(err.Error(), "AccessDeniedException") {
+			t.Errorf("Expected access denied error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ErrConcurrencyLimitReached is returned when no provider slot frees up within the wait limit
+var ErrConcurrencyLimitReached = errors.New("concurrency limit reached")
+
+// ConcurrencyLimiter caps concurrent calls to an external API provider.
+// Callers must invoke the returned release function once the call finishes.
+type ConcurrencyLimiter interface {
+	Acquire(ctx context.Context, provider string) (release func(), err error)
+}
+
+// ConcurrencyLeaseStore persists the slots of each provider's semaphore
+type ConcurrencyLeaseStore interface {
+	AcquireConcurrencySlot(ctx context.Context, lease *models.ConcurrencyLease) (bool, error)
+	ReleaseConcurrencySlot(ctx context.Context, provider string, slot int, holderID string) error
+}
+
+// ConcurrencySettingsProvider supplies the current per-provider concurrency caps
+type ConcurrencySettingsProvider interface {
+	ConcurrencySettings() *models.ConcurrencySettings
+}
+
+// LeaseConcurrencyLimiter is a distributed semaphore built on expiring DynamoDB leases.
+// Each provider has one item per slot; a caller holds a slot by writing its lease to a free
+// or expired item and frees it by deleting the item.
+type LeaseConcurrencyLimiter struct {
+	store        ConcurrencyLeaseStore
+	settings     ConcurrencySettingsProvider
+	stats        *ThrottleStatsCollector
+	maxWait      time.Duration
+	pollInterval time.Duration
+}
+
+// NewLeaseConcurrencyLimiter creates a limiter that waits up to maxWait for a free slot
+func NewLeaseConcurrencyLimiter(store ConcurrencyLeaseStore, settings ConcurrencySettingsProvider, maxWait time.Duration) *LeaseConcurrencyLimiter {
+	return &LeaseConcurrencyLimiter{
+		store:        store,
+		settings:     settings,
+		stats:        NewThrottleStatsCollector(),
+		maxWait:      maxWait,
+		pollInterval: 500 * time.Millisecond,
+	}
+}
+
+// Stats returns the collector recording throttle events for this limiter
+func (l *LeaseConcurrencyLimiter) Stats() *ThrottleStatsCollector {
+	return l.stats
+}
+
+// Acquire waits for a free slot for the provider. A limit of 0 disables limiting.
+// If the lease store is unavailable the call proceeds unthrottled rather than failing.
+func (l *LeaseConcurrencyLimiter) Acquire(ctx context.Context, provider string) (func(), error) {
+	settings := l.settings.ConcurrencySettings()
+	limit, ok := settings.Limits[provider]
+	if !ok {
+		limit = models.DefaultConcurrencyLimits[provider]
+	}
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	holderID := uuid.New().String()
+	start := time.Now()
+	throttled := false
+
+	for {
+		slot, err := l.tryAcquire(ctx, provider, limit, holderID, settings.LeaseSeconds)
+		if err != nil {
+			log.Printf("Warning: Concurrency limiter unavailable for %s, proceeding without a slot: %v", provider, err)
+			return func() {}, nil
+		}
+		if slot >= 0 {
+			l.stats.Record(provider, throttled, false, time.Since(start))
+			return l.releaseFunc(provider, slot, holderID), nil
+		}
+
+		if !throttled {
+			throttled = true
+			log.Printf("All %d %s slots are busy, waiting for one to free up", limit, provider)
+		}
+		if time.Since(start) >= l.maxWait {
+			l.stats.Record(provider, true, true, time.Since(start))
+			return nil, fmt.Errorf("%w: all %d %s slots busy after %s", ErrConcurrencyLimitReached, limit, provider, l.maxWait)
+		}
+
+		// Jitter keeps waiting Lambdas from retrying in lockstep
+		wait := l.pollInterval + time.Duration(rand.Int63n(int64(l.pollInterval)+1))
+		select {
+		case <-ctx.Done():
+			l.stats.Record(provider, true, true, time.Since(start))
+			return nil, fmt.Errorf("%w: %v", ErrConcurrencyLimitReached, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// tryAcquire attempts each slot once, starting at a random one to spread contention.
+// It returns the claimed slot, or -1 if every slot is held.
+func (l *LeaseConcurrencyLimiter) tryAcquire(ctx context.Context, provider string, limit int, holderID string, leaseSeconds int) (int, error) {
+	if leaseSeconds <= 0 {
+		leaseSeconds = models.DefaultConcurrencyLeaseSeconds
+	}
+
+	offset := rand.Intn(limit)
+	for i := 0; i < limit; i++ {
+		now := time.Now()
+		lease := &models.ConcurrencyLease{
+			Provider:   provider,
+			Slot:       (offset + i) % limit,
+			HolderID:   holderID,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(time.Duration(leaseSeconds) * time.Second).Unix(),
+		}
+		acquired, err := l.store.AcquireConcurrencySlot(ctx, lease)
+		if err != nil {
+			return -1, err
+		}
+		if acquired {
+			return lease.Slot, nil
+		}
+	}
+	return -1, nil
+}
+
+// releaseFunc returns an idempotent function that frees the slot.
+// A failed release is only logged; the lease expires on its own.
+func (l *LeaseConcurrencyLimiter) releaseFunc(provider string, slot int, holderID string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := l.store.ReleaseConcurrencySlot(ctx, provider, slot, holderID); err != nil {
+				log.Printf("Warning: Failed to release %s slot %d: %v", provider, slot, err)
+			}
+		})
+	}
+}
+
+// ConcurrencySettingsCache serves the concurrency settings stored in DynamoDB, refreshing them after a TTL.
+// The defaults apply until settings are stored; a failed load keeps the previous settings.
+type ConcurrencySettingsCache struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	settings *models.ConcurrencySettings
+	loadedAt time.Time
+}
+
+// NewConcurrencySettingsCache creates a concurrency settings cache backed by DynamoDB
+func NewConcurrencySettingsCache(dynamo *DynamoDBService, ttl time.Duration) *ConcurrencySettingsCache {
+	return &ConcurrencySettingsCache{
+		dynamo:   dynamo,
+		ttl:      ttl,
+		settings: models.NewDefaultConcurrencySettings(),
+	}
+}
+
+// ConcurrencySettings returns the current settings, loading them if the cached copy is stale
+func (c *ConcurrencySettingsCache) ConcurrencySettings() *models.ConcurrencySettings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.settings
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	settings, err := c.dynamo.GetConcurrencySettings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load concurrency settings, keeping previous limits: %v", err)
+	} else if settings != nil {
+		c.settings = settings
+	} else {
+		c.settings = models.NewDefaultConcurrencySettings()
+	}
+
+	c.loadedAt = time.Now()
+	return c.settings
+}
+
+// Invalidate drops the cached settings so the next lookup reloads them
+func (c *ConcurrencySettingsCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
+
+// ThrottleStats counts how often callers had to wait for a provider slot
+type ThrottleStats struct {
+	Acquired    int   `json:"acquired"`  // slots acquired, with or without waiting
+	Throttled   int   `json:"throttled"` // acquisitions that found every slot busy at first
+	TimedOut    int   `json:"timed_out"` // acquisitions that gave up waiting
+	TotalWaitMs int64 `json:"total_wait_ms"`
+}
+
+// ThrottleStatsCollector aggregates throttle events per provider between flushes
+type ThrottleStatsCollector struct {
+	mu         sync.Mutex
+	byProvider map[string]*ThrottleStats
+}
+
+// NewThrottleStatsCollector creates an empty throttle stats collector
+func NewThrottleStatsCollector() *ThrottleStatsCollector {
+	return &ThrottleStatsCollector{byProvider: make(map[string]*ThrottleStats)}
+}
+
+// Record adds the outcome of one slot acquisition
+func (c *ThrottleStatsCollector) Record(provider string, throttled, timedOut bool, wait time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, exists := c.byProvider[provider]
+	if !exists {
+		stats = &ThrottleStats{}
+		c.byProvider[provider] = stats
+	}
+	if timedOut {
+		stats.TimedOut++
+	} else {
+		stats.Acquired++
+	}
+	if throttled {
+		stats.Throttled++
+		stats.TotalWaitMs += wait.Milliseconds()
+	}
+}
+
+// Snapshot returns a copy of the current stats keyed by provider
+func (c *ThrottleStatsCollector) Snapshot() map[string]ThrottleStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[string]ThrottleStats, len(c.byProvider))
+	for provider, stats := range c.byProvider {
+		snapshot[provider] = *stats
+	}
+	return snapshot
+}
+
+// FlushToCloudWatch writes the collected stats as CloudWatch Embedded Metric Format records
+// dimensioned by provider, then resets the collector
+func (c *ThrottleStatsCollector) FlushToCloudWatch(namespace string) {
+	c.mu.Lock()
+	snapshot := c.byProvider
+	c.byProvider = make(map[string]*ThrottleStats)
+	c.mu.Unlock()
+
+	providers := make([]string, 0, len(snapshot))
+	for provider := range snapshot {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	for _, provider := range providers {
+		stats := snapshot[provider]
+		writeEMF(namespace, []emfDimension{{Name: "Provider", Value: provider}}, []emfMetric{
+			{Name: "SlotsAcquired", Unit: "Count", Value: stats.Acquired},
+			{Name: "ThrottledCalls", Unit: "Count", Value: stats.Throttled},
+			{Name: "ThrottleTimeouts", Unit: "Count", Value: stats.TimedOut},
+			{Name: "ThrottleWaitMs", Unit: "Milliseconds", Value: stats.TotalWaitMs},
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// memoryLeaseStore keeps semaphore slots in memory with the same semantics as DynamoDB
+type memoryLeaseStore struct {
+	mu     sync.Mutex
+	leases map[string]models.ConcurrencyLease
+	err    error
+}
+
+func newMemoryLeaseStore() *memoryLeaseStore {
+	return &memoryLeaseStore{leases: make(map[string]models.ConcurrencyLease)}
+}
+
+func (m *memoryLeaseStore) AcquireConcurrencySlot(ctx context.Context, lease *models.ConcurrencyLease) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+
+	key := models.CreateConcurrencyPK(lease.Provider) + models.CreateConcurrencySlotSK(lease.Slot)
+	if existing, held := m.leases[key]; held && existing.ExpiresAt >= time.Now().Unix() {
+		return false, nil
+	}
+	m.leases[key] = *lease
+	return true, nil
+}
+
+func (m *memoryLeaseStore) ReleaseConcurrencySlot(ctx context.Context, provider string, slot int, holderID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := models.CreateConcurrencyPK(provider) + models.CreateConcurrencySlotSK(slot)
+	if m.leases[key].HolderID == holderID {
+		delete(m.leases, key)
+	}
+	return nil
+}
+
+func (m *memoryLeaseStore) held() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.leases)
+}
+
+// staticConcurrencySettings serves fixed concurrency settings
+type staticConcurrencySettings struct {
+	settings *models.ConcurrencySettings
+}
+
+func (s staticConcurrencySettings) ConcurrencySettings() *models.ConcurrencySettings {
+	return s.settings
+}
+
+func newTestLimiter(store ConcurrencyLeaseStore, limits map[string]int) *LeaseConcurrencyLimiter {
+	limiter := NewLeaseConcurrencyLimiter(store, staticConcurrencySettings{settings: &models.ConcurrencySettings{
+		Limits:       limits,
+		LeaseSeconds: 60,
+	}}, 50*time.Millisecond)
+	limiter.pollInterval = 5 * time.Millisecond
+	return limiter
+}
+
+func TestLeaseConcurrencyLimiter(t *testing.T) {
+	t.Run("CapsConcurrentHolders", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		limiter := newTestLimiter(store, map[string]int{models.ProviderFirecrawl: 2})
+		ctx := context.Background()
+
+		release1, err := limiter.Acquire(ctx, models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("First acquire failed: %v", err)
+		}
+		release2, err := limiter.Acquire(ctx, models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Second acquire failed: %v", err)
+		}
+		if store.held() != 2 {
+			t.Errorf("Expected 2 held slots, got %d", store.held())
+		}
+
+		if _, err := limiter.Acquire(ctx, models.ProviderFirecrawl); !errors.Is(err, ErrConcurrencyLimitReached) {
+			t.Errorf("Expected the third caller to time out, got %v", err)
+		}
+
+		release1()
+		release1() // releasing twice is harmless
+		release3, err := limiter.Acquire(ctx, models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Expected a freed slot to be reused, got %v", err)
+		}
+		release2()
+		release3()
+		if store.held() != 0 {
+			t.Errorf("Expected all slots released, got %d", store.held())
+		}
+
+		stats := limiter.Stats().Snapshot()[models.ProviderFirecrawl]
+		if stats.Acquired != 3 || stats.TimedOut != 1 || stats.Throttled != 1 {
+			t.Errorf("Unexpected throttle stats: %+v", stats)
+		}
+	})
+
+	t.Run("WaitsForRelease", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		limiter := newTestLimiter(store, map[string]int{models.ProviderFirecrawl: 1})
+		limiter.maxWait = time.Second
+
+		release, err := limiter.Acquire(context.Background(), models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Acquire failed: %v", err)
+		}
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			release()
+		}()
+
+		release, err = limiter.Acquire(context.Background(), models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Expected the waiting caller to get the slot, got %v", err)
+		}
+		release()
+
+		stats := limiter.Stats().Snapshot()[models.ProviderFirecrawl]
+		if stats.Throttled != 1 || stats.TotalWaitMs <= 0 {
+			t.Errorf("Expected the wait to be recorded, got %+v", stats)
+		}
+	})
+
+	t.Run("ReclaimsExpiredLeases", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		store.leases[models.CreateConcurrencyPK(models.ProviderOpenAI)+models.CreateConcurrencySlotSK(0)] = models.ConcurrencyLease{
+			Provider:  models.ProviderOpenAI,
+			HolderID:  "crashed-lambda",
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		}
+		limiter := newTestLimiter(store, map[string]int{models.ProviderOpenAI: 1})
+
+		release, err := limiter.Acquire(context.Background(), models.ProviderOpenAI)
+		if err != nil {
+			t.Fatalf("Expected the expired lease to be reclaimed, got %v", err)
+		}
+		release()
+	})
+
+	t.Run("ZeroLimitDisablesLimiting", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		limiter := newTestLimiter(store, map[string]int{models.ProviderFirecrawl: 0})
+
+		for i := 0; i < 3; i++ {
+			if _, err := limiter.Acquire(context.Background(), models.ProviderFirecrawl); err != nil {
+				t.Fatalf("Expected unlimited acquires, got %v", err)
+			}
+		}
+		if store.held() != 0 {
+			t.Errorf("Expected no leases to be written, got %d", store.held())
+		}
+	})
+
+	t.Run("StoreErrorsFailOpen", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		store.err = errors.New("throttled by DynamoDB")
+		limiter := newTestLimiter(store, map[string]int{models.ProviderFirecrawl: 1})
+
+		release, err := limiter.Acquire(context.Background(), models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Expected the call to proceed without a slot, got %v", err)
+		}
+		release()
+	})
+
+	t.Run("MissingProviderUsesDefault", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		limiter := newTestLimiter(store, map[string]int{})
+
+		release, err := limiter.Acquire(context.Background(), models.ProviderFirecrawl)
+		if err != nil {
+			t.Fatalf("Acquire failed: %v", err)
+		}
+		if store.held() != 1 {
+			t.Errorf("Expected the default limit to apply, got %d held slots", store.held())
+		}
+		release()
+	})
+}
+
+func TestConcurrencySettingsValidate(t *testing.T) {
+	if err := models.NewDefaultConcurrencySettings().Validate(); err != nil {
+		t.Errorf("Expected default settings to be valid, got %v", err)
+	}
+
+	invalid := []*models.ConcurrencySettings{
+		{Limits: map[string]int{"geocoder": 1}, LeaseSeconds: 60},
+		{Limits: map[string]int{models.ProviderFirecrawl: -1}, LeaseSeconds: 60},
+		{Limits: map[string]int{models.ProviderFirecrawl: 5}, LeaseSeconds: 1},
+	}
+	for _, settings := range invalid {
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", settings)
+		}
+	}
+}
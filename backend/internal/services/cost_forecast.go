@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Pricing constants used for cost forecasts. Update these when provider pricing changes.
+const (
+	// FireCrawl Standard plan: $83 per 100,000 credits
+	FireCrawlDollarsPerCredit = 83.0 / 100000
+
+	// FireCrawl credits per page. Executors fetch each page with a plain scrape and parse it
+	// locally, so a page costs one scrape credit whatever the extraction method.
+	FireCrawlCreditsPerScrape = 1
+)
+
+// scrapesPerMonth maps scraping frequencies to the average number of runs per month
+var scrapesPerMonth = map[string]float64{
+	"hourly":    24 * 30,
+	"daily":     30,
+	"weekly":    52.0 / 12,
+	"biweekly":  26.0 / 12,
+	"bi-weekly": 26.0 / 12,
+	"monthly":   1,
+}
+
+// CostForecast is the projected monthly cost of scraping a source
+type CostForecast struct {
+	Frequency       string  `json:"frequency"`
+	PagesPerScrape  int     `json:"pages_per_scrape"`
+	ScrapesPerMonth float64 `json:"scrapes_per_month"`
+
+	FireCrawlCreditsPerMonth int     `json:"firecrawl_credits_per_month"`
+	FireCrawlCostPerMonth    float64 `json:"firecrawl_cost_per_month"` // USD
+	TotalCostPerMonth        float64 `json:"total_cost_per_month"`     // USD
+
+	Assumptions []string `json:"assumptions"`
+}
+
+// ForecastScrapingCost projects monthly credits and dollars for a source from its analysis. Each
+// target URL is scraped once per run and parsed without an LLM, so FireCrawl credits are the only cost.
+// frequency overrides the recommended scraping frequency when non-empty.
+func ForecastScrapingCost(analysis *models.SourceAnalysis, frequency string) *CostForecast {
+	config := analysis.RecommendedConfig
+	forecast := &CostForecast{
+		Frequency:      strings.ToLower(strings.TrimSpace(frequency)),
+		PagesPerScrape: len(config.TargetURLs),
+	}
+
+	if forecast.Frequency == "" {
+		forecast.Frequency = strings.ToLower(strings.TrimSpace(config.ScrapingFrequency))
+	}
+	runs, known := scrapesPerMonth[forecast.Frequency]
+	if !known {
+		forecast.Assumptions = append(forecast.Assumptions, fmt.Sprintf("unknown frequency %q, assuming weekly", forecast.Frequency))
+		forecast.Frequency = "weekly"
+		runs = scrapesPerMonth["weekly"]
+	}
+	forecast.ScrapesPerMonth = math.Round(runs*100) / 100
+
+	if forecast.PagesPerScrape == 0 {
+		forecast.PagesPerScrape = 1
+		forecast.Assumptions = append(forecast.Assumptions, "no target URLs recommended, assuming the base URL only")
+	}
+
+	pagesPerMonth := float64(forecast.PagesPerScrape) * runs
+	forecast.FireCrawlCreditsPerMonth = int(math.Ceil(pagesPerMonth * FireCrawlCreditsPerScrape))
+	forecast.FireCrawlCostPerMonth = roundCents(float64(forecast.FireCrawlCreditsPerMonth) * FireCrawlDollarsPerCredit)
+	forecast.TotalCostPerMonth = forecast.FireCrawlCostPerMonth
+
+	return forecast
+}
+
+// roundCents rounds a dollar amount to the nearest cent
+func roundCents(dollars float64) float64 {
+	return math.Round(dollars*100) / 100
+}
+
+// SummarizeDailyUsage totals the pages, activities and FireCrawl credits of the fan-out runs
+// started on one day
+func SummarizeDailyUsage(date string, runs []models.FanOutRun) *models.DailyScrapingUsage {
+	usage := &models.DailyScrapingUsage{Date: date, Runs: len(runs)}
+	for _, run := range runs {
+		// Cancelled tasks never fetched their page
+		usage.Pages += run.CompletedTasks + run.FailedTasks
+		usage.Activities += run.TotalActivities
+		usage.Credits += run.CreditsUsed
+	}
+	return usage
+}
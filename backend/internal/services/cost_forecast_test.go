@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestForecastScrapingCost(t *testing.T) {
+	analysis := &models.SourceAnalysis{
+		RecommendedConfig: models.RecommendedSourceConfig{
+			ScrapingFrequency:       "daily",
+			PreferredExtraction:     "html",
+			EstimatedItemsPerScrape: "10-20",
+			TargetURLs:              []string{"https://example.com/events", "https://example.com/classes"},
+		},
+	}
+
+	t.Run("ScrapeCreditsPerPage", func(t *testing.T) {
+		forecast := ForecastScrapingCost(analysis, "")
+
+		if forecast.ScrapesPerMonth != 30 || forecast.PagesPerScrape != 2 {
+			t.Fatalf("Unexpected inputs: %+v", forecast)
+		}
+		// 2 pages * 30 runs * 1 credit
+		if forecast.FireCrawlCreditsPerMonth != 60 {
+			t.Errorf("Expected 60 credits, got %d", forecast.FireCrawlCreditsPerMonth)
+		}
+		if forecast.FireCrawlCostPerMonth != 0.05 || forecast.TotalCostPerMonth != forecast.FireCrawlCostPerMonth {
+			t.Errorf("Expected FireCrawl credits to be the whole cost, got %+v", forecast)
+		}
+	})
+
+	t.Run("SameCostForEveryExtractionMethod", func(t *testing.T) {
+		structured := *analysis
+		structured.RecommendedConfig.PreferredExtraction = "structured-data"
+
+		if html, data := ForecastScrapingCost(analysis, ""), ForecastScrapingCost(&structured, ""); html.TotalCostPerMonth != data.TotalCostPerMonth {
+			t.Errorf("Expected the same cost for html and structured data, got %.2f and %.2f", html.TotalCostPerMonth, data.TotalCostPerMonth)
+		}
+	})
+
+	t.Run("FrequencyOverride", func(t *testing.T) {
+		daily := ForecastScrapingCost(analysis, "")
+		weekly := ForecastScrapingCost(analysis, "Weekly")
+		if weekly.Frequency != "weekly" || weekly.FireCrawlCreditsPerMonth >= daily.FireCrawlCreditsPerMonth {
+			t.Errorf("Expected weekly override to cost less than daily, got %d vs %d", weekly.FireCrawlCreditsPerMonth, daily.FireCrawlCreditsPerMonth)
+		}
+	})
+
+	t.Run("FallbackAssumptions", func(t *testing.T) {
+		forecast := ForecastScrapingCost(&models.SourceAnalysis{}, "")
+		if forecast.Frequency != "weekly" || forecast.PagesPerScrape != 1 {
+			t.Errorf("Unexpected defaults: %+v", forecast)
+		}
+		if len(forecast.Assumptions) != 2 {
+			t.Errorf("Expected 2 assumptions to be recorded, got %v", forecast.Assumptions)
+		}
+	})
+}
+
+func TestSummarizeDailyUsage(t *testing.T) {
+	runs := []models.FanOutRun{
+		{RunID: "run-1", CompletedTasks: 3, FailedTasks: 1, CancelledTasks: 2, TotalActivities: 25, CreditsUsed: 20},
+		{RunID: "run-2", CompletedTasks: 1, TotalActivities: 5, CreditsUsed: 5},
+	}
+
+	usage := SummarizeDailyUsage("2026-10-16", runs)
+
+	if usage.Date != "2026-10-16" || usage.Runs != 2 {
+		t.Errorf("Unexpected date or run count: %+v", usage)
+	}
+	if usage.Pages != 5 {
+		t.Errorf("Expected cancelled tasks to be left out of the 5 pages, got %d", usage.Pages)
+	}
+	if usage.Activities != 30 || usage.Credits != 25 {
+		t.Errorf("Expected 30 activities and 25 credits, got %d and %d", usage.Activities, usage.Credits)
+	}
+
+	if empty := SummarizeDailyUsage("2026-10-17", nil); empty.Runs != 0 || empty.Credits != 0 {
+		t.Errorf("Expected no usage without runs, got %+v", empty)
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Bounds of date range queries. Each day is its own DynamoDB query, so a "next 30 days" query
+// makes 30 of them, DateRangeParallelism at a time.
+const (
+	DateRangeParallelism = 8
+	maxDateRangeDays     = 92
+)
+
+// DayQuery returns every calendar entry on a date (YYYY-MM-DD)
+type DayQuery func(ctx context.Context, date string) ([]models.CalendarEntry, error)
+
+// QueryDateRange runs a day query for every day from one date to another (inclusive), up to
+// parallelism at a time, and merges the results ordered by date, then start time and title. With
+// a limit, only the first limit entries are returned, and days after the ones that fill the limit
+// are not queried once it is known they cannot contribute. Days are always read whole, since a
+// day's entries are only ordered by time once merged. The first failing day fails the query.
+func QueryDateRange(ctx context.Context, from, to time.Time, limit, parallelism int, queryDay DayQuery) ([]models.CalendarEntry, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("date range ends before it starts")
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxDateRangeDays {
+		return nil, fmt.Errorf("date range can be at most %d days", maxDateRangeDays)
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make([][]models.CalendarEntry, days)
+		done     = make([]bool, days)
+		cutoff   = days // days after the cutoff are not needed
+		firstErr error
+	)
+
+	// Days are handed out in order, so the earliest days are queried first
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for i := 0; i < days; i++ {
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for worker := 0; worker < parallelism && worker < days; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if ctx.Err() != nil {
+					continue
+				}
+				date := from.AddDate(0, 0, i).Format("2006-01-02")
+				entries, err := queryDay(ctx, date)
+
+				mu.Lock()
+				switch {
+				case i > cutoff:
+				case err != nil:
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to query %s: %w", date, err)
+					}
+					cancel()
+				default:
+					results[i] = entries
+					done[i] = true
+
+					// Once the days so far fill the limit, the rest cannot make the cut
+					if limit > 0 {
+						total := 0
+						for day := 0; day < days && done[day]; day++ {
+							total += len(results[day])
+							if total >= limit {
+								cutoff = day
+								cancel()
+								break
+							}
+						}
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := parent.Err(); err != nil && cutoff == days {
+		return nil, err // canceled by the caller, so days may be missing
+	}
+
+	merged := []models.CalendarEntry{}
+	for day := 0; day < days && day <= cutoff; day++ {
+		entries := append([]models.CalendarEntry(nil), results[day]...)
+		sort.SliceStable(entries, func(a, b int) bool {
+			if entries[a].StartTime != entries[b].StartTime {
+				return entries[a].StartTime < entries[b].StartTime
+			}
+			return entries[a].Title < entries[b].Title
+		})
+		merged = append(merged, entries...)
+		if limit > 0 && len(merged) >= limit {
+			return merged[:limit], nil
+		}
+	}
+	return merged, nil
+}
+
+// OnCalendar keeps the activities with calendar entries among entries, along with undated
+// activities. Undated activities have no calendar entries, so only their schedule can place them.
+func OnCalendar(activities []*models.PublicActivity, entries []models.CalendarEntry) []*models.PublicActivity {
+	scheduled := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		scheduled[entry.ActivityID] = true
+	}
+
+	kept := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if _, err := time.Parse("2006-01-02", activity.Schedule.StartDate); err != nil || scheduled[activity.ID] {
+			kept = append(kept, activity)
+		}
+	}
+	return kept
+}
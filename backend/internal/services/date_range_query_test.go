@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestQueryDateRange(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2025-06-01")
+	to := from.AddDate(0, 0, 29)
+
+	var running, peak int32
+	var mu sync.Mutex
+	queried := map[string]bool{}
+	queryDay := func(ctx context.Context, date string) ([]models.CalendarEntry, error) {
+		now := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if now <= seen || atomic.CompareAndSwapInt32(&peak, seen, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		queried[date] = true
+		mu.Unlock()
+		// Returned in key order rather than time order
+		return []models.CalendarEntry{
+			{ActivityID: "b", Date: date, StartTime: "14:00", Title: "Park Play"},
+			{ActivityID: "a", Date: date, StartTime: "10:00", Title: "Story Time"},
+		}, nil
+	}
+
+	entries, err := QueryDateRange(context.Background(), from, to, 0, 4, queryDay)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 60 || len(queried) != 30 {
+		t.Fatalf("Expected 60 entries from 30 days, got %d from %d", len(entries), len(queried))
+	}
+	if peak > 4 {
+		t.Errorf("Expected at most 4 concurrent day queries, saw %d", peak)
+	}
+	if entries[0].Date != "2025-06-01" || entries[0].StartTime != "10:00" || entries[1].StartTime != "14:00" || entries[59].Date != "2025-06-30" {
+		t.Errorf("Expected entries ordered by date and time, got %+v ... %+v", entries[0], entries[59])
+	}
+}
+
+func TestQueryDateRangeLimit(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2025-06-01")
+	var queries int32
+	queryDay := func(ctx context.Context, date string) ([]models.CalendarEntry, error) {
+		atomic.AddInt32(&queries, 1)
+		if date == "2025-06-01" {
+			return nil, nil // a quiet day
+		}
+		return []models.CalendarEntry{{ActivityID: date, Date: date}, {ActivityID: date + "-2", Date: date}}, nil
+	}
+
+	entries, err := QueryDateRange(context.Background(), from, from.AddDate(0, 0, 29), 3, 1, queryDay)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Date != "2025-06-02" || entries[2].Date != "2025-06-03" {
+		t.Errorf("Expected the first 3 entries, got %+v", entries)
+	}
+	// Run one at a time, the days after the limit is filled are never queried
+	if queries != 3 {
+		t.Errorf("Expected 3 day queries, got %d", queries)
+	}
+}
+
+func TestQueryDateRangeErrors(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2025-06-01")
+	failing := errors.New("throttled")
+	queryDay := func(ctx context.Context, date string) ([]models.CalendarEntry, error) {
+		if date == "2025-06-05" {
+			return nil, failing
+		}
+		return []models.CalendarEntry{{Date: date}}, nil
+	}
+	if _, err := QueryDateRange(context.Background(), from, from.AddDate(0, 0, 9), 0, 3, queryDay); !errors.Is(err, failing) {
+		t.Errorf("Expected the failing day's error, got %v", err)
+	}
+
+	if _, err := QueryDateRange(context.Background(), from, from.AddDate(0, 0, -1), 0, 3, queryDay); err == nil {
+		t.Error("Expected an error for a range ending before it starts")
+	}
+	if _, err := QueryDateRange(context.Background(), from, from.AddDate(1, 0, 0), 0, 3, queryDay); err == nil {
+		t.Error("Expected an error for a range over the maximum")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := QueryDateRange(ctx, from, from.AddDate(0, 0, 9), 0, 3, queryDay); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled query to fail, got %v", err)
+	}
+}
+
+func TestOnCalendar(t *testing.T) {
+	activity := func(id, startDate string) *models.PublicActivity {
+		return &models.PublicActivity{Activity: models.Activity{ID: id, Schedule: models.Schedule{StartDate: startDate}}}
+	}
+	kept := OnCalendar([]*models.PublicActivity{
+		activity("on", "2025-06-14"),
+		activity("off", "2025-06-20"),
+		activity("undated", ""),
+	}, []models.CalendarEntry{{ActivityID: "on", Date: "2025-06-14"}})
+
+	if len(kept) != 2 || kept[0].ID != "on" || kept[1].ID != "undated" {
+		t.Errorf("Expected the scheduled and undated activities, got %d", len(kept))
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// APIGatewayTimeout is how long API Gateway waits for a Lambda integration before it answers 504.
+// The Lambda keeps running after that, but its response is lost.
+const APIGatewayTimeout = 29 * time.Second
+
+// DeadlineBudget splits the time a request has left between the downstream calls it makes, so a
+// handler can tell before starting a step whether the step can still finish in time
+type DeadlineBudget struct {
+	deadline time.Time // zero when the budget never runs out
+	now      func() time.Time
+}
+
+// NewDeadlineBudget creates a budget that runs out after limit, or at ctx's deadline if that is
+// sooner. A limit of 0 only uses ctx's deadline.
+func NewDeadlineBudget(ctx context.Context, limit time.Duration) *DeadlineBudget {
+	return newDeadlineBudget(ctx, limit, time.Now)
+}
+
+func newDeadlineBudget(ctx context.Context, limit time.Duration, now func() time.Time) *DeadlineBudget {
+	b := &DeadlineBudget{now: now}
+	if limit > 0 {
+		b.deadline = b.now().Add(limit)
+	}
+	if deadline, ok := ctx.Deadline(); ok && (b.deadline.IsZero() || deadline.Before(b.deadline)) {
+		b.deadline = deadline
+	}
+	return b
+}
+
+// Remaining returns the time left in the budget
+func (b *DeadlineBudget) Remaining() time.Duration {
+	if b.deadline.IsZero() {
+		return math.MaxInt64
+	}
+	if remaining := b.deadline.Sub(b.now()); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Fits reports whether steps needing the given allocations can all finish within the budget
+func (b *DeadlineBudget) Fits(allocations ...time.Duration) bool {
+	var total time.Duration
+	for _, allocation := range allocations {
+		total += allocation
+	}
+	return total <= b.Remaining()
+}
+
+// Context returns a context for a downstream call that ends after allocation, or when the budget
+// runs out if that is sooner. An allocation of 0 gives the call the rest of the budget.
+func (b *DeadlineBudget) Context(ctx context.Context, allocation time.Duration) (context.Context, context.CancelFunc) {
+	remaining := b.Remaining()
+	if allocation <= 0 || allocation > remaining {
+		allocation = remaining
+	}
+	if allocation == math.MaxInt64 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, allocation)
+}
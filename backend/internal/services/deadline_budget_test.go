@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeadlineBudget(t *testing.T) {
+	start := time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)
+	clock := start
+	newBudget := func(ctx context.Context, limit time.Duration) *DeadlineBudget {
+		return newDeadlineBudget(ctx, limit, func() time.Time { return clock })
+	}
+
+	t.Run("AllocatesUntilTheLimit", func(t *testing.T) {
+		clock = start
+		budget := newBudget(context.Background(), 28*time.Second)
+
+		if !budget.Fits(20*time.Second, 5*time.Second) {
+			t.Error("Expected 25s of steps to fit in a 28s budget")
+		}
+		clock = clock.Add(10 * time.Second)
+		if budget.Remaining() != 18*time.Second {
+			t.Errorf("Expected 18s left, got %v", budget.Remaining())
+		}
+		if budget.Fits(20*time.Second, 5*time.Second) {
+			t.Error("Expected 25s of steps not to fit in the 18s left")
+		}
+		clock = clock.Add(time.Minute)
+		if budget.Remaining() != 0 {
+			t.Errorf("Expected a spent budget to have nothing left, got %v", budget.Remaining())
+		}
+	})
+
+	t.Run("EndsAtTheContextDeadline", func(t *testing.T) {
+		clock = start
+		ctx, cancel := context.WithDeadline(context.Background(), start.Add(5*time.Second))
+		defer cancel()
+
+		if got := newBudget(ctx, 28*time.Second).Remaining(); got != 5*time.Second {
+			t.Errorf("Expected the sooner context deadline to end the budget, got %v left", got)
+		}
+		if got := newBudget(ctx, 0).Remaining(); got != 5*time.Second {
+			t.Errorf("Expected a budget without a limit to end at the context deadline, got %v left", got)
+		}
+		if !newBudget(context.Background(), 0).Fits(time.Hour) {
+			t.Error("Expected a budget without a limit or deadline to fit any step")
+		}
+	})
+}
+
+func TestDeadlineBudgetContext(t *testing.T) {
+	budget := NewDeadlineBudget(context.Background(), 2*time.Second)
+
+	ctx, cancel := budget.Context(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 100*time.Millisecond {
+		t.Errorf("Expected the call to get its 100ms allocation, got deadline %v", deadline)
+	}
+
+	ctx, cancel = budget.Context(context.Background(), time.Minute)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	if !ok || time.Until(deadline) > 2*time.Second {
+		t.Errorf("Expected an allocation beyond the budget to be cut to what is left, got deadline %v", deadline)
+	}
+
+	ctx, cancel = NewDeadlineBudget(context.Background(), 0).Context(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline from a budget that never runs out")
+	}
+}
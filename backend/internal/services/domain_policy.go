@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// EvaluateDomainPolicy checks a URL against the domain allow and deny lists.
+// The most specific matching rule wins, so allowing "kids.example.com" exempts it from a deny
+// rule for "example.com". A nil policy allows everything.
+func EvaluateDomainPolicy(policy *models.DomainPolicy, rawURL string) models.DomainDecision {
+	domain := urlutil.Domain(rawURL)
+	decision := models.DomainDecision{Allowed: true, Domain: domain}
+	if domain == "" {
+		decision.Allowed = false
+		decision.Reason = "URL has no valid domain"
+		return decision
+	}
+	if policy == nil {
+		return decision
+	}
+
+	allowRule, allowed := matchDomainRule(policy.AllowList, domain)
+	denyRule, denied := matchDomainRule(policy.DenyList, domain)
+
+	switch {
+	case denied && (!allowed || len(denyRule.Domain) > len(allowRule.Domain)):
+		decision.Allowed = false
+		decision.List = models.DomainListDeny
+		decision.MatchedRule = denyRule.Domain
+		decision.Reason = denyRule.Reason
+		if decision.Reason == "" {
+			decision.Reason = "Domain " + denyRule.Domain + " is on the deny list"
+		}
+	case allowed:
+		decision.List = models.DomainListAllow
+		decision.MatchedRule = allowRule.Domain
+	case policy.AllowListOnly:
+		decision.Allowed = false
+		decision.Reason = "Domain " + domain + " is not on the allow list"
+	}
+
+	return decision
+}
+
+// matchDomainRule returns the most specific rule matching a domain or one of its parent domains
+func matchDomainRule(rules []models.DomainRule, domain string) (models.DomainRule, bool) {
+	var best models.DomainRule
+	found := false
+	for _, rule := range rules {
+		if domain != rule.Domain && !strings.HasSuffix(domain, "."+rule.Domain) {
+			continue
+		}
+		if !found || len(rule.Domain) > len(best.Domain) {
+			best = rule
+			found = true
+		}
+	}
+	return best, found
+}
+
+// DomainPolicyCache serves the domain policy stored in DynamoDB, refreshing it after a TTL.
+// If the policy cannot be loaded, the last loaded copy stays in effect.
+type DomainPolicyCache struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	policy   *models.DomainPolicy
+	loadedAt time.Time
+}
+
+// NewDomainPolicyCache creates a domain policy cache backed by DynamoDB
+func NewDomainPolicyCache(dynamo *DynamoDBService, ttl time.Duration) *DomainPolicyCache {
+	return &DomainPolicyCache{
+		dynamo: dynamo,
+		ttl:    ttl,
+	}
+}
+
+// Check evaluates a URL against the current domain policy
+func (c *DomainPolicyCache) Check(rawURL string) models.DomainDecision {
+	return EvaluateDomainPolicy(c.current(), rawURL)
+}
+
+// current returns the cached policy, loading it if the cached copy is stale
+func (c *DomainPolicyCache) current() *models.DomainPolicy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.policy
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	policy, err := c.dynamo.GetDomainPolicy(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load domain policy, keeping previous lists: %v", err)
+	} else {
+		c.policy = policy
+	}
+
+	c.loadedAt = time.Now()
+	return c.policy
+}
+
+// Invalidate drops the cached policy so the next check reloads it
+func (c *DomainPolicyCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
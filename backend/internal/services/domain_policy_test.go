@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestEvaluateDomainPolicy(t *testing.T) {
+	policy := &models.DomainPolicy{
+		AllowList: []models.DomainRule{
+			{Domain: "kids.wixsite.com"},
+			{Domain: "seattle.gov"},
+		},
+		DenyList: []models.DomainRule{
+			{Domain: "stubhub.com", Reason: "Ticket reseller"},
+			{Domain: "wixsite.com"},
+			{Domain: "events.seattle.gov", Reason: "Duplicates the parks calendar"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		allowed     bool
+		list        string
+		matchedRule string
+		reason      string
+	}{
+		{"Unlisted", "https://www.seattleschild.com/events", true, "", "", ""},
+		{"Denied", "https://www.stubhub.com/kids-shows", false, models.DomainListDeny, "stubhub.com", "Ticket reseller"},
+		{"DeniedSubdomain", "https://tickets.stubhub.com", false, models.DomainListDeny, "stubhub.com", "Ticket reseller"},
+		{"SimilarNameNotDenied", "https://notstubhub.com", true, "", "", ""},
+		{"DefaultReason", "https://someone.wixsite.com", false, models.DomainListDeny, "wixsite.com", "Domain wixsite.com is on the deny list"},
+		{"AllowOverridesBroaderDeny", "https://kids.wixsite.com/camps", true, models.DomainListAllow, "kids.wixsite.com", ""},
+		{"DenyOverridesBroaderAllow", "https://events.seattle.gov", false, models.DomainListDeny, "events.seattle.gov", "Duplicates the parks calendar"},
+		{"Allowed", "https://parks.seattle.gov", true, models.DomainListAllow, "seattle.gov", ""},
+		{"InvalidURL", "", false, "", "", "URL has no valid domain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := EvaluateDomainPolicy(policy, tt.url)
+			if decision.Allowed != tt.allowed || decision.List != tt.list || decision.MatchedRule != tt.matchedRule || decision.Reason != tt.reason {
+				t.Errorf("Unexpected decision for %s: %+v", tt.url, decision)
+			}
+		})
+	}
+}
+
+func TestEvaluateDomainPolicyAllowListOnly(t *testing.T) {
+	policy := &models.DomainPolicy{
+		AllowList:     []models.DomainRule{{Domain: "seattle.gov"}},
+		AllowListOnly: true,
+	}
+
+	if decision := EvaluateDomainPolicy(policy, "https://spl.seattle.gov"); !decision.Allowed {
+		t.Errorf("Expected allowed domain to pass, got %+v", decision)
+	}
+	decision := EvaluateDomainPolicy(policy, "https://seattleschild.com")
+	if decision.Allowed || decision.Reason != "Domain seattleschild.com is not on the allow list" {
+		t.Errorf("Expected unlisted domain to be denied, got %+v", decision)
+	}
+
+	if decision := EvaluateDomainPolicy(nil, "https://seattleschild.com"); !decision.Allowed {
+		t.Errorf("Expected a missing policy to allow everything, got %+v", decision)
+	}
+}
+
+func TestDomainPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy models.DomainPolicy
+		valid  bool
+	}{
+		{"Valid", models.DomainPolicy{DenyList: []models.DomainRule{{Domain: "stubhub.com"}}}, true},
+		{"Empty", models.DomainPolicy{}, true},
+		{"MissingDomain", models.DomainPolicy{DenyList: []models.DomainRule{{Reason: "Reseller"}}}, false},
+		{"URLNotHost", models.DomainPolicy{DenyList: []models.DomainRule{{Domain: "https://stubhub.com"}}}, false},
+		{"UpperCase", models.DomainPolicy{AllowList: []models.DomainRule{{Domain: "Seattle.gov"}}}, false},
+		{"OnBothLists", models.DomainPolicy{AllowList: []models.DomainRule{{Domain: "a.com"}}, DenyList: []models.DomainRule{{Domain: "a.com"}}}, false},
+		{"Duplicate", models.DomainPolicy{DenyList: []models.DomainRule{{Domain: "a.com"}, {Domain: "a.com"}}}, false},
+		{"AllowListOnlyWithoutAllowList", models.DomainPolicy{AllowListOnly: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid policy, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -25,7 +31,14 @@ type DynamoDBService struct {
 }
 
 // NewDynamoDBService creates a new DynamoDB service instance
+// Every call the service makes is recorded in the shared DynamoDB telemetry collector.
 func NewDynamoDBService(client *dynamodb.Client, familyActivitiesTable, sourceManagementTable, scrapingOperationsTable, adminEventsTable string) *DynamoDBService {
+	if client != nil {
+		telemetry := GetDynamoDBTelemetry()
+		client = dynamodb.New(client.Options(), func(o *dynamodb.Options) {
+			o.APIOptions = append(o.APIOptions, telemetry.attach)
+		})
+	}
 	return &DynamoDBService{
 		client:                  client,
 		familyActivitiesTable:   familyActivitiesTable,
@@ -221,7 +234,7 @@ func (s *DynamoDBService) CreateSourceSubmission(ctx context.Context, submission
 	submission.UpdatedAt = now
 	submission.PK = models.CreateSourcePK(submission.SourceID)
 	submission.SK = models.CreateSourceSubmissionSK()
-	submission.StatusKey = models.GenerateSourceStatusKey(submission.Status)
+	submission.StatusKey = models.GenerateSourceStatusKey(submission.Status, submission.SourceID)
 	submission.PriorityKey = models.GenerateSourcePriorityKey(submission.Priority, submission.SourceID)
 
 	// Marshal to DynamoDB attribute values
@@ -273,9 +286,10 @@ func (s *DynamoDBService) GetSourceSubmission(ctx context.Context, sourceID stri
 
 // UpdateSourceSubmission updates an existing source submission
 func (s *DynamoDBService) UpdateSourceSubmission(ctx context.Context, submission *models.SourceSubmission) error {
-	// Set updated timestamp
+	// Set updated timestamp, and move submissions stored before status keys were sharded onto their shard
 	now := time.Now()
 	submission.UpdatedAt = now
+	submission.StatusKey = models.GenerateSourceStatusKey(submission.Status, submission.SourceID)
 
 	// Marshal to DynamoDB attribute values
 	item, err := attributevalue.MarshalMap(submission)
@@ -295,7 +309,41 @@ func (s *DynamoDBService) UpdateSourceSubmission(ctx context.Context, submission
 	return nil
 }
 
-// CreateSourceAnalysis stores analysis results
+// GetAllSourceSubmissions retrieves every source submission, whatever its status
+func (s *DynamoDBService) GetAllSourceSubmissions(ctx context.Context) ([]models.SourceSubmission, error) {
+	var submissions []models.SourceSubmission
+	var lastEvaluatedKey map[string]types.AttributeValue
+
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.sourceManagementTable),
+			FilterExpression: aws.String("SK = :sk"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":sk": &types.AttributeValueMemberS{Value: models.CreateSourceSubmissionSK()},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source submissions: %w", err)
+		}
+
+		var page []models.SourceSubmission
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal source submissions: %w", err)
+		}
+		submissions = append(submissions, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return submissions, nil
+}
+
+// CreateSourceAnalysis stores analysis results as the source's latest analysis and keeps
+// a numbered copy so later analyses can be compared against it
 func (s *DynamoDBService) CreateSourceAnalysis(ctx context.Context, analysis *models.SourceAnalysis) error {
 	// Set timestamps and keys
 	now := time.Now()
@@ -303,24 +351,122 @@ func (s *DynamoDBService) CreateSourceAnalysis(ctx context.Context, analysis *mo
 	analysis.PK = models.CreateSourcePK(analysis.SourceID)
 	analysis.SK = models.CreateSourceAnalysisSK()
 
+	// Number this analysis after the latest one. Analyses stored before versioning have
+	// version 0 and no numbered copy, so history starts at the next analysis.
+	analysis.Version = 1
+	if latest, err := s.GetSourceAnalysis(ctx, analysis.SourceID); err == nil {
+		analysis.Version = latest.Version + 1
+	}
+
 	// Marshal to DynamoDB attribute values
 	item, err := attributevalue.MarshalMap(analysis)
 	if err != nil {
 		return fmt.Errorf("failed to marshal source analysis: %w", err)
 	}
 
-	// Put item
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.sourceManagementTable),
-		Item:      item,
+	versionItem := make(map[string]types.AttributeValue, len(item))
+	for key, value := range item {
+		versionItem[key] = value
+	}
+	versionItem["SK"] = &types.AttributeValueMemberS{Value: models.CreateSourceAnalysisVersionSK(analysis.Version)}
+
+	// Write both items together; the condition fails if another analysis took this version
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName: aws.String(s.sourceManagementTable),
+					Item:      item,
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(s.sourceManagementTable),
+					Item:                versionItem,
+					ConditionExpression: aws.String("attribute_not_exists(PK)"),
+				},
+			},
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create source analysis: %w", err)
 	}
 
+	// The analysis is stored either way; a missing inbox entry only costs the admins a reminder
+	if analysis.Status == models.SourceStatusAnalysisComplete {
+		notification := models.NewAdminNotification(models.NotificationKindAnalysisComplete,
+			fmt.Sprintf("Analysis of %s complete", analysis.SourceID),
+			fmt.Sprintf("Analysis v%d of %s is ready for review, with a quality score of %.2f.", analysis.Version, analysis.SourceID, analysis.OverallQualityScore),
+			now)
+		notification.SourceID = analysis.SourceID
+		if err := s.CreateAdminNotification(ctx, notification); err != nil {
+			log.Printf("Warning: Failed to record analysis notification for %s: %v", analysis.SourceID, err)
+		}
+	}
+
 	return nil
 }
 
+// GetSourceAnalysisVersion retrieves a stored version of a source's analysis
+func (s *DynamoDBService) GetSourceAnalysisVersion(ctx context.Context, sourceID string, version int) (*models.SourceAnalysis, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceAnalysisVersionSK(version)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source analysis version: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, fmt.Errorf("source analysis version %d not found", version)
+	}
+
+	var analysis models.SourceAnalysis
+	err = attributevalue.UnmarshalMap(result.Item, &analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal source analysis: %w", err)
+	}
+
+	return &analysis, nil
+}
+
+// querySourceAnalysisVersionKeys returns the sort keys of every stored analysis version of a source
+func (s *DynamoDBService) querySourceAnalysisVersionKeys(ctx context.Context, sourceID string) ([]string, error) {
+	var sortKeys []string
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.sourceManagementTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+				":prefix": &types.AttributeValueMemberS{Value: models.SourceAnalysisVersionPrefix},
+			},
+			ProjectionExpression: aws.String("SK"),
+			ExclusiveStartKey:    lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query source analysis versions: %w", err)
+		}
+
+		for _, item := range result.Items {
+			if sk, ok := item["SK"].(*types.AttributeValueMemberS); ok {
+				sortKeys = append(sortKeys, sk.Value)
+			}
+		}
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return sortKeys, nil
+}
+
 // GetSourceAnalysis retrieves analysis results
 func (s *DynamoDBService) GetSourceAnalysis(ctx context.Context, sourceID string) (*models.SourceAnalysis, error) {
 	pk := models.CreateSourcePK(sourceID)
@@ -358,7 +504,7 @@ func (s *DynamoDBService) CreateSourceConfig(ctx context.Context, config *models
 	config.LastModified = now
 	config.PK = models.CreateSourcePK(config.SourceID)
 	config.SK = models.CreateSourceConfigSK()
-	config.StatusKey = models.GenerateSourceStatusKey(config.Status)
+	config.StatusKey = models.GenerateSourceStatusKey(config.Status, config.SourceID)
 	config.PriorityKey = models.GenerateSourcePriorityKey(config.ScrapingConfig.Priority, config.SourceID)
 
 	// Marshal to DynamoDB attribute values
@@ -408,246 +554,2201 @@ func (s *DynamoDBService) GetSourceConfig(ctx context.Context, sourceID string)
 	return &config, nil
 }
 
-// QuerySourcesByStatus queries sources by status using table scan (temporary workaround)
-func (s *DynamoDBService) QuerySourcesByStatus(ctx context.Context, status string, limit int32) ([]models.SourceSubmission, error) {
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(s.sourceManagementTable),
-		FilterExpression: aws.String("#status = :status AND SK = :sk"),
-		ExpressionAttributeNames: map[string]string{
-			"#status": "status",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status": &types.AttributeValueMemberS{Value: status},
-			":sk":     &types.AttributeValueMemberS{Value: "SUBMISSION"},
+// GetDomainPolicy retrieves the domain allow and deny lists.
+// It returns nil without an error when no policy has been stored.
+func (s *DynamoDBService) GetDomainPolicy(ctx context.Context) (*models.DomainPolicy, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateDomainPolicyPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateDomainPolicySK()},
 		},
-		Limit: aws.Int32(limit),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan sources by status: %w", err)
+		return nil, fmt.Errorf("failed to get domain policy: %w", err)
 	}
 
-	var sources []models.SourceSubmission
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &sources)
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var policy models.DomainPolicy
+	err = attributevalue.UnmarshalMap(result.Item, &policy)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal sources: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal domain policy: %w", err)
 	}
 
-	return sources, nil
+	return &policy, nil
 }
 
-// Scraping Operations Table Operations
-
-// CreateScrapingTask creates a new scraping task
-func (s *DynamoDBService) CreateScrapingTask(ctx context.Context, task *models.ScrapingTask) error {
-	// Set timestamps and TTL
-	now := time.Now()
-	task.CreatedAt = now
-	task.UpdatedAt = now
-	
-	// Set TTL (90 days from now)
-	task.TTL = models.CalculateTTL(90 * 24 * time.Hour)
-
-	// Generate GSI keys
-	task.NextRunKey = models.GenerateNextRunKey(task.ScheduledTime)
-	task.PrioritySourceKey = models.GeneratePrioritySourceKey(task.Priority, task.SourceID, task.TaskID)
+// PutDomainPolicy stores the domain allow and deny lists, replacing any previous version
+func (s *DynamoDBService) PutDomainPolicy(ctx context.Context, policy *models.DomainPolicy) error {
+	policy.PK = models.CreateDomainPolicyPK()
+	policy.SK = models.CreateDomainPolicySK()
+	policy.UpdatedAt = time.Now()
 
-	// Marshal to DynamoDB attribute values
-	item, err := attributevalue.MarshalMap(task)
+	item, err := attributevalue.MarshalMap(policy)
 	if err != nil {
-		return fmt.Errorf("failed to marshal scraping task: %w", err)
+		return fmt.Errorf("failed to marshal domain policy: %w", err)
 	}
 
-	// Put item
 	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.scrapingOperationsTable),
+		TableName: aws.String(s.sourceManagementTable),
 		Item:      item,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create scraping task: %w", err)
+		return fmt.Errorf("failed to store domain policy: %w", err)
 	}
 
 	return nil
 }
 
-// GetScrapingTask retrieves a scraping task
-func (s *DynamoDBService) GetScrapingTask(ctx context.Context, taskID string) (*models.ScrapingTask, error) {
-	pk := models.CreateTaskPK(taskID)
-	sk := models.CreateTaskSK("medium", "default-source", taskID) // This would need proper implementation
-
+// GetNeighborhoodLookup retrieves the neighborhood polygons.
+// It returns nil without an error when no lookup has been stored.
+func (s *DynamoDBService) GetNeighborhoodLookup(ctx context.Context) (*models.NeighborhoodLookup, error) {
 	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
-		TableName: aws.String(s.scrapingOperationsTable),
+		TableName: aws.String(s.sourceManagementTable),
 		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: pk},
-			"SK": &types.AttributeValueMemberS{Value: sk},
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSettingsPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateNeighborhoodLookupSK()},
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get scraping task: %w", err)
+		return nil, fmt.Errorf("failed to get neighborhood lookup: %w", err)
 	}
 
 	if result.Item == nil {
-		return nil, fmt.Errorf("scraping task not found")
+		return nil, nil
 	}
 
-	var task models.ScrapingTask
-	err = attributevalue.UnmarshalMap(result.Item, &task)
+	var lookup models.NeighborhoodLookup
+	err = attributevalue.UnmarshalMap(result.Item, &lookup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal scraping task: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal neighborhood lookup: %w", err)
 	}
 
-	return &task, nil
+	return &lookup, nil
 }
 
-// QueryNextScrapingTasks queries tasks ready to run using GSI
-func (s *DynamoDBService) QueryNextScrapingTasks(ctx context.Context, maxTime time.Time, limit int32) ([]models.ScrapingTask, error) {
-	nextRunKey := models.GenerateNextRunKey(maxTime)
+// PutNeighborhoodLookup stores the neighborhood polygons, replacing any previous version
+func (s *DynamoDBService) PutNeighborhoodLookup(ctx context.Context, lookup *models.NeighborhoodLookup) error {
+	lookup.PK = models.CreateSettingsPK()
+	lookup.SK = models.CreateNeighborhoodLookupSK()
+	lookup.UpdatedAt = time.Now()
 
-	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(s.scrapingOperationsTable),
-		IndexName:              aws.String("next-run-index"),
-		KeyConditionExpression: aws.String("NextRunKey <= :nextRunKey"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":nextRunKey": &types.AttributeValueMemberS{Value: nextRunKey},
-		},
-		Limit: aws.Int32(limit),
-	})
+	item, err := attributevalue.MarshalMap(lookup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query next scraping tasks: %w", err)
+		return fmt.Errorf("failed to marshal neighborhood lookup: %w", err)
 	}
 
-	var tasks []models.ScrapingTask
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &tasks)
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		return fmt.Errorf("failed to store neighborhood lookup: %w", err)
 	}
 
-	return tasks, nil
+	return nil
 }
 
-// Helper function to populate GSI keys for family activities
-func (s *DynamoDBService) populateFamilyActivityGSIKeys(activity *models.FamilyActivity) {
-	// Generate location key
-	if activity.Location.Region != "" && activity.Location.City != "" {
-		activity.LocationKey = models.GenerateLocationKey(activity.Location.Region, activity.Location.City)
-	}
-
-	// Generate category-age key (use first age group if available)
-	if activity.Category != "" && len(activity.AgeGroups) > 0 {
-		activity.CategoryAgeKey = models.GenerateCategoryAgeKey(activity.Category, activity.AgeGroups[0].Category)
+// GetConcurrencySettings retrieves the per-provider concurrency caps.
+// It returns nil without an error when no settings have been stored.
+func (s *DynamoDBService) GetConcurrencySettings(ctx context.Context) (*models.ConcurrencySettings, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSettingsPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateConcurrencySettingsSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get concurrency settings: %w", err)
 	}
 
-	// Generate venue key for events and programs
-	switch activity.EntityType {
-	case models.EntityTypeEvent, models.EntityTypeProgram:
-		// For events and programs, we'd need to extract venue_id from the specific type
-		// This would be populated when creating typed entities (Event, Program)
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	// Generate provider key
-	if activity.ProviderID != "" {
-		activity.ProviderKey = "PROVIDER#" + activity.ProviderID
+	var settings models.ConcurrencySettings
+	err = attributevalue.UnmarshalMap(result.Item, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal concurrency settings: %w", err)
 	}
 
-	// Generate type-status key
-	if activity.EntityType != "" && activity.Status != "" {
-		activity.TypeStatusKey = models.GenerateTypeStatusKey(activity.EntityType, activity.Status, activity.EntityID)
-	}
+	return &settings, nil
 }
 
-// BatchPutActivities stores multiple activities in batches (for task executor)
-func (s *DynamoDBService) BatchPutActivities(ctx context.Context, activities []*models.Activity) error {
-	if len(activities) == 0 {
-		return nil
-	}
+// PutConcurrencySettings stores the per-provider concurrency caps, replacing any previous version
+func (s *DynamoDBService) PutConcurrencySettings(ctx context.Context, settings *models.ConcurrencySettings) error {
+	settings.PK = models.CreateSettingsPK()
+	settings.SK = models.CreateConcurrencySettingsSK()
+	settings.UpdatedAt = time.Now()
 
-	// Convert Activity to FamilyActivity format for storage
-	var familyActivities []*models.FamilyActivity
-	for _, activity := range activities {
-		familyActivity := s.convertActivityToFamilyActivity(activity)
-		familyActivities = append(familyActivities, familyActivity)
+	item, err := attributevalue.MarshalMap(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal concurrency settings: %w", err)
 	}
 
-	// Process in batches of 25 (DynamoDB limit)
-	batchSize := 25
-	for i := 0; i < len(familyActivities); i += batchSize {
-		end := i + batchSize
-		if end > len(familyActivities) {
-			end = len(familyActivities)
-		}
-
-		batch := familyActivities[i:end]
-		if err := s.batchWriteFamilyActivities(ctx, batch); err != nil {
-			return fmt.Errorf("failed to write batch %d-%d: %w", i, end-1, err)
-		}
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store concurrency settings: %w", err)
 	}
 
 	return nil
 }
 
-// batchWriteFamilyActivities writes a batch of family activities
-func (s *DynamoDBService) batchWriteFamilyActivities(ctx context.Context, activities []*models.FamilyActivity) error {
-	writeRequests := make([]types.WriteRequest, 0, len(activities))
+// GetFeatureFlagSettings retrieves the feature flags.
+// It returns nil without an error when no flags have been stored.
+func (s *DynamoDBService) GetFeatureFlagSettings(ctx context.Context) (*models.FeatureFlagSettings, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSettingsPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFeatureFlagSettingsSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
 
-	for _, activity := range activities {
-		// Set timestamps
-		now := time.Now()
-		activity.CreatedAt = now
-		activity.UpdatedAt = now
+	if result.Item == nil {
+		return nil, nil
+	}
 
-		// Populate GSI keys
-		s.populateFamilyActivityGSIKeys(activity)
+	var settings models.FeatureFlagSettings
+	err = attributevalue.UnmarshalMap(result.Item, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feature flags: %w", err)
+	}
 
-		// Marshal activity
-		item, err := attributevalue.MarshalMap(activity)
-		if err != nil {
-			return fmt.Errorf("failed to marshal activity %s: %w", activity.EntityID, err)
-		}
+	return &settings, nil
+}
 
-		writeRequests = append(writeRequests, types.WriteRequest{
-			PutRequest: &types.PutRequest{Item: item},
-		})
+// PutFeatureFlagSettings stores the feature flags, replacing any previous version
+func (s *DynamoDBService) PutFeatureFlagSettings(ctx context.Context, settings *models.FeatureFlagSettings) error {
+	settings.PK = models.CreateSettingsPK()
+	settings.SK = models.CreateFeatureFlagSettingsSK()
+	settings.UpdatedAt = time.Now()
+
+	item, err := attributevalue.MarshalMap(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature flags: %w", err)
 	}
 
-	// Execute batch write
-	_, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-		RequestItems: map[string][]types.WriteRequest{
-			s.familyActivitiesTable: writeRequests,
-		},
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to store feature flags: %w", err)
+	}
 
-	return err
+	return nil
 }
 
-// GetAllActivities retrieves all activities from the family activities table (for S3 export)
-func (s *DynamoDBService) GetAllActivities(ctx context.Context) ([]*models.Activity, error) {
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName: aws.String(s.familyActivitiesTable),
+// GetValidationRuleSet retrieves the stored validation rules for a schema type.
+// It returns nil without an error when no rule set has been stored.
+func (s *DynamoDBService) GetValidationRuleSet(ctx context.Context, schemaType string) (*models.ValidationRuleSet, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateValidationRulesPK(schemaType)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateValidationRulesSK()},
+		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan activities: %w", err)
+		return nil, fmt.Errorf("failed to get validation rules: %w", err)
 	}
 
-	var familyActivities []models.FamilyActivity
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &familyActivities)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal activities: %w", err)
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	// Convert to Activity format
-	var activities []*models.Activity
-	for _, fa := range familyActivities {
-		activity := s.convertFamilyActivityToActivity(&fa)
-		activities = append(activities, activity)
+	var ruleSet models.ValidationRuleSet
+	err = attributevalue.UnmarshalMap(result.Item, &ruleSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validation rules: %w", err)
 	}
 
-	return activities, nil
+	return &ruleSet, nil
 }
 
-// convertActivityToFamilyActivity converts a simple Activity to the complex FamilyActivity format
-func (s *DynamoDBService) convertActivityToFamilyActivity(activity *models.Activity) *models.FamilyActivity {
-	// TODO: Implement proper conversion when needed
-	// For now, return a minimal FamilyActivity to satisfy the interface
+// PutValidationRuleSet stores the validation rules for a schema type, replacing any previous version
+func (s *DynamoDBService) PutValidationRuleSet(ctx context.Context, ruleSet *models.ValidationRuleSet) error {
+	ruleSet.PK = models.CreateValidationRulesPK(ruleSet.SchemaType)
+	ruleSet.SK = models.CreateValidationRulesSK()
+	ruleSet.UpdatedAt = time.Now()
+
+	item, err := attributevalue.MarshalMap(ruleSet)
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation rules: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store validation rules: %w", err)
+	}
+
+	return nil
+}
+
+// PutReviewPreset stores an admin's review preset, replacing any preset with the same ID
+func (s *DynamoDBService) PutReviewPreset(ctx context.Context, preset *models.ReviewPreset) error {
+	preset.PK = models.CreateReviewPresetPK(preset.Admin)
+	preset.SK = models.CreateReviewPresetSK(preset.PresetID)
+	preset.UpdatedAt = time.Now()
+	if preset.CreatedAt.IsZero() {
+		preset.CreatedAt = preset.UpdatedAt
+	}
+
+	item, err := attributevalue.MarshalMap(preset)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review preset: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store review preset: %w", err)
+	}
+
+	return nil
+}
+
+// GetReviewPreset retrieves one of an admin's review presets.
+// It returns nil without an error when the admin has no preset with that ID.
+func (s *DynamoDBService) GetReviewPreset(ctx context.Context, admin, presetID string) (*models.ReviewPreset, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateReviewPresetPK(admin)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateReviewPresetSK(presetID)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review preset: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var preset models.ReviewPreset
+	err = attributevalue.UnmarshalMap(result.Item, &preset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal review preset: %w", err)
+	}
+
+	return &preset, nil
+}
+
+// ListReviewPresets retrieves all review presets saved by an admin
+func (s *DynamoDBService) ListReviewPresets(ctx context.Context, admin string) ([]models.ReviewPreset, error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.sourceManagementTable),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: models.CreateReviewPresetPK(admin)},
+			":prefix": &types.AttributeValueMemberS{Value: "PRESET#"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review presets: %w", err)
+	}
+
+	presets := []models.ReviewPreset{}
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &presets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal review presets: %w", err)
+	}
+
+	return presets, nil
+}
+
+// DeleteReviewPreset removes one of an admin's review presets
+func (s *DynamoDBService) DeleteReviewPreset(ctx context.Context, admin, presetID string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateReviewPresetPK(admin)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateReviewPresetSK(presetID)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete review preset: %w", err)
+	}
+
+	return nil
+}
+
+// PutTag stores a tag, replacing any tag with the same slug
+func (s *DynamoDBService) PutTag(ctx context.Context, tag *models.Tag) error {
+	tag.PK = models.CreateTagPK()
+	tag.SK = models.CreateTagSK(tag.Slug)
+	tag.UpdatedAt = time.Now()
+	if tag.CreatedAt.IsZero() {
+		tag.CreatedAt = tag.UpdatedAt
+	}
+
+	item, err := attributevalue.MarshalMap(tag)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store tag: %w", err)
+	}
+
+	return nil
+}
+
+// GetTag retrieves a stored tag.
+// It returns nil without an error when no tag is stored with that slug.
+func (s *DynamoDBService) GetTag(ctx context.Context, slug string) (*models.Tag, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateTagPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateTagSK(slug)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var tag models.Tag
+	err = attributevalue.UnmarshalMap(result.Item, &tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// ListTags retrieves every stored tag. Built-in tags are only included once stored.
+func (s *DynamoDBService) ListTags(ctx context.Context) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.sourceManagementTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateTagPK()},
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateTagSK("")},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tags: %w", err)
+		}
+
+		var page []models.Tag
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+		tags = append(tags, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return tags, nil
+}
+
+// DeleteTag removes a stored tag
+func (s *DynamoDBService) DeleteTag(ctx context.Context, slug string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateTagPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateTagSK(slug)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+// CountTaggedActivities counts the published activities with a tag using the tag-date-index GSI
+func (s *DynamoDBService) CountTaggedActivities(ctx context.Context, slug string) (int, error) {
+	count := 0
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			IndexName:              aws.String("tag-date-index"),
+			KeyConditionExpression: aws.String("TagKey = :tagKey"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":tagKey": &types.AttributeValueMemberS{Value: models.GenerateTagKey(slug)},
+			},
+			Select:            types.SelectCount,
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count tagged activities: %w", err)
+		}
+		count += int(result.Count)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return count, nil
+}
+
+// GetTagEntries returns the published activities with a tag that start on or after a date
+// (YYYY-MM-DD), soonest first, using the tag-date-index GSI. Activities without a start date
+// are only returned when from is empty.
+func (s *DynamoDBService) GetTagEntries(ctx context.Context, slug, from string, limit int) ([]models.TagEntry, error) {
+	entries := []models.TagEntry{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			IndexName:              aws.String("tag-date-index"),
+			KeyConditionExpression: aws.String("TagKey = :tagKey AND TagDateKey >= :from"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":tagKey": &types.AttributeValueMemberS{Value: models.GenerateTagKey(slug)},
+				":from":   &types.AttributeValueMemberS{Value: models.GenerateTagDateKey(from, "")},
+			},
+			Limit:             aws.Int32(int32(limit - len(entries))),
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tag entries: %w", err)
+		}
+
+		var page []models.TagEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tag entries: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil || len(entries) >= limit {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// CreateProviderAccount stores a new provider account, failing if the provider ID is taken
+func (s *DynamoDBService) CreateProviderAccount(ctx context.Context, account *models.ProviderAccount) error {
+	account.PK = models.CreateProviderAccountPK(account.ProviderID)
+	account.SK = models.CreateProviderAccountSK()
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = account.CreatedAt
+
+	item, err := attributevalue.MarshalMap(account)
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider account: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.sourceManagementTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create provider account: %w", err)
+	}
+
+	return nil
+}
+
+// GetProviderAccount retrieves a provider account.
+// It returns nil without an error when no provider has that ID.
+func (s *DynamoDBService) GetProviderAccount(ctx context.Context, providerID string) (*models.ProviderAccount, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateProviderAccountPK(providerID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateProviderAccountSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider account: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var account models.ProviderAccount
+	err = attributevalue.UnmarshalMap(result.Item, &account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal provider account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// ListProviderAccounts retrieves every provider account
+func (s *DynamoDBService) ListProviderAccounts(ctx context.Context) ([]models.ProviderAccount, error) {
+	accounts := []models.ProviderAccount{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.sourceManagementTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND SK = :sk"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateProviderAccountPK("")},
+				":sk":     &types.AttributeValueMemberS{Value: models.CreateProviderAccountSK()},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan provider accounts: %w", err)
+		}
+
+		var page []models.ProviderAccount
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal provider accounts: %w", err)
+		}
+		accounts = append(accounts, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return accounts, nil
+}
+
+// RevokeProviderAccount stops a provider's token from being accepted.
+// It returns false without an error when no provider has that ID.
+func (s *DynamoDBService) RevokeProviderAccount(ctx context.Context, providerID string) (bool, error) {
+	now := time.Now()
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateProviderAccountPK(providerID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateProviderAccountSK()},
+		},
+		UpdateExpression:    aws.String("SET #status = :revoked, revoked_at = :now, updated_at = :now"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":revoked": &types.AttributeValueMemberS{Value: models.ProviderStatusRevoked},
+			":now":     &types.AttributeValueMemberS{Value: now.Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to revoke provider account: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateAdminAPIKey stores a new admin API key, failing if the key ID is taken
+func (s *DynamoDBService) CreateAdminAPIKey(ctx context.Context, key *models.AdminAPIKey) error {
+	key.PK = models.CreateAdminAPIKeyPK(key.KeyID)
+	key.SK = models.CreateAdminAPIKeySK()
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = key.CreatedAt
+
+	item, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin API key: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.sourceManagementTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create admin API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdminAPIKey retrieves an admin API key.
+// It returns nil without an error when no key has that ID.
+func (s *DynamoDBService) GetAdminAPIKey(ctx context.Context, keyID string) (*models.AdminAPIKey, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeyPK(keyID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeySK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin API key: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var key models.AdminAPIKey
+	err = attributevalue.UnmarshalMap(result.Item, &key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admin API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ListAdminAPIKeys retrieves every admin API key, including revoked ones
+func (s *DynamoDBService) ListAdminAPIKeys(ctx context.Context) ([]models.AdminAPIKey, error) {
+	keys := []models.AdminAPIKey{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.sourceManagementTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND SK = :sk"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeyPK("")},
+				":sk":     &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeySK()},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan admin API keys: %w", err)
+		}
+
+		var page []models.AdminAPIKey
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal admin API keys: %w", err)
+		}
+		keys = append(keys, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return keys, nil
+}
+
+// RevokeAdminAPIKey stops an admin API key from being accepted.
+// It returns false without an error when no key has that ID.
+func (s *DynamoDBService) RevokeAdminAPIKey(ctx context.Context, keyID string) (bool, error) {
+	now := time.Now()
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeyPK(keyID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateAdminAPIKeySK()},
+		},
+		UpdateExpression:    aws.String("SET #status = :revoked, revoked_at = :now, updated_at = :now"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":revoked": &types.AttributeValueMemberS{Value: models.AdminAPIKeyStatusRevoked},
+			":now":     &types.AttributeValueMemberS{Value: now.Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to revoke admin API key: %w", err)
+	}
+
+	return true, nil
+}
+
+// ErrVenueClaimChanged is returned when a venue claim is no longer in the status a change expected
+var ErrVenueClaimChanged = errors.New("venue claim changed")
+
+// venueClaimPut returns the transaction put for a claim. A new claim (empty fromStatus) must
+// not exist yet; otherwise the stored claim must still be in fromStatus.
+func (s *DynamoDBService) venueClaimPut(claim *models.VenueClaim, fromStatus string) (types.TransactWriteItem, error) {
+	claim.PK = models.CreateVenueClaimPK(claim.ClaimID)
+	claim.SK = models.CreateVenueClaimSK()
+
+	item, err := attributevalue.MarshalMap(claim)
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("failed to marshal venue claim: %w", err)
+	}
+
+	put := &types.Put{
+		TableName:           aws.String(s.sourceManagementTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	}
+	if fromStatus != "" {
+		put.ConditionExpression = aws.String("#status = :from")
+		put.ExpressionAttributeNames = map[string]string{"#status": "status"}
+		put.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":from": &types.AttributeValueMemberS{Value: fromStatus},
+		}
+	}
+	return types.TransactWriteItem{Put: put}, nil
+}
+
+// writeVenueClaim stores a claim with the audit entry for the step and any extra items in one
+// transaction, returning ErrVenueClaimChanged if the claim's status check fails
+func (s *DynamoDBService) writeVenueClaim(ctx context.Context, claim *models.VenueClaim, fromStatus string, audit *models.VenueClaimAuditEntry, extra ...types.TransactWriteItem) error {
+	claimPut, err := s.venueClaimPut(claim, fromStatus)
+	if err != nil {
+		return err
+	}
+	auditItem, err := attributevalue.MarshalMap(audit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal venue claim audit entry: %w", err)
+	}
+
+	transactItems := []types.TransactWriteItem{
+		claimPut,
+		{Put: &types.Put{TableName: aws.String(s.sourceManagementTable), Item: auditItem}},
+	}
+	transactItems = append(transactItems, extra...)
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) > 0 &&
+			aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+			return ErrVenueClaimChanged
+		}
+		return fmt.Errorf("failed to save venue claim %s: %w", claim.ClaimID, err)
+	}
+	return nil
+}
+
+// SaveVenueClaim stores a claim together with the audit entry recording the step that changed it.
+// fromStatus is the status the stored claim must still have, or "" for a new claim.
+func (s *DynamoDBService) SaveVenueClaim(ctx context.Context, claim *models.VenueClaim, fromStatus string, audit *models.VenueClaimAuditEntry) error {
+	claim.UpdatedAt = audit.Timestamp
+	if fromStatus == "" {
+		claim.CreatedAt = audit.Timestamp
+	}
+	return s.writeVenueClaim(ctx, claim, fromStatus, audit)
+}
+
+// ApproveVenueClaim marks a verified claim approved and creates the provider account issued
+// for it, in one transaction with the approval's audit entry
+func (s *DynamoDBService) ApproveVenueClaim(ctx context.Context, claim *models.VenueClaim, account *models.ProviderAccount, audit *models.VenueClaimAuditEntry) error {
+	account.PK = models.CreateProviderAccountPK(account.ProviderID)
+	account.SK = models.CreateProviderAccountSK()
+	account.CreatedAt = audit.Timestamp
+	account.UpdatedAt = audit.Timestamp
+	accountItem, err := attributevalue.MarshalMap(account)
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider account: %w", err)
+	}
+
+	claim.UpdatedAt = audit.Timestamp
+	return s.writeVenueClaim(ctx, claim, models.VenueClaimStatusVerified, audit, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String(s.sourceManagementTable),
+			Item:                accountItem,
+			ConditionExpression: aws.String("attribute_not_exists(PK)"),
+		},
+	})
+}
+
+// GetVenueClaim retrieves a venue claim.
+// It returns nil without an error when no claim has that ID.
+func (s *DynamoDBService) GetVenueClaim(ctx context.Context, claimID string) (*models.VenueClaim, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateVenueClaimPK(claimID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateVenueClaimSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue claim: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var claim models.VenueClaim
+	err = attributevalue.UnmarshalMap(result.Item, &claim)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal venue claim: %w", err)
+	}
+
+	return &claim, nil
+}
+
+// ListVenueClaims retrieves venue claims, newest first, optionally only those for one venue
+// and in one status
+func (s *DynamoDBService) ListVenueClaims(ctx context.Context, venueID, status string) ([]models.VenueClaim, error) {
+	filter := "begins_with(PK, :prefix) AND SK = :sk"
+	names := map[string]string{}
+	values := map[string]types.AttributeValue{
+		":prefix": &types.AttributeValueMemberS{Value: models.CreateVenueClaimPK("")},
+		":sk":     &types.AttributeValueMemberS{Value: models.CreateVenueClaimSK()},
+	}
+	if venueID != "" {
+		filter += " AND venue_id = :venue_id"
+		values[":venue_id"] = &types.AttributeValueMemberS{Value: venueID}
+	}
+	if status != "" {
+		filter += " AND #status = :status"
+		names["#status"] = "status"
+		values[":status"] = &types.AttributeValueMemberS{Value: status}
+	}
+
+	claims := []models.VenueClaim{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		input := &dynamodb.ScanInput{
+			TableName:                 aws.String(s.sourceManagementTable),
+			FilterExpression:          aws.String(filter),
+			ExpressionAttributeValues: values,
+			ExclusiveStartKey:         lastEvaluatedKey,
+		}
+		if len(names) > 0 {
+			input.ExpressionAttributeNames = names
+		}
+		result, err := s.client.Scan(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan venue claims: %w", err)
+		}
+
+		var page []models.VenueClaim
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal venue claims: %w", err)
+		}
+		claims = append(claims, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].CreatedAt.After(claims[j].CreatedAt)
+	})
+	return claims, nil
+}
+
+// GetVenueClaimAudit retrieves a claim's audit trail, oldest first
+func (s *DynamoDBService) GetVenueClaimAudit(ctx context.Context, claimID string) ([]models.VenueClaimAuditEntry, error) {
+	entries := []models.VenueClaimAuditEntry{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.sourceManagementTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateVenueClaimPK(claimID)},
+				":prefix": &types.AttributeValueMemberS{Value: "AUDIT#"},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query venue claim audit: %w", err)
+		}
+
+		var page []models.VenueClaimAuditEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal venue claim audit: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// maxCatalogChangeAttempts bounds how often recording a change is retried when another change
+// took the next sequence first
+const maxCatalogChangeAttempts = 10
+
+// RecordCatalogChange appends a change to the catalog change log, assigning it the next sequence
+// and the next version of its activity. The entry, the log head and the activity version are written
+// in one transaction that only succeeds if no other change took the sequence, so entries are never
+// committed out of sequence order. streamSequence is the sequence number of the stream record the
+// change came from; a record that was already recorded for the activity is skipped, and false is returned.
+func (s *DynamoDBService) RecordCatalogChange(ctx context.Context, change *models.CatalogChange, streamSequence string) (bool, error) {
+	for attempt := 1; attempt <= maxCatalogChangeAttempts; attempt++ {
+		head, err := s.getCatalogChangeItem(ctx, models.CreateCatalogChangeHeadSK())
+		if err != nil {
+			return false, err
+		}
+		version, err := s.getCatalogChangeItem(ctx, models.CreateCatalogActivityVersionSK(change.ActivityID))
+		if err != nil {
+			return false, err
+		}
+
+		var headSequence, currentVersion int64
+		var lastStreamSequence string
+		if head != nil {
+			if err := attributevalue.Unmarshal(head["sequence"], &headSequence); err != nil {
+				return false, fmt.Errorf("failed to unmarshal catalog change head: %w", err)
+			}
+		}
+		if version != nil {
+			if err := attributevalue.Unmarshal(version["version"], &currentVersion); err != nil {
+				return false, fmt.Errorf("failed to unmarshal catalog activity version: %w", err)
+			}
+			if value, ok := version["stream_sequence"]; ok {
+				_ = attributevalue.Unmarshal(value, &lastStreamSequence)
+			}
+		}
+		if lastStreamSequence != "" && !streamSequenceAfter(streamSequence, lastStreamSequence) {
+			return false, nil
+		}
+
+		change.PK = models.CreateCatalogChangePK()
+		change.Sequence = headSequence + 1
+		change.SK = models.CreateCatalogChangeSK(change.Sequence)
+		change.Version = currentVersion + 1
+		changeItem, err := attributevalue.MarshalMap(change)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal catalog change: %w", err)
+		}
+
+		transactItems := []types.TransactWriteItem{
+			catalogCounterPut(s.familyActivitiesTable, models.CreateCatalogChangeHeadSK(), map[string]types.AttributeValue{
+				"sequence": &types.AttributeValueMemberN{Value: strconv.FormatInt(change.Sequence, 10)},
+			}, "sequence", head != nil, headSequence),
+			catalogCounterPut(s.familyActivitiesTable, models.CreateCatalogActivityVersionSK(change.ActivityID), map[string]types.AttributeValue{
+				"version":         &types.AttributeValueMemberN{Value: strconv.FormatInt(change.Version, 10)},
+				"stream_sequence": &types.AttributeValueMemberS{Value: streamSequence},
+			}, "version", version != nil, currentVersion),
+			{
+				Put: &types.Put{
+					TableName:           aws.String(s.familyActivitiesTable),
+					Item:                changeItem,
+					ConditionExpression: aws.String("attribute_not_exists(PK)"),
+				},
+			},
+		}
+
+		_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+		if err == nil {
+			return true, nil
+		}
+		var canceledErr *types.TransactionCanceledException
+		if !errors.As(err, &canceledErr) {
+			return false, fmt.Errorf("failed to record catalog change: %w", err)
+		}
+		// Another change took the sequence, or wrote concurrently; read the counters again
+	}
+
+	return false, fmt.Errorf("failed to record catalog change for activity %s after %d attempts", change.ActivityID, maxCatalogChangeAttempts)
+}
+
+// catalogCounterPut writes a change log counter item, conditional on it still holding the value
+// it was read with (or still not existing)
+func catalogCounterPut(table, sk string, attributes map[string]types.AttributeValue, counter string, exists bool, current int64) types.TransactWriteItem {
+	item := map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{Value: models.CreateCatalogChangePK()},
+		"SK": &types.AttributeValueMemberS{Value: sk},
+	}
+	for name, value := range attributes {
+		item[name] = value
+	}
+
+	put := &types.Put{
+		TableName:           aws.String(table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	}
+	if exists {
+		put.ConditionExpression = aws.String("#counter = :current")
+		put.ExpressionAttributeNames = map[string]string{"#counter": counter}
+		put.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":current": &types.AttributeValueMemberN{Value: strconv.FormatInt(current, 10)},
+		}
+	}
+	return types.TransactWriteItem{Put: put}
+}
+
+// getCatalogChangeItem reads an item of the change log partition with a consistent read.
+// It returns nil without an error when the item does not exist.
+func (s *DynamoDBService) getCatalogChangeItem(ctx context.Context, sk string) (map[string]types.AttributeValue, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateCatalogChangePK()},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog change item %s: %w", sk, err)
+	}
+	return result.Item, nil
+}
+
+// GetCatalogChangeHead returns the sequence of the latest catalog change, or 0 before the first one
+func (s *DynamoDBService) GetCatalogChangeHead(ctx context.Context) (int64, error) {
+	head, err := s.getCatalogChangeItem(ctx, models.CreateCatalogChangeHeadSK())
+	if err != nil || head == nil {
+		return 0, err
+	}
+	var sequence int64
+	if err := attributevalue.Unmarshal(head["sequence"], &sequence); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal catalog change head: %w", err)
+	}
+	return sequence, nil
+}
+
+// streamSequenceAfter reports whether stream sequence number a comes after b. Sequence numbers
+// are decimal strings of varying length.
+func streamSequenceAfter(a, b string) bool {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
+
+// GetCatalogChanges retrieves up to limit change log entries after a sequence, in sequence order.
+// more is true when further entries may follow.
+func (s *DynamoDBService) GetCatalogChanges(ctx context.Context, since int64, limit int32) (changes []models.CatalogChange, more bool, err error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.familyActivitiesTable),
+		KeyConditionExpression: aws.String("PK = :pk AND SK BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":   &types.AttributeValueMemberS{Value: models.CreateCatalogChangePK()},
+			":from": &types.AttributeValueMemberS{Value: models.CreateCatalogChangeSK(since + 1)},
+			":to":   &types.AttributeValueMemberS{Value: models.CreateCatalogChangeSK(math.MaxInt64)},
+		},
+		Limit:          aws.Int32(limit),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query catalog changes: %w", err)
+	}
+
+	changes = []models.CatalogChange{}
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &changes); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal catalog changes: %w", err)
+	}
+
+	return changes, result.LastEvaluatedKey != nil, nil
+}
+
+// QuerySourcesByStatus queries source submissions by status, reading every shard of the status
+// key in the status-priority-index GSI. The index only projects a few attributes, so the
+// submissions found are read from the table.
+func (s *DynamoDBService) QuerySourcesByStatus(ctx context.Context, status string, limit int32) ([]models.SourceSubmission, error) {
+	items, err := s.queryShards(ctx, shardedQuery{
+		table:   s.sourceManagementTable,
+		index:   "status-priority-index",
+		keyName: "StatusKey",
+		keys:    statusKeyPartitions("STATUS#" + status),
+		filter:  "SK = :sk",
+		values: map[string]types.AttributeValue{
+			":sk": &types.AttributeValueMemberS{Value: models.CreateSourceSubmissionSK()},
+		},
+		forward: true,
+		limit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sources by status: %w", err)
+	}
+
+	// Shards are merged in priority order
+	sort.Slice(items, func(i, j int) bool {
+		return attributeString(items[i], "PriorityKey") < attributeString(items[j], "PriorityKey")
+	})
+	if limit > 0 && len(items) > int(limit) {
+		items = items[:limit]
+	}
+
+	return s.getSourcesForIndexItems(ctx, items)
+}
+
+// QuerySourcesByStatusPage reads one page of up to limit sources in any of the statuses, in
+// priority order, and returns it with the token of the next page, or "" after the last page.
+func (s *DynamoDBService) QuerySourcesByStatusPage(ctx context.Context, statuses []string, limit int32, token string) ([]models.SourceSubmission, string, error) {
+	var keys []string
+	for _, status := range statuses {
+		keys = append(keys, statusKeyPartitions("STATUS#"+status)...)
+	}
+
+	items, next, err := s.queryShardsPage(ctx, shardedQuery{
+		table:   s.sourceManagementTable,
+		index:   "status-priority-index",
+		keyName: "StatusKey",
+		sortKey: "PriorityKey",
+		keys:    keys,
+		filter:  "SK = :sk",
+		values: map[string]types.AttributeValue{
+			":sk": &types.AttributeValueMemberS{Value: models.CreateSourceSubmissionSK()},
+		},
+		forward: true,
+		limit:   limit,
+	}, token, func(a, b map[string]types.AttributeValue) bool {
+		return attributeString(a, "PriorityKey") < attributeString(b, "PriorityKey")
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query sources by status: %w", err)
+	}
+
+	sources, err := s.getSourcesForIndexItems(ctx, items)
+	if err != nil {
+		return nil, "", err
+	}
+	return sources, next, nil
+}
+
+// getSourcesForIndexItems reads the full source submissions of status-priority-index items, which
+// only project some attributes, in priority order
+func (s *DynamoDBService) getSourcesForIndexItems(ctx context.Context, items []map[string]types.AttributeValue) ([]models.SourceSubmission, error) {
+	keys := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, map[string]types.AttributeValue{"PK": item["PK"], "SK": item["SK"]})
+	}
+	fullItems, err := s.batchGetItems(ctx, s.sourceManagementTable, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sources by status: %w", err)
+	}
+
+	var sources []models.SourceSubmission
+	err = attributevalue.UnmarshalListOfMaps(fullItems, &sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sources: %w", err)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].PriorityKey < sources[j].PriorityKey
+	})
+
+	return sources, nil
+}
+
+// shardedQuery reads a GSI partition key that is spread over shards
+type shardedQuery struct {
+	table     string
+	index     string
+	keyName   string            // partition key attribute of the index
+	keys      []string          // partition key value of every shard
+	condition string            // optional sort key condition, e.g. "NextRunKey <= :until"
+	filter    string            // optional filter expression
+	names     map[string]string // expression attribute names used by the condition or filter
+	values    map[string]types.AttributeValue
+	forward   bool  // read each shard in ascending sort key order
+	limit     int32 // items to read per shard; 0 reads every item
+
+	// Paged queries resume each shard after the key of the last item returned from it
+	sortKey   string                                      // sort key attribute of the index
+	startKeys map[string]map[string]types.AttributeValue // exclusive start key of each shard, by shard key
+}
+
+// shardRead is what was read from one shard of a sharded query
+type shardRead struct {
+	items     []map[string]types.AttributeValue
+	exhausted bool // every item left in the shard was read
+}
+
+// queryShards queries every shard of a sharded GSI key concurrently and returns the items of all
+// shards. Each shard is read in sort key order up to the limit, so the first limit items of the
+// merged result, once the caller sorts it the same way, are the first limit items overall.
+func (s *DynamoDBService) queryShards(ctx context.Context, q shardedQuery) ([]map[string]types.AttributeValue, error) {
+	reads, err := s.readShards(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]types.AttributeValue
+	for _, read := range reads {
+		items = append(items, read.items...)
+	}
+	return items, nil
+}
+
+// readShards queries every shard of a sharded GSI key concurrently, returning what was read from
+// each shard in the order of q.keys
+func (s *DynamoDBService) readShards(ctx context.Context, q shardedQuery) ([]shardRead, error) {
+	keyCondition := q.keyName + " = :shard"
+	if q.condition != "" {
+		keyCondition += " AND " + q.condition
+	}
+
+	results := make([]shardRead, len(q.keys))
+	errs := make([]error, len(q.keys))
+	var wg sync.WaitGroup
+	for i, key := range q.keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+
+			values := map[string]types.AttributeValue{
+				":shard": &types.AttributeValueMemberS{Value: key},
+			}
+			for k, v := range q.values {
+				values[k] = v
+			}
+			input := &dynamodb.QueryInput{
+				TableName:                 aws.String(q.table),
+				IndexName:                 aws.String(q.index),
+				KeyConditionExpression:    aws.String(keyCondition),
+				ExpressionAttributeValues: values,
+				ScanIndexForward:          aws.Bool(q.forward),
+			}
+			if q.filter != "" {
+				input.FilterExpression = aws.String(q.filter)
+			}
+			if len(q.names) > 0 {
+				input.ExpressionAttributeNames = q.names
+			}
+			if q.limit > 0 {
+				input.Limit = aws.Int32(q.limit)
+			}
+			if startKey, ok := q.startKeys[key]; ok {
+				input.ExclusiveStartKey = startKey
+			}
+
+			for {
+				result, err := s.client.Query(ctx, input)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to query %s shard %s: %w", q.index, key, err)
+					return
+				}
+				results[i].items = append(results[i].items, result.Items...)
+
+				// Filtered pages can come back short, so keep reading until the shard has enough items
+				if result.LastEvaluatedKey == nil {
+					results[i].exhausted = true
+					break
+				}
+				if q.limit > 0 && len(results[i].items) >= int(q.limit) {
+					break
+				}
+				input.ExclusiveStartKey = result.LastEvaluatedKey
+			}
+			if q.limit > 0 && len(results[i].items) > int(q.limit) {
+				results[i].items = results[i].items[:q.limit]
+				results[i].exhausted = false
+			}
+		}(i, key)
+	}
+	wg.Wait()
+
+	for i := range q.keys {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+	}
+	return results, nil
+}
+
+// statusKeyPartitions returns the partitions holding a status key: its shards, and the unsharded
+// key that items written before status keys were sharded keep until their next write
+func statusKeyPartitions(statusKey string) []string {
+	return append(models.GSIKeyShardValues(statusKey), statusKey)
+}
+
+// attributeString returns a string attribute of an item, or "" when it is missing
+func attributeString(item map[string]types.AttributeValue, name string) string {
+	if value, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return ""
+}
+
+// batchGetItems reads items by key, 100 at a time, retrying keys DynamoDB leaves unprocessed
+func (s *DynamoDBService) batchGetItems(ctx context.Context, table string, keys []map[string]types.AttributeValue) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+	for i := 0; i < len(keys); i += 100 {
+		end := i + 100
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		request := map[string]types.KeysAndAttributes{table: {Keys: keys[i:end]}}
+		for len(request) > 0 {
+			result, err := s.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+			if err != nil {
+				return nil, fmt.Errorf("failed to batch get items: %w", err)
+			}
+			items = append(items, result.Responses[table]...)
+			request = result.UnprocessedKeys
+		}
+	}
+	return items, nil
+}
+
+// Scraping Operations Table Operations
+
+// CreateScrapingTask creates a new scraping task
+func (s *DynamoDBService) CreateScrapingTask(ctx context.Context, task *models.ScrapingTask) error {
+	item, err := scrapingTaskItem(task)
+	if err != nil {
+		return err
+	}
+
+	// Put item
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create scraping task: %w", err)
+	}
+
+	return nil
+}
+
+// GetScrapingTask retrieves a scraping task. The sort key embeds the task's priority and source,
+// so the task is looked up by its partition key alone.
+func (s *DynamoDBService) GetScrapingTask(ctx context.Context, taskID string) (*models.ScrapingTask, error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.scrapingOperationsTable),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: models.CreateTaskPK(taskID)},
+		},
+		Limit: aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scraping task: %w", err)
+	}
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("scraping task not found")
+	}
+
+	var task models.ScrapingTask
+	err = attributevalue.UnmarshalMap(result.Items[0], &task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scraping task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// TransitionScrapingTask moves a scraping task to a new status if its current status allows it.
+// The update is conditional on the status read, so a task that changes concurrently is not
+// overwritten. Tasks leaving the schedule are removed from the next-run index; queued and retrying
+// tasks stay in it until they start, so a start that was lost is found again. It returns the task
+// as read and whether the transition was applied.
+func (s *DynamoDBService) TransitionScrapingTask(ctx context.Context, taskID string, to models.ScrapingTaskStatus) (*models.ScrapingTask, bool, error) {
+	task, err := s.GetScrapingTask(ctx, taskID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !task.CanTransitionTo(to) {
+		return task, false, nil
+	}
+
+	updateExpr := "SET #status = :to, updated_at = :now"
+	switch to {
+	case models.TaskStatusQueued, models.TaskStatusRetrying:
+	case models.TaskStatusCompleted:
+		// Errors from earlier attempts no longer apply
+		updateExpr += " REMOVE NextRunKey, error_message, error_code, retryable"
+	default:
+		updateExpr += " REMOVE NextRunKey"
+	}
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:    aws.String(updateExpr),
+		ConditionExpression: aws.String("#status = :current"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":to":      &types.AttributeValueMemberS{Value: string(to)},
+			":current": &types.AttributeValueMemberS{Value: string(task.Status)},
+			":now":     &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return task, false, nil
+		}
+		return task, false, fmt.Errorf("failed to update scraping task %s: %w", taskID, err)
+	}
+	task.Status = to
+	return task, true, nil
+}
+
+// RecordScrapingTaskRestart counts a restart of a queued scraping task whose start was lost and
+// moves the task's next-run key to nextCheck, when the scheduler looks at it again. The update
+// only applies while the task is still queued with the restart count that was read, so two
+// scheduler runs do not both restart it. It returns whether the update was applied.
+func (s *DynamoDBService) RecordScrapingTaskRestart(ctx context.Context, task *models.ScrapingTask, nextCheck time.Time) (bool, error) {
+	conditionExpr := "#status = :queued AND attribute_not_exists(restart_count)"
+	exprAttrValues := map[string]types.AttributeValue{
+		":now":      &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339Nano)},
+		":next_run": &types.AttributeValueMemberS{Value: models.GenerateNextRunKey(nextCheck)},
+		":queued":   &types.AttributeValueMemberS{Value: string(models.TaskStatusQueued)},
+		":one":      &types.AttributeValueMemberN{Value: "1"},
+	}
+	if task.RestartCount > 0 {
+		conditionExpr = "#status = :queued AND restart_count = :seen"
+		exprAttrValues[":seen"] = &types.AttributeValueMemberN{Value: strconv.Itoa(task.RestartCount)}
+	}
+
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:    aws.String("SET updated_at = :now, NextRunKey = :next_run ADD restart_count :one"),
+		ConditionExpression: aws.String(conditionExpr),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: exprAttrValues,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to record restart of scraping task %s: %w", task.TaskID, err)
+	}
+	task.RestartCount++
+	return true, nil
+}
+
+// RecordScrapingTaskHeartbeat marks an in-progress scraping task as alive on behalf of a run, and
+// records the run's latest checkpoint when set. The first heartbeat links the task to its run;
+// heartbeats from an older run of the task are ignored, as is a task that is no longer in progress.
+func (s *DynamoDBService) RecordScrapingTaskHeartbeat(ctx context.Context, taskID, runID, checkpoint string) error {
+	task, err := s.GetScrapingTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	updateExpr := "SET heartbeat_at = :now, run_id = :run_id"
+	exprAttrValues := map[string]types.AttributeValue{
+		":now":         &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339Nano)},
+		":run_id":      &types.AttributeValueMemberS{Value: runID},
+		":in_progress": &types.AttributeValueMemberS{Value: string(models.TaskStatusInProgress)},
+	}
+	if checkpoint != "" {
+		updateExpr += ", last_checkpoint = :checkpoint"
+		exprAttrValues[":checkpoint"] = &types.AttributeValueMemberS{Value: checkpoint}
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:    aws.String(updateExpr),
+		ConditionExpression: aws.String("#status = :in_progress AND (attribute_not_exists(run_id) OR run_id = :run_id)"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: exprAttrValues,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return nil
+		}
+		return fmt.Errorf("failed to record heartbeat for scraping task %s: %w", taskID, err)
+	}
+	return nil
+}
+
+// GetStaleScrapingTasks retrieves the in-progress scraping tasks that have not been seen for longer than timeout
+func (s *DynamoDBService) GetStaleScrapingTasks(ctx context.Context, now time.Time, timeout time.Duration) ([]models.ScrapingTask, error) {
+	var stale []models.ScrapingTask
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND #status = :in_progress"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix":      &types.AttributeValueMemberS{Value: models.CreateTaskPK("")},
+				":in_progress": &types.AttributeValueMemberS{Value: string(models.TaskStatusInProgress)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan in-progress scraping tasks: %w", err)
+		}
+
+		var tasks []models.ScrapingTask
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		}
+		for _, task := range tasks {
+			if task.HeartbeatStale(now, timeout) {
+				stale = append(stale, task)
+			}
+		}
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+	return stale, nil
+}
+
+// FailScrapingTask records a failure on an in-progress scraping task and takes it off its run.
+// A retryable failure on a task with retries left moves it to retrying, due again after the
+// retry backoff, or after the wait FireCrawl asked for when that is longer; any other failure fails the task for good. Either way the task is unlinked from
+// its run, so the next run can claim it. The update only applies while the task is still in
+// progress with the heartbeat that was read, so a task that came back to life is not failed.
+// It returns the task's new status and whether the update was applied.
+func (s *DynamoDBService) FailScrapingTask(ctx context.Context, task *models.ScrapingTask, failure TaskFailure) (models.ScrapingTaskStatus, bool, error) {
+	now := time.Now().UTC()
+	to := models.TaskStatusFailed
+	updateExpr := "SET #status = :to, updated_at = :now, error_message = :message, error_code = :code, retryable = :retryable REMOVE NextRunKey, run_id"
+	exprAttrValues := map[string]types.AttributeValue{
+		":to":          &types.AttributeValueMemberS{Value: string(to)},
+		":now":         &types.AttributeValueMemberS{Value: now.Format(time.RFC3339Nano)},
+		":message":     &types.AttributeValueMemberS{Value: failure.Message},
+		":code":        &types.AttributeValueMemberS{Value: failure.Code},
+		":retryable":   &types.AttributeValueMemberBOOL{Value: failure.Retryable},
+		":in_progress": &types.AttributeValueMemberS{Value: string(models.TaskStatusInProgress)},
+	}
+	if failure.Retryable && task.CanRetry() {
+		to = models.TaskStatusRetrying
+		backoff := models.RetryBackoff(task.RetryCount + 1)
+		if retryAfter := time.Duration(failure.RetryAfterSeconds) * time.Second; retryAfter > backoff {
+			backoff = retryAfter
+		}
+		nextRun := now.Add(backoff)
+		updateExpr = "SET #status = :to, updated_at = :now, error_message = :message, error_code = :code, retryable = :retryable, " +
+			"last_retry_at = :now, scheduled_time = :next_run_time, NextRunShard = :next_run_shard, NextRunKey = :next_run REMOVE run_id ADD retry_count :one"
+		exprAttrValues[":to"] = &types.AttributeValueMemberS{Value: string(to)}
+		exprAttrValues[":next_run_time"] = &types.AttributeValueMemberS{Value: nextRun.Format(time.RFC3339Nano)}
+		exprAttrValues[":next_run_shard"] = &types.AttributeValueMemberS{Value: models.GenerateNextRunShardKey(task.TaskID)}
+		exprAttrValues[":next_run"] = &types.AttributeValueMemberS{Value: models.GenerateNextRunKey(nextRun)}
+		exprAttrValues[":one"] = &types.AttributeValueMemberN{Value: "1"}
+	}
+
+	conditionExpr := "#status = :in_progress AND attribute_not_exists(heartbeat_at)"
+	if task.HeartbeatAt != nil {
+		conditionExpr = "#status = :in_progress AND heartbeat_at = :seen"
+		exprAttrValues[":seen"] = &types.AttributeValueMemberS{Value: task.HeartbeatAt.Format(time.RFC3339Nano)}
+	}
+
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:    aws.String(updateExpr),
+		ConditionExpression: aws.String(conditionExpr),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: exprAttrValues,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return task.Status, false, nil
+		}
+		return task.Status, false, fmt.Errorf("failed to fail scraping task %s: %w", task.TaskID, err)
+	}
+
+	task.Status = to
+	task.ErrorMessage = failure.Message
+	task.ErrorCode = failure.Code
+	task.Retryable = failure.Retryable
+	if to == models.TaskStatusRetrying {
+		task.RetryCount++
+	}
+	return to, true, nil
+}
+
+// GetDueRetryingScrapingTasks retrieves the retrying scraping tasks whose retry backoff has passed
+func (s *DynamoDBService) GetDueRetryingScrapingTasks(ctx context.Context, now time.Time) ([]models.ScrapingTask, error) {
+	var due []models.ScrapingTask
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND #status = :retrying"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix":   &types.AttributeValueMemberS{Value: models.CreateTaskPK("")},
+				":retrying": &types.AttributeValueMemberS{Value: string(models.TaskStatusRetrying)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan retrying scraping tasks: %w", err)
+		}
+
+		var tasks []models.ScrapingTask
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		}
+		for _, task := range tasks {
+			if !task.ScheduledTime.After(now) {
+				due = append(due, task)
+			}
+		}
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+	return due, nil
+}
+
+// GetUnshardedScrapingTasks retrieves the scraping tasks still in the next-run index that were
+// written before the index was sharded, so they have a NextRunKey but no NextRunShard. They are not
+// in next-run-shard-index until SetScrapingTaskNextRunShard gives them a shard.
+func (s *DynamoDBService) GetUnshardedScrapingTasks(ctx context.Context) ([]models.ScrapingTask, error) {
+	var tasks []models.ScrapingTask
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND attribute_exists(NextRunKey) AND attribute_not_exists(NextRunShard)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateTaskPK("")},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unsharded scraping tasks: %w", err)
+		}
+
+		var page []models.ScrapingTask
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		}
+		tasks = append(tasks, page...)
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+// SetScrapingTaskNextRunShard adds a task written before the next-run index was sharded to its
+// shard of next-run-shard-index. The update only applies while the task is still in the next-run
+// index without a shard, so a task that started or was sharded meanwhile is left alone. It returns
+// whether the update was applied.
+func (s *DynamoDBService) SetScrapingTaskNextRunShard(ctx context.Context, task *models.ScrapingTask) (bool, error) {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:    aws.String("SET NextRunShard = :shard"),
+		ConditionExpression: aws.String("attribute_exists(NextRunKey) AND attribute_not_exists(NextRunShard)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":shard": &types.AttributeValueMemberS{Value: models.GenerateNextRunShardKey(task.TaskID)},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to shard scraping task %s: %w", task.TaskID, err)
+	}
+	task.NextRunShard = models.GenerateNextRunShardKey(task.TaskID)
+	return true, nil
+}
+
+// QueryNextScrapingTasks queries the tasks with the status that are due by maxTime, soonest
+// first, reading every shard of the next-run-shard-index GSI. Queued and retrying tasks stay in
+// the index, so filtering on the status keeps them from filling the limit.
+func (s *DynamoDBService) QueryNextScrapingTasks(ctx context.Context, maxTime time.Time, status models.ScrapingTaskStatus, limit int32) ([]models.ScrapingTask, error) {
+	items, err := s.queryShards(ctx, shardedQuery{
+		table:     s.scrapingOperationsTable,
+		index:     "next-run-shard-index",
+		keyName:   "NextRunShard",
+		keys:      models.GSIKeyShardValues("NEXT_RUN"),
+		condition: "NextRunKey <= :nextRunKey",
+		filter:    "#status = :status",
+		names: map[string]string{
+			"#status": "status",
+		},
+		values: map[string]types.AttributeValue{
+			":nextRunKey": &types.AttributeValueMemberS{Value: models.GenerateNextRunKey(maxTime)},
+			":status":     &types.AttributeValueMemberS{Value: string(status)},
+		},
+		forward: true,
+		limit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query next scraping tasks: %w", err)
+	}
+
+	var tasks []models.ScrapingTask
+	err = attributevalue.UnmarshalListOfMaps(items, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].NextRunKey < tasks[j].NextRunKey
+	})
+	if limit > 0 && len(tasks) > int(limit) {
+		tasks = tasks[:limit]
+	}
+
+	return tasks, nil
+}
+
+// GetScrapingTaskLoad counts the scraping tasks that are running or waiting to run.
+// Scheduled tasks are split into those due within the hour after now and those already overdue.
+func (s *DynamoDBService) GetScrapingTaskLoad(ctx context.Context, now time.Time) (*models.ScrapingTaskLoad, error) {
+	load := &models.ScrapingTaskLoad{}
+	horizon := now.Add(time.Hour)
+
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("#status IN (:scheduled, :queued, :in_progress, :retrying)"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":scheduled":   &types.AttributeValueMemberS{Value: string(models.TaskStatusScheduled)},
+				":queued":      &types.AttributeValueMemberS{Value: string(models.TaskStatusQueued)},
+				":in_progress": &types.AttributeValueMemberS{Value: string(models.TaskStatusInProgress)},
+				":retrying":    &types.AttributeValueMemberS{Value: string(models.TaskStatusRetrying)},
+			},
+			ProjectionExpression: aws.String("#status, scheduled_time"),
+			ExclusiveStartKey:    lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scraping task load: %w", err)
+		}
+
+		var tasks []models.ScrapingTask
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		}
+		for _, task := range tasks {
+			switch task.Status {
+			case models.TaskStatusInProgress:
+				load.InProgress++
+			case models.TaskStatusQueued:
+				load.Queued++
+			case models.TaskStatusRetrying:
+				load.Retrying++
+			case models.TaskStatusScheduled:
+				if task.ScheduledTime.Before(now) {
+					load.Overdue++
+				} else if !task.ScheduledTime.After(horizon) {
+					load.ScheduledNextHour++
+				}
+			}
+		}
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+
+	return load, nil
+}
+
+// Helper function to populate GSI keys for family activities
+func (s *DynamoDBService) populateFamilyActivityGSIKeys(activity *models.FamilyActivity) {
+	// Generate location key
+	if activity.Location.Region != "" && activity.Location.City != "" {
+		activity.LocationKey = models.GenerateLocationKey(activity.Location.Region, activity.Location.City)
+	}
+
+	// Generate category-age key (use first age group if available)
+	if activity.Category != "" && len(activity.AgeGroups) > 0 {
+		activity.CategoryAgeKey = models.GenerateCategoryAgeKey(activity.Category, activity.AgeGroups[0].Category)
+	}
+
+	// Generate venue key for events and programs
+	switch activity.EntityType {
+	case models.EntityTypeEvent, models.EntityTypeProgram:
+		// For events and programs, we'd need to extract venue_id from the specific type
+		// This would be populated when creating typed entities (Event, Program)
+	}
+
+	// Generate provider key
+	if activity.ProviderID != "" {
+		activity.ProviderKey = "PROVIDER#" + activity.ProviderID
+	}
+
+	// Generate type-status key
+	if activity.EntityType != "" && activity.Status != "" {
+		activity.TypeStatusKey = models.GenerateTypeStatusKey(activity.EntityType, activity.Status, activity.EntityID)
+	}
+}
+
+// BatchPutActivities stores multiple activities in batches (for task executor)
+func (s *DynamoDBService) BatchPutActivities(ctx context.Context, activities []*models.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	// Convert Activity to FamilyActivity format for storage
+	var familyActivities []*models.FamilyActivity
+	for _, activity := range activities {
+		familyActivity := s.convertActivityToFamilyActivity(activity)
+		familyActivities = append(familyActivities, familyActivity)
+	}
+
+	// Process in batches of 25 (DynamoDB limit)
+	batchSize := 25
+	for i := 0; i < len(familyActivities); i += batchSize {
+		end := i + batchSize
+		if end > len(familyActivities) {
+			end = len(familyActivities)
+		}
+
+		batch := familyActivities[i:end]
+		if err := s.batchWriteFamilyActivities(ctx, batch); err != nil {
+			return fmt.Errorf("failed to write batch %d-%d: %w", i, end-1, err)
+		}
+	}
+
+	return nil
+}
+
+// batchWriteFamilyActivities writes a batch of family activities
+func (s *DynamoDBService) batchWriteFamilyActivities(ctx context.Context, activities []*models.FamilyActivity) error {
+	writeRequests := make([]types.WriteRequest, 0, len(activities))
+
+	for _, activity := range activities {
+		// Set timestamps
+		now := time.Now()
+		activity.CreatedAt = now
+		activity.UpdatedAt = now
+
+		// Populate GSI keys
+		s.populateFamilyActivityGSIKeys(activity)
+
+		// Marshal activity
+		item, err := attributevalue.MarshalMap(activity)
+		if err != nil {
+			return fmt.Errorf("failed to marshal activity %s: %w", activity.EntityID, err)
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	// Execute batch write
+	_, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			s.familyActivitiesTable: writeRequests,
+		},
+	})
+
+	return err
+}
+
+// GetAllActivities retrieves all activities from the family activities table (for S3 export)
+func (s *DynamoDBService) GetAllActivities(ctx context.Context) ([]*models.Activity, error) {
+	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		// Skip search suggestion entries, which share the table
+		FilterExpression: aws.String("attribute_exists(entity_type)"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan activities: %w", err)
+	}
+
+	var familyActivities []models.FamilyActivity
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &familyActivities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal activities: %w", err)
+	}
+
+	// Convert to Activity format
+	var activities []*models.Activity
+	for _, fa := range familyActivities {
+		activity := s.convertFamilyActivityToActivity(&fa)
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// AddSearchSuggestion counts one more approved activity for a term under one of its index keys
+func (s *DynamoDBService) AddSearchSuggestion(ctx context.Context, key, kind, text, normalizedText string) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSuggestionPK(key)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSuggestionSK(key, kind, normalizedText)},
+		},
+		UpdateExpression: aws.String("ADD #count :one SET kind = :kind, #text = :text"),
+		ExpressionAttributeNames: map[string]string{
+			"#count": "count",
+			"#text":  "text",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":  &types.AttributeValueMemberN{Value: "1"},
+			":kind": &types.AttributeValueMemberS{Value: kind},
+			":text": &types.AttributeValueMemberS{Value: text},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add search suggestion: %w", err)
+	}
+
+	return nil
+}
+
+// QuerySearchSuggestions retrieves up to limit index entries whose key starts with a normalized prefix
+func (s *DynamoDBService) QuerySearchSuggestions(ctx context.Context, prefix string, limit int32) ([]models.SearchSuggestion, error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.familyActivitiesTable),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: models.CreateSuggestionPK(prefix)},
+			":prefix": &types.AttributeValueMemberS{Value: prefix},
+		},
+		Limit: aws.Int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query search suggestions: %w", err)
+	}
+
+	var suggestions []models.SearchSuggestion
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &suggestions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// GetVenueNames retrieves the names of all venues in the family activities table
+func (s *DynamoDBService) GetVenueNames(ctx context.Context) ([]string, error) {
+	venues, err := s.GetVenues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(venues))
+	for _, venue := range venues {
+		if venue.VenueName != "" {
+			names = append(names, venue.VenueName)
+		} else if venue.Name != "" {
+			names = append(names, venue.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// GetVenues retrieves every venue in the family activities table
+func (s *DynamoDBService) GetVenues(ctx context.Context) ([]models.Venue, error) {
+	var venues []models.Venue
+	var lastEvaluatedKey map[string]types.AttributeValue
+
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.familyActivitiesTable),
+			FilterExpression: aws.String("entity_type = :type"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":type": &types.AttributeValueMemberS{Value: models.EntityTypeVenue},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan venues: %w", err)
+		}
+
+		var page []models.Venue
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal venues: %w", err)
+		}
+		venues = append(venues, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return venues, nil
+}
+
+// GetVenue retrieves a registry venue by ID, returning nil when it does not exist
+func (s *DynamoDBService) GetVenue(ctx context.Context, venueID string) (*models.Venue, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateVenuePK(venueID)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyMetadata},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var venue models.Venue
+	if err := attributevalue.UnmarshalMap(result.Item, &venue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal venue: %w", err)
+	}
+
+	return &venue, nil
+}
+
+// SaveVenueTransit stores the transit score of an existing registry venue
+func (s *DynamoDBService) SaveVenueTransit(ctx context.Context, venueID string, transit *models.VenueTransit) error {
+	item, err := attributevalue.Marshal(transit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal venue transit: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateVenuePK(venueID)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyMetadata},
+		},
+		UpdateExpression:    aws.String("SET transit = :transit"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":transit": item,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save venue transit: %w", err)
+	}
+
+	return nil
+}
+
+// ErrVenueNotFound is returned when a change is made to a venue that does not exist
+var ErrVenueNotFound = errors.New("venue not found")
+
+// SaveVenueArrivalInfo stores the arrival info of an existing registry venue, removing it when it is empty.
+// It returns ErrVenueNotFound when the venue does not exist.
+func (s *DynamoDBService) SaveVenueArrivalInfo(ctx context.Context, venueID string, info *models.ArrivalInfo) error {
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateVenuePK(venueID)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyMetadata},
+		},
+		UpdateExpression:    aws.String("REMOVE arrival_info"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+	}
+	if !info.IsEmpty() {
+		item, err := attributevalue.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("failed to marshal venue arrival info: %w", err)
+		}
+		input.UpdateExpression = aws.String("SET arrival_info = :arrival")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":arrival": item,
+		}
+	}
+
+	if _, err := s.client.UpdateItem(ctx, input); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrVenueNotFound
+		}
+		return fmt.Errorf("failed to save venue arrival info: %w", err)
+	}
+
+	return nil
+}
+
+// GetVenueCalendarEntries returns a venue's calendar entries from one date to another (YYYY-MM-DD, inclusive)
+// using the venue-date-index GSI
+func (s *DynamoDBService) GetVenueCalendarEntries(ctx context.Context, venueID, from, to string) ([]models.CalendarEntry, error) {
+	var entries []models.CalendarEntry
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			IndexName:              aws.String("venue-date-index"),
+			KeyConditionExpression: aws.String("VenueKey = :venueKey AND DateTypeKey BETWEEN :from AND :to"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":venueKey": &types.AttributeValueMemberS{Value: models.GenerateVenueKey(venueID)},
+				":from":     &types.AttributeValueMemberS{Value: "DATE#" + from},
+				":to":       &types.AttributeValueMemberS{Value: "DATE#" + to + "#~"}, // sorts after every key on that date
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query venue calendar: %w", err)
+		}
+
+		var page []models.CalendarEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal calendar entries: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// convertActivityToFamilyActivity converts a simple Activity to the complex FamilyActivity format
+func (s *DynamoDBService) convertActivityToFamilyActivity(activity *models.Activity) *models.FamilyActivity {
+	// TODO: Implement proper conversion when needed
+	// For now, return a minimal FamilyActivity to satisfy the interface
 	return &models.FamilyActivity{
+		PK:          models.CreateEventPK(activity.ID),
+		SK:          models.SortKeyMetadata,
 		EntityID:    activity.ID,
 		EntityType:  models.EntityTypeEvent,
 		Name:        activity.Title,
@@ -658,94 +2759,2077 @@ func (s *DynamoDBService) convertActivityToFamilyActivity(activity *models.Activ
 	}
 }
 
-// convertFamilyActivityToActivity converts a complex FamilyActivity to simple Activity format
-func (s *DynamoDBService) convertFamilyActivityToActivity(fa *models.FamilyActivity) *models.Activity {
-	// TODO: Implement proper conversion when needed
-	// For now, return a minimal Activity to satisfy the interface
-	return &models.Activity{
-		ID:          fa.EntityID,
-		Title:       fa.Name,
-		Description: fa.Description,
-		Type:        string(fa.EntityType),
+// ReplaceCalendarEntries stores the days an activity occurs on, removing entries for days
+// it no longer occurs on
+func (s *DynamoDBService) ReplaceCalendarEntries(ctx context.Context, activityID string, entries []models.CalendarEntry) error {
+	items := make(map[string]map[string]types.AttributeValue, len(entries))
+	for _, entry := range entries {
+		item, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal calendar entry %s: %w", entry.SK, err)
+		}
+		items[entry.SK] = item
+	}
+
+	if err := s.replaceActivityItems(ctx, activityID, models.CreateCalendarEntrySKPrefix(), items); err != nil {
+		return fmt.Errorf("failed to replace calendar entries: %w", err)
+	}
+	return nil
+}
+
+// ReplaceTagEntries stores the tags of a published activity in the tag index, removing entries
+// for tags it no longer has
+func (s *DynamoDBService) ReplaceTagEntries(ctx context.Context, activityID string, entries []models.TagEntry) error {
+	items := make(map[string]map[string]types.AttributeValue, len(entries))
+	for _, entry := range entries {
+		item, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tag entry %s: %w", entry.SK, err)
+		}
+		items[entry.SK] = item
+	}
+
+	if err := s.replaceActivityItems(ctx, activityID, models.CreateTagSK(""), items); err != nil {
+		return fmt.Errorf("failed to replace tag entries: %w", err)
+	}
+	return nil
+}
+
+// ReplaceGeoEntry stores where a published activity is held for nearby searches, removing its
+// entry when entry is nil
+func (s *DynamoDBService) ReplaceGeoEntry(ctx context.Context, activityID string, entry *models.GeoEntry) error {
+	items := make(map[string]map[string]types.AttributeValue, 1)
+	if entry != nil {
+		item, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal geo entry: %w", err)
+		}
+		items[entry.SK] = item
+	}
+
+	if err := s.replaceActivityItems(ctx, activityID, models.SortKeyGeo, items); err != nil {
+		return fmt.Errorf("failed to replace geo entry: %w", err)
+	}
+	return nil
+}
+
+// GetGeoEntries returns the geo entries in geohash cells using the geohash-index GSI. Cells are
+// 4-character partitions, or 5-character prefixes within them.
+func (s *DynamoDBService) GetGeoEntries(ctx context.Context, cells []string) ([]models.GeoEntry, error) {
+	entries := []models.GeoEntry{}
+	for _, cell := range cells {
+		if len(cell) < 4 {
+			return nil, fmt.Errorf("invalid geohash cell %q: must have at least 4 characters", cell)
+		}
+
+		input := &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			IndexName:              aws.String("geohash-index"),
+			KeyConditionExpression: aws.String("GeoCellKey = :cellKey"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":cellKey": &types.AttributeValueMemberS{Value: models.GenerateGeoCellKey(cell[:4])},
+			},
+		}
+		if len(cell) > 4 {
+			input.KeyConditionExpression = aws.String("GeoCellKey = :cellKey AND begins_with(GeoSortKey, :prefix)")
+			input.ExpressionAttributeValues[":prefix"] = &types.AttributeValueMemberS{Value: cell}
+		}
+
+		for {
+			result, err := s.client.Query(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query geohash cell %s: %w", cell, err)
+			}
+
+			var page []models.GeoEntry
+			if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal geo entries: %w", err)
+			}
+			entries = append(entries, page...)
+
+			if result.LastEvaluatedKey == nil {
+				break
+			}
+			input.ExclusiveStartKey = result.LastEvaluatedKey
+		}
+	}
+
+	return entries, nil
+}
+
+// replaceActivityItems writes the items, keyed by sort key, stored under an activity's partition
+// with a sort key prefix, deleting the stored items with that prefix that are not among them
+func (s *DynamoDBService) replaceActivityItems(ctx context.Context, activityID, skPrefix string, items map[string]map[string]types.AttributeValue) error {
+	var writeRequests []types.WriteRequest
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateEventPK(activityID)},
+				":prefix": &types.AttributeValueMemberS{Value: skPrefix},
+			},
+			ProjectionExpression: aws.String("PK, SK"),
+			ExclusiveStartKey:    lastEvaluatedKey,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query stored items: %w", err)
+		}
+
+		for _, item := range result.Items {
+			if sk, ok := item["SK"].(*types.AttributeValueMemberS); ok && items[sk.Value] == nil {
+				writeRequests = append(writeRequests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: item},
+				})
+			}
+		}
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	for _, item := range items {
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	// Process in batches of 25 (DynamoDB limit)
+	for i := 0; i < len(writeRequests); i += 25 {
+		end := i + 25
+		if end > len(writeRequests) {
+			end = len(writeRequests)
+		}
+		_, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				s.familyActivitiesTable: writeRequests[i:end],
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetCalendarEntriesForDate returns every calendar entry on a date (YYYY-MM-DD), read from its
+// month's partition of the month-date-index GSI
+func (s *DynamoDBService) GetCalendarEntriesForDate(ctx context.Context, date string) ([]models.CalendarEntry, error) {
+	if len(date) != len("2006-01-02") {
+		return nil, fmt.Errorf("invalid date %q: must be YYYY-MM-DD", date)
+	}
+
+	var entries []models.CalendarEntry
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.familyActivitiesTable),
+			IndexName:              aws.String("month-date-index"),
+			KeyConditionExpression: aws.String("CalendarMonthKey = :month AND DateTypeKey BETWEEN :from AND :to"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":month": &types.AttributeValueMemberS{Value: models.GenerateCalendarMonthKey(date[:7])},
+				":from":  &types.AttributeValueMemberS{Value: "DATE#" + date},
+				":to":    &types.AttributeValueMemberS{Value: "DATE#" + date + "#~"}, // sorts after every key on that date
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query calendar date: %w", err)
+		}
+
+		var page []models.CalendarEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal calendar entries: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// GetCalendarEntriesForRange returns the calendar entries from one date to another (inclusive),
+// querying the days concurrently. Entries are ordered by date, then start time and title; with a
+// limit, only the first limit of them are returned.
+func (s *DynamoDBService) GetCalendarEntriesForRange(ctx context.Context, from, to time.Time, limit int) ([]models.CalendarEntry, error) {
+	return QueryDateRange(ctx, from, to, limit, DateRangeParallelism, s.GetCalendarEntriesForDate)
+}
+
+// convertFamilyActivityToActivity converts a complex FamilyActivity to simple Activity format
+func (s *DynamoDBService) convertFamilyActivityToActivity(fa *models.FamilyActivity) *models.Activity {
+	// TODO: Implement proper conversion when needed
+	// For now, return a minimal Activity to satisfy the interface
+	return &models.Activity{
+		ID:          fa.EntityID,
+		Title:       fa.Name,
+		Description: fa.Description,
+		Type:        string(fa.EntityType),
+	}
+}
+
+// ErrSourceStatusChanged is returned when a source is no longer in the status a change expected
+var ErrSourceStatusChanged = errors.New("source status changed")
+
+// SetSourceStatus moves a source from one status to another. The submission and, when the
+// source has one, its production config are updated in one transaction, and only if the
+// submission is still in the from status; otherwise ErrSourceStatusChanged is returned.
+// reason is stored as the pause reason and is cleared by an empty string.
+func (s *DynamoDBService) SetSourceStatus(ctx context.Context, sourceID, from, to, reason string) error {
+	now := time.Now().Format(time.RFC3339Nano)
+	statusValues := map[string]types.AttributeValue{
+		":from":       &types.AttributeValueMemberS{Value: from},
+		":to":         &types.AttributeValueMemberS{Value: to},
+		":status_key": &types.AttributeValueMemberS{Value: models.GenerateSourceStatusKey(to, sourceID)},
+		":now":        &types.AttributeValueMemberS{Value: now},
+	}
+
+	submissionUpdate := "SET #status = :to, StatusKey = :status_key, updated_at = :now REMOVE pause_reason"
+	submissionValues := map[string]types.AttributeValue{}
+	for k, v := range statusValues {
+		submissionValues[k] = v
+	}
+	if reason != "" {
+		submissionUpdate = "SET #status = :to, StatusKey = :status_key, updated_at = :now, pause_reason = :reason"
+		submissionValues[":reason"] = &types.AttributeValueMemberS{Value: reason}
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				TableName: aws.String(s.sourceManagementTable),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+					"SK": &types.AttributeValueMemberS{Value: models.CreateSourceSubmissionSK()},
+				},
+				UpdateExpression:          aws.String(submissionUpdate),
+				ConditionExpression:       aws.String("#status = :from"),
+				ExpressionAttributeNames:  map[string]string{"#status": "status"},
+				ExpressionAttributeValues: submissionValues,
+			},
+		},
+	}
+
+	// Sources activated before production configs existed have only a submission
+	if _, err := s.GetSourceConfig(ctx, sourceID); err == nil {
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Update: &types.Update{
+				TableName: aws.String(s.sourceManagementTable),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+					"SK": &types.AttributeValueMemberS{Value: models.CreateSourceConfigSK()},
+				},
+				UpdateExpression:         aws.String("SET #status = :to, StatusKey = :status_key, last_modified = :now"),
+				ConditionExpression:      aws.String("attribute_exists(PK)"),
+				ExpressionAttributeNames: map[string]string{"#status": "status"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":to":         statusValues[":to"],
+					":status_key": statusValues[":status_key"],
+					":now":        statusValues[":now"],
+				},
+			},
+		})
+	}
+
+	_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) > 0 &&
+			aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+			return ErrSourceStatusChanged
+		}
+		return fmt.Errorf("failed to set source %s status to %s: %w", sourceID, to, err)
+	}
+	return nil
+}
+
+// scrapingTaskItem sets a new scraping task's timestamps, TTL and index keys and marshals it
+func scrapingTaskItem(task *models.ScrapingTask) (map[string]types.AttributeValue, error) {
+	// Set timestamps and TTL
+	now := time.Now()
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	
+	// Set TTL (90 days from now)
+	task.TTL = models.CalculateTTL(90 * 24 * time.Hour)
+
+	// Generate GSI keys
+	task.NextRunShard = models.GenerateNextRunShardKey(task.TaskID)
+	task.NextRunKey = models.GenerateNextRunKey(task.ScheduledTime)
+	task.PrioritySourceKey = models.GeneratePrioritySourceKey(task.Priority, task.SourceID, task.TaskID)
+
+	// Marshal to DynamoDB attribute values
+	item, err := attributevalue.MarshalMap(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scraping task: %w", err)
+	}
+	return item, nil
+}
+
+// GetPendingTasksForSource retrieves a source's scraping tasks that have not started yet
+// (scheduled, queued or waiting to retry)
+func (s *DynamoDBService) GetPendingTasksForSource(ctx context.Context, sourceID string) ([]models.ScrapingTask, error) {
+	var pending []models.ScrapingTask
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("source_id = :source_id AND #status IN (:scheduled, :queued, :retrying)"),
+			ConsistentRead:   aws.Bool(true), // a task created just before must be seen
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":source_id": &types.AttributeValueMemberS{Value: sourceID},
+				":scheduled": &types.AttributeValueMemberS{Value: string(models.TaskStatusScheduled)},
+				":queued":    &types.AttributeValueMemberS{Value: string(models.TaskStatusQueued)},
+				":retrying":  &types.AttributeValueMemberS{Value: string(models.TaskStatusRetrying)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending tasks for source %s: %w", sourceID, err)
+		}
+
+		var tasks []models.ScrapingTask
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		}
+		pending = append(pending, tasks...)
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+
+	return pending, nil
+}
+
+// CancelPendingTasksForSource cancels a source's scraping tasks that have not started yet
+// (scheduled, queued or waiting to retry) and removes them from the next-run index.
+// A task that starts while it is being cancelled is left alone. It returns the IDs of the
+// cancelled tasks.
+func (s *DynamoDBService) CancelPendingTasksForSource(ctx context.Context, sourceID string) ([]string, error) {
+	pending, err := s.GetPendingTasksForSource(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled := []string{}
+	for _, task := range pending {
+		if !task.CanTransitionTo(models.TaskStatusCancelled) {
+			continue
+		}
+		_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName: aws.String(s.scrapingOperationsTable),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: task.PK},
+				"SK": &types.AttributeValueMemberS{Value: task.SK},
+			},
+			UpdateExpression:    aws.String("SET #status = :cancelled, updated_at = :now REMOVE NextRunKey"),
+			ConditionExpression: aws.String("#status = :current"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":cancelled": &types.AttributeValueMemberS{Value: string(models.TaskStatusCancelled)},
+				":current":   &types.AttributeValueMemberS{Value: string(task.Status)},
+				":now":       &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339Nano)},
+			},
+		})
+		if err != nil {
+			var conditionErr *types.ConditionalCheckFailedException
+			if errors.As(err, &conditionErr) {
+				continue // the task started or changed since the scan
+			}
+			return cancelled, fmt.Errorf("failed to cancel task %s: %w", task.TaskID, err)
+		}
+		cancelled = append(cancelled, task.TaskID)
+	}
+	return cancelled, nil
+}
+
+// ErrManualTriggerConflict is returned when another manual trigger of the source was recorded first
+var ErrManualTriggerConflict = errors.New("source was triggered concurrently")
+
+// ErrManualTriggerLimitReached is returned when the hour's manual triggers, shared by all sources,
+// are used up
+var ErrManualTriggerLimitReached = errors.New("manual trigger limit reached")
+
+// GetManualTriggerState retrieves a source's streak of manual triggers.
+// It returns nil without an error when the source has no streak.
+func (s *DynamoDBService) GetManualTriggerState(ctx context.Context, sourceID string) (*models.ManualTriggerState, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateManualTriggerPK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateManualTriggerSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manual trigger state for source %s: %w", sourceID, err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var state models.ManualTriggerState
+	if err := attributevalue.UnmarshalMap(result.Item, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manual trigger state: %w", err)
+	}
+	return &state, nil
+}
+
+// CreateManualScrapingTask creates a manually triggered scraping task and records the trigger in
+// one transaction: the trigger counts against the hourly limit shared by all sources, and the
+// source's manual trigger state is replaced with next as long as it is still previous. Nothing is
+// written unless all of it succeeds. It returns ErrManualTriggerLimitReached when the hour's limit
+// is already reached, and ErrManualTriggerConflict when another trigger of the source was recorded
+// since previous was read, so only one of two concurrent triggers creates a task.
+func (s *DynamoDBService) CreateManualScrapingTask(ctx context.Context, previous, next *models.ManualTriggerState, task *models.ScrapingTask, limit int) error {
+	stateItem, err := attributevalue.MarshalMap(next)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manual trigger state: %w", err)
+	}
+	taskItem, err := scrapingTaskItem(task)
+	if err != nil {
+		return err
+	}
+
+	statePut := &types.Put{
+		TableName:           aws.String(s.scrapingOperationsTable),
+		Item:                stateItem,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	}
+	if previous != nil {
+		lastTriggeredAt, err := attributevalue.Marshal(previous.LastTriggeredAt)
+		if err != nil {
+			return fmt.Errorf("failed to marshal manual trigger time: %w", err)
+		}
+		statePut.ConditionExpression = aws.String("last_triggered_at = :previous")
+		statePut.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":previous": lastTriggeredAt,
+		}
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: aws.String(s.scrapingOperationsTable),
+					Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: models.CreateManualTriggerWindowPK(next.LastTriggeredAt)},
+						"SK": &types.AttributeValueMemberS{Value: models.CreateManualTriggerWindowSK()},
+					},
+					UpdateExpression:    aws.String("ADD trigger_count :one SET #ttl = :ttl"),
+					ConditionExpression: aws.String("attribute_not_exists(trigger_count) OR trigger_count < :limit"),
+					ExpressionAttributeNames: map[string]string{
+						"#ttl": "TTL",
+					},
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":one":   &types.AttributeValueMemberN{Value: "1"},
+						":limit": &types.AttributeValueMemberN{Value: strconv.Itoa(limit)},
+						":ttl":   &types.AttributeValueMemberN{Value: strconv.FormatInt(next.LastTriggeredAt.Add(2*time.Hour).Unix(), 10)},
+					},
+				},
+			},
+			{Put: statePut},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(s.scrapingOperationsTable),
+					Item:                taskItem,
+					ConditionExpression: aws.String("attribute_not_exists(PK)"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) >= 2 {
+			if aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+				return ErrManualTriggerLimitReached
+			}
+			if aws.ToString(canceledErr.CancellationReasons[1].Code) == "ConditionalCheckFailed" {
+				return ErrManualTriggerConflict
+			}
+		}
+		return fmt.Errorf("failed to create manual scraping task: %w", err)
+	}
+	return nil
+}
+
+// GetRecentTasksForSource retrieves recent scraping tasks for a specific source
+func (s *DynamoDBService) GetRecentTasksForSource(ctx context.Context, sourceID string, limit int) ([]models.ScrapingTask, error) {
+	// Query scraping operations table for tasks from this source
+	// We'll use a scan with filter since we need to search by source_id
+	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(s.scrapingOperationsTable),
+		FilterExpression: aws.String("source_id = :source_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":source_id": &types.AttributeValueMemberS{Value: sourceID},
+		},
+		Limit: aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tasks for source %s: %w", sourceID, err)
+	}
+
+	var tasks []models.ScrapingTask
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+	}
+
+	// Sort by UpdatedAt descending (most recent first)
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+	})
+
+	// Return up to the requested limit
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	return tasks, nil
+}
+
+// UpdateScrapingTask updates an existing scraping task
+func (s *DynamoDBService) UpdateScrapingTask(ctx context.Context, task *models.ScrapingTask) error {
+	// Update timestamp
+	task.UpdatedAt = time.Now()
+
+	// Create update expression for the fields that might change
+	updateExpr := "SET #status = :status, #updated_at = :updated_at"
+	exprAttrNames := map[string]string{
+		"#status":     "status",
+		"#updated_at": "updated_at",
+	}
+	exprAttrValues := map[string]types.AttributeValue{
+		":status":     &types.AttributeValueMemberS{Value: string(task.Status)},
+		":updated_at": &types.AttributeValueMemberS{Value: task.UpdatedAt.Format(time.RFC3339)},
+	}
+
+	// Add retry count if it has changed
+	if task.RetryCount > 0 {
+		updateExpr += ", #retry_count = :retry_count"
+		exprAttrNames["#retry_count"] = "retry_count"
+		exprAttrValues[":retry_count"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", task.RetryCount)}
+	}
+
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: task.PK},
+			"SK": &types.AttributeValueMemberS{Value: task.SK},
+		},
+		UpdateExpression:          aws.String(updateExpr),
+		ExpressionAttributeNames:  exprAttrNames,
+		ExpressionAttributeValues: exprAttrValues,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update scraping task %s: %w", task.TaskID, err)
+	}
+
+	return nil
+}
+
+// maxDiagnosticsPayloadBytes keeps diagnostics records well under the 400KB DynamoDB item size limit
+const maxDiagnosticsPayloadBytes = 300 * 1024
+
+// CreateDiagnosticsRecord stores extraction or conversion diagnostics keyed by event ID
+func (s *DynamoDBService) CreateDiagnosticsRecord(ctx context.Context, record *models.DiagnosticsRecord) error {
+	// Set keys, timestamps and TTL (30 days from now)
+	now := time.Now()
+	record.PK = models.CreateDiagnosticsPK(record.EventID)
+	record.SK = models.CreateDiagnosticsSK(record.Stage, now)
+	record.CreatedAt = now
+	record.TTL = models.CalculateTTL(30 * 24 * time.Hour)
+
+	// Trim oversized payloads so the item can still be written
+	payloadJSON, err := json.Marshal(record.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diagnostics payload: %w", err)
+	}
+	if len(payloadJSON) > maxDiagnosticsPayloadBytes {
+		log.Printf("Diagnostics payload for event %s (%s) is %d bytes, truncating", record.EventID, record.Stage, len(payloadJSON))
+		record.Payload = map[string]interface{}{
+			"payload_size":   len(payloadJSON),
+			"payload_sample": string(payloadJSON[:maxDiagnosticsPayloadBytes]),
+		}
+		record.Truncated = true
+	}
+
+	// Marshal to DynamoDB attribute values
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diagnostics record: %w", err)
+	}
+
+	// Put item
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create diagnostics record: %w", err)
+	}
+
+	return nil
+}
+
+// GetDiagnosticsForEvent retrieves all diagnostics records stored for an event, oldest first
+func (s *DynamoDBService) GetDiagnosticsForEvent(ctx context.Context, eventID string) ([]models.DiagnosticsRecord, error) {
+	pk := models.CreateDiagnosticsPK(eventID)
+
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.scrapingOperationsTable),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: pk},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diagnostics: %w", err)
+	}
+
+	var records []models.DiagnosticsRecord
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal diagnostics: %w", err)
+	}
+
+	return records, nil
+}
+
+// CreateProgressUpdate stores a progress update for a long-running job
+func (s *DynamoDBService) CreateProgressUpdate(ctx context.Context, update *models.ProgressUpdate) error {
+	// Set keys, timestamps and TTL (1 day from now)
+	now := time.Now()
+	update.PK = models.CreateProgressPK(update.JobID)
+	update.SK = models.CreateProgressUpdateSK(now)
+	update.CreatedAt = now
+	update.TTL = models.CalculateTTL(24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress update: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create progress update: %w", err)
+	}
+
+	return nil
+}
+
+// GetProgressUpdates retrieves all progress updates for a job, oldest first
+func (s *DynamoDBService) GetProgressUpdates(ctx context.Context, jobID string) ([]models.ProgressUpdate, error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.scrapingOperationsTable),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: models.CreateProgressPK(jobID)},
+			":prefix": &types.AttributeValueMemberS{Value: "UPDATE#"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query progress updates: %w", err)
+	}
+
+	var updates []models.ProgressUpdate
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal progress updates: %w", err)
+	}
+
+	return updates, nil
+}
+
+// CreateProgressTicket stores a progress ticket until it is redeemed or expires
+func (s *DynamoDBService) CreateProgressTicket(ctx context.Context, ticket *models.ProgressTicket) error {
+	item, err := attributevalue.MarshalMap(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress ticket: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create progress ticket: %w", err)
+	}
+
+	return nil
+}
+
+// RedeemProgressTicket deletes a progress ticket and returns it, so each ticket opens a single
+// connection. It returns nil without an error for an unknown, already redeemed or expired ticket.
+func (s *DynamoDBService) RedeemProgressTicket(ctx context.Context, ticket string, now time.Time) (*models.ProgressTicket, error) {
+	result, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateProgressTicketPK(ticket)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateProgressTicketSK()},
+		},
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem progress ticket: %w", err)
+	}
+	if len(result.Attributes) == 0 {
+		return nil, nil
+	}
+
+	var redeemed models.ProgressTicket
+	if err := attributevalue.UnmarshalMap(result.Attributes, &redeemed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal progress ticket: %w", err)
+	}
+	// Expired items linger until DynamoDB's TTL sweep removes them
+	if !now.Before(redeemed.ExpiresAt) {
+		return nil, nil
+	}
+	return &redeemed, nil
+}
+
+// maxPublicConversionAttempts bounds how often recording a conversion outcome is retried when
+// the event's outcome changed since it was read
+const maxPublicConversionAttempts = 5
+
+// RecordPublicConversion sets an event's public conversion outcome under its source domain and
+// schema type, replacing any earlier one. The event's outcome item and the counters are updated
+// in one transaction conditional on the outcome read, so each event is counted once, with its
+// latest outcome, across concurrent approvals and function instances.
+func (s *DynamoDBService) RecordPublicConversion(ctx context.Context, eventID, source, schemaType, outcome string) error {
+	outcomeKey := map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{Value: models.CreatePublicConversionOutcomePK(eventID)},
+		"SK": &types.AttributeValueMemberS{Value: models.CreatePublicConversionOutcomeSK()},
+	}
+	recorded := models.PublicConversionOutcome{
+		PK:         models.CreatePublicConversionOutcomePK(eventID),
+		SK:         models.CreatePublicConversionOutcomeSK(),
+		Source:     source,
+		SchemaType: schemaType,
+		Outcome:    outcome,
+	}
+	recordedItem, err := attributevalue.MarshalMap(recorded)
+	if err != nil {
+		return fmt.Errorf("failed to marshal public conversion outcome: %w", err)
+	}
+
+	for attempt := 1; attempt <= maxPublicConversionAttempts; attempt++ {
+		result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName:      aws.String(s.scrapingOperationsTable),
+			Key:            outcomeKey,
+			ConsistentRead: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get public conversion outcome: %w", err)
+		}
+
+		var previous *models.PublicConversionOutcome
+		if result.Item != nil {
+			previous = &models.PublicConversionOutcome{}
+			if err := attributevalue.UnmarshalMap(result.Item, previous); err != nil {
+				return fmt.Errorf("failed to unmarshal public conversion outcome: %w", err)
+			}
+			if previous.Source == source && previous.SchemaType == schemaType && previous.Outcome == outcome {
+				return nil
+			}
+		}
+
+		put := &types.Put{
+			TableName:           aws.String(s.scrapingOperationsTable),
+			Item:                recordedItem,
+			ConditionExpression: aws.String("attribute_not_exists(PK)"),
+		}
+		if previous != nil {
+			put.ConditionExpression = aws.String("#outcome = :outcome AND #source = :source AND schema_type = :schema_type")
+			put.ExpressionAttributeNames = map[string]string{"#outcome": "outcome", "#source": "source"}
+			put.ExpressionAttributeValues = map[string]types.AttributeValue{
+				":outcome":     &types.AttributeValueMemberS{Value: previous.Outcome},
+				":source":      &types.AttributeValueMemberS{Value: previous.Source},
+				":schema_type": &types.AttributeValueMemberS{Value: previous.SchemaType},
+			}
+		}
+
+		transactItems := []types.TransactWriteItem{{Put: put}}
+		if previous != nil && previous.Source == source && previous.SchemaType == schemaType {
+			// A transaction may update an item only once, so move the count within the item
+			transactItems = append(transactItems, publicConversionCountUpdate(s.scrapingOperationsTable, source, schemaType, map[string]int{outcome: 1, previous.Outcome: -1}))
+		} else {
+			transactItems = append(transactItems, publicConversionCountUpdate(s.scrapingOperationsTable, source, schemaType, map[string]int{outcome: 1}))
+			if previous != nil {
+				transactItems = append(transactItems, publicConversionCountUpdate(s.scrapingOperationsTable, previous.Source, previous.SchemaType, map[string]int{previous.Outcome: -1}))
+			}
+		}
+
+		_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+		if err == nil {
+			return nil
+		}
+		var canceledErr *types.TransactionCanceledException
+		if !errors.As(err, &canceledErr) {
+			return fmt.Errorf("failed to record public conversion outcome: %w", err)
+		}
+		// The event was converted concurrently; read its outcome again
+	}
+
+	return fmt.Errorf("failed to record public conversion outcome for event %s after %d attempts", eventID, maxPublicConversionAttempts)
+}
+
+// publicConversionCountUpdate adds to the outcome counters of a source domain and schema type
+func publicConversionCountUpdate(table, source, schemaType string, deltas map[string]int) types.TransactWriteItem {
+	names := map[string]string{"#source": "source"}
+	values := map[string]types.AttributeValue{
+		":source":      &types.AttributeValueMemberS{Value: source},
+		":schema_type": &types.AttributeValueMemberS{Value: schemaType},
+	}
+	outcomes := make([]string, 0, len(deltas))
+	for outcome := range deltas {
+		outcomes = append(outcomes, outcome)
+	}
+	sort.Strings(outcomes)
+	adds := make([]string, 0, len(outcomes))
+	for i, outcome := range outcomes {
+		names[fmt.Sprintf("#outcome%d", i)] = outcome
+		values[fmt.Sprintf(":delta%d", i)] = &types.AttributeValueMemberN{Value: strconv.Itoa(deltas[outcome])}
+		adds = append(adds, fmt.Sprintf("#outcome%d :delta%d", i, i))
+	}
+
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: aws.String(table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: models.CreatePublicConversionCountPK()},
+				"SK": &types.AttributeValueMemberS{Value: models.CreatePublicConversionCountSK(source, schemaType)},
+			},
+			UpdateExpression:          aws.String("ADD " + strings.Join(adds, ", ") + " SET #source = :source, schema_type = :schema_type"),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		},
+	}
+}
+
+// GetPublicConversionCounts retrieves the public conversion counters of every source domain and
+// schema type
+func (s *DynamoDBService) GetPublicConversionCounts(ctx context.Context) ([]models.PublicConversionCount, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.scrapingOperationsTable),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: models.CreatePublicConversionCountPK()},
+		},
+	}
+
+	var counts []models.PublicConversionCount
+	for {
+		result, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query public conversion counts: %w", err)
+		}
+
+		var page []models.PublicConversionCount
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal public conversion counts: %w", err)
+		}
+		counts = append(counts, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	return counts, nil
+}
+
+// CreateProgressConnection subscribes a WebSocket connection to a job's progress updates
+func (s *DynamoDBService) CreateProgressConnection(ctx context.Context, connectionID, jobID string) error {
+	now := time.Now()
+	ttl := models.CalculateTTL(24 * time.Hour)
+
+	// One item under the job for fan-out, one under the connection for cleanup on disconnect
+	subscription := models.ProgressConnection{
+		PK:           models.CreateProgressPK(jobID),
+		SK:           models.CreateProgressConnectionSK(connectionID),
+		ConnectionID: connectionID,
+		JobID:        jobID,
+		ConnectedAt:  now,
+		TTL:          ttl,
+	}
+	lookup := subscription
+	lookup.PK = models.CreateConnectionPK(connectionID)
+	lookup.SK = "JOB"
+
+	var writeRequests []types.WriteRequest
+	for _, connection := range []models.ProgressConnection{subscription, lookup} {
+		item, err := attributevalue.MarshalMap(connection)
+		if err != nil {
+			return fmt.Errorf("failed to marshal progress connection: %w", err)
+		}
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	_, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			s.scrapingOperationsTable: writeRequests,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create progress connection: %w", err)
+	}
+
+	return nil
+}
+
+// GetProgressConnections retrieves the WebSocket connections following a job
+func (s *DynamoDBService) GetProgressConnections(ctx context.Context, jobID string) ([]models.ProgressConnection, error) {
+	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.scrapingOperationsTable),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: models.CreateProgressPK(jobID)},
+			":prefix": &types.AttributeValueMemberS{Value: "CONNECTION#"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query progress connections: %w", err)
+	}
+
+	var connections []models.ProgressConnection
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &connections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal progress connections: %w", err)
+	}
+
+	return connections, nil
+}
+
+// DeleteProgressConnection removes a WebSocket connection and its job subscription
+func (s *DynamoDBService) DeleteProgressConnection(ctx context.Context, connectionID string) error {
+	lookupKey := map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{Value: models.CreateConnectionPK(connectionID)},
+		"SK": &types.AttributeValueMemberS{Value: "JOB"},
+	}
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key:       lookupKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get progress connection: %w", err)
+	}
+
+	if result.Item == nil {
+		// Connection never subscribed to a job
+		return nil
+	}
+
+	var connection models.ProgressConnection
+	if err := attributevalue.UnmarshalMap(result.Item, &connection); err != nil {
+		return fmt.Errorf("failed to unmarshal progress connection: %w", err)
+	}
+
+	_, err = s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			s.scrapingOperationsTable: {
+				{DeleteRequest: &types.DeleteRequest{Key: lookupKey}},
+				{DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: models.CreateProgressPK(connection.JobID)},
+					"SK": &types.AttributeValueMemberS{Value: models.CreateProgressConnectionSK(connectionID)},
+				}}},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete progress connection: %w", err)
+	}
+
+	return nil
+}
+
+// CreateFanOutRun creates the tracking record for a fan-out scraping run
+func (s *DynamoDBService) CreateFanOutRun(ctx context.Context, run *models.FanOutRun) error {
+	run.PK = models.CreateFanOutRunPK(run.RunID)
+	run.SK = models.CreateFanOutRunSK()
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+	if run.Status == "" {
+		run.Status = models.RunStatusRunning
+	}
+	run.TTL = models.CalculateTTL(30 * 24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fan-out run: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create fan-out run: %w", err)
+	}
+
+	return nil
+}
+
+// ShrinkFanOutRun lowers the task total of a running run to the tasks that were actually queued
+// and records why the rest were not, so the run finishes once the queued tasks have reported.
+// It returns the updated run.
+func (s *DynamoDBService) ShrinkFanOutRun(ctx context.Context, runID string, totalTasks int, reason string) (*models.FanOutRun, error) {
+	result, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+		},
+		UpdateExpression:    aws.String("SET total_tasks = :total, errors = list_append(if_not_exists(errors, :empty), :error)"),
+		ConditionExpression: aws.String("#status = :running"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":total":   &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", totalTasks)},
+			":empty":   &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+			":error":   &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: reason}}},
+			":running": &types.AttributeValueMemberS{Value: models.RunStatusRunning},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to shrink fan-out run: %w", err)
+	}
+
+	var run models.FanOutRun
+	if err := attributevalue.UnmarshalMap(result.Attributes, &run); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fan-out run: %w", err)
+	}
+
+	return &run, nil
+}
+
+// GetFanOutRun retrieves the tracking record for a fan-out scraping run
+func (s *DynamoDBService) GetFanOutRun(ctx context.Context, runID string) (*models.FanOutRun, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fan-out run: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, fmt.Errorf("fan-out run not found")
+	}
+
+	var run models.FanOutRun
+	err = attributevalue.UnmarshalMap(result.Item, &run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fan-out run: %w", err)
+	}
+
+	return &run, nil
+}
+
+// GetFanOutRunsStartedBetween retrieves the fan-out runs started at or after from and before to
+func (s *DynamoDBService) GetFanOutRunsStartedBetween(ctx context.Context, from, to time.Time) ([]models.FanOutRun, error) {
+	var runs []models.FanOutRun
+	var lastEvaluatedKey map[string]types.AttributeValue
+
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND SK = :sk AND started_at >= :from AND started_at < :to"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK("")},
+				":sk":     &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+				":from":   &types.AttributeValueMemberS{Value: from.UTC().Format(time.RFC3339Nano)},
+				":to":     &types.AttributeValueMemberS{Value: to.UTC().Format(time.RFC3339Nano)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fan-out runs: %w", err)
+		}
+
+		var page []models.FanOutRun
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fan-out runs: %w", err)
+		}
+		runs = append(runs, page...)
+
+		lastEvaluatedKey = result.LastEvaluatedKey
+		if lastEvaluatedKey == nil {
+			break
+		}
+	}
+
+	return runs, nil
+}
+
+// CheckpointFanOutTaskResult stores a task result before it is counted on the run, so a retry
+// after a timeout can resume from it instead of extracting the URL again.
+// It returns false without an error when a result for the task was already stored.
+func (s *DynamoDBService) CheckpointFanOutTaskResult(ctx context.Context, result *models.FanOutTaskResult) (bool, error) {
+	result.PK = models.CreateFanOutRunPK(result.RunID)
+	result.SK = models.CreateFanOutTaskSK(result.TaskID)
+	if result.CompletedAt.IsZero() {
+		result.CompletedAt = time.Now()
+	}
+	result.Counted = false
+	result.TTL = models.CalculateTTL(30 * 24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(result)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal task result: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.scrapingOperationsTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to store task result: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetFanOutTaskResult retrieves the stored result of a fan-out task.
+// It returns nil without an error when the task has not produced a result yet.
+func (s *DynamoDBService) GetFanOutTaskResult(ctx context.Context, runID, taskID string) (*models.FanOutTaskResult, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutTaskSK(taskID)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task result: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var taskResult models.FanOutTaskResult
+	err = attributevalue.UnmarshalMap(result.Item, &taskResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task result: %w", err)
+	}
+
+	return &taskResult, nil
+}
+
+// RecordFanOutTaskResult counts a checkpointed task result on its run. Marking the result
+// counted and updating the run counters happen in one transaction, so a result is counted
+// exactly once even when queue messages are delivered more than once.
+// It returns nil for the run when the result was already counted; otherwise the updated run.
+func (s *DynamoDBService) RecordFanOutTaskResult(ctx context.Context, result *models.FanOutTaskResult) (*models.FanOutRun, error) {
+	counter := "completed_tasks"
+	updateExpr := "ADD #counter :one, total_activities :activities, credits_used :credits"
+	exprAttrValues := map[string]types.AttributeValue{
+		":one":        &types.AttributeValueMemberN{Value: "1"},
+		":activities": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", result.ActivitiesFound)},
+		":credits":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", result.CreditsUsed)},
+	}
+	if result.Cancelled {
+		counter = "cancelled_tasks"
+	} else if result.SkippedUnchanged() {
+		updateExpr += ", unchanged_tasks :one"
+	} else if !result.Success {
+		counter = "failed_tasks"
+		updateExpr += " SET errors = list_append(if_not_exists(errors, :empty), :error)"
+		exprAttrValues[":empty"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{}}
+		exprAttrValues[":error"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: fmt.Sprintf("%s (%s): %s", result.SourceName, result.URL, result.ErrorMessage)},
+		}}
+	}
+
+	_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName: aws.String(s.scrapingOperationsTable),
+					Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(result.RunID)},
+						"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutTaskSK(result.TaskID)},
+					},
+					UpdateExpression:    aws.String("SET counted = :true"),
+					ConditionExpression: aws.String("counted = :false"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":true":  &types.AttributeValueMemberBOOL{Value: true},
+						":false": &types.AttributeValueMemberBOOL{Value: false},
+					},
+				},
+			},
+			{
+				Update: &types.Update{
+					TableName: aws.String(s.scrapingOperationsTable),
+					Key: map[string]types.AttributeValue{
+						"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(result.RunID)},
+						"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+					},
+					UpdateExpression:          aws.String(updateExpr),
+					ExpressionAttributeNames:  map[string]string{"#counter": counter},
+					ExpressionAttributeValues: exprAttrValues,
+				},
+			},
+		},
+	})
+	if err != nil {
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) > 0 &&
+			aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update fan-out run counters: %w", err)
+	}
+
+	return s.GetFanOutRun(ctx, result.RunID)
+}
+
+// FinalizeFanOutRun records the final status, completion time and stats of a finished run.
+// Only a run that is still running can be finalized, so when several executors finish the last
+// tasks concurrently exactly one of them gets true back and should publish and notify.
+func (s *DynamoDBService) FinalizeFanOutRun(ctx context.Context, run *models.FanOutRun) (bool, error) {
+	if run.CompletedAt == nil {
+		now := time.Now()
+		run.CompletedAt = &now
+	}
+
+	completedAt, err := attributevalue.Marshal(run.CompletedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal completion time: %w", err)
+	}
+	stats, err := attributevalue.Marshal(run.Stats)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal run stats: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(run.RunID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+		},
+		UpdateExpression:    aws.String("SET #status = :status, completed_at = :completed_at, stats = :stats"),
+		ConditionExpression: aws.String("#status = :running"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status":       &types.AttributeValueMemberS{Value: run.Status},
+			":running":      &types.AttributeValueMemberS{Value: models.RunStatusRunning},
+			":completed_at": completedAt,
+			":stats":        stats,
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to finalize fan-out run: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetFanOutRunPublished records how many activities were published when a run finished
+func (s *DynamoDBService) SetFanOutRunPublished(ctx context.Context, runID string, publishedActivities int) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunSK()},
+		},
+		UpdateExpression: aws.String("SET published_activities = :published"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":published": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", publishedActivities)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update published activities: %w", err)
+	}
+
+	return nil
+}
+
+// SetSourceSelectorDrift records a source's selector drift on its production config
+func (s *DynamoDBService) SetSourceSelectorDrift(ctx context.Context, sourceID string, drift *models.SelectorDrift) error {
+	driftValue, err := attributevalue.Marshal(drift)
+	if err != nil {
+		return fmt.Errorf("failed to marshal selector drift: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceConfigSK()},
+		},
+		UpdateExpression:    aws.String("SET selector_drift = :drift"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":drift": driftValue,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update selector drift: %w", err)
+	}
+
+	return nil
+}
+
+// ApplySourceSelectors replaces a source's content selectors and records the resolved drift
+func (s *DynamoDBService) ApplySourceSelectors(ctx context.Context, sourceID string, selectors models.DataSelectors, drift *models.SelectorDrift) error {
+	selectorsValue, err := attributevalue.Marshal(selectors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content selectors: %w", err)
+	}
+	driftValue, err := attributevalue.Marshal(drift)
+	if err != nil {
+		return fmt.Errorf("failed to marshal selector drift: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.sourceManagementTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceConfigSK()},
+		},
+		UpdateExpression:    aws.String("SET content_selectors = :selectors, selector_drift = :drift, last_modified = :now"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":selectors": selectorsValue,
+			":drift":     driftValue,
+			":now":       &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply content selectors: %w", err)
+	}
+
+	return nil
+}
+
+// ReserveSourceRunPage counts one more page fetched for a source in a run, unless the
+// source has already used up one of its run limits. It returns the source's usage and
+// false without an error when the page was refused.
+func (s *DynamoDBService) ReserveSourceRunPage(ctx context.Context, runID, sourceID string, limits *models.SourceRunLimits) (*models.SourceRunUsage, bool, error) {
+	exprAttrValues := map[string]types.AttributeValue{
+		":one":       &types.AttributeValueMemberN{Value: "1"},
+		":run_id":    &types.AttributeValueMemberS{Value: runID},
+		":source_id": &types.AttributeValueMemberS{Value: sourceID},
+		":ttl":       &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", models.CalculateTTL(30*24*time.Hour))},
+	}
+	var conditions []string
+	for _, limit := range []struct {
+		attr  string
+		value int
+	}{
+		{"pages", limits.MaxPages},
+		{"activities", limits.MaxActivities},
+		{"credits", limits.MaxCredits},
+	} {
+		if attr := limit.attr; limit.value > 0 {
+			conditions = append(conditions, fmt.Sprintf("(attribute_not_exists(%s) OR %s < :max_%s)", attr, attr, attr))
+			exprAttrValues[":max_"+attr] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", limit.value)}
+		}
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceRunUsageSK(sourceID)},
+		},
+		UpdateExpression:          aws.String("ADD pages :one SET run_id = :run_id, source_id = :source_id, #ttl = if_not_exists(#ttl, :ttl)"),
+		ExpressionAttributeNames:  map[string]string{"#ttl": "TTL"},
+		ExpressionAttributeValues: exprAttrValues,
+		ReturnValues:              types.ReturnValueAllNew,
+	}
+	if len(conditions) > 0 {
+		input.ConditionExpression = aws.String(strings.Join(conditions, " AND "))
+	}
+
+	result, err := s.client.UpdateItem(ctx, input)
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			usage, err := s.GetSourceRunUsage(ctx, runID, sourceID)
+			if err != nil {
+				return nil, false, err
+			}
+			return usage, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to reserve source run page: %w", err)
+	}
+
+	var usage models.SourceRunUsage
+	if err := attributevalue.UnmarshalMap(result.Attributes, &usage); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal source run usage: %w", err)
+	}
+
+	return &usage, true, nil
+}
+
+// AddSourceRunUsage adds the activities and credits one page produced to a source's usage
+// in a run and returns the updated totals
+func (s *DynamoDBService) AddSourceRunUsage(ctx context.Context, runID, sourceID string, activities, credits int) (*models.SourceRunUsage, error) {
+	result, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceRunUsageSK(sourceID)},
+		},
+		UpdateExpression:         aws.String("ADD activities :activities, credits :credits SET run_id = :run_id, source_id = :source_id, #ttl = if_not_exists(#ttl, :ttl)"),
+		ExpressionAttributeNames: map[string]string{"#ttl": "TTL"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":activities": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", activities)},
+			":credits":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", credits)},
+			":run_id":     &types.AttributeValueMemberS{Value: runID},
+			":source_id":  &types.AttributeValueMemberS{Value: sourceID},
+			":ttl":        &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", models.CalculateTTL(30*24*time.Hour))},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update source run usage: %w", err)
+	}
+
+	var usage models.SourceRunUsage
+	if err := attributevalue.UnmarshalMap(result.Attributes, &usage); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal source run usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// GetSourceRunUsage retrieves what a source has consumed so far in a run.
+// A source that has not fetched a page yet has zero usage.
+func (s *DynamoDBService) GetSourceRunUsage(ctx context.Context, runID, sourceID string) (*models.SourceRunUsage, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateSourceRunUsageSK(sourceID)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source run usage: %w", err)
+	}
+
+	usage := models.SourceRunUsage{RunID: runID, SourceID: sourceID}
+	if result.Item != nil {
+		if err := attributevalue.UnmarshalMap(result.Item, &usage); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal source run usage: %w", err)
+		}
+	}
+
+	return &usage, nil
+}
+
+// GetPageContentState retrieves the content hash recorded for one of a source's target URLs,
+// or nil when the page has not been extracted yet
+func (s *DynamoDBService) GetPageContentState(ctx context.Context, sourceID, url string) (*models.PageContentState, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreatePageContentSK(url)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page content state: %w", err)
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var state models.PageContentState
+	if err := attributevalue.UnmarshalMap(result.Item, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page content state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// PutPageContentState records the content hash of a page activities were just extracted from
+func (s *DynamoDBService) PutPageContentState(ctx context.Context, state *models.PageContentState) error {
+	state.PK = models.CreateSourcePK(state.SourceID)
+	state.SK = models.CreatePageContentSK(state.URL)
+	state.TTL = models.CalculateTTL(30 * 24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal page content state: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put page content state: %w", err)
+	}
+
+	return nil
+}
+
+// RecordUnchangedPageCheck counts a scrape of a page that was skipped because it had not changed
+func (s *DynamoDBService) RecordUnchangedPageCheck(ctx context.Context, sourceID, url string, checkedAt time.Time) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreatePageContentSK(url)},
+		},
+		UpdateExpression:    aws.String("ADD unchanged_checks :one SET checked_at = :checked_at"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":        &types.AttributeValueMemberN{Value: "1"},
+			":checked_at": &types.AttributeValueMemberS{Value: checkedAt.UTC().Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record unchanged page check: %w", err)
 	}
+
+	return nil
 }
 
-// GetRecentTasksForSource retrieves recent scraping tasks for a specific source
-func (s *DynamoDBService) GetRecentTasksForSource(ctx context.Context, sourceID string, limit int) ([]models.ScrapingTask, error) {
-	// Query scraping operations table for tasks from this source
-	// We'll use a scan with filter since we need to search by source_id
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(s.scrapingOperationsTable),
-		FilterExpression: aws.String("source_id = :source_id"),
+// AcquireConcurrencySlot claims a provider semaphore slot if it is free or its lease has expired.
+// It returns false without an error when another holder has the slot.
+func (s *DynamoDBService) AcquireConcurrencySlot(ctx context.Context, lease *models.ConcurrencyLease) (bool, error) {
+	lease.PK = models.CreateConcurrencyPK(lease.Provider)
+	lease.SK = models.CreateConcurrencySlotSK(lease.Slot)
+	lease.TTL = models.CalculateTTL(24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(lease)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal concurrency lease: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.scrapingOperationsTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK) OR expires_at < :now"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":source_id": &types.AttributeValueMemberS{Value: sourceID},
+			":now": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", time.Now().Unix())},
 		},
-		Limit: aws.Int32(int32(limit)),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan tasks for source %s: %w", sourceID, err)
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to acquire concurrency slot: %w", err)
 	}
 
-	var tasks []models.ScrapingTask
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &tasks)
+	return true, nil
+}
+
+// ReleaseConcurrencySlot frees a provider semaphore slot if it is still held by the given holder.
+// A lease that expired and was claimed by someone else is left alone.
+func (s *DynamoDBService) ReleaseConcurrencySlot(ctx context.Context, provider string, slot int, holderID string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateConcurrencyPK(provider)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateConcurrencySlotSK(slot)},
+		},
+		ConditionExpression: aws.String("holder_id = :holder"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":holder": &types.AttributeValueMemberS{Value: holderID},
+		},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal scraping tasks: %w", err)
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return nil
+		}
+		return fmt.Errorf("failed to release concurrency slot: %w", err)
 	}
 
-	// Sort by UpdatedAt descending (most recent first)
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+	return nil
+}
+
+// GetFanOutTaskResults retrieves all task results recorded for a fan-out run
+func (s *DynamoDBService) GetFanOutTaskResults(ctx context.Context, runID string) ([]models.FanOutTaskResult, error) {
+	var results []models.FanOutTaskResult
+	var lastEvaluatedKey map[string]types.AttributeValue
+
+	for {
+		output, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.scrapingOperationsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateFanOutRunPK(runID)},
+				":prefix": &types.AttributeValueMemberS{Value: "TASK#"},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query task results: %w", err)
+		}
+
+		var page []models.FanOutTaskResult
+		if err := attributevalue.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task results: %w", err)
+		}
+		results = append(results, page...)
+
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = output.LastEvaluatedKey
+	}
+
+	return results, nil
+}
+
+// RecordMessageFailure appends a failed delivery to the failures of a scrape task message, kept
+// until the message is dead-lettered
+func (s *DynamoDBService) RecordMessageFailure(ctx context.Context, messageID string, failure models.MessageFailure) error {
+	failureItem, err := attributevalue.MarshalMap(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message failure: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateMessageFailuresPK(messageID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateMessageFailuresSK()},
+		},
+		UpdateExpression: aws.String("SET failures = list_append(if_not_exists(failures, :empty), :failure), message_id = :message_id, #ttl = :ttl"),
+		ExpressionAttributeNames: map[string]string{
+			"#ttl": "TTL",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":empty":      &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+			":failure":    &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberM{Value: failureItem}}},
+			":message_id": &types.AttributeValueMemberS{Value: messageID},
+			":ttl":        &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", models.CalculateTTL(models.DeadLetterRetention))},
+		},
 	})
+	if err != nil {
+		return fmt.Errorf("failed to record message failure: %w", err)
+	}
+	return nil
+}
 
-	// Return up to the requested limit
-	if len(tasks) > limit {
-		tasks = tasks[:limit]
+// GetMessageFailures retrieves the failed deliveries recorded for a scrape task message, oldest first
+func (s *DynamoDBService) GetMessageFailures(ctx context.Context, messageID string) ([]models.MessageFailure, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateMessageFailuresPK(messageID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateMessageFailuresSK()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message failures: %w", err)
+	}
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	return tasks, nil
+	var item struct {
+		Failures []models.MessageFailure `dynamodbav:"failures"`
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message failures: %w", err)
+	}
+	return item.Failures, nil
 }
 
-// UpdateScrapingTask updates an existing scraping task
-func (s *DynamoDBService) UpdateScrapingTask(ctx context.Context, task *models.ScrapingTask) error {
-	// Update timestamp
-	task.UpdatedAt = time.Now()
+// CreateFailedTaskMessage stores a dead-lettered scrape task message
+func (s *DynamoDBService) CreateFailedTaskMessage(ctx context.Context, message *models.FailedTaskMessage) error {
+	item, err := attributevalue.MarshalMap(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal failed task message: %w", err)
+	}
 
-	// Create update expression for the fields that might change
-	updateExpr := "SET #status = :status, #updated_at = :updated_at"
-	exprAttrNames := map[string]string{
-		"#status":     "status",
-		"#updated_at": "updated_at",
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store failed task message: %w", err)
 	}
-	exprAttrValues := map[string]types.AttributeValue{
-		":status":     &types.AttributeValueMemberS{Value: string(task.Status)},
-		":updated_at": &types.AttributeValueMemberS{Value: task.UpdatedAt.Format(time.RFC3339)},
+	return nil
+}
+
+// ListFailedTaskMessages retrieves up to limit dead-lettered scrape task messages, most recently
+// dead-lettered first, optionally only those of one source
+func (s *DynamoDBService) ListFailedTaskMessages(ctx context.Context, sourceID string, limit int) ([]models.FailedTaskMessage, error) {
+	messages := []models.FailedTaskMessage{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for len(messages) < limit {
+		input := &dynamodb.QueryInput{
+			TableName:              aws.String(s.scrapingOperationsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateFailedTasksPK()},
+				":prefix": &types.AttributeValueMemberS{Value: "MESSAGE#"},
+			},
+			ScanIndexForward:  aws.Bool(false),
+			ExclusiveStartKey: lastEvaluatedKey,
+		}
+		if sourceID != "" {
+			input.FilterExpression = aws.String("source_id = :source_id")
+			input.ExpressionAttributeValues[":source_id"] = &types.AttributeValueMemberS{Value: sourceID}
+		}
+
+		result, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query failed task messages: %w", err)
+		}
+
+		var page []models.FailedTaskMessage
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal failed task messages: %w", err)
+		}
+		messages = append(messages, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
 	}
 
-	// Add retry count if it has changed
-	if task.RetryCount > 0 {
-		updateExpr += ", #retry_count = :retry_count"
-		exprAttrNames["#retry_count"] = "retry_count"
-		exprAttrValues[":retry_count"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", task.RetryCount)}
+	if len(messages) > limit {
+		messages = messages[:limit]
 	}
+	return messages, nil
+}
 
-	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+// CreateAdminNotification keeps a notification in the admin inbox
+func (s *DynamoDBService) CreateAdminNotification(ctx context.Context, notification *models.AdminNotification) error {
+	item, err := attributevalue.MarshalMap(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin notification: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store admin notification: %w", err)
+	}
+	return nil
+}
+
+// GetAdminNotification retrieves a notification from the admin inbox.
+// Returns nil without an error if there is no such notification, or it expired.
+func (s *DynamoDBService) GetAdminNotification(ctx context.Context, notificationID string) (*models.AdminNotification, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(s.scrapingOperationsTable),
 		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: task.PK},
-			"SK": &types.AttributeValueMemberS{Value: task.SK},
+			"PK": &types.AttributeValueMemberS{Value: models.CreateNotificationsPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateNotificationSK(notificationID)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin notification: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var notification models.AdminNotification
+	if err := attributevalue.UnmarshalMap(result.Item, &notification); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admin notification: %w", err)
+	}
+	return &notification, nil
+}
+
+// ListAdminNotifications retrieves every notification in the admin inbox, newest first,
+// optionally only those of one kind
+func (s *DynamoDBService) ListAdminNotifications(ctx context.Context, kind string) ([]models.AdminNotification, error) {
+	notifications := []models.AdminNotification{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		input := &dynamodb.QueryInput{
+			TableName:              aws.String(s.scrapingOperationsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateNotificationsPK()},
+				":prefix": &types.AttributeValueMemberS{Value: "NOTIFICATION#"},
+			},
+			ScanIndexForward:  aws.Bool(false),
+			ExclusiveStartKey: lastEvaluatedKey,
+		}
+		if kind != "" {
+			input.FilterExpression = aws.String("#kind = :kind")
+			input.ExpressionAttributeNames = map[string]string{"#kind": "kind"}
+			input.ExpressionAttributeValues[":kind"] = &types.AttributeValueMemberS{Value: kind}
+		}
+
+		result, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query admin notifications: %w", err)
+		}
+
+		var page []models.AdminNotification
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal admin notifications: %w", err)
+		}
+		notifications = append(notifications, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+	return notifications, nil
+}
+
+// ListNotificationReceipts retrieves the receipts of the notifications read with an admin API key
+func (s *DynamoDBService) ListNotificationReceipts(ctx context.Context, keyID string) ([]models.NotificationReceipt, error) {
+	receipts := []models.NotificationReceipt{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.scrapingOperationsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateNotificationReceiptPK(keyID)},
+				":prefix": &types.AttributeValueMemberS{Value: "NOTIFICATION_READ#"},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query notification receipts: %w", err)
+		}
+
+		var page []models.NotificationReceipt
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification receipts: %w", err)
+		}
+		receipts = append(receipts, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+	return receipts, nil
+}
+
+// CreateNotificationReceipt records that an admin read a notification. A notification is only
+// read once, so it returns false without an error when the admin had already read it.
+func (s *DynamoDBService) CreateNotificationReceipt(ctx context.Context, receipt *models.NotificationReceipt) (bool, error) {
+	item, err := attributevalue.MarshalMap(receipt)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal notification receipt: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.scrapingOperationsTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to store notification receipt: %w", err)
+	}
+	return true, nil
+}
+
+// GetLinkHealthRecords retrieves the stored records of links, each given by its URL and event ID.
+// It returns one record per link in the same order: the stored record, or the given link
+// unchanged when it has not been checked yet.
+func (s *DynamoDBService) GetLinkHealthRecords(ctx context.Context, links []models.LinkHealthRecord) ([]models.LinkHealthRecord, error) {
+	keys := make([]map[string]types.AttributeValue, 0, len(links))
+	requested := make(map[string]bool, len(links))
+	for _, link := range links {
+		pk, sk := models.CreateLinkHealthPK(link.URL), models.CreateLinkHealthSK(link.EventID)
+		if requested[pk+sk] {
+			continue // BatchGetItem rejects duplicate keys
+		}
+		requested[pk+sk] = true
+		keys = append(keys, map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: pk},
+			"SK": &types.AttributeValueMemberS{Value: sk},
+		})
+	}
+
+	items, err := s.batchGetItems(ctx, s.scrapingOperationsTable, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link health records: %w", err)
+	}
+	stored := make(map[string]models.LinkHealthRecord, len(items))
+	for _, item := range items {
+		var record models.LinkHealthRecord
+		if err := attributevalue.UnmarshalMap(item, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal link health record: %w", err)
+		}
+		stored[record.PK+record.SK] = record
+	}
+
+	records := make([]models.LinkHealthRecord, len(links))
+	for i, link := range links {
+		if record, exists := stored[models.CreateLinkHealthPK(link.URL)+models.CreateLinkHealthSK(link.EventID)]; exists {
+			records[i] = record
+		} else {
+			records[i] = link
+		}
+	}
+	return records, nil
+}
+
+// PutLinkHealthRecord creates or replaces a link health record
+func (s *DynamoDBService) PutLinkHealthRecord(ctx context.Context, record *models.LinkHealthRecord) error {
+	record.PK = models.CreateLinkHealthPK(record.URL)
+	record.SK = models.CreateLinkHealthSK(record.EventID)
+
+	// Records for links that stop being checked expire after 30 days
+	record.TTL = models.CalculateTTL(30 * 24 * time.Hour)
+
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal link health record: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put link health record: %w", err)
+	}
+
+	return nil
+}
+
+// GetGeocodeCacheEntry retrieves a provider's cached answer for a location description.
+// Returns nil without an error if the description has not been geocoded before.
+func (s *DynamoDBService) GetGeocodeCacheEntry(ctx context.Context, provider, text string) (*models.GeocodeCacheEntry, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateGeocodeCachePK(provider, text)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyMetadata},
 		},
-		UpdateExpression:          aws.String(updateExpr),
-		ExpressionAttributeNames:  exprAttrNames,
-		ExpressionAttributeValues: exprAttrValues,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geocode cache entry: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var entry models.GeocodeCacheEntry
+	if err := attributevalue.UnmarshalMap(result.Item, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal geocode cache entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// PutGeocodeCacheEntry caches a provider's answer for a location description
+func (s *DynamoDBService) PutGeocodeCacheEntry(ctx context.Context, entry *models.GeocodeCacheEntry) error {
+	entry.PK = models.CreateGeocodeCachePK(entry.Provider, entry.Text)
+	entry.SK = models.SortKeyMetadata
+	entry.CachedAt = time.Now()
+	if entry.Found {
+		entry.TTL = models.CalculateTTL(models.GeocodeCacheTTL)
+	} else {
+		entry.TTL = models.CalculateTTL(models.GeocodeCacheNotFoundTTL)
+	}
+
+	item, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal geocode cache entry: %w", err)
+	}
 
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.scrapingOperationsTable),
+		Item:      item,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update scraping task %s: %w", task.TaskID, err)
+		return fmt.Errorf("failed to put geocode cache entry: %w", err)
 	}
 
 	return nil
 }
 
+// GetBrokenLinks retrieves all links currently marked as broken (admin review queue)
+func (s *DynamoDBService) GetBrokenLinks(ctx context.Context) ([]models.LinkHealthRecord, error) {
+	var records []models.LinkHealthRecord
+	var lastEvaluatedKey map[string]types.AttributeValue
+
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:        aws.String(s.scrapingOperationsTable),
+			FilterExpression: aws.String("begins_with(PK, :prefix) AND #status = :status"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix": &types.AttributeValueMemberS{Value: "LINK#"},
+				":status": &types.AttributeValueMemberS{Value: models.LinkStatusBroken},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan broken links: %w", err)
+		}
+
+		var page []models.LinkHealthRecord
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal broken links: %w", err)
+		}
+		records = append(records, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return records, nil
+}
+
 // Admin Events Table Operations
 
 // CreateAdminEvent stores an admin event in DynamoDB
@@ -755,11 +4839,14 @@ func (s *DynamoDBService) CreateAdminEvent(ctx context.Context, event *models.Ad
 	event.CreatedAt = now
 	event.UpdatedAt = now
 	event.ExtractedAt = now
+	if event.QueuedAt == nil {
+		event.QueuedAt = &now
+	}
 
 	// Generate keys
 	event.PK = models.CreateAdminEventPK(event.EventID)
 	event.SK = models.CreateAdminEventSK(event.ExtractedAt)
-	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status)
+	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
 
 	// Marshal to DynamoDB attribute values
 	item, err := attributevalue.MarshalMap(event)
@@ -810,6 +4897,52 @@ func (s *DynamoDBService) GetAdminEvent(ctx context.Context, eventID string, ext
 
 // GetAdminEventByID retrieves an admin event by event ID (scans for latest submission)
 func (s *DynamoDBService) GetAdminEventByID(ctx context.Context, eventID string) (*models.AdminEvent, error) {
+	event, err := s.getLatestAdminEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("admin event not found")
+	}
+	return event, nil
+}
+
+// GetAdminEventsByIDs retrieves admin events in the order their IDs are given, querying up to
+// parallelism events at a time. Events that are not found are left out.
+func (s *DynamoDBService) GetAdminEventsByIDs(ctx context.Context, eventIDs []string, parallelism int) ([]models.AdminEvent, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	found := make([]*models.AdminEvent, len(eventIDs))
+	errs := make([]error, len(eventIDs))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, eventID := range eventIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, eventID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			found[i], errs[i] = s.getLatestAdminEvent(ctx, eventID)
+		}(i, eventID)
+	}
+	wg.Wait()
+
+	events := make([]models.AdminEvent, 0, len(eventIDs))
+	for i := range eventIDs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if found[i] != nil {
+			events = append(events, *found[i])
+		}
+	}
+	return events, nil
+}
+
+// getLatestAdminEvent retrieves the latest version of an admin event, or nil when there is none
+func (s *DynamoDBService) getLatestAdminEvent(ctx context.Context, eventID string) (*models.AdminEvent, error) {
 	pk := models.CreateAdminEventPK(eventID)
 
 	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
@@ -826,215 +4959,591 @@ func (s *DynamoDBService) GetAdminEventByID(ctx context.Context, eventID string)
 	}
 
 	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("admin event not found")
+		return nil, nil
+	}
+
+	var event models.AdminEvent
+	err = attributevalue.UnmarshalMap(result.Items[0], &event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admin event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// GetAdminEventByURL finds an admin event by source URL
+func (s *DynamoDBService) GetAdminEventByURL(ctx context.Context, sourceURL string) (*models.AdminEvent, error) {
+	// We need to scan the table to find events by URL since URL is not a key
+	// For better performance, we could add a GSI on source_url in the future
+	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(s.adminEventsTable),
+		FilterExpression: aws.String("source_url = :url"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":url": &types.AttributeValueMemberS{Value: sourceURL},
+		},
+		Limit: aws.Int32(1), // We only need to find one match
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for admin event by URL: %w", err)
+	}
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("admin event not found for URL: %s", sourceURL)
+	}
+
+	var event models.AdminEvent
+	err = attributevalue.UnmarshalMap(result.Items[0], &event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admin event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// GetSourceByURL finds a source submission by base URL
+func (s *DynamoDBService) GetSourceByURL(ctx context.Context, baseURL string) (*models.SourceSubmission, error) {
+	// Scan the source management table to find sources by URL
+	// For better performance, we could add a GSI on base_url in the future
+	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(s.sourceManagementTable),
+		FilterExpression: aws.String("base_url = :url"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":url": &types.AttributeValueMemberS{Value: baseURL},
+		},
+		Limit: aws.Int32(1), // We only need to find one match
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for source by URL: %w", err)
+	}
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("source not found for URL: %s", baseURL)
+	}
+
+	var source models.SourceSubmission
+	err = attributevalue.UnmarshalMap(result.Items[0], &source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal source submission: %w", err)
+	}
+
+	return &source, nil
+}
+
+// GetApprovedAdminEvents retrieves up to limit approved admin events, newest first
+func (s *DynamoDBService) GetApprovedAdminEvents(ctx context.Context, limit int32) ([]models.AdminEvent, error) {
+	return s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusApproved, limit)
+}
+
+// GetApprovedAdminEventsPage reads one page of approved admin events, newest first
+func (s *DynamoDBService) GetApprovedAdminEventsPage(ctx context.Context, limit int32, token string) ([]models.AdminEvent, string, error) {
+	return s.QueryAdminEventsByStatusPage(ctx, []models.AdminEventStatus{models.AdminEventStatusApproved}, limit, token)
+}
+
+// GetAllApprovedAdminEvents returns every approved admin event, newest first
+func (s *DynamoDBService) GetAllApprovedAdminEvents(ctx context.Context) ([]models.AdminEvent, error) {
+	return s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusApproved, 0)
+}
+
+// UpdateAdminEvent updates an existing admin event
+func (s *DynamoDBService) UpdateAdminEvent(ctx context.Context, event *models.AdminEvent) error {
+	// Update timestamp
+	event.UpdatedAt = time.Now()
+	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
+
+	// Marshal to DynamoDB attribute values
+	item, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin event: %w", err)
+	}
+
+	// Put item (upsert)
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update admin event: %w", err)
+	}
+
+	return nil
+}
+
+// ErrAdminEventChanged is returned when an admin event is no longer in the status a change expected
+var ErrAdminEventChanged = errors.New("admin event changed")
+
+// UpdateAdminEventFromStatus replaces an admin event only while its stored status is still
+// fromStatus, returning ErrAdminEventChanged if it was reviewed in the meantime. Events written
+// before status keys were sharded still carry the unsharded key, which matches as well.
+func (s *DynamoDBService) UpdateAdminEventFromStatus(ctx context.Context, event *models.AdminEvent, fromStatus models.AdminEventStatus) error {
+	event.UpdatedAt = time.Now()
+	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
+
+	item, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin event: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.adminEventsTable),
+		Item:                item,
+		ConditionExpression: aws.String("StatusKey IN (:from, :legacy_from)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":from":        &types.AttributeValueMemberS{Value: models.GenerateAdminEventStatusKey(fromStatus, event.EventID)},
+			":legacy_from": &types.AttributeValueMemberS{Value: fmt.Sprintf("STATUS#%s", string(fromStatus))},
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrAdminEventChanged
+		}
+		return fmt.Errorf("failed to update admin event: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAdminEventDiagnostics stores an admin event's cached review diagnostics.
+// Only the diagnostics attribute is written so a concurrent review is never overwritten.
+func (s *DynamoDBService) UpdateAdminEventDiagnostics(ctx context.Context, event *models.AdminEvent) error {
+	diagnostics, err := attributevalue.Marshal(event.Diagnostics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin event diagnostics: %w", err)
+	}
+
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateAdminEventPK(event.EventID)},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateAdminEventSK(event.ExtractedAt)},
+		},
+		UpdateExpression:    aws.String("SET #diagnostics = :diagnostics"),
+		ConditionExpression: aws.String("attribute_exists(PK)"),
+		ExpressionAttributeNames: map[string]string{
+			"#diagnostics": "Diagnostics", // AdminEvent attributes are stored under their field names
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":diagnostics": diagnostics,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update admin event diagnostics: %w", err)
+	}
+
+	return nil
+}
+
+// QueryAdminEventsByStatus queries up to limit admin events in a status, newest first, reading
+// every shard of the status key in the status-date-index GSI. A limit of 0 returns every event.
+func (s *DynamoDBService) QueryAdminEventsByStatus(ctx context.Context, status models.AdminEventStatus, limit int32) ([]models.AdminEvent, error) {
+	items, err := s.queryShards(ctx, shardedQuery{
+		table:   s.adminEventsTable,
+		index:   "status-date-index",
+		keyName: "StatusKey",
+		keys:    statusKeyPartitions(fmt.Sprintf("STATUS#%s", string(status))),
+		forward: false, // Get newest first
+		limit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query admin events by status: %w", err)
+	}
+
+	events := []models.AdminEvent{}
+	for _, item := range items {
+		var event models.AdminEvent
+		if err := attributevalue.UnmarshalMap(item, &event); err != nil {
+			log.Printf("Failed to unmarshal admin event: %v", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ExtractedAt.After(events[j].ExtractedAt)
+	})
+	if limit > 0 && len(events) > int(limit) {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// QueryAdminEventsByStatusPage reads one page of up to limit admin events in any of the statuses,
+// newest first, and returns it with the token of the next page, or "" after the last page.
+func (s *DynamoDBService) QueryAdminEventsByStatusPage(ctx context.Context, statuses []models.AdminEventStatus, limit int32, token string) ([]models.AdminEvent, string, error) {
+	var keys []string
+	for _, status := range statuses {
+		keys = append(keys, statusKeyPartitions(fmt.Sprintf("STATUS#%s", string(status)))...)
+	}
+
+	// The index sorts by SK, SUBMISSION#{timestamp}, so pages merge by it rather than ExtractedAt
+	items, next, err := s.queryShardsPage(ctx, shardedQuery{
+		table:   s.adminEventsTable,
+		index:   "status-date-index",
+		keyName: "StatusKey",
+		sortKey: "SK",
+		keys:    keys,
+		forward: false, // Get newest first
+		limit:   limit,
+	}, token, func(a, b map[string]types.AttributeValue) bool {
+		return attributeString(a, "SK") > attributeString(b, "SK")
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query admin events by status: %w", err)
+	}
+
+	events := []models.AdminEvent{}
+	for _, item := range items {
+		var event models.AdminEvent
+		if err := attributevalue.UnmarshalMap(item, &event); err != nil {
+			log.Printf("Failed to unmarshal admin event: %v", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, next, nil
+}
+
+// GetReviewQueue retrieves every admin event awaiting review, pending and edited alike
+func (s *DynamoDBService) GetReviewQueue(ctx context.Context) ([]models.AdminEvent, error) {
+	var queue []models.AdminEvent
+	for _, status := range []models.AdminEventStatus{models.AdminEventStatusPending, models.AdminEventStatusEdited} {
+		events, err := s.QueryAdminEventsByStatus(ctx, status, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s events: %w", status, err)
+		}
+		queue = append(queue, events...)
+	}
+	return queue, nil
+}
+
+// GetAllPendingAdminEvents retrieves all admin events that need review
+func (s *DynamoDBService) GetAllPendingAdminEvents(ctx context.Context, limit int32) ([]models.AdminEvent, error) {
+	// Get both pending and edited events
+	pendingEvents, err := s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusPending, limit/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending events: %w", err)
+	}
+
+	editedEvents, err := s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusEdited, limit/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edited events: %w", err)
+	}
+
+	// Combine and sort by extracted_at descending
+	allEvents := append(pendingEvents, editedEvents...)
+	sort.Slice(allEvents, func(i, j int) bool {
+		return allEvents[i].ExtractedAt.After(allEvents[j].ExtractedAt)
+	})
+
+	// Limit results
+	if len(allEvents) > int(limit) {
+		allEvents = allEvents[:limit]
+	}
+
+	return allEvents, nil
+}
+
+// GetAdminEventsByProvider retrieves every admin event a provider submitted, newest first
+func (s *DynamoDBService) GetAdminEventsByProvider(ctx context.Context, providerID string) ([]models.AdminEvent, error) {
+	return s.scanAdminEvents(ctx, "ProviderID = :provider", map[string]types.AttributeValue{
+		":provider": &types.AttributeValueMemberS{Value: providerID},
+	})
+}
+
+// GetAdminEventsWithPendingChanges retrieves the published events with a provider change
+// awaiting review, newest first
+func (s *DynamoDBService) GetAdminEventsWithPendingChanges(ctx context.Context) ([]models.AdminEvent, error) {
+	return s.scanAdminEvents(ctx, "attribute_exists(PendingChange)", nil)
+}
+
+// GetAdminEventsExtractedOrReviewedBetween retrieves the admin events extracted or reviewed at or
+// after from and before to, newest first
+func (s *DynamoDBService) GetAdminEventsExtractedOrReviewedBetween(ctx context.Context, from, to time.Time) ([]models.AdminEvent, error) {
+	return s.scanAdminEvents(ctx, "(ExtractedAt >= :from AND ExtractedAt < :to) OR (ReviewedAt >= :from AND ReviewedAt < :to)", map[string]types.AttributeValue{
+		":from": &types.AttributeValueMemberS{Value: from.UTC().Format(time.RFC3339Nano)},
+		":to":   &types.AttributeValueMemberS{Value: to.UTC().Format(time.RFC3339Nano)},
+	})
+}
+
+// GetPendingAdminEventsMatching retrieves the pending and edited admin events with the given
+// submission ID and source URL, newest first. Empty arguments match every event.
+func (s *DynamoDBService) GetPendingAdminEventsMatching(ctx context.Context, submissionID, sourceURL string) ([]models.AdminEvent, error) {
+	// Status is a reserved word, so events are matched by their status key, sharded or not
+	filter := "(begins_with(StatusKey, :pending) OR begins_with(StatusKey, :edited))"
+	values := map[string]types.AttributeValue{
+		":pending": &types.AttributeValueMemberS{Value: fmt.Sprintf("STATUS#%s", models.AdminEventStatusPending)},
+		":edited":  &types.AttributeValueMemberS{Value: fmt.Sprintf("STATUS#%s", models.AdminEventStatusEdited)},
+	}
+	if submissionID != "" {
+		filter += " AND SubmissionID = :submission"
+		values[":submission"] = &types.AttributeValueMemberS{Value: submissionID}
+	}
+	if sourceURL != "" {
+		filter += " AND SourceURL = :url"
+		values[":url"] = &types.AttributeValueMemberS{Value: sourceURL}
+	}
+	return s.scanAdminEvents(ctx, filter, values)
+}
+
+// GetAdminEventsBySubmission retrieves every admin event extracted by a crawl submission, newest first
+func (s *DynamoDBService) GetAdminEventsBySubmission(ctx context.Context, submissionID string) ([]models.AdminEvent, error) {
+	return s.scanAdminEvents(ctx, "SubmissionID = :submission", map[string]types.AttributeValue{
+		":submission": &types.AttributeValueMemberS{Value: submissionID},
+	})
+}
+
+// CreateCrawlSubmission stores a crawl submission in the admin events table
+func (s *DynamoDBService) CreateCrawlSubmission(ctx context.Context, submission *models.CrawlSubmission) error {
+	submission.CreatedAt = time.Now()
+	submission.PK = models.CreateCrawlSubmissionPK(submission.SubmissionID)
+	submission.SK = models.SortKeyMetadata
+
+	item, err := attributevalue.MarshalMap(submission)
+	if err != nil {
+		return fmt.Errorf("failed to marshal crawl submission: %w", err)
 	}
 
-	var event models.AdminEvent
-	err = attributevalue.UnmarshalMap(result.Items[0], &event)
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Item:      item,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal admin event: %w", err)
+		return fmt.Errorf("failed to create crawl submission: %w", err)
 	}
 
-	return &event, nil
+	return nil
 }
 
-// GetAdminEventByURL finds an admin event by source URL
-func (s *DynamoDBService) GetAdminEventByURL(ctx context.Context, sourceURL string) (*models.AdminEvent, error) {
-	// We need to scan the table to find events by URL since URL is not a key
-	// For better performance, we could add a GSI on source_url in the future
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(s.adminEventsTable),
-		FilterExpression: aws.String("source_url = :url"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":url": &types.AttributeValueMemberS{Value: sourceURL},
-		},
-		Limit: aws.Int32(1), // We only need to find one match
-	})
+// UpdateCrawlSubmission saves changes to a crawl submission, such as events split off later
+func (s *DynamoDBService) UpdateCrawlSubmission(ctx context.Context, submission *models.CrawlSubmission) error {
+	item, err := attributevalue.MarshalMap(submission)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan for admin event by URL: %w", err)
+		return fmt.Errorf("failed to marshal crawl submission: %w", err)
 	}
 
-	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("admin event not found for URL: %s", sourceURL)
-	}
-
-	var event models.AdminEvent
-	err = attributevalue.UnmarshalMap(result.Items[0], &event)
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Item:      item,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal admin event: %w", err)
+		return fmt.Errorf("failed to update crawl submission: %w", err)
 	}
 
-	return &event, nil
+	return nil
 }
 
-// GetSourceByURL finds a source submission by base URL
-func (s *DynamoDBService) GetSourceByURL(ctx context.Context, baseURL string) (*models.SourceSubmission, error) {
-	// Scan the source management table to find sources by URL
-	// For better performance, we could add a GSI on base_url in the future
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(s.sourceManagementTable),
-		FilterExpression: aws.String("base_url = :url"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":url": &types.AttributeValueMemberS{Value: baseURL},
+// GetCrawlSubmission retrieves a crawl submission, or nil if it does not exist
+func (s *DynamoDBService) GetCrawlSubmission(ctx context.Context, submissionID string) (*models.CrawlSubmission, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateCrawlSubmissionPK(submissionID)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyMetadata},
 		},
-		Limit: aws.Int32(1), // We only need to find one match
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan for source by URL: %w", err)
+		return nil, fmt.Errorf("failed to get crawl submission: %w", err)
 	}
 
-	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("source not found for URL: %s", baseURL)
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	var source models.SourceSubmission
-	err = attributevalue.UnmarshalMap(result.Items[0], &source)
+	var submission models.CrawlSubmission
+	err = attributevalue.UnmarshalMap(result.Item, &submission)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal source submission: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal crawl submission: %w", err)
 	}
 
-	return &source, nil
+	return &submission, nil
 }
 
-// GetApprovedAdminEvents retrieves all approved admin events
-func (s *DynamoDBService) GetApprovedAdminEvents(ctx context.Context, limit int32) ([]models.AdminEvent, error) {
-	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(s.adminEventsTable),
-		IndexName:              aws.String("StatusIndex"), // Assumes GSI on status exists
-		KeyConditionExpression: aws.String("status_key = :status"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status": &types.AttributeValueMemberS{Value: models.GenerateAdminEventStatusKey(models.AdminEventStatusApproved)},
-		},
-		ScanIndexForward: aws.Bool(false), // Get newest first
-		Limit:           aws.Int32(limit),
-	})
-	if err != nil {
-		// If GSI doesn't exist, fall back to scan
-		log.Printf("Query failed, falling back to scan: %v", err)
-		return s.scanForApprovedEvents(ctx, limit)
-	}
-
-	var events []models.AdminEvent
-	for _, item := range result.Items {
-		var event models.AdminEvent
-		err = attributevalue.UnmarshalMap(item, &event)
+// scanAdminEvents pages through the admin events table and returns the events matching the
+// filter, newest first. AdminEvent attributes are stored under their field names.
+func (s *DynamoDBService) scanAdminEvents(ctx context.Context, filter string, values map[string]types.AttributeValue) ([]models.AdminEvent, error) {
+	events := []models.AdminEvent{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 aws.String(s.adminEventsTable),
+			FilterExpression:          aws.String(filter),
+			ExpressionAttributeValues: values,
+			ExclusiveStartKey:         lastEvaluatedKey,
+		})
 		if err != nil {
-			log.Printf("Failed to unmarshal admin event: %v", err)
-			continue
+			return nil, fmt.Errorf("failed to scan admin events: %w", err)
 		}
-		events = append(events, event)
-	}
-
-	return events, nil
-}
 
-// scanForApprovedEvents is a fallback method when GSI is not available
-func (s *DynamoDBService) scanForApprovedEvents(ctx context.Context, limit int32) ([]models.AdminEvent, error) {
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName:        aws.String(s.adminEventsTable),
-		FilterExpression: aws.String("#status = :status"),
-		ExpressionAttributeNames: map[string]string{
-			"#status": "status",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status": &types.AttributeValueMemberS{Value: string(models.AdminEventStatusApproved)},
-		},
-		Limit: aws.Int32(limit),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to scan for approved events: %w", err)
-	}
+		for _, item := range result.Items {
+			var event models.AdminEvent
+			if err := attributevalue.UnmarshalMap(item, &event); err != nil {
+				log.Printf("Failed to unmarshal admin event: %v", err)
+				continue
+			}
+			events = append(events, event)
+		}
 
-	var events []models.AdminEvent
-	for _, item := range result.Items {
-		var event models.AdminEvent
-		err = attributevalue.UnmarshalMap(item, &event)
-		if err != nil {
-			log.Printf("Failed to unmarshal admin event: %v", err)
-			continue
+		if result.LastEvaluatedKey == nil {
+			break
 		}
-		events = append(events, event)
+		lastEvaluatedKey = result.LastEvaluatedKey
 	}
 
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ExtractedAt.After(events[j].ExtractedAt)
+	})
 	return events, nil
 }
 
-// UpdateAdminEvent updates an existing admin event
-func (s *DynamoDBService) UpdateAdminEvent(ctx context.Context, event *models.AdminEvent) error {
-	// Update timestamp
-	event.UpdatedAt = time.Now()
-	event.StatusKey = models.GenerateAdminEventStatusKey(event.Status)
+// GetAdminEventsByActivityID retrieves the admin events published under an activity ID, newest first
+func (s *DynamoDBService) GetAdminEventsByActivityID(ctx context.Context, activityID string) ([]models.AdminEvent, error) {
+	return s.scanAdminEvents(ctx, "ActivityID = :activity", map[string]types.AttributeValue{
+		":activity": &types.AttributeValueMemberS{Value: activityID},
+	})
+}
 
-	// Marshal to DynamoDB attribute values
-	item, err := attributevalue.MarshalMap(event)
+// PutActivityRedirect stores the redirect from an old activity ID to its current one
+func (s *DynamoDBService) PutActivityRedirect(ctx context.Context, redirect *models.ActivityRedirect) error {
+	item, err := attributevalue.MarshalMap(redirect)
 	if err != nil {
-		return fmt.Errorf("failed to marshal admin event: %w", err)
+		return fmt.Errorf("failed to marshal activity redirect: %w", err)
 	}
 
-	// Put item (upsert)
 	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.adminEventsTable),
+		TableName: aws.String(s.familyActivitiesTable),
 		Item:      item,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update admin event: %w", err)
+		return fmt.Errorf("failed to store activity redirect: %w", err)
 	}
 
 	return nil
 }
 
-// QueryAdminEventsByStatus queries admin events by status using GSI
-func (s *DynamoDBService) QueryAdminEventsByStatus(ctx context.Context, status models.AdminEventStatus, limit int32) ([]models.AdminEvent, error) {
-	statusKey := models.GenerateAdminEventStatusKey(status)
-
-	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(s.adminEventsTable),
-		IndexName:              aws.String("status-date-index"),
-		KeyConditionExpression: aws.String("StatusKey = :statusKey"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":statusKey": &types.AttributeValueMemberS{Value: statusKey},
+// GetActivityRedirect retrieves the redirect left under an old activity ID, or nil if there is none
+func (s *DynamoDBService) GetActivityRedirect(ctx context.Context, activityID string) (*models.ActivityRedirect, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.familyActivitiesTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateEventPK(activityID)},
+			"SK": &types.AttributeValueMemberS{Value: models.SortKeyRedirect},
 		},
-		ScanIndexForward: aws.Bool(false), // Get newest first
-		Limit:           aws.Int32(limit),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query admin events by status: %w", err)
+		return nil, fmt.Errorf("failed to get activity redirect: %w", err)
 	}
 
-	var events []models.AdminEvent
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &events)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal admin events: %w", err)
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	return events, nil
+	var redirect models.ActivityRedirect
+	if err := attributevalue.UnmarshalMap(result.Item, &redirect); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal activity redirect: %w", err)
+	}
+
+	return &redirect, nil
 }
 
-// GetAllPendingAdminEvents retrieves all admin events that need review
-func (s *DynamoDBService) GetAllPendingAdminEvents(ctx context.Context, limit int32) ([]models.AdminEvent, error) {
-	// Get both pending and edited events
-	pendingEvents, err := s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusPending, limit/2)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get pending events: %w", err)
+// DeleteActivity removes a published activity, the days it was placed on the calendar and its tag and geo entries
+func (s *DynamoDBService) DeleteActivity(ctx context.Context, activityID string) error {
+	if err := s.ReplaceCalendarEntries(ctx, activityID, nil); err != nil {
+		return err
+	}
+	if err := s.ReplaceTagEntries(ctx, activityID, nil); err != nil {
+		return err
+	}
+	if err := s.ReplaceGeoEntry(ctx, activityID, nil); err != nil {
+		return err
 	}
+	return s.DeleteFamilyActivity(ctx, models.CreateEventPK(activityID), models.SortKeyMetadata)
+}
 
-	editedEvents, err := s.QueryAdminEventsByStatus(ctx, models.AdminEventStatusEdited, limit/2)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get edited events: %w", err)
+// ClearSandboxTables deletes every item of the service's four tables, returning how many were
+// deleted. It refuses unless every table name ends with SandboxTableSuffix, so it can only empty
+// sandbox tables.
+func (s *DynamoDBService) ClearSandboxTables(ctx context.Context) (int, error) {
+	tables := []string{s.familyActivitiesTable, s.sourceManagementTable, s.scrapingOperationsTable, s.adminEventsTable}
+	for _, table := range tables {
+		if !strings.HasSuffix(table, SandboxTableSuffix) {
+			return 0, fmt.Errorf("refusing to clear %q, which is not a sandbox table", table)
+		}
 	}
 
-	// Combine and sort by extracted_at descending
-	allEvents := append(pendingEvents, editedEvents...)
-	sort.Slice(allEvents, func(i, j int) bool {
-		return allEvents[i].ExtractedAt.After(allEvents[j].ExtractedAt)
-	})
+	deleted := 0
+	for _, table := range tables {
+		var lastEvaluatedKey map[string]types.AttributeValue
+		for {
+			result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
+				TableName:                aws.String(table),
+				ProjectionExpression:     aws.String("#pk, #sk"),
+				ExpressionAttributeNames: map[string]string{"#pk": "PK", "#sk": "SK"},
+				ExclusiveStartKey:        lastEvaluatedKey,
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("failed to scan %s: %w", table, err)
+			}
 
-	// Limit results
-	if len(allEvents) > int(limit) {
-		allEvents = allEvents[:limit]
+			// Process in batches of 25 (DynamoDB limit), retrying what DynamoDB leaves unprocessed
+			for i := 0; i < len(result.Items); i += 25 {
+				end := min(i+25, len(result.Items))
+				requests := make([]types.WriteRequest, 0, end-i)
+				for _, key := range result.Items[i:end] {
+					requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+				}
+				pending := map[string][]types.WriteRequest{table: requests}
+				for attempt := 0; len(pending) > 0; attempt++ {
+					if attempt == 5 {
+						return deleted, fmt.Errorf("failed to delete items of %s: still unprocessed after %d attempts", table, attempt)
+					}
+					time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+					output, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: pending})
+					if err != nil {
+						return deleted, fmt.Errorf("failed to delete items of %s: %w", table, err)
+					}
+					pending = output.UnprocessedItems
+				}
+				deleted += end - i
+			}
+
+			if result.LastEvaluatedKey == nil {
+				break
+			}
+			lastEvaluatedKey = result.LastEvaluatedKey
+		}
 	}
+	return deleted, nil
+}
 
-	return allEvents, nil
+// putItems writes items to a table in batches, retrying what DynamoDB leaves unprocessed. Items
+// must already have their keys set.
+func (s *DynamoDBService) putItems(ctx context.Context, table string, items []interface{}) error {
+	for i := 0; i < len(items); i += 25 {
+		end := min(i+25, len(items))
+		requests := make([]types.WriteRequest, 0, end-i)
+		for _, value := range items[i:end] {
+			item, err := attributevalue.MarshalMap(value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal item: %w", err)
+			}
+			requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+		}
+		pending := map[string][]types.WriteRequest{table: requests}
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt == 5 {
+				return fmt.Errorf("failed to write items to %s: still unprocessed after %d attempts", table, attempt)
+			}
+			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+			output, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: pending})
+			if err != nil {
+				return fmt.Errorf("failed to write items to %s: %w", table, err)
+			}
+			pending = output.UnprocessedItems
+		}
+	}
+	return nil
 }
 
 // DeleteAdminEvent removes an admin event
@@ -1089,9 +5598,12 @@ func (s *DynamoDBService) batchWriteAdminEvents(ctx context.Context, events []*m
 		event.CreatedAt = now
 		event.UpdatedAt = now
 		event.ExtractedAt = now
+		if event.QueuedAt == nil {
+			event.QueuedAt = &now
+		}
 		event.PK = models.CreateAdminEventPK(event.EventID)
 		event.SK = models.CreateAdminEventSK(event.ExtractedAt)
-		event.StatusKey = models.GenerateAdminEventStatusKey(event.Status)
+		event.StatusKey = models.GenerateAdminEventStatusKey(event.Status, event.EventID)
 
 		// Marshal event
 		item, err := attributevalue.MarshalMap(event)
@@ -1164,6 +5676,24 @@ func (s *DynamoDBService) DeleteSourceCompletely(ctx context.Context, sourceID s
 		}
 	}
 
+	// Add stored analysis versions to transaction
+	versionKeys, err := s.querySourceAnalysisVersionKeys(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analysis versions for source %s: %w", sourceID, err)
+	}
+	for _, sk := range versionKeys {
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName: aws.String(s.sourceManagementTable),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: models.CreateSourcePK(sourceID)},
+					"SK": &types.AttributeValueMemberS{Value: sk},
+				},
+			},
+		})
+	}
+	result.AnalysisVersionsDeleted = len(versionKeys)
+
 	// Add activity deletions to transaction (in batches if needed)
 	for _, activity := range activities {
 		transactItems = append(transactItems, types.TransactWriteItem{
@@ -1193,6 +5723,7 @@ func (s *DynamoDBService) DeleteSourceCompletely(ctx context.Context, sourceID s
 	if result.ConfigDeleted {
 		result.TotalRecords++
 	}
+	result.TotalRecords += result.AnalysisVersionsDeleted
 	result.TotalRecords += result.ActivitiesDeleted
 
 	return result, nil
@@ -1262,6 +5793,210 @@ func (s *DynamoDBService) executeTransactionBatches(ctx context.Context, items [
 	return nil
 }
 
+// CreateAdminAuditEntry logs an admin review action
+func (s *DynamoDBService) CreateAdminAuditEntry(ctx context.Context, entry *models.AdminAuditEntry) error {
+	item, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin audit entry: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create admin audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdminAuditEntries retrieves an admin's audit entries logged at or after from and before to,
+// oldest first
+func (s *DynamoDBService) GetAdminAuditEntries(ctx context.Context, admin string, from, to time.Time) ([]models.AdminAuditEntry, error) {
+	entries := []models.AdminAuditEntry{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.adminEventsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND SK BETWEEN :from AND :to"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":   &types.AttributeValueMemberS{Value: models.CreateAdminAuditPK(admin)},
+				":from": &types.AttributeValueMemberS{Value: models.CreateAdminAuditSKBound(from)},
+				":to":   &types.AttributeValueMemberS{Value: models.CreateAdminAuditSKBound(to)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query admin audit entries: %w", err)
+		}
+
+		var page []models.AdminAuditEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal admin audit entries: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// ErrApprovalProposalChanged is returned when a proposal is no longer in the status a change expected
+var ErrApprovalProposalChanged = errors.New("approval proposal changed")
+
+// SaveApprovalProposal stores a proposal together with the audit entry recording the step that
+// changed it. fromStatus is the status the stored proposal must still have, or "" for a new
+// proposal, so two admins cannot both confirm it; ErrApprovalProposalChanged is returned otherwise.
+func (s *DynamoDBService) SaveApprovalProposal(ctx context.Context, proposal *models.ApprovalProposal, fromStatus string, audit *models.ApprovalAuditEntry) error {
+	proposal.PK = models.CreateApprovalPK()
+	proposal.SK = models.CreateApprovalProposalSK(proposal.ProposalID)
+	proposal.UpdatedAt = audit.Timestamp
+
+	item, err := attributevalue.MarshalMap(proposal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval proposal: %w", err)
+	}
+	auditItem, err := attributevalue.MarshalMap(audit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval audit entry: %w", err)
+	}
+
+	put := &types.Put{
+		TableName:           aws.String(s.adminEventsTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	}
+	if fromStatus != "" {
+		put.ConditionExpression = aws.String("#status = :from")
+		put.ExpressionAttributeNames = map[string]string{"#status": "status"}
+		put.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":from": &types.AttributeValueMemberS{Value: fromStatus},
+		}
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Put: put},
+			{Put: &types.Put{TableName: aws.String(s.adminEventsTable), Item: auditItem}},
+		},
+	})
+	if err != nil {
+		var canceledErr *types.TransactionCanceledException
+		if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) > 0 &&
+			aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+			return ErrApprovalProposalChanged
+		}
+		return fmt.Errorf("failed to save approval proposal %s: %w", proposal.ProposalID, err)
+	}
+	return nil
+}
+
+// GetApprovalProposal retrieves an approval proposal.
+// It returns nil without an error when no proposal has that ID.
+func (s *DynamoDBService) GetApprovalProposal(ctx context.Context, proposalID string) (*models.ApprovalProposal, error) {
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.adminEventsTable),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: models.CreateApprovalPK()},
+			"SK": &types.AttributeValueMemberS{Value: models.CreateApprovalProposalSK(proposalID)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval proposal: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var proposal models.ApprovalProposal
+	if err := attributevalue.UnmarshalMap(result.Item, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approval proposal: %w", err)
+	}
+	return &proposal, nil
+}
+
+// ListApprovalProposals retrieves approval proposals, newest first, optionally only those in one status
+func (s *DynamoDBService) ListApprovalProposals(ctx context.Context, status string) ([]models.ApprovalProposal, error) {
+	proposals := []models.ApprovalProposal{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		input := &dynamodb.QueryInput{
+			TableName:              aws.String(s.adminEventsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateApprovalPK()},
+				":prefix": &types.AttributeValueMemberS{Value: models.CreateApprovalProposalSK("")},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		}
+		if status != "" {
+			input.FilterExpression = aws.String("#status = :status")
+			input.ExpressionAttributeNames = map[string]string{"#status": "status"}
+			input.ExpressionAttributeValues[":status"] = &types.AttributeValueMemberS{Value: status}
+		}
+
+		result, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query approval proposals: %w", err)
+		}
+
+		var page []models.ApprovalProposal
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approval proposals: %w", err)
+		}
+		proposals = append(proposals, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ProposedAt.After(proposals[j].ProposedAt)
+	})
+	return proposals, nil
+}
+
+// GetApprovalAudit retrieves a proposal's audit trail, oldest first
+func (s *DynamoDBService) GetApprovalAudit(ctx context.Context, proposalID string) ([]models.ApprovalAuditEntry, error) {
+	entries := []models.ApprovalAuditEntry{}
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.adminEventsTable),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":     &types.AttributeValueMemberS{Value: models.CreateApprovalPK()},
+				":prefix": &types.AttributeValueMemberS{Value: "AUDIT#" + proposalID + "#"},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query approval audit: %w", err)
+		}
+
+		var page []models.ApprovalAuditEntry
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approval audit: %w", err)
+		}
+		entries = append(entries, page...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
 // CreateSourceDeletionEvent logs a source deletion event
 func (s *DynamoDBService) CreateSourceDeletionEvent(ctx context.Context, event *models.SourceDeletionEvent) error {
 	// Set timestamps and keys
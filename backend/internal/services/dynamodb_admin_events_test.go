@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// conditionalPutServer stands in for DynamoDB holding one admin event under storedKey. A put
+// succeeds when its condition names the stored status key, as a StatusKey condition would match.
+func conditionalPutServer(t *testing.T, storedKey string) (*DynamoDBService, *int) {
+	t.Helper()
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			ExpressionAttributeValues map[string]map[string]string
+		}
+		json.Unmarshal(body, &request)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		for _, value := range request.ExpressionAttributeValues {
+			if value["S"] == storedKey {
+				puts++
+				w.Write([]byte("{}"))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testAWSConfig()
+	cfg.BaseEndpoint = aws.String(server.URL)
+	return NewDynamoDBService(dynamodb.NewFromConfig(cfg), "activities", "sources", "operations", "admin-events"), &puts
+}
+
+func TestUpdateAdminEventFromStatus(t *testing.T) {
+	newEvent := func() *models.AdminEvent {
+		return &models.AdminEvent{
+			PK:      models.CreateAdminEventPK("evt-1"),
+			SK:      "SUBMISSION#2026-10-16",
+			EventID: "evt-1",
+			Status:  models.AdminEventStatusApproved,
+		}
+	}
+
+	t.Run("ShardedKey", func(t *testing.T) {
+		service, puts := conditionalPutServer(t, models.GenerateAdminEventStatusKey(models.AdminEventStatusPending, "evt-1"))
+		if err := service.UpdateAdminEventFromStatus(context.Background(), newEvent(), models.AdminEventStatusPending); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if *puts != 1 {
+			t.Errorf("Expected the event to be written, got %d puts", *puts)
+		}
+	})
+
+	t.Run("LegacyKey", func(t *testing.T) {
+		service, puts := conditionalPutServer(t, "STATUS#pending")
+		event := newEvent()
+		if err := service.UpdateAdminEventFromStatus(context.Background(), event, models.AdminEventStatusPending); err != nil {
+			t.Fatalf("Expected an event stored under the unsharded key to update, got %v", err)
+		}
+		if *puts != 1 {
+			t.Errorf("Expected the event to be written, got %d puts", *puts)
+		}
+		if event.StatusKey != models.GenerateAdminEventStatusKey(models.AdminEventStatusApproved, "evt-1") {
+			t.Errorf("Expected the event to move to the sharded key, got %s", event.StatusKey)
+		}
+	})
+
+	t.Run("StatusChanged", func(t *testing.T) {
+		service, _ := conditionalPutServer(t, "STATUS#rejected")
+		err := service.UpdateAdminEventFromStatus(context.Background(), newEvent(), models.AdminEventStatusPending)
+		if !errors.Is(err, ErrAdminEventChanged) {
+			t.Errorf("Expected ErrAdminEventChanged, got %v", err)
+		}
+	})
+}
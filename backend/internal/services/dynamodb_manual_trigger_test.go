@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestCreateManualScrapingTask(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	previous := (*models.ManualTriggerState)(nil).Next("parks-1a2b3c4d", now.Add(-2*time.Hour))
+	newTask := func() *models.ScrapingTask {
+		return &models.ScrapingTask{
+			PK:            models.CreateTaskPK("task-1"),
+			SK:            models.CreateTaskSK(models.TaskPriorityHigh, "parks-1a2b3c4d", "task-1"),
+			TaskID:        "task-1",
+			SourceID:      "parks-1a2b3c4d",
+			Priority:      models.TaskPriorityHigh,
+			ScheduledTime: now.Add(time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		response string // TransactWriteItems response; empty for success
+		expected error
+	}{
+		{"Created", "", nil},
+		{"HourlyLimitReached", `[{"Code":"ConditionalCheckFailed"},{"Code":"None"},{"Code":"None"}]`, ErrManualTriggerLimitReached},
+		{"TriggeredConcurrently", `[{"Code":"None"},{"Code":"ConditionalCheckFailed"},{"Code":"None"}]`, ErrManualTriggerConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if target := r.Header.Get("X-Amz-Target"); !strings.HasSuffix(target, ".TransactWriteItems") {
+					t.Errorf("Expected a single transaction, got %s", target)
+				}
+				raw, _ := io.ReadAll(r.Body)
+				body = string(raw)
+				w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+				if tt.response == "" {
+					w.Write([]byte("{}"))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#TransactionCanceledException","message":"Transaction cancelled","CancellationReasons":` + tt.response + `}`))
+			}))
+			defer server.Close()
+
+			cfg := testAWSConfig()
+			cfg.BaseEndpoint = aws.String(server.URL)
+			service := NewDynamoDBService(dynamodb.NewFromConfig(cfg), "activities", "sources", "operations", "admin-events")
+
+			err := service.CreateManualScrapingTask(context.Background(), previous, previous.Next("parks-1a2b3c4d", now), newTask(), 30)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, err)
+			}
+			// The hourly slot, the trigger state and the task are written together
+			for _, want := range []string{models.CreateManualTriggerWindowPK(now), models.CreateManualTriggerPK("parks-1a2b3c4d"), models.CreateTaskPK("task-1")} {
+				if !strings.Contains(body, want) {
+					t.Errorf("Expected the transaction to write %s, got %s", want, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrInvalidPageToken is returned for a next_token that was not issued for the query it was sent with
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageCursor is where a paged query over several partitions stopped. After holds the
+// LastEvaluatedKey of each partition, the key of the last item returned from it; Done lists the
+// partitions read to the end. Partitions in neither are read from the start.
+type pageCursor struct {
+	After map[string]map[string]string `json:"a,omitempty"`
+	Done  []string                     `json:"d,omitempty"`
+}
+
+// itemOrder reports whether item a comes before item b in a paged query's merged order
+type itemOrder func(a, b map[string]types.AttributeValue) bool
+
+// queryShardsPage reads one page of up to q.limit items from the shards of a sharded GSI key,
+// merged in order, and returns it with the token of the next page. The token is "" once every
+// shard has been read to the end. Each shard is read in the index's order, so order has to sort
+// the items of a shard the way the index does.
+func (s *DynamoDBService) queryShardsPage(ctx context.Context, q shardedQuery, token string, order itemOrder) ([]map[string]types.AttributeValue, string, error) {
+	if q.limit <= 0 {
+		return nil, "", fmt.Errorf("paged queries need a limit")
+	}
+	cursor, err := decodePageToken(token, q.keys)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Shards read to the end on an earlier page are not queried again
+	allKeys := q.keys
+	q.keys, q.startKeys = cursor.remainingShards(allKeys)
+	if len(q.keys) == 0 {
+		return []map[string]types.AttributeValue{}, "", nil
+	}
+
+	reads, err := s.readShards(ctx, q)
+	if err != nil {
+		return nil, "", err
+	}
+	items, taken := mergeShardReads(reads, int(q.limit), order)
+
+	next, err := cursor.advance(q.keys, reads, taken, []string{"PK", "SK", q.keyName, q.sortKey})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(next.Done) == len(allKeys) {
+		return items, "", nil
+	}
+	return items, encodePageToken(next), nil
+}
+
+// remainingShards returns the shards not yet read to the end, with the key to resume each from
+func (c pageCursor) remainingShards(keys []string) ([]string, map[string]map[string]types.AttributeValue) {
+	done := make(map[string]bool, len(c.Done))
+	for _, key := range c.Done {
+		done[key] = true
+	}
+
+	var remaining []string
+	startKeys := make(map[string]map[string]types.AttributeValue)
+	for _, key := range keys {
+		if done[key] {
+			continue
+		}
+		remaining = append(remaining, key)
+		if after, ok := c.After[key]; ok {
+			startKeys[key] = stringKeyAttributes(after)
+		}
+	}
+	return remaining, startKeys
+}
+
+// advance returns the cursor after a page that took the first taken[i] items read from shard
+// keys[i]. A shard is done once all of it was read and taken; otherwise it resumes after the last
+// item taken from it, or where it did before when nothing was.
+func (c pageCursor) advance(keys []string, reads []shardRead, taken []int, keyNames []string) (pageCursor, error) {
+	next := pageCursor{
+		After: make(map[string]map[string]string, len(c.After)),
+		Done:  append([]string(nil), c.Done...),
+	}
+	for key, after := range c.After {
+		next.After[key] = after
+	}
+
+	for i, key := range keys {
+		switch {
+		case taken[i] == len(reads[i].items) && reads[i].exhausted:
+			delete(next.After, key)
+			next.Done = append(next.Done, key)
+		case taken[i] > 0:
+			after, err := keyStringAttributes(reads[i].items[taken[i]-1], keyNames)
+			if err != nil {
+				return pageCursor{}, err
+			}
+			next.After[key] = after
+		}
+	}
+	return next, nil
+}
+
+// mergeShardReads merges the items read from shards in order, up to limit, and returns how many
+// items of each shard were taken. Only the front item of each shard is compared, so the items
+// taken from a shard are always the first ones read from it, whatever order does with ties.
+func mergeShardReads(reads []shardRead, limit int, order itemOrder) ([]map[string]types.AttributeValue, []int) {
+	taken := make([]int, len(reads))
+	items := []map[string]types.AttributeValue{}
+	for len(items) < limit {
+		next := -1
+		for i, read := range reads {
+			if taken[i] == len(read.items) {
+				continue
+			}
+			if next == -1 || order(read.items[taken[i]], reads[next].items[taken[next]]) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+		items = append(items, reads[next].items[taken[next]])
+		taken[next]++
+	}
+	return items, taken
+}
+
+// decodePageToken reads a page token issued for a query over the given partition keys
+func decodePageToken(token string, keys []string) (pageCursor, error) {
+	var cursor pageCursor
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	for key := range cursor.After {
+		if !known[key] {
+			return cursor, ErrInvalidPageToken
+		}
+	}
+	for _, key := range cursor.Done {
+		if !known[key] {
+			return cursor, ErrInvalidPageToken
+		}
+	}
+	return cursor, nil
+}
+
+// encodePageToken encodes a cursor as an opaque, URL-safe page token
+func encodePageToken(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor) // maps of strings always encode
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// keyStringAttributes returns the named string attributes of an item
+func keyStringAttributes(item map[string]types.AttributeValue, names []string) (map[string]string, error) {
+	key := make(map[string]string, len(names))
+	for _, name := range names {
+		value, ok := item[name].(*types.AttributeValueMemberS)
+		if !ok {
+			return nil, fmt.Errorf("item has no string key attribute %s to page from", name)
+		}
+		key[name] = value.Value
+	}
+	return key, nil
+}
+
+// stringKeyAttributes converts key attributes back into DynamoDB string attributes
+func stringKeyAttributes(key map[string]string) map[string]types.AttributeValue {
+	attributes := make(map[string]types.AttributeValue, len(key))
+	for name, value := range key {
+		attributes[name] = &types.AttributeValueMemberS{Value: value}
+	}
+	return attributes
+}
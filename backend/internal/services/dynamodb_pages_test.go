@@ -0,0 +1,134 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// pageItem builds a status-date-index item in a shard
+func pageItem(shard, sk, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"PK":        &types.AttributeValueMemberS{Value: "EVENT#" + id},
+		"SK":        &types.AttributeValueMemberS{Value: sk},
+		"StatusKey": &types.AttributeValueMemberS{Value: shard},
+	}
+}
+
+// readFakeShards reads up to limit items of each shard after its start key, the way a GSI query does
+func readFakeShards(shards map[string][]map[string]types.AttributeValue, keys []string, startKeys map[string]map[string]types.AttributeValue, limit int) []shardRead {
+	reads := make([]shardRead, len(keys))
+	for i, key := range keys {
+		items := shards[key]
+		if start, ok := startKeys[key]; ok {
+			for j, item := range items {
+				if attributeString(item, "PK") == attributeString(start, "PK") {
+					items = items[j+1:]
+					break
+				}
+			}
+		}
+		reads[i].exhausted = len(items) <= limit
+		if len(items) > limit {
+			items = items[:limit]
+		}
+		reads[i].items = items
+	}
+	return reads
+}
+
+func TestPagingVisitsEveryItemOnce(t *testing.T) {
+	keys := []string{"STATUS#pending#0", "STATUS#pending#1", "STATUS#pending", "STATUS#edited#0"}
+	// Each shard is newest first, as the index returns it, with ties across and within shards
+	shards := map[string][]map[string]types.AttributeValue{
+		"STATUS#pending#0": {pageItem("STATUS#pending#0", "SUBMISSION#09", "a"), pageItem("STATUS#pending#0", "SUBMISSION#07", "b"),
+			pageItem("STATUS#pending#0", "SUBMISSION#07", "c"), pageItem("STATUS#pending#0", "SUBMISSION#02", "d")},
+		"STATUS#pending#1": {pageItem("STATUS#pending#1", "SUBMISSION#08", "e"), pageItem("STATUS#pending#1", "SUBMISSION#07", "f"),
+			pageItem("STATUS#pending#1", "SUBMISSION#01", "g")},
+		"STATUS#edited#0": {pageItem("STATUS#edited#0", "SUBMISSION#05", "h")},
+	}
+	newestFirst := func(a, b map[string]types.AttributeValue) bool {
+		return attributeString(a, "SK") > attributeString(b, "SK")
+	}
+
+	for _, limit := range []int{1, 2, 3, 8, 20} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			var got []string
+			token := ""
+			for page := 0; page < 20; page++ {
+				cursor, err := decodePageToken(token, keys)
+				if err != nil {
+					t.Fatalf("Failed to decode token: %v", err)
+				}
+				remaining, startKeys := cursor.remainingShards(keys)
+				reads := readFakeShards(shards, remaining, startKeys, limit)
+				items, taken := mergeShardReads(reads, limit, newestFirst)
+				if len(items) > limit {
+					t.Fatalf("Expected at most %d items, got %d", limit, len(items))
+				}
+				for _, item := range items {
+					got = append(got, attributeString(item, "SK")+"/"+attributeString(item, "PK"))
+				}
+
+				next, err := cursor.advance(remaining, reads, taken, []string{"PK", "SK", "StatusKey", "SK"})
+				if err != nil {
+					t.Fatalf("Failed to advance cursor: %v", err)
+				}
+				if len(next.Done) == len(keys) {
+					token = ""
+					break
+				}
+				token = encodePageToken(next)
+			}
+			if token != "" {
+				t.Fatal("Expected paging to finish")
+			}
+
+			if len(got) != 8 {
+				t.Fatalf("Expected all 8 items once, got %v", got)
+			}
+			seen := make(map[string]bool)
+			for i, item := range got {
+				if seen[item] {
+					t.Errorf("Item %s returned twice", item)
+				}
+				seen[item] = true
+				if i > 0 && item[:13] > got[i-1][:13] {
+					t.Errorf("Expected newest first, got %s after %s", item, got[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeShardReadsKeepsShardOrder(t *testing.T) {
+	// Ties always come from the earlier shard, and each shard contributes its first items
+	reads := []shardRead{
+		{items: []map[string]types.AttributeValue{pageItem("s0", "SUBMISSION#05", "a"), pageItem("s0", "SUBMISSION#05", "b")}},
+		{items: []map[string]types.AttributeValue{pageItem("s1", "SUBMISSION#05", "c")}},
+	}
+	items, taken := mergeShardReads(reads, 2, func(a, b map[string]types.AttributeValue) bool {
+		return attributeString(a, "SK") > attributeString(b, "SK")
+	})
+	if len(items) != 2 || attributeString(items[0], "PK") != "EVENT#a" || attributeString(items[1], "PK") != "EVENT#b" {
+		t.Errorf("Expected a then b, got %v", items)
+	}
+	if taken[0] != 2 || taken[1] != 0 {
+		t.Errorf("Expected 2 items taken from the first shard only, got %v", taken)
+	}
+}
+
+func TestDecodePageTokenRejectsOtherQueries(t *testing.T) {
+	token := encodePageToken(pageCursor{Done: []string{"STATUS#approved#0"}})
+
+	if _, err := decodePageToken(token, []string{"STATUS#approved#0", "STATUS#approved"}); err != nil {
+		t.Errorf("Expected the token to decode for its own query, got %v", err)
+	}
+	for _, bad := range []string{token, "not a token", "bm90IGpzb24"} {
+		if _, err := decodePageToken(bad, []string{"STATUS#pending#0"}); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("Expected ErrInvalidPageToken for %q, got %v", bad, err)
+		}
+	}
+}
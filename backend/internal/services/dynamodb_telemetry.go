@@ -0,0 +1,480 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// DefaultDynamoDBTelemetryWindow is how far back the rolling DynamoDB summary reaches
+const DefaultDynamoDBTelemetryWindow = 15 * time.Minute
+
+// DynamoDBCall is one DynamoDB API call as seen by the service layer, including its retries
+type DynamoDBCall struct {
+	Table      string
+	Operation  string
+	At         time.Time
+	Latency    time.Duration
+	Attempts   int
+	Throttles  int // attempts rejected for exceeding capacity or request limits
+	Failed     bool
+	ReadUnits  float64
+	WriteUnits float64
+}
+
+// DynamoDBOperationStats summarizes the calls of one operation on one table
+type DynamoDBOperationStats struct {
+	Table        string  `json:"table"`
+	Operation    string  `json:"operation"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	Throttles    int     `json:"throttles"`
+	Retries      int     `json:"retries"`
+	ReadUnits    float64 `json:"read_units"`
+	WriteUnits   float64 `json:"write_units"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// DynamoDBTableStats totals the calls made to one table
+type DynamoDBTableStats struct {
+	Table      string  `json:"table"`
+	Calls      int     `json:"calls"`
+	Throttles  int     `json:"throttles"`
+	ReadUnits  float64 `json:"read_units"`
+	WriteUnits float64 `json:"write_units"`
+	// Capacity consumed per second over the window, comparable to provisioned capacity
+	ReadUnitsPerSecond  float64 `json:"read_units_per_second"`
+	WriteUnitsPerSecond float64 `json:"write_units_per_second"`
+}
+
+// DynamoDBTelemetrySummary is the rolling summary of the DynamoDB calls this instance made
+type DynamoDBTelemetrySummary struct {
+	WindowMinutes int                      `json:"window_minutes"`
+	Since         time.Time                `json:"since"`
+	Tables        []DynamoDBTableStats     `json:"tables"`     // most consumed capacity first
+	Operations    []DynamoDBOperationStats `json:"operations"` // most consumed capacity first
+}
+
+// dynamoDBStatsKey identifies the calls of one operation on one table
+type dynamoDBStatsKey struct {
+	table     string
+	operation string
+}
+
+// record adds a call to the stats
+func (s *DynamoDBOperationStats) record(call DynamoDBCall) {
+	s.Calls++
+	if call.Failed {
+		s.Errors++
+	}
+	s.Throttles += call.Throttles
+	if call.Attempts > 1 {
+		s.Retries += call.Attempts - 1
+	}
+	s.ReadUnits += call.ReadUnits
+	s.WriteUnits += call.WriteUnits
+	s.totalLatency += call.Latency
+	if call.Latency > s.maxLatency {
+		s.maxLatency = call.Latency
+	}
+}
+
+// merge adds the calls of other to the stats
+func (s *DynamoDBOperationStats) merge(other *DynamoDBOperationStats) {
+	s.Calls += other.Calls
+	s.Errors += other.Errors
+	s.Throttles += other.Throttles
+	s.Retries += other.Retries
+	s.ReadUnits += other.ReadUnits
+	s.WriteUnits += other.WriteUnits
+	s.totalLatency += other.totalLatency
+	if other.maxLatency > s.maxLatency {
+		s.maxLatency = other.maxLatency
+	}
+}
+
+// finish fills in the latency fields
+func (s DynamoDBOperationStats) finish() DynamoDBOperationStats {
+	if s.Calls > 0 {
+		s.AvgLatencyMs = float64(s.totalLatency.Microseconds()) / 1000 / float64(s.Calls)
+	}
+	s.MaxLatencyMs = float64(s.maxLatency.Microseconds()) / 1000
+	return s
+}
+
+// DynamoDBTelemetry collects the consumed capacity, latency, throttles and retries of DynamoDB
+// calls per table and operation. Calls are kept in one-minute buckets for the rolling summary,
+// and counted separately until the next CloudWatch flush. It is safe for concurrent use.
+type DynamoDBTelemetry struct {
+	mu        sync.Mutex
+	window    time.Duration
+	buckets   map[int64]map[dynamoDBStatsKey]*DynamoDBOperationStats // unix minute -> stats
+	unflushed map[dynamoDBStatsKey]*DynamoDBOperationStats
+}
+
+// NewDynamoDBTelemetry creates a collector whose summary covers the given window
+func NewDynamoDBTelemetry(window time.Duration) *DynamoDBTelemetry {
+	return &DynamoDBTelemetry{
+		window:    window,
+		buckets:   make(map[int64]map[dynamoDBStatsKey]*DynamoDBOperationStats),
+		unflushed: make(map[dynamoDBStatsKey]*DynamoDBOperationStats),
+	}
+}
+
+var globalDynamoDBTelemetry = NewDynamoDBTelemetry(DefaultDynamoDBTelemetryWindow)
+
+// GetDynamoDBTelemetry returns the collector every DynamoDBService records its calls in
+func GetDynamoDBTelemetry() *DynamoDBTelemetry {
+	return globalDynamoDBTelemetry
+}
+
+// Record adds a call to the collector
+func (t *DynamoDBTelemetry) Record(call DynamoDBCall) {
+	key := dynamoDBStatsKey{table: call.Table, operation: call.Operation}
+	minute := call.At.Unix() / 60
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, exists := t.buckets[minute]
+	if !exists {
+		bucket = make(map[dynamoDBStatsKey]*DynamoDBOperationStats)
+		t.buckets[minute] = bucket
+		t.evictLocked(call.At)
+	}
+	for _, stats := range []map[dynamoDBStatsKey]*DynamoDBOperationStats{bucket, t.unflushed} {
+		entry, exists := stats[key]
+		if !exists {
+			entry = &DynamoDBOperationStats{Table: call.Table, Operation: call.Operation}
+			stats[key] = entry
+		}
+		entry.record(call)
+	}
+}
+
+// evictLocked drops the buckets that fell out of the window; callers must hold t.mu
+func (t *DynamoDBTelemetry) evictLocked(now time.Time) {
+	oldest := now.Add(-t.window).Unix() / 60
+	for minute := range t.buckets {
+		if minute < oldest {
+			delete(t.buckets, minute)
+		}
+	}
+}
+
+// Summary returns the calls made in the window ending at now, per table and per operation
+func (t *DynamoDBTelemetry) Summary(now time.Time) *DynamoDBTelemetrySummary {
+	since := now.Add(-t.window)
+	oldest := since.Unix() / 60
+
+	merged := make(map[dynamoDBStatsKey]*DynamoDBOperationStats)
+	t.mu.Lock()
+	for minute, bucket := range t.buckets {
+		if minute < oldest {
+			continue
+		}
+		for key, stats := range bucket {
+			entry, exists := merged[key]
+			if !exists {
+				entry = &DynamoDBOperationStats{Table: key.table, Operation: key.operation}
+				merged[key] = entry
+			}
+			entry.merge(stats)
+		}
+	}
+	t.mu.Unlock()
+
+	summary := &DynamoDBTelemetrySummary{
+		WindowMinutes: int(t.window / time.Minute),
+		Since:         since,
+		Tables:        []DynamoDBTableStats{},
+		Operations:    make([]DynamoDBOperationStats, 0, len(merged)),
+	}
+	tables := make(map[string]*DynamoDBTableStats)
+	for _, stats := range merged {
+		summary.Operations = append(summary.Operations, stats.finish())
+
+		table, exists := tables[stats.Table]
+		if !exists {
+			table = &DynamoDBTableStats{Table: stats.Table}
+			tables[stats.Table] = table
+		}
+		table.Calls += stats.Calls
+		table.Throttles += stats.Throttles
+		table.ReadUnits += stats.ReadUnits
+		table.WriteUnits += stats.WriteUnits
+	}
+	for _, table := range tables {
+		table.ReadUnitsPerSecond = table.ReadUnits / t.window.Seconds()
+		table.WriteUnitsPerSecond = table.WriteUnits / t.window.Seconds()
+		summary.Tables = append(summary.Tables, *table)
+	}
+
+	sort.Slice(summary.Operations, func(i, j int) bool {
+		a, b := summary.Operations[i], summary.Operations[j]
+		if a.ReadUnits+a.WriteUnits != b.ReadUnits+b.WriteUnits {
+			return a.ReadUnits+a.WriteUnits > b.ReadUnits+b.WriteUnits
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return a.Operation < b.Operation
+	})
+	sort.Slice(summary.Tables, func(i, j int) bool {
+		a, b := summary.Tables[i], summary.Tables[j]
+		if a.ReadUnits+a.WriteUnits != b.ReadUnits+b.WriteUnits {
+			return a.ReadUnits+a.WriteUnits > b.ReadUnits+b.WriteUnits
+		}
+		return a.Table < b.Table
+	})
+	return summary
+}
+
+// FlushToCloudWatch writes the calls recorded since the last flush as CloudWatch Embedded Metric
+// Format records dimensioned by table and operation. The rolling summary is left alone.
+func (t *DynamoDBTelemetry) FlushToCloudWatch(namespace string) {
+	t.mu.Lock()
+	unflushed := t.unflushed
+	t.unflushed = make(map[dynamoDBStatsKey]*DynamoDBOperationStats)
+	t.mu.Unlock()
+
+	keys := make([]dynamoDBStatsKey, 0, len(unflushed))
+	for key := range unflushed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].table != keys[j].table {
+			return keys[i].table < keys[j].table
+		}
+		return keys[i].operation < keys[j].operation
+	})
+
+	for _, key := range keys {
+		stats := unflushed[key].finish()
+		writeEMF(namespace, []emfDimension{
+			{Name: "Table", Value: stats.Table},
+			{Name: "Operation", Value: stats.Operation},
+		}, []emfMetric{
+			{Name: "Calls", Unit: "Count", Value: stats.Calls},
+			{Name: "Errors", Unit: "Count", Value: stats.Errors},
+			{Name: "Throttles", Unit: "Count", Value: stats.Throttles},
+			{Name: "Retries", Unit: "Count", Value: stats.Retries},
+			{Name: "ConsumedReadCapacity", Unit: "Count", Value: stats.ReadUnits},
+			{Name: "ConsumedWriteCapacity", Unit: "Count", Value: stats.WriteUnits},
+			{Name: "AvgLatencyMs", Unit: "Milliseconds", Value: stats.AvgLatencyMs},
+			{Name: "MaxLatencyMs", Unit: "Milliseconds", Value: stats.MaxLatencyMs},
+		})
+	}
+}
+
+// attach adds the telemetry middleware to a DynamoDB client's operations. The outer middleware
+// asks for the consumed capacity and times the whole call; the inner one runs once per attempt,
+// after the retry middleware, to count retries and throttles.
+func (t *DynamoDBTelemetry) attach(stack *middleware.Stack) error {
+	if err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DynamoDBTelemetry", t.handleCall), middleware.Before); err != nil {
+		return err
+	}
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("DynamoDBTelemetryAttempt", countDynamoDBAttempt), middleware.After)
+}
+
+// dynamoDBAttemptsKey holds the attempt counter of a call in its context
+type dynamoDBAttemptsKey struct{}
+
+// dynamoDBAttempts counts the attempts of one call
+type dynamoDBAttempts struct {
+	attempts  int
+	throttles int
+}
+
+func (t *DynamoDBTelemetry) handleCall(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	table, operation := describeDynamoDBCall(in.Parameters)
+	if operation == "" {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	counter := &dynamoDBAttempts{}
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(context.WithValue(ctx, dynamoDBAttemptsKey{}, counter), in)
+
+	call := DynamoDBCall{
+		Table:     table,
+		Operation: operation,
+		At:        start,
+		Latency:   time.Since(start),
+		Attempts:  counter.attempts,
+		Throttles: counter.throttles,
+		Failed:    err != nil,
+	}
+	if err == nil {
+		call.ReadUnits, call.WriteUnits = dynamoDBConsumedCapacity(operation, out.Result)
+	}
+	t.Record(call)
+
+	return out, metadata, err
+}
+
+func countDynamoDBAttempt(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if counter, ok := ctx.Value(dynamoDBAttemptsKey{}).(*dynamoDBAttempts); ok {
+		counter.attempts++
+		if isDynamoDBThrottle(err) {
+			counter.throttles++
+		}
+	}
+	return out, metadata, err
+}
+
+// isDynamoDBThrottle reports whether DynamoDB rejected an attempt for exceeding capacity or request limits
+func isDynamoDBThrottle(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "ProvisionedThroughputExceededException", "ThrottlingException", "RequestLimitExceeded":
+		return true
+	}
+	return false
+}
+
+// describeDynamoDBCall returns the table and operation of a call's input and asks DynamoDB to
+// return the capacity the call consumed. Calls to several tables are reported as "multiple".
+// The operation is empty for calls the telemetry does not track, such as DescribeTable.
+func describeDynamoDBCall(params interface{}) (table, operation string) {
+	total := types.ReturnConsumedCapacityTotal
+	switch input := params.(type) {
+	case *dynamodb.GetItemInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "GetItem"
+	case *dynamodb.PutItemInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "PutItem"
+	case *dynamodb.UpdateItemInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "UpdateItem"
+	case *dynamodb.DeleteItemInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "DeleteItem"
+	case *dynamodb.QueryInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "Query"
+	case *dynamodb.ScanInput:
+		input.ReturnConsumedCapacity = total
+		return aws.ToString(input.TableName), "Scan"
+	case *dynamodb.BatchGetItemInput:
+		input.ReturnConsumedCapacity = total
+		tables := make([]string, 0, len(input.RequestItems))
+		for name := range input.RequestItems {
+			tables = append(tables, name)
+		}
+		return singleTable(tables), "BatchGetItem"
+	case *dynamodb.BatchWriteItemInput:
+		input.ReturnConsumedCapacity = total
+		tables := make([]string, 0, len(input.RequestItems))
+		for name := range input.RequestItems {
+			tables = append(tables, name)
+		}
+		return singleTable(tables), "BatchWriteItem"
+	case *dynamodb.TransactWriteItemsInput:
+		input.ReturnConsumedCapacity = total
+		var tables []string
+		for _, item := range input.TransactItems {
+			switch {
+			case item.Put != nil:
+				tables = append(tables, aws.ToString(item.Put.TableName))
+			case item.Update != nil:
+				tables = append(tables, aws.ToString(item.Update.TableName))
+			case item.Delete != nil:
+				tables = append(tables, aws.ToString(item.Delete.TableName))
+			case item.ConditionCheck != nil:
+				tables = append(tables, aws.ToString(item.ConditionCheck.TableName))
+			}
+		}
+		return singleTable(tables), "TransactWriteItems"
+	case *dynamodb.TransactGetItemsInput:
+		input.ReturnConsumedCapacity = total
+		var tables []string
+		for _, item := range input.TransactItems {
+			if item.Get != nil {
+				tables = append(tables, aws.ToString(item.Get.TableName))
+			}
+		}
+		return singleTable(tables), "TransactGetItems"
+	}
+	return "", ""
+}
+
+// singleTable returns the table all names refer to, or "multiple"
+func singleTable(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	for _, name := range names[1:] {
+		if name != names[0] {
+			return "multiple"
+		}
+	}
+	return names[0]
+}
+
+// dynamoDBConsumedCapacity totals the read and write capacity a call's output reports
+func dynamoDBConsumedCapacity(operation string, result interface{}) (read, write float64) {
+	var consumed []types.ConsumedCapacity
+	switch output := result.(type) {
+	case *dynamodb.GetItemOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.PutItemOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.UpdateItemOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.DeleteItemOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.QueryOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.ScanOutput:
+		consumed = capacityList(output.ConsumedCapacity)
+	case *dynamodb.BatchGetItemOutput:
+		consumed = output.ConsumedCapacity
+	case *dynamodb.BatchWriteItemOutput:
+		consumed = output.ConsumedCapacity
+	case *dynamodb.TransactWriteItemsOutput:
+		consumed = output.ConsumedCapacity
+	case *dynamodb.TransactGetItemsOutput:
+		consumed = output.ConsumedCapacity
+	}
+
+	readOperation := operation == "GetItem" || operation == "Query" || operation == "Scan" ||
+		operation == "BatchGetItem" || operation == "TransactGetItems"
+	for _, capacity := range consumed {
+		if capacity.ReadCapacityUnits != nil || capacity.WriteCapacityUnits != nil {
+			read += aws.ToFloat64(capacity.ReadCapacityUnits)
+			write += aws.ToFloat64(capacity.WriteCapacityUnits)
+			continue
+		}
+		// Only the total is reported; attribute it to the kind of the operation
+		if readOperation {
+			read += aws.ToFloat64(capacity.CapacityUnits)
+		} else {
+			write += aws.ToFloat64(capacity.CapacityUnits)
+		}
+	}
+	return read, write
+}
+
+func capacityList(capacity *types.ConsumedCapacity) []types.ConsumedCapacity {
+	if capacity == nil {
+		return nil
+	}
+	return []types.ConsumedCapacity{*capacity}
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// scriptedDynamoDB answers DynamoDB requests with canned responses, in order
+type scriptedDynamoDB struct {
+	responses []scriptedResponse
+	requests  int
+}
+
+type scriptedResponse struct {
+	status int
+	body   string
+}
+
+func (s *scriptedDynamoDB) Do(req *http.Request) (*http.Response, error) {
+	response := s.responses[s.requests]
+	s.requests++
+	return &http.Response{
+		StatusCode: response.status,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.0"}},
+		Body:       io.NopCloser(strings.NewReader(response.body)),
+		Request:    req,
+	}, nil
+}
+
+type noRetryBackoff struct{}
+
+func (noRetryBackoff) BackoffDelay(int, error) (time.Duration, error) { return 0, nil }
+
+func TestDynamoDBTelemetryRollingSummary(t *testing.T) {
+	telemetry := NewDynamoDBTelemetry(15 * time.Minute)
+	now := time.Date(2026, 10, 16, 17, 5, 0, 0, time.UTC)
+
+	telemetry.Record(DynamoDBCall{Table: "events", Operation: "Query", At: now.Add(-30 * time.Minute), Attempts: 1, ReadUnits: 100})
+	telemetry.Record(DynamoDBCall{Table: "events", Operation: "Query", At: now.Add(-5 * time.Minute), Latency: 10 * time.Millisecond, Attempts: 1, ReadUnits: 4})
+	telemetry.Record(DynamoDBCall{Table: "events", Operation: "Query", At: now.Add(-time.Minute), Latency: 30 * time.Millisecond, Attempts: 3, Throttles: 2, ReadUnits: 2})
+	telemetry.Record(DynamoDBCall{Table: "events", Operation: "PutItem", At: now, Latency: 5 * time.Millisecond, Attempts: 1, WriteUnits: 1})
+	telemetry.Record(DynamoDBCall{Table: "sources", Operation: "GetItem", At: now, Attempts: 1, Failed: true})
+
+	summary := telemetry.Summary(now)
+	if summary.WindowMinutes != 15 {
+		t.Errorf("Expected a 15 minute window, got %d", summary.WindowMinutes)
+	}
+	if len(summary.Operations) != 3 {
+		t.Fatalf("Expected 3 operations, got %+v", summary.Operations)
+	}
+
+	query := summary.Operations[0]
+	if query.Operation != "Query" || query.Calls != 2 {
+		t.Fatalf("Expected the two recent queries first, got %+v", query)
+	}
+	if query.ReadUnits != 6 || query.Throttles != 2 || query.Retries != 2 {
+		t.Errorf("Unexpected query stats: %+v", query)
+	}
+	if query.AvgLatencyMs != 20 || query.MaxLatencyMs != 30 {
+		t.Errorf("Expected 20ms avg and 30ms max latency, got %v and %v", query.AvgLatencyMs, query.MaxLatencyMs)
+	}
+	if failed := summary.Operations[2]; failed.Table != "sources" || failed.Errors != 1 {
+		t.Errorf("Expected the failed GetItem last, got %+v", failed)
+	}
+
+	if len(summary.Tables) != 2 || summary.Tables[0].Table != "events" {
+		t.Fatalf("Expected events then sources, got %+v", summary.Tables)
+	}
+	events := summary.Tables[0]
+	if events.Calls != 3 || events.ReadUnits != 6 || events.WriteUnits != 1 {
+		t.Errorf("Unexpected events table stats: %+v", events)
+	}
+	if events.ReadUnitsPerSecond != 6.0/900 {
+		t.Errorf("Expected %v read units per second, got %v", 6.0/900, events.ReadUnitsPerSecond)
+	}
+
+	// The call from half an hour ago was evicted when a newer minute started
+	telemetry.mu.Lock()
+	buckets := len(telemetry.buckets)
+	telemetry.mu.Unlock()
+	if buckets != 3 {
+		t.Errorf("Expected 3 minute buckets left, got %d", buckets)
+	}
+}
+
+func TestDynamoDBTelemetryFlushKeepsSummary(t *testing.T) {
+	telemetry := NewDynamoDBTelemetry(15 * time.Minute)
+	now := time.Now()
+	telemetry.Record(DynamoDBCall{Table: "events", Operation: "Scan", At: now, Attempts: 1, ReadUnits: 8})
+
+	telemetry.FlushToCloudWatch("Test/DynamoDB")
+
+	if len(telemetry.unflushed) != 0 {
+		t.Errorf("Expected flushed calls to be cleared, got %d", len(telemetry.unflushed))
+	}
+	if summary := telemetry.Summary(now); len(summary.Operations) != 1 || summary.Operations[0].ReadUnits != 8 {
+		t.Errorf("Expected the rolling summary to keep flushed calls, got %+v", summary.Operations)
+	}
+}
+
+func TestDescribeDynamoDBCall(t *testing.T) {
+	query := &dynamodb.QueryInput{TableName: aws.String("events")}
+	table, operation := describeDynamoDBCall(query)
+	if table != "events" || operation != "Query" {
+		t.Errorf("Expected events Query, got %s %s", table, operation)
+	}
+	if query.ReturnConsumedCapacity != types.ReturnConsumedCapacityTotal {
+		t.Errorf("Expected the query to ask for consumed capacity, got %q", query.ReturnConsumedCapacity)
+	}
+
+	batch := &dynamodb.BatchGetItemInput{RequestItems: map[string]types.KeysAndAttributes{"events": {}, "sources": {}}}
+	if table, _ := describeDynamoDBCall(batch); table != "multiple" {
+		t.Errorf("Expected a batch over two tables to be reported as multiple, got %s", table)
+	}
+
+	if _, operation := describeDynamoDBCall(&dynamodb.DescribeTableInput{}); operation != "" {
+		t.Errorf("Expected DescribeTable not to be tracked, got %s", operation)
+	}
+}
+
+func TestDynamoDBConsumedCapacity(t *testing.T) {
+	read, write := dynamoDBConsumedCapacity("Query", &dynamodb.QueryOutput{
+		ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(2.5)},
+	})
+	if read != 2.5 || write != 0 {
+		t.Errorf("Expected a query's total to count as reads, got %v read and %v write", read, write)
+	}
+
+	read, write = dynamoDBConsumedCapacity("TransactWriteItems", &dynamodb.TransactWriteItemsOutput{
+		ConsumedCapacity: []types.ConsumedCapacity{
+			{CapacityUnits: aws.Float64(2)},
+			{CapacityUnits: aws.Float64(4), ReadCapacityUnits: aws.Float64(1), WriteCapacityUnits: aws.Float64(3)},
+		},
+	})
+	if read != 1 || write != 5 {
+		t.Errorf("Expected 1 read and 5 write units, got %v and %v", read, write)
+	}
+}
+
+func TestDynamoDBTelemetryMiddlewareCountsThrottledAttempts(t *testing.T) {
+	telemetry := NewDynamoDBTelemetry(15 * time.Minute)
+	server := &scriptedDynamoDB{responses: []scriptedResponse{
+		{status: 400, body: `{"__type":"com.amazonaws.dynamodb.v20120810#ProvisionedThroughputExceededException","message":"slow down"}`},
+		{status: 200, body: `{"Items":[],"Count":0,"ScannedCount":0,"ConsumedCapacity":{"TableName":"events","CapacityUnits":1.5}}`},
+	}}
+	client := dynamodb.New(dynamodb.Options{
+		Region:      "us-west-2",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  server,
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = noRetryBackoff{}
+			o.RateLimiter = ratelimit.None
+		}),
+		APIOptions: []func(*middleware.Stack) error{telemetry.attach},
+	})
+
+	if _, err := client.Query(context.Background(), &dynamodb.QueryInput{TableName: aws.String("events")}); err != nil {
+		t.Fatalf("Expected the query to succeed after a retry, got %v", err)
+	}
+
+	summary := telemetry.Summary(time.Now())
+	if len(summary.Operations) != 1 {
+		t.Fatalf("Expected one operation, got %+v", summary.Operations)
+	}
+	query := summary.Operations[0]
+	if query.Table != "events" || query.Operation != "Query" || query.Calls != 1 {
+		t.Errorf("Unexpected query stats: %+v", query)
+	}
+	if query.Throttles != 1 || query.Retries != 1 || query.Errors != 0 {
+		t.Errorf("Expected 1 throttle, 1 retry and no errors, got %+v", query)
+	}
+	if query.ReadUnits != 1.5 {
+		t.Errorf("Expected 1.5 read units, got %v", query.ReadUnits)
+	}
+}
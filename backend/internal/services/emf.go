@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// emfOutput receives EMF records. Lambda forwards stdout to CloudWatch Logs, which turns the
+// records into metrics without an extra API call.
+var emfOutput io.Writer = os.Stdout
+
+// emfDimension is a dimension of an EMF record and its value
+type emfDimension struct {
+	Name  string
+	Value string
+}
+
+// emfMetric is a metric of an EMF record with its CloudWatch unit, e.g. "Count" or "Milliseconds"
+type emfMetric struct {
+	Name  string
+	Unit  string
+	Value interface{}
+}
+
+// writeEMF writes a single CloudWatch Embedded Metric Format record. The metrics are published
+// for every leading run of the dimensions, so a record dimensioned by table and operation also
+// rolls up by table; a record without dimensions publishes the metrics undimensioned.
+func writeEMF(namespace string, dimensions []emfDimension, metrics []emfMetric) {
+	record := make(map[string]interface{}, len(dimensions)+len(metrics)+1)
+
+	dimensionSets := [][]string{{}}
+	if len(dimensions) > 0 {
+		dimensionSets = make([][]string, 0, len(dimensions))
+	}
+	names := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		record[dimension.Name] = dimension.Value
+		names = append(names, dimension.Name)
+		dimensionSets = append(dimensionSets, append([]string(nil), names...))
+	}
+
+	definitions := make([]map[string]string, 0, len(metrics))
+	for _, metric := range metrics {
+		record[metric.Name] = metric.Value
+		definitions = append(definitions, map[string]string{"Name": metric.Name, "Unit": metric.Unit})
+	}
+
+	record["_aws"] = map[string]interface{}{
+		"Timestamp": time.Now().UnixMilli(),
+		"CloudWatchMetrics": []map[string]interface{}{
+			{
+				"Namespace":  namespace,
+				"Dimensions": dimensionSets,
+				"Metrics":    definitions,
+			},
+		},
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	// EMF records must be written to stdout on their own line, without a log prefix
+	fmt.Fprintln(emfOutput, string(line))
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteEMF(t *testing.T) {
+	var output bytes.Buffer
+	previous := emfOutput
+	emfOutput = &output
+	defer func() { emfOutput = previous }()
+
+	writeEMF("Test/EMF", []emfDimension{
+		{Name: "Table", Value: "events"},
+		{Name: "Operation", Value: "Query"},
+	}, []emfMetric{
+		{Name: "Calls", Unit: "Count", Value: 3},
+		{Name: "AvgLatencyMs", Unit: "Milliseconds", Value: 12.5},
+	})
+	writeEMF("Test/EMF", nil, []emfMetric{{Name: "Calls", Unit: "Count", Value: 1}})
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per record, got %q", output.String())
+	}
+
+	var record struct {
+		Table        string  `json:"Table"`
+		Operation    string  `json:"Operation"`
+		Calls        int     `json:"Calls"`
+		AvgLatencyMs float64 `json:"AvgLatencyMs"`
+		AWS          struct {
+			Timestamp         int64 `json:"Timestamp"`
+			CloudWatchMetrics []struct {
+				Namespace  string              `json:"Namespace"`
+				Dimensions [][]string          `json:"Dimensions"`
+				Metrics    []map[string]string `json:"Metrics"`
+			} `json:"CloudWatchMetrics"`
+		} `json:"_aws"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %v", err)
+	}
+	if record.Table != "events" || record.Operation != "Query" || record.Calls != 3 || record.AvgLatencyMs != 12.5 {
+		t.Errorf("Expected the dimension and metric values on the record, got %s", lines[0])
+	}
+	if record.AWS.Timestamp == 0 || len(record.AWS.CloudWatchMetrics) != 1 {
+		t.Fatalf("Expected a timestamped metric directive, got %s", lines[0])
+	}
+	directive := record.AWS.CloudWatchMetrics[0]
+	if directive.Namespace != "Test/EMF" {
+		t.Errorf("Expected namespace Test/EMF, got %q", directive.Namespace)
+	}
+	if want := [][]string{{"Table"}, {"Table", "Operation"}}; !reflect.DeepEqual(directive.Dimensions, want) {
+		t.Errorf("Expected dimension sets %v, got %v", want, directive.Dimensions)
+	}
+	if want := []map[string]string{{"Name": "Calls", "Unit": "Count"}, {"Name": "AvgLatencyMs", "Unit": "Milliseconds"}}; !reflect.DeepEqual(directive.Metrics, want) {
+		t.Errorf("Expected metric definitions %v, got %v", want, directive.Metrics)
+	}
+
+	if !strings.Contains(lines[1], `"Dimensions":[[]]`) {
+		t.Errorf("Expected a record without dimensions to publish undimensioned metrics, got %s", lines[1])
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Errors the scrape executor wraps its own failures in, so they classify without string matching
+var (
+	ErrDomainDenied = errors.New("denied by policy")
+	ErrSourcePaused = errors.New("was paused")
+)
+
+// TaskFailure is a classified scraping error
+type TaskFailure struct {
+	Code      string `json:"error_code"`
+	Message   string `json:"error_message"`
+	Retryable bool   `json:"retryable"` // whether running the task again may succeed
+
+	// RetryAfterSeconds is how long FireCrawl asked to wait before trying again, when it said
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+// retryableErrorCodes lists the error codes that are worth retrying; everything else fails
+// the same way on every attempt until someone changes the source or its configuration
+var retryableErrorCodes = map[string]bool{
+	models.ErrorCodeTimeout:       true,
+	models.ErrorCodeRateLimited:   true,
+	models.ErrorCodeNetwork:       true,
+	models.ErrorCodeUpstream:      true,
+	models.ErrorCodeHeartbeatLost: true,
+	models.ErrorCodeUnknown:       true,
+}
+
+// IsRetryableErrorCode reports whether a task that failed with the error code may succeed if run again
+func IsRetryableErrorCode(code string) bool {
+	return retryableErrorCodes[code]
+}
+
+// statusCodePattern finds the HTTP status in FireCrawl SDK errors like "Status code 429."
+var statusCodePattern = regexp.MustCompile(`(?i)status(?: code)?:? (\d{3})`)
+
+// ClassifyError maps a scraping error to an error code and whether it is retryable.
+// FireCrawl API errors carry their own code; other failures, such as those the SDK reports as
+// formatted strings, are matched on their text.
+func ClassifyError(err error) TaskFailure {
+	if err == nil {
+		return TaskFailure{}
+	}
+	code := classifyErrorCode(err)
+	return TaskFailure{
+		Code:              code,
+		Message:           err.Error(),
+		Retryable:         IsRetryableErrorCode(code),
+		RetryAfterSeconds: int(FireCrawlRetryAfter(err) / time.Second),
+	}
+}
+
+// classifyErrorCode returns the error code for a non-nil error
+func classifyErrorCode(err error) string {
+	var apiErr *FireCrawlAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+
+	switch {
+	case errors.Is(err, ErrDomainDenied):
+		return models.ErrorCodeBlocked
+	case errors.Is(err, ErrSourcePaused):
+		return models.ErrorCodeSourcePaused
+	case errors.Is(err, context.DeadlineExceeded):
+		return models.ErrorCodeTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return models.ErrorCodeTimeout
+		}
+		return models.ErrorCodeNetwork
+	}
+
+	message := strings.ToLower(err.Error())
+	if match := statusCodePattern.FindStringSubmatch(message); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		if code := errorCodeForStatus(status); code != "" {
+			return code
+		}
+	}
+
+	switch {
+	case strings.Contains(message, "payment required"), strings.Contains(message, "insufficient credits"):
+		return models.ErrorCodeQuotaExhausted
+	case strings.Contains(message, "rate limit"), strings.Contains(message, "too many requests"):
+		return models.ErrorCodeRateLimited
+	case strings.Contains(message, "timed out"), strings.Contains(message, "timeout"):
+		return models.ErrorCodeTimeout
+	case strings.Contains(message, "internal server error"), strings.Contains(message, "bad gateway"), strings.Contains(message, "service unavailable"):
+		return models.ErrorCodeUpstream
+	case strings.Contains(message, "connection refused"), strings.Contains(message, "connection reset"), strings.Contains(message, "no such host"), strings.Contains(message, "eof"):
+		return models.ErrorCodeNetwork
+	case strings.Contains(message, "unauthorized"), strings.Contains(message, "forbidden"):
+		return models.ErrorCodeAuth
+	case strings.Contains(message, "not found"):
+		return models.ErrorCodeNotFound
+	case strings.Contains(message, "failed to parse"), strings.Contains(message, "unexpected response format"), strings.Contains(message, "is not a list"):
+		return models.ErrorCodeInvalidResponse
+	case strings.Contains(message, "cannot be empty"), strings.Contains(message, "is required"), strings.Contains(message, "schema"):
+		return models.ErrorCodeInvalidRequest
+	}
+	return models.ErrorCodeUnknown
+}
+
+// errorCodeForStatus maps an HTTP status from FireCrawl or the scraped site to an error code,
+// or "" for statuses that say nothing about the failure
+func errorCodeForStatus(status int) string {
+	switch {
+	case status == 402:
+		return models.ErrorCodeQuotaExhausted
+	case status == 408:
+		return models.ErrorCodeTimeout
+	case status == 429:
+		return models.ErrorCodeRateLimited
+	case status == 401, status == 403:
+		return models.ErrorCodeAuth
+	case status == 404, status == 410:
+		return models.ErrorCodeNotFound
+	case status >= 500:
+		return models.ErrorCodeUpstream
+	case status >= 400:
+		return models.ErrorCodeInvalidRequest
+	}
+	return ""
+}
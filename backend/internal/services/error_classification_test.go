@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      string
+		retryable bool
+	}{
+		{"RateLimited", errors.New("FireCrawl extraction failed: Unexpected error during scrape URL: Status code 429. Rate limit exceeded"), models.ErrorCodeRateLimited, true},
+		{"PaymentRequired", errors.New("FireCrawl extraction failed: Payment Required: Failed to scrape URL. Insufficient credits"), models.ErrorCodeQuotaExhausted, false},
+		{"FireCrawlTimeout", errors.New("Request Timeout: Failed to scrape URL as the request timed out."), models.ErrorCodeTimeout, true},
+		{"UpstreamError", errors.New("Internal Server Error: Failed to scrape URL. boom"), models.ErrorCodeUpstream, true},
+		{"SiteNotFound", errors.New("Unexpected error during scrape URL: Status code 404. Page not found"), models.ErrorCodeNotFound, false},
+		{"Forbidden", errors.New("Unexpected error during scrape URL: Status code 403. Forbidden"), models.ErrorCodeAuth, false},
+		{"DeadlineExceeded", fmt.Errorf("FireCrawl extraction failed: %w", context.DeadlineExceeded), models.ErrorCodeTimeout, true},
+		{"NetworkError", fmt.Errorf("request failed: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), models.ErrorCodeNetwork, true},
+		{"DomainDenied", fmt.Errorf("domain example.com %w: spam", ErrDomainDenied), models.ErrorCodeBlocked, false},
+		{"SourcePaused", fmt.Errorf("source Parks %w", ErrSourcePaused), models.ErrorCodeSourcePaused, false},
+		{"InvalidResponse", errors.New("failed to parse extract response: unexpected end of JSON input"), models.ErrorCodeInvalidResponse, false},
+		{"Unknown", errors.New("something odd happened"), models.ErrorCodeUnknown, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := ClassifyError(tt.err)
+			if failure.Code != tt.code || failure.Retryable != tt.retryable {
+				t.Errorf("Expected %s (retryable %v), got %s (retryable %v)", tt.code, tt.retryable, failure.Code, failure.Retryable)
+			}
+			if failure.Message != tt.err.Error() {
+				t.Errorf("Expected the error message to be kept, got %q", failure.Message)
+			}
+		})
+	}
+
+	if failure := ClassifyError(nil); failure.Code != "" || failure.Retryable {
+		t.Errorf("Expected no failure for a nil error, got %+v", failure)
+	}
+}
@@ -372,40 +372,45 @@ func (fc *FireCrawlClient) calculateExtractionQualityScore(activities []models.A
 	}
 
 	var totalScore float64
-	for _, activity := range activities {
-		score := 0.0
-		
-		// Title (required) - 30%
-		if activity.Title != "" {
-			score += 0.3
-		}
-		
-		// Location (required) - 25%
-		if activity.Location.Name != "" {
-			score += 0.25
-		}
-		
-		// Schedule/Date - 20%
-		if activity.Schedule.StartDate != "" || activity.Schedule.StartTime != "" {
-			score += 0.2
-		}
-		
-		// Pricing - 15%
-		if activity.Pricing.Type != "" || activity.Pricing.Description != "" {
-			score += 0.15
-		}
-		
-		// Description - 10%
-		if activity.Description != "" && len(activity.Description) > 10 {
-			score += 0.1
-		}
-		
-		totalScore += score
+	for i := range activities {
+		totalScore += activityFieldScore(&activities[i])
 	}
 	
 	return totalScore / float64(len(activities))
 }
 
+// activityFieldScore scores the core fields of one activity from 0.0 to 1.0
+func activityFieldScore(activity *models.Activity) float64 {
+	score := 0.0
+	
+	// Title (required) - 30%
+	if activity.Title != "" {
+		score += 0.3
+	}
+	
+	// Location (required) - 25%
+	if activity.Location.Name != "" {
+		score += 0.25
+	}
+	
+	// Schedule/Date - 20%
+	if activity.Schedule.StartDate != "" || activity.Schedule.StartTime != "" {
+		score += 0.2
+	}
+	
+	// Pricing - 15%
+	if activity.Pricing.Type != "" || activity.Pricing.Description != "" {
+		score += 0.15
+	}
+	
+	// Description - 10%
+	if activity.Description != "" && len(activity.Description) > 10 {
+		score += 0.1
+	}
+	
+	return score
+}
+
 // calculateConversionQualityMetrics calculates quality metrics for a converted activity
 func (scs *SchemaConversionService) calculateConversionQualityMetrics(activity *models.Activity, issues []string) QualityMetrics {
 	metrics := QualityMetrics{}
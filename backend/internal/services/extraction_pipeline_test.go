@@ -112,10 +112,7 @@ func testCompleteExtractionPipeline(t *testing.T, url string) ([]models.Activity
 	}
 	
 	// Get extraction diagnostics
-	var diagnostics *ExtractionDiagnostics
-	if lastExtractionDiagnostics != nil {
-		diagnostics = lastExtractionDiagnostics
-	}
+	diagnostics := extractResponse.Diagnostics
 	
 	// Step 3: Validate extracted activities
 	activities := extractResponse.Data.Activities
@@ -0,0 +1,64 @@
+package services
+
+import "seattle-family-activities-scraper/internal/models"
+
+// costBuckets lists the cost buckets in the order the frontend shows them
+var costBuckets = []models.FacetCount{
+	{Value: models.CostBucketFree, Label: "Free"},
+	{Value: models.CostBucketUnder10, Label: "Under $10"},
+	{Value: models.CostBucket10To25, Label: "$10–25"},
+	{Value: models.CostBucket25Plus, Label: "$25+"},
+}
+
+// facetCategories lists the categories in the order the frontend shows them
+var facetCategories = []string{
+	models.CategoryArtsCreativity,
+	models.CategoryActiveSports,
+	models.CategoryEducationalSTEM,
+	models.CategoryEntertainmentEvents,
+	models.CategoryCampsPrograms,
+	models.CategoryFreeCommunity,
+}
+
+// CostBucket returns the cost bucket of an activity's pricing, or "" when its cost is not known.
+// Free and donation activities are free; paid ones are bucketed by their cost whatever its unit.
+func CostBucket(pricing models.Pricing) string {
+	switch {
+	case pricing.Type == models.PricingTypeFree || pricing.Type == models.PricingTypeDonation:
+		return models.CostBucketFree
+	case pricing.Cost <= 0:
+		return ""
+	case pricing.Cost < 10:
+		return models.CostBucketUnder10
+	case pricing.Cost <= 25:
+		return models.CostBucket10To25
+	default:
+		return models.CostBucket25Plus
+	}
+}
+
+// ComputeActivityFacets counts activities by cost bucket and category. Activities whose cost is not
+// known are left out of the cost counts.
+func ComputeActivityFacets(activities []*models.PublicActivity) models.ActivityFacets {
+	facets := models.ActivityFacets{
+		Cost:     append([]models.FacetCount(nil), costBuckets...),
+		Category: make([]models.FacetCount, 0, len(facetCategories)),
+	}
+	for _, category := range facetCategories {
+		facets.Category = append(facets.Category, models.FacetCount{Value: category, Label: models.GetCategoryDisplayName(category)})
+	}
+	for _, activity := range activities {
+		countFacet(facets.Cost, CostBucket(activity.Pricing))
+		countFacet(facets.Category, activity.Category)
+	}
+	return facets
+}
+
+func countFacet(counts []models.FacetCount, value string) {
+	for i := range counts {
+		if counts[i].Value == value {
+			counts[i].Count++
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestCostBucket(t *testing.T) {
+	tests := []struct {
+		pricing models.Pricing
+		want    string
+	}{
+		{models.Pricing{Type: models.PricingTypeFree}, models.CostBucketFree},
+		{models.Pricing{Type: models.PricingTypeDonation, Cost: 5}, models.CostBucketFree},
+		{models.Pricing{Type: models.PricingTypePaid, Cost: 9.99}, models.CostBucketUnder10},
+		{models.Pricing{Type: models.PricingTypePaid, Cost: 10}, models.CostBucket10To25},
+		{models.Pricing{Type: models.PricingTypePaid, Cost: 25}, models.CostBucket10To25},
+		{models.Pricing{Type: models.PricingTypePaid, Cost: 180, Unit: "per-week"}, models.CostBucket25Plus},
+		{models.Pricing{Type: models.PricingTypePaid}, ""},
+		{models.Pricing{Type: models.PricingTypeVariable}, ""},
+	}
+	for _, tt := range tests {
+		if got := CostBucket(tt.pricing); got != tt.want {
+			t.Errorf("CostBucket(%+v) = %q, want %q", tt.pricing, got, tt.want)
+		}
+	}
+}
+
+func TestComputeActivityFacets(t *testing.T) {
+	activity := func(category string, pricing models.Pricing) *models.PublicActivity {
+		return &models.PublicActivity{Activity: models.Activity{Category: category, Pricing: pricing}}
+	}
+	facets := ComputeActivityFacets([]*models.PublicActivity{
+		activity(models.CategoryArtsCreativity, models.Pricing{Type: models.PricingTypeFree}),
+		activity(models.CategoryArtsCreativity, models.Pricing{Type: models.PricingTypePaid, Cost: 12}),
+		activity(models.CategoryActiveSports, models.Pricing{Type: models.PricingTypePaid, Cost: 40}),
+		activity(models.CategoryActiveSports, models.Pricing{Type: models.PricingTypePaid}),
+	})
+
+	wantCost := map[string]int{models.CostBucketFree: 1, models.CostBucketUnder10: 0, models.CostBucket10To25: 1, models.CostBucket25Plus: 1}
+	if len(facets.Cost) != len(wantCost) || facets.Cost[0].Value != models.CostBucketFree {
+		t.Fatalf("Expected the 4 cost buckets in order, got %+v", facets.Cost)
+	}
+	for _, facet := range facets.Cost {
+		if facet.Count != wantCost[facet.Value] {
+			t.Errorf("Cost %s: got %d, want %d", facet.Value, facet.Count, wantCost[facet.Value])
+		}
+	}
+
+	if len(facets.Category) != 6 {
+		t.Fatalf("Expected every category, got %+v", facets.Category)
+	}
+	for _, facet := range facets.Category {
+		want := 0
+		if facet.Value == models.CategoryArtsCreativity || facet.Value == models.CategoryActiveSports {
+			want = 2
+		}
+		if facet.Count != want || facet.Label == "" {
+			t.Errorf("Category %s: got %d (%q), want %d", facet.Value, facet.Count, facet.Label, want)
+		}
+	}
+
+	// Facets of separate responses do not share counts
+	if again := ComputeActivityFacets(nil); again.Cost[0].Count != 0 {
+		t.Errorf("Expected fresh counts, got %+v", again.Cost)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// FeatureFlagCache serves the feature flags stored in DynamoDB, refreshing them after a TTL.
+// Every flag is off until flags are stored; a failed load keeps the previous flags.
+type FeatureFlagCache struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	settings *models.FeatureFlagSettings
+	loadedAt time.Time
+}
+
+// NewFeatureFlagCache creates a feature flag cache backed by DynamoDB
+func NewFeatureFlagCache(dynamo *DynamoDBService, ttl time.Duration) *FeatureFlagCache {
+	return &FeatureFlagCache{
+		dynamo:   dynamo,
+		ttl:      ttl,
+		settings: models.NewDefaultFeatureFlagSettings(),
+	}
+}
+
+// FeatureFlags returns the current flags, loading them if the cached copy is stale
+func (c *FeatureFlagCache) FeatureFlags() *models.FeatureFlagSettings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.settings
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	settings, err := c.dynamo.GetFeatureFlagSettings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load feature flags, keeping previous flags: %v", err)
+	} else if settings != nil {
+		c.settings = settings
+	} else {
+		c.settings = models.NewDefaultFeatureFlagSettings()
+	}
+
+	c.loadedAt = time.Now()
+	return c.settings
+}
+
+// IsEnabled reports whether a flag is on for a source
+func (c *FeatureFlagCache) IsEnabled(name, sourceID string) bool {
+	return c.FeatureFlags().IsEnabled(name, sourceID)
+}
+
+// Invalidate drops the cached flags so the next lookup reloads them
+func (c *FeatureFlagCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"seattle-family-activities-scraper/internal/export"
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Object keys the RSS and Atom feeds of newly approved activities are published to
+const (
+	PublishedFeedsPrefix = "feeds/"
+	PublishedRSSFeedKey  = PublishedFeedsPrefix + "latest.rss"
+	PublishedAtomFeedKey = PublishedFeedsPrefix + "latest.atom"
+
+	// PublishedFeedsInvalidationPath covers both feeds at the CDN
+	PublishedFeedsInvalidationPath = "/feeds/*"
+)
+
+// DefaultFeedItems is how many of the most recently approved activities a feed lists
+const DefaultFeedItems = 50
+
+// DefaultFeedChannel describes the published feeds. The site link is set per deployment.
+var DefaultFeedChannel = export.Channel{
+	Title:       "Seattle Family Activities",
+	Description: "Family activities in Seattle, newest approvals first",
+	Domain:      "seattle-family-activities",
+	TTL:         15 * time.Minute,
+}
+
+// FeedPublisher writes the RSS and Atom feeds of newly approved activities to S3
+type FeedPublisher struct {
+	dynamo     *DynamoDBService
+	conversion *SchemaConversionService
+	store      *S3Store
+	channel    export.Channel
+}
+
+// NewFeedPublisher creates a publisher that reads approved events and writes the feeds to the store
+func NewFeedPublisher(dynamo *DynamoDBService, conversion *SchemaConversionService, store *S3Store, channel export.Channel) *FeedPublisher {
+	return &FeedPublisher{
+		dynamo:     dynamo,
+		conversion: conversion,
+		store:      store,
+		channel:    channel,
+	}
+}
+
+// Publish renders the most recently approved activities as RSS and Atom and uploads both feeds.
+// It returns the number of activities listed.
+func (p *FeedPublisher) Publish(ctx context.Context, now time.Time) (int, error) {
+	approvedEvents, err := p.dynamo.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get approved events: %w", err)
+	}
+	items := BuildFeedItems(VisibleAdminEvents(approvedEvents, now), p.conversion, DefaultFeedItems)
+
+	rss, err := export.RSS(p.channel, items, now)
+	if err != nil {
+		return 0, err
+	}
+	atom, err := export.Atom(p.channel, items, now)
+	if err != nil {
+		return 0, err
+	}
+	run := NewPublishRun(p.store, PublisherFeeds, PublishedFeedsPrefix, now)
+	err = run.Put(ctx, PublishedRSSFeedKey, rss, "application/rss+xml; charset=utf-8")
+	if err != nil {
+		err = fmt.Errorf("failed to publish the RSS feed: %w", err)
+	} else if err = run.Put(ctx, PublishedAtomFeedKey, atom, "application/atom+xml; charset=utf-8"); err != nil {
+		err = fmt.Errorf("failed to publish the Atom feed: %w", err)
+	}
+	if _, err := run.Finish(ctx, err); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// BuildFeedItems converts the most recently approved events into feed items, newest approval
+// first. Events that cannot be converted, or whose activity would not render, are skipped.
+func BuildFeedItems(approvedEvents []models.AdminEvent, conversion *SchemaConversionService, limit int) []export.FeedItem {
+	// Only the latest events are converted, as a feed lists a small part of the catalog
+	events := append([]models.AdminEvent(nil), approvedEvents...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].ApprovalTime().After(events[j].ApprovalTime())
+	})
+
+	items := []export.FeedItem{}
+	for i := range events {
+		if limit > 0 && len(items) == limit {
+			break
+		}
+		activity, err := publishableActivity(&events[i], conversion)
+		if err != nil {
+			log.Printf("Warning: Leaving event %s out of the feeds: %v", events[i].EventID, err)
+			continue
+		}
+		items = append(items, export.FeedItem{Activity: activity, ApprovedAt: events[i].ApprovalTime()})
+	}
+	return items
+}
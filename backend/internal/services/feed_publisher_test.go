@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildFeedItems(t *testing.T) {
+	newEvent := func(id, title string, reviewedAt time.Time) models.AdminEvent {
+		return models.AdminEvent{
+			EventID:    id,
+			SourceURL:  "https://www.seattle.gov/parks/events",
+			SchemaType: "events",
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{"title": title, "location": "Green Lake Park", "date": "2025-07-12", "price": "Free"},
+				},
+			},
+			ExtractedAt: reviewedAt.Add(-24 * time.Hour),
+			ReviewedAt:  &reviewedAt,
+		}
+	}
+	approvedAt := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	unpublishable := newEvent("evt-4", "Broken", approvedAt.Add(4*time.Hour))
+	unpublishable.RawExtractedData = map[string]interface{}{}
+
+	approvedEvents := []models.AdminEvent{
+		newEvent("evt-1", "Toddler Story Time", approvedAt),
+		newEvent("evt-2", "Summer Splash Day", approvedAt.Add(2*time.Hour)),
+		newEvent("evt-3", "Kids Art Class", approvedAt.Add(time.Hour)),
+		unpublishable,
+	}
+
+	items := BuildFeedItems(approvedEvents, NewSchemaConversionService(), 2)
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+	if items[0].Activity.Title != "Summer Splash Day" || items[1].Activity.Title != "Kids Art Class" {
+		t.Errorf("Expected the latest approvals first, got %q and %q", items[0].Activity.Title, items[1].Activity.Title)
+	}
+	if !items[0].ApprovedAt.Equal(approvedAt.Add(2 * time.Hour)) {
+		t.Errorf("Expected the review time as the approval time, got %v", items[0].ApprovedAt)
+	}
+}
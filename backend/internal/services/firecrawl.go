@@ -1,16 +1,22 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mendableai/firecrawl-go"
+	"seattle-family-activities-scraper/internal/activityid"
 	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/urlutil"
 )
 
 // ExtractionDiagnostics captures detailed information about the extraction process
@@ -52,6 +58,14 @@ type ValidationIssue struct {
 type FireCrawlClient struct {
 	client  *firecrawl.FirecrawlApp
 	timeout time.Duration
+
+	stats   *FireCrawlStatsCollector
+
+	validationRules ValidationRuleProvider
+	limiter         ConcurrencyLimiter
+
+	mu              sync.Mutex
+	lastDiagnostics *ExtractionDiagnostics
 }
 
 // FireCrawlExtractRequest represents a request to extract structured data
@@ -66,6 +80,25 @@ type FireCrawlExtractResponse struct {
 	Data      ActivityExtractionData `json:"data"`
 	Metadata  ExtractMetadata        `json:"metadata"`
 	CreditsUsed int                  `json:"credits_used"`
+	Diagnostics *ExtractionDiagnostics `json:"-"` // Diagnostics for this extraction, not part of the API payload
+}
+
+// ScrapedPage is the markdown of a page fetched by FireCrawl, before activities are extracted from it
+type ScrapedPage struct {
+	URL         string
+	Markdown    string
+	ContentHash string // SHA-256 of the raw markdown
+	CreditsUsed int
+
+	doc         *firecrawl.FirecrawlDocument
+	startTime   time.Time
+	diagnostics *ExtractionDiagnostics
+}
+
+// ContentHash returns the hex SHA-256 of a page's raw markdown, which changes whenever the page does
+func ContentHash(markdown string) string {
+	hash := sha256.Sum256([]byte(markdown))
+	return hex.EncodeToString(hash[:])
 }
 
 // ActivityExtractionData contains the extracted activities
@@ -91,10 +124,13 @@ func NewFireCrawlClient() (*FireCrawlClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize FireCrawl client: %w", err)
 	}
+	// Error responses come back as FireCrawlAPIErrors, so they classify on status and Retry-After
+	app.Client.Transport = &fireCrawlErrorTransport{base: app.Client.Transport}
 
 	return &FireCrawlClient{
 		client:  app,
 		timeout: 60 * time.Second,
+		stats:   NewFireCrawlStatsCollector(),
 	}, nil
 }
 
@@ -110,6 +146,16 @@ func NewFireCrawlClientWithTimeout(timeout time.Duration) (*FireCrawlClient, err
 
 // ExtractActivities extracts structured activities from a webpage URL
 func (fc *FireCrawlClient) ExtractActivities(url string) (*FireCrawlExtractResponse, error) {
+	page, err := fc.ScrapePage(url)
+	if err != nil {
+		return nil, err
+	}
+	return fc.ExtractActivitiesFromPage(page)
+}
+
+// ScrapePage fetches a page's markdown without extracting activities from it, so a page that has
+// not changed since it was last extracted can be skipped
+func (fc *FireCrawlClient) ScrapePage(url string) (*ScrapedPage, error) {
 	startTime := time.Now()
 	
 	// Initialize diagnostics
@@ -139,7 +185,7 @@ func (fc *FireCrawlClient) ExtractActivities(url string) (*FireCrawlExtractRespo
 
 	// Make the extract request using ScrapeURL with extraction parameters
 	// Note: Using nil for now - will need to create proper ScrapeParams struct
-	response, err := fc.client.ScrapeURL(url, nil)
+	response, err := fc.scrapeURL(url)
 	if err != nil {
 		diagnostics.EndTime = time.Now()
 		diagnostics.ProcessingTime = time.Since(startTime)
@@ -150,18 +196,35 @@ func (fc *FireCrawlClient) ExtractActivities(url string) (*FireCrawlExtractRespo
 		// Record failed extraction
 		metrics := GetExtractionMetrics()
 		metrics.RecordExtractionAttempt(url, false, 0, time.Since(startTime), 0.0)
+		fc.trackRequest(url, false, time.Since(startTime), 0, 0)
 		
 		return nil, fmt.Errorf("FireCrawl extract failed: %w", err)
 	}
 
+	return &ScrapedPage{
+		URL:         url,
+		Markdown:    response.Markdown,
+		ContentHash: ContentHash(response.Markdown),
+		CreditsUsed: fc.extractCreditsFromDoc(response),
+		doc:         response,
+		startTime:   startTime,
+		diagnostics: diagnostics,
+	}, nil
+}
+
+// ExtractActivitiesFromPage extracts structured activities from a page fetched by ScrapePage
+func (fc *FireCrawlClient) ExtractActivitiesFromPage(page *ScrapedPage) (*FireCrawlExtractResponse, error) {
+	url, startTime, diagnostics := page.URL, page.startTime, page.diagnostics
+
 	// Parse the response with diagnostics
-	extractResponse, err := fc.parseExtractResponseWithDiagnostics(response, url, startTime, diagnostics)
+	extractResponse, err := fc.parseExtractResponseWithDiagnostics(page.doc, url, startTime, diagnostics)
 	if err != nil {
 		diagnostics.EndTime = time.Now()
 		diagnostics.ProcessingTime = time.Since(startTime)
 		diagnostics.Success = false
 		diagnostics.ErrorMessage = fmt.Sprintf("Failed to parse extract response: %v", err)
 		fc.logDiagnostics(diagnostics)
+		fc.trackRequest(url, false, time.Since(startTime), 0, 0)
 		return nil, fmt.Errorf("failed to parse extract response: %w", err)
 	}
 
@@ -171,14 +234,16 @@ func (fc *FireCrawlClient) ExtractActivities(url string) (*FireCrawlExtractRespo
 	diagnostics.Success = true
 	diagnostics.CreditsUsed = extractResponse.CreditsUsed
 
-	// Log final diagnostics and store for debugging
+	// Log final diagnostics and attach them to the response
 	fc.logDiagnostics(diagnostics)
-	lastExtractionDiagnostics = diagnostics
+	fc.setLastExtractionDiagnostics(diagnostics)
+	extractResponse.Diagnostics = diagnostics
 
 	// Record metrics
 	qualityScore := fc.calculateExtractionQualityScore(extractResponse.Data.Activities, diagnostics)
 	metrics := GetExtractionMetrics()
 	metrics.RecordExtractionAttempt(url, true, len(extractResponse.Data.Activities), time.Since(startTime), qualityScore)
+	fc.trackRequest(url, true, time.Since(startTime), extractResponse.CreditsUsed, len(extractResponse.Data.Activities))
 
 	log.Printf("[EXTRACTION] Successfully extracted %d activities from %s in %v (Credits: %d)",
 		len(extractResponse.Data.Activities), url, time.Since(startTime), extractResponse.CreditsUsed)
@@ -237,6 +302,7 @@ func (fc *FireCrawlClient) parseExtractResponseWithDiagnostics(response interfac
 
 	// Use domain-based parsing strategy selection with fallback
 	activities, extractionAttempt = fc.extractActivitiesWithSourceStrategy(doc.Markdown, url, diagnostics)
+	assignActivityIDs(activities)
 
 	diagnostics.ExtractionAttempts = append(diagnostics.ExtractionAttempts, extractionAttempt)
 
@@ -295,7 +361,7 @@ func (fc *FireCrawlClient) convertToActivities(activitiesRaw interface{}, source
 			UpdatedAt: time.Now(),
 			Source: models.Source{
 				URL:         sourceURL,
-				Domain:      extractDomain(sourceURL),
+				Domain:      urlutil.Domain(sourceURL),
 				ScrapedAt:   time.Now(),
 				LastChecked: time.Now(),
 				Reliability: "medium",
@@ -392,6 +458,11 @@ func (fc *FireCrawlClient) convertToActivities(activitiesRaw interface{}, source
 			}
 		}
 
+		// Extract parking and arrival notes
+		if arrival := fc.extractStringField(activityMap, "arrival_info"); arrival != "" {
+			activity.ArrivalInfo = arrivalInfoFromSentences(splitSentences(arrival), nil)
+		}
+
 		// Only add activity if it has required fields
 		if activity.Title != "" && activity.Location.Name != "" {
 			activities = append(activities, activity)
@@ -506,6 +577,10 @@ func getActivityExtractionSchema() map[string]interface{} {
 							"type":        "string",
 							"description": "URL for registration or more information",
 						},
+						"arrival_info": map[string]interface{}{
+							"type":        "string",
+							"description": "Parking and arrival notes, e.g. 'street parking only', 'enter via the north gate'",
+						},
 					},
 					"required": []string{"title", "location"},
 				},
@@ -550,82 +625,13 @@ func (fc *FireCrawlClient) IsFireCrawlAvailable() bool {
 	testURL := "https://httpbin.org/get"
 
 	// Make a simple scrape request (not extract) to test availability
-	_, err := fc.client.ScrapeURL(testURL, nil)
+	_, err := fc.scrapeURL(testURL)
 
 	return err == nil
 }
 
-// GetStats returns basic usage statistics
-type FireCrawlStats struct {
-	TotalRequests      int           `json:"total_requests"`
-	SuccessfulReqs     int           `json:"successful_requests"`
-	FailedReqs         int           `json:"failed_requests"`
-	AvgResponseTime    time.Duration `json:"avg_response_time"`
-	TotalCreditsUsed   int           `json:"total_credits_used"`
-	TotalActivitiesExt int           `json:"total_activities_extracted"`
-}
-
-// Simple in-memory stats tracking for FireCrawl
-type fireCrawlStatsTracker struct {
-	requests        int
-	successful      int
-	failed          int
-	totalTime       time.Duration
-	totalCredits    int
-	totalActivities int
-}
-
-// Global stats tracker for FireCrawl
-var globalFireCrawlStats = &fireCrawlStatsTracker{}
-
-// GetStats returns current statistics
-func (fc *FireCrawlClient) GetStats() FireCrawlStats {
-	avgTime := time.Duration(0)
-	if globalFireCrawlStats.requests > 0 {
-		avgTime = globalFireCrawlStats.totalTime / time.Duration(globalFireCrawlStats.requests)
-	}
-
-	return FireCrawlStats{
-		TotalRequests:      globalFireCrawlStats.requests,
-		SuccessfulReqs:     globalFireCrawlStats.successful,
-		FailedReqs:         globalFireCrawlStats.failed,
-		AvgResponseTime:    avgTime,
-		TotalCreditsUsed:   globalFireCrawlStats.totalCredits,
-		TotalActivitiesExt: globalFireCrawlStats.totalActivities,
-	}
-}
-
-// trackRequest updates statistics
-func (fc *FireCrawlClient) trackRequest(success bool, duration time.Duration, creditsUsed, activitiesExtracted int) {
-	globalFireCrawlStats.requests++
-	globalFireCrawlStats.totalTime += duration
-	globalFireCrawlStats.totalCredits += creditsUsed
-	globalFireCrawlStats.totalActivities += activitiesExtracted
-
-	if success {
-		globalFireCrawlStats.successful++
-	} else {
-		globalFireCrawlStats.failed++
-	}
-}
-
 // Helper functions for data conversion
 
-// extractDomain extracts domain from URL
-func extractDomain(url string) string {
-	if strings.HasPrefix(url, "http://") {
-		url = url[7:]
-	} else if strings.HasPrefix(url, "https://") {
-		url = url[8:]
-	}
-
-	if idx := strings.Index(url, "/"); idx != -1 {
-		url = url[:idx]
-	}
-
-	return url
-}
-
 // parseCoordinates parses "lat,lng" string into float64 values
 func parseCoordinates(coordsStr string) (lat, lng float64, err error) {
 	parts := strings.Split(coordsStr, ",")
@@ -768,7 +774,7 @@ func (fc *FireCrawlClient) parseParentMapActivitiesWithDiagnostics(markdown, url
 			continue
 		}
 
-		activity := fc.convertEventToActivity(event, url, fmt.Sprintf("parentmap-%d", i))
+		activity := fc.convertEventToActivity(event, url)
 		if activity != nil {
 			// Validate the converted activity
 			activityValidation := fc.validateActivityData(*activity)
@@ -1154,7 +1160,7 @@ func (fc *FireCrawlClient) parseRemlingerActivitiesWithDiagnostics(markdown, url
 			continue
 		}
 
-		activity := fc.convertEventToActivity(event, url, fmt.Sprintf("remlinger-%d", i))
+		activity := fc.convertEventToActivity(event, url)
 		if activity != nil {
 			// Validate the converted activity
 			activityValidation := fc.validateActivityData(*activity)
@@ -1351,7 +1357,6 @@ func (fc *FireCrawlClient) parseRemlingerFallback(markdown, url string, attempt
 	if len(keywordMatches) > 0 {
 		// Create a general Remlinger Farms activity
 		activity := models.Activity{
-			ID:          fmt.Sprintf("remlinger-fallback-%d", time.Now().Unix()),
 			Title:       "Remlinger Farms Activities",
 			Description: "Visit Remlinger Farms for family fun including pumpkin picking, farm activities, and more.",
 			Type:        models.TypeEvent,
@@ -1385,7 +1390,7 @@ func (fc *FireCrawlClient) parseRemlingerFallback(markdown, url string, attempt
 			UpdatedAt: time.Now(),
 			Source: models.Source{
 				URL:         url,
-				Domain:      extractDomain(url),
+				Domain:      urlutil.Domain(url),
 				ScrapedAt:   time.Now(),
 				LastChecked: time.Now(),
 				Reliability: "medium",
@@ -1509,7 +1514,7 @@ func (fc *FireCrawlClient) determineParsingStrategy(url string) string {
 	}
 	
 	// Extract domain from URL
-	domain := extractDomain(url)
+	domain := urlutil.Domain(url)
 	
 	// Check for exact domain matches
 	if strategy, exists := domainStrategies[domain]; exists {
@@ -1539,7 +1544,6 @@ func (fc *FireCrawlClient) extractWithContentHeuristics(markdown, url string) []
 	// If we find strong event indicators, create a generic activity
 	if eventIndicators.HasEventKeywords && (eventIndicators.HasDatePatterns || eventIndicators.HasTimePatterns) {
 		activity := models.Activity{
-			ID:          fmt.Sprintf("heuristic-%d", time.Now().Unix()),
 			Title:       fc.generateHeuristicTitle(markdown, url),
 			Description: fc.generateHeuristicDescription(markdown, eventIndicators),
 			Type:        models.TypeEvent,
@@ -1572,7 +1576,7 @@ func (fc *FireCrawlClient) extractWithContentHeuristics(markdown, url string) []
 			UpdatedAt: time.Now(),
 			Source: models.Source{
 				URL:         url,
-				Domain:      extractDomain(url),
+				Domain:      urlutil.Domain(url),
 				ScrapedAt:   time.Now(),
 				LastChecked: time.Now(),
 				Reliability: "low", // Heuristic extraction has lower reliability
@@ -1663,7 +1667,7 @@ func (fc *FireCrawlClient) analyzeContentForEvents(markdown string) EventIndicat
 
 // generateHeuristicTitle creates a title based on content analysis
 func (fc *FireCrawlClient) generateHeuristicTitle(markdown, url string) string {
-	domain := extractDomain(url)
+	domain := urlutil.Domain(url)
 	
 	// Try to extract a title from headers
 	lines := strings.Split(markdown, "\n")
@@ -1713,7 +1717,7 @@ func (fc *FireCrawlClient) determineHeuristicCategory(indicators EventIndicators
 
 // extractHeuristicLocation extracts location information using heuristics
 func (fc *FireCrawlClient) extractHeuristicLocation(markdown, url string) string {
-	domain := extractDomain(url)
+	domain := urlutil.Domain(url)
 	
 	// Look for location patterns in the content
 	lines := strings.Split(markdown, "\n")
@@ -2010,7 +2014,7 @@ func (fc *FireCrawlClient) extractGenericActivitiesWithDiagnostics(markdown, url
 			continue
 		}
 
-		activity := fc.convertEventToActivity(event, url, fmt.Sprintf("generic-%d", i))
+		activity := fc.convertEventToActivity(event, url)
 		if activity != nil {
 			// Validate the converted activity
 			activityValidation := fc.validateActivityData(*activity)
@@ -2072,8 +2076,7 @@ func (fc *FireCrawlClient) extractGenericFallback(markdown, url string, attempt
 		
 		for i := 0; i < activityCount; i++ {
 			activity := models.Activity{
-				ID:          fmt.Sprintf("generic-fallback-%d-%d", time.Now().Unix(), i),
-				Title:       fmt.Sprintf("Event from %s", extractDomain(url)),
+				Title:       fmt.Sprintf("Event from %s", urlutil.Domain(url)),
 				Description: fc.generateFallbackDescription(markdown, keywordMatches),
 				Type:        models.TypeEvent,
 				Category:    fc.determineFallbackCategory(keywordMatches),
@@ -2084,7 +2087,7 @@ func (fc *FireCrawlClient) extractGenericFallback(markdown, url string, attempt
 					Timezone:  "America/Los_Angeles",
 				},
 				Location: models.Location{
-					Name:   fmt.Sprintf("Venue from %s", extractDomain(url)),
+					Name:   fmt.Sprintf("Venue from %s", urlutil.Domain(url)),
 					City:   "Seattle",
 					State:  "WA",
 					Region: "Seattle Metro",
@@ -2105,7 +2108,7 @@ func (fc *FireCrawlClient) extractGenericFallback(markdown, url string, attempt
 				UpdatedAt: time.Now(),
 				Source: models.Source{
 					URL:         url,
-					Domain:      extractDomain(url),
+					Domain:      urlutil.Domain(url),
 					ScrapedAt:   time.Now(),
 					LastChecked: time.Now(),
 					Reliability: "low", // Lower reliability for fallback
@@ -2169,81 +2172,10 @@ type ValidationResult struct {
 
 // validateEventData validates extracted event data before conversion
 func (fc *FireCrawlClient) validateEventData(event EventData) ValidationResult {
-	result := ValidationResult{
-		IsValid:         true,
-		ConfidenceScore: 100.0,
-		Issues:          []string{},
-		Warnings:        []string{},
-	}
-
 	log.Printf("[VALIDATION] Validating event data for: %s", event.Title)
 
-	// Check required fields
-	if event.Title == "" {
-		result.Issues = append(result.Issues, "Title is required")
-		result.IsValid = false
-		result.ConfidenceScore -= 50
-	} else if len(event.Title) < 3 {
-		result.Warnings = append(result.Warnings, "Title is very short")
-		result.ConfidenceScore -= 10
-	} else if len(event.Title) > 100 {
-		result.Warnings = append(result.Warnings, "Title is very long")
-		result.ConfidenceScore -= 5
-	}
-
-	// Check data quality
-	if event.Description == "" {
-		result.Warnings = append(result.Warnings, "No description provided")
-		result.ConfidenceScore -= 15
-	} else if len(event.Description) < 10 {
-		result.Warnings = append(result.Warnings, "Description is very short")
-		result.ConfidenceScore -= 10
-	}
-
-	if event.Date == "" {
-		result.Warnings = append(result.Warnings, "No date information")
-		result.ConfidenceScore -= 20
-	} else {
-		// Validate date format
-		if !fc.isValidDateFormat(event.Date) {
-			result.Warnings = append(result.Warnings, "Date format may be invalid")
-			result.ConfidenceScore -= 10
-		}
-	}
-
-	if event.Time == "" {
-		result.Warnings = append(result.Warnings, "No time information")
-		result.ConfidenceScore -= 15
-	} else {
-		// Validate time format
-		if !fc.isValidTimeFormat(event.Time) {
-			result.Warnings = append(result.Warnings, "Time format may be invalid")
-			result.ConfidenceScore -= 5
-		}
-	}
-
-	if event.Location == "" {
-		result.Warnings = append(result.Warnings, "No location information")
-		result.ConfidenceScore -= 25
-	} else if len(event.Location) < 3 {
-		result.Warnings = append(result.Warnings, "Location information is very brief")
-		result.ConfidenceScore -= 10
-	}
-
-	if event.Price == "" {
-		result.Warnings = append(result.Warnings, "No pricing information")
-		result.ConfidenceScore -= 10
-	}
-
-	if len(event.AgeGroups) == 0 {
-		result.Warnings = append(result.Warnings, "No age group information")
-		result.ConfidenceScore -= 10
-	}
-
-	// Ensure confidence score doesn't go below 0
-	if result.ConfidenceScore < 0 {
-		result.ConfidenceScore = 0
-	}
+	// Markdown parsing always produces events, so the events rule set applies
+	result := ApplyValidationRules(fc.validationRuleSet("events").ParsingRules, eventDataFields(event))
 
 	log.Printf("[VALIDATION] Event validation completed: Valid=%t, Confidence=%.1f, Issues=%d, Warnings=%d", 
 		result.IsValid, result.ConfidenceScore, len(result.Issues), len(result.Warnings))
@@ -2253,84 +2185,61 @@ func (fc *FireCrawlClient) validateEventData(event EventData) ValidationResult {
 
 // validateActivityData validates converted Activity data
 func (fc *FireCrawlClient) validateActivityData(activity models.Activity) ValidationResult {
-	result := ValidationResult{
-		IsValid:         true,
-		ConfidenceScore: 100.0,
-		Issues:          []string{},
-		Warnings:        []string{},
-	}
-
 	log.Printf("[VALIDATION] Validating activity data for: %s", activity.Title)
 
-	// Check required fields
-	if activity.Title == "" {
-		result.Issues = append(result.Issues, "Activity title is required")
-		result.IsValid = false
-		result.ConfidenceScore -= 50
-	}
-
-	if activity.Location.Name == "" {
-		result.Issues = append(result.Issues, "Activity location name is required")
-		result.IsValid = false
-		result.ConfidenceScore -= 30
-	}
+	result := ApplyValidationRules(fc.validationRuleSet("events").ConversionRules, activityFields(activity))
 
-	// Check data types and consistency
-	if activity.Type == "" {
-		result.Warnings = append(result.Warnings, "Activity type not set")
-		result.ConfidenceScore -= 10
-	}
+	log.Printf("[VALIDATION] Activity validation completed: Valid=%t, Confidence=%.1f, Issues=%d, Warnings=%d", 
+		result.IsValid, result.ConfidenceScore, len(result.Issues), len(result.Warnings))
 
-	if activity.Category == "" {
-		result.Warnings = append(result.Warnings, "Activity category not set")
-		result.ConfidenceScore -= 10
-	}
+	return result
+}
 
-	if activity.Schedule.StartDate == "" {
-		result.Warnings = append(result.Warnings, "No start date specified")
-		result.ConfidenceScore -= 20
+// assignActivityIDs gives the activities parsed from a page their activity IDs
+func assignActivityIDs(activities []models.Activity) {
+	var assigner activityid.Assigner
+	for i := range activities {
+		activity := &activities[i]
+		activity.ID = assigner.Next(activity.Source.URL, activity.Title, activity.Schedule.StartDate, activity.Location.Name)
 	}
+}
 
-	if activity.Schedule.StartTime == "" {
-		result.Warnings = append(result.Warnings, "No start time specified")
-		result.ConfidenceScore -= 15
-	}
+// SetConcurrencyLimiter caps concurrent FireCrawl calls across Lambdas (unlimited when unset)
+func (fc *FireCrawlClient) SetConcurrencyLimiter(limiter ConcurrencyLimiter) {
+	fc.limiter = limiter
+}
 
-	if len(activity.AgeGroups) == 0 {
-		result.Warnings = append(result.Warnings, "No age groups specified")
-		result.ConfidenceScore -= 10
-	}
+// scrapeURL calls FireCrawl's scrape endpoint while holding a provider concurrency slot
+func (fc *FireCrawlClient) scrapeURL(url string) (*firecrawl.FirecrawlDocument, error) {
+	if fc.limiter != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), fc.timeout)
+		defer cancel()
 
-	// Check pricing consistency
-	if activity.Pricing.Type == "" {
-		result.Warnings = append(result.Warnings, "Pricing type not specified")
-		result.ConfidenceScore -= 5
+		release, err := fc.limiter.Acquire(ctx, models.ProviderFirecrawl)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
 	}
 
-	// Check source information
-	if activity.Source.URL == "" {
-		result.Warnings = append(result.Warnings, "No source URL")
-		result.ConfidenceScore -= 5
-	}
+	return fc.client.ScrapeURL(url, nil)
+}
 
-	if activity.Source.Domain == "" {
-		result.Warnings = append(result.Warnings, "No source domain")
-		result.ConfidenceScore -= 5
-	}
+// SetValidationRules sets where validation rule sets are loaded from (built-in defaults when unset)
+func (fc *FireCrawlClient) SetValidationRules(provider ValidationRuleProvider) {
+	fc.validationRules = provider
+}
 
-	// Ensure confidence score doesn't go below 0
-	if result.ConfidenceScore < 0 {
-		result.ConfidenceScore = 0
+// validationRuleSet returns the rule set for a schema type from the configured provider
+func (fc *FireCrawlClient) validationRuleSet(schemaType string) *models.ValidationRuleSet {
+	if fc.validationRules == nil {
+		return DefaultValidationRuleSet(schemaType)
 	}
-
-	log.Printf("[VALIDATION] Activity validation completed: Valid=%t, Confidence=%.1f, Issues=%d, Warnings=%d", 
-		result.IsValid, result.ConfidenceScore, len(result.Issues), len(result.Warnings))
-
-	return result
+	return fc.validationRules.RuleSet(schemaType)
 }
 
 // isValidDateFormat checks if a date string appears to be in a valid format
-func (fc *FireCrawlClient) isValidDateFormat(dateStr string) bool {
+func isValidDateFormat(dateStr string) bool {
 	// Basic date format validation
 	datePatterns := []string{
 		`^\d{1,2}/\d{1,2}/\d{2,4}$`,                                                                                    // MM/DD/YYYY
@@ -2359,7 +2268,7 @@ func (fc *FireCrawlClient) isValidDateFormat(dateStr string) bool {
 }
 
 // isValidTimeFormat checks if a time string appears to be in a valid format
-func (fc *FireCrawlClient) isValidTimeFormat(timeStr string) bool {
+func isValidTimeFormat(timeStr string) bool {
 	// Basic time format validation
 	timePatterns := []string{
 		`^(1[0-2]|0?[1-9]):[0-5]\d\s*(AM|PM|am|pm)$`,     // 12-hour format with valid minutes
@@ -2503,12 +2412,19 @@ func (fc *FireCrawlClient) logDiagnostics(diagnostics *ExtractionDiagnostics) {
 	log.Printf("[DIAGNOSTICS] ============================================")
 }
 
-// GetExtractionDiagnostics returns the last extraction diagnostics (for testing/debugging)
-var lastExtractionDiagnostics *ExtractionDiagnostics
+// setLastExtractionDiagnostics records the diagnostics from the most recent extraction on this client
+func (fc *FireCrawlClient) setLastExtractionDiagnostics(diagnostics *ExtractionDiagnostics) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.lastDiagnostics = diagnostics
+}
 
-// GetLastExtractionDiagnostics returns the diagnostics from the last extraction
+// GetLastExtractionDiagnostics returns the diagnostics from the last extraction (for testing/debugging).
+// Callers that need diagnostics for a specific request should use the Diagnostics field on the response instead.
 func (fc *FireCrawlClient) GetLastExtractionDiagnostics() *ExtractionDiagnostics {
-	return lastExtractionDiagnostics
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.lastDiagnostics
 }
 
 // extractDateFromLine extracts date information from a text line
@@ -3351,13 +3267,12 @@ func (fc *FireCrawlClient) capitalizeLocation(location string) string {
 }
 
 // convertEventToActivity converts parsed event data to Activity model
-func (fc *FireCrawlClient) convertEventToActivity(event EventData, sourceURL, idSuffix string) *models.Activity {
+func (fc *FireCrawlClient) convertEventToActivity(event EventData, sourceURL string) *models.Activity {
 	if event.Title == "" {
 		return nil
 	}
 	
 	activity := &models.Activity{
-		ID:          fmt.Sprintf("parentmap-%s-%d", idSuffix, time.Now().Unix()),
 		Title:       event.Title,
 		Description: event.Description,
 		Type:        models.TypeEvent,
@@ -3438,7 +3353,7 @@ func (fc *FireCrawlClient) convertEventToActivity(event EventData, sourceURL, id
 	// Set source information
 	activity.Source = models.Source{
 		URL:         sourceURL,
-		Domain:      extractDomain(sourceURL),
+		Domain:      urlutil.Domain(sourceURL),
 		ScrapedAt:   time.Now(),
 		LastChecked: time.Now(),
 		Reliability: "medium",
@@ -3492,7 +3407,6 @@ func (fc *FireCrawlClient) parseParentMapFallback(markdown, url string, attempt
 		maxActivities := min(activityCount, 3) // Limit to 3 for fallback
 		for i := 0; i < maxActivities; i++ {
 			activity := models.Activity{
-				ID:          fmt.Sprintf("parentmap-fallback-%d-%d", time.Now().Unix(), i),
 				Title:       fmt.Sprintf("ParentMap Event %d", i+1),
 				Description: "Event extracted from ParentMap calendar (fallback method)",
 				Type:        models.TypeEvent,
@@ -3525,7 +3439,7 @@ func (fc *FireCrawlClient) parseParentMapFallback(markdown, url string, attempt
 				UpdatedAt: time.Now(),
 				Source: models.Source{
 					URL:         url,
-					Domain:      extractDomain(url),
+					Domain:      urlutil.Domain(url),
 					ScrapedAt:   time.Now(),
 					LastChecked: time.Now(),
 					Reliability: "low", // Lower reliability for fallback method
@@ -3554,8 +3468,9 @@ func min(a, b int) int {
 // AdminExtractRequest represents a request for admin-driven extraction
 type AdminExtractRequest struct {
 	URL          string                 `json:"url"`
-	SchemaType   string                 `json:"schema_type"`   // "events"|"activities"|"venues"|"custom"
+	SchemaType   string                 `json:"schema_type"`   // "events"|"activities"|"camps"|"classes"|"venues"|"custom"
 	CustomSchema map[string]interface{} `json:"custom_schema"` // Only used if schema_type = "custom"
+	OnProgress   func(stage string)     `json:"-"`             // Optional callback invoked as extraction moves between stages
 }
 
 // AdminExtractResponse represents the response from admin extraction
@@ -3566,6 +3481,7 @@ type AdminExtractResponse struct {
 	Metadata     AdminExtractMetadata   `json:"metadata"`
 	CreditsUsed  int                    `json:"credits_used"`
 	EventsCount  int                    `json:"events_count"`  // Number of events/activities extracted
+	Diagnostics  *ExtractionDiagnostics `json:"-"`             // Diagnostics for this extraction, not part of the API payload
 }
 
 // AdminExtractMetadata contains metadata about the admin extraction
@@ -3581,6 +3497,15 @@ type AdminExtractMetadata struct {
 func (fc *FireCrawlClient) ExtractWithSchema(request AdminExtractRequest) (*AdminExtractResponse, error) {
 	startTime := time.Now()
 
+	// Initialize diagnostics
+	diagnostics := &ExtractionDiagnostics{
+		URL:                request.URL,
+		StartTime:          startTime,
+		ExtractionAttempts: []ExtractionAttempt{},
+		ValidationIssues:   []ValidationIssue{},
+		StructuredData:     make(map[string]interface{}),
+	}
+
 	if request.URL == "" {
 		return nil, fmt.Errorf("URL cannot be empty")
 	}
@@ -3595,24 +3520,70 @@ func (fc *FireCrawlClient) ExtractWithSchema(request AdminExtractRequest) (*Admi
 
 	// For now, use the basic scrape functionality
 	// TODO: Implement proper schema-based extraction when Firecrawl Go SDK supports it
-	response, err := fc.client.ScrapeURL(request.URL, nil)
+	request.reportProgress(models.ProgressStageFetching)
+	response, err := fc.scrapeURL(request.URL)
 	if err != nil {
+		diagnostics.EndTime = time.Now()
+		diagnostics.ProcessingTime = time.Since(startTime)
+		diagnostics.Success = false
+		diagnostics.ErrorMessage = fmt.Sprintf("Firecrawl extraction failed: %v", err)
+		fc.logDiagnostics(diagnostics)
+		fc.trackRequest(request.URL, false, time.Since(startTime), 0, 0)
 		return nil, fmt.Errorf("Firecrawl extraction failed: %w", err)
 	}
 
+	// Record raw content details for diagnostics
+	if response != nil {
+		diagnostics.RawMarkdownLength = len(response.Markdown)
+		if len(response.Markdown) > 500 {
+			diagnostics.RawMarkdownSample = response.Markdown[:500] + "..."
+		} else {
+			diagnostics.RawMarkdownSample = response.Markdown
+		}
+	}
+
 	// Parse the response into structured data
+	request.reportProgress(models.ProgressStageExtracting)
 	rawData, err := fc.parseAdminExtractResponse(response, request.SchemaType)
 	if err != nil {
+		diagnostics.EndTime = time.Now()
+		diagnostics.ProcessingTime = time.Since(startTime)
+		diagnostics.Success = false
+		diagnostics.ErrorMessage = fmt.Sprintf("Failed to parse extraction response: %v", err)
+		fc.logDiagnostics(diagnostics)
+		fc.trackRequest(request.URL, false, time.Since(startTime), 0, 0)
 		return nil, fmt.Errorf("failed to parse extraction response: %w", err)
 	}
 
 	// Count extracted events
 	eventsCount := fc.countExtractedEvents(rawData, request.SchemaType)
 
+	diagnostics.ExtractionAttempts = append(diagnostics.ExtractionAttempts, ExtractionAttempt{
+		Method:      "schema_" + request.SchemaType,
+		Timestamp:   time.Now(),
+		Success:     eventsCount > 0,
+		EventsFound: eventsCount,
+		Details: map[string]interface{}{
+			"schema_type": request.SchemaType,
+		},
+	})
+	for key := range rawData {
+		diagnostics.StructuredData[key] = fmt.Sprintf("%T", rawData[key])
+	}
+
 	// Extract metadata from response
 	title := fc.extractTitleFromDoc(response)
 	creditsUsed := fc.extractCreditsFromDoc(response)
 
+	// Complete diagnostics
+	diagnostics.EndTime = time.Now()
+	diagnostics.ProcessingTime = time.Since(startTime)
+	diagnostics.Success = true
+	diagnostics.CreditsUsed = creditsUsed
+	fc.logDiagnostics(diagnostics)
+	fc.setLastExtractionDiagnostics(diagnostics)
+	fc.trackRequest(request.URL, true, time.Since(startTime), creditsUsed, eventsCount)
+
 	extractResponse := &AdminExtractResponse{
 		Success:     true,
 		RawData:     rawData,
@@ -3626,6 +3597,7 @@ func (fc *FireCrawlClient) ExtractWithSchema(request AdminExtractRequest) (*Admi
 			SchemaType:     request.SchemaType,
 			ProcessingTime: time.Since(startTime),
 		},
+		Diagnostics: diagnostics,
 	}
 
 	log.Printf("Admin extraction completed for %s: found %d events in %v",
@@ -3634,6 +3606,13 @@ func (fc *FireCrawlClient) ExtractWithSchema(request AdminExtractRequest) (*Admi
 	return extractResponse, nil
 }
 
+// reportProgress invokes the progress callback if one was provided
+func (r AdminExtractRequest) reportProgress(stage string) {
+	if r.OnProgress != nil {
+		r.OnProgress(stage)
+	}
+}
+
 // getSchemaForExtraction returns the appropriate schema based on type
 func (fc *FireCrawlClient) getSchemaForExtraction(schemaType string, customSchema map[string]interface{}) (map[string]interface{}, error) {
 	if schemaType == "custom" {
@@ -3676,6 +3655,14 @@ func (fc *FireCrawlClient) parseAdminExtractResponse(response interface{}, schem
 		venues := fc.extractVenuesFromMarkdown(doc.Markdown)
 		rawData["venues"] = venues
 
+	case "camps":
+		camps := fc.extractCampsFromMarkdown(doc.Markdown)
+		rawData["camps"] = camps
+
+	case "classes":
+		classes := fc.extractClassesFromMarkdown(doc.Markdown)
+		rawData["classes"] = classes
+
 	case "custom":
 		// For custom schemas, try to extract generic objects
 		items := fc.extractGenericItemsFromMarkdown(doc.Markdown)
@@ -3967,6 +3954,14 @@ func (fc *FireCrawlClient) countExtractedEvents(rawData map[string]interface{},
 		if venues, ok := rawData["venues"].([]map[string]interface{}); ok {
 			return len(venues)
 		}
+	case "camps":
+		if camps, ok := rawData["camps"].([]map[string]interface{}); ok {
+			return len(camps)
+		}
+	case "classes":
+		if classes, ok := rawData["classes"].([]map[string]interface{}); ok {
+			return len(classes)
+		}
 	case "custom":
 		if items, ok := rawData["items"].([]map[string]interface{}); ok {
 			return len(items)
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxFireCrawlErrorBody caps how much of an error response is read for its message
+const maxFireCrawlErrorBody = 64 * 1024
+
+// FireCrawlAPIError is an error response from the FireCrawl API, classified into the scraping error
+// code catalog. RetryAfter is set when FireCrawl said how long to wait before trying again.
+type FireCrawlAPIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *FireCrawlAPIError) Error() string {
+	return fmt.Sprintf("FireCrawl API error: status code %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// FireCrawlRetryAfter returns how long FireCrawl asked to wait before retrying the request that
+// failed with err, or 0 when it did not say
+func FireCrawlRetryAfter(err error) time.Duration {
+	var apiErr *FireCrawlAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// ParseFireCrawlError classifies a FireCrawl error response from its status, headers and body
+func ParseFireCrawlError(statusCode int, header http.Header, body []byte, now time.Time) *FireCrawlAPIError {
+	apiErr := &FireCrawlAPIError{
+		StatusCode: statusCode,
+		Message:    fireCrawlErrorMessage(body),
+		RetryAfter: parseRetryAfter(header.Get("Retry-After"), now),
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+
+	message := strings.ToLower(apiErr.Message)
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		apiErr.Code = models.ErrorCodeInvalidAPIKey
+	case statusCode == http.StatusPaymentRequired, strings.Contains(message, "insufficient credits"):
+		apiErr.Code = models.ErrorCodeQuotaExhausted
+	case statusCode == http.StatusTooManyRequests:
+		apiErr.Code = models.ErrorCodeRateLimited
+	case (statusCode == http.StatusForbidden || statusCode == http.StatusBadRequest) && strings.Contains(message, "support"):
+		// FireCrawl refuses some sites outright, e.g. "This website is no longer supported"
+		apiErr.Code = models.ErrorCodeUnsupportedURL
+	default:
+		apiErr.Code = errorCodeForStatus(statusCode)
+		if apiErr.Code == "" {
+			apiErr.Code = models.ErrorCodeUnknown
+		}
+	}
+	return apiErr
+}
+
+// fireCrawlErrorMessage returns the error message of a FireCrawl error body, which is JSON with
+// an "error" field and sometimes "details"
+func fireCrawlErrorMessage(body []byte) string {
+	var payload struct {
+		Error   string          `json:"error"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	message := payload.Error
+	if len(payload.Details) > 0 && string(payload.Details) != "null" {
+		message = strings.TrimSpace(message + " " + string(payload.Details))
+	}
+	return message
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// fireCrawlErrorTransport turns FireCrawl error responses into FireCrawlAPIErrors. The SDK reduces
+// error responses to a formatted string and drops the headers, Retry-After included. 502s are
+// passed through because the SDK retries them itself.
+type fireCrawlErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *fireCrawlErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 || resp.StatusCode == http.StatusBadGateway {
+		return resp, err
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxFireCrawlErrorBody))
+	resp.Body.Close()
+	return nil, ParseFireCrawlError(resp.StatusCode, resp.Header, body, time.Now())
+}
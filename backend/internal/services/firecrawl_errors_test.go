@@ -0,0 +1,66 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestParseFireCrawlError(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		body       string
+		code       string
+		wait       time.Duration
+	}{
+		{"RateLimitedSeconds", 429, "30", `{"error":"Rate limit exceeded"}`, models.ErrorCodeRateLimited, 30 * time.Second},
+		{"RateLimitedDate", 429, now.Add(2 * time.Minute).Format(http.TimeFormat), `{"error":"Rate limit exceeded"}`, models.ErrorCodeRateLimited, 2 * time.Minute},
+		{"InvalidAPIKey", 401, "", `{"error":"Unauthorized: Invalid token"}`, models.ErrorCodeInvalidAPIKey, 0},
+		{"InsufficientCredits", 402, "", `{"error":"Insufficient credits to perform this request."}`, models.ErrorCodeQuotaExhausted, 0},
+		{"UnsupportedURL", 403, "", `{"error":"This website is no longer supported, please reach out to help@firecrawl.com"}`, models.ErrorCodeUnsupportedURL, 0},
+		{"BadRequest", 400, "", `{"error":"Bad Request","details":[{"path":["url"],"message":"Invalid URL"}]}`, models.ErrorCodeInvalidRequest, 0},
+		{"ServerError", 500, "", `not json`, models.ErrorCodeUpstream, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.retryAfter != "" {
+				header.Set("Retry-After", tt.retryAfter)
+			}
+			apiErr := ParseFireCrawlError(tt.status, header, []byte(tt.body), now)
+			if apiErr.Code != tt.code || apiErr.RetryAfter != tt.wait {
+				t.Errorf("Expected %s waiting %s, got %s waiting %s", tt.code, tt.wait, apiErr.Code, apiErr.RetryAfter)
+			}
+			if apiErr.Message == "" {
+				t.Error("Expected an error message")
+			}
+		})
+	}
+}
+
+func TestFireCrawlErrorTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "45")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &fireCrawlErrorTransport{}}
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("Expected the error response to become an error")
+	}
+
+	failure := ClassifyError(err)
+	if failure.Code != models.ErrorCodeRateLimited || !failure.Retryable || failure.RetryAfterSeconds != 45 {
+		t.Errorf("Expected a retryable rate limit after 45s, got %+v", failure)
+	}
+}
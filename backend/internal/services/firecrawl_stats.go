@@ -0,0 +1,200 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// FireCrawlStats contains usage statistics for FireCrawl requests
+type FireCrawlStats struct {
+	TotalRequests      int                       `json:"total_requests"`
+	SuccessfulReqs     int                       `json:"successful_requests"`
+	FailedReqs         int                       `json:"failed_requests"`
+	AvgResponseTime    time.Duration             `json:"avg_response_time"`
+	TotalCreditsUsed   int                       `json:"total_credits_used"`
+	TotalActivitiesExt int                       `json:"total_activities_extracted"`
+	BySource           map[string]FireCrawlStats `json:"by_source,omitempty"`
+}
+
+// fireCrawlStatsTracker holds raw counters for a single scope (overall or per source)
+type fireCrawlStatsTracker struct {
+	requests        int
+	successful      int
+	failed          int
+	totalTime       time.Duration
+	totalCredits    int
+	totalActivities int
+}
+
+// record adds a single request to the tracker
+func (t *fireCrawlStatsTracker) record(success bool, duration time.Duration, creditsUsed, activitiesExtracted int) {
+	t.requests++
+	t.totalTime += duration
+	t.totalCredits += creditsUsed
+	t.totalActivities += activitiesExtracted
+
+	if success {
+		t.successful++
+	} else {
+		t.failed++
+	}
+}
+
+// snapshot converts the raw counters into FireCrawlStats
+func (t *fireCrawlStatsTracker) snapshot() FireCrawlStats {
+	avgTime := time.Duration(0)
+	if t.requests > 0 {
+		avgTime = t.totalTime / time.Duration(t.requests)
+	}
+
+	return FireCrawlStats{
+		TotalRequests:      t.requests,
+		SuccessfulReqs:     t.successful,
+		FailedReqs:         t.failed,
+		AvgResponseTime:    avgTime,
+		TotalCreditsUsed:   t.totalCredits,
+		TotalActivitiesExt: t.totalActivities,
+	}
+}
+
+// fireCrawlStatsScope holds the trackers for a span of requests, overall and per source
+type fireCrawlStatsScope struct {
+	overall  fireCrawlStatsTracker
+	bySource map[string]*fireCrawlStatsTracker
+}
+
+// record adds a single request to the scope
+func (s *fireCrawlStatsScope) record(source string, success bool, duration time.Duration, creditsUsed, activitiesExtracted int) {
+	s.overall.record(success, duration, creditsUsed, activitiesExtracted)
+
+	if source == "" {
+		return
+	}
+	if s.bySource == nil {
+		s.bySource = make(map[string]*fireCrawlStatsTracker)
+	}
+	tracker, exists := s.bySource[source]
+	if !exists {
+		tracker = &fireCrawlStatsTracker{}
+		s.bySource[source] = tracker
+	}
+	tracker.record(success, duration, creditsUsed, activitiesExtracted)
+}
+
+// snapshot converts the scope into FireCrawlStats including the per-source breakdown
+func (s *fireCrawlStatsScope) snapshot() FireCrawlStats {
+	stats := s.overall.snapshot()
+	if len(s.bySource) > 0 {
+		stats.BySource = make(map[string]FireCrawlStats, len(s.bySource))
+		for source, tracker := range s.bySource {
+			stats.BySource[source] = tracker.snapshot()
+		}
+	}
+
+	return stats
+}
+
+// FireCrawlStatsCollector tracks FireCrawl usage overall and per source. It keeps the totals
+// since it was created or reset apart from the requests since the last CloudWatch flush, so
+// flushing does not empty the dashboard. It is safe for concurrent use.
+type FireCrawlStatsCollector struct {
+	mu     sync.Mutex
+	totals fireCrawlStatsScope
+	window fireCrawlStatsScope // since the last flush
+}
+
+// NewFireCrawlStatsCollector creates an empty stats collector
+func NewFireCrawlStatsCollector() *FireCrawlStatsCollector {
+	return &FireCrawlStatsCollector{}
+}
+
+// RecordRequest records the outcome of a single FireCrawl request for the given source
+func (c *FireCrawlStatsCollector) RecordRequest(source string, success bool, duration time.Duration, creditsUsed, activitiesExtracted int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totals.record(source, success, duration, creditsUsed, activitiesExtracted)
+	c.window.record(source, success, duration, creditsUsed, activitiesExtracted)
+}
+
+// Snapshot returns a copy of the total statistics including the per-source breakdown
+func (c *FireCrawlStatsCollector) Snapshot() FireCrawlStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.totals.snapshot()
+}
+
+// Reset clears all collected statistics
+func (c *FireCrawlStatsCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totals = fireCrawlStatsScope{}
+	c.window = fireCrawlStatsScope{}
+}
+
+// FlushToCloudWatch writes the statistics collected since the last flush as CloudWatch Embedded Metric
+// Format log lines and starts a new window; the totals are kept. Lambda forwards these lines to
+// CloudWatch Logs, which turns them into metrics without an extra API call.
+func (c *FireCrawlStatsCollector) FlushToCloudWatch(namespace string) {
+	c.mu.Lock()
+	stats := c.window.snapshot()
+	c.window = fireCrawlStatsScope{}
+	c.mu.Unlock()
+
+	if stats.TotalRequests == 0 {
+		return
+	}
+	writeFireCrawlEMF(namespace, nil, stats)
+
+	sources := make([]string, 0, len(stats.BySource))
+	for source := range stats.BySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		writeFireCrawlEMF(namespace, []emfDimension{{Name: "Source", Value: source}}, stats.BySource[source])
+	}
+}
+
+// writeFireCrawlEMF writes the EMF record of a set of statistics
+func writeFireCrawlEMF(namespace string, dimensions []emfDimension, stats FireCrawlStats) {
+	writeEMF(namespace, dimensions, []emfMetric{
+		{Name: "TotalRequests", Unit: "Count", Value: stats.TotalRequests},
+		{Name: "SuccessfulRequests", Unit: "Count", Value: stats.SuccessfulReqs},
+		{Name: "FailedRequests", Unit: "Count", Value: stats.FailedReqs},
+		{Name: "AvgResponseTimeMs", Unit: "Milliseconds", Value: stats.AvgResponseTime.Milliseconds()},
+		{Name: "CreditsUsed", Unit: "Count", Value: stats.TotalCreditsUsed},
+		{Name: "ActivitiesExtracted", Unit: "Count", Value: stats.TotalActivitiesExt},
+	})
+}
+
+// SetStatsCollector injects the collector used to track requests made by this client
+func (fc *FireCrawlClient) SetStatsCollector(collector *FireCrawlStatsCollector) {
+	fc.stats = collector
+}
+
+// StatsCollector returns the collector used by this client (nil if stats are not tracked)
+func (fc *FireCrawlClient) StatsCollector() *FireCrawlStatsCollector {
+	return fc.stats
+}
+
+// GetStats returns current statistics
+func (fc *FireCrawlClient) GetStats() FireCrawlStats {
+	if fc.stats == nil {
+		return FireCrawlStats{}
+	}
+	return fc.stats.Snapshot()
+}
+
+// trackRequest updates statistics for a request to the given URL
+func (fc *FireCrawlClient) trackRequest(url string, success bool, duration time.Duration, creditsUsed, activitiesExtracted int) {
+	if fc.stats == nil {
+		return
+	}
+	fc.stats.RecordRequest(urlutil.Domain(url), success, duration, creditsUsed, activitiesExtracted)
+}
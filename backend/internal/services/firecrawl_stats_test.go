@@ -0,0 +1,96 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFireCrawlStatsCollector(t *testing.T) {
+	t.Run("RecordsOverallAndPerSource", func(t *testing.T) {
+		collector := NewFireCrawlStatsCollector()
+
+		collector.RecordRequest("example.com", true, 2*time.Second, 1, 5)
+		collector.RecordRequest("example.com", false, 1*time.Second, 0, 0)
+		collector.RecordRequest("other.org", true, 3*time.Second, 2, 3)
+
+		stats := collector.Snapshot()
+		if stats.TotalRequests != 3 {
+			t.Errorf("Expected 3 total requests, got %d", stats.TotalRequests)
+		}
+		if stats.SuccessfulReqs != 2 || stats.FailedReqs != 1 {
+			t.Errorf("Expected 2 successful and 1 failed, got %d and %d", stats.SuccessfulReqs, stats.FailedReqs)
+		}
+		if stats.TotalCreditsUsed != 3 {
+			t.Errorf("Expected 3 credits used, got %d", stats.TotalCreditsUsed)
+		}
+		if stats.AvgResponseTime != 2*time.Second {
+			t.Errorf("Expected avg response time 2s, got %v", stats.AvgResponseTime)
+		}
+
+		source, exists := stats.BySource["example.com"]
+		if !exists {
+			t.Fatal("Expected per-source stats for example.com")
+		}
+		if source.TotalRequests != 2 || source.TotalActivitiesExt != 5 {
+			t.Errorf("Unexpected example.com stats: %+v", source)
+		}
+	})
+
+	t.Run("ConcurrentRecording", func(t *testing.T) {
+		collector := NewFireCrawlStatsCollector()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				collector.RecordRequest("example.com", true, time.Millisecond, 1, 1)
+			}()
+		}
+		wg.Wait()
+
+		if stats := collector.Snapshot(); stats.TotalRequests != 50 {
+			t.Errorf("Expected 50 total requests, got %d", stats.TotalRequests)
+		}
+	})
+
+	t.Run("FlushKeepsTotals", func(t *testing.T) {
+		collector := NewFireCrawlStatsCollector()
+		collector.RecordRequest("example.com", true, time.Second, 1, 1)
+
+		collector.FlushToCloudWatch("Test/FireCrawl")
+		collector.RecordRequest("other.org", true, time.Second, 2, 1)
+
+		if stats := collector.Snapshot(); stats.TotalRequests != 2 || len(stats.BySource) != 2 {
+			t.Errorf("Expected the totals to survive the flush, got %+v", stats)
+		}
+		if window := collector.window.snapshot(); window.TotalRequests != 1 || window.TotalCreditsUsed != 2 {
+			t.Errorf("Expected only the request since the flush in the window, got %+v", window)
+		}
+
+		collector.Reset()
+		if stats := collector.Snapshot(); stats.TotalRequests != 0 || len(stats.BySource) != 0 {
+			t.Errorf("Expected collector to be empty after reset, got %+v", stats)
+		}
+	})
+
+	t.Run("ClientTracksByDomain", func(t *testing.T) {
+		fc := &FireCrawlClient{}
+		if stats := fc.GetStats(); stats.TotalRequests != 0 {
+			t.Errorf("Expected empty stats without a collector, got %+v", stats)
+		}
+		fc.trackRequest("https://example.com/events", true, time.Second, 1, 2)
+
+		fc.SetStatsCollector(NewFireCrawlStatsCollector())
+		fc.trackRequest("https://example.com/events", true, time.Second, 1, 2)
+
+		stats := fc.GetStats()
+		if stats.TotalRequests != 1 {
+			t.Errorf("Expected 1 tracked request, got %d", stats.TotalRequests)
+		}
+		if _, exists := stats.BySource["example.com"]; !exists {
+			t.Errorf("Expected stats keyed by domain, got %v", stats.BySource)
+		}
+	})
+}
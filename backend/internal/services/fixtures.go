@@ -0,0 +1,538 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// FixtureIDPrefix starts the ID of every generated source, task, execution and event, so fixture
+// data can be told apart from real data
+const FixtureIDPrefix = "seed-"
+
+// FixtureAnalysisVersion marks the analyses of generated sources
+const FixtureAnalysisVersion = "seed-fixture"
+
+// FixtureOptions sizes the generated data. The same options and reference time always generate
+// the same fixtures.
+type FixtureOptions struct {
+	Sources         int   // sources to generate, spread over every source status
+	EventsPerSource int   // extracted events of each source that was scraped
+	Seed            int64 // varies the generated data
+}
+
+// Fixtures is internally consistent test data for the four tables: sources in every status, the
+// scraping tasks and executions of the sources that were scraped, the events extracted from them
+// at each confidence level, and the published activities of the approved events
+type Fixtures struct {
+	Submissions []*models.SourceSubmission   `json:"submissions"`
+	Analyses    []*models.SourceAnalysis     `json:"analyses"`
+	Configs     []*models.DynamoSourceConfig `json:"configs"`
+	Tasks       []*models.ScrapingTask       `json:"tasks"`
+	Executions  []*models.ScrapingExecution  `json:"executions"`
+	AdminEvents []*models.AdminEvent         `json:"admin_events"`
+	Activities  []*models.Activity           `json:"activities"`
+}
+
+// fixtureSourceStatuses are given to the first sources in order, so a handful of sources covers
+// every status. Later sources are mostly active, since only scraped sources have events.
+var fixtureSourceStatuses = []string{
+	models.SourceStatusActive,
+	models.SourceStatusPendingAnalysis,
+	models.SourceStatusAnalysisComplete,
+	models.SourceStatusPaused,
+	models.SourceStatusInactive,
+	models.SourceStatusRejected,
+	models.SourceStatusPreflightFailed,
+}
+
+// fixtureVenue is a venue sources and their events are placed at. Addresses name the neighborhood
+// or city, so conversion places them the way it places real listings.
+type fixtureVenue struct {
+	name     string
+	address  string
+	lat, lng float64
+}
+
+var fixtureVenues = []fixtureVenue{
+	{"Ballard Community Center", "6020 28th Ave NW, Ballard, Seattle, WA 98107", 47.6722, -122.3925},
+	{"Capitol Hill Library", "425 Harvard Ave E, Capitol Hill, Seattle, WA 98102", 47.6228, -122.3220},
+	{"Fremont Arts Studio", "3501 Phinney Ave N, Fremont, Seattle, WA 98103", 47.6505, -122.3550},
+	{"Green Lake Park Fieldhouse", "7201 E Green Lake Dr N, Green Lake, Seattle, WA 98115", 47.6805, -122.3280},
+	{"Queen Anne Pool", "1920 1st Ave W, Queen Anne, Seattle, WA 98119", 47.6360, -122.3590},
+	{"Beacon Hill Playfield", "1820 13th Ave S, Beacon Hill, Seattle, WA 98144", 47.5870, -122.3150},
+	{"Bellevue Children's Theatre", "16661 Northup Way, Bellevue, WA 98008", 47.6280, -122.1160},
+	{"Redmond Nature Center", "17200 NE 116th St, Redmond, WA 98052", 47.7030, -122.1100},
+	{"Kirkland Waterfront Park", "25 Lakeshore Plaza, Kirkland, WA 98033", 47.6760, -122.2090},
+}
+
+// fixtureActivity is an activity sources list. Titles and descriptions carry the keywords
+// conversion derives categories from, so the published activities cover every category.
+type fixtureActivity struct {
+	title       string
+	description string
+	ages        string
+	price       string
+}
+
+var fixtureActivities = []fixtureActivity{
+	{"Watercolor Painting for Kids", "Young painters explore color mixing with watercolors.", "5-10 years", "$15"},
+	{"Family Music Jam", "Sing along and play percussion instruments together.", "0-5 years", "Free"},
+	{"Youth Soccer Skills", "Dribbling, passing and small-sided games on the field.", "6-9 years", "$20"},
+	{"Swim Lessons: Level 1", "Water safety and beginning swim strokes with certified instructors.", "4-6 years", "$60"},
+	{"Robot Building Workshop", "Design and program a small robot to complete challenges.", "8-12 years", "$35"},
+	{"Kitchen Science Experiments", "Hands-on science with everyday kitchen ingredients.", "6-10 years", "$12"},
+	{"Puppet Show Matinee", "A puppet show for the whole family, followed by a meet and greet.", "3-8 years", "$8"},
+	{"Outdoor Movie Night", "Bring a blanket for a family movie on the lawn.", "All ages", "Free"},
+	{"Summer Nature Camp", "A week of outdoor exploration, games and nature journaling.", "7-11 years", "$325"},
+	{"After School Chess Program", "Weekly chess lessons and friendly games for all levels.", "8-14 years", "$90"},
+	{"Toddler Story Time", "Songs, rhymes and picture books for toddlers and their grown-ups.", "1-3 years", "Free"},
+	{"Community Garden Day", "Help plant the neighborhood garden and take home a seedling.", "All ages", "Free"},
+}
+
+// Confidence levels of generated events: complete listings, listings missing their time, address
+// and price, and listings with only a title and an unreadable date
+const (
+	fixtureConfidenceHigh   = "high"
+	fixtureConfidenceMedium = "medium"
+	fixtureConfidenceLow    = "low"
+)
+
+var fixtureConfidenceLevels = []string{fixtureConfidenceHigh, fixtureConfidenceMedium, fixtureConfidenceLow}
+
+var fixtureReviewers = []string{"reviewer-1@example.com", "reviewer-2@example.com", "reviewer-3@example.com"}
+
+var fixtureTaskErrorCodes = []string{models.ErrorCodeTimeout, models.ErrorCodeRateLimited, models.ErrorCodeUpstream, models.ErrorCodeNotFound}
+
+// GenerateFixtures builds fixture data around now: sources were submitted over the last two
+// months, tasks ran over the last four weeks, and events take place from two weeks ago to two
+// months ahead. Events are converted with conversion, which sets their confidence and the
+// activities the approved ones published.
+func GenerateFixtures(options FixtureOptions, conversion *SchemaConversionService, now time.Time) *Fixtures {
+	rng := rand.New(rand.NewSource(options.Seed))
+	fixtures := &Fixtures{}
+	eventNumber := 0
+	published := make(map[string]bool)
+
+	for i := 0; i < options.Sources; i++ {
+		status := models.SourceStatusActive
+		if i < len(fixtureSourceStatuses) {
+			status = fixtureSourceStatuses[i]
+		} else if rng.Intn(3) == 0 {
+			status = fixtureSourceStatuses[rng.Intn(len(fixtureSourceStatuses))]
+		}
+		venue := fixtureVenues[i%len(fixtureVenues)]
+		submission := fixtureSubmission(i, status, venue, rng, now)
+		fixtures.Submissions = append(fixtures.Submissions, submission)
+
+		if status == models.SourceStatusPendingAnalysis || status == models.SourceStatusPreflightFailed {
+			continue
+		}
+		analysis := SandboxAnalysis(submission)
+		analysis.PK = models.CreateSourcePK(submission.SourceID)
+		analysis.SK = models.CreateSourceAnalysisSK()
+		analysis.AnalysisVersion = FixtureAnalysisVersion
+		analysis.Version = 1
+		analysis.AnalysisCompletedAt = submission.SubmittedAt.Add(time.Duration(10+rng.Intn(50)) * time.Minute)
+		analysis.Recommendations = []string{"Fixture analysis: no pages were crawled."}
+		fixtures.Analyses = append(fixtures.Analyses, analysis)
+
+		// Only sources that were activated have a production config and were scraped
+		if status != models.SourceStatusActive && status != models.SourceStatusPaused && status != models.SourceStatusInactive {
+			continue
+		}
+		config := fixtureConfig(submission, analysis, rng)
+		fixtures.Configs = append(fixtures.Configs, config)
+		tasks, executions := fixtureTasks(config, rng, now)
+		fixtures.Tasks = append(fixtures.Tasks, tasks...)
+		fixtures.Executions = append(fixtures.Executions, executions...)
+
+		for j := 0; j < options.EventsPerSource; j++ {
+			eventNumber++
+			level := fixtureConfidenceLevels[(i+j)%len(fixtureConfidenceLevels)]
+			adminEvent, activity := fixtureEvent(eventNumber, level, config, venue, conversion, published, rng, now)
+			fixtures.AdminEvents = append(fixtures.AdminEvents, adminEvent)
+			if activity != nil {
+				published[activity.ID] = true
+				fixtures.Activities = append(fixtures.Activities, activity)
+			}
+		}
+	}
+	return fixtures
+}
+
+func fixtureSubmission(i int, status string, venue fixtureVenue, rng *rand.Rand, now time.Time) *models.SourceSubmission {
+	sourceID := fmt.Sprintf("%ssource-%03d", FixtureIDPrefix, i+1)
+	sourceTypes := []string{models.SourceTypeVenue, models.SourceTypeEventOrganizer, models.SourceTypeProgramProvider, models.SourceTypeCommunityCalendar}
+	priorities := []string{models.SourcePriorityHigh, models.SourcePriorityMedium, models.SourcePriorityLow}
+	submittedAt := now.AddDate(0, 0, -30-rng.Intn(30)).Add(-time.Duration(rng.Intn(24*60)) * time.Minute)
+
+	submission := &models.SourceSubmission{
+		PK:              models.CreateSourcePK(sourceID),
+		SK:              models.CreateSourceSubmissionSK(),
+		SourceID:        sourceID,
+		SourceName:      fmt.Sprintf("%s (%03d)", venue.name, i+1),
+		BaseURL:         fmt.Sprintf("https://source-%03d.example.org/events", i+1),
+		SourceType:      sourceTypes[i%len(sourceTypes)],
+		Priority:        priorities[rng.Intn(len(priorities))],
+		ExpectedContent: []string{"events"},
+		HintURLs:        []string{fmt.Sprintf("https://source-%03d.example.org/programs", i+1)},
+		SubmittedBy:     "seed",
+		SubmittedAt:     submittedAt,
+		UpdatedAt:       submittedAt,
+		Status:          status,
+	}
+
+	switch status {
+	case models.SourceStatusPreflightFailed:
+		submission.Preflight = &models.PreflightResult{
+			Passed:       false,
+			CheckedAt:    submittedAt,
+			FailureStage: models.PreflightStageDNS,
+			Diagnosis:    "The domain does not resolve",
+		}
+	case models.SourceStatusRejected:
+		submission.DenialReason = "Listings are for adults only"
+	case models.SourceStatusPaused:
+		submission.PauseReason = "Site redesign in progress"
+	}
+	submission.StatusKey = models.GenerateSourceStatusKey(submission.Status, sourceID)
+	submission.PriorityKey = models.GenerateSourcePriorityKey(submission.Priority, sourceID)
+	return submission
+}
+
+func fixtureConfig(submission *models.SourceSubmission, analysis *models.SourceAnalysis, rng *rand.Rand) *models.DynamoSourceConfig {
+	activatedAt := analysis.AnalysisCompletedAt.Add(time.Duration(1+rng.Intn(48)) * time.Hour)
+	config := &models.DynamoSourceConfig{
+		PK:               models.CreateSourcePK(submission.SourceID),
+		SK:               models.CreateSourceConfigSK(),
+		SourceID:         submission.SourceID,
+		SourceName:       submission.SourceName,
+		SourceType:       submission.SourceType,
+		BaseURL:          submission.BaseURL,
+		TargetURLs:       analysis.RecommendedConfig.TargetURLs,
+		ContentSelectors: analysis.DiscoveredPatterns.DataSelectors,
+		ScrapingConfig: models.DynamoScrapingConfig{
+			Frequency:         analysis.RecommendedConfig.ScrapingFrequency,
+			Priority:          submission.Priority,
+			RateLimit:         analysis.RecommendedConfig.RateLimit,
+			UserAgent:         "SeattleFamilyActivities/1.0",
+			RespectRobotsTxt:  true,
+			Timeout:           30,
+			MaxRetries:        3,
+			BackoffMultiplier: 2.0,
+		},
+		DataQuality: models.DataQuality{
+			ReliabilityScore:   0.6 + float64(rng.Intn(40))/100,
+			ExpectedItemsRange: models.ItemRange{Min: 5, Max: 50},
+		},
+		AdaptiveFrequency: models.AdaptiveFrequency{
+			BaseFrequency:    analysis.RecommendedConfig.ScrapingFrequency,
+			CurrentFrequency: analysis.RecommendedConfig.ScrapingFrequency,
+		},
+		Status:       submission.Status,
+		ActivatedBy:  "seed",
+		ActivatedAt:  activatedAt,
+		LastModified: activatedAt,
+	}
+	config.StatusKey = models.GenerateSourceStatusKey(config.Status, config.SourceID)
+	config.PriorityKey = models.GenerateSourcePriorityKey(config.ScrapingConfig.Priority, config.SourceID)
+	return config
+}
+
+// fixtureTasks builds a source's weekly scrapes over the last four weeks, most of them successful,
+// and the next scheduled scrape of an active source. Finished scrapes have an execution.
+func fixtureTasks(config *models.DynamoSourceConfig, rng *rand.Rand, now time.Time) ([]*models.ScrapingTask, []*models.ScrapingExecution) {
+	var tasks []*models.ScrapingTask
+	var executions []*models.ScrapingExecution
+	sourceNumber := strings.TrimPrefix(config.SourceID, FixtureIDPrefix+"source-")
+
+	for week := 4; week >= 0; week-- {
+		scheduledTime := now.AddDate(0, 0, -7*week).Add(-time.Duration(rng.Intn(12*60)) * time.Minute)
+		status := models.TaskStatusCompleted
+		switch {
+		case week == 0 && config.Status == models.SourceStatusActive:
+			status = models.TaskStatusScheduled
+			scheduledTime = now.Add(time.Duration(1+rng.Intn(72)) * time.Hour)
+		case week == 0:
+			continue
+		case week == 1 && config.Status == models.SourceStatusPaused:
+			status = models.TaskStatusCancelled
+		case rng.Intn(5) == 0:
+			status = models.TaskStatusFailed
+		}
+
+		taskID := fmt.Sprintf("%stask-%s-%d", FixtureIDPrefix, sourceNumber, 5-week)
+		task := &models.ScrapingTask{
+			PK:                models.CreateTaskPK(taskID),
+			SK:                models.CreateTaskSK(config.ScrapingConfig.Priority, config.SourceID, taskID),
+			TaskID:            taskID,
+			SourceID:          config.SourceID,
+			TaskType:          models.TaskTypeFullScrape,
+			Priority:          config.ScrapingConfig.Priority,
+			ScheduledTime:     scheduledTime,
+			TargetURLs:        config.TargetURLs,
+			ExtractionRules:   config.ContentSelectors,
+			RateLimits:        config.ScrapingConfig.RateLimit,
+			Timeout:           300,
+			MaxRetries:        3,
+			Status:            status,
+			EstimatedDuration: 120,
+			Dependencies:      []string{},
+			CreatedAt:         scheduledTime.Add(-time.Hour),
+			UpdatedAt:         scheduledTime,
+			TTL:               models.CalculateTaskTTL(scheduledTime, 90),
+			NextRunShard:      models.GenerateNextRunShardKey(taskID),
+			NextRunKey:        models.GenerateNextRunKey(scheduledTime),
+			PrioritySourceKey: models.GeneratePrioritySourceKey(config.ScrapingConfig.Priority, config.SourceID, taskID),
+		}
+		switch status {
+		case models.TaskStatusFailed:
+			task.ErrorCode = fixtureTaskErrorCodes[rng.Intn(len(fixtureTaskErrorCodes))]
+			task.ErrorMessage = fmt.Sprintf("Scrape of %s failed: %s", config.BaseURL, task.ErrorCode)
+			task.Retryable = task.ErrorCode != models.ErrorCodeNotFound
+			task.RetryCount = task.MaxRetries
+			task.LastRetryAt = scheduledTime.Add(10 * time.Minute)
+		case models.TaskStatusCancelled:
+			task.ErrorCode = models.ErrorCodeSourcePaused
+			task.ErrorMessage = "Source was paused"
+		}
+		tasks = append(tasks, task)
+
+		if status != models.TaskStatusCompleted && status != models.TaskStatusFailed {
+			continue
+		}
+		executions = append(executions, fixtureExecution(task, rng))
+	}
+	return tasks, executions
+}
+
+func fixtureExecution(task *models.ScrapingTask, rng *rand.Rand) *models.ScrapingExecution {
+	executionID := strings.Replace(task.TaskID, "task-", "execution-", 1)
+	duration := time.Duration(20+rng.Intn(200)) * time.Second
+	execution := &models.ScrapingExecution{
+		PK:          models.CreateExecutionPK(executionID),
+		SK:          "STATUS",
+		ExecutionID: executionID,
+		TaskID:      task.TaskID,
+		SourceID:    task.SourceID,
+		StartedAt:   task.ScheduledTime,
+		CompletedAt: task.ScheduledTime.Add(duration),
+		Duration:    duration.Milliseconds(),
+		Status:      "completed",
+		Metrics: models.ExecutionMetrics{
+			RequestCount:        len(task.TargetURLs),
+			SuccessfulRequests:  len(task.TargetURLs),
+			AverageResponseTime: int64(300 + rng.Intn(1500)),
+		},
+		Errors:   []models.ExecutionError{},
+		Warnings: []models.ExecutionError{},
+	}
+	if task.Status == models.TaskStatusFailed {
+		execution.Status = "failed"
+		execution.ErrorCode = task.ErrorCode
+		execution.ErrorMessage = task.ErrorMessage
+		execution.Retryable = task.Retryable
+		execution.ErrorCount = 1
+		execution.Metrics.SuccessfulRequests = 0
+		execution.Metrics.FailedRequests = len(task.TargetURLs)
+		execution.Errors = []models.ExecutionError{{
+			Type:      "error",
+			Code:      task.ErrorCode,
+			Message:   task.ErrorMessage,
+			URL:       task.TargetURLs[0],
+			Timestamp: execution.CompletedAt,
+		}}
+	} else {
+		execution.ItemsExtracted = 5 + rng.Intn(30)
+		execution.ItemsProcessed = execution.ItemsExtracted
+		execution.ItemsStored = execution.ItemsExtracted - rng.Intn(3)
+		execution.Metrics.ExtractionSuccess = 100
+		execution.Metrics.DataCompleteness = float64(60 + rng.Intn(40))
+	}
+	execution.TTL = models.CalculateExecutionTTL(execution.CompletedAt, 90)
+	return execution
+}
+
+// fixtureEvent builds an event extracted from a source's listing, described as completely as its
+// confidence level. Complete listings are mostly approved and publish an activity; sparse ones
+// wait for review or are rejected. A listing that would publish an activity already published is
+// left pending, as a duplicate waiting for review.
+func fixtureEvent(number int, level string, config *models.DynamoSourceConfig, venue fixtureVenue, conversion *SchemaConversionService, published map[string]bool, rng *rand.Rand, now time.Time) (*models.AdminEvent, *models.Activity) {
+	listing := fixtureActivities[rng.Intn(len(fixtureActivities))]
+	date := now.AddDate(0, 0, rng.Intn(75)-14)
+	data := map[string]interface{}{
+		"title":       listing.title,
+		"description": listing.description,
+	}
+	switch level {
+	case fixtureConfidenceHigh:
+		data["date"] = date.Format("2006-01-02")
+		data["time"] = fmt.Sprintf("%02d:%02d", 9+rng.Intn(9), 30*rng.Intn(2))
+		data["location"] = venue.name
+		data["address"] = venue.address
+		data["latitude"] = venue.lat
+		data["longitude"] = venue.lng
+		data["ages"] = listing.ages
+		data["price"] = listing.price
+		data["registration_url"] = fmt.Sprintf("%s/register/%d", config.BaseURL, number)
+	case fixtureConfidenceMedium:
+		data["date"] = date.Format("2006-01-02")
+		data["location"] = venue.name
+	default:
+		delete(data, "description")
+		data["date"] = "Dates TBA"
+	}
+
+	extractedAt := now.AddDate(0, 0, -rng.Intn(30)).Add(-time.Duration(1+rng.Intn(23*60)) * time.Minute).Truncate(time.Second)
+	adminEvent := &models.AdminEvent{
+		EventID:          fmt.Sprintf("%sevent-%05d", FixtureIDPrefix, number),
+		SourceURL:        config.TargetURLs[0],
+		SchemaType:       "events",
+		RawExtractedData: map[string]interface{}{"events": []interface{}{data}},
+		Status:           models.AdminEventStatusPending,
+		ExtractedAt:      extractedAt,
+		QueuedAt:         &extractedAt,
+		CreatedAt:        extractedAt,
+		UpdatedAt:        extractedAt,
+		ExtractedByUser:  "seed",
+		SubmissionID:     fmt.Sprintf("%ssubmission-%s", FixtureIDPrefix, strings.TrimPrefix(config.SourceID, FixtureIDPrefix)),
+	}
+
+	// Conversion stamps activities with the current time; fixtures use the extraction time
+	result, err := conversion.ConvertToActivity(adminEvent)
+	if err == nil {
+		if result.Activity != nil {
+			result.Activity.CreatedAt = extractedAt
+			result.Activity.UpdatedAt = extractedAt
+			result.Activity.Source.ScrapedAt = extractedAt
+			result.Activity.Source.LastChecked = extractedAt
+			activityJSON, _ := json.Marshal(result.Activity)
+			json.Unmarshal(activityJSON, &adminEvent.ConvertedData)
+		}
+		adminEvent.ConversionIssues = result.Issues
+		adminEvent.ConfidenceScore = result.ConfidenceScore
+		adminEvent.CompletenessScore = CompletenessScore(result.Activity)
+	}
+
+	// Reviewed events were reviewed within three days of extraction, and never in the future
+	roll := rng.Intn(10)
+	status := models.AdminEventStatusPending
+	switch level {
+	case fixtureConfidenceHigh:
+		if roll < 7 {
+			status = models.AdminEventStatusApproved
+		} else if roll < 8 {
+			status = models.AdminEventStatusEdited
+		}
+	case fixtureConfidenceMedium:
+		if roll < 3 {
+			status = models.AdminEventStatusApproved
+		} else if roll < 5 {
+			status = models.AdminEventStatusRejected
+		} else if roll < 6 {
+			status = models.AdminEventStatusEdited
+		}
+	default:
+		if roll < 4 {
+			status = models.AdminEventStatusRejected
+		}
+	}
+	if status == models.AdminEventStatusApproved && (result == nil || result.Activity == nil || published[result.Activity.ID]) {
+		status = models.AdminEventStatusPending
+	}
+
+	if status != models.AdminEventStatusPending {
+		reviewedAt := extractedAt.Add(time.Duration(10+rng.Intn(72*60)) * time.Minute)
+		if reviewedAt.After(now) {
+			reviewedAt = now
+		}
+		adminEvent.ReviewedAt = &reviewedAt
+		adminEvent.ReviewedBy = fixtureReviewers[rng.Intn(len(fixtureReviewers))]
+		adminEvent.UpdatedAt = reviewedAt
+		if status == models.AdminEventStatusRejected {
+			adminEvent.AdminNotes = "Missing the details families need"
+		}
+	}
+	adminEvent.Status = status
+	adminEvent.PK = models.CreateAdminEventPK(adminEvent.EventID)
+	adminEvent.SK = models.CreateAdminEventSK(adminEvent.ExtractedAt)
+	adminEvent.StatusKey = models.GenerateAdminEventStatusKey(adminEvent.Status, adminEvent.EventID)
+
+	if status != models.AdminEventStatusApproved {
+		return adminEvent, nil
+	}
+	activity := result.Activity
+	activity.CreatedAt = *adminEvent.ReviewedAt
+	activity.UpdatedAt = *adminEvent.ReviewedAt
+	adminEvent.ActivityID = activity.ID
+	return adminEvent, activity
+}
+
+// WriteFixtures stores fixture data, with the calendar, tag and map entries of the published
+// activities. Fixtures overwrite the items they were generated to, so writing the same fixtures
+// again changes nothing.
+func WriteFixtures(ctx context.Context, dynamo *DynamoDBService, fixtures *Fixtures) error {
+	var sourceItems []interface{}
+	for _, submission := range fixtures.Submissions {
+		sourceItems = append(sourceItems, submission)
+	}
+	for _, analysis := range fixtures.Analyses {
+		version := *analysis
+		version.SK = models.CreateSourceAnalysisVersionSK(analysis.Version)
+		sourceItems = append(sourceItems, analysis, &version)
+	}
+	for _, config := range fixtures.Configs {
+		sourceItems = append(sourceItems, config)
+	}
+	if err := dynamo.putItems(ctx, dynamo.sourceManagementTable, sourceItems); err != nil {
+		return fmt.Errorf("failed to write sources: %w", err)
+	}
+
+	var operationItems []interface{}
+	for _, task := range fixtures.Tasks {
+		operationItems = append(operationItems, task)
+	}
+	for _, execution := range fixtures.Executions {
+		operationItems = append(operationItems, execution)
+	}
+	if err := dynamo.putItems(ctx, dynamo.scrapingOperationsTable, operationItems); err != nil {
+		return fmt.Errorf("failed to write tasks and executions: %w", err)
+	}
+
+	if err := dynamo.BatchPutActivities(ctx, fixtures.Activities); err != nil {
+		return fmt.Errorf("failed to write activities: %w", err)
+	}
+	eventIDs := make(map[string]string, len(fixtures.Activities))
+	for _, adminEvent := range fixtures.AdminEvents {
+		if adminEvent.ActivityID != "" {
+			eventIDs[adminEvent.ActivityID] = adminEvent.EventID
+		}
+	}
+	for _, activity := range fixtures.Activities {
+		if err := dynamo.ReplaceCalendarEntries(ctx, activity.ID, CalendarEntriesForActivity(activity, "")); err != nil {
+			return fmt.Errorf("failed to write calendar entries of activity %s: %w", activity.ID, err)
+		}
+		if err := dynamo.ReplaceTagEntries(ctx, activity.ID, TagEntriesForActivity(activity)); err != nil {
+			return fmt.Errorf("failed to write tag entries of activity %s: %w", activity.ID, err)
+		}
+		if err := dynamo.ReplaceGeoEntry(ctx, activity.ID, GeoEntryForActivity(activity, eventIDs[activity.ID])); err != nil {
+			return fmt.Errorf("failed to write geo entry of activity %s: %w", activity.ID, err)
+		}
+	}
+
+	// Events last, so the catalog change log records approved events whose activities exist
+	var eventItems []interface{}
+	for _, adminEvent := range fixtures.AdminEvents {
+		eventItems = append(eventItems, adminEvent)
+	}
+	if err := dynamo.putItems(ctx, dynamo.adminEventsTable, eventItems); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestGenerateFixtures(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	options := FixtureOptions{Sources: 20, EventsPerSource: 12, Seed: 7}
+	fixtures := GenerateFixtures(options, NewSchemaConversionService(), now)
+
+	first, _ := json.Marshal(fixtures)
+	again, _ := json.Marshal(GenerateFixtures(options, NewSchemaConversionService(), now))
+	if string(first) != string(again) {
+		t.Error("Expected the same options and time to generate the same fixtures")
+	}
+
+	if len(fixtures.Submissions) != options.Sources {
+		t.Fatalf("Expected %d sources, got %d", options.Sources, len(fixtures.Submissions))
+	}
+	statuses := make(map[string]bool)
+	for _, submission := range fixtures.Submissions {
+		statuses[submission.Status] = true
+		if err := submission.Validate(); err != nil {
+			t.Errorf("Expected source %s to be valid, got %v", submission.SourceID, err)
+		}
+	}
+	for _, status := range fixtureSourceStatuses {
+		if !statuses[status] {
+			t.Errorf("Expected a source with status %s", status)
+		}
+	}
+
+	// Tasks, executions and events belong to sources that were activated
+	configs := make(map[string]bool)
+	for _, config := range fixtures.Configs {
+		configs[config.SourceID] = true
+	}
+	tasks := make(map[string]*models.ScrapingTask)
+	for _, task := range fixtures.Tasks {
+		tasks[task.TaskID] = task
+		if !configs[task.SourceID] {
+			t.Errorf("Expected task %s to belong to an activated source, got %s", task.TaskID, task.SourceID)
+		}
+	}
+	for _, execution := range fixtures.Executions {
+		task := tasks[execution.TaskID]
+		if task == nil || task.SourceID != execution.SourceID {
+			t.Errorf("Expected execution %s to belong to a task of its source", execution.ExecutionID)
+		} else if (task.Status == models.TaskStatusFailed) != (execution.Status == "failed") {
+			t.Errorf("Expected execution %s to match task status %s, got %s", execution.ExecutionID, task.Status, execution.Status)
+		}
+	}
+
+	activities := make(map[string]*models.Activity)
+	categories := make(map[string]bool)
+	cities := make(map[string]bool)
+	for _, activity := range fixtures.Activities {
+		activities[activity.ID] = activity
+		categories[activity.Category] = true
+		cities[activity.Location.City] = true
+	}
+	eventStatuses := make(map[models.AdminEventStatus]int)
+	var highest, lowest float64 = 0, 100
+	for _, adminEvent := range fixtures.AdminEvents {
+		eventStatuses[adminEvent.Status]++
+		if adminEvent.ConfidenceScore > highest {
+			highest = adminEvent.ConfidenceScore
+		}
+		if adminEvent.ConfidenceScore < lowest {
+			lowest = adminEvent.ConfidenceScore
+		}
+		if adminEvent.IsApproved() != (activities[adminEvent.ActivityID] != nil) {
+			t.Errorf("Expected exactly the approved events to have published activities, got %s with %q", adminEvent.Status, adminEvent.ActivityID)
+		}
+		if adminEvent.ReviewedAt != nil && (adminEvent.ReviewedAt.Before(adminEvent.ExtractedAt) || adminEvent.ReviewedAt.After(now)) {
+			t.Errorf("Expected event %s to be reviewed after extraction and before now", adminEvent.EventID)
+		}
+	}
+	if len(activities) != eventStatuses[models.AdminEventStatusApproved] {
+		t.Errorf("Expected one activity per approved event, got %d for %d", len(activities), eventStatuses[models.AdminEventStatusApproved])
+	}
+	for _, status := range []models.AdminEventStatus{models.AdminEventStatusPending, models.AdminEventStatusApproved, models.AdminEventStatusRejected, models.AdminEventStatusEdited} {
+		if eventStatuses[status] == 0 {
+			t.Errorf("Expected events with status %s, got %v", status, eventStatuses)
+		}
+	}
+	if highest-lowest < 30 {
+		t.Errorf("Expected confidence scores to spread across levels, got %.0f to %.0f", lowest, highest)
+	}
+	if len(categories) < 5 || len(cities) < 3 {
+		t.Errorf("Expected activities across categories and cities, got %v and %v", categories, cities)
+	}
+}
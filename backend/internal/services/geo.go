@@ -0,0 +1,129 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Geohash precisions of the geohash-index GSI. Entries are partitioned by their 4-character cell
+// (about 20 by 26 km in Seattle) and sorted by their 7-character geohash, so a 5-character cell
+// (about 5 km across) is a prefix query within its partition.
+const (
+	geoPartitionPrecision = 4
+	geoPrefixPrecision    = 5
+	// A search covering more 5-character cells than this queries the 4-character cells instead
+	maxGeoPrefixCells = 16
+)
+
+const kmPerDegreeLat = 111.32
+
+// GeohashCellsWithin returns the geohash cells covering a circle, as 5-character cells for small
+// circles and 4-character cells for large ones. Cells are sorted.
+func GeohashCellsWithin(center models.Coordinates, radiusKm float64) []string {
+	latDelta := radiusKm / kmPerDegreeLat
+	lngDelta := radiusKm / (kmPerDegreeLat * math.Max(math.Cos(center.Lat*math.Pi/180), 0.01))
+	minLat, maxLat := math.Max(center.Lat-latDelta, -90), math.Min(center.Lat+latDelta, 90)
+	minLng, maxLng := math.Max(center.Lng-lngDelta, -180), math.Min(center.Lng+lngDelta, 180)
+
+	cells := geohashCellsInBox(minLat, maxLat, minLng, maxLng, geoPrefixPrecision)
+	if len(cells) > maxGeoPrefixCells {
+		cells = geohashCellsInBox(minLat, maxLat, minLng, maxLng, geoPartitionPrecision)
+	}
+	return cells
+}
+
+// geohashCellsInBox returns the cells of a precision that a bounding box overlaps. Points are
+// sampled a cell apart, plus the box's far edges, so every row and column of cells is hit.
+func geohashCellsInBox(minLat, maxLat, minLng, maxLng float64, precision int) []string {
+	cellLat, cellLng := geohashCellSize(precision)
+	steps := func(from, to, step float64) []float64 {
+		var points []float64
+		for point := from; point < to; point += step {
+			points = append(points, point)
+		}
+		return append(points, to)
+	}
+
+	seen := make(map[string]bool)
+	var cells []string
+	for _, lat := range steps(minLat, maxLat, cellLat) {
+		for _, lng := range steps(minLng, maxLng, cellLng) {
+			cell := EncodeGeohash(lat, lng, precision)
+			if !seen[cell] {
+				seen[cell] = true
+				cells = append(cells, cell)
+			}
+		}
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+// GeoEntryForActivity returns the geo entry of a published activity, or nil when its location
+// has no coordinates
+func GeoEntryForActivity(activity *models.Activity, eventID string) *models.GeoEntry {
+	coordinates := activity.Location.Coordinates
+	if !hasCoordinates(coordinates) {
+		return nil
+	}
+
+	geohash := EncodeGeohash(coordinates.Lat, coordinates.Lng, locationGeohashPrecision)
+	return &models.GeoEntry{
+		PK:         models.CreateEventPK(activity.ID),
+		SK:         models.SortKeyGeo,
+		ActivityID: activity.ID,
+		EventID:    eventID,
+		Title:      activity.Title,
+		Lat:        coordinates.Lat,
+		Lng:        coordinates.Lng,
+		GeoCellKey: models.GenerateGeoCellKey(geohash[:geoPartitionPrecision]),
+		GeoSortKey: geohash + "#" + activity.ID,
+	}
+}
+
+// NearestGeoEntries keeps the entries within a radius of a point, nearest first
+func NearestGeoEntries(entries []models.GeoEntry, center models.Coordinates, radiusKm float64) []models.GeoEntry {
+	type nearby struct {
+		entry models.GeoEntry
+		km    float64
+	}
+	var within []nearby
+	for _, entry := range entries {
+		if km := haversineKm(center, entry.Coordinates()); km <= radiusKm {
+			within = append(within, nearby{entry, km})
+		}
+	}
+	sort.SliceStable(within, func(i, j int) bool {
+		if within[i].km != within[j].km {
+			return within[i].km < within[j].km
+		}
+		return within[i].entry.ActivityID < within[j].entry.ActivityID
+	})
+
+	nearest := make([]models.GeoEntry, len(within))
+	for i := range within {
+		nearest[i] = within[i].entry
+	}
+	return nearest
+}
+
+// WithinRadius keeps the activities held within a radius of a point, setting how far away each
+// is. Activities without coordinates are left out.
+func WithinRadius(activities []*models.PublicActivity, center models.Coordinates, radiusKm float64) []*models.PublicActivity {
+	filtered := []*models.PublicActivity{}
+	for _, activity := range activities {
+		if !hasCoordinates(activity.Location.Coordinates) {
+			continue
+		}
+		km := haversineKm(center, activity.Location.Coordinates)
+		if km > radiusKm {
+			continue
+		}
+		km = math.Round(km*100) / 100
+		activity.DistanceKm = &km
+		filtered = append(filtered, activity)
+	}
+	return filtered
+}
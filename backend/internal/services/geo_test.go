@@ -0,0 +1,105 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestGeohashCellsWithin(t *testing.T) {
+	fremont := models.Coordinates{Lat: 47.6505, Lng: -122.3493}
+
+	cells := GeohashCellsWithin(fremont, 2)
+	if len(cells) == 0 || len(cells) > maxGeoPrefixCells {
+		t.Fatalf("Expected 1-%d cells for a 2 km radius, got %v", maxGeoPrefixCells, cells)
+	}
+	center := EncodeGeohash(fremont.Lat, fremont.Lng, geoPrefixPrecision)
+	found := false
+	for _, cell := range cells {
+		if len(cell) != geoPrefixPrecision {
+			t.Errorf("Expected %d-character cells, got %q", geoPrefixPrecision, cell)
+		}
+		found = found || cell == center
+	}
+	if !found {
+		t.Errorf("Expected the center cell %s among %v", center, cells)
+	}
+
+	// A point at the edge of the radius falls in one of the cells
+	north := models.Coordinates{Lat: fremont.Lat + 1.9/kmPerDegreeLat, Lng: fremont.Lng}
+	edge := EncodeGeohash(north.Lat, north.Lng, geoPrefixPrecision)
+	found = false
+	for _, cell := range cells {
+		found = found || cell == edge
+	}
+	if !found {
+		t.Errorf("Expected the edge cell %s among %v", edge, cells)
+	}
+
+	// Large radii query whole partitions
+	for _, cell := range GeohashCellsWithin(fremont, models.MaxGeoRadiusKm) {
+		if len(cell) != geoPartitionPrecision {
+			t.Errorf("Expected %d-character cells for a large radius, got %q", geoPartitionPrecision, cell)
+		}
+	}
+}
+
+func TestGeoEntryForActivity(t *testing.T) {
+	activity := &models.Activity{
+		ID:       "act-1",
+		Title:    "Story time",
+		Location: models.Location{Coordinates: models.Coordinates{Lat: 47.6505, Lng: -122.3493}},
+	}
+
+	entry := GeoEntryForActivity(activity, "evt-1")
+	if entry == nil {
+		t.Fatal("Expected a geo entry")
+	}
+	geohash := EncodeGeohash(47.6505, -122.3493, locationGeohashPrecision)
+	if entry.PK != "EVENT#act-1" || entry.SK != models.SortKeyGeo || entry.EventID != "evt-1" {
+		t.Errorf("Unexpected entry keys %+v", entry)
+	}
+	if entry.GeoCellKey != "GEO#"+geohash[:4] || entry.GeoSortKey != geohash+"#act-1" {
+		t.Errorf("Unexpected index keys %q, %q", entry.GeoCellKey, entry.GeoSortKey)
+	}
+	if !strings.HasPrefix(entry.GeoSortKey, EncodeGeohash(47.6505, -122.3493, geoPrefixPrecision)) {
+		t.Errorf("Expected the sort key to start with the 5-character cell, got %q", entry.GeoSortKey)
+	}
+
+	activity.Location.Coordinates = models.Coordinates{}
+	if entry := GeoEntryForActivity(activity, "evt-1"); entry != nil {
+		t.Errorf("Expected no entry without coordinates, got %+v", entry)
+	}
+}
+
+func TestNearestGeoEntries(t *testing.T) {
+	center := models.Coordinates{Lat: 47.6505, Lng: -122.3493}
+	entries := []models.GeoEntry{
+		{ActivityID: "far", Lat: 47.7505, Lng: -122.3493},  // about 11 km
+		{ActivityID: "near", Lat: 47.6515, Lng: -122.3493}, // about 0.1 km
+		{ActivityID: "mid", Lat: 47.6705, Lng: -122.3493},  // about 2.2 km
+	}
+
+	nearest := NearestGeoEntries(entries, center, 5)
+	if len(nearest) != 2 || nearest[0].ActivityID != "near" || nearest[1].ActivityID != "mid" {
+		t.Errorf("Expected near then mid, got %+v", nearest)
+	}
+}
+
+func TestWithinRadius(t *testing.T) {
+	center := models.Coordinates{Lat: 47.6505, Lng: -122.3493}
+	activities := []*models.PublicActivity{
+		{Activity: models.Activity{ID: "mid", Location: models.Location{Coordinates: models.Coordinates{Lat: 47.6705, Lng: -122.3493}}}},
+		{Activity: models.Activity{ID: "unplaced"}},
+		{Activity: models.Activity{ID: "far", Location: models.Location{Coordinates: models.Coordinates{Lat: 47.7505, Lng: -122.3493}}}},
+	}
+
+	within := WithinRadius(activities, center, 5)
+	if len(within) != 1 || within[0].ID != "mid" {
+		t.Fatalf("Expected only mid, got %+v", within)
+	}
+	if within[0].DistanceKm == nil || *within[0].DistanceKm != 2.22 {
+		t.Errorf("Expected a distance of 2.22 km, got %v", within[0].DistanceKm)
+	}
+}
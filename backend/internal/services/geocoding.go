@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// GeocodeResult is a geocoding provider's best match for a place description
+type GeocodeResult struct {
+	Coordinates models.Coordinates
+	// Locality is the neighborhood or city of the match, when the provider reports one
+	Locality string
+}
+
+// Geocoder turns a place description, such as a street address, into coordinates. Geocode returns
+// nil without an error when there is no sufficiently confident match.
+type Geocoder interface {
+	Geocode(ctx context.Context, text string) (*GeocodeResult, error)
+}
+
+// Geocoding results less relevant than this are treated as not found, since a poor match would
+// place an activity in the wrong neighborhood
+const minGeocodeRelevance = 0.8
+
+// geocodeTimeout bounds a lookup made without a caller's deadline, e.g. during conversion
+const geocodeTimeout = 5 * time.Second
+
+// seattleCenter is where ambiguous place descriptions are resolved towards
+var seattleCenter = models.Coordinates{Lat: 47.6062, Lng: -122.3321}
+
+// NewGeocodingServiceFromEnv creates a caching geocoder for the provider named by
+// GEOCODING_PROVIDER: amazon-location (the default when GEOCODING_PLACE_INDEX is set), google,
+// mapbox or nominatim. Google and Mapbox take their key from GEOCODING_API_KEY. It returns nil
+// when no provider is configured. dynamo may be nil.
+func NewGeocodingServiceFromEnv(cfg aws.Config, dynamo *DynamoDBService) (*GeocodingService, error) {
+	provider := os.Getenv("GEOCODING_PROVIDER")
+	placeIndex := os.Getenv("GEOCODING_PLACE_INDEX")
+	apiKey := os.Getenv("GEOCODING_API_KEY")
+	if provider == "" && placeIndex != "" {
+		provider = models.GeocodingProviderAmazonLocation
+	}
+
+	var geocoder Geocoder
+	switch provider {
+	case "":
+		return nil, nil
+	case models.GeocodingProviderAmazonLocation:
+		if placeIndex == "" {
+			return nil, fmt.Errorf("GEOCODING_PLACE_INDEX is required for %s geocoding", provider)
+		}
+		geocoder = NewPlaceIndexGeocoder(cfg, placeIndex)
+	case models.GeocodingProviderGoogle:
+		if apiKey == "" {
+			return nil, fmt.Errorf("GEOCODING_API_KEY is required for %s geocoding", provider)
+		}
+		geocoder = NewGoogleGeocoder(apiKey)
+	case models.GeocodingProviderMapbox:
+		if apiKey == "" {
+			return nil, fmt.Errorf("GEOCODING_API_KEY is required for %s geocoding", provider)
+		}
+		geocoder = NewMapboxGeocoder(apiKey)
+	case models.GeocodingProviderNominatim:
+		geocoder = NewNominatimGeocoder()
+	default:
+		return nil, fmt.Errorf("unknown geocoding provider %q: must be one of %s, %s, %s, %s", provider,
+			models.GeocodingProviderAmazonLocation, models.GeocodingProviderGoogle, models.GeocodingProviderMapbox, models.GeocodingProviderNominatim)
+	}
+	return NewGeocodingService(geocoder, provider, dynamo), nil
+}
+
+// maxMemoizedGeocodes bounds the answers a GeocodingService keeps in memory
+const maxMemoizedGeocodes = 2048
+
+// GeocodingService geocodes with a provider, caching its answers in DynamoDB so each place
+// description is looked up once, and in memory so repeated conversions in a Lambda instance do not
+// read the cache again. Descriptions that could not be placed are cached too.
+type GeocodingService struct {
+	provider     Geocoder
+	providerName string
+	dynamo       *DynamoDBService // nil disables the DynamoDB cache
+
+	mu       sync.Mutex
+	memoized map[string]*GeocodeResult
+}
+
+// NewGeocodingService creates a caching geocoder. providerName keys the cache, so the answers of
+// different providers are kept apart. dynamo may be nil.
+func NewGeocodingService(provider Geocoder, providerName string, dynamo *DynamoDBService) *GeocodingService {
+	return &GeocodingService{
+		provider:     provider,
+		providerName: providerName,
+		dynamo:       dynamo,
+		memoized:     make(map[string]*GeocodeResult),
+	}
+}
+
+// Geocode returns the cached answer for a place description, asking the provider on a cache miss.
+// A cache that cannot be read or written is logged and bypassed.
+func (g *GeocodingService) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	key := models.CreateGeocodeCachePK(g.providerName, text)
+	g.mu.Lock()
+	result, ok := g.memoized[key]
+	g.mu.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	if g.dynamo != nil {
+		entry, err := g.dynamo.GetGeocodeCacheEntry(ctx, g.providerName, text)
+		if err != nil {
+			log.Printf("Warning: Failed to read the geocode cache: %v", err)
+		} else if entry != nil {
+			if entry.Found {
+				result = &GeocodeResult{Coordinates: models.Coordinates{Lat: entry.Lat, Lng: entry.Lng}, Locality: entry.Locality}
+			}
+			g.memoize(key, result)
+			return result, nil
+		}
+	}
+
+	result, err := g.provider.Geocode(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	g.memoize(key, result)
+
+	if g.dynamo != nil {
+		entry := &models.GeocodeCacheEntry{Provider: g.providerName, Text: text, Found: result != nil}
+		if result != nil {
+			entry.Lat, entry.Lng, entry.Locality = result.Coordinates.Lat, result.Coordinates.Lng, result.Locality
+		}
+		if err := g.dynamo.PutGeocodeCacheEntry(ctx, entry); err != nil {
+			log.Printf("Warning: Failed to cache the geocode of %q: %v", text, err)
+		}
+	}
+	return result, nil
+}
+
+func (g *GeocodingService) memoize(key string, result *GeocodeResult) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.memoized) >= maxMemoizedGeocodes {
+		g.memoized = make(map[string]*GeocodeResult)
+	}
+	g.memoized[key] = result
+}
+
+// GeocodeLocation sets the coordinates of a location that has none from its address, or from its
+// name and city when it has no address, and its region when the match's locality is in one. It
+// returns what was geocoded, or nil when coordinates were not set.
+func GeocodeLocation(ctx context.Context, geocoder Geocoder, location *models.Location) (*models.GeocodedLocation, error) {
+	if hasCoordinates(location.Coordinates) {
+		return nil, nil
+	}
+	text := geocodingText(*location)
+	if text == "" {
+		return nil, nil
+	}
+
+	result, err := geocoder.Geocode(ctx, text)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	geocoded := &models.GeocodedLocation{
+		Text:        text,
+		Coordinates: result.Coordinates,
+		Region:      RegionOfLocality(result.Locality),
+	}
+	ApplyGeocodedLocation(location, geocoded)
+	return geocoded, nil
+}
+
+// ApplyGeocodedLocation sets a location's coordinates, and its region when one was found
+func ApplyGeocodedLocation(location *models.Location, geocoded *models.GeocodedLocation) {
+	location.Coordinates = geocoded.Coordinates
+	if geocoded.Region != "" {
+		location.Region = geocoded.Region
+	}
+}
+
+// RegionOfLocality returns the name of the weekend planner region a neighborhood or city is in,
+// e.g. "North Seattle" for Ballard, or "" when it is in none
+func RegionOfLocality(locality string) string {
+	if strings.TrimSpace(locality) == "" {
+		return ""
+	}
+	for _, region := range PlannerRegions() {
+		if inPlannerRegion(models.Location{City: locality}, region) {
+			words := strings.Split(region, "-")
+			for i, word := range words {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+			return strings.Join(words, " ")
+		}
+	}
+	return ""
+}
+
+// geocodingText describes a location for geocoding, or returns "" when it says too little to be
+// placed reliably
+func geocodingText(location models.Location) string {
+	var parts []string
+	switch {
+	case strings.TrimSpace(location.Address) != "":
+		parts = append(parts, location.Address)
+	case strings.TrimSpace(location.Name) != "" && strings.TrimSpace(location.City) != "":
+		parts = append(parts, location.Name)
+	default:
+		return ""
+	}
+
+	for _, part := range []string{location.City, location.State, location.ZipCode} {
+		part = strings.TrimSpace(part)
+		// Addresses often already end with the city, state and zip code
+		if part != "" && !containsPhrase(strings.ToLower(parts[0]), strings.ToLower(part)) {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
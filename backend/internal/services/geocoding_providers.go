@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// geocodingHTTPTimeout bounds each request to a geocoding provider
+const geocodingHTTPTimeout = 5 * time.Second
+
+// PlaceIndexGeocoder geocodes with an Amazon Location Service place index
+type PlaceIndexGeocoder struct {
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+}
+
+// NewPlaceIndexGeocoder creates a geocoder that searches a place index
+func NewPlaceIndexGeocoder(cfg aws.Config, placeIndex string) *PlaceIndexGeocoder {
+	return &PlaceIndexGeocoder{
+		endpoint:    fmt.Sprintf("https://places.geo.%s.amazonaws.com/places/v0/indexes/%s/search/text", cfg.Region, url.PathEscape(placeIndex)),
+		region:      cfg.Region,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient:  &http.Client{Timeout: geocodingHTTPTimeout},
+	}
+}
+
+type placeSearchRequest struct {
+	Text            string    `json:"Text"`
+	BiasPosition    []float64 `json:"BiasPosition"`
+	FilterCountries []string  `json:"FilterCountries"`
+	MaxResults      int       `json:"MaxResults"`
+}
+
+type placeSearchResponse struct {
+	Results []struct {
+		Place struct {
+			Label        string `json:"Label"`
+			Municipality string `json:"Municipality"`
+			Neighborhood string `json:"Neighborhood"`
+			Geometry     struct {
+				Point []float64 `json:"Point"` // [lng, lat]
+			} `json:"Geometry"`
+		} `json:"Place"`
+		Relevance float64 `json:"Relevance"`
+	} `json:"Results"`
+}
+
+// Geocode returns the best match for a place description in the US, resolved towards Seattle
+func (g *PlaceIndexGeocoder) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	payload, err := json.Marshal(placeSearchRequest{
+		Text:            text,
+		BiasPosition:    []float64{seattleCenter.Lng, seattleCenter.Lat},
+		FilterCountries: []string{"USA"},
+		MaxResults:      1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal place search request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create place search request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	credentials, err := g.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	err = g.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), "geo", g.region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign place search request: %w", err)
+	}
+
+	var result placeSearchResponse
+	if err := doGeocodingRequest(g.httpClient, req, "place search", &result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) == 0 || result.Results[0].Relevance < minGeocodeRelevance || len(result.Results[0].Place.Geometry.Point) != 2 {
+		return nil, nil
+	}
+
+	place := result.Results[0].Place
+	return &GeocodeResult{
+		Coordinates: models.Coordinates{Lat: place.Geometry.Point[1], Lng: place.Geometry.Point[0]},
+		Locality:    firstNonEmpty(place.Neighborhood, place.Municipality),
+	}, nil
+}
+
+// GoogleGeocoder geocodes with the Google Maps Geocoding API
+type GoogleGeocoder struct {
+	endpoint   string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewGoogleGeocoder creates a geocoder that calls the Google Maps Geocoding API with an API key
+func NewGoogleGeocoder(apiKey string) *GoogleGeocoder {
+	return &GoogleGeocoder{
+		endpoint:   "https://maps.googleapis.com/maps/api/geocode/json",
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: geocodingHTTPTimeout},
+	}
+}
+
+type googleGeocodeResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		AddressComponents []struct {
+			LongName string   `json:"long_name"`
+			Types    []string `json:"types"`
+		} `json:"address_components"`
+		Geometry struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+			LocationType string `json:"location_type"` // ROOFTOP, RANGE_INTERPOLATED, GEOMETRIC_CENTER or APPROXIMATE
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+// Geocode returns the best match for a place description in the US. Approximate matches, such as
+// the center of a city, are treated as not found.
+func (g *GoogleGeocoder) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	params := url.Values{
+		"address":    {text},
+		"components": {"country:US"},
+		"bounds":     {seattleBounds},
+		"key":        {g.apiKey},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocode request: %w", err)
+	}
+
+	var result googleGeocodeResponse
+	if err := doGeocodingRequest(g.httpClient, req, "geocode", &result); err != nil {
+		return nil, err
+	}
+	switch result.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("geocode returned status %s: %s", result.Status, result.ErrorMessage)
+	}
+	if len(result.Results) == 0 || result.Results[0].Geometry.LocationType == "APPROXIMATE" {
+		return nil, nil
+	}
+
+	match := result.Results[0]
+	components := make(map[string]string)
+	for _, component := range match.AddressComponents {
+		for _, componentType := range component.Types {
+			if _, ok := components[componentType]; !ok {
+				components[componentType] = component.LongName
+			}
+		}
+	}
+	return &GeocodeResult{
+		Coordinates: models.Coordinates{Lat: match.Geometry.Location.Lat, Lng: match.Geometry.Location.Lng},
+		Locality:    firstNonEmpty(components["neighborhood"], components["locality"]),
+	}, nil
+}
+
+// seattleBounds is the Seattle area as south-west|north-east corners, which Google prefers matches in
+const seattleBounds = "47.3,-122.6|47.9,-121.9"
+
+// MapboxGeocoder geocodes with the Mapbox Geocoding API
+type MapboxGeocoder struct {
+	endpoint    string
+	accessToken string
+	httpClient  *http.Client
+}
+
+// NewMapboxGeocoder creates a geocoder that calls the Mapbox Geocoding API with an access token
+func NewMapboxGeocoder(accessToken string) *MapboxGeocoder {
+	return &MapboxGeocoder{
+		endpoint:    "https://api.mapbox.com/geocoding/v5/mapbox.places",
+		accessToken: accessToken,
+		httpClient:  &http.Client{Timeout: geocodingHTTPTimeout},
+	}
+}
+
+type mapboxGeocodeResponse struct {
+	Features []struct {
+		Center    []float64 `json:"center"` // [lng, lat]
+		Relevance float64   `json:"relevance"`
+		Context   []struct {
+			ID   string `json:"id"` // {type}.{id}, e.g. neighborhood.2103290
+			Text string `json:"text"`
+		} `json:"context"`
+	} `json:"features"`
+}
+
+// Geocode returns the best match for a place description in the US, resolved towards Seattle
+func (g *MapboxGeocoder) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	params := url.Values{
+		"access_token": {g.accessToken},
+		"country":      {"us"},
+		"limit":        {"1"},
+		"proximity":    {strconv.FormatFloat(seattleCenter.Lng, 'f', -1, 64) + "," + strconv.FormatFloat(seattleCenter.Lat, 'f', -1, 64)},
+	}
+	endpoint := g.endpoint + "/" + url.PathEscape(text) + ".json?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocode request: %w", err)
+	}
+
+	var result mapboxGeocodeResponse
+	if err := doGeocodingRequest(g.httpClient, req, "geocode", &result); err != nil {
+		return nil, err
+	}
+	if len(result.Features) == 0 || result.Features[0].Relevance < minGeocodeRelevance || len(result.Features[0].Center) != 2 {
+		return nil, nil
+	}
+
+	feature := result.Features[0]
+	var neighborhood, place string
+	for _, area := range feature.Context {
+		switch {
+		case neighborhood == "" && strings.HasPrefix(area.ID, "neighborhood."):
+			neighborhood = area.Text
+		case place == "" && strings.HasPrefix(area.ID, "place."):
+			place = area.Text
+		}
+	}
+	return &GeocodeResult{
+		Coordinates: models.Coordinates{Lat: feature.Center[1], Lng: feature.Center[0]},
+		Locality:    firstNonEmpty(neighborhood, place),
+	}, nil
+}
+
+// NominatimGeocoder geocodes with OpenStreetMap's Nominatim. Its usage policy allows one request a
+// second and requires an identifying User-Agent, so it suits backfills better than busy crawls.
+type NominatimGeocoder struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewNominatimGeocoder creates a geocoder that calls the public Nominatim service
+func NewNominatimGeocoder() *NominatimGeocoder {
+	return &NominatimGeocoder{
+		endpoint:   "https://nominatim.openstreetmap.org/search",
+		httpClient: &http.Client{Timeout: geocodingHTTPTimeout},
+	}
+}
+
+type nominatimPlace struct {
+	Lat     string `json:"lat"`
+	Lon     string `json:"lon"`
+	Address struct {
+		Neighbourhood string `json:"neighbourhood"`
+		Suburb        string `json:"suburb"`
+		City          string `json:"city"`
+		Town          string `json:"town"`
+	} `json:"address"`
+}
+
+// Geocode returns the best match for a place description in the US, preferring the Seattle area
+func (g *NominatimGeocoder) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	params := url.Values{
+		"q":              {text},
+		"format":         {"jsonv2"},
+		"addressdetails": {"1"},
+		"countrycodes":   {"us"},
+		"limit":          {"1"},
+		"viewbox":        {"-122.6,47.9,-121.9,47.3"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocode request: %w", err)
+	}
+	req.Header.Set("User-Agent", "seattle-family-activities-scraper")
+
+	var places []nominatimPlace
+	if err := doGeocodingRequest(g.httpClient, req, "geocode", &places); err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, nil
+	}
+
+	lat, latErr := strconv.ParseFloat(places[0].Lat, 64)
+	lng, lngErr := strconv.ParseFloat(places[0].Lon, 64)
+	if latErr != nil || lngErr != nil {
+		return nil, fmt.Errorf("geocode returned invalid coordinates %q, %q", places[0].Lat, places[0].Lon)
+	}
+	address := places[0].Address
+	return &GeocodeResult{
+		Coordinates: models.Coordinates{Lat: lat, Lng: lng},
+		Locality:    firstNonEmpty(address.Neighbourhood, address.Suburb, address.City, address.Town),
+	}, nil
+}
+
+// doGeocodingRequest sends a request to a geocoding provider and decodes its JSON response
+func doGeocodingRequest(client *http.Client, req *http.Request, operation string, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned status %d: %s", operation, resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", operation, err)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestPlaceIndexGeocoder(t *testing.T) {
+	t.Run("SignsAndParsesPoint", func(t *testing.T) {
+		var gotAuth string
+		var gotRequest placeSearchRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+				t.Errorf("Invalid JSON body: %v", err)
+			}
+			w.Write([]byte(`{"Results":[{"Place":{"Label":"1400 NW 56th St, Seattle","Municipality":"Seattle","Neighborhood":"Ballard","Geometry":{"Point":[-122.3751,47.6688]}},"Relevance":0.97}]}`))
+		}))
+		defer server.Close()
+
+		geocoder := NewPlaceIndexGeocoder(testAWSConfig(), "places")
+		geocoder.endpoint = server.URL
+
+		result, err := geocoder.Geocode(context.Background(), "1400 NW 56th St, Seattle")
+		if err != nil || result == nil {
+			t.Fatalf("Expected a match, got %+v, %v", result, err)
+		}
+		if result.Coordinates.Lat != 47.6688 || result.Coordinates.Lng != -122.3751 || result.Locality != "Ballard" {
+			t.Errorf("Unexpected result %+v", result)
+		}
+		if gotRequest.Text != "1400 NW 56th St, Seattle" || gotRequest.MaxResults != 1 {
+			t.Errorf("Unexpected request %+v", gotRequest)
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/geo/") {
+			t.Errorf("Expected SigV4 authorization for geo, got %q", gotAuth)
+		}
+	})
+
+	t.Run("IgnoresIrrelevantMatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"Results":[{"Place":{"Geometry":{"Point":[-122.3,47.6]}},"Relevance":0.5}]}`))
+		}))
+		defer server.Close()
+
+		geocoder := NewPlaceIndexGeocoder(testAWSConfig(), "places")
+		geocoder.endpoint = server.URL
+
+		result, err := geocoder.Geocode(context.Background(), "Somewhere")
+		if err != nil || result != nil {
+			t.Errorf("Expected no match, got %+v, %v", result, err)
+		}
+	})
+
+	t.Run("ReportsErrorStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Place index not found"}`))
+		}))
+		defer server.Close()
+
+		geocoder := NewPlaceIndexGeocoder(testAWSConfig(), "places")
+		geocoder.endpoint = server.URL
+
+		_, err := geocoder.Geocode(context.Background(), "Somewhere")
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestGoogleGeocoder(t *testing.T) {
+	var gotQuery map[string][]string
+	body := `{"status":"OK","results":[{"address_components":[{"long_name":"Fremont","types":["neighborhood","political"]},{"long_name":"Seattle","types":["locality","political"]}],"geometry":{"location":{"lat":47.6505,"lng":-122.3493},"location_type":"ROOFTOP"}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	geocoder := NewGoogleGeocoder("secret")
+	geocoder.endpoint = server.URL
+
+	result, err := geocoder.Geocode(context.Background(), "3400 Fremont Ave N, Seattle")
+	if err != nil || result == nil {
+		t.Fatalf("Expected a match, got %+v, %v", result, err)
+	}
+	if result.Coordinates.Lat != 47.6505 || result.Locality != "Fremont" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if gotQuery["key"][0] != "secret" || gotQuery["address"][0] != "3400 Fremont Ave N, Seattle" {
+		t.Errorf("Unexpected query %v", gotQuery)
+	}
+
+	// A city-wide match would place the activity at the city center
+	body = `{"status":"OK","results":[{"geometry":{"location":{"lat":47.6,"lng":-122.3},"location_type":"APPROXIMATE"}}]}`
+	if result, err := geocoder.Geocode(context.Background(), "Seattle"); err != nil || result != nil {
+		t.Errorf("Expected approximate matches to be ignored, got %+v, %v", result, err)
+	}
+
+	body = `{"status":"REQUEST_DENIED","error_message":"The provided API key is invalid."}`
+	if _, err := geocoder.Geocode(context.Background(), "Seattle"); err == nil || !strings.Contains(err.Error(), "REQUEST_DENIED") {
+		t.Errorf("Expected a denied error, got %v", err)
+	}
+}
+
+func TestMapboxGeocoder(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"features":[{"center":[-122.3846,47.6697],"relevance":0.9,"context":[{"id":"neighborhood.1","text":"Ballard"},{"id":"place.2","text":"Seattle"}]}]}`))
+	}))
+	defer server.Close()
+
+	geocoder := NewMapboxGeocoder("token")
+	geocoder.endpoint = server.URL
+
+	result, err := geocoder.Geocode(context.Background(), "5614 22nd Ave NW")
+	if err != nil || result == nil {
+		t.Fatalf("Expected a match, got %+v, %v", result, err)
+	}
+	if result.Coordinates.Lat != 47.6697 || result.Coordinates.Lng != -122.3846 || result.Locality != "Ballard" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if gotPath != "/5614 22nd Ave NW.json" {
+		t.Errorf("Expected the text in the path, got %q", gotPath)
+	}
+}
+
+func TestNominatimGeocoder(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`[{"lat":"47.5790","lon":"-122.4095","address":{"suburb":"Alki","city":"Seattle"}}]`))
+	}))
+	defer server.Close()
+
+	geocoder := NewNominatimGeocoder()
+	geocoder.endpoint = server.URL
+
+	result, err := geocoder.Geocode(context.Background(), "Alki Beach Park, Seattle")
+	if err != nil || result == nil {
+		t.Fatalf("Expected a match, got %+v, %v", result, err)
+	}
+	if result.Coordinates.Lat != 47.579 || result.Coordinates.Lng != -122.4095 || result.Locality != "Alki" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if gotUserAgent == "" || strings.HasPrefix(gotUserAgent, "Go-http-client") {
+		t.Errorf("Expected an identifying User-Agent, got %q", gotUserAgent)
+	}
+}
+
+type staticGeocoder struct {
+	result  *GeocodeResult
+	queries []string
+}
+
+func (g *staticGeocoder) Geocode(ctx context.Context, text string) (*GeocodeResult, error) {
+	g.queries = append(g.queries, text)
+	return g.result, nil
+}
+
+func TestGeocodingServiceMemoizes(t *testing.T) {
+	provider := &staticGeocoder{}
+	geocoder := NewGeocodingService(provider, models.GeocodingProviderNominatim, nil)
+
+	// Misses are remembered too, and descriptions differing in case and spacing share an answer
+	for _, text := range []string{"Nowhere Park, Seattle", "nowhere  park, seattle"} {
+		if result, err := geocoder.Geocode(context.Background(), text); err != nil || result != nil {
+			t.Errorf("Expected no match for %q, got %+v, %v", text, result, err)
+		}
+	}
+	if len(provider.queries) != 1 {
+		t.Errorf("Expected the provider to be asked once, got %v", provider.queries)
+	}
+}
+
+func TestGeocodeLocation(t *testing.T) {
+	geocoder := &staticGeocoder{result: &GeocodeResult{
+		Coordinates: models.Coordinates{Lat: 47.6688, Lng: -122.3751},
+		Locality:    "Ballard",
+	}}
+
+	location := models.Location{Address: "1400 NW 56th St, Seattle, WA", City: "Seattle", State: "WA", ZipCode: "98107", Region: "Seattle Metro"}
+	geocoded, err := GeocodeLocation(context.Background(), geocoder, &location)
+	if err != nil || geocoded == nil {
+		t.Fatalf("Expected the location to be geocoded, got %+v, %v", geocoded, err)
+	}
+	if geocoded.Text != "1400 NW 56th St, Seattle, WA, 98107" {
+		t.Errorf("Expected the address with its zip code, got %q", geocoded.Text)
+	}
+	if location.Coordinates != geocoder.result.Coordinates || location.Region != "North Seattle" {
+		t.Errorf("Expected the coordinates and region to be set, got %+v in %q", location.Coordinates, location.Region)
+	}
+
+	// Locations with coordinates, or too little to go on, are not geocoded
+	geocoder.queries = nil
+	for _, location := range []models.Location{
+		{Address: "Green Lake Park", Coordinates: models.Coordinates{Lat: 47.68, Lng: -122.33}},
+		{Name: "Community center"},
+	} {
+		geocoded, err := GeocodeLocation(context.Background(), geocoder, &location)
+		if err != nil || geocoded != nil {
+			t.Errorf("Expected %+v not to be geocoded, got %+v, %v", location, geocoded, err)
+		}
+	}
+	if len(geocoder.queries) != 0 {
+		t.Errorf("Expected no queries, got %v", geocoder.queries)
+	}
+}
+
+func TestRegionOfLocality(t *testing.T) {
+	tests := map[string]string{
+		"Ballard":  "North Seattle",
+		"Bellevue": "Eastside",
+		"Alki":     "West Seattle",
+		"Seattle":  "",
+		"Spokane":  "",
+		"":         "",
+	}
+	for locality, expected := range tests {
+		if got := RegionOfLocality(locality); got != expected {
+			t.Errorf("RegionOfLocality(%q) = %q, expected %q", locality, got, expected)
+		}
+	}
+}
+
+func TestGeocodingText(t *testing.T) {
+	tests := []struct {
+		location models.Location
+		expected string
+	}{
+		{models.Location{Address: "7400 Sand Point Way NE", City: "Seattle", State: "WA"}, "7400 Sand Point Way NE, Seattle, WA"},
+		{models.Location{Name: "Magnuson Park", City: "Seattle"}, "Magnuson Park, Seattle"},
+		{models.Location{Name: "Magnuson Park"}, ""},
+		{models.Location{}, ""},
+	}
+	for _, tt := range tests {
+		if got := geocodingText(tt.location); got != tt.expected {
+			t.Errorf("geocodingText(%+v) = %q, expected %q", tt.location, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LinkCheckResult is the outcome of checking a single URL
+type LinkCheckResult struct {
+	URL        string `json:"url"`
+	Healthy    bool   `json:"healthy"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+
+	// Inconclusive is set when the site could not be asked: the check was cancelled or the request
+	// failed at the network level. It says nothing about whether the link is broken.
+	Inconclusive bool `json:"inconclusive,omitempty"`
+}
+
+// LinkChecker checks URLs with HEAD requests, rate limited per host
+type LinkChecker struct {
+	httpClient      *http.Client
+	minHostInterval time.Duration
+
+	mu          sync.Mutex
+	lastRequest map[string]time.Time
+}
+
+// NewLinkChecker creates a link checker that waits at least minHostInterval between requests to the same host
+func NewLinkChecker(minHostInterval time.Duration) *LinkChecker {
+	return &LinkChecker{
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		minHostInterval: minHostInterval,
+		lastRequest:     make(map[string]time.Time),
+	}
+}
+
+// Check requests the URL and reports whether it is still reachable.
+// Sites that reject HEAD fall back to GET. Auth walls (401/403) and rate limiting (429)
+// prove the page exists, so they are treated as healthy rather than broken. A check that got no
+// response is inconclusive.
+func (c *LinkChecker) Check(ctx context.Context, rawURL string) LinkCheckResult {
+	result := LinkCheckResult{URL: rawURL}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		result.Error = "invalid URL"
+		return result
+	}
+
+	if err := c.waitForHost(ctx, parsed.Host); err != nil {
+		result.Error = err.Error()
+		result.Inconclusive = true
+		return result
+	}
+
+	statusCode, err := c.request(ctx, http.MethodHead, rawURL)
+	if err == nil && (statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented) {
+		statusCode, err = c.request(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Inconclusive = true
+		return result
+	}
+
+	result.StatusCode = statusCode
+	switch {
+	case statusCode < 400:
+		result.Healthy = true
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden, statusCode == http.StatusTooManyRequests:
+		result.Healthy = true
+	default:
+		result.Error = fmt.Sprintf("status %d", statusCode)
+	}
+
+	return result
+}
+
+// request performs a single request and returns the response status code
+func (c *LinkChecker) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; SeattleFamilyActivities-LinkChecker/1.0)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// waitForHost blocks until the host's rate limit allows another request
+func (c *LinkChecker) waitForHost(ctx context.Context, host string) error {
+	host = strings.ToLower(host)
+
+	c.mu.Lock()
+	next := c.lastRequest[host].Add(c.minHostInterval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	c.lastRequest[host] = next
+	c.mu.Unlock()
+
+	wait := time.Until(next)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestLinkChecker(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := NewLinkChecker(0)
+	ctx := context.Background()
+
+	tests := []struct {
+		path        string
+		wantHealthy bool
+		wantStatus  int
+	}{
+		{"/ok", true, http.StatusOK},
+		{"/gone", false, http.StatusNotFound},
+		{"/login", true, http.StatusForbidden},
+		{"/no-head", true, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := checker.Check(ctx, server.URL+tt.path)
+			if result.Healthy != tt.wantHealthy {
+				t.Errorf("Expected healthy=%v, got %v (error: %s)", tt.wantHealthy, result.Healthy, result.Error)
+			}
+			if result.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, result.StatusCode)
+			}
+		})
+	}
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		result := checker.Check(ctx, "mailto:someone@example.com")
+		if result.Healthy || result.Error == "" || result.Inconclusive {
+			t.Errorf("Expected invalid URL to be unhealthy with an error, got %+v", result)
+		}
+	})
+
+	t.Run("Gone", func(t *testing.T) {
+		if result := checker.Check(ctx, server.URL+"/gone"); result.Inconclusive {
+			t.Errorf("Expected a 404 to be conclusive, got %+v", result)
+		}
+	})
+
+	t.Run("NoResponseIsInconclusive", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		if result := checker.Check(ctx, closed.URL+"/ok"); result.Healthy || !result.Inconclusive {
+			t.Errorf("Expected a refused connection to be inconclusive, got %+v", result)
+		}
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if result := checker.Check(cancelled, server.URL+"/gone"); result.Healthy || !result.Inconclusive {
+			t.Errorf("Expected a cancelled check to be inconclusive, got %+v", result)
+		}
+	})
+}
+
+func TestLinkCheckerRateLimitsPerHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	interval := 50 * time.Millisecond
+	checker := NewLinkChecker(interval)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		checker.Check(context.Background(), server.URL)
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("Expected at least %v between requests to the same host, finished in %v", interval, elapsed)
+	}
+}
+
+func TestLinkHealthRecordCheck(t *testing.T) {
+	record := &models.LinkHealthRecord{URL: "https://example.com/register"}
+	now := time.Now()
+
+	record.RecordCheck(false, 404, "status 404", now)
+	if record.Status != models.LinkStatusFailing || record.IsBroken() {
+		t.Errorf("Expected first failure to mark link failing, got %s", record.Status)
+	}
+
+	record.RecordCheck(false, 404, "status 404", now.Add(24*time.Hour))
+	if !record.IsBroken() {
+		t.Errorf("Expected link broken after %d failures, got %s", models.LinkBrokenThreshold, record.Status)
+	}
+	if record.FirstFailedAt == nil || !record.FirstFailedAt.Equal(now) {
+		t.Errorf("Expected first failure time to be preserved")
+	}
+
+	record.RecordCheck(true, 200, "", now.Add(48*time.Hour))
+	if record.Status != models.LinkStatusHealthy || record.ConsecutiveFailures != 0 || record.FirstFailedAt != nil {
+		t.Errorf("Expected healthy check to reset failure state, got %+v", record)
+	}
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Geohash precisions. Locations carry a 7-character geohash (about 150 m across); neighborhoods
+// are indexed by the 5-character cells (about 5 km across) their polygons overlap.
+const (
+	locationGeohashPrecision  = 7
+	neighborhoodCellPrecision = 5
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeohash returns the geohash of a point with the given number of characters
+func EncodeGeohash(lat, lng float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+	bits, value := 0, 0
+	even := true // bits alternate between longitude and latitude, longitude first
+	for hash.Len() < precision {
+		span := &latRange
+		point := lat
+		if even {
+			span = &lngRange
+			point = lng
+		}
+		mid := (span[0] + span[1]) / 2
+		value <<= 1
+		if point >= mid {
+			value |= 1
+			span[0] = mid
+		} else {
+			span[1] = mid
+		}
+		even = !even
+
+		bits++
+		if bits == 5 {
+			hash.WriteByte(geohashAlphabet[value])
+			bits, value = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// geohashCellSize returns the height and width in degrees of the cells of a geohash precision
+func geohashCellSize(precision int) (lat, lng float64) {
+	bits := 5 * precision
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / float64(int(1)<<latBits), 360 / float64(int(1)<<lngBits)
+}
+
+// NeighborhoodLocator assigns activity locations to neighborhood clusters
+type NeighborhoodLocator interface {
+	AssignNeighborhood(location *models.Location)
+}
+
+// NeighborhoodIndex finds the neighborhood polygon a point falls in. Polygons are indexed by the
+// geohash cells they overlap, so a lookup only tests the few polygons near the point.
+type NeighborhoodIndex struct {
+	neighborhoods []models.Neighborhood
+	cells         map[string][]int // geohash cell -> indexes of neighborhoods overlapping it
+	bySlug        map[string]int
+}
+
+// NewNeighborhoodIndex indexes neighborhood polygons. Where polygons overlap, the one listed first wins.
+func NewNeighborhoodIndex(neighborhoods []models.Neighborhood) *NeighborhoodIndex {
+	index := &NeighborhoodIndex{
+		neighborhoods: neighborhoods,
+		cells:         make(map[string][]int),
+		bySlug:        make(map[string]int),
+	}
+	cellLat, cellLng := geohashCellSize(neighborhoodCellPrecision)
+	for i, neighborhood := range neighborhoods {
+		slug := neighborhood.Slug
+		if slug == "" {
+			slug = models.NeighborhoodSlug(neighborhood.Name)
+		}
+		index.bySlug[slug] = i
+		if len(neighborhood.Polygon) == 0 {
+			continue
+		}
+
+		minLat, maxLat := neighborhood.Polygon[0].Lat, neighborhood.Polygon[0].Lat
+		minLng, maxLng := neighborhood.Polygon[0].Lng, neighborhood.Polygon[0].Lng
+		for _, vertex := range neighborhood.Polygon[1:] {
+			minLat, maxLat = minFloat(minLat, vertex.Lat), maxFloat(maxLat, vertex.Lat)
+			minLng, maxLng = minFloat(minLng, vertex.Lng), maxFloat(maxLng, vertex.Lng)
+		}
+
+		// Sample the bounding box once per cell, including its far edges
+		seen := make(map[string]bool)
+		for lat := minLat; ; lat += cellLat {
+			lat = minFloat(lat, maxLat)
+			for lng := minLng; ; lng += cellLng {
+				lng = minFloat(lng, maxLng)
+				cell := EncodeGeohash(lat, lng, neighborhoodCellPrecision)
+				if !seen[cell] {
+					seen[cell] = true
+					index.cells[cell] = append(index.cells[cell], i)
+				}
+				if lng >= maxLng {
+					break
+				}
+			}
+			if lat >= maxLat {
+				break
+			}
+		}
+	}
+	return index
+}
+
+// Locate returns the neighborhood containing a point, or nil when none does
+func (x *NeighborhoodIndex) Locate(point models.Coordinates) *models.Neighborhood {
+	if x == nil {
+		return nil
+	}
+	for _, i := range x.cells[EncodeGeohash(point.Lat, point.Lng, neighborhoodCellPrecision)] {
+		if polygonContains(x.neighborhoods[i].Polygon, point) {
+			return &x.neighborhoods[i]
+		}
+	}
+	return nil
+}
+
+// Find returns the neighborhood with a name or slug, or nil when there is none
+func (x *NeighborhoodIndex) Find(nameOrSlug string) *models.Neighborhood {
+	if x == nil {
+		return nil
+	}
+	if i, ok := x.bySlug[models.NeighborhoodSlug(nameOrSlug)]; ok {
+		return &x.neighborhoods[i]
+	}
+	return nil
+}
+
+// Assign sets a location's geohash and neighborhood. A location with coordinates takes the
+// neighborhood its coordinates fall in; one without keeps the neighborhood it names, spelled the
+// way the lookup spells it.
+func (x *NeighborhoodIndex) Assign(location *models.Location) {
+	if hasCoordinates(location.Coordinates) {
+		location.Geohash = EncodeGeohash(location.Coordinates.Lat, location.Coordinates.Lng, locationGeohashPrecision)
+		if neighborhood := x.Locate(location.Coordinates); neighborhood != nil {
+			location.Neighborhood = neighborhood.Name
+			return
+		}
+	}
+	if location.Neighborhood != "" {
+		if neighborhood := x.Find(location.Neighborhood); neighborhood != nil {
+			location.Neighborhood = neighborhood.Name
+		}
+	}
+}
+
+// polygonContains reports whether a point is inside a polygon, by counting how many edges a ray
+// from the point crosses
+func polygonContains(polygon []models.Coordinates, point models.Coordinates) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) &&
+			point.Lng < (b.Lng-a.Lng)*(point.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// NeighborhoodCache serves the neighborhood lookup stored in DynamoDB, refreshing it after a TTL.
+// If the lookup cannot be loaded, the last loaded copy stays in effect. Locations without
+// coordinates take those of the registry venue they are held at.
+type NeighborhoodCache struct {
+	dynamo *DynamoDBService
+	venues *VenueRegistry
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	index    *NeighborhoodIndex
+	loadedAt time.Time
+}
+
+// NewNeighborhoodCache creates a neighborhood lookup cache backed by DynamoDB. venues may be nil.
+func NewNeighborhoodCache(dynamo *DynamoDBService, venues *VenueRegistry, ttl time.Duration) *NeighborhoodCache {
+	return &NeighborhoodCache{
+		dynamo: dynamo,
+		venues: venues,
+		ttl:    ttl,
+	}
+}
+
+// AssignNeighborhood sets a location's geohash and neighborhood from the current lookup
+func (c *NeighborhoodCache) AssignNeighborhood(location *models.Location) {
+	if !hasCoordinates(location.Coordinates) {
+		if coordinates, ok := c.venues.Coordinates(*location); ok {
+			location.Coordinates = coordinates
+		}
+	}
+	c.current().Assign(location)
+}
+
+// Find returns the neighborhood with a name or slug in the current lookup, or nil when there is none
+func (c *NeighborhoodCache) Find(nameOrSlug string) *models.Neighborhood {
+	return c.current().Find(nameOrSlug)
+}
+
+// current returns the cached index, loading it if the cached copy is stale
+func (c *NeighborhoodCache) current() *NeighborhoodIndex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.index
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lookup, err := c.dynamo.GetNeighborhoodLookup(ctx)
+	switch {
+	case err != nil:
+		log.Printf("Warning: Failed to load neighborhood lookup, keeping previous neighborhoods: %v", err)
+	case lookup == nil:
+		c.index = nil
+	default:
+		c.index = NewNeighborhoodIndex(lookup.Neighborhoods)
+	}
+
+	c.loadedAt = time.Now()
+	return c.index
+}
+
+// Invalidate drops the cached lookup so the next assignment reloads it
+func (c *NeighborhoodCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
+
+// extractCoordinates reads a location's coordinates from event data, given as latitude and
+// longitude fields or as a coordinates object. It returns zero coordinates when there are none.
+func (scs *SchemaConversionService) extractCoordinates(eventData map[string]interface{}) models.Coordinates {
+	lat, latOK := coordinateValue(eventData, "latitude", "lat")
+	lng, lngOK := coordinateValue(eventData, "longitude", "lng", "lon")
+	if !latOK || !lngOK {
+		nested, ok := eventData["coordinates"].(map[string]interface{})
+		if !ok {
+			return models.Coordinates{}
+		}
+		lat, latOK = coordinateValue(nested, "latitude", "lat")
+		lng, lngOK = coordinateValue(nested, "longitude", "lng", "lon")
+		if !latOK || !lngOK {
+			return models.Coordinates{}
+		}
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return models.Coordinates{}
+	}
+	return models.Coordinates{Lat: lat, Lng: lng}
+}
+
+// coordinateValue reads the first of the named fields holding a number or a numeric string
+func coordinateValue(data map[string]interface{}, names ...string) (float64, bool) {
+	for _, name := range names {
+		switch value := data[name].(type) {
+		case float64:
+			return value, true
+		case string:
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// rectangle returns a polygon spanning the given latitudes and longitudes
+func rectangle(minLat, minLng, maxLat, maxLng float64) []models.Coordinates {
+	return []models.Coordinates{
+		{Lat: maxLat, Lng: minLng}, {Lat: maxLat, Lng: maxLng}, {Lat: minLat, Lng: maxLng}, {Lat: minLat, Lng: minLng},
+	}
+}
+
+func TestEncodeGeohash(t *testing.T) {
+	if got := EncodeGeohash(57.64911, 10.40744, 11); got != "u4pruydqqvj" {
+		t.Errorf("Expected u4pruydqqvj, got %s", got)
+	}
+	if got := EncodeGeohash(47.6205, -122.3493, 5); got != "c22yz" {
+		t.Errorf("Expected the Space Needle in c22yz, got %s", got)
+	}
+}
+
+func TestNeighborhoodIndex(t *testing.T) {
+	index := NewNeighborhoodIndex([]models.Neighborhood{
+		{Name: "Ballard", Polygon: rectangle(47.655, -122.405, 47.695, -122.360)},
+		// A triangle, so the bounding box alone would misplace points
+		{Name: "Columbia City", Polygon: []models.Coordinates{{Lat: 47.570, Lng: -122.300}, {Lat: 47.570, Lng: -122.270}, {Lat: 47.540, Lng: -122.270}}},
+		// Spans many geohash cells
+		{Name: "Eastside", Slug: "the-eastside", Polygon: rectangle(47.50, -122.25, 47.80, -121.90)},
+	})
+
+	tests := []struct {
+		point models.Coordinates
+		want  string
+	}{
+		{models.Coordinates{Lat: 47.6687, Lng: -122.3847}, "Ballard"},
+		{models.Coordinates{Lat: 47.5650, Lng: -122.2750}, "Columbia City"},
+		{models.Coordinates{Lat: 47.5450, Lng: -122.2950}, ""}, // in the triangle's bounding box only
+		{models.Coordinates{Lat: 47.7900, Lng: -121.9100}, "Eastside"},
+		{models.Coordinates{Lat: 47.6205, Lng: -122.3493}, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if neighborhood := index.Locate(tt.point); neighborhood != nil {
+			got = neighborhood.Name
+		}
+		if got != tt.want {
+			t.Errorf("Locate(%v) = %q, want %q", tt.point, got, tt.want)
+		}
+	}
+
+	if neighborhood := index.Find("columbia city"); neighborhood == nil || neighborhood.Name != "Columbia City" {
+		t.Errorf("Expected to find Columbia City by name, got %+v", neighborhood)
+	}
+	if neighborhood := index.Find("the-eastside"); neighborhood == nil || neighborhood.Name != "Eastside" {
+		t.Errorf("Expected to find Eastside by slug, got %+v", neighborhood)
+	}
+}
+
+func TestNeighborhoodIndexAssign(t *testing.T) {
+	index := NewNeighborhoodIndex([]models.Neighborhood{
+		{Name: "Ballard", Polygon: rectangle(47.655, -122.405, 47.695, -122.360)},
+		{Name: "Columbia City", Polygon: rectangle(47.540, -122.300, 47.570, -122.270)},
+	})
+
+	located := models.Location{Neighborhood: "Fremont", Coordinates: models.Coordinates{Lat: 47.6687, Lng: -122.3847}}
+	index.Assign(&located)
+	if located.Neighborhood != "Ballard" || located.Geohash != EncodeGeohash(47.6687, -122.3847, 7) {
+		t.Errorf("Expected coordinates to place the location in Ballard, got %+v", located)
+	}
+
+	named := models.Location{Neighborhood: "columbia city"}
+	index.Assign(&named)
+	if named.Neighborhood != "Columbia City" || named.Geohash != "" {
+		t.Errorf("Expected the named neighborhood to be spelled as in the lookup, got %+v", named)
+	}
+
+	outside := models.Location{Neighborhood: "Fremont", Coordinates: models.Coordinates{Lat: 47.6510, Lng: -122.3500}}
+	index.Assign(&outside)
+	if outside.Neighborhood != "Fremont" || outside.Geohash == "" {
+		t.Errorf("Expected a location outside every polygon to keep its neighborhood, got %+v", outside)
+	}
+
+	var empty *NeighborhoodIndex
+	unindexed := models.Location{Coordinates: models.Coordinates{Lat: 47.6687, Lng: -122.3847}}
+	empty.Assign(&unindexed)
+	if unindexed.Geohash == "" || unindexed.Neighborhood != "" {
+		t.Errorf("Expected only a geohash without a lookup, got %+v", unindexed)
+	}
+}
+
+type staticNeighborhoods struct {
+	index *NeighborhoodIndex
+}
+
+func (s staticNeighborhoods) AssignNeighborhood(location *models.Location) {
+	s.index.Assign(location)
+}
+
+func TestConversionAssignsNeighborhood(t *testing.T) {
+	scs := NewSchemaConversionService()
+	scs.SetNeighborhoods(staticNeighborhoods{NewNeighborhoodIndex([]models.Neighborhood{
+		{Name: "Ballard", Polygon: rectangle(47.655, -122.405, 47.695, -122.360)},
+	})})
+
+	adminEvent := &models.AdminEvent{
+		EventID:    "test-neighborhood",
+		SourceURL:  "https://test.example.com",
+		SchemaType: "events",
+		RawExtractedData: map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{
+					"title":       "Story Time",
+					"date":        "2024-12-15",
+					"location":    "Ballard Library",
+					"coordinates": map[string]interface{}{"lat": 47.6687, "lng": "-122.3847"},
+				},
+			},
+		},
+		ExtractedAt: time.Now(),
+	}
+	result, err := scs.ConvertToActivity(adminEvent)
+	if err != nil || result.Activity == nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+	location := result.Activity.Location
+	if location.Coordinates.Lat != 47.6687 || location.Coordinates.Lng != -122.3847 || location.Neighborhood != "Ballard" || location.Geohash == "" {
+		t.Errorf("Unexpected location: %+v", location)
+	}
+}
+
+func TestExtractCoordinates(t *testing.T) {
+	scs := NewSchemaConversionService()
+	tests := []struct {
+		data map[string]interface{}
+		want models.Coordinates
+	}{
+		{map[string]interface{}{"latitude": 47.6, "longitude": -122.3}, models.Coordinates{Lat: 47.6, Lng: -122.3}},
+		{map[string]interface{}{"lat": "47.6", "lon": " -122.3 "}, models.Coordinates{Lat: 47.6, Lng: -122.3}},
+		{map[string]interface{}{"latitude": 47.6}, models.Coordinates{}},
+		{map[string]interface{}{"latitude": 147.6, "longitude": -122.3}, models.Coordinates{}},
+	}
+	for _, tt := range tests {
+		if got := scs.extractCoordinates(tt.data); got != tt.want {
+			t.Errorf("extractCoordinates(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestVenueRegistryCoordinates(t *testing.T) {
+	venue := models.Venue{VenueName: "Ballard Library", Coordinates: models.Coordinates{Lat: 47.6687, Lng: -122.3847}}
+	venue.EntityID = "venue-1"
+	registry := NewVenueRegistry([]models.Venue{venue})
+
+	if coordinates, ok := registry.Coordinates(models.Location{Name: "ballard  library"}); !ok || coordinates != venue.Coordinates {
+		t.Errorf("Expected the venue's coordinates, got %v (%v)", coordinates, ok)
+	}
+	if _, ok := registry.Coordinates(models.Location{Name: "Fremont Library"}); ok {
+		t.Error("Expected no coordinates for a location outside the registry")
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// OpenDataPrefix is the public key prefix the open data catalog is published under
+const OpenDataPrefix = "open-data/"
+
+// OpenDataSchemaVersion is bumped whenever the columns or JSON fields of the dump change
+const OpenDataSchemaVersion = "1.0"
+
+// OpenDataLicense describes the terms the catalog is published under
+type OpenDataLicense struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Attribution string `json:"attribution"`
+}
+
+// DefaultOpenDataLicense is used unless the exporter is configured with another license
+var DefaultOpenDataLicense = OpenDataLicense{
+	Name:        "CC-BY-4.0",
+	URL:         "https://creativecommons.org/licenses/by/4.0/",
+	Attribution: "Seattle Family Activities",
+}
+
+// OpenDataMetadata is published alongside every catalog dump
+type OpenDataMetadata struct {
+	License         OpenDataLicense   `json:"license"`
+	SchemaVersion   string            `json:"schema_version"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+	TotalActivities int               `json:"total_activities"`
+	Sources         []string          `json:"sources"`
+	Coverage        string            `json:"coverage"`
+	Files           map[string]string `json:"files"` // format -> object key of the latest dump
+}
+
+// OpenDataCatalog is the JSON form of the catalog dump
+type OpenDataCatalog struct {
+	Metadata   OpenDataMetadata  `json:"metadata"`
+	Activities []models.Activity `json:"activities"`
+}
+
+// OpenDataCSVHeader lists the columns of the CSV dump in order
+var OpenDataCSVHeader = []string{
+	"id", "title", "description", "type", "category", "subcategory",
+	"schedule_type", "start_date", "end_date", "start_time", "end_time", "days_of_week",
+	"age_groups", "min_age", "max_age", "age_unit",
+	"location_name", "address", "city", "neighborhood", "latitude", "longitude",
+	"pricing_type", "cost", "currency",
+	"registration_url", "detail_url", "provider_name", "source_url", "source_domain", "updated_at",
+}
+
+// OpenDataExporter publishes the full catalog of approved activities as JSON and CSV
+type OpenDataExporter struct {
+	dynamo     *DynamoDBService
+	conversion *SchemaConversionService
+	store      *S3Store
+	license    OpenDataLicense
+}
+
+// NewOpenDataExporter creates an exporter that writes the catalog under OpenDataPrefix
+func NewOpenDataExporter(dynamo *DynamoDBService, conversion *SchemaConversionService, store *S3Store, license OpenDataLicense) *OpenDataExporter {
+	return &OpenDataExporter{
+		dynamo:     dynamo,
+		conversion: conversion,
+		store:      store,
+		license:    license,
+	}
+}
+
+// Export dumps every publishable approved activity to the latest keys and to a dated snapshot.
+// It returns the metadata of the dump.
+func (e *OpenDataExporter) Export(ctx context.Context, now time.Time) (*OpenDataMetadata, error) {
+	approvedEvents, err := e.dynamo.GetAllApprovedAdminEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approved events: %w", err)
+	}
+
+	output := BuildActivitiesOutput(VisibleAdminEvents(approvedEvents, now), e.conversion)
+	catalog := BuildOpenDataCatalog(output, e.license, now)
+
+	jsonBody, err := json.Marshal(catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal catalog: %w", err)
+	}
+	var csvBody bytes.Buffer
+	if err := WriteOpenDataCSV(&csvBody, catalog.Activities); err != nil {
+		return nil, fmt.Errorf("failed to write catalog CSV: %w", err)
+	}
+	metadataBody, err := json.Marshal(catalog.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal catalog metadata: %w", err)
+	}
+
+	// The dated snapshot is written first so the latest keys never point at a dump that has no archive
+	run := NewPublishRun(e.store, PublisherOpenData, OpenDataPrefix, now)
+	err = e.putDump(ctx, run, now, jsonBody, csvBody.Bytes(), metadataBody)
+	if _, err := run.Finish(ctx, err); err != nil {
+		return nil, err
+	}
+
+	return &catalog.Metadata, nil
+}
+
+// putDump writes the catalog files to the dated snapshot, then to the latest keys
+func (e *OpenDataExporter) putDump(ctx context.Context, run *PublishRun, now time.Time, jsonBody, csvBody, metadataBody []byte) error {
+	for _, dir := range []string{now.UTC().Format("2006-01-02") + "/", "latest/"} {
+		objects := []struct {
+			name        string
+			body        []byte
+			contentType string
+		}{
+			{"activities.json", jsonBody, "application/json"},
+			{"activities.csv", csvBody, "text/csv; charset=utf-8"},
+			{"metadata.json", metadataBody, "application/json"},
+		}
+		for _, object := range objects {
+			key := OpenDataPrefix + dir + object.name
+			if err := run.Put(ctx, key, object.body, object.contentType); err != nil {
+				return fmt.Errorf("failed to publish %s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// BuildOpenDataCatalog wraps the published feed with license and schema version metadata
+func BuildOpenDataCatalog(output *models.ActivitiesOutput, license OpenDataLicense, now time.Time) *OpenDataCatalog {
+	return &OpenDataCatalog{
+		Metadata: OpenDataMetadata{
+			License:         license,
+			SchemaVersion:   OpenDataSchemaVersion,
+			GeneratedAt:     now.UTC(),
+			TotalActivities: len(output.Activities),
+			Sources:         output.Metadata.Sources,
+			Coverage:        output.Metadata.Coverage,
+			Files: map[string]string{
+				"json":     OpenDataPrefix + "latest/activities.json",
+				"csv":      OpenDataPrefix + "latest/activities.csv",
+				"metadata": OpenDataPrefix + "latest/metadata.json",
+			},
+		},
+		Activities: output.Activities,
+	}
+}
+
+// WriteOpenDataCSV writes one row per activity using OpenDataCSVHeader.
+// Activities with several age groups list every category and span their combined age range.
+func WriteOpenDataCSV(w io.Writer, activities []models.Activity) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(OpenDataCSVHeader); err != nil {
+		return err
+	}
+
+	for _, activity := range activities {
+		var ageCategories []string
+		minAge, maxAge, ageUnit := "", "", ""
+		for i, group := range activity.AgeGroups {
+			ageCategories = append(ageCategories, group.Category)
+			if i == 0 {
+				minAge, maxAge, ageUnit = strconv.Itoa(group.MinAge), strconv.Itoa(group.MaxAge), group.Unit
+				continue
+			}
+			if group.Unit != ageUnit {
+				// Ranges in different units cannot be combined
+				minAge, maxAge, ageUnit = "", "", ""
+				continue
+			}
+			if current, err := strconv.Atoi(minAge); err == nil && group.MinAge < current {
+				minAge = strconv.Itoa(group.MinAge)
+			}
+			if current, err := strconv.Atoi(maxAge); err == nil && group.MaxAge > current {
+				maxAge = strconv.Itoa(group.MaxAge)
+			}
+		}
+
+		latitude, longitude := "", ""
+		if activity.Location.Coordinates.Lat != 0 || activity.Location.Coordinates.Lng != 0 {
+			latitude = strconv.FormatFloat(activity.Location.Coordinates.Lat, 'f', -1, 64)
+			longitude = strconv.FormatFloat(activity.Location.Coordinates.Lng, 'f', -1, 64)
+		}
+		cost := ""
+		if activity.Pricing.Cost > 0 {
+			cost = strconv.FormatFloat(activity.Pricing.Cost, 'f', 2, 64)
+		}
+
+		row := []string{
+			activity.ID, activity.Title, activity.Description, activity.Type, activity.Category, activity.Subcategory,
+			activity.Schedule.Type, activity.Schedule.StartDate, activity.Schedule.EndDate,
+			activity.Schedule.StartTime, activity.Schedule.EndTime, strings.Join(activity.Schedule.DaysOfWeek, ";"),
+			strings.Join(ageCategories, ";"), minAge, maxAge, ageUnit,
+			activity.Location.Name, activity.Location.Address, activity.Location.City, activity.Location.Neighborhood, latitude, longitude,
+			activity.Pricing.Type, cost, activity.Pricing.Currency,
+			activity.Registration.URL, activity.DetailURL, activity.Provider.Name, activity.Source.URL, activity.Source.Domain,
+			activity.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
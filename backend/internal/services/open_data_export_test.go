@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildOpenDataCatalog(t *testing.T) {
+	output := &models.ActivitiesOutput{
+		Metadata:   models.NewActivitiesMetadata(1, []string{"seattle.gov"}),
+		Activities: []models.Activity{{ID: "act-1", Title: "Toddler Story Time"}},
+	}
+	now := time.Date(2025, 7, 12, 4, 0, 0, 0, time.FixedZone("PDT", -7*3600))
+
+	catalog := BuildOpenDataCatalog(output, DefaultOpenDataLicense, now)
+
+	if catalog.Metadata.SchemaVersion != OpenDataSchemaVersion || catalog.Metadata.License.Name != "CC-BY-4.0" {
+		t.Errorf("Expected schema version and license metadata, got %+v", catalog.Metadata)
+	}
+	if !catalog.Metadata.GeneratedAt.Equal(now) || catalog.Metadata.GeneratedAt.Location() != time.UTC {
+		t.Errorf("Expected the generation time in UTC, got %v", catalog.Metadata.GeneratedAt)
+	}
+	if catalog.Metadata.TotalActivities != 1 || catalog.Metadata.Files["csv"] != "open-data/latest/activities.csv" {
+		t.Errorf("Unexpected catalog metadata: %+v", catalog.Metadata)
+	}
+}
+
+func TestWriteOpenDataCSV(t *testing.T) {
+	activities := []models.Activity{
+		{
+			ID:          "act-1",
+			Title:       "Story Time, Songs & Rhymes",
+			Description: "Stories \"and\" songs\nfor little ones",
+			Type:        models.TypeEvent,
+			Schedule:    models.Schedule{Type: models.ScheduleTypeRecurring, DaysOfWeek: []string{"monday", "thursday"}},
+			AgeGroups: []models.AgeGroup{
+				{Category: models.AgeGroupToddler, MinAge: 1, MaxAge: 3, Unit: "years"},
+				{Category: models.AgeGroupPreschool, MinAge: 3, MaxAge: 5, Unit: "years"},
+			},
+			Location: models.Location{Name: "Green Lake Library", Coordinates: models.Coordinates{Lat: 47.68, Lng: -122.33}},
+			Pricing:  models.Pricing{Type: models.PricingTypePaid, Cost: 12.5, Currency: "USD"},
+		},
+		{ID: "act-2", Title: "Free Splash Day", Pricing: models.Pricing{Type: models.PricingTypeFree}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOpenDataCSV(&buf, activities); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV back: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d records", len(records))
+	}
+
+	rowAt := func(index int) map[string]string {
+		row := make(map[string]string)
+		for i, column := range OpenDataCSVHeader {
+			row[column] = records[index][i]
+		}
+		return row
+	}
+
+	row := rowAt(1)
+	expected := map[string]string{
+		"title":        "Story Time, Songs & Rhymes",
+		"description":  "Stories \"and\" songs\nfor little ones",
+		"days_of_week": "monday;thursday",
+		"age_groups":   "toddler;preschool",
+		"min_age":      "1",
+		"max_age":      "5",
+		"latitude":     "47.68",
+		"cost":         "12.50",
+	}
+	for column, value := range expected {
+		if row[column] != value {
+			t.Errorf("Expected %s %q, got %q", column, value, row[column])
+		}
+	}
+
+	if free := rowAt(2); free["id"] != "act-2" || free["cost"] != "" || free["min_age"] != "" {
+		t.Errorf("Expected no cost or ages for the free activity, got %v", free)
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxPreflightRedirects is the longest redirect chain followed before giving up
+const maxPreflightRedirects = 10
+
+// certificateExpiryWarning is how close to expiry a certificate must be to raise a warning
+const certificateExpiryWarning = 14 * 24 * time.Hour
+
+// PreflightChecker verifies that a source URL is reachable before it is analyzed or scraped,
+// so DNS and TLS problems are diagnosed at submission time instead of surfacing as extraction failures
+type PreflightChecker struct {
+	timeout    time.Duration
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	tlsConfig  *tls.Config // nil uses the system roots
+}
+
+// NewPreflightChecker creates a pre-flight checker using the system resolver and certificate roots
+func NewPreflightChecker() *PreflightChecker {
+	return &PreflightChecker{
+		timeout:    10 * time.Second,
+		lookupHost: net.DefaultResolver.LookupHost,
+	}
+}
+
+// Check resolves DNS, validates the certificate chain for https URLs and follows redirects.
+// The returned result is never nil; Passed is false with a diagnosis if any stage fails.
+func (p *PreflightChecker) Check(ctx context.Context, rawURL string) *models.PreflightResult {
+	result := &models.PreflightResult{
+		CheckedAt: time.Now(),
+	}
+
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Hostname() == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return failPreflight(result, models.PreflightStageURL, fmt.Sprintf("%q is not a valid http(s) URL", rawURL))
+	}
+	host := parsed.Hostname()
+
+	// DNS
+	dnsCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	addresses, err := p.lookupHost(dnsCtx, host)
+	cancel()
+	if err != nil {
+		return failPreflight(result, models.PreflightStageDNS, describeDNSError(host, err))
+	}
+	result.ResolvedAddresses = addresses
+
+	// TLS
+	if parsed.Scheme == "https" {
+		if err := p.checkTLS(ctx, parsed, result); err != nil {
+			if !isTLSError(err) {
+				return failPreflight(result, models.PreflightStageHTTP, fmt.Sprintf("could not connect to %s: %v", host, err))
+			}
+			return failPreflight(result, models.PreflightStageTLS, describeTLSError(host, err))
+		}
+	}
+
+	// HTTP with redirects
+	p.checkHTTP(ctx, parsed, result)
+	return result
+}
+
+// failPreflight marks the result as failed at the given stage
+func failPreflight(result *models.PreflightResult, stage, diagnosis string) *models.PreflightResult {
+	result.Passed = false
+	result.FailureStage = stage
+	result.Diagnosis = diagnosis
+	return result
+}
+
+// checkTLS performs a verified TLS handshake and records the certificate details on the result
+func (p *PreflightChecker) checkTLS(ctx context.Context, target *url.URL, result *models.PreflightResult) error {
+	host := target.Hostname()
+	port := target.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	config := &tls.Config{ServerName: host}
+	if p.tlsConfig != nil {
+		config = p.tlsConfig.Clone()
+		config.ServerName = host
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: p.timeout},
+		Config:    config,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	result.TLSVersion = tls.VersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		expiry := leaf.NotAfter
+		result.CertificateIssuer = leaf.Issuer.CommonName
+		result.CertificateExpiry = &expiry
+		if time.Until(expiry) < certificateExpiryWarning {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("TLS certificate for %s expires on %s", host, expiry.Format("2006-01-02")))
+		}
+	}
+
+	return nil
+}
+
+// checkHTTP requests the URL, following redirects, and records the outcome on the result
+func (p *PreflightChecker) checkHTTP(ctx context.Context, target *url.URL, result *models.PreflightResult) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if p.tlsConfig != nil {
+		transport.TLSClientConfig = p.tlsConfig.Clone()
+	}
+
+	client := &http.Client{
+		Timeout:   p.timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxPreflightRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxPreflightRedirects)
+			}
+			result.RedirectChain = append(result.RedirectChain, req.URL.String())
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		failPreflight(result, models.PreflightStageHTTP, fmt.Sprintf("failed to build request: %v", err))
+		return
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; SeattleFamilyActivities-Preflight/1.0)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Redirects can lead to hosts with their own DNS or certificate problems
+		switch {
+		case isDNSError(err):
+			failPreflight(result, models.PreflightStageDNS, "redirect target could not be resolved: "+describeDNSError(redirectHost(result, target), err))
+		case isTLSError(err):
+			failPreflight(result, models.PreflightStageTLS, describeTLSError(redirectHost(result, target), err))
+		default:
+			failPreflight(result, models.PreflightStageHTTP, fmt.Sprintf("request to %s failed: %v", target.String(), err))
+		}
+		return
+	}
+	resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.FinalURL = resp.Request.URL.String()
+	if !strings.EqualFold(resp.Request.URL.Hostname(), target.Hostname()) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s redirects to a different host: %s", target.Hostname(), resp.Request.URL.Hostname()))
+	}
+
+	switch {
+	case resp.StatusCode < 400:
+		result.Passed = true
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusTooManyRequests:
+		// Bot protection often blocks plain HTTP clients but not the scraping service
+		result.Passed = true
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s returned status %d; the site may block automated requests", result.FinalURL, resp.StatusCode))
+	default:
+		failPreflight(result, models.PreflightStageHTTP, fmt.Sprintf("%s returned status %d", result.FinalURL, resp.StatusCode))
+	}
+}
+
+// redirectHost returns the host of the last URL requested
+func redirectHost(result *models.PreflightResult, target *url.URL) string {
+	if n := len(result.RedirectChain); n > 0 {
+		if last, err := url.Parse(result.RedirectChain[n-1]); err == nil {
+			return last.Hostname()
+		}
+	}
+	return target.Hostname()
+}
+
+// isDNSError reports whether err is a name resolution failure
+func isDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// isTLSError reports whether err is a certificate verification or handshake failure
+func isTLSError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var verification *tls.CertificateVerificationError
+	var record tls.RecordHeaderError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &invalid) || errors.As(err, &hostname) ||
+		errors.As(err, &verification) || errors.As(err, &record) || strings.Contains(err.Error(), "tls: ")
+}
+
+// describeDNSError explains a DNS lookup failure
+func describeDNSError(host string, err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		switch {
+		case dnsErr.IsNotFound:
+			return fmt.Sprintf("domain %s does not resolve (no such host); the domain may have expired or moved", host)
+		case dnsErr.IsTimeout:
+			return fmt.Sprintf("DNS lookup for %s timed out", host)
+		}
+	}
+	return fmt.Sprintf("DNS lookup for %s failed: %v", host, err)
+}
+
+// describeTLSError explains a TLS handshake failure
+func describeTLSError(host string, err error) string {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+
+	switch {
+	case errors.As(err, &hostname):
+		return fmt.Sprintf("TLS certificate for %s does not match the hostname: %v", host, err)
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		return fmt.Sprintf("TLS certificate for %s has expired or is not yet valid: %v", host, err)
+	case errors.As(err, &invalid):
+		return fmt.Sprintf("TLS certificate for %s is invalid: %v", host, err)
+	case errors.As(err, &unknownAuthority):
+		return fmt.Sprintf("TLS certificate for %s is not signed by a trusted authority (self-signed or incomplete chain): %v", host, err)
+	}
+	return fmt.Sprintf("TLS handshake with %s failed: %v", host, err)
+}
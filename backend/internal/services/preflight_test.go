@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func newTestPreflightChecker() *PreflightChecker {
+	checker := NewPreflightChecker()
+	checker.timeout = 5 * time.Second
+	return checker
+}
+
+func TestPreflightChecker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		result := newTestPreflightChecker().Check(ctx, "not a url")
+		if result.Passed || result.FailureStage != models.PreflightStageURL {
+			t.Errorf("Expected url stage failure, got %+v", result)
+		}
+	})
+
+	t.Run("DNSFailure", func(t *testing.T) {
+		checker := newTestPreflightChecker()
+		checker.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		result := checker.Check(ctx, "https://seattlefunforkids.example/events")
+		if result.Passed || result.FailureStage != models.PreflightStageDNS {
+			t.Fatalf("Expected dns stage failure, got %+v", result)
+		}
+		if !strings.Contains(result.Diagnosis, "does not resolve") {
+			t.Errorf("Expected diagnosis to explain the missing domain, got %q", result.Diagnosis)
+		}
+	})
+
+	t.Run("UntrustedCertificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		result := newTestPreflightChecker().Check(ctx, server.URL)
+		if result.Passed || result.FailureStage != models.PreflightStageTLS {
+			t.Fatalf("Expected tls stage failure, got %+v", result)
+		}
+		if !strings.Contains(result.Diagnosis, "trusted authority") {
+			t.Errorf("Expected diagnosis to mention the untrusted chain, got %q", result.Diagnosis)
+		}
+	})
+
+	t.Run("TrustedCertificateWithRedirects", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/calendar", http.StatusMovedPermanently)
+		})
+		mux.HandleFunc("/calendar", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		server := httptest.NewTLSServer(mux)
+		defer server.Close()
+
+		roots := x509.NewCertPool()
+		roots.AddCert(server.Certificate())
+		checker := newTestPreflightChecker()
+		checker.tlsConfig = &tls.Config{RootCAs: roots}
+
+		result := checker.Check(ctx, server.URL+"/")
+		if !result.Passed {
+			t.Fatalf("Expected preflight to pass, got %+v", result)
+		}
+		if result.CertificateExpiry == nil || result.TLSVersion == "" {
+			t.Errorf("Expected certificate details to be recorded, got %+v", result)
+		}
+		if len(result.RedirectChain) != 1 || !strings.HasSuffix(result.FinalURL, "/calendar") {
+			t.Errorf("Expected one redirect ending at /calendar, got chain %v final %s", result.RedirectChain, result.FinalURL)
+		}
+	})
+
+	t.Run("HTTPErrorStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		result := newTestPreflightChecker().Check(ctx, server.URL)
+		if result.Passed || result.FailureStage != models.PreflightStageHTTP || result.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected http stage failure with 404, got %+v", result)
+		}
+	})
+
+	t.Run("BotProtectionPassesWithWarning", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		result := newTestPreflightChecker().Check(ctx, server.URL)
+		if !result.Passed || len(result.Warnings) == 0 {
+			t.Errorf("Expected 403 to pass with a warning, got %+v", result)
+		}
+	})
+}
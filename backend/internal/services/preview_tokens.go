@@ -0,0 +1,71 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Preview token errors
+var (
+	ErrInvalidPreviewToken = errors.New("invalid preview token")
+	ErrPreviewTokenExpired = errors.New("preview token expired")
+)
+
+// PreviewTokenSigner signs and verifies preview tokens with a shared secret. A token is its
+// payload and an HMAC-SHA256 of the payload, both base64url encoded and joined by a dot.
+type PreviewTokenSigner struct {
+	secret []byte
+}
+
+// NewPreviewTokenSigner creates a signer for a secret. Changing the secret invalidates every
+// token signed with the old one.
+func NewPreviewTokenSigner(secret string) *PreviewTokenSigner {
+	return &PreviewTokenSigner{secret: []byte(secret)}
+}
+
+// Sign returns the signed form of a token
+func (s *PreviewTokenSigner) Sign(token models.PreviewToken) string {
+	payload, _ := json.Marshal(token) // strings and numbers always encode
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify checks a signed token and returns it. It returns ErrInvalidPreviewToken when the token
+// was not signed with the secret, and ErrPreviewTokenExpired once it has expired at now.
+func (s *PreviewTokenSigner) Verify(value string, now time.Time) (*models.PreviewToken, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalidPreviewToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidPreviewToken
+	}
+	var token models.PreviewToken
+	if err := json.Unmarshal(payload, &token); err != nil || len(token.EventIDs) == 0 {
+		return nil, ErrInvalidPreviewToken
+	}
+	if !now.Before(time.Unix(token.ExpiresAt, 0)) {
+		return nil, ErrPreviewTokenExpired
+	}
+	return &token, nil
+}
+
+// mac returns the signature of an encoded payload
+func (s *PreviewTokenSigner) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
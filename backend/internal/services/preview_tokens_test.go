@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestPreviewTokenSigner(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer := NewPreviewTokenSigner("preview-secret")
+	token := models.PreviewToken{
+		EventIDs:  []string{"event-1", "event-2"},
+		Label:     "Winter break camps",
+		CreatedBy: "editor@example.com",
+		ExpiresAt: now.Add(48 * time.Hour).Unix(),
+	}
+	signed := signer.Sign(token)
+
+	t.Run("VerifiesSignedToken", func(t *testing.T) {
+		verified, err := signer.Verify(signed, now)
+		if err != nil {
+			t.Fatalf("Expected the signed token to verify, got %v", err)
+		}
+		if strings.Join(verified.EventIDs, ",") != "event-1,event-2" || verified.Label != token.Label || verified.CreatedBy != token.CreatedBy {
+			t.Errorf("Unexpected verified token %+v", verified)
+		}
+	})
+
+	t.Run("RefusesExpiredToken", func(t *testing.T) {
+		if _, err := signer.Verify(signed, now.Add(48*time.Hour)); !errors.Is(err, ErrPreviewTokenExpired) {
+			t.Errorf("Expected ErrPreviewTokenExpired, got %v", err)
+		}
+	})
+
+	t.Run("RefusesOtherSecret", func(t *testing.T) {
+		if _, err := NewPreviewTokenSigner("other-secret").Verify(signed, now); !errors.Is(err, ErrInvalidPreviewToken) {
+			t.Errorf("Expected ErrInvalidPreviewToken, got %v", err)
+		}
+	})
+
+	t.Run("RefusesChangedPayload", func(t *testing.T) {
+		// A reviewer adding an event to their own token breaks the signature
+		widened := token
+		widened.EventIDs = append(widened.EventIDs, "event-3")
+		payload, _, _ := strings.Cut(signer.Sign(widened), ".")
+		_, signature, _ := strings.Cut(signed, ".")
+		if _, err := signer.Verify(payload+"."+signature, now); !errors.Is(err, ErrInvalidPreviewToken) {
+			t.Errorf("Expected ErrInvalidPreviewToken, got %v", err)
+		}
+	})
+
+	t.Run("RefusesMalformedTokens", func(t *testing.T) {
+		for _, malformed := range []string{"", "no-dot", ".", signed + "x", "!!!." + strings.SplitN(signed, ".", 2)[1]} {
+			if _, err := signer.Verify(malformed, now); !errors.Is(err, ErrInvalidPreviewToken) {
+				t.Errorf("Expected %q to be refused, got %v", malformed, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ErrConnectionGone is returned when a WebSocket client has already disconnected
+var ErrConnectionGone = errors.New("websocket connection is gone")
+
+// ConnectionPoster pushes messages to connected WebSocket clients
+type ConnectionPoster interface {
+	PostToConnection(ctx context.Context, connectionID string, data []byte) error
+}
+
+// WebSocketPoster posts messages through the API Gateway WebSocket management API
+type WebSocketPoster struct {
+	endpoint  string
+	requester *awsRequester
+}
+
+// NewWebSocketPoster creates a poster for the given management endpoint
+// (https://{api-id}.execute-api.{region}.amazonaws.com/{stage})
+func NewWebSocketPoster(cfg aws.Config, endpoint string) *WebSocketPoster {
+	return &WebSocketPoster{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		requester: newAWSRequester(cfg, "execute-api", cfg.Region, 5*time.Second),
+	}
+}
+
+// PostToConnection sends data to a single WebSocket connection
+func (p *WebSocketPoster) PostToConnection(ctx context.Context, connectionID string, data []byte) error {
+	requestURL := p.endpoint + "/@connections/" + url.PathEscape(connectionID)
+	_, err := p.requester.post(ctx, "post to connection", requestURL, map[string]string{
+		"Content-Type": "application/json",
+	}, data)
+	var statusErr *awsStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusGone {
+		return ErrConnectionGone
+	}
+	return err
+}
+
+// ProgressReporter records stage progress for long-running admin jobs and pushes it to subscribed clients
+type ProgressReporter struct {
+	dynamo *DynamoDBService
+	poster ConnectionPoster
+}
+
+// NewProgressReporter creates a progress reporter. poster may be nil, in which case progress is only persisted.
+func NewProgressReporter(dynamo *DynamoDBService, poster ConnectionPoster) *ProgressReporter {
+	return &ProgressReporter{
+		dynamo: dynamo,
+		poster: poster,
+	}
+}
+
+// Report records a stage transition for the job and notifies subscribers.
+// Progress reporting is best effort: failures are logged and never interrupt the job.
+func (r *ProgressReporter) Report(ctx context.Context, jobID, stage, message string, details map[string]interface{}) {
+	if r == nil || jobID == "" {
+		return
+	}
+
+	update := &models.ProgressUpdate{
+		JobID:   jobID,
+		Stage:   stage,
+		Message: message,
+		Details: details,
+	}
+
+	if r.dynamo != nil {
+		if err := r.dynamo.CreateProgressUpdate(ctx, update); err != nil {
+			log.Printf("Warning: Failed to store progress for job %s: %v", jobID, err)
+		}
+	}
+
+	if r.poster == nil || r.dynamo == nil {
+		return
+	}
+
+	connections, err := r.dynamo.GetProgressConnections(ctx, jobID)
+	if err != nil {
+		log.Printf("Warning: Failed to load progress subscribers for job %s: %v", jobID, err)
+		return
+	}
+	if len(connections) == 0 {
+		return
+	}
+
+	if update.CreatedAt.IsZero() {
+		update.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(update)
+	if err != nil {
+		log.Printf("Warning: Failed to marshal progress for job %s: %v", jobID, err)
+		return
+	}
+
+	r.broadcast(ctx, connections, data)
+}
+
+// Replay sends all progress recorded so far for a job to a single connection
+func (r *ProgressReporter) Replay(ctx context.Context, jobID, connectionID string) error {
+	if r.poster == nil || r.dynamo == nil {
+		return nil
+	}
+
+	updates, err := r.dynamo.GetProgressUpdates(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	for _, update := range updates {
+		data, err := json.Marshal(update)
+		if err != nil {
+			return fmt.Errorf("failed to marshal progress update: %w", err)
+		}
+		if err := r.poster.PostToConnection(ctx, connectionID, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// broadcast posts data to every connection, removing connections that have gone away
+func (r *ProgressReporter) broadcast(ctx context.Context, connections []models.ProgressConnection, data []byte) {
+	for _, connection := range connections {
+		err := r.poster.PostToConnection(ctx, connection.ConnectionID, data)
+		if errors.Is(err, ErrConnectionGone) {
+			if err := r.dynamo.DeleteProgressConnection(ctx, connection.ConnectionID); err != nil {
+				log.Printf("Warning: Failed to remove stale connection %s: %v", connection.ConnectionID, err)
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to push progress to connection %s: %v", connection.ConnectionID, err)
+		}
+	}
+}
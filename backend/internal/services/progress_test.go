@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func testAWSConfig() aws.Config {
+	return aws.Config{
+		Region: "us-west-2",
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+	}
+}
+
+func TestWebSocketPoster(t *testing.T) {
+	t.Run("SignsAndPostsToConnection", func(t *testing.T) {
+		var gotPath, gotAuth, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.EscapedPath()
+			gotAuth = r.Header.Get("Authorization")
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		poster := NewWebSocketPoster(testAWSConfig(), server.URL+"/prod/")
+		err := poster.PostToConnection(context.Background(), "abc=", []byte(`{"stage":"fetching"}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if gotPath != "/prod/@connections/abc=" {
+			t.Errorf("Unexpected request path: %s", gotPath)
+		}
+		if !strings.Contains(gotAuth, "AWS4-HMAC-SHA256") || !strings.Contains(gotAuth, "/us-west-2/execute-api/") {
+			t.Errorf("Expected SigV4 authorization for execute-api, got %q", gotAuth)
+		}
+		if gotBody != `{"stage":"fetching"}` {
+			t.Errorf("Unexpected request body: %s", gotBody)
+		}
+	})
+
+	t.Run("GoneConnection", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		poster := NewWebSocketPoster(testAWSConfig(), server.URL)
+		err := poster.PostToConnection(context.Background(), "abc", []byte(`{}`))
+		if !errors.Is(err, ErrConnectionGone) {
+			t.Errorf("Expected ErrConnectionGone, got %v", err)
+		}
+	})
+
+	t.Run("NilReporterIsSafe", func(t *testing.T) {
+		var reporter *ProgressReporter
+		reporter.Report(context.Background(), "job-1", "fetching", "Fetching", nil)
+
+		NewProgressReporter(nil, nil).Report(context.Background(), "job-1", "fetching", "Fetching", nil)
+	})
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"sort"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Public conversion outcomes recorded when an event is converted for the frontend on approval.
+// Each is also the name of its counter on the stored counts.
+const (
+	PublicConversionConverted     = "converted"     // converted and passed public payload validation
+	PublicConversionFailed        = "failed"        // the conversion service returned an error or no activity
+	PublicConversionUnpublishable = "unpublishable" // converted but would render incorrectly on the frontend
+)
+
+// PublicConversionStats counts how events from one source and schema type fared when converted
+// for the frontend on approval. A high failure rate means the conversion service or the schema
+// needs fixing for that source.
+type PublicConversionStats struct {
+	Source        string  `json:"source"`
+	SchemaType    string  `json:"schema_type"`
+	Converted     int     `json:"converted"`
+	Failed        int     `json:"failed"`
+	Unpublishable int     `json:"unpublishable"`
+	FailureRate   float64 `json:"failure_rate"` // share of conversions that were not served
+}
+
+// BuildPublicConversionStats turns the stored conversion counts into statistics, worst failure
+// rate first. Source and schema type pairs without any counted event are left out.
+func BuildPublicConversionStats(counts []models.PublicConversionCount) []PublicConversionStats {
+	stats := make([]PublicConversionStats, 0, len(counts))
+	for _, count := range counts {
+		s := PublicConversionStats{
+			Source:        count.Source,
+			SchemaType:    count.SchemaType,
+			Converted:     count.Converted,
+			Failed:        count.Failed,
+			Unpublishable: count.Unpublishable,
+		}
+		total := s.Converted + s.Failed + s.Unpublishable
+		if total <= 0 {
+			continue
+		}
+		s.FailureRate = float64(s.Failed+s.Unpublishable) / float64(total)
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FailureRate != stats[j].FailureRate {
+			return stats[i].FailureRate > stats[j].FailureRate
+		}
+		if stats[i].Source != stats[j].Source {
+			return stats[i].Source < stats[j].Source
+		}
+		return stats[i].SchemaType < stats[j].SchemaType
+	})
+	return stats
+}
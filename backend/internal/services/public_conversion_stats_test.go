@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildPublicConversionStats(t *testing.T) {
+	stats := BuildPublicConversionStats([]models.PublicConversionCount{
+		{Source: "seattle.gov", SchemaType: "events", Converted: 2},
+		{Source: "parentmap.com", SchemaType: "events", Converted: 1, Failed: 1, Unpublishable: 1},
+		{Source: "parentmap.com", SchemaType: "camps", Converted: 1},
+		// Every event moved on to another outcome
+		{Source: "kidsoutandabout.com", SchemaType: "events"},
+	})
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 source and schema type pairs, got %+v", stats)
+	}
+
+	worst := stats[0]
+	if worst.Source != "parentmap.com" || worst.SchemaType != "events" {
+		t.Fatalf("Expected parentmap.com events to have the worst failure rate, got %+v", worst)
+	}
+	if worst.Converted != 1 || worst.Failed != 1 || worst.Unpublishable != 1 {
+		t.Errorf("Unexpected parentmap.com counts: %+v", worst)
+	}
+	if worst.FailureRate < 0.66 || worst.FailureRate > 0.67 {
+		t.Errorf("Expected a failure rate of 2/3, got %f", worst.FailureRate)
+	}
+
+	for _, s := range stats[1:] {
+		if s.FailureRate != 0 {
+			t.Errorf("Expected no failures for %s %s, got %+v", s.Source, s.SchemaType, s)
+		}
+	}
+}
+
+// publicConversionServer stands in for DynamoDB, keeping event outcomes and applying the counter
+// updates of conversion transactions
+func publicConversionServer(t *testing.T) (*DynamoDBService, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	outcomes := make(map[string]json.RawMessage) // outcome items by PK
+	counts := make(map[string]int)               // by counter SK and outcome
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+		type attributes map[string]map[string]string
+		switch target := r.Header.Get("X-Amz-Target"); {
+		case strings.HasSuffix(target, ".GetItem"):
+			var request struct{ Key attributes }
+			json.Unmarshal(body, &request)
+			if item, ok := outcomes[request.Key["PK"]["S"]]; ok {
+				w.Write([]byte(`{"Item":` + string(item) + `}`))
+				return
+			}
+			w.Write([]byte("{}"))
+		case strings.HasSuffix(target, ".TransactWriteItems"):
+			var request struct {
+				TransactItems []struct {
+					Put *struct {
+						Item json.RawMessage
+					}
+					Update *struct {
+						Key                       attributes
+						ExpressionAttributeNames  map[string]string
+						ExpressionAttributeValues attributes
+					}
+				}
+			}
+			json.Unmarshal(body, &request)
+			for _, item := range request.TransactItems {
+				if item.Put != nil {
+					var key struct{ PK map[string]string }
+					json.Unmarshal(item.Put.Item, &key)
+					outcomes[key.PK["S"]] = item.Put.Item
+				}
+				if item.Update != nil {
+					for name, outcome := range item.Update.ExpressionAttributeNames {
+						if !strings.HasPrefix(name, "#outcome") {
+							continue
+						}
+						delta, _ := strconv.Atoi(item.Update.ExpressionAttributeValues[":delta"+strings.TrimPrefix(name, "#outcome")]["N"])
+						counts[item.Update.Key["SK"]["S"]+" "+outcome] += delta
+					}
+				}
+			}
+			w.Write([]byte("{}"))
+		default:
+			t.Errorf("Unexpected request %s", target)
+			w.Write([]byte("{}"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testAWSConfig()
+	cfg.BaseEndpoint = aws.String(server.URL)
+	return NewDynamoDBService(dynamodb.NewFromConfig(cfg), "activities", "sources", "operations", "admin-events"), counts
+}
+
+func TestRecordPublicConversion(t *testing.T) {
+	service, counts := publicConversionServer(t)
+	ctx := context.Background()
+	record := func(eventID, source, schemaType, outcome string) {
+		t.Helper()
+		if err := service.RecordPublicConversion(ctx, eventID, source, schemaType, outcome); err != nil {
+			t.Fatalf("Expected outcome %s of %s to be recorded, got %v", outcome, eventID, err)
+		}
+	}
+
+	record("event-1", "parentmap.com", "events", PublicConversionFailed)
+	record("event-2", "parentmap.com", "events", PublicConversionFailed)
+	// An event approved after a failed attempt counts once, as converted
+	record("event-1", "parentmap.com", "events", PublicConversionConverted)
+	record("event-1", "parentmap.com", "events", PublicConversionConverted)
+	// An event re-extracted with another schema moves to that schema's counters
+	record("event-2", "parentmap.com", "camps", PublicConversionUnpublishable)
+
+	events := models.CreatePublicConversionCountSK("parentmap.com", "events")
+	camps := models.CreatePublicConversionCountSK("parentmap.com", "camps")
+	want := map[string]int{
+		events + " " + PublicConversionConverted:    1,
+		events + " " + PublicConversionFailed:       0,
+		camps + " " + PublicConversionUnpublishable: 1,
+	}
+	for counter, expected := range want {
+		if counts[counter] != expected {
+			t.Errorf("Expected %s to be %d, got %d", counter, expected, counts[counter])
+		}
+	}
+}
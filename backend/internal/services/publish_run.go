@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Publishers recorded in publish manifests
+const (
+	PublisherActivities      = "activities"
+	PublisherFeeds           = "feeds"
+	PublisherOpenData        = "open_data"
+	PublisherCatalogSnapshot = "catalog_snapshot"
+)
+
+// PublishRun uploads the objects of one publisher run and records each of them in the run's
+// publish manifest
+type PublishRun struct {
+	store    *S3Store
+	prefix   string
+	manifest models.PublishManifest
+}
+
+// NewPublishRun starts a run of a publisher. Its manifest is stored under prefix, the key prefix the
+// publisher writes to, so the publisher needs no access to the rest of the bucket.
+func NewPublishRun(store *S3Store, publisher, prefix string, now time.Time) *PublishRun {
+	return &PublishRun{
+		store:  store,
+		prefix: prefix,
+		manifest: models.PublishManifest{
+			RunID:     now.UTC().Format("20060102T150405Z") + "-" + uuid.New().String()[:8],
+			Publisher: publisher,
+			StartedAt: now.UTC(),
+			Objects:   []models.PublishedObject{},
+		},
+	}
+}
+
+// Put uploads an object as part of the run
+func (r *PublishRun) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	object, err := r.store.PutObject(ctx, key, body, contentType)
+	if err != nil {
+		return err
+	}
+	r.manifest.Objects = append(r.manifest.Objects, *object)
+	return nil
+}
+
+// Finish writes the run's manifest. runErr is the error that stopped the run, or nil when it wrote
+// everything. The manifest of a failed run is still written, listing the objects written before the
+// failure, and runErr is returned. A run that succeeded fails when its manifest cannot be written.
+func (r *PublishRun) Finish(ctx context.Context, runErr error) (*models.PublishManifest, error) {
+	manifest := &r.manifest
+	manifest.FinishedAt = time.Now().UTC()
+	manifest.Status = models.PublishManifestStatusComplete
+	if runErr != nil {
+		manifest.Status = models.PublishManifestStatusFailed
+		manifest.Error = runErr.Error()
+	}
+
+	key := models.PublishManifestKey(r.prefix, manifest.RunID)
+	body, err := json.Marshal(manifest)
+	if err == nil {
+		err = r.store.Put(ctx, key, body, "application/json")
+	}
+	if runErr != nil {
+		if err != nil {
+			log.Printf("Warning: Failed to write manifest of failed %s run %s: %v", manifest.Publisher, manifest.RunID, err)
+		}
+		return manifest, runErr
+	}
+	if err != nil {
+		return manifest, fmt.Errorf("failed to write publish manifest %s: %w", key, err)
+	}
+
+	log.Printf("Published %d objects (%d bytes) in %s run %s, manifest %s",
+		len(manifest.Objects), manifest.Size(), manifest.Publisher, manifest.RunID, key)
+	return manifest, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestPublishRun(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.HasSuffix(r.URL.Path, "/broken.json") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		objects[strings.TrimPrefix(r.URL.Path, "/")] = body
+		sum := md5.Sum(body)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	}))
+	defer server.Close()
+
+	store := NewS3Store(testAWSConfig(), "published")
+	store.endpoint = server.URL
+
+	readManifest := func(t *testing.T, manifest *models.PublishManifest) models.PublishManifest {
+		t.Helper()
+		var stored models.PublishManifest
+		body, ok := objects[models.PublishManifestKey(PublishedFeedsPrefix, manifest.RunID)]
+		if !ok {
+			t.Fatalf("Expected manifest of run %s to be written", manifest.RunID)
+		}
+		if err := json.Unmarshal(body, &stored); err != nil {
+			t.Fatalf("Expected a JSON manifest, got %v", err)
+		}
+		return stored
+	}
+
+	t.Run("RecordsObjectsOfRun", func(t *testing.T) {
+		run := NewPublishRun(store, PublisherFeeds, PublishedFeedsPrefix, now)
+		if err := run.Put(context.Background(), PublishedRSSFeedKey, []byte("<rss/>"), "application/rss+xml"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := run.Put(context.Background(), PublishedAtomFeedKey, []byte("<feed/>"), "application/atom+xml"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		manifest, err := run.Finish(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stored := readManifest(t, manifest)
+		if stored.Status != models.PublishManifestStatusComplete || stored.Publisher != PublisherFeeds || !strings.HasPrefix(stored.RunID, "20261016T180000Z-") {
+			t.Errorf("Unexpected manifest: %+v", stored)
+		}
+		if len(stored.Objects) != 2 || stored.Objects[0].Key != PublishedRSSFeedKey || stored.Objects[1].Key != PublishedAtomFeedKey {
+			t.Fatalf("Expected both feeds in order, got %+v", stored.Objects)
+		}
+		if stored.Objects[0].SHA256 == "" || stored.Objects[0].ETag == "" || stored.Objects[0].Size != 6 || stored.Size() != 13 {
+			t.Errorf("Expected size, checksum and ETag of each object, got %+v", stored.Objects)
+		}
+	})
+
+	t.Run("RecordsFailedRun", func(t *testing.T) {
+		run := NewPublishRun(store, PublisherFeeds, PublishedFeedsPrefix, now)
+		if err := run.Put(context.Background(), PublishedRSSFeedKey, []byte("<rss/>"), "application/rss+xml"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		runErr := run.Put(context.Background(), PublishedFeedsPrefix+"broken.json", []byte("{}"), "application/json")
+		if runErr == nil {
+			t.Fatal("Expected the upload to fail")
+		}
+
+		manifest, err := run.Finish(context.Background(), runErr)
+		if !errors.Is(err, runErr) {
+			t.Errorf("Expected the run's error, got %v", err)
+		}
+		stored := readManifest(t, manifest)
+		if stored.Status != models.PublishManifestStatusFailed || stored.Error == "" || len(stored.Objects) != 1 {
+			t.Errorf("Expected a failed manifest listing the object written, got %+v", stored)
+		}
+	})
+}
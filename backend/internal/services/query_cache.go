@@ -0,0 +1,236 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheService stores serialized query results for a limited time
+type CacheService interface {
+	// Get returns the value stored under key; found is false when it is missing or expired
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores value under key until ttl has passed
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// LRUCache is an in-memory CacheService that keeps the most recently used entries.
+// It lives as long as the Lambda instance and is safe for concurrent use.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is the most recently used
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates an in-memory cache that holds up to capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value stored under key and marks it as recently used
+func (c *LRUCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores value under key, evicting the least recently used entry when the cache is full
+func (c *LRUCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, including expired ones not yet evicted
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// TieredCache reads through a list of caches, fastest first. A value found in a slower tier is
+// copied into the faster ones, and values are stored in every tier. A tier that fails is skipped,
+// so a shared cache being unreachable only costs its hits.
+type TieredCache struct {
+	tiers []CacheService
+	// copyTTL is how long values copied into a faster tier are kept
+	copyTTL time.Duration
+}
+
+// NewTieredCache creates a cache over the given tiers, fastest first
+func NewTieredCache(copyTTL time.Duration, tiers ...CacheService) *TieredCache {
+	return &TieredCache{tiers: tiers, copyTTL: copyTTL}
+}
+
+// Get returns the value from the fastest tier that has it
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	for i, tier := range c.tiers {
+		value, found, err := tier.Get(ctx, key)
+		if err != nil {
+			log.Printf("Warning: Cache tier %d failed to get %s: %v", i, key, err)
+			continue
+		}
+		if !found {
+			continue
+		}
+		for _, faster := range c.tiers[:i] {
+			if err := faster.Set(ctx, key, value, c.copyTTL); err != nil {
+				log.Printf("Warning: Failed to copy %s into a faster cache tier: %v", key, err)
+			}
+		}
+		return value, true, nil
+	}
+	return nil, false, nil
+}
+
+// Set stores value in every tier
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var failed []string
+	for i, tier := range c.tiers {
+		if err := tier.Set(ctx, key, value, ttl); err != nil {
+			failed = append(failed, fmt.Sprintf("tier %d: %v", i, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to cache %s: %s", key, strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// CatalogGenerationSource reports the catalog change log head, which moves whenever the
+// stream processor records a change to the published catalog
+type CatalogGenerationSource interface {
+	GetCatalogChangeHead(ctx context.Context) (int64, error)
+}
+
+// PublicQueryCache caches the results of public catalog queries. Keys include the catalog
+// generation, so results cached before the stream processor recorded a catalog change are no
+// longer read once the new generation is seen. The generation is re-read at most once per
+// refresh interval, which bounds how long a change can take to show.
+type PublicQueryCache struct {
+	cache       CacheService
+	generations CatalogGenerationSource
+	ttl         time.Duration
+	refresh     time.Duration
+	now         func() time.Time
+
+	mu          sync.Mutex
+	generation  int64
+	refreshedAt time.Time
+}
+
+// NewPublicQueryCache creates a cache of public query results stored in cache for ttl
+func NewPublicQueryCache(cache CacheService, generations CatalogGenerationSource, ttl, refresh time.Duration) *PublicQueryCache {
+	return &PublicQueryCache{
+		cache:       cache,
+		generations: generations,
+		ttl:         ttl,
+		refresh:     refresh,
+		now:         time.Now,
+	}
+}
+
+// Key returns the cache key of a query: its path, its non-empty parameters in name order and
+// the catalog generation. The UTC date is included too, because public queries default to
+// today's date and filter relative to it.
+func (c *PublicQueryCache) Key(ctx context.Context, path string, params map[string]string) (string, error) {
+	generation, err := c.currentGeneration(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("public:%d:%s:%s", generation, c.now().UTC().Format("2006-01-02"), NormalizeQuery(path, params)), nil
+}
+
+// Get returns the result cached under key
+func (c *PublicQueryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return c.cache.Get(ctx, key)
+}
+
+// Set caches a result under key
+func (c *PublicQueryCache) Set(ctx context.Context, key string, value []byte) error {
+	return c.cache.Set(ctx, key, value, c.ttl)
+}
+
+// currentGeneration returns the catalog generation, reading it again when the refresh interval has passed
+func (c *PublicQueryCache) currentGeneration(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.refreshedAt.IsZero() && now.Sub(c.refreshedAt) < c.refresh {
+		return c.generation, nil
+	}
+	generation, err := c.generations.GetCatalogChangeHead(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read catalog generation: %w", err)
+	}
+	c.generation = generation
+	c.refreshedAt = now
+	return generation, nil
+}
+
+// NormalizeQuery returns path and its non-empty parameters in name order, so the same query
+// with its parameters in another order, or with empty ones, maps to the same key. Names and values
+// are otherwise kept as given, since handlers may read them differently.
+func NormalizeQuery(path string, params map[string]string) string {
+	values := url.Values{}
+	for name, value := range params {
+		if value == "" {
+			continue
+		}
+		values.Set(name, value)
+	}
+	if encoded := values.Encode(); encoded != "" {
+		return strings.TrimSuffix(path, "/") + "?" + encoded
+	}
+	return strings.TrimSuffix(path, "/")
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeCatalogHead struct {
+	sequence int64
+	reads    int
+	err      error
+}
+
+func (f *fakeCatalogHead) GetCatalogChangeHead(ctx context.Context) (int64, error) {
+	f.reads++
+	return f.sequence, f.err
+}
+
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("unreachable")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("unreachable")
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	cache.Set(ctx, "a", []byte("1"), time.Minute)
+	cache.Set(ctx, "b", []byte("2"), time.Minute)
+	cache.Get(ctx, "a") // b is now the least recently used
+	cache.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, found, _ := cache.Get(ctx, "b"); found {
+		t.Error("Expected b to be evicted")
+	}
+	if value, found, _ := cache.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Errorf("Expected a to be kept, got %q %v", value, found)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestLRUCacheExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cache := NewLRUCache(10)
+	cache.now = func() time.Time { return now }
+
+	cache.Set(ctx, "a", []byte("1"), time.Minute)
+	now = now.Add(time.Minute)
+
+	if _, found, _ := cache.Get(ctx, "a"); found {
+		t.Error("Expected a to have expired")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected the expired entry to be dropped, got %d entries", cache.Len())
+	}
+}
+
+func TestTieredCacheCopiesIntoFasterTiers(t *testing.T) {
+	ctx := context.Background()
+	local, shared := NewLRUCache(10), NewLRUCache(10)
+	cache := NewTieredCache(time.Minute, local, shared)
+
+	shared.Set(ctx, "a", []byte("1"), time.Minute)
+	if value, found, _ := cache.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Fatalf("Expected a from the shared tier, got %q %v", value, found)
+	}
+	if _, found, _ := local.Get(ctx, "a"); !found {
+		t.Error("Expected a to be copied into the local tier")
+	}
+
+	cache.Set(ctx, "b", []byte("2"), time.Minute)
+	if _, found, _ := shared.Get(ctx, "b"); !found {
+		t.Error("Expected b to be stored in the shared tier")
+	}
+}
+
+func TestTieredCacheSkipsFailingTier(t *testing.T) {
+	ctx := context.Background()
+	local := NewLRUCache(10)
+	cache := NewTieredCache(time.Minute, local, failingCache{})
+
+	if err := cache.Set(ctx, "a", []byte("1"), time.Minute); err == nil {
+		t.Error("Expected the failing tier to be reported")
+	}
+	if value, found, err := cache.Get(ctx, "a"); err != nil || !found || string(value) != "1" {
+		t.Errorf("Expected a from the local tier, got %q %v %v", value, found, err)
+	}
+	if _, found, err := cache.Get(ctx, "missing"); err != nil || found {
+		t.Errorf("Expected a plain miss despite the failing tier, got %v %v", found, err)
+	}
+}
+
+func TestPublicQueryCacheKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	head := &fakeCatalogHead{sequence: 7}
+	cache := NewPublicQueryCache(NewLRUCache(10), head, 5*time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return now }
+
+	key, err := cache.Key(ctx, "/api/events/approved", map[string]string{"limit": "20", "category": "arts", "date_from": ""})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key != "public:7:2026-10-16:/api/events/approved?category=arts&limit=20" {
+		t.Errorf("Unexpected key %s", key)
+	}
+
+	// The generation is not read again within the refresh interval
+	head.sequence = 8
+	now = now.Add(5 * time.Second)
+	if again, _ := cache.Key(ctx, "/api/events/approved", map[string]string{"category": "arts", "limit": "20"}); again != key {
+		t.Errorf("Expected the same key within the refresh interval, got %s", again)
+	}
+	if head.reads != 1 {
+		t.Errorf("Expected 1 generation read, got %d", head.reads)
+	}
+
+	// A catalog change recorded by the stream processor moves queries to new keys
+	now = now.Add(10 * time.Second)
+	if changed, _ := cache.Key(ctx, "/api/events/approved", map[string]string{"category": "arts", "limit": "20"}); !strings.HasPrefix(changed, "public:8:") {
+		t.Errorf("Expected the new generation in the key, got %s", changed)
+	}
+
+	head.err = errors.New("throttled")
+	now = now.Add(time.Minute)
+	if _, err := cache.Key(ctx, "/api/events/calendar", nil); err == nil {
+		t.Error("Expected an error when the generation cannot be read")
+	}
+}
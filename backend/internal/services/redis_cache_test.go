@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis serves GET and SET from a map over the Redis protocol
+type fakeRedis struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRedisCommand(reader)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+		var reply string
+		switch strings.ToUpper(args[0]) {
+		case "GET":
+			if value, ok := f.values[args[1]]; ok {
+				reply = "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+			} else {
+				reply = "$-1\r\n"
+			}
+		case "SET":
+			f.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen on loopback: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{values: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server, listener.Addr().String()
+}
+
+func TestRedisCacheGetAndSet(t *testing.T) {
+	server, addr := startFakeRedis(t)
+	cache := NewRedisCache(addr, false, time.Second)
+	ctx := context.Background()
+
+	if _, found, err := cache.Get(ctx, "public:1:calendar"); err != nil || found {
+		t.Fatalf("Expected a miss, got %v %v", found, err)
+	}
+	if err := cache.Set(ctx, "public:1:calendar", []byte(`{"success":true}`), 5*time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	value, found, err := cache.Get(ctx, "public:1:calendar")
+	if err != nil || !found || string(value) != `{"success":true}` {
+		t.Errorf("Expected the stored value, got %q %v %v", value, found, err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.commands[1] != `SET public:1:calendar {"success":true} PX 300000` {
+		t.Errorf("Unexpected SET command %q", server.commands[1])
+	}
+}
+
+func TestRedisCacheReportsUnreachableServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen on loopback: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	cache := NewRedisCache(addr, false, 100*time.Millisecond)
+	if _, _, err := cache.Get(context.Background(), "key"); err == nil {
+		t.Error("Expected an error from an unreachable server")
+	}
+}
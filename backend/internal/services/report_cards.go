@@ -0,0 +1,227 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// reportCardTopIssues is how many of the most frequent conversion issues a report card lists
+const reportCardTopIssues = 5
+
+// Report card score weights, renormalized over the parts a source has data for
+const (
+	reportCardRunWeight    = 0.4
+	reportCardIssueWeight  = 0.3
+	reportCardReviewWeight = 0.3
+)
+
+// quotedValue matches the values conversion issues quote, e.g. the date in "Could not parse date '...'"
+var quotedValue = regexp.MustCompile(`'[^']*'`)
+
+// BuildSourceReportCard grades a source for the week starting at weekStart. Runs are the fan-out
+// runs started in the week; events are admin events extracted or reviewed in the week, and only
+// those on the source's domain are counted.
+func BuildSourceReportCard(source *models.SourceSubmission, weekStart time.Time, runs []models.FanOutRun, events []models.AdminEvent, now time.Time) *models.SourceReportCard {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	card := &models.SourceReportCard{
+		SourceID:    source.SourceID,
+		SourceName:  source.SourceName,
+		BaseURL:     source.BaseURL,
+		WeekStart:   weekStart,
+		WeekEnd:     weekEnd,
+		GeneratedAt: now,
+	}
+
+	card.Metrics = reportCardMetrics(source.SourceID, weekStart, runs, now)
+
+	inWeek := func(t time.Time) bool {
+		return !t.Before(weekStart) && t.Before(weekEnd)
+	}
+	sourceDomain := urlutil.Domain(source.BaseURL)
+	issueCounts := make(map[string]int)
+	for i := range events {
+		event := &events[i]
+		if sourceDomain == "" || urlutil.Domain(event.SourceURL) != sourceDomain {
+			continue
+		}
+
+		if inWeek(event.ExtractedAt) {
+			card.Conversion.EventsExtracted++
+			if len(event.ConversionIssues) > 0 {
+				card.Conversion.EventsWithIssues++
+			}
+			// Count each issue once per event, however often it was reported
+			seen := make(map[string]bool)
+			for _, issue := range event.ConversionIssues {
+				issue = normalizeConversionIssue(issue)
+				if !seen[issue] {
+					seen[issue] = true
+					issueCounts[issue]++
+				}
+			}
+		}
+
+		if event.ReviewedAt != nil && inWeek(*event.ReviewedAt) {
+			switch {
+			case event.IsApproved():
+				card.Review.Approved++
+			case event.IsRejected():
+				card.Review.Rejected++
+			default:
+				continue
+			}
+			card.Review.Reviewed++
+		}
+	}
+
+	if card.Conversion.EventsExtracted > 0 {
+		card.Conversion.IssueRate = roundRate(float64(card.Conversion.EventsWithIssues) / float64(card.Conversion.EventsExtracted))
+	}
+	card.Conversion.TopIssues = topConversionIssues(issueCounts, reportCardTopIssues)
+	if card.Review.Reviewed > 0 {
+		card.Review.RejectionRate = roundRate(float64(card.Review.Rejected) / float64(card.Review.Reviewed))
+	}
+
+	card.Score, card.Grade = gradeReportCard(card)
+	card.Metrics.DataQualityScore = card.Score
+	return card
+}
+
+// reportCardMetrics aggregates the source's tasks across the week's fan-out runs
+func reportCardMetrics(sourceID string, weekStart time.Time, runs []models.FanOutRun, now time.Time) models.SourceMetrics {
+	metricsDate := weekStart.Format("2006-01-02")
+	metrics := models.SourceMetrics{
+		PK:          models.CreateSourcePK(sourceID),
+		SK:          models.CreateMetricsSK(metricsDate),
+		SourceID:    sourceID,
+		MetricsDate: metricsDate,
+		UpdatedAt:   now,
+	}
+
+	for _, run := range runs {
+		// Stats are only aggregated once a run has finished
+		if run.Stats == nil {
+			continue
+		}
+		stats, ok := run.Stats.Sources[sourceID]
+		if !ok {
+			continue
+		}
+		metrics.TotalRuns += stats.Tasks
+		metrics.FailedRuns += stats.FailedTasks
+		metrics.TotalItemsFound += stats.ActivitiesFound
+	}
+	metrics.SuccessfulRuns = metrics.TotalRuns - metrics.FailedRuns
+
+	if metrics.TotalRuns > 0 {
+		metrics.AverageItemsFound = roundRate(float64(metrics.TotalItemsFound) / float64(metrics.TotalRuns))
+		metrics.SuccessRate = math.Round(float64(metrics.SuccessfulRuns)/float64(metrics.TotalRuns)*1000) / 10
+	}
+	return metrics
+}
+
+// gradeReportCard scores a report card from its run success rate, issue rate and rejection rate.
+// Parts without data in the week are left out, and a card with no data at all is not graded.
+func gradeReportCard(card *models.SourceReportCard) (float64, string) {
+	var score, weight float64
+	if card.Metrics.TotalRuns > 0 {
+		score += reportCardRunWeight * card.Metrics.SuccessRate / 100
+		weight += reportCardRunWeight
+	}
+	if card.Conversion.EventsExtracted > 0 {
+		score += reportCardIssueWeight * (1 - card.Conversion.IssueRate)
+		weight += reportCardIssueWeight
+	}
+	if card.Review.Reviewed > 0 {
+		score += reportCardReviewWeight * (1 - card.Review.RejectionRate)
+		weight += reportCardReviewWeight
+	}
+
+	if weight == 0 {
+		return 0, models.ReportCardGradeNone
+	}
+	score = roundRate(score / weight)
+	return score, models.ReportCardGrade(score)
+}
+
+// normalizeConversionIssue blanks the values quoted in an issue so the same problem with
+// different values is counted together
+func normalizeConversionIssue(issue string) string {
+	return quotedValue.ReplaceAllString(strings.TrimSpace(issue), "'…'")
+}
+
+// topConversionIssues returns the most frequent issues, most frequent first
+func topConversionIssues(counts map[string]int, limit int) []models.ReportCardIssue {
+	issues := make([]models.ReportCardIssue, 0, len(counts))
+	for issue, count := range counts {
+		issues = append(issues, models.ReportCardIssue{Issue: issue, Count: count})
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Count != issues[j].Count {
+			return issues[i].Count > issues[j].Count
+		}
+		return issues[i].Issue < issues[j].Issue
+	})
+	if len(issues) > limit {
+		issues = issues[:limit]
+	}
+	return issues
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*1000) / 1000
+}
+
+// FormatReportCardDigest builds the weekly digest notification summarizing the report cards,
+// worst grades first so sources needing attention lead the message
+func FormatReportCardDigest(cards []*models.SourceReportCard, weekStart time.Time) (string, string) {
+	subject := fmt.Sprintf("Source report cards for the week of %s", weekStart.Format("Jan 2, 2006"))
+
+	sorted := make([]*models.SourceReportCard, len(cards))
+	copy(sorted, cards)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iGraded := sorted[i].Grade != models.ReportCardGradeNone
+		jGraded := sorted[j].Grade != models.ReportCardGradeNone
+		if iGraded != jGraded {
+			return iGraded
+		}
+		return sorted[i].Score < sorted[j].Score
+	})
+
+	grades := make(map[string]int)
+	for _, card := range sorted {
+		grades[card.Grade]++
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Data quality report cards for %d active sources, %s to %s.\n\n",
+		len(sorted), weekStart.Format("2006-01-02"), weekStart.AddDate(0, 0, 6).Format("2006-01-02"))
+	fmt.Fprintf(&body, "Grades: A %d, B %d, C %d, D %d, F %d, not graded %d\n\n",
+		grades[models.ReportCardGradeA], grades[models.ReportCardGradeB], grades[models.ReportCardGradeC],
+		grades[models.ReportCardGradeD], grades[models.ReportCardGradeF], grades[models.ReportCardGradeNone])
+
+	for _, card := range sorted {
+		if card.Grade == models.ReportCardGradeNone {
+			fmt.Fprintf(&body, "%s  %s (%s): no runs, extractions or reviews\n", card.Grade, card.SourceName, card.SourceID)
+			continue
+		}
+		fmt.Fprintf(&body, "%s  %s (%s): score %.2f, runs %d (%.1f%% successful), %d extracted (%.0f%% with issues), %d reviewed (%.0f%% rejected)\n",
+			card.Grade, card.SourceName, card.SourceID, card.Score,
+			card.Metrics.TotalRuns, card.Metrics.SuccessRate,
+			card.Conversion.EventsExtracted, card.Conversion.IssueRate*100,
+			card.Review.Reviewed, card.Review.RejectionRate*100)
+		if len(card.Conversion.TopIssues) > 0 {
+			fmt.Fprintf(&body, "    top issue: %s (%d events)\n", card.Conversion.TopIssues[0].Issue, card.Conversion.TopIssues[0].Count)
+		}
+	}
+
+	body.WriteString("\nFull report cards: GET /api/sources/{id}/report-card\n")
+	return subject, body.String()
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestBuildSourceReportCard(t *testing.T) {
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	inWeek := weekStart.Add(50 * time.Hour)
+	before := weekStart.Add(-time.Hour)
+	after := weekStart.AddDate(0, 0, 7)
+	source := &models.SourceSubmission{SourceID: "src-a", SourceName: "Seattle Parks", BaseURL: "https://www.parks.example.org"}
+
+	runs := []models.FanOutRun{
+		{Stats: &models.FanOutRunStats{Sources: map[string]models.FanOutSourceStats{
+			"src-a": {Tasks: 4, FailedTasks: 1, ActivitiesFound: 20},
+			"src-b": {Tasks: 2, FailedTasks: 2},
+		}}},
+		{Stats: &models.FanOutRunStats{Sources: map[string]models.FanOutSourceStats{
+			"src-a": {Tasks: 4, ActivitiesFound: 10},
+		}}},
+		{}, // still running
+	}
+	events := []models.AdminEvent{
+		{SourceURL: "https://parks.example.org/camps", ExtractedAt: inWeek, ReviewedAt: &inWeek, Status: models.AdminEventStatusApproved,
+			ConversionIssues: []string{"Could not parse date 'Sat 3pm'", "Missing date information", "Could not parse date 'noon'"}},
+		{SourceURL: "https://parks.example.org/classes", ExtractedAt: inWeek, ReviewedAt: &inWeek, Status: models.AdminEventStatusRejected,
+			ConversionIssues: []string{"Could not parse date 'Sunday'"}},
+		{SourceURL: "https://parks.example.org/events", ExtractedAt: inWeek, Status: models.AdminEventStatusPending},
+		{SourceURL: "https://parks.example.org/old", ExtractedAt: before, ReviewedAt: &inWeek, Status: models.AdminEventStatusApproved,
+			ConversionIssues: []string{"Missing location"}},
+		{SourceURL: "https://other.example.com/events", ExtractedAt: inWeek, ReviewedAt: &inWeek, Status: models.AdminEventStatusRejected},
+		{SourceURL: "https://parks.example.org/next", ExtractedAt: after, ConversionIssues: []string{"Missing location"}},
+	}
+
+	card := BuildSourceReportCard(source, weekStart, runs, events, after)
+
+	if card.Metrics.TotalRuns != 8 || card.Metrics.FailedRuns != 1 || card.Metrics.SuccessfulRuns != 7 {
+		t.Errorf("Unexpected run counts: %+v", card.Metrics)
+	}
+	if card.Metrics.SuccessRate != 87.5 || card.Metrics.TotalItemsFound != 30 {
+		t.Errorf("Expected 87.5%% success and 30 items, got %.1f%% and %d", card.Metrics.SuccessRate, card.Metrics.TotalItemsFound)
+	}
+	if card.Metrics.MetricsDate != "2026-10-05" {
+		t.Errorf("Expected metrics date 2026-10-05, got %s", card.Metrics.MetricsDate)
+	}
+
+	if card.Conversion.EventsExtracted != 3 || card.Conversion.EventsWithIssues != 2 {
+		t.Errorf("Expected 2 of 3 extracted events with issues, got %+v", card.Conversion)
+	}
+	if len(card.Conversion.TopIssues) != 2 {
+		t.Fatalf("Expected 2 top issues, got %+v", card.Conversion.TopIssues)
+	}
+	if top := card.Conversion.TopIssues[0]; top.Issue != "Could not parse date '…'" || top.Count != 2 {
+		t.Errorf("Expected date parsing issues counted once per event, got %+v", top)
+	}
+
+	if card.Review.Reviewed != 3 || card.Review.Approved != 2 || card.Review.Rejected != 1 {
+		t.Errorf("Unexpected review counts: %+v", card.Review)
+	}
+	if card.Review.RejectionRate != 0.333 {
+		t.Errorf("Expected rejection rate 0.333, got %.3f", card.Review.RejectionRate)
+	}
+
+	if card.Score != 0.65 || card.Grade != models.ReportCardGradeD {
+		t.Errorf("Expected score 0.65 and grade D, got %.3f and %s", card.Score, card.Grade)
+	}
+}
+
+func TestBuildSourceReportCardPartialData(t *testing.T) {
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	source := &models.SourceSubmission{SourceID: "src-a", BaseURL: "https://parks.example.org"}
+
+	card := BuildSourceReportCard(source, weekStart, nil, nil, weekStart)
+	if card.Grade != models.ReportCardGradeNone || card.Score != 0 {
+		t.Errorf("Expected an ungraded card without data, got %s %.2f", card.Grade, card.Score)
+	}
+
+	// Only the parts with data are weighted
+	runs := []models.FanOutRun{{Stats: &models.FanOutRunStats{Sources: map[string]models.FanOutSourceStats{
+		"src-a": {Tasks: 2},
+	}}}}
+	card = BuildSourceReportCard(source, weekStart, runs, nil, weekStart)
+	if card.Grade != models.ReportCardGradeA || card.Score != 1 {
+		t.Errorf("Expected grade A from runs alone, got %s %.2f", card.Grade, card.Score)
+	}
+}
+
+func TestFormatReportCardDigest(t *testing.T) {
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	cards := []*models.SourceReportCard{
+		{SourceID: "src-a", SourceName: "Seattle Parks", Grade: models.ReportCardGradeA, Score: 0.95},
+		{SourceID: "src-b", SourceName: "ParentMap", Grade: models.ReportCardGradeNone},
+		{SourceID: "src-c", SourceName: "Library", Grade: models.ReportCardGradeF, Score: 0.4,
+			Conversion: models.ReportCardConversion{EventsExtracted: 5, TopIssues: []models.ReportCardIssue{{Issue: "Missing date information", Count: 4}}}},
+	}
+
+	subject, message := FormatReportCardDigest(cards, weekStart)
+
+	if !strings.Contains(subject, "Oct 5, 2026") {
+		t.Errorf("Expected the week in the subject, got %q", subject)
+	}
+	if !strings.Contains(message, "Grades: A 1, B 0, C 0, D 0, F 1, not graded 1") {
+		t.Errorf("Expected grade counts in the digest, got:\n%s", message)
+	}
+	library := strings.Index(message, "Library")
+	parks := strings.Index(message, "Seattle Parks")
+	parentMap := strings.Index(message, "ParentMap")
+	if !(library < parks && parks < parentMap) {
+		t.Errorf("Expected worst grades first and ungraded sources last, got:\n%s", message)
+	}
+	if !strings.Contains(message, "top issue: Missing date information (4 events)") {
+		t.Errorf("Expected the top issue in the digest, got:\n%s", message)
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+	"seattle-family-activities-scraper/internal/urlutil"
+)
+
+// sourcePriorityRank orders source priorities from most to least urgent
+var sourcePriorityRank = map[string]int{
+	"high":   0,
+	"medium": 1,
+	"low":    2,
+}
+
+// FilterAdminEvents returns the events matching the review filters. Events whose confidence
+// was never stored count as 0.
+func FilterAdminEvents(events []models.AdminEvent, filters models.ReviewFilters) []models.AdminEvent {
+	domain := urlutil.Domain(filters.SourceDomain)
+	filtered := []models.AdminEvent{}
+	for _, event := range events {
+		switch {
+		case filters.SchemaType != "" && event.SchemaType != filters.SchemaType:
+		case domain != "" && urlutil.Domain(event.SourceURL) != domain:
+		case filters.ExtractedBy != "" && event.ExtractedByUser != filters.ExtractedBy:
+		case filters.MinConfidence != nil && event.ConfidenceScore < *filters.MinConfidence:
+		case filters.MaxConfidence != nil && event.ConfidenceScore > *filters.MaxConfidence:
+		default:
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// SortAdminEvents orders events by the filters' sort, newest extraction first by default.
+// Scores sort highest first unless the order is asc.
+func SortAdminEvents(events []models.AdminEvent, filters models.ReviewFilters) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return adminEventBefore(events[i], events[j], filters)
+	})
+}
+
+// adminEventBefore reports whether event a comes before b in the filters' order. Ties are broken
+// by event ID, so the order is the same however the events were read.
+func adminEventBefore(a, b models.AdminEvent, filters models.ReviewFilters) bool {
+	if filters.Order == models.ReviewOrderAsc {
+		a, b = b, a
+	}
+	switch {
+	case filters.Sort == models.ReviewSortConfidence && a.ConfidenceScore != b.ConfidenceScore:
+		return a.ConfidenceScore > b.ConfidenceScore
+	case filters.Sort == models.ReviewSortCompleteness && a.CompletenessScore != b.CompletenessScore:
+		return a.CompletenessScore > b.CompletenessScore
+	case filters.Sort != models.ReviewSortConfidence && filters.Sort != models.ReviewSortCompleteness && !a.ExtractedAt.Equal(b.ExtractedAt):
+		return a.ExtractedAt.After(b.ExtractedAt)
+	default:
+		return a.EventID < b.EventID
+	}
+}
+
+// NextAdminEvent returns the event that follows after in the review queue as the filters order
+// it, or the first event when after is nil, with the number of events left behind it. after need
+// not be in the queue any more, so a reviewer can advance from an event they just reviewed.
+func NextAdminEvent(events []models.AdminEvent, filters models.ReviewFilters, after *models.AdminEvent) (*models.AdminEvent, int) {
+	queue := FilterAdminEvents(events, filters)
+	SortAdminEvents(queue, filters)
+	for i := range queue {
+		if after == nil || adminEventBefore(*after, queue[i], filters) {
+			return &queue[i], len(queue) - i - 1
+		}
+	}
+	return nil, 0
+}
+
+// reviewPageCursor is where a page of the review queue ended: the order it was read in and the
+// place of its last event in that order. The event may be reviewed before the next page is read,
+// so its place is kept rather than its position.
+type reviewPageCursor struct {
+	Sort         string    `json:"s,omitempty"`
+	Order        string    `json:"o,omitempty"`
+	EventID      string    `json:"id"`
+	ExtractedAt  time.Time `json:"t"`
+	Confidence   float64   `json:"c,omitempty"`
+	Completeness float64   `json:"p,omitempty"`
+}
+
+// PageAdminEvents returns one page of up to limit events of the review queue, filtered and sorted
+// by the filters, with the token of the next page. The token is "" on the last page. Filtering and
+// sorting the whole queue before paging keeps every page in the same order as NextAdminEvent.
+func PageAdminEvents(events []models.AdminEvent, filters models.ReviewFilters, limit int, token string) ([]models.AdminEvent, string, error) {
+	queue := FilterAdminEvents(events, filters)
+	SortAdminEvents(queue, filters)
+
+	start := 0
+	if token != "" {
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		var cursor reviewPageCursor
+		if err := json.Unmarshal(data, &cursor); err != nil || cursor.EventID == "" {
+			return nil, "", ErrInvalidPageToken
+		}
+		if cursor.Sort != filters.Sort || cursor.Order != filters.Order {
+			return nil, "", ErrInvalidPageToken
+		}
+		after := models.AdminEvent{
+			EventID:           cursor.EventID,
+			ExtractedAt:       cursor.ExtractedAt,
+			ConfidenceScore:   cursor.Confidence,
+			CompletenessScore: cursor.Completeness,
+		}
+		start = sort.Search(len(queue), func(i int) bool {
+			return adminEventBefore(after, queue[i], filters)
+		})
+	}
+
+	end := start + limit
+	if end >= len(queue) {
+		return queue[start:], "", nil
+	}
+	last := queue[end-1]
+	data, _ := json.Marshal(reviewPageCursor{
+		Sort:         filters.Sort,
+		Order:        filters.Order,
+		EventID:      last.EventID,
+		ExtractedAt:  last.ExtractedAt,
+		Confidence:   last.ConfidenceScore,
+		Completeness: last.CompletenessScore,
+	})
+	return queue[start:end], base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// FilterSourceSubmissions returns the sources matching the review filters
+func FilterSourceSubmissions(sources []models.SourceSubmission, filters models.ReviewFilters) []models.SourceSubmission {
+	filtered := []models.SourceSubmission{}
+	for _, source := range sources {
+		switch {
+		case filters.SourceType != "" && source.SourceType != filters.SourceType:
+		case filters.Priority != "" && source.Priority != filters.Priority:
+		default:
+			filtered = append(filtered, source)
+		}
+	}
+	return filtered
+}
+
+// SortSourceSubmissions orders sources by the filters' sort, newest submission first by
+// default. Priority sorts high first and name sorts A to Z unless the order says otherwise.
+func SortSourceSubmissions(sources []models.SourceSubmission, filters models.ReviewFilters) {
+	var less func(a, b models.SourceSubmission) bool
+	switch filters.Sort {
+	case models.ReviewSortPriority:
+		less = func(a, b models.SourceSubmission) bool {
+			return priorityRank(a.Priority) < priorityRank(b.Priority)
+		}
+	case models.ReviewSortName:
+		less = func(a, b models.SourceSubmission) bool {
+			return strings.ToLower(a.SourceName) < strings.ToLower(b.SourceName)
+		}
+	default:
+		less = func(a, b models.SourceSubmission) bool {
+			return a.SubmittedAt.After(b.SubmittedAt)
+		}
+	}
+
+	// Each sort's natural direction is the default; the opposite order reverses it
+	natural := models.ReviewOrderAsc
+	if filters.Sort == "" || filters.Sort == models.ReviewSortSubmittedAt {
+		natural = models.ReviewOrderDesc
+	}
+	reverse := filters.Order != "" && filters.Order != natural
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		if reverse {
+			return less(sources[j], sources[i])
+		}
+		return less(sources[i], sources[j])
+	})
+}
+
+// priorityRank ranks unknown priorities after low
+func priorityRank(priority string) int {
+	if rank, ok := sourcePriorityRank[priority]; ok {
+		return rank
+	}
+	return len(sourcePriorityRank)
+}
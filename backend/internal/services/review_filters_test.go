@@ -0,0 +1,178 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestFilterAndSortAdminEvents(t *testing.T) {
+	now := time.Now()
+	events := []models.AdminEvent{
+		{EventID: "a", SchemaType: "events", SourceURL: "https://www.parentmap.com/calendar", ConfidenceScore: 90, ExtractedAt: now.Add(-3 * time.Hour)},
+		{EventID: "b", SchemaType: "events", SourceURL: "https://parentmap.com/camps", ConfidenceScore: 40, ExtractedAt: now.Add(-1 * time.Hour)},
+		{EventID: "c", SchemaType: "activities", SourceURL: "https://parentmap.com/classes", ConfidenceScore: 60, ExtractedAt: now},
+		{EventID: "d", SchemaType: "events", SourceURL: "https://spl.org/events", ConfidenceScore: 70, ExtractedAt: now.Add(-2 * time.Hour)},
+	}
+
+	maxConfidence := 95.0
+	filters := models.ReviewFilters{
+		SchemaType:    "events",
+		SourceDomain:  "parentmap.com",
+		MaxConfidence: &maxConfidence,
+		Sort:          models.ReviewSortConfidence,
+		Order:         models.ReviewOrderAsc,
+	}
+	filtered := FilterAdminEvents(events, filters)
+	SortAdminEvents(filtered, filters)
+
+	if len(filtered) != 2 || filtered[0].EventID != "b" || filtered[1].EventID != "a" {
+		t.Errorf("Expected events b then a, got %+v", filtered)
+	}
+
+	// The default sort is newest extraction first
+	all := FilterAdminEvents(events, models.ReviewFilters{})
+	SortAdminEvents(all, models.ReviewFilters{})
+	if all[0].EventID != "c" || all[3].EventID != "a" {
+		t.Errorf("Expected newest first, got %s ... %s", all[0].EventID, all[3].EventID)
+	}
+
+	events[3].ExtractedByUser = "reviewer@example.com"
+	mine := FilterAdminEvents(events, models.ReviewFilters{ExtractedBy: "reviewer@example.com"})
+	if len(mine) != 1 || mine[0].EventID != "d" {
+		t.Errorf("Expected only event d extracted by reviewer, got %+v", mine)
+	}
+}
+
+func TestPageAdminEvents(t *testing.T) {
+	now := time.Now()
+	// The matches are the oldest events, so a page of the newest events would hold none of them
+	var events []models.AdminEvent
+	for i, confidence := range []float64{90, 85, 80, 30, 20, 10} {
+		events = append(events, models.AdminEvent{
+			EventID:         string(rune('a' + i)),
+			ConfidenceScore: confidence,
+			ExtractedAt:     now.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	maxConfidence := 50.0
+	filters := models.ReviewFilters{MaxConfidence: &maxConfidence, Sort: models.ReviewSortConfidence, Order: models.ReviewOrderAsc}
+
+	page, token, err := PageAdminEvents(events, filters, 2, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page) != 2 || page[0].EventID != "f" || page[1].EventID != "e" || token == "" {
+		t.Fatalf("Expected events f and e with a next page, got %+v (token %q)", page, token)
+	}
+
+	// The last event of the page was reviewed before the next page was read
+	remaining := append(append([]models.AdminEvent{}, events[:4]...), events[5])
+	page, token, err = PageAdminEvents(remaining, filters, 2, token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page) != 1 || page[0].EventID != "d" || token != "" {
+		t.Errorf("Expected only event d on the last page, got %+v (token %q)", page, token)
+	}
+
+	if _, _, err := PageAdminEvents(events, models.ReviewFilters{}, 2, "not-a-token"); err != ErrInvalidPageToken {
+		t.Errorf("Expected ErrInvalidPageToken for a malformed token, got %v", err)
+	}
+	_, token, _ = PageAdminEvents(events, filters, 1, "")
+	if _, _, err := PageAdminEvents(events, models.ReviewFilters{}, 1, token); err != ErrInvalidPageToken {
+		t.Errorf("Expected ErrInvalidPageToken for a token of another sort, got %v", err)
+	}
+}
+
+func TestFilterAndSortSourceSubmissions(t *testing.T) {
+	now := time.Now()
+	sources := []models.SourceSubmission{
+		{SourceID: "1", SourceName: "Zoo", SourceType: "venue", Priority: "low", SubmittedAt: now.Add(-time.Hour)},
+		{SourceID: "2", SourceName: "aquarium", SourceType: "venue", Priority: "high", SubmittedAt: now.Add(-2 * time.Hour)},
+		{SourceID: "3", SourceName: "Library", SourceType: "community-calendar", Priority: "medium", SubmittedAt: now},
+	}
+
+	venues := FilterSourceSubmissions(sources, models.ReviewFilters{SourceType: "venue"})
+	if len(venues) != 2 {
+		t.Fatalf("Expected 2 venues, got %d", len(venues))
+	}
+
+	tests := []struct {
+		filters  models.ReviewFilters
+		expected []string
+	}{
+		{models.ReviewFilters{}, []string{"3", "1", "2"}},
+		{models.ReviewFilters{Sort: models.ReviewSortSubmittedAt, Order: models.ReviewOrderAsc}, []string{"2", "1", "3"}},
+		{models.ReviewFilters{Sort: models.ReviewSortPriority}, []string{"2", "3", "1"}},
+		{models.ReviewFilters{Sort: models.ReviewSortPriority, Order: models.ReviewOrderDesc}, []string{"1", "3", "2"}},
+		{models.ReviewFilters{Sort: models.ReviewSortName}, []string{"2", "3", "1"}},
+	}
+	for _, tt := range tests {
+		sorted := append([]models.SourceSubmission(nil), sources...)
+		SortSourceSubmissions(sorted, tt.filters)
+		for i, id := range tt.expected {
+			if sorted[i].SourceID != id {
+				t.Errorf("Sort %q %q: expected %v, got position %d = %s", tt.filters.Sort, tt.filters.Order, tt.expected, i, sorted[i].SourceID)
+				break
+			}
+		}
+	}
+}
+
+func TestReviewFiltersMergeAndValidate(t *testing.T) {
+	minConfidence := 50.0
+	preset := models.ReviewFilters{SchemaType: "events", MinConfidence: &minConfidence, Sort: models.ReviewSortConfidence}
+	merged := preset.Merge(models.ReviewFilters{Order: models.ReviewOrderAsc, SchemaType: "activities"})
+
+	if merged.SchemaType != "activities" || merged.Order != models.ReviewOrderAsc || merged.Sort != models.ReviewSortConfidence || *merged.MinConfidence != 50 {
+		t.Errorf("Unexpected merged filters: %+v", merged)
+	}
+	if err := merged.Validate(models.PresetTargetEvents); err != nil {
+		t.Errorf("Expected merged filters to be valid, got %v", err)
+	}
+	if err := merged.Validate(models.PresetTargetSources); err == nil {
+		t.Error("Expected a confidence sort to be rejected for sources")
+	}
+
+	maxConfidence := 20.0
+	merged.MaxConfidence = &maxConfidence
+	if err := merged.Validate(models.PresetTargetEvents); err == nil {
+		t.Error("Expected an inverted confidence range to be rejected")
+	}
+}
+
+func TestNextAdminEvent(t *testing.T) {
+	now := time.Now()
+	events := []models.AdminEvent{
+		{EventID: "a", SchemaType: "events", ConfidenceScore: 90, ExtractedAt: now.Add(-3 * time.Hour)},
+		{EventID: "b", SchemaType: "events", ConfidenceScore: 40, ExtractedAt: now.Add(-1 * time.Hour)},
+		{EventID: "c", SchemaType: "activities", ConfidenceScore: 60, ExtractedAt: now},
+		{EventID: "d", SchemaType: "events", ConfidenceScore: 90, ExtractedAt: now.Add(-2 * time.Hour)},
+	}
+	filters := models.ReviewFilters{SchemaType: "events", Sort: models.ReviewSortConfidence}
+
+	next, remaining := NextAdminEvent(events, filters, nil)
+	if next == nil || next.EventID != "a" || remaining != 2 {
+		t.Fatalf("Expected a with 2 remaining, got %+v, %d", next, remaining)
+	}
+
+	// a and d tie on confidence, so d follows a by event ID
+	next, remaining = NextAdminEvent(events, filters, next)
+	if next == nil || next.EventID != "d" || remaining != 1 {
+		t.Fatalf("Expected d with 1 remaining, got %+v, %d", next, remaining)
+	}
+
+	// Advancing from an event that was just approved, and so left the queue
+	approved := events[3]
+	approved.Status = models.AdminEventStatusApproved
+	next, remaining = NextAdminEvent([]models.AdminEvent{events[0], events[1], events[2]}, filters, &approved)
+	if next == nil || next.EventID != "b" || remaining != 0 {
+		t.Fatalf("Expected b with none remaining, got %+v, %d", next, remaining)
+	}
+
+	if next, _ := NextAdminEvent(events, filters, &events[1]); next != nil {
+		t.Errorf("Expected no event after the last one, got %+v", next)
+	}
+}
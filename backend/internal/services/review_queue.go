@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// DefaultReviewLatencySLO is how long the 90th percentile pending event may wait for review
+const DefaultReviewLatencySLO = 48 * time.Hour
+
+// reviewQueueBuckets are the upper bounds of the time-in-queue distribution; the last bucket is open
+var reviewQueueBuckets = []struct {
+	label string
+	max   time.Duration
+}{
+	{"0-4h", 4 * time.Hour},
+	{"4-12h", 12 * time.Hour},
+	{"12-24h", 24 * time.Hour},
+	{"1-2d", 48 * time.Hour},
+	{"2-4d", 96 * time.Hour},
+	{"4-7d", 7 * 24 * time.Hour},
+	{"7d+", 0},
+}
+
+// MeasureReviewQueue measures how long the pending events have waited since they entered the
+// review queue. Events that are no longer pending are skipped. Percentiles use the nearest rank.
+func MeasureReviewQueue(events []models.AdminEvent, slo time.Duration, now time.Time) *models.ReviewQueueLatency {
+	latency := &models.ReviewQueueLatency{
+		GeneratedAt: now,
+		SLOHours:    roundHours(slo),
+	}
+	for _, bucket := range reviewQueueBuckets {
+		latency.Distribution = append(latency.Distribution, models.ReviewQueueBucket{Label: bucket.label})
+	}
+
+	var waits []time.Duration
+	for i := range events {
+		event := &events[i]
+		if !event.IsPending() {
+			continue
+		}
+		queuedAt := event.QueueEntryTime()
+		wait := now.Sub(queuedAt)
+		if wait < 0 {
+			wait = 0
+		}
+		waits = append(waits, wait)
+		latency.Distribution[reviewQueueBucket(wait)].Count++
+
+		if latency.OldestQueuedAt == nil || queuedAt.Before(*latency.OldestQueuedAt) {
+			latency.OldestEventID = event.EventID
+			latency.OldestQueuedAt = &queuedAt
+		}
+	}
+
+	latency.Pending = len(waits)
+	if len(waits) == 0 {
+		return latency
+	}
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	latency.P50Hours = roundHours(nearestRank(waits, 50))
+	latency.P90Hours = roundHours(nearestRank(waits, 90))
+	latency.P99Hours = roundHours(nearestRank(waits, 99))
+	latency.MaxHours = roundHours(waits[len(waits)-1])
+	latency.SLOBreached = slo > 0 && nearestRank(waits, 90) > slo
+	return latency
+}
+
+// FormatReviewSLOAlert builds the subject and body of the alert sent when the review queue's
+// p90 time-in-queue exceeds the SLO
+func FormatReviewSLOAlert(latency *models.ReviewQueueLatency) (string, string) {
+	subject := fmt.Sprintf("Review queue p90 at %.1fh, over the %.0fh SLO", latency.P90Hours, latency.SLOHours)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%d events are pending review, and the 90th percentile has waited %.1f hours against an SLO of %.0f hours.\n\n",
+		latency.Pending, latency.P90Hours, latency.SLOHours)
+	fmt.Fprintf(&body, "p50: %.1fh\np90: %.1fh\np99: %.1fh\nmax: %.1fh\n", latency.P50Hours, latency.P90Hours, latency.P99Hours, latency.MaxHours)
+	if latency.OldestQueuedAt != nil {
+		fmt.Fprintf(&body, "Oldest: %s, queued %s\n", latency.OldestEventID, latency.OldestQueuedAt.UTC().Format(time.RFC3339))
+	}
+
+	body.WriteString("\nTime in queue:\n")
+	for _, bucket := range latency.Distribution {
+		fmt.Fprintf(&body, "  %-7s %d\n", bucket.Label, bucket.Count)
+	}
+	return subject, body.String()
+}
+
+// nearestRank returns the pth percentile of sorted durations
+func nearestRank(sorted []time.Duration, p int) time.Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func reviewQueueBucket(wait time.Duration) int {
+	for i, bucket := range reviewQueueBuckets {
+		if bucket.max == 0 || wait < bucket.max {
+			return i
+		}
+	}
+	return len(reviewQueueBuckets) - 1
+}
+
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*10) / 10
+}
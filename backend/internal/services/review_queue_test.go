@@ -0,0 +1,71 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestMeasureReviewQueue(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	event := func(id string, status models.AdminEventStatus, waited time.Duration) models.AdminEvent {
+		queuedAt := now.Add(-waited)
+		return models.AdminEvent{EventID: id, Status: status, ExtractedAt: queuedAt.Add(-time.Hour), QueuedAt: &queuedAt}
+	}
+
+	var events []models.AdminEvent
+	for i := 1; i <= 8; i++ {
+		events = append(events, event("recent", models.AdminEventStatusPending, time.Duration(i)*time.Hour))
+	}
+	events = append(events,
+		event("edited", models.AdminEventStatusEdited, 30*time.Hour),
+		event("approved", models.AdminEventStatusApproved, 200*time.Hour),
+	)
+	// Events stored before queue entry was tracked wait from their extraction
+	legacy := models.AdminEvent{EventID: "legacy", Status: models.AdminEventStatusPending, ExtractedAt: now.Add(-100 * time.Hour)}
+	events = append(events, legacy)
+
+	latency := MeasureReviewQueue(events, DefaultReviewLatencySLO, now)
+
+	if latency.Pending != 10 {
+		t.Fatalf("Expected 10 pending events, got %d", latency.Pending)
+	}
+	if latency.P50Hours != 5 || latency.P90Hours != 30 || latency.P99Hours != 100 || latency.MaxHours != 100 {
+		t.Errorf("Expected p50 5h, p90 30h, p99 and max 100h, got %+v", latency)
+	}
+	if latency.SLOBreached {
+		t.Error("Expected a p90 of 30h to be within the 48h SLO")
+	}
+	if latency.OldestEventID != "legacy" {
+		t.Errorf("Expected the legacy event to be the oldest, got %s", latency.OldestEventID)
+	}
+
+	counts := map[string]int{}
+	for _, bucket := range latency.Distribution {
+		counts[bucket.Label] = bucket.Count
+	}
+	if counts["0-4h"] != 3 || counts["4-12h"] != 5 || counts["1-2d"] != 1 || counts["4-7d"] != 1 || counts["7d+"] != 0 {
+		t.Errorf("Unexpected distribution %+v", latency.Distribution)
+	}
+
+	breached := MeasureReviewQueue(events, 24*time.Hour, now)
+	if !breached.SLOBreached {
+		t.Error("Expected a p90 of 30h to breach a 24h SLO")
+	}
+	subject, body := FormatReviewSLOAlert(breached)
+	if !strings.Contains(subject, "30.0h") || !strings.Contains(body, "legacy") {
+		t.Errorf("Expected the alert to name the p90 and oldest event, got %q / %q", subject, body)
+	}
+}
+
+func TestMeasureReviewQueueEmpty(t *testing.T) {
+	latency := MeasureReviewQueue(nil, DefaultReviewLatencySLO, time.Now())
+	if latency.Pending != 0 || latency.SLOBreached || latency.OldestQueuedAt != nil {
+		t.Errorf("Expected an empty queue, got %+v", latency)
+	}
+	if len(latency.Distribution) != len(reviewQueueBuckets) {
+		t.Errorf("Expected every bucket in the distribution, got %d", len(latency.Distribution))
+	}
+}
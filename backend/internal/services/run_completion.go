@@ -0,0 +1,287 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxNotificationErrors caps how many task errors are listed in a run completion notification
+const maxNotificationErrors = 10
+
+// FinalRunStatus returns the status a finished fan-out run should be recorded with: cancelled
+// when tasks were skipped by a cancellation, failed when no task succeeded, partial when some
+// tasks failed, completed otherwise. Tasks that could not be queued are not counted on the run
+// but leave an error on it, so they count as failures.
+func FinalRunStatus(run *models.FanOutRun) string {
+	switch {
+	case run.CancelledTasks > 0:
+		return models.RunStatusCancelled
+	case run.CompletedTasks == 0 && (run.TotalTasks > 0 || len(run.Errors) > 0):
+		return models.RunStatusFailed
+	case run.FailedTasks > 0 || len(run.Errors) > 0:
+		return models.RunStatusPartial
+	default:
+		return models.RunStatusCompleted
+	}
+}
+
+// SummarizeFanOutRun aggregates the task results of a finished run into run-level statistics
+func SummarizeFanOutRun(run *models.FanOutRun, results []models.FanOutTaskResult, finishedAt time.Time) *models.FanOutRunStats {
+	stats := &models.FanOutRunStats{
+		DurationMs: finishedAt.Sub(run.StartedAt).Milliseconds(),
+		Sources:    make(map[string]models.FanOutSourceStats),
+	}
+
+	var totalTaskDuration int64
+	succeeded, ran := 0, 0
+	for _, result := range results {
+		if result.Cancelled {
+			continue // never ran, so it says nothing about the source
+		}
+		ran++
+		source := stats.Sources[result.SourceID]
+		source.SourceName = result.SourceName
+		source.Tasks++
+		if result.Success {
+			succeeded++
+			source.ActivitiesFound += result.ActivitiesFound
+			if result.SkippedUnchanged() {
+				source.UnchangedTasks++
+			}
+		} else {
+			source.FailedTasks++
+			code := result.ErrorCode
+			if code == "" {
+				code = models.ErrorCodeUnknown
+			}
+			if stats.ErrorCodes == nil {
+				stats.ErrorCodes = make(map[string]int)
+			}
+			stats.ErrorCodes[code]++
+		}
+		if result.LimitHit != "" {
+			source.LimitHit = result.LimitHit
+		}
+		stats.Sources[result.SourceID] = source
+
+		totalTaskDuration += result.DurationMs
+		if result.DurationMs > stats.MaxTaskDurationMs {
+			stats.MaxTaskDurationMs = result.DurationMs
+		}
+	}
+
+	if ran > 0 {
+		stats.SuccessRate = float64(succeeded) / float64(ran)
+		stats.AvgTaskDurationMs = totalTaskDuration / int64(ran)
+	}
+
+	for _, source := range stats.Sources {
+		if source.FailedTasks == source.Tasks {
+			stats.FailedSources++
+		} else {
+			stats.SuccessfulSources++
+		}
+	}
+
+	return stats
+}
+
+// FormatRunNotification builds the subject and plain-text body of a run completion notification
+func FormatRunNotification(run *models.FanOutRun) (string, string) {
+	subject := fmt.Sprintf("Scraping run %s %s", run.RunID, run.Status)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Scraping run %s (%s) finished with status %s.\n\n", run.RunID, run.TriggerType, run.Status)
+	fmt.Fprintf(&body, "Tasks: %d completed, %d failed of %d\n", run.CompletedTasks, run.FailedTasks, run.TotalTasks)
+	if run.CancelledTasks > 0 {
+		fmt.Fprintf(&body, "Tasks cancelled before running: %d\n", run.CancelledTasks)
+	}
+	if run.UnchangedTasks > 0 {
+		fmt.Fprintf(&body, "Pages skipped as unchanged: %d\n", run.UnchangedTasks)
+	}
+	fmt.Fprintf(&body, "Activities extracted: %d\n", run.TotalActivities)
+	if run.PublishedActivities > 0 {
+		fmt.Fprintf(&body, "Activities published: %d\n", run.PublishedActivities)
+	}
+
+	if stats := run.Stats; stats != nil {
+		fmt.Fprintf(&body, "Duration: %s\n", (time.Duration(stats.DurationMs) * time.Millisecond).Round(time.Second))
+		fmt.Fprintf(&body, "Success rate: %.0f%%\n", stats.SuccessRate*100)
+		fmt.Fprintf(&body, "Sources: %d succeeded, %d failed\n", stats.SuccessfulSources, stats.FailedSources)
+
+		if len(stats.Sources) > 0 {
+			ids := make([]string, 0, len(stats.Sources))
+			for id := range stats.Sources {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			body.WriteString("\nPer source:\n")
+			for _, id := range ids {
+				source := stats.Sources[id]
+				fmt.Fprintf(&body, "- %s: %d activities, %d/%d tasks failed", source.SourceName, source.ActivitiesFound, source.FailedTasks, source.Tasks)
+				if source.LimitHit != "" {
+					fmt.Fprintf(&body, ", stopped at %s limit", source.LimitHit)
+				}
+				if source.SelectorDrift != "" {
+					fmt.Fprintf(&body, ", selector drift %s", source.SelectorDrift)
+				}
+				body.WriteString("\n")
+			}
+		}
+
+		if len(stats.ErrorCodes) > 0 {
+			codes := make([]string, 0, len(stats.ErrorCodes))
+			for code := range stats.ErrorCodes {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+
+			body.WriteString("\nFailures by cause:\n")
+			for _, code := range codes {
+				kind := "needs attention"
+				if IsRetryableErrorCode(code) {
+					kind = "transient, retried before failing"
+				}
+				fmt.Fprintf(&body, "- %s: %d (%s)\n", code, stats.ErrorCodes[code], kind)
+			}
+		}
+	}
+
+	if len(run.Errors) > 0 {
+		body.WriteString("\nErrors:\n")
+		for i, runError := range run.Errors {
+			if i == maxNotificationErrors {
+				fmt.Fprintf(&body, "... and %d more\n", len(run.Errors)-maxNotificationErrors)
+				break
+			}
+			fmt.Fprintf(&body, "- %s\n", runError)
+		}
+	}
+
+	return subject, body.String()
+}
+
+// FormatFireCrawlAccountAlert builds the alert sent when tasks of a run failed because of the
+// FireCrawl account rather than the sites: credits ran out or the API key was rejected. Every
+// scrape fails the same way until someone fixes the account, so it is sent apart from the run
+// notification. ok is false when the run had no such failures.
+func FormatFireCrawlAccountAlert(run *models.FanOutRun) (subject, body string, ok bool) {
+	if run.Stats == nil {
+		return "", "", false
+	}
+	quota := run.Stats.ErrorCodes[models.ErrorCodeQuotaExhausted]
+	invalidKey := run.Stats.ErrorCodes[models.ErrorCodeInvalidAPIKey]
+
+	var message strings.Builder
+	switch {
+	case quota > 0:
+		subject = "FireCrawl credits exhausted"
+		fmt.Fprintf(&message, "%d tasks of scraping run %s failed because the FireCrawl account is out of credits.\n", quota, run.RunID)
+		message.WriteString("Scraping, previews and admin crawls will keep failing until credits are added or the plan renews.\n")
+	case invalidKey > 0:
+		subject = "FireCrawl API key rejected"
+		fmt.Fprintf(&message, "%d tasks of scraping run %s failed because FireCrawl rejected the API key.\n", invalidKey, run.RunID)
+		message.WriteString("Check FIRECRAWL_API_KEY on the scraping and admin Lambdas.\n")
+	default:
+		return "", "", false
+	}
+	fmt.Fprintf(&message, "\nThe run finished with status %s: %d completed, %d failed of %d tasks.\n", run.Status, run.CompletedTasks, run.FailedTasks, run.TotalTasks)
+	return subject, message.String(), true
+}
+
+// FormatStaleTaskAlert builds the subject and plain-text body of the alert sent when the watchdog
+// takes a stale task off its run. The last checkpoint shows how far the run got before it stalled.
+func FormatStaleTaskAlert(task *models.ScrapingTask, now time.Time) (string, string) {
+	subject := fmt.Sprintf("Scraping task %s stalled", task.TaskID)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Scraping task %s for source %s stopped sending heartbeats while in progress.\n\n", task.TaskID, task.SourceID)
+	if task.RunID != "" {
+		fmt.Fprintf(&body, "Run: %s\n", task.RunID)
+	}
+	fmt.Fprintf(&body, "Last seen: %s (%s ago)\n", task.LastSeen().UTC().Format(time.RFC3339), now.Sub(task.LastSeen()).Round(time.Minute))
+	if task.LastCheckpoint != "" {
+		fmt.Fprintf(&body, "Last checkpoint: %s\n", task.LastCheckpoint)
+	} else {
+		body.WriteString("Last checkpoint: none, no URL finished\n")
+	}
+
+	if task.Status == models.TaskStatusRetrying {
+		fmt.Fprintf(&body, "\nThe task will be retried after %s (retry %d of %d).\n", models.RetryBackoff(task.RetryCount), task.RetryCount, task.MaxRetries)
+	} else {
+		fmt.Fprintf(&body, "\nThe task failed after %d retries.\n", task.RetryCount)
+	}
+
+	return subject, body.String()
+}
+
+// FormatDeadLetterAlert builds the subject and plain-text body of the alert sent when a scrape task
+// message failed every delivery and was moved to the dead-letter queue
+func FormatDeadLetterAlert(message *models.FailedTaskMessage) (string, string) {
+	subject := fmt.Sprintf("Scrape task message %s dead-lettered", message.MessageID)
+
+	var body strings.Builder
+	if message.Task != nil {
+		subject = fmt.Sprintf("Scrape task %s dead-lettered", message.Task.TaskID)
+		fmt.Fprintf(&body, "The scrape of %s for source %s failed every delivery and was moved to the dead-letter queue.\n\n", message.Task.URL, message.Task.SourceName)
+		fmt.Fprintf(&body, "Run: %s\n", message.Task.RunID)
+		if message.RunRecorded {
+			body.WriteString("The URL was recorded as failed on the run.\n")
+		} else {
+			body.WriteString("The URL could not be recorded on the run, which may not finish on its own.\n")
+		}
+	} else {
+		body.WriteString("A message that is not a scrape task failed every delivery and was moved to the dead-letter queue.\n")
+	}
+
+	fmt.Fprintf(&body, "\nFailed deliveries recorded: %d\n", len(message.Failures))
+	fmt.Fprintf(&body, "Last error: %s\n", message.LastError())
+	fmt.Fprintf(&body, "\nThe message is listed at GET /api/tasks/failed until %s.\n", message.DeadLetteredAt.Add(models.DeadLetterRetention).UTC().Format(time.RFC3339))
+
+	return subject, body.String()
+}
+
+// RunFailure classifies a failed run from its task results for the scraping task that requested it.
+// The code is the most common among the failed tasks, and the run is only retryable when every
+// failure was, since a run with a permanent failure would fail the same way again.
+func RunFailure(results []models.FanOutTaskResult) TaskFailure {
+	counts := make(map[string]int)
+	failure := TaskFailure{Retryable: true}
+	failed := 0
+	for _, result := range results {
+		if result.Success || result.Cancelled {
+			continue
+		}
+		failed++
+		code := result.ErrorCode
+		if code == "" {
+			code = models.ErrorCodeUnknown
+		}
+		counts[code]++
+		if counts[code] > counts[failure.Code] || (counts[code] == counts[failure.Code] && code < failure.Code) {
+			failure.Code = code
+		}
+		if failure.Message == "" {
+			failure.Message = result.ErrorMessage
+		}
+		if result.RetryAfterSeconds > failure.RetryAfterSeconds {
+			failure.RetryAfterSeconds = result.RetryAfterSeconds
+		}
+		if !IsRetryableErrorCode(code) {
+			failure.Retryable = false
+		}
+	}
+
+	if failed == 0 {
+		return TaskFailure{Code: models.ErrorCodeUnknown, Message: "run failed without task errors", Retryable: true}
+	}
+	if failed > 1 {
+		failure.Message = fmt.Sprintf("%d URLs failed, first: %s", failed, failure.Message)
+	}
+	return failure
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestFinalRunStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      models.FanOutRun
+		expected string
+	}{
+		{"AllSucceeded", models.FanOutRun{TotalTasks: 3, CompletedTasks: 3}, models.RunStatusCompleted},
+		{"SomeFailed", models.FanOutRun{TotalTasks: 3, CompletedTasks: 2, FailedTasks: 1}, models.RunStatusPartial},
+		{"AllFailed", models.FanOutRun{TotalTasks: 2, FailedTasks: 2}, models.RunStatusFailed},
+		{"NoTasks", models.FanOutRun{}, models.RunStatusCompleted},
+		{"SomeNotQueued", models.FanOutRun{TotalTasks: 2, CompletedTasks: 2, Errors: []string{"enqueue: 1 of 3 tasks were not queued"}}, models.RunStatusPartial},
+		{"NoneQueued", models.FanOutRun{Errors: []string{"enqueue: 3 of 3 tasks were not queued"}}, models.RunStatusFailed},
+		{"Cancelled", models.FanOutRun{TotalTasks: 3, CompletedTasks: 1, CancelledTasks: 2}, models.RunStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FinalRunStatus(&tt.run); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSummarizeFanOutRun(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	run := &models.FanOutRun{RunID: "run-1", StartedAt: start}
+	results := []models.FanOutTaskResult{
+		{SourceID: "src-a", SourceName: "Seattle Parks", Success: true, ActivitiesFound: 12, DurationMs: 4000},
+		{SourceID: "src-a", SourceName: "Seattle Parks", Success: false, DurationMs: 1000},
+		{SourceID: "src-a", SourceName: "Seattle Parks", Success: true, DurationMs: 1000, Outcome: models.TaskOutcomeSkippedUnchanged},
+		{SourceID: "src-b", SourceName: "ParentMap", Success: true, ActivitiesFound: 8, DurationMs: 7000, LimitHit: models.RunLimitActivities},
+		{SourceID: "src-c", SourceName: "Library", Success: false, DurationMs: 2000},
+		{SourceID: "src-d", SourceName: "Museum", Cancelled: true},
+	}
+
+	stats := SummarizeFanOutRun(run, results, start.Add(90*time.Second))
+
+	if stats.DurationMs != 90000 {
+		t.Errorf("Expected duration 90000 ms, got %d", stats.DurationMs)
+	}
+	if stats.SuccessRate != 0.6 {
+		t.Errorf("Expected success rate 0.6, got %.2f", stats.SuccessRate)
+	}
+	if stats.AvgTaskDurationMs != 3000 || stats.MaxTaskDurationMs != 7000 {
+		t.Errorf("Unexpected task durations: avg=%d max=%d", stats.AvgTaskDurationMs, stats.MaxTaskDurationMs)
+	}
+	if stats.SuccessfulSources != 2 || stats.FailedSources != 1 {
+		t.Errorf("Expected 2 successful and 1 failed source, got %d and %d", stats.SuccessfulSources, stats.FailedSources)
+	}
+	if _, ok := stats.Sources["src-d"]; ok {
+		t.Errorf("Expected cancelled tasks to be left out of the source stats")
+	}
+
+	parks := stats.Sources["src-a"]
+	if parks.SourceName != "Seattle Parks" || parks.Tasks != 3 || parks.FailedTasks != 1 || parks.UnchangedTasks != 1 || parks.ActivitiesFound != 12 {
+		t.Errorf("Unexpected source stats: %+v", parks)
+	}
+	if parks.LimitHit != "" || stats.Sources["src-b"].LimitHit != models.RunLimitActivities {
+		t.Errorf("Expected only ParentMap to report a run limit, got %+v", stats.Sources)
+	}
+}
+
+func TestFormatRunNotification(t *testing.T) {
+	run := &models.FanOutRun{
+		RunID:               "run-1",
+		TriggerType:         "scheduled",
+		Status:              models.RunStatusPartial,
+		TotalTasks:          3,
+		CompletedTasks:      2,
+		FailedTasks:         1,
+		TotalActivities:     20,
+		PublishedActivities: 140,
+		Errors:              []string{"Library (https://lib.example.com): timeout"},
+		Stats: &models.FanOutRunStats{
+			DurationMs:        90000,
+			SuccessRate:       2.0 / 3,
+			SuccessfulSources: 1,
+			FailedSources:     1,
+			Sources: map[string]models.FanOutSourceStats{
+				"src-a": {SourceName: "Seattle Parks", Tasks: 2, ActivitiesFound: 20, LimitHit: models.RunLimitPages},
+				"src-b": {SourceName: "Library", Tasks: 1, FailedTasks: 1},
+			},
+		},
+	}
+
+	subject, message := FormatRunNotification(run)
+
+	if subject != "Scraping run run-1 partial" {
+		t.Errorf("Unexpected subject: %q", subject)
+	}
+	for _, expected := range []string{
+		"Tasks: 2 completed, 1 failed of 3",
+		"Activities published: 140",
+		"Duration: 1m30s",
+		"Success rate: 67%",
+		"- Seattle Parks: 20 activities, 0/2 tasks failed, stopped at max_pages limit",
+		"- Library: 0 activities, 1/1 tasks failed\n",
+		"- Library (https://lib.example.com): timeout",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected message to contain %q, got:\n%s", expected, message)
+		}
+	}
+}
+
+func TestFormatStaleTaskAlert(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	heartbeat := now.Add(-50 * time.Minute)
+	task := &models.ScrapingTask{
+		TaskID:         "task-1",
+		SourceID:       "src-a",
+		RunID:          "run-1",
+		Status:         models.TaskStatusRetrying,
+		RetryCount:     1,
+		MaxRetries:     2,
+		HeartbeatAt:    &heartbeat,
+		LastCheckpoint: "src-a-0 (https://parks.example.com/events) succeeded with 12 activities",
+	}
+
+	subject, message := FormatStaleTaskAlert(task, now)
+
+	if subject != "Scraping task task-1 stalled" {
+		t.Errorf("Unexpected subject: %q", subject)
+	}
+	for _, expected := range []string{
+		"Run: run-1",
+		"Last seen: 2026-10-16T17:10:00Z (50m0s ago)",
+		"Last checkpoint: src-a-0 (https://parks.example.com/events) succeeded with 12 activities",
+		"retried after 5m0s (retry 1 of 2)",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected message to contain %q, got:\n%s", expected, message)
+		}
+	}
+
+	task.Status = models.TaskStatusFailed
+	task.LastCheckpoint = ""
+	_, message = FormatStaleTaskAlert(task, now)
+	if !strings.Contains(message, "Last checkpoint: none") || !strings.Contains(message, "failed after 1 retries") {
+		t.Errorf("Expected a failed task without a checkpoint, got:\n%s", message)
+	}
+}
+
+func TestFormatDeadLetterAlert(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	body := `{"run_id":"run-1","task_id":"src-a-0","source_id":"src-a","source_name":"Seattle Parks","url":"https://parks.example.com/events"}`
+	failures := []models.MessageFailure{{ReceiveCount: 3, Error: "failed to record task result: throttled", FailedAt: now}}
+	message := models.NewFailedTaskMessage("msg-1", body, failures, now.Add(-time.Hour), now)
+	message.RunRecorded = true
+
+	subject, text := FormatDeadLetterAlert(message)
+
+	if subject != "Scrape task src-a-0 dead-lettered" {
+		t.Errorf("Unexpected subject: %q", subject)
+	}
+	for _, expected := range []string{
+		"The scrape of https://parks.example.com/events for source Seattle Parks",
+		"Run: run-1",
+		"recorded as failed on the run",
+		"Failed deliveries recorded: 1",
+		"Last error: failed to record task result: throttled",
+		"until 2026-10-30T18:00:00Z",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected message to contain %q, got:\n%s", expected, text)
+		}
+	}
+
+	subject, text = FormatDeadLetterAlert(models.NewFailedTaskMessage("msg-2", "not json", nil, now, now))
+	if subject != "Scrape task message msg-2 dead-lettered" || !strings.Contains(text, "not a scrape task") {
+		t.Errorf("Expected an alert for a message that is not a task, got %q:\n%s", subject, text)
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	t.Run("MostCommonCode", func(t *testing.T) {
+		failure := RunFailure([]models.FanOutTaskResult{
+			{TaskID: "a", ErrorMessage: "Status code 429", ErrorCode: models.ErrorCodeRateLimited, Retryable: true},
+			{TaskID: "b", ErrorMessage: "timed out", ErrorCode: models.ErrorCodeTimeout, Retryable: true},
+			{TaskID: "c", ErrorMessage: "Status code 429", ErrorCode: models.ErrorCodeRateLimited, Retryable: true},
+			{TaskID: "d", Cancelled: true},
+		})
+		if failure.Code != models.ErrorCodeRateLimited || !failure.Retryable {
+			t.Errorf("Expected a retryable rate limit failure, got %+v", failure)
+		}
+		if failure.Message != "3 URLs failed, first: Status code 429" {
+			t.Errorf("Unexpected message %q", failure.Message)
+		}
+	})
+
+	t.Run("PermanentFailureIsNotRetryable", func(t *testing.T) {
+		failure := RunFailure([]models.FanOutTaskResult{
+			{ErrorMessage: "timed out", ErrorCode: models.ErrorCodeTimeout, Retryable: true},
+			{ErrorMessage: "denied", ErrorCode: models.ErrorCodeBlocked},
+		})
+		if failure.Retryable {
+			t.Errorf("Expected a run with a blocked URL not to be retryable, got %+v", failure)
+		}
+	})
+
+	t.Run("LongestRetryAfter", func(t *testing.T) {
+		failure := RunFailure([]models.FanOutTaskResult{
+			{ErrorMessage: "rate limited", ErrorCode: models.ErrorCodeRateLimited, Retryable: true, RetryAfterSeconds: 120},
+			{ErrorMessage: "rate limited", ErrorCode: models.ErrorCodeRateLimited, Retryable: true, RetryAfterSeconds: 900},
+		})
+		if failure.RetryAfterSeconds != 900 {
+			t.Errorf("Expected the longest retry-after, got %+v", failure)
+		}
+	})
+}
+
+func TestFormatFireCrawlAccountAlert(t *testing.T) {
+	run := &models.FanOutRun{RunID: "run-1", Status: models.RunStatusFailed, FailedTasks: 3, TotalTasks: 3}
+	results := []models.FanOutTaskResult{
+		{SourceID: "a", ErrorCode: models.ErrorCodeQuotaExhausted},
+		{SourceID: "a", ErrorCode: models.ErrorCodeQuotaExhausted},
+		{SourceID: "b", ErrorCode: models.ErrorCodeRateLimited, Retryable: true},
+	}
+	run.Stats = SummarizeFanOutRun(run, results, time.Now())
+
+	subject, body, ok := FormatFireCrawlAccountAlert(run)
+	if !ok || subject != "FireCrawl credits exhausted" || !strings.Contains(body, "2 tasks of scraping run run-1") {
+		t.Errorf("Expected a credits alert for 2 tasks, got %v %q %q", ok, subject, body)
+	}
+
+	_, notification := FormatRunNotification(run)
+	if !strings.Contains(notification, "- quota_exhausted: 2 (needs attention)") || !strings.Contains(notification, "- rate_limited: 1 (transient") {
+		t.Errorf("Expected failures split by cause in the run notification, got %q", notification)
+	}
+
+	run.Stats = SummarizeFanOutRun(run, results[2:], time.Now())
+	if _, _, ok := FormatFireCrawlAccountAlert(run); ok {
+		t.Error("Expected no account alert for transient failures")
+	}
+}
@@ -0,0 +1,423 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Upload limits. Uploads are retried up to maxS3Attempts requests, waiting s3RetryBaseDelay before
+// the first retry and doubling the wait up to s3RetryMaxDelay.
+const (
+	// MultipartThreshold is the body size from which objects are uploaded in parts
+	MultipartThreshold = 16 << 20
+
+	// multipartPartSize is the size of every part but the last; S3 requires at least 5 MiB
+	multipartPartSize = 8 << 20
+
+	maxS3Attempts = 5
+)
+
+var (
+	s3RetryBaseDelay = 500 * time.Millisecond
+	s3RetryMaxDelay  = 10 * time.Second
+)
+
+// S3Store uploads and downloads objects in an S3 bucket and hands out presigned download links
+type S3Store struct {
+	bucket      string
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+
+	multipartThreshold int
+	partSize           int
+}
+
+// NewS3Store creates a store for the given bucket
+func NewS3Store(cfg aws.Config, bucket string) *S3Store {
+	endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, cfg.Region)
+	if cfg.BaseEndpoint != nil {
+		// A configured endpoint, such as a local S3, is addressed path-style
+		endpoint = strings.TrimSuffix(*cfg.BaseEndpoint, "/") + "/" + bucket
+	}
+	return &S3Store{
+		bucket:      bucket,
+		endpoint:    endpoint,
+		region:      cfg.Region,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+
+		multipartThreshold: MultipartThreshold,
+		partSize:           multipartPartSize,
+	}
+}
+
+// Put uploads an object to the bucket
+func (s *S3Store) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	_, err := s.PutObject(ctx, key, body, contentType)
+	return err
+}
+
+// PutObject uploads an object to the bucket and returns what was stored. Bodies of at least
+// MultipartThreshold bytes are uploaded in parts. Throttling, server errors and dropped connections
+// are retried with backoff, and the ETag S3 returns is checked against the body's MD5.
+func (s *S3Store) PutObject(ctx context.Context, key string, body []byte, contentType string) (*models.PublishedObject, error) {
+	hash := sha256.Sum256(body)
+	object := &models.PublishedObject{
+		Key:         key,
+		ContentType: contentType,
+		Size:        int64(len(body)),
+		SHA256:      hex.EncodeToString(hash[:]),
+	}
+
+	var err error
+	if len(body) >= s.multipartThreshold {
+		err = s.putMultipart(ctx, object, body)
+	} else {
+		err = s.putSingle(ctx, object, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// putSingle uploads a body in one request
+func (s *S3Store) putSingle(ctx context.Context, object *models.PublishedObject, body []byte) error {
+	sum := md5.Sum(body)
+	header := http.Header{}
+	header.Set("Content-Type", object.ContentType)
+	header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+
+	respHeader, _, err := s.sendWithRetry(ctx, http.MethodPut, s.objectURL(object.Key), body, header, &object.Attempts)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	return verifyUpload(object, respHeader.Get("ETag"), respHeader, hex.EncodeToString(sum[:]))
+}
+
+// putMultipart uploads a body in parts of partSize bytes. A failed upload is aborted so its parts
+// are not kept, and billed, by the bucket.
+func (s *S3Store) putMultipart(ctx context.Context, object *models.PublishedObject, body []byte) error {
+	objectURL := s.objectURL(object.Key)
+	header := http.Header{}
+	header.Set("Content-Type", object.ContentType)
+	_, respBody, err := s.sendWithRetry(ctx, http.MethodPost, objectURL+"?uploads", nil, header, &object.Attempts)
+	if err != nil {
+		return fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(respBody, &initiated); err != nil || initiated.UploadID == "" {
+		return fmt.Errorf("failed to read multipart upload ID: %v", err)
+	}
+	uploadURL := objectURL + "?uploadId=" + url.QueryEscape(initiated.UploadID)
+
+	completed := completeMultipartUpload{}
+	var partSums []byte
+	for offset := 0; offset < len(body); offset += s.partSize {
+		end := offset + s.partSize
+		if end > len(body) {
+			end = len(body)
+		}
+		part := body[offset:end]
+		number := len(completed.Parts) + 1
+
+		sum := md5.Sum(part)
+		partHeader := http.Header{}
+		partHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		partURL := fmt.Sprintf("%s?partNumber=%d&uploadId=%s", objectURL, number, url.QueryEscape(initiated.UploadID))
+		respHeader, _, err := s.sendWithRetry(ctx, http.MethodPut, partURL, part, partHeader, &object.Attempts)
+		if err != nil {
+			s.abortMultipart(ctx, uploadURL)
+			return fmt.Errorf("failed to upload part %d: %w", number, err)
+		}
+		etag := strings.Trim(respHeader.Get("ETag"), `"`)
+		if !kmsEncrypted(respHeader) && etag != hex.EncodeToString(sum[:]) {
+			s.abortMultipart(ctx, uploadURL)
+			return fmt.Errorf("%w: part %d of %s has ETag %s", ErrChecksumMismatch, number, object.Key, etag)
+		}
+
+		completed.Parts = append(completed.Parts, completedPart{PartNumber: number, ETag: respHeader.Get("ETag")})
+		partSums = append(partSums, sum[:]...)
+	}
+
+	payload, err := xml.Marshal(completed)
+	if err != nil {
+		s.abortMultipart(ctx, uploadURL)
+		return fmt.Errorf("failed to marshal completed parts: %w", err)
+	}
+	respHeader, respBody, err := s.sendWithRetry(ctx, http.MethodPost, uploadURL, payload, http.Header{"Content-Type": {"application/xml"}}, &object.Attempts)
+	if err != nil {
+		s.abortMultipart(ctx, uploadURL)
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	var result struct {
+		ETag string `xml:"ETag"`
+	}
+	if err := xml.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to read completed multipart upload: %w", err)
+	}
+
+	// The ETag of a multipart object is the MD5 of its parts' MD5s, followed by the part count
+	object.Parts = len(completed.Parts)
+	sum := md5.Sum(partSums)
+	return verifyUpload(object, result.ETag, respHeader, fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), object.Parts))
+}
+
+// abortMultipart discards the parts of a failed multipart upload. It runs even when the upload
+// failed because ctx was cancelled; a failure is only logged, as a lifecycle rule can still clean up.
+func (s *S3Store) abortMultipart(ctx context.Context, uploadURL string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if _, _, err := s.send(ctx, http.MethodDelete, uploadURL, nil, http.Header{}); err != nil {
+		log.Printf("Warning: Failed to abort multipart upload %s: %v", uploadURL, err)
+	}
+}
+
+// completeMultipartUpload is the body of a CompleteMultipartUpload request
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// ErrChecksumMismatch is returned when S3 stored an object that does not match the uploaded body
+var ErrChecksumMismatch = errors.New("uploaded object does not match its checksum")
+
+// verifyUpload records the ETag and version S3 returned for an upload, checking the ETag against
+// the one expected for the body. ETags of KMS-encrypted objects are not MD5s, so they are not checked.
+func verifyUpload(object *models.PublishedObject, etag string, header http.Header, expected string) error {
+	object.ETag = strings.Trim(etag, `"`)
+	object.VersionID = header.Get("X-Amz-Version-Id")
+	if !kmsEncrypted(header) && object.ETag != expected {
+		return fmt.Errorf("%w: %s has ETag %s, expected %s", ErrChecksumMismatch, object.Key, object.ETag, expected)
+	}
+	return nil
+}
+
+func kmsEncrypted(header http.Header) bool {
+	return strings.HasPrefix(header.Get("X-Amz-Server-Side-Encryption"), "aws:kms")
+}
+
+// S3Error is an error response from S3
+type S3Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *S3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("S3 returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("S3 returned status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Retryable reports whether the request may succeed when sent again: throttling such as 503
+// SlowDown, server errors, and requests S3 timed out waiting for
+func (e *S3Error) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500 || e.Code == "RequestTimeout"
+}
+
+// sendWithRetry sends a request until it succeeds or maxS3Attempts requests were sent, backing off
+// exponentially between them. S3 answers a prefix written faster than it can scale with 503
+// SlowDown, so backing off is what lets a large publish through. attempts counts the requests sent.
+func (s *S3Store) sendWithRetry(ctx context.Context, method, rawURL string, body []byte, header http.Header, attempts *int) (http.Header, []byte, error) {
+	delay := s3RetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		*attempts++
+		respHeader, respBody, err := s.send(ctx, method, rawURL, body, header)
+		if err == nil {
+			return respHeader, respBody, nil
+		}
+		if attempt == maxS3Attempts || !retryableSendError(ctx, err) {
+			return nil, nil, err
+		}
+
+		log.Printf("Warning: S3 %s attempt %d of %d failed, retrying in %s: %v", method, attempt, maxS3Attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > s3RetryMaxDelay {
+			delay = s3RetryMaxDelay
+		}
+	}
+}
+
+// retryableSendError reports whether a failed request is worth sending again. Dropped connections
+// are; requests that could not be built or signed, and those of a cancelled context, are not.
+func retryableSendError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var s3Err *S3Error
+	if errors.As(err, &s3Err) {
+		return s3Err.Retryable()
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// send signs and sends one upload request, returning the response headers and body. Error responses
+// are returned as *S3Error, including the errors S3 reports in the body of a 200 response to
+// CompleteMultipartUpload.
+func (s *S3Store) send(ctx context.Context, method, rawURL string, body []byte, header http.Header) (http.Header, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	// S3 requires the payload hash as a header in addition to the signature
+	payloadHash := sha256.Sum256(body)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+
+	err = s.signer.SignHTTP(ctx, credentials, req, payloadHex, "s3", s.region, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Upload responses are small XML documents
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, nil, &url.Error{Op: method, URL: rawURL, Err: err}
+	}
+
+	var s3Err struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}
+	if resp.StatusCode >= 300 {
+		xml.Unmarshal(respBody, &s3Err)
+		return nil, nil, &S3Error{StatusCode: resp.StatusCode, Code: s3Err.Code, Message: s3Err.Message}
+	}
+	if len(respBody) > 0 && xml.Unmarshal(respBody, &s3Err) == nil {
+		return nil, nil, &S3Error{StatusCode: http.StatusInternalServerError, Code: s3Err.Code, Message: s3Err.Message}
+	}
+	return resp.Header, respBody, nil
+}
+
+// ErrObjectNotFound is returned by Get when the bucket has no object with the key
+var ErrObjectNotFound = errors.New("object not found")
+
+// Get downloads an object from the bucket
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	// The hash of an empty payload
+	payloadHex := hex.EncodeToString(sha256.New().Sum(nil))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+
+	err = s.signer.SignHTTP(ctx, credentials, req, payloadHex, "s3", s.region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign download request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("download returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return body, nil
+}
+
+// PresignGet returns a time-limited download URL for an object in the bucket
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create presign request: %w", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	req.URL.RawQuery = query.Encode()
+
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	signedURL, _, err := s.signer.PresignHTTP(ctx, credentials, req, "UNSIGNED-PAYLOAD", "s3", s.region, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object URL: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+// objectURL builds the virtual-hosted style URL for a key
+func (s *S3Store) objectURL(key string) string {
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return s.endpoint + "/" + strings.Join(segments, "/")
+}
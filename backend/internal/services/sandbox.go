@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// SandboxTableSuffix ends the name of every sandbox table. Sandbox tables are only cleared when all
+// four names end with it, so a misconfigured sandbox cannot empty production.
+const SandboxTableSuffix = "-sandbox"
+
+// SandboxAnalysisVersion marks the analyses the sandbox gives submitted sources
+const SandboxAnalysisVersion = "sandbox-demo"
+
+// SandboxSeed is the demo data a sandbox starts with after a reset: analyzed sources waiting to be
+// activated, and extracted events waiting for review
+type SandboxSeed struct {
+	Submissions []*models.SourceSubmission `json:"submissions"`
+	AdminEvents []*models.AdminEvent       `json:"admin_events"`
+}
+
+// NewSandboxSeed builds the demo data. Event dates are a few days after now, so the events are
+// upcoming whenever the sandbox is reset.
+func NewSandboxSeed(now time.Time) *SandboxSeed {
+	seed := &SandboxSeed{
+		Submissions: []*models.SourceSubmission{
+			{
+				SourceID:        "demo-library-story-times",
+				SourceName:      "Demo Library Story Times",
+				BaseURL:         "https://library.example.org/events",
+				SourceType:      "venue",
+				Priority:        "high",
+				ExpectedContent: []string{"events"},
+				SubmittedBy:     "sandbox",
+				Status:          models.SourceStatusAnalysisComplete,
+			},
+			{
+				SourceID:        "demo-parks-nature-programs",
+				SourceName:      "Demo Parks Nature Programs",
+				BaseURL:         "https://parks.example.org/programs",
+				SourceType:      "program-provider",
+				Priority:        "medium",
+				ExpectedContent: []string{"classes", "camps"},
+				SubmittedBy:     "sandbox",
+				Status:          models.SourceStatusAnalysisComplete,
+			},
+		},
+	}
+
+	day := func(days int) string {
+		return now.AddDate(0, 0, days).Format("2006-01-02")
+	}
+	events := []struct {
+		id   string
+		url  string
+		data map[string]interface{}
+	}{
+		{"demo-event-toddler-story-time", "https://library.example.org/events", map[string]interface{}{
+			"title":       "Toddler Story Time",
+			"description": "Songs, rhymes and picture books for toddlers and their grown-ups.",
+			"date":        day(3),
+			"time":        "10:30",
+			"location":    "Demo Branch Library, 5614 22nd Ave NW, Seattle",
+			"ages":        "1-3 years",
+			"price":       "Free",
+		}},
+		{"demo-event-lego-club", "https://library.example.org/events", map[string]interface{}{
+			"title":       "LEGO Build Club",
+			"description": "Build with the library's LEGO collection. Bricks provided.",
+			"date":        day(5),
+			"time":        "15:30",
+			"location":    "Demo Branch Library, 5614 22nd Ave NW, Seattle",
+			"ages":        "6-12 years",
+			"price":       "Free",
+		}},
+		{"demo-event-tide-pool-walk", "https://parks.example.org/programs", map[string]interface{}{
+			"title":       "Tide Pool Walk",
+			"description": "Explore the beach at low tide with a park naturalist.",
+			"date":        day(9),
+			"time":        "09:00",
+			"location":    "Demo Beach Park, 4503 Beach Dr SW, Seattle",
+			"ages":        "4-10 years",
+			"price":       "$5 per child",
+		}},
+		// Missing a date and location, for practicing edits and rejections
+		{"demo-event-incomplete", "https://parks.example.org/programs", map[string]interface{}{
+			"title":       "Nature Journaling (details TBA)",
+			"description": "Check back soon!",
+		}},
+	}
+	for _, event := range events {
+		seed.AdminEvents = append(seed.AdminEvents, &models.AdminEvent{
+			EventID:          event.id,
+			SourceURL:        event.url,
+			SchemaType:       "events",
+			RawExtractedData: map[string]interface{}{"events": []interface{}{event.data}},
+			Status:           models.AdminEventStatusPending,
+			ExtractedByUser:  "sandbox",
+		})
+	}
+	return seed
+}
+
+// ResetSandbox empties the sandbox tables and stores the demo data, with the conversion preview
+// reviewers see on crawled events. It returns the seed and how many items were deleted.
+func ResetSandbox(ctx context.Context, dynamo *DynamoDBService, conversion *SchemaConversionService, now time.Time) (*SandboxSeed, int, error) {
+	deleted, err := dynamo.ClearSandboxTables(ctx)
+	if err != nil {
+		return nil, deleted, err
+	}
+
+	seed := NewSandboxSeed(now)
+	for _, submission := range seed.Submissions {
+		if err := dynamo.CreateSourceSubmission(ctx, submission); err != nil {
+			return nil, deleted, fmt.Errorf("failed to seed source %s: %w", submission.SourceID, err)
+		}
+		if err := dynamo.CreateSourceAnalysis(ctx, SandboxAnalysis(submission)); err != nil {
+			return nil, deleted, fmt.Errorf("failed to seed the analysis of source %s: %w", submission.SourceID, err)
+		}
+	}
+	for _, adminEvent := range seed.AdminEvents {
+		if result, err := conversion.ConvertToActivity(adminEvent); err == nil {
+			if result.Activity != nil {
+				activityJSON, _ := json.Marshal(result.Activity)
+				json.Unmarshal(activityJSON, &adminEvent.ConvertedData)
+			}
+			adminEvent.ConversionIssues = result.Issues
+			adminEvent.ConfidenceScore = result.ConfidenceScore
+			adminEvent.CompletenessScore = CompletenessScore(result.Activity)
+		}
+		if err := dynamo.CreateAdminEvent(ctx, adminEvent); err != nil {
+			return nil, deleted, fmt.Errorf("failed to seed event %s: %w", adminEvent.EventID, err)
+		}
+	}
+	return seed, deleted, nil
+}
+
+// SandboxAnalysis is the analysis the sandbox gives a submitted source in place of the source
+// analyzer's, which would crawl the source and write to production. It recommends weekly scraping
+// of the source's base URL and hint URLs.
+func SandboxAnalysis(submission *models.SourceSubmission) *models.SourceAnalysis {
+	targetURLs := append([]string{submission.BaseURL}, submission.HintURLs...)
+	return &models.SourceAnalysis{
+		SourceID:        submission.SourceID,
+		AnalysisVersion: SandboxAnalysisVersion,
+		DiscoveredPatterns: models.DiscoveryPatterns{
+			ContentPages: []models.ContentPage{
+				{URL: submission.BaseURL, Type: "events", Confidence: 0.9, Title: submission.SourceName, Language: "en"},
+			},
+			DataSelectors: models.DataSelectors{
+				Title:       ".event-title",
+				Date:        ".event-date",
+				Time:        ".event-time",
+				Description: ".event-description",
+				Location:    ".event-location",
+				Price:       ".event-price",
+			},
+		},
+		ExtractionTestResults: models.ExtractionTestResults{
+			TestURL:      submission.BaseURL,
+			ItemsFound:   12,
+			QualityScore: 0.85,
+			SampleData: []models.ExtractedActivity{
+				{Title: "Family Story Time", Date: "Saturdays", Time: "10:30 AM", Location: submission.SourceName, Price: "Free", AgeRange: "0-5 years"},
+			},
+			Metrics: models.ExtractionMetrics{
+				TitleCompleteness:       1,
+				DateCompleteness:        0.9,
+				DescriptionCompleteness: 0.8,
+				LocationCompleteness:    0.9,
+				PriceCompleteness:       0.7,
+				OverallCompleteness:     0.86,
+			},
+		},
+		RecommendedConfig: models.RecommendedSourceConfig{
+			ScrapingFrequency:       "weekly",
+			RateLimit:               models.RateLimit{RequestsPerMinute: 10, DelayBetweenRequests: 2000, ConcurrentRequests: 1},
+			EstimatedItemsPerScrape: "10-20",
+			PreferredExtraction:     "html",
+			TargetURLs:              targetURLs,
+		},
+		OverallQualityScore: 0.85,
+		Issues:              []string{},
+		Recommendations:     []string{"Sandbox analysis: no pages were crawled. Real sources are analyzed by the source analyzer."},
+		Status:              models.SourceStatusAnalysisComplete,
+	}
+}
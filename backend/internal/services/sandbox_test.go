@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestNewSandboxSeed(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	seed := NewSandboxSeed(now)
+
+	if len(seed.Submissions) == 0 || len(seed.AdminEvents) == 0 {
+		t.Fatalf("Expected sources and events, got %d and %d", len(seed.Submissions), len(seed.AdminEvents))
+	}
+	for _, submission := range seed.Submissions {
+		if err := submission.Validate(); err != nil {
+			t.Errorf("Expected source %s to be valid, got %v", submission.SourceID, err)
+		}
+	}
+
+	conversion := NewSchemaConversionService()
+	converted, incomplete := 0, 0
+	for _, adminEvent := range seed.AdminEvents {
+		if adminEvent.Status != models.AdminEventStatusPending {
+			t.Errorf("Expected event %s to be pending, got %s", adminEvent.EventID, adminEvent.Status)
+		}
+		result, err := conversion.ConvertToActivity(adminEvent)
+		if err != nil || result.Activity == nil {
+			t.Errorf("Expected event %s to convert, got %v", adminEvent.EventID, err)
+			continue
+		}
+		if start := result.Activity.Schedule.StartDate; start == "" {
+			incomplete++
+		} else if start <= now.Format("2006-01-02") {
+			t.Errorf("Expected event %s to be upcoming, got %s", adminEvent.EventID, start)
+		} else {
+			converted++
+		}
+	}
+	if converted == 0 || incomplete != 1 {
+		t.Errorf("Expected upcoming events and one incomplete event, got %d and %d", converted, incomplete)
+	}
+}
+
+func TestSandboxAnalysis(t *testing.T) {
+	submission := &models.SourceSubmission{
+		SourceID:   "demo-source",
+		SourceName: "Demo Source",
+		BaseURL:    "https://example.org/events",
+		HintURLs:   []string{"https://example.org/camps"},
+	}
+
+	analysis := SandboxAnalysis(submission)
+	if analysis.SourceID != submission.SourceID || analysis.Status != models.SourceStatusAnalysisComplete {
+		t.Errorf("Expected a complete analysis of %s, got %s of %s", submission.SourceID, analysis.Status, analysis.SourceID)
+	}
+	targets := analysis.RecommendedConfig.TargetURLs
+	if len(targets) != 2 || targets[0] != submission.BaseURL || targets[1] != submission.HintURLs[0] {
+		t.Errorf("Expected the base and hint URLs as targets, got %v", targets)
+	}
+	if len(submission.HintURLs) != 1 {
+		t.Errorf("Expected the submission's hint URLs to be left alone, got %v", submission.HintURLs)
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// scheduledProgramTypes are the activity types that run as sessions of a program, which a venue
+// room can only host one of at a time
+var scheduledProgramTypes = map[string]bool{
+	models.TypeClass: true,
+	models.TypeCamp:  true,
+}
+
+// FindScheduleConflicts returns the pairs of program sessions held in the same room of a venue on
+// the same day whose times overlap but whose titles differ. Rooms are told apart by location name.
+// All-day sessions, sessions without a start time and entries not linked to a venue are skipped.
+// Conflicts are ordered by date, room and start time.
+func FindScheduleConflicts(entries []models.CalendarEntry) []models.ScheduleConflict {
+	groups := make(map[string][]models.CalendarEntry)
+	var keys []string
+	for _, entry := range entries {
+		if entry.VenueID == "" || entry.IsAllDay || !scheduledProgramTypes[entry.Type] {
+			continue
+		}
+		if _, _, ok := sessionMinutes(entry); !ok {
+			continue
+		}
+		key := entry.VenueID + "|" + entry.Date + "|" + normalizeVenueName(entry.LocationName)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], entry)
+	}
+
+	conflicts := []models.ScheduleConflict{}
+	for _, key := range keys {
+		sessions := groups[key]
+		sort.Slice(sessions, func(i, j int) bool {
+			if sessions[i].StartTime != sessions[j].StartTime {
+				return sessions[i].StartTime < sessions[j].StartTime
+			}
+			return sessions[i].ActivityID < sessions[j].ActivityID
+		})
+
+		for i := range sessions {
+			for j := i + 1; j < len(sessions); j++ {
+				first, second := sessions[i], sessions[j]
+				if first.ActivityID == second.ActivityID || normalizeVenueName(first.Title) == normalizeVenueName(second.Title) {
+					continue
+				}
+				if !sessionsOverlap(first, second) {
+					continue
+				}
+				conflicts = append(conflicts, models.ScheduleConflict{
+					VenueID: first.VenueID,
+					Date:    first.Date,
+					Room:    first.LocationName,
+					First:   conflictSession(first),
+					Second:  conflictSession(second),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Date != conflicts[j].Date {
+			return conflicts[i].Date < conflicts[j].Date
+		}
+		if conflicts[i].Room != conflicts[j].Room {
+			return conflicts[i].Room < conflicts[j].Room
+		}
+		return conflicts[i].First.StartTime < conflicts[j].First.StartTime
+	})
+	return conflicts
+}
+
+// ScheduleConflictWarnings describes the conflicts an activity is part of as conversion issues,
+// one per conflicting activity with the first day they overlap
+func ScheduleConflictWarnings(activityID string, conflicts []models.ScheduleConflict) []string {
+	type otherActivity struct {
+		conflict models.ScheduleConflict
+		other    models.ScheduleConflictSession
+		days     int
+	}
+	byOther := make(map[string]*otherActivity)
+	var order []string
+	for _, conflict := range conflicts {
+		var other models.ScheduleConflictSession
+		switch activityID {
+		case conflict.First.ActivityID:
+			other = conflict.Second
+		case conflict.Second.ActivityID:
+			other = conflict.First
+		default:
+			continue
+		}
+		if existing, ok := byOther[other.ActivityID]; ok {
+			existing.days++
+			continue
+		}
+		byOther[other.ActivityID] = &otherActivity{conflict: conflict, other: other, days: 1}
+		order = append(order, other.ActivityID)
+	}
+
+	var warnings []string
+	for _, otherID := range order {
+		found := byOther[otherID]
+		warning := fmt.Sprintf("Schedule conflict: overlaps '%s' (%s) in %s on %s",
+			found.other.Title, sessionTimes(found.other), found.conflict.Room, found.conflict.Date)
+		if found.days > 1 {
+			warning += fmt.Sprintf(" and %d more days", found.days-1)
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// sessionMinutes returns a session's start and end as minutes after midnight. A session without
+// a usable end time is treated as lasting a minute, so it only conflicts with sessions running at
+// its start time.
+func sessionMinutes(entry models.CalendarEntry) (int, int, bool) {
+	start, ok := clockMinutes(entry.StartTime)
+	if !ok {
+		return 0, 0, false
+	}
+	end, ok := clockMinutes(entry.EndTime)
+	if !ok || end <= start {
+		end = start + 1
+	}
+	return start, end, true
+}
+
+func sessionsOverlap(a, b models.CalendarEntry) bool {
+	aStart, aEnd, _ := sessionMinutes(a)
+	bStart, bEnd, _ := sessionMinutes(b)
+	return aStart < bEnd && bStart < aEnd
+}
+
+// clockMinutes parses an HH:MM time
+func clockMinutes(clock string) (int, bool) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &hours, &minutes); err != nil {
+		return 0, false
+	}
+	if hours < 0 || hours > 23 || minutes < 0 || minutes > 59 {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+func conflictSession(entry models.CalendarEntry) models.ScheduleConflictSession {
+	return models.ScheduleConflictSession{
+		ActivityID: entry.ActivityID,
+		Title:      entry.Title,
+		StartTime:  entry.StartTime,
+		EndTime:    entry.EndTime,
+	}
+}
+
+func sessionTimes(session models.ScheduleConflictSession) string {
+	if session.EndTime == "" {
+		return session.StartTime
+	}
+	return session.StartTime + "-" + session.EndTime
+}
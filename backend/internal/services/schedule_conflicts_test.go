@@ -0,0 +1,82 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func conflictEntry(activityID, title, date, start, end string) models.CalendarEntry {
+	return models.CalendarEntry{
+		ActivityID:   activityID,
+		Title:        title,
+		Type:         models.TypeClass,
+		Date:         date,
+		StartTime:    start,
+		EndTime:      end,
+		LocationName: "Ballard Library",
+		VenueID:      "ballard-library",
+	}
+}
+
+func TestFindScheduleConflicts(t *testing.T) {
+	otherRoom := conflictEntry("act-e", "Chess Club", "2026-10-05", "10:00", "11:00")
+	otherRoom.LocationName = "Ballard Library Meeting Room"
+	allDay := conflictEntry("act-f", "Book Sale", "2026-10-05", "10:00", "")
+	allDay.IsAllDay = true
+	event := conflictEntry("act-g", "Author Talk", "2026-10-05", "10:00", "11:00")
+	event.Type = models.TypeEvent
+
+	entries := []models.CalendarEntry{
+		conflictEntry("act-a", "Toddler Story Time", "2026-10-05", "10:00", "10:45"),
+		conflictEntry("act-b", "Lego Lab", "2026-10-05", "10:30", "11:30"),
+		conflictEntry("act-c", "Toddler  story time", "2026-10-05", "10:00", "10:45"), // same program listed twice
+		conflictEntry("act-d", "Teen Coding", "2026-10-05", "10:45", "12:00"),         // starts as story time ends
+		conflictEntry("act-h", "Baby Signing", "2026-10-06", "10:00", ""),             // no end time
+		conflictEntry("act-i", "Music Makers", "2026-10-06", "09:30", "10:30"),
+		otherRoom,
+		allDay,
+		event,
+	}
+
+	conflicts := FindScheduleConflicts(entries)
+
+	var pairs []string
+	for _, conflict := range conflicts {
+		pairs = append(pairs, conflict.Date+" "+conflict.First.ActivityID+"/"+conflict.Second.ActivityID)
+	}
+	expected := []string{
+		"2026-10-05 act-a/act-b",
+		"2026-10-05 act-c/act-b",
+		"2026-10-05 act-b/act-d",
+		"2026-10-06 act-i/act-h",
+	}
+	if strings.Join(pairs, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected conflicts %v, got %v", expected, pairs)
+	}
+	if conflicts[0].Room != "Ballard Library" || conflicts[0].VenueID != "ballard-library" {
+		t.Errorf("Unexpected conflict location: %+v", conflicts[0])
+	}
+}
+
+func TestScheduleConflictWarnings(t *testing.T) {
+	entries := []models.CalendarEntry{
+		conflictEntry("new", "Lego Lab", "2026-10-05", "10:30", "11:30"),
+		conflictEntry("new", "Lego Lab", "2026-10-12", "10:30", "11:30"),
+		conflictEntry("act-a", "Toddler Story Time", "2026-10-05", "10:00", "10:45"),
+		conflictEntry("act-a", "Toddler Story Time", "2026-10-12", "10:00", "10:45"),
+		conflictEntry("act-b", "Music Makers", "2026-10-12", "11:00", ""),
+		conflictEntry("act-c", "Chess Club", "2026-10-12", "11:30", "12:00"), // starts as Lego Lab ends
+	}
+
+	warnings := ScheduleConflictWarnings("new", FindScheduleConflicts(entries))
+
+	expected := []string{
+		"Schedule conflict: overlaps 'Toddler Story Time' (10:00-10:45) in Ballard Library on 2026-10-05 and 1 more days",
+		"Schedule conflict: overlaps 'Music Makers' (11:00) in Ballard Library on 2026-10-12",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected warnings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(warnings, "\n"))
+	}
+}
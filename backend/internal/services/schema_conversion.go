@@ -1,13 +1,16 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
+	"seattle-family-activities-scraper/internal/activityid"
 	"seattle-family-activities-scraper/internal/models"
 )
 
@@ -57,6 +60,11 @@ type FieldMapping struct {
 	MappingType   string   `json:"mapping_type"`      // direct|fallback|derived|default
 	Confidence    float64  `json:"confidence"`        // 0.0-1.0 confidence in the mapping
 	ValidationStatus string `json:"validation_status"` // valid|invalid|warning|not_validated
+
+	// Provenance for values rewritten after extraction
+	OriginalValue   string   `json:"original_value,omitempty"`   // value as extracted
+	NormalizedValue string   `json:"normalized_value,omitempty"` // value stored on the activity
+	Transformations []string `json:"transformations,omitempty"`  // normalization steps that changed the value
 }
 
 // FieldValidationResult represents the result of validating a field
@@ -68,15 +76,67 @@ type FieldValidationResult struct {
 }
 
 // SchemaConversionService handles conversion from raw extracted data to Activity model
-type SchemaConversionService struct{}
+type SchemaConversionService struct {
+	mu              sync.Mutex
+	lastDiagnostics *ConversionDiagnostics
+	validationRules ValidationRuleProvider
+	titleNormalizer *TitleNormalizer
+	neighborhoods   NeighborhoodLocator
+	tags            TagProvider
+	geocoder        Geocoder
+}
 
 // NewSchemaConversionService creates a new schema conversion service
 func NewSchemaConversionService() *SchemaConversionService {
-	return &SchemaConversionService{}
+	return &SchemaConversionService{
+		titleNormalizer: NewTitleNormalizer(),
+	}
+}
+
+// TitleNormalizer returns the normalizer applied to converted titles, e.g. to load the venue registry
+func (scs *SchemaConversionService) TitleNormalizer() *TitleNormalizer {
+	return scs.titleNormalizer
+}
+
+// SetValidationRules sets where validation rule sets are loaded from (built-in defaults when unset)
+func (scs *SchemaConversionService) SetValidationRules(provider ValidationRuleProvider) {
+	scs.validationRules = provider
+}
+
+// SetNeighborhoods sets how converted locations are assigned to neighborhood clusters (not assigned when unset)
+func (scs *SchemaConversionService) SetNeighborhoods(locator NeighborhoodLocator) {
+	scs.neighborhoods = locator
+}
+
+// SetTags sets the tags suggested for converted activities (built-in tags when unset)
+func (scs *SchemaConversionService) SetTags(provider TagProvider) {
+	scs.tags = provider
+}
+
+// SetGeocoder sets how locations without coordinates are geocoded (not geocoded when unset). The
+// geocoder should cache, since the same events are converted again and again.
+func (scs *SchemaConversionService) SetGeocoder(geocoder Geocoder) {
+	scs.geocoder = geocoder
 }
 
 // ConvertToActivity converts raw extracted data to Activity model
 func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEvent) (*models.ConversionResult, error) {
+	result, diagnostics, err := scs.ConvertToActivityWithDiagnostics(adminEvent)
+	scs.setLastConversionDiagnostics(diagnostics)
+	return result, err
+}
+
+// conversionActivityID returns the ID of a converted event: the ID it was published under, so
+// re-conversions keep it, or the deterministic ID of its first activity
+func conversionActivityID(adminEvent *models.AdminEvent, activity *models.Activity) string {
+	if adminEvent.ActivityID != "" {
+		return adminEvent.ActivityID
+	}
+	return activityid.New(adminEvent.SourceURL, activity.Title, activity.Schedule.StartDate, activity.Location.Name)
+}
+
+// ConvertToActivityWithDiagnostics converts raw extracted data to Activity model and returns the conversion diagnostics
+func (scs *SchemaConversionService) ConvertToActivityWithDiagnostics(adminEvent *models.AdminEvent) (*models.ConversionResult, *ConversionDiagnostics, error) {
 	startTime := time.Now()
 	
 	// Initialize conversion diagnostics
@@ -116,7 +176,7 @@ func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEv
 		diagnostics.Success = false
 		diagnostics.ErrorMessage = fmt.Sprintf("Failed to extract events from raw data: %v", err)
 		scs.logConversionDiagnostics(diagnostics)
-		return nil, fmt.Errorf("failed to extract events from raw data: %w", err)
+		return nil, diagnostics, fmt.Errorf("failed to extract events from raw data: %w", err)
 	}
 
 	extractionAttempt.Success = len(events) > 0
@@ -144,7 +204,7 @@ func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEv
 			Issues:          issues,
 			FieldMappings:   fieldMappings,
 			ConfidenceScore: 0.0,
-		}, nil
+		}, diagnostics, nil
 	}
 
 	log.Printf("[CONVERSION] Found %d events in raw data, converting first event", len(events))
@@ -178,14 +238,19 @@ func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEv
 	confidence := scs.calculateConfidenceScore(activity, issues)
 	diagnostics.ConfidenceScore = confidence
 
+	// Score how complete the activity is for families once published
+	if activity != nil {
+		activity.Completeness = ScoreActivityCompleteness(activity)
+		activity.ID = conversionActivityID(adminEvent, activity)
+	}
+
 	// Complete diagnostics
 	diagnostics.EndTime = time.Now()
 	diagnostics.ProcessingTime = time.Since(startTime)
 	diagnostics.Success = activity != nil
 
-	// Log final diagnostics and store for debugging
+	// Log final diagnostics
 	scs.logConversionDiagnostics(diagnostics)
-	lastConversionDiagnostics = diagnostics
 
 	// Record conversion metrics
 	qualityMetrics := scs.calculateConversionQualityMetrics(activity, issues)
@@ -210,6 +275,19 @@ func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEv
 		}
 	}
 
+	// Apply the schema type's conversion rules, the same engine used when parsing scraped events
+	if activity != nil {
+		ruleResult := ApplyValidationRules(scs.validationRuleSet(adminEvent.SchemaType).ConversionRules, activityFields(*activity))
+		validationResults["rules"] = ruleResult
+		for _, ruleIssue := range ruleResult.Issues {
+			diagnostics.ConversionIssues = append(diagnostics.ConversionIssues, ConversionIssue{
+				Type:     "validation_error",
+				Message:  ruleIssue,
+				Severity: "error",
+			})
+		}
+	}
+
 	return &models.ConversionResult{
 		Activity:          activity,
 		Issues:            issues,
@@ -217,7 +295,15 @@ func (scs *SchemaConversionService) ConvertToActivity(adminEvent *models.AdminEv
 		ConfidenceScore:   confidence,
 		DetailedMappings:  detailedMappings,
 		ValidationResults: validationResults,
-	}, nil
+	}, diagnostics, nil
+}
+
+// validationRuleSet returns the rule set for a schema type from the configured provider
+func (scs *SchemaConversionService) validationRuleSet(schemaType string) *models.ValidationRuleSet {
+	if scs.validationRules == nil {
+		return DefaultValidationRuleSet(schemaType)
+	}
+	return scs.validationRules.RuleSet(schemaType)
 }
 
 // extractEventsFromRawData extracts events array from different schema types (legacy method)
@@ -302,6 +388,20 @@ func (scs *SchemaConversionService) extractEventsFromRawDataWithDiagnostics(rawD
 		}
 		return events, nil
 
+	case "camps":
+		events, err := scs.extractEventsArrayWithValidation(rawData, "camps", attempt, diagnostics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract camps array: %w", err)
+		}
+		return events, nil
+
+	case "classes":
+		events, err := scs.extractEventsArrayWithValidation(rawData, "classes", attempt, diagnostics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract classes array: %w", err)
+		}
+		return events, nil
+
 	case "custom":
 		events, err := scs.extractCustomArrayWithValidation(rawData, attempt, diagnostics)
 		if err != nil {
@@ -316,7 +416,7 @@ func (scs *SchemaConversionService) extractEventsFromRawDataWithDiagnostics(rawD
 			Type:       "invalid_format",
 			Field:      "schema_type",
 			Message:    fmt.Sprintf("Unknown schema type: %s", schemaType),
-			Suggestion: "Use one of: events, activities, venues, custom",
+			Suggestion: "Use one of: events, activities, venues, camps, classes, custom",
 			Severity:   "error",
 		})
 		log.Printf("[CONVERSION] Unknown schema type: %s", schemaType)
@@ -350,6 +450,9 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 
 	log.Printf("[CONVERSION] Converting single event to Activity model")
 
+	// Map camp and class fields onto the generic ones read below
+	eventData = scs.prepareCategoryFields(eventData, adminEvent.SchemaType)
+
 	// Log available fields in event data
 	availableFields := make([]string, 0, len(eventData))
 	for k := range eventData {
@@ -359,10 +462,11 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 	log.Printf("[CONVERSION] Available fields in event data: %v", availableFields)
 
 	activity := &models.Activity{
-		ID:        uuid.New().String(),
-		Status:    models.ActivityStatusActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Status:      models.ActivityStatusActive,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		PublishAt:   adminEvent.PublishAt,
+		UnpublishAt: adminEvent.UnpublishAt,
 	}
 
 	// Extract title with comprehensive field mapping and validation
@@ -385,9 +489,15 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 	fieldMappings["type"] = typeMapping
 	diagnostics.FieldMappings["type"] = typeMapping
 
-	// Determine category
-	activity.Category = scs.determineCategory(title, description)
-	categoryMapping := scs.createFieldMapping("category", "auto_classified", []string{"title", "description"}, "derived", activity.Category, FieldValidationResult{IsValid: true, Confidence: 0.8})
+	// Determine category, keeping a valid category set on the event data, e.g. by an admin bulk edit
+	var categoryMapping FieldMapping
+	if category, ok := eventData["category"].(string); ok && models.ValidateCategory(category) {
+		activity.Category = category
+		categoryMapping = scs.createFieldMapping("category", "category", []string{"category", "title", "description"}, "direct", activity.Category, FieldValidationResult{IsValid: true, Confidence: 1.0})
+	} else {
+		activity.Category = scs.determineCategory(title, description)
+		categoryMapping = scs.createFieldMapping("category", "auto_classified", []string{"title", "description"}, "derived", activity.Category, FieldValidationResult{IsValid: true, Confidence: 0.8})
+	}
 	fieldMappings["category"] = categoryMapping
 	diagnostics.FieldMappings["category"] = categoryMapping
 
@@ -400,11 +510,34 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 
 	// Extract and convert location with comprehensive validation
 	location, locationMapping, locationIssues := scs.extractLocationWithValidation(eventData, adminEvent.SourceURL, attempt, diagnostics)
+	location.Coordinates = scs.extractCoordinates(eventData)
+	if geocoded := adminEvent.Geocoded; !hasCoordinates(location.Coordinates) && geocoded != nil && geocoded.Text == geocodingText(location) {
+		ApplyGeocodedLocation(&location, geocoded)
+	}
+	if scs.neighborhoods != nil {
+		scs.neighborhoods.AssignNeighborhood(&location)
+	}
+	// Locations that are still not placed, e.g. at venues outside the registry, are geocoded
+	if scs.geocoder != nil && !hasCoordinates(location.Coordinates) {
+		ctx, cancel := context.WithTimeout(context.Background(), geocodeTimeout)
+		geocoded, err := GeocodeLocation(ctx, scs.geocoder, &location)
+		cancel()
+		if err != nil {
+			log.Printf("Warning: Failed to geocode the location of event %s: %v", adminEvent.EventID, err)
+		} else if geocoded != nil && scs.neighborhoods != nil {
+			scs.neighborhoods.AssignNeighborhood(&location)
+		}
+	}
 	activity.Location = location
 	fieldMappings["location"] = locationMapping
 	diagnostics.FieldMappings["location"] = locationMapping
 	issues = append(issues, locationIssues...)
 
+	// Extract parking and arrival notes
+	arrivalInfo, arrivalIssues := scs.extractArrivalInfo(eventData)
+	activity.ArrivalInfo = arrivalInfo
+	issues = append(issues, arrivalIssues...)
+
 	// Extract and convert pricing with comprehensive validation
 	pricing, pricingMapping, pricingIssues := scs.extractPricingWithValidation(eventData, attempt, diagnostics)
 	activity.Pricing = pricing
@@ -422,14 +555,22 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 	diagnostics.FieldMappings["age_groups"] = ageGroupsMapping
 
 	// Extract registration info
-	registration, regIssues := scs.extractRegistration(eventData)
+	registration, participationType, regIssues := scs.extractRegistration(eventData)
 	activity.Registration = registration
+	activity.ParticipationType = participationType
 	issues = append(issues, regIssues...)
 	registrationSourceField := scs.findSourceField(eventData, []string{"registration_url", "website", "url"})
 	registrationMapping := scs.createFieldMapping("registration", registrationSourceField, []string{"registration_url", "website", "url"}, "direct", registration, FieldValidationResult{IsValid: true, Confidence: 0.8})
 	fieldMappings["registration"] = registrationMapping
 	diagnostics.FieldMappings["registration"] = registrationMapping
 
+	// Tag the activity, keeping tags set on the event data, e.g. by an admin edit
+	tags, tagsMapping, tagIssues := scs.extractTags(eventData, activity)
+	activity.Tags = tags
+	fieldMappings["tags"] = tagsMapping
+	diagnostics.FieldMappings["tags"] = tagsMapping
+	issues = append(issues, tagIssues...)
+
 	// Set provider info
 	activity.Provider = models.Provider{
 		Name:     scs.extractDomainFromURL(adminEvent.SourceURL),
@@ -447,6 +588,9 @@ func (scs *SchemaConversionService) convertSingleEventWithDiagnostics(eventData
 		Reliability: "medium",
 	}
 
+	// Set camp or class details
+	scs.applyCategoryDetails(activity, eventData, adminEvent.SchemaType)
+
 	return activity, fieldMappings, issues
 }
 
@@ -491,6 +635,10 @@ func (scs *SchemaConversionService) determineActivityType(eventData map[string]i
 		return models.TypeFreeActivity
 	case "venues":
 		return models.TypeFreeActivity
+	case "camps":
+		return models.TypeCamp
+	case "classes":
+		return models.TypeClass
 	}
 
 	// Content-based classification
@@ -540,6 +688,32 @@ func (scs *SchemaConversionService) determineCategory(title, description string)
 	return models.CategoryFreeCommunity
 }
 
+// extractTags returns the tags set on the event data, or the tags suggested for the activity
+// when none are set or they are not valid. Category and participation type must already be set.
+func (scs *SchemaConversionService) extractTags(eventData map[string]interface{}, activity *models.Activity) ([]string, FieldMapping, []string) {
+	var issues []string
+	if raw, ok := eventData["tags"].([]interface{}); ok {
+		values := make([]string, 0, len(raw))
+		for _, value := range raw {
+			if text, ok := value.(string); ok {
+				values = append(values, text)
+			}
+		}
+		tags, err := models.NormalizeTags(values)
+		if err == nil {
+			return tags, scs.createFieldMapping("tags", "tags", []string{"tags"}, "direct", tags, FieldValidationResult{IsValid: true, Confidence: 1.0}), nil
+		}
+		issues = append(issues, fmt.Sprintf("Ignoring tags: %v", err))
+	}
+
+	definitions := models.DefaultTags()
+	if scs.tags != nil {
+		definitions = scs.tags.Tags()
+	}
+	tags := SuggestTags(activity, definitions)
+	return tags, scs.createFieldMapping("tags", "auto_suggested", []string{"tags", "title", "description"}, "derived", tags, FieldValidationResult{IsValid: true, Confidence: 0.7}), issues
+}
+
 // containsKeywords checks if content contains any of the keywords
 func (scs *SchemaConversionService) containsKeywords(content string, keywords []string) bool {
 	for _, keyword := range keywords {
@@ -805,6 +979,11 @@ func (scs *SchemaConversionService) extractAgeGroups(data map[string]interface{}
 func (scs *SchemaConversionService) parseAgeGroup(ageGroupStr string) models.AgeGroup {
 	ageGroupStr = strings.ToLower(strings.TrimSpace(ageGroupStr))
 
+	// Explicit ranges like "ages 3-5" or "5 and up" are more precise than the keywords below
+	if ageGroup, ok := parseNumericAgeRange(ageGroupStr); ok {
+		return ageGroup
+	}
+
 	// Map common age group strings
 	switch {
 	case scs.containsKeywords(ageGroupStr, []string{"infant", "baby", "babies"}):
@@ -874,8 +1053,95 @@ func (scs *SchemaConversionService) parseAgeGroup(ageGroupStr string) models.Age
 	}
 }
 
-// extractRegistration extracts registration information
-func (scs *SchemaConversionService) extractRegistration(data map[string]interface{}) (models.Registration, []string) {
+var (
+	ageSpanPattern  = regexp.MustCompile(`(\d+)\s*(months?|mos?|years?|yrs?)?\s*(?:-|–|to)\s*(\d+)\s*(months?|mos?|years?|yrs?)?`)
+	ageAndUpPattern = regexp.MustCompile(`(\d+)\s*(months?|mos?|years?|yrs?)?\s*(?:\+|and up|& up|and older|or older)`)
+)
+
+// parseNumericAgeRange parses "3-5 years", "18 months to 3 years" and open-ended "5 and up" / "5+" ranges.
+// Open-ended ranges have no maximum age. Ages are in years unless the text says months.
+func parseNumericAgeRange(ageGroupStr string) (models.AgeGroup, bool) {
+	if match := ageSpanPattern.FindStringSubmatch(ageGroupStr); match != nil {
+		minAge, _ := strconv.Atoi(match[1])
+		maxAge, _ := strconv.Atoi(match[3])
+		minInMonths := isMonthUnit(match[2]) || (match[2] == "" && isMonthUnit(match[4]))
+		maxInMonths := isMonthUnit(match[4])
+		if minInMonths != maxInMonths {
+			// Mixed units such as "18 months - 3 years" are kept in months
+			if !minInMonths {
+				minAge *= 12
+			}
+			if !maxInMonths {
+				maxAge = maxAge*12 + 11
+			}
+			minInMonths = true
+		}
+		if maxAge < minAge {
+			return models.AgeGroup{}, false
+		}
+		unit := "years"
+		if minInMonths {
+			unit = "months"
+		}
+		return models.AgeGroup{
+			Category:    ageCategoryForMonths(minAge, unit),
+			MinAge:      minAge,
+			MaxAge:      maxAge,
+			Unit:        unit,
+			Description: fmt.Sprintf("Ages %d-%d %s", minAge, maxAge, unit),
+		}, true
+	}
+
+	if match := ageAndUpPattern.FindStringSubmatch(ageGroupStr); match != nil {
+		minAge, _ := strconv.Atoi(match[1])
+		unit := "years"
+		if isMonthUnit(match[2]) {
+			unit = "months"
+		}
+		return models.AgeGroup{
+			Category:    ageCategoryForMonths(minAge, unit),
+			MinAge:      minAge,
+			Unit:        unit,
+			Description: fmt.Sprintf("Ages %d %s and up", minAge, unit),
+		}, true
+	}
+
+	return models.AgeGroup{}, false
+}
+
+// isMonthUnit reports whether a parsed age unit refers to months
+func isMonthUnit(unit string) bool {
+	return strings.HasPrefix(unit, "mo")
+}
+
+// ageCategoryForMonths picks the age group category a range starting at minAge falls into
+func ageCategoryForMonths(minAge int, unit string) string {
+	months := minAge
+	if unit != "months" {
+		months = minAge * 12
+	}
+	switch {
+	case months < 12:
+		return models.AgeGroupInfant
+	case months < 36:
+		return models.AgeGroupToddler
+	case months < 72:
+		return models.AgeGroupPreschool
+	case months < 132:
+		return models.AgeGroupElementary
+	case months < 156:
+		return models.AgeGroupTween
+	case months < 216:
+		return models.AgeGroupTeen
+	default:
+		return models.AgeGroupAdult
+	}
+}
+
+// extractRegistration extracts registration information and how families take part.
+// Only a dedicated registration link or the page's wording marks registration as required;
+// a generic website link is kept as the URL but says nothing about whether signup is needed.
+func (scs *SchemaConversionService) extractRegistration(data map[string]interface{}) (models.Registration, string, []string) {
 	var issues []string
 	registration := models.Registration{
 		Required: false,
@@ -884,19 +1150,77 @@ func (scs *SchemaConversionService) extractRegistration(data map[string]interfac
 	}
 
 	// Extract registration URL
-	regURL := scs.extractStringWithFallbacks(data, []string{"registration_url", "website", "url", "link"})
-	if regURL != "" {
+	if regURL := scs.extractStringWithFallbacks(data, []string{"registration_url"}); regURL != "" {
 		registration.URL = regURL
 		registration.Required = true
 		registration.Method = "online"
+	} else if regURL := scs.extractStringWithFallbacks(data, []string{"website", "url", "link"}); regURL != "" {
+		registration.URL = regURL
+		registration.Method = "online"
 	}
 
-	// Check if registration is required
+	// The wording of the listing decides between drop-in, registration, tickets and sold out
+	text := strings.ToLower(strings.Join([]string{
+		scs.extractStringWithFallbacks(data, []string{"participation", "registration"}),
+		scs.extractStringWithFallbacks(data, []string{"title", "name"}),
+		scs.extractStringWithFallbacks(data, []string{"description"}),
+		scs.extractStringWithFallbacks(data, []string{"price", "cost", "pricing"}),
+	}, " "))
+	participation := classifyParticipation(text)
+	switch participation {
+	case models.ParticipationDropIn:
+		registration.Required = false
+		if registration.URL == "" {
+			registration.Method = "walk-in"
+		}
+	case models.ParticipationRegistration, models.ParticipationTicketed:
+		registration.Required = true
+	case models.ParticipationSoldOut:
+		registration.Required = true
+		registration.Status = models.RegistrationStatusSoldOut
+	}
+
+	// An explicit flag from extraction beats anything inferred from the wording
 	if regRequired, ok := data["registration_required"].(bool); ok {
 		registration.Required = regRequired
+		if participation != models.ParticipationTicketed && participation != models.ParticipationSoldOut {
+			participation = ""
+		}
+	}
+
+	if participation == "" {
+		participation = models.ParticipationDropIn
+		if registration.Required {
+			participation = models.ParticipationRegistration
+		}
 	}
 
-	return registration, issues
+	return registration, participation, issues
+}
+
+var (
+	soldOutPattern      = regexp.MustCompile(`\bsold[\s-]?out\b|\bfully booked\b|\bno (?:spots|seats|tickets) (?:left|remaining)\b`)
+	ticketedPattern     = regexp.MustCompile(`\btickets? (?:required|needed|on sale|available)\b|\bbuy tickets\b|\bticketed\b|\bpurchase (?:a )?tickets?\b`)
+	dropInPattern       = regexp.MustCompile(`\bdrop[\s-]?in\b|\bwalk[\s-]?ins? welcome\b|\bno (?:registration|sign[\s-]?up|rsvp) (?:required|needed|necessary)\b|\bregistration (?:is )?not required\b|\bjust show up\b`)
+	registrationPattern = regexp.MustCompile(`\b(?:pre-?)?registration (?:is )?(?:required|needed|recommended|opens|closes)\b|\bregister (?:online|now|today|at|by|here)\b|\bsign[\s-]?up (?:required|online|now|today|at|by)\b|\brsvp (?:required|at|by)\b|\benroll(?:ment)? (?:required|now|online)\b`)
+)
+
+// classifyParticipation reads listing text for how families take part. Sold out wins over tickets,
+// tickets over drop-in and drop-in over registration, since "drop-in" listings often mention
+// registration for other sessions. It returns "" when the text gives no signal.
+func classifyParticipation(text string) string {
+	switch {
+	case soldOutPattern.MatchString(text):
+		return models.ParticipationSoldOut
+	case ticketedPattern.MatchString(text):
+		return models.ParticipationTicketed
+	case dropInPattern.MatchString(text):
+		return models.ParticipationDropIn
+	case registrationPattern.MatchString(text):
+		return models.ParticipationRegistration
+	default:
+		return ""
+	}
 }
 
 // calculateConfidenceScore calculates a confidence score for the conversion
@@ -1049,12 +1373,19 @@ func (scs *SchemaConversionService) logConversionDiagnostics(diagnostics *Conver
 	log.Printf("[CONVERSION-DIAGNOSTICS] ===============================================")
 }
 
-// GetConversionDiagnostics returns the last conversion diagnostics (for testing/debugging)
-var lastConversionDiagnostics *ConversionDiagnostics
+// setLastConversionDiagnostics records the diagnostics from the most recent conversion on this service
+func (scs *SchemaConversionService) setLastConversionDiagnostics(diagnostics *ConversionDiagnostics) {
+	scs.mu.Lock()
+	defer scs.mu.Unlock()
+	scs.lastDiagnostics = diagnostics
+}
 
-// GetLastConversionDiagnostics returns the diagnostics from the last conversion
+// GetLastConversionDiagnostics returns the diagnostics from the last conversion (for testing/debugging).
+// Callers that need diagnostics for a specific event should use ConvertToActivityWithDiagnostics instead.
 func (scs *SchemaConversionService) GetLastConversionDiagnostics() *ConversionDiagnostics {
-	return lastConversionDiagnostics
+	scs.mu.Lock()
+	defer scs.mu.Unlock()
+	return scs.lastDiagnostics
 }
 
 // analyzeDataStructure provides detailed analysis of raw data structure
@@ -1746,6 +2077,17 @@ func (scs *SchemaConversionService) extractTitleWithValidation(eventData map[str
 		})
 	}
 	
+	// Strip boilerplate, dates and venue names; the extracted title is kept in the mapping for provenance
+	originalTitle := title
+	var transformations []string
+	if mappingType != "default" && scs.titleNormalizer != nil {
+		locationName := scs.extractStringWithFallbacks(eventData, []string{"location", "venue", "location_name", "venue_name"})
+		title, transformations = scs.titleNormalizer.Normalize(title, locationName)
+		if len(transformations) > 0 {
+			log.Printf("[CONVERSION] Normalized title %q to %q (%s)", originalTitle, title, strings.Join(transformations, ", "))
+		}
+	}
+	
 	// Validate title
 	validation := scs.validateTitleField(title)
 	if !validation.IsValid {
@@ -1763,6 +2105,11 @@ func (scs *SchemaConversionService) extractTitleWithValidation(eventData map[str
 	}
 	
 	mapping := scs.createFieldMapping("title", sourceField, attemptedFields, mappingType, title, validation)
+	if len(transformations) > 0 {
+		mapping.OriginalValue = originalTitle
+		mapping.NormalizedValue = title
+		mapping.Transformations = transformations
+	}
 	return title, mapping, issues
 }
 
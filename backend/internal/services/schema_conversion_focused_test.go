@@ -1,6 +1,7 @@
 package services
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -466,4 +467,174 @@ func TestErrorRecovery(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+func TestParseAgeGroupRanges(t *testing.T) {
+	scs := NewSchemaConversionService()
+
+	tests := []struct {
+		input    string
+		category string
+		minAge   int
+		maxAge   int
+		unit     string
+	}{
+		{"Ages 3-5", models.AgeGroupPreschool, 3, 5, "years"},
+		{"6 to 18 months", models.AgeGroupInfant, 6, 18, "months"},
+		{"18 months - 3 years", models.AgeGroupToddler, 18, 47, "months"},
+		{"Kids 5 and up", models.AgeGroupPreschool, 5, 0, "years"},
+		{"8+", models.AgeGroupElementary, 8, 0, "years"},
+		{"Toddlers", models.AgeGroupToddler, 1, 2, "years"},
+		{"Everyone welcome", models.AgeGroupAllAges, 0, 99, "years"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := scs.parseAgeGroup(tt.input)
+			if got.Category != tt.category || got.MinAge != tt.minAge || got.MaxAge != tt.maxAge || got.Unit != tt.unit {
+				t.Errorf("parseAgeGroup(%q) = %+v, want %s %d-%d %s", tt.input, got, tt.category, tt.minAge, tt.maxAge, tt.unit)
+			}
+		})
+	}
+}
+
+func TestExtractRegistrationParticipation(t *testing.T) {
+	scs := NewSchemaConversionService()
+
+	tests := []struct {
+		name          string
+		data          map[string]interface{}
+		participation string
+		required      bool
+		status        string
+	}{
+		{"drop-in wording", map[string]interface{}{"title": "Toddler Drop-In Play", "url": "https://example.org/play"}, models.ParticipationDropIn, false, models.RegistrationStatusOpen},
+		{"website link alone is not registration", map[string]interface{}{"title": "Park Day", "url": "https://example.org/park"}, models.ParticipationDropIn, false, models.RegistrationStatusOpen},
+		{"registration link", map[string]interface{}{"title": "Pottery", "registration_url": "https://example.org/register"}, models.ParticipationRegistration, true, models.RegistrationStatusOpen},
+		{"registration wording", map[string]interface{}{"title": "Story Time", "description": "Registration required, space is limited."}, models.ParticipationRegistration, true, models.RegistrationStatusOpen},
+		{"tickets", map[string]interface{}{"title": "Puppet Show", "price": "$12, tickets on sale now"}, models.ParticipationTicketed, true, models.RegistrationStatusOpen},
+		{"sold out", map[string]interface{}{"title": "Puppet Show - SOLD OUT", "registration_url": "https://example.org/tickets"}, models.ParticipationSoldOut, true, models.RegistrationStatusSoldOut},
+		{"explicit flag", map[string]interface{}{"name": "Swim Lessons", "registration_required": true}, models.ParticipationRegistration, true, models.RegistrationStatusOpen},
+		{"explicit flag overrides wording", map[string]interface{}{"name": "Open Gym", "participation": "registration recommended", "registration_required": false}, models.ParticipationDropIn, false, models.RegistrationStatusOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registration, participation, _ := scs.extractRegistration(tt.data)
+			if participation != tt.participation || registration.Required != tt.required || registration.Status != tt.status {
+				t.Errorf("extractRegistration() = %s required=%v status=%s, want %s required=%v status=%s",
+					participation, registration.Required, registration.Status, tt.participation, tt.required, tt.status)
+			}
+		})
+	}
+}
+
+func TestConversionKeepsValidCategory(t *testing.T) {
+	scs := NewSchemaConversionService()
+	convert := func(category string) *models.ConversionResult {
+		adminEvent := &models.AdminEvent{
+			EventID:    "test-category",
+			SourceURL:  "https://test.example.com",
+			SchemaType: "events",
+			RawExtractedData: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{
+						"title":    "Robot Building Workshop",
+						"date":     "2024-12-15",
+						"location": "Seattle Community Center",
+						"category": category,
+					},
+				},
+			},
+			ExtractedAt: time.Now(),
+		}
+		result, err := scs.ConvertToActivity(adminEvent)
+		if err != nil || result.Activity == nil {
+			t.Fatalf("Conversion failed: %v", err)
+		}
+		return result
+	}
+
+	result := convert(models.CategoryActiveSports)
+	if result.Activity.Category != models.CategoryActiveSports || result.FieldMappings["category"] != "category" {
+		t.Errorf("Expected the event's category to be kept, got %s from %s", result.Activity.Category, result.FieldMappings["category"])
+	}
+
+	result = convert("robots")
+	if result.Activity.Category != models.CategoryEducationalSTEM {
+		t.Errorf("Expected an unknown category to be classified from the title, got %s", result.Activity.Category)
+	}
+}
+
+func TestConversionTags(t *testing.T) {
+	scs := NewSchemaConversionService()
+	convert := func(eventData map[string]interface{}) *models.ConversionResult {
+		adminEvent := &models.AdminEvent{
+			EventID:          "test-tags",
+			SourceURL:        "https://test.example.com",
+			SchemaType:       "events",
+			RawExtractedData: map[string]interface{}{"events": []interface{}{eventData}},
+			ExtractedAt:      time.Now(),
+		}
+		result, err := scs.ConvertToActivity(adminEvent)
+		if err != nil || result.Activity == nil {
+			t.Fatalf("Conversion failed: %v", err)
+		}
+		return result
+	}
+
+	result := convert(map[string]interface{}{
+		"title":       "Robot Building Workshop",
+		"description": "Drop-in robotics on the beach. No registration required.",
+		"date":        "2024-12-15",
+		"location":    "Alki Beach",
+	})
+	if expected := []string{"drop-in", "outdoor", "stem"}; !reflect.DeepEqual(result.Activity.Tags, expected) {
+		t.Errorf("Expected suggested tags %v, got %v", expected, result.Activity.Tags)
+	}
+
+	result = convert(map[string]interface{}{
+		"title":    "Robot Building Workshop",
+		"date":     "2024-12-15",
+		"location": "Seattle Community Center",
+		"tags":     []interface{}{"Spanish Immersion", "lego"},
+	})
+	if expected := []string{"lego", "spanish-immersion"}; !reflect.DeepEqual(result.Activity.Tags, expected) || result.FieldMappings["tags"] != "tags" {
+		t.Errorf("Expected the event's tags %v to be kept, got %v from %s", expected, result.Activity.Tags, result.FieldMappings["tags"])
+	}
+}
+
+func TestConversionGeocodedCoordinates(t *testing.T) {
+	scs := NewSchemaConversionService()
+	convert := func(address string) *models.Activity {
+		adminEvent := &models.AdminEvent{
+			EventID:     "test-geocoded",
+			SourceURL:   "https://test.example.com",
+			SchemaType:  "events",
+			ExtractedAt: time.Now(),
+			RawExtractedData: map[string]interface{}{"events": []interface{}{map[string]interface{}{
+				"title":    "Toddler Story Time",
+				"date":     "2024-12-15",
+				"location": "Ballard Branch",
+				"address":  address,
+			}}},
+			Geocoded: &models.GeocodedLocation{
+				Text:        "5614 22nd Ave NW, Seattle, WA",
+				Coordinates: models.Coordinates{Lat: 47.6697, Lng: -122.3846},
+			},
+		}
+		result, err := scs.ConvertToActivity(adminEvent)
+		if err != nil || result.Activity == nil {
+			t.Fatalf("Conversion failed: %v", err)
+		}
+		return result.Activity
+	}
+
+	activity := convert("5614 22nd Ave NW, Seattle")
+	if activity.Location.Coordinates.Lat != 47.6697 || activity.Location.Coordinates.Lng != -122.3846 {
+		t.Errorf("Expected the geocoded coordinates, got %+v", activity.Location.Coordinates)
+	}
+
+	// A moved event is not placed at its old address
+	activity = convert("1000 4th Ave, Seattle")
+	if activity.Location.Coordinates != (models.Coordinates{}) {
+		t.Errorf("Expected no coordinates after the address changed, got %+v", activity.Location.Coordinates)
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxSuggestionKeyWords bounds how many word positions of a long title are indexed
+const maxSuggestionKeyWords = 6
+
+// suggestionCandidates is how many index entries are read per query before ranking
+const suggestionCandidates = 200
+
+// maxCachedQueries bounds the per-instance answer cache; it is cleared when full
+const maxCachedQueries = 1000
+
+// suggestionKindOrder breaks ties between equally common completions
+var suggestionKindOrder = map[string]int{
+	models.SuggestionKindTitle:    0,
+	models.SuggestionKindVenue:    1,
+	models.SuggestionKindCategory: 2,
+}
+
+// NormalizeSuggestText lowercases text, drops apostrophes and turns other punctuation into
+// spaces, so "Seattle Children's Theatre" and "seattle childrens theatre" match
+func NormalizeSuggestText(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// SuggestionKeys returns the keys a term is indexed under: the normalized text from the
+// start of each of its first words, so a query can match any word, not just the first
+func SuggestionKeys(normalizedText string) []string {
+	words := strings.Fields(normalizedText)
+	var keys []string
+	for i := range words {
+		if i == maxSuggestionKeyWords {
+			break
+		}
+		key := strings.Join(words[i:], " ")
+		if len([]rune(key)) >= models.SuggestPartitionLength {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ActivitySuggestions returns the title, venue and category completions an activity contributes
+func ActivitySuggestions(activity *models.Activity) []models.SearchSuggestion {
+	var suggestions []models.SearchSuggestion
+	for _, term := range []models.SearchSuggestion{
+		{Kind: models.SuggestionKindTitle, Text: activity.Title},
+		{Kind: models.SuggestionKindVenue, Text: activity.Location.Name},
+		{Kind: models.SuggestionKindCategory, Text: activity.Category},
+	} {
+		if term.Text = strings.TrimSpace(term.Text); NormalizeSuggestText(term.Text) != "" {
+			suggestions = append(suggestions, term)
+		}
+	}
+	return suggestions
+}
+
+// RankSuggestions merges index entries that reached the same term through different words and
+// orders them by how many activities carry the term, then by kind and text
+func RankSuggestions(entries []models.SearchSuggestion, limit int) []models.SearchSuggestion {
+	merged := make(map[string]models.SearchSuggestion)
+	for _, entry := range entries {
+		id := entry.Kind + "#" + NormalizeSuggestText(entry.Text)
+		if existing, ok := merged[id]; !ok || entry.Count > existing.Count {
+			merged[id] = entry
+		}
+	}
+
+	ranked := make([]models.SearchSuggestion, 0, len(merged))
+	for _, suggestion := range merged {
+		ranked = append(ranked, suggestion)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		if ranked[i].Kind != ranked[j].Kind {
+			return suggestionKindOrder[ranked[i].Kind] < suggestionKindOrder[ranked[j].Kind]
+		}
+		return ranked[i].Text < ranked[j].Text
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// SuggestIndex serves search box completions from the prefix index in DynamoDB.
+// Answers are cached briefly so a user typing a query hits DynamoDB once per prefix.
+type SuggestIndex struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedSuggestions
+}
+
+type cachedSuggestions struct {
+	suggestions []models.SearchSuggestion
+	loadedAt    time.Time
+}
+
+// NewSuggestIndex creates a suggestion index backed by DynamoDB
+func NewSuggestIndex(dynamo *DynamoDBService, ttl time.Duration) *SuggestIndex {
+	return &SuggestIndex{
+		dynamo:  dynamo,
+		ttl:     ttl,
+		entries: make(map[string]cachedSuggestions),
+	}
+}
+
+// Suggest returns up to limit ranked completions for a query
+func (i *SuggestIndex) Suggest(ctx context.Context, query string, limit int) ([]models.SearchSuggestion, error) {
+	prefix := NormalizeSuggestText(query)
+	if len([]rune(prefix)) < models.SuggestPartitionLength {
+		return []models.SearchSuggestion{}, nil
+	}
+
+	i.mu.Lock()
+	entry, ok := i.entries[prefix]
+	i.mu.Unlock()
+	if !ok || time.Since(entry.loadedAt) >= i.ttl {
+		candidates, err := i.dynamo.QuerySearchSuggestions(ctx, prefix, suggestionCandidates)
+		if err != nil {
+			return nil, err
+		}
+		entry = cachedSuggestions{suggestions: RankSuggestions(candidates, 0), loadedAt: time.Now()}
+
+		i.mu.Lock()
+		if len(i.entries) >= maxCachedQueries {
+			i.entries = make(map[string]cachedSuggestions)
+		}
+		i.entries[prefix] = entry
+		i.mu.Unlock()
+	}
+
+	suggestions := entry.suggestions
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// IndexActivity adds an approved activity's title, venue and category to the index
+func (i *SuggestIndex) IndexActivity(ctx context.Context, activity *models.Activity) error {
+	for _, suggestion := range ActivitySuggestions(activity) {
+		normalized := NormalizeSuggestText(suggestion.Text)
+		for _, key := range SuggestionKeys(normalized) {
+			if err := i.dynamo.AddSearchSuggestion(ctx, key, suggestion.Kind, suggestion.Text, normalized); err != nil {
+				return fmt.Errorf("failed to index %s %q: %w", suggestion.Kind, suggestion.Text, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestNormalizeSuggestText(t *testing.T) {
+	tests := map[string]string{
+		"Seattle Children's Theatre": "seattle childrens theatre",
+		"  Arts & Crafts -- Kids ":   "arts crafts kids",
+		"Café Storytime":             "café storytime",
+		"!!!":                        "",
+	}
+	for input, expected := range tests {
+		if got := NormalizeSuggestText(input); got != expected {
+			t.Errorf("NormalizeSuggestText(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestSuggestionKeys(t *testing.T) {
+	keys := SuggestionKeys("seattle childrens theatre")
+	expected := []string{"seattle childrens theatre", "childrens theatre", "theatre"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+
+	// Single characters are too short to reach through the partitioned index
+	if keys := SuggestionKeys("a b"); len(keys) != 1 || keys[0] != "a b" {
+		t.Errorf("Expected only the full term for short words, got %v", keys)
+	}
+
+	long := SuggestionKeys("one two three four five six seven eight")
+	if len(long) != maxSuggestionKeyWords {
+		t.Errorf("Expected %d keys for a long title, got %d", maxSuggestionKeyWords, len(long))
+	}
+}
+
+func TestActivitySuggestions(t *testing.T) {
+	activity := &models.Activity{
+		Title:    "Peter Pan",
+		Location: models.Location{Name: "Seattle Children's Theatre"},
+	}
+	suggestions := ActivitySuggestions(activity)
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected title and venue suggestions without an empty category, got %+v", suggestions)
+	}
+	if suggestions[1].Kind != models.SuggestionKindVenue || suggestions[1].Text != "Seattle Children's Theatre" {
+		t.Errorf("Unexpected venue suggestion: %+v", suggestions[1])
+	}
+}
+
+func TestRankSuggestions(t *testing.T) {
+	entries := []models.SearchSuggestion{
+		{Kind: models.SuggestionKindCategory, Text: "arts-creativity", Count: 4},
+		{Kind: models.SuggestionKindTitle, Text: "Art Camp", Count: 4},
+		{Kind: models.SuggestionKindVenue, Text: "Seattle Art Museum", Count: 9},
+		// The same venue reached through a second word of its name
+		{Kind: models.SuggestionKindVenue, Text: "Seattle Art Museum", Count: 9},
+		{Kind: models.SuggestionKindTitle, Text: "Art Walk", Count: 1},
+	}
+
+	ranked := RankSuggestions(entries, 3)
+
+	expected := []string{"Seattle Art Museum", "Art Camp", "arts-creativity"}
+	if len(ranked) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %+v", len(expected), ranked)
+	}
+	for i, text := range expected {
+		if ranked[i].Text != text {
+			t.Errorf("Expected suggestion %d to be %q, got %q", i, text, ranked[i].Text)
+		}
+	}
+}
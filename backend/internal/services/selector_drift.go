@@ -0,0 +1,29 @@
+package services
+
+import (
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// SelectorDriftSuspected reports whether a source's run results suggest its selectors drifted:
+// every task succeeded, yet the pages extracted yielded no items and no run limit cut the source
+// short. Pages skipped as unchanged were not extracted, so they say nothing either way.
+func SelectorDriftSuspected(stats models.FanOutSourceStats) bool {
+	return stats.Tasks > stats.UnchangedTasks && stats.FailedTasks == 0 && stats.ActivitiesFound == 0 && stats.LimitHit == ""
+}
+
+// ProposeSelectorFix turns a detected drift into a proposal using the selectors recommended by
+// the first analysis completed after the drift was detected. It reports whether the drift changed.
+func ProposeSelectorFix(drift *models.SelectorDrift, analysis *models.SourceAnalysis) bool {
+	if drift == nil || drift.Status != models.SelectorDriftDetected || analysis == nil {
+		return false
+	}
+	if !analysis.AnalysisCompletedAt.After(drift.DetectedAt) || analysis.RecommendedConfig.BestSelectors.IsEmpty() {
+		return false
+	}
+
+	selectors := analysis.RecommendedConfig.BestSelectors
+	drift.ProposedSelectors = &selectors
+	drift.ProposedFromVersion = analysis.Version
+	drift.Status = models.SelectorDriftProposed
+	return true
+}
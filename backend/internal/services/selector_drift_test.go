@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestSelectorDriftSuspected(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    models.FanOutSourceStats
+		expected bool
+	}{
+		{"no items from successful pages", models.FanOutSourceStats{Tasks: 3}, true},
+		{"items found", models.FanOutSourceStats{Tasks: 3, ActivitiesFound: 12}, false},
+		{"failed pages", models.FanOutSourceStats{Tasks: 3, FailedTasks: 1}, false},
+		{"stopped at a run limit", models.FanOutSourceStats{Tasks: 3, LimitHit: models.RunLimitCredits}, false},
+		{"no items from changed pages", models.FanOutSourceStats{Tasks: 3, UnchangedTasks: 2}, true},
+		{"every page unchanged", models.FanOutSourceStats{Tasks: 3, UnchangedTasks: 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectorDriftSuspected(tt.stats); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestProposeSelectorFix(t *testing.T) {
+	detectedAt := time.Now()
+	drift := &models.SelectorDrift{Status: models.SelectorDriftDetected, DetectedAt: detectedAt}
+	analysis := &models.SourceAnalysis{
+		Version:             4,
+		AnalysisCompletedAt: detectedAt.Add(-time.Hour),
+		RecommendedConfig: models.RecommendedSourceConfig{
+			BestSelectors: models.DataSelectors{Title: ".card h3"},
+		},
+	}
+
+	if ProposeSelectorFix(drift, analysis) {
+		t.Fatal("Expected an analysis from before the drift not to be proposed")
+	}
+
+	analysis.AnalysisCompletedAt = detectedAt.Add(time.Hour)
+	if !ProposeSelectorFix(drift, analysis) {
+		t.Fatal("Expected a newer analysis to be proposed")
+	}
+	if drift.Status != models.SelectorDriftProposed || drift.ProposedFromVersion != 4 || drift.ProposedSelectors.Title != ".card h3" {
+		t.Errorf("Unexpected proposal: %+v", drift)
+	}
+
+	if ProposeSelectorFix(drift, analysis) {
+		t.Error("Expected an existing proposal not to be replaced")
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// SESMailer sends plain text email to individual addresses using the SES v2 API
+type SESMailer struct {
+	endpoint  string
+	from      string
+	requester *awsRequester
+}
+
+// NewSESMailer creates a mailer that sends from a verified SES identity
+func NewSESMailer(cfg aws.Config, from string) *SESMailer {
+	return &SESMailer{
+		endpoint:  fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/outbound-emails", cfg.Region),
+		from:      from,
+		requester: newAWSRequester(cfg, "ses", cfg.Region, 10*time.Second),
+	}
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesSendEmailRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Text sesContent `json:"Text"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send emails a plain text message to one recipient
+func (m *SESMailer) Send(ctx context.Context, to, subject, body string) error {
+	var request sesSendEmailRequest
+	request.FromEmailAddress = m.from
+	request.Destination.ToAddresses = []string{to}
+	request.Content.Simple.Subject = sesContent{Data: subject, Charset: "UTF-8"}
+	request.Content.Simple.Body.Text = sesContent{Data: body, Charset: "UTF-8"}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal send email request: %w", err)
+	}
+
+	_, err = m.requester.post(ctx, "send email", m.endpoint, map[string]string{
+		"Content-Type": "application/json",
+	}, payload)
+	return err
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSESMailerSend(t *testing.T) {
+	t.Run("SignsAndSends", func(t *testing.T) {
+		var gotAuth string
+		var gotRequest sesSendEmailRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+				t.Errorf("Invalid JSON body: %v", err)
+			}
+			w.Write([]byte(`{"MessageId":"1"}`))
+		}))
+		defer server.Close()
+
+		mailer := NewSESMailer(testAWSConfig(), "claims@example.com")
+		mailer.endpoint = server.URL
+
+		err := mailer.Send(context.Background(), "events@example.org", "Your code", "Code: 123456")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if gotRequest.FromEmailAddress != "claims@example.com" {
+			t.Errorf("Expected sender claims@example.com, got %q", gotRequest.FromEmailAddress)
+		}
+		if len(gotRequest.Destination.ToAddresses) != 1 || gotRequest.Destination.ToAddresses[0] != "events@example.org" {
+			t.Errorf("Unexpected recipients %v", gotRequest.Destination.ToAddresses)
+		}
+		if gotRequest.Content.Simple.Subject.Data != "Your code" || gotRequest.Content.Simple.Body.Text.Data != "Code: 123456" {
+			t.Errorf("Unexpected content %+v", gotRequest.Content.Simple)
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/ses/") {
+			t.Errorf("Expected SigV4 authorization for ses, got %q", gotAuth)
+		}
+	})
+
+	t.Run("ReportsErrorStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"Email address is not verified"}`))
+		}))
+		defer server.Close()
+
+		mailer := NewSESMailer(testAWSConfig(), "claims@example.com")
+		mailer.endpoint = server.URL
+
+		err := mailer.Send(context.Background(), "events@example.org", "subject", "body")
+		if err == nil || !strings.Contains(err.Error(), "not verified") {
+			t.Errorf("Expected not verified error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// maxSNSSubjectLength is the longest subject SNS accepts for email notifications
+const maxSNSSubjectLength = 100
+
+// SNSNotifier publishes notifications to SNS topics using the SNS query protocol
+type SNSNotifier struct {
+	endpoint  string
+	requester *awsRequester
+}
+
+// NewSNSNotifier creates an SNS notifier from the AWS configuration
+func NewSNSNotifier(cfg aws.Config) *SNSNotifier {
+	return &SNSNotifier{
+		endpoint:  fmt.Sprintf("https://sns.%s.amazonaws.com/", cfg.Region),
+		requester: newAWSRequester(cfg, "sns", cfg.Region, 10*time.Second),
+	}
+}
+
+// Publish sends a message to the topic; the subject is used by email subscriptions
+func (n *SNSNotifier) Publish(ctx context.Context, topicARN, subject, message string) error {
+	if len(subject) > maxSNSSubjectLength {
+		subject = subject[:maxSNSSubjectLength]
+	}
+
+	form := url.Values{}
+	form.Set("Action", "Publish")
+	form.Set("Version", "2010-03-31")
+	form.Set("TopicArn", topicARN)
+	form.Set("Subject", subject)
+	form.Set("Message", message)
+	payload := []byte(form.Encode())
+
+	_, err := n.requester.post(ctx, "publish", n.endpoint, map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded; charset=utf-8",
+	}, payload)
+	return err
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSNSNotifierPublish(t *testing.T) {
+	t.Run("SignsAndPublishes", func(t *testing.T) {
+		var gotAuth, gotAction, gotTopic, gotSubject, gotMessage string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("Invalid form body: %v", err)
+			}
+			gotAction = r.PostForm.Get("Action")
+			gotTopic = r.PostForm.Get("TopicArn")
+			gotSubject = r.PostForm.Get("Subject")
+			gotMessage = r.PostForm.Get("Message")
+			w.Write([]byte(`<PublishResponse><PublishResult><MessageId>1</MessageId></PublishResult></PublishResponse>`))
+		}))
+		defer server.Close()
+
+		notifier := NewSNSNotifier(testAWSConfig())
+		notifier.endpoint = server.URL
+
+		topic := "arn:aws:sns:us-west-2:123456789012:alerts"
+		err := notifier.Publish(context.Background(), topic, strings.Repeat("s", 150), "Run finished")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if gotAction != "Publish" || gotTopic != topic || gotMessage != "Run finished" {
+			t.Errorf("Unexpected publish request: action=%q topic=%q message=%q", gotAction, gotTopic, gotMessage)
+		}
+		if len(gotSubject) != maxSNSSubjectLength {
+			t.Errorf("Expected subject truncated to %d characters, got %d", maxSNSSubjectLength, len(gotSubject))
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/sns/") {
+			t.Errorf("Expected SigV4 authorization for sns, got %q", gotAuth)
+		}
+	})
+
+	t.Run("ReportsErrorStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<ErrorResponse><Error><Code>AuthorizationError</Code></Error></ErrorResponse>`))
+		}))
+		defer server.Close()
+
+		notifier := NewSNSNotifier(testAWSConfig())
+		notifier.endpoint = server.URL
+
+		err := notifier.Publish(context.Background(), "arn:aws:sns:us-west-2:123456789012:alerts", "subject", "message")
+		if err == nil || !strings.Contains(err.Error(), "AuthorizationError") {
+			t.Errorf("Expected authorization error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// maxSQSBatchSize is the most entries SQS accepts in a single SendMessageBatch call
+const maxSQSBatchSize = 10
+
+// SQSClient sends messages to SQS queues using the SQS JSON protocol
+type SQSClient struct {
+	requester *awsRequester
+}
+
+// NewSQSClient creates an SQS client from the AWS configuration
+func NewSQSClient(cfg aws.Config) *SQSClient {
+	return &SQSClient{
+		requester: newAWSRequester(cfg, "sqs", cfg.Region, 10*time.Second),
+	}
+}
+
+// sqsBatchEntry is a single message in a SendMessageBatch request
+type sqsBatchEntry struct {
+	ID          string `json:"Id"`
+	MessageBody string `json:"MessageBody"`
+}
+
+// sqsBatchResponse is the SendMessageBatch response body
+type sqsBatchResponse struct {
+	Failed []struct {
+		ID      string `json:"Id"`
+		Code    string `json:"Code"`
+		Message string `json:"Message"`
+	} `json:"Failed"`
+}
+
+// SendMessages sends each message to the queue as JSON, batching requests where possible.
+// It returns how many messages were sent, which is less than len(messages) when it fails.
+func (c *SQSClient) SendMessages(ctx context.Context, queueURL string, messages []interface{}) (int, error) {
+	sent := 0
+	for start := 0; start < len(messages); start += maxSQSBatchSize {
+		end := start + maxSQSBatchSize
+		if end > len(messages) {
+			end = len(messages)
+		}
+
+		entries := make([]sqsBatchEntry, 0, end-start)
+		for i, message := range messages[start:end] {
+			body, err := json.Marshal(message)
+			if err != nil {
+				return sent, fmt.Errorf("failed to marshal queue message: %w", err)
+			}
+			entries = append(entries, sqsBatchEntry{ID: strconv.Itoa(i), MessageBody: string(body)})
+		}
+
+		var response sqsBatchResponse
+		err := c.call(ctx, queueURL, "SendMessageBatch", map[string]interface{}{
+			"QueueUrl": queueURL,
+			"Entries":  entries,
+		}, &response)
+		if err != nil {
+			return sent, err
+		}
+		sent += len(entries) - len(response.Failed)
+		if len(response.Failed) > 0 {
+			failure := response.Failed[0]
+			return sent, fmt.Errorf("failed to send %d of %d messages: %s: %s", len(response.Failed), len(entries), failure.Code, failure.Message)
+		}
+	}
+
+	return sent, nil
+}
+
+// SendMessage sends a single message to the queue as JSON, hidden from consumers for the given delay (at most 15 minutes)
+func (c *SQSClient) SendMessage(ctx context.Context, queueURL string, message interface{}, delay time.Duration) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queue message: %w", err)
+	}
+
+	return c.call(ctx, queueURL, "SendMessage", map[string]interface{}{
+		"QueueUrl":     queueURL,
+		"MessageBody":  string(body),
+		"DelaySeconds": int(delay / time.Second),
+	}, nil)
+}
+
+// QueueDepth is the approximate number of messages in a queue by state
+type QueueDepth struct {
+	Visible  int `json:"visible"`   // waiting to be received
+	InFlight int `json:"in_flight"` // received but not yet deleted
+	Delayed  int `json:"delayed"`   // not yet available
+}
+
+// GetQueueDepth returns the approximate message counts for a queue
+func (c *SQSClient) GetQueueDepth(ctx context.Context, queueURL string) (*QueueDepth, error) {
+	var response struct {
+		Attributes map[string]string `json:"Attributes"`
+	}
+	err := c.call(ctx, queueURL, "GetQueueAttributes", map[string]interface{}{
+		"QueueUrl": queueURL,
+		"AttributeNames": []string{
+			"ApproximateNumberOfMessages",
+			"ApproximateNumberOfMessagesNotVisible",
+			"ApproximateNumberOfMessagesDelayed",
+		},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQS returns the counts as strings; a missing attribute counts as zero
+	depth := &QueueDepth{}
+	depth.Visible, _ = strconv.Atoi(response.Attributes["ApproximateNumberOfMessages"])
+	depth.InFlight, _ = strconv.Atoi(response.Attributes["ApproximateNumberOfMessagesNotVisible"])
+	depth.Delayed, _ = strconv.Atoi(response.Attributes["ApproximateNumberOfMessagesDelayed"])
+
+	return depth, nil
+}
+
+// call signs and sends a single SQS JSON protocol request to the queue's regional endpoint
+func (c *SQSClient) call(ctx context.Context, queueURL, action string, input interface{}, output interface{}) error {
+	parsed, err := url.Parse(queueURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid queue URL %q", queueURL)
+	}
+	endpoint := parsed.Scheme + "://" + parsed.Host + "/"
+
+	return c.requester.callJSON(ctx, endpoint, "AmazonSQS", action, input, output)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQSClientSendMessages(t *testing.T) {
+	t.Run("BatchesMessages", func(t *testing.T) {
+		var batchSizes []int
+		var gotTarget, gotAuth, gotQueue string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotTarget = r.Header.Get("X-Amz-Target")
+			gotAuth = r.Header.Get("Authorization")
+
+			var input struct {
+				QueueUrl string
+				Entries  []sqsBatchEntry
+			}
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				t.Errorf("Invalid request body: %v", err)
+			}
+			gotQueue = input.QueueUrl
+			batchSizes = append(batchSizes, len(input.Entries))
+			w.Write([]byte(`{"Successful":[],"Failed":[]}`))
+		}))
+		defer server.Close()
+
+		messages := make([]interface{}, 12)
+		for i := range messages {
+			messages[i] = map[string]int{"n": i}
+		}
+
+		queueURL := server.URL + "/123456789012/scrape-tasks"
+		sent, err := NewSQSClient(testAWSConfig()).SendMessages(context.Background(), queueURL, messages)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sent != 12 {
+			t.Errorf("Expected 12 messages sent, got %d", sent)
+		}
+
+		if len(batchSizes) != 2 || batchSizes[0] != 10 || batchSizes[1] != 2 {
+			t.Errorf("Expected batches of 10 and 2, got %v", batchSizes)
+		}
+		if gotTarget != "AmazonSQS.SendMessageBatch" || gotQueue != queueURL {
+			t.Errorf("Unexpected request target %q for queue %q", gotTarget, gotQueue)
+		}
+		if !strings.Contains(gotAuth, "/us-west-2/sqs/") {
+			t.Errorf("Expected SigV4 authorization for sqs, got %q", gotAuth)
+		}
+	})
+
+	t.Run("ReportsFailedEntries", func(t *testing.T) {
+		batches := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			batches++
+			if batches == 1 {
+				w.Write([]byte(`{"Failed":[]}`))
+				return
+			}
+			w.Write([]byte(`{"Failed":[{"Id":"0","Code":"InternalError","Message":"boom"}]}`))
+		}))
+		defer server.Close()
+
+		messages := make([]interface{}, 13)
+		for i := range messages {
+			messages[i] = "a"
+		}
+		sent, err := NewSQSClient(testAWSConfig()).SendMessages(context.Background(), server.URL+"/1/q", messages)
+		if err == nil || !strings.Contains(err.Error(), "InternalError") {
+			t.Errorf("Expected failed entry error, got %v", err)
+		}
+		if sent != 12 {
+			t.Errorf("Expected the first batch and 2 of the second to be sent, got %d", sent)
+		}
+	})
+}
+
+func TestSQSClientSendMessage(t *testing.T) {
+	var gotTarget string
+	var input struct {
+		QueueUrl     string
+		MessageBody  string
+		DelaySeconds int
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTarget = r.Header.Get("X-Amz-Target")
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("Invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"MessageId":"1"}`))
+	}))
+	defer server.Close()
+
+	queueURL := server.URL + "/123456789012/scrape-tasks"
+	err := NewSQSClient(testAWSConfig()).SendMessage(context.Background(), queueURL, map[string]int{"n": 1}, 2*time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotTarget != "AmazonSQS.SendMessage" || input.QueueUrl != queueURL {
+		t.Errorf("Unexpected request target %q for queue %q", gotTarget, input.QueueUrl)
+	}
+	if input.MessageBody != `{"n":1}` || input.DelaySeconds != 120 {
+		t.Errorf("Unexpected message body %q with delay %d", input.MessageBody, input.DelaySeconds)
+	}
+}
+
+func TestSQSClientGetQueueDepth(t *testing.T) {
+	var gotTarget string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTarget = r.Header.Get("X-Amz-Target")
+		w.Write([]byte(`{"Attributes":{"ApproximateNumberOfMessages":"7","ApproximateNumberOfMessagesNotVisible":"2"}}`))
+	}))
+	defer server.Close()
+
+	depth, err := NewSQSClient(testAWSConfig()).GetQueueDepth(context.Background(), server.URL+"/1/q")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotTarget != "AmazonSQS.GetQueueAttributes" {
+		t.Errorf("Unexpected request target %q", gotTarget)
+	}
+	if depth.Visible != 7 || depth.InFlight != 2 || depth.Delayed != 0 {
+		t.Errorf("Unexpected queue depth: %+v", depth)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// TagProvider supplies the tags suggested for converted activities
+type TagProvider interface {
+	Tags() []models.Tag
+}
+
+// MergeTags combines the built-in tags with stored ones, a stored tag replacing the built-in tag
+// with the same slug but staying marked built in. Tags are sorted by slug.
+func MergeTags(defaults, stored []models.Tag) []models.Tag {
+	bySlug := make(map[string]models.Tag, len(defaults)+len(stored))
+	for _, tag := range defaults {
+		bySlug[tag.Slug] = tag
+	}
+	for _, tag := range stored {
+		tag.BuiltIn = bySlug[tag.Slug].BuiltIn
+		bySlug[tag.Slug] = tag
+	}
+
+	merged := make([]models.Tag, 0, len(bySlug))
+	for _, tag := range bySlug {
+		merged = append(merged, tag)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Slug < merged[j].Slug })
+	return merged
+}
+
+// SuggestTags returns the tags an activity should carry: every tag with a keyword its title or
+// description mentions as whole words. STEM activities are tagged stem and drop-in ones drop-in
+// whatever their text says.
+func SuggestTags(activity *models.Activity, tags []models.Tag) []string {
+	content := strings.ToLower(activity.Title + " " + activity.Description)
+
+	suggested := []string{}
+	for _, tag := range tags {
+		matched := false
+		switch tag.Slug {
+		case models.TagSTEM:
+			matched = activity.Category == models.CategoryEducationalSTEM
+		case models.TagDropIn:
+			matched = activity.ParticipationType == models.ParticipationDropIn
+		}
+		for _, keyword := range tag.Keywords {
+			matched = matched || containsPhrase(content, keyword)
+		}
+		if matched {
+			suggested = append(suggested, tag.Slug)
+		}
+	}
+	sort.Strings(suggested)
+	if len(suggested) > models.MaxTagsPerActivity {
+		suggested = suggested[:models.MaxTagsPerActivity]
+	}
+	return suggested
+}
+
+// containsPhrase reports whether a phrase occurs in lowercase text as whole words, so "stem"
+// does not match "system"
+func containsPhrase(text, phrase string) bool {
+	if phrase == "" {
+		return false
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], phrase)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// TagEntriesForActivity returns the tag index entries of a published activity, one per tag
+func TagEntriesForActivity(activity *models.Activity) []models.TagEntry {
+	entries := make([]models.TagEntry, 0, len(activity.Tags))
+	for _, tag := range activity.Tags {
+		entries = append(entries, models.TagEntry{
+			PK:         models.CreateEventPK(activity.ID),
+			SK:         models.CreateTagSK(tag),
+			ActivityID: activity.ID,
+			Tag:        tag,
+			Title:      activity.Title,
+			Type:       activity.Type,
+			Category:   activity.Category,
+			StartDate:  activity.Schedule.StartDate,
+			TagKey:     models.GenerateTagKey(tag),
+			TagDateKey: models.GenerateTagDateKey(activity.Schedule.StartDate, activity.ID),
+		})
+	}
+	return entries
+}
+
+// TagCache serves the built-in and stored tags, refreshing them after a TTL. A failed load
+// keeps the previous tags.
+type TagCache struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	tags     []models.Tag
+	loadedAt time.Time
+}
+
+// NewTagCache creates a tag cache backed by DynamoDB
+func NewTagCache(dynamo *DynamoDBService, ttl time.Duration) *TagCache {
+	return &TagCache{
+		dynamo: dynamo,
+		ttl:    ttl,
+		tags:   models.DefaultTags(),
+	}
+}
+
+// Tags returns the current tags, loading them if the cached copy is stale
+func (c *TagCache) Tags() []models.Tag {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.tags
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stored, err := c.dynamo.ListTags(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load tags, keeping previous tags: %v", err)
+	} else {
+		c.tags = MergeTags(models.DefaultTags(), stored)
+	}
+
+	c.loadedAt = time.Now()
+	return c.tags
+}
+
+// Invalidate drops the cached tags so the next lookup reloads them
+func (c *TagCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestSuggestTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity models.Activity
+		expected []string
+	}{
+		{"Keywords", models.Activity{Title: "Nature Walk at Discovery Park", Description: "A guided hike for families"}, []string{"outdoor"}},
+		{"STEMCategory", models.Activity{Title: "Lego Lab", Category: models.CategoryEducationalSTEM}, []string{"stem"}},
+		{"DropIn", models.Activity{Title: "Toddler Play Time", ParticipationType: models.ParticipationDropIn}, []string{"drop-in"}},
+		{"Phrase", models.Activity{Title: "Cuentos: Story Time en Español"}, []string{"spanish-immersion"}},
+		{"WholeWords", models.Activity{Title: "Solar System Night", Description: "Learn about the aftermath of the big bang"}, []string{}},
+		{"Several", models.Activity{Title: "Outdoor Science Club", ParticipationType: models.ParticipationDropIn}, []string{"drop-in", "outdoor", "stem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestTags(&tt.activity, models.DefaultTags()); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SuggestTags() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSuggestTagsUsesStoredKeywords(t *testing.T) {
+	tags := MergeTags(models.DefaultTags(), []models.Tag{
+		{Slug: "outdoor", Label: "Outdoor", Keywords: []string{"splash pad"}},
+		{Slug: "lego", Label: "LEGO", Keywords: []string{"lego"}},
+	})
+
+	activity := &models.Activity{Title: "Lego Build at the Splash Pad", Description: "Bring a towel, we will be outside"}
+	if got, expected := SuggestTags(activity, tags), []string{"lego", "outdoor"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("SuggestTags() = %v, expected %v", got, expected)
+	}
+
+	// The stored outdoor tag replaced the built-in keywords
+	if got := SuggestTags(&models.Activity{Title: "Beach cleanup"}, tags); len(got) != 0 {
+		t.Errorf("Expected the built-in outdoor keywords to be replaced, got %v", got)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	merged := MergeTags(models.DefaultTags(), []models.Tag{{Slug: "art-walk", Label: "Art walk"}, {Slug: "stem", Label: "Science & tech"}})
+
+	var slugs []string
+	for _, tag := range merged {
+		slugs = append(slugs, tag.Slug)
+		if tag.Slug == "stem" && (tag.Label != "Science & tech" || !tag.BuiltIn) {
+			t.Errorf("Expected the stored stem tag to replace the built-in one, got %+v", tag)
+		}
+		if tag.Slug == "art-walk" && tag.BuiltIn {
+			t.Errorf("Expected a stored tag not to be built in, got %+v", tag)
+		}
+	}
+	if expected := []string{"art-walk", "drop-in", "outdoor", "spanish-immersion", "stem"}; !reflect.DeepEqual(slugs, expected) {
+		t.Errorf("MergeTags slugs = %v, expected %v", slugs, expected)
+	}
+}
+
+func TestTagEntriesForActivity(t *testing.T) {
+	activity := &models.Activity{
+		ID:       "act-1",
+		Title:    "Lego Lab",
+		Type:     models.TypeClass,
+		Category: models.CategoryEducationalSTEM,
+		Schedule: models.Schedule{StartDate: "2026-10-17"},
+		Tags:     []string{"drop-in", "stem"},
+	}
+
+	entries := TagEntriesForActivity(activity)
+	if len(entries) != 2 {
+		t.Fatalf("Expected one entry per tag, got %d", len(entries))
+	}
+	entry := entries[1]
+	if entry.PK != "EVENT#act-1" || entry.SK != "TAG#stem" || entry.TagKey != "TAG#stem" || entry.TagDateKey != "DATE#2026-10-17#act-1" {
+		t.Errorf("Unexpected entry keys %+v", entry)
+	}
+	if entry.Title != "Lego Lab" || entry.Category != models.CategoryEducationalSTEM {
+		t.Errorf("Expected the entry to describe the activity, got %+v", entry)
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Emoji policy constants for title normalization
+const (
+	EmojiPolicyStrip = "strip" // remove emoji and pictographs from titles
+	EmojiPolicyKeep  = "keep"  // leave emoji in titles
+)
+
+// Title transformation names recorded in field provenance
+const (
+	TitleTransformBoilerplate  = "strip_boilerplate"
+	TitleTransformTrailingDate = "strip_trailing_date"
+	TitleTransformVenueSuffix  = "strip_venue_suffix"
+	TitleTransformEmoji        = "strip_emoji"
+	TitleTransformTitleCase    = "title_case"
+	TitleTransformWhitespace   = "collapse_whitespace"
+)
+
+// titleSeparator matches the punctuation sites put between a title and its suffixes
+const titleSeparator = `\s*(?:[-–—|:~•·]+|,)\s*`
+
+var (
+	// boilerplateSuffixPattern matches calls to action appended to titles, e.g. "— Registration Open!"
+	boilerplateSuffixPattern = regexp.MustCompile(`(?i)(?:` + titleSeparator + `|\s*[(\[]\s*|\s+)(?:registration\s+(?:is\s+)?(?:now\s+)?open|register\s+(?:now|today)|sign[-\s]?up\s+(?:now|today)|tickets?\s+(?:on\s+sale|available)(?:\s+now)?|book\s+(?:now|today)|limited\s+(?:spots|space|seats)(?:\s+available)?|few\s+spots\s+left|spots?\s+(?:left|available)|sold\s+out|waitlist\s+only)\s*[)\]]?\s*!*\s*$`)
+
+	// trailingDatePattern matches a date appended to a title, e.g. " - Sat, July 12" or " on 7/12/2025"
+	trailingDatePattern = regexp.MustCompile(`(?i)(?:` + titleSeparator + `|\s+on\s+|\s*[(\[]\s*)(?:(?:mon|tue|tues|wed|thu|thur|thurs|fri|sat|sun)[a-z]*\.?,?\s+)?(?:(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?\s+\d{1,2}(?:st|nd|rd|th)?(?:\s*[-–]\s*\d{1,2}(?:st|nd|rd|th)?)?(?:,?\s+\d{4})?|\d{1,2}/\d{1,2}(?:/\d{2,4})?|\d{4}-\d{2}-\d{2})\s*[)\]]?\s*$`)
+
+	// trailingSeparatorPattern matches punctuation left dangling after a suffix was removed
+	trailingSeparatorPattern = regexp.MustCompile(`(?:\s*[-–—|:~•·,])+\s*$`)
+
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// titleSmallWords stay lowercase inside title-cased titles
+var titleSmallWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "but": true, "by": true, "for": true,
+	"from": true, "in": true, "into": true, "of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// titleAcronyms keep their capitalization when an all-caps title is recased
+var titleAcronyms = map[string]bool{
+	"ASL": true, "DIY": true, "LEGO": true, "STEAM": true, "STEM": true, "YMCA": true, "YWCA": true,
+}
+
+// TitleNormalizer strips boilerplate, trailing dates and venue names from extracted titles
+type TitleNormalizer struct {
+	mu          sync.RWMutex
+	venueNames  []string
+	emojiPolicy string
+}
+
+// NewTitleNormalizer creates a normalizer that strips emoji and knows no venues
+func NewTitleNormalizer() *TitleNormalizer {
+	return &TitleNormalizer{emojiPolicy: EmojiPolicyStrip}
+}
+
+// SetVenueNames sets the known venue names whose suffixes are removed from titles
+func (n *TitleNormalizer) SetVenueNames(names []string) {
+	venues := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			venues = append(venues, name)
+		}
+	}
+	// Try longer names first so "Seattle Public Library - Ballard" wins over "Seattle Public Library"
+	sort.Slice(venues, func(i, j int) bool { return len(venues[i]) > len(venues[j]) })
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.venueNames = venues
+}
+
+// SetEmojiPolicy sets whether emoji are stripped from or kept in titles
+func (n *TitleNormalizer) SetEmojiPolicy(policy string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emojiPolicy = policy
+}
+
+// Normalize cleans up a title and returns it with the transformations that changed it.
+// locationName is the event's own venue, which is stripped like a registry venue.
+// A transformation that would leave the title empty is skipped.
+func (n *TitleNormalizer) Normalize(title, locationName string) (string, []string) {
+	n.mu.RLock()
+	venues := n.venueNames
+	emojiPolicy := n.emojiPolicy
+	n.mu.RUnlock()
+
+	var applied []string
+	apply := func(name string, transform func(string) string) {
+		result := strings.TrimSpace(transform(title))
+		if result != "" && result != title {
+			title = result
+			applied = appendTransform(applied, name)
+		}
+	}
+
+	apply(TitleTransformWhitespace, func(s string) string {
+		return whitespacePattern.ReplaceAllString(s, " ")
+	})
+
+	if emojiPolicy != EmojiPolicyKeep {
+		apply(TitleTransformEmoji, func(s string) string {
+			return whitespacePattern.ReplaceAllString(strings.Map(dropEmoji, s), " ")
+		})
+	}
+
+	if locationName = strings.TrimSpace(locationName); locationName != "" {
+		venues = append([]string{locationName}, venues...)
+	}
+
+	// Suffixes can be stacked, e.g. "Story Time - Green Lake Library - July 12 - Registration Open!"
+	for {
+		before := title
+		apply(TitleTransformBoilerplate, func(s string) string {
+			return trimDanglingSeparators(boilerplateSuffixPattern.ReplaceAllString(s, ""))
+		})
+		apply(TitleTransformTrailingDate, func(s string) string {
+			return trimDanglingSeparators(trailingDatePattern.ReplaceAllString(s, ""))
+		})
+		apply(TitleTransformVenueSuffix, func(s string) string {
+			return trimDanglingSeparators(stripVenueSuffix(s, venues))
+		})
+		if title == before {
+			break
+		}
+	}
+
+	apply(TitleTransformTitleCase, smartTitleCase)
+
+	return title, applied
+}
+
+// appendTransform records a transformation once
+func appendTransform(applied []string, name string) []string {
+	for _, existing := range applied {
+		if existing == name {
+			return applied
+		}
+	}
+	return append(applied, name)
+}
+
+// trimDanglingSeparators removes separators left at the end of a title
+func trimDanglingSeparators(title string) string {
+	return trailingSeparatorPattern.ReplaceAllString(strings.TrimSpace(title), "")
+}
+
+// stripVenueSuffix removes the first venue name found at the end of a title after "at", "@",
+// a separator, or in parentheses
+func stripVenueSuffix(title string, venues []string) string {
+	lower := strings.ToLower(title)
+	if len(lower) != len(title) {
+		// Lowercasing changed byte offsets, so suffixes cannot be cut safely
+		return title
+	}
+	for _, venue := range venues {
+		venueLower := strings.ToLower(venue)
+		if strings.HasSuffix(lower, "("+venueLower+")") {
+			return title[:len(title)-len(venue)-2]
+		}
+		if !strings.HasSuffix(lower, venueLower) || len(title) == len(venue) {
+			continue
+		}
+
+		prefix := strings.TrimRight(title[:len(title)-len(venue)], " ")
+		prefixLower := strings.ToLower(prefix)
+		switch {
+		case strings.HasSuffix(prefixLower, " at"):
+			return prefix[:len(prefix)-3]
+		case strings.HasSuffix(prefix, "@"):
+			return prefix[:len(prefix)-1]
+		case trailingSeparatorPattern.MatchString(prefix):
+			return prefix
+		}
+	}
+	return title
+}
+
+// dropEmoji maps emoji, pictographs and their joiners to -1 so strings.Map removes them
+func dropEmoji(r rune) rune {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, transport, flags
+		r >= 0x2600 && r <= 0x27BF,            // misc symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF,            // arrows and stars
+		r == 0x200D, r == 0xFE0F, r == 0x20E3: // joiner, variation selector, keycap
+		return -1
+	}
+	return r
+}
+
+// smartTitleCase recases titles that are entirely upper or lower case. Mixed-case titles
+// are left alone since their capitalization is usually intentional.
+func smartTitleCase(title string) string {
+	hasUpper, hasLower := false, false
+	for _, r := range title {
+		if unicode.IsUpper(r) {
+			hasUpper = true
+		} else if unicode.IsLower(r) {
+			hasLower = true
+		}
+	}
+	if hasUpper == hasLower {
+		return title
+	}
+
+	words := strings.Fields(title)
+	for i, word := range words {
+		upper := strings.ToUpper(word)
+		switch {
+		case titleAcronyms[strings.Trim(upper, ".,!?:;()")]:
+			words[i] = upper
+		case i > 0 && i < len(words)-1 && titleSmallWords[strings.ToLower(word)]:
+			words[i] = strings.ToLower(word)
+		default:
+			words[i] = capitalizeWord(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// capitalizeWord uppercases the first letter of each hyphenated part and lowercases the rest
+func capitalizeWord(word string) string {
+	parts := strings.Split(strings.ToLower(word), "-")
+	for i, part := range parts {
+		runes := []rune(part)
+		for j, r := range runes {
+			if unicode.IsLetter(r) {
+				runes[j] = unicode.ToUpper(r)
+				break
+			}
+			if unicode.IsDigit(r) {
+				break
+			}
+		}
+		parts[i] = string(runes)
+	}
+	return strings.Join(parts, "-")
+}
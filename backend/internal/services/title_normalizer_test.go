@@ -0,0 +1,83 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestTitleNormalizerNormalize(t *testing.T) {
+	normalizer := NewTitleNormalizer()
+	normalizer.SetVenueNames([]string{"Seattle Public Library", "Seattle Public Library - Ballard", "Woodland Park Zoo"})
+
+	tests := []struct {
+		name            string
+		title           string
+		location        string
+		expected        string
+		transformations []string
+	}{
+		{"Unchanged", "Toddler Story Time", "", "Toddler Story Time", nil},
+		{"Boilerplate", "Summer Art Camp — Registration Open!", "", "Summer Art Camp", []string{TitleTransformBoilerplate}},
+		{"TrailingDate", "Family Bird Walk - Sat, July 12", "", "Family Bird Walk", []string{TitleTransformTrailingDate}},
+		{"NumericDate", "Family Bird Walk on 7/12/2025", "", "Family Bird Walk", []string{TitleTransformTrailingDate}},
+		{"RegistryVenue", "Bug Safari at Woodland Park Zoo", "", "Bug Safari", []string{TitleTransformVenueSuffix}},
+		{"LongestVenueWins", "Lego Club | Seattle Public Library - Ballard", "", "Lego Club", []string{TitleTransformVenueSuffix}},
+		{"EventLocation", "Puppet Show (Green Lake Community Center)", "Green Lake Community Center", "Puppet Show", []string{TitleTransformVenueSuffix}},
+		{"Stacked", "Story Time - Green Lake Library - July 12 - Register Now", "Green Lake Library", "Story Time", []string{TitleTransformBoilerplate, TitleTransformTrailingDate, TitleTransformVenueSuffix}},
+		{"Emoji", "🎨 Kids Paint Night 🎉", "", "Kids Paint Night", []string{TitleTransformEmoji}},
+		{"AllCaps", "STEM SATURDAY FOR KIDS", "", "STEM Saturday for Kids", []string{TitleTransformTitleCase}},
+		{"AllLower", "family swim at the pool", "", "Family Swim at the Pool", []string{TitleTransformTitleCase}},
+		{"MixedCaseKept", "iPad Coding for Kids", "", "iPad Coding for Kids", nil},
+		{"NeverEmpty", "Woodland Park Zoo", "", "Woodland Park Zoo", nil},
+		{"Whitespace", "Music   in the  Park", "", "Music in the Park", []string{TitleTransformWhitespace}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, transformations := normalizer.Normalize(tt.title, tt.location)
+			if title != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, title)
+			}
+			if !reflect.DeepEqual(transformations, tt.transformations) {
+				t.Errorf("Expected transformations %v, got %v", tt.transformations, transformations)
+			}
+		})
+	}
+}
+
+func TestTitleNormalizerKeepsEmoji(t *testing.T) {
+	normalizer := NewTitleNormalizer()
+	normalizer.SetEmojiPolicy(EmojiPolicyKeep)
+
+	title, transformations := normalizer.Normalize("🎨 Kids Paint Night", "")
+	if title != "🎨 Kids Paint Night" || len(transformations) != 0 {
+		t.Errorf("Expected emoji to be kept, got %q %v", title, transformations)
+	}
+}
+
+func TestSchemaConversionRecordsTitleProvenance(t *testing.T) {
+	scs := NewSchemaConversionService()
+	_, diagnostics, err := scs.ConvertToActivityWithDiagnostics(&models.AdminEvent{
+		EventID:    "evt-1",
+		SourceURL:  "https://www.seattle.gov/parks/events",
+		SchemaType: "events",
+		RawExtractedData: map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{"title": "Toddler Story Time at Green Lake Library - July 12", "location": "Green Lake Library", "date": "2025-07-12"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	mapping := diagnostics.FieldMappings["title"]
+	if mapping.OriginalValue != "Toddler Story Time at Green Lake Library - July 12" || mapping.NormalizedValue != "Toddler Story Time" {
+		t.Errorf("Expected before and after titles in the mapping, got %+v", mapping)
+	}
+	if len(mapping.Transformations) != 2 {
+		t.Errorf("Expected date and venue transformations, got %v", mapping.Transformations)
+	}
+}
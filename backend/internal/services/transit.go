@@ -0,0 +1,207 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// Walking distances transit access is scored on
+const (
+	transitNearMeters = 400 // about a 5 minute walk
+	transitFarMeters  = 800 // about a 10 minute walk
+)
+
+// transitGridDegrees is the cell size of the stop index, about 1.1 km north-south
+const transitGridDegrees = 0.01
+
+// TransitStop is a boarding stop of a GTFS feed
+type TransitStop struct {
+	ID   string
+	Name string
+	Lat  float64
+	Lng  float64
+}
+
+// ParseGTFSStops reads the boarding stops of a GTFS stops.txt file. Stations, entrances and other
+// location types that are not boarded are skipped.
+func ParseGTFSStops(r io.Reader) ([]TransitStop, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stops header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		// Feeds exported from spreadsheets often start with a byte order mark
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, required := range []string{"stop_id", "stop_lat", "stop_lon"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("stops file has no %s column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var stops []TransitStop
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stops: %w", err)
+		}
+		if locationType := field(record, "location_type"); locationType != "" && locationType != "0" {
+			continue
+		}
+		lat, latErr := strconv.ParseFloat(field(record, "stop_lat"), 64)
+		lng, lngErr := strconv.ParseFloat(field(record, "stop_lon"), 64)
+		if latErr != nil || lngErr != nil {
+			continue
+		}
+		stops = append(stops, TransitStop{ID: field(record, "stop_id"), Name: field(record, "stop_name"), Lat: lat, Lng: lng})
+	}
+	return stops, nil
+}
+
+// LoadGTFSStops reads the stops of a GTFS feed from a local path or an http(s) URL. The feed can
+// be the zipped feed or its stops.txt file.
+func LoadGTFSStops(ctx context.Context, location string) ([]TransitStop, error) {
+	var data []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GTFS feed URL: %w", err)
+		}
+		response, err := (&http.Client{Timeout: 2 * time.Minute}).Do(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download GTFS feed: %w", err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download GTFS feed: HTTP %d", response.StatusCode)
+		}
+		if data, err = io.ReadAll(response.Body); err != nil {
+			return nil, fmt.Errorf("failed to download GTFS feed: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, fmt.Errorf("failed to read GTFS feed: %w", err)
+		}
+	}
+
+	if !bytes.HasPrefix(data, []byte("PK")) {
+		return ParseGTFSStops(bytes.NewReader(data))
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GTFS feed: %w", err)
+	}
+	for _, file := range archive.File {
+		if file.Name != "stops.txt" && !strings.HasSuffix(file.Name, "/stops.txt") {
+			continue
+		}
+		stopsFile, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open stops.txt: %w", err)
+		}
+		defer stopsFile.Close()
+		return ParseGTFSStops(stopsFile)
+	}
+	return nil, fmt.Errorf("GTFS feed has no stops.txt")
+}
+
+// TransitStopIndex finds the stops near a point. Stops are bucketed into a grid of
+// transitGridDegrees cells, so a lookup only measures the stops of the cells around the point.
+type TransitStopIndex struct {
+	cells map[[2]int][]TransitStop
+}
+
+// NewTransitStopIndex indexes stops by grid cell
+func NewTransitStopIndex(stops []TransitStop) *TransitStopIndex {
+	index := &TransitStopIndex{cells: make(map[[2]int][]TransitStop)}
+	for _, stop := range stops {
+		cell := transitGridCell(stop.Lat, stop.Lng)
+		index.cells[cell] = append(index.cells[cell], stop)
+	}
+	return index
+}
+
+func transitGridCell(lat, lng float64) [2]int {
+	return [2]int{int(math.Floor(lat / transitGridDegrees)), int(math.Floor(lng / transitGridDegrees))}
+}
+
+// ScoreVenueTransit scores how well the point is served by transit, from 0 to 100. Up to 50
+// points come from the nearest stop: 50 within 200 m, 35 within 400 m and 15 within 800 m. Up to 50
+// more come from the number of stops within 400 m, 5 for each of up to 10.
+func (x *TransitStopIndex) ScoreVenueTransit(point models.Coordinates, feed string, now time.Time) *models.VenueTransit {
+	transit := &models.VenueTransit{Coordinates: point, Feed: feed, ScoredAt: now}
+
+	// Cells are narrower east-west than the search radius at higher latitudes
+	latCells := int(math.Ceil(transitFarMeters / 1000.0 / (transitGridDegrees * 111.32)))
+	lngCells := int(math.Ceil(transitFarMeters / 1000.0 / (transitGridDegrees * 111.32 * math.Max(math.Cos(point.Lat*math.Pi/180), 0.01))))
+	center := transitGridCell(point.Lat, point.Lng)
+
+	nearest := math.MaxFloat64
+	for dLat := -latCells; dLat <= latCells; dLat++ {
+		for dLng := -lngCells; dLng <= lngCells; dLng++ {
+			for _, stop := range x.cells[[2]int{center[0] + dLat, center[1] + dLng}] {
+				meters := haversineKm(point, models.Coordinates{Lat: stop.Lat, Lng: stop.Lng}) * 1000
+				if meters > transitFarMeters {
+					continue
+				}
+				transit.StopsWithin800m++
+				if meters <= transitNearMeters {
+					transit.StopsWithin400m++
+				}
+				if meters < nearest || (meters == nearest && stop.Name < transit.NearestStopName) {
+					nearest = meters
+					transit.NearestStopName = stop.Name
+				}
+			}
+		}
+	}
+
+	if transit.StopsWithin800m == 0 {
+		return transit
+	}
+	transit.NearestStopMeters = int(math.Round(nearest))
+	switch {
+	case nearest <= 200:
+		transit.Score = 50
+	case nearest <= transitNearMeters:
+		transit.Score = 35
+	default:
+		transit.Score = 15
+	}
+	transit.Score += 5 * int(math.Min(float64(transit.StopsWithin400m), 10))
+	return transit
+}
+
+// NeedsTransitScore reports whether a venue has coordinates and has not been scored at them
+func NeedsTransitScore(venue *models.Venue) bool {
+	if !hasCoordinates(venue.Coordinates) {
+		return false
+	}
+	return venue.Transit == nil || venue.Transit.Coordinates != venue.Coordinates
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+const testStops = "\ufeffstop_id,stop_code,stop_name,stop_lat,stop_lon,location_type\n" +
+	"1,1,3rd Ave & Pine St,47.6110,-122.3380,0\n" +
+	"2,2,Westlake Station,47.6116,-122.3370,1\n" +
+	"3,3,4th Ave & Pine St,47.6115,-122.3360,\n" +
+	"4,4,Broken Stop,north,-122.3360,0\n"
+
+func TestParseGTFSStops(t *testing.T) {
+	stops, err := ParseGTFSStops(strings.NewReader(testStops))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The station and the stop without coordinates are skipped
+	if len(stops) != 2 || stops[0].ID != "1" || stops[1].Name != "4th Ave & Pine St" || stops[1].Lat != 47.6115 {
+		t.Errorf("Unexpected stops: %+v", stops)
+	}
+
+	if _, err := ParseGTFSStops(strings.NewReader("stop_id,stop_name\n1,Nowhere\n")); err == nil {
+		t.Error("Expected an error for stops without coordinates columns")
+	}
+}
+
+func TestLoadGTFSStops(t *testing.T) {
+	dir := t.TempDir()
+	feed := filepath.Join(dir, "google_transit.zip")
+	file, err := os.Create(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := zip.NewWriter(file)
+	for name, content := range map[string]string{"agency.txt": "agency_id\n1\n", "stops.txt": testStops} {
+		w, _ := archive.Create(name)
+		w.Write([]byte(content))
+	}
+	archive.Close()
+	file.Close()
+
+	stops, err := LoadGTFSStops(context.Background(), feed)
+	if err != nil || len(stops) != 2 {
+		t.Errorf("Expected 2 stops from the zipped feed, got %d (%v)", len(stops), err)
+	}
+
+	plain := filepath.Join(dir, "stops.txt")
+	os.WriteFile(plain, []byte(testStops), 0o644)
+	if stops, err := LoadGTFSStops(context.Background(), plain); err != nil || len(stops) != 2 {
+		t.Errorf("Expected 2 stops from stops.txt, got %d (%v)", len(stops), err)
+	}
+}
+
+func TestScoreVenueTransit(t *testing.T) {
+	venue := models.Coordinates{Lat: 47.6100, Lng: -122.3400}
+	stops := []TransitStop{
+		{ID: "near", Name: "Near", Lat: 47.6101, Lng: -122.3401},     // about 15 m
+		{ID: "block", Name: "Block", Lat: 47.6125, Lng: -122.3400},   // about 280 m
+		{ID: "walk", Name: "Walk", Lat: 47.6150, Lng: -122.3400},     // about 560 m
+		{ID: "across", Name: "Across", Lat: 47.6100, Lng: -122.3500}, // about 750 m west, in another grid cell
+		{ID: "far", Name: "Far", Lat: 47.6300, Lng: -122.3400},       // about 2.2 km
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	transit := NewTransitStopIndex(stops).ScoreVenueTransit(venue, "test", now)
+	if transit.StopsWithin400m != 2 || transit.StopsWithin800m != 4 {
+		t.Errorf("Expected 2 stops within 400 m and 4 within 800 m, got %+v", transit)
+	}
+	if transit.NearestStopName != "Near" || transit.NearestStopMeters > 20 {
+		t.Errorf("Expected the nearest stop to be Near, got %q at %d m", transit.NearestStopName, transit.NearestStopMeters)
+	}
+	if transit.Score != 60 || !transit.TransitFriendly() {
+		t.Errorf("Expected a transit friendly score of 60, got %d", transit.Score)
+	}
+	if transit.Coordinates != venue || transit.Feed != "test" || !transit.ScoredAt.Equal(now) {
+		t.Errorf("Expected the score to record where and how it was computed, got %+v", transit)
+	}
+
+	// Only a stop within a 10 minute walk
+	transit = NewTransitStopIndex(stops[2:3]).ScoreVenueTransit(venue, "test", now)
+	if transit.Score != 15 || transit.TransitFriendly() {
+		t.Errorf("Expected a score of 15, got %d", transit.Score)
+	}
+
+	transit = NewTransitStopIndex(stops[4:]).ScoreVenueTransit(venue, "test", now)
+	if transit.Score != 0 || transit.StopsWithin800m != 0 || transit.NearestStopName != "" {
+		t.Errorf("Expected no score without nearby stops, got %+v", transit)
+	}
+}
+
+func TestNeedsTransitScore(t *testing.T) {
+	at := models.Coordinates{Lat: 47.61, Lng: -122.34}
+	tests := []struct {
+		venue models.Venue
+		want  bool
+	}{
+		{models.Venue{}, false},
+		{models.Venue{Coordinates: at}, true},
+		{models.Venue{Coordinates: at, Transit: &models.VenueTransit{Coordinates: at}}, false},
+		{models.Venue{Coordinates: at, Transit: &models.VenueTransit{Coordinates: models.Coordinates{Lat: 47.60, Lng: -122.34}}}, true},
+	}
+	for i, tt := range tests {
+		if got := NeedsTransitScore(&tt.venue); got != tt.want {
+			t.Errorf("Case %d: NeedsTransitScore = %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestVenueRegistryTransitFriendly(t *testing.T) {
+	venues := []models.Venue{
+		{VenueName: "Central Library", Transit: &models.VenueTransit{Score: 80}},
+		{VenueName: "Farm", Transit: &models.VenueTransit{Score: 10}},
+		{VenueName: "New Park"},
+	}
+	venues[0].EntityID, venues[1].EntityID, venues[2].EntityID = "library", "farm", "park"
+	registry := NewVenueRegistry(venues)
+
+	if !registry.TransitFriendly(models.Location{Name: "central library"}) {
+		t.Error("Expected the library to be transit friendly")
+	}
+	for _, name := range []string{"Farm", "New Park", "Somewhere Else"} {
+		if registry.TransitFriendly(models.Location{Name: name}) {
+			t.Errorf("Expected %s not to be transit friendly", name)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// ValidationRuleProvider supplies the validation rule set for a schema type
+type ValidationRuleProvider interface {
+	RuleSet(schemaType string) *models.ValidationRuleSet
+}
+
+// DefaultValidationRuleSet returns the built-in rules used when no rule set is stored for a schema type
+func DefaultValidationRuleSet(schemaType string) *models.ValidationRuleSet {
+	warning := func(field, check string, value int, weight float64, message string) models.ValidationRule {
+		return models.ValidationRule{Field: field, Check: check, Value: value, Severity: models.ValidationSeverityWarning, Weight: weight, Message: message}
+	}
+	required := func(field string, weight float64, message string) models.ValidationRule {
+		return models.ValidationRule{Field: field, Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityError, Weight: weight, Message: message}
+	}
+
+	return &models.ValidationRuleSet{
+		SchemaType: schemaType,
+		ParsingRules: []models.ValidationRule{
+			required("title", 50, "Title is required"),
+			warning("title", models.ValidationCheckMinLength, 3, 10, "Title is very short"),
+			warning("title", models.ValidationCheckMaxLength, 100, 5, "Title is very long"),
+			warning("description", models.ValidationCheckRequired, 0, 15, "No description provided"),
+			warning("description", models.ValidationCheckMinLength, 10, 10, "Description is very short"),
+			warning("date", models.ValidationCheckRequired, 0, 20, "No date information"),
+			warning("date", models.ValidationCheckDateFormat, 0, 10, "Date format may be invalid"),
+			warning("time", models.ValidationCheckRequired, 0, 15, "No time information"),
+			warning("time", models.ValidationCheckTimeFormat, 0, 5, "Time format may be invalid"),
+			warning("location", models.ValidationCheckRequired, 0, 25, "No location information"),
+			warning("location", models.ValidationCheckMinLength, 3, 10, "Location information is very brief"),
+			warning("price", models.ValidationCheckRequired, 0, 10, "No pricing information"),
+			warning("age_groups", models.ValidationCheckRequired, 0, 10, "No age group information"),
+		},
+		ConversionRules: []models.ValidationRule{
+			required("title", 50, "Activity title is required"),
+			required("location.name", 30, "Activity location name is required"),
+			warning("type", models.ValidationCheckRequired, 0, 10, "Activity type not set"),
+			warning("category", models.ValidationCheckRequired, 0, 10, "Activity category not set"),
+			warning("schedule.start_date", models.ValidationCheckRequired, 0, 20, "No start date specified"),
+			warning("schedule.start_time", models.ValidationCheckRequired, 0, 15, "No start time specified"),
+			warning("age_groups", models.ValidationCheckRequired, 0, 10, "No age groups specified"),
+			warning("pricing.type", models.ValidationCheckRequired, 0, 5, "Pricing type not specified"),
+			warning("source.url", models.ValidationCheckRequired, 0, 5, "No source URL"),
+			warning("source.domain", models.ValidationCheckRequired, 0, 5, "No source domain"),
+		},
+	}
+}
+
+// ApplyValidationRules evaluates rules against a record keyed by field name.
+// Every record starts at 100 confidence; each failed rule deducts its weight, and failed
+// error-severity rules mark the record invalid. Length and format checks skip empty fields.
+func ApplyValidationRules(rules []models.ValidationRule, record map[string]interface{}) ValidationResult {
+	result := ValidationResult{
+		IsValid:         true,
+		ConfidenceScore: 100.0,
+		Issues:          []string{},
+		Warnings:        []string{},
+	}
+
+	for _, rule := range rules {
+		if ruleHolds(rule, record[rule.Field]) {
+			continue
+		}
+
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("%s failed %s check", rule.Field, rule.Check)
+		}
+		if rule.Severity == models.ValidationSeverityError {
+			result.Issues = append(result.Issues, message)
+			result.IsValid = false
+		} else {
+			result.Warnings = append(result.Warnings, message)
+		}
+		result.ConfidenceScore -= rule.Weight
+	}
+
+	// Ensure confidence score doesn't go below 0
+	if result.ConfidenceScore < 0 {
+		result.ConfidenceScore = 0
+	}
+
+	return result
+}
+
+// ruleHolds reports whether a field value satisfies a rule
+func ruleHolds(rule models.ValidationRule, value interface{}) bool {
+	text, isText := value.(string)
+	if rule.Check == models.ValidationCheckRequired {
+		if isText {
+			return text != ""
+		}
+		return !isEmptyValue(value)
+	}
+
+	// The remaining checks only apply to text that is present
+	if !isText || text == "" {
+		return true
+	}
+
+	switch rule.Check {
+	case models.ValidationCheckMinLength:
+		return len(text) >= rule.Value
+	case models.ValidationCheckMaxLength:
+		return len(text) <= rule.Value
+	case models.ValidationCheckPattern:
+		matched, err := regexp.MatchString(rule.Pattern, text)
+		return err == nil && matched
+	case models.ValidationCheckDateFormat:
+		return isValidDateFormat(text)
+	case models.ValidationCheckTimeFormat:
+		return isValidTimeFormat(text)
+	}
+
+	return true
+}
+
+// isEmptyValue reports whether a non-string field value is missing or an empty collection
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// eventDataFields exposes a parsed event as a record for rule evaluation
+func eventDataFields(event EventData) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       event.Title,
+		"description": event.Description,
+		"date":        event.Date,
+		"time":        event.Time,
+		"location":    event.Location,
+		"price":       event.Price,
+		"age_groups":  event.AgeGroups,
+		"url":         event.URL,
+	}
+}
+
+// activityFields exposes a converted activity as a record for rule evaluation
+func activityFields(activity models.Activity) map[string]interface{} {
+	return map[string]interface{}{
+		"title":               activity.Title,
+		"description":         activity.Description,
+		"type":                activity.Type,
+		"category":            activity.Category,
+		"subcategory":         activity.Subcategory,
+		"detail_url":          activity.DetailURL,
+		"schedule.start_date": activity.Schedule.StartDate,
+		"schedule.end_date":   activity.Schedule.EndDate,
+		"schedule.start_time": activity.Schedule.StartTime,
+		"schedule.end_time":   activity.Schedule.EndTime,
+		"location.name":       activity.Location.Name,
+		"location.address":    activity.Location.Address,
+		"location.city":       activity.Location.City,
+		"pricing.type":        activity.Pricing.Type,
+		"pricing.description": activity.Pricing.Description,
+		"registration.url":    activity.Registration.URL,
+		"age_groups":          activity.AgeGroups,
+		"source.url":          activity.Source.URL,
+		"source.domain":       activity.Source.Domain,
+	}
+}
+
+// defaultValidationRules provides the built-in rule sets
+type defaultValidationRules struct{}
+
+func (defaultValidationRules) RuleSet(schemaType string) *models.ValidationRuleSet {
+	return DefaultValidationRuleSet(schemaType)
+}
+
+// ValidationRuleCache serves rule sets stored in DynamoDB, refreshing them after a TTL.
+// Schema types without a stored rule set, or whose rule set cannot be loaded, use the defaults.
+type ValidationRuleCache struct {
+	dynamo *DynamoDBService
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedRuleSet
+}
+
+type cachedRuleSet struct {
+	ruleSet  *models.ValidationRuleSet
+	loadedAt time.Time
+}
+
+// NewValidationRuleCache creates a rule cache backed by DynamoDB
+func NewValidationRuleCache(dynamo *DynamoDBService, ttl time.Duration) *ValidationRuleCache {
+	return &ValidationRuleCache{
+		dynamo:  dynamo,
+		ttl:     ttl,
+		entries: make(map[string]cachedRuleSet),
+	}
+}
+
+// RuleSet returns the rule set for a schema type, loading it if the cached copy is stale
+func (c *ValidationRuleCache) RuleSet(schemaType string) *models.ValidationRuleSet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[schemaType]; ok && time.Since(entry.loadedAt) < c.ttl {
+		return entry.ruleSet
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ruleSet, err := c.dynamo.GetValidationRuleSet(ctx, schemaType)
+	if err != nil {
+		log.Printf("Warning: Failed to load validation rules for %s, using defaults: %v", schemaType, err)
+	}
+	if ruleSet == nil {
+		ruleSet = DefaultValidationRuleSet(schemaType)
+	}
+
+	c.entries[schemaType] = cachedRuleSet{ruleSet: ruleSet, loadedAt: time.Now()}
+	return ruleSet
+}
+
+// Invalidate drops the cached rule set for a schema type so the next lookup reloads it
+func (c *ValidationRuleCache) Invalidate(schemaType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, schemaType)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// staticValidationRules serves a fixed rule set for every schema type
+type staticValidationRules struct {
+	ruleSet *models.ValidationRuleSet
+}
+
+func (s staticValidationRules) RuleSet(schemaType string) *models.ValidationRuleSet {
+	return s.ruleSet
+}
+
+func TestApplyValidationRules(t *testing.T) {
+	rules := []models.ValidationRule{
+		{Field: "title", Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityError, Weight: 50, Message: "Title is required"},
+		{Field: "price", Check: models.ValidationCheckPattern, Pattern: `^(Free|\$\d+)$`, Severity: models.ValidationSeverityWarning, Weight: 10, Message: "Price is not normalized"},
+		{Field: "description", Check: models.ValidationCheckMaxLength, Value: 20, Severity: models.ValidationSeverityWarning, Weight: 5},
+		{Field: "age_groups", Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityWarning, Weight: 10, Message: "No age groups"},
+	}
+
+	t.Run("PassingRecord", func(t *testing.T) {
+		result := ApplyValidationRules(rules, map[string]interface{}{
+			"title":       "Story Time",
+			"price":       "$15",
+			"description": "Songs and stories",
+			"age_groups":  []string{"toddler"},
+		})
+		if !result.IsValid || result.ConfidenceScore != 100 || len(result.Warnings) != 0 {
+			t.Errorf("Expected a clean pass, got %+v", result)
+		}
+	})
+
+	t.Run("FailingRecord", func(t *testing.T) {
+		result := ApplyValidationRules(rules, map[string]interface{}{
+			"title":       "",
+			"price":       "15 dollars",
+			"description": "A very long description of the event",
+			"age_groups":  []string{},
+		})
+		if result.IsValid || len(result.Issues) != 1 || result.Issues[0] != "Title is required" {
+			t.Errorf("Expected the error rule to invalidate the record, got %+v", result)
+		}
+		if len(result.Warnings) != 3 || result.Warnings[1] != "description failed max_length check" {
+			t.Errorf("Unexpected warnings: %v", result.Warnings)
+		}
+		if result.ConfidenceScore != 25 {
+			t.Errorf("Expected confidence 25, got %.1f", result.ConfidenceScore)
+		}
+	})
+
+	t.Run("FormatChecksSkipEmptyFields", func(t *testing.T) {
+		result := ApplyValidationRules(rules[1:3], map[string]interface{}{})
+		if !result.IsValid || result.ConfidenceScore != 100 {
+			t.Errorf("Expected empty fields to skip format checks, got %+v", result)
+		}
+	})
+}
+
+func TestFireCrawlClientUsesConfiguredValidationRules(t *testing.T) {
+	// Require a price and nothing else: an event with only a title is now invalid
+	fc := &FireCrawlClient{}
+	fc.SetValidationRules(staticValidationRules{ruleSet: &models.ValidationRuleSet{
+		SchemaType: "events",
+		ParsingRules: []models.ValidationRule{
+			{Field: "price", Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityError, Weight: 40, Message: "Price is required"},
+		},
+	}})
+
+	result := fc.validateEventData(EventData{Title: "Story Time"})
+	if result.IsValid || len(result.Issues) != 1 || result.Issues[0] != "Price is required" {
+		t.Errorf("Expected the configured rule to apply, got %+v", result)
+	}
+	if result.ConfidenceScore != 60 || len(result.Warnings) != 0 {
+		t.Errorf("Expected only the configured rule to be applied, got %+v", result)
+	}
+}
+
+func TestSchemaConversionAppliesValidationRules(t *testing.T) {
+	scs := NewSchemaConversionService()
+	scs.SetValidationRules(staticValidationRules{ruleSet: &models.ValidationRuleSet{
+		SchemaType: "events",
+		ConversionRules: []models.ValidationRule{
+			{Field: "registration.url", Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityError, Weight: 20, Message: "Registration link is required"},
+		},
+	}})
+
+	result, err := scs.ConvertToActivity(&models.AdminEvent{
+		EventID:    "evt-1",
+		SourceURL:  "https://www.seattle.gov/parks/events",
+		SchemaType: "events",
+		RawExtractedData: map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{"title": "Story Time", "location": "Green Lake Library", "date": "2025-07-12"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	ruleResult, ok := result.ValidationResults["rules"].(ValidationResult)
+	if !ok {
+		t.Fatalf("Expected rule results in validation results, got %v", result.ValidationResults["rules"])
+	}
+	if ruleResult.IsValid || ruleResult.Issues[0] != "Registration link is required" {
+		t.Errorf("Expected the configured conversion rule to fail, got %+v", ruleResult)
+	}
+}
+
+func TestValidationRuleSetValidate(t *testing.T) {
+	if err := DefaultValidationRuleSet("events").Validate(); err != nil {
+		t.Errorf("Expected default rules to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rule     models.ValidationRule
+		expected string
+	}{
+		{"MissingField", models.ValidationRule{Check: models.ValidationCheckRequired, Severity: models.ValidationSeverityError}, "field is required"},
+		{"UnknownCheck", models.ValidationRule{Field: "title", Check: "spellcheck", Severity: models.ValidationSeverityError}, "unknown check"},
+		{"MissingLength", models.ValidationRule{Field: "title", Check: models.ValidationCheckMinLength, Severity: models.ValidationSeverityWarning}, "positive value"},
+		{"BadPattern", models.ValidationRule{Field: "title", Check: models.ValidationCheckPattern, Pattern: "([", Severity: models.ValidationSeverityWarning}, "invalid pattern"},
+		{"UnknownSeverity", models.ValidationRule{Field: "title", Check: models.ValidationCheckRequired, Severity: "fatal"}, "unknown severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSet := &models.ValidationRuleSet{SchemaType: "events", ConversionRules: []models.ValidationRule{tt.rule}}
+			err := ruleSet.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	if err := (&models.ValidationRuleSet{SchemaType: "podcasts"}).Validate(); err == nil {
+		t.Error("Expected unknown schema type to be rejected")
+	}
+}
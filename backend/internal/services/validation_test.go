@@ -133,8 +133,6 @@ func TestActivityDataValidation(t *testing.T) {
 
 // TestDateTimeValidation tests date and time format validation
 func TestDateTimeValidation(t *testing.T) {
-	fc := &FireCrawlClient{}
-
 	// Test valid date formats
 	validDates := []string{
 		"12/25/2024",
@@ -145,7 +143,7 @@ func TestDateTimeValidation(t *testing.T) {
 	}
 
 	for _, date := range validDates {
-		if !fc.isValidDateFormat(date) {
+		if !isValidDateFormat(date) {
 			t.Errorf("Expected '%s' to be a valid date format", date)
 		}
 	}
@@ -159,7 +157,7 @@ func TestDateTimeValidation(t *testing.T) {
 	}
 
 	for _, date := range invalidDates {
-		if fc.isValidDateFormat(date) {
+		if isValidDateFormat(date) {
 			t.Errorf("Expected '%s' to be an invalid date format", date)
 		}
 	}
@@ -174,7 +172,7 @@ func TestDateTimeValidation(t *testing.T) {
 	}
 
 	for _, time := range validTimes {
-		if !fc.isValidTimeFormat(time) {
+		if !isValidTimeFormat(time) {
 			t.Errorf("Expected '%s' to be a valid time format", time)
 		}
 	}
@@ -188,7 +186,7 @@ func TestDateTimeValidation(t *testing.T) {
 	}
 
 	for _, time := range invalidTimes {
-		if fc.isValidTimeFormat(time) {
+		if isValidTimeFormat(time) {
 			t.Errorf("Expected '%s' to be an invalid time format", time)
 		}
 	}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"strings"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// VenueRegistry matches activity locations to the venues stored in the family activities table
+type VenueRegistry struct {
+	names       []string
+	byName      map[string]string // normalized venue name or address -> venue ID
+	coordinates map[string]models.Coordinates
+	transit     map[string]bool // venue ID -> transit friendly
+	arrival     map[string]*models.ArrivalInfo
+}
+
+// NewVenueRegistry indexes venues by their names and addresses
+func NewVenueRegistry(venues []models.Venue) *VenueRegistry {
+	registry := &VenueRegistry{byName: make(map[string]string), coordinates: make(map[string]models.Coordinates), transit: make(map[string]bool), arrival: make(map[string]*models.ArrivalInfo)}
+	for _, venue := range venues {
+		name := venue.VenueName
+		if name == "" {
+			name = venue.Name
+		}
+		if name == "" || venue.EntityID == "" {
+			continue
+		}
+		registry.names = append(registry.names, name)
+		registry.byName[normalizeVenueName(name)] = venue.EntityID
+		if venue.Address != "" {
+			registry.byName[normalizeVenueName(venue.Address)] = venue.EntityID
+		}
+		if hasCoordinates(venue.Coordinates) {
+			registry.coordinates[venue.EntityID] = venue.Coordinates
+		}
+		if venue.Transit.TransitFriendly() {
+			registry.transit[venue.EntityID] = true
+		}
+		if !venue.ArrivalInfo.IsEmpty() {
+			registry.arrival[venue.EntityID] = venue.ArrivalInfo
+		}
+	}
+	return registry
+}
+
+// Names returns the display name of every registry venue
+func (r *VenueRegistry) Names() []string {
+	return r.names
+}
+
+// MatchID returns the ID of the registry venue a location refers to, or "" when it is not in the registry
+func (r *VenueRegistry) MatchID(location models.Location) string {
+	if r == nil {
+		return ""
+	}
+	for _, candidate := range []string{location.Name, location.Address} {
+		if candidate == "" {
+			continue
+		}
+		if venueID, ok := r.byName[normalizeVenueName(candidate)]; ok {
+			return venueID
+		}
+	}
+	return ""
+}
+
+// Coordinates returns the coordinates of the registry venue a location refers to
+func (r *VenueRegistry) Coordinates(location models.Location) (models.Coordinates, bool) {
+	if r == nil {
+		return models.Coordinates{}, false
+	}
+	coordinates, ok := r.coordinates[r.MatchID(location)]
+	return coordinates, ok
+}
+
+// TransitFriendly reports whether a location is a registry venue scored as transit friendly
+func (r *VenueRegistry) TransitFriendly(location models.Location) bool {
+	if r == nil {
+		return false
+	}
+	return r.transit[r.MatchID(location)]
+}
+
+// ArrivalInfo returns the arrival info of the registry venue a location refers to, or nil when it has none
+func (r *VenueRegistry) ArrivalInfo(location models.Location) *models.ArrivalInfo {
+	if r == nil {
+		return nil
+	}
+	return r.arrival[r.MatchID(location)]
+}
+
+// normalizeVenueName lowercases a name and collapses its whitespace so minor formatting differences still match
+func normalizeVenueName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
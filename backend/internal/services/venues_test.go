@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestVenueRegistryMatchID(t *testing.T) {
+	venues := []models.Venue{
+		{FamilyActivity: models.FamilyActivity{EntityID: "green-lake-library"}, VenueName: "Green Lake Library", Address: "7364 E Green Lake Dr N, Seattle"},
+		{FamilyActivity: models.FamilyActivity{EntityID: "magnuson-park", Name: "Magnuson Park"}},
+		{VenueName: "Venue Without ID"},
+	}
+	registry := NewVenueRegistry(venues)
+
+	if names := registry.Names(); len(names) != 2 {
+		t.Errorf("Expected 2 named venues with IDs, got %v", names)
+	}
+
+	tests := []struct {
+		location models.Location
+		expected string
+	}{
+		{models.Location{Name: "green lake  LIBRARY"}, "green-lake-library"},
+		{models.Location{Name: "Library", Address: "7364 E Green Lake Dr N, Seattle"}, "green-lake-library"},
+		{models.Location{Name: "Magnuson Park"}, "magnuson-park"},
+		{models.Location{Name: "Venue Without ID"}, ""},
+		{models.Location{}, ""},
+	}
+	for _, tt := range tests {
+		if got := registry.MatchID(tt.location); got != tt.expected {
+			t.Errorf("MatchID(%+v) = %q, expected %q", tt.location, got, tt.expected)
+		}
+	}
+
+	var unloaded *VenueRegistry
+	if got := unloaded.MatchID(models.Location{Name: "Magnuson Park"}); got != "" {
+		t.Errorf("Expected a nil registry to match nothing, got %q", got)
+	}
+}
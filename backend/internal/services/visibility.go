@@ -0,0 +1,37 @@
+package services
+
+import (
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// VisibilityRefreshInterval is how often the visibility refresh runs, and so how late an activity
+// can surface on the calendar, tag and map projections after its window opens or closes
+const VisibilityRefreshInterval = 15 * time.Minute
+
+// VisibleAdminEvents keeps the approved events whose activities may be shown publicly at now,
+// dropping those outside their visibility window or withdrawn until the refresh publishes them
+func VisibleAdminEvents(adminEvents []models.AdminEvent, now time.Time) []models.AdminEvent {
+	visible := make([]models.AdminEvent, 0, len(adminEvents))
+	for i := range adminEvents {
+		if adminEvents[i].IsPubliclyVisible(now) {
+			visible = append(visible, adminEvents[i])
+		}
+	}
+	return visible
+}
+
+// VisibilityChanges returns the approved events whose hidden flag no longer matches their
+// visibility window at now: hidden events whose window opened, and shown events whose window
+// has not opened yet or has closed
+func VisibilityChanges(approvedEvents []models.AdminEvent, now time.Time) []models.AdminEvent {
+	var changes []models.AdminEvent
+	for i := range approvedEvents {
+		if approvedEvents[i].Hidden == !approvedEvents[i].InVisibilityWindow(now) {
+			continue
+		}
+		changes = append(changes, approvedEvents[i])
+	}
+	return changes
+}
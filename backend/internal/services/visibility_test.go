@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestVisibilityChanges(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	events := []models.AdminEvent{
+		{EventID: "open", Status: models.AdminEventStatusApproved},
+		{EventID: "opened", Status: models.AdminEventStatusApproved, PublishAt: &past, Hidden: true},
+		{EventID: "not-yet", Status: models.AdminEventStatusApproved, PublishAt: &future, Hidden: true},
+		{EventID: "closed", Status: models.AdminEventStatusApproved, UnpublishAt: &past},
+		{EventID: "scheduled", Status: models.AdminEventStatusApproved, PublishAt: &future},
+	}
+
+	var changed []string
+	for _, event := range VisibilityChanges(events, now) {
+		changed = append(changed, event.EventID)
+	}
+	if len(changed) != 3 || changed[0] != "opened" || changed[1] != "closed" || changed[2] != "scheduled" {
+		t.Errorf("Expected opened, closed and scheduled to change, got %v", changed)
+	}
+
+	var visible []string
+	for _, event := range VisibleAdminEvents(events, now) {
+		visible = append(visible, event.EventID)
+	}
+	if len(visible) != 1 || visible[0] != "open" {
+		t.Errorf("Expected only the open event to be visible until the refresh, got %v", visible)
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+// maxPlanAlternates is how many alternates each weekend plan slot lists
+const maxPlanAlternates = 3
+
+// maxPlanAges bounds how many children a weekend plan is matched to
+const maxPlanAges = 10
+
+// plannerRegions lists the areas of each region a weekend plan can be limited to. Activities match
+// a region when their location's region is the region itself, or their neighborhood, city or
+// address names one of its areas.
+var plannerRegions = map[string][]string{
+	"north-seattle": {
+		"Ballard", "Fremont", "Wallingford", "Green Lake", "Greenwood", "Phinney Ridge", "Crown Hill",
+		"Northgate", "Lake City", "Maple Leaf", "Roosevelt", "Ravenna", "Wedgwood", "University District",
+		"U District", "Sand Point", "Laurelhurst", "Bitter Lake", "Broadview",
+	},
+	"central-seattle": {
+		"Downtown", "Belltown", "Capitol Hill", "First Hill", "Central District", "Queen Anne",
+		"South Lake Union", "Eastlake", "Montlake", "Madison Park", "Madrona", "Leschi", "Pioneer Square",
+		"International District", "Chinatown", "Magnolia", "Interbay",
+	},
+	"south-seattle": {
+		"Beacon Hill", "Columbia City", "Georgetown", "SoDo", "Mount Baker", "Rainier Valley", "Rainier Beach",
+		"Seward Park", "Hillman City", "South Park",
+	},
+	"west-seattle": {
+		"West Seattle", "Alki", "Admiral", "Fauntleroy", "Delridge", "Morgan Junction", "Arbor Heights", "High Point",
+	},
+	"eastside": {
+		"Bellevue", "Kirkland", "Redmond", "Issaquah", "Sammamish", "Mercer Island", "Bothell", "Woodinville",
+		"Newcastle", "Kenmore",
+	},
+	"north-end": {
+		"Shoreline", "Lake Forest Park", "Edmonds", "Lynnwood", "Mountlake Terrace", "Mukilteo", "Everett",
+	},
+	"south-end": {
+		"Renton", "Burien", "Tukwila", "SeaTac", "Kent", "Des Moines", "Federal Way", "Auburn",
+	},
+}
+
+// PlannerRegions returns the regions a weekend plan can be limited to, in name order
+func PlannerRegions() []string {
+	regions := make([]string, 0, len(plannerRegions))
+	for region := range plannerRegions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// WeekendPlanRequest holds the filters of a weekend plan
+type WeekendPlanRequest struct {
+	Ages    []int   // children's ages in years; every child must be able to join
+	Region  string  // one of PlannerRegions, or empty for anywhere
+	Budget  string  // models.PlanBudgetFree, models.PlanBudgetAny or a maximum cost
+	MaxCost float64 // maximum cost per person when Budget is a cost
+}
+
+// ParseWeekendPlanRequest reads the ages, region and budget query parameters of a weekend plan.
+// ages is a comma-separated list of ages in years. budget is "free", "any" (the default) or a
+// maximum cost per person such as "20".
+func ParseWeekendPlanRequest(params map[string]string) (WeekendPlanRequest, error) {
+	request := WeekendPlanRequest{Budget: models.PlanBudgetAny}
+
+	if agesParam := strings.TrimSpace(params["ages"]); agesParam != "" {
+		for _, part := range strings.Split(agesParam, ",") {
+			age, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || age < 0 || age > 99 {
+				return request, fmt.Errorf("invalid age %q: ages must be whole years between 0 and 99", strings.TrimSpace(part))
+			}
+			request.Ages = append(request.Ages, age)
+		}
+		if len(request.Ages) > maxPlanAges {
+			return request, fmt.Errorf("too many ages: at most %d", maxPlanAges)
+		}
+	}
+
+	if region := strings.ToLower(strings.TrimSpace(params["region"])); region != "" {
+		if _, ok := plannerRegions[region]; !ok {
+			return request, fmt.Errorf("unknown region %q: must be one of %s", region, strings.Join(PlannerRegions(), ", "))
+		}
+		request.Region = region
+	}
+
+	switch budget := strings.ToLower(strings.TrimSpace(params["budget"])); budget {
+	case "", models.PlanBudgetAny:
+	case models.PlanBudgetFree:
+		request.Budget = models.PlanBudgetFree
+	default:
+		maxCost, err := strconv.ParseFloat(strings.TrimPrefix(budget, "$"), 64)
+		if err != nil || maxCost < 0 {
+			return request, fmt.Errorf("invalid budget %q: must be free, any or a maximum cost", budget)
+		}
+		if maxCost == 0 {
+			request.Budget = models.PlanBudgetFree
+		} else {
+			request.Budget = strconv.FormatFloat(maxCost, 'f', -1, 64)
+			request.MaxCost = maxCost
+		}
+	}
+
+	return request, nil
+}
+
+// WeekendOf returns the Saturday of the weekend now falls in, or of the next weekend on a weekday
+func WeekendOf(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch day.Weekday() {
+	case time.Sunday:
+		return day.AddDate(0, 0, -1)
+	default:
+		return day.AddDate(0, 0, int(time.Saturday-day.Weekday()))
+	}
+}
+
+// PlanWeekend assembles a weekend plan from published activities. Each day's slots are filled in
+// order, morning first, with the best matching activity not already in the plan. Once a day has
+// an activity, the rest of the day favors activities close to it. The next best matches of each
+// slot are its alternates.
+func PlanWeekend(activities []*models.PublicActivity, saturday time.Time, request WeekendPlanRequest) *models.WeekendPlan {
+	sunday := saturday.AddDate(0, 0, 1)
+	plan := &models.WeekendPlan{
+		Saturday: saturday.Format("2006-01-02"),
+		Sunday:   sunday.Format("2006-01-02"),
+		Ages:     request.Ages,
+		Region:   request.Region,
+		Budget:   request.Budget,
+		Days:     []models.PlanDay{},
+	}
+
+	var matching []*models.PublicActivity
+	for _, activity := range activities {
+		if matchesWeekendPlan(&activity.Activity, request) {
+			matching = append(matching, activity)
+		}
+	}
+	occurrences := ExpandOccurrences(matching, saturday, sunday)
+	plan.Candidates = len(occurrences)
+
+	planned := make(map[string]bool)
+	for _, day := range []time.Time{saturday, sunday} {
+		date := day.Format("2006-01-02")
+		planDay := models.PlanDay{Date: date, Weekday: day.Weekday().String(), Slots: []models.PlanSlot{}}
+
+		var anchor *models.PublicActivity
+		for _, slot := range []string{models.PlanSlotMorning, models.PlanSlotAfternoon} {
+			var candidates []*models.PublicActivity
+			for _, occurrence := range occurrences {
+				if occurrence.Occurrence.Date == date && !planned[occurrence.ID] && fitsPlanSlot(occurrence.Schedule, slot) {
+					candidates = append(candidates, occurrence)
+				}
+			}
+			rankPlanCandidates(candidates, anchor, request)
+
+			planSlot := models.PlanSlot{Slot: slot, Alternates: []*models.PublicActivity{}}
+			if len(candidates) > 0 {
+				planSlot.Activity = candidates[0]
+				planned[candidates[0].ID] = true
+				if anchor == nil {
+					anchor = candidates[0]
+				}
+				for _, alternate := range candidates[1:] {
+					if len(planSlot.Alternates) == maxPlanAlternates {
+						break
+					}
+					planSlot.Alternates = append(planSlot.Alternates, alternate)
+				}
+			}
+			planDay.Slots = append(planDay.Slots, planSlot)
+		}
+		plan.Days = append(plan.Days, planDay)
+	}
+
+	return plan
+}
+
+// matchesWeekendPlan reports whether an activity suits every child, is in the region and fits the budget
+func matchesWeekendPlan(activity *models.Activity, request WeekendPlanRequest) bool {
+	for _, age := range request.Ages {
+		if !activity.SuitableForAgeMonths(age * 12) {
+			return false
+		}
+	}
+	if request.Region != "" && !inPlannerRegion(activity.Location, request.Region) {
+		return false
+	}
+	return withinPlanBudget(activity.Pricing, request)
+}
+
+// inPlannerRegion reports whether a location is in one of the region's areas
+func inPlannerRegion(location models.Location, region string) bool {
+	if strings.ReplaceAll(strings.ToLower(strings.TrimSpace(location.Region)), " ", "-") == region {
+		return true
+	}
+	text := " " + planWords(strings.Join([]string{location.Neighborhood, location.City, location.Address}, " ")) + " "
+	for _, area := range plannerRegions[region] {
+		if strings.Contains(text, " "+planWords(area)+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// planWords lowercases text and separates its words by single spaces, so area names match whole words
+func planWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), " ")
+}
+
+// withinPlanBudget reports whether pricing fits the budget. Free and donation activities fit any
+// budget; paid ones fit a maximum cost only when their cost is known.
+func withinPlanBudget(pricing models.Pricing, request WeekendPlanRequest) bool {
+	if pricing.Type == models.PricingTypeFree || pricing.Type == models.PricingTypeDonation {
+		return true
+	}
+	switch request.Budget {
+	case models.PlanBudgetAny:
+		return true
+	case models.PlanBudgetFree:
+		return false
+	}
+	return pricing.Cost > 0 && pricing.Cost <= request.MaxCost
+}
+
+// fitsPlanSlot reports whether an occurrence starts in the slot. All-day and untimed occurrences fit either slot.
+func fitsPlanSlot(schedule models.Schedule, slot string) bool {
+	if schedule.IsAllDay || schedule.StartTime == "" {
+		return true
+	}
+	start, err := time.Parse("15:04", schedule.StartTime)
+	if err != nil {
+		return true
+	}
+	switch slot {
+	case models.PlanSlotMorning:
+		return start.Hour() < 12
+	case models.PlanSlotAfternoon:
+		return start.Hour() >= 12 && start.Hour() < 18
+	}
+	return false
+}
+
+// rankPlanCandidates orders a slot's candidates best first. Featured activities, ones aimed at the
+// children's ages and ones at a set time rank higher, and ones far from the day's first activity lower.
+func rankPlanCandidates(candidates []*models.PublicActivity, anchor *models.PublicActivity, request WeekendPlanRequest) {
+	scores := make(map[*models.PublicActivity]float64, len(candidates))
+	for _, candidate := range candidates {
+		score := 0.0
+		if !candidate.Schedule.IsAllDay && candidate.Schedule.StartTime != "" {
+			score += 0.25 // untimed activities fit around timed ones
+		}
+		if candidate.Featured {
+			score += 0.5
+		}
+		if len(request.Ages) > 0 && len(candidate.AgeGroups) > 0 {
+			score += 0.5
+		}
+		if anchor != nil {
+			if km, known := planDistanceKm(anchor.Location, candidate.Location); known {
+				score -= math.Min(km/10, 3)
+			} else {
+				score -= 1.5
+			}
+		}
+		scores[candidate] = score
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		if a.Schedule.StartTime != b.Schedule.StartTime {
+			return a.Schedule.StartTime < b.Schedule.StartTime
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID < b.ID
+	})
+}
+
+// planDistanceKm estimates how far apart two locations are. Coordinates give the straight-line
+// distance; without them, locations in the same neighborhood count as together and ones in the same
+// city as 5 km apart. known is false when the distance cannot be estimated.
+func planDistanceKm(a, b models.Location) (km float64, known bool) {
+	if hasCoordinates(a.Coordinates) && hasCoordinates(b.Coordinates) {
+		return haversineKm(a.Coordinates, b.Coordinates), true
+	}
+	if a.Neighborhood != "" && strings.EqualFold(a.Neighborhood, b.Neighborhood) {
+		return 0, true
+	}
+	if a.City != "" && strings.EqualFold(a.City, b.City) {
+		return 5, true
+	}
+	return 0, false
+}
+
+func hasCoordinates(c models.Coordinates) bool {
+	return c.Lat != 0 || c.Lng != 0
+}
+
+// haversineKm returns the great-circle distance between two coordinates in kilometers
+func haversineKm(a, b models.Coordinates) float64 {
+	const earthRadiusKm = 6371.0
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(b.Lat - a.Lat)
+	dLng := toRadians(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(a.Lat))*math.Cos(toRadians(b.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
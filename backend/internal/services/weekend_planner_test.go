@@ -0,0 +1,158 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"seattle-family-activities-scraper/internal/models"
+)
+
+func TestParseWeekendPlanRequest(t *testing.T) {
+	request, err := ParseWeekendPlanRequest(map[string]string{"ages": "4, 7", "region": "North-Seattle", "budget": "$20"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(request.Ages) != 2 || request.Ages[1] != 7 || request.Region != "north-seattle" || request.Budget != "20" || request.MaxCost != 20 {
+		t.Errorf("Unexpected request: %+v", request)
+	}
+
+	request, err = ParseWeekendPlanRequest(map[string]string{})
+	if err != nil || request.Budget != models.PlanBudgetAny || request.Region != "" || len(request.Ages) != 0 {
+		t.Errorf("Expected no filters by default, got %+v (%v)", request, err)
+	}
+
+	if request, _ := ParseWeekendPlanRequest(map[string]string{"budget": "0"}); request.Budget != models.PlanBudgetFree {
+		t.Errorf("Expected a zero budget to mean free, got %q", request.Budget)
+	}
+
+	for _, params := range []map[string]string{
+		{"ages": "4,seven"},
+		{"ages": "-1"},
+		{"region": "tacoma"},
+		{"budget": "cheap"},
+	} {
+		if _, err := ParseWeekendPlanRequest(params); err == nil {
+			t.Errorf("Expected an error for %v", params)
+		}
+	}
+}
+
+func TestWeekendOf(t *testing.T) {
+	tests := map[string]string{
+		"2025-06-11": "2025-06-14", // Wednesday
+		"2025-06-14": "2025-06-14", // Saturday
+		"2025-06-15": "2025-06-14", // Sunday
+		"2025-06-16": "2025-06-21", // Monday
+	}
+	for day, want := range tests {
+		now, _ := time.Parse("2006-01-02", day)
+		if got := WeekendOf(now.Add(15 * time.Hour)).Format("2006-01-02"); got != want {
+			t.Errorf("WeekendOf(%s) = %s, want %s", day, got, want)
+		}
+	}
+}
+
+func TestPlanWeekend(t *testing.T) {
+	ballard := models.Location{Name: "Ballard Library", Neighborhood: "Ballard", City: "Seattle", Coordinates: models.Coordinates{Lat: 47.6690, Lng: -122.3840}}
+	free := models.Pricing{Type: models.PricingTypeFree}
+	activity := func(id, title, startTime string, location models.Location, pricing models.Pricing) *models.PublicActivity {
+		return &models.PublicActivity{Activity: models.Activity{
+			ID:       id,
+			Title:    title,
+			Schedule: models.Schedule{Type: models.ScheduleTypeOneTime, StartDate: "2025-06-14", StartTime: startTime},
+			Location: location,
+			Pricing:  pricing,
+		}}
+	}
+
+	storyTime := activity("a", "Story Time", "10:00", ballard, free)
+	parkPlay := activity("b", "Park Play", "14:00", models.Location{Name: "Gilman Playground", Coordinates: models.Coordinates{Lat: 47.6640, Lng: -122.3770}}, free)
+	festival := activity("c", "Bellevue Festival", "14:00", models.Location{Name: "Downtown Park", City: "Bellevue", Coordinates: models.Coordinates{Lat: 47.6120, Lng: -122.2040}}, free)
+	festival.Featured = true
+	pottery := activity("d", "Pottery", "11:00", ballard, models.Pricing{Type: models.PricingTypePaid, Cost: 30})
+	teenNight := activity("e", "Teen Night", "15:00", ballard, free)
+	teenNight.AgeGroups = []models.AgeGroup{{Category: "teen", MinAge: 13, MaxAge: 17, Unit: "years"}}
+	zoo := &models.PublicActivity{Activity: models.Activity{
+		ID:       "f",
+		Title:    "Zoo Visit",
+		Schedule: models.Schedule{Type: models.ScheduleTypeOngoing},
+		Pricing:  free,
+	}}
+
+	saturday, _ := time.Parse("2006-01-02", "2025-06-14")
+	request := WeekendPlanRequest{Ages: []int{4, 7}, Budget: models.PlanBudgetFree}
+	plan := PlanWeekend([]*models.PublicActivity{storyTime, parkPlay, festival, pottery, teenNight, zoo}, saturday, request)
+
+	if plan.Saturday != "2025-06-14" || plan.Sunday != "2025-06-15" || len(plan.Days) != 2 {
+		t.Fatalf("Unexpected plan: %+v", plan)
+	}
+	// Pottery is over budget and teen night too old for the children; the zoo occurs on both days
+	if plan.Candidates != 5 {
+		t.Errorf("Expected 5 candidate occurrences, got %d", plan.Candidates)
+	}
+
+	ids := func(slot models.PlanSlot) (string, []string) {
+		picked := ""
+		if slot.Activity != nil {
+			picked = slot.Activity.ID
+		}
+		var alternates []string
+		for _, alternate := range slot.Alternates {
+			alternates = append(alternates, alternate.ID)
+		}
+		return picked, alternates
+	}
+
+	// The timed story time beats the zoo, which fits any time
+	picked, alternates := ids(plan.Days[0].Slots[0])
+	if picked != "a" || len(alternates) != 1 || alternates[0] != "f" {
+		t.Errorf("Unexpected Saturday morning: %s with alternates %v", picked, alternates)
+	}
+
+	// The nearby park beats the featured festival across the lake
+	picked, alternates = ids(plan.Days[0].Slots[1])
+	if picked != "b" || len(alternates) != 2 || alternates[0] != "c" || alternates[1] != "f" {
+		t.Errorf("Unexpected Saturday afternoon: %s with alternates %v", picked, alternates)
+	}
+
+	// Activities are planned once, so Sunday afternoon is left open
+	picked, _ = ids(plan.Days[1].Slots[0])
+	if picked != "f" || plan.Days[1].Slots[0].Activity.Occurrence.Date != "2025-06-15" {
+		t.Errorf("Expected the zoo on Sunday morning, got %q", picked)
+	}
+	if picked, alternates = ids(plan.Days[1].Slots[1]); picked != "" || len(alternates) != 0 {
+		t.Errorf("Expected an empty Sunday afternoon, got %q with alternates %v", picked, alternates)
+	}
+}
+
+func TestInPlannerRegion(t *testing.T) {
+	tests := []struct {
+		location models.Location
+		region   string
+		want     bool
+	}{
+		{models.Location{Neighborhood: "Green Lake"}, "north-seattle", true},
+		{models.Location{Address: "1000 4th Ave, Seattle, WA"}, "north-seattle", false},
+		{models.Location{City: "Bellevue"}, "eastside", true},
+		{models.Location{Region: "Eastside"}, "eastside", true},
+		{models.Location{Address: "123 Kentucky Ave"}, "south-end", false},
+	}
+	for _, tt := range tests {
+		if got := inPlannerRegion(tt.location, tt.region); got != tt.want {
+			t.Errorf("inPlannerRegion(%+v, %s) = %v, want %v", tt.location, tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestWithinPlanBudget(t *testing.T) {
+	capped := WeekendPlanRequest{Budget: "20", MaxCost: 20}
+	if !withinPlanBudget(models.Pricing{Type: models.PricingTypePaid, Cost: 15}, capped) {
+		t.Error("Expected a $15 activity to fit a $20 budget")
+	}
+	if withinPlanBudget(models.Pricing{Type: models.PricingTypePaid}, capped) {
+		t.Error("Expected a paid activity without a cost not to fit a capped budget")
+	}
+	if !withinPlanBudget(models.Pricing{Type: models.PricingTypeDonation}, WeekendPlanRequest{Budget: models.PlanBudgetFree}) {
+		t.Error("Expected a donation activity to fit a free budget")
+	}
+}
@@ -0,0 +1,245 @@
+// Package urlutil parses source URLs and derives the domains activities are grouped by.
+//
+// Every Source.Domain in the system should come from Domain so the same site is always
+// recorded the same way regardless of scheme, case, port, "www." prefix or IDN encoding.
+package urlutil
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Parse parses a source URL, assuming https when the scheme is missing.
+// The host is lowercased and internationalized labels are converted to punycode.
+func Parse(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, fmt.Errorf("empty URL")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + strings.TrimPrefix(rawURL, "//")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("URL %q has no host", rawURL)
+	}
+
+	host, err := ToASCII(parsed.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("invalid host in %q: %w", rawURL, err)
+	}
+	if port := parsed.Port(); port != "" {
+		parsed.Host = net.JoinHostPort(host, port)
+	} else {
+		parsed.Host = host
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+
+	return parsed, nil
+}
+
+// Validate checks that a URL is an absolute http or https URL with a host
+func Validate(rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("URL must use http or https")
+	}
+	if parsed.Hostname() == "" {
+		return fmt.Errorf("URL must include a host")
+	}
+	return nil
+}
+
+// Host returns the normalized host name of a URL without its port, or "" if it cannot be parsed
+func Host(rawURL string) string {
+	parsed, err := Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// Domain returns the host of a URL without a leading "www.", e.g. "parks.seattle.gov".
+// This is the value stored in Source.Domain; it is "" if the URL cannot be parsed.
+func Domain(rawURL string) string {
+	return strings.TrimPrefix(Host(rawURL), "www.")
+}
+
+// RegistrableDomain returns the eTLD+1 of a URL, e.g. "seattle.gov" for
+// "https://parks.seattle.gov/events", or "" if the URL cannot be parsed.
+// IP addresses are returned unchanged.
+func RegistrableDomain(rawURL string) string {
+	host := Host(rawURL)
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+
+	suffix := PublicSuffix(host)
+	if host == suffix {
+		return host
+	}
+	rest := strings.TrimSuffix(host, "."+suffix)
+	if idx := strings.LastIndex(rest, "."); idx != -1 {
+		rest = rest[idx+1:]
+	}
+	return rest + "." + suffix
+}
+
+// PublicSuffix returns the public suffix of a host name, e.g. "co.uk" for "www.example.co.uk".
+// Hosts under suffixes missing from the built-in list fall back to their last label.
+func PublicSuffix(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	labels := strings.Split(host, ".")
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+		if publicSuffixes[candidate] {
+			return candidate
+		}
+	}
+	return labels[len(labels)-1]
+}
+
+// publicSuffixes lists the multi-label public suffixes our sources are likely to use.
+// Single-label TLDs are implied, so only deeper suffixes need to be listed.
+var publicSuffixes = map[string]bool{
+	// Country second-level domains
+	"co.uk": true, "org.uk": true, "ac.uk": true, "gov.uk": true,
+	"com.au": true, "org.au": true, "net.au": true, "edu.au": true, "gov.au": true,
+	"co.nz": true, "org.nz": true, "co.jp": true, "com.br": true, "com.mx": true, "co.in": true,
+	// US state and locality domains used by Washington schools, libraries and cities
+	"wa.us": true, "k12.wa.us": true, "lib.wa.us": true, "cc.wa.us": true,
+	// Hosting platforms where each subdomain is a separate site
+	"github.io": true, "herokuapp.com": true, "netlify.app": true, "vercel.app": true,
+	"blogspot.com": true, "wordpress.com": true, "squarespace.com": true, "wixsite.com": true,
+}
+
+// ToASCII lowercases a host name and converts internationalized labels to punycode
+func ToASCII(host string) (string, error) {
+	host = strings.ToLower(host)
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := punycodeEncode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode parameters from RFC 3492
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// punycodeEncode encodes a Unicode label as punycode (RFC 3492), without the "xn--" prefix
+func punycodeEncode(label string) (string, error) {
+	input := []rune(label)
+	var output strings.Builder
+	for _, r := range input {
+		if r < 0x80 {
+			output.WriteRune(r)
+		}
+	}
+	basicCount := output.Len()
+	handled := basicCount
+	if basicCount > 0 {
+		output.WriteByte('-')
+	}
+
+	n, delta, bias := rune(punycodeInitialN), 0, punycodeInitialBias
+	for handled < len(input) {
+		// Find the smallest code point not yet handled
+		next := rune(0x7FFFFFFF)
+		for _, r := range input {
+			if r >= n && r < next {
+				next = r
+			}
+		}
+		if int(next-n) > (1<<31-1-delta)/(handled+1) {
+			return "", fmt.Errorf("label %q is too long to encode", label)
+		}
+		delta += int(next-n) * (handled + 1)
+		n = next
+
+		for _, r := range input {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := k - bias
+				if t < punycodeTMin {
+					t = punycodeTMin
+				} else if t > punycodeTMax {
+					t = punycodeTMax
+				}
+				if q < t {
+					break
+				}
+				output.WriteByte(punycodeDigit(t + (q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+			output.WriteByte(punycodeDigit(q))
+			bias = punycodeAdapt(delta, handled+1, handled == basicCount)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+
+	return output.String(), nil
+}
+
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punycodeAdapt(delta, numPoints int, firstTime bool) int {
+	if firstTime {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
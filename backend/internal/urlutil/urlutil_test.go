@@ -0,0 +1,81 @@
+package urlutil
+
+import "testing"
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://www.seattleschild.com/events/", "seattleschild.com"},
+		{"http://seattleschild.com", "seattleschild.com"},
+		{"HTTPS://WWW.SeattlesChild.com:443/Events", "seattleschild.com"},
+		{"seattleschild.com/events", "seattleschild.com"},
+		{"  https://parks.seattle.gov/events?page=2  ", "parks.seattle.gov"},
+		{"https://münchen.de/kinder", "xn--mnchen-3ya.de"},
+		{"https://xn--mnchen-3ya.de/kinder", "xn--mnchen-3ya.de"},
+		{"", ""},
+		{"https://", ""},
+		{"http://[::1", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Domain(tt.url); got != tt.expected {
+			t.Errorf("Domain(%q) = %q, expected %q", tt.url, got, tt.expected)
+		}
+	}
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://parks.seattle.gov/events", "seattle.gov"},
+		{"https://www.seattleschild.com", "seattleschild.com"},
+		{"https://events.bbc.co.uk/kids", "bbc.co.uk"},
+		{"https://www.spl.lib.wa.us", "spl.lib.wa.us"},
+		{"https://someone.github.io/camps", "someone.github.io"},
+		{"https://co.uk", "co.uk"},
+		{"http://10.0.0.1:8080/events", "10.0.0.1"},
+		{"not a url", ""},
+	}
+
+	for _, tt := range tests {
+		if got := RegistrableDomain(tt.url); got != tt.expected {
+			t.Errorf("RegistrableDomain(%q) = %q, expected %q", tt.url, got, tt.expected)
+		}
+	}
+}
+
+func TestToASCII(t *testing.T) {
+	tests := map[string]string{
+		"Example.COM":  "example.com",
+		"bücher.de":    "xn--bcher-kva.de",
+		"www.münchen":  "www.xn--mnchen-3ya",
+		"例え.テスト":       "xn--r8jz45g.xn--zckzah",
+		"café.example": "xn--caf-dma.example",
+	}
+
+	for host, expected := range tests {
+		got, err := ToASCII(host)
+		if err != nil {
+			t.Errorf("ToASCII(%q) failed: %v", host, err)
+		} else if got != expected {
+			t.Errorf("ToASCII(%q) = %q, expected %q", host, got, expected)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, valid := range []string{"https://example.com", "http://example.com/events?x=1"} {
+		if err := Validate(valid); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "example.com", "ftp://example.com", "https:///path"} {
+		if err := Validate(invalid); err == nil {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
@@ -4,11 +4,19 @@ import { Construct } from 'constructs';
 import * as dynamodb from 'aws-cdk-lib/aws-dynamodb';
 import * as lambda from 'aws-cdk-lib/aws-lambda';
 import * as apigateway from 'aws-cdk-lib/aws-apigateway';
+import * as apigatewayv2 from 'aws-cdk-lib/aws-apigatewayv2';
+import { WebSocketLambdaIntegration } from 'aws-cdk-lib/aws-apigatewayv2-integrations';
 import * as iam from 'aws-cdk-lib/aws-iam';
+import * as s3 from 'aws-cdk-lib/aws-s3';
+import * as sqs from 'aws-cdk-lib/aws-sqs';
+import * as lambdaEventSources from 'aws-cdk-lib/aws-lambda-event-sources';
 import * as cloudwatch from 'aws-cdk-lib/aws-cloudwatch';
 import * as sns from 'aws-cdk-lib/aws-sns';
 import * as snsSubscriptions from 'aws-cdk-lib/aws-sns-subscriptions';
 import * as cloudwatchActions from 'aws-cdk-lib/aws-cloudwatch-actions';
+import * as events from 'aws-cdk-lib/aws-events';
+import * as targets from 'aws-cdk-lib/aws-events-targets';
+import * as location from 'aws-cdk-lib/aws-location';
 import { GoFunction } from '@aws-cdk/aws-lambda-go-alpha';
 
 export class SeattleFamilyActivitiesMVPStack extends Stack {
@@ -82,6 +90,56 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
       nonKeyAttributes: ['venue_name', 'event_name', 'program_name', 'status', 'updated_at']
     });
 
+    // DynamoDB creates only one GSI per table update, so on an existing table the indexes below
+    // are added one deploy at a time: `npm run deploy -- -c activityIndexStep=1`, then 2, 3 and 4, each
+    // once the previous index is active. A new table gets all of them by default.
+    const activityIndexStep = Number(this.node.tryGetContext('activityIndexStep') ?? 4);
+
+    // Calendar entries (one per day an approved activity occurs) for the public month view
+    if (activityIndexStep >= 1) familyActivitiesTable.addGlobalSecondaryIndex({
+      indexName: 'month-date-index',
+      partitionKey: { name: 'CalendarMonthKey', type: dynamodb.AttributeType.STRING },
+      sortKey: { name: 'DateTypeKey', type: dynamodb.AttributeType.STRING },
+      projectionType: dynamodb.ProjectionType.INCLUDE,
+      nonKeyAttributes: ['activity_id', 'date', 'start_time', 'end_time', 'is_all_day', 'title', 'type', 'category', 'location_name', 'pricing_type', 'featured']
+    });
+
+    // Calendar entries linked to a registry venue, for venue profile pages
+    if (activityIndexStep >= 2) familyActivitiesTable.addGlobalSecondaryIndex({
+      indexName: 'venue-date-index',
+      partitionKey: { name: 'VenueKey', type: dynamodb.AttributeType.STRING },
+      sortKey: { name: 'DateTypeKey', type: dynamodb.AttributeType.STRING },
+      projectionType: dynamodb.ProjectionType.INCLUDE,
+      nonKeyAttributes: ['activity_id', 'venue_id', 'date', 'start_time', 'end_time', 'is_all_day', 'title', 'type', 'category', 'location_name', 'pricing_type', 'featured']
+    });
+
+    // Tag entries (one per tag of an approved activity) for tag pages and usage counts
+    if (activityIndexStep >= 3) familyActivitiesTable.addGlobalSecondaryIndex({
+      indexName: 'tag-date-index',
+      partitionKey: { name: 'TagKey', type: dynamodb.AttributeType.STRING },
+      sortKey: { name: 'TagDateKey', type: dynamodb.AttributeType.STRING },
+      projectionType: dynamodb.ProjectionType.INCLUDE,
+      nonKeyAttributes: ['activity_id', 'tag', 'title', 'type', 'category', 'start_date']
+    });
+
+    // Geo entries (one per approved activity with coordinates) for nearby searches, partitioned by
+    // 4-character geohash cell and sorted by 7-character geohash
+    if (activityIndexStep >= 4) familyActivitiesTable.addGlobalSecondaryIndex({
+      indexName: 'geohash-index',
+      partitionKey: { name: 'GeoCellKey', type: dynamodb.AttributeType.STRING },
+      sortKey: { name: 'GeoSortKey', type: dynamodb.AttributeType.STRING },
+      projectionType: dynamodb.ProjectionType.INCLUDE,
+      nonKeyAttributes: ['activity_id', 'event_id', 'title', 'lat', 'lng']
+    });
+
+    // Place index that published locations without coordinates are geocoded with
+    const placeIndex = new location.CfnPlaceIndex(this, 'PlaceIndex', {
+      indexName: 'seattle-family-activities-places',
+      dataSource: 'Esri',
+      pricingPlan: 'RequestBasedUsage',
+      description: 'Geocodes activity addresses for nearby searches',
+    });
+
     // DynamoDB Table 2: Source Management (Source Configuration)
     const sourceManagementTable = new dynamodb.Table(this, 'SourceManagementTable', {
       tableName: 'seattle-source-management',
@@ -128,10 +186,13 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
       pointInTimeRecoverySpecification: {
         pointInTimeRecoveryEnabled: true // Important for admin data
       },
-      encryption: dynamodb.TableEncryption.AWS_MANAGED
+      encryption: dynamodb.TableEncryption.AWS_MANAGED,
+      stream: dynamodb.StreamViewType.NEW_AND_OLD_IMAGES // feeds the catalog change log
     });
 
     // Add Global Secondary Index to Scraping Operations Table
+    // No longer queried: its partition key is the run time itself. Drop it in a later deploy,
+    // since DynamoDB creates or deletes only one GSI per table update.
     scrapingOperationsTable.addGlobalSecondaryIndex({
       indexName: 'next-run-index',
       partitionKey: { name: 'NextRunKey', type: dynamodb.AttributeType.STRING },
@@ -140,6 +201,16 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
       nonKeyAttributes: ['source_id', 'scheduled_time', 'task_type', 'status', 'retry_count']
     });
 
+    // Due tasks, spread over 16 shards (NEXT_RUN#00..15) and ordered by run time within each.
+    // Tasks written before it existed have no NextRunShard: once it is active, run
+    // `go run ./cmd/next_run_backfill -apply` so the scheduler picks them up again.
+    scrapingOperationsTable.addGlobalSecondaryIndex({
+      indexName: 'next-run-shard-index',
+      partitionKey: { name: 'NextRunShard', type: dynamodb.AttributeType.STRING },
+      sortKey: { name: 'NextRunKey', type: dynamodb.AttributeType.STRING },
+      projectionType: dynamodb.ProjectionType.ALL
+    });
+
     // Add Global Secondary Index to Admin Events Table
     adminEventsTable.addGlobalSecondaryIndex({
       indexName: 'status-date-index',
@@ -245,6 +316,89 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
 
 
 
+    // Fan-out scraping: the orchestrator queues one task per source URL for the executor
+    const scrapeTaskDeadLetterQueue = new sqs.Queue(this, 'ScrapeTaskDeadLetterQueue', {
+      queueName: 'seattle-family-activities-scrape-tasks-dlq',
+      retentionPeriod: Duration.days(14),
+    });
+
+    const scrapeTaskQueue = new sqs.Queue(this, 'ScrapeTaskQueue', {
+      queueName: 'seattle-family-activities-scrape-tasks',
+      visibilityTimeout: Duration.minutes(30), // must exceed the executor timeout
+      deadLetterQueue: {
+        queue: scrapeTaskDeadLetterQueue,
+        maxReceiveCount: 3,
+      },
+    });
+
+    const scrapeExecutorFunction = new GoFunction(this, 'ScrapeExecutorFunction', {
+      entry: '../backend/cmd/scrape_executor',
+      functionName: 'seattle-family-activities-scrape-executor',
+      timeout: Duration.minutes(5),
+      memorySize: 512,
+      role: scraperRole,
+      environment: {
+        FAMILY_ACTIVITIES_TABLE: familyActivitiesTable.tableName,
+        SOURCE_MANAGEMENT_TABLE: sourceManagementTable.tableName,
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        FIRECRAWL_API_KEY: process.env.FIRECRAWL_API_KEY || '',
+        DYNAMODB_METRICS_NAMESPACE: 'SeattleFamilyActivities/DynamoDB',
+        GEOCODING_PROVIDER: process.env.GEOCODING_PROVIDER || '',
+        GEOCODING_PLACE_INDEX: placeIndex.indexName,
+        GEOCODING_API_KEY: process.env.GEOCODING_API_KEY || '',
+        LOG_LEVEL: 'INFO'
+      },
+      description: 'Extracts activities for a single source URL from the scrape task queue'
+    });
+    // Manual triggers are queued separately so they run ahead of scheduled backfill work
+    const scrapeTaskHighPriorityQueue = new sqs.Queue(this, 'ScrapeTaskHighPriorityQueue', {
+      queueName: 'seattle-family-activities-scrape-tasks-high',
+      visibilityTimeout: Duration.minutes(30),
+      deadLetterQueue: {
+        queue: scrapeTaskDeadLetterQueue,
+        maxReceiveCount: 3,
+      },
+    });
+
+    // Combined concurrency stays at 5 to keep FireCrawl usage within rate limits
+    scrapeExecutorFunction.addEventSource(new lambdaEventSources.SqsEventSource(scrapeTaskHighPriorityQueue, {
+      batchSize: 1,
+      maxConcurrency: 2,
+      reportBatchItemFailures: true,
+    }));
+    scrapeExecutorFunction.addEventSource(new lambdaEventSources.SqsEventSource(scrapeTaskQueue, {
+      batchSize: 1,
+      maxConcurrency: 3,
+      reportBatchItemFailures: true,
+    }));
+    // Dead-lettered messages are only recorded and reported, without calling FireCrawl
+    scrapeExecutorFunction.addEventSource(new lambdaEventSources.SqsEventSource(scrapeTaskDeadLetterQueue, {
+      batchSize: 10,
+      maxConcurrency: 2,
+      reportBatchItemFailures: true,
+    }));
+    scrapeExecutorFunction.addEnvironment('SCRAPE_TASK_DLQ_ARN', scrapeTaskDeadLetterQueue.queueArn);
+
+    scrapeTaskQueue.grantSendMessages(scrapingOrchestratorFunction);
+    scrapeTaskHighPriorityQueue.grantSendMessages(scrapingOrchestratorFunction);
+    scrapingOrchestratorFunction.addEnvironment('SCRAPE_TASK_QUEUE_URL', scrapeTaskQueue.queueUrl);
+    scrapingOrchestratorFunction.addEnvironment('SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL', scrapeTaskHighPriorityQueue.queueUrl);
+
+    // Normal-priority executors re-queue their task while manual triggers are waiting
+    scrapeTaskQueue.grantSendMessages(scrapeExecutorFunction);
+    scrapeTaskHighPriorityQueue.grant(scrapeExecutorFunction, 'sqs:GetQueueAttributes');
+    scrapeTaskHighPriorityQueue.grantSendMessages(scrapeExecutorFunction); // requeue near the Lambda deadline
+    scrapeExecutorFunction.addEnvironment('SCRAPE_TASK_QUEUE_URL', scrapeTaskQueue.queueUrl);
+    scrapeExecutorFunction.addEnvironment('SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL', scrapeTaskHighPriorityQueue.queueUrl);
+
+    // Extracted locations without coordinates are geocoded, by default with the place index
+    scrapeExecutorFunction.addToRolePolicy(new iam.PolicyStatement({
+      effect: iam.Effect.ALLOW,
+      actions: ['geo:SearchPlaceIndexForText'],
+      resources: [placeIndex.attrIndexArn],
+    }));
+
     // SNS topic for alerts
     const alertTopic = new sns.Topic(this, 'ScrapingAlertsTopic', {
       topicName: 'SeattleFamilyActivities-Alerts',
@@ -258,6 +412,22 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
       );
     }
 
+    // The executor that finishes a fan-out run publishes the activity feed and notifies admins
+    const publishedDataBucket = s3.Bucket.fromBucketName(this, 'PublishedDataBucket',
+      process.env.PUBLISH_BUCKET || 'seattle-family-activities-mvp-data-usw2');
+    publishedDataBucket.grantPut(scrapeExecutorFunction);
+    alertTopic.grantPublish(scrapeExecutorFunction);
+    scrapeExecutorFunction.addEnvironment('PUBLISH_BUCKET', publishedDataBucket.bucketName);
+    scrapeExecutorFunction.addEnvironment('ALERT_TOPIC_ARN', alertTopic.topicArn);
+
+    // Sources whose selectors drift are re-analyzed when a source analyzer function is provided
+    if (process.env.SOURCE_ANALYZER_FUNCTION_NAME) {
+      const sourceAnalyzerFunction = lambda.Function.fromFunctionName(this, 'SourceAnalyzerFunction',
+        process.env.SOURCE_ANALYZER_FUNCTION_NAME);
+      sourceAnalyzerFunction.grantInvoke(scrapeExecutorFunction);
+      scrapeExecutorFunction.addEnvironment('SOURCE_ANALYZER_FUNCTION_NAME', sourceAnalyzerFunction.functionName);
+    }
+
     // Create a separate IAM role for Admin API Lambda  
     const adminApiRole = new iam.Role(this, 'AdminApiLambdaRole', {
       assumedBy: new iam.ServicePrincipal('lambda.amazonaws.com'),
@@ -300,7 +470,10 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
                 'lambda:InvokeFunction'
               ],
               resources: [
-                scrapingOrchestratorFunction.functionArn
+                scrapingOrchestratorFunction.functionArn,
+                // The admin API finishes crawl submissions that outlast API Gateway by invoking itself;
+                // built from the name, as the function's ARN depends on this role
+                `arn:aws:lambda:${this.region}:${this.account}:function:seattle-family-activities-admin-api`
               ]
             })
           ]
@@ -321,18 +494,398 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
         SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
         ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
         SOURCE_ANALYZER_FUNCTION_NAME: scrapingOrchestratorFunction.functionName,
+        ORCHESTRATOR_FUNCTION_NAME: scrapingOrchestratorFunction.functionName,
         FIRECRAWL_API_KEY: process.env.FIRECRAWL_API_KEY || '',
+        SCRAPE_TASK_QUEUE_URL: scrapeTaskQueue.queueUrl,
+        SCRAPE_TASK_HIGH_PRIORITY_QUEUE_URL: scrapeTaskHighPriorityQueue.queueUrl,
+        MONITORED_FUNCTION_NAMES: [scrapingOrchestratorFunction.functionName, scrapeExecutorFunction.functionName].join(','),
+        CLAIM_EMAIL_FROM: process.env.CLAIM_EMAIL_FROM || '',
+        REVIEW_LATENCY_SLO_HOURS: '48',
+        MANUAL_TRIGGER_HOURLY_LIMIT: '30',
+        DYNAMODB_METRICS_NAMESPACE: 'SeattleFamilyActivities/DynamoDB',
+        GEOCODING_PROVIDER: process.env.GEOCODING_PROVIDER || '',
+        GEOCODING_PLACE_INDEX: placeIndex.indexName,
+        GEOCODING_API_KEY: process.env.GEOCODING_API_KEY || '',
       }
     });
 
+    // Queue depth per priority is reported on the analytics endpoints
+    scrapeTaskQueue.grant(adminApiFunction, 'sqs:GetQueueAttributes');
+    scrapeTaskHighPriorityQueue.grant(adminApiFunction, 'sqs:GetQueueAttributes');
+
+    // Lambda error rates on the system load endpoint are read from CloudWatch, which has no resource-level permissions
+    adminApiFunction.addToRolePolicy(new iam.PolicyStatement({
+      effect: iam.Effect.ALLOW,
+      actions: ['cloudwatch:GetMetricData'],
+      resources: ['*'],
+    }));
+
+    // Venue claim verification codes and provider tokens are emailed through SES
+    adminApiFunction.addToRolePolicy(new iam.PolicyStatement({
+      effect: iam.Effect.ALLOW,
+      actions: ['ses:SendEmail'],
+      resources: ['*'],
+    }));
+
+    // Published locations without coordinates are geocoded with the place index
+    adminApiFunction.addToRolePolicy(new iam.PolicyStatement({
+      effect: iam.Effect.ALLOW,
+      actions: ['geo:SearchPlaceIndexForText'],
+      resources: [placeIndex.attrIndexArn],
+    }));
+
+    // WebSocket function for streaming crawl/debug job progress to the admin UI
+    const progressSocketFunction = new GoFunction(this, 'ProgressSocketFunction', {
+      entry: '../backend/cmd/progress_socket',
+      functionName: 'seattle-family-activities-progress-socket',
+      timeout: Duration.seconds(30),
+      memorySize: 256,
+      environment: {
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        SOURCE_MANAGEMENT_TABLE: sourceManagementTable.tableName,
+      },
+      description: 'Tracks admin UI WebSocket subscriptions for job progress updates'
+    });
+    scrapingOperationsTable.grantReadWriteData(progressSocketFunction);
+    // Connections are authenticated with admin API keys
+    sourceManagementTable.grantReadData(progressSocketFunction);
+
+    const progressSocketIntegration = new WebSocketLambdaIntegration('ProgressSocketIntegration', progressSocketFunction);
+    const progressSocketApi = new apigatewayv2.WebSocketApi(this, 'ProgressSocketApi', {
+      apiName: 'SeattleFamilyActivities-ProgressSocket',
+      description: 'Streams crawl and debug extraction progress to the admin UI',
+      connectRouteOptions: { integration: progressSocketIntegration },
+      disconnectRouteOptions: { integration: progressSocketIntegration },
+      defaultRouteOptions: { integration: progressSocketIntegration },
+    });
+    const progressSocketStage = new apigatewayv2.WebSocketStage(this, 'ProgressSocketStage', {
+      webSocketApi: progressSocketApi,
+      stageName: 'prod',
+      autoDeploy: true,
+    });
+
+    // Both functions push updates through the connection management API
+    progressSocketApi.grantManageConnections(adminApiFunction);
+    progressSocketApi.grantManageConnections(progressSocketFunction);
+    adminApiFunction.addEnvironment('PROGRESS_WEBSOCKET_ENDPOINT', progressSocketStage.callbackUrl);
+    progressSocketFunction.addEnvironment('PROGRESS_WEBSOCKET_ENDPOINT', progressSocketStage.callbackUrl);
+
+    // Generated source analysis reports, shared with founders through presigned links, and weekly source report cards
+    const analysisReportsBucket = new s3.Bucket(this, 'AnalysisReportsBucket', {
+      blockPublicAccess: s3.BlockPublicAccess.BLOCK_ALL,
+      encryption: s3.BucketEncryption.S3_MANAGED,
+      enforceSSL: true,
+      lifecycleRules: [
+        { prefix: 'analysis-reports/', expiration: Duration.days(30) },
+        { prefix: 'report-cards/', expiration: Duration.days(365) }, // a year of weekly grades per source
+      ],
+      removalPolicy: RemovalPolicy.DESTROY, // For MVP - allows easy cleanup
+      autoDeleteObjects: true,
+    });
+    analysisReportsBucket.grantReadWrite(adminApiFunction);
+    adminApiFunction.addEnvironment('ANALYSIS_REPORTS_BUCKET', analysisReportsBucket.bucketName);
+
+    // Daily link health check for registration and detail URLs of approved events
+    const linkCheckerFunction = new GoFunction(this, 'LinkCheckerFunction', {
+      entry: '../backend/cmd/link_checker',
+      functionName: 'seattle-family-activities-link-checker',
+      timeout: Duration.minutes(15),
+      memorySize: 256,
+      environment: {
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        LINK_CHECK_HOST_INTERVAL_MS: '2000',
+        LINK_CHECK_MAX_URLS: '300',
+      },
+      description: 'Checks event registration and detail URLs and flags broken links for admin review'
+    });
+    scrapingOperationsTable.grantReadWriteData(linkCheckerFunction);
+    adminEventsTable.grantReadWriteData(linkCheckerFunction);
+
+    new events.Rule(this, 'LinkCheckerSchedule', {
+      schedule: events.Schedule.cron({ minute: '0', hour: '10' }), // 3am Pacific
+      targets: [new targets.LambdaFunction(linkCheckerFunction)],
+      description: 'Runs the link health checker daily'
+    });
+
+    // Weekly data quality report card per active source, stored with the analysis reports
+    const reportCardsFunction = new GoFunction(this, 'ReportCardsFunction', {
+      entry: '../backend/cmd/report_cards',
+      functionName: 'seattle-family-activities-report-cards',
+      timeout: Duration.minutes(5),
+      memorySize: 512,
+      environment: {
+        SOURCE_MANAGEMENT_TABLE: sourceManagementTable.tableName,
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        ANALYSIS_REPORTS_BUCKET: analysisReportsBucket.bucketName,
+        ALERT_TOPIC_ARN: alertTopic.topicArn,
+      },
+      description: 'Grades each active source on run success, conversion issues and admin rejections for the past week'
+    });
+    sourceManagementTable.grantReadData(reportCardsFunction);
+    scrapingOperationsTable.grantReadData(reportCardsFunction);
+    adminEventsTable.grantReadData(reportCardsFunction);
+    analysisReportsBucket.grantPut(reportCardsFunction, 'report-cards/*');
+    alertTopic.grantPublish(reportCardsFunction);
+
+    new events.Rule(this, 'ReportCardsSchedule', {
+      schedule: events.Schedule.cron({ minute: '0', hour: '15', weekDay: 'MON' }), // 8am Pacific on Mondays
+      targets: [new targets.LambdaFunction(reportCardsFunction)],
+      description: 'Generates the weekly source report cards and emails the digest'
+    });
+
+    // Watchdog for scraping tasks stuck in progress after an executor crash
+    const taskWatchdogFunction = new GoFunction(this, 'TaskWatchdogFunction', {
+      entry: '../backend/cmd/task_watchdog',
+      functionName: 'seattle-family-activities-task-watchdog',
+      timeout: Duration.minutes(2),
+      memorySize: 256,
+      environment: {
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        ORCHESTRATOR_FUNCTION_NAME: scrapingOrchestratorFunction.functionName,
+        ALERT_TOPIC_ARN: alertTopic.topicArn,
+        STALE_TASK_TIMEOUT_MINUTES: '45', // longer than the task queue visibility timeout
+      },
+      description: 'Fails and requeues scraping tasks whose executor heartbeats stopped'
+    });
+    scrapingOperationsTable.grantReadWriteData(taskWatchdogFunction);
+    scrapingOrchestratorFunction.grantInvoke(taskWatchdogFunction);
+    alertTopic.grantPublish(taskWatchdogFunction);
+
+    new events.Rule(this, 'TaskWatchdogSchedule', {
+      schedule: events.Schedule.rate(Duration.minutes(10)),
+      targets: [new targets.LambdaFunction(taskWatchdogFunction)],
+      description: 'Checks for stale scraping task heartbeats every 10 minutes'
+    });
+
+    // Scheduler that starts scraping tasks when their run time comes and schedules recurring runs
+    const taskSchedulerFunction = new GoFunction(this, 'TaskSchedulerFunction', {
+      entry: '../backend/cmd/task_scheduler',
+      functionName: 'seattle-family-activities-task-scheduler',
+      timeout: Duration.minutes(2),
+      memorySize: 256,
+      environment: {
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        SOURCE_MANAGEMENT_TABLE: sourceManagementTable.tableName,
+        ORCHESTRATOR_FUNCTION_NAME: scrapingOrchestratorFunction.functionName,
+      },
+      description: 'Queues due scraping tasks and schedules the next run of recurring ones'
+    });
+    scrapingOperationsTable.grantReadWriteData(taskSchedulerFunction);
+    sourceManagementTable.grantReadData(taskSchedulerFunction);
+    scrapingOrchestratorFunction.grantInvoke(taskSchedulerFunction);
+
+    new events.Rule(this, 'TaskSchedulerSchedule', {
+      schedule: events.Schedule.rate(Duration.minutes(5)),
+      targets: [new targets.LambdaFunction(taskSchedulerFunction)],
+      description: 'Starts due scraping tasks every 5 minutes'
+    });
+
+    // Review latency SLO check on the events waiting in the admin review queue
+    const reviewSloFunction = new GoFunction(this, 'ReviewSloFunction', {
+      entry: '../backend/cmd/review_slo',
+      functionName: 'seattle-family-activities-review-slo',
+      timeout: Duration.minutes(2),
+      memorySize: 256,
+      environment: {
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable.tableName,
+        ALERT_TOPIC_ARN: alertTopic.topicArn,
+        REVIEW_LATENCY_SLO_HOURS: '48',
+      },
+      description: 'Alerts when the p90 time pending events wait for review exceeds the SLO'
+    });
+    adminEventsTable.grantReadData(reviewSloFunction);
+    scrapingOperationsTable.grantWriteData(reviewSloFunction); // breaches are kept in the admin inbox
+    alertTopic.grantPublish(reviewSloFunction);
+
+    new events.Rule(this, 'ReviewSloSchedule', {
+      schedule: events.Schedule.cron({ minute: '0', hour: '16,22', weekDay: 'MON-FRI' }), // 9am and 3pm Pacific on weekdays
+      targets: [new targets.LambdaFunction(reviewSloFunction)],
+      description: 'Checks the review queue latency against the SLO twice each weekday'
+    });
+
+    // Nightly open data dump of the full catalog, under the public open-data/ prefix of the published bucket
+    const openDataExporterFunction = new GoFunction(this, 'OpenDataExporterFunction', {
+      entry: '../backend/cmd/open_data_exporter',
+      functionName: 'seattle-family-activities-open-data-exporter',
+      timeout: Duration.minutes(5),
+      memorySize: 512,
+      environment: {
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        PUBLISH_BUCKET: publishedDataBucket.bucketName,
+        OPEN_DATA_LICENSE: process.env.OPEN_DATA_LICENSE || '',
+        OPEN_DATA_LICENSE_URL: process.env.OPEN_DATA_LICENSE_URL || '',
+        OPEN_DATA_ATTRIBUTION: process.env.OPEN_DATA_ATTRIBUTION || '',
+      },
+      description: 'Publishes the approved activity catalog as JSON and CSV with license metadata'
+    });
+    adminEventsTable.grantReadData(openDataExporterFunction);
+    publishedDataBucket.grantPut(openDataExporterFunction, 'open-data/*');
+
+    new events.Rule(this, 'OpenDataExporterSchedule', {
+      schedule: events.Schedule.cron({ minute: '0', hour: '11' }), // 4am Pacific, after the link check
+      targets: [new targets.LambdaFunction(openDataExporterFunction)],
+      description: 'Publishes the open data catalog dump nightly'
+    });
+
+    // Nightly immutable snapshots of the approved catalog, served by the public API with ?snapshot=
+    const catalogSnapshotsBucket = new s3.Bucket(this, 'CatalogSnapshotsBucket', {
+      blockPublicAccess: s3.BlockPublicAccess.BLOCK_ALL,
+      encryption: s3.BucketEncryption.S3_MANAGED,
+      enforceSSL: true,
+      versioned: true, // an overwritten snapshot can still be recovered
+      removalPolicy: RemovalPolicy.RETAIN, // snapshots are rollback points, so they outlive the stack
+    });
+    catalogSnapshotsBucket.grantRead(adminApiFunction, 'catalog-snapshots/*');
+    adminApiFunction.addEnvironment('CATALOG_SNAPSHOTS_BUCKET', catalogSnapshotsBucket.bucketName);
+
+    const catalogSnapshotFunction = new GoFunction(this, 'CatalogSnapshotFunction', {
+      entry: '../backend/cmd/catalog_snapshot',
+      functionName: 'seattle-family-activities-catalog-snapshot',
+      timeout: Duration.minutes(5),
+      memorySize: 512,
+      environment: {
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        CATALOG_SNAPSHOTS_BUCKET: catalogSnapshotsBucket.bucketName,
+      },
+      description: 'Freezes the approved catalog into a dated, immutable snapshot'
+    });
+    adminEventsTable.grantReadData(catalogSnapshotFunction);
+    catalogSnapshotsBucket.grantReadWrite(catalogSnapshotFunction, 'catalog-snapshots/*');
+
+    new events.Rule(this, 'CatalogSnapshotSchedule', {
+      schedule: events.Schedule.cron({ minute: '30', hour: '11' }), // 4:30am Pacific, after the open data export
+      targets: [new targets.LambdaFunction(catalogSnapshotFunction)],
+      description: 'Takes the nightly catalog snapshot'
+    });
+
+    // Catalog change log for partners syncing incrementally, materialized from admin event writes
+    const catalogChangesFunction = new GoFunction(this, 'CatalogChangesFunction', {
+      entry: '../backend/cmd/catalog_changes',
+      functionName: 'seattle-family-activities-catalog-changes',
+      timeout: Duration.minutes(1),
+      memorySize: 256,
+      environment: {
+        FAMILY_ACTIVITIES_TABLE: familyActivitiesTable.tableName,
+        // The RSS and Atom feeds of newly approved activities are refreshed under feeds/ of the published bucket
+        ADMIN_EVENTS_TABLE: adminEventsTable.tableName,
+        PUBLISH_BUCKET: publishedDataBucket.bucketName,
+        PUBLIC_SITE_URL: process.env.PUBLIC_SITE_URL || '',
+      },
+      description: 'Records created, updated and expired catalog activities from the admin events stream'
+    });
+    familyActivitiesTable.grantReadWriteData(catalogChangesFunction);
+    adminEventsTable.grantReadData(catalogChangesFunction);
+    publishedDataBucket.grantPut(catalogChangesFunction, 'feeds/*');
+    catalogChangesFunction.addEventSource(new lambdaEventSources.DynamoEventSource(adminEventsTable, {
+      startingPosition: lambda.StartingPosition.TRIM_HORIZON,
+      batchSize: 100,
+      retryAttempts: 10,
+      reportBatchItemFailures: true, // failed records and those after them are retried in order
+    }));
+
+    // Publishes and withdraws approved activities as their visibility windows open and close, and
+    // republishes the S3 activity feed when any did
+    adminApiFunction.addEnvironment('PUBLISH_BUCKET', publishedDataBucket.bucketName);
+    publishedDataBucket.grantPut(adminApiFunction, 'activities/*');
+
+    new events.Rule(this, 'EventVisibilitySchedule', {
+      schedule: events.Schedule.rate(Duration.minutes(15)),
+      targets: [new targets.LambdaFunction(adminApiFunction, {
+        event: events.RuleTargetInput.fromObject({ refresh_visibility: true }),
+      })],
+      description: 'Refreshes approved activity visibility windows every 15 minutes'
+    });
+
+    // CloudFront invalidations when the public API or the published exports change. The distribution
+    // in front of them is managed outside this stack; without one nothing is invalidated.
+    const cdnDistributionId = process.env.CDN_DISTRIBUTION_ID || '';
+    if (cdnDistributionId) {
+      const invalidationPolicy = new iam.PolicyStatement({
+        actions: ['cloudfront:CreateInvalidation'],
+        resources: [`arn:aws:cloudfront::${this.account}:distribution/${cdnDistributionId}`],
+      });
+      for (const fn of [catalogChangesFunction, scrapeExecutorFunction, openDataExporterFunction, adminApiFunction]) {
+        fn.addToRolePolicy(invalidationPolicy);
+        fn.addEnvironment('CDN_DISTRIBUTION_ID', cdnDistributionId);
+      }
+    }
+
+    // Hide broken registration links from the public events API when enabled
+    adminApiFunction.addEnvironment('STRIP_DEAD_REGISTRATION_LINKS', process.env.STRIP_DEAD_REGISTRATION_LINKS || 'false');
+
+    // The public site the RSS and Atom feeds link to
+    adminApiFunction.addEnvironment('PUBLIC_SITE_URL', process.env.PUBLIC_SITE_URL || '');
+
+    // Activating an auto-approved source and bulk deleting activities need a second admin when enabled
+    adminApiFunction.addEnvironment('REQUIRE_TWO_PERSON_APPROVAL', process.env.REQUIRE_TWO_PERSON_APPROVAL || 'false');
+
+    // Emoji in converted titles are stripped unless set to 'keep'
+    adminApiFunction.addEnvironment('TITLE_EMOJI_POLICY', process.env.TITLE_EMOJI_POLICY || 'strip');
+
+    // Signs preview tokens for unpublished events; previews are disabled without it
+    adminApiFunction.addEnvironment('PREVIEW_TOKEN_SECRET', process.env.PREVIEW_TOKEN_SECRET || '');
+
+    // Browser origins allowed to call the APIs, comma-separated; any origin is allowed when unset.
+    // API Gateway answers preflight requests from the same list.
+    const corsAllowedOrigins = (process.env.CORS_ALLOWED_ORIGINS || '').split(',')
+      .map(origin => origin.trim().replace(/\/$/, ''))
+      .filter(origin => origin !== '');
+    adminApiFunction.addEnvironment('CORS_ALLOWED_ORIGINS', corsAllowedOrigins.join(','));
+    progressSocketFunction.addEnvironment('CORS_ALLOWED_ORIGINS', corsAllowedOrigins.join(','));
+
+    // Sandbox copies of the tables, used by admin API keys created with sandbox set so new admins can
+    // practice on seeded demo data. The copies share the production keys and indexes, without the
+    // stream or backups; the admin API only uses tables whose names end with -sandbox. Analyses are
+    // written with transactions, which in production only the analyzer does.
+    const sandboxTables: { [envName: string]: dynamodb.Table } = {
+      SANDBOX_FAMILY_ACTIVITIES_TABLE: familyActivitiesTable,
+      SANDBOX_SOURCE_MANAGEMENT_TABLE: sourceManagementTable,
+      SANDBOX_SCRAPING_OPERATIONS_TABLE: scrapingOperationsTable,
+      SANDBOX_ADMIN_EVENTS_TABLE: adminEventsTable,
+    };
+    for (const [envName, table] of Object.entries(sandboxTables)) {
+      const production = table.node.defaultChild as dynamodb.CfnTable;
+      const sandboxTable = new dynamodb.CfnTable(this, `${table.node.id}Sandbox`, {
+        tableName: `${production.tableName}-sandbox`,
+        keySchema: production.keySchema,
+        attributeDefinitions: production.attributeDefinitions,
+        globalSecondaryIndexes: production.globalSecondaryIndexes,
+        billingMode: production.billingMode,
+        timeToLiveSpecification: production.timeToLiveSpecification,
+        sseSpecification: production.sseSpecification,
+      });
+      sandboxTable.applyRemovalPolicy(RemovalPolicy.DESTROY);
+      adminApiFunction.addToRolePolicy(new iam.PolicyStatement({
+        effect: iam.Effect.ALLOW,
+        actions: [
+          'dynamodb:PutItem',
+          'dynamodb:GetItem',
+          'dynamodb:UpdateItem',
+          'dynamodb:DeleteItem',
+          'dynamodb:Query',
+          'dynamodb:Scan',
+          'dynamodb:BatchGetItem',
+          'dynamodb:BatchWriteItem',
+          'dynamodb:TransactWriteItems',
+          'dynamodb:ConditionCheckItem',
+        ],
+        resources: [sandboxTable.attrArn, `${sandboxTable.attrArn}/index/*`],
+      }));
+      adminApiFunction.addEnvironment(envName, sandboxTable.ref);
+    }
+
     // API Gateway for Admin UI
     const adminApi = new apigateway.RestApi(this, 'AdminApi', {
       restApiName: 'SeattleFamilyActivities-AdminAPI',
       description: 'Admin API for Seattle Family Activities source management',
       defaultCorsPreflightOptions: {
-        allowOrigins: ['*'],
+        allowOrigins: corsAllowedOrigins.length > 0 ? corsAllowedOrigins : ['*'],
         allowMethods: ['GET', 'POST', 'PUT', 'DELETE', 'OPTIONS'],
         allowHeaders: ['Content-Type', 'X-Amz-Date', 'Authorization', 'X-Api-Key', 'X-Amz-Security-Token', 'Cache-Control', 'Accept'],
+        maxAge: Duration.minutes(10),
       },
       deployOptions: {
         stageName: 'prod'
@@ -353,6 +906,41 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
     const eventsResource = apiResource.addResource('events');
     const approvedEventsResource = eventsResource.addResource('approved');
     approvedEventsResource.addMethod('GET', adminApiIntegration); // GET /api/events/approved - for main frontend
+    const approvedEventsFeedResource = eventsResource.addResource('approved.ics');
+    approvedEventsFeedResource.addMethod('GET', adminApiIntegration); // GET /api/events/approved.ics - iCalendar feed for subscriptions
+    const approvedEventsRssResource = eventsResource.addResource('approved.rss');
+    approvedEventsRssResource.addMethod('GET', adminApiIntegration); // GET /api/events/approved.rss - latest approvals as RSS
+    const approvedEventsAtomResource = eventsResource.addResource('approved.atom');
+    approvedEventsAtomResource.addMethod('GET', adminApiIntegration); // GET /api/events/approved.atom - latest approvals as Atom
+    const calendarEventsResource = eventsResource.addResource('calendar');
+    calendarEventsResource.addMethod('GET', adminApiIntegration); // GET /api/events/calendar?month=YYYY-MM - for main frontend
+
+    // Catalog change feed - public, for partners syncing incrementally
+    const changesResource = apiResource.addResource('changes');
+    changesResource.addMethod('GET', adminApiIntegration); // GET /api/changes?since=&limit=
+
+    // Planner API - public, for the main frontend's weekend planner
+    const plansResource = apiResource.addResource('plans');
+    const weekendPlanResource = plansResource.addResource('weekend');
+    weekendPlanResource.addMethod('GET', adminApiIntegration); // GET /api/plans/weekend?ages=&region=&budget=
+
+    // Search API - public, for the main frontend search box
+    const searchResource = apiResource.addResource('search');
+    const suggestResource = searchResource.addResource('suggest');
+    suggestResource.addMethod('GET', adminApiIntegration); // GET /api/search/suggest?q=
+
+    // Venue API - public, for venue profile pages
+    const venuesResource = apiResource.addResource('venues');
+    const venueResource = venuesResource.addResource('{id}');
+    const venueEventsResource = venueResource.addResource('events');
+    venueEventsResource.addMethod('GET', adminApiIntegration); // GET /api/venues/{id}/events?from=&to=
+
+    // Activity API - public, resolves activity IDs retired by the activity ID backfill
+    const activitiesResource = apiResource.addResource('activities');
+    const activityResource = activitiesResource.addResource('{id}');
+    activityResource.addMethod('GET', adminApiIntegration); // GET /api/activities/{id}
+    const activitiesBulkDeleteResource = activitiesResource.addResource('bulk-delete');
+    activitiesBulkDeleteResource.addMethod('POST', adminApiIntegration); // POST /api/activities/bulk-delete (admin role)
     
     // Sources routes
     sourcesResource.addMethod('POST', adminApiIntegration); // POST /api/sources (with {action: 'submit'} in body)
@@ -367,14 +955,35 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
     
     sourceResource.addMethod('DELETE', adminApiIntegration); // DELETE /api/sources/{id}
     analysisResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/analysis
+    const analysisReportResource = analysisResource.addResource('report');
+    analysisReportResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/analysis/report
+    const analysisCompareResource = analysisResource.addResource('compare');
+    analysisCompareResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/analysis/compare
+    const costForecastResource = sourceResource.addResource('cost-forecast');
+    costForecastResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/cost-forecast
+
+    const reportCardResource = sourceResource.addResource('report-card');
+    reportCardResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/report-card
+
+    // Onboarding checklist driving the guided source setup
+    const onboardingResource = sourceResource.addResource('onboarding');
+    onboardingResource.addMethod('GET', adminApiIntegration); // GET /api/sources/{id}/onboarding
+    const onboardingStepResource = onboardingResource.addResource('{step}');
+    onboardingStepResource.addMethod('PUT', adminApiIntegration); // PUT /api/sources/{id}/onboarding/{step}
     activateResource.addMethod('PUT', adminApiIntegration); // PUT /api/sources/{id}/activate
     rejectResource.addMethod('PUT', adminApiIntegration);   // PUT /api/sources/{id}/reject
+    const pauseResource = sourceResource.addResource('pause');
+    pauseResource.addMethod('PUT', adminApiIntegration); // PUT /api/sources/{id}/pause
+    const resumeResource = sourceResource.addResource('resume');
+    resumeResource.addMethod('PUT', adminApiIntegration); // PUT /api/sources/{id}/resume
     detailsResource.addMethod('GET', adminApiIntegration);  // GET /api/sources/{id}/details
     triggerResource.addMethod('POST', adminApiIntegration); // POST /api/sources/{id}/trigger
     
     // Analytics route
     const analyticsResource = apiResource.addResource('analytics');
     analyticsResource.addMethod('GET', adminApiIntegration); // GET /api/analytics
+    const systemAnalyticsResource = analyticsResource.addResource('system');
+    systemAnalyticsResource.addMethod('GET', adminApiIntegration); // GET /api/analytics/system
 
     // Submit route for backwards compatibility
     const submitResource = sourcesResource.addResource('submit');
@@ -390,28 +999,207 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
     const reExtractResource = sourceResource.addResource('re-extract');
     reExtractResource.addMethod('POST', adminApiIntegration); // POST /api/sources/{id}/re-extract
 
+    const previewDiffResource = sourceResource.addResource('preview-diff');
+    previewDiffResource.addMethod('POST', adminApiIntegration); // POST /api/sources/{id}/preview-diff
+
     // Admin Crawling Routes
     const crawlResource = apiResource.addResource('crawl');
     const crawlSubmitResource = crawlResource.addResource('submit');
     crawlSubmitResource.addMethod('POST', adminApiIntegration); // POST /api/crawl/submit
 
+    // Crawl submissions, reviewed as a batch
+    const submissionsResource = apiResource.addResource('submissions');
+    const submissionResource = submissionsResource.addResource('{id}');
+    submissionResource.addMethod('GET', adminApiIntegration); // GET /api/submissions/{id}
+    const approveSubmissionResource = submissionResource.addResource('approve');
+    approveSubmissionResource.addMethod('PUT', adminApiIntegration); // PUT /api/submissions/{id}/approve
+    const rejectSubmissionResource = submissionResource.addResource('reject');
+    rejectSubmissionResource.addMethod('PUT', adminApiIntegration); // PUT /api/submissions/{id}/reject
+
     const eventsPendingResource = eventsResource.addResource('pending');
     eventsPendingResource.addMethod('GET', adminApiIntegration); // GET /api/events/pending
 
+    // Approve-and-advance triage, one pending event at a time
+    const nextEventResource = eventsResource.addResource('next');
+    nextEventResource.addMethod('GET', adminApiIntegration); // GET /api/events/next
+
     const eventResource = eventsResource.addResource('{id}');
     eventResource.addMethod('GET', adminApiIntegration); // GET /api/events/{id}
 
     const approveResource = eventResource.addResource('approve');
     const rejectEventResource = eventResource.addResource('reject');
     const editResource = eventResource.addResource('edit');
+    const diagnosticsResource = eventResource.addResource('diagnostics');
+    const visibilityResource = eventResource.addResource('visibility');
 
     approveResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/approve
     rejectEventResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/reject
     editResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/edit
+    diagnosticsResource.addMethod('GET', adminApiIntegration); // GET /api/events/{id}/diagnostics
+    visibilityResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/visibility
+
+    const publicPreviewResource = eventResource.addResource('public-preview');
+    publicPreviewResource.addMethod('GET', adminApiIntegration); // GET /api/events/{id}/public-preview
+
+    // Bulk edit of pending events extracted from the same page
+    const bulkEditResource = eventsResource.addResource('bulk-edit');
+    bulkEditResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/bulk-edit
+
+    // Splitting events that hold every event extracted from a page
+    const splitResource = eventResource.addResource('split');
+    splitResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/split
+
+    // Provider change review
+    const eventChangesResource = eventsResource.addResource('changes');
+    eventChangesResource.addMethod('GET', adminApiIntegration); // GET /api/events/changes
+    const approveChangeResource = eventResource.addResource('approve-change');
+    approveChangeResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/approve-change
+    const rejectChangeResource = eventResource.addResource('reject-change');
+    rejectChangeResource.addMethod('PUT', adminApiIntegration); // PUT /api/events/{id}/reject-change
+
+    // Provider accounts, managed by admins
+    const providersResource = apiResource.addResource('providers');
+    providersResource.addMethod('GET', adminApiIntegration); // GET /api/providers
+    providersResource.addMethod('POST', adminApiIntegration); // POST /api/providers
+    const providerAccountResource = providersResource.addResource('{id}');
+    const revokeProviderResource = providerAccountResource.addResource('revoke');
+    revokeProviderResource.addMethod('PUT', adminApiIntegration); // PUT /api/providers/{id}/revoke
+
+    // Provider self-service API, authenticated with a provider token
+    const providerResource = apiResource.addResource('provider');
+    const providerEventsResource = providerResource.addResource('events');
+    providerEventsResource.addMethod('GET', adminApiIntegration); // GET /api/provider/events
+    providerEventsResource.addMethod('POST', adminApiIntegration); // POST /api/provider/events
+    const providerEventResource = providerEventsResource.addResource('{id}');
+    providerEventResource.addMethod('PUT', adminApiIntegration); // PUT /api/provider/events/{id}
+    providerEventResource.addMethod('DELETE', adminApiIntegration); // DELETE /api/provider/events/{id}
+
+    // Venue claims: claimants request and verify publicly, admins decide
+    const venueClaimsResource = venueResource.addResource('claims');
+    venueClaimsResource.addMethod('POST', adminApiIntegration); // POST /api/venues/{id}/claims
+    const claimsResource = apiResource.addResource('venue-claims');
+    claimsResource.addMethod('GET', adminApiIntegration); // GET /api/venue-claims?status=&venue_id=
+    const claimResource = claimsResource.addResource('{id}');
+    claimResource.addMethod('GET', adminApiIntegration); // GET /api/venue-claims/{id}
+    const verifyClaimResource = claimResource.addResource('verify');
+    verifyClaimResource.addMethod('POST', adminApiIntegration); // POST /api/venue-claims/{id}/verify
+    const approveClaimResource = claimResource.addResource('approve');
+    approveClaimResource.addMethod('PUT', adminApiIntegration); // PUT /api/venue-claims/{id}/approve
+    const rejectClaimResource = claimResource.addResource('reject');
+    rejectClaimResource.addMethod('PUT', adminApiIntegration); // PUT /api/venue-claims/{id}/reject
 
     const schemasResource = apiResource.addResource('schemas');
     schemasResource.addMethod('GET', adminApiIntegration); // GET /api/schemas
 
+    // Validation rule sets per schema type
+    const validationRulesResource = apiResource.addResource('validation-rules');
+    const schemaRulesResource = validationRulesResource.addResource('{schema_type}');
+    schemaRulesResource.addMethod('GET', adminApiIntegration); // GET /api/validation-rules/{schema_type}
+    schemaRulesResource.addMethod('PUT', adminApiIntegration); // PUT /api/validation-rules/{schema_type}
+
+    // Domain allow/deny lists
+    const domainPolicyResource = apiResource.addResource('domain-policy');
+    domainPolicyResource.addMethod('GET', adminApiIntegration); // GET /api/domain-policy
+    domainPolicyResource.addMethod('PUT', adminApiIntegration); // PUT /api/domain-policy
+
+    // Shared settings
+    const settingsResource = apiResource.addResource('settings');
+    const concurrencySettingsResource = settingsResource.addResource('concurrency');
+    concurrencySettingsResource.addMethod('GET', adminApiIntegration); // GET /api/settings/concurrency
+    concurrencySettingsResource.addMethod('PUT', adminApiIntegration); // PUT /api/settings/concurrency
+    const featureFlagsResource = settingsResource.addResource('feature-flags');
+    featureFlagsResource.addMethod('GET', adminApiIntegration); // GET /api/settings/feature-flags
+    featureFlagsResource.addMethod('PUT', adminApiIntegration); // PUT /api/settings/feature-flags
+
+    // Statuses and allowed transitions of sources, scraping tasks and admin events, for the admin UI
+    const metaResource = apiResource.addResource('meta');
+    const pipelineMetaResource = metaResource.addResource('pipeline');
+    pipelineMetaResource.addMethod('GET', adminApiIntegration); // GET /api/meta/pipeline
+    const modelsMetaResource = metaResource.addResource('models');
+    modelsMetaResource.addMethod('GET', adminApiIntegration); // GET /api/meta/models
+
+    // Activity tags: listed and browsed publicly, managed by editors
+    const tagsResource = apiResource.addResource('tags');
+    tagsResource.addMethod('GET', adminApiIntegration); // GET /api/tags
+    tagsResource.addMethod('POST', adminApiIntegration); // POST /api/tags
+    const tagResource = tagsResource.addResource('{slug}');
+    tagResource.addMethod('GET', adminApiIntegration); // GET /api/tags/{slug}?from=&limit=
+    tagResource.addMethod('PUT', adminApiIntegration); // PUT /api/tags/{slug}
+    tagResource.addMethod('DELETE', adminApiIntegration); // DELETE /api/tags/{slug}
+
+    // Saved review filter presets, per admin
+    const adminResource = apiResource.addResource('admin');
+    const presetsResource = adminResource.addResource('presets');
+    presetsResource.addMethod('GET', adminApiIntegration); // GET /api/admin/presets?admin=
+    presetsResource.addMethod('POST', adminApiIntegration); // POST /api/admin/presets
+    const presetResource = presetsResource.addResource('{id}');
+    presetResource.addMethod('DELETE', adminApiIntegration); // DELETE /api/admin/presets/{id}?admin=
+
+    // Admin venue detail view with schedule conflicts between programs
+    const adminVenuesResource = adminResource.addResource('venues');
+    const adminVenueResource = adminVenuesResource.addResource('{id}');
+    adminVenueResource.addMethod('GET', adminApiIntegration); // GET /api/admin/venues/{id}?from=&to=
+    const adminVenueArrivalResource = adminVenueResource.addResource('arrival');
+    adminVenueArrivalResource.addMethod('PUT', adminApiIntegration); // PUT /api/admin/venues/{id}/arrival
+
+    // Per-admin review throughput, built from the admin audit log
+    const adminMeResource = adminResource.addResource('me');
+    const adminStatsResource = adminMeResource.addResource('stats');
+    adminStatsResource.addMethod('GET', adminApiIntegration); // GET /api/admin/me/stats?admin=&week=
+
+    // Neighborhood polygons activity locations are clustered into
+    const neighborhoodsResource = adminResource.addResource('neighborhoods');
+    neighborhoodsResource.addMethod('GET', adminApiIntegration); // GET /api/admin/neighborhoods
+    neighborhoodsResource.addMethod('PUT', adminApiIntegration); // PUT /api/admin/neighborhoods
+
+    // Two-person approval of high-impact actions
+    const approvalsResource = apiResource.addResource('approvals');
+    approvalsResource.addMethod('GET', adminApiIntegration); // GET /api/approvals?status=
+    const approvalResource = approvalsResource.addResource('{id}');
+    approvalResource.addMethod('GET', adminApiIntegration); // GET /api/approvals/{id}
+    const approvalConfirmResource = approvalResource.addResource('confirm');
+    approvalConfirmResource.addMethod('POST', adminApiIntegration); // POST /api/approvals/{id}/confirm
+
+    // Admin API keys; every route but the public catalog, venue claim steps and provider API needs one
+    const apiKeysResource = adminResource.addResource('api-keys');
+    apiKeysResource.addMethod('GET', adminApiIntegration); // GET /api/admin/api-keys
+    apiKeysResource.addMethod('POST', adminApiIntegration); // POST /api/admin/api-keys
+    const apiKeyResource = apiKeysResource.addResource('{id}');
+    const revokeApiKeyResource = apiKeyResource.addResource('revoke');
+    revokeApiKeyResource.addMethod('PUT', adminApiIntegration); // PUT /api/admin/api-keys/{id}/revoke
+
+    // Sandbox for training new admins; only sandbox API keys may reset it
+    const sandboxResource = apiResource.addResource('sandbox');
+    const sandboxResetResource = sandboxResource.addResource('reset');
+    sandboxResetResource.addMethod('POST', adminApiIntegration); // POST /api/sandbox/reset
+
+    // Signed preview tokens that show unpublished events in GET /api/events/approved?preview_token=
+    const previewTokensResource = adminResource.addResource('preview-tokens');
+    previewTokensResource.addMethod('POST', adminApiIntegration); // POST /api/admin/preview-tokens
+
+    // Link health review queue
+    const linksResource = apiResource.addResource('links');
+    const brokenLinksResource = linksResource.addResource('broken');
+    brokenLinksResource.addMethod('GET', adminApiIntegration); // GET /api/links/broken
+
+    // Fan-out scraping run status
+    const runsResource = apiResource.addResource('runs');
+    const runResource = runsResource.addResource('{id}');
+    runResource.addMethod('GET', adminApiIntegration); // GET /api/runs/{id}
+
+    // Scraping task cancellation
+    const tasksResource = apiResource.addResource('tasks');
+    const taskResource = tasksResource.addResource('{id}');
+    taskResource.addMethod('DELETE', adminApiIntegration); // DELETE /api/tasks/{id}
+    const failedTasksResource = tasksResource.addResource('failed');
+    failedTasksResource.addMethod('GET', adminApiIntegration); // GET /api/tasks/failed
+
+    // Job progress polling (fallback for the progress WebSocket)
+    const jobsResource = apiResource.addResource('jobs');
+    const jobResource = jobsResource.addResource('{id}');
+    const jobProgressResource = jobResource.addResource('progress');
+    jobProgressResource.addMethod('GET', adminApiIntegration); // GET /api/jobs/{id}/progress
+
     // Outputs for reference
     new CfnOutput(this, 'ScrapingOrchestratorFunctionName', {
       value: scrapingOrchestratorFunction.functionName,
@@ -469,6 +1257,12 @@ export class SeattleFamilyActivitiesMVPStack extends Stack {
       exportName: 'SeattleFamilyActivities-AdminApiUrl'
     });
 
+    new CfnOutput(this, 'ProgressSocketUrl', {
+      value: progressSocketStage.url,
+      description: 'WebSocket URL for live crawl/debug job progress',
+      exportName: 'SeattleFamilyActivities-ProgressSocketUrl'
+    });
+
     new CfnOutput(this, 'AdminApiId', {
       value: adminApi.restApiId,
       description: 'Admin API Gateway ID',